@@ -0,0 +1,271 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+type (
+	// Command describes a command of a command line client for the
+	// generation of the shell completion scripts. The root command is the
+	// client itself, its subcommands are the services and their
+	// subcommands the service methods.
+	Command struct {
+		// Name is the name of the command.
+		Name string
+		// Aliases lists the alternative names of the command.
+		Aliases []string
+		// Description is the help text of the command.
+		Description string
+		// Flags lists the flags of the command.
+		Flags []*Flag
+		// Subcommands lists the subcommands.
+		Subcommands []*Command
+		// Columns lists the columns of the table output of the results of
+		// the command, see Output.
+		Columns []*Column
+	}
+
+	// Flag describes a command line flag.
+	Flag struct {
+		// Name is the name of the flag without the leading dash.
+		Name string
+		// Description is the help text of the flag.
+		Description string
+		// Bool is true if the flag does not take a value.
+		Bool bool
+	}
+
+	// boolFlag is implemented by the values of the boolean flags.
+	boolFlag interface {
+		IsBoolFlag() bool
+	}
+)
+
+// Shells lists the shells supported by WriteCompletion.
+var Shells = []string{"bash", "zsh", "fish"}
+
+// FlagsOf returns the description of the flags defined in fs sorted by name.
+func FlagsOf(fs *flag.FlagSet) []*Flag {
+	var flags []*Flag
+	fs.VisitAll(func(f *flag.Flag) {
+		b, ok := f.Value.(boolFlag)
+		flags = append(flags, &Flag{Name: f.Name, Description: f.Usage, Bool: ok && b.IsBoolFlag()})
+	})
+	return flags
+}
+
+// Lookup returns the command at the given path in cmds, the names in the path
+// may be aliases. Lookup returns nil if there is no such command.
+func Lookup(cmds []*Command, path ...string) *Command {
+	var cmd *Command
+	for _, name := range path {
+		cmd = nil
+		for _, c := range cmds {
+			if c.Name == name || contains(c.Aliases, name) {
+				cmd = c
+				break
+			}
+		}
+		if cmd == nil {
+			return nil
+		}
+		cmds = cmd.Subcommands
+	}
+	return cmd
+}
+
+// WriteCompletion writes the completion script of the root command for the
+// given shell, one of Shells. The script completes the services, methods and
+// flags described by root as well as the "completion" command itself. The
+// script is typically loaded in the shell initialization file, for example:
+//
+//    source <(cars-cli completion bash)
+//
+func WriteCompletion(w io.Writer, shell string, root *Command) error {
+	root = withCompletion(root)
+	switch shell {
+	case "bash":
+		return writeBash(w, root)
+	case "zsh":
+		// zsh runs the bash completion functions through bashcompinit.
+		if _, err := fmt.Fprintf(w, "#compdef %s\nautoload -U +X bashcompinit && bashcompinit\n", root.Name); err != nil {
+			return err
+		}
+		return writeBash(w, root)
+	case "fish":
+		return writeFish(w, root)
+	default:
+		return fmt.Errorf("unsupported shell %q, must be one of %s", shell, strings.Join(Shells, ", "))
+	}
+}
+
+// withCompletion returns a copy of root with the "completion" subcommand.
+func withCompletion(root *Command) *Command {
+	c := *root
+	c.Subcommands = append(append([]*Command{}, root.Subcommands...), &Command{
+		Name:        "completion",
+		Description: "Generate the shell completion script",
+	})
+	for _, s := range Shells {
+		last := c.Subcommands[len(c.Subcommands)-1]
+		last.Subcommands = append(last.Subcommands, &Command{Name: s, Description: "Generate the " + s + " completion script"})
+	}
+	return &c
+}
+
+// writeBash writes the bash completion function of root. The function skips
+// the flags and their values to compute the command path made of the
+// service and method names and completes the subcommands and flags of the
+// command at that path.
+func writeBash(w io.Writer, root *Command) error {
+	fn := "_" + identifier(root.Name)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s() {\n", fn)
+	b.WriteString("\tlocal cur=\"${COMP_WORDS[COMP_CWORD]}\" cmd=\"\" skip=0 i w words\n")
+	b.WriteString("\tfor ((i = 1; i < COMP_CWORD; i++)); do\n")
+	b.WriteString("\t\tw=\"${COMP_WORDS[i]}\"\n")
+	b.WriteString("\t\tif [[ $skip == 1 ]]; then\n\t\t\tskip=0\n\t\t\tcontinue\n\t\tfi\n")
+	b.WriteString("\t\tcase \"$w\" in\n")
+	b.WriteString("\t\t-*=*) ;;\n")
+	if bools := boolFlagPatterns(root); bools != "" {
+		fmt.Fprintf(&b, "\t\t%s) ;;\n", bools)
+	}
+	b.WriteString("\t\t-*) skip=1 ;;\n")
+	b.WriteString("\t\t*) cmd=\"$cmd $w\" ;;\n")
+	b.WriteString("\t\tesac\n")
+	b.WriteString("\tdone\n")
+	b.WriteString("\tcase \"$cmd\" in\n")
+	walk(root, nil, func(c *Command, paths []string) {
+		patterns := make([]string, len(paths))
+		for i, p := range paths {
+			patterns[i] = fmt.Sprintf("%q", p)
+		}
+		var words []string
+		for _, s := range c.Subcommands {
+			words = append(words, s.Name)
+		}
+		for _, f := range c.Flags {
+			words = append(words, "-"+f.Name)
+		}
+		if len(words) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\t%s) words=%q ;;\n", strings.Join(patterns, "|"), strings.Join(words, " "))
+	})
+	b.WriteString("\tesac\n")
+	b.WriteString("\tCOMPREPLY=($(compgen -W \"$words\" -- \"$cur\"))\n")
+	b.WriteString("}\n")
+	fmt.Fprintf(&b, "complete -F %s %s\n", fn, root.Name)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeFish writes the fish completion commands of root.
+func writeFish(w io.Writer, root *Command) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "complete -c %s -f\n", root.Name)
+	for _, f := range root.Flags {
+		fmt.Fprintf(&b, "complete -c %s -n '__fish_use_subcommand' -o %s%s -d %s\n", root.Name, f.Name, fishRequiresValue(f), fishQuote(f.Description))
+	}
+	for _, svc := range root.Subcommands {
+		fmt.Fprintf(&b, "complete -c %s -n '__fish_use_subcommand' -a %s -d %s\n", root.Name, svc.Name, fishQuote(svc.Description))
+		names := strings.Join(append([]string{svc.Name}, svc.Aliases...), " ")
+		var subs []string
+		for _, m := range svc.Subcommands {
+			subs = append(subs, append([]string{m.Name}, m.Aliases...)...)
+		}
+		for _, m := range svc.Subcommands {
+			cond := fmt.Sprintf("__fish_seen_subcommand_from %s; and not __fish_seen_subcommand_from %s", names, strings.Join(subs, " "))
+			fmt.Fprintf(&b, "complete -c %s -n '%s' -a %s -d %s\n", root.Name, cond, m.Name, fishQuote(m.Description))
+		}
+		for _, m := range svc.Subcommands {
+			cond := fmt.Sprintf("__fish_seen_subcommand_from %s; and __fish_seen_subcommand_from %s", names, strings.Join(append([]string{m.Name}, m.Aliases...), " "))
+			for _, f := range m.Flags {
+				fmt.Fprintf(&b, "complete -c %s -n '%s' -o %s%s -d %s\n", root.Name, cond, f.Name, fishRequiresValue(f), fishQuote(f.Description))
+			}
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// walk calls fn with each command of the tree rooted at c and the list of
+// command paths that lead to it given the aliases. The paths are made of the
+// names separated by spaces, each name preceded with a space.
+func walk(c *Command, paths []string, fn func(*Command, []string)) {
+	if paths == nil {
+		paths = []string{""}
+	}
+	fn(c, paths)
+	for _, s := range c.Subcommands {
+		var subs []string
+		for _, p := range paths {
+			for _, n := range append([]string{s.Name}, s.Aliases...) {
+				subs = append(subs, p+" "+n)
+			}
+		}
+		walk(s, subs, fn)
+	}
+}
+
+// boolFlagPatterns returns the bash case pattern that matches the boolean
+// flags of the tree rooted at c, the empty string if there are none.
+func boolFlagPatterns(c *Command) string {
+	seen := make(map[string]bool)
+	walk(c, nil, func(c *Command, _ []string) {
+		for _, f := range c.Flags {
+			if f.Bool {
+				seen["-"+f.Name] = true
+				seen["--"+f.Name] = true
+			}
+		}
+	})
+	patterns := make([]string, 0, len(seen))
+	for p := range seen {
+		patterns = append(patterns, p)
+	}
+	sort.Strings(patterns)
+	return strings.Join(patterns, "|")
+}
+
+// fishRequiresValue returns the fish option that indicates that the flag
+// takes a value.
+func fishRequiresValue(f *Flag) string {
+	if f.Bool {
+		return ""
+	}
+	return " -r"
+}
+
+// fishQuote quotes s for fish.
+func fishQuote(s string) string {
+	if i := strings.IndexAny(s, "\n"); i >= 0 {
+		s = s[:i]
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+// contains returns true if vals contains val.
+func contains(vals []string, val string) bool {
+	for _, v := range vals {
+		if v == val {
+			return true
+		}
+	}
+	return false
+}
+
+// identifier returns name with the characters that are neither letters nor
+// digits replaced with underscores.
+func identifier(name string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+}
@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+var testRoot = &Command{
+	Name: "cars-cli",
+	Flags: []*Flag{
+		{Name: "host", Description: "Server host"},
+		{Name: "verbose", Description: "Print request and response details", Bool: true},
+	},
+	Subcommands: []*Command{
+		{
+			Name:        "storage",
+			Aliases:     []string{"st"},
+			Description: "The storage service makes it possible to view, add or remove wine bottles.",
+			Subcommands: []*Command{
+				{
+					Name:        "add",
+					Description: "Add new bottle and return its ID.",
+					Flags:       []*Flag{{Name: "body", Description: "JSON body"}},
+				},
+				{
+					Name:        "list",
+					Aliases:     []string{"ls"},
+					Description: "List all stored bottles",
+					Flags:       []*Flag{{Name: "view", Description: "View"}, {Name: "limit", Description: "Limit"}},
+				},
+			},
+		},
+	},
+}
+
+func TestFlagsOf(t *testing.T) {
+	fs := flag.NewFlagSet("cars-cli", flag.ContinueOnError)
+	fs.String("host", "localhost", "Server host")
+	fs.Bool("v", false, "Verbose")
+	flags := FlagsOf(fs)
+	if len(flags) != 2 {
+		t.Fatalf("got %d flags, expected 2", len(flags))
+	}
+	if f := flags[0]; f.Name != "host" || f.Description != "Server host" || f.Bool {
+		t.Errorf("got flag %+v, expected host string flag", f)
+	}
+	if f := flags[1]; f.Name != "v" || !f.Bool {
+		t.Errorf("got flag %+v, expected v boolean flag", f)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Path     []string
+		Expected string
+	}{
+		{"service", []string{"storage"}, "storage"},
+		{"method", []string{"storage", "add"}, "add"},
+		{"aliases", []string{"st", "ls"}, "list"},
+		{"unknown-service", []string{"sommelier", "pick"}, ""},
+		{"unknown-method", []string{"storage", "remove"}, ""},
+		{"empty", nil, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			cmd := Lookup(testRoot.Subcommands, c.Path...)
+			name := ""
+			if cmd != nil {
+				name = cmd.Name
+			}
+			if name != c.Expected {
+				t.Errorf("got %q, expected %q", name, c.Expected)
+			}
+		})
+	}
+}
+
+func TestWriteCompletion(t *testing.T) {
+	for _, shell := range Shells {
+		t.Run(shell, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteCompletion(&buf, shell, testRoot); err != nil {
+				t.Fatalf("got error %q", err)
+			}
+			if !strings.Contains(buf.String(), "cars-cli") {
+				t.Errorf("got script\n%s\nexpected it to reference the command", buf.String())
+			}
+		})
+	}
+	t.Run("unsupported", func(t *testing.T) {
+		if err := WriteCompletion(&bytes.Buffer{}, "tcsh", testRoot); err == nil {
+			t.Errorf("got no error, expected unsupported shell error")
+		}
+	})
+	if len(testRoot.Subcommands) != 1 {
+		t.Errorf("got %d subcommands, expected the root command to be left unchanged", len(testRoot.Subcommands))
+	}
+}
+
+func TestBashCompletion(t *testing.T) {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash not found")
+	}
+	var buf bytes.Buffer
+	if err := WriteCompletion(&buf, "bash", testRoot); err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		Name     string
+		Line     string
+		Expected string
+	}{
+		{"services", "cars-cli ", "storage completion -host -verbose"},
+		{"prefix", "cars-cli st", "storage"},
+		{"flag-value", "cars-cli -host localhost ", "storage completion -host -verbose"},
+		{"bool-flag", "cars-cli -verbose ", "storage completion -host -verbose"},
+		{"methods", "cars-cli storage ", "add list"},
+		{"service-alias", "cars-cli -host=localhost st ", "add list"},
+		{"method-flags", "cars-cli storage add ", "-body"},
+		{"method-alias", "cars-cli st ls -view tiny ", "-view -limit"},
+		{"completion", "cars-cli completion ", "bash zsh fish"},
+		{"unknown", "cars-cli unknown ", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			words := strings.Fields(c.Line)
+			if strings.HasSuffix(c.Line, " ") {
+				// Complete a new word.
+				words = append(words, `""`)
+			}
+			script := buf.String() + `
+COMP_WORDS=(` + strings.Join(words, " ") + `)
+COMP_CWORD=$((${#COMP_WORDS[@]} - 1))
+_cars_cli
+echo "${COMPREPLY[@]}"
+`
+			out, err := exec.Command(bash, "-c", script).CombinedOutput()
+			if err != nil {
+				t.Fatalf("%s: %s", err, out)
+			}
+			if got := strings.TrimSpace(string(out)); got != c.Expected {
+				t.Errorf("got %q, expected %q", got, c.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+// Package cli implements the runtime support of the generated command line
+// clients: the initialization of the flags from environment variables and
+// configuration files, the generation of shell completion scripts, the
+// rendering of the results as JSON, YAML or tables filtered with JMESPath
+// queries and the execution of the streaming endpoints.
+//
+// The flags that are not given on the command line are read from the
+// environment variable PREFIX_NAME for the global flags and
+// PREFIX_SERVICE_METHOD_NAME for the flags of the service methods, where
+// PREFIX is the upper case name of the API. Flags that are not set in the
+// environment are read from the configuration file given with the -config
+// flag or the PREFIX_CONFIG environment variable, $HOME/.config/API/config.json
+// by default. The configuration file is a JSON object whose keys are the names
+// of the global flags and of the services. The values of the services are
+// objects whose keys are the names of the methods and whose values are objects
+// that map the method flag names to their values, for example:
+//
+//    {
+//        "host": "production",
+//        "timeout": 10,
+//        "storage": {
+//            "add": {
+//                "body": {"name": "Blue's Cuvee", "vintage": 2015}
+//            }
+//        }
+//    }
+//
+// Dotted keys such as "storage.add.body" are equivalent to the nested objects.
+// String values are used as is, the other values are converted to JSON.
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type (
+	// Defaults sets the flags that are not given explicitly on the command
+	// line to the values of the environment variables or of the
+	// configuration file.
+	Defaults struct {
+		// Prefix is the prefix of the environment variable names.
+		Prefix string
+		// Config contains the content of the configuration file.
+		Config Config
+	}
+
+	// Config is the content of a configuration file.
+	Config map[string]interface{}
+)
+
+// NewDefaults returns the defaults of the command line client of the API with
+// the given name. The path to the configuration file is read from the "config"
+// flag of fs if set, from the PREFIX_CONFIG environment variable otherwise and
+// defaults to the file returned by DefaultConfigPath which may not exist.
+func NewDefaults(api string, fs *flag.FlagSet) (*Defaults, error) {
+	d := &Defaults{Prefix: api}
+	path, explicit := "", true
+	if f := fs.Lookup("config"); f != nil {
+		path = f.Value.String()
+	}
+	if path == "" {
+		path = os.Getenv(envName(api, "config"))
+	}
+	if path == "" {
+		p, err := DefaultConfigPath(api)
+		if err != nil {
+			return d, nil
+		}
+		path, explicit = p, false
+	}
+	c, err := LoadConfig(path)
+	if err != nil {
+		if os.IsNotExist(err) && !explicit {
+			return d, nil
+		}
+		return nil, err
+	}
+	d.Config = c
+	return d, nil
+}
+
+// DefaultConfigPath returns the path to the configuration file of the API
+// with the given name, $HOME/.config/API/config.json.
+func DefaultConfigPath(api string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", strings.ToLower(api), "config.json"), nil
+}
+
+// LoadConfig reads the configuration file at path.
+func LoadConfig(path string) (Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var c Config
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("invalid configuration file %s: %s", path, err)
+	}
+	return c, nil
+}
+
+// Apply sets the flags of fs that were not given explicitly to the values of
+// the environment variables or of the configuration file. cmd is the path of
+// the command that defines the flags, i.e. the service and method names for
+// the method flags and empty for the global flags.
+func (d *Defaults) Apply(fs *flag.FlagSet, cmd ...string) error {
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		if err != nil || set[f.Name] {
+			return
+		}
+		path := append(append([]string{}, cmd...), f.Name)
+		val, ok := os.LookupEnv(envName(append([]string{d.Prefix}, path...)...))
+		if !ok {
+			val, ok = d.Config.Lookup(path...)
+		}
+		if !ok {
+			return
+		}
+		if e := fs.Set(f.Name, val); e != nil {
+			err = fmt.Errorf("invalid value %q for flag -%s: %s", val, strings.Join(path, "."), e)
+		}
+	})
+	return err
+}
+
+// Lookup returns the value of the configuration at the given path.
+func (c Config) Lookup(path ...string) (string, bool) {
+	if len(path) == 0 {
+		return "", false
+	}
+	for i := len(path); i > 0; i-- {
+		v, ok := c[strings.Join(path[:i], ".")]
+		if !ok {
+			continue
+		}
+		if i == len(path) {
+			return configValue(v)
+		}
+		if m, ok := v.(map[string]interface{}); ok {
+			if val, ok := Config(m).Lookup(path[i:]...); ok {
+				return val, true
+			}
+		}
+	}
+	return "", false
+}
+
+// configValue returns the flag value of the configuration value v.
+func configValue(v interface{}) (string, bool) {
+	switch val := v.(type) {
+	case nil:
+		return "", false
+	case string:
+		return val, true
+	default:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return "", false
+		}
+		return string(b), true
+	}
+}
+
+// envName returns the name of the environment variable made of the given
+// parts: the upper case parts joined with underscores where the characters
+// that are neither letters nor digits are replaced with underscores.
+func envName(parts ...string) string {
+	var b strings.Builder
+	for _, p := range parts {
+		if p == "" {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('_')
+		}
+		for _, r := range strings.ToUpper(p) {
+			if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+				b.WriteRune(r)
+			} else {
+				b.WriteByte('_')
+			}
+		}
+	}
+	return b.String()
+}
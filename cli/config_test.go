@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigLookup(t *testing.T) {
+	c := Config{
+		"host":    "production",
+		"timeout": 10.0,
+		"storage": map[string]interface{}{
+			"add": map[string]interface{}{
+				"body": map[string]interface{}{"name": "Cuvee"},
+			},
+			"show.view": "tiny",
+		},
+		"storage.list.limit": 5.0,
+		"verbose":            true,
+		"unset":              nil,
+	}
+	cases := []struct {
+		Name     string
+		Path     []string
+		Expected string
+		OK       bool
+	}{
+		{"string", []string{"host"}, "production", true},
+		{"number", []string{"timeout"}, "10", true},
+		{"bool", []string{"verbose"}, "true", true},
+		{"nested", []string{"storage", "add", "body"}, `{"name":"Cuvee"}`, true},
+		{"nested-dotted", []string{"storage", "show", "view"}, "tiny", true},
+		{"dotted", []string{"storage", "list", "limit"}, "5", true},
+		{"null", []string{"unset"}, "", false},
+		{"missing", []string{"storage", "remove", "id"}, "", false},
+		{"empty", nil, "", false},
+	}
+	for _, k := range cases {
+		t.Run(k.Name, func(t *testing.T) {
+			val, ok := c.Lookup(k.Path...)
+			if val != k.Expected || ok != k.OK {
+				t.Errorf("got %q, %v, expected %q, %v", val, ok, k.Expected, k.OK)
+			}
+		})
+	}
+}
+
+func TestDefaultsApply(t *testing.T) {
+	os.Setenv("CARS_STORAGE_ADD_VINTAGE", "2015")
+	defer os.Unsetenv("CARS_STORAGE_ADD_VINTAGE")
+	os.Setenv("CARS_STORAGE_ADD_NAME", "from env")
+	defer os.Unsetenv("CARS_STORAGE_ADD_NAME")
+	d := &Defaults{
+		Prefix: "cars",
+		Config: Config{"storage": map[string]interface{}{
+			"add": map[string]interface{}{"name": "from config", "rating": 4.0, "count": "many"},
+		}},
+	}
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+	name := fs.String("name", "", "")
+	vintage := fs.String("vintage", "", "")
+	rating := fs.String("rating", "", "")
+	other := fs.String("other", "default", "")
+	if err := fs.Parse([]string{"-name", "explicit"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Apply(fs, "storage", "add"); err != nil {
+		t.Fatalf("got error %q", err)
+	}
+	if *name != "explicit" {
+		t.Errorf("got name %q, expected the explicit value", *name)
+	}
+	if *vintage != "2015" {
+		t.Errorf("got vintage %q, expected the environment value", *vintage)
+	}
+	if *rating != "4" {
+		t.Errorf("got rating %q, expected the configuration value", *rating)
+	}
+	if *other != "default" {
+		t.Errorf("got other %q, expected the default value", *other)
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		fs := flag.NewFlagSet("add", flag.ContinueOnError)
+		fs.Int("count", 0, "")
+		if err := d.Apply(fs, "storage", "add"); err == nil {
+			t.Errorf("got no error, expected invalid value error")
+		}
+	})
+}
+
+func TestNewDefaults(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goacli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.json")
+	if err := ioutil.WriteFile(path, []byte(`{"host": "staging"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	home := os.Getenv("HOME")
+	defer os.Setenv("HOME", home)
+	os.Setenv("HOME", dir)
+
+	t.Run("flag", func(t *testing.T) {
+		fs := flag.NewFlagSet("cars-cli", flag.ContinueOnError)
+		fs.String("config", "", "")
+		if err := fs.Parse([]string{"-config", path}); err != nil {
+			t.Fatal(err)
+		}
+		d, err := NewDefaults("cars", fs)
+		if err != nil {
+			t.Fatalf("got error %q", err)
+		}
+		if v, _ := d.Config.Lookup("host"); v != "staging" {
+			t.Errorf("got host %q, expected %q", v, "staging")
+		}
+	})
+	t.Run("env", func(t *testing.T) {
+		os.Setenv("CARS_CONFIG", path)
+		defer os.Unsetenv("CARS_CONFIG")
+		d, err := NewDefaults("cars", flag.NewFlagSet("cars-cli", flag.ContinueOnError))
+		if err != nil {
+			t.Fatalf("got error %q", err)
+		}
+		if v, _ := d.Config.Lookup("host"); v != "staging" {
+			t.Errorf("got host %q, expected %q", v, "staging")
+		}
+	})
+	t.Run("missing-default", func(t *testing.T) {
+		d, err := NewDefaults("cars", flag.NewFlagSet("cars-cli", flag.ContinueOnError))
+		if err != nil {
+			t.Fatalf("got error %q", err)
+		}
+		if d.Config != nil {
+			t.Errorf("got config %v, expected nil", d.Config)
+		}
+	})
+	t.Run("missing-explicit", func(t *testing.T) {
+		os.Setenv("CARS_CONFIG", filepath.Join(dir, "missing.json"))
+		defer os.Unsetenv("CARS_CONFIG")
+		if _, err := NewDefaults("cars", flag.NewFlagSet("cars-cli", flag.ContinueOnError)); err == nil {
+			t.Errorf("got no error, expected missing file error")
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		if err := ioutil.WriteFile(path, []byte(`{`), 0600); err != nil {
+			t.Fatal(err)
+		}
+		os.Setenv("CARS_CONFIG", path)
+		defer os.Unsetenv("CARS_CONFIG")
+		if _, err := NewDefaults("cars", flag.NewFlagSet("cars-cli", flag.ContinueOnError)); err == nil {
+			t.Errorf("got no error, expected invalid file error")
+		}
+	})
+}
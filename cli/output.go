@@ -0,0 +1,368 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v2"
+)
+
+type (
+	// Output writes the results of the command line clients in the
+	// selected format after applying the query if any.
+	Output struct {
+		// Format is the output format, one of Formats.
+		Format string
+		// Columns lists the columns of the tables, the columns are
+		// derived from the result otherwise.
+		Columns []*Column
+
+		query *Query
+	}
+
+	// Column describes a column of the table output.
+	Column struct {
+		// Header is the column header.
+		Header string
+		// Field is the name of the result field rendered in the column.
+		Field string
+	}
+)
+
+// Formats lists the output formats supported by Output.
+var Formats = []string{"json", "yaml", "table"}
+
+// NewOutput returns the output that writes the results in the given format,
+// json if empty, after applying the JMESPath query if not empty, see Compile.
+func NewOutput(format, query string) (*Output, error) {
+	if format == "" {
+		format = "json"
+	}
+	valid := false
+	for _, f := range Formats {
+		if f == format {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return nil, fmt.Errorf("invalid output format %q, must be one of %s", format, strings.Join(Formats, ", "))
+	}
+	o := &Output{Format: format}
+	if query != "" {
+		q, err := Compile(query)
+		if err != nil {
+			return nil, err
+		}
+		o.query = q
+	}
+	return o, nil
+}
+
+// Write writes data to w. The fields of data are named after the JSON
+// representation of data. The table format renders lists of objects with one
+// row per element and objects with a single row. The table columns are the
+// output columns unless a query is applied, they are the sorted fields of the
+// objects otherwise. Values that are neither lists nor objects are written as
+// is.
+func (o *Output) Write(w io.Writer, data interface{}) error {
+	if o.query == nil && o.Format == "json" {
+		// Preserve the order of the fields of data.
+		b, err := json.MarshalIndent(data, "", "    ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	}
+	v, columns, err := o.eval(data)
+	if err != nil {
+		return err
+	}
+	switch o.Format {
+	case "yaml":
+		b, err := yaml.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+	case "table":
+		return writeTable(w, v, columns)
+	default:
+		b, err := json.MarshalIndent(v, "", "    ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	}
+}
+
+// StreamWriter returns a function that writes the results of a stream to w as
+// they arrive. The JSON format writes one value per line (NDJSON), the YAML
+// format separates the values with "---" and the table format writes the
+// header once and the rows of each value as they arrive with the columns
+// aligned on the headers.
+func (o *Output) StreamWriter(w io.Writer) func(interface{}) error {
+	var (
+		first   = true
+		columns []*Column
+	)
+	return func(data interface{}) error {
+		defer func() { first = false }()
+		v, cols, err := o.eval(data)
+		if err != nil {
+			return err
+		}
+		switch o.Format {
+		case "yaml":
+			b, err := yaml.Marshal(v)
+			if err != nil {
+				return err
+			}
+			if !first {
+				if _, err := io.WriteString(w, "---\n"); err != nil {
+					return err
+				}
+			}
+			_, err = w.Write(b)
+			return err
+		case "table":
+			rows, ok := tableRows(v)
+			if !ok {
+				return writeValues(w, v)
+			}
+			if columns == nil {
+				columns = cols
+				if len(columns) == 0 {
+					columns = deriveColumns(rows)
+				}
+				headers := make([]string, len(columns))
+				for i, c := range columns {
+					headers[i] = c.Header
+				}
+				if err := writeRow(w, columns, headers); err != nil {
+					return err
+				}
+			}
+			for _, r := range rows {
+				cells := make([]string, len(columns))
+				for i, c := range columns {
+					cells[i] = cell(r[c.Field])
+				}
+				if err := writeRow(w, columns, cells); err != nil {
+					return err
+				}
+			}
+			return nil
+		default:
+			b, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(w, string(b))
+			return err
+		}
+	}
+}
+
+// eval returns the JSON representation of data after applying the query and
+// the table columns, nil if a query is applied.
+func (o *Output) eval(data interface{}) (interface{}, []*Column, error) {
+	v, err := normalize(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if o.query != nil {
+		return o.query.Search(v), nil, nil
+	}
+	return v, o.Columns, nil
+}
+
+// writeTable writes v as a table with the given columns.
+func writeTable(w io.Writer, v interface{}, columns []*Column) error {
+	rows, ok := tableRows(v)
+	if !ok {
+		return writeValues(w, v)
+	}
+	if len(columns) == 0 {
+		columns = deriveColumns(rows)
+	}
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', 0)
+	headers := make([]string, len(columns))
+	for i, c := range columns {
+		headers[i] = c.Header
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, r := range rows {
+		cells := make([]string, len(columns))
+		for i, c := range columns {
+			cells[i] = cell(r[c.Field])
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	// Remove the padding of the empty cells at the end of the rows.
+	for _, line := range strings.SplitAfter(buf.String(), "\n") {
+		if line == "" {
+			continue
+		}
+		if _, err := fmt.Fprintln(w, strings.TrimRight(line, " \n")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tableRows returns the rows of the table that renders v: v itself if it is an
+// object and its elements if it is a list of objects. tableRows returns false
+// if v is neither an object nor a list of objects.
+func tableRows(v interface{}) ([]map[string]interface{}, bool) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return []map[string]interface{}{val}, true
+	case []interface{}:
+		rows := make([]map[string]interface{}, len(val))
+		for i, e := range val {
+			m, ok := e.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			rows[i] = m
+		}
+		return rows, true
+	}
+	return nil, false
+}
+
+// writeValues writes the elements of v one per line if v is a list, v itself
+// otherwise. Null values are not written.
+func writeValues(w io.Writer, v interface{}) error {
+	vals, ok := v.([]interface{})
+	if !ok {
+		if v == nil {
+			return nil
+		}
+		vals = []interface{}{v}
+	}
+	for _, e := range vals {
+		if _, err := fmt.Fprintln(w, cell(e)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeRow writes the cells of a streamed table row padded to the width of the
+// column headers.
+func writeRow(w io.Writer, columns []*Column, cells []string) error {
+	var b strings.Builder
+	for i, c := range cells {
+		if i == len(cells)-1 {
+			b.WriteString(c)
+			break
+		}
+		b.WriteString(c)
+		pad := utf8.RuneCountInString(columns[i].Header) - utf8.RuneCountInString(c)
+		if pad < 0 {
+			pad = 0
+		}
+		b.WriteString(strings.Repeat(" ", pad+3))
+	}
+	_, err := fmt.Fprintln(w, strings.TrimRight(b.String(), " "))
+	return err
+}
+
+// deriveColumns returns the columns made of the sorted fields of the rows.
+func deriveColumns(rows []map[string]interface{}) []*Column {
+	seen := make(map[string]bool)
+	var fields []string
+	for _, r := range rows {
+		for f := range r {
+			if !seen[f] {
+				seen[f] = true
+				fields = append(fields, f)
+			}
+		}
+	}
+	sort.Strings(fields)
+	columns := make([]*Column, len(fields))
+	for i, f := range fields {
+		columns[i] = &Column{Header: strings.ToUpper(f), Field: f}
+	}
+	return columns
+}
+
+// cell returns the table cell content of v: the empty string for null values,
+// the value of strings, numbers and booleans and the compact JSON
+// representation of lists and objects.
+func cell(v interface{}) string {
+	var s string
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		s = val
+	case int64:
+		s = strconv.FormatInt(val, 10)
+	case float64:
+		s = strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		s = strconv.FormatBool(val)
+	default:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return "?"
+		}
+		s = string(b)
+	}
+	return strings.NewReplacer("\t", " ", "\n", " ").Replace(s)
+}
+
+// normalize returns the JSON representation of data made of maps, slices,
+// strings, int64, float64 and booleans.
+func normalize(data interface{}) (interface{}, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return numbers(v), nil
+}
+
+// numbers replaces the JSON numbers in v with int64 or float64 values.
+func numbers(v interface{}) interface{} {
+	switch val := v.(type) {
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return i
+		}
+		f, _ := val.Float64()
+		return f
+	case []interface{}:
+		for i, e := range val {
+			val[i] = numbers(e)
+		}
+	case map[string]interface{}:
+		for k, e := range val {
+			val[k] = numbers(e)
+		}
+	}
+	return v
+}
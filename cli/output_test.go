@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type bottle struct {
+	ID      string
+	Name    string
+	Vintage int
+	Rating  *float64
+	Tags    []string
+}
+
+func TestOutputWrite(t *testing.T) {
+	rating := 4.5
+	bottles := []*bottle{
+		{ID: "1", Name: "Cuvee", Vintage: 2015, Rating: &rating, Tags: []string{"red", "dry"}},
+		{ID: "2", Name: "Blanc", Vintage: 2018},
+	}
+	columns := []*Column{{Header: "ID", Field: "ID"}, {Header: "NAME", Field: "Name"}, {Header: "RATING", Field: "Rating"}}
+	cases := []struct {
+		Name     string
+		Format   string
+		Query    string
+		Columns  []*Column
+		Data     interface{}
+		Expected string
+	}{
+		{"json", "", "", nil, bottles[1], `{
+    "ID": "2",
+    "Name": "Blanc",
+    "Vintage": 2018,
+    "Rating": null,
+    "Tags": null
+}
+`},
+		{"json-query", "json", "[*].Name", nil, bottles, `[
+    "Cuvee",
+    "Blanc"
+]
+`},
+		{"yaml", "yaml", "[0].{name: Name, tags: Tags}", nil, bottles, `name: Cuvee
+tags:
+- red
+- dry
+`},
+		{"table-columns", "table", "", columns, bottles, `ID   NAME    RATING
+1    Cuvee   4.5
+2    Blanc
+`},
+		{"table-derived", "table", "", nil, bottles[0], `ID   NAME    RATING   TAGS            VINTAGE
+1    Cuvee   4.5      ["red","dry"]   2015
+`},
+		{"table-query", "table", "[?Vintage > `2016`].{name: Name, year: Vintage}", columns, bottles, `NAME    YEAR
+Blanc   2018
+`},
+		{"table-values", "table", "[*].Vintage", columns, bottles, `2015
+2018
+`},
+		{"table-scalar", "table", "", nil, "done", `done
+`},
+		{"table-null", "table", "[5]", nil, bottles, ``},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			o, err := NewOutput(c.Format, c.Query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			o.Columns = c.Columns
+			var buf bytes.Buffer
+			if err := o.Write(&buf, c.Data); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != c.Expected {
+				t.Errorf("got:\n%s\nexpected:\n%s", buf.String(), c.Expected)
+			}
+		})
+	}
+}
+
+func TestNewOutputErrors(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Format string
+		Query  string
+		Error  string
+	}{
+		{"format", "xml", "", `invalid output format "xml", must be one of json, yaml, table`},
+		{"query", "json", "a[", "invalid query"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			_, err := NewOutput(c.Format, c.Query)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), c.Error) {
+				t.Errorf("got %q, expected it to contain %q", err, c.Error)
+			}
+		})
+	}
+}
+
+func TestOutputStreamWriter(t *testing.T) {
+	rating := 4.5
+	bottles := []interface{}{
+		&bottle{ID: "1", Name: "Cuvee", Vintage: 2015, Rating: &rating},
+		&bottle{ID: "2", Name: "Blanc", Vintage: 2018},
+		[]*bottle{{ID: "3", Name: "Rose", Vintage: 2012}, {ID: "42", Name: "Grenache", Vintage: 2020}},
+	}
+	columns := []*Column{{Header: "ID", Field: "ID"}, {Header: "NAME", Field: "Name"}, {Header: "RATING", Field: "Rating"}}
+	cases := []struct {
+		Name     string
+		Format   string
+		Query    string
+		Columns  []*Column
+		Expected string
+	}{
+		{"json", "json", "", nil, `{"ID":"1","Name":"Cuvee","Rating":4.5,"Tags":null,"Vintage":2015}
+{"ID":"2","Name":"Blanc","Rating":null,"Tags":null,"Vintage":2018}
+[{"ID":"3","Name":"Rose","Rating":null,"Tags":null,"Vintage":2012},{"ID":"42","Name":"Grenache","Rating":null,"Tags":null,"Vintage":2020}]
+`},
+		{"json-query", "json", "Name", nil, `"Cuvee"
+"Blanc"
+null
+`},
+		{"yaml", "yaml", "[Name]", nil, `- Cuvee
+---
+- Blanc
+---
+- null
+`},
+		{"table", "table", "", columns, `ID   NAME   RATING
+1    Cuvee   4.5
+2    Blanc
+3    Rose
+42   Grenache
+`},
+		{"table-query", "table", "Vintage", columns, `2015
+2018
+`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			o, err := NewOutput(c.Format, c.Query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			o.Columns = c.Columns
+			var buf bytes.Buffer
+			write := o.StreamWriter(&buf)
+			for _, b := range bottles {
+				if err := write(b); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if buf.String() != c.Expected {
+				t.Errorf("got:\n%s\nexpected:\n%s", buf.String(), c.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,746 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type (
+	// Query is a compiled JMESPath expression, see Compile.
+	Query struct {
+		expr string
+		root node
+	}
+
+	// node is a node of the syntax tree of a query.
+	node interface {
+		eval(v interface{}) interface{}
+	}
+
+	// projectionKind is the kind of a projection node.
+	projectionKind int
+
+	currentNode struct{}
+	literalNode struct{ val interface{} }
+	fieldNode   struct{ name string }
+	indexNode   struct{ index int }
+	subexprNode struct{ left, right node }
+	pipeNode    struct{ left, right node }
+	listNode    struct{ items []node }
+	hashNode    struct {
+		keys  []string
+		items []node
+	}
+	projectionNode struct {
+		kind        projectionKind
+		left, right node
+		cond        node
+	}
+	compareNode struct {
+		op          string
+		left, right node
+	}
+	andNode struct{ left, right node }
+	orNode  struct{ left, right node }
+	notNode struct{ expr node }
+
+	// token is a lexical token of a query.
+	token struct {
+		kind string // "ident", "number", "literal" or the punctuation
+		text string
+		val  interface{}
+		pos  int
+	}
+
+	// parser parses a query.
+	parser struct {
+		expr   string
+		tokens []token
+		pos    int
+	}
+)
+
+const (
+	// listProjection projects the elements of a list: [*]
+	listProjection projectionKind = iota
+	// flattenProjection projects the flattened elements of a list: []
+	flattenProjection
+	// filterProjection projects the elements that match a condition: [?cond]
+	filterProjection
+	// valuesProjection projects the values of an object: *
+	valuesProjection
+)
+
+// Compile parses a JMESPath expression. The supported subset includes:
+//
+//    field, "quoted field", a.b        field access
+//    a[0], a[-1]                       list index
+//    a[*].b, a[].b, *.b                list, flatten and object projections
+//    a[?b == 'x' && c > `1`]           filters with ==, !=, <, <=, >, >=, &&, || and !
+//    [a, b], {x: a, y: b}              multiselect lists and hashes
+//    a | b                             pipes
+//    @, 'raw string', `json literal`   current node and literals
+//
+// Numbers may be used in comparisons without the backticks, e.g. [?a > 1].
+func Compile(expr string) (*Query, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{expr: expr, tokens: tokens}
+	root, err := p.parsePipe()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != "eof" {
+		return nil, p.errorf(t, "unexpected %q", t.text)
+	}
+	return &Query{expr: expr, root: root}, nil
+}
+
+// Search applies the query to v and returns the result. v must be made of the
+// values produced by encoding/json when decoding into an empty interface.
+func (q *Query) Search(v interface{}) interface{} {
+	return q.root.eval(v)
+}
+
+// String returns the query expression.
+func (q *Query) String() string {
+	return q.expr
+}
+
+func (currentNode) eval(v interface{}) interface{} { return v }
+
+func (n literalNode) eval(interface{}) interface{} { return n.val }
+
+func (n fieldNode) eval(v interface{}) interface{} {
+	if m, ok := v.(map[string]interface{}); ok {
+		return m[n.name]
+	}
+	return nil
+}
+
+func (n indexNode) eval(v interface{}) interface{} {
+	l, ok := v.([]interface{})
+	if !ok {
+		return nil
+	}
+	i := n.index
+	if i < 0 {
+		i += len(l)
+	}
+	if i < 0 || i >= len(l) {
+		return nil
+	}
+	return l[i]
+}
+
+func (n subexprNode) eval(v interface{}) interface{} {
+	l := n.left.eval(v)
+	if l == nil {
+		return nil
+	}
+	return n.right.eval(l)
+}
+
+func (n pipeNode) eval(v interface{}) interface{} {
+	return n.right.eval(n.left.eval(v))
+}
+
+func (n listNode) eval(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	res := make([]interface{}, len(n.items))
+	for i, item := range n.items {
+		res[i] = item.eval(v)
+	}
+	return res
+}
+
+func (n hashNode) eval(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	res := make(map[string]interface{}, len(n.items))
+	for i, item := range n.items {
+		res[n.keys[i]] = item.eval(v)
+	}
+	return res
+}
+
+func (n projectionNode) eval(v interface{}) interface{} {
+	l := n.left.eval(v)
+	var elems []interface{}
+	switch n.kind {
+	case valuesProjection:
+		m, ok := l.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			elems = append(elems, m[k])
+		}
+	case flattenProjection:
+		list, ok := l.([]interface{})
+		if !ok {
+			return nil
+		}
+		for _, e := range list {
+			if sub, ok := e.([]interface{}); ok {
+				elems = append(elems, sub...)
+			} else {
+				elems = append(elems, e)
+			}
+		}
+	default:
+		list, ok := l.([]interface{})
+		if !ok {
+			return nil
+		}
+		elems = list
+	}
+	res := []interface{}{}
+	for _, e := range elems {
+		if n.kind == filterProjection && !truthy(n.cond.eval(e)) {
+			continue
+		}
+		if r := n.right.eval(e); r != nil {
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+func (n compareNode) eval(v interface{}) interface{} {
+	l, r := n.left.eval(v), n.right.eval(v)
+	switch n.op {
+	case "==":
+		return equal(l, r)
+	case "!=":
+		return !equal(l, r)
+	}
+	if lf, ok := toFloat(l); ok {
+		rf, ok := toFloat(r)
+		if !ok {
+			return nil
+		}
+		return order(n.op, compareFloats(lf, rf))
+	}
+	if ls, ok := l.(string); ok {
+		rs, ok := r.(string)
+		if !ok {
+			return nil
+		}
+		return order(n.op, strings.Compare(ls, rs))
+	}
+	return nil
+}
+
+func (n andNode) eval(v interface{}) interface{} {
+	l := n.left.eval(v)
+	if !truthy(l) {
+		return l
+	}
+	return n.right.eval(v)
+}
+
+func (n orNode) eval(v interface{}) interface{} {
+	l := n.left.eval(v)
+	if truthy(l) {
+		return l
+	}
+	return n.right.eval(v)
+}
+
+func (n notNode) eval(v interface{}) interface{} {
+	return !truthy(n.expr.eval(v))
+}
+
+// parsePipe parses pipe expressions.
+func (p *parser) parsePipe() (node, error) {
+	left, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == "|" {
+		p.next()
+		right, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		left = pipeNode{left, right}
+	}
+	return left, nil
+}
+
+// parseOr parses || expressions.
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == "||" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+// parseAnd parses && expressions.
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == "&&" {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+// parseNot parses negations.
+func (p *parser) parseNot() (node, error) {
+	if p.peek().kind == "!" {
+		p.next()
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{expr}, nil
+	}
+	return p.parseComparison()
+}
+
+// parseComparison parses comparisons.
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseChain()
+	if err != nil {
+		return nil, err
+	}
+	switch op := p.peek().kind; op {
+	case "==", "!=", "<", "<=", ">", ">=":
+		p.next()
+		right, err := p.parseChain()
+		if err != nil {
+			return nil, err
+		}
+		return compareNode{op, left, right}, nil
+	}
+	return left, nil
+}
+
+// parseChain parses a primary expression followed by field accesses, indexes
+// and projections.
+func (p *parser) parseChain() (node, error) {
+	t := p.peek()
+	switch t.kind {
+	case "ident":
+		p.next()
+		return p.parseSuffixes(fieldNode{t.text}, false)
+	case "@":
+		p.next()
+		return p.parseSuffixes(currentNode{}, false)
+	case "literal", "number":
+		p.next()
+		return literalNode{t.val}, nil
+	case "*":
+		p.next()
+		right, err := p.parseSuffixes(currentNode{}, true)
+		if err != nil {
+			return nil, err
+		}
+		return p.parseSuffixes(projectionNode{kind: valuesProjection, left: currentNode{}, right: right}, false)
+	case "(":
+		p.next()
+		expr, err := p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return p.parseSuffixes(expr, false)
+	case "{":
+		hash, err := p.parseHash()
+		if err != nil {
+			return nil, err
+		}
+		return p.parseSuffixes(hash, false)
+	case "[":
+		switch p.peekAt(1).kind {
+		case "number", "*", "]", "?":
+			return p.parseSuffixes(currentNode{}, false)
+		}
+		list, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return p.parseSuffixes(list, false)
+	case "eof":
+		return nil, p.errorf(t, "unexpected end of expression")
+	default:
+		return nil, p.errorf(t, "unexpected %q", t.text)
+	}
+}
+
+// parseSuffixes parses the field accesses, indexes and projections applied
+// to left. Projections apply the remaining suffixes to each projected element
+// up to the next flatten operator which applies to the whole projection, so
+// that projected is true when parsing the right hand side of a projection.
+func (p *parser) parseSuffixes(left node, projected bool) (node, error) {
+	for {
+		switch p.peek().kind {
+		case ".":
+			p.next()
+			t := p.peek()
+			switch t.kind {
+			case "ident":
+				p.next()
+				left = subexprNode{left, fieldNode{t.text}}
+			case "*":
+				p.next()
+				right, err := p.parseSuffixes(currentNode{}, true)
+				if err != nil {
+					return nil, err
+				}
+				left = projectionNode{kind: valuesProjection, left: left, right: right}
+			case "[":
+				list, err := p.parseList()
+				if err != nil {
+					return nil, err
+				}
+				left = subexprNode{left, list}
+			case "{":
+				hash, err := p.parseHash()
+				if err != nil {
+					return nil, err
+				}
+				left = subexprNode{left, hash}
+			default:
+				return nil, p.errorf(t, "expected field name after '.'")
+			}
+		case "[":
+			if projected && p.peekAt(1).kind == "]" {
+				return left, nil
+			}
+			p.next()
+			t := p.next()
+			switch t.kind {
+			case "number":
+				if err := p.expect("]"); err != nil {
+					return nil, err
+				}
+				i, ok := t.val.(int64)
+				if !ok {
+					return nil, p.errorf(t, "invalid index %s", t.text)
+				}
+				left = subexprNode{left, indexNode{int(i)}}
+			case "*", "]":
+				kind := listProjection
+				if t.kind == "*" {
+					if err := p.expect("]"); err != nil {
+						return nil, err
+					}
+				} else {
+					kind = flattenProjection
+				}
+				right, err := p.parseSuffixes(currentNode{}, true)
+				if err != nil {
+					return nil, err
+				}
+				left = projectionNode{kind: kind, left: left, right: right}
+			case "?":
+				cond, err := p.parseOr()
+				if err != nil {
+					return nil, err
+				}
+				if err := p.expect("]"); err != nil {
+					return nil, err
+				}
+				right, err := p.parseSuffixes(currentNode{}, true)
+				if err != nil {
+					return nil, err
+				}
+				left = projectionNode{kind: filterProjection, left: left, cond: cond, right: right}
+			case "eof":
+				return nil, p.errorf(t, "unexpected end of expression")
+			default:
+				return nil, p.errorf(t, "unexpected %q after '['", t.text)
+			}
+		default:
+			return left, nil
+		}
+	}
+}
+
+// parseList parses a multiselect list.
+func (p *parser) parseList() (node, error) {
+	if err := p.expect("["); err != nil {
+		return nil, err
+	}
+	var items []node
+	for {
+		item, err := p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		if p.peek().kind != "," {
+			break
+		}
+		p.next()
+	}
+	if err := p.expect("]"); err != nil {
+		return nil, err
+	}
+	return listNode{items}, nil
+}
+
+// parseHash parses a multiselect hash.
+func (p *parser) parseHash() (node, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var n hashNode
+	for {
+		t := p.next()
+		if t.kind != "ident" {
+			return nil, p.errorf(t, "expected key name")
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		item, err := p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+		n.keys = append(n.keys, t.text)
+		n.items = append(n.items, item)
+		if p.peek().kind != "," {
+			break
+		}
+		p.next()
+	}
+	if err := p.expect("}"); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// peek returns the current token.
+func (p *parser) peek() token {
+	return p.peekAt(0)
+}
+
+// peekAt returns the token at the given offset from the current token.
+func (p *parser) peekAt(offset int) token {
+	if i := p.pos + offset; i < len(p.tokens) {
+		return p.tokens[i]
+	}
+	return token{kind: "eof", pos: len(p.expr)}
+}
+
+// next returns the current token and moves to the next one.
+func (p *parser) next() token {
+	t := p.peek()
+	if p.pos < len(p.tokens) {
+		p.pos++
+	}
+	return t
+}
+
+// expect consumes the current token if it has the given kind and returns an
+// error otherwise.
+func (p *parser) expect(kind string) error {
+	if t := p.next(); t.kind != kind {
+		if t.kind == "eof" {
+			return p.errorf(t, "expected %q, got end of expression", kind)
+		}
+		return p.errorf(t, "expected %q, got %q", kind, t.text)
+	}
+	return nil
+}
+
+// errorf returns a syntax error at the position of t.
+func (p *parser) errorf(t token, format string, args ...interface{}) error {
+	return fmt.Errorf("invalid query %q at position %d: %s", p.expr, t.pos, fmt.Sprintf(format, args...))
+}
+
+// tokenize splits the expression into tokens.
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	rs := []rune(expr)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		start := i
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case r == '_' || unicode.IsLetter(r):
+			for i < len(rs) && (rs[i] == '_' || unicode.IsLetter(rs[i]) || unicode.IsDigit(rs[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: "ident", text: string(rs[start:i]), pos: start})
+			continue
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(rs) && unicode.IsDigit(rs[i+1])):
+			i++
+			for i < len(rs) && (unicode.IsDigit(rs[i]) || rs[i] == '.') {
+				i++
+			}
+			text := string(rs[start:i])
+			var val interface{}
+			if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+				val = n
+			} else if f, err := strconv.ParseFloat(text, 64); err == nil {
+				val = f
+			} else {
+				return nil, fmt.Errorf("invalid query %q at position %d: invalid number %s", expr, start, text)
+			}
+			tokens = append(tokens, token{kind: "number", text: text, val: val, pos: start})
+			continue
+		case r == '"' || r == '\'' || r == '`':
+			i++
+			for i < len(rs) && rs[i] != r {
+				if rs[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			if i >= len(rs) {
+				return nil, fmt.Errorf("invalid query %q at position %d: unterminated %c", expr, start, r)
+			}
+			i++
+			t, err := quoted(expr, rs[start:i], start)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, t)
+			continue
+		}
+		if i+1 < len(rs) {
+			switch op := string(rs[i : i+2]); op {
+			case "==", "!=", "<=", ">=", "&&", "||":
+				tokens = append(tokens, token{kind: op, text: op, pos: start})
+				i += 2
+				continue
+			}
+		}
+		switch r {
+		case '.', '[', ']', '{', '}', '(', ')', ',', ':', '*', '@', '|', '?', '!', '<', '>':
+			tokens = append(tokens, token{kind: string(r), text: string(r), pos: start})
+			i++
+		default:
+			return nil, fmt.Errorf("invalid query %q at position %d: unexpected %q", expr, start, r)
+		}
+	}
+	return tokens, nil
+}
+
+// quoted returns the token of a quoted identifier, raw string or JSON literal.
+func quoted(expr string, rs []rune, pos int) (token, error) {
+	text := string(rs)
+	inner := string(rs[1 : len(rs)-1])
+	switch rs[0] {
+	case '"':
+		name, err := strconv.Unquote(text)
+		if err != nil {
+			return token{}, fmt.Errorf("invalid query %q at position %d: invalid quoted identifier %s", expr, pos, text)
+		}
+		return token{kind: "ident", text: name, pos: pos}, nil
+	case '\'':
+		return token{kind: "literal", text: text, val: strings.Replace(inner, `\'`, `'`, -1), pos: pos}, nil
+	default:
+		v, err := normalize(json.RawMessage(strings.Replace(inner, "\\`", "`", -1)))
+		if err != nil {
+			return token{}, fmt.Errorf("invalid query %q at position %d: invalid JSON literal %s", expr, pos, text)
+		}
+		return token{kind: "literal", text: text, val: v, pos: pos}, nil
+	}
+}
+
+// truthy returns false for null, false, empty strings, lists and objects and
+// true for the other values.
+func truthy(v interface{}) bool {
+	switch val := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return val
+	case string:
+		return val != ""
+	case []interface{}:
+		return len(val) > 0
+	case map[string]interface{}:
+		return len(val) > 0
+	default:
+		return true
+	}
+}
+
+// equal returns true if a and b are equal, numbers are compared by value.
+func equal(a, b interface{}) bool {
+	if af, ok := toFloat(a); ok {
+		bf, ok := toFloat(b)
+		return ok && af == bf
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// toFloat returns the value of numbers as float64.
+func toFloat(v interface{}) (float64, bool) {
+	switch val := v.(type) {
+	case int64:
+		return float64(val), true
+	case float64:
+		return val, true
+	}
+	return 0, false
+}
+
+// compareFloats returns -1, 0 or 1 if a is less than, equal to or greater
+// than b.
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// order returns the result of the ordering comparison op given the result of
+// the comparison of the operands.
+func order(op string, cmp int) bool {
+	switch op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestQuerySearch(t *testing.T) {
+	const data = `{
+		"name": "cellar",
+		"bottles": [
+			{"name": "Cuvee", "vintage": 2015, "rating": 4.5, "tags": ["red", "dry"], "winery": {"name": "Lamonarda"}},
+			{"name": "Blanc", "vintage": 2018, "rating": 3, "tags": ["white"], "winery": {"name": "Moulin"}},
+			{"name": "Rose", "vintage": 2012, "tags": [], "winery": null}
+		],
+		"counts": {"red": 1, "white": 1, "rose": 1},
+		"odd key": true
+	}`
+	cases := []struct {
+		Name     string
+		Query    string
+		Expected string
+	}{
+		{"field", "name", `"cellar"`},
+		{"quoted-field", `"odd key"`, `true`},
+		{"missing", "foo.bar", `null`},
+		{"subexpression", "bottles[0].winery.name", `"Lamonarda"`},
+		{"negative-index", "bottles[-1].name", `"Rose"`},
+		{"out-of-range", "bottles[5]", `null`},
+		{"current", "@.name", `"cellar"`},
+		{"list-projection", "bottles[*].name", `["Cuvee","Blanc","Rose"]`},
+		{"projection-drops-nulls", "bottles[*].winery.name", `["Lamonarda","Moulin"]`},
+		{"flatten", "bottles[].tags[]", `["red","dry","white"]`},
+		{"values", "counts.*", `[1,1,1]`},
+		{"filter-number", "bottles[?vintage > `2014`].name", `["Cuvee","Blanc"]`},
+		{"filter-bare-number", "bottles[?vintage <= 2015].name", `["Cuvee","Rose"]`},
+		{"filter-string", "bottles[?name == 'Blanc'].vintage", `[2018]`},
+		{"filter-float", "bottles[?rating >= `4`].name", `["Cuvee"]`},
+		{"filter-and", "bottles[?vintage > `2010` && rating].name", `["Cuvee","Blanc"]`},
+		{"filter-or", "bottles[?vintage == `2012` || name == 'Blanc'].name", `["Blanc","Rose"]`},
+		{"filter-not", "bottles[?!tags].name", `["Rose"]`},
+		{"filter-parens", "bottles[?!(vintage < `2013` || rating < `4`)].name", `["Cuvee"]`},
+		{"filter-string-order", "bottles[?name < 'D'].name", `["Cuvee","Blanc"]`},
+		{"filter-not-equal", "bottles[?winery != null].name", `["Cuvee","Blanc"]`},
+		{"multiselect-list", "bottles[*].[name, vintage]", `[["Cuvee",2015],["Blanc",2018],["Rose",2012]]`},
+		{"multiselect-hash", "bottles[0].{n: name, w: winery.name}", `{"n":"Cuvee","w":"Lamonarda"}`},
+		{"pipe", "bottles[*].name | [0]", `"Cuvee"`},
+		{"pipe-stops-projection", "bottles[*].tags | [0]", `["red","dry"]`},
+		{"literal", "`{\"a\": 1}`", `{"a":1}`},
+		{"raw-string", `'it\'s'`, `"it's"`},
+	}
+	v, err := normalize(json.RawMessage(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			q, err := Compile(c.Query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := json.Marshal(q.Search(v))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != c.Expected {
+				t.Errorf("got %s, expected %s", b, c.Expected)
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Query string
+		Error string
+	}{
+		{"empty", "", "unexpected end of expression"},
+		{"trailing", "a b", `unexpected "b"`},
+		{"unterminated-bracket", "a[0", `expected "]", got end of expression`},
+		{"unterminated-projection", "a[", "unexpected end of expression"},
+		{"unterminated-string", "a[?b == 'x]", "unterminated '"},
+		{"invalid-literal", "`{a`", "invalid JSON literal"},
+		{"invalid-character", "a#b", `unexpected '#'`},
+		{"invalid-dot", "a.0", "expected field name after '.'"},
+		{"invalid-hash", "{0: a}", "expected key name"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			_, err := Compile(c.Query)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), c.Error) {
+				t.Errorf("got %q, expected it to contain %q", err, c.Error)
+			}
+		})
+	}
+}
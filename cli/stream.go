@@ -0,0 +1,201 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+)
+
+type (
+	// Stream adapts the client stream returned by a streaming endpoint so
+	// that the command line clients can run it, see Run.
+	Stream struct {
+		// Send decodes the JSON value and sends it to the stream. Send is
+		// nil if the endpoint does not stream its payload.
+		Send func(data []byte) error
+		// Recv receives the next result from the stream, it returns
+		// io.EOF once the server closes the stream. Recv is nil if the
+		// endpoint does not stream its results.
+		Recv func() (interface{}, error)
+		// Close closes the stream and returns the result of the endpoints
+		// that stream their payload and return a single result. Close is
+		// nil if the stream does not need to be closed.
+		Close func() (interface{}, error)
+		// HalfClose is true if Close only closes the sending side of the
+		// stream so that the results can still be received, e.g. gRPC.
+		HalfClose bool
+	}
+
+	// received is a value received from a stream.
+	received struct {
+		val interface{}
+		err error
+	}
+)
+
+// Run sends the JSON values read from in, one value per line (NDJSON), to the
+// stream and calls out with each result as it arrives. The end of the input
+// closes the stream of the endpoints that do not stream their results, Run
+// then calls out with the result if any. The endpoints that stream their
+// results run until the server closes the stream, the end of the input closes
+// the sending side of the stream first if HalfClose is true.
+//
+// Cancelling ctx terminates the stream gracefully: Run stops sending values,
+// closes the stream, calls out with the result of the endpoints that return a
+// single result and returns nil. See InterruptContext.
+func (s *Stream) Run(ctx context.Context, in io.Reader, out func(interface{}) error) error {
+	var (
+		mu     sync.Mutex
+		closed bool
+	)
+	// closeStream closes the stream once, Send is never called afterwards.
+	closeStream := func() (interface{}, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if closed || s.Close == nil {
+			closed = true
+			return nil, nil
+		}
+		closed = true
+		return s.Close()
+	}
+	send := func(data []byte) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if closed {
+			return nil
+		}
+		return s.Send(data)
+	}
+
+	var sent chan error
+	if s.Send != nil {
+		sent = make(chan error, 1)
+		go func() { sent <- sendLines(in, send) }()
+	}
+	var results chan received
+	done := make(chan struct{})
+	defer close(done)
+	if s.Recv != nil {
+		results = make(chan received)
+		go func() {
+			for {
+				v, err := s.Recv()
+				select {
+				case results <- received{v, err}:
+				case <-done:
+					return
+				}
+				if err != nil {
+					return
+				}
+			}
+		}()
+	}
+
+	for {
+		select {
+		case err := <-sent:
+			sent = nil
+			if err != nil {
+				closeStream()
+				return err
+			}
+			if s.Recv == nil {
+				return closeAndWrite(closeStream, out)
+			}
+			if s.HalfClose {
+				if _, err := closeStream(); err != nil {
+					return err
+				}
+			}
+		case r := <-results:
+			if r.err == io.EOF {
+				closeStream()
+				return nil
+			}
+			if r.err != nil {
+				return r.err
+			}
+			if err := out(r.val); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			if s.Recv == nil {
+				return closeAndWrite(closeStream, out)
+			}
+			closeStream()
+			return nil
+		}
+	}
+}
+
+// InterruptContext returns a copy of ctx that is cancelled when the process
+// receives an interrupt signal (SIGINT) so that the streams can be terminated
+// gracefully, see Stream.Run. A second interrupt signal exits the process
+// immediately with status 130. Calling cancel releases the resources
+// associated with the context and restores the default behavior of the
+// signal.
+func InterruptContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	sig := make(chan os.Signal, 1)
+	stop := make(chan struct{})
+	signal.Notify(sig, os.Interrupt)
+	go func() {
+		select {
+		case <-sig:
+			cancel()
+		case <-stop:
+			return
+		}
+		select {
+		case <-sig:
+			os.Exit(130)
+		case <-stop:
+		}
+	}()
+	var once sync.Once
+	return ctx, func() {
+		once.Do(func() {
+			signal.Stop(sig)
+			close(stop)
+			cancel()
+		})
+	}
+}
+
+// sendLines calls send with each non-empty line read from in.
+func sendLines(in io.Reader, send func([]byte) error) error {
+	r := bufio.NewReader(in)
+	for n := 1; ; n++ {
+		line, err := r.ReadBytes('\n')
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			if serr := send(line); serr != nil {
+				return fmt.Errorf("line %d: %s", n, serr)
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// closeAndWrite closes the stream and calls out with the result if any.
+func closeAndWrite(closeStream func() (interface{}, error), out func(interface{}) error) error {
+	res, err := closeStream()
+	if err != nil {
+		return err
+	}
+	if res == nil {
+		return nil
+	}
+	return out(res)
+}
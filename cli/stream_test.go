@@ -0,0 +1,213 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeStream records the values sent to a stream and serves the results.
+type fakeStream struct {
+	mu      sync.Mutex
+	sent    []int
+	results chan interface{}
+	closed  bool
+}
+
+func newFakeStream() *fakeStream {
+	return &fakeStream{results: make(chan interface{}, 10)}
+}
+
+func (f *fakeStream) send(data []byte) error {
+	var v int
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, v)
+	return nil
+}
+
+func (f *fakeStream) recv() (interface{}, error) {
+	v, ok := <-f.results
+	if !ok {
+		return nil, io.EOF
+	}
+	if err, ok := v.(error); ok {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (f *fakeStream) sum() (interface{}, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	total := 0
+	for _, v := range f.sent {
+		total += v
+	}
+	return total, nil
+}
+
+func (f *fakeStream) close() (interface{}, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	return nil, nil
+}
+
+func TestStreamRun(t *testing.T) {
+	t.Run("server-stream", func(t *testing.T) {
+		f := newFakeStream()
+		f.results <- "a"
+		f.results <- "b"
+		close(f.results)
+		var got []interface{}
+		s := &Stream{Recv: f.recv}
+		if err := s.Run(context.Background(), strings.NewReader(""), collect(&got)); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, []interface{}{"a", "b"}) {
+			t.Errorf("got %v, expected [a b]", got)
+		}
+	})
+
+	t.Run("client-stream", func(t *testing.T) {
+		f := newFakeStream()
+		var got []interface{}
+		s := &Stream{Send: f.send, Close: f.sum}
+		if err := s.Run(context.Background(), strings.NewReader("1\n\n2\n 3 "), collect(&got)); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(f.sent, []int{1, 2, 3}) {
+			t.Errorf("got sent values %v, expected [1 2 3]", f.sent)
+		}
+		if !reflect.DeepEqual(got, []interface{}{6}) {
+			t.Errorf("got %v, expected [6]", got)
+		}
+	})
+
+	t.Run("invalid-input", func(t *testing.T) {
+		f := newFakeStream()
+		s := &Stream{Send: f.send, Close: f.sum}
+		err := s.Run(context.Background(), strings.NewReader("1\nfoo\n"), collect(new([]interface{})))
+		if err == nil || !strings.HasPrefix(err.Error(), "line 2: ") {
+			t.Errorf("got error %v, expected invalid line 2", err)
+		}
+		if !f.closed {
+			t.Error("stream not closed")
+		}
+	})
+
+	t.Run("bidirectional-half-close", func(t *testing.T) {
+		f := newFakeStream()
+		var got []interface{}
+		s := &Stream{
+			Send: f.send,
+			Recv: f.recv,
+			Close: func() (interface{}, error) {
+				// The server echoes the values and closes the stream
+				// once the client closes the sending side.
+				for _, v := range f.sent {
+					f.results <- v
+				}
+				close(f.results)
+				return nil, nil
+			},
+			HalfClose: true,
+		}
+		if err := s.Run(context.Background(), strings.NewReader("1\n2\n"), collect(&got)); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, []interface{}{1, 2}) {
+			t.Errorf("got %v, expected [1 2]", got)
+		}
+	})
+
+	t.Run("bidirectional-closed-by-server", func(t *testing.T) {
+		f := newFakeStream()
+		var got []interface{}
+		s := &Stream{Send: f.send, Recv: f.recv, Close: f.close}
+		f.results <- "a"
+		go func() {
+			f.results <- "b"
+			close(f.results)
+		}()
+		if err := s.Run(context.Background(), strings.NewReader("1\n"), collect(&got)); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, []interface{}{"a", "b"}) {
+			t.Errorf("got %v, expected [a b]", got)
+		}
+		if !f.closed {
+			t.Error("stream not closed")
+		}
+	})
+
+	t.Run("receive-error", func(t *testing.T) {
+		f := newFakeStream()
+		f.results <- errors.New("boom")
+		s := &Stream{Recv: f.recv}
+		err := s.Run(context.Background(), strings.NewReader(""), collect(new([]interface{})))
+		if err == nil || err.Error() != "boom" {
+			t.Errorf("got error %v, expected boom", err)
+		}
+	})
+
+	t.Run("cancel-client-stream", func(t *testing.T) {
+		f := newFakeStream()
+		r, w := io.Pipe()
+		defer w.Close()
+		ctx, cancel := context.WithCancel(context.Background())
+		var got []interface{}
+		s := &Stream{Send: func(data []byte) error {
+			err := f.send(data)
+			cancel()
+			return err
+		}, Close: f.sum}
+		go fmt.Fprintln(w, "4")
+		if err := s.Run(ctx, r, collect(&got)); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, []interface{}{4}) {
+			t.Errorf("got %v, expected [4]", got)
+		}
+	})
+
+	t.Run("cancel-server-stream", func(t *testing.T) {
+		f := newFakeStream()
+		ctx, cancel := context.WithCancel(context.Background())
+		s := &Stream{Recv: f.recv, Close: f.close}
+		var got []interface{}
+		f.results <- "a"
+		err := s.Run(ctx, strings.NewReader(""), func(v interface{}) error {
+			got = append(got, v)
+			cancel()
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, []interface{}{"a"}) {
+			t.Errorf("got %v, expected [a]", got)
+		}
+		if !f.closed {
+			t.Error("stream not closed")
+		}
+	})
+}
+
+func collect(vals *[]interface{}) func(interface{}) error {
+	return func(v interface{}) error {
+		*vals = append(*vals, v)
+		return nil
+	}
+}
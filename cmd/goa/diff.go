@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/build"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen/diff"
+)
+
+// compareDesigns prints the changes made to the design package with import
+// path oldPath to produce the design package with import path newPath that
+// break existing clients. It exits with status 2 if there are any so that
+// the command may be used to gate continuous integration builds.
+func compareDesigns(oldPath, newPath string, jsonOut, debug bool) {
+	var (
+		old, new *diff.Surface
+		changes  []*diff.Change
+		err      error
+	)
+
+	if old, err = surface(oldPath, debug); err != nil {
+		goto fail
+	}
+	if new, err = surface(newPath, debug); err != nil {
+		goto fail
+	}
+
+	changes = diff.Compare(old, new)
+	if jsonOut {
+		if changes == nil {
+			changes = []*diff.Change{}
+		}
+		var b []byte
+		if b, err = json.MarshalIndent(changes, "", "  "); err != nil {
+			goto fail
+		}
+		fmt.Println(string(b))
+	} else {
+		for _, c := range changes {
+			fmt.Println(c.String())
+		}
+	}
+	if len(changes) > 0 {
+		os.Exit(2)
+	}
+	return
+fail:
+	fmt.Fprintln(os.Stderr, err.Error())
+	os.Exit(1)
+}
+
+// surface runs the "diff" code generator on the design package with the given
+// import path and returns the resulting design surface.
+func surface(path string, debug bool) (*diff.Surface, error) {
+	if _, err := build.Import(path, ".", 0); err != nil {
+		return nil, err
+	}
+
+	// The output directory must be in the current module so that the
+	// generator can compute the import path of the "gen" package.
+	out, err := ioutil.TempDir(".", "goadiff")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(out)
+
+	tmp := NewGenerator("diff", path, out)
+	if !debug {
+		defer tmp.Remove()
+	}
+	if err := tmp.Write(debug); err != nil {
+		return nil, err
+	}
+	if err := tmp.Compile(); err != nil {
+		return nil, err
+	}
+	if _, err := tmp.Run(); err != nil {
+		return nil, err
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(out, diff.SurfaceFilename))
+	if err != nil {
+		return nil, err
+	}
+	var s diff.Surface
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, fmt.Errorf("invalid surface of design %q: %s", path, err)
+	}
+	return &s, nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/generator"
+)
+
+// explainCode prints the design expressions that produced the generated code
+// at the location loc formatted as "file:line" or "file" using the source map
+// written to the gen directory by the "gen" command. All the declarations of
+// the file are listed if loc has no line. It exits with status 1 if the code
+// is not mapped to the design.
+func explainCode(loc string) {
+	if err := explainTo(os.Stdout, loc); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+// explainTo writes the design expressions that produced the generated code at
+// the location loc to w, see explainCode.
+func explainTo(w io.Writer, loc string) error {
+	file, line := loc, 0
+	if i := strings.LastIndex(loc, ":"); i > 0 {
+		if l, err := strconv.Atoi(loc[i+1:]); err == nil {
+			file, line = loc[:i], l
+		}
+	}
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return err
+	}
+	gendir, err := findSourceMap(filepath.Dir(abs))
+	if err != nil {
+		return fmt.Errorf("%s: %s", file, err)
+	}
+	m, err := generator.ReadSourceMap(gendir)
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(gendir, abs)
+	if err != nil {
+		return err
+	}
+	mappings := m.Lookup(filepath.ToSlash(rel), line)
+	if len(mappings) == 0 {
+		return fmt.Errorf("%s: no design expression produced the code", loc)
+	}
+	for _, sm := range mappings {
+		fmt.Fprintf(w, "%s:%d-%d: %s generated from %s\n", file, sm.Start, sm.End, sm.Decl, sm.Expr)
+		if sm.Design != "" {
+			fmt.Fprintf(w, "\tdefined at %s\n", designPath(gendir, sm))
+		}
+	}
+	return nil
+}
+
+// findSourceMap returns the path to the closest directory containing the
+// source map file starting with dir and walking up the parent directories.
+func findSourceMap(dir string) (string, error) {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, generator.SourceMapFile)); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("no %s found, generate the code with \"goa gen\" first", generator.SourceMapFile)
+		}
+		dir = parent
+	}
+}
+
+// designPath returns the location of the design of sm relative to the working
+// directory.
+func designPath(gendir string, sm *codegen.SourceMapping) string {
+	loc := sm.Design
+	i := strings.LastIndex(loc, ":")
+	if i < 0 {
+		return loc
+	}
+	file := filepath.Join(gendir, filepath.FromSlash(loc[:i]))
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, file); err == nil {
+			file = rel
+		}
+	}
+	return file + loc[i:]
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goa-explain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	gendir := filepath.Join(dir, "gen")
+	if err := os.MkdirAll(filepath.Join(gendir, "svc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	sourceMap := `{"files": {"svc/endpoints.go": [
+		{"decl": "Endpoints", "start": 10, "end": 14, "expr": "service \"svc\"", "design": "../design/design.go:8"},
+		{"decl": "NewAddEndpoint", "start": 16, "end": 24, "expr": "service \"svc\" method \"add\""}
+	]}}`
+	if err := ioutil.WriteFile(filepath.Join(gendir, "sourcemap.json"), []byte(sourceMap), 0644); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(gendir, "svc", "endpoints.go")
+	design := filepath.Join(dir, "design", "design.go")
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel, err := filepath.Rel(wd, design); err == nil {
+		design = rel
+	}
+
+	cases := map[string]struct {
+		Loc      string
+		Expected string
+		Error    bool
+	}{
+		"line": {file + ":12", file + ":10-14: Endpoints generated from service \"svc\"\n\tdefined at " + design + ":8\n", false},
+		"file": {file, file + ":10-14: Endpoints generated from service \"svc\"\n\tdefined at " + design + ":8\n" +
+			file + ":16-24: NewAddEndpoint generated from service \"svc\" method \"add\"\n", false},
+		"unmapped line": {file + ":15", "", true},
+		"no source map": {filepath.Join(dir, "other.go"), "", true},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := explainTo(&buf, c.Loc)
+			if c.Error {
+				if err == nil {
+					t.Errorf("expected an error, got output %q", buf.String())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != c.Expected {
+				t.Errorf("got\n%s\nexpected\n%s", buf.String(), c.Expected)
+			}
+		})
+	}
+}
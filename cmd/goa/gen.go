@@ -2,8 +2,10 @@ package main
 
 import (
 	"fmt"
+	"go/ast"
 	"go/parser"
 	"go/token"
+	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
@@ -32,6 +34,42 @@ type Generator struct {
 	// DesignVersion is either 2 or 3.
 	DesignVersion int
 
+	// Locale is the locale of the descriptions used by the generated code,
+	// see expr.Locale.
+	Locale string
+
+	// State is the path to the file that holds the fingerprints of the
+	// design computed by the previous generation, see
+	// generator.Incremental. If set only the files affected by the changes
+	// made to the design since then are generated.
+	State string
+
+	// Cache is the path to the directory used to cache the generated Go
+	// files, see generator.CacheDir. Caching requires goa v3
+	// designs and is disabled if Cache is empty.
+	Cache string
+
+	// Profile receives the report of the time spent generating the code
+	// if not nil, see generator.Profile.
+	Profile io.Writer
+
+	// Update causes the example files that already exist to be merged
+	// with the newly generated content, see generator.Update.
+	Update bool
+
+	// Diff receives the unified diff between the existing files and the
+	// generated files if not nil. The files are not written, see
+	// generator.DryRun.
+	Diff io.Writer
+
+	// Changed is set by Run in dry-run mode if the generated files differ
+	// from the existing files.
+	Changed bool
+
+	// Plugins lists the import paths of the plugins declared in the design
+	// with the Plugins DSL. The generator imports the plugin packages.
+	Plugins []string
+
 	// bin is the filename of the generated generator.
 	bin string
 
@@ -46,11 +84,12 @@ func NewGenerator(cmd string, path, output string) *Generator {
 		bin += ".exe"
 	}
 
+	pkgs, _ := packages.Load(&packages.Config{Mode: packages.NeedFiles}, path)
+
 	var version int
 	{
 		version = 2
 		matched := false
-		pkgs, _ := packages.Load(&packages.Config{Mode: packages.NeedFiles}, path)
 		fset := token.NewFileSet()
 		p := regexp.MustCompile(`goa.design/goa/v(\d+)/dsl`)
 		for _, pkg := range pkgs {
@@ -76,15 +115,86 @@ func NewGenerator(cmd string, path, output string) *Generator {
 		}
 	}
 
+	var plugins []string
+	if version > 2 {
+		for _, pkg := range pkgs {
+			plugins = append(plugins, designPlugins(pkg.GoFiles)...)
+		}
+	}
+
 	return &Generator{
 		Command:       cmd,
 		DesignPath:    path,
 		Output:        output,
 		DesignVersion: version,
+		Plugins:       plugins,
 		bin:           bin,
 	}
 }
 
+// designPlugins returns the import paths given to the Plugins DSL in the given
+// Go source files. Only the string literal arguments are returned as the
+// design has not been evaluated yet.
+func designPlugins(files []string) []string {
+	var (
+		plugins []string
+		seen    = make(map[string]bool)
+		fset    = token.NewFileSet()
+	)
+	for _, gof := range files {
+		f, err := parser.ParseFile(fset, gof, nil, 0)
+		if err != nil {
+			continue
+		}
+		var dot bool
+		names := make(map[string]bool)
+		for _, s := range f.Imports {
+			if p, _ := strconv.Unquote(s.Path.Value); p != "goa.design/goa/v3/dsl" {
+				continue
+			}
+			switch {
+			case s.Name == nil:
+				names["dsl"] = true
+			case s.Name.Name == ".":
+				dot = true
+			default:
+				names[s.Name.Name] = true
+			}
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			switch fun := call.Fun.(type) {
+			case *ast.Ident:
+				if !dot || fun.Name != "Plugins" {
+					return true
+				}
+			case *ast.SelectorExpr:
+				x, ok := fun.X.(*ast.Ident)
+				if !ok || !names[x.Name] || fun.Sel.Name != "Plugins" {
+					return true
+				}
+			default:
+				return true
+			}
+			for _, arg := range call.Args {
+				lit, ok := arg.(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					continue
+				}
+				if p, err := strconv.Unquote(lit.Value); err == nil && !seen[p] {
+					seen[p] = true
+					plugins = append(plugins, p)
+				}
+			}
+			return true
+		})
+	}
+	return plugins
+}
+
 // Write writes the main file.
 func (g *Generator) Write(debug bool) error {
 	var tmpDir string
@@ -107,6 +217,7 @@ func (g *Generator) Write(debug bool) error {
 			"Command":       g.Command,
 			"CleanupDirs":   cleanupDirs(g.Command, g.Output),
 			"DesignVersion": g.DesignVersion,
+			"Plugins":       g.Plugins,
 		}
 		ver := ""
 		if g.DesignVersion > 2 {
@@ -126,6 +237,12 @@ func (g *Generator) Write(debug bool) error {
 			codegen.NewImport("goa", "goa.design/goa/"+ver+"pkg"),
 			codegen.NewImport("_", g.DesignPath),
 		}
+		if g.DesignVersion > 2 {
+			imports = append(imports, codegen.SimpleImport("goa.design/goa/"+ver+"expr"))
+			for _, p := range g.Plugins {
+				imports = append(imports, codegen.NewImport("_", p))
+			}
+		}
 		sections = []*codegen.SectionTemplate{
 			codegen.Header("Code Generator", "main", imports),
 			{
@@ -150,18 +267,22 @@ func (g *Generator) Compile() error {
 func (g *Generator) Run() ([]string, error) {
 	var cmdl string
 	{
-		args := make([]string, len(os.Args)-1)
+		args := make([]string, 0, len(os.Args)-1)
 		gopaths := filepath.SplitList(os.Getenv("GOPATH"))
-		for i, a := range os.Args[1:] {
+		for _, a := range os.Args[1:] {
+			// The files generated by a dry run must be identical to the
+			// files generated without the flag.
+			if isDryRunFlag(a) {
+				continue
+			}
+			arg := a
 			for _, p := range gopaths {
 				if strings.Contains(a, p) {
-					args[i] = strings.Replace(a, p, "$(GOPATH)", -1)
+					arg = strings.Replace(a, p, "$(GOPATH)", -1)
 					break
 				}
 			}
-			if args[i] == "" {
-				args[i] = a
-			}
+			args = append(args, arg)
 		}
 		cmdl = " " + strings.Join(args, " ")
 		rawcmd := filepath.Base(os.Args[0])
@@ -172,11 +293,52 @@ func (g *Generator) Run() ([]string, error) {
 	}
 
 	args := []string{"--version=" + strconv.Itoa(g.DesignVersion), "--output=" + g.Output, "--cmd=" + cmdl}
+	if g.Locale != "" {
+		args = append(args, "--locale="+g.Locale)
+	}
+	if g.State != "" {
+		args = append(args, "--state="+g.State)
+	}
+	if g.Cache != "" && g.DesignVersion > 2 {
+		args = append(args, "--cache="+g.Cache)
+	}
+	if g.Update {
+		args = append(args, "--update")
+	}
+	var profile string
+	if g.Profile != nil {
+		profile = filepath.Join(g.tmpDir, "profile.txt")
+		args = append(args, "--profile="+profile)
+	}
+	var diff string
+	if g.Diff != nil {
+		diff = filepath.Join(g.tmpDir, "diff.txt")
+		args = append(args, "--dry-run="+diff)
+	}
 	cmd := exec.Command(filepath.Join(g.tmpDir, g.bin), args...)
 	out, err := cmd.CombinedOutput()
 	if err != nil {
 		return nil, fmt.Errorf("%s\n%s", err, string(out))
 	}
+	if profile != "" {
+		report, err := ioutil.ReadFile(profile)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := g.Profile.Write(report); err != nil {
+			return nil, err
+		}
+	}
+	if diff != "" {
+		d, err := ioutil.ReadFile(diff)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := g.Diff.Write(d); err != nil {
+			return nil, err
+		}
+		g.Changed = len(d) > 0
+	}
 	res := strings.Split(string(out), "\n")
 	for (len(res) > 0) && (res[len(res)-1] == "") {
 		res = res[:len(res)-1]
@@ -184,6 +346,16 @@ func (g *Generator) Run() ([]string, error) {
 	return res, nil
 }
 
+// isDryRunFlag returns true if the command line argument a is the dry-run
+// flag.
+func isDryRunFlag(a string) bool {
+	name := strings.TrimLeft(a, "-")
+	if i := strings.Index(name, "="); i >= 0 {
+		name = name[:i]
+	}
+	return strings.HasPrefix(a, "-") && name == "dry-run"
+}
+
 // Remove deletes the package files.
 func (g *Generator) Remove() {
 	if g.tmpDir != "" {
@@ -216,7 +388,9 @@ func (g *Generator) runGoCmd(args ...string) error {
 }
 
 // cleanupDirs returns the paths of the subdirectories under gendir to delete
-// before generating code.
+// before generating code with goa v2 designs. The generator computes them from
+// the output layout defined in the design with goa v3 designs, see
+// generator.CleanupDirs.
 func cleanupDirs(cmd, output string) []string {
 	if cmd == "gen" {
 		gendirPath := filepath.Join(output, codegen.Gendir)
@@ -246,6 +420,12 @@ const mainT = `func main() {
 		out     = flag.String("output", "", "")
 		version = flag.String("version", "", "")
 		cmdl    = flag.String("cmd", "", "")
+		locale  = flag.String("locale", "", "")
+		state   = flag.String("state", "", "")
+		cache   = flag.String("cache", "", "")
+		profile = flag.String("profile", "", "")
+		update  = flag.Bool("update", false, "")
+		dryRun  = flag.String("dry-run", "", "")
 		ver int
 	)
 	{
@@ -272,21 +452,111 @@ const mainT = `func main() {
 	if err := eval.Context.Errors; err != nil {
 		fail(err.Error())
 	}
+{{- if gt .DesignVersion 2 }}
+	expr.Locale = *locale
+{{- else }}
+	if *locale != "" {
+		fail("the locale flag requires goa v3 designs")
+	}
+	if *state != "" {
+		fail("the state flag requires goa v3 designs")
+	}
+	if *cache != "" {
+		fail("the cache flag requires goa v3 designs")
+	}
+	if *profile != "" {
+		fail("the profile flag requires goa v3 designs")
+	}
+	if *update {
+		fail("the update flag requires goa v3 designs")
+	}
+	if *dryRun != "" {
+		fail("the dry-run flag requires goa v3 designs")
+	}
+{{- end }}
 	if err := eval.RunDSL(); err != nil {
 		fail(err.Error())
 	}
+{{- if gt .DesignVersion 2 }}
+	for _, w := range eval.Context.Warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", w)
+	}
+	codegen.DesignVersion = ver
+	loaded := map[string]bool{
+{{- range .Plugins }}
+		{{ printf "%q" . }}: true,
+{{- end }}
+	}
+	for _, p := range expr.Root.API.Plugins {
+		if !loaded[p] {
+			fail("plugin %q was not loaded, the import paths given to Plugins must be string literals\n", p)
+		}
+	}
+	generator.CacheDir = *cache
+	generator.Update = *update
+	if *profile != "" {
+		generator.Profiling = &generator.Profile{}
+	}
+	if *dryRun != "" {
+		f, err := os.Create(*dryRun)
+		if err != nil {
+			fail(err.Error())
+		}
+		_, err = generator.DryRun(*out, {{ printf "%q" .Command }}, f)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			fail(err.Error())
+		}
+		return
+	}
+	roots, err := eval.Context.Roots()
+	if err != nil {
+		fail(err.Error())
+	}
+	cleanup := {{ if eq .Command "gen" }}true{{ else }}false{{ end }}
+	if *state != "" {
+		gens, all, err := generator.Incremental(*state, *out, roots)
+		if err != nil {
+			fail(err.Error())
+		}
+		generator.Generators = func(string) ([]generator.Genfunc, error) { return gens, nil }
+		cleanup = all
+	}
+	if cleanup {
+		for _, dir := range generator.CleanupDirs(*out, roots) {
+			if err := os.RemoveAll(dir); err != nil {
+				fail(err.Error())
+			}
+		}
+	}
+{{- else }}
 {{- range .CleanupDirs }}
 	if err := os.RemoveAll({{ printf "%q" . }}); err != nil {
 		fail(err.Error())
 	}
 {{- end }}
-{{- if gt .DesignVersion 2 }}
-	codegen.DesignVersion = ver
 {{- end }}
 	outputs, err := generator.Generate(*out, {{ printf "%q" .Command }})
 	if err != nil {
 		fail(err.Error())
 	}
+{{- if gt .DesignVersion 2 }}
+	if *profile != "" {
+		f, err := os.Create(*profile)
+		if err != nil {
+			fail(err.Error())
+		}
+		err = generator.Profiling.Report(f)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			fail(err.Error())
+		}
+	}
+{{- end }}
 
 	fmt.Println(strings.Join(outputs, "\n"))
 }
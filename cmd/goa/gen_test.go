@@ -0,0 +1,49 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDesignPlugins(t *testing.T) {
+	cases := map[string]struct {
+		Source   string
+		Expected []string
+	}{
+		"dot import": {`package design
+import . "goa.design/goa/v3/dsl"
+var _ = API("api", func() {
+	Plugins("goa.design/plugins/v3/cors", "github.com/acme/goa-redis")
+	Plugins("goa.design/plugins/v3/cors")
+})`, []string{"goa.design/plugins/v3/cors", "github.com/acme/goa-redis"}},
+		"named import": {`package design
+import d "goa.design/goa/v3/dsl"
+var _ = d.API("api", func() { d.Plugins("github.com/acme/goa-redis") })`, []string{"github.com/acme/goa-redis"}},
+		"not literal": {`package design
+import . "goa.design/goa/v3/dsl"
+const cors = "goa.design/plugins/v3/cors"
+var _ = API("api", func() { Plugins(cors) })`, nil},
+		"other package": {`package design
+import . "example.com/other"
+var _ = Plugins("goa.design/plugins/v3/cors")`, nil},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "goa-plugins")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, "design.go")
+			if err := ioutil.WriteFile(path, []byte(c.Source), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if plugins := designPlugins([]string{path}); !reflect.DeepEqual(plugins, c.Expected) {
+				t.Errorf("got %v, expected %v", plugins, c.Expected)
+			}
+		})
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"go/build"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"flag"
 
@@ -13,9 +15,10 @@ import (
 
 func main() {
 	var (
-		cmd    string
-		path   string
-		offset int
+		cmd     string
+		path    string
+		newPath string
+		offset  int
 	)
 	{
 		if len(os.Args) == 1 {
@@ -33,14 +36,37 @@ func main() {
 			cmd = os.Args[1]
 			path = os.Args[2]
 			offset = 2
+		case "explain":
+			if len(os.Args) != 3 {
+				usage()
+				return
+			}
+			explain(os.Args[2])
+			return
+		case "diff":
+			if len(os.Args) < 4 {
+				usage()
+				return
+			}
+			cmd = os.Args[1]
+			path = os.Args[2]
+			newPath = os.Args[3]
+			offset = 3
 		default:
 			usage()
 		}
 	}
 
 	var (
-		output = "."
-		debug  bool
+		output  = "."
+		locale  string
+		debug   bool
+		profile bool
+		update  bool
+		dryRun  bool
+		jsonOut bool
+		watch   bool
+		every   = time.Second
 	)
 	if len(os.Args) > offset+1 {
 		var (
@@ -48,7 +74,14 @@ func main() {
 			o    = fset.String("o", "", "output `directory`")
 			out  = fset.String("output", output, "output `directory`")
 		)
+		fset.StringVar(&locale, "locale", "", "Locale of the generated descriptions")
 		fset.BoolVar(&debug, "debug", false, "Print debug information")
+		fset.BoolVar(&profile, "profile", false, "Print the time spent generating the code")
+		fset.BoolVar(&update, "update", false, "Merge the changes into the existing example files")
+		fset.BoolVar(&dryRun, "dry-run", false, "Print the changes instead of writing the files")
+		fset.BoolVar(&jsonOut, "json", false, "Print breaking changes in JSON")
+		fset.BoolVar(&watch, "watch", false, "Regenerate the code when the design changes")
+		fset.DurationVar(&every, "interval", every, "Interval between two checks of the design files")
 
 		fset.Usage = usage
 		fset.Parse(os.Args[offset+1:])
@@ -59,16 +92,39 @@ func main() {
 		}
 	}
 
-	gen(cmd, path, output, debug)
+	if cmd == "diff" {
+		compare(path, newPath, jsonOut, debug)
+		return
+	}
+	if update && cmd != "example" {
+		usage()
+		return
+	}
+	if dryRun && (cmd != "gen" || watch) {
+		usage()
+		return
+	}
+	if watch {
+		if cmd != "gen" || every <= 0 {
+			usage()
+			return
+		}
+		watchGen(path, output, locale, every, debug)
+		return
+	}
+	gen(cmd, path, output, locale, profile, update, dryRun, debug)
 }
 
 // help with tests
 var (
-	usage = help
-	gen   = generate
+	usage    = help
+	gen      = generate
+	compare  = compareDesigns
+	explain  = explainCode
+	watchGen = watch
 )
 
-func generate(cmd, path, output string, debug bool) {
+func generate(cmd, path, output, locale string, profile, update, dryRun, debug bool) {
 	var (
 		files []string
 		err   error
@@ -80,6 +136,15 @@ func generate(cmd, path, output string, debug bool) {
 	}
 
 	tmp = NewGenerator(cmd, path, output)
+	tmp.Locale = locale
+	tmp.Cache = cacheDir()
+	tmp.Update = update
+	if profile {
+		tmp.Profile = os.Stderr
+	}
+	if dryRun {
+		tmp.Diff = os.Stdout
+	}
 	if !debug {
 		defer tmp.Remove()
 	}
@@ -96,6 +161,15 @@ func generate(cmd, path, output string, debug bool) {
 		goto fail
 	}
 
+	if dryRun {
+		if !debug {
+			tmp.Remove()
+		}
+		if tmp.Changed {
+			os.Exit(1)
+		}
+		return
+	}
 	fmt.Println(strings.Join(files, "\n"))
 	return
 fail:
@@ -106,13 +180,25 @@ fail:
 	os.Exit(1)
 }
 
+// cacheDir returns the path to the directory used to cache the generated Go
+// files, an empty string if there is no user cache directory.
+func cacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "goa")
+}
+
 func help() {
 	fmt.Fprint(os.Stderr, `goa is the code generation tool for the goa framework.
 Learn more at https://goa.design.
 
 Usage:
-  goa gen PACKAGE [--out DIRECTORY] [--debug]
-  goa example PACKAGE [--out DIRECTORY] [--debug]
+  goa gen PACKAGE [--out DIRECTORY] [--locale LOCALE] [--watch [--interval DURATION] | --dry-run] [--profile] [--debug]
+  goa example PACKAGE [--out DIRECTORY] [--update] [--profile] [--debug]
+  goa diff OLD_PACKAGE NEW_PACKAGE [--json] [--debug]
+  goa explain FILE[:LINE]
   goa version
 
 Commands:
@@ -120,6 +206,16 @@ Commands:
         Generate service interfaces, endpoints, transport code and OpenAPI spec.
   example
         Generate example server and client tool.
+  diff
+        Report the changes made to the HTTP and gRPC surfaces of the design
+        in OLD_PACKAGE to produce the design in NEW_PACKAGE that break
+        existing clients. Exits with status 2 if there are any.
+  explain
+        Print the design expressions that produced the generated code at
+        the given line of FILE, or all the declarations of FILE if LINE is
+        omitted, and where the expressions are defined in the design. The
+        code generated by "gen" must be up to date: the mapping is read
+        from the sourcemap.json file written to the gen directory.
   version
         Print version information (exclusive with other flags and commands).
 
@@ -131,12 +227,64 @@ Flags:
   -o, -output DIRECTORY
         output directory, defaults to the current working directory
 
+  -locale LOCALE
+        locale of the descriptions used in the generated code and
+        documentation when the design defines descriptions in multiple
+        locales, e.g. "ja"
+
+  -watch
+        Regenerate the code each time the design package source files
+        change until interrupted (gen only). Only the code affected by the
+        changes is regenerated: changes to the HTTP or gRPC expressions only
+        regenerate the corresponding transport code. The files added,
+        modified and deleted by each generation are reported.
+
+  -interval DURATION
+        Interval between two checks of the design package source files
+        (watch only), defaults to 1s
+
+  -dry-run
+        Generate the code in memory and print the unified diff between the
+        existing files and the generated files followed by a summary of the
+        changes instead of writing the files (gen only). Exits with status 1
+        if the generated code is not up to date, e.g. to check in CI that
+        the generated code was committed after changing the design.
+
+  -update
+        Merge the example files generated from the design into the existing
+        files instead of leaving them untouched (example only). The code
+        generated for new design elements, e.g. the methods added to a
+        service, is added to the existing files while preserving the edits
+        made to them. Changes that cannot be merged automatically are
+        delimited with conflict markers.
+
+  -profile
+        Print the time spent in each step of the code generation, in each
+        generator and rendering the most expensive files on stderr
+
+  -json
+        Print the breaking changes in JSON (diff only)
+
   -debug
         Print debug information (mainly intended for goa developers)
 
+The Go files generated for the services are cached in the "goa" subdirectory
+of the user cache directory so that the files of the services whose design
+does not change are not rendered again.
+
+The templates used to render sections of the generated code may be
+overridden by files named after the sections with the ".tmpl" extension
+located in the "goa-templates" subdirectory of the output directory, e.g.
+goa-templates/server-handler.tmpl. The generation fails if an override is
+not compatible with the template it replaces.
+
 Example:
 
   goa gen goa.design/cellar/design -o gendir
+  goa gen goa.design/cellar/design --watch
+  goa gen goa.design/cellar/design --dry-run
+  goa diff goa.design/cellar/design/released goa.design/cellar/design --json
+  goa explain gen/http/cellar/server/encode_decode.go:123
 
 `)
 	os.Exit(1)
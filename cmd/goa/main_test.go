@@ -4,6 +4,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestCmdLine(t *testing.T) {
@@ -15,11 +16,17 @@ func TestCmdLine(t *testing.T) {
 		usageCalled  bool
 		cmd          string
 		path, output string
+		locale       string
+		profile      bool
+		update       bool
+		dryRun       bool
 		debug        bool
 	)
 
 	usage = func() { usageCalled = true }
-	gen = func(c string, p, o string, d bool) { cmd, path, output, debug = c, p, o, d }
+	gen = func(c string, p, o, l string, pr, u, dr, d bool) {
+		cmd, path, output, locale, profile, update, dryRun, debug = c, p, o, l, pr, u, dr, d
+	}
 	defer func() {
 		usage = help
 		gen = generate
@@ -32,17 +39,31 @@ func TestCmdLine(t *testing.T) {
 		ExpectedPath    string
 		ExpectedOutput  string
 		ExpectedDebug   bool
+		ExpectedLocale  string
+		ExpectedProfile bool
+		ExpectedUpdate  bool
+		ExpectedDryRun  bool
 	}{
-		"gen": {"gen " + testPkg, false, "gen", testPkg, ".", false},
+		"gen": {"gen " + testPkg, false, "gen", testPkg, ".", false, "", false, false, false},
+
+		"invalid":     {"invalid " + testPkg, true, "", "", ".", false, "", false, false, false},
+		"empty":       {"", true, "", "", ".", false, "", false, false, false},
+		"invalid gen": {"invalid gen" + testPkg, true, "", "", ".", false, "", false, false, false},
+
+		"output":       {"gen " + testPkg + " -output " + testOutput, false, "gen", testPkg, testOutput, false, "", false, false, false},
+		"output short": {"gen " + testPkg + " -o " + testOutput, false, "gen", testPkg, testOutput, false, "", false, false, false},
+
+		"debug": {"gen " + testPkg + " -debug", false, "gen", testPkg, ".", true, "", false, false, false},
+
+		"locale": {"gen " + testPkg + " -locale ja", false, "gen", testPkg, ".", false, "ja", false, false, false},
 
-		"invalid":     {"invalid " + testPkg, true, "", "", ".", false},
-		"empty":       {"", true, "", "", ".", false},
-		"invalid gen": {"invalid gen" + testPkg, true, "", "", ".", false},
+		"profile": {"gen " + testPkg + " -profile", false, "gen", testPkg, ".", false, "", true, false, false},
 
-		"output":       {"gen " + testPkg + " -output " + testOutput, false, "gen", testPkg, testOutput, false},
-		"output short": {"gen " + testPkg + " -o " + testOutput, false, "gen", testPkg, testOutput, false},
+		"update":         {"example " + testPkg + " -update", false, "example", testPkg, ".", false, "", false, true, false},
+		"invalid update": {"gen " + testPkg + " -update", true, "", "", "", false, "", false, false, false},
 
-		"debug": {"gen " + testPkg + " -debug", false, "gen", testPkg, ".", true},
+		"dry run":         {"gen " + testPkg + " -dry-run", false, "gen", testPkg, ".", false, "", false, false, true},
+		"invalid dry run": {"example " + testPkg + " -dry-run", true, "", "", "", false, "", false, false, false},
 	}
 
 	for k, c := range cases {
@@ -53,6 +74,10 @@ func TestCmdLine(t *testing.T) {
 			cmd = ""
 			path = ""
 			output = ""
+			locale = ""
+			profile = false
+			update = false
+			dryRun = false
 			debug = false
 		}
 
@@ -70,8 +95,130 @@ func TestCmdLine(t *testing.T) {
 		if output != c.ExpectedOutput {
 			t.Errorf("%s: Expected output to be %s but got %s", k, c.ExpectedOutput, output)
 		}
+		if profile != c.ExpectedProfile {
+			t.Errorf("%s: Expected profile to be %v but got %v", k, c.ExpectedProfile, profile)
+		}
+		if update != c.ExpectedUpdate {
+			t.Errorf("%s: Expected update to be %v but got %v", k, c.ExpectedUpdate, update)
+		}
+		if dryRun != c.ExpectedDryRun {
+			t.Errorf("%s: Expected dry run to be %v but got %v", k, c.ExpectedDryRun, dryRun)
+		}
 		if debug != c.ExpectedDebug {
 			t.Errorf("%s: Expected debug to be %v but got %v", k, c.ExpectedDebug, debug)
 		}
+		if locale != c.ExpectedLocale {
+			t.Errorf("%s: Expected locale to be %s but got %s", k, c.ExpectedLocale, locale)
+		}
+	}
+}
+
+func TestDiffCmdLine(t *testing.T) {
+	var (
+		usageCalled      bool
+		oldPath, newPath string
+		jsonOut, debug   bool
+	)
+
+	usage = func() { usageCalled = true }
+	compare = func(o, n string, j, d bool) { oldPath, newPath, jsonOut, debug = o, n, j, d }
+	defer func() {
+		usage = help
+		compare = compareDesigns
+	}()
+
+	cases := map[string]struct {
+		CmdLine         string
+		ExpectedUsage   bool
+		ExpectedOldPath string
+		ExpectedNewPath string
+		ExpectedJSON    bool
+		ExpectedDebug   bool
+	}{
+		"diff":         {"diff /old /new", false, "/old", "/new", false, false},
+		"json":         {"diff /old /new -json", false, "/old", "/new", true, false},
+		"debug":        {"diff /old /new -debug", false, "/old", "/new", false, true},
+		"missing path": {"diff /old", true, "", "", false, false},
+	}
+
+	for k, c := range cases {
+		os.Args = append([]string{"goa"}, strings.Split(c.CmdLine, " ")...)
+		usageCalled = false
+		oldPath, newPath = "", ""
+		jsonOut, debug = false, false
+
+		main()
+
+		if usageCalled != c.ExpectedUsage {
+			t.Errorf("%s: Expected usage to be %v but got %v", k, c.ExpectedUsage, usageCalled)
+		}
+		if oldPath != c.ExpectedOldPath {
+			t.Errorf("%s: Expected old path to be %s but got %s", k, c.ExpectedOldPath, oldPath)
+		}
+		if newPath != c.ExpectedNewPath {
+			t.Errorf("%s: Expected new path to be %s but got %s", k, c.ExpectedNewPath, newPath)
+		}
+		if jsonOut != c.ExpectedJSON {
+			t.Errorf("%s: Expected JSON to be %v but got %v", k, c.ExpectedJSON, jsonOut)
+		}
+		if debug != c.ExpectedDebug {
+			t.Errorf("%s: Expected debug to be %v but got %v", k, c.ExpectedDebug, debug)
+		}
+	}
+}
+
+func TestWatchCmdLine(t *testing.T) {
+	var (
+		usageCalled, genCalled bool
+		path, output           string
+		interval               time.Duration
+	)
+
+	usage = func() { usageCalled = true }
+	gen = func(string, string, string, string, bool, bool, bool, bool) { genCalled = true }
+	watchGen = func(p, o, _ string, i time.Duration, _ bool) { path, output, interval = p, o, i }
+	defer func() {
+		usage = help
+		gen = generate
+		watchGen = watch
+	}()
+
+	cases := map[string]struct {
+		CmdLine          string
+		ExpectedUsage    bool
+		ExpectedPath     string
+		ExpectedOutput   string
+		ExpectedInterval time.Duration
+	}{
+		"watch":            {"gen /test -watch", false, "/test", ".", time.Second},
+		"output":           {"gen /test -watch -o out", false, "/test", "out", time.Second},
+		"interval":         {"gen /test -watch -interval 200ms", false, "/test", ".", 200 * time.Millisecond},
+		"invalid command":  {"example /test -watch", true, "", "", 0},
+		"invalid interval": {"gen /test -watch -interval 0s", true, "", "", 0},
+		"dry run":          {"gen /test -watch -dry-run", true, "", "", 0},
+	}
+
+	for k, c := range cases {
+		os.Args = append([]string{"goa"}, strings.Split(c.CmdLine, " ")...)
+		usageCalled, genCalled = false, false
+		path, output, interval = "", "", 0
+
+		main()
+
+		if usageCalled != c.ExpectedUsage {
+			t.Errorf("%s: Expected usage to be %v but got %v", k, c.ExpectedUsage, usageCalled)
+		}
+		if genCalled {
+			t.Errorf("%s: Expected gen not to be called", k)
+		}
+		if path != c.ExpectedPath {
+			t.Errorf("%s: Expected path to be %s but got %s", k, c.ExpectedPath, path)
+		}
+		if output != c.ExpectedOutput {
+			t.Errorf("%s: Expected output to be %s but got %s", k, c.ExpectedOutput, output)
+		}
+		if interval != c.ExpectedInterval {
+			t.Errorf("%s: Expected interval to be %s but got %s", k, c.ExpectedInterval, interval)
+		}
 	}
 }
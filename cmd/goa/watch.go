@@ -0,0 +1,289 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/build"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/generator"
+)
+
+type (
+	// watcher regenerates the code each time the design changes.
+	watcher struct {
+		// path is the Go import path to the design package.
+		path string
+		// output is the path to the output directory.
+		output string
+		// locale is the locale of the generated descriptions.
+		locale string
+		// debug keeps the generator sources if true.
+		debug bool
+		// state is the path to the file that holds the fingerprints of
+		// the design, see generator.Incremental.
+		state string
+		// out receives the reports.
+		out io.Writer
+	}
+
+	// fileStamp identifies a version of a design source file.
+	fileStamp struct {
+		ModTime time.Time
+		Size    int64
+	}
+
+	// fileChange describes the changes made to a generated file.
+	fileChange struct {
+		// Path is the path to the file.
+		Path string
+		// Kind is "added", "modified" or "deleted".
+		Kind string
+		// Added is the number of added lines.
+		Added int
+		// Deleted is the number of deleted lines.
+		Deleted int
+	}
+)
+
+// watch generates the code for the design package at path then polls the
+// package source files and the template overrides at the given interval and
+// regenerates the code each time they change until interrupted. Only the generators affected by the
+// changes run, see generator.Incremental, and the changes made to the
+// generated files are reported on stdout.
+func watch(path, output, locale string, interval time.Duration, debug bool) {
+	pkg, err := build.Import(path, ".", 0)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	dir, err := ioutil.TempDir("", "goa-watch")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	defer os.RemoveAll(dir)
+	w := &watcher{
+		path:   path,
+		output: output,
+		locale: locale,
+		debug:  debug,
+		state:  filepath.Join(dir, "state.json"),
+		out:    os.Stdout,
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt)
+	defer signal.Stop(sigs)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	generated := watchedFiles(pkg.Dir, output)
+	w.regenerate()
+	fmt.Fprintf(w.out, "watching %s for changes, press Ctrl+C to stop\n", pkg.Dir)
+	last := generated
+	for {
+		select {
+		case <-sigs:
+			return
+		case <-ticker.C:
+			current := watchedFiles(pkg.Dir, output)
+			// Wait for the files to stop changing so that editors
+			// saving multiple files trigger a single generation.
+			if sameFiles(current, last) && !sameFiles(current, generated) {
+				generated = current
+				w.regenerate()
+			}
+			last = current
+		}
+	}
+}
+
+// regenerate runs the "gen" command and reports the changes made to the
+// generated files. Errors are reported on stderr so that the design can be
+// fixed without restarting the watcher.
+func (w *watcher) regenerate() {
+	gendir := filepath.Join(w.output, codegen.Gendir)
+	before := snapshot(gendir)
+	start := time.Now()
+
+	g := NewGenerator("gen", w.path, w.output)
+	g.Locale = w.locale
+	g.State = w.state
+	g.Cache = cacheDir()
+	if !w.debug {
+		defer g.Remove()
+	}
+	err := g.Write(w.debug)
+	if err == nil {
+		err = g.Compile()
+	}
+	if err == nil {
+		_, err = g.Run()
+	}
+	if err != nil {
+		// Regenerate all the files on the next run as the fingerprints
+		// may have been stored without the files being written.
+		os.Remove(w.state)
+		fmt.Fprintln(os.Stderr, err.Error())
+		return
+	}
+
+	var state generator.IncrementalState
+	if b, err := ioutil.ReadFile(w.state); err == nil {
+		json.Unmarshal(b, &state)
+	}
+	report(w.out, state.Changed, diffSnapshots(before, snapshot(gendir)), time.Since(start))
+}
+
+// watchedFiles returns the stamps of the design package source files located
+// in dir and of the template overrides located in the output directory.
+func watchedFiles(dir, output string) map[string]fileStamp {
+	files := designFiles(dir, filepath.Join(output, codegen.Gendir))
+	paths, _ := filepath.Glob(filepath.Join(output, codegen.TemplatesDir, "*.tmpl"))
+	for _, p := range paths {
+		if fi, err := os.Stat(p); err == nil {
+			files[p] = fileStamp{ModTime: fi.ModTime(), Size: fi.Size()}
+		}
+	}
+	return files
+}
+
+// designFiles returns the stamps of the Go source files located in dir and
+// its subdirectories, excluding the gendir directory, hidden directories and
+// testdata directories.
+func designFiles(dir, gendir string) map[string]fileStamp {
+	gendir, _ = filepath.Abs(gendir)
+	files := make(map[string]fileStamp)
+	filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if fi.IsDir() {
+			if path == dir {
+				return nil
+			}
+			abs, _ := filepath.Abs(path)
+			if abs == gendir || fi.Name() == "testdata" || strings.HasPrefix(fi.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) == ".go" {
+			files[path] = fileStamp{ModTime: fi.ModTime(), Size: fi.Size()}
+		}
+		return nil
+	})
+	return files
+}
+
+// sameFiles returns true if a and b contain the same stamps.
+func sameFiles(a, b map[string]fileStamp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, sa := range a {
+		sb, ok := b[path]
+		if !ok || sb.Size != sa.Size || !sb.ModTime.Equal(sa.ModTime) {
+			return false
+		}
+	}
+	return true
+}
+
+// snapshot returns the content of the files located in dir and its
+// subdirectories indexed by path relative to dir.
+func snapshot(dir string) map[string]string {
+	files := make(map[string]string)
+	filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() {
+			return nil
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return nil
+		}
+		files[filepath.ToSlash(rel)] = string(b)
+		return nil
+	})
+	return files
+}
+
+// diffSnapshots returns the changes made to the files of before to produce
+// the files of after sorted by path.
+func diffSnapshots(before, after map[string]string) []*fileChange {
+	var changes []*fileChange
+	for path, content := range after {
+		old, ok := before[path]
+		switch {
+		case !ok:
+			changes = append(changes, &fileChange{Path: path, Kind: "added", Added: countLines(content)})
+		case old != content:
+			added, deleted := diffLines(old, content)
+			changes = append(changes, &fileChange{Path: path, Kind: "modified", Added: added, Deleted: deleted})
+		}
+	}
+	for path, content := range before {
+		if _, ok := after[path]; !ok {
+			changes = append(changes, &fileChange{Path: path, Kind: "deleted", Deleted: countLines(content)})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// diffLines returns the number of lines added and deleted to produce b from a.
+func diffLines(a, b string) (added, deleted int) {
+	dmp := diffmatchpatch.New()
+	ca, cb, lines := dmp.DiffLinesToChars(a, b)
+	for _, d := range dmp.DiffCharsToLines(dmp.DiffMain(ca, cb, false), lines) {
+		switch d.Type {
+		case diffmatchpatch.DiffInsert:
+			added += countLines(d.Text)
+		case diffmatchpatch.DiffDelete:
+			deleted += countLines(d.Text)
+		}
+	}
+	return
+}
+
+// countLines returns the number of lines of s.
+func countLines(s string) int {
+	if s == "" {
+		return 0
+	}
+	n := strings.Count(s, "\n")
+	if !strings.HasSuffix(s, "\n") {
+		n++
+	}
+	return n
+}
+
+// report writes the areas of the design that changed and the changes made to
+// the generated files to w.
+func report(w io.Writer, areas []string, changes []*fileChange, elapsed time.Duration) {
+	if len(areas) == 0 {
+		areas = []string{"none"}
+	}
+	fmt.Fprintf(w, "regenerated in %s (changed: %s)\n", elapsed.Round(time.Millisecond), strings.Join(areas, ", "))
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "  no changes")
+		return
+	}
+	for _, c := range changes {
+		fmt.Fprintf(w, "  %-8s %s (+%d -%d)\n", c.Kind, c.Path, c.Added, c.Deleted)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	before := map[string]string{
+		"http/svc/server/server.go": "a\nb\nc\n",
+		"http/svc/client/client.go": "a\n",
+		"svc/service.go":            "a\nb\n",
+	}
+	after := map[string]string{
+		"http/svc/server/server.go": "a\nB\nc\nd\n",
+		"svc/service.go":            "a\nb\n",
+		"grpc/svc/pb/svc.proto":     "a\nb",
+	}
+	expected := []*fileChange{
+		{Path: "grpc/svc/pb/svc.proto", Kind: "added", Added: 2},
+		{Path: "http/svc/client/client.go", Kind: "deleted", Deleted: 1},
+		{Path: "http/svc/server/server.go", Kind: "modified", Added: 2, Deleted: 1},
+	}
+	changes := diffSnapshots(before, after)
+	if !reflect.DeepEqual(changes, expected) {
+		for _, c := range changes {
+			t.Logf("%+v", *c)
+		}
+		t.Errorf("got %d changes, expected %d", len(changes), len(expected))
+	}
+}
+
+func TestReport(t *testing.T) {
+	cases := map[string]struct {
+		Areas    []string
+		Changes  []*fileChange
+		Expected string
+	}{
+		"no changes": {nil, nil, "regenerated in 1.5s (changed: none)\n  no changes\n"},
+		"changes": {
+			[]string{"http"},
+			[]*fileChange{
+				{Path: "http/svc/server/server.go", Kind: "modified", Added: 2, Deleted: 1},
+				{Path: "http/svc/client/client.go", Kind: "deleted", Deleted: 10},
+			},
+			"regenerated in 1.5s (changed: http)\n" +
+				"  modified http/svc/server/server.go (+2 -1)\n" +
+				"  deleted  http/svc/client/client.go (+0 -10)\n",
+		},
+	}
+	for k, c := range cases {
+		var b bytes.Buffer
+		report(&b, c.Areas, c.Changes, 1500*time.Millisecond)
+		if b.String() != c.Expected {
+			t.Errorf("%s: got\n%s\nexpected\n%s", k, b.String(), c.Expected)
+		}
+	}
+}
+
+func TestDesignFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goa-design")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, p := range []string{"design.go", "types/types.go", "gen/svc/service.go", "testdata/dsl.go", "README.md"} {
+		p = filepath.Join(dir, p)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte("package design\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files := designFiles(dir, filepath.Join(dir, "gen"))
+	if len(files) != 2 {
+		t.Fatalf("got %d files, expected 2: %v", len(files), files)
+	}
+	for _, p := range []string{"design.go", "types/types.go"} {
+		if _, ok := files[filepath.Join(dir, p)]; !ok {
+			t.Errorf("missing %s", p)
+		}
+	}
+	if !sameFiles(files, designFiles(dir, filepath.Join(dir, "gen"))) {
+		t.Error("expected unchanged files to be the same")
+	}
+	p := filepath.Join(dir, "design.go")
+	if err := ioutil.WriteFile(p, []byte("package design\n\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if sameFiles(files, designFiles(dir, filepath.Join(dir, "gen"))) {
+		t.Error("expected modified files to differ")
+	}
+}
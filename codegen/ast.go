@@ -0,0 +1,248 @@
+package codegen
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/imports"
+)
+
+type (
+	// ASTTransform modifies the syntax tree of a generated Go source file
+	// before it is formatted and written. path is the absolute path to the
+	// file. The unused imports are removed after the transforms run so a
+	// transform only needs to add the imports it uses, e.g. with
+	// astutil.AddImport.
+	ASTTransform func(fset *token.FileSet, file *ast.File, path string) error
+
+	// astTransform is a transform registered with RegisterASTTransform.
+	astTransform struct {
+		// name identifies the transform.
+		name string
+		// fn is the transform function.
+		fn ASTTransform
+	}
+)
+
+// astTransforms lists the registered AST transforms in registration order.
+var astTransforms []*astTransform
+
+// generatedRx matches the comment that marks generated Go source files.
+var generatedRx = regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`)
+
+// RegisterASTTransform registers a transform applied to all the generated Go
+// source files before the file specific transforms (see File.Transforms).
+// Transforms run in registration order. The name identifies the transform in
+// the keys of the cached files (see Renderer) and must change when
+// the behavior of the transform does. Plugins typically call
+// RegisterASTTransform in an init function.
+func RegisterASTTransform(name string, t ASTTransform) {
+	astTransforms = append(astTransforms, &astTransform{name: name, fn: t})
+}
+
+// CleanImports removes the duplicate and unused imports from file and sorts
+// the imports.
+func CleanImports(fset *token.FileSet, file *ast.File) {
+	// Remove duplicates first as astutil.DeleteNamedImport deletes all the
+	// matching imports.
+	var (
+		seen  = make(map[string]bool)
+		decls = file.Decls[:0]
+		imps  = file.Imports[:0]
+	)
+	for _, d := range file.Decls {
+		if gd, ok := d.(*ast.GenDecl); ok && gd.Tok == token.IMPORT {
+			specs := gd.Specs[:0]
+			for _, s := range gd.Specs {
+				imp := s.(*ast.ImportSpec)
+				key := imp.Path.Value
+				if imp.Name != nil {
+					key = imp.Name.Name + " " + key
+				}
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				specs = append(specs, s)
+				imps = append(imps, imp)
+			}
+			gd.Specs = specs
+			if len(specs) == 0 {
+				continue
+			}
+		}
+		decls = append(decls, d)
+	}
+	file.Decls = decls
+	file.Imports = imps
+
+	// Clean unused imports
+	for _, group := range astutil.Imports(fset, file) {
+		for _, imp := range group {
+			path := strings.Trim(imp.Path.Value, `"`)
+			if !astutil.UsesImport(file, path) {
+				if imp.Name != nil {
+					astutil.DeleteNamedImport(fset, file, imp.Name.Name, path)
+				} else {
+					astutil.DeleteImport(fset, file, path)
+				}
+			}
+		}
+	}
+	ast.SortImports(fset, file)
+}
+
+// formatGoFile prints file using the goimports standard.
+func formatGoFile(fset *token.FileSet, file *ast.File, path string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, err
+	}
+	opt := imports.Options{
+		Comments:   true,
+		FormatOnly: true,
+	}
+	return imports.Process(path, buf.Bytes(), &opt)
+}
+
+// removeDeadHelpers deletes the unexported functions that are not referenced
+// from the Go packages located in the given directories. Only the directories
+// where all the Go source files are generated are considered so that user
+// code is never modified. The affected files are formatted again.
+func removeDeadHelpers(dirs []string) error {
+	for _, dir := range dirs {
+		if err := removeDeadHelpersInDir(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeDeadHelpersInDir removes the dead helpers of the package located in
+// dir, see removeDeadHelpers.
+func removeDeadHelpersInDir(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil || len(paths) == 0 {
+		return err
+	}
+	sort.Strings(paths)
+	var (
+		fset  = token.NewFileSet()
+		files = make(map[string]*ast.File, len(paths))
+		cmaps = make(map[string]ast.CommentMap, len(paths))
+	)
+	for _, p := range paths {
+		src, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		if !generatedRx.Match(src) {
+			return nil
+		}
+		f, err := parser.ParseFile(fset, p, src, parser.ParseComments)
+		if err != nil {
+			// Leave files that do not parse alone, e.g. templates
+			// with a .go extension.
+			return nil
+		}
+		files[p] = f
+		cmaps[p] = ast.NewCommentMap(fset, f, f.Comments)
+	}
+
+	modified := make(map[string]bool)
+	for {
+		refs := make(map[string]int)
+		for _, f := range files {
+			ast.Inspect(f, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.FuncDecl:
+					// Visit everything but the function name.
+					if n.Recv != nil {
+						ast.Inspect(n.Recv, countRefs(refs))
+					}
+					ast.Inspect(n.Type, countRefs(refs))
+					if n.Body != nil {
+						ast.Inspect(n.Body, countRefs(refs))
+					}
+					return false
+				case *ast.Ident:
+					refs[n.Name]++
+				}
+				return true
+			})
+		}
+		removed := false
+		for _, p := range paths {
+			f := files[p]
+			decls := f.Decls[:0]
+			for _, d := range f.Decls {
+				if fd, ok := d.(*ast.FuncDecl); ok && isDeadHelper(fd, refs) {
+					removed = true
+					modified[p] = true
+					continue
+				}
+				decls = append(decls, d)
+			}
+			f.Decls = decls
+		}
+		if !removed {
+			break
+		}
+	}
+
+	for _, p := range paths {
+		if !modified[p] {
+			continue
+		}
+		f := files[p]
+		// Remove the comments of the deleted functions.
+		f.Comments = cmaps[p].Filter(f).Comments()
+		CleanImports(fset, f)
+		bs, err := formatGoFile(fset, f, p)
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(p, bs, os.ModePerm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// countRefs returns an ast.Inspect visitor that counts the identifiers.
+func countRefs(refs map[string]int) func(ast.Node) bool {
+	return func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			refs[id.Name]++
+		}
+		return true
+	}
+}
+
+// isDeadHelper returns true if fd declares an unexported function that is not
+// referenced.
+func isDeadHelper(fd *ast.FuncDecl, refs map[string]int) bool {
+	name := fd.Name.Name
+	if fd.Recv != nil || ast.IsExported(name) || name == "init" || name == "main" || name == "_" {
+		return false
+	}
+	if fd.Doc != nil {
+		for _, c := range fd.Doc.List {
+			// Keep functions referenced by compiler directives.
+			if strings.HasPrefix(c.Text, "//go:") || strings.HasPrefix(c.Text, "//export ") {
+				return false
+			}
+		}
+	}
+	return refs[name] == 0
+}
@@ -0,0 +1,143 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFinalizeGoSource(t *testing.T) {
+	const (
+		code = `package foo
+
+import (
+	"fmt"
+	str "strings"
+	"fmt"
+	"os"
+)
+
+func foo() { fmt.Println(str.ToUpper("foo")) }
+`
+		expected = `package foo
+
+import (
+	"fmt"
+	str "strings"
+)
+
+func bar() { fmt.Println(str.ToUpper("foo")) }
+`
+	)
+	rename := func(_ *token.FileSet, f *ast.File, _ string) error {
+		for _, d := range f.Decls {
+			if fd, ok := d.(*ast.FuncDecl); ok && fd.Name.Name == "foo" {
+				fd.Name.Name = "bar"
+			}
+		}
+		return nil
+	}
+	tmp := CreateTempFile(t, code)
+	defer os.Remove(tmp)
+	if err := finalizeGoSource(tmp, []ASTTransform{rename}); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(tmp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != expected {
+		t.Errorf("got\n%s\nexpected\n%s", string(b), expected)
+	}
+}
+
+func TestRemoveDeadHelpers(t *testing.T) {
+	const header = "// Code generated by goa, DO NOT EDIT.\n\n"
+	cases := map[string]struct {
+		Files    map[string]string
+		Expected map[string]string
+	}{
+		"generated": {
+			Files: map[string]string{
+				"a.go": header + `package foo
+
+import "strings"
+
+// Exported is kept.
+func Exported() string { return used() }
+
+// used is referenced from Exported.
+func used() string { return "used" }
+
+// unused is removed.
+func unused() string { return chained() }
+
+// chained is only referenced from unused.
+func chained() string { return strings.ToUpper("chained") }
+`,
+				"b.go": header + `package foo
+
+//go:noinline
+func directive() {}
+
+func recursive(n int) int { return recursive(n - 1) }
+
+func viaOtherFile() {}
+`,
+				"c.go": header + `package foo
+
+var _ = viaOtherFile
+`,
+			},
+			Expected: map[string]string{
+				"a.go": header + `package foo
+
+// Exported is kept.
+func Exported() string { return used() }
+
+// used is referenced from Exported.
+func used() string { return "used" }
+`,
+			},
+		},
+		"user code": {
+			Files: map[string]string{
+				"a.go": header + "package foo\n\nfunc unused() {}\n",
+				"b.go": "package foo\n",
+			},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "goa-dead")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			for p, content := range c.Files {
+				if err := ioutil.WriteFile(filepath.Join(dir, p), []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := removeDeadHelpers([]string{dir}); err != nil {
+				t.Fatal(err)
+			}
+			for p, content := range c.Files {
+				expected, ok := c.Expected[p]
+				if !ok {
+					expected = content
+				}
+				b, err := ioutil.ReadFile(filepath.Join(dir, p))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(b) != expected {
+					t.Errorf("%s: got\n%s\nexpected\n%s", p, string(b), expected)
+				}
+			}
+		})
+	}
+}
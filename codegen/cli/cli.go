@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -24,6 +25,9 @@ type (
 		// VarName is the name of the command variable e.g.
 		// "cellarStorage"
 		VarName string
+		// Aliases lists the alternative names of the command defined
+		// with the "cli:alias" meta.
+		Aliases []string
 		// Description is the help text.
 		Description string
 		// Subcommands is the list of endpoint commands.
@@ -42,6 +46,9 @@ type (
 		Name string
 		// FullName is the sub-command full name e.g. "storageAdd"
 		FullName string
+		// Aliases lists the alternative names of the sub-command defined
+		// with the "cli:alias" meta.
+		Aliases []string
 		// Description is the help text.
 		Description string
 		// Flags is the list of flags supported by the subcommand.
@@ -56,6 +63,55 @@ type (
 		Conversion string
 		// Example is a valid command invocation, starting with the command name.
 		Example string
+		// NamedExamples lists the named examples that can be selected
+		// with the -example flag sorted by name.
+		NamedExamples []*NamedExampleData
+		// Columns lists the columns of the table output of the method
+		// results.
+		Columns []*ColumnData
+		// Stream describes the client stream returned by the endpoint if
+		// the method is a streaming method, nil otherwise. The transport
+		// specific code generators initialize it.
+		Stream *StreamData
+	}
+
+	// StreamData contains the data needed to render the code that runs the
+	// client stream returned by a streaming endpoint.
+	StreamData struct {
+		// TypeRef is the reference to the client stream struct type
+		// returned by the endpoint, e.g. "*chatterc.EchoClientStream".
+		TypeRef string
+		// SendTypeRef is the fully qualified reference to the type of the
+		// values sent to the stream, empty if the method does not stream
+		// its payload.
+		SendTypeRef string
+		// RecvName is the name of the function that receives the results,
+		// "Recv" if the method streams its results, "CloseAndRecv" if it
+		// streams its payload and returns a single result and empty
+		// otherwise.
+		RecvName string
+		// MustClose is true if the client stream implements Close.
+		MustClose bool
+		// HalfClose is true if Close only closes the sending side of the
+		// stream.
+		HalfClose bool
+	}
+
+	// ColumnData describes a column of the table output of the results.
+	ColumnData struct {
+		// Header is the column header, e.g. "VINTAGE".
+		Header string
+		// Field is the name of the result field rendered in the column,
+		// e.g. "Vintage".
+		Field string
+	}
+
+	// NamedExampleData contains the flag values of a named example.
+	NamedExampleData struct {
+		// Name is the name of the example.
+		Name string
+		// Flags contains the values of the example indexed by flag name.
+		Flags map[string]string
 	}
 
 	// FlagData contains the data needed to render a command-line flag.
@@ -74,6 +130,33 @@ type (
 		Required bool
 		// Example returns a JSON serialized example value.
 		Example string
+		// NamedExamples contains the values of the named examples indexed
+		// by example name, see SetNamedExamples.
+		NamedExamples map[string]string
+		// Credential describes the credential used to initialize the
+		// flag when it is not given explicitly if any, see
+		// SetCredential.
+		Credential *CredentialData
+	}
+
+	// CredentialData describes the stored credential used to initialize a
+	// security flag.
+	CredentialData struct {
+		// Name is the name of the credential: the name of the security
+		// scheme or the name of the basic auth scheme followed by
+		// ".username" or ".password".
+		Name string
+		// Kind is the kind of security scheme, one of "Basic",
+		// "APIKey", "JWT" or "OAuth2".
+		Kind string
+		// TokenURL is the URL of the OAuth2 token endpoint used to
+		// refresh the access tokens if any.
+		TokenURL string
+		// ClientCredentials is true if the OAuth2 scheme supports the
+		// client credentials flow.
+		ClientCredentials bool
+		// Scopes lists the scopes required by the method.
+		Scopes []string
 	}
 
 	// BuildFunctionData contains the data needed to generate a constructor
@@ -159,9 +242,14 @@ func BuildCommandData(data *service.Data) *CommandData {
 	if description == "" {
 		description = fmt.Sprintf("Make requests to the %q service", data.Name)
 	}
+	var aliases []string
+	if svc := expr.Root.Service(data.Name); svc != nil {
+		aliases = svc.Meta["cli:alias"]
+	}
 	return &CommandData{
 		Name:        codegen.KebabCase(data.Name),
 		VarName:     codegen.Goify(data.Name, false),
+		Aliases:     aliases,
 		Description: description,
 		PkgName:     data.PkgName + "c",
 	}
@@ -216,16 +304,29 @@ func BuildSubcommandData(svcName string, m *service.MethodData, buildFunction *B
 			}
 		}
 	}
+	var (
+		aliases []string
+		columns []*ColumnData
+	)
+	if svc := expr.Root.Service(svcName); svc != nil {
+		if me := svc.Method(m.Name); me != nil {
+			aliases = me.Meta["cli:alias"]
+			columns = resultColumns(me.Result)
+		}
+	}
 	sub := &SubcommandData{
 		Name:          name,
 		FullName:      fullName,
+		Aliases:       aliases,
 		Description:   description,
 		Flags:         flags,
 		MethodVarName: m.VarName,
 		BuildFunction: buildFunction,
 		Conversion:    conversion,
+		Columns:       columns,
 	}
 	generateExample(sub, svcName)
+	sub.NamedExamples = namedExamples(flags)
 
 	return sub
 }
@@ -272,7 +373,14 @@ func FlagsCode(data []*CommandData) string {
 		Name:    "parse-endpoint-flags",
 		Source:  parseFlagsT,
 		Data:    data,
-		FuncMap: map[string]interface{}{"printDescription": printDescription},
+		FuncMap: map[string]interface{}{
+			"printDescription": printDescription,
+			"exampleNames":     exampleNames,
+			"hasNamedExamples": hasNamedExamples,
+			"hasCredentials":   HasCredentials,
+			"credentialFlags":  credentialFlags,
+			"apiName":          func() string { return APIName(expr.Root.API) },
+		},
 	}
 	var flagsCode bytes.Buffer
 	err := section.Write(&flagsCode)
@@ -290,10 +398,55 @@ func CommandUsage(data *CommandData) *codegen.SectionTemplate {
 		Name:    "cli-command-usage",
 		Source:  commandUsageT,
 		Data:    data,
-		FuncMap: map[string]interface{}{"printDescription": printDescription},
+		FuncMap: map[string]interface{}{
+			"printDescription": printDescription,
+			"exampleNames":     exampleNames,
+			"join":             strings.Join,
+		},
+	}
+}
+
+// Commands builds the section template that generates the Commands function
+// which describes the commands, sub-commands and flags of the CLI tool. The
+// example clients use the description to generate the shell completion
+// scripts and to render the results as tables.
+func Commands(data []*CommandData) *codegen.SectionTemplate {
+	return &codegen.SectionTemplate{
+		Name:    "cli-commands",
+		Source:  commandsT,
+		Data:    data,
+		FuncMap: map[string]interface{}{"quoteList": quoteList},
 	}
 }
 
+// Stream builds the section template that generates the Stream function which
+// adapts the client streams returned by the streaming endpoints so that the
+// example clients can run them.
+func Stream(data []*CommandData) *codegen.SectionTemplate {
+	hasStream := false
+	for _, cmd := range data {
+		for _, sub := range cmd.Subcommands {
+			if sub.Stream != nil {
+				hasStream = true
+			}
+		}
+	}
+	return &codegen.SectionTemplate{
+		Name:   "cli-stream",
+		Source: streamT,
+		Data: map[string]interface{}{
+			"Commands":  data,
+			"HasStream": hasStream,
+		},
+	}
+}
+
+// APIName returns the name of the API used to name the environment variables,
+// the configuration file and the credentials of the CLI tool, e.g. "cellar".
+func APIName(api *expr.APIExpr) string {
+	return codegen.SnakeCase(codegen.Goify(api.Name, true))
+}
+
 // PayloadBuilderSection builds the section template that can be used to
 // generate the payload builder code.
 func PayloadBuilderSection(buildFunction *BuildFunctionData) *codegen.SectionTemplate {
@@ -328,6 +481,19 @@ func NewFlagData(svcn, en, name, typeName, description string, required bool, ex
 	}
 }
 
+// SetNamedExamples sets the values of the flag for the named examples defined
+// in the design. examples maps the example names to the corresponding
+// argument values.
+func (f *FlagData) SetNamedExamples(examples map[string]interface{}) {
+	if len(examples) == 0 {
+		return
+	}
+	f.NamedExamples = make(map[string]string, len(examples))
+	for n, v := range examples {
+		f.NamedExamples[n] = flagValue(f.Type, v)
+	}
+}
+
 // FieldLoadCode returns the code used in the build payload function that
 // initializes one of the payload object fields. It returns the initialization
 // code and a boolean indicating whether the code requires an "err" variable.
@@ -383,8 +549,39 @@ func flagType(tname string) string {
 	}
 }
 
+// flagValue returns the string that sets a flag of the given type to v.
+// Contrary to jsonExample the string is not meant to be processed by a shell.
+func flagValue(typ string, v interface{}) string {
+	if typ != "JSON" {
+		switch actual := v.(type) {
+		case string:
+			return actual
+		case []byte:
+			return string(actual)
+		}
+	}
+	b, err := json.Marshal(stringKeys(v))
+	if err != nil {
+		return "?"
+	}
+	return string(b)
+}
+
 // jsonExample generates a json example
 func jsonExample(v interface{}) string {
+	b, err := json.MarshalIndent(stringKeys(v), "   ", "   ")
+	ex := "?"
+	if err == nil {
+		ex = string(b)
+	}
+	if strings.Contains(ex, "\n") {
+		ex = "'" + strings.Replace(ex, "'", "\\'", -1) + "'"
+	}
+	return ex
+}
+
+// stringKeys returns v with the keys converted to strings if v is a map.
+func stringKeys(v interface{}) interface{} {
 	// In JSON, keys must be a string. But goa allows map keys to be anything.
 	r := reflect.ValueOf(v)
 	if r.Kind() == reflect.Map {
@@ -414,15 +611,7 @@ func jsonExample(v interface{}) string {
 			v = a
 		}
 	}
-	b, err := json.MarshalIndent(v, "   ", "   ")
-	ex := "?"
-	if err == nil {
-		ex = string(b)
-	}
-	if strings.Contains(ex, "\n") {
-		ex = "'" + strings.Replace(ex, "'", "\\'", -1) + "'"
-	}
-	return ex
+	return v
 }
 
 var (
@@ -540,6 +729,192 @@ func generateExample(sub *SubcommandData, svc string) {
 	sub.Example = ex
 }
 
+// SetCredential sets the credential used to initialize the flag if the flag
+// sets the payload field with the given name and the field holds the
+// credentials of one of the security schemes of the method.
+func (f *FlagData) SetCredential(m *service.MethodData, field string) {
+	if field == "" {
+		return
+	}
+	for _, req := range m.Requirements {
+		for _, sch := range req.Schemes {
+			var name string
+			switch {
+			case sch.Type == "Basic" && sch.UsernameField == field:
+				name = sch.SchemeName + ".username"
+			case sch.Type == "Basic" && sch.PasswordField == field:
+				name = sch.SchemeName + ".password"
+			case (sch.Type == "APIKey" || sch.Type == "JWT" || sch.Type == "OAuth2") && sch.CredField == field:
+				name = sch.SchemeName
+			default:
+				continue
+			}
+			f.Credential = NewCredentialData(name, sch, req.Scopes)
+			return
+		}
+	}
+}
+
+// NewCredentialData returns the data describing the credential with the given
+// name of the security scheme sch required with the given scopes.
+func NewCredentialData(name string, sch *service.SchemeData, scopes []string) *CredentialData {
+	c := &CredentialData{Name: name, Kind: sch.Type, Scopes: scopes}
+	for _, flow := range sch.Flows {
+		u := flow.RefreshURL
+		if u == "" {
+			u = flow.TokenURL
+		}
+		if u == "" {
+			continue
+		}
+		if flow.Kind == expr.ClientCredentialsFlowKind {
+			c.TokenURL = u
+			c.ClientCredentials = true
+			break
+		}
+		if c.TokenURL == "" {
+			c.TokenURL = u
+		}
+	}
+	return c
+}
+
+// HasCredentials returns true if any of the flags of the given commands is
+// initialized with a stored credential. The ParseEndpoint functions generated
+// by the transports must define the creds variable of type
+// *credentials.Profile in this case.
+func HasCredentials(data []*CommandData) bool {
+	for _, cmd := range data {
+		for _, sub := range cmd.Subcommands {
+			if len(credentialFlags(sub)) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// credentialFlags returns the flags of the sub-command that are initialized
+// with stored credentials.
+func credentialFlags(sub *SubcommandData) []*FlagData {
+	var flags []*FlagData
+	for _, f := range sub.Flags {
+		if f.Credential != nil {
+			flags = append(flags, f)
+		}
+	}
+	return flags
+}
+
+// namedExamples returns the named examples defined by the given flags sorted
+// by name. It returns nil if one of the flags is already named "example".
+func namedExamples(flags []*FlagData) []*NamedExampleData {
+	idx := make(map[string]*NamedExampleData)
+	for _, f := range flags {
+		if f.Name == "example" {
+			return nil
+		}
+		for n, v := range f.NamedExamples {
+			ex, ok := idx[n]
+			if !ok {
+				ex = &NamedExampleData{Name: n, Flags: make(map[string]string)}
+				idx[n] = ex
+			}
+			ex.Flags[f.Name] = v
+		}
+	}
+	if len(idx) == 0 {
+		return nil
+	}
+	res := make([]*NamedExampleData, 0, len(idx))
+	for _, ex := range idx {
+		res = append(res, ex)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+// resultColumns returns the columns of the table output of the given result:
+// the attributes of the result view defined with the "view" meta or of the
+// default view if the result is a result type, the attributes of the result
+// otherwise. The columns of collections and arrays are the attributes of their
+// elements.
+func resultColumns(result *expr.AttributeExpr) []*ColumnData {
+	if result == nil {
+		return nil
+	}
+	att := result
+	view := expr.DefaultView
+	if v, ok := result.Meta["view"]; ok && len(v) > 0 {
+		view = v[0]
+	}
+	if arr := expr.AsArray(att.Type); arr != nil {
+		att = arr.ElemType
+	}
+	obj := expr.AsObject(att.Type)
+	if obj == nil {
+		return nil
+	}
+	names := make([]string, len(*obj))
+	for i, nat := range *obj {
+		names[i] = nat.Name
+	}
+	if rt, ok := att.Type.(*expr.ResultTypeExpr); ok {
+		if v := rt.View(view); v != nil {
+			if vobj := expr.AsObject(v.Type); vobj != nil {
+				names = names[:0]
+				for _, nat := range *vobj {
+					names = append(names, nat.Name)
+				}
+			}
+		}
+	}
+	var columns []*ColumnData
+	for _, n := range names {
+		a := obj.Attribute(n)
+		if a == nil {
+			continue
+		}
+		columns = append(columns, &ColumnData{
+			Header: strings.ToUpper(strings.Replace(n, "_", " ", -1)),
+			Field:  codegen.GoifyAtt(a, n, true),
+		})
+	}
+	return columns
+}
+
+// quoteList returns the Go string literals of the given values separated with
+// commas.
+func quoteList(vals []string) string {
+	quoted := make([]string, len(vals))
+	for i, v := range vals {
+		quoted[i] = strconv.Quote(v)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// exampleNames returns the names of the given examples separated with commas.
+func exampleNames(examples []*NamedExampleData) string {
+	names := make([]string, len(examples))
+	for i, ex := range examples {
+		names[i] = ex.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// hasNamedExamples returns true if any of the sub-commands of the given
+// commands define named examples.
+func hasNamedExamples(data []*CommandData) bool {
+	for _, cmd := range data {
+		for _, sub := range cmd.Subcommands {
+			if len(sub.NamedExamples) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // input: []string
 const usageT = `// UsageCommands returns the set of commands and sub-commands using the format
 //
@@ -569,6 +944,9 @@ const parseFlagsT = `var (
 		{{- range .Flags }}
 		{{ .FullName }}Flag = {{ $sub.FullName }}Flags.String("{{ .Name }}", "{{ if .Required }}REQUIRED{{ end }}", {{ printf "%q" .Description }})
 		{{- end }}
+		{{- if .NamedExamples }}
+		{{ .FullName }}ExampleFlag = {{ .FullName }}Flags.String("example", "", {{ printf "%q" (printf "Name of example used to set the flags not given explicitly, one of %s" (exampleNames .NamedExamples)) }})
+		{{- end }}
 		{{ end }}
 		{{- end }}
 	)
@@ -595,7 +973,10 @@ const parseFlagsT = `var (
 		svcn = flag.Arg(0)
 		switch svcn {
 	{{- range . }}
-		case "{{ .Name }}":
+		case "{{ .Name }}"{{ range .Aliases }}, {{ printf "%q" . }}{{ end }}:
+			{{- if .Aliases }}
+			svcn = "{{ .Name }}"
+			{{- end }}
 			svcf = {{ .VarName }}Flags
 	{{- end }}
 		default:
@@ -617,7 +998,10 @@ const parseFlagsT = `var (
 		case "{{ .Name }}":
 			switch epn {
 		{{- range .Subcommands }}
-			case "{{ .Name }}":
+			case "{{ .Name }}"{{ range .Aliases }}, {{ printf "%q" . }}{{ end }}:
+			{{- if .Aliases }}
+				epn = "{{ .Name }}"
+			{{- end }}
 				epf = {{ .FullName }}Flags
 		{{ end }}
 			}
@@ -634,6 +1018,104 @@ const parseFlagsT = `var (
 			return nil, nil, err
 		}
 	}
+
+	// Set the flags not given explicitly to the values of the environment
+	// variables or of the configuration file if any.
+	{
+		defaults, err := goacli.NewDefaults({{ printf "%q" apiName }}, flag.CommandLine)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := defaults.Apply(epf, svcn, epn); err != nil {
+			return nil, nil, err
+		}
+	}
+	{{- if hasCredentials . }}
+
+	// Set the security flags not given explicitly to the credentials
+	// stored in the profile if any.
+	if creds != nil {
+		var cs map[string]*credentials.Credential
+		switch epf {
+	{{- range . }}
+		{{- range .Subcommands }}
+			{{- if credentialFlags . }}
+		case {{ .FullName }}Flags:
+			cs = map[string]*credentials.Credential{
+				{{- range credentialFlags . }}
+				{{ printf "%q" .Name }}: {Name: {{ printf "%q" .Credential.Name }}, Kind: {{ printf "%q" .Credential.Kind }}
+					{{- if .Credential.TokenURL }}, TokenURL: {{ printf "%q" .Credential.TokenURL }}{{ end }}
+					{{- if .Credential.ClientCredentials }}, ClientCredentials: true{{ end }}
+					{{- if .Credential.Scopes }}, Scopes: []string{ {{- range $i, $s := .Credential.Scopes }}{{ if $i }}, {{ end }}{{ printf "%q" $s }}{{ end }}}{{ end }}},
+				{{- end }}
+			}
+			{{- end }}
+		{{- end }}
+	{{- end }}
+		}
+		set := make(map[string]bool)
+		epf.Visit(func(f *flag.Flag) { set[f.Name] = true })
+		for name, c := range cs {
+			if set[name] {
+				continue
+			}
+			val, err := creds.Value(context.Background(), c)
+			if err != nil {
+				return nil, nil, err
+			}
+			if val != "" {
+				if err := epf.Set(name, val); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+	}
+	{{- end }}
+	{{- if hasNamedExamples . }}
+
+	// Set the flags not given explicitly to the values of the example
+	// selected with -example if any.
+	{
+		var (
+			exn string
+			exs map[string]map[string]string
+		)
+		switch epf {
+	{{- range . }}
+		{{- range .Subcommands }}
+			{{- if .NamedExamples }}
+		case {{ .FullName }}Flags:
+			exn = *{{ .FullName }}ExampleFlag
+			exs = map[string]map[string]string{
+				{{- range .NamedExamples }}
+				{{ printf "%q" .Name }}: {
+					{{- range $name, $val := .Flags }}
+					{{ printf "%q" $name }}: {{ printf "%q" $val }},
+					{{- end }}
+				},
+				{{- end }}
+			}
+			{{- end }}
+		{{- end }}
+	{{- end }}
+		}
+		if exn != "" {
+			ex, ok := exs[exn]
+			if !ok {
+				return nil, nil, fmt.Errorf("unknown example %q for %q endpoint %q", exn, svcn, epn)
+			}
+			set := make(map[string]bool)
+			epf.Visit(func(f *flag.Flag) { set[f.Name] = true })
+			for name, val := range ex {
+				if !set[name] {
+					if err := epf.Set(name, val); err != nil {
+						return nil, nil, err
+					}
+				}
+			}
+		}
+	}
+	{{- end }}
 `
 
 // input: commandData
@@ -642,10 +1124,15 @@ func {{ .VarName }}Usage() {
 	fmt.Fprintf(os.Stderr, ` + "`" + `{{ printDescription .Description }}
 Usage:
     %s [globalflags] {{ .Name }} COMMAND [flags]
+{{- if .Aliases }}
+
+Aliases:
+    {{ join .Aliases ", " }}
+{{- end }}
 
 COMMAND:
     {{- range .Subcommands }}
-    {{ .Name }}: {{ printDescription .Description }}
+    {{ .Name }}{{ if .Aliases }} ({{ join .Aliases ", " }}){{ end }}: {{ printDescription .Description }}
     {{- end }}
 
 Additional help:
@@ -655,12 +1142,15 @@ Additional help:
 
 {{- range .Subcommands }}
 func {{ .FullName }}Usage() {
-	fmt.Fprintf(os.Stderr, ` + "`" + `%s [flags] {{ $.Name }} {{ .Name }}{{range .Flags }} -{{ .Name }} {{ .Type }}{{ end }}
+	fmt.Fprintf(os.Stderr, ` + "`" + `%s [flags] {{ $.Name }} {{ .Name }}{{range .Flags }} -{{ .Name }} {{ .Type }}{{ end }}{{ if .NamedExamples }} -example STRING{{ end }}
 
 {{ printDescription .Description}}
 	{{- range .Flags }}
     -{{ .Name }} {{ .Type }}: {{ .Description }}
 	{{- end }}
+	{{- if .NamedExamples }}
+    -example STRING: Name of example used to set the flags not given explicitly, one of {{ exampleNames .NamedExamples }}
+	{{- end }}
 
 Example:
     ` + "`+os.Args[0]+" + "`" + ` {{ .Example }}
@@ -718,3 +1208,91 @@ func {{ .Name }}({{ range .FormalParams }}{{ . }} string, {{ end }}) ({{ .Result
 	{{- end }}
 }
 `
+
+// input: []*CommandData
+const commandsT = `// Commands returns the description of the commands, sub-commands and flags of
+// the CLI tool used to generate the shell completion scripts and the table
+// output of the results.
+func Commands() []*goacli.Command {
+	return []*goacli.Command{
+	{{- range . }}
+		{
+			Name: {{ printf "%q" .Name }},
+			{{- if .Aliases }}
+			Aliases: []string{ {{ quoteList .Aliases }} },
+			{{- end }}
+			Description: {{ printf "%q" .Description }},
+			Subcommands: []*goacli.Command{
+			{{- range .Subcommands }}
+				{
+					Name: {{ printf "%q" .Name }},
+					{{- if .Aliases }}
+					Aliases: []string{ {{ quoteList .Aliases }} },
+					{{- end }}
+					Description: {{ printf "%q" .Description }},
+					{{- if or .Flags .NamedExamples }}
+					Flags: []*goacli.Flag{
+					{{- range .Flags }}
+						{Name: {{ printf "%q" .Name }}, Description: {{ printf "%q" .Description }}},
+					{{- end }}
+					{{- if .NamedExamples }}
+						{Name: "example", Description: "Name of example used to set the flags not given explicitly"},
+					{{- end }}
+					},
+					{{- end }}
+					{{- if .Columns }}
+					Columns: []*goacli.Column{
+					{{- range .Columns }}
+						{Header: {{ printf "%q" .Header }}, Field: {{ printf "%q" .Field }}},
+					{{- end }}
+					},
+					{{- end }}
+				},
+			{{- end }}
+			},
+		},
+	{{- end }}
+	}
+}
+`
+
+// input: map[string]interface{}{"Commands": []*CommandData, "HasStream": bool}
+const streamT = `// Stream returns the client stream returned by a streaming endpoint adapted to
+// be run by the CLI tool, nil if data is not a client stream.
+func Stream(data interface{}) *goacli.Stream {
+{{- if .HasStream }}
+	switch stream := data.(type) {
+	{{- range .Commands }}
+		{{- range .Subcommands }}
+			{{- if .Stream }}
+	case {{ .Stream.TypeRef }}:
+		return &goacli.Stream{
+				{{- if .Stream.SendTypeRef }}
+			Send: func(data []byte) error {
+				var v {{ .Stream.SendTypeRef }}
+				if err := json.Unmarshal(data, &v); err != nil {
+					return err
+				}
+				return stream.Send(v)
+			},
+				{{- end }}
+				{{- if eq .Stream.RecvName "Recv" }}
+			Recv: func() (interface{}, error) { return stream.Recv() },
+				{{- end }}
+				{{- if eq .Stream.RecvName "CloseAndRecv" }}
+			Close: func() (interface{}, error) { return stream.CloseAndRecv() },
+				{{- else if .Stream.MustClose }}
+			Close: func() (interface{}, error) { return nil, stream.Close() },
+				{{- end }}
+				{{- if .Stream.HalfClose }}
+			HalfClose: true,
+				{{- end }}
+		}
+			{{- end }}
+		{{- end }}
+	{{- end }}
+	}
+{{- end }}
+	return nil
+}
+`
@@ -0,0 +1,54 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/token"
+	"path"
+	"strconv"
+)
+
+// CompatMeta is the key of the API meta that sets the version of the
+// compatibility layer targeted by the generated code, see package
+// goa.design/goa/v3/compat.
+const CompatMeta = "goa:compat"
+
+// compatPackages maps the import paths of the goa runtime packages to the
+// names of the corresponding compatibility layer packages.
+var compatPackages = map[string]string{
+	"goa.design/goa/v3/pkg":      "goa",
+	"goa.design/goa/v3/http":     "http",
+	"goa.design/goa/v3/grpc":     "grpc",
+	"goa.design/goa/v3/security": "security",
+	"goa.design/goa/v3/cli":      "cli",
+}
+
+// CompatImportPath returns the import path of the package of the given
+// compatibility layer version that replaces the goa runtime package with the
+// given import path, an empty string if the package has no replacement.
+func CompatImportPath(version, runtime string) string {
+	name, ok := compatPackages[runtime]
+	if !ok {
+		return ""
+	}
+	return path.Join("goa.design/goa/v3/compat", version, name)
+}
+
+// CompatTransform returns the AST transform that replaces the imports of the
+// goa runtime packages with the imports of the packages of the given version
+// of the compatibility layer. The layer packages have the same names and
+// export the same identifiers as the runtime packages so that the rest of the
+// generated code is unchanged.
+func CompatTransform(version string) ASTTransform {
+	return func(_ *token.FileSet, file *ast.File, _ string) error {
+		for _, imp := range file.Imports {
+			p, err := strconv.Unquote(imp.Path.Value)
+			if err != nil {
+				continue
+			}
+			if cp := CompatImportPath(version, p); cp != "" {
+				imp.Path.Value = strconv.Quote(cp)
+			}
+		}
+		return nil
+	}
+}
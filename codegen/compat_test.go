@@ -0,0 +1,51 @@
+package codegen
+
+import (
+	"bytes"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestCompatTransform(t *testing.T) {
+	const (
+		src = `package foo
+
+import (
+	"context"
+
+	goa "goa.design/goa/v3/pkg"
+	goahttp "goa.design/goa/v3/http"
+	"goa.design/goa/v3/security"
+	"goa.design/goa/v3/security/credentials"
+)
+`
+		expected = `package foo
+
+import (
+	"context"
+
+	goa "goa.design/goa/v3/compat/v1/goa"
+	goahttp "goa.design/goa/v3/compat/v1/http"
+	"goa.design/goa/v3/compat/v1/security"
+	"goa.design/goa/v3/security/credentials"
+)
+`
+	)
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "foo.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := CompatTransform("v1")(fset, f, "foo.go"); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, f); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != expected {
+		t.Errorf("got\n%s\nexpected\n%s", buf.String(), expected)
+	}
+}
@@ -0,0 +1,307 @@
+package deploy
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/example"
+	"goa.design/goa/v3/expr"
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// deploymentData contains the data used to render the deployment
+	// artifacts of a server.
+	deploymentData struct {
+		// Server is the server name.
+		Server string
+		// Name is the name of the Kubernetes resources and of the
+		// container.
+		Name string
+		// Binary is the name of the server executable.
+		Binary string
+		// Cmd is the path to the server main package relative to the
+		// module root.
+		Cmd string
+		// Dockerfile is the path to the Dockerfile relative to the
+		// module root.
+		Dockerfile string
+		// Manifests is the path to the Kubernetes manifests relative to
+		// the module root.
+		Manifests string
+		// Image is the name of the container image.
+		Image string
+		// Replicas is the number of instances of the server.
+		Replicas int
+		// Args lists the arguments given to the server executable.
+		Args []string
+		// Ports lists the ports listened to by the server.
+		Ports []*portData
+		// Env lists the environment variables set in the container.
+		Env []*expr.EnvVarExpr
+		// Requests lists the resources requested by the container.
+		Requests []*resourceData
+		// Limits lists the resource limits of the container.
+		Limits []*resourceData
+		// LivenessPath is the path of the HTTP liveness endpoint if any.
+		LivenessPath string
+		// ReadinessPath is the path of the HTTP readiness endpoint if
+		// any.
+		ReadinessPath string
+		// ToolVersion is the version of goa used to generate the
+		// artifacts.
+		ToolVersion string
+	}
+
+	// portData describes the port of a transport.
+	portData struct {
+		// Name is the port name, the transport name.
+		Name string
+		// Port is the port number.
+		Port string
+	}
+
+	// resourceData describes a resource quantity.
+	resourceData struct {
+		// Name is the resource name, "cpu" or "memory".
+		Name string
+		// Quantity is the resource quantity.
+		Quantity string
+	}
+)
+
+// Files returns the Dockerfile and the Kubernetes manifests of the servers that
+// define deployment hints with Deployment. The files are generated in the
+// gen/deploy/<server> directory. The container image runs the server main
+// generated by the "example" command and listens to the ports of the URIs of
+// the server default host unless overridden in the design.
+func Files(genpkg string, root *expr.RootExpr) []*codegen.File {
+	if root.API == nil {
+		return nil
+	}
+	var fw []*codegen.File
+	for _, svr := range root.API.Servers {
+		if svr.Deployment == nil {
+			continue
+		}
+		data := buildDeploymentData(svr, root.API.HealthCheck)
+		dir := filepath.Join(codegen.Gendir, "deploy", data.Binary)
+		fw = append(fw,
+			&codegen.File{
+				Path: filepath.Join(dir, "Dockerfile"),
+				SectionTemplates: []*codegen.SectionTemplate{
+					{Name: "deploy-header", Source: headerT, Data: data},
+					{Name: "deploy-dockerfile", Source: dockerfileT, Data: data},
+				},
+			},
+			&codegen.File{
+				Path: filepath.Join(dir, "kubernetes.yaml"),
+				SectionTemplates: []*codegen.SectionTemplate{
+					{Name: "deploy-header", Source: headerT, Data: data},
+					{Name: "deploy-kubernetes", Source: kubernetesT, Data: data},
+				},
+			},
+		)
+	}
+	return fw
+}
+
+// buildDeploymentData builds the data needed to render the deployment
+// artifacts of the given server.
+func buildDeploymentData(svr *expr.ServerExpr, health *expr.HealthCheckExpr) *deploymentData {
+	var (
+		sd   = example.Servers.Get(svr)
+		d    = svr.Deployment
+		name = strings.Replace(sd.Dir, "_", "-", -1)
+	)
+	data := &deploymentData{
+		Server:      svr.Name,
+		Name:        name,
+		Binary:      sd.Dir,
+		Cmd:         "./cmd/" + sd.Dir,
+		Dockerfile:  filepath.ToSlash(filepath.Join(codegen.Gendir, "deploy", sd.Dir, "Dockerfile")),
+		Manifests:   filepath.ToSlash(filepath.Join(codegen.Gendir, "deploy", sd.Dir, "kubernetes.yaml")),
+		Image:       d.Image,
+		Replicas:    d.Replicas,
+		Args:        []string{"-domain", "0.0.0.0"},
+		Env:         d.Env,
+		ToolVersion: goa.Version(),
+	}
+	if data.Image == "" {
+		data.Image = name
+	}
+	if data.Replicas == 0 {
+		data.Replicas = 1
+	}
+	host := sd.DefaultHost()
+	hasHTTP := false
+	for _, t := range sd.Transports {
+		port := ""
+		if p := d.Port(string(t.Type)); p > 0 {
+			port = strconv.Itoa(p)
+		} else if host != nil {
+			for _, u := range host.URIs {
+				if u.Transport.Type == t.Type {
+					port = uriPort(u)
+					break
+				}
+			}
+		}
+		if port == "" {
+			continue
+		}
+		if t.Type == example.TransportHTTP {
+			hasHTTP = true
+		}
+		data.Args = append(data.Args, "-"+string(t.Type)+"-port", port)
+		data.Ports = append(data.Ports, &portData{Name: string(t.Type), Port: port})
+	}
+	if health != nil && hasHTTP {
+		data.LivenessPath = health.LivenessPath
+		data.ReadinessPath = health.ReadinessPath
+	}
+	if d.CPURequest != "" {
+		data.Requests = append(data.Requests, &resourceData{Name: "cpu", Quantity: d.CPURequest})
+	}
+	if d.MemoryRequest != "" {
+		data.Requests = append(data.Requests, &resourceData{Name: "memory", Quantity: d.MemoryRequest})
+	}
+	if d.CPULimit != "" {
+		data.Limits = append(data.Limits, &resourceData{Name: "cpu", Quantity: d.CPULimit})
+	}
+	if d.MemoryLimit != "" {
+		data.Limits = append(data.Limits, &resourceData{Name: "memory", Quantity: d.MemoryLimit})
+	}
+	return data
+}
+
+// uriPort returns the port of the given URI, the default port of the URI
+// scheme if the URI does not specify one.
+func uriPort(u *example.URIData) string {
+	hostport := u.URL
+	if i := strings.Index(hostport, "://"); i >= 0 {
+		hostport = hostport[i+3:]
+	}
+	if i := strings.Index(hostport, "/"); i >= 0 {
+		hostport = hostport[:i]
+	}
+	if i := strings.LastIndex(hostport, ":"); i >= 0 && !strings.Contains(hostport[i:], "]") {
+		if _, err := strconv.Atoi(hostport[i+1:]); err == nil {
+			return hostport[i+1:]
+		}
+	}
+	return u.Port
+}
+
+// input: deploymentData
+const headerT = `# Code generated by goa {{ .ToolVersion }}, DO NOT EDIT.
+#
+`
+
+// input: deploymentData
+const dockerfileT = `# {{ .Server }} server container image. The image runs the server main package
+# generated by "goa example" in {{ .Cmd }}. Build it from the module root with:
+#
+#    docker build -f {{ .Dockerfile }} -t {{ .Image }} .
+
+FROM golang:1 AS build
+WORKDIR /src
+COPY go.mod go.sum ./
+RUN go mod download
+COPY . .
+RUN CGO_ENABLED=0 go build -o /out/{{ .Binary }} {{ .Cmd }}
+
+FROM gcr.io/distroless/static
+COPY --from=build /out/{{ .Binary }} /{{ .Binary }}
+{{- range .Env }}
+ENV {{ .Name }}={{ printf "%q" .Value }}
+{{- end }}
+{{- if .Ports }}
+EXPOSE{{ range .Ports }} {{ .Port }}{{ end }}
+{{- end }}
+ENTRYPOINT ["/{{ .Binary }}"{{ range .Args }}, {{ printf "%q" . }}{{ end }}]
+`
+
+// input: deploymentData
+const kubernetesT = `# {{ .Server }} server Kubernetes manifests. Apply them with:
+#
+#    kubectl apply -f {{ .Manifests }}
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Name }}
+  labels:
+    app: {{ .Name }}
+spec:
+  replicas: {{ .Replicas }}
+  selector:
+    matchLabels:
+      app: {{ .Name }}
+  template:
+    metadata:
+      labels:
+        app: {{ .Name }}
+    spec:
+      containers:
+      - name: {{ .Name }}
+        image: {{ .Image }}
+{{- if .Ports }}
+        ports:
+  {{- range .Ports }}
+        - name: {{ .Name }}
+          containerPort: {{ .Port }}
+  {{- end }}
+{{- end }}
+{{- if .Env }}
+        env:
+  {{- range .Env }}
+        - name: {{ .Name }}
+          value: {{ printf "%q" .Value }}
+  {{- end }}
+{{- end }}
+{{- if or .Requests .Limits }}
+        resources:
+  {{- if .Requests }}
+          requests:
+    {{- range .Requests }}
+            {{ .Name }}: {{ printf "%q" .Quantity }}
+    {{- end }}
+  {{- end }}
+  {{- if .Limits }}
+          limits:
+    {{- range .Limits }}
+            {{ .Name }}: {{ printf "%q" .Quantity }}
+    {{- end }}
+  {{- end }}
+{{- end }}
+{{- if .LivenessPath }}
+        livenessProbe:
+          httpGet:
+            path: {{ .LivenessPath }}
+            port: http
+        readinessProbe:
+          httpGet:
+            path: {{ .ReadinessPath }}
+            port: http
+{{- end }}
+{{- if .Ports }}
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: {{ .Name }}
+  labels:
+    app: {{ .Name }}
+spec:
+  selector:
+    app: {{ .Name }}
+  ports:
+  {{- range .Ports }}
+  - name: {{ .Name }}
+    port: {{ .Port }}
+    targetPort: {{ .Name }}
+  {{- end }}
+{{- end }}
+`
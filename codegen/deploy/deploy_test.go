@@ -0,0 +1,59 @@
+package deploy
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/deploy/testdata"
+	"goa.design/goa/v3/codegen/example"
+)
+
+func TestFiles(t *testing.T) {
+	cases := []struct {
+		Name    string
+		DSL     func()
+		File    string
+		Section string
+		Code    string
+	}{
+		{"dockerfile", testdata.DeploymentDSL, "gen/deploy/calc_svc/Dockerfile", "deploy-dockerfile", testdata.DeploymentDockerfileCode},
+		{"kubernetes", testdata.DeploymentDSL, "gen/deploy/calc_svc/kubernetes.yaml", "deploy-kubernetes", testdata.DeploymentKubernetesCode},
+		{"default-dockerfile", testdata.DeploymentDefaultDSL, "gen/deploy/calc/Dockerfile", "deploy-dockerfile", testdata.DeploymentDefaultDockerfileCode},
+		{"default-kubernetes", testdata.DeploymentDefaultDSL, "gen/deploy/calc/kubernetes.yaml", "deploy-kubernetes", testdata.DeploymentDefaultKubernetesCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			example.Servers = make(example.ServersData)
+			root := codegen.RunDSL(t, c.DSL)
+			var file *codegen.File
+			for _, f := range Files("", root) {
+				if filepath.ToSlash(f.Path) == c.File {
+					file = f
+				}
+			}
+			if file == nil {
+				t.Fatalf("file %q not found", c.File)
+			}
+			sections := file.Section(c.Section)
+			if len(sections) != 1 {
+				t.Fatalf("got %d %q sections, expected one", len(sections), c.Section)
+			}
+			var buf bytes.Buffer
+			if err := sections[0].Write(&buf); err != nil {
+				t.Fatal(err)
+			}
+			if code := buf.String(); code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestFilesNoDeployment(t *testing.T) {
+	root := codegen.RunDSL(t, func() {})
+	if fs := Files("", root); len(fs) != 0 {
+		t.Errorf("got %d files, expected none", len(fs))
+	}
+}
@@ -0,0 +1,143 @@
+package testdata
+
+const DeploymentDockerfileCode = `# calc-svc server container image. The image runs the server main package
+# generated by "goa example" in ./cmd/calc_svc. Build it from the module root with:
+#
+#    docker build -f gen/deploy/calc_svc/Dockerfile -t acme/calc:1.0 .
+
+FROM golang:1 AS build
+WORKDIR /src
+COPY go.mod go.sum ./
+RUN go mod download
+COPY . .
+RUN CGO_ENABLED=0 go build -o /out/calc_svc ./cmd/calc_svc
+
+FROM gcr.io/distroless/static
+COPY --from=build /out/calc_svc /calc_svc
+ENV LOG_LEVEL="info"
+EXPOSE 8000 9090
+ENTRYPOINT ["/calc_svc", "-domain", "0.0.0.0", "-http-port", "8000", "-grpc-port", "9090"]
+`
+
+const DeploymentKubernetesCode = `# calc-svc server Kubernetes manifests. Apply them with:
+#
+#    kubectl apply -f gen/deploy/calc_svc/kubernetes.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: calc-svc
+  labels:
+    app: calc-svc
+spec:
+  replicas: 3
+  selector:
+    matchLabels:
+      app: calc-svc
+  template:
+    metadata:
+      labels:
+        app: calc-svc
+    spec:
+      containers:
+      - name: calc-svc
+        image: acme/calc:1.0
+        ports:
+        - name: http
+          containerPort: 8000
+        - name: grpc
+          containerPort: 9090
+        env:
+        - name: LOG_LEVEL
+          value: "info"
+        resources:
+          requests:
+            cpu: "100m"
+            memory: "64Mi"
+          limits:
+            cpu: "1"
+            memory: "256Mi"
+        livenessProbe:
+          httpGet:
+            path: /healthz
+            port: http
+        readinessProbe:
+          httpGet:
+            path: /readyz
+            port: http
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: calc-svc
+  labels:
+    app: calc-svc
+spec:
+  selector:
+    app: calc-svc
+  ports:
+  - name: http
+    port: 8000
+    targetPort: http
+  - name: grpc
+    port: 9090
+    targetPort: grpc
+`
+
+const DeploymentDefaultDockerfileCode = `# calc server container image. The image runs the server main package
+# generated by "goa example" in ./cmd/calc. Build it from the module root with:
+#
+#    docker build -f gen/deploy/calc/Dockerfile -t calc .
+
+FROM golang:1 AS build
+WORKDIR /src
+COPY go.mod go.sum ./
+RUN go mod download
+COPY . .
+RUN CGO_ENABLED=0 go build -o /out/calc ./cmd/calc
+
+FROM gcr.io/distroless/static
+COPY --from=build /out/calc /calc
+EXPOSE 80
+ENTRYPOINT ["/calc", "-domain", "0.0.0.0", "-http-port", "80"]
+`
+
+const DeploymentDefaultKubernetesCode = `# calc server Kubernetes manifests. Apply them with:
+#
+#    kubectl apply -f gen/deploy/calc/kubernetes.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: calc
+  labels:
+    app: calc
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: calc
+  template:
+    metadata:
+      labels:
+        app: calc
+    spec:
+      containers:
+      - name: calc
+        image: calc
+        ports:
+        - name: http
+          containerPort: 80
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: calc
+  labels:
+    app: calc
+spec:
+  selector:
+    app: calc
+  ports:
+  - name: http
+    port: 80
+    targetPort: http
+`
@@ -0,0 +1,51 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var DeploymentDSL = func() {
+	API("calc", func() {
+		HealthCheck()
+		Server("calc-svc", func() {
+			Host("local", func() {
+				URI("http://localhost:8000")
+				URI("grpc://localhost:8080")
+			})
+			Deployment(func() {
+				DeploymentImage("acme/calc:1.0")
+				DeploymentReplicas(3)
+				DeploymentPort("grpc", 9090)
+				DeploymentEnv("LOG_LEVEL", "info")
+				DeploymentCPU("100m", "1")
+				DeploymentMemory("64Mi", "256Mi")
+			})
+		})
+	})
+	Service("calc", func() {
+		Method("add", func() {
+			HTTP(func() {
+				GET("/add")
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
+var DeploymentDefaultDSL = func() {
+	API("calc", func() {
+		Server("calc", func() {
+			Host("local", func() {
+				URI("http://localhost")
+			})
+			Deployment(func() {})
+		})
+	})
+	Service("calc", func() {
+		Method("add", func() {
+			HTTP(func() {
+				GET("/add")
+			})
+		})
+	})
+}
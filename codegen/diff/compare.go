@@ -0,0 +1,476 @@
+package diff
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Change describes a change that breaks the existing clients of a
+	// service.
+	Change struct {
+		// Service is the name of the service.
+		Service string `json:"service"`
+		// Method is the name of the method if the change is specific
+		// to a method.
+		Method string `json:"method,omitempty"`
+		// Transport is "http" or "grpc" if the change is specific to
+		// a transport.
+		Transport string `json:"transport,omitempty"`
+		// Location is the path to the changed value in the request or
+		// response, e.g. "request.body.address.city".
+		Location string `json:"location,omitempty"`
+		// Message describes the change.
+		Message string `json:"message"`
+	}
+
+	// comparer accumulates the changes made to a method.
+	comparer struct {
+		service   string
+		method    string
+		transport string
+		changes   []*Change
+	}
+)
+
+// routeParamsRegex matches the parameters of route paths.
+var routeParamsRegex = regexp.MustCompile(`\{(\*?)[^}]*\}`)
+
+// Compare returns the changes made to old to produce new that break the
+// existing clients. The changes detected include:
+//
+//   - removed services, methods, endpoints, routes, responses, parameters
+//     and fields,
+//   - type changes,
+//   - parameters and request fields that become required and response
+//     fields that become optional,
+//   - protocol buffer field numbers that change or that are reused by a
+//     different field,
+//   - request validations that are narrowed and response validations that
+//     are widened, e.g. an enum value removed from a request field or added
+//     to a response field.
+func Compare(old, new *Surface) []*Change {
+	var changes []*Change
+	for _, os := range old.Services {
+		ns := new.service(os.Name)
+		if ns == nil {
+			changes = append(changes, &Change{Service: os.Name, Message: "service removed"})
+			continue
+		}
+		for _, om := range os.Methods {
+			c := &comparer{service: os.Name, method: om.Name}
+			nm := ns.method(om.Name)
+			if nm == nil {
+				c.add("", "method removed")
+				changes = append(changes, c.changes...)
+				continue
+			}
+			if om.HTTP != nil {
+				c.transport = "http"
+				if nm.HTTP == nil {
+					c.add("", "HTTP endpoint removed")
+				} else {
+					c.http(om.HTTP, nm.HTTP)
+				}
+			}
+			if om.GRPC != nil {
+				c.transport = "grpc"
+				if nm.GRPC == nil {
+					c.add("", "gRPC endpoint removed")
+				} else {
+					c.types("request", om.GRPC.Request, nm.GRPC.Request, false)
+					c.types("response", om.GRPC.Response, nm.GRPC.Response, true)
+				}
+			}
+			changes = append(changes, c.changes...)
+		}
+	}
+	return changes
+}
+
+// String returns a human readable description of the change, e.g.
+// `orders.create (http) request.body.total: field removed`.
+func (c *Change) String() string {
+	var b strings.Builder
+	b.WriteString(c.Service)
+	if c.Method != "" {
+		b.WriteString("." + c.Method)
+	}
+	if c.Transport != "" {
+		b.WriteString(" (" + c.Transport + ")")
+	}
+	if c.Location != "" {
+		b.WriteString(" " + c.Location)
+	}
+	b.WriteString(": " + c.Message)
+	return b.String()
+}
+
+// service returns the service with the given name, nil if there is none.
+func (s *Surface) service(name string) *Service {
+	for _, svc := range s.Services {
+		if svc.Name == name {
+			return svc
+		}
+	}
+	return nil
+}
+
+// method returns the method with the given name, nil if there is none.
+func (s *Service) method(name string) *Method {
+	for _, m := range s.Methods {
+		if m.Name == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// add records a change made to the value at the given location.
+func (c *comparer) add(loc, format string, args ...interface{}) {
+	c.changes = append(c.changes, &Change{
+		Service:   c.service,
+		Method:    c.method,
+		Transport: c.transport,
+		Location:  loc,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+// http compares the requests and responses of two versions of a HTTP
+// endpoint.
+func (c *comparer) http(o, n *HTTPEndpoint) {
+	routes := make(map[string]struct{}, len(n.Routes))
+	for _, r := range n.Routes {
+		routes[routeKey(r)] = struct{}{}
+	}
+	for _, r := range o.Routes {
+		if _, ok := routes[routeKey(r)]; !ok {
+			c.add("", "route %q removed", r)
+		}
+	}
+	c.params("request", o.Params, n.Params, false)
+	c.types("request.body", o.Body, n.Body, false)
+	for _, or := range o.Responses {
+		var nr *HTTPResponse
+		for _, r := range n.Responses {
+			if r.Status == or.Status {
+				nr = r
+				break
+			}
+		}
+		loc := "response." + strconv.Itoa(or.Status)
+		if nr == nil {
+			c.add(loc, "response removed")
+			continue
+		}
+		c.params(loc, or.Headers, nr.Headers, true)
+		c.types(loc+".body", or.Body, nr.Body, true)
+	}
+}
+
+// params compares two versions of the parameters of a request or of the
+// headers of a response.
+func (c *comparer) params(loc string, o, n []*Param, resp bool) {
+	for _, op := range o {
+		ploc := loc + "." + op.In + "." + op.Name
+		np := findParam(n, op.In, op.Name)
+		if np == nil {
+			moved := false
+			for _, p := range n {
+				if p.Name == op.Name {
+					c.add(ploc, "moved from %s to %s", op.In, p.In)
+					moved = true
+					break
+				}
+			}
+			if !moved {
+				c.add(ploc, "%s removed", paramKind(op))
+			}
+			continue
+		}
+		if !resp && !op.Required && np.Required {
+			c.add(ploc, "%s became required", paramKind(op))
+		}
+		if resp && op.Required && !np.Required {
+			c.add(ploc, "%s became optional", paramKind(op))
+		}
+		c.types(ploc, op.Type, np.Type, resp)
+	}
+	if resp {
+		return
+	}
+	for _, np := range n {
+		if np.Required && findParam(o, np.In, np.Name) == nil {
+			c.add(loc+"."+np.In+"."+np.Name, "required %s added", paramKind(np))
+		}
+	}
+}
+
+// types compares two versions of the type of a value. resp indicates whether
+// the value is part of a response.
+func (c *comparer) types(loc string, o, n *Type, resp bool) {
+	if o == nil {
+		if !resp && n != nil && hasRequired(n) {
+			c.add(loc, "added with required fields")
+		}
+		return
+	}
+	if n == nil {
+		c.add(loc, "removed")
+		return
+	}
+	if o.Kind != n.Kind {
+		c.add(loc, "type changed from %s to %s", o.Kind, n.Kind)
+		return
+	}
+	if o.Ref != "" || n.Ref != "" {
+		// Recursive type, the definition is compared by the enclosing
+		// type.
+		return
+	}
+	c.validations(loc, o.Validation, n.Validation, resp)
+	switch o.Kind {
+	case "array":
+		c.types(loc+"[]", o.Elem, n.Elem, resp)
+	case "map":
+		c.types(loc+"[key]", o.Key, n.Key, resp)
+		c.types(loc+"[value]", o.Elem, n.Elem, resp)
+	case "object":
+		c.fields(loc, o.Fields, n.Fields, resp)
+	}
+}
+
+// fields compares two versions of the fields of an object.
+func (c *comparer) fields(loc string, o, n []*Field, resp bool) {
+	tags := make(map[string]string)
+	for _, of := range o {
+		if of.Tag != "" {
+			tags[of.Tag] = of.Name
+		}
+		floc := join(loc, of.Name)
+		nf := findField(n, of.Name)
+		if nf == nil {
+			c.add(floc, "field removed")
+			continue
+		}
+		if !resp && !of.Required && nf.Required {
+			c.add(floc, "field became required")
+		}
+		if resp && of.Required && !nf.Required {
+			c.add(floc, "field became optional")
+		}
+		if of.Tag != "" && nf.Tag != "" && of.Tag != nf.Tag {
+			c.add(floc, "field number changed from %s to %s", of.Tag, nf.Tag)
+		}
+		c.types(floc, of.Type, nf.Type, resp)
+	}
+	for _, nf := range n {
+		floc := join(loc, nf.Name)
+		if name, ok := tags[nf.Tag]; ok && nf.Tag != "" && name != nf.Name {
+			c.add(floc, "field number %s reused, previously assigned to %q", nf.Tag, name)
+		}
+		if !resp && nf.Required && findField(o, nf.Name) == nil {
+			c.add(floc, "required field added")
+		}
+	}
+}
+
+// validations compares two versions of the constraints of a value. Narrowing
+// the constraints of requests breaks the clients that send values that are
+// not valid anymore while widening the constraints of responses breaks the
+// clients that validate the values they receive.
+func (c *comparer) validations(loc string, o, n *Validation, resp bool) {
+	if o == nil {
+		o = &Validation{}
+	}
+	if n == nil {
+		n = &Validation{}
+	}
+	var narrowed, widened []string
+	{
+		switch {
+		case len(o.Enum) == 0 && len(n.Enum) > 0:
+			narrowed = append(narrowed, fmt.Sprintf("enum %s added", quote(n.Enum)))
+		case len(o.Enum) > 0 && len(n.Enum) == 0:
+			widened = append(widened, "enum removed")
+		default:
+			for _, v := range o.Enum {
+				if !contains(n.Enum, v) {
+					narrowed = append(narrowed, fmt.Sprintf("enum value %q removed", v))
+				}
+			}
+			for _, v := range n.Enum {
+				if !contains(o.Enum, v) {
+					widened = append(widened, fmt.Sprintf("enum value %q added", v))
+				}
+			}
+		}
+	}
+	for _, s := range []struct {
+		name string
+		o, n string
+	}{
+		{"format", o.Format, n.Format},
+		{"pattern", o.Pattern, n.Pattern},
+		{"unique by", o.UniqueBy, n.UniqueBy},
+		{"content media type", o.ContentMediaType, n.ContentMediaType},
+	} {
+		switch {
+		case s.o == s.n:
+		case s.o == "":
+			narrowed = append(narrowed, fmt.Sprintf("%s %q added", s.name, s.n))
+		case s.n == "":
+			widened = append(widened, fmt.Sprintf("%s %q removed", s.name, s.o))
+		default:
+			msg := fmt.Sprintf("%s changed from %q to %q", s.name, s.o, s.n)
+			narrowed = append(narrowed, msg)
+			widened = append(widened, msg)
+		}
+	}
+	for _, b := range []struct {
+		name  string
+		o, n  *float64
+		lower bool
+	}{
+		{"minimum", o.Minimum, n.Minimum, true},
+		{"maximum", o.Maximum, n.Maximum, false},
+		{"exclusive minimum", o.ExclusiveMinimum, n.ExclusiveMinimum, true},
+		{"exclusive maximum", o.ExclusiveMaximum, n.ExclusiveMaximum, false},
+		{"min length", toFloat(o.MinLength), toFloat(n.MinLength), true},
+		{"max length", toFloat(o.MaxLength), toFloat(n.MaxLength), false},
+		{"max bytes", toFloat(o.MaxBytes), toFloat(n.MaxBytes), false},
+	} {
+		if b.o == nil && b.n == nil || b.o != nil && b.n != nil && *b.o == *b.n {
+			continue
+		}
+		msg := fmt.Sprintf("%s changed from %s to %s", b.name, bound(b.o), bound(b.n))
+		// A lower bound is narrowed if it is added or increased and an
+		// upper bound if it is added or decreased.
+		narrow := b.o == nil || b.n != nil && (*b.n > *b.o) == b.lower
+		if narrow {
+			narrowed = append(narrowed, msg)
+		} else {
+			widened = append(widened, msg)
+		}
+	}
+	switch om, nm := o.MultipleOf, n.MultipleOf; {
+	case om == nil && nm == nil, om != nil && nm != nil && *om == *nm:
+	case om == nil:
+		narrowed = append(narrowed, fmt.Sprintf("multiple of %s added", bound(nm)))
+	case nm == nil:
+		widened = append(widened, fmt.Sprintf("multiple of %s removed", bound(om)))
+	default:
+		msg := fmt.Sprintf("multiple of changed from %s to %s", bound(om), bound(nm))
+		// Multiples of the new value are multiples of the old value if the
+		// new value is itself a multiple of the old value.
+		if math.Mod(*nm, *om) == 0 {
+			narrowed = append(narrowed, msg)
+		} else {
+			narrowed = append(narrowed, msg)
+			widened = append(widened, msg)
+		}
+	}
+	msgs := narrowed
+	if resp {
+		msgs = widened
+	}
+	for _, msg := range msgs {
+		c.add(loc, "%s", msg)
+	}
+}
+
+// routeKey returns the key used to compare routes. The parameter names are
+// removed from the route path as they do not appear in the requests.
+func routeKey(r string) string {
+	return routeParamsRegex.ReplaceAllString(r, "{$1}")
+}
+
+// findParam returns the parameter with the given location and name, nil if
+// there is none. Header names are case insensitive.
+func findParam(params []*Param, in, name string) *Param {
+	for _, p := range params {
+		if p.In != in {
+			continue
+		}
+		if p.Name == name || in == "header" && strings.EqualFold(p.Name, name) {
+			return p
+		}
+	}
+	return nil
+}
+
+// findField returns the field with the given name, nil if there is none.
+func findField(fields []*Field, name string) *Field {
+	for _, f := range fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// paramKind returns "header" for headers and "parameter" otherwise.
+func paramKind(p *Param) string {
+	if p.In == "header" {
+		return "header"
+	}
+	return "parameter"
+}
+
+// hasRequired returns true if t is an object with required fields.
+func hasRequired(t *Type) bool {
+	for _, f := range t.Fields {
+		if f.Required {
+			return true
+		}
+	}
+	return false
+}
+
+// join appends the name of a field to the given location.
+func join(loc, name string) string {
+	if loc == "" {
+		return name
+	}
+	return loc + "." + name
+}
+
+// contains returns true if vals contains v.
+func contains(vals []string, v string) bool {
+	for _, val := range vals {
+		if val == v {
+			return true
+		}
+	}
+	return false
+}
+
+// quote returns the quoted values separated with commas.
+func quote(vals []string) string {
+	quoted := make([]string, len(vals))
+	for i, v := range vals {
+		quoted[i] = strconv.Quote(v)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// toFloat converts the given int pointer into a float pointer.
+func toFloat(i *int) *float64 {
+	if i == nil {
+		return nil
+	}
+	f := float64(*i)
+	return &f
+}
+
+// bound returns the string representation of the given bound.
+func bound(f *float64) string {
+	if f == nil {
+		return "none"
+	}
+	return strconv.FormatFloat(*f, 'g', -1, 64)
+}
@@ -0,0 +1,168 @@
+package diff
+
+import (
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	var (
+		one  = 1.0
+		five = 5.0
+		ten  = 10
+		str  = func(v *Validation) *Type { return &Type{Kind: "string", Validation: v} }
+		obj  = func(fields ...*Field) *Type { return &Type{Kind: "object", Fields: fields} }
+		http = func(body, result *Type, params ...*Param) *Surface {
+			return surface(&Method{Name: "m", HTTP: &HTTPEndpoint{
+				Routes:    []string{"GET /items/{id}"},
+				Params:    params,
+				Body:      body,
+				Responses: []*HTTPResponse{{Status: 200, Body: result}},
+			}})
+		}
+		grpc = func(req *Type) *Surface {
+			return surface(&Method{Name: "m", GRPC: &GRPCEndpoint{Request: req}})
+		}
+	)
+	cases := []struct {
+		Name     string
+		Old      *Surface
+		New      *Surface
+		Expected []string
+	}{
+		{"no change", http(obj(&Field{Name: "a", Type: str(nil)}), nil), http(obj(&Field{Name: "a", Type: str(nil)}), nil), nil},
+		{"service removed", surface(&Method{Name: "m"}), &Surface{}, []string{"s: service removed"}},
+		{"method removed", surface(&Method{Name: "m"}), surface(), []string{"s.m: method removed"}},
+		{"http removed", http(nil, nil), surface(&Method{Name: "m"}), []string{"s.m (http): HTTP endpoint removed"}},
+		{"route renamed param", http(nil, nil), withRoute(http(nil, nil), "GET /items/{itemID}"), nil},
+		{"route removed", http(nil, nil), withRoute(http(nil, nil), "GET /v2/items/{id}"), []string{`s.m (http): route "GET /items/{id}" removed`}},
+		{"response removed", http(nil, nil), withStatus(http(nil, nil), 201), []string{"s.m (http) response.200: response removed"}},
+		{"param removed",
+			http(nil, nil, &Param{Name: "q", In: "query", Type: str(nil)}),
+			http(nil, nil),
+			[]string{"s.m (http) request.query.q: parameter removed"}},
+		{"param moved",
+			http(nil, nil, &Param{Name: "q", In: "query", Type: str(nil)}),
+			http(nil, nil, &Param{Name: "q", In: "header", Type: str(nil)}),
+			[]string{"s.m (http) request.query.q: moved from query to header"}},
+		{"param required",
+			http(nil, nil, &Param{Name: "q", In: "query", Type: str(nil)}),
+			http(nil, nil, &Param{Name: "q", In: "query", Required: true, Type: str(nil)}),
+			[]string{"s.m (http) request.query.q: parameter became required"}},
+		{"required header added",
+			http(nil, nil),
+			http(nil, nil, &Param{Name: "X-Key", In: "header", Required: true, Type: str(nil)}),
+			[]string{"s.m (http) request.header.X-Key: required header added"}},
+		{"header case",
+			http(nil, nil, &Param{Name: "x-key", In: "header", Type: str(nil)}),
+			http(nil, nil, &Param{Name: "X-Key", In: "header", Type: str(nil)}),
+			nil},
+		{"type changed",
+			http(obj(&Field{Name: "a", Type: str(nil)}), nil),
+			http(obj(&Field{Name: "a", Type: &Type{Kind: "int"}}), nil),
+			[]string{"s.m (http) request.body.a: type changed from string to int"}},
+		{"field removed",
+			http(nil, obj(&Field{Name: "a", Type: str(nil)})),
+			http(nil, obj()),
+			[]string{"s.m (http) response.200.body.a: field removed"}},
+		{"required field added",
+			http(obj(), nil),
+			http(obj(&Field{Name: "a", Required: true, Type: str(nil)}), nil),
+			[]string{"s.m (http) request.body.a: required field added"}},
+		{"optional field added",
+			http(obj(), obj()),
+			http(obj(&Field{Name: "a", Type: str(nil)}), obj(&Field{Name: "a", Type: str(nil)})),
+			nil},
+		{"result field optional",
+			http(nil, obj(&Field{Name: "a", Required: true, Type: str(nil)})),
+			http(nil, obj(&Field{Name: "a", Type: str(nil)})),
+			[]string{"s.m (http) response.200.body.a: field became optional"}},
+		{"body added with required fields",
+			http(nil, nil),
+			http(obj(&Field{Name: "a", Required: true, Type: str(nil)}), nil),
+			[]string{"s.m (http) request.body: added with required fields"}},
+		{"nested array element",
+			http(&Type{Kind: "array", Elem: str(nil)}, nil),
+			http(&Type{Kind: "array", Elem: &Type{Kind: "int"}}, nil),
+			[]string{"s.m (http) request.body[]: type changed from string to int"}},
+		{"enum narrowed",
+			http(str(&Validation{Enum: []string{"a", "b"}}), str(&Validation{Enum: []string{"a", "b"}})),
+			http(str(&Validation{Enum: []string{"a"}}), str(&Validation{Enum: []string{"a"}})),
+			[]string{`s.m (http) request.body: enum value "b" removed`}},
+		{"enum widened",
+			http(str(&Validation{Enum: []string{"a"}}), str(&Validation{Enum: []string{"a"}})),
+			http(str(&Validation{Enum: []string{"a", "b"}}), str(&Validation{Enum: []string{"a", "b"}})),
+			[]string{`s.m (http) response.200.body: enum value "b" added`}},
+		{"enum added",
+			http(str(nil), nil),
+			http(str(&Validation{Enum: []string{"a", "b"}}), nil),
+			[]string{`s.m (http) request.body: enum "a", "b" added`}},
+		{"bounds narrowed",
+			http(&Type{Kind: "int", Validation: &Validation{Minimum: &one}}, nil),
+			http(&Type{Kind: "int", Validation: &Validation{Minimum: &five, MaxLength: &ten}}, nil),
+			[]string{
+				"s.m (http) request.body: minimum changed from 1 to 5",
+				"s.m (http) request.body: max length changed from none to 10",
+			}},
+		{"bounds widened",
+			http(nil, &Type{Kind: "int", Validation: &Validation{Minimum: &five}}),
+			http(nil, &Type{Kind: "int", Validation: &Validation{Minimum: &one}}),
+			[]string{"s.m (http) response.200.body: minimum changed from 5 to 1"}},
+		{"multiple of narrowed",
+			http(&Type{Kind: "float", Validation: &Validation{ExclusiveMinimum: &one, MultipleOf: &one}}, nil),
+			http(&Type{Kind: "float", Validation: &Validation{ExclusiveMinimum: &five, MultipleOf: &five}}, nil),
+			[]string{
+				"s.m (http) request.body: exclusive minimum changed from 1 to 5",
+				"s.m (http) request.body: multiple of changed from 1 to 5",
+			}},
+		{"pattern changed",
+			http(str(&Validation{Pattern: "^a"}), str(&Validation{Pattern: "^a"})),
+			http(str(&Validation{Pattern: "^b"}), str(&Validation{Pattern: "^b"})),
+			[]string{
+				`s.m (http) request.body: pattern changed from "^a" to "^b"`,
+				`s.m (http) response.200.body: pattern changed from "^a" to "^b"`,
+			}},
+		{"recursive reference",
+			http(obj(&Field{Name: "a", Type: &Type{Kind: "object", Ref: "A"}}), nil),
+			http(obj(&Field{Name: "a", Type: obj(&Field{Name: "b", Required: true, Type: str(nil)})}), nil),
+			nil},
+		{"field number changed",
+			grpc(obj(&Field{Name: "a", Tag: "1", Type: str(nil)})),
+			grpc(obj(&Field{Name: "a", Tag: "2", Type: str(nil)})),
+			[]string{"s.m (grpc) request.a: field number changed from 1 to 2"}},
+		{"field number reused",
+			grpc(obj(&Field{Name: "a", Tag: "1", Type: str(nil)})),
+			grpc(obj(&Field{Name: "b", Tag: "1", Type: str(nil)})),
+			[]string{
+				"s.m (grpc) request.a: field removed",
+				`s.m (grpc) request.b: field number 1 reused, previously assigned to "a"`,
+			}},
+		{"grpc removed", grpc(obj()), surface(&Method{Name: "m"}), []string{"s.m (grpc): gRPC endpoint removed"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			changes := Compare(c.Old, c.New)
+			if len(changes) != len(c.Expected) {
+				t.Fatalf("got %d changes %v, expected %d", len(changes), changes, len(c.Expected))
+			}
+			for i, ch := range changes {
+				if ch.String() != c.Expected[i] {
+					t.Errorf("got change %q, expected %q", ch.String(), c.Expected[i])
+				}
+			}
+		})
+	}
+}
+
+func surface(methods ...*Method) *Surface {
+	return &Surface{Services: []*Service{{Name: "s", Methods: methods}}}
+}
+
+func withRoute(s *Surface, route string) *Surface {
+	s.Services[0].Methods[0].HTTP.Routes = []string{route}
+	return s
+}
+
+func withStatus(s *Surface, status int) *Surface {
+	s.Services[0].Methods[0].HTTP.Responses[0].Status = status
+	return s
+}
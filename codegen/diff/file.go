@@ -0,0 +1,36 @@
+package diff
+
+import (
+	"encoding/json"
+	"text/template"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// SurfaceFilename is the name of the file written by the "diff" command of the
+// code generator that contains the JSON representation of the design surface.
+const SurfaceFilename = "surface.json"
+
+// SurfaceFile returns the file that contains the JSON representation of the
+// surface of the design described by root.
+func SurfaceFile(root *expr.RootExpr) *codegen.File {
+	section := &codegen.SectionTemplate{
+		Name:    "surface",
+		FuncMap: template.FuncMap{"toJSON": toJSON},
+		Source:  "{{ toJSON . }}",
+		Data:    NewSurface(root),
+	}
+	return &codegen.File{
+		Path:             SurfaceFilename,
+		SectionTemplates: []*codegen.SectionTemplate{section},
+	}
+}
+
+func toJSON(d interface{}) string {
+	b, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		panic("diff: " + err.Error()) // bug
+	}
+	return string(b)
+}
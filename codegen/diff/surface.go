@@ -0,0 +1,336 @@
+/*
+Package diff detects the breaking changes made to the HTTP and gRPC surfaces
+of a design.
+
+The surface of a design (see Surface) lists the services, methods, HTTP
+endpoints and gRPC endpoints defined in the design together with the shapes of
+the requests and responses they accept and return. The goa tool computes the
+surfaces of two versions of a design and compares them with Compare to report
+the changes that break existing clients, e.g.:
+
+	goa diff example.com/cellar/design/released example.com/cellar/design
+*/
+package diff
+
+import (
+	"fmt"
+	"sort"
+
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// Surface describes the parts of a design that are visible to the
+	// clients of the services it defines.
+	Surface struct {
+		// Services lists the services of the design.
+		Services []*Service `json:"services"`
+	}
+
+	// Service describes a service.
+	Service struct {
+		// Name is the name of the service.
+		Name string `json:"name"`
+		// Methods lists the service methods.
+		Methods []*Method `json:"methods"`
+	}
+
+	// Method describes a service method and its transport endpoints.
+	Method struct {
+		// Name is the name of the method.
+		Name string `json:"name"`
+		// HTTP describes the HTTP endpoint of the method if any.
+		HTTP *HTTPEndpoint `json:"http,omitempty"`
+		// GRPC describes the gRPC endpoint of the method if any.
+		GRPC *GRPCEndpoint `json:"grpc,omitempty"`
+	}
+
+	// HTTPEndpoint describes the requests and responses of a HTTP endpoint.
+	HTTPEndpoint struct {
+		// Routes lists the endpoint routes, e.g. "GET /accounts/{id}".
+		Routes []string `json:"routes"`
+		// Params lists the request path and query parameters, headers
+		// and cookies.
+		Params []*Param `json:"params,omitempty"`
+		// Body describes the request body if any.
+		Body *Type `json:"body,omitempty"`
+		// Responses lists the success responses.
+		Responses []*HTTPResponse `json:"responses,omitempty"`
+	}
+
+	// HTTPResponse describes a HTTP response.
+	HTTPResponse struct {
+		// Status is the response status code.
+		Status int `json:"status"`
+		// Headers lists the response headers.
+		Headers []*Param `json:"headers,omitempty"`
+		// Body describes the response body if any.
+		Body *Type `json:"body,omitempty"`
+	}
+
+	// GRPCEndpoint describes the messages of a gRPC endpoint.
+	GRPCEndpoint struct {
+		// Request describes the request message.
+		Request *Type `json:"request,omitempty"`
+		// Response describes the response message.
+		Response *Type `json:"response,omitempty"`
+	}
+
+	// Param describes a HTTP request parameter or a HTTP header.
+	Param struct {
+		// Name is the name of the parameter as it appears in the
+		// request or response.
+		Name string `json:"name"`
+		// In is the location of the parameter, one of "path",
+		// "query", "header" or "cookie".
+		In string `json:"in"`
+		// Required is true if the parameter is required.
+		Required bool `json:"required,omitempty"`
+		// Type describes the parameter type.
+		Type *Type `json:"type"`
+	}
+
+	// Type describes the shape of a value.
+	Type struct {
+		// Kind is the name of the primitive type of the value or one
+		// of "array", "map" or "object".
+		Kind string `json:"kind"`
+		// Ref is the name of the user type whose definition is being
+		// described by one of the enclosing types. Ref makes it
+		// possible to describe recursive types.
+		Ref string `json:"ref,omitempty"`
+		// Fields lists the fields of objects.
+		Fields []*Field `json:"fields,omitempty"`
+		// Key describes the keys of maps.
+		Key *Type `json:"key,omitempty"`
+		// Elem describes the elements of arrays and the values of maps.
+		Elem *Type `json:"elem,omitempty"`
+		// Validation lists the constraints of the value if any.
+		Validation *Validation `json:"validation,omitempty"`
+	}
+
+	// Field describes an object field.
+	Field struct {
+		// Name is the name of the field.
+		Name string `json:"name"`
+		// Tag is the protocol buffer field number of the field if any.
+		Tag string `json:"tag,omitempty"`
+		// Required is true if the field is required.
+		Required bool `json:"required,omitempty"`
+		// Type describes the field type.
+		Type *Type `json:"type"`
+	}
+
+	// Validation describes the constraints of a value.
+	Validation struct {
+		// Enum lists the values allowed by an enum validation.
+		Enum []string `json:"enum,omitempty"`
+		// Format is the format of string values.
+		Format string `json:"format,omitempty"`
+		// Pattern is the regular expression string values must match.
+		Pattern string `json:"pattern,omitempty"`
+		// Minimum is the minimum value of numbers.
+		Minimum *float64 `json:"minimum,omitempty"`
+		// Maximum is the maximum value of numbers.
+		Maximum *float64 `json:"maximum,omitempty"`
+		// ExclusiveMinimum is the value numbers must be greater than.
+		ExclusiveMinimum *float64 `json:"exclusiveMinimum,omitempty"`
+		// ExclusiveMaximum is the value numbers must be lesser than.
+		ExclusiveMaximum *float64 `json:"exclusiveMaximum,omitempty"`
+		// MultipleOf is the number numbers must be a multiple of.
+		MultipleOf *float64 `json:"multipleOf,omitempty"`
+		// MinLength is the minimum length of strings and arrays.
+		MinLength *int `json:"minLength,omitempty"`
+		// MaxLength is the maximum length of strings and arrays.
+		MaxLength *int `json:"maxLength,omitempty"`
+		// MaxBytes is the maximum length of strings in bytes.
+		MaxBytes *int `json:"maxBytes,omitempty"`
+		// UniqueBy is the attribute of array elements whose values
+		// must be unique.
+		UniqueBy string `json:"uniqueBy,omitempty"`
+		// ContentMediaType is the media type of bytes values.
+		ContentMediaType string `json:"contentMediaType,omitempty"`
+	}
+
+	// builder computes the types of a surface.
+	builder struct {
+		// stack lists the names of the user types being described.
+		stack []string
+		// tags is true if the field numbers must be recorded.
+		tags bool
+	}
+)
+
+// NewSurface returns the surface of the design described by root.
+func NewSurface(root *expr.RootExpr) *Surface {
+	s := &Surface{Services: []*Service{}}
+	for _, svc := range root.Services {
+		service := &Service{Name: svc.Name, Methods: []*Method{}}
+		for _, m := range svc.Methods {
+			method := &Method{Name: m.Name}
+			if hs := root.API.HTTP.Service(svc.Name); hs != nil {
+				if e := hs.Endpoint(m.Name); e != nil {
+					method.HTTP = newHTTPEndpoint(e)
+				}
+			}
+			if gs := root.API.GRPC.Service(svc.Name); gs != nil {
+				if e := gs.Endpoint(m.Name); e != nil {
+					method.GRPC = newGRPCEndpoint(e)
+				}
+			}
+			service.Methods = append(service.Methods, method)
+		}
+		s.Services = append(s.Services, service)
+	}
+	return s
+}
+
+// newHTTPEndpoint returns the surface of the given HTTP endpoint.
+func newHTTPEndpoint(e *expr.HTTPEndpointExpr) *HTTPEndpoint {
+	var b builder
+	h := &HTTPEndpoint{Routes: []string{}}
+	for _, r := range e.Routes {
+		for _, p := range r.FullPaths() {
+			h.Routes = append(h.Routes, r.Method+" "+p)
+		}
+	}
+	h.Params = append(h.Params, b.params("path", e.PathParams())...)
+	h.Params = append(h.Params, b.params("query", e.QueryParams())...)
+	h.Params = append(h.Params, b.params("header", e.Headers)...)
+	h.Params = append(h.Params, b.params("cookie", e.Cookies)...)
+	h.Body = b.typ(e.Body)
+	for _, r := range e.Responses {
+		h.Responses = append(h.Responses, &HTTPResponse{
+			Status:  r.StatusCode,
+			Headers: b.params("header", r.Headers),
+			Body:    b.typ(r.Body),
+		})
+	}
+	return h
+}
+
+// newGRPCEndpoint returns the surface of the given gRPC endpoint.
+func newGRPCEndpoint(e *expr.GRPCEndpointExpr) *GRPCEndpoint {
+	b := builder{tags: true}
+	g := &GRPCEndpoint{Request: b.typ(e.Request)}
+	if e.Response != nil {
+		g.Response = b.typ(e.Response.Message)
+	}
+	return g
+}
+
+// params returns the parameters described by the given mapped attribute
+// located in in.
+func (b *builder) params(in string, ma *expr.MappedAttributeExpr) []*Param {
+	if ma == nil || ma.Type == nil || ma.IsEmpty() {
+		return nil
+	}
+	var params []*Param
+	expr.WalkMappedAttr(ma, func(name, elem string, att *expr.AttributeExpr) error {
+		params = append(params, &Param{
+			Name:     elem,
+			In:       in,
+			Required: ma.IsRequired(name),
+			Type:     b.typ(att),
+		})
+		return nil
+	})
+	return params
+}
+
+// typ returns the description of the type of att, nil if att does not define
+// a type.
+func (b *builder) typ(att *expr.AttributeExpr) *Type {
+	if att == nil || att.Type == nil || att.Type == expr.Empty {
+		return nil
+	}
+	if ut, ok := att.Type.(expr.UserType); ok {
+		name := ut.Name()
+		for _, n := range b.stack {
+			if n == name {
+				return &Type{Kind: kind(ut.Attribute().Type), Ref: name}
+			}
+		}
+		b.stack = append(b.stack, name)
+		defer func() { b.stack = b.stack[:len(b.stack)-1] }()
+		t := b.typ(ut.Attribute())
+		if t == nil {
+			return nil
+		}
+		if v := newValidation(att.Validation); v != nil && t.Validation == nil {
+			t.Validation = v
+		}
+		return t
+	}
+	t := &Type{Kind: kind(att.Type), Validation: newValidation(att.Validation)}
+	switch actual := att.Type.(type) {
+	case *expr.Array:
+		t.Elem = b.typ(actual.ElemType)
+	case *expr.Map:
+		t.Key = b.typ(actual.KeyType)
+		t.Elem = b.typ(actual.ElemType)
+	case *expr.Object:
+		t.Fields = []*Field{}
+		for _, nat := range *actual {
+			f := &Field{
+				Name:     nat.Name,
+				Required: att.IsRequired(nat.Name),
+				Type:     b.typ(nat.Attribute),
+			}
+			if tag, ok := nat.Attribute.Meta["rpc:tag"]; ok && len(tag) > 0 && b.tags {
+				f.Tag = tag[0]
+			}
+			t.Fields = append(t.Fields, f)
+		}
+	}
+	return t
+}
+
+// kind returns the kind of the given data type.
+func kind(dt expr.DataType) string {
+	switch dt.(type) {
+	case *expr.Array:
+		return "array"
+	case *expr.Map:
+		return "map"
+	case *expr.Object:
+		return "object"
+	case expr.UserType:
+		return kind(dt.(expr.UserType).Attribute().Type)
+	default:
+		return dt.Name()
+	}
+}
+
+// newValidation returns the description of the constraints defined by v
+// except the required fields, nil if there is none.
+func newValidation(v *expr.ValidationExpr) *Validation {
+	if v == nil {
+		return nil
+	}
+	res := &Validation{
+		Format:           string(v.Format),
+		Pattern:          v.Pattern,
+		Minimum:          v.Minimum,
+		Maximum:          v.Maximum,
+		ExclusiveMinimum: v.ExclusiveMinimum,
+		ExclusiveMaximum: v.ExclusiveMaximum,
+		MultipleOf:       v.MultipleOf,
+		MinLength:        v.MinLength,
+		MaxLength:        v.MaxLength,
+		MaxBytes:         v.MaxBytes,
+		UniqueBy:         v.UniqueBy,
+		ContentMediaType: v.ContentMediaType,
+	}
+	for _, val := range v.Values {
+		res.Enum = append(res.Enum, fmt.Sprintf("%v", val))
+	}
+	sort.Strings(res.Enum)
+	if len(res.Enum) == 0 && res.Format == "" && res.Pattern == "" &&
+		res.Minimum == nil && res.Maximum == nil && res.ExclusiveMinimum == nil &&
+		res.ExclusiveMaximum == nil && res.MultipleOf == nil && res.MinLength == nil && res.MaxLength == nil &&
+		res.MaxBytes == nil && res.UniqueBy == "" && res.ContentMediaType == "" {
+		return nil
+	}
+	return res
+}
@@ -0,0 +1,61 @@
+package diff
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen/diff/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestNewSurface(t *testing.T) {
+	root := expr.RunDSL(t, testdata.SurfaceDSL)
+	s := NewSurface(root)
+	if len(s.Services) != 1 || len(s.Services[0].Methods) != 2 {
+		t.Fatalf("got %d services, expected 1 service with 2 methods", len(s.Services))
+	}
+
+	h := s.Services[0].Methods[0].HTTP
+	if h == nil {
+		t.Fatal("got no HTTP endpoint for method \"Method\"")
+	}
+	if len(h.Routes) != 1 || h.Routes[0] != "POST /nodes/{id}" {
+		t.Errorf("got routes %v, expected [POST /nodes/{id}]", h.Routes)
+	}
+	if len(h.Params) != 2 {
+		t.Fatalf("got %d params, expected 2", len(h.Params))
+	}
+	if p := h.Params[0]; p.Name != "id" || p.In != "path" || !p.Required || p.Type.Validation == nil || *p.Type.Validation.Minimum != 1 {
+		t.Errorf("got path param %+v, expected required id with minimum 1", p)
+	}
+	if p := h.Params[1]; p.Name != "Authorization" || p.In != "header" || p.Required {
+		t.Errorf("got header %+v, expected optional Authorization", p)
+	}
+	node := findField(h.Body.Fields, "node")
+	if node == nil {
+		t.Fatal("got no node field in request body")
+	}
+	if f := findField(node.Type.Fields, "name"); f == nil || f.Tag != "" || !f.Required {
+		t.Errorf("got HTTP name field %+v, expected required field with no tag", f)
+	}
+	if f := findField(node.Type.Fields, "child"); f == nil || f.Type.Ref == "" {
+		t.Errorf("got child field %+v, expected recursive reference", f)
+	}
+	if len(h.Responses) != 1 || h.Responses[0].Status != 201 || h.Responses[0].Body == nil {
+		t.Errorf("got responses %+v, expected 201 response with body", h.Responses)
+	}
+
+	g := s.Services[0].Methods[1].GRPC
+	if g == nil {
+		t.Fatal("got no gRPC endpoint for method \"Message\"")
+	}
+	if s.Services[0].Methods[1].HTTP != nil {
+		t.Error("got HTTP endpoint for method \"Message\", expected none")
+	}
+	f := findField(g.Request.Fields, "kind")
+	if f == nil || f.Tag != "2" {
+		t.Fatalf("got gRPC kind field %+v, expected tag 2", f)
+	}
+	if v := f.Type.Validation; v == nil || len(v.Enum) != 2 || v.Enum[0] != "branch" || v.Enum[1] != "leaf" {
+		t.Errorf("got validation %+v, expected sorted enum", v)
+	}
+}
@@ -0,0 +1,42 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var SurfaceDSL = func() {
+	var Node = Type("Node", func() {
+		Field(1, "name", String, func() {
+			Pattern("^[a-z]+$")
+			MaxLength(20)
+		})
+		Field(2, "kind", String, func() {
+			Enum("leaf", "branch")
+		})
+		Field(3, "child", "Node")
+		Required("name")
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", Int, func() {
+					Minimum(1)
+				})
+				Attribute("node", Node)
+				Attribute("token", String)
+				Required("id")
+			})
+			Result(Node)
+			HTTP(func() {
+				POST("/nodes/{id}")
+				Header("token:Authorization")
+				Response(StatusCreated)
+			})
+		})
+		Method("Message", func() {
+			Payload(Node)
+			Result(Node)
+			GRPC(func() {})
+		})
+	})
+}
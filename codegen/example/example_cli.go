@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/cli"
 	"goa.design/goa/v3/expr"
 )
 
@@ -32,13 +33,14 @@ func exampleCLIMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 	}
 	specs := []*codegen.ImportSpec{
 		{Path: "context"},
-		{Path: "encoding/json"},
 		{Path: "flag"},
 		{Path: "fmt"},
 		{Path: "net/url"},
 		{Path: "os"},
+		{Path: "path/filepath"},
 		{Path: "strings"},
 		codegen.GoaImport(""),
+		codegen.GoaNamedImport("cli", "goacli"),
 	}
 	sections := []*codegen.SectionTemplate{
 		codegen.Header("", "main", specs),
@@ -46,7 +48,8 @@ func exampleCLIMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 			Name:   "cli-main-start",
 			Source: cliMainStartT,
 			Data: map[string]interface{}{
-				"Server": svrdata,
+				"Server":  svrdata,
+				"APIName": cli.APIName(root.API),
 			},
 			FuncMap: map[string]interface{}{
 				"join": strings.Join,
@@ -73,13 +76,20 @@ func exampleCLIMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 				"toUpper": strings.ToUpper,
 			},
 		},
-		&codegen.SectionTemplate{Name: "cli-main-end", Source: cliMainEndT},
+		&codegen.SectionTemplate{
+			Name:   "cli-main-end",
+			Source: cliMainEndT,
+			Data: map[string]interface{}{
+				"Server": svrdata,
+			},
+		},
 		&codegen.SectionTemplate{
 			Name:   "cli-main-usage",
 			Source: cliMainUsageT,
 			Data: map[string]interface{}{
-				"APIName": root.API.Name,
-				"Server":  svrdata,
+				"APIName":    root.API.Name,
+				"ConfigName": cli.APIName(root.API),
+				"Server":     svrdata,
 			},
 			FuncMap: map[string]interface{}{
 				"toUpper": strings.ToUpper,
@@ -91,7 +101,7 @@ func exampleCLIMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 }
 
 const (
-	// input: map[string]interface{}{"Server": *Data}
+	// input: map[string]interface{}{"Server": *Data, "APIName": string}
 	cliMainStartT = `func main() {
 	var (
 		hostF = flag.String("host", {{ printf "%q" .Server.DefaultHost.Name }}, "Server host (valid values: {{ (join .Server.AvailableHosts ", ") }})")
@@ -102,9 +112,49 @@ const (
 		verboseF = flag.Bool("verbose", false, "Print request and response details")
 		vF = flag.Bool("v", false, "Print request and response details")
 		timeoutF = flag.Int("timeout", 30, "Maximum number of seconds to wait for response")
+		outputF = flag.String("output", "json", "Output format (valid values: json, yaml, table)")
+		queryF = flag.String("query", "", "JMESPath expression applied to the results")
 	)
+	flag.String("config", "", "Path to the configuration file that sets the flags not given explicitly")
 	flag.Usage = usage
 	flag.Parse()
+
+	if flag.Arg(0) == "completion" {
+		root := &goacli.Command{
+			Name:        filepath.Base(os.Args[0]),
+			Flags:       goacli.FlagsOf(flag.CommandLine),
+			Subcommands: {{ .Server.DefaultTransport.Type }}Commands(),
+		}
+		if err := goacli.WriteCompletion(os.Stdout, flag.Arg(1), root); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Set the flags not given explicitly to the values of the environment
+	// variables or of the configuration file if any.
+	{
+		defaults, err := goacli.NewDefaults({{ printf "%q" .APIName }}, flag.CommandLine)
+		if err == nil {
+			err = defaults.Apply(flag.CommandLine)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	}
+
+	var out *goacli.Output
+	{
+		var err error
+		out, err = goacli.NewOutput(*outputF, *queryF)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	}
+
 `
 
 	// input: map[string]interface{}{"Server": *Data}
@@ -190,6 +240,7 @@ const (
 	}
 `
 
+	// input: map[string]interface{}{"Server": *Data}
 	cliMainEndT = `
 	data, err := endpoint(context.Background(), payload)
 	if err != nil {
@@ -197,29 +248,64 @@ const (
 		os.Exit(1)
 	}
 
+	var stream *goacli.Stream
+	switch scheme {
+{{- range $t := .Server.Transports }}
+	case "{{ $t.Type }}", "{{ $t.Type }}s":
+		stream = {{ $t.Type }}Stream(data)
+{{- end }}
+	}
+	if stream != nil {
+		// Send the JSON values read from the standard input to the
+		// stream and write the results as they arrive, an interrupt
+		// signal closes the stream.
+		ctx, cancel := goacli.InterruptContext(context.Background())
+		err = stream.Run(ctx, os.Stdin, out.StreamWriter(os.Stdout))
+		cancel()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
 	if data != nil {
-		m, _ := json.MarshalIndent(data, "", "    ")
-		fmt.Println(string(m))
+		if cmd := goacli.Lookup({{ .Server.DefaultTransport.Type }}Commands(), flag.Arg(0), flag.Arg(1)); cmd != nil {
+			out.Columns = cmd.Columns
+		}
+		if err := out.Write(os.Stdout, data); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
 	}
 }
 `
 
-	// input: map[string]interface{}{"APIName": string, "Server": *Data}
+	// input: map[string]interface{}{"APIName": string, "ConfigName": string, "Server": *Data}
 	cliMainUsageT = `
 func usage() {
   fmt.Fprintf(os.Stderr, ` + "`" + `%s is a command line client for the {{ .APIName }} API.
 
 Usage:
-    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v]{{ range .Server.Variables }}[-{{ .Name }} {{ toUpper .Name }}]{{ end }} SERVICE ENDPOINT [flags]
+    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v][-config FILE][-output FORMAT][-query QUERY]{{ range .Server.Variables }}[-{{ .Name }} {{ toUpper .Name }}]{{ end }} SERVICE ENDPOINT [flags]
+    %s completion (bash|zsh|fish)
 
     -host HOST:  server host ({{ .Server.DefaultHost.Name }}). valid values: {{ (join .Server.AvailableHosts ", ") }}
     -url URL:    specify service URL overriding host URL (http://localhost:8080)
     -timeout:    maximum number of seconds to wait for response (30)
     -verbose|-v: print request and response details (false)
+    -config:     configuration file ($HOME/.config/{{ .ConfigName }}/config.json)
+    -output:     output format (json). valid values: json, yaml, table
+    -query:      JMESPath expression applied to the results, e.g. "[?rating > 3].name"
 	{{- range .Server.Variables }}
     -{{ .Name }}:    {{ .Description }} ({{ .DefaultValue }})
 	{{- end }}
 
+Environment:
+    The flags not given explicitly are read from the environment variables
+    {{ toUpper .ConfigName }}_FLAG for the global flags and {{ toUpper .ConfigName }}_SERVICE_ENDPOINT_FLAG
+    for the endpoint flags and then from the configuration file.
+
 Commands:
 %s
 Additional help:
@@ -227,7 +313,7 @@ Additional help:
 
 Example:
 %s
-` + "`" + `, os.Args[0], os.Args[0], indent({{ .Server.DefaultTransport.Type }}UsageCommands()), os.Args[0], indent({{ .Server.DefaultTransport.Type }}UsageExamples()))
+` + "`" + `, os.Args[0], os.Args[0], os.Args[0], indent({{ .Server.DefaultTransport.Type }}UsageCommands()), os.Args[0], indent({{ .Server.DefaultTransport.Type }}UsageExamples()))
 }
 
 func indent(s string) string {
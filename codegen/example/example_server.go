@@ -41,13 +41,16 @@ func exampleSvrMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 		{Path: "os/signal"},
 		{Path: "strings"},
 		{Path: "sync"},
+		{Path: "syscall"},
 		{Path: "time"},
+		codegen.GoaImport(""),
 		codegen.GoaImport("middleware"),
 	}
 
 	// Iterate through services listed in the server expression.
 	svcData := make([]*service.Data, len(svr.Services))
 	scope := codegen.NewNameScope()
+	logPkgs := make(map[string]string)
 	for i, svc := range svr.Services {
 		sd := service.Services.Get(svc)
 		svcData[i] = sd
@@ -55,6 +58,56 @@ func exampleSvrMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 			Path: path.Join(genpkg, codegen.SnakeCase(sd.VarName)),
 			Name: scope.Unique(sd.PkgName),
 		})
+		if s := root.Service(svc); s != nil && s.IsLogged() && len(s.Methods) > 0 {
+			logPkgs[svc] = scope.Unique(sd.PkgName + "log")
+			specs = append(specs, &codegen.ImportSpec{
+				Path: path.Join(genpkg, codegen.SnakeCase(sd.VarName), "logging"),
+				Name: logPkgs[svc],
+			})
+		}
+	}
+	if len(logPkgs) > 0 {
+		specs = append(specs, &codegen.ImportSpec{Path: "log/slog"})
+	}
+	cached := make(map[string]bool)
+	for _, svc := range svr.Services {
+		s := root.Service(svc)
+		if s == nil {
+			continue
+		}
+		for _, m := range s.Methods {
+			if m.Cache != nil {
+				cached[svc] = true
+				break
+			}
+		}
+	}
+	publishers := make(map[string]string)
+	for _, svc := range svr.Services {
+		s := root.Service(svc)
+		if s == nil || len(s.Topics()) == 0 {
+			continue
+		}
+		publishers[svc] = service.PublisherName(service.Services.Get(svc))
+	}
+	var metricsPkg string
+	metricsStreams := make(map[string]bool)
+	for _, svc := range svr.Services {
+		s := root.Service(svc)
+		if s == nil || !s.IsPrometheusInstrumented() || len(s.Methods) == 0 {
+			continue
+		}
+		if metricsPkg == "" {
+			metricsPkg = scope.Unique("metrics")
+			specs = append(specs,
+				&codegen.ImportSpec{Path: path.Join(genpkg, "metrics"), Name: metricsPkg},
+				&codegen.ImportSpec{Path: "github.com/prometheus/client_golang/prometheus"})
+		}
+		for _, m := range s.Methods {
+			if m.IsStreaming() {
+				metricsStreams[svc] = true
+			}
+		}
 	}
 
 	var (
@@ -62,12 +115,7 @@ func exampleSvrMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 		apiPkg   string
 	)
 	{
-		// genpkg is created by path.Join so the separator is / regardless of operating system
-		idx := strings.LastIndex(genpkg, string("/"))
-		rootPath = "."
-		if idx > 0 {
-			rootPath = genpkg[:idx]
-		}
+		rootPath = codegen.Layout.RootPackage(genpkg)
 		apiPkg = scope.Unique(strings.ToLower(codegen.Goify(root.API.Name, false)), "api")
 	}
 	specs = append(specs, &codegen.ImportSpec{Path: rootPath, Name: apiPkg})
@@ -110,15 +158,29 @@ func exampleSvrMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 			},
 			FuncMap: map[string]interface{}{
 				"mustInitServices": mustInitServices,
+				"loggingPkg":       func(svc string) string { return logPkgs[svc] },
+				"cachedSvc":        func(svc string) bool { return cached[svc] },
+				"cached":           func() bool { return len(cached) > 0 },
+				"publisher":        func(svc string) string { return publishers[svc] },
+				"publishes":        func() bool { return len(publishers) > 0 },
+				"metricsPkg":       func() string { return metricsPkg },
+				"metricsStreams":   func(svc string) bool { return metricsStreams[svc] },
+			},
+		},
+		&codegen.SectionTemplate{
+			Name:   "server-main-interrupts",
+			Source: mainInterruptsT,
+			Data: map[string]interface{}{
+				"Health": root.API.HealthCheck != nil,
 			},
 		},
-		&codegen.SectionTemplate{Name: "server-main-interrupts", Source: mainInterruptsT},
 		&codegen.SectionTemplate{
 			Name:   "server-main-handler",
 			Source: mainServerHndlrT,
 			Data: map[string]interface{}{
 				"Server":   svrdata,
 				"Services": svcData,
+				"Health":   root.API.HealthCheck != nil,
 			},
 			FuncMap: map[string]interface{}{
 				"goify":   codegen.Goify,
@@ -159,6 +221,7 @@ func main() {
 	{{- end }}
 		secureF = flag.Bool("secure", false, "Use secure scheme (https or grpcs)")
 		dbgF  = flag.Bool("debug", false, "Log request and response bodies")
+		graceF = flag.Duration("grace", 30*time.Second, "Grace period given to the in-flight requests and streams to complete on shutdown")
 	)
 	flag.Parse()
 `
@@ -172,6 +235,12 @@ func main() {
 	{
 		logger = log.New(os.Stderr, "[{{ .APIPkg }}] ", log.Ltime)
 	}
+
+	// Setup the reporter of the panics recovered by the servers and the
+	// endpoints. Replace it to send the panics to an error tracking service.
+	reporter := goa.PanicReporterFunc(func(_ context.Context, p interface{}, stack []byte) {
+		logger.Printf("panic: %v\n%s", p, stack)
+	})
 `
 
 	// input: map[string]interface{"APIPkg": string, "Services": []*service.Data}
@@ -207,15 +276,47 @@ func main() {
 	{{- end }}
 	)
 	{
+	{{- if cached }}
+		{{ comment "Memoize the results of the cached methods in memory. Replace the store with a shared cache to share the results between the service instances." }}
+		cache := goa.NewLRUCache(1024)
+	{{- end }}
+	{{- if publishes }}
+		{{ comment "Log the events emitted by the methods. Replace the publisher with goa.NewNATSPublisher or goa.NewKafkaPublisher to publish the events to a message broker." }}
+		events := goa.EventPublisherFunc(func(_ context.Context, topic string, ev interface{}) error {
+			logger.Printf("event %s: %+v", topic, ev)
+			return nil
+		})
+	{{- end }}
 	{{- range .Services }}
 		{{- if .Methods }}
 			{{ .VarName }}Endpoints = {{ .PkgName }}.NewEndpoints({{ .VarName }}Svc)
+			{{ .VarName }}Endpoints.Use(goa.RecoverEndpoint(reporter))
+			{{- if publisher .Name }}
+			{{ .VarName }}Endpoints.UsePublisher({{ .PkgName }}.New{{ publisher .Name }}(events))
+			{{- end }}
+			{{- if cachedSvc .Name }}
+			{{ .VarName }}Endpoints.UseCache(cache)
+			{{- end }}
+			{{- if metricsStreams .Name }}
+			{{ metricsPkg }}.Wrap{{ .StructName }}({{ .VarName }}Endpoints)
+			{{- end }}
+			{{- if loggingPkg .Name }}
+			{{ loggingPkg .Name }}.Wrap({{ .VarName }}Endpoints, slog.Default())
+			{{- end }}
 		{{- end }}
 	{{- end }}
 	}
+{{- if metricsPkg }}
+
+	{{ comment "Register the Prometheus metrics recorded by the generated metrics package." }}
+	if err := {{ metricsPkg }}.Register(prometheus.DefaultRegisterer); err != nil {
+		logger.Fatalf("failed to register metrics: %v", err)
+	}
+{{- end }}
 {{- end }}
 `
 
+	// input: map[string]interface{"Health": bool}
 	mainInterruptsT = `
 	// Create channel used by both the signal handler and server goroutines
 	// to notify the main goroutine when to stop the server.
@@ -225,15 +326,25 @@ func main() {
 	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
 	go func() {
 		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		errc <- fmt.Errorf("%s", <-c)
 	}()
 
+	// Create the drainer used by the servers to report their readiness and
+	// to drain the in-flight requests and streams on shutdown.
+	drainer := goa.NewDrainer(*graceF)
+{{- if .Health }}
+
+	// Create the health checks reported by the readiness endpoints. Register
+	// the checks of the service dependencies with health.Register.
+	health := goa.NewHealth(drainer)
+{{- end }}
+
 	var wg sync.WaitGroup
 	ctx, cancel := context.WithCancel(context.Background())
 `
 
-	// input: map[string]interface{"Server": *Data, "Services": []*service.Data}
+	// input: map[string]interface{"Server": *Data, "Services": []*service.Data, "Health": bool}
 	mainServerHndlrT = `
 	{{ comment "Start the servers and send errors (if any) to the error channel." }}
 	switch *hostF {
@@ -278,7 +389,7 @@ func main() {
 			} else if u.Port() == "" {
 				u.Host += ":{{ $u.Port }}"
 			}
-			handle{{ toUpper $u.Transport.Name }}Server(ctx, u, {{ range $.Services }}{{ if .Methods }}{{ .VarName }}Endpoints, {{ end }}{{ end }}&wg, errc, logger, *dbgF)
+			handle{{ toUpper $u.Transport.Name }}Server(ctx, u, {{ range $.Services }}{{ if .Methods }}{{ .VarName }}Endpoints, {{ end }}{{ end }}&wg, errc, drainer, {{ if $.Health }}health, {{ end }}reporter, logger, *dbgF)
 		}
 	{{- end }}
 	{{ end }}
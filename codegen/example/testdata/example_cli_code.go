@@ -9,9 +9,49 @@ const (
 		verboseF = flag.Bool("verbose", false, "Print request and response details")
 		vF       = flag.Bool("v", false, "Print request and response details")
 		timeoutF = flag.Int("timeout", 30, "Maximum number of seconds to wait for response")
+		outputF  = flag.String("output", "json", "Output format (valid values: json, yaml, table)")
+		queryF   = flag.String("query", "", "JMESPath expression applied to the results")
 	)
+	flag.String("config", "", "Path to the configuration file that sets the flags not given explicitly")
 	flag.Usage = usage
 	flag.Parse()
+
+	if flag.Arg(0) == "completion" {
+		root := &goacli.Command{
+			Name:        filepath.Base(os.Args[0]),
+			Flags:       goacli.FlagsOf(flag.CommandLine),
+			Subcommands: httpCommands(),
+		}
+		if err := goacli.WriteCompletion(os.Stdout, flag.Arg(1), root); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Set the flags not given explicitly to the values of the environment
+	// variables or of the configuration file if any.
+	{
+		defaults, err := goacli.NewDefaults("test_api", flag.CommandLine)
+		if err == nil {
+			err = defaults.Apply(flag.CommandLine)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	}
+
+	var out *goacli.Output
+	{
+		var err error
+		out, err = goacli.NewOutput(*outputF, *queryF)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	}
+
 	var (
 		addr    string
 		timeout int
@@ -76,9 +116,35 @@ const (
 		os.Exit(1)
 	}
 
+	var stream *goacli.Stream
+	switch scheme {
+	case "http", "https":
+		stream = httpStream(data)
+	case "grpc", "grpcs":
+		stream = grpcStream(data)
+	}
+	if stream != nil {
+		// Send the JSON values read from the standard input to the
+		// stream and write the results as they arrive, an interrupt
+		// signal closes the stream.
+		ctx, cancel := goacli.InterruptContext(context.Background())
+		err = stream.Run(ctx, os.Stdin, out.StreamWriter(os.Stdout))
+		cancel()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
 	if data != nil {
-		m, _ := json.MarshalIndent(data, "", "    ")
-		fmt.Println(string(m))
+		if cmd := goacli.Lookup(httpCommands(), flag.Arg(0), flag.Arg(1)); cmd != nil {
+			out.Columns = cmd.Columns
+		}
+		if err := out.Write(os.Stdout, data); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
 	}
 }
 
@@ -86,12 +152,21 @@ func usage() {
 	fmt.Fprintf(os.Stderr, ` + "`" + `%s is a command line client for the test api API.
 
 Usage:
-    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v] SERVICE ENDPOINT [flags]
+    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v][-config FILE][-output FORMAT][-query QUERY] SERVICE ENDPOINT [flags]
+    %s completion (bash|zsh|fish)
 
     -host HOST:  server host (localhost). valid values: localhost
     -url URL:    specify service URL overriding host URL (http://localhost:8080)
     -timeout:    maximum number of seconds to wait for response (30)
     -verbose|-v: print request and response details (false)
+    -config:     configuration file ($HOME/.config/test_api/config.json)
+    -output:     output format (json). valid values: json, yaml, table
+    -query:      JMESPath expression applied to the results, e.g. "[?rating > 3].name"
+
+Environment:
+    The flags not given explicitly are read from the environment variables
+    TEST_API_FLAG for the global flags and TEST_API_SERVICE_ENDPOINT_FLAG
+    for the endpoint flags and then from the configuration file.
 
 Commands:
 %s
@@ -100,7 +175,7 @@ Additional help:
 
 Example:
 %s
-` + "`" + `, os.Args[0], os.Args[0], indent(httpUsageCommands()), os.Args[0], indent(httpUsageExamples()))
+` + "`" + `, os.Args[0], os.Args[0], os.Args[0], indent(httpUsageCommands()), os.Args[0], indent(httpUsageExamples()))
 }
 
 func indent(s string) string {
@@ -119,9 +194,49 @@ func indent(s string) string {
 		verboseF = flag.Bool("verbose", false, "Print request and response details")
 		vF       = flag.Bool("v", false, "Print request and response details")
 		timeoutF = flag.Int("timeout", 30, "Maximum number of seconds to wait for response")
+		outputF  = flag.String("output", "json", "Output format (valid values: json, yaml, table)")
+		queryF   = flag.String("query", "", "JMESPath expression applied to the results")
 	)
+	flag.String("config", "", "Path to the configuration file that sets the flags not given explicitly")
 	flag.Usage = usage
 	flag.Parse()
+
+	if flag.Arg(0) == "completion" {
+		root := &goacli.Command{
+			Name:        filepath.Base(os.Args[0]),
+			Flags:       goacli.FlagsOf(flag.CommandLine),
+			Subcommands: httpCommands(),
+		}
+		if err := goacli.WriteCompletion(os.Stdout, flag.Arg(1), root); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Set the flags not given explicitly to the values of the environment
+	// variables or of the configuration file if any.
+	{
+		defaults, err := goacli.NewDefaults("single_server_single_host", flag.CommandLine)
+		if err == nil {
+			err = defaults.Apply(flag.CommandLine)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	}
+
+	var out *goacli.Output
+	{
+		var err error
+		out, err = goacli.NewOutput(*outputF, *queryF)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	}
+
 	var (
 		addr    string
 		timeout int
@@ -186,9 +301,35 @@ func indent(s string) string {
 		os.Exit(1)
 	}
 
+	var stream *goacli.Stream
+	switch scheme {
+	case "http", "https":
+		stream = httpStream(data)
+	case "grpc", "grpcs":
+		stream = grpcStream(data)
+	}
+	if stream != nil {
+		// Send the JSON values read from the standard input to the
+		// stream and write the results as they arrive, an interrupt
+		// signal closes the stream.
+		ctx, cancel := goacli.InterruptContext(context.Background())
+		err = stream.Run(ctx, os.Stdin, out.StreamWriter(os.Stdout))
+		cancel()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
 	if data != nil {
-		m, _ := json.MarshalIndent(data, "", "    ")
-		fmt.Println(string(m))
+		if cmd := goacli.Lookup(httpCommands(), flag.Arg(0), flag.Arg(1)); cmd != nil {
+			out.Columns = cmd.Columns
+		}
+		if err := out.Write(os.Stdout, data); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
 	}
 }
 
@@ -196,12 +337,21 @@ func usage() {
 	fmt.Fprintf(os.Stderr, ` + "`" + `%s is a command line client for the SingleServerSingleHost API.
 
 Usage:
-    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v] SERVICE ENDPOINT [flags]
+    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v][-config FILE][-output FORMAT][-query QUERY] SERVICE ENDPOINT [flags]
+    %s completion (bash|zsh|fish)
 
     -host HOST:  server host (dev). valid values: dev
     -url URL:    specify service URL overriding host URL (http://localhost:8080)
     -timeout:    maximum number of seconds to wait for response (30)
     -verbose|-v: print request and response details (false)
+    -config:     configuration file ($HOME/.config/single_server_single_host/config.json)
+    -output:     output format (json). valid values: json, yaml, table
+    -query:      JMESPath expression applied to the results, e.g. "[?rating > 3].name"
+
+Environment:
+    The flags not given explicitly are read from the environment variables
+    SINGLE_SERVER_SINGLE_HOST_FLAG for the global flags and SINGLE_SERVER_SINGLE_HOST_SERVICE_ENDPOINT_FLAG
+    for the endpoint flags and then from the configuration file.
 
 Commands:
 %s
@@ -210,7 +360,7 @@ Additional help:
 
 Example:
 %s
-` + "`" + `, os.Args[0], os.Args[0], indent(httpUsageCommands()), os.Args[0], indent(httpUsageExamples()))
+` + "`" + `, os.Args[0], os.Args[0], os.Args[0], indent(httpUsageCommands()), os.Args[0], indent(httpUsageExamples()))
 }
 
 func indent(s string) string {
@@ -238,9 +388,49 @@ func indent(s string) string {
 		verboseF  = flag.Bool("verbose", false, "Print request and response details")
 		vF        = flag.Bool("v", false, "Print request and response details")
 		timeoutF  = flag.Int("timeout", 30, "Maximum number of seconds to wait for response")
+		outputF   = flag.String("output", "json", "Output format (valid values: json, yaml, table)")
+		queryF    = flag.String("query", "", "JMESPath expression applied to the results")
 	)
+	flag.String("config", "", "Path to the configuration file that sets the flags not given explicitly")
 	flag.Usage = usage
 	flag.Parse()
+
+	if flag.Arg(0) == "completion" {
+		root := &goacli.Command{
+			Name:        filepath.Base(os.Args[0]),
+			Flags:       goacli.FlagsOf(flag.CommandLine),
+			Subcommands: httpCommands(),
+		}
+		if err := goacli.WriteCompletion(os.Stdout, flag.Arg(1), root); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Set the flags not given explicitly to the values of the environment
+	// variables or of the configuration file if any.
+	{
+		defaults, err := goacli.NewDefaults("single_server_single_host_with_variables", flag.CommandLine)
+		if err == nil {
+			err = defaults.Apply(flag.CommandLine)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	}
+
+	var out *goacli.Output
+	{
+		var err error
+		out, err = goacli.NewOutput(*outputF, *queryF)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	}
+
 	var (
 		addr    string
 		timeout int
@@ -312,9 +502,33 @@ func indent(s string) string {
 		os.Exit(1)
 	}
 
+	var stream *goacli.Stream
+	switch scheme {
+	case "http", "https":
+		stream = httpStream(data)
+	}
+	if stream != nil {
+		// Send the JSON values read from the standard input to the
+		// stream and write the results as they arrive, an interrupt
+		// signal closes the stream.
+		ctx, cancel := goacli.InterruptContext(context.Background())
+		err = stream.Run(ctx, os.Stdin, out.StreamWriter(os.Stdout))
+		cancel()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
 	if data != nil {
-		m, _ := json.MarshalIndent(data, "", "    ")
-		fmt.Println(string(m))
+		if cmd := goacli.Lookup(httpCommands(), flag.Arg(0), flag.Arg(1)); cmd != nil {
+			out.Columns = cmd.Columns
+		}
+		if err := out.Write(os.Stdout, data); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
 	}
 }
 
@@ -322,12 +536,16 @@ func usage() {
 	fmt.Fprintf(os.Stderr, ` + "`" + `%s is a command line client for the SingleServerSingleHostWithVariables API.
 
 Usage:
-    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v][-int INT][-uint UINT][-float32 FLOAT32][-int32 INT32][-int64 INT64][-uint32 UINT32][-uint64 UINT64][-float64 FLOAT64][-bool BOOL] SERVICE ENDPOINT [flags]
+    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v][-config FILE][-output FORMAT][-query QUERY][-int INT][-uint UINT][-float32 FLOAT32][-int32 INT32][-int64 INT64][-uint32 UINT32][-uint64 UINT64][-float64 FLOAT64][-bool BOOL] SERVICE ENDPOINT [flags]
+    %s completion (bash|zsh|fish)
 
     -host HOST:  server host (dev). valid values: dev
     -url URL:    specify service URL overriding host URL (http://localhost:8080)
     -timeout:    maximum number of seconds to wait for response (30)
     -verbose|-v: print request and response details (false)
+    -config:     configuration file ($HOME/.config/single_server_single_host_with_variables/config.json)
+    -output:     output format (json). valid values: json, yaml, table
+    -query:      JMESPath expression applied to the results, e.g. "[?rating > 3].name"
     -int:     (1)
     -uint:     (1)
     -float32:     (1.1)
@@ -338,6 +556,11 @@ Usage:
     -float64:     (1)
     -bool:     (true)
 
+Environment:
+    The flags not given explicitly are read from the environment variables
+    SINGLE_SERVER_SINGLE_HOST_WITH_VARIABLES_FLAG for the global flags and SINGLE_SERVER_SINGLE_HOST_WITH_VARIABLES_SERVICE_ENDPOINT_FLAG
+    for the endpoint flags and then from the configuration file.
+
 Commands:
 %s
 Additional help:
@@ -345,7 +568,7 @@ Additional help:
 
 Example:
 %s
-` + "`" + `, os.Args[0], os.Args[0], indent(httpUsageCommands()), os.Args[0], indent(httpUsageExamples()))
+` + "`" + `, os.Args[0], os.Args[0], os.Args[0], indent(httpUsageCommands()), os.Args[0], indent(httpUsageExamples()))
 }
 
 func indent(s string) string {
@@ -364,9 +587,49 @@ func indent(s string) string {
 		verboseF = flag.Bool("verbose", false, "Print request and response details")
 		vF       = flag.Bool("v", false, "Print request and response details")
 		timeoutF = flag.Int("timeout", 30, "Maximum number of seconds to wait for response")
+		outputF  = flag.String("output", "json", "Output format (valid values: json, yaml, table)")
+		queryF   = flag.String("query", "", "JMESPath expression applied to the results")
 	)
+	flag.String("config", "", "Path to the configuration file that sets the flags not given explicitly")
 	flag.Usage = usage
 	flag.Parse()
+
+	if flag.Arg(0) == "completion" {
+		root := &goacli.Command{
+			Name:        filepath.Base(os.Args[0]),
+			Flags:       goacli.FlagsOf(flag.CommandLine),
+			Subcommands: httpCommands(),
+		}
+		if err := goacli.WriteCompletion(os.Stdout, flag.Arg(1), root); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Set the flags not given explicitly to the values of the environment
+	// variables or of the configuration file if any.
+	{
+		defaults, err := goacli.NewDefaults("single_server_multiple_hosts", flag.CommandLine)
+		if err == nil {
+			err = defaults.Apply(flag.CommandLine)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	}
+
+	var out *goacli.Output
+	{
+		var err error
+		out, err = goacli.NewOutput(*outputF, *queryF)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	}
+
 	var (
 		addr    string
 		timeout int
@@ -431,9 +694,33 @@ func indent(s string) string {
 		os.Exit(1)
 	}
 
+	var stream *goacli.Stream
+	switch scheme {
+	case "http", "https":
+		stream = httpStream(data)
+	}
+	if stream != nil {
+		// Send the JSON values read from the standard input to the
+		// stream and write the results as they arrive, an interrupt
+		// signal closes the stream.
+		ctx, cancel := goacli.InterruptContext(context.Background())
+		err = stream.Run(ctx, os.Stdin, out.StreamWriter(os.Stdout))
+		cancel()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
 	if data != nil {
-		m, _ := json.MarshalIndent(data, "", "    ")
-		fmt.Println(string(m))
+		if cmd := goacli.Lookup(httpCommands(), flag.Arg(0), flag.Arg(1)); cmd != nil {
+			out.Columns = cmd.Columns
+		}
+		if err := out.Write(os.Stdout, data); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
 	}
 }
 
@@ -441,12 +728,21 @@ func usage() {
 	fmt.Fprintf(os.Stderr, ` + "`" + `%s is a command line client for the SingleServerMultipleHosts API.
 
 Usage:
-    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v] SERVICE ENDPOINT [flags]
+    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v][-config FILE][-output FORMAT][-query QUERY] SERVICE ENDPOINT [flags]
+    %s completion (bash|zsh|fish)
 
     -host HOST:  server host (dev). valid values: dev, stage
     -url URL:    specify service URL overriding host URL (http://localhost:8080)
     -timeout:    maximum number of seconds to wait for response (30)
     -verbose|-v: print request and response details (false)
+    -config:     configuration file ($HOME/.config/single_server_multiple_hosts/config.json)
+    -output:     output format (json). valid values: json, yaml, table
+    -query:      JMESPath expression applied to the results, e.g. "[?rating > 3].name"
+
+Environment:
+    The flags not given explicitly are read from the environment variables
+    SINGLE_SERVER_MULTIPLE_HOSTS_FLAG for the global flags and SINGLE_SERVER_MULTIPLE_HOSTS_SERVICE_ENDPOINT_FLAG
+    for the endpoint flags and then from the configuration file.
 
 Commands:
 %s
@@ -455,7 +751,7 @@ Additional help:
 
 Example:
 %s
-` + "`" + `, os.Args[0], os.Args[0], indent(httpUsageCommands()), os.Args[0], indent(httpUsageExamples()))
+` + "`" + `, os.Args[0], os.Args[0], os.Args[0], indent(httpUsageCommands()), os.Args[0], indent(httpUsageExamples()))
 }
 
 func indent(s string) string {
@@ -477,9 +773,49 @@ func indent(s string) string {
 		verboseF = flag.Bool("verbose", false, "Print request and response details")
 		vF       = flag.Bool("v", false, "Print request and response details")
 		timeoutF = flag.Int("timeout", 30, "Maximum number of seconds to wait for response")
+		outputF  = flag.String("output", "json", "Output format (valid values: json, yaml, table)")
+		queryF   = flag.String("query", "", "JMESPath expression applied to the results")
 	)
+	flag.String("config", "", "Path to the configuration file that sets the flags not given explicitly")
 	flag.Usage = usage
 	flag.Parse()
+
+	if flag.Arg(0) == "completion" {
+		root := &goacli.Command{
+			Name:        filepath.Base(os.Args[0]),
+			Flags:       goacli.FlagsOf(flag.CommandLine),
+			Subcommands: httpCommands(),
+		}
+		if err := goacli.WriteCompletion(os.Stdout, flag.Arg(1), root); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Set the flags not given explicitly to the values of the environment
+	// variables or of the configuration file if any.
+	{
+		defaults, err := goacli.NewDefaults("single_server_multiple_hosts_with_variables", flag.CommandLine)
+		if err == nil {
+			err = defaults.Apply(flag.CommandLine)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	}
+
+	var out *goacli.Output
+	{
+		var err error
+		out, err = goacli.NewOutput(*outputF, *queryF)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	}
+
 	var (
 		addr    string
 		timeout int
@@ -560,9 +896,33 @@ func indent(s string) string {
 		os.Exit(1)
 	}
 
+	var stream *goacli.Stream
+	switch scheme {
+	case "http", "https":
+		stream = httpStream(data)
+	}
+	if stream != nil {
+		// Send the JSON values read from the standard input to the
+		// stream and write the results as they arrive, an interrupt
+		// signal closes the stream.
+		ctx, cancel := goacli.InterruptContext(context.Background())
+		err = stream.Run(ctx, os.Stdin, out.StreamWriter(os.Stdout))
+		cancel()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
 	if data != nil {
-		m, _ := json.MarshalIndent(data, "", "    ")
-		fmt.Println(string(m))
+		if cmd := goacli.Lookup(httpCommands(), flag.Arg(0), flag.Arg(1)); cmd != nil {
+			out.Columns = cmd.Columns
+		}
+		if err := out.Write(os.Stdout, data); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
 	}
 }
 
@@ -570,16 +930,25 @@ func usage() {
 	fmt.Fprintf(os.Stderr, ` + "`" + `%s is a command line client for the SingleServerMultipleHostsWithVariables API.
 
 Usage:
-    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v][-version VERSION][-domain DOMAIN][-port PORT] SERVICE ENDPOINT [flags]
+    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v][-config FILE][-output FORMAT][-query QUERY][-version VERSION][-domain DOMAIN][-port PORT] SERVICE ENDPOINT [flags]
+    %s completion (bash|zsh|fish)
 
     -host HOST:  server host (dev). valid values: dev, stage
     -url URL:    specify service URL overriding host URL (http://localhost:8080)
     -timeout:    maximum number of seconds to wait for response (30)
     -verbose|-v: print request and response details (false)
+    -config:     configuration file ($HOME/.config/single_server_multiple_hosts_with_variables/config.json)
+    -output:     output format (json). valid values: json, yaml, table
+    -query:      JMESPath expression applied to the results, e.g. "[?rating > 3].name"
     -version:    Version (v1)
     -domain:    Domain (test)
     -port:    Port (8080)
 
+Environment:
+    The flags not given explicitly are read from the environment variables
+    SINGLE_SERVER_MULTIPLE_HOSTS_WITH_VARIABLES_FLAG for the global flags and SINGLE_SERVER_MULTIPLE_HOSTS_WITH_VARIABLES_SERVICE_ENDPOINT_FLAG
+    for the endpoint flags and then from the configuration file.
+
 Commands:
 %s
 Additional help:
@@ -587,7 +956,7 @@ Additional help:
 
 Example:
 %s
-` + "`" + `, os.Args[0], os.Args[0], indent(httpUsageCommands()), os.Args[0], indent(httpUsageExamples()))
+` + "`" + `, os.Args[0], os.Args[0], os.Args[0], indent(httpUsageCommands()), os.Args[0], indent(httpUsageExamples()))
 }
 
 func indent(s string) string {
@@ -11,6 +11,7 @@ const (
 		grpcPortF = flag.String("grpc-port", "", "gRPC port (overrides host gRPC port specified in service design)")
 		secureF   = flag.Bool("secure", false, "Use secure scheme (https or grpcs)")
 		dbgF      = flag.Bool("debug", false, "Log request and response bodies")
+		graceF    = flag.Duration("grace", 30*time.Second, "Grace period given to the in-flight requests and streams to complete on shutdown")
 	)
 	flag.Parse()
 
@@ -22,6 +23,12 @@ const (
 		logger = log.New(os.Stderr, "[testapi] ", log.Ltime)
 	}
 
+	// Setup the reporter of the panics recovered by the servers and the
+	// endpoints. Replace it to send the panics to an error tracking service.
+	reporter := goa.PanicReporterFunc(func(_ context.Context, p interface{}, stack []byte) {
+		logger.Printf("panic: %v\n%s", p, stack)
+	})
+
 	// Initialize the services.
 	var (
 		serviceSvc service.Service
@@ -37,6 +44,7 @@ const (
 	)
 	{
 		serviceEndpoints = service.NewEndpoints(serviceSvc)
+		serviceEndpoints.Use(goa.RecoverEndpoint(reporter))
 	}
 
 	// Create channel used by both the signal handler and server goroutines
@@ -47,10 +55,14 @@ const (
 	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
 	go func() {
 		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		errc <- fmt.Errorf("%s", <-c)
 	}()
 
+	// Create the drainer used by the servers to report their readiness and
+	// to drain the in-flight requests and streams on shutdown.
+	drainer := goa.NewDrainer(*graceF)
+
 	var wg sync.WaitGroup
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -76,7 +88,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":80"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, drainer, reporter, logger, *dbgF)
 		}
 
 		{
@@ -98,7 +110,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":8080"
 			}
-			handleGRPCServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleGRPCServer(ctx, u, serviceEndpoints, &wg, errc, drainer, reporter, logger, *dbgF)
 		}
 
 	default:
@@ -126,6 +138,7 @@ const (
 		grpcPortF = flag.String("grpc-port", "", "gRPC port (overrides host gRPC port specified in service design)")
 		secureF   = flag.Bool("secure", false, "Use secure scheme (https or grpcs)")
 		dbgF      = flag.Bool("debug", false, "Log request and response bodies")
+		graceF    = flag.Duration("grace", 30*time.Second, "Grace period given to the in-flight requests and streams to complete on shutdown")
 	)
 	flag.Parse()
 
@@ -137,6 +150,12 @@ const (
 		logger = log.New(os.Stderr, "[serviceapi] ", log.Ltime)
 	}
 
+	// Setup the reporter of the panics recovered by the servers and the
+	// endpoints. Replace it to send the panics to an error tracking service.
+	reporter := goa.PanicReporterFunc(func(_ context.Context, p interface{}, stack []byte) {
+		logger.Printf("panic: %v\n%s", p, stack)
+	})
+
 	// Initialize the services.
 	var (
 		serviceSvc service.Service
@@ -152,6 +171,7 @@ const (
 	)
 	{
 		serviceEndpoints = service.NewEndpoints(serviceSvc)
+		serviceEndpoints.Use(goa.RecoverEndpoint(reporter))
 	}
 
 	// Create channel used by both the signal handler and server goroutines
@@ -162,10 +182,14 @@ const (
 	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
 	go func() {
 		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		errc <- fmt.Errorf("%s", <-c)
 	}()
 
+	// Create the drainer used by the servers to report their readiness and
+	// to drain the in-flight requests and streams on shutdown.
+	drainer := goa.NewDrainer(*graceF)
+
 	var wg sync.WaitGroup
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -191,7 +215,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":80"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, drainer, reporter, logger, *dbgF)
 		}
 
 		{
@@ -213,7 +237,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":8080"
 			}
-			handleGRPCServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleGRPCServer(ctx, u, serviceEndpoints, &wg, errc, drainer, reporter, logger, *dbgF)
 		}
 
 	default:
@@ -241,6 +265,7 @@ const (
 		grpcPortF = flag.String("grpc-port", "", "gRPC port (overrides host gRPC port specified in service design)")
 		secureF   = flag.Bool("secure", false, "Use secure scheme (https or grpcs)")
 		dbgF      = flag.Bool("debug", false, "Log request and response bodies")
+		graceF    = flag.Duration("grace", 30*time.Second, "Grace period given to the in-flight requests and streams to complete on shutdown")
 	)
 	flag.Parse()
 
@@ -252,6 +277,12 @@ const (
 		logger = log.New(os.Stderr, "[singleserversinglehost] ", log.Ltime)
 	}
 
+	// Setup the reporter of the panics recovered by the servers and the
+	// endpoints. Replace it to send the panics to an error tracking service.
+	reporter := goa.PanicReporterFunc(func(_ context.Context, p interface{}, stack []byte) {
+		logger.Printf("panic: %v\n%s", p, stack)
+	})
+
 	// Initialize the services.
 	var (
 		serviceSvc service.Service
@@ -267,6 +298,7 @@ const (
 	)
 	{
 		serviceEndpoints = service.NewEndpoints(serviceSvc)
+		serviceEndpoints.Use(goa.RecoverEndpoint(reporter))
 	}
 
 	// Create channel used by both the signal handler and server goroutines
@@ -277,10 +309,14 @@ const (
 	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
 	go func() {
 		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		errc <- fmt.Errorf("%s", <-c)
 	}()
 
+	// Create the drainer used by the servers to report their readiness and
+	// to drain the in-flight requests and streams on shutdown.
+	drainer := goa.NewDrainer(*graceF)
+
 	var wg sync.WaitGroup
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -306,7 +342,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":80"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, drainer, reporter, logger, *dbgF)
 		}
 
 		{
@@ -328,7 +364,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":443"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, drainer, reporter, logger, *dbgF)
 		}
 
 		{
@@ -350,7 +386,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":8080"
 			}
-			handleGRPCServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleGRPCServer(ctx, u, serviceEndpoints, &wg, errc, drainer, reporter, logger, *dbgF)
 		}
 
 	default:
@@ -386,6 +422,7 @@ const (
 		bool_F    = flag.String("bool", "true", "")
 		secureF   = flag.Bool("secure", false, "Use secure scheme (https or grpcs)")
 		dbgF      = flag.Bool("debug", false, "Log request and response bodies")
+		graceF    = flag.Duration("grace", 30*time.Second, "Grace period given to the in-flight requests and streams to complete on shutdown")
 	)
 	flag.Parse()
 
@@ -397,6 +434,12 @@ const (
 		logger = log.New(os.Stderr, "[singleserversinglehostwithvariables] ", log.Ltime)
 	}
 
+	// Setup the reporter of the panics recovered by the servers and the
+	// endpoints. Replace it to send the panics to an error tracking service.
+	reporter := goa.PanicReporterFunc(func(_ context.Context, p interface{}, stack []byte) {
+		logger.Printf("panic: %v\n%s", p, stack)
+	})
+
 	// Initialize the services.
 	var (
 		serviceSvc service.Service
@@ -412,6 +455,7 @@ const (
 	)
 	{
 		serviceEndpoints = service.NewEndpoints(serviceSvc)
+		serviceEndpoints.Use(goa.RecoverEndpoint(reporter))
 	}
 
 	// Create channel used by both the signal handler and server goroutines
@@ -422,10 +466,14 @@ const (
 	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
 	go func() {
 		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		errc <- fmt.Errorf("%s", <-c)
 	}()
 
+	// Create the drainer used by the servers to report their readiness and
+	// to drain the in-flight requests and streams on shutdown.
+	drainer := goa.NewDrainer(*graceF)
+
 	var wg sync.WaitGroup
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -460,7 +508,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":80"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, drainer, reporter, logger, *dbgF)
 		}
 
 		{
@@ -491,7 +539,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":443"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, drainer, reporter, logger, *dbgF)
 		}
 
 	default:
@@ -518,6 +566,7 @@ const (
 		httpPortF = flag.String("http-port", "", "HTTP port (overrides host HTTP port specified in service design)")
 		secureF   = flag.Bool("secure", false, "Use secure scheme (https or grpcs)")
 		dbgF      = flag.Bool("debug", false, "Log request and response bodies")
+		graceF    = flag.Duration("grace", 30*time.Second, "Grace period given to the in-flight requests and streams to complete on shutdown")
 	)
 	flag.Parse()
 
@@ -529,6 +578,12 @@ const (
 		logger = log.New(os.Stderr, "[serverhostingservicewithfileserver] ", log.Ltime)
 	}
 
+	// Setup the reporter of the panics recovered by the servers and the
+	// endpoints. Replace it to send the panics to an error tracking service.
+	reporter := goa.PanicReporterFunc(func(_ context.Context, p interface{}, stack []byte) {
+		logger.Printf("panic: %v\n%s", p, stack)
+	})
+
 	// Create channel used by both the signal handler and server goroutines
 	// to notify the main goroutine when to stop the server.
 	errc := make(chan error)
@@ -537,10 +592,14 @@ const (
 	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
 	go func() {
 		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		errc <- fmt.Errorf("%s", <-c)
 	}()
 
+	// Create the drainer used by the servers to report their readiness and
+	// to drain the in-flight requests and streams on shutdown.
+	drainer := goa.NewDrainer(*graceF)
+
 	var wg sync.WaitGroup
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -566,7 +625,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":80"
 			}
-			handleHTTPServer(ctx, u, &wg, errc, logger, *dbgF)
+			handleHTTPServer(ctx, u, &wg, errc, drainer, reporter, logger, *dbgF)
 		}
 
 	default:
@@ -594,6 +653,7 @@ const (
 		grpcPortF = flag.String("grpc-port", "", "gRPC port (overrides host gRPC port specified in service design)")
 		secureF   = flag.Bool("secure", false, "Use secure scheme (https or grpcs)")
 		dbgF      = flag.Bool("debug", false, "Log request and response bodies")
+		graceF    = flag.Duration("grace", 30*time.Second, "Grace period given to the in-flight requests and streams to complete on shutdown")
 	)
 	flag.Parse()
 
@@ -605,6 +665,12 @@ const (
 		logger = log.New(os.Stderr, "[serverhostingservicesubset] ", log.Ltime)
 	}
 
+	// Setup the reporter of the panics recovered by the servers and the
+	// endpoints. Replace it to send the panics to an error tracking service.
+	reporter := goa.PanicReporterFunc(func(_ context.Context, p interface{}, stack []byte) {
+		logger.Printf("panic: %v\n%s", p, stack)
+	})
+
 	// Initialize the services.
 	var (
 		serviceSvc service.Service
@@ -620,6 +686,7 @@ const (
 	)
 	{
 		serviceEndpoints = service.NewEndpoints(serviceSvc)
+		serviceEndpoints.Use(goa.RecoverEndpoint(reporter))
 	}
 
 	// Create channel used by both the signal handler and server goroutines
@@ -630,10 +697,14 @@ const (
 	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
 	go func() {
 		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		errc <- fmt.Errorf("%s", <-c)
 	}()
 
+	// Create the drainer used by the servers to report their readiness and
+	// to drain the in-flight requests and streams on shutdown.
+	drainer := goa.NewDrainer(*graceF)
+
 	var wg sync.WaitGroup
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -659,7 +730,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":80"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, drainer, reporter, logger, *dbgF)
 		}
 
 		{
@@ -681,7 +752,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":8080"
 			}
-			handleGRPCServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleGRPCServer(ctx, u, serviceEndpoints, &wg, errc, drainer, reporter, logger, *dbgF)
 		}
 
 	default:
@@ -709,6 +780,7 @@ const (
 		grpcPortF = flag.String("grpc-port", "", "gRPC port (overrides host gRPC port specified in service design)")
 		secureF   = flag.Bool("secure", false, "Use secure scheme (https or grpcs)")
 		dbgF      = flag.Bool("debug", false, "Log request and response bodies")
+		graceF    = flag.Duration("grace", 30*time.Second, "Grace period given to the in-flight requests and streams to complete on shutdown")
 	)
 	flag.Parse()
 
@@ -720,6 +792,12 @@ const (
 		logger = log.New(os.Stderr, "[serverhostingmultipleservices] ", log.Ltime)
 	}
 
+	// Setup the reporter of the panics recovered by the servers and the
+	// endpoints. Replace it to send the panics to an error tracking service.
+	reporter := goa.PanicReporterFunc(func(_ context.Context, p interface{}, stack []byte) {
+		logger.Printf("panic: %v\n%s", p, stack)
+	})
+
 	// Initialize the services.
 	var (
 		serviceSvc        service.Service
@@ -738,7 +816,9 @@ const (
 	)
 	{
 		serviceEndpoints = service.NewEndpoints(serviceSvc)
+		serviceEndpoints.Use(goa.RecoverEndpoint(reporter))
 		anotherServiceEndpoints = anotherservice.NewEndpoints(anotherServiceSvc)
+		anotherServiceEndpoints.Use(goa.RecoverEndpoint(reporter))
 	}
 
 	// Create channel used by both the signal handler and server goroutines
@@ -749,10 +829,14 @@ const (
 	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
 	go func() {
 		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		errc <- fmt.Errorf("%s", <-c)
 	}()
 
+	// Create the drainer used by the servers to report their readiness and
+	// to drain the in-flight requests and streams on shutdown.
+	drainer := goa.NewDrainer(*graceF)
+
 	var wg sync.WaitGroup
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -778,7 +862,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":80"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, anotherServiceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(ctx, u, serviceEndpoints, anotherServiceEndpoints, &wg, errc, drainer, reporter, logger, *dbgF)
 		}
 
 		{
@@ -800,7 +884,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":8080"
 			}
-			handleGRPCServer(ctx, u, serviceEndpoints, anotherServiceEndpoints, &wg, errc, logger, *dbgF)
+			handleGRPCServer(ctx, u, serviceEndpoints, anotherServiceEndpoints, &wg, errc, drainer, reporter, logger, *dbgF)
 		}
 
 	default:
@@ -827,6 +911,7 @@ const (
 		httpPortF = flag.String("http-port", "", "HTTP port (overrides host HTTP port specified in service design)")
 		secureF   = flag.Bool("secure", false, "Use secure scheme (https or grpcs)")
 		dbgF      = flag.Bool("debug", false, "Log request and response bodies")
+		graceF    = flag.Duration("grace", 30*time.Second, "Grace period given to the in-flight requests and streams to complete on shutdown")
 	)
 	flag.Parse()
 
@@ -838,6 +923,12 @@ const (
 		logger = log.New(os.Stderr, "[singleservermultiplehosts] ", log.Ltime)
 	}
 
+	// Setup the reporter of the panics recovered by the servers and the
+	// endpoints. Replace it to send the panics to an error tracking service.
+	reporter := goa.PanicReporterFunc(func(_ context.Context, p interface{}, stack []byte) {
+		logger.Printf("panic: %v\n%s", p, stack)
+	})
+
 	// Initialize the services.
 	var (
 		serviceSvc service.Service
@@ -853,6 +944,7 @@ const (
 	)
 	{
 		serviceEndpoints = service.NewEndpoints(serviceSvc)
+		serviceEndpoints.Use(goa.RecoverEndpoint(reporter))
 	}
 
 	// Create channel used by both the signal handler and server goroutines
@@ -863,10 +955,14 @@ const (
 	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
 	go func() {
 		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		errc <- fmt.Errorf("%s", <-c)
 	}()
 
+	// Create the drainer used by the servers to report their readiness and
+	// to drain the in-flight requests and streams on shutdown.
+	drainer := goa.NewDrainer(*graceF)
+
 	var wg sync.WaitGroup
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -892,7 +988,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":80"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, drainer, reporter, logger, *dbgF)
 		}
 
 	case "stage":
@@ -915,7 +1011,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":443"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, drainer, reporter, logger, *dbgF)
 		}
 
 	default:
@@ -945,6 +1041,7 @@ const (
 		portF     = flag.String("port", "8080", "Port")
 		secureF   = flag.Bool("secure", false, "Use secure scheme (https or grpcs)")
 		dbgF      = flag.Bool("debug", false, "Log request and response bodies")
+		graceF    = flag.Duration("grace", 30*time.Second, "Grace period given to the in-flight requests and streams to complete on shutdown")
 	)
 	flag.Parse()
 
@@ -956,6 +1053,12 @@ const (
 		logger = log.New(os.Stderr, "[singleservermultiplehostswithvariables] ", log.Ltime)
 	}
 
+	// Setup the reporter of the panics recovered by the servers and the
+	// endpoints. Replace it to send the panics to an error tracking service.
+	reporter := goa.PanicReporterFunc(func(_ context.Context, p interface{}, stack []byte) {
+		logger.Printf("panic: %v\n%s", p, stack)
+	})
+
 	// Initialize the services.
 	var (
 		serviceSvc service.Service
@@ -971,6 +1074,7 @@ const (
 	)
 	{
 		serviceEndpoints = service.NewEndpoints(serviceSvc)
+		serviceEndpoints.Use(goa.RecoverEndpoint(reporter))
 	}
 
 	// Create channel used by both the signal handler and server goroutines
@@ -981,10 +1085,14 @@ const (
 	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
 	go func() {
 		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		errc <- fmt.Errorf("%s", <-c)
 	}()
 
+	// Create the drainer used by the servers to report their readiness and
+	// to drain the in-flight requests and streams on shutdown.
+	drainer := goa.NewDrainer(*graceF)
+
 	var wg sync.WaitGroup
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -1024,7 +1132,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":80"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, drainer, reporter, logger, *dbgF)
 		}
 
 	case "stage":
@@ -1049,7 +1157,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":443"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, drainer, reporter, logger, *dbgF)
 		}
 
 	default:
@@ -1076,6 +1184,7 @@ const (
 		grpcPortF = flag.String("grpc-port", "", "gRPC port (overrides host gRPC port specified in service design)")
 		secureF   = flag.Bool("secure", false, "Use secure scheme (https or grpcs)")
 		dbgF      = flag.Bool("debug", false, "Log request and response bodies")
+		graceF    = flag.Duration("grace", 30*time.Second, "Grace period given to the in-flight requests and streams to complete on shutdown")
 	)
 	flag.Parse()
 
@@ -1087,6 +1196,12 @@ const (
 		logger = log.New(os.Stderr, "[apiwithspaces] ", log.Ltime)
 	}
 
+	// Setup the reporter of the panics recovered by the servers and the
+	// endpoints. Replace it to send the panics to an error tracking service.
+	reporter := goa.PanicReporterFunc(func(_ context.Context, p interface{}, stack []byte) {
+		logger.Printf("panic: %v\n%s", p, stack)
+	})
+
 	// Initialize the services.
 	var (
 		serviceWithSpacesSvc servicewithspaces.Service
@@ -1102,6 +1217,7 @@ const (
 	)
 	{
 		serviceWithSpacesEndpoints = servicewithspaces.NewEndpoints(serviceWithSpacesSvc)
+		serviceWithSpacesEndpoints.Use(goa.RecoverEndpoint(reporter))
 	}
 
 	// Create channel used by both the signal handler and server goroutines
@@ -1112,10 +1228,14 @@ const (
 	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
 	go func() {
 		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		errc <- fmt.Errorf("%s", <-c)
 	}()
 
+	// Create the drainer used by the servers to report their readiness and
+	// to drain the in-flight requests and streams on shutdown.
+	drainer := goa.NewDrainer(*graceF)
+
 	var wg sync.WaitGroup
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -1141,7 +1261,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":80"
 			}
-			handleHTTPServer(ctx, u, serviceWithSpacesEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(ctx, u, serviceWithSpacesEndpoints, &wg, errc, drainer, reporter, logger, *dbgF)
 		}
 
 		{
@@ -1163,7 +1283,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":8080"
 			}
-			handleGRPCServer(ctx, u, serviceWithSpacesEndpoints, &wg, errc, logger, *dbgF)
+			handleGRPCServer(ctx, u, serviceWithSpacesEndpoints, &wg, errc, drainer, reporter, logger, *dbgF)
 		}
 
 	default:
@@ -3,8 +3,6 @@ package codegen
 import (
 	"bytes"
 	"fmt"
-	"go/ast"
-	"go/format"
 	"go/parser"
 	"go/scanner"
 	"go/token"
@@ -12,11 +10,9 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
-	"strings"
 	"text/template"
 
-	"golang.org/x/tools/go/ast/astutil"
-	"golang.org/x/tools/imports"
+	"goa.design/goa/v3/eval"
 )
 
 // Gendir is the name of the subdirectory of the output directory that contains
@@ -38,6 +34,19 @@ type (
 		// FinalizeFunc is called after the file has been generated. It
 		// is given the absolute path to the file as argument.
 		FinalizeFunc func(string) error
+		// Transforms lists the functions that modify the syntax tree of
+		// the generated Go source file before it is formatted, after the
+		// transforms registered with RegisterASTTransform.
+		Transforms []ASTTransform
+		// Service is the name of the service the file is generated for
+		// if the content of the file depends on the design of this
+		// service only. The files of a service are rendered
+		// concurrently with the files of the other services and may be
+		// cached, see Renderer.
+		Service string
+		// sectionEnds lists the offsets of the ends of the sections in
+		// the content last rendered by execute.
+		sectionEnds []int
 	}
 
 	// A SectionTemplate is a template and accompanying render data. The
@@ -52,6 +61,13 @@ type (
 		FuncMap map[string]interface{}
 		// Data used as input of template.
 		Data interface{}
+		// Expr is the design expression rendered by the section if
+		// any. It maps the declarations of the section back to the
+		// design, see SourceMap.
+		Expr eval.Expression
+		// override is the path to the file the source was read from if
+		// overridden, see OverrideTemplates.
+		override string
 	}
 )
 
@@ -72,19 +88,64 @@ func (f *File) Section(name string) []*SectionTemplate {
 // happens the smallest integer value greater than 1 to make it unique. Renders
 // returns the computed path.
 func (f *File) Render(dir string) (string, error) {
-	base, err := filepath.Abs(dir)
+	path, _, err := f.plan(dir, nil, false)
+	if err != nil || path == "" {
+		return "", err
+	}
+	src, err := f.execute()
 	if err != nil {
 		return "", err
 	}
+	if err := f.write(path, src); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// plan computes the absolute path of the file. It returns an empty path if the
+// file must be skipped because it already exists in planned or on disk unless
+// update is true in which case the returned boolean is true to indicate that
+// the rendered content must be merged with the existing file.
+func (f *File) plan(dir string, planned map[string]bool, update bool) (string, bool, error) {
+	base, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false, err
+	}
 	path := filepath.Join(base, f.Path)
+	var merge bool
 	if f.SkipExist {
+		if planned[path] {
+			return "", false, nil
+		}
 		if _, err = os.Stat(path); err == nil {
-			return "", nil
+			if !update {
+				return "", false, nil
+			}
+			merge = true
 		}
 	}
+	return path, merge, nil
+}
 
+// execute executes the section templates of the file and returns the
+// rendered content.
+func (f *File) execute() ([]byte, error) {
+	var buf bytes.Buffer
+	f.sectionEnds = make([]int, len(f.SectionTemplates))
+	for i, s := range f.SectionTemplates {
+		if err := s.Write(&buf); err != nil {
+			return nil, err
+		}
+		f.sectionEnds[i] = buf.Len()
+	}
+	return buf.Bytes(), nil
+}
+
+// write appends src to the file at path, formats the result if it is a Go
+// source file and runs the file finalizer.
+func (f *File) write(path string, src []byte) error {
 	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
-		return "", err
+		return err
 	}
 
 	file, err := os.OpenFile(
@@ -93,32 +154,31 @@ func (f *File) Render(dir string) (string, error) {
 		0644,
 	)
 	if err != nil {
-		return "", err
+		return err
 	}
-	for _, s := range f.SectionTemplates {
-		if err := s.Write(file); err != nil {
-			return "", err
-		}
+	if _, err := file.Write(src); err != nil {
+		file.Close()
+		return err
 	}
 	if err := file.Close(); err != nil {
-		return "", err
+		return err
 	}
 
 	// Format Go source files
 	if filepath.Ext(path) == ".go" {
-		if err := finalizeGoSource(path); err != nil {
-			return "", err
+		if err := formatGoSource(path, f.Transforms); err != nil {
+			return err
 		}
 	}
 
 	// Run finalizer if any
 	if f.FinalizeFunc != nil {
 		if err := f.FinalizeFunc(path); err != nil {
-			return "", err
+			return err
 		}
 	}
 
-	return path, nil
+	return nil
 }
 
 // Write writes the section to the given writer.
@@ -128,12 +188,19 @@ func (s *SectionTemplate) Write(w io.Writer) error {
 		funcs[k] = v
 	}
 	tmpl := template.Must(template.New(s.Name).Funcs(funcs).Parse(s.Source))
-	return tmpl.Execute(w, s.Data)
+	if err := tmpl.Execute(w, s.Data); err != nil {
+		if s.override != "" {
+			return fmt.Errorf("template override %s: %s", s.override, err)
+		}
+		return err
+	}
+	return nil
 }
 
-// finalizeGoSource removes unneeded imports from the given Go source file and
-// runs go fmt on it.
-func finalizeGoSource(path string) error {
+// finalizeGoSource applies the given transforms to the syntax tree of the Go
+// source file at path, removes the unused and duplicate imports and formats
+// the result using the goimports standard.
+func finalizeGoSource(path string, transforms []ASTTransform) error {
 	// Make sure file parses and print content if it does not.
 	fset := token.NewFileSet()
 	file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
@@ -143,41 +210,13 @@ func finalizeGoSource(path string) error {
 		scanner.PrintError(&buf, err)
 		return fmt.Errorf("%s\n========\nContent:\n%s", buf.String(), content)
 	}
-
-	// Clean unused imports
-	imps := astutil.Imports(fset, file)
-	for _, group := range imps {
-		for _, imp := range group {
-			path := strings.Trim(imp.Path.Value, `"`)
-			if !astutil.UsesImport(file, path) {
-				if imp.Name != nil {
-					astutil.DeleteNamedImport(fset, file, imp.Name.Name, path)
-				} else {
-					astutil.DeleteImport(fset, file, path)
-				}
-			}
+	for _, t := range transforms {
+		if err := t(fset, file, path); err != nil {
+			return err
 		}
 	}
-	ast.SortImports(fset, file)
-	w, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.ModePerm)
-	if err != nil {
-		return err
-	}
-	if err := format.Node(w, fset, file); err != nil {
-		return err
-	}
-	w.Close()
-
-	// Format code using goimport standard
-	bs, err := ioutil.ReadFile(path)
-	if err != nil {
-		return err
-	}
-	opt := imports.Options{
-		Comments:   true,
-		FormatOnly: true,
-	}
-	bs, err = imports.Process(path, bs, &opt)
+	CleanImports(fset, file)
+	bs, err := formatGoFile(fset, file, path)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,19 @@
+package generator
+
+import (
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/deploy"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Deploy iterates through the roots and returns the Dockerfile and Kubernetes
+// manifests of the servers that define deployment hints.
+func Deploy(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
+	for _, root := range roots {
+		if r, ok := root.(*expr.RootExpr); ok {
+			return deploy.Files(genpkg, r), nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,19 @@
+package generator
+
+import (
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/diff"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Diff iterates through the roots and returns the file that describes the
+// surface of the design compared by the "goa diff" command.
+func Diff(_ string, roots []eval.Root) ([]*codegen.File, error) {
+	for _, root := range roots {
+		if r, ok := root.(*expr.RootExpr); ok {
+			return []*codegen.File{diff.SurfaceFile(r)}, nil
+		}
+	}
+	return nil, nil
+}
@@ -9,13 +9,93 @@ The code generated for the service server and client includes:
     - A `service` package that contains the declarations for the service
       interfaces and endpoints which wrap the service methods.
     - A `views` package that contains code to render a result type using a view.
+    - A `jwtauth` package for the services that use JWT security schemes
+      defining a JSON Web Key Set with the "jwtauth:jwks" meta. The package
+      implements the JWT authorization function of the service.
+    - An `oauth2` package per OAuth2 security scheme whose token URL is hosted
+      by the API. The package implements the token endpoint HTTP handler and
+      a client that retrieves and refreshes the access tokens used by the
+      service clients with the client credentials grant.
+    - A `mock` package per service that implements the service interface with
+      configurable functions and returns the design examples by default. The
+      `mock/stub` command serves the mocks over the transports defined in the
+      design so that the API consumers may be tested against a stub server.
     - transport specific packages for each of the transports defined in the
       design.
+    - An `inmem` package per service and transport that wires the generated
+      client to the generated server in memory, without opening network ports,
+      so that unit tests may call the typed client methods against a service
+      implementation.
     - An example implementation of the client, server, and the service.
 
 OpenAPI
 
 The OpenAPI generator generates a OpenAPI v2 specification for the service
 REST endpoints. This generator requires the design to define the HTTP transport.
+If the API defines the "openapi:lint" meta the specification is checked against
+Spectral style lint rules and the generation fails on violations of rules with
+severity "error". The generator also writes the corresponding Spectral ruleset
+to gen/http/.spectral.yaml so that the same rules may be enforced in CI.
+
+Postman
+
+The Postman generator generates a Postman collection and environment for the
+HTTP endpoints if the API defines the "postman:collection" meta. The collection
+requests use the design examples and the environment defines the base URL and
+the security credentials.
+
+TypeScript
+
+The TypeScript generator generates a TypeScript client for the HTTP endpoints
+in the gen/http/typescript directory if the API defines the "typescript:client"
+meta. The client uses the fetch API, it declares interfaces matching the design
+types and a discriminated union of the errors of each method. Streaming
+results are received over websockets or chunked HTTP responses.
+
+Contract Tests
+
+The HTTP transport generator generates provider contract tests for the HTTP
+endpoints if the API defines the "contract:test" meta. The tests exercise a
+running server with requests built from the design examples and verify the
+status codes, the response bodies and headers and the mapping of the errors.
+They also send requests that omit required values or violate validations and
+expect the server to reject them.
+
+Fuzz Targets
+
+The transport generators generate Go fuzz targets for the HTTP and gRPC request
+decoders if the API defines the "fuzz:test" meta. The targets feed malformed
+parameters, headers, metadata and bodies to the decoders and fail if decoding
+or validating a request panics. The corpus is seeded with the design examples.
+
+Fixtures
+
+The transport generators generate golden fixtures for each method if the API
+defines the "fixtures:record" meta. The HTTP fixtures are raw requests and
+responses written to gen/http/<service>/fixtures, the gRPC fixtures are the
+JSON representations of the request and response messages written to
+gen/grpc/<service>/fixtures. Each directory also contains a package that loads
+the fixtures and replays the HTTP requests so that tests may verify that a
+release still accepts the requests and decodes the responses recorded by a
+previous one.
+
+JSON Schema
+
+The JSON Schema generator generates a standalone JSON schema (draft 2020-12) for
+each user and result type of the design in the gen/schemas directory. The
+schemas may be used to validate data independently of the OpenAPI
+specification.
+
+Locales
+
+The Locales generator writes the descriptions defined in multiple locales with
+the Description DSL to the gen/locales.json file. The file maps the names of
+the described design elements to their descriptions indexed by locale, it may
+be used to build localized developer portals.
+
+Diff
+
+The Diff generator writes the JSON representation of the design surface used
+by the "goa diff" command to detect breaking changes, see package diff.
 */
 package generator
@@ -0,0 +1,425 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"goa.design/goa/v3/eval"
+)
+
+// diffContext is the number of unchanged lines surrounding the changes in the
+// hunks of the unified diffs.
+const diffContext = 3
+
+// maxDiffEdits is the maximum number of line edits computed by the diff
+// algorithm. The changed lines of files that differ more are listed as
+// deleted and inserted as a whole to bound the time and memory spent.
+const maxDiffEdits = 2000
+
+type (
+	// diffOp is a line of a unified diff.
+	diffOp struct {
+		// kind is ' ' for an unchanged line, '-' for a deleted line and
+		// '+' for an inserted line.
+		kind byte
+		// line is the line including the trailing newline if any.
+		line string
+	}
+
+	// diffStat describes the changes made to a file.
+	diffStat struct {
+		// path is the path to the file relative to the output directory.
+		path string
+		// insertions is the number of inserted lines.
+		insertions int
+		// deletions is the number of deleted lines.
+		deletions int
+	}
+)
+
+// DryRun runs the code generation algorithms for the given command like
+// Generate but renders the files in a temporary directory instead of the
+// output directory dir. It writes the unified diff between the files located
+// in dir and the generated files to w followed by a summary of the changes and
+// returns true if there are changes. The files located in the directories that
+// the "gen" command deletes before generating the code that are not generated
+// anymore are listed as deleted. DryRun does not modify dir.
+func DryRun(dir, cmd string, w io.Writer) (bool, error) {
+	roots, err := eval.Context.Roots()
+	if err != nil {
+		return false, err
+	}
+	tmp, err := ioutil.TempDir("", "goa-dry-run")
+	if err != nil {
+		return false, err
+	}
+	defer os.RemoveAll(tmp)
+
+	// Computing the import path of the generated packages requires the
+	// directory to exist, delete it afterwards if it did not.
+	created := missingAncestor(filepath.Join(dir, NewLayout(roots).Gen))
+	if created != "" {
+		defer os.RemoveAll(created)
+	}
+	if _, err := generate(dir, tmp, cmd); err != nil {
+		return false, err
+	}
+
+	paths := make(map[string]bool)
+	if err := addFiles(tmp, tmp, paths); err != nil {
+		return false, err
+	}
+	if cmd == "gen" {
+		for _, d := range CleanupDirs(dir, roots) {
+			if err := addFiles(dir, d, paths); err != nil {
+				return false, err
+			}
+		}
+	}
+	sorted := make([]string, 0, len(paths))
+	for p := range paths {
+		sorted = append(sorted, p)
+	}
+	sort.Strings(sorted)
+
+	var stats []*diffStat
+	for _, p := range sorted {
+		before, bok := readIfExists(filepath.Join(dir, p))
+		after, aok := readIfExists(filepath.Join(tmp, p))
+		if bok && aok && bytes.Equal(before, after) {
+			continue
+		}
+		from, to := "a/"+filepath.ToSlash(p), "b/"+filepath.ToSlash(p)
+		if !bok {
+			from = "/dev/null"
+		}
+		if !aok {
+			to = "/dev/null"
+		}
+		st, err := writeUnifiedDiff(w, from, to, before, after)
+		if err != nil {
+			return false, err
+		}
+		st.path = filepath.ToSlash(p)
+		stats = append(stats, st)
+	}
+	if len(stats) == 0 {
+		return false, nil
+	}
+	return true, writeDiffStats(w, stats)
+}
+
+// missingAncestor returns the path to the topmost directory of the given path
+// that does not exist, an empty string if the path exists.
+func missingAncestor(path string) string {
+	var missing string
+	for p := filepath.Clean(path); ; p = filepath.Dir(p) {
+		if _, err := os.Stat(p); err == nil {
+			return missing
+		}
+		missing = p
+		if filepath.Dir(p) == p {
+			return missing
+		}
+	}
+}
+
+// addFiles adds the paths relative to base of the files located in dir and its
+// subdirectories to paths.
+func addFiles(base, dir string, paths map[string]bool) error {
+	err := filepath.Walk(dir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(base, p)
+		if err != nil {
+			return err
+		}
+		paths[rel] = true
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// readIfExists returns the content of the file at path and true if it exists.
+func readIfExists(path string) ([]byte, bool) {
+	b, err := ioutil.ReadFile(path)
+	return b, err == nil
+}
+
+// writeUnifiedDiff writes the unified diff between before and after to w and
+// returns the number of inserted and deleted lines.
+func writeUnifiedDiff(w io.Writer, from, to string, before, after []byte) (*diffStat, error) {
+	ops := diffLines(splitDiffLines(before), splitDiffLines(after))
+	st := &diffStat{}
+	for _, op := range ops {
+		switch op.kind {
+		case '+':
+			st.insertions++
+		case '-':
+			st.deletions++
+		}
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "--- %s\n+++ %s\n", from, to)
+	for _, h := range hunks(ops) {
+		var (
+			aline, bline   = 1, 1
+			acount, bcount int
+		)
+		for _, op := range ops[:h[0]] {
+			if op.kind != '+' {
+				aline++
+			}
+			if op.kind != '-' {
+				bline++
+			}
+		}
+		for _, op := range ops[h[0]:h[1]] {
+			if op.kind != '+' {
+				acount++
+			}
+			if op.kind != '-' {
+				bcount++
+			}
+		}
+		if acount == 0 {
+			aline--
+		}
+		if bcount == 0 {
+			bline--
+		}
+		fmt.Fprintf(&buf, "@@ -%s +%s @@\n", hunkRange(aline, acount), hunkRange(bline, bcount))
+		for _, op := range ops[h[0]:h[1]] {
+			buf.WriteByte(op.kind)
+			buf.WriteString(op.line)
+			if !strings.HasSuffix(op.line, "\n") {
+				buf.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+	}
+	_, err := w.Write(buf.Bytes())
+	return st, err
+}
+
+// hunkRange returns the range of a hunk header.
+func hunkRange(start, count int) string {
+	if count == 1 {
+		return fmt.Sprint(start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// hunks returns the start and end indices of the hunks of ops. A hunk contains
+// consecutive changes separated by at most 2*diffContext unchanged lines and
+// the diffContext unchanged lines surrounding them.
+func hunks(ops []diffOp) [][2]int {
+	var res [][2]int
+	for i := 0; i < len(ops); i++ {
+		if ops[i].kind == ' ' {
+			continue
+		}
+		start := i - diffContext
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for j := i; j < len(ops); j++ {
+			if ops[j].kind != ' ' {
+				end = j
+				continue
+			}
+			if j-end > 2*diffContext {
+				break
+			}
+		}
+		i = end
+		end += diffContext + 1
+		if end > len(ops) {
+			end = len(ops)
+		}
+		res = append(res, [2]int{start, end})
+	}
+	return res
+}
+
+// diffLines returns the shortest edit script that transforms the lines a into
+// the lines b computed with the Myers algorithm.
+func diffLines(a, b []string) []diffOp {
+	// Skip the common prefix and suffix.
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+	var ops []diffOp
+	for _, l := range a[:pre] {
+		ops = append(ops, diffOp{' ', l})
+	}
+	ops = append(ops, myers(a[pre:len(a)-suf], b[pre:len(b)-suf])...)
+	for _, l := range a[len(a)-suf:] {
+		ops = append(ops, diffOp{' ', l})
+	}
+	return ops
+}
+
+// myers returns the edit script that transforms a into b, see diffLines.
+func myers(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	replace := func() []diffOp {
+		ops := make([]diffOp, 0, n+m)
+		for _, l := range a {
+			ops = append(ops, diffOp{'-', l})
+		}
+		for _, l := range b {
+			ops = append(ops, diffOp{'+', l})
+		}
+		return ops
+	}
+	if n == 0 || m == 0 {
+		return replace()
+	}
+	var (
+		max   = n + m
+		off   = max + 1
+		v     = make([]int, 2*max+2)
+		trace [][]int
+	)
+	for d := 0; ; d++ {
+		if d > maxDiffEdits {
+			return replace()
+		}
+		trace = append(trace, append([]int(nil), v...))
+		done := false
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[off+k-1] < v[off+k+1]) {
+				x = v[off+k+1]
+			} else {
+				x = v[off+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[off+k] = x
+			if x >= n && y >= m {
+				done = true
+				break
+			}
+		}
+		if done {
+			break
+		}
+	}
+
+	// Backtrack to compute the edits in reverse order.
+	var (
+		ops  []diffOp
+		x, y = n, m
+	)
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		var pk int
+		if k == -d || (k != d && v[off+k-1] < v[off+k+1]) {
+			pk = k + 1
+		} else {
+			pk = k - 1
+		}
+		px := v[off+pk]
+		py := px - pk
+		for x > px && y > py {
+			ops = append(ops, diffOp{' ', a[x-1]})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == px {
+				ops = append(ops, diffOp{'+', b[y-1]})
+				y--
+			} else {
+				ops = append(ops, diffOp{'-', a[x-1]})
+				x--
+			}
+		}
+	}
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
+
+// splitDiffLines splits b into lines that include the trailing newline if any.
+func splitDiffLines(b []byte) []string {
+	var lines []string
+	for len(b) > 0 {
+		i := bytes.IndexByte(b, '\n')
+		if i < 0 {
+			lines = append(lines, string(b))
+			break
+		}
+		lines = append(lines, string(b[:i+1]))
+		b = b[i+1:]
+	}
+	return lines
+}
+
+// writeDiffStats writes the summary of the changes to w.
+func writeDiffStats(w io.Writer, stats []*diffStat) error {
+	// width is the maximum number of + and - signs in a line.
+	const width = 40
+	var (
+		buf        bytes.Buffer
+		plen, most int
+		ins, dels  int
+	)
+	for _, s := range stats {
+		if len(s.path) > plen {
+			plen = len(s.path)
+		}
+		if c := s.insertions + s.deletions; c > most {
+			most = c
+		}
+		ins += s.insertions
+		dels += s.deletions
+	}
+	cwidth := len(fmt.Sprint(most))
+	for _, s := range stats {
+		plus, minus := s.insertions, s.deletions
+		if most > width {
+			plus = (plus*width + most - 1) / most
+			minus = (minus*width + most - 1) / most
+		}
+		fmt.Fprintf(&buf, " %-*s | %*d %s%s\n", plen, s.path, cwidth, s.insertions+s.deletions,
+			strings.Repeat("+", plus), strings.Repeat("-", minus))
+	}
+	fmt.Fprintf(&buf, " %s changed, %s(+), %s(-)\n",
+		plural(len(stats), "file"), plural(ins, "insertion"), plural(dels, "deletion"))
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// plural returns n followed by the given noun, pluralized if n is not 1.
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,113 @@
+package generator_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/generator"
+	. "goa.design/goa/v3/dsl"
+)
+
+// dryRunDSL returns a design whose method has the given description.
+func dryRunDSL(desc string) func() {
+	return func() {
+		Service("DryRunService", func() {
+			Method("Method", func() {
+				Description(desc)
+				Payload(String)
+				Result(String)
+				HTTP(func() {
+					GET("/{p}")
+				})
+			})
+		})
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	// The output directory must be located in a Go module to compute the
+	// import path of the generated packages.
+	dir, err := ioutil.TempDir(".", "dryrun")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	codegen.RunDSL(t, dryRunDSL("desc"))
+	if _, err := generator.Generate(dir, "gen"); err != nil {
+		t.Fatal(err)
+	}
+	svc := filepath.Join(dir, "gen", "dry_run_service", "service.go")
+	before, err := ioutil.ReadFile(svc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale := filepath.Join(dir, "gen", "dry_run_service", "stale.go")
+	if err := ioutil.WriteFile(stale, []byte("package dryrunservice\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Changed  bool
+		Expected []string
+	}{
+		{"stale file", dryRunDSL("desc"), true, []string{
+			"--- a/gen/dry_run_service/stale.go\n+++ /dev/null\n@@ -1 +0,0 @@\n-package dryrunservice\n",
+			" gen/dry_run_service/stale.go | 1 -\n",
+			" 1 file changed, 0 insertions(+), 1 deletion(-)\n",
+		}},
+		{"description", dryRunDSL("other"), true, []string{
+			"--- a/gen/http/openapi.yaml\n+++ b/gen/http/openapi.yaml\n@@ -17,7 +17,7 @@\n",
+			"-      description: desc\n+      description: other\n",
+			" gen/http/openapi.yaml        | 2 +-\n",
+			" 3 files changed, 2 insertions(+), 3 deletions(-)\n",
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			codegen.RunDSL(t, c.DSL)
+			var buf bytes.Buffer
+			changed, err := generator.DryRun(dir, "gen", &buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if changed != c.Changed {
+				t.Errorf("got changed %v, expected %v", changed, c.Changed)
+			}
+			for _, e := range c.Expected {
+				if !strings.Contains(buf.String(), e) {
+					t.Errorf("got diff:\n%s\nexpected it to contain:\n%s", buf.String(), e)
+				}
+			}
+			after, err := ioutil.ReadFile(svc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(after, before) {
+				t.Errorf("dry run modified %s", svc)
+			}
+			if _, err := os.Stat(stale); err != nil {
+				t.Errorf("dry run deleted %s", stale)
+			}
+		})
+	}
+
+	if err := os.Remove(stale); err != nil {
+		t.Fatal(err)
+	}
+	codegen.RunDSL(t, dryRunDSL("desc"))
+	var buf bytes.Buffer
+	changed, err := generator.DryRun(dir, "gen", &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed || buf.Len() > 0 {
+		t.Errorf("got changes for up to date code:\n%s", buf.String())
+	}
+}
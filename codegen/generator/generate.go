@@ -4,33 +4,69 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sort"
+	"time"
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
 	"golang.org/x/tools/go/packages"
 )
 
-// Generate runs the code generation algorithms.
+var (
+	// Workers is the maximum number of files that Generate writes and
+	// formats concurrently.
+	Workers = runtime.NumCPU()
+
+	// CacheDir is the path to the directory used by Generate to cache the
+	// Go files generated for the services, see codegen.Renderer. No cache
+	// is used if CacheDir is empty.
+	CacheDir string
+
+	// Update causes the "example" command to merge the newly generated
+	// content with the example files that already exist instead of
+	// skipping them, see codegen.Renderer.
+	Update bool
+)
+
+// BaseDir is the path to the directory relative to the output directory that
+// contains the content of the example files as last generated. The content is
+// used as base when merging the files in update mode.
+var BaseDir = filepath.Join(".goa", "example")
+
+// Generate runs the code generation algorithms. The time spent in each step is
+// recorded in Profiling if not nil.
 func Generate(dir, cmd string) ([]string, error) {
+	return generate(dir, dir, cmd)
+}
+
+// generate runs the code generation algorithms for the output directory dir and
+// writes the files to the directory out. The files that must be skipped when
+// they exist are skipped if they exist in dir.
+func generate(dir, out, cmd string) ([]string, error) {
 	// 1. Compute design roots.
 	var roots []eval.Root
 	{
+		start := time.Now()
 		rs, err := eval.Context.Roots()
 		if err != nil {
 			return nil, err
 		}
 		roots = rs
+		codegen.Layout = NewLayout(roots)
+		record("roots", "", start)
 	}
 
 	// 2. Compute "gen" package import path.
 	var genpkg string
 	{
+		start := time.Now()
 		base, err := filepath.Abs(dir)
 		if err != nil {
 			return nil, err
 		}
-		path := filepath.Join(base, codegen.Gendir)
+		path := filepath.Join(base, codegen.Layout.Gen)
 		if err := os.MkdirAll(path, 0777); err != nil {
 			return nil, err
 		}
@@ -50,6 +86,7 @@ func Generate(dir, cmd string) ([]string, error) {
 			return nil, err
 		}
 		genpkg = pkgs[0].PkgPath
+		record("genpkg", "", start)
 	}
 
 	// 3. Retrieve goa generators for given command.
@@ -63,40 +100,121 @@ func Generate(dir, cmd string) ([]string, error) {
 	}
 
 	// 4. Run the code pre generation plugins.
+	start := time.Now()
 	err := codegen.RunPluginsPrepare(cmd, genpkg, roots)
 	if err != nil {
 		return nil, err
 	}
+	record("plugins prepare", "", start)
 
 	// 5. Generate initial set of files produced by goa code generators.
 	var genfiles []*codegen.File
+	start = time.Now()
 	for _, gen := range genfuncs {
+		genstart := time.Now()
 		fs, err := gen(genpkg, roots)
 		if err != nil {
 			return nil, err
 		}
+		record("generators", genfuncName(gen), genstart)
 		genfiles = append(genfiles, fs...)
 	}
+	record("generators", "", start)
 
 	// 6. Run the code generation plugins.
+	start = time.Now()
 	genfiles, err = codegen.RunPlugins(cmd, genpkg, roots, genfiles)
 	if err != nil {
 		return nil, err
 	}
+	record("plugins", "", start)
+
+	// 7. Apply the template overrides, the compatibility layer and the
+	// output layout.
+	if err := codegen.OverrideTemplates(filepath.Join(dir, codegen.TemplatesDir), genfiles); err != nil {
+		return nil, err
+	}
+	compat := compatVersion(roots)
+	if compat != "" && cmd == "gen" {
+		t := codegen.CompatTransform(compat)
+		for _, f := range genfiles {
+			if filepath.Ext(f.Path) == ".go" && !f.SkipExist {
+				f.Transforms = append(f.Transforms, t)
+			}
+		}
+	}
+	if err := relocate(out, genfiles); err != nil {
+		return nil, err
+	}
 
-	// 7. Write the files.
-	written := make(map[string]struct{})
-	for _, f := range genfiles {
-		filename, err := f.Render(dir)
+	// 8. Write the files.
+	var (
+		written   = make(map[string]struct{})
+		sourceMap *codegen.SourceMap
+		rendered  []string
+	)
+	{
+		start = time.Now()
+		if out != dir {
+			genfiles = skipExisting(dir, genfiles)
+		}
+		r := &codegen.Renderer{Workers: Workers, CacheDir: CacheDir}
+		if CacheDir != "" {
+			r.CacheKeys = serviceFingerprints(genpkg, roots)
+		}
+		if cmd == "example" {
+			r.Update = Update
+			r.BaseDir = filepath.Join(dir, BaseDir)
+		}
+		if cmd == "gen" {
+			r.SourceMap = &codegen.SourceMap{}
+		}
+		if p := Profiling; p != nil {
+			base, _ := filepath.Abs(out)
+			r.Trace = func(t *codegen.RenderTrace) {
+				name := t.Path
+				if rel, err := filepath.Rel(base, t.Path); err == nil {
+					name = filepath.ToSlash(rel)
+				}
+				p.Record("render", name, t.Execute+t.Write, t.Cached)
+			}
+		}
+		filenames, err := r.Render(out, genfiles)
 		if err != nil {
 			return nil, err
 		}
-		if filename != "" {
+		sourceMap, rendered = r.SourceMap, filenames
+		for _, filename := range filenames {
 			written[filename] = struct{}{}
 		}
+		record("render", "", start)
 	}
 
-	// 8. Compute all output filenames.
+	// 9. Write the report of the goa runtime API used by the generated code
+	// and the source map.
+	if cmd == "gen" {
+		base, err := filepath.Abs(out)
+		if err != nil {
+			return nil, err
+		}
+		gendir := filepath.Join(base, codegen.Layout.Gen)
+		p, err := writeRuntimeReport(gendir, genpkg, compat)
+		if err != nil {
+			return nil, err
+		}
+		written[p] = struct{}{}
+		root, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		p, err = writeSourceMap(base, gendir, filepath.Join(root, codegen.Layout.Gen), sourceMap, rendered)
+		if err != nil {
+			return nil, err
+		}
+		written[p] = struct{}{}
+	}
+
+	// 10. Compute all output filenames.
 	var outputs []string
 	{
 		outputs = make([]string, len(written))
@@ -118,3 +236,70 @@ func Generate(dir, cmd string) ([]string, error) {
 
 	return outputs, nil
 }
+
+// skipExisting returns the files minus the files that must be skipped when they
+// exist and that exist in dir.
+func skipExisting(dir string, files []*codegen.File) []*codegen.File {
+	res := files[:0:0]
+	for _, f := range files {
+		if f.SkipExist {
+			if _, err := os.Stat(filepath.Join(dir, f.Path)); err == nil {
+				continue
+			}
+		}
+		res = append(res, f)
+	}
+	return res
+}
+
+// NewLayout returns the output layout defined by the design in roots, the
+// default layout if there is none.
+func NewLayout(roots []eval.Root) *codegen.OutputLayout {
+	for _, root := range roots {
+		if r, ok := root.(*expr.RootExpr); ok {
+			return codegen.NewOutputLayout(r.API)
+		}
+	}
+	return codegen.DefaultLayout()
+}
+
+// CleanupDirs returns the paths to the subdirectories of the generated Go
+// packages directory located in dir according to the output layout defined by
+// the design in roots. The "gen" command deletes them before generating the
+// code.
+func CleanupDirs(dir string, roots []eval.Root) []string {
+	gendir := filepath.Join(dir, NewLayout(roots).Gen)
+	fis, err := ioutil.ReadDir(gendir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return []string{gendir}
+	}
+	var dirs []string
+	for _, fi := range fis {
+		if fi.IsDir() {
+			dirs = append(dirs, filepath.Join(gendir, fi.Name()))
+		}
+	}
+	return dirs
+}
+
+// relocate moves the files according to codegen.Layout. The existing files at
+// the new paths are deleted unless the files must be skipped when they exist
+// as the content of the rendered files is appended to them otherwise. This
+// also applies to the files that are not relocated as they may be located
+// outside of the cleaned up directories, e.g. the files produced by the
+// generators defined in the design.
+func relocate(dir string, files []*codegen.File) error {
+	for _, f := range files {
+		f.Path = codegen.Layout.Relocate(f.Path)
+		if f.SkipExist {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, f.Path)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
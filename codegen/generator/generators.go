@@ -21,9 +21,11 @@ var Generators = generators
 func generators(cmd string) ([]Genfunc, error) {
 	switch cmd {
 	case "gen":
-		return []Genfunc{Service, Transport, OpenAPI}, nil
+		return []Genfunc{Service, Transport, OpenAPI, Postman, TypeScript, JSONSchema, Locales, Deploy, Routes, Inline}, nil
 	case "example":
 		return []Genfunc{Example}, nil
+	case "diff":
+		return []Genfunc{Diff}, nil
 	default:
 		return nil, fmt.Errorf("unknown command %q", cmd)
 	}
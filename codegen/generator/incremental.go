@@ -0,0 +1,375 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+const (
+	// DesignArea is the area of the design that contains the API, the
+	// services, methods and types and the list of the services exposed by
+	// each transport. All the generators run when it changes.
+	DesignArea = "design"
+	// HTTPArea is the area of the design that contains the HTTP
+	// expressions.
+	HTTPArea = "http"
+	// GRPCArea is the area of the design that contains the gRPC
+	// expressions.
+	GRPCArea = "grpc"
+)
+
+// IncrementalState is the content of the file that holds the fingerprints of
+// the design areas between two incremental generations, see Incremental.
+type IncrementalState struct {
+	// Fingerprints maps the design areas to their digests.
+	Fingerprints map[string]string `json:"fingerprints"`
+	// Changed lists the areas that changed since the previous generation,
+	// all the areas if there was none.
+	Changed []string `json:"changed"`
+	// Templates is the digest of the template overrides, see
+	// codegen.OverrideTemplates.
+	Templates string `json:"templates,omitempty"`
+}
+
+// Incremental returns the generators of the "gen" command that must run to
+// regenerate the files affected by the changes made to the design since the
+// fingerprints stored in the file at path were computed. It stores the new
+// fingerprints and the changed areas in the file. All the generators run if
+// the file does not exist, if the design uses plugins that define their own
+// roots or if the template overrides changed, the returned boolean is true in
+// this case and the output directory should be cleaned up before generating
+// the files.
+//
+// Only the generators of the changed areas run otherwise: a change to the
+// HTTP expressions regenerates the HTTP transport code, the OpenAPI
+// specification and the HTTP clients while a change to the gRPC expressions
+// regenerates the gRPC transport code. Both regenerate the routes registry. Any other change regenerates all the
+// files. The generators defined in the design with codegen.Generate always
+// run. The returned generators delete the existing files they produce from
+// the output directory dir so that the files are rendered from scratch.
+func Incremental(path, dir string, roots []eval.Root) ([]Genfunc, bool, error) {
+	var prev IncrementalState
+	if b, err := ioutil.ReadFile(path); err == nil {
+		if err := json.Unmarshal(b, &prev); err != nil {
+			return nil, false, fmt.Errorf("invalid incremental state %q: %s", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, false, err
+	}
+
+	templates, err := templatesFingerprint(filepath.Join(dir, codegen.TemplatesDir))
+	if err != nil {
+		return nil, false, err
+	}
+	state := IncrementalState{Fingerprints: Fingerprints(roots), Templates: templates}
+	for _, a := range []string{DesignArea, HTTPArea, GRPCArea} {
+		if fp, ok := prev.Fingerprints[a]; !ok || fp != state.Fingerprints[a] {
+			state.Changed = append(state.Changed, a)
+		}
+	}
+	if hasPluginRoots(roots) || prev.Fingerprints == nil || prev.Templates != templates {
+		state.Changed = []string{DesignArea, HTTPArea, GRPCArea}
+	}
+	b, err := json.MarshalIndent(&state, "", "  ")
+	if err != nil {
+		return nil, false, err
+	}
+	if err := ioutil.WriteFile(path, b, 0644); err != nil {
+		return nil, false, err
+	}
+	gens := affected(state.Changed)
+	if hasInlineGenerators(roots) {
+		// The fingerprints do not reflect the code of the generators
+		// defined in the design, they always run.
+		gens = append(gens, Inline)
+	}
+	if len(state.Changed) > 0 && state.Changed[0] == DesignArea {
+		return gens, true, nil
+	}
+	for i, gen := range gens {
+		gens[i] = overwrite(dir, gen)
+	}
+	return gens, false, nil
+}
+
+// templatesFingerprint returns the digest of the template override files
+// located in dir, an empty string if there are none.
+func templatesFingerprint(dir string) (string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil || len(paths) == 0 {
+		return "", err
+	}
+	sort.Strings(paths)
+	h := sha256.New()
+	for _, p := range paths {
+		b, err := ioutil.ReadFile(p)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00", filepath.Base(p), len(b))
+		h.Write(b)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// overwrite returns a generator that deletes the files produced by gen from
+// dir, the files would otherwise be appended to the existing ones.
+func overwrite(dir string, gen Genfunc) Genfunc {
+	return func(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
+		files, err := gen(genpkg, roots)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			if f.SkipExist {
+				continue
+			}
+			if err := os.Remove(filepath.Join(dir, f.Path)); err != nil && !os.IsNotExist(err) {
+				return nil, err
+			}
+		}
+		return files, nil
+	}
+}
+
+// hasPluginRoots returns true if roots contains expressions defined by plugins.
+func hasPluginRoots(roots []eval.Root) bool {
+	for _, r := range roots {
+		switch r.(type) {
+		case *expr.RootExpr, *expr.GeneratedRoot:
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// hasInlineGenerators returns true if the design defines generators with
+// codegen.Generate.
+func hasInlineGenerators(roots []eval.Root) bool {
+	for _, r := range roots {
+		if rt, ok := r.(*expr.RootExpr); ok && rt.API != nil && len(rt.API.Generators) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// affected returns the generators of the "gen" command that produce the files
+// that depend on the given areas.
+func affected(areas []string) []Genfunc {
+	var http, grpc bool
+	for _, a := range areas {
+		switch a {
+		case DesignArea:
+			return []Genfunc{Service, Transport, OpenAPI, Postman, TypeScript, JSONSchema, Locales, Deploy, Routes}
+		case HTTPArea:
+			http = true
+		case GRPCArea:
+			grpc = true
+		}
+	}
+	var gens []Genfunc
+	if http {
+		gens = append(gens, HTTPTransport, OpenAPI, Postman, TypeScript)
+	}
+	if grpc {
+		gens = append(gens, GRPCTransport)
+	}
+	if http || grpc {
+		// Descriptions may be defined in the transport expressions and
+		// the routes registry lists the routes of both transports.
+		gens = append(gens, Locales, Routes)
+	}
+	return gens
+}
+
+// Fingerprints returns the digests of the design areas. The digests are
+// computed from the values of the exported fields of the expressions
+// reachable from each area.
+func Fingerprints(roots []eval.Root) map[string]string {
+	var r *expr.RootExpr
+	for _, root := range roots {
+		if rt, ok := root.(*expr.RootExpr); ok {
+			r = rt
+			break
+		}
+	}
+	if r == nil {
+		return nil
+	}
+	var transports []string
+	if r.API.HTTP != nil {
+		for _, svc := range r.API.HTTP.Services {
+			transports = append(transports, "http:"+svc.Name())
+		}
+	}
+	if r.API.GRPC != nil {
+		for _, svc := range r.API.GRPC.Services {
+			transports = append(transports, "grpc:"+svc.Name())
+		}
+	}
+	// The transport expressions reference the services and methods, ignore
+	// them so that the transport areas only change with the transport
+	// expressions.
+	var design []interface{}
+	for _, svc := range r.Services {
+		design = append(design, svc)
+		for _, m := range svc.Methods {
+			design = append(design, m)
+		}
+	}
+	design = design[:len(design):len(design)]
+	return map[string]string{
+		DesignArea: fingerprint([]interface{}{r, transports}, r.API.HTTP, r.API.GRPC),
+		HTTPArea:   fingerprint(r.API.HTTP, append(design, r.API.GRPC)...),
+		GRPCArea:   fingerprint(r.API.GRPC, append(design, r.API.HTTP)...),
+	}
+}
+
+// serviceFingerprints returns the digests of the design the content of the
+// files generated for each service depends on indexed by service name, see
+// codegen.Renderer. The digest of a service is computed from the design
+// ignoring the other services and their transport expressions, from the
+// import path of the generated packages, from the output layout and from the
+// command line recorded in the file headers. It
+// returns nil if the design uses plugins that define their own roots as the
+// files may depend on them.
+func serviceFingerprints(genpkg string, roots []eval.Root) map[string]string {
+	if hasPluginRoots(roots) {
+		return nil
+	}
+	var r *expr.RootExpr
+	for _, root := range roots {
+		if rt, ok := root.(*expr.RootExpr); ok {
+			r = rt
+			break
+		}
+	}
+	if r == nil || r.API == nil {
+		return nil
+	}
+	fps := make(map[string]string, len(r.Services))
+	for _, svc := range r.Services {
+		var others []interface{}
+		for _, o := range r.Services {
+			if o != svc {
+				others = append(others, o)
+			}
+		}
+		if r.API.HTTP != nil {
+			for _, o := range r.API.HTTP.Services {
+				if o.ServiceExpr != svc {
+					others = append(others, o)
+				}
+			}
+		}
+		if r.API.GRPC != nil {
+			for _, o := range r.API.GRPC.Services {
+				if o.ServiceExpr != svc {
+					others = append(others, o)
+				}
+			}
+		}
+		fps[svc.Name] = fingerprint([]interface{}{genpkg, codegen.Layout, codegen.CommandLine(), r}, others...)
+	}
+	return fps
+}
+
+// fingerprint returns the digest of v ignoring the values referenced by the
+// given pointers.
+func fingerprint(v interface{}, skip ...interface{}) string {
+	h := &hasher{Hash: sha256.New(), seen: make(map[uintptr]int)}
+	for _, s := range skip {
+		if p := reflect.ValueOf(s); p.Kind() == reflect.Ptr && !p.IsNil() {
+			h.seen[p.Pointer()] = -1
+		}
+	}
+	h.walk(reflect.ValueOf(v))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// hasher computes the digest of a graph of values. Each pointer is followed
+// once, the following occurrences are hashed as references to the first one
+// so that cycles terminate and the digest does not depend on the addresses.
+type hasher struct {
+	hash.Hash
+	seen map[uintptr]int
+}
+
+// walk hashes v.
+func (h *hasher) walk(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Invalid:
+		io.WriteString(h, "nil;")
+	case reflect.Ptr:
+		if v.IsNil() {
+			io.WriteString(h, "nil;")
+			return
+		}
+		if id, ok := h.seen[v.Pointer()]; ok {
+			io.WriteString(h, "ref:"+strconv.Itoa(id)+";")
+			return
+		}
+		h.seen[v.Pointer()] = len(h.seen)
+		h.walk(v.Elem())
+	case reflect.Interface:
+		if v.IsNil() {
+			io.WriteString(h, "nil;")
+			return
+		}
+		io.WriteString(h, v.Elem().Type().String()+":")
+		h.walk(v.Elem())
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if f := t.Field(i); f.PkgPath == "" {
+				io.WriteString(h, f.Name+"=")
+				h.walk(v.Field(i))
+			}
+		}
+		io.WriteString(h, ";")
+	case reflect.Slice, reflect.Array:
+		io.WriteString(h, "["+strconv.Itoa(v.Len())+"]")
+		for i := 0; i < v.Len(); i++ {
+			h.walk(v.Index(i))
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		io.WriteString(h, "{"+strconv.Itoa(len(keys))+"}")
+		for _, k := range keys {
+			h.walk(k)
+			h.walk(v.MapIndex(k))
+		}
+	case reflect.String:
+		io.WriteString(h, strconv.Quote(v.String())+";")
+	case reflect.Bool:
+		io.WriteString(h, strconv.FormatBool(v.Bool())+";")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		io.WriteString(h, strconv.FormatInt(v.Int(), 10)+";")
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		io.WriteString(h, strconv.FormatUint(v.Uint(), 10)+";")
+	case reflect.Float32, reflect.Float64:
+		io.WriteString(h, strconv.FormatFloat(v.Float(), 'g', -1, 64)+";")
+	case reflect.Complex64, reflect.Complex128:
+		io.WriteString(h, fmt.Sprint(v.Complex())+";")
+	default:
+		// Functions (e.g. the DSL functions), channels and unsafe
+		// pointers do not describe the design.
+	}
+}
@@ -0,0 +1,189 @@
+package generator_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/generator"
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/eval"
+)
+
+// incrementalDSL returns a design whose method has the given description and
+// is exposed over HTTP with the given path and over gRPC with the given
+// response code.
+func incrementalDSL(desc, path string, code int) func() {
+	return func() {
+		Service("IncrementalService", func() {
+			Method("Method", func() {
+				Description(desc)
+				Payload(String)
+				Result(String)
+				HTTP(func() {
+					GET(path)
+					Param("p")
+				})
+				GRPC(func() {
+					Response(code)
+				})
+			})
+		})
+	}
+}
+
+func TestFingerprints(t *testing.T) {
+	base := incrementalDSL("desc", "/", CodeOK)
+	cases := map[string]struct {
+		DSL     func()
+		Changed []string
+	}{
+		"same":   {base, nil},
+		"design": {incrementalDSL("other", "/", CodeOK), []string{generator.DesignArea}},
+		"http":   {incrementalDSL("desc", "/other", CodeOK), []string{generator.HTTPArea}},
+		"grpc":   {incrementalDSL("desc", "/", CodeNotFound), []string{generator.GRPCArea}},
+	}
+	expected := generator.Fingerprints([]eval.Root{codegen.RunDSL(t, base)})
+	if len(expected) != 3 {
+		t.Fatalf("got %d fingerprints, expected 3", len(expected))
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			fps := generator.Fingerprints([]eval.Root{codegen.RunDSL(t, c.DSL)})
+			var changed []string
+			for _, a := range []string{generator.DesignArea, generator.HTTPArea, generator.GRPCArea} {
+				if fps[a] != expected[a] {
+					changed = append(changed, a)
+				}
+			}
+			if !reflect.DeepEqual(changed, c.Changed) {
+				t.Errorf("got changed areas %v, expected %v", changed, c.Changed)
+			}
+		})
+	}
+}
+
+func TestIncremental(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goa-incremental")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	state := filepath.Join(dir, "state.json")
+
+	cases := []struct {
+		Name       string
+		DSL        func()
+		Generators int
+		All        bool
+	}{
+		{"initial", incrementalDSL("desc", "/", CodeOK), 9, true},
+		{"unchanged", incrementalDSL("desc", "/", CodeOK), 0, false},
+		{"http", incrementalDSL("desc", "/other", CodeOK), 6, false},
+		{"grpc", incrementalDSL("desc", "/other", CodeNotFound), 3, false},
+		{"design", incrementalDSL("other", "/other", CodeNotFound), 9, true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := codegen.RunDSL(t, c.DSL)
+			gens, all, err := generator.Incremental(state, dir, []eval.Root{root, root.GeneratedTypes})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(gens) != c.Generators {
+				t.Errorf("got %d generators, expected %d", len(gens), c.Generators)
+			}
+			if all != c.All {
+				t.Errorf("got all %v, expected %v", all, c.All)
+			}
+		})
+	}
+}
+
+func TestIncrementalTemplates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goa-incremental")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	state := filepath.Join(dir, "state.json")
+	dsl := incrementalDSL("desc", "/", CodeOK)
+	tmpl := filepath.Join(dir, codegen.TemplatesDir, "server-handler.tmpl")
+	if err := os.MkdirAll(filepath.Dir(tmpl), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		Name       string
+		Override   string
+		Generators int
+		All        bool
+	}{
+		{"initial", "", 9, true},
+		{"added", "override", 9, true},
+		{"unchanged", "override", 0, false},
+		{"modified", "modified", 9, true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Override != "" {
+				if err := ioutil.WriteFile(tmpl, []byte(c.Override), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			root := codegen.RunDSL(t, dsl)
+			gens, all, err := generator.Incremental(state, dir, []eval.Root{root, root.GeneratedTypes})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(gens) != c.Generators {
+				t.Errorf("got %d generators, expected %d", len(gens), c.Generators)
+			}
+			if all != c.All {
+				t.Errorf("got all %v, expected %v", all, c.All)
+			}
+		})
+	}
+}
+
+func TestIncrementalInline(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goa-incremental")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	state := filepath.Join(dir, "state.json")
+	dsl := func() {
+		API("inline", func() {
+			codegen.Generate(func(string, []eval.Root) []*codegen.File { return nil })
+		})
+		incrementalDSL("desc", "/", CodeOK)()
+	}
+
+	cases := []struct {
+		Name       string
+		Generators int
+		All        bool
+	}{
+		{"initial", 10, true},
+		{"unchanged", 1, false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := codegen.RunDSL(t, dsl)
+			gens, all, err := generator.Incremental(state, dir, []eval.Root{root, root.GeneratedTypes})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(gens) != c.Generators {
+				t.Errorf("got %d generators, expected %d", len(gens), c.Generators)
+			}
+			if all != c.All {
+				t.Errorf("got all %v, expected %v", all, c.All)
+			}
+		})
+	}
+}
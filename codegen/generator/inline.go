@@ -0,0 +1,12 @@
+package generator
+
+import (
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/eval"
+)
+
+// Inline returns the files produced by the generators defined in the design
+// with codegen.Generate.
+func Inline(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
+	return codegen.InlineFiles(genpkg, roots), nil
+}
@@ -0,0 +1,19 @@
+package generator
+
+import (
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+)
+
+// JSONSchema iterates through the roots and returns the files containing the
+// standalone JSON schemas of the design types.
+func JSONSchema(_ string, roots []eval.Root) ([]*codegen.File, error) {
+	for _, root := range roots {
+		if r, ok := root.(*expr.RootExpr); ok {
+			return httpcodegen.JSONSchemaFiles(r), nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,50 @@
+package generator
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"text/template"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Locales iterates through the roots and returns the file that lists the
+// descriptions of the design elements in all the locales they are defined in.
+// There is no file if the design does not define localized descriptions.
+func Locales(_ string, roots []eval.Root) ([]*codegen.File, error) {
+	for _, root := range roots {
+		r, ok := root.(*expr.RootExpr)
+		if !ok {
+			continue
+		}
+		lds := r.LocalizedDescriptions()
+		if len(lds) == 0 {
+			return nil, nil
+		}
+		data := make(map[string]map[string]string, len(lds))
+		for n, ld := range lds {
+			data[n] = ld.Descriptions
+		}
+		section := &codegen.SectionTemplate{
+			Name:    "locales",
+			FuncMap: template.FuncMap{"toJSON": toJSON},
+			Source:  "{{ toJSON . }}\n",
+			Data:    data,
+		}
+		return []*codegen.File{{
+			Path:             filepath.Join(codegen.Gendir, "locales.json"),
+			SectionTemplates: []*codegen.SectionTemplate{section},
+		}}, nil
+	}
+	return nil, nil
+}
+
+func toJSON(d interface{}) string {
+	b, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		panic("locales: " + err.Error()) // bug
+	}
+	return string(b)
+}
@@ -0,0 +1,21 @@
+package generator
+
+import (
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+)
+
+// Postman iterates through the roots and returns the files needed to render
+// the Postman collection and environment of the HTTP endpoints. It produces
+// the files only if the roots define a HTTP service and the API defines the
+// "postman:collection" meta.
+func Postman(_ string, roots []eval.Root) ([]*codegen.File, error) {
+	for _, root := range roots {
+		if r, ok := root.(*expr.RootExpr); ok {
+			return httpcodegen.PostmanFiles(r), nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,138 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// Profile records the time spent in each step of the code generation,
+	// see Profiling.
+	Profile struct {
+		// Entries lists the recorded durations in order.
+		Entries []*ProfileEntry
+
+		mu sync.Mutex
+	}
+
+	// ProfileEntry is the time spent in a step of the code generation.
+	ProfileEntry struct {
+		// Step is the name of the step, e.g. "generators".
+		Step string
+		// Name identifies the timed work within the step, e.g. the name
+		// of a generator or the path of a rendered file. Name is empty
+		// for the entries that time a whole step.
+		Name string
+		// Duration is the time spent.
+		Duration time.Duration
+		// Cached is true if the work was skipped thanks to the cache.
+		Cached bool
+	}
+)
+
+// Profiling is the profile that Generate records the time spent generating
+// code into. Generate does not record anything if Profiling is nil.
+var Profiling *Profile
+
+// maxProfileEntries is the maximum number of entries listed for each step by
+// Profile.Report.
+const maxProfileEntries = 10
+
+// Record appends an entry to the profile. It is safe to call Record
+// concurrently.
+func (p *Profile) Record(step, name string, d time.Duration, cached bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Entries = append(p.Entries, &ProfileEntry{Step: step, Name: name, Duration: d, Cached: cached})
+}
+
+// Report writes a summary of the profile to w. The steps are listed in the
+// order they ran followed by their most expensive entries. The time of a step
+// is the sum of its unnamed entries, the named entries detail where the time
+// went and may overlap when the work runs concurrently.
+func (p *Profile) Report(w io.Writer) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var (
+		steps  []string
+		totals = make(map[string]time.Duration)
+		named  = make(map[string][]*ProfileEntry)
+		total  time.Duration
+	)
+	for _, e := range p.Entries {
+		if _, ok := totals[e.Step]; !ok {
+			steps = append(steps, e.Step)
+			totals[e.Step] = 0
+		}
+		if e.Name == "" {
+			totals[e.Step] += e.Duration
+			total += e.Duration
+			continue
+		}
+		named[e.Step] = append(named[e.Step], e)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-40s %12s %6s\n", "STEP", "TIME", "%")
+	for _, s := range steps {
+		fmt.Fprintf(&b, "%-40s %12s %6s\n", s, totals[s].Round(time.Microsecond), percent(totals[s], total))
+		entries := named[s]
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].Duration > entries[j].Duration })
+		cached := 0
+		for _, e := range entries {
+			if e.Cached {
+				cached++
+			}
+		}
+		for i, e := range entries {
+			if i == maxProfileEntries {
+				fmt.Fprintf(&b, "  ... %d more\n", len(entries)-maxProfileEntries)
+				break
+			}
+			fmt.Fprintf(&b, "  %-38s %12s\n", e.Name, e.Duration.Round(time.Microsecond))
+		}
+		if cached > 0 {
+			fmt.Fprintf(&b, "  %d/%d cached\n", cached, len(entries))
+		}
+	}
+	fmt.Fprintf(&b, "%-40s %12s\n", "total", total.Round(time.Microsecond))
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// percent returns the share of total represented by d.
+func percent(d, total time.Duration) string {
+	if total == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f", float64(d)*100/float64(total))
+}
+
+// record records the time elapsed since start for the given step in the
+// current profile if any.
+func record(step, name string, start time.Time) {
+	if Profiling != nil {
+		Profiling.Record(step, name, time.Since(start), false)
+	}
+}
+
+// genfuncName returns the name of the given generator function, e.g.
+// "generator.Service".
+func genfuncName(gen Genfunc) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(gen).Pointer())
+	if fn == nil {
+		return "unknown"
+	}
+	name := fn.Name()
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
@@ -0,0 +1,40 @@
+package generator
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestProfileReport(t *testing.T) {
+	p := &Profile{}
+	p.Record("roots", "", time.Millisecond, false)
+	p.Record("generators", "generator.Service", time.Millisecond, false)
+	p.Record("generators", "generator.Transport", 2*time.Millisecond, false)
+	p.Record("generators", "", 3*time.Millisecond, false)
+	p.Record("render", "gen/svc/service.go", time.Millisecond, true)
+
+	var b bytes.Buffer
+	if err := p.Report(&b); err != nil {
+		t.Fatal(err)
+	}
+	expected := `STEP                                             TIME      %
+roots                                             1ms   25.0
+generators                                        3ms   75.0
+  generator.Transport                             2ms
+  generator.Service                               1ms
+render                                             0s    0.0
+  gen/svc/service.go                              1ms
+  1/1 cached
+total                                             4ms
+`
+	if b.String() != expected {
+		t.Errorf("got\n%s\nexpected\n%s", b.String(), expected)
+	}
+}
+
+func TestGenfuncName(t *testing.T) {
+	if name := genfuncName(Service); name != "generator.Service" {
+		t.Errorf("got %q, expected %q", name, "generator.Service")
+	}
+}
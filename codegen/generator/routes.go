@@ -0,0 +1,19 @@
+package generator
+
+import (
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/routes"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Routes iterates through the roots and returns the file that implements the
+// registry of the routes of the design methods.
+func Routes(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
+	for _, root := range roots {
+		if r, ok := root.(*expr.RootExpr); ok {
+			return routes.Files(genpkg, r), nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,159 @@
+package generator
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+	goa "goa.design/goa/v3/pkg"
+)
+
+// RuntimeReportFile is the name of the file written by the "gen" command in the
+// generated packages directory that describes the goa runtime API used by the
+// generated code, see RuntimeReport.
+const RuntimeReportFile = "runtime.json"
+
+// goaPrefix is the prefix of the import paths of the goa packages.
+const goaPrefix = "goa.design/goa/v3/"
+
+// RuntimeReport describes the goa runtime API required by the generated code.
+// Comparing the reports of two generations makes it possible to assess the
+// impact of upgrading goa on the code that depends on the generated packages.
+type RuntimeReport struct {
+	// Goa is the version of goa that generated the code, i.e. the version
+	// pinned by the module of the design package.
+	Goa string `json:"goa"`
+	// Compat is the version of the compatibility layer targeted by the
+	// generated code if any, see codegen.CompatMeta.
+	Compat string `json:"compat,omitempty"`
+	// Requirements maps the import paths of the goa packages used by the
+	// generated code to the sorted identifiers it uses.
+	Requirements map[string][]string `json:"requirements"`
+}
+
+// NewRuntimeReport returns the report of the goa runtime API used by the Go
+// source files located in the directory gendir and its subdirectories. genpkg
+// is the import path of gendir, the generated packages are not part of the
+// report.
+func NewRuntimeReport(gendir, genpkg, compat string) (*RuntimeReport, error) {
+	var (
+		fset = token.NewFileSet()
+		reqs = make(map[string]map[string]bool)
+	)
+	err := filepath.Walk(gendir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() || filepath.Ext(p) != ".go" {
+			return nil
+		}
+		f, err := parser.ParseFile(fset, p, nil, 0)
+		if err != nil {
+			// Leave files that do not parse alone, the report
+			// describes the generated code that compiles.
+			return nil
+		}
+		collectRuntimeRefs(f, genpkg, reqs)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	report := &RuntimeReport{Goa: goa.Version(), Compat: compat, Requirements: make(map[string][]string, len(reqs))}
+	for p, ids := range reqs {
+		names := make([]string, 0, len(ids))
+		for id := range ids {
+			names = append(names, id)
+		}
+		sort.Strings(names)
+		report.Requirements[p] = names
+	}
+	return report, nil
+}
+
+// collectRuntimeRefs adds the identifiers of the goa packages referenced by f
+// to reqs.
+func collectRuntimeRefs(f *ast.File, genpkg string, reqs map[string]map[string]bool) {
+	pkgs := make(map[string]string)
+	for _, imp := range f.Imports {
+		p, err := strconv.Unquote(imp.Path.Value)
+		if err != nil || !strings.HasPrefix(p, goaPrefix) {
+			continue
+		}
+		if genpkg != "" && (p == genpkg || strings.HasPrefix(p, genpkg+"/")) {
+			continue
+		}
+		name := path.Base(p)
+		if p == goaPrefix+"pkg" {
+			name = "goa"
+		}
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		if name == "_" || name == "." {
+			continue
+		}
+		pkgs[name] = p
+	}
+	if len(pkgs) == 0 {
+		return
+	}
+	ast.Inspect(f, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		id, ok := sel.X.(*ast.Ident)
+		if !ok || id.Obj != nil {
+			return true
+		}
+		if p, ok := pkgs[id.Name]; ok {
+			if reqs[p] == nil {
+				reqs[p] = make(map[string]bool)
+			}
+			reqs[p][sel.Sel.Name] = true
+		}
+		return true
+	})
+}
+
+// writeRuntimeReport writes the runtime report of the generated packages
+// located in gendir and returns the path to the written file.
+func writeRuntimeReport(gendir, genpkg, compat string) (string, error) {
+	report, err := NewRuntimeReport(gendir, genpkg, compat)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	p := filepath.Join(gendir, RuntimeReportFile)
+	if err := ioutil.WriteFile(p, append(b, '\n'), 0644); err != nil {
+		return "", err
+	}
+	return p, nil
+}
+
+// compatVersion returns the version of the compatibility layer targeted by the
+// code generated for the design in roots, an empty string if there is none.
+func compatVersion(roots []eval.Root) string {
+	for _, root := range roots {
+		if r, ok := root.(*expr.RootExpr); ok && r.API != nil {
+			v, _ := r.API.Meta.Last(codegen.CompatMeta)
+			return v
+		}
+	}
+	return ""
+}
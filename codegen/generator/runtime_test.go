@@ -0,0 +1,72 @@
+package generator_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"goa.design/goa/v3/codegen/generator"
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestNewRuntimeReport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goa-runtime")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"svc/service.go": `package svc
+
+import (
+	"context"
+
+	goa "goa.design/goa/v3/pkg"
+	"goa.design/goa/v3/security"
+	types "goa.design/goa/example/gen/types"
+)
+
+type Service interface {
+	Show(context.Context, *security.APIKeyScheme) (*types.T, error)
+}
+
+var errs = goa.MergeErrors(nil, goa.MissingFieldError("a", "b"))
+
+func f(goa int) int { return goa }
+`,
+		"http/svc/server/server.go": `package server
+
+import goahttp "goa.design/goa/v3/http"
+
+func New(mux goahttp.Muxer, dec func(r interface{}) goahttp.Decoder) {}
+`,
+		"http/svc/server/broken.go": "package server\nfunc {",
+	}
+	for p, src := range files {
+		p = filepath.Join(dir, filepath.FromSlash(p))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	report, err := generator.NewRuntimeReport(dir, "goa.design/goa/example/gen", "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &generator.RuntimeReport{
+		Goa:    goa.Version(),
+		Compat: "v1",
+		Requirements: map[string][]string{
+			"goa.design/goa/v3/pkg":      {"MergeErrors", "MissingFieldError"},
+			"goa.design/goa/v3/security": {"APIKeyScheme"},
+			"goa.design/goa/v3/http":     {"Decoder", "Muxer"},
+		},
+	}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("got %+v, expected %+v", report, expected)
+	}
+}
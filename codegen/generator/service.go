@@ -31,6 +31,31 @@ func Service(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 						service.AddServiceDataMetaTypeImports(f.SectionTemplates[0], s)
 					}
 				}
+				if f := service.JWTAuthFile(s); f != nil {
+					files = append(files, f)
+				}
+				if f := service.APIKeyAuthFile(s); f != nil {
+					files = append(files, f)
+				}
+				if f := service.BasicAuthFile(s); f != nil {
+					files = append(files, f)
+				}
+				if f := service.AuditFile(genpkg, s); f != nil {
+					files = append(files, f)
+				}
+				if f := service.LoggingFile(genpkg, s); f != nil {
+					files = append(files, f)
+				}
+				if f := service.CachingFile(genpkg, s); f != nil {
+					files = append(files, f)
+				}
+				if f := service.JobsFile(genpkg, s); f != nil {
+					files = append(files, f)
+				}
+				if f := service.EventsFile(genpkg, s); f != nil {
+					files = append(files, f)
+				}
+				files = append(files, service.MockFile(genpkg, s))
 				f, err := service.ConvertFile(r, s)
 				if err != nil {
 					return nil, err
@@ -39,6 +64,13 @@ func Service(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 					files = append(files, f)
 				}
 			}
+			files = append(files, service.ScaffoldFiles(genpkg, r)...)
+			if f := service.ContextValuesFile(genpkg, r); f != nil {
+				files = append(files, f)
+			}
+			if f := service.MockServerFile(genpkg, r); f != nil {
+				files = append(files, f)
+			}
 		}
 	}
 	if len(files) == 0 {
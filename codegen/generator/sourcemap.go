@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+)
+
+// SourceMapFile is the name of the file written to the gen directory by the
+// "gen" command that maps the top level declarations of the generated Go files
+// to the design expressions that produced them, see codegen.SourceMap. The
+// paths of the generated files and of the design files are relative to the gen
+// directory.
+const SourceMapFile = "sourcemap.json"
+
+// ReadSourceMap reads the source map located in the given gen directory.
+func ReadSourceMap(gendir string) (*codegen.SourceMap, error) {
+	b, err := ioutil.ReadFile(filepath.Join(gendir, SourceMapFile))
+	if err != nil {
+		return nil, err
+	}
+	var m codegen.SourceMap
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// writeSourceMap writes the source map m computed by rendering the given files
+// to the output directory out to the gen directory gendir. The mappings of the
+// files that were not rendered are read from the existing source map so that
+// incremental generations keep them, unless the files no longer exist. The
+// design file paths are made relative to the directory base, the gen directory
+// of the output directory the code is generated for. writeSourceMap returns
+// the path to the source map file.
+func writeSourceMap(out, gendir, base string, m *codegen.SourceMap, rendered []string) (string, error) {
+	res := &codegen.SourceMap{Files: make(map[string][]*codegen.SourceMapping)}
+	if old, err := ReadSourceMap(gendir); err == nil {
+		skip := make(map[string]bool, len(rendered))
+		for _, p := range rendered {
+			if rel, err := filepath.Rel(gendir, p); err == nil {
+				skip[filepath.ToSlash(rel)] = true
+			}
+		}
+		for p, ms := range old.Files {
+			if skip[p] {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(gendir, filepath.FromSlash(p))); err != nil {
+				continue
+			}
+			res.Files[p] = ms
+		}
+	}
+	for p, ms := range m.Files {
+		rel, err := filepath.Rel(gendir, filepath.Join(out, filepath.FromSlash(p)))
+		if err != nil {
+			return "", err
+		}
+		for _, sm := range ms {
+			sm.Design = relativeDesign(base, sm.Design)
+		}
+		res.Files[filepath.ToSlash(rel)] = ms
+	}
+	b, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	p := filepath.Join(gendir, SourceMapFile)
+	if err := ioutil.WriteFile(p, append(b, '\n'), 0644); err != nil {
+		return "", err
+	}
+	return p, nil
+}
+
+// relativeDesign returns the design location loc formatted as "file:line"
+// with the file path relative to the working directory rewritten relative to
+// dir so that the source map does not depend on where the code is generated
+// from.
+func relativeDesign(dir, loc string) string {
+	i := strings.LastIndex(loc, ":")
+	if i < 0 {
+		return loc
+	}
+	file, err := filepath.Abs(loc[:i])
+	if err != nil {
+		return loc
+	}
+	rel, err := filepath.Rel(dir, file)
+	if err != nil {
+		return loc
+	}
+	return filepath.ToSlash(rel) + loc[i:]
+}
@@ -0,0 +1,230 @@
+package generator_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/generator"
+	"goa.design/goa/v3/codegen/service"
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/eval"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+)
+
+var stableDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("name", String)
+		Attribute("tags", ArrayOf(String))
+		Attribute("attrs", MapOf(String, Int))
+	})
+	var Order = Type("Order", func() {
+		Attribute("id", Int)
+		Attribute("items", ArrayOf(Item))
+		Attribute("main", Item)
+		Required("id")
+	})
+	Service("Orders", func() {
+		Method("Create", func() {
+			Payload(Order)
+			Result(Order)
+			HTTP(func() {
+				POST("/orders")
+			})
+		})
+		Method("List", func() {
+			Payload(func() {
+				Attribute("limit", Int)
+			})
+			Result(ArrayOf(Order))
+			HTTP(func() {
+				GET("/orders")
+				Param("limit")
+			})
+		})
+	})
+	Service("Items", func() {
+		Method("Show", func() {
+			Payload(String)
+			Result(Item)
+			HTTP(func() {
+				GET("/items/{id}")
+			})
+		})
+	})
+}
+
+// detailDSL returns a design with two types whose names Goify to the same
+// identifier and that both require transform helpers. The permuted design
+// declares the types and the methods that use them in the reverse order.
+func detailDSL(permuted bool) func() {
+	return func() {
+		var detail, nested interface{}
+		types := []func(){
+			func() { detail = Type("ItemDetail", func() { Attribute("summary", String) }) },
+			func() { nested = Type("item_detail", func() { Attribute("text", String) }) },
+		}
+		methods := []func(){
+			func() {
+				Method("Show", func() {
+					Result(func() {
+						Attribute("detail", detail)
+					})
+					HTTP(func() {
+						GET("/details")
+					})
+				})
+			},
+			func() {
+				Method("List", func() {
+					Result(func() {
+						Attribute("first", nested)
+						Attribute("all", ArrayOf(nested))
+					})
+					HTTP(func() {
+						GET("/details/all")
+					})
+				})
+			},
+		}
+		if permuted {
+			types[0], types[1] = types[1], types[0]
+			methods[0], methods[1] = methods[1], methods[0]
+		}
+		for _, typ := range types {
+			typ()
+		}
+		Service("Details", func() {
+			for _, m := range methods {
+				m()
+			}
+		})
+	}
+}
+
+func TestStableGeneration(t *testing.T) {
+	first := generateStable(t, stableDSL)
+	defer os.RemoveAll(first)
+	second := generateStable(t, stableDSL)
+	defer os.RemoveAll(second)
+
+	var count int
+	err := filepath.Walk(first, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		count++
+		rel, err := filepath.Rel(first, path)
+		if err != nil {
+			return err
+		}
+		expected, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		actual, err := ioutil.ReadFile(filepath.Join(second, rel))
+		if err != nil {
+			return err
+		}
+		if string(actual) != string(expected) {
+			t.Errorf("%s: regenerated file differs\n%s\n---\n%s", rel, expected, actual)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count == 0 {
+		t.Fatal("no file generated")
+	}
+}
+
+func TestStableHelperNames(t *testing.T) {
+	var helpers []map[string]map[string]string
+	for _, permuted := range []bool{false, true} {
+		dir := generateStable(t, detailDSL(permuted))
+		defer os.RemoveAll(dir)
+		helpers = append(helpers, helperNames(t, dir))
+	}
+	if len(helpers[0]) == 0 {
+		t.Fatal("no transform helper generated")
+	}
+	if !reflect.DeepEqual(helpers[0], helpers[1]) {
+		t.Errorf("got helpers %v, expected %v", helpers[1], helpers[0])
+	}
+}
+
+// helperRx matches the declarations of the transform helper functions.
+var helperRx = regexp.MustCompile(`(?m)^func ((?:marshal|unmarshal|transform)\w+)\((?s:.*?)\n}\n`)
+
+// fieldRx matches the fields initialized by the transform helper functions.
+var fieldRx = regexp.MustCompile(`(?m)^\s+(\w+):\s+v\.`)
+
+// helperNames returns the names of the transform helper functions declared in
+// the files generated in dir indexed by file and by the sorted names of the
+// fields they initialize.
+func helperNames(t *testing.T, dir string) map[string]map[string]string {
+	names := make(map[string]map[string]string)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || filepath.Ext(path) != ".go" {
+			return err
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		for _, m := range helperRx.FindAllStringSubmatch(string(b), -1) {
+			var fields []string
+			for _, f := range fieldRx.FindAllStringSubmatch(m[0], -1) {
+				fields = append(fields, f[1])
+			}
+			sort.Strings(fields)
+			if names[rel] == nil {
+				names[rel] = make(map[string]string)
+			}
+			names[rel][strings.Join(fields, ",")] = m[1]
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return names
+}
+
+// generateStable generates the code for the given design in a new temporary
+// directory and returns its path.
+func generateStable(t *testing.T, dsl func()) string {
+	service.Services = make(service.ServicesData)
+	httpcodegen.HTTPServices = make(httpcodegen.ServicesData)
+	root := codegen.RunDSL(t, dsl)
+	roots := []eval.Root{root, root.GeneratedTypes}
+	var files []*codegen.File
+	for _, gen := range []generator.Genfunc{generator.Service, generator.Transport, generator.OpenAPI, generator.JSONSchema} {
+		fs, err := gen("goa.design/stable/gen", roots)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, fs...)
+	}
+	dir, err := ioutil.TempDir("", "goa-stable")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &codegen.Renderer{Workers: 4}
+	if _, err := r.Render(dir, files); err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return dir
+}
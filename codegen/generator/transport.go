@@ -15,29 +15,39 @@ import (
 // the transport code. It returns an error if the roots slice does not include
 // at least one transport design.
 func Transport(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
+	files := transportFiles(genpkg, roots, func(genpkg string, r *expr.RootExpr) []*codegen.File {
+		return append(httpFiles(genpkg, r), grpcFiles(genpkg, r)...)
+	})
+	if len(files) == 0 {
+		return nil, fmt.Errorf("transport: no HTTP/gRPC design found")
+	}
+	return files, nil
+}
+
+// HTTPTransport iterates through the roots and returns the files needed to
+// render the HTTP transport code. It is used to regenerate the HTTP code only
+// when the gRPC parts of the design do not change, see Incremental.
+func HTTPTransport(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
+	return transportFiles(genpkg, roots, httpFiles), nil
+}
+
+// GRPCTransport iterates through the roots and returns the files needed to
+// render the gRPC transport code. It is used to regenerate the gRPC code only
+// when the HTTP parts of the design do not change, see Incremental.
+func GRPCTransport(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
+	return transportFiles(genpkg, roots, grpcFiles), nil
+}
+
+// transportFiles returns the files produced by fn for the design roots with
+// the imports of the meta types used by the services.
+func transportFiles(genpkg string, roots []eval.Root, fn func(string, *expr.RootExpr) []*codegen.File) []*codegen.File {
 	var files []*codegen.File
 	for _, root := range roots {
 		r, ok := root.(*expr.RootExpr)
 		if !ok {
 			continue // could be a plugin root expression
 		}
-
-		// HTTP
-		files = append(files, httpcodegen.ServerFiles(genpkg, r)...)
-		files = append(files, httpcodegen.ClientFiles(genpkg, r)...)
-		files = append(files, httpcodegen.ServerTypeFiles(genpkg, r)...)
-		files = append(files, httpcodegen.ClientTypeFiles(genpkg, r)...)
-		files = append(files, httpcodegen.PathFiles(r)...)
-		files = append(files, httpcodegen.ClientCLIFiles(genpkg, r)...)
-
-		// GRPC
-		files = append(files, grpccodegen.ProtoFiles(genpkg, r)...)
-		files = append(files, grpccodegen.ServerFiles(genpkg, r)...)
-		files = append(files, grpccodegen.ClientFiles(genpkg, r)...)
-		files = append(files, grpccodegen.ServerTypeFiles(genpkg, r)...)
-		files = append(files, grpccodegen.ClientTypeFiles(genpkg, r)...)
-		files = append(files, grpccodegen.ClientCLIFiles(genpkg, r)...)
-
+		files = append(files, fn(genpkg, r)...)
 		for _, f := range files {
 			if len(f.SectionTemplates) > 0 {
 				for _, s := range r.Services {
@@ -46,8 +56,46 @@ func Transport(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 			}
 		}
 	}
-	if len(files) == 0 {
-		return nil, fmt.Errorf("transport: no HTTP/gRPC design found")
-	}
-	return files, nil
+	return files
+}
+
+// httpFiles returns the files needed to render the HTTP transport code.
+func httpFiles(genpkg string, r *expr.RootExpr) []*codegen.File {
+	var files []*codegen.File
+	files = append(files, httpcodegen.ServerFiles(genpkg, r)...)
+	files = append(files, httpcodegen.ClientFiles(genpkg, r)...)
+	files = append(files, httpcodegen.ServerTypeFiles(genpkg, r)...)
+	files = append(files, httpcodegen.ClientTypeFiles(genpkg, r)...)
+	files = append(files, httpcodegen.PathFiles(r)...)
+	files = append(files, httpcodegen.MuxerAdapterFiles(r)...)
+	files = append(files, httpcodegen.RadixMuxerFiles(r)...)
+	files = append(files, httpcodegen.OAuth2Files(r)...)
+	files = append(files, httpcodegen.BenchmarkFiles(genpkg, r)...)
+	files = append(files, httpcodegen.ClientCLIFiles(genpkg, r)...)
+	files = append(files, httpcodegen.SDKFiles(genpkg, r)...)
+	files = append(files, httpcodegen.MockServerFiles(genpkg, r)...)
+	files = append(files, httpcodegen.ContractFiles(genpkg, r)...)
+	files = append(files, httpcodegen.FuzzFiles(genpkg, r)...)
+	files = append(files, httpcodegen.InMemoryFiles(genpkg, r)...)
+	files = append(files, httpcodegen.FixturesFiles(genpkg, r)...)
+	files = append(files, httpcodegen.TelemetryFiles(genpkg, r)...)
+	files = append(files, httpcodegen.MetricsFiles(genpkg, r)...)
+	return files
+}
+
+// grpcFiles returns the files needed to render the gRPC transport code.
+func grpcFiles(genpkg string, r *expr.RootExpr) []*codegen.File {
+	var files []*codegen.File
+	files = append(files, grpccodegen.ProtoFiles(genpkg, r)...)
+	files = append(files, grpccodegen.ServerFiles(genpkg, r)...)
+	files = append(files, grpccodegen.ClientFiles(genpkg, r)...)
+	files = append(files, grpccodegen.ServerTypeFiles(genpkg, r)...)
+	files = append(files, grpccodegen.ClientTypeFiles(genpkg, r)...)
+	files = append(files, grpccodegen.ClientCLIFiles(genpkg, r)...)
+	files = append(files, grpccodegen.MockServerFiles(genpkg, r)...)
+	files = append(files, grpccodegen.FuzzFiles(genpkg, r)...)
+	files = append(files, grpccodegen.InMemoryFiles(genpkg, r)...)
+	files = append(files, grpccodegen.FixturesFiles(genpkg, r)...)
+	files = append(files, grpccodegen.TelemetryFiles(genpkg, r)...)
+	return files
 }
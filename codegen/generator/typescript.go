@@ -0,0 +1,21 @@
+package generator
+
+import (
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+)
+
+// TypeScript iterates through the roots and returns the files needed to
+// render the TypeScript client of the HTTP endpoints. It produces the files
+// only if the roots define a HTTP service and the API defines the
+// "typescript:client" meta.
+func TypeScript(_ string, roots []eval.Root) ([]*codegen.File, error) {
+	for _, root := range roots {
+		if r, ok := root.(*expr.RootExpr); ok {
+			return httpcodegen.TypeScriptFiles(r), nil
+		}
+	}
+	return nil, nil
+}
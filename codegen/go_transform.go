@@ -2,6 +2,8 @@ package codegen
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"strings"
 	"text/template"
@@ -452,8 +454,14 @@ func transformHelperName(source, target *expr.AttributeExpr, ta *TransformAttrs)
 		prefix string
 	)
 	{
-		sname = Goify(ta.SourceCtx.Scope.Name(source, ta.SourceCtx.Pkg), true)
-		tname = Goify(ta.TargetCtx.Scope.Name(target, ta.TargetCtx.Pkg), true)
+		sname = TransformHelperTypeName(source, ta.SourceCtx.Pkg)
+		if sname == "" {
+			sname = Goify(ta.SourceCtx.Scope.Name(source, ta.SourceCtx.Pkg), true)
+		}
+		tname = TransformHelperTypeName(target, ta.TargetCtx.Pkg)
+		if tname == "" {
+			tname = Goify(ta.TargetCtx.Scope.Name(target, ta.TargetCtx.Pkg), true)
+		}
 		prefix = ta.Prefix
 		if prefix == "" {
 			prefix = "transform"
@@ -462,6 +470,35 @@ func transformHelperName(source, target *expr.AttributeExpr, ta *TransformAttrs)
 	return Goify(prefix+sname+"To"+tname, false)
 }
 
+// TransformHelperTypeName returns the name that identifies the type of att in
+// the names of the transform helper functions or the empty string if att is not
+// a user type. The name is derived from the type name the type hash is computed
+// from rather than from the name scope so that it does not depend on the order
+// in which the types are visited. Type names that are not already valid Go
+// identifiers get a suffix computed from the type hash so that distinct types
+// whose names Goify to the same identifier produce distinct helper names.
+func TransformHelperTypeName(att *expr.AttributeExpr, pkg string) string {
+	switch actual := att.Type.(type) {
+	case expr.UserType:
+		if actual == expr.ErrorResult {
+			return ""
+		}
+		name := Goify(actual.Name(), true)
+		if name != actual.Name() {
+			sum := sha256.Sum256([]byte(actual.Hash()))
+			name += hex.EncodeToString(sum[:])[:6]
+		}
+		if pkg == "" {
+			return name
+		}
+		return Goify(pkg+"."+name, true)
+	case expr.CompositeExpr:
+		return TransformHelperTypeName(actual.Attribute(), pkg)
+	default:
+		return ""
+	}
+}
+
 const (
 	transformGoArrayTmpl = `{{ .TargetVar }} {{ if .NewVar }}:={{ else }}={{ end }} make([]{{ .ElemTypeRef }}, len({{ .SourceVar }}))
 for {{ .LoopVar }}, val := range {{ .SourceVar }} {
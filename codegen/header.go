@@ -33,6 +33,15 @@ func AddImport(section *SectionTemplate, imprts ...*ImportSpec) {
 	}
 }
 
+// AddBuildConstraint adds the build constraint defined by the given
+// expression, e.g. "go1.18", to a section template that was generated with
+// Header.
+func AddBuildConstraint(section *SectionTemplate, constraint string) {
+	if data, ok := section.Data.(map[string]interface{}); ok {
+		data["BuildConstraint"] = constraint
+	}
+}
+
 const (
 	headerT = `{{if .Title}}// Code generated by goa {{.ToolVersion}}, DO NOT EDIT.
 //
@@ -41,6 +50,9 @@ const (
 // Command:
 {{comment commandLine}}
 
+{{end}}{{with .BuildConstraint}}//go:build {{.}}
+// +build {{.}}
+
 {{end}}package {{.Pkg}}
 
 {{if .Imports}}import {{if gt (len .Imports) 1}}(
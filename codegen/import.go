@@ -85,6 +85,22 @@ func getMetaTypeInfo(att *expr.AttributeExpr) (typeName string, importS *ImportS
 	return typeName, importS
 }
 
+// addAttributeImports adds the imports defined by the struct:field:type meta
+// and the ValidateWith validations of att to imports.
+func addAttributeImports(imports map[ImportSpec]struct{}, att *expr.AttributeExpr) {
+	if _, im := getMetaTypeInfo(att); im != nil {
+		imports[*im] = struct{}{}
+	}
+	if att == nil || att.Validation == nil {
+		return
+	}
+	for _, f := range att.Validation.Funcs {
+		if f.ImportPath != "" {
+			imports[ImportSpec{Name: f.ImportName, Path: f.ImportPath}] = struct{}{}
+		}
+	}
+}
+
 // GetMetaTypeImports parses the attribute for all user defined imports
 func GetMetaTypeImports(att *expr.AttributeExpr) []*ImportSpec {
 	return safelyGetMetaTypeImports(att, nil)
@@ -113,33 +129,26 @@ func safelyGetMetaTypeImports(att *expr.AttributeExpr, seen map[string]struct{})
 			}
 		}
 	case *expr.Array:
-		_, im := getMetaTypeInfo(t.ElemType)
-		if im != nil {
+		for _, im := range safelyGetMetaTypeImports(t.ElemType, seen) {
 			uniqueImports[*im] = struct{}{}
 		}
 	case *expr.Map:
-		_, im := getMetaTypeInfo(t.ElemType)
-		if im != nil {
+		for _, im := range safelyGetMetaTypeImports(t.ElemType, seen) {
 			uniqueImports[*im] = struct{}{}
 		}
-		_, im = getMetaTypeInfo(t.KeyType)
-		if im != nil {
+		for _, im := range safelyGetMetaTypeImports(t.KeyType, seen) {
 			uniqueImports[*im] = struct{}{}
 		}
 	case *expr.Object:
 		for _, key := range *t {
 			if key != nil {
-				_, im := getMetaTypeInfo(key.Attribute)
-				if im != nil {
+				for _, im := range safelyGetMetaTypeImports(key.Attribute, seen) {
 					uniqueImports[*im] = struct{}{}
 				}
 			}
 		}
 	}
-	_, im := getMetaTypeInfo(att)
-	if im != nil {
-		uniqueImports[*im] = struct{}{}
-	}
+	addAttributeImports(uniqueImports, att)
 	for imp := range uniqueImports {
 		// Copy loop variable into body so next iteration doesnt overwrite its address https://stackoverflow.com/questions/27610039/golang-appending-leaves-only-last-element
 		copy := imp
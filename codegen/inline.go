@@ -0,0 +1,66 @@
+package codegen
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// InlineGenerator is the type of the functions defined in a design that
+// generate additional files, see Generate. An InlineGenerator accepts the Go
+// import path of the "gen" package and the design roots and returns the files
+// to generate.
+type InlineGenerator func(genpkg string, roots []eval.Root) []*File
+
+// Generate adds a generator to the design. The generator runs with the goa
+// code generators when the "gen" command generates the code for the design,
+// after the design has been evaluated. Generate makes it possible to generate
+// small additional files such as constants or deployment manifests without
+// building a separate plugin. The file paths are relative to the output
+// directory. Generate is a DSL function located in the codegen package as it
+// refers to the codegen types.
+//
+// Generate must appear in a API expression.
+//
+// Example:
+//
+//    var _ = API("cellar", func() {
+//        codegen.Generate(func(genpkg string, roots []eval.Root) []*codegen.File {
+//            return []*codegen.File{{
+//                Path: "deploy/service.yaml",
+//                SectionTemplates: []*codegen.SectionTemplate{
+//                    {Name: "manifest", Source: manifestT, Data: expr.Root.API},
+//                },
+//            }}
+//        })
+//    })
+//
+func Generate(fn InlineGenerator) {
+	a, ok := eval.Current().(*expr.APIExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if fn == nil {
+		eval.ReportError("generator function cannot be nil")
+		return
+	}
+	a.Generators = append(a.Generators, fn)
+}
+
+// InlineFiles runs the generators added to the design with Generate in the
+// order they were added and returns the files they produce.
+func InlineFiles(genpkg string, roots []eval.Root) []*File {
+	var files []*File
+	for _, root := range roots {
+		r, ok := root.(*expr.RootExpr)
+		if !ok || r.API == nil {
+			continue
+		}
+		for _, g := range r.API.Generators {
+			if fn, ok := g.(InlineGenerator); ok {
+				files = append(files, fn(genpkg, roots)...)
+			}
+		}
+	}
+	return files
+}
@@ -0,0 +1,51 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+func TestInlineFiles(t *testing.T) {
+	var gotpkg string
+	root := RunDSL(t, func() {
+		dsl.API("inline", func() {
+			Generate(func(genpkg string, roots []eval.Root) []*File {
+				gotpkg = genpkg
+				return []*File{{Path: "constants.go"}}
+			})
+			Generate(func(string, []eval.Root) []*File {
+				return []*File{{Path: "deploy.yaml"}, {Path: "gen/extra.go"}}
+			})
+		})
+	})
+	fs := InlineFiles("goa.design/goa/example/gen", []eval.Root{root})
+	expected := []string{"constants.go", "deploy.yaml", "gen/extra.go"}
+	if len(fs) != len(expected) {
+		t.Fatalf("got %d files, expected %d", len(fs), len(expected))
+	}
+	for i, f := range fs {
+		if f.Path != expected[i] {
+			t.Errorf("got path %q at index %d, expected %q", f.Path, i, expected[i])
+		}
+	}
+	if gotpkg != "goa.design/goa/example/gen" {
+		t.Errorf("got genpkg %q, expected %q", gotpkg, "goa.design/goa/example/gen")
+	}
+}
+
+func TestGenerateIncompatible(t *testing.T) {
+	eval.Reset()
+	expr.Root = new(expr.RootExpr)
+	eval.Register(expr.Root)
+	design := func() {
+		dsl.Service("svc", func() {
+			Generate(func(string, []eval.Root) []*File { return nil })
+		})
+	}
+	if eval.Execute(design, nil) && eval.RunDSL() == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,134 @@
+package codegen
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/expr"
+)
+
+const (
+	// LayoutGenMeta is the key of the API meta that sets the directory
+	// containing the generated Go packages relative to the output
+	// directory, "gen" by default.
+	LayoutGenMeta = "goa:layout:gen"
+	// LayoutOpenAPIMeta is the key of the API meta that sets the directory
+	// containing the OpenAPI specifications relative to the output
+	// directory, the "http" subdirectory of the generated Go packages
+	// directory by default.
+	LayoutOpenAPIMeta = "goa:layout:openapi"
+	// LayoutProtoMeta is the key of the API meta that sets the directory
+	// containing the protocol buffer definitions relative to the output
+	// directory. The definitions are located in the directories of the
+	// generated pb packages by default.
+	LayoutProtoMeta = "goa:layout:proto"
+	// LayoutExampleMeta is the key of the API meta that sets the directory
+	// containing the files generated by the "example" command relative to
+	// the output directory, the output directory itself by default.
+	LayoutExampleMeta = "goa:layout:example"
+)
+
+// OutputLayout describes where the generated files are written relative to
+// the output directory. The generators produce files whose paths follow the
+// default layout, i.e. the generated Go packages and specifications are
+// located under Gendir and the example files under the output directory. The
+// paths are then relocated according to the layout before the files are
+// written, see Relocate. The import paths of the generated Go packages are
+// computed from the layout so that the generated code compiles wherever it
+// is written.
+type OutputLayout struct {
+	// Gen is the directory containing the generated Go packages.
+	Gen string
+	// OpenAPI is the directory containing the OpenAPI specifications if
+	// not the default.
+	OpenAPI string
+	// Proto is the directory containing the protocol buffer definitions
+	// if not the default.
+	Proto string
+	// Example is the directory containing the files generated by the
+	// "example" command if not the output directory.
+	Example string
+}
+
+// Layout is the layout of the generated files. generator.Generate initializes
+// it from the design before running the generators.
+var Layout = DefaultLayout()
+
+// DefaultLayout returns the default layout of the generated files.
+func DefaultLayout() *OutputLayout {
+	return &OutputLayout{Gen: Gendir}
+}
+
+// NewOutputLayout returns the layout of the generated files defined by the
+// API meta, see LayoutGenMeta, LayoutOpenAPIMeta, LayoutProtoMeta and
+// LayoutExampleMeta. The meta values are validated by the API expression.
+func NewOutputLayout(api *expr.APIExpr) *OutputLayout {
+	l := DefaultLayout()
+	if api == nil {
+		return l
+	}
+	dir := func(key string) string {
+		if v, ok := api.Meta.Last(key); ok {
+			return filepath.Clean(filepath.FromSlash(v))
+		}
+		return ""
+	}
+	if d := dir(LayoutGenMeta); d != "" {
+		l.Gen = d
+	}
+	l.OpenAPI = dir(LayoutOpenAPIMeta)
+	l.Proto = dir(LayoutProtoMeta)
+	l.Example = dir(LayoutExampleMeta)
+	if l.Example == "." {
+		l.Example = ""
+	}
+	return l
+}
+
+// Relocate returns the path of the file with the given path in the default
+// layout relative to the output directory according to l.
+func (l *OutputLayout) Relocate(p string) string {
+	rel, err := filepath.Rel(Gendir, p)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.Join(l.Example, p)
+	}
+	slash := filepath.ToSlash(rel)
+	switch {
+	case l.OpenAPI != "" && isOpenAPIPath(slash):
+		return filepath.Join(l.OpenAPI, filepath.FromSlash(strings.TrimPrefix(slash, "http/")))
+	case l.Proto != "" && path.Ext(slash) == ".proto":
+		return filepath.Join(l.Proto, filepath.FromSlash(strings.TrimPrefix(slash, "grpc/")))
+	}
+	return filepath.Join(l.Gen, rel)
+}
+
+// RootPackage returns the import path of the package containing the service
+// implementations generated by the "example" command given the import path
+// of the generated Go packages directory.
+func (l *OutputLayout) RootPackage(genpkg string) string {
+	// genpkg is created by path.Join so the separator is / regardless of
+	// operating system
+	root := strings.TrimSuffix(genpkg, "/"+filepath.ToSlash(l.Gen))
+	if root == genpkg {
+		idx := strings.LastIndex(genpkg, "/")
+		if idx <= 0 {
+			return "."
+		}
+		root = genpkg[:idx]
+	}
+	if l.Example != "" {
+		root = path.Join(root, filepath.ToSlash(l.Example))
+	}
+	return root
+}
+
+// isOpenAPIPath returns true if p is the path of an OpenAPI specification
+// file relative to Gendir in the default layout.
+func isOpenAPIPath(p string) bool {
+	if strings.HasPrefix(p, "http/openapi/") {
+		return true
+	}
+	dir, base := path.Split(p)
+	return dir == "http/" && (strings.HasPrefix(base, "openapi") || base == ".spectral.yaml")
+}
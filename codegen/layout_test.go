@@ -0,0 +1,64 @@
+package codegen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+)
+
+func TestOutputLayoutRelocate(t *testing.T) {
+	custom := NewOutputLayout(&expr.APIExpr{Meta: expr.MetaExpr{
+		LayoutGenMeta:     {"internal/gen"},
+		LayoutOpenAPIMeta: {"api"},
+		LayoutProtoMeta:   {"proto"},
+		LayoutExampleMeta: {"cmd/.."},
+	}})
+	examples := NewOutputLayout(&expr.APIExpr{Meta: expr.MetaExpr{LayoutExampleMeta: {"services"}}})
+	cases := map[string]struct {
+		Layout   *OutputLayout
+		Path     string
+		Expected string
+	}{
+		"default":         {DefaultLayout(), "gen/svc/service.go", "gen/svc/service.go"},
+		"default example": {DefaultLayout(), "cmd/svc/main.go", "cmd/svc/main.go"},
+		"gen":             {custom, "gen/svc/service.go", "internal/gen/svc/service.go"},
+		"openapi":         {custom, "gen/http/openapi3.yaml", "api/openapi3.yaml"},
+		"split openapi":   {custom, "gen/http/openapi/paths/foo.json", "api/openapi/paths/foo.json"},
+		"spectral":        {custom, "gen/http/.spectral.yaml", "api/.spectral.yaml"},
+		"http":            {custom, "gen/http/svc/server/server.go", "internal/gen/http/svc/server/server.go"},
+		"proto":           {custom, "gen/grpc/svc/pb/svc.proto", "proto/svc/pb/svc.proto"},
+		"pb":              {custom, "gen/grpc/svc/pb", "internal/gen/grpc/svc/pb"},
+		"example":         {custom, "svc.go", "svc.go"},
+		"examples":        {examples, "cmd/svc/main.go", "services/cmd/svc/main.go"},
+		"not gen":         {examples, "generated/foo.go", "services/generated/foo.go"},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if p := c.Layout.Relocate(filepath.FromSlash(c.Path)); p != filepath.FromSlash(c.Expected) {
+				t.Errorf("got %q, expected %q", p, filepath.FromSlash(c.Expected))
+			}
+		})
+	}
+}
+
+func TestOutputLayoutRootPackage(t *testing.T) {
+	custom := &OutputLayout{Gen: filepath.FromSlash("internal/gen"), Example: "services"}
+	cases := map[string]struct {
+		Layout   *OutputLayout
+		Genpkg   string
+		Expected string
+	}{
+		"default":    {DefaultLayout(), "example.com/api/gen", "example.com/api"},
+		"no path":    {DefaultLayout(), "gen", "."},
+		"other name": {DefaultLayout(), "example.com/api/other", "example.com/api"},
+		"custom":     {custom, "example.com/api/internal/gen", "example.com/api/services"},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if p := c.Layout.RootPackage(c.Genpkg); p != c.Expected {
+				t.Errorf("got %q, expected %q", p, c.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,297 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// MergeConflictsError is the error returned by Renderer.Render when merging
+// the files generated in update mode with the existing files results in
+// conflicts. The conflicting changes are delimited with conflict markers in
+// the written files.
+type MergeConflictsError struct {
+	// Paths lists the absolute paths to the files with conflicts.
+	Paths []string
+}
+
+// Error returns the error message.
+func (e *MergeConflictsError) Error() string {
+	return fmt.Sprintf("merge conflicts, resolve the conflicts delimited with markers in:\n%s", strings.Join(e.Paths, "\n"))
+}
+
+// Merge3 merges the changes made to base in ours and in theirs line by line.
+// Both changes are kept and delimited with conflict markers when they overlap
+// and differ. Merge3 returns the merged content and true if there are
+// conflicts.
+func Merge3(base, ours, theirs []byte) ([]byte, bool) {
+	var (
+		b = splitLines(base)
+		o = splitLines(ours)
+		t = splitLines(theirs)
+
+		mo = matchLines(b, o)
+		mt = matchLines(b, t)
+
+		buf      bytes.Buffer
+		conflict bool
+		i, j, k  int
+	)
+	write := func(lines []string) {
+		for _, l := range lines {
+			buf.WriteString(l)
+		}
+	}
+	for {
+		// Find the next base line kept by both sides.
+		next := i
+		for next < len(b) && (mo[next] < 0 || mt[next] < 0) {
+			next++
+		}
+		oend, tend := len(o), len(t)
+		if next < len(b) {
+			oend, tend = mo[next], mt[next]
+		}
+		bc, oc, tc := b[i:next], o[j:oend], t[k:tend]
+		switch {
+		case sameLines(oc, bc):
+			write(tc)
+		case sameLines(tc, bc), sameLines(oc, tc):
+			write(oc)
+		default:
+			conflict = true
+			buf.WriteString("<<<<<<< current\n")
+			write(terminated(oc))
+			buf.WriteString("=======\n")
+			write(terminated(tc))
+			buf.WriteString(">>>>>>> generated\n")
+		}
+		if next == len(b) {
+			break
+		}
+		buf.WriteString(b[next])
+		i, j, k = next+1, oend+1, tend+1
+	}
+	return buf.Bytes(), conflict
+}
+
+// mergeMissingDecls appends the top level declarations of the Go source theirs
+// that are missing from the Go source ours to ours, for example the methods
+// added to a service since ours was generated. The imports used by the added
+// declarations are added to ours as well.
+func mergeMissingDecls(path string, ours, theirs []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	of, err := parser.ParseFile(fset, path, ours, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	tf, err := parser.ParseFile(fset, path, theirs, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool)
+	for _, d := range of.Decls {
+		for _, k := range declKeys(d) {
+			existing[k] = true
+		}
+	}
+	var added [][]byte
+	for _, d := range tf.Decls {
+		keys := declKeys(d)
+		missing := len(keys) > 0
+		for _, k := range keys {
+			if existing[k] {
+				missing = false
+				break
+			}
+		}
+		if !missing {
+			continue
+		}
+		start := d.Pos()
+		switch d := d.(type) {
+		case *ast.FuncDecl:
+			if d.Doc != nil {
+				start = d.Doc.Pos()
+			}
+		case *ast.GenDecl:
+			if d.Doc != nil {
+				start = d.Doc.Pos()
+			}
+		}
+		added = append(added, theirs[fset.Position(start).Offset:fset.Position(d.End()).Offset])
+	}
+	if len(added) == 0 {
+		return ours, nil
+	}
+
+	src := append([]byte{}, ours...)
+	for _, a := range added {
+		src = append(src, '\n')
+		src = append(src, a...)
+		src = append(src, '\n')
+	}
+	fset = token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	for _, imp := range tf.Imports {
+		p, _ := strconv.Unquote(imp.Path.Value)
+		if imp.Name != nil {
+			astutil.AddNamedImport(fset, f, imp.Name.Name, p)
+		} else {
+			astutil.AddImport(fset, f, p)
+		}
+	}
+	CleanImports(fset, f)
+	return formatGoFile(fset, f, path)
+}
+
+// declKeys returns the keys that identify the symbols declared by d. Methods
+// are identified by their receiver type and name.
+func declKeys(d ast.Decl) []string {
+	switch d := d.(type) {
+	case *ast.FuncDecl:
+		if d.Recv == nil || len(d.Recv.List) == 0 {
+			return []string{d.Name.Name}
+		}
+		typ := d.Recv.List[0].Type
+		if star, ok := typ.(*ast.StarExpr); ok {
+			typ = star.X
+		}
+		if id, ok := typ.(*ast.Ident); ok {
+			return []string{id.Name + "." + d.Name.Name}
+		}
+		return []string{d.Name.Name}
+	case *ast.GenDecl:
+		if d.Tok == token.IMPORT {
+			return nil
+		}
+		var keys []string
+		for _, s := range d.Specs {
+			switch s := s.(type) {
+			case *ast.TypeSpec:
+				keys = append(keys, s.Name.Name)
+			case *ast.ValueSpec:
+				for _, n := range s.Names {
+					if n.Name != "_" {
+						keys = append(keys, n.Name)
+					}
+				}
+			}
+		}
+		sort.Strings(keys)
+		return keys
+	}
+	return nil
+}
+
+// formatMerged formats the merged Go source src if it parses, src is returned
+// as is otherwise.
+func formatMerged(src []byte) []byte {
+	if bs, err := format.Source(src); err == nil {
+		return bs
+	}
+	return src
+}
+
+// splitLines splits b into lines that include the trailing newline if any.
+func splitLines(b []byte) []string {
+	var lines []string
+	for len(b) > 0 {
+		i := bytes.IndexByte(b, '\n')
+		if i < 0 {
+			lines = append(lines, string(b))
+			break
+		}
+		lines = append(lines, string(b[:i+1]))
+		b = b[i+1:]
+	}
+	return lines
+}
+
+// matchLines returns the index of the line of b that matches each line of a
+// in a longest common subsequence of a and b, -1 if the line is not part of
+// it.
+func matchLines(a, b []string) []int {
+	// Skip the common prefix and suffix to reduce the size of the table.
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+	m := make([]int, len(a))
+	for i := range m {
+		m[i] = -1
+	}
+	for i := 0; i < pre; i++ {
+		m[i] = i
+	}
+	for i := 0; i < suf; i++ {
+		m[len(a)-1-i] = len(b) - 1 - i
+	}
+	ma, mb := a[pre:len(a)-suf], b[pre:len(b)-suf]
+	lcs := make([][]int, len(ma)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(mb)+1)
+	}
+	for i := len(ma) - 1; i >= 0; i-- {
+		for j := len(mb) - 1; j >= 0; j-- {
+			switch {
+			case ma[i] == mb[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	for i, j := 0, 0; i < len(ma) && j < len(mb); {
+		switch {
+		case ma[i] == mb[j]:
+			m[pre+i] = pre + j
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return m
+}
+
+// sameLines returns true if a and b contain the same lines.
+func sameLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// terminated returns lines making sure the last line ends with a newline so
+// that conflict markers start on their own line.
+func terminated(lines []string) []string {
+	if n := len(lines); n > 0 && !strings.HasSuffix(lines[n-1], "\n") {
+		lines = append(lines[:n-1:n-1], lines[n-1]+"\n")
+	}
+	return lines
+}
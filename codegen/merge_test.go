@@ -0,0 +1,129 @@
+package codegen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMerge3(t *testing.T) {
+	const base = "a\nb\nc\nd\n"
+	cases := map[string]struct {
+		Ours     string
+		Theirs   string
+		Expected string
+		Conflict bool
+	}{
+		"unchanged":      {base, base, base, false},
+		"ours only":      {"a\nB\nc\nd\n", base, "a\nB\nc\nd\n", false},
+		"theirs only":    {base, "a\nb\nc\nd\ne\n", "a\nb\nc\nd\ne\n", false},
+		"both disjoint":  {"a\nB\nc\nd\n", "a\nb\nc\nD\n", "a\nB\nc\nD\n", false},
+		"both identical": {"a\nB\nc\nd\n", "a\nB\nc\nd\n", "a\nB\nc\nd\n", false},
+		"conflict":       {"a\nB\nc\nd\n", "a\nX\nc\nd\n", "a\n<<<<<<< current\nB\n=======\nX\n>>>>>>> generated\nc\nd\n", true},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			merged, conflict := Merge3([]byte(base), []byte(c.Ours), []byte(c.Theirs))
+			if conflict != c.Conflict {
+				t.Errorf("got conflict %v, expected %v", conflict, c.Conflict)
+			}
+			if string(merged) != c.Expected {
+				t.Errorf("got\n%s\nexpected\n%s", merged, c.Expected)
+			}
+		})
+	}
+}
+
+func TestMergeMissingDecls(t *testing.T) {
+	const (
+		ours = `package svc
+
+type service struct{}
+
+// Show is edited.
+func (s *service) Show() error {
+	return nil
+}
+`
+		theirs = `package svc
+
+import "context"
+
+type service struct{}
+
+// Show shows.
+func (s *service) Show() error {
+	panic("not implemented")
+}
+
+// Add adds.
+func (s *service) Add(ctx context.Context) error {
+	return nil
+}
+`
+	)
+	merged, err := mergeMissingDecls("svc.go", []byte(ours), []byte(theirs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := string(merged)
+	for _, s := range []string{`import "context"`, "// Show is edited.", "// Add adds.", "func (s *service) Add(ctx context.Context) error"} {
+		if !strings.Contains(m, s) {
+			t.Errorf("expected merged source to contain %q, got\n%s", s, m)
+		}
+	}
+	if strings.Contains(m, "not implemented") {
+		t.Errorf("expected existing declaration to be kept, got\n%s", m)
+	}
+}
+
+func TestRendererUpdate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goa-render")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	out := filepath.Join(dir, "out")
+	file := func(src string) []*File {
+		return []*File{{Path: "notes.txt", SectionTemplates: []*SectionTemplate{{Name: "section", Source: src}}, SkipExist: true}}
+	}
+	r := &Renderer{BaseDir: filepath.Join(dir, "base")}
+	if _, err := r.Render(out, file("a\nb\nc\n")); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(out, "notes.txt")
+	if err := ioutil.WriteFile(path, []byte("A\nb\nc\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without update the existing file is kept as is.
+	if _, err := r.Render(out, file("a\nb\nc\nd\n")); err != nil {
+		t.Fatal(err)
+	}
+	expectContent(t, path, "A\nb\nc\n")
+
+	r.Update = true
+	if _, err := r.Render(out, file("a\nb\nc\nd\n")); err != nil {
+		t.Fatal(err)
+	}
+	expectContent(t, path, "A\nb\nc\nd\n")
+
+	_, err = r.Render(out, file("X\nb\nc\nd\n"))
+	if _, ok := err.(*MergeConflictsError); !ok {
+		t.Fatalf("got error %v, expected merge conflicts", err)
+	}
+	expectContent(t, path, "<<<<<<< current\nA\n=======\nX\n>>>>>>> generated\nb\nc\nd\n")
+}
+
+func expectContent(t *testing.T, path, expected string) {
+	t.Helper()
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != expected {
+		t.Errorf("got\n%s\nexpected\n%s", b, expected)
+	}
+}
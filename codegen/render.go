@@ -0,0 +1,516 @@
+package codegen
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"goa.design/goa/v3/eval"
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// Renderer renders sets of files concurrently.
+	Renderer struct {
+		// Workers is the maximum number of services whose files are
+		// rendered concurrently and of files written and formatted
+		// concurrently, see File.Service. The files are rendered and
+		// written sequentially if Workers is lower than 2.
+		Workers int
+		// CacheDir is the path to the directory used to cache the Go
+		// files generated for the services listed in CacheKeys. The
+		// files are looked up in the cache before their section
+		// templates are executed so that the files of the services
+		// whose design does not change are neither rendered nor
+		// formatted again. No cache is used if CacheDir is empty.
+		CacheDir string
+		// CacheKeys maps the names of the services to the digests of
+		// the design the content of their files depends on, see
+		// File.Service. The cache keys of the files are computed from
+		// these digests, from the goa version and from the section
+		// templates of the files. The files of the services that are
+		// not listed are not cached.
+		CacheKeys map[string]string
+		// Trace is called once per rendered file after all the files
+		// have been written if not nil.
+		Trace func(*RenderTrace)
+		// Update causes the files that are skipped because they exist
+		// (see File.SkipExist) to be rendered and merged with the
+		// existing files instead. The changes made to the previously
+		// rendered content are merged with the changes made to the
+		// newly rendered content if the previously rendered content
+		// is stored in BaseDir, see Merge3. The missing top level
+		// declarations are added to the existing Go source files
+		// otherwise.
+		Update bool
+		// BaseDir is the path to the directory where the content of the
+		// files rendered with SkipExist is stored so that they can be
+		// merged in update mode later on. The content is not stored if
+		// BaseDir is empty.
+		BaseDir string
+		// SourceMap records the design expressions that produced the
+		// top level declarations of the rendered Go files if not nil,
+		// see SectionTemplate.Expr. The file paths are relative to the
+		// output directory.
+		SourceMap *SourceMap
+	}
+
+	// RenderTrace describes the rendering of a single file.
+	RenderTrace struct {
+		// Path is the absolute path to the rendered file.
+		Path string
+		// Execute is the time spent executing the section templates.
+		Execute time.Duration
+		// Write is the time spent writing, formatting and finalizing
+		// the file.
+		Write time.Duration
+		// Cached is true if the file was read from the cache.
+		Cached bool
+	}
+
+	// renderJob lists the files rendered to the same path.
+	renderJob struct {
+		path   string
+		files  []*File
+		srcs   [][]byte
+		traces []*RenderTrace
+		// merge is true if the rendered content must be merged with
+		// the existing file.
+		merge bool
+		// conflict is true if merging resulted in conflicts.
+		conflict bool
+		// decls lists the indexes of the sections of each file indexed
+		// by the names of the top level declarations they render, see
+		// File.declSections.
+		decls []map[string]int
+		// key is the cache key of the job, empty if the job is not
+		// cached.
+		key string
+		// entry is the cache entry read for the job if any.
+		entry *cacheEntry
+		err   error
+	}
+
+	// cacheEntry is the content of a file stored in the cache.
+	cacheEntry struct {
+		// Content is the content of the written file.
+		Content []byte
+		// Decls lists the indexes of the sections of each file
+		// indexed by the names of the top level declarations they
+		// render.
+		Decls []map[string]int
+	}
+
+	// renderTask identifies a file of a render job.
+	renderTask struct {
+		job *renderJob
+		i   int
+	}
+)
+
+// Render renders the given files to dir and returns the absolute paths of the
+// files that were written in order. The files found in the cache are written
+// as is, the section templates of the other files are executed first: the
+// files of each service are rendered in order by a dedicated goroutine as the
+// template functions share the service state (e.g. name scopes) while the
+// files that do not belong to a service are rendered sequentially once the
+// services are done as they may use the state of any service, see
+// File.Service. The rendered sources are then written, formatted and finalized
+// concurrently. Files that share the same path are written in order by the
+// same goroutine. Finally the unexported functions that are not referenced
+// anywhere are removed from the generated Go packages.
+func (r *Renderer) Render(dir string, files []*File) ([]string, error) {
+	var (
+		jobs    []*renderJob
+		byPath  = make(map[string]*renderJob)
+		planned = make(map[string]bool)
+	)
+	for _, f := range files {
+		path, merge, err := f.plan(dir, planned, r.Update)
+		if err != nil {
+			return nil, err
+		}
+		if path == "" {
+			continue
+		}
+		planned[path] = true
+		job, ok := byPath[path]
+		if !ok {
+			job = &renderJob{path: path, merge: merge}
+			byPath[path] = job
+			jobs = append(jobs, job)
+		}
+		job.files = append(job.files, f)
+		job.traces = append(job.traces, &RenderTrace{Path: path})
+	}
+	for _, job := range jobs {
+		job.srcs = make([][]byte, len(job.files))
+		job.decls = make([]map[string]int, len(job.files))
+		r.lookup(job)
+	}
+	if err := r.execute(jobs); err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		if job.entry != nil {
+			job.decls = job.entry.Decls
+			continue
+		}
+		if r.SourceMap == nil && job.key == "" {
+			continue
+		}
+		for i, f := range job.files {
+			job.decls[i] = f.declSections(job.srcs[i])
+		}
+	}
+
+	var (
+		wg   sync.WaitGroup
+		todo = make(chan *renderJob)
+	)
+	for i := 0; i < r.workers(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range todo {
+				job.err = r.write(job)
+			}
+		}()
+	}
+	for _, job := range jobs {
+		todo <- job
+	}
+	close(todo)
+	wg.Wait()
+
+	var (
+		paths     = make([]string, len(jobs))
+		dirs      []string
+		seen      = make(map[string]bool)
+		conflicts []string
+	)
+	for i, job := range jobs {
+		if job.err != nil {
+			return nil, job.err
+		}
+		if job.conflict {
+			conflicts = append(conflicts, job.path)
+		}
+		paths[i] = job.path
+		if dir := filepath.Dir(job.path); filepath.Ext(job.path) == ".go" && !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	if err := removeDeadHelpers(dirs); err != nil {
+		return nil, err
+	}
+	if r.SourceMap != nil {
+		base, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, job := range jobs {
+			exprs := job.exprs()
+			if len(exprs) == 0 {
+				continue
+			}
+			rel, err := filepath.Rel(base, job.path)
+			if err != nil {
+				return nil, err
+			}
+			r.SourceMap.add(rel, job.path, exprs)
+		}
+	}
+	if r.Trace != nil {
+		for _, job := range jobs {
+			for _, t := range job.traces {
+				r.Trace(t)
+			}
+		}
+	}
+	if len(conflicts) > 0 {
+		return paths, &MergeConflictsError{Paths: conflicts}
+	}
+	return paths, nil
+}
+
+// workers returns the number of goroutines used to render the files.
+func (r *Renderer) workers() int {
+	if r.Workers < 1 {
+		return 1
+	}
+	return r.Workers
+}
+
+// execute executes the section templates of the files of the jobs that were
+// not found in the cache, see Render.
+func (r *Renderer) execute(jobs []*renderJob) error {
+	var (
+		services []string
+		tasks    = make(map[string][]renderTask)
+	)
+	for _, job := range jobs {
+		if job.entry != nil {
+			continue
+		}
+		for i, f := range job.files {
+			if _, ok := tasks[f.Service]; !ok && f.Service != "" {
+				services = append(services, f.Service)
+			}
+			tasks[f.Service] = append(tasks[f.Service], renderTask{job: job, i: i})
+		}
+	}
+	var (
+		wg   sync.WaitGroup
+		sem  = make(chan struct{}, r.workers())
+		errs = make([]error, len(services))
+	)
+	for i, svc := range services {
+		wg.Add(1)
+		go func(i int, ts []renderTask) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			errs[i] = executeTasks(ts)
+		}(i, tasks[svc])
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return executeTasks(tasks[""])
+}
+
+// executeTasks executes the section templates of the files identified by ts in
+// order.
+func executeTasks(ts []renderTask) error {
+	for _, t := range ts {
+		start := time.Now()
+		src, err := t.job.files[t.i].execute()
+		if err != nil {
+			return err
+		}
+		t.job.srcs[t.i] = src
+		t.job.traces[t.i].Execute = time.Since(start)
+	}
+	return nil
+}
+
+// exprs returns the expressions that produced the top level declarations of
+// the files of job indexed by declaration name.
+func (job *renderJob) exprs() map[string]eval.Expression {
+	var exprs map[string]eval.Expression
+	for i, decls := range job.decls {
+		for name, s := range decls {
+			if exprs == nil {
+				exprs = make(map[string]eval.Expression)
+			}
+			exprs[name] = job.files[i].SectionTemplates[s].Expr
+		}
+	}
+	return exprs
+}
+
+// write writes the files of job.
+func (r *Renderer) write(job *renderJob) error {
+	if job.entry != nil {
+		start := time.Now()
+		if err := os.MkdirAll(filepath.Dir(job.path), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(job.path, job.entry.Content, 0644); err != nil {
+			return err
+		}
+		job.traces[0].Write = time.Since(start)
+		for _, t := range job.traces {
+			t.Cached = true
+		}
+		return nil
+	}
+	if job.merge {
+		start := time.Now()
+		if err := r.merge(job); err != nil {
+			return err
+		}
+		job.traces[0].Write = time.Since(start)
+		return nil
+	}
+	for i, f := range job.files {
+		start := time.Now()
+		if err := f.write(job.path, job.srcs[i]); err != nil {
+			return err
+		}
+		job.traces[i].Write = time.Since(start)
+		if f.SkipExist && r.BaseDir != "" {
+			b, err := ioutil.ReadFile(job.path)
+			if err != nil {
+				return err
+			}
+			if err := r.storeBase(f.Path, b); err != nil {
+				return err
+			}
+		}
+	}
+	if job.key != "" {
+		r.store(job)
+	}
+	return nil
+}
+
+// merge renders the file of job and merges the result with the existing file.
+// Jobs that merge files contain a single file as the files rendered with
+// SkipExist are rendered once.
+func (r *Renderer) merge(job *renderJob) error {
+	f := job.files[0]
+	ours, err := ioutil.ReadFile(job.path)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(job.path); err != nil {
+		return err
+	}
+	if err := f.write(job.path, job.srcs[0]); err != nil {
+		// Restore the existing file.
+		ioutil.WriteFile(job.path, ours, 0644)
+		return err
+	}
+	theirs, err := ioutil.ReadFile(job.path)
+	if err != nil {
+		return err
+	}
+	merged := ours
+	if base, ok := r.readBase(f.Path); ok {
+		merged, job.conflict = Merge3(base, ours, theirs)
+		if !job.conflict && filepath.Ext(job.path) == ".go" {
+			merged = formatMerged(merged)
+		}
+	} else if filepath.Ext(job.path) == ".go" {
+		if bs, err := mergeMissingDecls(job.path, ours, theirs); err == nil {
+			merged = bs
+		}
+	}
+	if err := ioutil.WriteFile(job.path, merged, 0644); err != nil {
+		return err
+	}
+	return r.storeBase(f.Path, theirs)
+}
+
+// readBase returns the content of the file with the given path relative to
+// the output directory stored in BaseDir if any.
+func (r *Renderer) readBase(rel string) ([]byte, bool) {
+	if r.BaseDir == "" {
+		return nil, false
+	}
+	b, err := ioutil.ReadFile(filepath.Join(r.BaseDir, rel))
+	return b, err == nil
+}
+
+// storeBase stores content in BaseDir as the content of the file with the
+// given path relative to the output directory.
+func (r *Renderer) storeBase(rel string, content []byte) error {
+	if r.BaseDir == "" {
+		return nil
+	}
+	p := filepath.Join(r.BaseDir, rel)
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(p, content, 0644)
+}
+
+// lookup computes the cache key of job and reads the corresponding cache entry
+// if any. Only the Go files that belong to the services listed in CacheKeys and
+// that are written as rendered are cached: the key does not reflect the
+// content of the existing files, of the file transforms or of the files
+// modified by the file finalizers.
+func (r *Renderer) lookup(job *renderJob) {
+	if r.CacheDir == "" || job.merge || filepath.Ext(job.path) != ".go" {
+		return
+	}
+	h := sha256.New()
+	io.WriteString(h, goa.Version()+"\x00")
+	for _, t := range astTransforms {
+		io.WriteString(h, t.name+"\x00")
+	}
+	io.WriteString(h, filepath.ToSlash(job.files[0].Path)+"\x00")
+	for _, f := range job.files {
+		key, ok := r.CacheKeys[f.Service]
+		if !ok || f.Service == "" || f.SkipExist || len(f.Transforms) > 0 || f.FinalizeFunc != nil {
+			return
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00", key, len(f.SectionTemplates))
+		for _, s := range f.SectionTemplates {
+			fmt.Fprintf(h, "%s\x00%d\x00%s", s.Name, len(s.Source), s.Source)
+		}
+	}
+	job.key = hex.EncodeToString(h.Sum(nil))
+	b, err := ioutil.ReadFile(r.cachePath(job.key))
+	if err != nil {
+		return
+	}
+	var e cacheEntry
+	if err := json.Unmarshal(b, &e); err != nil || len(e.Decls) != len(job.files) {
+		return
+	}
+	job.entry = &e
+}
+
+// store stores the content of the file written by job in the cache. Failing to
+// cache the file only slows down the next generation so errors are ignored.
+func (r *Renderer) store(job *renderJob) {
+	content, err := ioutil.ReadFile(job.path)
+	if err != nil {
+		return
+	}
+	b, err := json.Marshal(&cacheEntry{Content: content, Decls: job.decls})
+	if err != nil {
+		return
+	}
+	cached := r.cachePath(job.key)
+	if err := os.MkdirAll(filepath.Dir(cached), 0755); err != nil {
+		return
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(cached), job.key+".*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(b)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		// Rename so that concurrent generations never read a partially
+		// written entry.
+		err = os.Rename(tmp.Name(), cached)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// cachePath returns the path to the cache entry with the given key.
+func (r *Renderer) cachePath(key string) string {
+	return filepath.Join(r.CacheDir, key[:2], key)
+}
+
+// formatGoSource formats the Go source file at path after applying the
+// registered AST transforms followed by the given file transforms, see
+// finalizeGoSource.
+func formatGoSource(path string, transforms []ASTTransform) error {
+	all := make([]ASTTransform, 0, len(astTransforms)+len(transforms))
+	for _, t := range astTransforms {
+		all = append(all, t.fn)
+	}
+	all = append(all, transforms...)
+	return finalizeGoSource(path, all)
+}
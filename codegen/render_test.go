@@ -0,0 +1,171 @@
+package codegen
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRendererRender(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goa-render")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cache := filepath.Join(dir, "cache")
+	out := filepath.Join(dir, "out")
+
+	var executed int32
+	section := func(src string) []*SectionTemplate {
+		count := func() string { atomic.AddInt32(&executed, 1); return "" }
+		return []*SectionTemplate{{Name: "section", Source: "{{ count }}" + src, FuncMap: map[string]interface{}{"count": count}}}
+	}
+	files := func() []*File {
+		return []*File{
+			{Path: "a.go", SectionTemplates: section("package a\nimport \"fmt\"\nvar A   = 1\n"), Service: "a"},
+			{Path: "notes.txt", SectionTemplates: section("first\n"), Service: "a"},
+			{Path: "b.go", SectionTemplates: section("package b\nvar B   = 2\n"), Service: "b"},
+			{Path: "c.go", SectionTemplates: section("package c\nvar C   = 3\n")},
+			{Path: "notes.txt", SectionTemplates: section("second\n"), Service: "b"},
+			{Path: "skip.txt", SectionTemplates: section("kept\n"), SkipExist: true},
+			{Path: "skip.txt", SectionTemplates: section("skipped\n"), SkipExist: true},
+		}
+	}
+
+	var traces []*RenderTrace
+	r := &Renderer{
+		Workers:   4,
+		CacheDir:  cache,
+		CacheKeys: map[string]string{"a": "design a", "b": "design b"},
+		Trace:     func(t *RenderTrace) { traces = append(traces, t) },
+	}
+	paths, err := r.Render(out, files())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"a.go", "notes.txt", "b.go", "c.go", "skip.txt"}
+	if len(paths) != len(expected) {
+		t.Fatalf("got %d paths, expected %d: %v", len(paths), len(expected), paths)
+	}
+	for i, p := range expected {
+		if paths[i] != filepath.Join(out, p) {
+			t.Errorf("got path %q at index %d, expected %q", paths[i], i, filepath.Join(out, p))
+		}
+	}
+	contents := map[string]string{
+		"a.go":      "package a\n\nvar A = 1\n",
+		"b.go":      "package b\n\nvar B = 2\n",
+		"c.go":      "package c\n\nvar C = 3\n",
+		"notes.txt": "first\nsecond\n",
+		"skip.txt":  "kept\n",
+	}
+	for p, c := range contents {
+		b, err := ioutil.ReadFile(filepath.Join(out, p))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != c {
+			t.Errorf("%s: got\n%s\nexpected\n%s", p, string(b), c)
+		}
+	}
+	if len(traces) != 6 {
+		t.Errorf("got %d traces, expected 6", len(traces))
+	}
+	for _, tr := range traces {
+		if tr.Cached {
+			t.Errorf("%s: expected first rendering not to be cached", tr.Path)
+		}
+	}
+
+	if err := os.RemoveAll(out); err != nil {
+		t.Fatal(err)
+	}
+	traces = nil
+	executed = 0
+	if _, err := r.Render(out, files()); err != nil {
+		t.Fatal(err)
+	}
+	cached := 0
+	for _, tr := range traces {
+		if tr.Cached {
+			cached++
+		}
+	}
+	if cached != 2 {
+		t.Errorf("got %d cached files, expected 2", cached)
+	}
+	// a.go and b.go are cached, the sections of notes.txt (twice), c.go
+	// and skip.txt are executed.
+	if executed != 4 {
+		t.Errorf("got %d executed sections, expected 4", executed)
+	}
+	for _, p := range []string{"a.go", "b.go"} {
+		b, err := ioutil.ReadFile(filepath.Join(out, p))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != contents[p] {
+			t.Errorf("%s: got cached content\n%s\nexpected\n%s", p, string(b), contents[p])
+		}
+	}
+
+	if err := os.RemoveAll(out); err != nil {
+		t.Fatal(err)
+	}
+	traces = nil
+	executed = 0
+	r.CacheKeys["a"] = "design a changed"
+	if _, err := r.Render(out, files()); err != nil {
+		t.Fatal(err)
+	}
+	for _, tr := range traces {
+		if tr.Cached != (tr.Path == filepath.Join(out, "b.go")) {
+			t.Errorf("%s: got cached %v after changing the design of service a", tr.Path, tr.Cached)
+		}
+	}
+	if executed != 5 {
+		t.Errorf("got %d executed sections, expected 5", executed)
+	}
+}
+
+func TestRendererRenderServicesConcurrently(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goa-render")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The section of each service waits for the section of the other
+	// service to start executing so that rendering fails unless the
+	// services are rendered concurrently.
+	var (
+		started int32
+		both    = make(chan struct{})
+	)
+	wait := func() (string, error) {
+		if atomic.AddInt32(&started, 1) == 2 {
+			close(both)
+		}
+		select {
+		case <-both:
+			return "", nil
+		case <-time.After(5 * time.Second):
+			return "", errors.New("services rendered sequentially")
+		}
+	}
+	section := func(src string) []*SectionTemplate {
+		return []*SectionTemplate{{Name: "section", Source: "{{ wait }}" + src, FuncMap: map[string]interface{}{"wait": wait}}}
+	}
+	files := []*File{
+		{Path: "a.go", SectionTemplates: section("package a\n"), Service: "a"},
+		{Path: "b.go", SectionTemplates: section("package b\n"), Service: "b"},
+	}
+	r := &Renderer{Workers: 2}
+	if _, err := r.Render(dir, files); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,332 @@
+package routes
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// routeData contains the data used to render the registry entry of a
+	// method.
+	routeData struct {
+		// Service is the service name.
+		Service string
+		// Method is the method name.
+		Method string
+		// HTTP lists the HTTP routes of the method if any.
+		HTTP []*httpRouteData
+		// GRPC is the full name of the gRPC method if any.
+		GRPC string
+		// Requirements lists the security requirements of the method.
+		Requirements []*requirementData
+		// Timeout is the code of the timeout applied by the generated
+		// HTTP clients if any.
+		Timeout string
+		// QueueTimeout is the code of the maximum duration a request
+		// waits for a concurrency limit slot if any.
+		QueueTimeout string
+		// Streaming is true if the method streams its payload or result.
+		Streaming bool
+	}
+
+	// httpRouteData describes an HTTP route.
+	httpRouteData struct {
+		// Method is the HTTP method.
+		Method string
+		// Pattern is the full path pattern including wildcards.
+		Pattern string
+	}
+
+	// requirementData describes a security requirement.
+	requirementData struct {
+		// Schemes lists the names of the security schemes.
+		Schemes []string
+		// Scopes lists the required scopes.
+		Scopes []string
+	}
+)
+
+// Files returns the file that implements the gen/routes package. The package
+// lists the HTTP routes, the gRPC full method names, the security requirements
+// and the timeouts of all the methods of the design so that they may be
+// queried at runtime, for example to build admin dashboards, export the
+// configuration of an API gateway or produce authorization matrices.
+func Files(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var routes []*routeData
+	for _, svc := range root.Services {
+		for _, m := range svc.Methods {
+			routes = append(routes, buildRouteData(root, svc, m))
+		}
+	}
+	if len(routes) == 0 {
+		return nil
+	}
+	specs := []*codegen.ImportSpec{
+		{Path: "strings"},
+		{Path: "time"},
+	}
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(fmt.Sprintf("%s routes", root.API.Name), "routes", specs),
+		{Name: "routes-types", Source: typesT},
+		{Name: "routes-registry", Source: registryT, Data: routes},
+		{Name: "routes-lookup", Source: lookupT},
+	}
+	return []*codegen.File{{
+		Path:             filepath.Join(codegen.Gendir, "routes", "routes.go"),
+		SectionTemplates: sections,
+	}}
+}
+
+// buildRouteData builds the data needed to render the registry entry of the
+// given method.
+func buildRouteData(root *expr.RootExpr, svc *expr.ServiceExpr, m *expr.MethodExpr) *routeData {
+	data := &routeData{
+		Service:   svc.Name,
+		Method:    m.Name,
+		Streaming: m.IsStreaming(),
+	}
+	if hsvc := root.API.HTTP.Service(svc.Name); hsvc != nil {
+		if e := hsvc.Endpoint(m.Name); e != nil {
+			for _, r := range e.Routes {
+				for _, p := range r.FullPaths() {
+					data.HTTP = append(data.HTTP, &httpRouteData{Method: r.Method, Pattern: p})
+				}
+			}
+			if t := e.RequestTimeout(); t > 0 {
+				data.Timeout = durationLiteral(t)
+			}
+		}
+	}
+	if gsvc := root.API.GRPC.Service(svc.Name); gsvc != nil && gsvc.Endpoint(m.Name) != nil {
+		data.GRPC = grpcFullMethod(svc, m)
+	}
+	for _, req := range m.Requirements {
+		rd := &requirementData{Scopes: req.Scopes}
+		for _, s := range req.Schemes {
+			rd.Schemes = append(rd.Schemes, s.SchemeName)
+		}
+		data.Requirements = append(data.Requirements, rd)
+	}
+	if l := m.Limit(); l != nil && l.QueueTimeout > 0 {
+		data.QueueTimeout = durationLiteral(l.QueueTimeout)
+	}
+	return data
+}
+
+// grpcFullMethod returns the full name of the gRPC method generated for m as
+// used by the gRPC interceptors, it matches the package, service and rpc names
+// of the protocol buffer definition produced by the gRPC code generator.
+func grpcFullMethod(svc *expr.ServiceExpr, m *expr.MethodExpr) string {
+	sd := service.Services.Get(svc.Name)
+	pkg := codegen.SnakeCase(codegen.Goify(codegen.SnakeCase(sd.VarName), false))
+	return fmt.Sprintf("/%s.%s/%s", pkg, codegen.Goify(svc.Name, true), sd.Method(m.Name).VarName)
+}
+
+// durationLiteral returns the Go code for the given duration.
+func durationLiteral(d time.Duration) string {
+	units := []struct {
+		unit time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+	}
+	for _, u := range units {
+		if d == u.unit {
+			return u.name
+		}
+		if d%u.unit == 0 {
+			return fmt.Sprintf("%d * %s", d/u.unit, u.name)
+		}
+	}
+	return fmt.Sprintf("%d", d)
+}
+
+const typesT = `type (
+	// Route describes how a method is exposed: its HTTP routes, its gRPC
+	// method, its security requirements and its timeouts.
+	Route struct {
+		// Service is the name of the service.
+		Service string ` + "`" + `json:"service"` + "`" + `
+		// Method is the name of the method.
+		Method string ` + "`" + `json:"method"` + "`" + `
+		// HTTP lists the HTTP routes of the method if any.
+		HTTP []*HTTPRoute ` + "`" + `json:"http,omitempty"` + "`" + `
+		// GRPC is the gRPC full method name ("/package.Service/Method")
+		// if any.
+		GRPC string ` + "`" + `json:"grpc,omitempty"` + "`" + `
+		// Requirements lists the security requirements of the method,
+		// any one of them must be satisfied.
+		Requirements []*Requirement ` + "`" + `json:"requirements,omitempty"` + "`" + `
+		// Timeout is the timeout applied by the generated HTTP clients
+		// to the requests made to the method if any.
+		Timeout time.Duration ` + "`" + `json:"timeout,omitempty"` + "`" + `
+		// QueueTimeout is the maximum duration a request waits for a
+		// slot when the method concurrency limit is reached if any.
+		QueueTimeout time.Duration ` + "`" + `json:"queue_timeout,omitempty"` + "`" + `
+		// Streaming is true if the method streams its payload or its
+		// result.
+		Streaming bool ` + "`" + `json:"streaming,omitempty"` + "`" + `
+	}
+
+	// HTTPRoute is an HTTP route of a method.
+	HTTPRoute struct {
+		// Method is the HTTP method.
+		Method string ` + "`" + `json:"method"` + "`" + `
+		// Pattern is the path pattern, the path parameters and wildcards
+		// are enclosed in curly braces.
+		Pattern string ` + "`" + `json:"pattern"` + "`" + `
+	}
+
+	// Requirement is a security requirement of a method.
+	Requirement struct {
+		// Schemes lists the names of the security schemes that must all
+		// be satisfied.
+		Schemes []string ` + "`" + `json:"schemes"` + "`" + `
+		// Scopes lists the required scopes.
+		Scopes []string ` + "`" + `json:"scopes,omitempty"` + "`" + `
+	}
+)
+`
+
+// input: []*routeData
+const registryT = `// Routes lists the routes of all the methods in the order they are defined in
+// the design.
+var Routes = []*Route{
+{{- range . }}
+	{
+		Service: {{ printf "%q" .Service }},
+		Method:  {{ printf "%q" .Method }},
+	{{- if .HTTP }}
+		HTTP: []*HTTPRoute{
+		{{- range .HTTP }}
+			{Method: {{ printf "%q" .Method }}, Pattern: {{ printf "%q" .Pattern }}},
+		{{- end }}
+		},
+	{{- end }}
+	{{- if .GRPC }}
+		GRPC: {{ printf "%q" .GRPC }},
+	{{- end }}
+	{{- if .Requirements }}
+		Requirements: []*Requirement{
+		{{- range .Requirements }}
+			{Schemes: {{ printf "%#v" .Schemes }}{{ if .Scopes }}, Scopes: {{ printf "%#v" .Scopes }}{{ end }}},
+		{{- end }}
+		},
+	{{- end }}
+	{{- if .Timeout }}
+		Timeout: {{ .Timeout }},
+	{{- end }}
+	{{- if .QueueTimeout }}
+		QueueTimeout: {{ .QueueTimeout }},
+	{{- end }}
+	{{- if .Streaming }}
+		Streaming: true,
+	{{- end }}
+	},
+{{- end }}
+}
+`
+
+const lookupT = `// Lookup returns the route of the given service method, nil if there is none.
+func Lookup(service, method string) *Route {
+	for _, r := range Routes {
+		if r.Service == service && r.Method == method {
+			return r
+		}
+	}
+	return nil
+}
+
+// ServiceRoutes returns the routes of the methods of the given service.
+func ServiceRoutes(service string) []*Route {
+	var routes []*Route
+	for _, r := range Routes {
+		if r.Service == service {
+			routes = append(routes, r)
+		}
+	}
+	return routes
+}
+
+// RequiringScope returns the routes of the methods with a security requirement
+// that includes the given scope.
+func RequiringScope(scope string) []*Route {
+	var routes []*Route
+	for _, r := range Routes {
+		if r.RequiresScope(scope) {
+			routes = append(routes, r)
+		}
+	}
+	return routes
+}
+
+// MatchHTTP returns the route of the method that serves the HTTP requests made
+// with the given HTTP method to the given path, nil if there is none.
+func MatchHTTP(method, path string) *Route {
+	for _, r := range Routes {
+		for _, h := range r.HTTP {
+			if h.Method == method && h.Match(path) {
+				return r
+			}
+		}
+	}
+	return nil
+}
+
+// GRPCMethod returns the route of the method with the given gRPC full method
+// name, nil if there is none.
+func GRPCMethod(fullMethod string) *Route {
+	for _, r := range Routes {
+		if r.GRPC != "" && r.GRPC == fullMethod {
+			return r
+		}
+	}
+	return nil
+}
+
+// RequiresScope returns true if one of the security requirements of the method
+// includes the given scope.
+func (r *Route) RequiresScope(scope string) bool {
+	for _, req := range r.Requirements {
+		for _, s := range req.Scopes {
+			if s == scope {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Match returns true if the given path matches the route pattern. The
+// wildcards that start with "*" match the remainder of the path.
+func (h *HTTPRoute) Match(path string) bool {
+	pattern := strings.Split(strings.Trim(h.Pattern, "/"), "/")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, p := range pattern {
+		if strings.HasPrefix(p, "{*") {
+			return true
+		}
+		if i >= len(segments) {
+			return false
+		}
+		if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+			if segments[i] == "" {
+				return false
+			}
+			continue
+		}
+		if p != segments[i] {
+			return false
+		}
+	}
+	return len(pattern) == len(segments)
+}
+`
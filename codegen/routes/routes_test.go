@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"bytes"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/routes/testdata"
+)
+
+func TestFiles(t *testing.T) {
+	root := codegen.RunDSL(t, testdata.RoutesDSL)
+	fs := Files("", root)
+	if len(fs) != 1 {
+		t.Fatalf("got %d files, expected one", len(fs))
+	}
+	sections := fs[0].Section("routes-registry")
+	if len(sections) != 1 {
+		t.Fatalf("got %d routes-registry sections, expected one", len(sections))
+	}
+	var buf bytes.Buffer
+	if err := sections[0].Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if code := buf.String(); code != testdata.RoutesRegistryCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.RoutesRegistryCode))
+	}
+}
+
+func TestFilesNoMethod(t *testing.T) {
+	root := codegen.RunDSL(t, func() {})
+	if fs := Files("", root); len(fs) != 0 {
+		t.Errorf("got %d files, expected none", len(fs))
+	}
+}
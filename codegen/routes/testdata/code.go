@@ -0,0 +1,37 @@
+package testdata
+
+const RoutesRegistryCode = `// Routes lists the routes of all the methods in the order they are defined in
+// the design.
+var Routes = []*Route{
+	{
+		Service: "calc",
+		Method:  "add",
+		HTTP: []*HTTPRoute{
+			{Method: "GET", Pattern: "/calc/add/{a}/{b}"},
+			{Method: "GET", Pattern: "/calc/sum/{a}/{b}"},
+		},
+		GRPC: "/calc.Calc/Add",
+		Requirements: []*Requirement{
+			{Schemes: []string{"jwt"}, Scopes: []string{"api:read"}},
+		},
+		Timeout: 5 * time.Second,
+	},
+	{
+		Service: "calc",
+		Method:  "report",
+		GRPC: "/calc.Calc/Report",
+		Requirements: []*Requirement{
+			{Schemes: []string{"jwt"}, Scopes: []string{"api:write"}},
+		},
+		QueueTimeout: 1500 * time.Millisecond,
+	},
+	{
+		Service: "calc",
+		Method:  "watch",
+		HTTP: []*HTTPRoute{
+			{Method: "GET", Pattern: "/calc/watch"},
+		},
+		Streaming: true,
+	},
+}
+`
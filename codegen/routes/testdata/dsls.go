@@ -0,0 +1,52 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var RoutesDSL = func() {
+	var JWTAuth = JWTSecurity("jwt", func() {
+		Scope("api:read")
+		Scope("api:write")
+	})
+	Service("calc", func() {
+		Security(JWTAuth, func() {
+			Scope("api:read")
+		})
+		HTTP(func() {
+			Path("/calc")
+		})
+		Method("add", func() {
+			Payload(func() {
+				Token("token", String)
+				Attribute("a", Int)
+				Attribute("b", Int)
+			})
+			HTTP(func() {
+				ClientTimeout("5s")
+				GET("/add/{a}/{b}")
+				GET("/sum/{a}/{b}")
+			})
+			GRPC(func() {})
+		})
+		Method("report", func() {
+			Security(JWTAuth, func() {
+				Scope("api:write")
+			})
+			Payload(func() {
+				Token("token", String)
+			})
+			ConcurrencyLimit(4, func() {
+				Queue(16, "1500ms")
+			})
+			GRPC(func() {})
+		})
+		Method("watch", func() {
+			NoSecurity()
+			StreamingResult(Int)
+			HTTP(func() {
+				GET("/watch")
+			})
+		})
+	})
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// apiKeyAuthData contains the data used to render the apikeyauth
+	// package of a service.
+	apiKeyAuthData struct {
+		// Name is the service name.
+		Name string
+		// Schemes lists the API key security schemes that load their
+		// keys from the environment.
+		Schemes []*apiKeyAuthSchemeData
+	}
+
+	// apiKeyAuthSchemeData describes the key ring of an API key security
+	// scheme.
+	apiKeyAuthSchemeData struct {
+		// SchemeName is the name of the security scheme.
+		SchemeName string
+		// Env is the name of the environment variable that lists the
+		// keys.
+		Env string
+		// Separator separates the key IDs from the secrets if any.
+		Separator string
+	}
+)
+
+// APIKeyAuthFile returns the file implementing the apikeyauth package of the
+// given service. The package implements the authorization function of the API
+// key security schemes that load their keys from the environment variable
+// defined with the "apikeyauth:env" meta. APIKeyAuthFile returns nil if the
+// service does not use such a scheme.
+func APIKeyAuthFile(service *expr.ServiceExpr) *codegen.File {
+	data := &apiKeyAuthData{Name: service.Name}
+	seen := make(map[string]bool)
+	for _, m := range service.Methods {
+		for _, req := range m.Requirements {
+			for _, s := range req.Schemes {
+				if s.Kind != expr.APIKeyKind || seen[s.SchemeName] {
+					continue
+				}
+				env, ok := s.Meta.Last("apikeyauth:env")
+				if !ok {
+					continue
+				}
+				seen[s.SchemeName] = true
+				sep, _ := s.Meta.Last("apikeyauth:separator")
+				data.Schemes = append(data.Schemes, &apiKeyAuthSchemeData{
+					SchemeName: s.SchemeName,
+					Env:        env,
+					Separator:  sep,
+				})
+			}
+		}
+	}
+	if len(data.Schemes) == 0 {
+		return nil
+	}
+	svc := Services.Get(service.Name)
+	path := filepath.Join(codegen.Gendir, codegen.SnakeCase(svc.VarName), "apikeyauth", "apikeyauth.go")
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" API key authorization", "apikeyauth",
+			[]*codegen.ImportSpec{
+				{Path: "context"},
+				{Path: "fmt"},
+				{Path: "os"},
+				codegen.GoaImport(""),
+				codegen.GoaImport("security"),
+			}),
+		{
+			Name:   "apikeyauth",
+			Source: apiKeyAuthT,
+			Data:   data,
+		},
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections, Service: service.Name}
+}
+
+// input: apiKeyAuthData
+const apiKeyAuthT = `{{ printf "Auth implements the API key security schemes of the %q service by verifying the keys with key rings loaded from the environment." .Name | comment }}
+type Auth struct {
+	// Rings indexes the key rings by security scheme name.
+	Rings map[string]*security.APIKeyRing
+}
+
+// New returns the API key authorization of the service loaded with the keys
+// listed in the environment variables, see Reload.
+func New() *Auth {
+	a := &Auth{
+		Rings: map[string]*security.APIKeyRing{
+		{{- range .Schemes }}
+			{{ printf "%q" .SchemeName }}: security.NewAPIKeyRing({{ printf "%q" .Separator }}),
+		{{- end }}
+		},
+	}
+	a.Reload()
+	return a
+}
+
+// Reload loads the keys listed in the environment variables into the key rings.
+// The variables contain comma separated lists of keys so that keys are rotated
+// by adding the new key to the list, calling Reload, moving the clients to the
+// new key and removing the old key from the list.
+func (a *Auth) Reload() {
+{{- range .Schemes }}
+	a.Rings[{{ printf "%q" .SchemeName }}].Set(security.ParseAPIKeys(os.Getenv({{ printf "%q" .Env }}))...)
+{{- end }}
+}
+
+// APIKeyAuth verifies the key in constant time and returns a context that
+// contains the ID of the key, see KeyID.
+// APIKeyAuth implements the APIKeyAuth method of the service.
+func (a *Auth) APIKeyAuth(ctx context.Context, key string, scheme *security.APIKeyScheme) (context.Context, error) {
+	r, ok := a.Rings[scheme.Name]
+	if !ok {
+		return ctx, fmt.Errorf("no key ring for security scheme %q", scheme.Name)
+	}
+	id, ok := r.Verify(key)
+	if !ok {
+		return ctx, goa.PermanentError("unauthorized", "invalid API key")
+	}
+	return security.WithAPIKeyID(ctx, id), nil
+}
+
+// KeyID returns the ID of the key verified by APIKeyAuth, the empty string if
+// the key has no ID.
+func KeyID(ctx context.Context) string {
+	id, _ := security.ContextAPIKeyID(ctx)
+	return id
+}
+`
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// auditData contains the data used to render the audit package of a
+	// service.
+	auditData struct {
+		// Name is the service name.
+		Name string
+		// PkgName is the name of the service package.
+		PkgName string
+		// Methods lists the audited methods.
+		Methods []*auditMethodData
+	}
+
+	// auditMethodData describes an audited method.
+	auditMethodData struct {
+		// Name is the method name.
+		Name string
+		// VarName is the name of the method endpoint field.
+		VarName string
+		// PayloadRef is the fully qualified reference to the type of the
+		// endpoint request if the method accesses resources.
+		PayloadRef string
+		// PayloadField is the name of the field of the endpoint request
+		// that holds the payload for methods that stream results.
+		PayloadField string
+		// Resources lists the payload attributes that identify the
+		// resources accessed by the method.
+		Resources []*auditResourceData
+	}
+
+	// auditResourceData describes a payload attribute that identifies a
+	// resource accessed by a method.
+	auditResourceData struct {
+		// Name is the name of the resource in the audit events.
+		Name string
+		// Field is the name of the payload field.
+		Field string
+		// Pointer is true if the payload field is a pointer.
+		Pointer bool
+		// String is true if the payload field is a string.
+		String bool
+	}
+)
+
+// AuditFile returns the file implementing the audit package of the given
+// service. The package wraps the endpoints of the methods audited in the design
+// with endpoints that record an audit event for each request, see the "audit"
+// and "audit:resource" meta. AuditFile returns nil if the service has no
+// audited method.
+func AuditFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
+	svc := Services.Get(service.Name)
+	data := &auditData{Name: service.Name, PkgName: svc.PkgName}
+	for _, m := range service.Methods {
+		if !m.IsAudited() {
+			continue
+		}
+		md := svc.Method(m.Name)
+		amd := &auditMethodData{Name: m.Name, VarName: md.VarName}
+		for _, nat := range m.AuditResources() {
+			name := nat.Name
+			if v, ok := nat.Attribute.Meta.Last("audit:resource"); ok && v != "" {
+				name = v
+			}
+			amd.Resources = append(amd.Resources, &auditResourceData{
+				Name:    name,
+				Field:   codegen.Goify(nat.Name, true),
+				Pointer: m.Payload.IsPrimitivePointer(nat.Name, true),
+				String:  nat.Attribute.Type == expr.String,
+			})
+		}
+		if len(amd.Resources) > 0 {
+			if md.ServerStream != nil {
+				amd.PayloadRef = "*" + svc.PkgName + "." + md.ServerStream.EndpointStruct
+				amd.PayloadField = "Payload"
+			} else {
+				amd.PayloadRef = svc.Scope.GoFullTypeRef(m.Payload, svc.PkgName)
+			}
+		}
+		data.Methods = append(data.Methods, amd)
+	}
+	if len(data.Methods) == 0 {
+		return nil
+	}
+	svcName := codegen.SnakeCase(svc.VarName)
+	path := filepath.Join(codegen.Gendir, svcName, "audit", "audit.go")
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" audit", "audit",
+			[]*codegen.ImportSpec{
+				{Path: "fmt"},
+				{Path: genpkg + "/" + svcName, Name: svc.PkgName},
+				{Path: "goa.design/goa/v3/security/audit", Name: "goaaudit"},
+			}),
+		{
+			Name:   "audit-wrap",
+			Source: auditWrapT,
+			Data:   data,
+		},
+	}
+	for _, m := range data.Methods {
+		if len(m.Resources) == 0 {
+			continue
+		}
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "audit-resources",
+			Source: auditResourcesT,
+			Data:   m,
+		})
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections, Service: service.Name}
+}
+
+// input: auditData
+const auditWrapT = `{{ printf "Wrap wraps the audited endpoints of the %q service with endpoints that record an audit event in sink for each request. It must be called before the endpoints are mounted on the transport servers." .Name | comment }}
+func Wrap(e *{{ .PkgName }}.Endpoints, sink goaaudit.Sink) {
+{{- range .Methods }}
+	e.{{ .VarName }} = goaaudit.Endpoint(sink, {{ printf "%q" $.Name }}, {{ printf "%q" .Name }}, {{ if .Resources }}{{ .VarName }}Resources{{ else }}nil{{ end }}, e.{{ .VarName }})
+{{- end }}
+}
+`
+
+// input: auditMethodData
+const auditResourcesT = `{{ printf "%sResources returns the resources accessed by the requests made to the %q method." .VarName .Name | comment }}
+func {{ .VarName }}Resources(v interface{}) map[string]string {
+	p := v.({{ .PayloadRef }}){{ if .PayloadField }}.{{ .PayloadField }}{{ end }}
+	res := make(map[string]string)
+{{- range .Resources }}
+	{{- if .Pointer }}
+	if p.{{ .Field }} != nil {
+		res[{{ printf "%q" .Name }}] = {{ if .String }}*p.{{ .Field }}{{ else }}fmt.Sprint(*p.{{ .Field }}){{ end }}
+	}
+	{{- else }}
+	res[{{ printf "%q" .Name }}] = {{ if .String }}p.{{ .Field }}{{ else }}fmt.Sprint(p.{{ .Field }}){{ end }}
+	{{- end }}
+{{- end }}
+	return res
+}
+`
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"bytes"
+	"go/format"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestAuditFile(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Path string
+		Code string
+	}{
+		{"service", testdata.AuditDSL, filepath.Join("gen", "orders", "audit", "audit.go"), testdata.AuditCode},
+		{"resource", testdata.AuditResourceDSL, filepath.Join("gen", "audit_resource", "audit", "audit.go"), testdata.AuditResourceCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			codegen.RunDSL(t, c.DSL)
+			f := AuditFile("goa.design/goa/example", expr.Root.Services[0])
+			if f == nil {
+				t.Fatalf("got nil file, expected not nil")
+			}
+			if f.Path != c.Path {
+				t.Errorf("got path %q, expected %q", f.Path, c.Path)
+			}
+			buf := new(bytes.Buffer)
+			for _, s := range f.SectionTemplates[1:] {
+				if err := s.Write(buf); err != nil {
+					t.Fatal(err)
+				}
+			}
+			bs, err := format.Source(buf.Bytes())
+			if err != nil {
+				t.Fatalf("%s\n%s", err, buf.String())
+			}
+			if code := string(bs); code != c.Code {
+				t.Errorf("got\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+	t.Run("none", func(t *testing.T) {
+		codegen.RunDSL(t, testdata.AuditNoneDSL)
+		if f := AuditFile("goa.design/goa/example", expr.Root.Services[0]); f != nil {
+			t.Errorf("got file %q, expected nil", f.Path)
+		}
+	})
+}
@@ -67,10 +67,46 @@ func AuthFuncsFile(genpkg string, root *expr.RootExpr) *codegen.File {
 // data: Data
 const dummyAuthFuncsT = `{{ range .Schemes }}
 {{ printf "%sAuth implements the authorization logic for service %q for the %q security scheme." .Type $.Name .SchemeName | comment }}
-func (s *{{ $.VarName }}srvc) {{ .Type }}Auth(ctx context.Context, {{ if eq .Type "Basic" }}user, pass{{ else if eq .Type "APIKey" }}key{{ else }}token{{ end }} string, scheme *security.{{ .Type }}Scheme) (context.Context, error) {
+func (s *{{ $.VarName }}srvc) {{ .Type }}Auth(ctx context.Context, {{ if eq .Type "Basic" }}user, pass string{{ else if eq .Type "APIKey" }}key string{{ else if eq .Type "MTLS" }}cert *security.ClientCertificate{{ else if eq .Type "Signature" }}keyID string{{ else }}token string{{ end }}, scheme *security.{{ .Type }}Scheme) (context.Context, error) {
 	//
 	// TBD: add authorization logic.
 	//
+{{- if or (eq .Type "JWT") (eq .Type "OAuth2") }}
+{{- if .WildcardScopes }}
+	// Once the token is verified, check that the scopes it grants imply
+	// the scopes required by the method. Wildcard scopes such as
+	// "orders:*" imply all the scopes that share their prefix, e.g.:
+{{- else }}
+	// Once the token is verified, check that it grants the scopes
+	// required by the method, e.g.:
+{{- end }}
+	//
+	//    if err := scheme.Validate(scopes); err != nil {
+	//        return ctx, goa.PermanentError("unauthorized", err.Error())
+	//    }
+	//
+{{- end }}
+{{- if eq .Type "MTLS" }}
+	// cert is nil if the client did not present a certificate. The
+	// certificate chain has been verified during the TLS handshake when
+	// the server is configured with security.MTLSConfig, the function
+	// only needs to authorize the client identity, e.g.:
+	//
+	//    if cert == nil || !cert.HasOrganizationalUnit("payments") {
+	//        return ctx, goa.PermanentError("unauthorized", "invalid client certificate")
+	//    }
+	//
+{{- end }}
+{{- if eq .Type "Signature" }}
+	// keyID is the ID of the key that signed the request, the signature
+	// has already been verified by the transport. keyID is empty if the
+	// request is not signed, e.g.:
+	//
+	//    if keyID == "" {
+	//        return ctx, goa.PermanentError("unauthorized", "request is not signed")
+	//    }
+	//
+{{- end }}
 	// In case of authorization failure this function should return
 	// one of the generated error structs, e.g.:
 	//
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// basicAuthData contains the data used to render the basicauth package
+	// of a service.
+	basicAuthData struct {
+		// Name is the service name.
+		Name string
+		// Schemes lists the names of the basic auth security schemes
+		// that verify the passwords with a user store.
+		Schemes []string
+	}
+)
+
+// BasicAuthFile returns the file implementing the basicauth package of the
+// given service. The package implements the authorization function of the
+// basic auth security schemes that define the "basicauth" meta by verifying
+// the passwords with the hashes of a user store. BasicAuthFile returns nil if
+// the service does not use such a scheme.
+func BasicAuthFile(service *expr.ServiceExpr) *codegen.File {
+	data := &basicAuthData{Name: service.Name}
+	seen := make(map[string]bool)
+	for _, m := range service.Methods {
+		for _, req := range m.Requirements {
+			for _, s := range req.Schemes {
+				if s.Kind != expr.BasicAuthKind || seen[s.SchemeName] {
+					continue
+				}
+				if _, ok := s.Meta["basicauth"]; !ok {
+					continue
+				}
+				seen[s.SchemeName] = true
+				data.Schemes = append(data.Schemes, s.SchemeName)
+			}
+		}
+	}
+	if len(data.Schemes) == 0 {
+		return nil
+	}
+	svc := Services.Get(service.Name)
+	path := filepath.Join(codegen.Gendir, codegen.SnakeCase(svc.VarName), "basicauth", "basicauth.go")
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" basic auth authorization", "basicauth",
+			[]*codegen.ImportSpec{
+				{Path: "context"},
+				{Path: "fmt"},
+				codegen.GoaImport(""),
+				codegen.GoaImport("security"),
+			}),
+		{
+			Name:   "basicauth",
+			Source: basicAuthT,
+			Data:   data,
+		},
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections, Service: service.Name}
+}
+
+// input: basicAuthData
+const basicAuthT = `{{ printf "Auth implements the basic auth security schemes of the %q service by verifying the passwords with the hashes of a user store." .Name | comment }}
+type Auth struct {
+	// Authenticators indexes the authenticators by security scheme name.
+	Authenticators map[string]*security.BasicAuthenticator
+}
+
+// New returns the basic auth authorization of the service that verifies the
+// passwords with the hashes of users. Register the verifiers of the hash
+// formats used by the store with UseBcrypt or UseArgon2id, for example:
+//
+//    users, err := security.ParseHtpasswd(f)
+//    ...
+//    a := basicauth.New(users)
+//    a.UseBcrypt(bcrypt.CompareHashAndPassword)
+//
+func New(users security.UserStore) *Auth {
+	return &Auth{
+		Authenticators: map[string]*security.BasicAuthenticator{
+		{{- range .Schemes }}
+			{{ printf "%q" . }}: security.NewBasicAuthenticator(users),
+		{{- end }}
+		},
+	}
+}
+
+// UseBcrypt registers the verifier of the bcrypt hashes with all the
+// authenticators, typically bcrypt.CompareHashAndPassword of
+// golang.org/x/crypto/bcrypt.
+func (a *Auth) UseBcrypt(v security.PasswordVerifier) {
+	for _, au := range a.Authenticators {
+		au.UseBcrypt(v)
+	}
+}
+
+// UseArgon2id registers the verifier of the argon2id hashes with all the
+// authenticators, idKey is typically argon2.IDKey of
+// golang.org/x/crypto/argon2.
+func (a *Auth) UseArgon2id(idKey security.Argon2IDKeyFunc) {
+	for _, au := range a.Authenticators {
+		au.UseArgon2id(idKey)
+	}
+}
+
+// BasicAuth verifies the password of the user and returns a context that
+// contains the name of the user, see User.
+// BasicAuth implements the BasicAuth method of the service.
+func (a *Auth) BasicAuth(ctx context.Context, user, pass string, scheme *security.BasicScheme) (context.Context, error) {
+	au, ok := a.Authenticators[scheme.Name]
+	if !ok {
+		return ctx, fmt.Errorf("no authenticator for security scheme %q", scheme.Name)
+	}
+	if err := au.Verify(ctx, user, pass); err != nil {
+		if err == security.ErrInvalidCredentials {
+			return ctx, goa.PermanentError("unauthorized", "invalid username or password")
+		}
+		return ctx, goa.Fault("failed to verify the password of %q: %s", user, err)
+	}
+	return security.WithBasicUser(ctx, user), nil
+}
+
+// User returns the name of the user authenticated by BasicAuth.
+func User(ctx context.Context) string {
+	u, _ := security.ContextBasicUser(ctx)
+	return u
+}
+`
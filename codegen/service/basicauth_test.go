@@ -0,0 +1,44 @@
+package service
+
+import (
+	"bytes"
+	"go/format"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestBasicAuthFile(t *testing.T) {
+	t.Run("meta", func(t *testing.T) {
+		codegen.RunDSL(t, testdata.BasicAuthDSL)
+		f := BasicAuthFile(expr.Root.Services[0])
+		if f == nil {
+			t.Fatalf("got nil file, expected not nil")
+		}
+		if f.Path != filepath.Join("gen", "basic_auth", "basicauth", "basicauth.go") {
+			t.Errorf("got path %q", f.Path)
+		}
+		buf := new(bytes.Buffer)
+		for _, s := range f.SectionTemplates[1:] {
+			if err := s.Write(buf); err != nil {
+				t.Fatal(err)
+			}
+		}
+		bs, err := format.Source(buf.Bytes())
+		if err != nil {
+			t.Fatalf("%s\n%s", err, buf.String())
+		}
+		if code := string(bs); code != testdata.BasicAuthCode {
+			t.Errorf("got\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.BasicAuthCode))
+		}
+	})
+	t.Run("no-meta", func(t *testing.T) {
+		codegen.RunDSL(t, testdata.BasicAuthNoMetaDSL)
+		if f := BasicAuthFile(expr.Root.Services[0]); f != nil {
+			t.Errorf("got file %q, expected nil", f.Path)
+		}
+	})
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// cachingData contains the data used to render the caching package of
+	// a service.
+	cachingData struct {
+		// Name is the service name.
+		Name string
+		// PkgName is the name of the service package.
+		PkgName string
+		// Methods lists the cached methods.
+		Methods []*cachingMethodData
+	}
+
+	// cachingMethodData describes a cached method.
+	cachingMethodData struct {
+		// Service is the service name.
+		Service string
+		// Name is the method name.
+		Name string
+		// VarName is the name of the method endpoint field.
+		VarName string
+		// InvalidateDoc is the documentation of the invalidation
+		// function.
+		InvalidateDoc string
+		// PayloadRef is the fully qualified reference to the payload
+		// type if the method has a payload.
+		PayloadRef string
+		// Keys lists the payload attributes that key the results, the
+		// whole payload keys the results if empty.
+		Keys []*cachingKeyData
+	}
+
+	// cachingKeyData describes a payload attribute that keys the results.
+	cachingKeyData struct {
+		// Name is the attribute name.
+		Name string
+		// ArgName is the name of the invalidation function argument.
+		ArgName string
+		// TypeName is the Go type of the attribute.
+		TypeName string
+	}
+)
+
+// CachingFile returns the file implementing the caching package of the given
+// service. The package provides the functions that invalidate the results of
+// the methods defined with the Cache DSL, the endpoints memoize the results
+// once the requests are authenticated and authorized, see UseCache.
+// CachingFile returns nil if the service has no cached method.
+func CachingFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
+	svc := Services.Get(service.Name)
+	data := &cachingData{Name: service.Name, PkgName: svc.PkgName}
+	for _, m := range service.Methods {
+		if m.Cache == nil {
+			continue
+		}
+		cmd := &cachingMethodData{
+			Service: service.Name,
+			Name:    m.Name,
+			VarName: svc.Method(m.Name).VarName,
+		}
+		if m.Payload.Type != expr.Empty {
+			cmd.PayloadRef = svc.Scope.GoFullTypeRef(m.Payload, svc.PkgName)
+		}
+		scope := codegen.NewNameScope()
+		scope.Unique("ctx")
+		scope.Unique("store")
+		obj := expr.AsObject(m.Payload.Type)
+		for _, k := range m.Cache.Keys {
+			cmd.Keys = append(cmd.Keys, &cachingKeyData{
+				Name:     k,
+				ArgName:  scope.Unique(codegen.Goify(k, false)),
+				TypeName: codegen.GoNativeTypeName(obj.Attribute(k).Type),
+			})
+		}
+		cmd.InvalidateDoc = fmt.Sprintf("Invalidate%s removes the result of the %q method cached in store.", cmd.VarName, m.Name)
+		if cmd.PayloadRef != "" {
+			cmd.InvalidateDoc = fmt.Sprintf("Invalidate%s removes the result of the %q method cached for the given key from store.", cmd.VarName, m.Name)
+		}
+		cmd.InvalidateDoc += " Call it from the methods that modify the result."
+		data.Methods = append(data.Methods, cmd)
+	}
+	if len(data.Methods) == 0 {
+		return nil
+	}
+	svcName := codegen.SnakeCase(svc.VarName)
+	path := filepath.Join(codegen.Gendir, svcName, "caching", "caching.go")
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" caching", "caching",
+			[]*codegen.ImportSpec{
+				{Path: "context"},
+				codegen.GoaImport(""),
+				{Path: genpkg + "/" + svcName, Name: svc.PkgName},
+			}),
+	}
+	for _, m := range data.Methods {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "caching-invalidate",
+			Source: cachingInvalidateT,
+			Data:   m,
+		})
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections, Service: service.Name}
+}
+
+// durationLiteral returns the Go expression of the given duration.
+func durationLiteral(d time.Duration) string {
+	units := []struct {
+		unit time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+	}
+	for _, u := range units {
+		if d == u.unit {
+			return u.name
+		}
+		if d%u.unit == 0 {
+			return fmt.Sprintf("%d * %s", d/u.unit, u.name)
+		}
+	}
+	return fmt.Sprintf("time.Duration(%d)", int64(d))
+}
+
+// input: cachingMethodData
+const cachingInvalidateT = `{{ comment .InvalidateDoc }}
+func Invalidate{{ .VarName }}(ctx context.Context, store goa.CacheStore{{ if .Keys }}{{ range .Keys }}, {{ .ArgName }} {{ .TypeName }}{{ end }}{{ else if .PayloadRef }}, p {{ .PayloadRef }}{{ end }}) error {
+	return store.Delete(ctx, goa.CacheKey({{ printf "%q" .Service }}, {{ printf "%q" .Name }}{{ if .Keys }}{{ range .Keys }}, {{ .ArgName }}{{ end }}{{ else if .PayloadRef }}, p{{ end }}))
+}
+`
+
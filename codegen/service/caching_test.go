@@ -0,0 +1,43 @@
+package service
+
+import (
+	"bytes"
+	"go/format"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestCachingFile(t *testing.T) {
+	codegen.RunDSL(t, testdata.CachingDSL)
+	f := CachingFile("goa.design/goa/example", expr.Root.Services[0])
+	if f == nil {
+		t.Fatalf("got nil file, expected not nil")
+	}
+	if p := filepath.Join("gen", "cached_accounts", "caching", "caching.go"); f.Path != p {
+		t.Errorf("got path %q, expected %q", f.Path, p)
+	}
+	buf := new(bytes.Buffer)
+	for _, s := range f.SectionTemplates[1:] {
+		if err := s.Write(buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bs, err := format.Source(buf.Bytes())
+	if err != nil {
+		t.Fatalf("%s\n%s", err, buf.String())
+	}
+	if code := string(bs); code != testdata.CachingCode {
+		t.Errorf("got\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.CachingCode))
+	}
+
+	t.Run("none", func(t *testing.T) {
+		codegen.RunDSL(t, testdata.CachingNoneDSL)
+		if f := CachingFile("goa.design/goa/example", expr.Root.Services[0]); f != nil {
+			t.Errorf("got file %q, expected nil", f.Path)
+		}
+	})
+}
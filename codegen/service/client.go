@@ -30,11 +30,13 @@ func ClientFile(service *expr.ServiceExpr) *codegen.File {
 			Name:   "client-struct",
 			Source: serviceClientT,
 			Data:   data,
+			Expr:   service,
 		}
 		init := &codegen.SectionTemplate{
 			Name:   "client-init",
 			Source: serviceClientInitT,
 			Data:   data,
+			Expr:   service,
 		}
 		sections = []*codegen.SectionTemplate{header, def, init}
 		for _, m := range data.Methods {
@@ -42,11 +44,12 @@ func ClientFile(service *expr.ServiceExpr) *codegen.File {
 				Name:   "client-method",
 				Source: serviceClientMethodT,
 				Data:   m,
+				Expr:   service.Method(m.Name),
 			})
 		}
 	}
 
-	return &codegen.File{Path: path, SectionTemplates: sections}
+	return &codegen.File{Path: path, SectionTemplates: sections, Service: service.Name}
 }
 
 // input: endpointsData
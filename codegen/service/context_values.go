@@ -0,0 +1,192 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// contextValueData describes a context value accessor.
+type contextValueData struct {
+	// Name is the name of the context value.
+	Name string
+	// VarName is the name of the accessor function.
+	VarName string
+	// Doc is the documentation of the accessor.
+	Doc string
+	// Description is the context value description.
+	Description string
+	// Header is the name of the header that carries the value.
+	Header string
+	// Key is the key of the value in the propagated values, the lower case
+	// header name.
+	Key string
+	// TypeName is the Go type of the value.
+	TypeName string
+	// Zero is the Go zero value of the type.
+	Zero string
+	// Default is the Go literal of the default value if any.
+	Default string
+	// Parse is the code that parses s, the header value, and returns the
+	// result.
+	Parse string
+	// Format is the expression that formats v as a header value.
+	Format string
+}
+
+// ContextValuesFile returns the file implementing the contextvalues package of
+// the API. The package defines typed accessors for the context values declared
+// in the design with ContextValue. The values are stored in the context with
+// the other propagated headers so that the generated servers initialize them
+// and the generated clients forward them. The package also defines the Tenant
+// accessors if the design defines the tenant of the requests with Tenant.
+// ContextValuesFile returns nil if the design does not declare any context
+// value nor tenant.
+func ContextValuesFile(genpkg string, root *expr.RootExpr) *codegen.File {
+	if root.API == nil || len(root.API.ContextValues) == 0 && root.API.Tenant == nil {
+		return nil
+	}
+	var values []*contextValueData
+	for _, c := range root.API.ContextValues {
+		values = append(values, buildContextValueData(c))
+	}
+	if t := root.API.Tenant; t != nil {
+		values = append(values, buildContextValueData(&expr.ContextValueExpr{
+			Name:      "tenant",
+			Header:    t.Header,
+			Attribute: &expr.AttributeExpr{Type: expr.String, Description: "The tenant identifies the customer on behalf of which the request is made. The generated endpoints reject the requests that do not carry a tenant."},
+		}))
+	}
+	path := filepath.Join(codegen.Gendir, "contextvalues", "contextvalues.go")
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(root.API.Name+" context values", "contextvalues",
+			[]*codegen.ImportSpec{
+				{Path: "context"},
+				{Path: "strconv"},
+				codegen.GoaImport(""),
+			}),
+		{
+			Name:   "context-values-store",
+			Source: contextValuesStoreT,
+		},
+	}
+	for _, v := range values {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "context-value",
+			Source: contextValueT,
+			Data:   v,
+		})
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// buildContextValueData builds the data needed to render the accessors of the
+// given context value.
+func buildContextValueData(c *expr.ContextValueExpr) *contextValueData {
+	dt := c.Attribute.Type
+	d := &contextValueData{
+		Name:     c.Name,
+		VarName:  codegen.Goify(c.Name, true),
+		Header:   c.Header,
+		Key:      strings.ToLower(c.Header),
+		TypeName: codegen.GoNativeTypeName(dt),
+		Zero:     "0",
+	}
+	if c.Attribute.DefaultValue != nil {
+		d.Default = fmt.Sprintf("%#v", c.Attribute.DefaultValue)
+	}
+	parse := func(call, conv string) {
+		d.Parse = fmt.Sprintf("n, err := %s\nif err != nil {\n\treturn %s, false\n}\nreturn %s, true", call, d.Zero, conv)
+	}
+	switch dt {
+	case expr.String:
+		d.Zero = `""`
+		d.Parse = "return s, true"
+		d.Format = "v"
+	case expr.Boolean:
+		d.Zero = "false"
+		parse("strconv.ParseBool(s)", "n")
+		d.Format = "strconv.FormatBool(v)"
+	case expr.Int:
+		parse("strconv.ParseInt(s, 10, strconv.IntSize)", "int(n)")
+		d.Format = "strconv.Itoa(v)"
+	case expr.Int32:
+		parse("strconv.ParseInt(s, 10, 32)", "int32(n)")
+		d.Format = "strconv.FormatInt(int64(v), 10)"
+	case expr.Int64:
+		parse("strconv.ParseInt(s, 10, 64)", "n")
+		d.Format = "strconv.FormatInt(v, 10)"
+	case expr.UInt:
+		parse("strconv.ParseUint(s, 10, strconv.IntSize)", "uint(n)")
+		d.Format = "strconv.FormatUint(uint64(v), 10)"
+	case expr.UInt32:
+		parse("strconv.ParseUint(s, 10, 32)", "uint32(n)")
+		d.Format = "strconv.FormatUint(uint64(v), 10)"
+	case expr.UInt64:
+		parse("strconv.ParseUint(s, 10, 64)", "n")
+		d.Format = "strconv.FormatUint(v, 10)"
+	case expr.Float32:
+		parse("strconv.ParseFloat(s, 32)", "float32(n)")
+		d.Format = "strconv.FormatFloat(float64(v), 'g', -1, 32)"
+	case expr.Float64:
+		parse("strconv.ParseFloat(s, 64)", "n")
+		d.Format = "strconv.FormatFloat(v, 'g', -1, 64)"
+	}
+	d.Doc = fmt.Sprintf("%s returns the %q context value carried by ctx. The generated servers read the value from the %q header of the incoming requests.", d.VarName, d.Name, d.Header)
+	switch {
+	case d.Default != "" && dt == expr.String:
+		d.Doc += fmt.Sprintf(" %s returns the default value %s if ctx does not carry the value.", d.VarName, d.Default)
+	case d.Default != "":
+		d.Doc += fmt.Sprintf(" %s returns the default value %s if ctx does not carry the value and false if the value is not a valid %s.", d.VarName, d.Default, d.TypeName)
+	case dt == expr.String:
+		d.Doc += fmt.Sprintf(" %s returns false if ctx does not carry the value.", d.VarName)
+	default:
+		d.Doc += fmt.Sprintf(" %s returns false if ctx does not carry the value or if the value is not a valid %s.", d.VarName, d.TypeName)
+	}
+	d.Description = c.Attribute.Description
+	return d
+}
+
+const contextValuesStoreT = `// value returns the value of the propagated header with the given lower case
+// name carried by ctx.
+func value(ctx context.Context, key string) (string, bool) {
+	vals, _ := ctx.Value(goa.PropagatedKey).(map[string]string)
+	v, ok := vals[key]
+	return v, ok
+}
+
+// withValue returns a copy of ctx that carries v as value of the propagated
+// header with the given lower case name.
+func withValue(ctx context.Context, key, v string) context.Context {
+	vals, _ := ctx.Value(goa.PropagatedKey).(map[string]string)
+	res := make(map[string]string, len(vals)+1)
+	for k, val := range vals {
+		res[k] = val
+	}
+	res[key] = v
+	return context.WithValue(ctx, goa.PropagatedKey, res)
+}
+`
+
+// input: contextValueData
+const contextValueT = `{{ comment .Doc }}
+{{- if .Description }}
+//
+{{ comment .Description }}
+{{- end }}
+func {{ .VarName }}(ctx context.Context) ({{ .TypeName }}, bool) {
+	s, ok := value(ctx, {{ printf "%q" .Key }})
+	if !ok {
+		return {{ if .Default }}{{ .Default }}, true{{ else }}{{ .Zero }}, false{{ end }}
+	}
+	{{ .Parse }}
+}
+
+{{ printf "With%s returns a copy of ctx that carries v as %q context value. The generated clients send the value in the %q header of the requests made with the returned context." .VarName .Name .Header | comment }}
+func With{{ .VarName }}(ctx context.Context, v {{ .TypeName }}) context.Context {
+	return withValue(ctx, {{ printf "%q" .Key }}, {{ .Format }})
+}
+`
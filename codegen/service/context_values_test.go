@@ -0,0 +1,57 @@
+package service
+
+import (
+	"bytes"
+	"go/format"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestContextValuesFile(t *testing.T) {
+	codegen.RunDSL(t, testdata.ContextValuesDSL)
+	f := ContextValuesFile("goa.design/goa/example", expr.Root)
+	if f == nil {
+		t.Fatalf("got nil file, expected not nil")
+	}
+	if p := filepath.Join("gen", "contextvalues", "contextvalues.go"); f.Path != p {
+		t.Errorf("got path %q, expected %q", f.Path, p)
+	}
+	buf := new(bytes.Buffer)
+	for _, s := range f.SectionTemplates[1:] {
+		if err := s.Write(buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bs, err := format.Source(buf.Bytes())
+	if err != nil {
+		t.Fatalf("%s\n%s", err, buf.String())
+	}
+	if code := string(bs); code != testdata.ContextValuesCode {
+		t.Errorf("got\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ContextValuesCode))
+	}
+	t.Run("tenant", func(t *testing.T) {
+		codegen.RunDSL(t, testdata.TenantContextValuesDSL)
+		f := ContextValuesFile("goa.design/goa/example", expr.Root)
+		if f == nil {
+			t.Fatalf("got nil file, expected not nil")
+		}
+		sections := f.Section("context-value")
+		if len(sections) != 1 {
+			t.Fatalf("got %d context values, expected 1", len(sections))
+		}
+		d := sections[0].Data.(*contextValueData)
+		if d.VarName != "Tenant" || d.Key != "x-org" {
+			t.Errorf("got accessor %q for key %q, expected Tenant for x-org", d.VarName, d.Key)
+		}
+	})
+	t.Run("none", func(t *testing.T) {
+		codegen.RunDSL(t, testdata.LoggingNoneDSL)
+		if f := ContextValuesFile("goa.design/goa/example", expr.Root); f != nil {
+			t.Errorf("got file %q, expected nil", f.Path)
+		}
+	})
+}
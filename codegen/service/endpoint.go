@@ -30,6 +30,14 @@ type (
 		// Schemes contains the security schemes types used by the
 		// all the endpoints.
 		Schemes SchemesData
+		// Authorized is true if any of the endpoints requires
+		// authorization.
+		Authorized bool
+		// Tenancy is the Go expression that creates the tenancy
+		// settings checked by the endpoints if any, see Tenant.
+		Tenancy string
+		// Cached is true if any of the methods is defined with Cache.
+		Cached bool
 	}
 
 	// endpointMethodData describes a single endpoint method.
@@ -44,6 +52,27 @@ type (
 		ServiceName string
 		// ServiceVarName is the name of the owner service Go interface.
 		ServiceVarName string
+		// Limiter is the Go expression that creates the concurrency
+		// limiter of the method endpoint if any, see ConcurrencyLimit.
+		Limiter string
+		// Cache describes how the endpoint memoizes the method results
+		// if the method is defined with Cache.
+		Cache *endpointCacheData
+	}
+
+	// endpointCacheData describes how an endpoint memoizes the method
+	// results.
+	endpointCacheData struct {
+		// Key is the Go expression that computes the key of the
+		// results from the payload, it matches the keys deleted by the
+		// invalidation functions of the caching package.
+		Key string
+		// TTL is the Go expression of the duration during which the
+		// results are cached.
+		TTL string
+		// PerCaller is true if the method is secured in which case the
+		// results are cached per principal and granted scopes.
+		PerCaller bool
 	}
 )
 
@@ -70,14 +99,17 @@ func EndpointFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 			[]*codegen.ImportSpec{
 				{Path: "context"},
 				{Path: "fmt"},
+				{Path: "time"},
 				codegen.GoaImport(""),
 				codegen.GoaImport("security"),
+				codegen.GoaImport("security/audit"),
 				{Path: genpkg + "/" + svcName + "/" + "views", Name: svc.ViewsPkg},
 			})
 		def := &codegen.SectionTemplate{
 			Name:   "endpoints-struct",
 			Source: serviceEndpointsT,
 			Data:   data,
+			Expr:   service,
 		}
 		sections = []*codegen.SectionTemplate{header, def}
 		for _, m := range data.Methods {
@@ -86,6 +118,7 @@ func EndpointFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 					Name:   "endpoint-input-struct",
 					Source: serviceEndpointInputStructT,
 					Data:   m,
+					Expr:   service.Method(m.Name),
 				})
 			}
 		}
@@ -93,31 +126,56 @@ func EndpointFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 			Name:   "endpoints-init",
 			Source: serviceEndpointsInitT,
 			Data:   data,
+			Expr:   service,
 		})
 		sections = append(sections, &codegen.SectionTemplate{
 			Name:   "endpoints-use",
 			Source: serviceEndpointsUseT,
 			Data:   data,
+			Expr:   service,
 		})
+		if data.Cached {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "endpoints-use-cache",
+				Source: serviceEndpointsUseCacheT,
+				Data:   data,
+				Expr:   service,
+			})
+		}
+		if data.Tenancy != "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "endpoints-use-tenant-validator",
+				Source: serviceEndpointsUseTenantValidatorT,
+				Data:   data,
+				Expr:   service,
+			})
+		}
 		for _, m := range data.Methods {
 			sections = append(sections, &codegen.SectionTemplate{
 				Name:   "endpoint-method",
 				Source: serviceEndpointMethodT,
 				Data:   m,
+				Expr:   service.Method(m.Name),
 				FuncMap: map[string]interface{}{
-					"payloadVar": payloadVar,
+					"payloadVar":           payloadVar,
+					"credentialsCheck":     credentialsCheck,
+					"signedRequest":        signedRequest,
+					"wildcardScopes":       wildcardScopes,
+					"authorizationPayload": authorizationPayload,
+					"clearCredentials":     clearCredentials,
 				},
 			})
 		}
 	}
 
-	return &codegen.File{Path: path, SectionTemplates: sections}
+	return &codegen.File{Path: path, SectionTemplates: sections, Service: service.Name}
 }
 
 func endpointData(service *expr.ServiceExpr) *endpointsData {
 	svc := Services.Get(service.Name)
 	methods := make([]*endpointMethodData, len(svc.Methods))
 	names := make([]string, len(svc.Methods))
+	var cached bool
 	for i, m := range svc.Methods {
 		methods[i] = &endpointMethodData{
 			MethodData:     m,
@@ -126,9 +184,24 @@ func endpointData(service *expr.ServiceExpr) *endpointsData {
 			ServiceVarName: serviceInterfaceName,
 			ClientVarName:  clientStructName,
 		}
+		if l := service.Method(m.Name).Limit(); l != nil {
+			timeout := "0"
+			if l.QueueTimeout > 0 {
+				timeout = durationLiteral(l.QueueTimeout)
+			}
+			methods[i].Limiter = fmt.Sprintf("goa.NewLimiter(%d, %d, %s)", l.Max, l.QueueSize, timeout)
+		}
+		if c := service.Method(m.Name).Cache; c != nil {
+			methods[i].Cache = endpointCache(service.Name, m, c)
+			cached = true
+		}
 		names[i] = codegen.Goify(m.VarName, false)
 	}
 	desc := fmt.Sprintf("%s wraps the %q service endpoints.", endpointsStructName, service.Name)
+	var tenancy string
+	if expr.Root.API != nil && expr.Root.API.Tenant != nil && len(svc.Methods) > 0 {
+		tenancy = tenancyLiteral(expr.Root.API.Tenant)
+	}
 	return &endpointsData{
 		Name:           service.Name,
 		Description:    desc,
@@ -138,7 +211,38 @@ func endpointData(service *expr.ServiceExpr) *endpointsData {
 		ClientInitArgs: strings.Join(names, ", "),
 		Methods:        methods,
 		Schemes:        svc.Schemes,
+		Authorized:     svc.Authorized,
+		Tenancy:        tenancy,
+		Cached:         cached,
+	}
+}
+
+// endpointCache returns the data used to render the code that memoizes the
+// results of the given method.
+func endpointCache(svc string, m *MethodData, c *expr.CacheExpr) *endpointCacheData {
+	args := []string{fmt.Sprintf("%q", svc), fmt.Sprintf("%q", m.Name)}
+	if len(c.Keys) > 0 {
+		obj := expr.AsObject(c.Method.Payload.Type)
+		for _, k := range c.Keys {
+			args = append(args, "p."+codegen.GoifyAtt(obj.Attribute(k), k, true))
+		}
+	} else if m.PayloadRef != "" {
+		args = append(args, "p")
+	}
+	return &endpointCacheData{
+		Key:       "goa.CacheKey(" + strings.Join(args, ", ") + ")",
+		TTL:       durationLiteral(c.TTL),
+		PerCaller: len(m.Requirements) > 0,
+	}
+}
+
+// tenancyLiteral returns the Go expression that creates the goa.Tenancy
+// corresponding to t.
+func tenancyLiteral(t *expr.TenantExpr) string {
+	if t.Claim == "" {
+		return fmt.Sprintf("&goa.Tenancy{Header: %q}", t.Header)
 	}
+	return fmt.Sprintf("&goa.Tenancy{Header: %q, Claim: %q}", t.Header, t.Claim)
 }
 
 func payloadVar(e *endpointMethodData) string {
@@ -148,12 +252,110 @@ func payloadVar(e *endpointMethodData) string {
 	return "p"
 }
 
+// credentialsCheck returns the code of the condition that tests whether the
+// request carries credentials for any of the security schemes of the method.
+func credentialsCheck(e *endpointMethodData) string {
+	var conds []string
+	seen := make(map[string]struct{})
+	add := func(cond string) {
+		if _, ok := seen[cond]; ok {
+			return
+		}
+		seen[cond] = struct{}{}
+		conds = append(conds, cond)
+	}
+	payload := payloadVar(e)
+	for _, s := range e.Schemes {
+		field, pointer := s.CredField, s.CredPointer
+		switch s.Type {
+		case "Signature":
+			add("signed")
+			continue
+		case "Basic":
+			field, pointer = s.UsernameField, s.UsernamePointer
+		}
+		if pointer {
+			add(fmt.Sprintf("%s.%s != nil", payload, field))
+		} else {
+			add(fmt.Sprintf("%s.%s != \"\"", payload, field))
+		}
+	}
+	return strings.Join(conds, " || ")
+}
+
+// signedRequest returns true if the method uses a signature scheme.
+func signedRequest(e *endpointMethodData) bool {
+	for _, s := range e.Schemes {
+		if s.Type == "Signature" {
+			return true
+		}
+	}
+	return false
+}
+
+// wildcardScopes returns true if one of the method security schemes enables
+// wildcard scopes.
+func wildcardScopes(e *endpointMethodData) bool {
+	for _, s := range e.Schemes {
+		if s.WildcardScopes {
+			return true
+		}
+	}
+	return false
+}
+
+// authorizationPayload returns the expression used to initialize the payload
+// of the authorization input of the method: the address of the copy of the
+// payload made by the endpoint if the payload is a struct so that the
+// credentials can be removed, the payload itself otherwise.
+func authorizationPayload(e *endpointMethodData) string {
+	if e.PayloadRef == "" {
+		return ""
+	}
+	if strings.HasPrefix(e.PayloadRef, "*") {
+		return "&pc"
+	}
+	return payloadVar(e)
+}
+
+// clearCredentials returns the statements that remove the secret credentials
+// from the copy of the payload given to the authorization policies.
+// Certificates and signatures are not secret and are kept.
+func clearCredentials(e *endpointMethodData) []string {
+	var stmts []string
+	seen := make(map[string]struct{})
+	add := func(field string, pointer bool) {
+		if _, ok := seen[field]; ok || field == "" {
+			return
+		}
+		seen[field] = struct{}{}
+		if pointer {
+			stmts = append(stmts, fmt.Sprintf("pc.%s = nil", field))
+		} else {
+			stmts = append(stmts, fmt.Sprintf("pc.%s = \"\"", field))
+		}
+	}
+	for _, s := range e.Schemes {
+		switch s.Type {
+		case "Basic":
+			add(s.UsernameField, s.UsernamePointer)
+			add(s.PasswordField, s.PasswordPointer)
+		case "APIKey", "JWT", "OAuth2":
+			add(s.CredField, s.CredPointer)
+		}
+	}
+	return stmts
+}
+
 // input: endpointsData
 const serviceEndpointsT = `{{ comment .Description }}
 type {{ .VarName }} struct {
 {{- range .Methods}}
 	{{ .VarName }} goa.Endpoint
 {{- end }}
+{{- if .Cached }}
+	cache *goa.ResultCache
+{{- end }}
 }
 `
 
@@ -163,10 +365,23 @@ func New{{ .VarName }}(s {{ .ServiceVarName }}) *{{ .VarName }} {
 {{- if .Schemes }}
 	// Casting service to Auther interface
 	a := s.(Auther)
+{{- end }}
+{{- if .Authorized }}
+	// Casting service to Authorizer interface
+	z := s.(Authorizer)
+{{- end }}
+{{- if .Tenancy }}
+	tenancy := {{ .Tenancy }}
+{{- end }}
+{{- if .Cached }}
+	cache := &goa.ResultCache{ {{- if .Tenancy }}Tenancy: tenancy{{ end }}}
 {{- end }}
 	return &{{ .VarName }}{
 {{- range .Methods }}
-		{{ .VarName }}: New{{ .VarName }}Endpoint(s{{ range .Schemes }}, a.{{ .Type }}Auth{{ end }}),
+		{{ .VarName }}: {{ if .Limiter }}goa.LimitEndpoint({{ .Limiter }})({{ end }}{{ if $.Tenancy }}goa.RequireTenant(tenancy)({{ end }}New{{ .VarName }}Endpoint(s{{ range .Schemes }}, a.{{ .Type }}Auth{{ end }}{{ if .AuthorizationInput }}, z{{ end }}{{ if .Cache }}, cache{{ end }}){{ if $.Tenancy }}){{ end }}{{ if .Limiter }}){{ end }},
+{{- end }}
+{{- if .Cached }}
+		cache: cache,
 {{- end }}
 	}
 }
@@ -186,7 +401,7 @@ type {{ .ServerStream.EndpointStruct }} struct {
 
 // input: endpointMethodData
 const serviceEndpointMethodT = `{{ printf "New%sEndpoint returns an endpoint function that calls the method %q of service %q." .VarName .Name .ServiceName | comment }}
-func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth{{ .Type }}Fn security.Auth{{ .Type }}Func{{ end }}) goa.Endpoint {
+func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth{{ .Type }}Fn security.Auth{{ .Type }}Func{{ end }}{{ if .AuthorizationInput }}, authz Authorizer{{ end }}{{ if .Cache }}, cache *goa.ResultCache{{ end }}) goa.Endpoint {
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
 {{- if .ServerStream }}
 		ep := req.(*{{ .ServerStream.EndpointStruct }})
@@ -195,7 +410,23 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 {{- end }}
 {{- $payload := payloadVar . }}
 {{- if .Requirements }}
-		var err error
+		var (
+			err  error
+			aerr security.AuthError
+		)
+	{{- if wildcardScopes . }}
+		ctx = security.WithScopeWildcards(ctx)
+	{{- end }}
+	{{- if .Realm }}
+		aerr.Realm = {{ printf "%q" .Realm }}
+	{{- end }}
+	{{- if .OptionalSecurity }}
+		{{- if signedRequest . }}
+		_, signed := security.ContextSignatureKeyID(ctx)
+		{{- end }}
+		{{ comment "Requests that carry no credentials are anonymous." }}
+		if {{ credentialsCheck . }} {
+	{{- end }}
 	{{- range $ridx, $r := .Requirements }}
 		{{- if ne $ridx 0 }}
 		if err != nil {
@@ -209,6 +440,9 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 					Name: {{ printf "%q" .SchemeName }},
 					Scopes: []string{ {{- range .Scopes }}{{ printf "%q" . }}, {{ end }} },
 					RequiredScopes: []string{ {{- range $r.Scopes }}{{ printf "%q" . }}, {{ end }} },
+					{{- if .WildcardScopes }}
+					WildcardScopes: true,
+					{{- end }}
 				}
 				{{- if .UsernamePointer }}
 				var user string
@@ -230,6 +464,9 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 					Name: {{ printf "%q" .SchemeName }},
 					Scopes: []string{ {{- range .Scopes }}{{ printf "%q" . }}, {{ end }} },
 					RequiredScopes: []string{ {{- range $r.Scopes }}{{ printf "%q" . }}, {{ end }} },
+					{{- if .WildcardScopes }}
+					WildcardScopes: true,
+					{{- end }}
 				}
 				{{- if $s.CredPointer }}
 				var key string
@@ -244,6 +481,9 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 					Name: {{ printf "%q" .SchemeName }},
 					Scopes: []string{ {{- range .Scopes }}{{ printf "%q" . }}, {{ end }} },
 					RequiredScopes: []string{ {{- range $r.Scopes }}{{ printf "%q" . }}, {{ end }} },
+					{{- if .WildcardScopes }}
+					WildcardScopes: true,
+					{{- end }}
 				}
 				{{- if $s.CredPointer }}
 				var token string
@@ -258,6 +498,9 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 					Name: {{ printf "%q" .SchemeName }},
 					Scopes: []string{ {{- range .Scopes }}{{ printf "%q" . }}, {{ end }} },
 					RequiredScopes: []string{ {{- range $r.Scopes }}{{ printf "%q" . }}, {{ end }} },
+					{{- if .WildcardScopes }}
+					WildcardScopes: true,
+					{{- end }}
 					{{- if .Flows }}
 					Flows: []*security.OAuthFlow{
 						{{- range .Flows }}
@@ -285,7 +528,43 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 				{{- end }}
 				ctx, err = auth{{ .Type }}Fn(ctx, {{ if $s.CredPointer }}token{{ else }}{{ $payload }}.{{ $s.CredField }}{{ end }}, &sc)
 
+			{{- else if eq .Type "MTLS" }}
+				sc := security.MTLSScheme{
+					Name: {{ printf "%q" .SchemeName }},
+					Scopes: []string{ {{- range .Scopes }}{{ printf "%q" . }}, {{ end }} },
+					RequiredScopes: []string{ {{- range $r.Scopes }}{{ printf "%q" . }}, {{ end }} },
+					{{- if .WildcardScopes }}
+					WildcardScopes: true,
+					{{- end }}
+				}
+				var cert *security.ClientCertificate
+				{{- if $s.CredPointer }}
+				if {{ $payload }}.{{ $s.CredField }} != nil && *{{ $payload }}.{{ $s.CredField }} != "" {
+					cert, err = security.ParseClientCertificate(*{{ $payload }}.{{ $s.CredField }})
+				}
+				{{- else }}
+				if {{ $payload }}.{{ $s.CredField }} != "" {
+					cert, err = security.ParseClientCertificate({{ $payload }}.{{ $s.CredField }})
+				}
+				{{- end }}
+				if err == nil {
+					ctx, err = auth{{ .Type }}Fn(ctx, cert, &sc)
+				}
+
+			{{- else if eq .Type "Signature" }}
+				sc := security.SignatureScheme{
+					Name: {{ printf "%q" .SchemeName }},
+					Scopes: []string{ {{- range .Scopes }}{{ printf "%q" . }}, {{ end }} },
+					RequiredScopes: []string{ {{- range $r.Scopes }}{{ printf "%q" . }}, {{ end }} },
+					{{- if .WildcardScopes }}
+					WildcardScopes: true,
+					{{- end }}
+				}
+				keyID, _ := security.ContextSignatureKeyID(ctx)
+				ctx, err = auth{{ .Type }}Fn(ctx, keyID, &sc)
+
 			{{- end }}
+				aerr.Record({{ printf "%q" .SchemeName }}, {{ printf "%q" .Type }}, sc.RequiredScopes, err)
 			{{- if ne $sidx 0 }}
 				}
 			{{- end }}
@@ -293,11 +572,49 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 		{{- if ne $ridx 0 }}
 		}
 		{{- end }}
+	{{- end }}
+	{{- if .OptionalSecurity }}
+		}
 	{{- end }}
 		if err != nil {
+			return nil, aerr.Err()
+		}
+	{{- if .Audited }}
+		audit.Authenticated(ctx, aerr.Authorized)
+	{{- end }}
+{{- end }}
+{{- if .AuthorizationInput }}
+	{{- $input := authorizationPayload . }}
+	{{- if eq $input "&pc" }}
+		pc := *{{ $payload }}
+		{{- range clearCredentials . }}
+		{{ . }}
+		{{- end }}
+	{{- end }}
+		scopes, _ := security.ContextScopes(ctx)
+		if err := authz.Authorize(ctx, &{{ .AuthorizationInput }}{
+			Service:   {{ printf "%q" .ServiceName }},
+			Method:    {{ printf "%q" .Name }},
+			Principal: audit.Principal(ctx),
+	{{- if .Requirements }}
+			Scheme:    aerr.Authorized,
+	{{- end }}
+			Scopes:    scopes,
+	{{- if $input }}
+			Payload:   {{ $input }},
+	{{- end }}
+			Transport: security.ContextTransportMetadata(ctx),
+		}); err != nil {
 			return nil, err
 		}
 {{- end }}
+{{- if .Cache }}
+	{{- if .Cache.PerCaller }}
+		granted, _ := security.ContextScopes(ctx)
+		caller := []interface{}{audit.Principal(ctx), granted}
+	{{- end }}
+		return cache.Do(ctx, {{ .Cache.Key }}, {{ .Cache.TTL }}, {{ if .Cache.PerCaller }}caller{{ else }}nil{{ end }}, func() (interface{}, error) {
+{{- end }}
 {{- if .ServerStream }}
 	return nil, s.{{ .VarName }}(ctx, {{ if .PayloadRef }}{{ $payload }}, {{ end }}ep.Stream)
 {{- else if .ViewedResult }}
@@ -305,12 +622,25 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 		if err != nil {
 			return nil, err
 		}
+	{{- if .ResultFilter }}
+		{{ .ResultFilter }}(ctx, res)
+	{{- end }}
 		vres := {{ $.ViewedResult.Init.Name }}(res, {{ if .ViewedResult.ViewName }}{{ printf "%q" .ViewedResult.ViewName }}{{ else }}view{{ end }})
 		return vres, nil
+{{- else if .ResultFilter }}
+		res, err := s.{{ .VarName }}(ctx{{ if .PayloadRef }}, {{ $payload }}{{ end }})
+		if err != nil {
+			return nil, err
+		}
+		{{ .ResultFilter }}(ctx, res)
+		return res, nil
 {{- else if .ResultRef }}
 		return s.{{ .VarName }}(ctx{{ if .PayloadRef }}, {{ $payload }}{{ end }})
 {{- else }}
 	return {{ if not .ResultRef }}nil, {{ end }}s.{{ .VarName }}(ctx{{ if .PayloadRef }}, {{ $payload }}{{ end }})
+{{- end }}
+{{- if .Cache }}
+		})
 {{- end }}
 	}
 }
@@ -324,3 +654,17 @@ func (e *{{ .VarName }}) Use(m func(goa.Endpoint) goa.Endpoint) {
 {{- end }}
 }
 `
+
+// input: endpointsData
+const serviceEndpointsUseCacheT = `{{ printf "UseCache memoizes the results of the cached methods of the %q service in store. The results are cached once the requests are authenticated and authorized, per principal and granted scopes for the secured methods. It must be called before the endpoints are mounted on the transport servers." .Name | comment }}
+func (e *{{ .VarName }}) UseCache(store goa.CacheStore) {
+	e.cache.Store = store
+}
+`
+
+// input: endpointsData
+const serviceEndpointsUseTenantValidatorT = `{{ printf "UseTenantValidator applies v to the tenant of the requests handled by the %q service endpoints. The endpoints return the error returned by v if any." .Name | comment }}
+func (e *{{ .VarName }}) UseTenantValidator(v goa.TenantValidator) {
+	e.Use(goa.ValidateTenant({{ .Tenancy }}, v))
+}
+`
@@ -31,6 +31,17 @@ func TestEndpoint(t *testing.T) {
 		{"streaming-payload-no-result", testdata.StreamingPayloadNoResultMethodDSL, testdata.StreamingPayloadNoResultMethodEndpoint},
 		{"bidirectional-streaming", testdata.BidirectionalStreamingEndpointDSL, testdata.BidirectionalStreamingMethodEndpoint},
 		{"bidirectional-streaming-no-payload", testdata.BidirectionalStreamingNoPayloadMethodDSL, testdata.BidirectionalStreamingNoPayloadMethodEndpoint},
+		{"mtls", testdata.MTLSEndpointDSL, testdata.MTLSEndpoint},
+		{"signature", testdata.SignatureEndpointDSL, testdata.SignatureEndpoint},
+		{"security-chain", testdata.SecurityChainEndpointDSL, testdata.SecurityChainEndpoint},
+		{"audit", testdata.AuditDSL, testdata.AuditEndpoint},
+		{"basic-auth-realm", testdata.BasicAuthDSL, testdata.BasicAuthEndpoint},
+		{"authorization", testdata.AuthorizationDSL, testdata.AuthorizationEndpoint},
+		{"result-filter", testdata.ResultFilterDSL, testdata.ResultFilterEndpoint},
+		{"concurrency-limit", testdata.ConcurrencyLimitEndpointDSL, testdata.ConcurrencyLimitEndpoint},
+		{"tenant", testdata.TenantEndpointDSL, testdata.TenantEndpoint},
+		{"cache", testdata.CacheEndpointDSL, testdata.CacheEndpoint},
+		{"wildcard-scopes", testdata.WildcardScopesEndpointDSL, testdata.WildcardScopesEndpoint},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
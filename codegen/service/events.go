@@ -0,0 +1,153 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// eventsData contains the data used to render the event publication
+	// code of a service.
+	eventsData struct {
+		// Name is the service name.
+		Name string
+		// Publisher is the name of the publisher interface.
+		Publisher string
+		// Events lists the distinct events emitted by the service
+		// methods.
+		Events []*eventData
+		// Methods lists the methods that emit events.
+		Methods []*MethodData
+	}
+
+	// eventData describes an event.
+	eventData struct {
+		// Topic is the event topic.
+		Topic string
+		// VarName is the Go name of the event used to name the publisher
+		// method and the emit function.
+		VarName string
+		// Description is the event description.
+		Description string
+		// TypeRef is the reference to the event type.
+		TypeRef string
+	}
+)
+
+// EventsFile returns the file implementing the publication of the events
+// emitted by the methods of the given service, see dsl.Emits. EventsFile
+// returns nil if the service methods do not emit events.
+func EventsFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
+	topics := service.Topics()
+	if len(topics) == 0 {
+		return nil
+	}
+	svc := Services.Get(service.Name)
+	data := &eventsData{Name: service.Name, Publisher: PublisherName(svc)}
+	for _, e := range topics {
+		desc := fmt.Sprintf("Publish%s publishes the %q event.", codegen.Goify(e.Topic, true), e.Topic)
+		if e.Description != "" {
+			desc += " " + e.Description
+		}
+		data.Events = append(data.Events, &eventData{
+			Topic:       e.Topic,
+			VarName:     codegen.Goify(e.Topic, true),
+			Description: desc,
+			TypeRef:     svc.Scope.GoTypeRef(e.Attribute),
+		})
+	}
+	for _, m := range service.Methods {
+		if len(m.Events) > 0 {
+			data.Methods = append(data.Methods, svc.Method(m.Name))
+		}
+	}
+	path := filepath.Join(codegen.Gendir, codegen.SnakeCase(svc.VarName), "events.go")
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" events", svc.PkgName,
+			[]*codegen.ImportSpec{
+				{Path: "context"},
+				{Path: "fmt"},
+				codegen.GoaImport(""),
+			}),
+		{
+			Name:   "events-publisher",
+			Source: eventsPublisherT,
+			Data:   data,
+		},
+		{
+			Name:   "events-emit",
+			Source: eventsEmitT,
+			Data:   data,
+		},
+		{
+			Name:   "events-use",
+			Source: eventsUseT,
+			Data:   data,
+		},
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections, Service: service.Name}
+}
+
+// PublisherName returns the name of the interface generated to publish the
+// events emitted by the methods of the given service.
+func PublisherName(svc *Data) string {
+	return svc.Scope.Name("Publisher")
+}
+
+// input: eventsData
+const eventsPublisherT = `{{ printf "%s publishes the events emitted by the %q service methods." .Publisher .Name | comment }}
+type {{ .Publisher }} interface {
+{{- range .Events }}
+	{{ comment .Description }}
+	Publish{{ .VarName }}(context.Context, {{ .TypeRef }}) error
+{{- end }}
+}
+
+{{ printf "New%s returns a %s that publishes the events with p on the topics defined in the design." .Publisher .Publisher | comment }}
+func New{{ .Publisher }}(p goa.EventPublisher) {{ .Publisher }} {
+	return &publisher{p: p}
+}
+
+{{ printf "publisher implements %s with a goa.EventPublisher." .Publisher | comment }}
+type publisher struct {
+	p goa.EventPublisher
+}
+{{- range .Events }}
+
+{{ printf "Publish%s publishes ev on the %q topic." .VarName .Topic | comment }}
+func (p *publisher) Publish{{ .VarName }}(ctx context.Context, ev {{ .TypeRef }}) error {
+	return p.p.Publish(ctx, {{ printf "%q" .Topic }}, ev)
+}
+{{- end }}
+`
+
+// input: eventsData
+const eventsEmitT = `{{- range $i, $e := .Events }}{{ if $i }}
+
+{{ end }}{{ printf "Emit%s records the %q event so that it is published once the method completes successfully. It returns an error if the endpoint does not publish events, see UsePublisher." .VarName .Topic | comment }}
+func Emit{{ .VarName }}(ctx context.Context, ev {{ .TypeRef }}) error {
+	return goa.EmitEvent(ctx, {{ printf "%q" .Topic }}, ev)
+}
+{{- end }}
+`
+
+// input: eventsData
+const eventsUseT = `{{ printf "UsePublisher wraps the endpoints of the %q service methods that emit events with a middleware that publishes the emitted events with p once the methods complete successfully." .Name | comment }}
+func (e *Endpoints) UsePublisher(p {{ .Publisher }}) {
+	m := goa.PublishEvents(goa.EventPublisherFunc(func(ctx context.Context, topic string, ev interface{}) error {
+		switch topic {
+	{{- range .Events }}
+		case {{ printf "%q" .Topic }}:
+			return p.Publish{{ .VarName }}(ctx, ev.({{ .TypeRef }}))
+	{{- end }}
+		}
+		return fmt.Errorf("unknown event topic %q", topic)
+	}))
+{{- range .Methods }}
+	e.{{ .VarName }} = m(e.{{ .VarName }})
+{{- end }}
+}
+`
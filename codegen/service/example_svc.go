@@ -30,6 +30,12 @@ type (
 		// StreamInterface is the stream interface in the service package used
 		// by the endpoint implementation.
 		StreamInterface string
+		// Scaffold is true if the endpoint implementation is rendered in
+		// its own file, see ScaffoldFiles.
+		Scaffold bool
+		// LoggingPkg is the name of the logging package of the service if
+		// the service is logged, see LoggingFile.
+		LoggingPkg string
 	}
 )
 
@@ -37,7 +43,23 @@ type (
 // service expression.
 func ExampleServiceFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 
-	// determine the unique API package name different from the service names
+	apipkg := exampleAPIPkg(root)
+	var fw []*codegen.File
+	for _, svc := range root.Services {
+		if svc.IsScaffolded() {
+			fw = append(fw, scaffoldFiles(genpkg, svc, apipkg)...)
+			continue
+		}
+		if f := exampleServiceFile(genpkg, root, svc, apipkg); f != nil {
+			fw = append(fw, f)
+		}
+	}
+	return fw
+}
+
+// exampleAPIPkg returns the name of the package of the example service
+// implementations, a unique name different from the service package names.
+func exampleAPIPkg(root *expr.RootExpr) string {
 	scope := codegen.NewNameScope()
 	for _, svc := range root.Services {
 		s := Services.Get(svc.Name)
@@ -46,15 +68,7 @@ func ExampleServiceFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 		}
 		scope.Unique(s.PkgName)
 	}
-	apipkg := scope.Unique(strings.ToLower(codegen.Goify(root.API.Name, false)), "api")
-
-	var fw []*codegen.File
-	for _, svc := range root.Services {
-		if f := exampleServiceFile(genpkg, root, svc, apipkg); f != nil {
-			fw = append(fw, f)
-		}
-	}
-	return fw
+	return scope.Unique(strings.ToLower(codegen.Goify(root.API.Name, false)), "api")
 }
 
 // exampleServiceFile returns a basic implementation of the given service.
@@ -70,6 +84,9 @@ func exampleServiceFile(genpkg string, root *expr.RootExpr, svc *expr.ServiceExp
 		{Path: "log"},
 		{Path: path.Join(genpkg, codegen.SnakeCase(svcName)), Name: data.PkgName},
 	}
+	if svc.IsLogged() {
+		specs = append(specs, loggingImport(genpkg, data))
+	}
 	sections := []*codegen.SectionTemplate{
 		codegen.Header("", apipkg, specs),
 		{Name: "basic-service-struct", Source: svcStructT, Data: data},
@@ -86,6 +103,13 @@ func exampleServiceFile(genpkg string, root *expr.RootExpr, svc *expr.ServiceExp
 	}
 }
 
+// loggingImport returns the import spec of the logging package of the given
+// service, see LoggingFile.
+func loggingImport(genpkg string, data *Data) *codegen.ImportSpec {
+	svcName := codegen.SnakeCase(data.VarName)
+	return &codegen.ImportSpec{Path: path.Join(genpkg, svcName, "logging"), Name: data.PkgName + "log"}
+}
+
 // basicEndpointSection returns a section with a basic implementation for the
 // given method.
 func basicEndpointSection(m *expr.MethodExpr, svcData *Data) *codegen.SectionTemplate {
@@ -114,6 +138,9 @@ func basicEndpointSection(m *expr.MethodExpr, svcData *Data) *codegen.SectionTem
 	if md.ServerStream != nil {
 		ed.StreamInterface = svcData.PkgName + "." + md.ServerStream.Interface
 	}
+	if m.Service.IsLogged() {
+		ed.LoggingPkg = svcData.PkgName + "log"
+	}
 	return &codegen.SectionTemplate{
 		Name:   "basic-endpoint",
 		Source: endpointT,
@@ -155,7 +182,14 @@ func (s *{{ .ServiceVarName }}srvc) {{ .VarName }}(ctx context.Context{{ if .Pay
 		{{- end }}
 	{{- end }}
 {{- end }}
+{{- if .Scaffold }}
+  // TODO: implement the {{ printf "%q" .Name }} method.
+{{- end }}
+{{- if .LoggingPkg }}
+  {{ .LoggingPkg }}.Logger(ctx).Info("{{ .ServiceVarName }}.{{ .Name }}")
+{{- else }}
   s.logger.Print("{{ .ServiceVarName }}.{{ .Name }}")
+{{- end }}
   return
 }
 `
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// jobsData contains the data used to render the job helpers of a
+	// service.
+	jobsData struct {
+		// Name is the service name.
+		Name string
+		// ResultRef is the reference to the job status type.
+		ResultRef string
+		// ResultType is the name of the job status type.
+		ResultType string
+		// StatusMethod is the name of the job status method.
+		StatusMethod string
+		// NotFoundName is the name of the error returned when there is
+		// no job with a given ID.
+		NotFoundName string
+		// NotFound is the name of the function that creates the error
+		// returned when there is no job with a given ID.
+		NotFound string
+	}
+)
+
+// JobsFile returns the file implementing the helper functions used by the
+// async methods of the given service to start jobs and report their status.
+// JobsFile returns nil if the service has no async method.
+func JobsFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
+	m := service.JobStatusMethod()
+	if m == nil {
+		return nil
+	}
+	svc := Services.Get(service.Name)
+	md := svc.Method(m.Name)
+	data := &jobsData{
+		Name:         service.Name,
+		ResultRef:    md.ResultRef,
+		ResultType:   svc.Scope.GoTypeName(m.Result),
+		StatusMethod: m.Name,
+		NotFoundName: expr.JobNotFoundErrorName,
+		NotFound:     "Make" + codegen.Goify(expr.JobNotFoundErrorName, true),
+	}
+	path := filepath.Join(codegen.Gendir, codegen.SnakeCase(svc.VarName), "jobs.go")
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" jobs", svc.PkgName,
+			[]*codegen.ImportSpec{
+				{Path: "context"},
+				{Path: "fmt"},
+				{Path: "time"},
+				codegen.GoaImport(""),
+			}),
+		{
+			Name:   "jobs",
+			Source: jobsT,
+			Data:   data,
+		},
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections, Service: service.Name}
+}
+
+// input: jobsData
+const jobsT = `{{ printf "StartJob starts a job that runs fn with jobs and returns the status of the pending job. The async methods of the %q service return the status so that the HTTP servers respond with 202 Accepted and the path of the job status in the Location header." .Name | comment }}
+func StartJob(ctx context.Context, jobs *goa.Jobs, fn goa.JobFunc) ({{ .ResultRef }}, error) {
+	j, err := jobs.Start(ctx, fn)
+	if err != nil {
+		return nil, err
+	}
+	return New{{ .ResultType }}(j), nil
+}
+
+{{ printf "LoadJobStatus returns the status of the job with the given ID recorded by jobs. It returns a %q error if there is no such job. The %q method of the %q service may be implemented with it." .NotFoundName .StatusMethod .Name | comment }}
+func LoadJobStatus(ctx context.Context, jobs *goa.Jobs, id string) ({{ .ResultRef }}, error) {
+	j, err := jobs.Status(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if j == nil {
+		return nil, {{ .NotFound }}(fmt.Errorf("job %q not found", id))
+	}
+	return New{{ .ResultType }}(j), nil
+}
+
+{{ printf "New%s converts j into the job status returned by the async methods." .ResultType | comment }}
+func New{{ .ResultType }}(j *goa.Job) {{ .ResultRef }} {
+	res := &{{ .ResultType }}{
+		ID:        j.ID,
+		Status:    j.State,
+		Result:    j.Result,
+		CreatedAt: j.Created.Format(time.RFC3339),
+		UpdatedAt: j.Updated.Format(time.RFC3339),
+	}
+	if j.Error != "" {
+		res.Error = &j.Error
+	}
+	return res
+}
+`
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// jwtAuthData contains the data used to render the jwtauth package of a
+	// service.
+	jwtAuthData struct {
+		// Name is the service name.
+		Name string
+		// Schemes lists the JWT security schemes that define a JSON Web
+		// Key Set.
+		Schemes []*jwtAuthSchemeData
+	}
+
+	// jwtAuthSchemeData describes the validation of the tokens of a JWT
+	// security scheme.
+	jwtAuthSchemeData struct {
+		// SchemeName is the name of the security scheme.
+		SchemeName string
+		// JWKS is the URL of the JSON Web Key Set.
+		JWKS string
+		// Issuer is the expected token issuer if any.
+		Issuer string
+		// Audience is the expected token audience if any.
+		Audience string
+	}
+)
+
+// JWTAuthFile returns the file implementing the jwtauth package of the given
+// service. The package implements the authorization function of the JWT
+// security schemes that define a JSON Web Key Set with the "jwtauth:jwks"
+// meta. JWTAuthFile returns nil if the service does not use such a scheme.
+func JWTAuthFile(service *expr.ServiceExpr) *codegen.File {
+	data := &jwtAuthData{Name: service.Name}
+	seen := make(map[string]bool)
+	for _, m := range service.Methods {
+		for _, req := range m.Requirements {
+			for _, s := range req.Schemes {
+				if s.Kind != expr.JWTKind || seen[s.SchemeName] {
+					continue
+				}
+				jwks, ok := s.Meta.Last("jwtauth:jwks")
+				if !ok {
+					continue
+				}
+				seen[s.SchemeName] = true
+				iss, _ := s.Meta.Last("jwtauth:issuer")
+				aud, _ := s.Meta.Last("jwtauth:audience")
+				data.Schemes = append(data.Schemes, &jwtAuthSchemeData{
+					SchemeName: s.SchemeName,
+					JWKS:       jwks,
+					Issuer:     iss,
+					Audience:   aud,
+				})
+			}
+		}
+	}
+	if len(data.Schemes) == 0 {
+		return nil
+	}
+	svc := Services.Get(service.Name)
+	path := filepath.Join(codegen.Gendir, codegen.SnakeCase(svc.VarName), "jwtauth", "jwtauth.go")
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" JWT authorization", "jwtauth",
+			[]*codegen.ImportSpec{
+				{Path: "context"},
+				{Path: "fmt"},
+				{Path: "net/http"},
+				codegen.GoaImport(""),
+				codegen.GoaImport("security"),
+				codegen.GoaImport("security/jwt"),
+			}),
+		{
+			Name:   "jwtauth",
+			Source: jwtAuthT,
+			Data:   data,
+		},
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections, Service: service.Name}
+}
+
+// input: jwtAuthData
+const jwtAuthT = `{{ printf "Auth implements the JWT security schemes of the %q service by validating the tokens with the keys of the JSON Web Key Sets defined in the design." .Name | comment }}
+type Auth struct {
+	// Validators indexes the token validators by security scheme name.
+	Validators map[string]*jwt.Validator
+}
+
+// New returns the JWT authorization of the service. client is used to retrieve
+// the JSON Web Key Sets, http.DefaultClient is used if nil.
+func New(client *http.Client) *Auth {
+	return &Auth{
+		Validators: map[string]*jwt.Validator{
+		{{- range .Schemes }}
+			{{ printf "%q" .SchemeName }}: {
+				Keys: jwt.NewKeySet({{ printf "%q" .JWKS }}, client),
+			{{- if .Issuer }}
+				Issuer: {{ printf "%q" .Issuer }},
+			{{- end }}
+			{{- if .Audience }}
+				Audience: {{ printf "%q" .Audience }},
+			{{- end }}
+			},
+		{{- end }}
+		},
+	}
+}
+
+// JWTAuth validates the token, checks that it grants the scopes required by the
+// method and returns a context that contains the token claims, see Claims, and
+// the granted scopes, see security.ContextScopes.
+// JWTAuth implements the JWTAuth method of the service.
+func (a *Auth) JWTAuth(ctx context.Context, token string, scheme *security.JWTScheme) (context.Context, error) {
+	v, ok := a.Validators[scheme.Name]
+	if !ok {
+		return ctx, fmt.Errorf("no validator for security scheme %q", scheme.Name)
+	}
+	claims, err := v.Validate(ctx, token)
+	if err != nil {
+		return ctx, goa.PermanentError("unauthorized", "%s", err)
+	}
+	if err := scheme.Validate(claims.Scopes); err != nil {
+		return ctx, goa.PermanentError("forbidden", "%s", err)
+	}
+	return security.WithScopes(jwt.WithClaims(ctx, claims), claims.Scopes...), nil
+}
+
+// Claims returns the claims of the token validated by JWTAuth.
+func Claims(ctx context.Context) (*jwt.Claims, bool) {
+	return jwt.ContextClaims(ctx)
+}
+`
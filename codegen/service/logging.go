@@ -0,0 +1,279 @@
+package service
+
+import (
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// loggingData contains the data used to render the logging package of
+	// a service.
+	loggingData struct {
+		// Name is the service name.
+		Name string
+		// PkgName is the name of the service package.
+		PkgName string
+		// HTTP is true if the service is exposed over HTTP.
+		HTTP bool
+		// Methods lists the service methods.
+		Methods []*loggingMethodData
+	}
+
+	// loggingMethodData describes a logged method.
+	loggingMethodData struct {
+		// Name is the method name.
+		Name string
+		// VarName is the name of the method endpoint field.
+		VarName string
+		// Route is the path of the first HTTP route of the method if
+		// any.
+		Route string
+		// PayloadRef is the fully qualified reference to the type of the
+		// endpoint request if the method logs payload attributes.
+		PayloadRef string
+		// PayloadField is the name of the field of the endpoint request
+		// that holds the payload for methods that stream results.
+		PayloadField string
+		// Fields lists the payload attributes added to the log records.
+		Fields []*loggingFieldData
+	}
+
+	// loggingFieldData describes a payload attribute added to the log
+	// records.
+	loggingFieldData struct {
+		// Key is the key of the attribute in the log records.
+		Key string
+		// Field is the name of the payload field.
+		Field string
+		// Pointer is true if the payload field is a pointer.
+		Pointer bool
+		// Redact is true if the value of the field is redacted.
+		Redact bool
+	}
+)
+
+// LoggingFile returns the file implementing the logging package of the given
+// service. The package logs the requests made to the service with log/slog
+// using the same schema for all the methods, see the "log:slog", "log:field"
+// and "log:redact" meta. LoggingFile returns nil if the service is not logged.
+func LoggingFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
+	if !service.IsLogged() || len(service.Methods) == 0 {
+		return nil
+	}
+	svc := Services.Get(service.Name)
+	data := &loggingData{Name: service.Name, PkgName: svc.PkgName}
+	var hsvc *expr.HTTPServiceExpr
+	if expr.Root.API != nil && expr.Root.API.HTTP != nil {
+		hsvc = expr.Root.API.HTTP.Service(service.Name)
+		data.HTTP = hsvc != nil
+	}
+	for _, m := range service.Methods {
+		md := svc.Method(m.Name)
+		lmd := &loggingMethodData{Name: m.Name, VarName: md.VarName}
+		if hsvc != nil {
+			if e := hsvc.Endpoint(m.Name); e != nil && len(e.Routes) > 0 {
+				lmd.Route = e.Routes[0].FullPaths()[0]
+			}
+		}
+		for _, nat := range m.LogFields() {
+			key := nat.Name
+			if v, ok := nat.Attribute.Meta.Last("log:field"); ok && v != "" {
+				key = v
+			}
+			_, redact := nat.Attribute.Meta["log:redact"]
+			lmd.Fields = append(lmd.Fields, &loggingFieldData{
+				Key:     key,
+				Field:   codegen.Goify(nat.Name, true),
+				Pointer: m.Payload.IsPrimitivePointer(nat.Name, true),
+				Redact:  redact,
+			})
+		}
+		if len(lmd.Fields) > 0 {
+			if md.ServerStream != nil {
+				lmd.PayloadRef = "*" + svc.PkgName + "." + md.ServerStream.EndpointStruct
+				lmd.PayloadField = "Payload"
+			} else {
+				lmd.PayloadRef = svc.Scope.GoFullTypeRef(m.Payload, svc.PkgName)
+			}
+		}
+		data.Methods = append(data.Methods, lmd)
+	}
+	svcName := codegen.SnakeCase(svc.VarName)
+	path := filepath.Join(codegen.Gendir, svcName, "logging", "logging.go")
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" logging", "logging",
+			[]*codegen.ImportSpec{
+				{Path: "context"},
+				{Path: "log/slog"},
+				{Path: "net/http"},
+				{Path: "time"},
+				codegen.GoaImport(""),
+				codegen.GoaNamedImport("http/middleware", "httpmdlwr"),
+				{Path: genpkg + "/" + svcName, Name: svc.PkgName},
+			}),
+		{
+			Name:   "logging-context",
+			Source: loggingContextT,
+		},
+		{
+			Name:   "logging-wrap",
+			Source: loggingWrapT,
+			Data:   data,
+		},
+	}
+	if data.HTTP {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "logging-http-handler",
+			Source: loggingHandlerT,
+			Data:   data,
+		})
+	}
+	sections = append(sections, &codegen.SectionTemplate{
+		Name:   "logging-endpoint",
+		Source: loggingEndpointT,
+		Data:   data,
+	})
+	for _, m := range data.Methods {
+		if len(m.Fields) == 0 {
+			continue
+		}
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "logging-attrs",
+			Source: loggingAttrsT,
+			Data:   m,
+		})
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections, Service: service.Name}
+}
+
+const loggingContextT = `type (
+	// ctxKey is the type of the context keys used by the package.
+	ctxKey int
+
+	// record holds the attributes added by the endpoints to the log record
+	// of a HTTP request written by Handler.
+	record struct {
+		attrs []slog.Attr
+		err   error
+	}
+)
+
+const (
+	loggerKey ctxKey = iota + 1
+	recordKey
+)
+
+// RedactedValue replaces the values of the payload attributes that define the
+// "log:redact" meta in the log records.
+const RedactedValue = "[REDACTED]"
+
+// WithLogger returns a copy of ctx that carries logger.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, logger)
+}
+
+// Logger returns the logger carried by ctx, the default logger if there is
+// none. The logger given to the service methods by the endpoints wrapped with
+// Wrap includes the service, method and logged payload attributes.
+func Logger(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+`
+
+// input: loggingData
+const loggingWrapT = `{{ printf "Wrap wraps the endpoints of the %q service with endpoints that write a log record for each request with logger. The records have the message \"request\" and the \"service\", \"method\", logged payload attributes, \"duration\" and \"error\" attributes. The context given to the service methods carries a logger with the same request attributes, see Logger. It must be called before the endpoints are mounted on the transport servers." .Name | comment }}
+func Wrap(e *{{ .PkgName }}.Endpoints, logger *slog.Logger) {
+{{- range .Methods }}
+	e.{{ .VarName }} = endpoint(logger, {{ printf "%q" .Name }}, {{ printf "%q" .Route }}, {{ if .Fields }}{{ .VarName }}Attrs{{ else }}nil{{ end }}, e.{{ .VarName }})
+{{- end }}
+}
+`
+
+// input: loggingData
+const loggingHandlerT = `{{ printf "Handler returns a HTTP middleware that writes a log record for each request served by the %q service HTTP server in place of the record written by the endpoints wrapped with Wrap. The record also has the \"route\" and \"status\" attributes and is written at the error level if the status code is 5xx. Use it with the Use method of the server." .Name | comment }}
+func Handler(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := &record{}
+			start := time.Now()
+			rw := httpmdlwr.CaptureResponse(w)
+			h.ServeHTTP(rw, r.WithContext(context.WithValue(r.Context(), recordKey, rec)))
+			status := rw.StatusCode
+			if status == 0 {
+				status = http.StatusOK
+			}
+			attrs := append([]slog.Attr{slog.String("service", {{ printf "%q" .Name }})}, rec.attrs...)
+			attrs = append(attrs, slog.Int("status", status), slog.Duration("duration", time.Since(start)))
+			if rec.err != nil {
+				attrs = append(attrs, slog.String("error", rec.err.Error()))
+			}
+			level := slog.LevelInfo
+			if status >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+			logger.LogAttrs(r.Context(), level, "request", attrs...)
+		})
+	}
+}
+`
+
+// input: loggingData
+const loggingEndpointT = `// endpoint returns an endpoint that gives a logger with the request attributes
+// to e and writes the log record of the request, or adds the attributes to the
+// record written by Handler.
+func endpoint(logger *slog.Logger, method, route string, fields func(interface{}) []slog.Attr, e goa.Endpoint) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		attrs := []slog.Attr{slog.String("service", {{ printf "%q" .Name }}), slog.String("method", method)}
+		if fields != nil {
+			attrs = append(attrs, fields(req)...)
+		}
+		args := make([]interface{}, len(attrs))
+		for i, a := range attrs {
+			args[i] = a
+		}
+		ctx = WithLogger(ctx, logger.With(args...))
+		start := time.Now()
+		res, err := e(ctx, req)
+		if rec, ok := ctx.Value(recordKey).(*record); ok {
+			rec.attrs = append(rec.attrs, attrs[1])
+			if route != "" {
+				rec.attrs = append(rec.attrs, slog.String("route", route))
+			}
+			rec.attrs = append(rec.attrs, attrs[2:]...)
+			rec.err = err
+			return res, err
+		}
+		attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+		level := slog.LevelInfo
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+			level = slog.LevelError
+		}
+		logger.LogAttrs(ctx, level, "request", attrs...)
+		return res, err
+	}
+}
+`
+
+// input: loggingMethodData
+const loggingAttrsT = `{{ printf "%sAttrs returns the payload attributes added to the log records of the requests made to the %q method." .VarName .Name | comment }}
+func {{ .VarName }}Attrs(v interface{}) []slog.Attr {
+	p := v.({{ .PayloadRef }}){{ if .PayloadField }}.{{ .PayloadField }}{{ end }}
+	var attrs []slog.Attr
+{{- range .Fields }}
+	{{- if .Pointer }}
+	if p.{{ .Field }} != nil {
+		attrs = append(attrs, slog.Any({{ printf "%q" .Key }}, {{ if .Redact }}RedactedValue{{ else }}*p.{{ .Field }}{{ end }}))
+	}
+	{{- else }}
+	attrs = append(attrs, slog.Any({{ printf "%q" .Key }}, {{ if .Redact }}RedactedValue{{ else }}p.{{ .Field }}{{ end }}))
+	{{- end }}
+{{- end }}
+	return attrs
+}
+`
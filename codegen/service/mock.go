@@ -0,0 +1,695 @@
+package service
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// mockData contains the data used to render the mock package of a
+	// service.
+	mockData struct {
+		// Name is the service name.
+		Name string
+		// SvcPkg is the name of the service package import.
+		SvcPkg string
+		// Methods lists the mocked methods.
+		Methods []*mockMethodData
+		// Schemes lists the types of the security schemes used by the
+		// service methods.
+		Schemes []string
+		// Authorized is true if the mock must implement the Authorizer
+		// interface.
+		Authorized bool
+		// Pointers lists the Go types of the pointer helper functions
+		// used by the examples.
+		Pointers []string
+		// CallsMethod is the name of the mock method that returns the
+		// recorded calls.
+		CallsMethod string
+		// ResetMethod is the name of the mock method that clears the
+		// recorded calls.
+		ResetMethod string
+	}
+
+	// mockMethodData describes a mocked method.
+	mockMethodData struct {
+		// Name is the method name.
+		Name string
+		// VarName is the name of the method.
+		VarName string
+		// PayloadRef is the fully qualified reference to the payload type
+		// if any.
+		PayloadRef string
+		// ResultRef is the fully qualified reference to the result type
+		// if any.
+		ResultRef string
+		// ResultFunc is the name of the function that returns the result
+		// example if any.
+		ResultFunc string
+		// ResultExample is the code that initializes the result example.
+		ResultExample string
+		// ResultView is the view used to render the result if the method
+		// returns the view.
+		ResultView string
+		// StreamView is the view set on the stream for the methods that
+		// stream results with views.
+		StreamView string
+		// Stream is the fully qualified name of the server stream
+		// interface for streaming methods.
+		Stream string
+		// SendName is the name of the function that sends results to the
+		// stream if any.
+		SendName string
+		// Recv is true if the stream receives payloads.
+		Recv bool
+		// MustClose is true if the stream must be closed.
+		MustClose bool
+		// Doc is the method documentation.
+		Doc string
+	}
+
+	// mockServiceData describes a service served by the stub server.
+	mockServiceData struct {
+		// VarName is the service variable name.
+		VarName string
+		// PkgName is the name of the service package import.
+		PkgName string
+		// MockName is the name of the mock package import.
+		MockName string
+	}
+
+	// exampleCode generates the Go code that initializes the design
+	// examples.
+	exampleCode struct {
+		scope *codegen.NameScope
+		pkg   string
+		// pointers records the Go types of the pointer helper
+		// functions used by the examples.
+		pointers map[string]bool
+	}
+)
+
+// MockFile returns the file implementing the mock package of the given
+// service. The package lives in gen/mock/<service> and defines Mock, an
+// implementation of the service interface that returns the examples defined
+// in the design unless configured otherwise and that records the calls made
+// to its methods. Consumers of the service may use it to test against the
+// service contract without running the actual service.
+func MockFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
+	svc := Services.Get(service.Name)
+	svcName := codegen.SnakeCase(svc.VarName)
+	data := &mockData{
+		Name:       service.Name,
+		SvcPkg:     svc.PkgName + "svc",
+		Authorized: svc.Authorized,
+	}
+	ex := &exampleCode{scope: svc.Scope, pkg: data.SvcPkg, pointers: make(map[string]bool)}
+	names := codegen.NewNameScope()
+	for _, n := range []string{"Mock", "New", "Call"} {
+		names.Unique(n)
+	}
+	// The helper methods must not collide with the service methods.
+	for _, m := range svc.Methods {
+		names.Unique(m.VarName)
+	}
+	data.CallsMethod = names.Unique("Calls", "Made")
+	data.ResetMethod = names.Unique("Reset", "Calls")
+	seen := make(map[string]bool)
+	for _, s := range svc.Schemes {
+		if !seen[s.Type] {
+			seen[s.Type] = true
+			data.Schemes = append(data.Schemes, s.Type)
+		}
+	}
+	for _, m := range service.Methods {
+		md := svc.Method(m.Name)
+		mmd := &mockMethodData{Name: m.Name, VarName: md.VarName}
+		if m.Payload.Type != expr.Empty {
+			mmd.PayloadRef = svc.Scope.GoFullTypeRef(m.Payload, data.SvcPkg)
+		}
+		if m.Result.Type != expr.Empty {
+			mmd.ResultRef = svc.Scope.GoFullTypeRef(m.Result, data.SvcPkg)
+			mmd.ResultFunc = names.Unique(md.VarName + "Result")
+			if v := ex.value(m.Result, md.ResultEx); v != "nil" {
+				mmd.ResultExample = v
+			}
+			if md.ViewedResult != nil && md.ViewedResult.ViewName == "" {
+				view := "default"
+				if v, ok := m.Result.Meta.Last("view"); ok {
+					view = v
+				}
+				if md.ServerStream != nil {
+					mmd.StreamView = view
+				} else {
+					mmd.ResultView = view
+				}
+			}
+		}
+		if s := md.ServerStream; s != nil {
+			mmd.Stream = data.SvcPkg + "." + s.Interface
+			if s.SendTypeRef != "" {
+				mmd.SendName = s.SendName
+			}
+			mmd.Recv = s.RecvTypeRef != ""
+			mmd.MustClose = s.MustClose
+		}
+		mmd.Doc = mockMethodDoc(mmd)
+		data.Methods = append(data.Methods, mmd)
+	}
+	for t := range ex.pointers {
+		data.Pointers = append(data.Pointers, t)
+	}
+	sort.Strings(data.Pointers)
+
+	fpath := filepath.Join(codegen.Gendir, "mock", svcName, "mock.go")
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" mock", svc.PkgName, []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "io"},
+			{Path: "sync"},
+			{Path: path.Join(genpkg, svcName), Name: data.SvcPkg},
+			codegen.GoaImport("security"),
+		}),
+		{Name: "mock-struct", Source: mockStructT, Data: data},
+	}
+	for _, m := range data.Methods {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "mock-method",
+			Source: mockMethodT,
+			Data:   m,
+		})
+	}
+	sections = append(sections, &codegen.SectionTemplate{
+		Name:    "mock-auth",
+		Source:  mockAuthT,
+		Data:    data,
+		FuncMap: map[string]interface{}{"authArgs": mockAuthArgs},
+	})
+	for _, m := range data.Methods {
+		if m.ResultFunc != "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "mock-result",
+				Source: mockResultT,
+				Data:   m,
+			})
+		}
+	}
+	if len(data.Pointers) > 0 {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "mock-pointers",
+			Source: mockPointersT,
+			Data:   data.Pointers,
+		})
+	}
+	return &codegen.File{Path: fpath, SectionTemplates: sections, Service: service.Name}
+}
+
+// MockServerFile returns the file implementing the main package of the stub
+// server command in gen/mock/stub. The command serves the mocks of all the
+// services over the transports defined in the design so that the examples
+// defined in the design are returned to the consumers of the API. The
+// transport specific code is generated by the HTTP and gRPC code generators.
+func MockServerFile(genpkg string, root *expr.RootExpr) *codegen.File {
+	var (
+		httpSvcs []string
+		grpcSvcs []string
+		served   = make(map[string]bool)
+	)
+	for _, svc := range root.API.HTTP.Services {
+		httpSvcs = append(httpSvcs, Services.Get(svc.Name()).VarName)
+		served[svc.Name()] = true
+	}
+	for _, svc := range root.API.GRPC.Services {
+		grpcSvcs = append(grpcSvcs, Services.Get(svc.Name()).VarName)
+		served[svc.Name()] = true
+	}
+	var (
+		svcs  []*mockServiceData
+		scope = codegen.NewNameScope()
+		specs = []*codegen.ImportSpec{{Path: "flag"}, {Path: "log"}}
+	)
+	for _, service := range root.Services {
+		if !served[service.Name] {
+			continue
+		}
+		svc := Services.Get(service.Name)
+		svcName := codegen.SnakeCase(svc.VarName)
+		sd := &mockServiceData{
+			VarName:  svc.VarName,
+			PkgName:  scope.Unique(svc.PkgName),
+			MockName: scope.Unique(svc.PkgName + "mock"),
+		}
+		specs = append(specs,
+			&codegen.ImportSpec{Path: path.Join(genpkg, svcName), Name: sd.PkgName},
+			&codegen.ImportSpec{Path: path.Join(genpkg, "mock", svcName), Name: sd.MockName},
+		)
+		svcs = append(svcs, sd)
+	}
+	if len(svcs) == 0 {
+		return nil
+	}
+	var flags []string
+	if len(httpSvcs) > 0 {
+		flags = append(flags, "-http-addr")
+	}
+	if len(grpcSvcs) > 0 {
+		flags = append(flags, "-grpc-addr")
+	}
+	flagsDoc := flags[0] + " flag"
+	if len(flags) > 1 {
+		flagsDoc = strings.Join(flags, " and ") + " flags"
+	}
+	return &codegen.File{
+		Path: filepath.Join(codegen.Gendir, "mock", "stub", "main.go"),
+		SectionTemplates: []*codegen.SectionTemplate{
+			codegen.Header(root.API.Name+" stub server", "main", specs),
+			{
+				Name:   "mock-server-main",
+				Source: mockServerMainT,
+				Data: map[string]interface{}{
+					"API":      root.API.Name,
+					"Flags":    flagsDoc,
+					"Services": svcs,
+					"HTTP":     httpSvcs,
+					"GRPC":     grpcSvcs,
+				},
+			},
+		},
+	}
+}
+
+// mockMethodDoc returns the documentation of the mock method.
+func mockMethodDoc(m *mockMethodData) string {
+	if m.Stream == "" {
+		res := "nil"
+		if m.ResultFunc != "" {
+			res = "the result of " + m.ResultFunc
+		}
+		return fmt.Sprintf("%s calls %sFunc if not nil and returns %s otherwise.", m.VarName, m.VarName, res)
+	}
+	var does string
+	switch {
+	case m.Recv && m.SendName == "SendAndClose":
+		does = "receives the payloads until the client closes the stream and then sends the result of " + m.ResultFunc
+	case m.Recv && m.SendName != "":
+		does = "sends the result of " + m.ResultFunc + " for each payload received until the client closes the stream"
+	case m.Recv:
+		does = "receives the payloads until the client closes the stream"
+	default:
+		does = "sends the result of " + m.ResultFunc + " and closes the stream"
+	}
+	return fmt.Sprintf("%s calls %sFunc if not nil. Otherwise it %s.", m.VarName, m.VarName, does)
+}
+
+// mockAuthArgs returns the parameters of the authorization function of the
+// given security scheme type that carry the credentials.
+func mockAuthArgs(typ string) string {
+	switch typ {
+	case "Basic":
+		return "user, pass string"
+	case "APIKey":
+		return "key string"
+	case "MTLS":
+		return "cert *security.ClientCertificate"
+	case "Signature":
+		return "keyID string"
+	default:
+		return "token string"
+	}
+}
+
+// value returns the Go code that initializes a value of the type of att with
+// the example v. The code has the type returned by GoFullTypeRef. value
+// returns "nil" if the example cannot be represented, for example because the
+// type is an anonymous struct.
+func (ex *exampleCode) value(att *expr.AttributeExpr, v interface{}) string {
+	if v == nil || hasTypeMeta(att) {
+		return "nil"
+	}
+	switch actual := att.Type.(type) {
+	case expr.Primitive:
+		return ex.primitive(actual, v)
+	case *expr.Array:
+		if isAnonymousStruct(actual.ElemType) {
+			return "nil"
+		}
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return "nil"
+		}
+		elems := make([]string, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			elems[i] = ex.value(actual.ElemType, rv.Index(i).Interface())
+		}
+		return ex.scope.GoFullTypeName(att, ex.pkg) + "{" + strings.Join(elems, ", ") + "}"
+	case *expr.Map:
+		if isAnonymousStruct(actual.KeyType) || isAnonymousStruct(actual.ElemType) {
+			return "nil"
+		}
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Map {
+			return "nil"
+		}
+		pairs := make([]string, 0, rv.Len())
+		for _, k := range rv.MapKeys() {
+			pairs = append(pairs, ex.value(actual.KeyType, k.Interface())+": "+ex.value(actual.ElemType, rv.MapIndex(k).Interface()))
+		}
+		sort.Strings(pairs)
+		return ex.scope.GoFullTypeName(att, ex.pkg) + "{" + strings.Join(pairs, ", ") + "}"
+	case *expr.Object:
+		return "nil"
+	case expr.UserType:
+		if actual == expr.ErrorResult {
+			return "nil"
+		}
+		name := ex.scope.GoFullTypeName(att, ex.pkg)
+		uatt := actual.Attribute()
+		obj := expr.AsObject(uatt.Type)
+		if obj == nil {
+			val := ex.value(&expr.AttributeExpr{Type: uatt.Type, Meta: uatt.Meta}, v)
+			if val == "nil" {
+				return "nil"
+			}
+			return name + "(" + val + ")"
+		}
+		fields, ok := v.(map[string]interface{})
+		if !ok {
+			return "nil"
+		}
+		var inits []string
+		for _, nat := range *obj {
+			fv, ok := fields[nat.Name]
+			if !ok {
+				continue
+			}
+			var val string
+			if uatt.IsPrimitivePointer(nat.Name, true) {
+				val = ex.pointer(nat.Attribute, fv)
+			} else {
+				val = ex.value(nat.Attribute, fv)
+			}
+			if val == "nil" {
+				continue
+			}
+			inits = append(inits, codegen.GoifyAtt(nat.Attribute, nat.Name, true)+": "+val)
+		}
+		if len(inits) == 0 {
+			return "&" + name + "{}"
+		}
+		return "&" + name + "{\n" + strings.Join(inits, ",\n") + ",\n}"
+	default:
+		return "nil"
+	}
+}
+
+// pointer returns the Go code that initializes a pointer to a primitive value
+// of the type of att with the example v.
+func (ex *exampleCode) pointer(att *expr.AttributeExpr, v interface{}) string {
+	p, ok := att.Type.(expr.Primitive)
+	if !ok {
+		return "nil"
+	}
+	val := ex.primitive(p, v)
+	if val == "nil" {
+		return "nil"
+	}
+	t := codegen.GoNativeTypeName(p)
+	ex.pointers[t] = true
+	return t + "Ptr(" + val + ")"
+}
+
+// primitive returns the Go literal of the primitive example v.
+func (ex *exampleCode) primitive(p expr.Primitive, v interface{}) string {
+	rv := reflect.ValueOf(v)
+	switch p.Kind() {
+	case expr.BooleanKind:
+		if rv.Kind() == reflect.Bool {
+			return fmt.Sprint(v)
+		}
+	case expr.StringKind:
+		if rv.Kind() == reflect.String {
+			return fmt.Sprintf("%q", v)
+		}
+	case expr.BytesKind:
+		switch b := v.(type) {
+		case []byte:
+			return fmt.Sprintf("[]byte(%q)", string(b))
+		case string:
+			return fmt.Sprintf("[]byte(%q)", b)
+		}
+	case expr.AnyKind:
+		return literal(v)
+	case expr.Float32Kind, expr.Float64Kind:
+		switch rv.Kind() {
+		case reflect.Float32, reflect.Float64:
+			return fmt.Sprint(rv.Float())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return fmt.Sprint(v)
+		}
+	default:
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return fmt.Sprint(v)
+		}
+	}
+	return "nil"
+}
+
+// literal returns the Go literal of the value of type interface{} v.
+func literal(v interface{}) string {
+	switch actual := v.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return fmt.Sprintf("%q", actual)
+	case []interface{}:
+		elems := make([]string, len(actual))
+		for i, e := range actual {
+			elems[i] = literal(e)
+		}
+		return "[]interface{}{" + strings.Join(elems, ", ") + "}"
+	case map[string]interface{}:
+		pairs := make([]string, 0, len(actual))
+		for k, e := range actual {
+			pairs = append(pairs, fmt.Sprintf("%q: %s", k, literal(e)))
+		}
+		sort.Strings(pairs)
+		return "map[string]interface{}{" + strings.Join(pairs, ", ") + "}"
+	case map[interface{}]interface{}:
+		pairs := make([]string, 0, len(actual))
+		for k, e := range actual {
+			pairs = append(pairs, literal(k)+": "+literal(e))
+		}
+		sort.Strings(pairs)
+		return "map[interface{}]interface{}{" + strings.Join(pairs, ", ") + "}"
+	default:
+		return fmt.Sprintf("%#v", v)
+	}
+}
+
+// hasTypeMeta returns true if the Go type of the attribute is overridden with
+// the "struct:field:type" meta.
+func hasTypeMeta(att *expr.AttributeExpr) bool {
+	_, ok := att.Meta["struct:field:type"]
+	return ok
+}
+
+// isAnonymousStruct returns true if the Go type of the attribute is an
+// anonymous struct.
+func isAnonymousStruct(att *expr.AttributeExpr) bool {
+	_, ok := att.Type.(*expr.Object)
+	return ok
+}
+
+// input: mockData
+const mockStructT = `{{ printf "Mock is a mock implementation of the %q service for testing the consumers of the service. Each method calls the corresponding function field if set and returns the example defined in the design otherwise. The mock records the calls made to its methods, see %s." .Name .CallsMethod | comment }}
+type Mock struct {
+{{- range .Methods }}
+	{{ printf "%sFunc implements %s if not nil." .VarName .VarName | comment }}
+	{{ .VarName }}Func func(context.Context{{ if .PayloadRef }}, {{ .PayloadRef }}{{ end }}{{ if .Stream }}, {{ .Stream }}) error{{ else }}) ({{ if .ResultRef }}{{ .ResultRef }}, {{ if .ResultView }}string, {{ end }}{{ end }}error){{ end }}
+{{- end }}
+{{- range .Schemes }}
+	{{ printf "%sAuthFunc implements %sAuth if not nil, %sAuth accepts all the credentials otherwise." . . . | comment }}
+	{{ . }}AuthFunc func(context.Context, {{ if eq . "Basic" }}string, string{{ else if eq . "MTLS" }}*security.ClientCertificate{{ else }}string{{ end }}, *security.{{ . }}Scheme) (context.Context, error)
+{{- end }}
+{{- if .Authorized }}
+	// AuthorizeFunc implements Authorize if not nil, Authorize allows all the
+	// requests otherwise.
+	AuthorizeFunc func(context.Context, interface{}) error
+{{- end }}
+
+	mu    sync.Mutex
+	calls []*Call
+}
+
+// Call describes a call made to a method of the mock.
+type Call struct {
+	// Method is the name of the method as defined in the design.
+	Method string
+	// Payload is the method payload, nil if the method has no payload.
+	Payload interface{}
+}
+
+// New returns a mock that returns the examples defined in the design.
+func New() *Mock {
+	return &Mock{}
+}
+
+{{ printf "%s returns the calls made to the mock methods in order." .CallsMethod | comment }}
+func (m *Mock) {{ .CallsMethod }}() []*Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*Call(nil), m.calls...)
+}
+
+{{ printf "%s clears the calls recorded by the mock." .ResetMethod | comment }}
+func (m *Mock) {{ .ResetMethod }}() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+}
+
+// record records a call made to the given method.
+func (m *Mock) record(method string, payload interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, &Call{Method: method, Payload: payload})
+}
+
+var _ {{ .SvcPkg }}.Service = (*Mock)(nil)
+`
+
+// input: mockMethodData
+const mockMethodT = `{{ comment .Doc }}
+{{- if .Stream }}
+func (m *Mock) {{ .VarName }}(ctx context.Context{{ if .PayloadRef }}, p {{ .PayloadRef }}{{ end }}, stream {{ .Stream }}) error {
+	m.record({{ printf "%q" .Name }}, {{ if .PayloadRef }}p{{ else }}nil{{ end }})
+	if m.{{ .VarName }}Func != nil {
+		return m.{{ .VarName }}Func(ctx{{ if .PayloadRef }}, p{{ end }}, stream)
+	}
+	{{- if .StreamView }}
+	stream.SetView({{ printf "%q" .StreamView }})
+	{{- end }}
+	{{- if .Recv }}
+	for {
+		_, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		{{- if and .SendName (ne .SendName "SendAndClose") }}
+		if err := stream.{{ .SendName }}({{ .ResultFunc }}()); err != nil {
+			return err
+		}
+		{{- end }}
+	}
+	{{- else if .SendName }}
+	if err := stream.{{ .SendName }}({{ .ResultFunc }}()); err != nil {
+		return err
+	}
+	{{- end }}
+	{{- if eq .SendName "SendAndClose" }}
+	return stream.SendAndClose({{ .ResultFunc }}())
+	{{- else if .MustClose }}
+	return stream.Close()
+	{{- else }}
+	return nil
+	{{- end }}
+}
+{{- else }}
+func (m *Mock) {{ .VarName }}(ctx context.Context{{ if .PayloadRef }}, p {{ .PayloadRef }}{{ end }}) ({{ if .ResultRef }}{{ .ResultRef }}, {{ if .ResultView }}string, {{ end }}{{ end }}error) {
+	m.record({{ printf "%q" .Name }}, {{ if .PayloadRef }}p{{ else }}nil{{ end }})
+	if m.{{ .VarName }}Func != nil {
+		return m.{{ .VarName }}Func(ctx{{ if .PayloadRef }}, p{{ end }})
+	}
+	return {{ if .ResultRef }}{{ .ResultFunc }}(), {{ if .ResultView }}{{ printf "%q" .ResultView }}, {{ end }}{{ end }}nil
+}
+{{- end }}
+`
+
+// input: mockData
+const mockAuthT = `{{- range .Schemes }}
+{{ printf "%sAuth calls %sAuthFunc if not nil and accepts all the credentials otherwise." . . | comment }}
+func (m *Mock) {{ . }}Auth(ctx context.Context, {{ authArgs . }}, scheme *security.{{ . }}Scheme) (context.Context, error) {
+	if m.{{ . }}AuthFunc != nil {
+		return m.{{ . }}AuthFunc(ctx, {{ if eq . "Basic" }}user, pass{{ else if eq . "APIKey" }}key{{ else if eq . "MTLS" }}cert{{ else if eq . "Signature" }}keyID{{ else }}token{{ end }}, scheme)
+	}
+	return ctx, nil
+}
+{{- end }}
+{{- if .Authorized }}
+
+// Authorize calls AuthorizeFunc if not nil and allows all the requests
+// otherwise.
+func (m *Mock) Authorize(ctx context.Context, input interface{}) error {
+	if m.AuthorizeFunc != nil {
+		return m.AuthorizeFunc(ctx, input)
+	}
+	return nil
+}
+{{- end }}
+`
+
+// input: map[string]interface{}{"API":string, "Flags":string, "Services":[]*mockServiceData, "HTTP":[]string, "GRPC":[]string}
+const mockServerMainT = `{{ printf "The stub server serves the mocks of the %s API services, the endpoints return the examples defined in the design. Use the %s to configure the listen addresses of the servers." .API .Flags | comment }}
+func main() {
+	var (
+	{{- if .HTTP }}
+		httpAddr = flag.String("http-addr", ":8080", "HTTP listen address, empty to disable the HTTP server")
+	{{- end }}
+	{{- if .GRPC }}
+		grpcAddr = flag.String("grpc-addr", ":8081", "gRPC listen address, empty to disable the gRPC server")
+	{{- end }}
+	)
+	flag.Parse()
+
+	var (
+	{{- range .Services }}
+		{{ .VarName }}Endpoints = {{ .PkgName }}.NewEndpoints({{ .MockName }}.New())
+	{{- end }}
+	)
+	errc := make(chan error, 2)
+	{{- if .HTTP }}
+	if *httpAddr != "" {
+		go func() {
+			errc <- serveHTTP(*httpAddr{{ range .HTTP }}, {{ . }}Endpoints{{ end }})
+		}()
+	}
+	{{- end }}
+	{{- if .GRPC }}
+	if *grpcAddr != "" {
+		go func() {
+			errc <- serveGRPC(*grpcAddr{{ range .GRPC }}, {{ . }}Endpoints{{ end }})
+		}()
+	}
+	{{- end }}
+	log.Fatal(<-errc)
+}
+`
+
+// input: mockMethodData
+const mockResultT = `{{ printf "%s returns the example result of the %q method defined in the design." .ResultFunc .Name | comment }}
+func {{ .ResultFunc }}() {{ if .ResultExample }}{{ .ResultRef }}{{ else }}(res {{ .ResultRef }}){{ end }} {
+	return {{ if .ResultExample }}{{ .ResultExample }}{{ else }}res{{ end }}
+}
+`
+
+// input: []string
+const mockPointersT = `{{- range . }}
+{{ printf "%sPtr returns a pointer to v." . | comment }}
+func {{ . }}Ptr(v {{ . }}) *{{ . }} {
+	return &v
+}
+{{ end }}`
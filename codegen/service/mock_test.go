@@ -0,0 +1,79 @@
+package service
+
+import (
+	"bytes"
+	"go/format"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestMockFile(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Path string
+		Code string
+	}{
+		{"service", testdata.MockDSL, filepath.Join("gen", "mock", "mock_service", "mock.go"), testdata.MockCode},
+		{"streaming", testdata.MockStreamingDSL, filepath.Join("gen", "mock", "mock_streaming_service", "mock.go"), testdata.MockStreamingCode},
+		{"helper-collision", testdata.MockCollisionDSL, filepath.Join("gen", "mock", "mock_collision_service", "mock.go"), testdata.MockCollisionCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			codegen.RunDSL(t, c.DSL)
+			f := MockFile("goa.design/goa/example", expr.Root.Services[0])
+			if f.Path != c.Path {
+				t.Errorf("got path %q, expected %q", f.Path, c.Path)
+			}
+			buf := new(bytes.Buffer)
+			for _, s := range f.SectionTemplates[1:] {
+				if err := s.Write(buf); err != nil {
+					t.Fatal(err)
+				}
+			}
+			bs, err := format.Source(buf.Bytes())
+			if err != nil {
+				t.Fatalf("%s\n%s", err, buf.String())
+			}
+			if code := string(bs); code != c.Code {
+				t.Errorf("got\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestMockServerFile(t *testing.T) {
+	t.Run("services", func(t *testing.T) {
+		codegen.RunDSL(t, testdata.MockServerDSL)
+		f := MockServerFile("goa.design/goa/example", expr.Root)
+		if f == nil {
+			t.Fatalf("got nil file, expected not nil")
+		}
+		if p := filepath.Join("gen", "mock", "stub", "main.go"); f.Path != p {
+			t.Errorf("got path %q, expected %q", f.Path, p)
+		}
+		buf := new(bytes.Buffer)
+		for _, s := range f.SectionTemplates[1:] {
+			if err := s.Write(buf); err != nil {
+				t.Fatal(err)
+			}
+		}
+		bs, err := format.Source(buf.Bytes())
+		if err != nil {
+			t.Fatalf("%s\n%s", err, buf.String())
+		}
+		if code := string(bs); code != testdata.MockServerCode {
+			t.Errorf("got\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.MockServerCode))
+		}
+	})
+	t.Run("none", func(t *testing.T) {
+		codegen.RunDSL(t, testdata.MockServerNoneDSL)
+		if f := MockServerFile("goa.design/goa/example", expr.Root); f != nil {
+			t.Errorf("got file %q, expected nil", f.Path)
+		}
+	})
+}
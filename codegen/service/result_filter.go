@@ -0,0 +1,177 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// ResultFilterData describes a function that removes the attributes of
+	// a method result that require security scopes not granted to the
+	// caller.
+	ResultFilterData struct {
+		// Name is the name of the function.
+		Name string
+		// TypeName is the name of the filtered type used in the function
+		// comment.
+		TypeName string
+		// TypeRef is the reference to the filtered type.
+		TypeRef string
+		// Code is the function body.
+		Code string
+	}
+
+	// resultFilterBuilder builds the result filter functions of a service.
+	resultFilterBuilder struct {
+		scope   *codegen.NameScope
+		filters []*ResultFilterData
+		names   map[string]string
+	}
+)
+
+// newResultFilterBuilder returns a builder that uses scope to compute the
+// names and references of the service types.
+func newResultFilterBuilder(scope *codegen.NameScope) *resultFilterBuilder {
+	return &resultFilterBuilder{scope: scope, names: make(map[string]string)}
+}
+
+// methodFilter returns the name of the function that filters the result of
+// the given method, it returns the empty string if the result does not define
+// attributes that require security scopes or if the method streams its
+// results.
+func (b *resultFilterBuilder) methodFilter(m *expr.MethodExpr, vname string) string {
+	if m.Result.Type == expr.Empty || m.IsStreaming() {
+		return ""
+	}
+	if !needsResultFilter(m.Result, make(map[string]struct{})) {
+		return ""
+	}
+	if ut, ok := m.Result.Type.(expr.UserType); ok {
+		return b.userTypeFilter(ut)
+	}
+	d := &ResultFilterData{
+		Name:     "filter" + vname + "Result",
+		TypeName: fmt.Sprintf("the %s method result", vname),
+		TypeRef:  b.scope.GoTypeRef(m.Result),
+	}
+	b.filters = append(b.filters, d)
+	d.Code = indentCode(b.filterCode(m.Result, "v", 0))
+	return d.Name
+}
+
+// userTypeFilter returns the name of the function that filters values of the
+// given user type, building the function if needed.
+func (b *resultFilterBuilder) userTypeFilter(ut expr.UserType) string {
+	if name, ok := b.names[ut.ID()]; ok {
+		return name
+	}
+	att := &expr.AttributeExpr{Type: ut}
+	tname := b.scope.GoTypeName(att)
+	d := &ResultFilterData{
+		Name:     "filter" + tname,
+		TypeName: tname,
+		TypeRef:  b.scope.GoTypeRef(att),
+	}
+	b.names[ut.ID()] = d.Name
+	b.filters = append(b.filters, d)
+	code := b.filterCode(ut.Attribute(), "v", 0)
+	if expr.IsObject(ut) {
+		code = "if v == nil {\n\treturn\n}\n" + code
+	}
+	d.Code = indentCode(code)
+	return d.Name
+}
+
+// filterCode returns the code that filters the value of the given attribute
+// held by target.
+func (b *resultFilterBuilder) filterCode(att *expr.AttributeExpr, target string, depth int) string {
+	switch actual := att.Type.(type) {
+	case expr.UserType:
+		return fmt.Sprintf("%s(ctx, %s)\n", b.userTypeFilter(actual), target)
+	case *expr.Object:
+		var code strings.Builder
+		for _, nat := range *actual {
+			field := target + "." + codegen.GoifyAtt(nat.Attribute, nat.Name, true)
+			if scopes := nat.Attribute.Meta["security:scope"]; len(scopes) > 0 {
+				quoted := make([]string, len(scopes))
+				for i, s := range scopes {
+					quoted[i] = fmt.Sprintf("%q", s)
+				}
+				fmt.Fprintf(&code, "if !security.HasScopes(ctx, %s) {\n\t%s = %s\n}\n",
+					strings.Join(quoted, ", "), field, filterZeroValue(att, nat))
+			}
+			if !needsResultFilter(nat.Attribute, make(map[string]struct{})) {
+				continue
+			}
+			inner := b.filterCode(nat.Attribute, field, depth+1)
+			if _, ok := nat.Attribute.Type.(*expr.Object); ok {
+				inner = fmt.Sprintf("if %s != nil {\n%s}\n", field, indentCode(inner))
+			}
+			code.WriteString(inner)
+		}
+		return code.String()
+	case *expr.Array:
+		elem := fmt.Sprintf("e%d", depth)
+		return fmt.Sprintf("for _, %s := range %s {\n%s}\n", elem, target, indentCode(b.filterCode(actual.ElemType, elem, depth+1)))
+	case *expr.Map:
+		elem := fmt.Sprintf("e%d", depth)
+		return fmt.Sprintf("for _, %s := range %s {\n%s}\n", elem, target, indentCode(b.filterCode(actual.ElemType, elem, depth+1)))
+	}
+	return ""
+}
+
+// needsResultFilter returns true if the given attribute or any of its child
+// attributes requires security scopes.
+func needsResultFilter(att *expr.AttributeExpr, seen map[string]struct{}) bool {
+	switch actual := att.Type.(type) {
+	case expr.UserType:
+		if _, ok := seen[actual.ID()]; ok {
+			return false
+		}
+		seen[actual.ID()] = struct{}{}
+		return needsResultFilter(actual.Attribute(), seen)
+	case *expr.Object:
+		for _, nat := range *actual {
+			if len(nat.Attribute.Meta["security:scope"]) > 0 || needsResultFilter(nat.Attribute, seen) {
+				return true
+			}
+		}
+	case *expr.Array:
+		return needsResultFilter(actual.ElemType, seen)
+	case *expr.Map:
+		return needsResultFilter(actual.ElemType, seen)
+	}
+	return false
+}
+
+// filterZeroValue returns the code initializing the zero value of the field
+// holding the given attribute of parent.
+func filterZeroValue(parent *expr.AttributeExpr, nat *expr.NamedAttributeExpr) string {
+	if !expr.IsPrimitive(nat.Attribute.Type) || parent.IsPrimitivePointer(nat.Name, true) {
+		return "nil"
+	}
+	switch nat.Attribute.Type.Kind() {
+	case expr.BooleanKind:
+		return "false"
+	case expr.StringKind:
+		return `""`
+	case expr.BytesKind, expr.AnyKind:
+		return "nil"
+	default:
+		return "0"
+	}
+}
+
+// indentCode indents each line of code with a tab.
+func indentCode(code string) string {
+	lines := strings.SplitAfter(code, "\n")
+	for i, l := range lines {
+		if l != "" && l != "\n" {
+			lines[i] = "\t" + l
+		}
+	}
+	return strings.Join(lines, "")
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"path"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// ScaffoldFiles returns the implementation skeletons of the services that
+// define the "scaffold" meta. A skeleton consists of a file that defines the
+// service struct and constructor and of one file per method with a stub
+// implementation. The files are only rendered if they do not exist so that
+// generating the code after adding methods to the design adds the stubs of the
+// new methods while leaving the existing implementations untouched. The stub
+// files reference the method endpoint constructor of the generated service
+// package so that the files of the methods removed from the design fail to
+// compile.
+func ScaffoldFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var (
+		apipkg string
+		fw     []*codegen.File
+	)
+	for _, svc := range root.Services {
+		if !svc.IsScaffolded() {
+			continue
+		}
+		if apipkg == "" {
+			apipkg = exampleAPIPkg(root)
+		}
+		fw = append(fw, scaffoldFiles(genpkg, svc, apipkg)...)
+	}
+	return fw
+}
+
+// scaffoldFiles returns the implementation skeleton of the given service.
+func scaffoldFiles(genpkg string, svc *expr.ServiceExpr, apipkg string) []*codegen.File {
+	data := Services.Get(svc.Name)
+	svcName := codegen.SnakeCase(data.VarName)
+	specs := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "log"},
+		{Path: path.Join(genpkg, svcName), Name: data.PkgName},
+	}
+	if svc.IsLogged() {
+		specs = append(specs, loggingImport(genpkg, data))
+	}
+	fw := []*codegen.File{{
+		Path: svcName + ".go",
+		SectionTemplates: []*codegen.SectionTemplate{
+			codegen.Header("", apipkg, specs),
+			{Name: "basic-service-struct", Source: svcStructT, Data: data},
+			{Name: "basic-service-init", Source: svcInitT, Data: data},
+		},
+		SkipExist: true,
+	}}
+	for _, m := range svc.Methods {
+		s := basicEndpointSection(m, data)
+		ed := s.Data.(*basicEndpointData)
+		ed.Scaffold = true
+		fw = append(fw, &codegen.File{
+			Path: svcName + "_" + codegen.SnakeCase(ed.VarName) + ".go",
+			SectionTemplates: []*codegen.SectionTemplate{
+				codegen.Header("", apipkg, specs),
+				s,
+				{Name: "scaffold-method-check", Source: scaffoldCheckT, Data: map[string]interface{}{
+					"Name":    m.Name,
+					"VarName": ed.VarName,
+					"PkgName": data.PkgName,
+				}},
+			},
+			SkipExist: true,
+		})
+	}
+	return fw
+}
+
+// input: map[string]interface{}{"Name": string, "VarName": string, "PkgName": string}
+const scaffoldCheckT = `// The {{ printf "%q" .Name }} method is no longer defined in the design if
+// {{ .PkgName }}.New{{ .VarName }}Endpoint is undefined, delete this file.
+var _ = {{ .PkgName }}.New{{ .VarName }}Endpoint
+`
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"bytes"
+	"go/format"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestScaffoldFiles(t *testing.T) {
+	codegen.RunDSL(t, testdata.ScaffoldDSL)
+	fs := ScaffoldFiles("goa.design/goa/example", expr.Root)
+	expected := []string{"calc.go", "calc_add.go", "calc_reset.go"}
+	if len(fs) != len(expected) {
+		t.Fatalf("got %d files, expected %d", len(fs), len(expected))
+	}
+	for i, f := range fs {
+		if f.Path != expected[i] {
+			t.Errorf("got path %q at index %d, expected %q", f.Path, i, expected[i])
+		}
+		if !f.SkipExist {
+			t.Errorf("%s: expected file to be skipped if it exists", f.Path)
+		}
+	}
+	buf := new(bytes.Buffer)
+	for _, s := range fs[1].SectionTemplates[1:] {
+		if err := s.Write(buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bs, err := format.Source(buf.Bytes())
+	if err != nil {
+		t.Fatalf("%s\n%s", err, buf.String())
+	}
+	if code := string(bs); code != testdata.ScaffoldAddCode {
+		t.Errorf("got\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ScaffoldAddCode))
+	}
+
+	// The example service files of scaffolded services are the skeletons.
+	if fs := ExampleServiceFiles("goa.design/goa/example", expr.Root); len(fs) != 4 {
+		t.Errorf("got %d example files, expected 4", len(fs))
+	}
+}
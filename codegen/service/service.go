@@ -29,6 +29,7 @@ func File(genpkg string, service *expr.ServiceExpr) *codegen.File {
 		FuncMap: map[string]interface{}{
 			"streamInterfaceFor": streamInterfaceFor,
 		},
+		Expr: service,
 	}
 
 	sections := []*codegen.SectionTemplate{header, def}
@@ -42,6 +43,7 @@ func File(genpkg string, service *expr.ServiceExpr) *codegen.File {
 					Name:   "service-payload",
 					Source: payloadT,
 					Data:   m,
+					Expr:   service.Method(m.Name),
 				})
 			}
 		}
@@ -52,9 +54,18 @@ func File(genpkg string, service *expr.ServiceExpr) *codegen.File {
 					Name:   "service-streamig-payload",
 					Source: streamingPayloadT,
 					Data:   m,
+					Expr:   service.Method(m.Name),
 				})
 			}
 		}
+		if m.AuthorizationInput != "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "service-authorization-input",
+				Source: authorizationInputT,
+				Data:   m,
+				Expr:   service.Method(m.Name),
+			})
+		}
 		if m.ResultDef != "" {
 			if _, ok := seen[m.Result]; !ok {
 				seen[m.Result] = struct{}{}
@@ -62,6 +73,7 @@ func File(genpkg string, service *expr.ServiceExpr) *codegen.File {
 					Name:   "service-result",
 					Source: resultT,
 					Data:   m,
+					Expr:   service.Method(m.Name),
 				})
 			}
 		}
@@ -72,6 +84,7 @@ func File(genpkg string, service *expr.ServiceExpr) *codegen.File {
 				Name:   "service-user-type",
 				Source: userTypeT,
 				Data:   ut,
+				Expr:   ut.Type,
 			})
 		}
 	}
@@ -86,6 +99,7 @@ func File(genpkg string, service *expr.ServiceExpr) *codegen.File {
 				Name:   "error-user-type",
 				Source: userTypeT,
 				Data:   et,
+				Expr:   et.Type,
 			})
 			errorTypes = append(errorTypes, et)
 		}
@@ -120,6 +134,14 @@ func File(genpkg string, service *expr.ServiceExpr) *codegen.File {
 			Data:   t.Init,
 		})
 	}
+	for _, f := range svc.resultFilters {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "service-result-filter",
+			Source: resultFilterT,
+			Data:   f,
+		})
+	}
+
 	var projh []*codegen.TransformFunctionData
 	for _, t := range svc.projectedTypes {
 		for _, i := range t.TypeInits {
@@ -148,7 +170,7 @@ func File(genpkg string, service *expr.ServiceExpr) *codegen.File {
 		})
 	}
 
-	return &codegen.File{Path: path, SectionTemplates: sections}
+	return &codegen.File{Path: path, SectionTemplates: sections, Service: service.Name}
 }
 
 // AddServiceDataMetaTypeImports Adds all imports defined by struct:field:type from the service expr and the service data
@@ -232,11 +254,27 @@ type Service interface {
 type Auther interface {
 	{{- range .Schemes }}
 	{{ printf "%sAuth implements the authorization logic for the %s security scheme." .Type .Type | comment }}
-	{{ .Type }}Auth(ctx context.Context, {{ if eq .Type "Basic" }}user, pass{{ else if eq .Type "APIKey" }}key{{ else }}token{{ end }} string, schema *security.{{ .Type }}Scheme) (context.Context, error)
+	{{ .Type }}Auth(ctx context.Context, {{ if eq .Type "Basic" }}user, pass string{{ else if eq .Type "APIKey" }}key string{{ else if eq .Type "MTLS" }}cert *security.ClientCertificate{{ else if eq .Type "Signature" }}keyID string{{ else }}token string{{ end }}, schema *security.{{ .Type }}Scheme) (context.Context, error)
 	{{- end }}
 }
 {{- end }}
 
+{{- if .Authorized }}
+
+// Authorizer defines the authorization policies evaluated by the endpoints of
+// the methods that require authorization once the requests are authenticated.
+type Authorizer interface {
+	// Authorize returns an error, typically a "forbidden" error, if the
+	// policies deny the request described by input which is one of:
+	{{- range .Methods }}
+		{{- if .AuthorizationInput }}
+	{{ printf "//	- *%s" .AuthorizationInput }}
+		{{- end }}
+	{{- end }}
+	Authorize(ctx context.Context, input interface{}) error
+}
+{{- end }}
+
 // ServiceName is the name of the service as defined in the design. This is the
 // same value that is set in the endpoint request contexts under the ServiceKey
 // key.
@@ -284,10 +322,40 @@ const streamingPayloadT = `{{ comment .StreamingPayloadDesc }}
 type {{ .StreamingPayload }} {{ .StreamingPayloadDef }}
 `
 
+// input: MethodData
+const authorizationInputT = `{{ printf "%s describes the requests made to the %q method to the authorization policies." .AuthorizationInput .Name | comment }}
+type {{ .AuthorizationInput }} struct {
+	// Service is the name of the service.
+	Service string ` + "`" + `json:"service"` + "`" + `
+	// Method is the name of the method.
+	Method string ` + "`" + `json:"method"` + "`" + `
+	// Principal identifies the caller, see audit.Principal.
+	Principal string ` + "`" + `json:"principal,omitempty"` + "`" + `
+	// Scheme is the name of the security scheme that authenticated the
+	// request, empty if the request is anonymous.
+	Scheme string ` + "`" + `json:"scheme,omitempty"` + "`" + `
+	// Scopes lists the scopes granted to the caller.
+	Scopes []string ` + "`" + `json:"scopes,omitempty"` + "`" + `
+{{- if .PayloadRef }}
+	// Payload is a copy of the method payload without the credentials.
+	Payload {{ .PayloadRef }} ` + "`" + `json:"payload,omitempty"` + "`" + `
+{{- end }}
+	// Transport describes the transport request, nil if the endpoint is
+	// not called by a generated transport handler.
+	Transport *security.TransportMetadata ` + "`" + `json:"transport,omitempty"` + "`" + `
+}
+`
+
 const resultT = `{{ comment .ResultDesc }}
 type {{ .Result }} {{ .ResultDef }}
 `
 
+// input: ResultFilterData
+const resultFilterT = `{{ printf "%s sets the attributes of %s that require security scopes not granted to the caller to their zero value, see security.HasScopes." .Name .TypeName | comment }}
+func {{ .Name }}(ctx context.Context, v {{ .TypeRef }}) {
+{{ .Code }}}
+`
+
 const userTypeT = `{{ comment .Description }}
 type {{ .VarName }} {{ .Def }}
 `
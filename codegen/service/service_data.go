@@ -54,6 +54,9 @@ type (
 		Methods []*MethodData
 		// Schemes is the list of security schemes required by the service methods.
 		Schemes SchemesData
+		// Authorized is true if any of the service methods requires
+		// authorization, see the "authorize" meta.
+		Authorized bool
 		// Scope initialized with all the service types.
 		Scope *codegen.NameScope
 		// ViewScope initialized with all the viewed types.
@@ -71,6 +74,10 @@ type (
 		projectedTypes []*ProjectedTypeData
 		// viewedResultTypes lists all the viewed method result types.
 		viewedResultTypes []*ViewedResultTypeData
+		// resultFilters lists the functions that remove the result
+		// attributes that require security scopes not granted to the
+		// caller.
+		resultFilters []*ResultFilterData
 	}
 
 	// ErrorInitData describes an error returned by a service method of type
@@ -140,6 +147,20 @@ type (
 		// Schemes contains the security schemes types used by the
 		// method.
 		Schemes SchemesData
+		// OptionalSecurity is true if the method may be called without
+		// credentials, in which case the security requirements are only
+		// enforced for requests that carry credentials.
+		OptionalSecurity bool
+		// Realm is the realm of the service used in the authentication
+		// challenges if any, see expr.ServiceExpr.AuthRealm.
+		Realm string
+		// Audited is true if the requests made to the method are
+		// audited, see the "audit" meta.
+		Audited bool
+		// AuthorizationInput is the name of the struct that describes the
+		// requests made to the method to the authorization policies if the
+		// method requires authorization, see the "authorize" meta.
+		AuthorizationInput string
 		// ViewedResult contains the data required to generate the code handling
 		// views if any.
 		ViewedResult *ViewedResultTypeData
@@ -151,6 +172,10 @@ type (
 		ClientStream *StreamData
 		// StreamKind is the kind of the stream (payload or result or bidirectional).
 		StreamKind expr.StreamKind
+		// ResultFilter is the name of the function that removes the
+		// result attributes that require security scopes not granted to
+		// the caller if any.
+		ResultFilter string
 	}
 
 	// StreamData is the data used to generate client and server interfaces that
@@ -216,8 +241,8 @@ type (
 
 	// SchemeData describes a single security scheme.
 	SchemeData struct {
-		// Kind is the type of scheme, one of "Basic", "APIKey", "JWT"
-		// or "OAuth2".
+		// Kind is the type of scheme, one of "Basic", "APIKey", "JWT",
+		// "OAuth2", "MTLS" or "Signature".
 		Type string
 		// SchemeName is the name of the scheme.
 		SchemeName string
@@ -247,18 +272,21 @@ type (
 		// contains the password is required.
 		PasswordRequired bool
 		// CredField contains the name of the payload field that should
-		// be initialized with the API key, the JWT token or the OAuth2
-		// access token.
+		// be initialized with the API key, the JWT token, the OAuth2
+		// access token or the client certificate.
 		CredField string
 		// CredPointer is true if the credential field is a pointer.
 		CredPointer bool
 		// CredRequired specifies if the key is a required attribute.
 		CredRequired bool
 		// KeyAttr is the name of the attribute that contains
-		// the security tag (for APIKey, OAuth2, and JWT schemes).
+		// the security tag (for APIKey, OAuth2, JWT and MTLS schemes).
 		KeyAttr string
 		// Scopes lists the scopes that apply to the scheme.
 		Scopes []string
+		// WildcardScopes is true if the wildcard scopes of the scheme
+		// imply the scopes sharing their prefix.
+		WildcardScopes bool
 		// Flows describes the OAuth2 flows.
 		Flows []*expr.FlowExpr
 		// In indicates the request element that holds the credential.
@@ -433,6 +461,7 @@ func (s *SchemeData) Dup() *SchemeData {
 		CredRequired:     s.CredRequired,
 		KeyAttr:          s.KeyAttr,
 		Scopes:           s.Scopes,
+		WildcardScopes:   s.WildcardScopes,
 		Flows:            s.Flows,
 		In:               s.In,
 	}
@@ -538,6 +567,9 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 			for _, er := range m.Errors {
 				recordError(er)
 			}
+			for _, e := range m.Events {
+				types = append(types, collectTypes(e.Attribute, scope, seen)...)
+			}
 		}
 	}
 
@@ -562,11 +594,13 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 	var (
 		methods []*MethodData
 		schemes SchemesData
+		filters = newResultFilterBuilder(scope)
 	)
 	{
 		methods = make([]*MethodData, len(service.Methods))
 		for i, e := range service.Methods {
 			m := buildMethodData(e, pkgName, service, scope)
+			m.ResultFilter = filters.methodFilter(e, m.VarName)
 			if rt, ok := e.Result.Type.(*expr.ResultTypeExpr); ok {
 				if vrt, ok := seenViewed[m.Result]; ok {
 					m.ViewedResult = vrt
@@ -596,6 +630,13 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		}
 	}
 
+	var authorized bool
+	for _, m := range methods {
+		if m.AuthorizationInput != "" {
+			authorized = true
+		}
+	}
+
 	data := &Data{
 		Name:              service.Name,
 		Description:       desc,
@@ -605,6 +646,7 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		ViewsPkg:          viewspkg,
 		Methods:           methods,
 		Schemes:           schemes,
+		Authorized:        authorized,
 		Scope:             scope,
 		ViewScope:         viewScope,
 		errorTypes:        errTypes,
@@ -612,6 +654,7 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		userTypes:         types,
 		projectedTypes:    projTypes,
 		viewedResultTypes: viewedRTs,
+		resultFilters:     filters.filters,
 	}
 	d[service.Name] = data
 
@@ -709,8 +752,12 @@ func buildMethodData(m *expr.MethodExpr, svcPkgName string, service *expr.Servic
 		schemes      SchemesData
 		svrStream    *StreamData
 		cliStream    *StreamData
+		authzInput   string
 	)
 	vname = scope.Unique(codegen.Goify(m.Name, true), "Endpoint")
+	if m.RequiresAuthorization() {
+		authzInput = vname + "AuthorizationInput"
+	}
 	desc = m.Description
 	if desc == "" {
 		desc = codegen.Goify(m.Name, true) + " implements " + m.Name + "."
@@ -838,6 +885,10 @@ func buildMethodData(m *expr.MethodExpr, svcPkgName string, service *expr.Servic
 		Errors:               errors,
 		Requirements:         reqs,
 		Schemes:              schemes,
+		OptionalSecurity:     m.OptionalSecurity,
+		Realm:                m.Service.AuthRealm(),
+		Audited:              m.IsAudited(),
+		AuthorizationInput:   authzInput,
 		ServerStream:         svrStream,
 		ClientStream:         cliStream,
 		StreamKind:           m.Stream,
@@ -846,6 +897,23 @@ func buildMethodData(m *expr.MethodExpr, svcPkgName string, service *expr.Servic
 
 // buildSchemeData builds the scheme data for the given scheme and method expr.
 func buildSchemeData(s *expr.SchemeExpr, m *expr.MethodExpr) *SchemeData {
+	if s.Kind == expr.SignatureKind {
+		// The request signature is verified by the transport, the
+		// scheme does not need a payload attribute.
+		var scopes []string
+		if len(s.Scopes) > 0 {
+			scopes = make([]string, len(s.Scopes))
+			for i, s := range s.Scopes {
+				scopes[i] = s.Name
+			}
+		}
+		return &SchemeData{
+			Type:           s.Kind.String(),
+			SchemeName:     s.SchemeName,
+			Scopes:         scopes,
+			WildcardScopes: s.WildcardScopes,
+		}
+	}
 	if !expr.IsObject(m.Payload.Type) {
 		return nil
 	}
@@ -874,6 +942,7 @@ func buildSchemeData(s *expr.SchemeExpr, m *expr.MethodExpr) *SchemeData {
 			PasswordPointer:  m.Payload.IsPrimitivePointer(passAtt, true),
 			PasswordRequired: m.Payload.IsRequired(passAtt),
 			Scopes:           scopes,
+			WildcardScopes:   s.WildcardScopes,
 		}
 	case expr.APIKeyKind:
 		if keyAtt := expr.TaggedAttribute(m.Payload, "security:apikey:"+s.SchemeName); keyAtt != "" {
@@ -886,15 +955,16 @@ func buildSchemeData(s *expr.SchemeExpr, m *expr.MethodExpr) *SchemeData {
 				}
 			}
 			return &SchemeData{
-				Type:         s.Kind.String(),
-				Name:         s.Name,
-				SchemeName:   s.SchemeName,
-				CredField:    key,
-				CredPointer:  m.Payload.IsPrimitivePointer(keyAtt, true),
-				CredRequired: m.Payload.IsRequired(keyAtt),
-				KeyAttr:      keyAtt,
-				Scopes:       scopes,
-				In:           s.In,
+				Type:           s.Kind.String(),
+				Name:           s.Name,
+				SchemeName:     s.SchemeName,
+				CredField:      key,
+				CredPointer:    m.Payload.IsPrimitivePointer(keyAtt, true),
+				CredRequired:   m.Payload.IsRequired(keyAtt),
+				KeyAttr:        keyAtt,
+				Scopes:         scopes,
+				WildcardScopes: s.WildcardScopes,
+				In:             s.In,
 			}
 		}
 	case expr.JWTKind:
@@ -908,15 +978,16 @@ func buildSchemeData(s *expr.SchemeExpr, m *expr.MethodExpr) *SchemeData {
 				}
 			}
 			return &SchemeData{
-				Type:         s.Kind.String(),
-				Name:         s.Name,
-				SchemeName:   s.SchemeName,
-				CredField:    key,
-				CredPointer:  m.Payload.IsPrimitivePointer(keyAtt, true),
-				CredRequired: m.Payload.IsRequired(keyAtt),
-				KeyAttr:      keyAtt,
-				Scopes:       scopes,
-				In:           s.In,
+				Type:           s.Kind.String(),
+				Name:           s.Name,
+				SchemeName:     s.SchemeName,
+				CredField:      key,
+				CredPointer:    m.Payload.IsPrimitivePointer(keyAtt, true),
+				CredRequired:   m.Payload.IsRequired(keyAtt),
+				KeyAttr:        keyAtt,
+				Scopes:         scopes,
+				WildcardScopes: s.WildcardScopes,
+				In:             s.In,
 			}
 		}
 	case expr.OAuth2Kind:
@@ -930,16 +1001,38 @@ func buildSchemeData(s *expr.SchemeExpr, m *expr.MethodExpr) *SchemeData {
 				}
 			}
 			return &SchemeData{
-				Type:         s.Kind.String(),
-				Name:         s.Name,
-				SchemeName:   s.SchemeName,
-				CredField:    key,
-				CredPointer:  m.Payload.IsPrimitivePointer(keyAtt, true),
-				CredRequired: m.Payload.IsRequired(keyAtt),
-				KeyAttr:      keyAtt,
-				Scopes:       scopes,
-				Flows:        s.Flows,
-				In:           s.In,
+				Type:           s.Kind.String(),
+				Name:           s.Name,
+				SchemeName:     s.SchemeName,
+				CredField:      key,
+				CredPointer:    m.Payload.IsPrimitivePointer(keyAtt, true),
+				CredRequired:   m.Payload.IsRequired(keyAtt),
+				KeyAttr:        keyAtt,
+				Scopes:         scopes,
+				WildcardScopes: s.WildcardScopes,
+				Flows:          s.Flows,
+				In:             s.In,
+			}
+		}
+	case expr.MTLSKind:
+		if certAtt := expr.TaggedAttribute(m.Payload, "security:clientcert"); certAtt != "" {
+			cert := codegen.Goify(certAtt, true)
+			var scopes []string
+			if len(s.Scopes) > 0 {
+				scopes = make([]string, len(s.Scopes))
+				for i, s := range s.Scopes {
+					scopes[i] = s.Name
+				}
+			}
+			return &SchemeData{
+				Type:           s.Kind.String(),
+				SchemeName:     s.SchemeName,
+				CredField:      cert,
+				CredPointer:    m.Payload.IsPrimitivePointer(certAtt, true),
+				CredRequired:   m.Payload.IsRequired(certAtt),
+				KeyAttr:        certAtt,
+				Scopes:         scopes,
+				WildcardScopes: s.WildcardScopes,
 			}
 		}
 	}
@@ -995,7 +1088,6 @@ func collectProjectedTypes(projected, att *expr.AttributeExpr, viewspkg string,
 // buildProjectedType builds projected type for the given user type.
 //
 // viewspkg is the name of the views package
-//
 func buildProjectedType(projected, att *expr.AttributeExpr, viewspkg string, scope, viewScope *codegen.NameScope) *ProjectedTypeData {
 	var (
 		projections []*InitData
@@ -1465,7 +1557,6 @@ func buildValidations(projected *expr.AttributeExpr, scope *codegen.NameScope) [
 // target data structures in the transformation code.
 //
 // view is used to generate the constructor function name.
-//
 func buildConstructorCode(src, tgt *expr.AttributeExpr, sourceVar, targetVar string, sourceCtx, targetCtx *codegen.AttributeContext, view string) (string, []*codegen.TransformFunctionData) {
 	var (
 		helpers []*codegen.TransformFunctionData
@@ -45,6 +45,8 @@ func TestService(t *testing.T) {
 		{"bidirectional-streaming-no-payload", testdata.BidirectionalStreamingNoPayloadMethodDSL, testdata.BidirectionalStreamingNoPayloadMethod},
 		{"bidirectional-streaming-result-with-views", testdata.BidirectionalStreamingResultWithViewsMethodDSL, testdata.BidirectionalStreamingResultWithViewsMethod},
 		{"bidirectional-streaming-result-with-explicit-view", testdata.BidirectionalStreamingResultWithExplicitViewMethodDSL, testdata.BidirectionalStreamingResultWithExplicitViewMethod},
+		{"result-filter", testdata.ResultFilterDSL, testdata.ResultFilter},
+		{"authorization", testdata.AuthorizationDSL, testdata.AuthorizationService},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -0,0 +1,53 @@
+package testdata
+
+const APIKeyAuthCode = `// Auth implements the API key security schemes of the "APIKeyAuth" service by
+// verifying the keys with key rings loaded from the environment.
+type Auth struct {
+	// Rings indexes the key rings by security scheme name.
+	Rings map[string]*security.APIKeyRing
+}
+
+// New returns the API key authorization of the service loaded with the keys
+// listed in the environment variables, see Reload.
+func New() *Auth {
+	a := &Auth{
+		Rings: map[string]*security.APIKeyRing{
+			"api_key":     security.NewAPIKeyRing("."),
+			"partner_key": security.NewAPIKeyRing(""),
+		},
+	}
+	a.Reload()
+	return a
+}
+
+// Reload loads the keys listed in the environment variables into the key rings.
+// The variables contain comma separated lists of keys so that keys are rotated
+// by adding the new key to the list, calling Reload, moving the clients to the
+// new key and removing the old key from the list.
+func (a *Auth) Reload() {
+	a.Rings["api_key"].Set(security.ParseAPIKeys(os.Getenv("API_KEYS"))...)
+	a.Rings["partner_key"].Set(security.ParseAPIKeys(os.Getenv("PARTNER_KEYS"))...)
+}
+
+// APIKeyAuth verifies the key in constant time and returns a context that
+// contains the ID of the key, see KeyID.
+// APIKeyAuth implements the APIKeyAuth method of the service.
+func (a *Auth) APIKeyAuth(ctx context.Context, key string, scheme *security.APIKeyScheme) (context.Context, error) {
+	r, ok := a.Rings[scheme.Name]
+	if !ok {
+		return ctx, fmt.Errorf("no key ring for security scheme %q", scheme.Name)
+	}
+	id, ok := r.Verify(key)
+	if !ok {
+		return ctx, goa.PermanentError("unauthorized", "invalid API key")
+	}
+	return security.WithAPIKeyID(ctx, id), nil
+}
+
+// KeyID returns the ID of the key verified by APIKeyAuth, the empty string if
+// the key has no ID.
+func KeyID(ctx context.Context) string {
+	id, _ := security.ContextAPIKeyID(ctx)
+	return id
+}
+`
@@ -0,0 +1,46 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var APIKeyAuthDSL = func() {
+	var Key = APIKeySecurity("api_key", func() {
+		KeyLocation("header", "X-API-Key")
+		KeyLocation("query", "api_key")
+		Meta("apikeyauth:env", "API_KEYS")
+		Meta("apikeyauth:separator", ".")
+	})
+	var PartnerKey = APIKeySecurity("partner_key", func() {
+		Meta("apikeyauth:env", "PARTNER_KEYS")
+	})
+	Service("APIKeyAuth", func() {
+		Method("Read", func() {
+			Security(Key)
+			Payload(func() {
+				APIKey("api_key", "key", String)
+			})
+		})
+		Method("Partner", func() {
+			Security(PartnerKey)
+			Security(Key)
+			Payload(func() {
+				APIKey("partner_key", "partner", String)
+				APIKey("api_key", "key", String)
+			})
+		})
+		Method("Unsecure", func() {})
+	})
+}
+
+var APIKeyAuthNoEnvDSL = func() {
+	var Key = APIKeySecurity("api_key")
+	Service("APIKeyAuthNoEnv", func() {
+		Method("Read", func() {
+			Security(Key)
+			Payload(func() {
+				APIKey("api_key", "key", String)
+			})
+		})
+	})
+}
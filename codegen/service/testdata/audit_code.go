@@ -0,0 +1,100 @@
+package testdata
+
+const AuditCode = `// Wrap wraps the audited endpoints of the "Orders" service with endpoints that
+// record an audit event in sink for each request. It must be called before the
+// endpoints are mounted on the transport servers.
+func Wrap(e *orders.Endpoints, sink goaaudit.Sink) {
+	e.Cancel = goaaudit.Endpoint(sink, "Orders", "Cancel", CancelResources, e.Cancel)
+	e.List = goaaudit.Endpoint(sink, "Orders", "List", nil, e.List)
+}
+
+// CancelResources returns the resources accessed by the requests made to the
+// "Cancel" method.
+func CancelResources(v interface{}) map[string]string {
+	p := v.(*orders.CancelPayload)
+	res := make(map[string]string)
+	res["order"] = p.ID
+	if p.Line != nil {
+		res["line"] = fmt.Sprint(*p.Line)
+	}
+	return res
+}
+`
+
+const AuditResourceCode = `// Wrap wraps the audited endpoints of the "AuditResource" service with
+// endpoints that record an audit event in sink for each request. It must be
+// called before the endpoints are mounted on the transport servers.
+func Wrap(e *auditresource.Endpoints, sink goaaudit.Sink) {
+	e.Read = goaaudit.Endpoint(sink, "AuditResource", "Read", ReadResources, e.Read)
+}
+
+// ReadResources returns the resources accessed by the requests made to the
+// "Read" method.
+func ReadResources(v interface{}) map[string]string {
+	p := v.(*auditresource.ReadPayload)
+	res := make(map[string]string)
+	if p.ID != nil {
+		res["id"] = *p.ID
+	}
+	return res
+}
+`
+
+const AuditEndpoint = `// Endpoints wraps the "Orders" service endpoints.
+type Endpoints struct {
+	Cancel goa.Endpoint
+	List   goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "Orders" service with endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	// Casting service to Auther interface
+	a := s.(Auther)
+	return &Endpoints{
+		Cancel: NewCancelEndpoint(s, a.JWTAuth),
+		List:   NewListEndpoint(s),
+	}
+}
+
+// Use applies the given middleware to all the "Orders" service endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.Cancel = m(e.Cancel)
+	e.List = m(e.List)
+}
+
+// NewCancelEndpoint returns an endpoint function that calls the method
+// "Cancel" of service "Orders".
+func NewCancelEndpoint(s Service, authJWTFn security.AuthJWTFunc) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*CancelPayload)
+		var (
+			err  error
+			aerr security.AuthError
+		)
+		sc := security.JWTScheme{
+			Name:           "jwt",
+			Scopes:         []string{"orders:write"},
+			RequiredScopes: []string{"orders:write"},
+		}
+		var token string
+		if p.Token != nil {
+			token = *p.Token
+		}
+		ctx, err = authJWTFn(ctx, token, &sc)
+		aerr.Record("jwt", "JWT", sc.RequiredScopes, err)
+		if err != nil {
+			return nil, aerr.Err()
+		}
+		audit.Authenticated(ctx, aerr.Authorized)
+		return nil, s.Cancel(ctx, p)
+	}
+}
+
+// NewListEndpoint returns an endpoint function that calls the method "List" of
+// service "Orders".
+func NewListEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, s.List(ctx)
+	}
+}
+`
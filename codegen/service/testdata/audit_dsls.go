@@ -0,0 +1,54 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var AuditDSL = func() {
+	var JWTAuth = JWTSecurity("jwt", func() {
+		Scope("orders:write")
+	})
+	Service("Orders", func() {
+		Meta("audit")
+		Method("Cancel", func() {
+			Security(JWTAuth, func() {
+				Scope("orders:write")
+			})
+			Payload(func() {
+				Token("token", String)
+				Attribute("id", String, func() {
+					Meta("audit:resource", "order")
+				})
+				Attribute("line", Int, func() {
+					Meta("audit:resource")
+				})
+				Attribute("reason", String)
+				Required("id")
+			})
+		})
+		Method("List", func() {})
+	})
+}
+
+var AuditResourceDSL = func() {
+	Service("AuditResource", func() {
+		Method("Read", func() {
+			Payload(func() {
+				Attribute("id", String, func() {
+					Meta("audit:resource")
+				})
+			})
+		})
+		Method("Unaudited", func() {
+			Payload(String)
+		})
+	})
+}
+
+var AuditNoneDSL = func() {
+	Service("AuditNone", func() {
+		Method("Read", func() {
+			Payload(String)
+		})
+	})
+}
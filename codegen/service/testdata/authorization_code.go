@@ -0,0 +1,199 @@
+package testdata
+
+const AuthorizationEndpoint = `// Endpoints wraps the "Invoices" service endpoints.
+type Endpoints struct {
+	Cancel goa.Endpoint
+	Ping   goa.Endpoint
+	List   goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "Invoices" service with endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	// Casting service to Auther interface
+	a := s.(Auther)
+	// Casting service to Authorizer interface
+	z := s.(Authorizer)
+	return &Endpoints{
+		Cancel: NewCancelEndpoint(s, a.BasicAuth, a.APIKeyAuth, z),
+		Ping:   NewPingEndpoint(s, z),
+		List:   NewListEndpoint(s),
+	}
+}
+
+// Use applies the given middleware to all the "Invoices" service endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.Cancel = m(e.Cancel)
+	e.Ping = m(e.Ping)
+	e.List = m(e.List)
+}
+
+// NewCancelEndpoint returns an endpoint function that calls the method
+// "Cancel" of service "Invoices".
+func NewCancelEndpoint(s Service, authBasicFn security.AuthBasicFunc, authAPIKeyFn security.AuthAPIKeyFunc, authz Authorizer) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*CancelPayload)
+		var (
+			err  error
+			aerr security.AuthError
+		)
+		sc := security.BasicScheme{
+			Name:           "basic",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		ctx, err = authBasicFn(ctx, p.User, p.Pass, &sc)
+		aerr.Record("basic", "Basic", sc.RequiredScopes, err)
+		if err != nil {
+			sc := security.APIKeyScheme{
+				Name:           "api_key",
+				Scopes:         []string{},
+				RequiredScopes: []string{},
+			}
+			var key string
+			if p.Key != nil {
+				key = *p.Key
+			}
+			ctx, err = authAPIKeyFn(ctx, key, &sc)
+			aerr.Record("api_key", "APIKey", sc.RequiredScopes, err)
+		}
+		if err != nil {
+			return nil, aerr.Err()
+		}
+		pc := *p
+		pc.User = ""
+		pc.Pass = ""
+		pc.Key = nil
+		scopes, _ := security.ContextScopes(ctx)
+		if err := authz.Authorize(ctx, &CancelAuthorizationInput{
+			Service:   "Invoices",
+			Method:    "Cancel",
+			Principal: audit.Principal(ctx),
+			Scheme:    aerr.Authorized,
+			Scopes:    scopes,
+			Payload:   &pc,
+			Transport: security.ContextTransportMetadata(ctx),
+		}); err != nil {
+			return nil, err
+		}
+		return nil, s.Cancel(ctx, p)
+	}
+}
+
+// NewPingEndpoint returns an endpoint function that calls the method "Ping" of
+// service "Invoices".
+func NewPingEndpoint(s Service, authz Authorizer) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(string)
+		scopes, _ := security.ContextScopes(ctx)
+		if err := authz.Authorize(ctx, &PingAuthorizationInput{
+			Service:   "Invoices",
+			Method:    "Ping",
+			Principal: audit.Principal(ctx),
+			Scopes:    scopes,
+			Payload:   p,
+			Transport: security.ContextTransportMetadata(ctx),
+		}); err != nil {
+			return nil, err
+		}
+		return nil, s.Ping(ctx, p)
+	}
+}
+
+// NewListEndpoint returns an endpoint function that calls the method "List" of
+// service "Invoices".
+func NewListEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, s.List(ctx)
+	}
+}
+`
+
+const AuthorizationService = `
+// Service is the Invoices service interface.
+type Service interface {
+	// Cancel implements Cancel.
+	Cancel(context.Context, *CancelPayload) (err error)
+	// Ping implements Ping.
+	Ping(context.Context, string) (err error)
+	// List implements List.
+	List(context.Context) (err error)
+}
+
+// Auther defines the authorization functions to be implemented by the service.
+type Auther interface {
+	// BasicAuth implements the authorization logic for the Basic security scheme.
+	BasicAuth(ctx context.Context, user, pass string, schema *security.BasicScheme) (context.Context, error)
+	// APIKeyAuth implements the authorization logic for the APIKey security scheme.
+	APIKeyAuth(ctx context.Context, key string, schema *security.APIKeyScheme) (context.Context, error)
+}
+
+// Authorizer defines the authorization policies evaluated by the endpoints of
+// the methods that require authorization once the requests are authenticated.
+type Authorizer interface {
+	// Authorize returns an error, typically a "forbidden" error, if the
+	// policies deny the request described by input which is one of:
+	//	- *CancelAuthorizationInput
+	//	- *PingAuthorizationInput
+	Authorize(ctx context.Context, input interface{}) error
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "Invoices"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [3]string{"Cancel", "Ping", "List"}
+
+// CancelPayload is the payload type of the Invoices service Cancel method.
+type CancelPayload struct {
+	User string
+	Pass string
+	Key  *string
+	ID   string
+}
+
+// CancelAuthorizationInput describes the requests made to the "Cancel" method
+// to the authorization policies.
+type CancelAuthorizationInput struct {
+	// Service is the name of the service.
+	Service string ` + "`" + `json:"service"` + "`" + `
+	// Method is the name of the method.
+	Method string ` + "`" + `json:"method"` + "`" + `
+	// Principal identifies the caller, see audit.Principal.
+	Principal string ` + "`" + `json:"principal,omitempty"` + "`" + `
+	// Scheme is the name of the security scheme that authenticated the
+	// request, empty if the request is anonymous.
+	Scheme string ` + "`" + `json:"scheme,omitempty"` + "`" + `
+	// Scopes lists the scopes granted to the caller.
+	Scopes []string ` + "`" + `json:"scopes,omitempty"` + "`" + `
+	// Payload is a copy of the method payload without the credentials.
+	Payload *CancelPayload ` + "`" + `json:"payload,omitempty"` + "`" + `
+	// Transport describes the transport request, nil if the endpoint is
+	// not called by a generated transport handler.
+	Transport *security.TransportMetadata ` + "`" + `json:"transport,omitempty"` + "`" + `
+}
+
+// PingAuthorizationInput describes the requests made to the "Ping" method to
+// the authorization policies.
+type PingAuthorizationInput struct {
+	// Service is the name of the service.
+	Service string ` + "`" + `json:"service"` + "`" + `
+	// Method is the name of the method.
+	Method string ` + "`" + `json:"method"` + "`" + `
+	// Principal identifies the caller, see audit.Principal.
+	Principal string ` + "`" + `json:"principal,omitempty"` + "`" + `
+	// Scheme is the name of the security scheme that authenticated the
+	// request, empty if the request is anonymous.
+	Scheme string ` + "`" + `json:"scheme,omitempty"` + "`" + `
+	// Scopes lists the scopes granted to the caller.
+	Scopes []string ` + "`" + `json:"scopes,omitempty"` + "`" + `
+	// Payload is a copy of the method payload without the credentials.
+	Payload string ` + "`" + `json:"payload,omitempty"` + "`" + `
+	// Transport describes the transport request, nil if the endpoint is
+	// not called by a generated transport handler.
+	Transport *security.TransportMetadata ` + "`" + `json:"transport,omitempty"` + "`" + `
+}
+`
@@ -0,0 +1,29 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var AuthorizationDSL = func() {
+	var BasicAuth = BasicAuthSecurity("basic")
+	var APIKeyAuth = APIKeySecurity("api_key")
+	Service("Invoices", func() {
+		Method("Cancel", func() {
+			Meta("authorize")
+			Security(BasicAuth)
+			Security(APIKeyAuth)
+			Payload(func() {
+				Username("user", String)
+				Password("pass", String)
+				APIKey("api_key", "key", String)
+				Attribute("id", String)
+				Required("user", "pass", "id")
+			})
+		})
+		Method("Ping", func() {
+			Meta("authorize")
+			Payload(String)
+		})
+		Method("List", func() {})
+	})
+}
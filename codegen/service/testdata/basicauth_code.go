@@ -0,0 +1,165 @@
+package testdata
+
+const BasicAuthCode = `// Auth implements the basic auth security schemes of the "BasicAuth" service
+// by verifying the passwords with the hashes of a user store.
+type Auth struct {
+	// Authenticators indexes the authenticators by security scheme name.
+	Authenticators map[string]*security.BasicAuthenticator
+}
+
+// New returns the basic auth authorization of the service that verifies the
+// passwords with the hashes of users. Register the verifiers of the hash
+// formats used by the store with UseBcrypt or UseArgon2id, for example:
+//
+//	users, err := security.ParseHtpasswd(f)
+//	...
+//	a := basicauth.New(users)
+//	a.UseBcrypt(bcrypt.CompareHashAndPassword)
+func New(users security.UserStore) *Auth {
+	return &Auth{
+		Authenticators: map[string]*security.BasicAuthenticator{
+			"basic":       security.NewBasicAuthenticator(users),
+			"admin_basic": security.NewBasicAuthenticator(users),
+		},
+	}
+}
+
+// UseBcrypt registers the verifier of the bcrypt hashes with all the
+// authenticators, typically bcrypt.CompareHashAndPassword of
+// golang.org/x/crypto/bcrypt.
+func (a *Auth) UseBcrypt(v security.PasswordVerifier) {
+	for _, au := range a.Authenticators {
+		au.UseBcrypt(v)
+	}
+}
+
+// UseArgon2id registers the verifier of the argon2id hashes with all the
+// authenticators, idKey is typically argon2.IDKey of
+// golang.org/x/crypto/argon2.
+func (a *Auth) UseArgon2id(idKey security.Argon2IDKeyFunc) {
+	for _, au := range a.Authenticators {
+		au.UseArgon2id(idKey)
+	}
+}
+
+// BasicAuth verifies the password of the user and returns a context that
+// contains the name of the user, see User.
+// BasicAuth implements the BasicAuth method of the service.
+func (a *Auth) BasicAuth(ctx context.Context, user, pass string, scheme *security.BasicScheme) (context.Context, error) {
+	au, ok := a.Authenticators[scheme.Name]
+	if !ok {
+		return ctx, fmt.Errorf("no authenticator for security scheme %q", scheme.Name)
+	}
+	if err := au.Verify(ctx, user, pass); err != nil {
+		if err == security.ErrInvalidCredentials {
+			return ctx, goa.PermanentError("unauthorized", "invalid username or password")
+		}
+		return ctx, goa.Fault("failed to verify the password of %q: %s", user, err)
+	}
+	return security.WithBasicUser(ctx, user), nil
+}
+
+// User returns the name of the user authenticated by BasicAuth.
+func User(ctx context.Context) string {
+	u, _ := security.ContextBasicUser(ctx)
+	return u
+}
+`
+
+const BasicAuthEndpoint = `// Endpoints wraps the "BasicAuth" service endpoints.
+type Endpoints struct {
+	Login    goa.Endpoint
+	Admin    goa.Endpoint
+	Unsecure goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "BasicAuth" service with endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	// Casting service to Auther interface
+	a := s.(Auther)
+	return &Endpoints{
+		Login:    NewLoginEndpoint(s, a.BasicAuth),
+		Admin:    NewAdminEndpoint(s, a.BasicAuth),
+		Unsecure: NewUnsecureEndpoint(s),
+	}
+}
+
+// Use applies the given middleware to all the "BasicAuth" service endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.Login = m(e.Login)
+	e.Admin = m(e.Admin)
+	e.Unsecure = m(e.Unsecure)
+}
+
+// NewLoginEndpoint returns an endpoint function that calls the method "Login"
+// of service "BasicAuth".
+func NewLoginEndpoint(s Service, authBasicFn security.AuthBasicFunc) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*LoginPayload)
+		var (
+			err  error
+			aerr security.AuthError
+		)
+		aerr.Realm = "Accounts"
+		sc := security.BasicScheme{
+			Name:           "basic",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		var user string
+		if p.User != nil {
+			user = *p.User
+		}
+		var pass string
+		if p.Pass != nil {
+			pass = *p.Pass
+		}
+		ctx, err = authBasicFn(ctx, user, pass, &sc)
+		aerr.Record("basic", "Basic", sc.RequiredScopes, err)
+		if err != nil {
+			return nil, aerr.Err()
+		}
+		return nil, s.Login(ctx, p)
+	}
+}
+
+// NewAdminEndpoint returns an endpoint function that calls the method "Admin"
+// of service "BasicAuth".
+func NewAdminEndpoint(s Service, authBasicFn security.AuthBasicFunc) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*AdminPayload)
+		var (
+			err  error
+			aerr security.AuthError
+		)
+		aerr.Realm = "Accounts"
+		sc := security.BasicScheme{
+			Name:           "admin_basic",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		var user string
+		if p.User != nil {
+			user = *p.User
+		}
+		var pass string
+		if p.Pass != nil {
+			pass = *p.Pass
+		}
+		ctx, err = authBasicFn(ctx, user, pass, &sc)
+		aerr.Record("admin_basic", "Basic", sc.RequiredScopes, err)
+		if err != nil {
+			return nil, aerr.Err()
+		}
+		return nil, s.Admin(ctx, p)
+	}
+}
+
+// NewUnsecureEndpoint returns an endpoint function that calls the method
+// "Unsecure" of service "BasicAuth".
+func NewUnsecureEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, s.Unsecure(ctx)
+	}
+}
+`
@@ -0,0 +1,45 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var BasicAuthDSL = func() {
+	var Basic = BasicAuthSecurity("basic", func() {
+		Meta("basicauth")
+	})
+	var AdminBasic = BasicAuthSecurity("admin_basic", func() {
+		Meta("basicauth")
+	})
+	Service("BasicAuth", func() {
+		Realm("Accounts")
+		Method("Login", func() {
+			Security(Basic)
+			Payload(func() {
+				Username("user", String)
+				Password("pass", String)
+			})
+		})
+		Method("Admin", func() {
+			Security(AdminBasic)
+			Payload(func() {
+				Username("user", String)
+				Password("pass", String)
+			})
+		})
+		Method("Unsecure", func() {})
+	})
+}
+
+var BasicAuthNoMetaDSL = func() {
+	var Basic = BasicAuthSecurity("basic")
+	Service("BasicAuthNoMeta", func() {
+		Method("Login", func() {
+			Security(Basic)
+			Payload(func() {
+				Username("user", String)
+				Password("pass", String)
+			})
+		})
+	})
+}
@@ -0,0 +1,20 @@
+package testdata
+
+const CachingCode = `// InvalidateShow removes the result of the "Show" method cached for the given
+// key from store. Call it from the methods that modify the result.
+func InvalidateShow(ctx context.Context, store goa.CacheStore, id string, view string) error {
+	return store.Delete(ctx, goa.CacheKey("CachedAccounts", "Show", id, view))
+}
+
+// InvalidateList removes the result of the "List" method cached for the given
+// key from store. Call it from the methods that modify the result.
+func InvalidateList(ctx context.Context, store goa.CacheStore, p *cachedaccounts.ListPayload) error {
+	return store.Delete(ctx, goa.CacheKey("CachedAccounts", "List", p))
+}
+
+// InvalidateCount removes the result of the "Count" method cached in store.
+// Call it from the methods that modify the result.
+func InvalidateCount(ctx context.Context, store goa.CacheStore) error {
+	return store.Delete(ctx, goa.CacheKey("CachedAccounts", "Count"))
+}
+`
@@ -0,0 +1,59 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var CachingDSL = func() {
+	Service("CachedAccounts", func() {
+		Method("Show", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("view", String)
+				Attribute("verbose", Boolean)
+				Required("id")
+			})
+			Result(String)
+			Cache("5m", CacheKey("id", "view"))
+			HTTP(func() {
+				GET("/accounts/{id}")
+				Param("view")
+				Param("verbose")
+			})
+		})
+		Method("List", func() {
+			Payload(func() {
+				Attribute("page", Int)
+			})
+			Result(ArrayOf(String))
+			Cache("90s")
+			HTTP(func() {
+				GET("/accounts")
+				Param("page")
+			})
+		})
+		Method("Count", func() {
+			Result(Int)
+			Cache("1h")
+			HTTP(func() {
+				GET("/accounts/count")
+			})
+		})
+		Method("Update", func() {
+			Payload(String)
+			HTTP(func() {
+				PUT("/accounts/{id}")
+			})
+		})
+	})
+}
+
+var CachingNoneDSL = func() {
+	Service("UncachedAccounts", func() {
+		Method("Show", func() {
+			HTTP(func() {
+				GET("/accounts")
+			})
+		})
+	})
+}
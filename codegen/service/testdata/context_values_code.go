@@ -0,0 +1,107 @@
+package testdata
+
+const ContextValuesCode = `// value returns the value of the propagated header with the given lower case
+// name carried by ctx.
+func value(ctx context.Context, key string) (string, bool) {
+	vals, _ := ctx.Value(goa.PropagatedKey).(map[string]string)
+	v, ok := vals[key]
+	return v, ok
+}
+
+// withValue returns a copy of ctx that carries v as value of the propagated
+// header with the given lower case name.
+func withValue(ctx context.Context, key, v string) context.Context {
+	vals, _ := ctx.Value(goa.PropagatedKey).(map[string]string)
+	res := make(map[string]string, len(vals)+1)
+	for k, val := range vals {
+		res[k] = val
+	}
+	res[key] = v
+	return context.WithValue(ctx, goa.PropagatedKey, res)
+}
+
+// TenantID returns the "tenant_id" context value carried by ctx. The generated
+// servers read the value from the "X-Tenant-ID" header of the incoming
+// requests. TenantID returns false if ctx does not carry the value.
+//
+// TenantID identifies the tenant making the request.
+func TenantID(ctx context.Context) (string, bool) {
+	s, ok := value(ctx, "x-tenant-id")
+	if !ok {
+		return "", false
+	}
+	return s, true
+}
+
+// WithTenantID returns a copy of ctx that carries v as "tenant_id" context
+// value. The generated clients send the value in the "X-Tenant-ID" header of
+// the requests made with the returned context.
+func WithTenantID(ctx context.Context, v string) context.Context {
+	return withValue(ctx, "x-tenant-id", v)
+}
+
+// Locale returns the "locale" context value carried by ctx. The generated
+// servers read the value from the "Accept-Language" header of the incoming
+// requests. Locale returns the default value "en-US" if ctx does not carry the
+// value.
+func Locale(ctx context.Context) (string, bool) {
+	s, ok := value(ctx, "accept-language")
+	if !ok {
+		return "en-US", true
+	}
+	return s, true
+}
+
+// WithLocale returns a copy of ctx that carries v as "locale" context value.
+// The generated clients send the value in the "Accept-Language" header of the
+// requests made with the returned context.
+func WithLocale(ctx context.Context, v string) context.Context {
+	return withValue(ctx, "accept-language", v)
+}
+
+// Priority returns the "priority" context value carried by ctx. The generated
+// servers read the value from the "priority" header of the incoming requests.
+// Priority returns false if ctx does not carry the value or if the value is
+// not a valid int.
+func Priority(ctx context.Context) (int, bool) {
+	s, ok := value(ctx, "priority")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(s, 10, strconv.IntSize)
+	if err != nil {
+		return 0, false
+	}
+	return int(n), true
+}
+
+// WithPriority returns a copy of ctx that carries v as "priority" context
+// value. The generated clients send the value in the "priority" header of the
+// requests made with the returned context.
+func WithPriority(ctx context.Context, v int) context.Context {
+	return withValue(ctx, "priority", strconv.Itoa(v))
+}
+
+// DryRun returns the "dry_run" context value carried by ctx. The generated
+// servers read the value from the "X-Dry-Run" header of the incoming requests.
+// DryRun returns false if ctx does not carry the value or if the value is not
+// a valid bool.
+func DryRun(ctx context.Context) (bool, bool) {
+	s, ok := value(ctx, "x-dry-run")
+	if !ok {
+		return false, false
+	}
+	n, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, false
+	}
+	return n, true
+}
+
+// WithDryRun returns a copy of ctx that carries v as "dry_run" context value.
+// The generated clients send the value in the "X-Dry-Run" header of the
+// requests made with the returned context.
+func WithDryRun(ctx context.Context, v bool) context.Context {
+	return withValue(ctx, "x-dry-run", strconv.FormatBool(v))
+}
+`
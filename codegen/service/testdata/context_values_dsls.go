@@ -0,0 +1,38 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ContextValuesDSL = func() {
+	API("ContextValues", func() {
+		ContextValue("tenant_id:X-Tenant-ID", String, "TenantID identifies the tenant making the request.")
+		ContextValue("locale:Accept-Language", String, func() {
+			Default("en-US")
+		})
+		ContextValue("priority", Int)
+		ContextValue("dry_run:X-Dry-Run", Boolean)
+	})
+	Service("ContextValuesService", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var TenantContextValuesDSL = func() {
+	API("ContextValues", func() {
+		Tenant(func() {
+			Header("X-Org")
+		})
+	})
+	Service("ContextValuesService", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
@@ -505,3 +505,481 @@ func NewBidirectionalStreamingNoPayloadMethodEndpoint(s Service) goa.Endpoint {
 	}
 }
 `
+
+const MTLSEndpoint = `// Endpoints wraps the "MTLSEndpoint" service endpoints.
+type Endpoints struct {
+	Required goa.Endpoint
+	Optional goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "MTLSEndpoint" service with endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	// Casting service to Auther interface
+	a := s.(Auther)
+	return &Endpoints{
+		Required: NewRequiredEndpoint(s, a.MTLSAuth),
+		Optional: NewOptionalEndpoint(s, a.JWTAuth, a.MTLSAuth),
+	}
+}
+
+// Use applies the given middleware to all the "MTLSEndpoint" service endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.Required = m(e.Required)
+	e.Optional = m(e.Optional)
+}
+
+// NewRequiredEndpoint returns an endpoint function that calls the method
+// "Required" of service "MTLSEndpoint".
+func NewRequiredEndpoint(s Service, authMTLSFn security.AuthMTLSFunc) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*RequiredPayload)
+		var (
+			err  error
+			aerr security.AuthError
+		)
+		sc := security.MTLSScheme{
+			Name:           "mtls",
+			Scopes:         []string{"payments"},
+			RequiredScopes: []string{"payments"},
+		}
+		var cert *security.ClientCertificate
+		if p.Cert != "" {
+			cert, err = security.ParseClientCertificate(p.Cert)
+		}
+		if err == nil {
+			ctx, err = authMTLSFn(ctx, cert, &sc)
+		}
+		aerr.Record("mtls", "MTLS", sc.RequiredScopes, err)
+		if err != nil {
+			return nil, aerr.Err()
+		}
+		return nil, s.Required(ctx, p)
+	}
+}
+
+// NewOptionalEndpoint returns an endpoint function that calls the method
+// "Optional" of service "MTLSEndpoint".
+func NewOptionalEndpoint(s Service, authJWTFn security.AuthJWTFunc, authMTLSFn security.AuthMTLSFunc) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*OptionalPayload)
+		var (
+			err  error
+			aerr security.AuthError
+		)
+		sc := security.JWTScheme{
+			Name:           "jwt",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		var token string
+		if p.Token != nil {
+			token = *p.Token
+		}
+		ctx, err = authJWTFn(ctx, token, &sc)
+		aerr.Record("jwt", "JWT", sc.RequiredScopes, err)
+		if err == nil {
+			sc := security.MTLSScheme{
+				Name:           "mtls",
+				Scopes:         []string{"payments"},
+				RequiredScopes: []string{},
+			}
+			var cert *security.ClientCertificate
+			if p.Cert != nil && *p.Cert != "" {
+				cert, err = security.ParseClientCertificate(*p.Cert)
+			}
+			if err == nil {
+				ctx, err = authMTLSFn(ctx, cert, &sc)
+			}
+			aerr.Record("mtls", "MTLS", sc.RequiredScopes, err)
+		}
+		if err != nil {
+			return nil, aerr.Err()
+		}
+		return nil, s.Optional(ctx, p)
+	}
+}
+`
+
+const SignatureEndpoint = `// Endpoints wraps the "SignatureEndpoint" service endpoints.
+type Endpoints struct {
+	Signed goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "SignatureEndpoint" service with
+// endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	// Casting service to Auther interface
+	a := s.(Auther)
+	return &Endpoints{
+		Signed: NewSignedEndpoint(s, a.SignatureAuth),
+	}
+}
+
+// Use applies the given middleware to all the "SignatureEndpoint" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.Signed = m(e.Signed)
+}
+
+// NewSignedEndpoint returns an endpoint function that calls the method
+// "Signed" of service "SignatureEndpoint".
+func NewSignedEndpoint(s Service, authSignatureFn security.AuthSignatureFunc) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		var (
+			err  error
+			aerr security.AuthError
+		)
+		sc := security.SignatureScheme{
+			Name:           "signed",
+			Scopes:         []string{"orders:write"},
+			RequiredScopes: []string{"orders:write"},
+		}
+		keyID, _ := security.ContextSignatureKeyID(ctx)
+		ctx, err = authSignatureFn(ctx, keyID, &sc)
+		aerr.Record("signed", "Signature", sc.RequiredScopes, err)
+		if err != nil {
+			return nil, aerr.Err()
+		}
+		return nil, s.Signed(ctx)
+	}
+}
+`
+
+const ResultFilterEndpoint = `// Endpoints wraps the "ResultFilter" service endpoints.
+type Endpoints struct {
+	Show goa.Endpoint
+	List goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "ResultFilter" service with endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	return &Endpoints{
+		Show: NewShowEndpoint(s),
+		List: NewListEndpoint(s),
+	}
+}
+
+// Use applies the given middleware to all the "ResultFilter" service endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.Show = m(e.Show)
+	e.List = m(e.List)
+}
+
+// NewShowEndpoint returns an endpoint function that calls the method "Show" of
+// service "ResultFilter".
+func NewShowEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		res, view, err := s.Show(ctx)
+		if err != nil {
+			return nil, err
+		}
+		filterEmployee(ctx, res)
+		vres := NewViewedEmployee(res, view)
+		return vres, nil
+	}
+}
+
+// NewListEndpoint returns an endpoint function that calls the method "List" of
+// service "ResultFilter".
+func NewListEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		res, err := s.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		filterListResult(ctx, res)
+		return res, nil
+	}
+}
+`
+
+const SecurityChainEndpoint = `// Endpoints wraps the "SecurityChainEndpoint" service endpoints.
+type Endpoints struct {
+	Chained goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "SecurityChainEndpoint" service with
+// endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	// Casting service to Auther interface
+	a := s.(Auther)
+	return &Endpoints{
+		Chained: NewChainedEndpoint(s, a.JWTAuth, a.APIKeyAuth, a.SignatureAuth),
+	}
+}
+
+// Use applies the given middleware to all the "SecurityChainEndpoint" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.Chained = m(e.Chained)
+}
+
+// NewChainedEndpoint returns an endpoint function that calls the method
+// "Chained" of service "SecurityChainEndpoint".
+func NewChainedEndpoint(s Service, authJWTFn security.AuthJWTFunc, authAPIKeyFn security.AuthAPIKeyFunc, authSignatureFn security.AuthSignatureFunc) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*ChainedPayload)
+		var (
+			err  error
+			aerr security.AuthError
+		)
+		_, signed := security.ContextSignatureKeyID(ctx)
+		// Requests that carry no credentials are anonymous.
+		if p.Token != nil || p.Key != nil || signed {
+			sc := security.JWTScheme{
+				Name:           "jwt",
+				Scopes:         []string{},
+				RequiredScopes: []string{},
+			}
+			var token string
+			if p.Token != nil {
+				token = *p.Token
+			}
+			ctx, err = authJWTFn(ctx, token, &sc)
+			aerr.Record("jwt", "JWT", sc.RequiredScopes, err)
+			if err != nil {
+				sc := security.APIKeyScheme{
+					Name:           "api_key",
+					Scopes:         []string{},
+					RequiredScopes: []string{},
+				}
+				var key string
+				if p.Key != nil {
+					key = *p.Key
+				}
+				ctx, err = authAPIKeyFn(ctx, key, &sc)
+				aerr.Record("api_key", "APIKey", sc.RequiredScopes, err)
+			}
+			if err != nil {
+				sc := security.SignatureScheme{
+					Name:           "signed",
+					Scopes:         []string{},
+					RequiredScopes: []string{},
+				}
+				keyID, _ := security.ContextSignatureKeyID(ctx)
+				ctx, err = authSignatureFn(ctx, keyID, &sc)
+				aerr.Record("signed", "Signature", sc.RequiredScopes, err)
+			}
+		}
+		if err != nil {
+			return nil, aerr.Err()
+		}
+		return nil, s.Chained(ctx, p)
+	}
+}
+`
+
+const ConcurrencyLimitEndpoint = `// Endpoints wraps the "ConcurrencyLimitEndpoint" service endpoints.
+type Endpoints struct {
+	A goa.Endpoint
+	B goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "ConcurrencyLimitEndpoint" service
+// with endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	return &Endpoints{
+		A: goa.LimitEndpoint(goa.NewLimiter(100, 0, 0))(NewAEndpoint(s)),
+		B: goa.LimitEndpoint(goa.NewLimiter(4, 16, 2*time.Second))(NewBEndpoint(s)),
+	}
+}
+
+// Use applies the given middleware to all the "ConcurrencyLimitEndpoint"
+// service endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+	e.B = m(e.B)
+}
+
+// NewAEndpoint returns an endpoint function that calls the method "A" of
+// service "ConcurrencyLimitEndpoint".
+func NewAEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(string)
+		return nil, s.A(ctx, p)
+	}
+}
+
+// NewBEndpoint returns an endpoint function that calls the method "B" of
+// service "ConcurrencyLimitEndpoint".
+func NewBEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, s.B(ctx)
+	}
+}
+`
+
+const TenantEndpoint = `// Endpoints wraps the "TenantEndpoint" service endpoints.
+type Endpoints struct {
+	A goa.Endpoint
+	B goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "TenantEndpoint" service with
+// endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	tenancy := &goa.Tenancy{Header: "X-Tenant-ID", Claim: "tenant_id"}
+	return &Endpoints{
+		A: goa.RequireTenant(tenancy)(NewAEndpoint(s)),
+		B: goa.LimitEndpoint(goa.NewLimiter(4, 0, 0))(goa.RequireTenant(tenancy)(NewBEndpoint(s))),
+	}
+}
+
+// Use applies the given middleware to all the "TenantEndpoint" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+	e.B = m(e.B)
+}
+
+// UseTenantValidator applies v to the tenant of the requests handled by the
+// "TenantEndpoint" service endpoints. The endpoints return the error returned
+// by v if any.
+func (e *Endpoints) UseTenantValidator(v goa.TenantValidator) {
+	e.Use(goa.ValidateTenant(&goa.Tenancy{Header: "X-Tenant-ID", Claim: "tenant_id"}, v))
+}
+
+// NewAEndpoint returns an endpoint function that calls the method "A" of
+// service "TenantEndpoint".
+func NewAEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(string)
+		return nil, s.A(ctx, p)
+	}
+}
+
+// NewBEndpoint returns an endpoint function that calls the method "B" of
+// service "TenantEndpoint".
+func NewBEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, s.B(ctx)
+	}
+}
+`
+
+const CacheEndpoint = `// Endpoints wraps the "CacheEndpoint" service endpoints.
+type Endpoints struct {
+	Show  goa.Endpoint
+	Count goa.Endpoint
+	cache *goa.ResultCache
+}
+
+// NewEndpoints wraps the methods of the "CacheEndpoint" service with endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	// Casting service to Auther interface
+	a := s.(Auther)
+	cache := &goa.ResultCache{}
+	return &Endpoints{
+		Show:  NewShowEndpoint(s, a.JWTAuth, cache),
+		Count: NewCountEndpoint(s, cache),
+		cache: cache,
+	}
+}
+
+// Use applies the given middleware to all the "CacheEndpoint" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.Show = m(e.Show)
+	e.Count = m(e.Count)
+}
+
+// UseCache memoizes the results of the cached methods of the "CacheEndpoint"
+// service in store. The results are cached once the requests are authenticated
+// and authorized, per principal and granted scopes for the secured methods. It
+// must be called before the endpoints are mounted on the transport servers.
+func (e *Endpoints) UseCache(store goa.CacheStore) {
+	e.cache.Store = store
+}
+
+// NewShowEndpoint returns an endpoint function that calls the method "Show" of
+// service "CacheEndpoint".
+func NewShowEndpoint(s Service, authJWTFn security.AuthJWTFunc, cache *goa.ResultCache) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*ShowPayload)
+		var (
+			err  error
+			aerr security.AuthError
+		)
+		sc := security.JWTScheme{
+			Name:           "jwt",
+			Scopes:         []string{"api:read"},
+			RequiredScopes: []string{"api:read"},
+		}
+		var token string
+		if p.Token != nil {
+			token = *p.Token
+		}
+		ctx, err = authJWTFn(ctx, token, &sc)
+		aerr.Record("jwt", "JWT", sc.RequiredScopes, err)
+		if err != nil {
+			return nil, aerr.Err()
+		}
+		granted, _ := security.ContextScopes(ctx)
+		caller := []interface{}{audit.Principal(ctx), granted}
+		return cache.Do(ctx, goa.CacheKey("CacheEndpoint", "Show", p.ID, p.Token), 5*time.Minute, caller, func() (interface{}, error) {
+			return s.Show(ctx, p)
+		})
+	}
+}
+
+// NewCountEndpoint returns an endpoint function that calls the method "Count"
+// of service "CacheEndpoint".
+func NewCountEndpoint(s Service, cache *goa.ResultCache) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		return cache.Do(ctx, goa.CacheKey("CacheEndpoint", "Count"), time.Hour, nil, func() (interface{}, error) {
+			return s.Count(ctx)
+		})
+	}
+}
+`
+
+const WildcardScopesEndpoint = `// Endpoints wraps the "WildcardScopesEndpoint" service endpoints.
+type Endpoints struct {
+	Show goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "WildcardScopesEndpoint" service with
+// endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	// Casting service to Auther interface
+	a := s.(Auther)
+	return &Endpoints{
+		Show: NewShowEndpoint(s, a.JWTAuth),
+	}
+}
+
+// Use applies the given middleware to all the "WildcardScopesEndpoint" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.Show = m(e.Show)
+}
+
+// NewShowEndpoint returns an endpoint function that calls the method "Show" of
+// service "WildcardScopesEndpoint".
+func NewShowEndpoint(s Service, authJWTFn security.AuthJWTFunc) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*ShowPayload)
+		var (
+			err  error
+			aerr security.AuthError
+		)
+		ctx = security.WithScopeWildcards(ctx)
+		sc := security.JWTScheme{
+			Name:           "jwt",
+			Scopes:         []string{"orders:*", "orders:read"},
+			RequiredScopes: []string{"orders:read"},
+			WildcardScopes: true,
+		}
+		var token string
+		if p.Token != nil {
+			token = *p.Token
+		}
+		ctx, err = authJWTFn(ctx, token, &sc)
+		aerr.Record("jwt", "JWT", sc.RequiredScopes, err)
+		if err != nil {
+			return nil, aerr.Err()
+		}
+		return nil, s.Show(ctx, p)
+	}
+}
+`
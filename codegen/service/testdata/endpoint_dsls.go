@@ -158,3 +158,130 @@ var BidirectionalStreamingEndpointDSL = func() {
 		})
 	})
 }
+
+var MTLSEndpointDSL = func() {
+	var MTLS = MTLSSecurity("mtls", func() {
+		Scope("payments")
+	})
+	var JWT = JWTSecurity("jwt")
+	Service("MTLSEndpoint", func() {
+		Method("Required", func() {
+			Security(MTLS, func() {
+				Scope("payments")
+			})
+			Payload(func() {
+				ClientCertificate("cert", String)
+				Required("cert")
+			})
+		})
+		Method("Optional", func() {
+			Security(JWT, MTLS)
+			Payload(func() {
+				Token("token", String)
+				ClientCertificate("cert", String)
+			})
+		})
+	})
+}
+
+var SignatureEndpointDSL = func() {
+	var Signed = SignatureSecurity("signed", func() {
+		Scope("orders:write")
+	})
+	Service("SignatureEndpoint", func() {
+		Method("Signed", func() {
+			Security(Signed, func() {
+				Scope("orders:write")
+			})
+		})
+	})
+}
+
+var SecurityChainEndpointDSL = func() {
+	var JWT = JWTSecurity("jwt")
+	var Key = APIKeySecurity("api_key")
+	var Signed = SignatureSecurity("signed")
+	Service("SecurityChainEndpoint", func() {
+		Method("Chained", func() {
+			Security(JWT)
+			Security(Key)
+			Security(Signed)
+			Optional()
+			Payload(func() {
+				Token("token", String)
+				APIKey("api_key", "key", String)
+			})
+		})
+	})
+}
+
+var ConcurrencyLimitEndpointDSL = func() {
+	Service("ConcurrencyLimitEndpoint", func() {
+		ConcurrencyLimit(100)
+		Method("A", func() {
+			Payload(String)
+		})
+		Method("B", func() {
+			ConcurrencyLimit(4, func() {
+				Queue(16, "2s")
+			})
+		})
+	})
+}
+
+var TenantEndpointDSL = func() {
+	API("TenantAPI", func() {
+		Tenant(func() {
+			Claim("tenant_id")
+		})
+	})
+	Service("TenantEndpoint", func() {
+		Method("A", func() {
+			Payload(String)
+		})
+		Method("B", func() {
+			ConcurrencyLimit(4)
+		})
+	})
+}
+
+var CacheEndpointDSL = func() {
+	var JWT = JWTSecurity("jwt", func() {
+		Scope("api:read")
+	})
+	Service("CacheEndpoint", func() {
+		Method("Show", func() {
+			Security(JWT, func() {
+				Scope("api:read")
+			})
+			Payload(func() {
+				Token("token", String)
+				Attribute("id", String)
+			})
+			Result(String)
+			Cache("5m", CacheKey("id", "token"))
+		})
+		Method("Count", func() {
+			Result(Int)
+			Cache("1h")
+		})
+	})
+}
+
+var WildcardScopesEndpointDSL = func() {
+	var JWT = JWTSecurity("jwt", func() {
+		WildcardScopes()
+		Scope("orders:*")
+		Scope("orders:read")
+	})
+	Service("WildcardScopesEndpoint", func() {
+		Method("Show", func() {
+			Security(JWT, func() {
+				Scope("orders:read")
+			})
+			Payload(func() {
+				Token("token", String)
+			})
+		})
+	})
+}
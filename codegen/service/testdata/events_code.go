@@ -0,0 +1,62 @@
+package testdata
+
+const EventsCode = `// Publisher publishes the events emitted by the "Orders" service methods.
+type Publisher interface {
+	// PublishOrderCreated publishes the "order.created" event. Order was created
+	PublishOrderCreated(context.Context, *OrderEvent) error
+	// PublishOrderCancelled publishes the "order.cancelled" event.
+	PublishOrderCancelled(context.Context, *OrderEvent) error
+}
+
+// NewPublisher returns a Publisher that publishes the events with p on the
+// topics defined in the design.
+func NewPublisher(p goa.EventPublisher) Publisher {
+	return &publisher{p: p}
+}
+
+// publisher implements Publisher with a goa.EventPublisher.
+type publisher struct {
+	p goa.EventPublisher
+}
+
+// PublishOrderCreated publishes ev on the "order.created" topic.
+func (p *publisher) PublishOrderCreated(ctx context.Context, ev *OrderEvent) error {
+	return p.p.Publish(ctx, "order.created", ev)
+}
+
+// PublishOrderCancelled publishes ev on the "order.cancelled" topic.
+func (p *publisher) PublishOrderCancelled(ctx context.Context, ev *OrderEvent) error {
+	return p.p.Publish(ctx, "order.cancelled", ev)
+}
+
+// EmitOrderCreated records the "order.created" event so that it is published
+// once the method completes successfully. It returns an error if the endpoint
+// does not publish events, see UsePublisher.
+func EmitOrderCreated(ctx context.Context, ev *OrderEvent) error {
+	return goa.EmitEvent(ctx, "order.created", ev)
+}
+
+// EmitOrderCancelled records the "order.cancelled" event so that it is
+// published once the method completes successfully. It returns an error if the
+// endpoint does not publish events, see UsePublisher.
+func EmitOrderCancelled(ctx context.Context, ev *OrderEvent) error {
+	return goa.EmitEvent(ctx, "order.cancelled", ev)
+}
+
+// UsePublisher wraps the endpoints of the "Orders" service methods that emit
+// events with a middleware that publishes the emitted events with p once the
+// methods complete successfully.
+func (e *Endpoints) UsePublisher(p Publisher) {
+	m := goa.PublishEvents(goa.EventPublisherFunc(func(ctx context.Context, topic string, ev interface{}) error {
+		switch topic {
+		case "order.created":
+			return p.PublishOrderCreated(ctx, ev.(*OrderEvent))
+		case "order.cancelled":
+			return p.PublishOrderCancelled(ctx, ev.(*OrderEvent))
+		}
+		return fmt.Errorf("unknown event topic %q", topic)
+	}))
+	e.Create = m(e.Create)
+	e.Cancel = m(e.Cancel)
+}
+`
@@ -0,0 +1,44 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var EventsDSL = func() {
+	var OrderEvent = Type("OrderEvent", func() {
+		Attribute("id", String)
+		Attribute("total", Float64)
+		Required("id")
+	})
+	Service("Orders", func() {
+		Method("Create", func() {
+			Payload(String)
+			Emits("order.created", OrderEvent, "Order was created")
+			HTTP(func() {
+				POST("/orders")
+			})
+		})
+		Method("Cancel", func() {
+			Payload(String)
+			Emits("order.cancelled", OrderEvent)
+			HTTP(func() {
+				DELETE("/orders")
+			})
+		})
+		Method("List", func() {
+			HTTP(func() {
+				GET("/orders")
+			})
+		})
+	})
+}
+
+var EventsNoneDSL = func() {
+	Service("Orders", func() {
+		Method("List", func() {
+			HTTP(func() {
+				GET("/orders")
+			})
+		})
+	})
+}
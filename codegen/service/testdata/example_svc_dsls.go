@@ -21,3 +21,21 @@ var ConflictWithGoifiedAPINameAndServiceNamesDSL = func() {
 	var _ = Service("good-by-api", func() {})   // API name + 'api' suffix
 	var _ = Service("good-by-api-1", func() {}) // API name + 'api' suffix + sequential no.
 }
+
+var ScaffoldDSL = func() {
+	var _ = API("calc", func() {})
+	var _ = Service("calc", func() {
+		Meta("scaffold")
+		Method("add", func() {
+			Payload(func() {
+				Attribute("a", Int)
+				Attribute("b", Int)
+			})
+			Result(Int)
+		})
+		Method("reset", func() {})
+	})
+	var _ = Service("other", func() {
+		Method("get", func() {})
+	})
+}
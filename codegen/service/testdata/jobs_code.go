@@ -0,0 +1,43 @@
+package testdata
+
+const JobsCode = `// StartJob starts a job that runs fn with jobs and returns the status of the
+// pending job. The async methods of the "Reports" service return the status so
+// that the HTTP servers respond with 202 Accepted and the path of the job
+// status in the Location header.
+func StartJob(ctx context.Context, jobs *goa.Jobs, fn goa.JobFunc) (*JobStatus, error) {
+	j, err := jobs.Start(ctx, fn)
+	if err != nil {
+		return nil, err
+	}
+	return NewJobStatus(j), nil
+}
+
+// LoadJobStatus returns the status of the job with the given ID recorded by
+// jobs. It returns a "job_not_found" error if there is no such job. The
+// "get_job_status" method of the "Reports" service may be implemented with it.
+func LoadJobStatus(ctx context.Context, jobs *goa.Jobs, id string) (*JobStatus, error) {
+	j, err := jobs.Status(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if j == nil {
+		return nil, MakeJobNotFound(fmt.Errorf("job %q not found", id))
+	}
+	return NewJobStatus(j), nil
+}
+
+// NewJobStatus converts j into the job status returned by the async methods.
+func NewJobStatus(j *goa.Job) *JobStatus {
+	res := &JobStatus{
+		ID:        j.ID,
+		Status:    j.State,
+		Result:    j.Result,
+		CreatedAt: j.Created.Format(time.RFC3339),
+		UpdatedAt: j.Updated.Format(time.RFC3339),
+	}
+	if j.Error != "" {
+		res.Error = &j.Error
+	}
+	return res
+}
+`
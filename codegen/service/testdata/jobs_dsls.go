@@ -0,0 +1,36 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var JobsDSL = func() {
+	Service("Reports", func() {
+		Method("Generate", func() {
+			Payload(func() {
+				Attribute("query", String)
+			})
+			Async()
+			HTTP(func() {
+				POST("/reports")
+			})
+		})
+		Method("Export", func() {
+			Async()
+			HTTP(func() {
+				POST("/exports")
+			})
+		})
+	})
+}
+
+var JobsNoneDSL = func() {
+	Service("Reports", func() {
+		Method("Generate", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/reports")
+			})
+		})
+	})
+}
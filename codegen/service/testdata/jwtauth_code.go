@@ -0,0 +1,51 @@
+package testdata
+
+const JWTAuthCode = `// Auth implements the JWT security schemes of the "JWTAuth" service by
+// validating the tokens with the keys of the JSON Web Key Sets defined in the
+// design.
+type Auth struct {
+	// Validators indexes the token validators by security scheme name.
+	Validators map[string]*jwt.Validator
+}
+
+// New returns the JWT authorization of the service. client is used to retrieve
+// the JSON Web Key Sets, http.DefaultClient is used if nil.
+func New(client *http.Client) *Auth {
+	return &Auth{
+		Validators: map[string]*jwt.Validator{
+			"jwks": {
+				Keys:     jwt.NewKeySet("https://auth.goa.design/.well-known/jwks.json", client),
+				Issuer:   "https://auth.goa.design/",
+				Audience: "https://api.goa.design",
+			},
+			"partner": {
+				Keys: jwt.NewKeySet("https://partner.goa.design/jwks.json", client),
+			},
+		},
+	}
+}
+
+// JWTAuth validates the token, checks that it grants the scopes required by the
+// method and returns a context that contains the token claims, see Claims, and
+// the granted scopes, see security.ContextScopes.
+// JWTAuth implements the JWTAuth method of the service.
+func (a *Auth) JWTAuth(ctx context.Context, token string, scheme *security.JWTScheme) (context.Context, error) {
+	v, ok := a.Validators[scheme.Name]
+	if !ok {
+		return ctx, fmt.Errorf("no validator for security scheme %q", scheme.Name)
+	}
+	claims, err := v.Validate(ctx, token)
+	if err != nil {
+		return ctx, goa.PermanentError("unauthorized", "%s", err)
+	}
+	if err := scheme.Validate(claims.Scopes); err != nil {
+		return ctx, goa.PermanentError("forbidden", "%s", err)
+	}
+	return security.WithScopes(jwt.WithClaims(ctx, claims), claims.Scopes...), nil
+}
+
+// Claims returns the claims of the token validated by JWTAuth.
+func Claims(ctx context.Context) (*jwt.Claims, bool) {
+	return jwt.ContextClaims(ctx)
+}
+`
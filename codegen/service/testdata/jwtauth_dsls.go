@@ -0,0 +1,47 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var JWTAuthDSL = func() {
+	var JWKSAuth = JWTSecurity("jwks", func() {
+		Meta("jwtauth:jwks", "https://auth.goa.design/.well-known/jwks.json")
+		Meta("jwtauth:issuer", "https://auth.goa.design/")
+		Meta("jwtauth:audience", "https://api.goa.design")
+		Scope("orders:*", "Full access to orders")
+		Scope("orders:read", "Read orders")
+	})
+	var PartnerAuth = JWTSecurity("partner", func() {
+		Meta("jwtauth:jwks", "https://partner.goa.design/jwks.json")
+	})
+	Service("JWTAuth", func() {
+		Method("Read", func() {
+			Security(JWKSAuth, func() {
+				Scope("orders:read")
+			})
+			Payload(func() {
+				Token("token", String)
+			})
+		})
+		Method("Partner", func() {
+			Security(PartnerAuth, JWKSAuth)
+			Payload(func() {
+				Token("token", String)
+			})
+		})
+		Method("Unsecure", func() {})
+	})
+}
+
+var JWTAuthNoJWKSDSL = func() {
+	var JWTAuth = JWTSecurity("jwt")
+	Service("JWTAuthNoJWKS", func() {
+		Method("Read", func() {
+			Security(JWTAuth)
+			Payload(func() {
+				Token("token", String)
+			})
+		})
+	})
+}
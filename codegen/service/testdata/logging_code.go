@@ -0,0 +1,152 @@
+package testdata
+
+const LoggingCode = `// Wrap wraps the endpoints of the "LoggedOrders" service with endpoints that
+// write a log record for each request with logger. The records have the
+// message "request" and the "service", "method", logged payload attributes,
+// "duration" and "error" attributes. The context given to the service methods
+// carries a logger with the same request attributes, see Logger. It must be
+// called before the endpoints are mounted on the transport servers.
+func Wrap(e *loggedorders.Endpoints, logger *slog.Logger) {
+	e.Create = endpoint(logger, "Create", "/orders/{id}", CreateAttrs, e.Create)
+	e.List = endpoint(logger, "List", "/orders", nil, e.List)
+}
+
+// Handler returns a HTTP middleware that writes a log record for each request
+// served by the "LoggedOrders" service HTTP server in place of the record
+// written by the endpoints wrapped with Wrap. The record also has the "route"
+// and "status" attributes and is written at the error level if the status code
+// is 5xx. Use it with the Use method of the server.
+func Handler(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := &record{}
+			start := time.Now()
+			rw := httpmdlwr.CaptureResponse(w)
+			h.ServeHTTP(rw, r.WithContext(context.WithValue(r.Context(), recordKey, rec)))
+			status := rw.StatusCode
+			if status == 0 {
+				status = http.StatusOK
+			}
+			attrs := append([]slog.Attr{slog.String("service", "LoggedOrders")}, rec.attrs...)
+			attrs = append(attrs, slog.Int("status", status), slog.Duration("duration", time.Since(start)))
+			if rec.err != nil {
+				attrs = append(attrs, slog.String("error", rec.err.Error()))
+			}
+			level := slog.LevelInfo
+			if status >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+			logger.LogAttrs(r.Context(), level, "request", attrs...)
+		})
+	}
+}
+
+// endpoint returns an endpoint that gives a logger with the request attributes
+// to e and writes the log record of the request, or adds the attributes to the
+// record written by Handler.
+func endpoint(logger *slog.Logger, method, route string, fields func(interface{}) []slog.Attr, e goa.Endpoint) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		attrs := []slog.Attr{slog.String("service", "LoggedOrders"), slog.String("method", method)}
+		if fields != nil {
+			attrs = append(attrs, fields(req)...)
+		}
+		args := make([]interface{}, len(attrs))
+		for i, a := range attrs {
+			args[i] = a
+		}
+		ctx = WithLogger(ctx, logger.With(args...))
+		start := time.Now()
+		res, err := e(ctx, req)
+		if rec, ok := ctx.Value(recordKey).(*record); ok {
+			rec.attrs = append(rec.attrs, attrs[1])
+			if route != "" {
+				rec.attrs = append(rec.attrs, slog.String("route", route))
+			}
+			rec.attrs = append(rec.attrs, attrs[2:]...)
+			rec.err = err
+			return res, err
+		}
+		attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+		level := slog.LevelInfo
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+			level = slog.LevelError
+		}
+		logger.LogAttrs(ctx, level, "request", attrs...)
+		return res, err
+	}
+}
+
+// CreateAttrs returns the payload attributes added to the log records of the
+// requests made to the "Create" method.
+func CreateAttrs(v interface{}) []slog.Attr {
+	p := v.(*loggedorders.CreatePayload)
+	var attrs []slog.Attr
+	attrs = append(attrs, slog.Any("order_id", p.ID))
+	if p.Count != nil {
+		attrs = append(attrs, slog.Any("count", *p.Count))
+	}
+	if p.Token != nil {
+		attrs = append(attrs, slog.Any("token", RedactedValue))
+	}
+	return attrs
+}
+`
+
+const LoggingStreamingCode = `// Wrap wraps the endpoints of the "LoggingStreaming" service with endpoints
+// that write a log record for each request with logger. The records have the
+// message "request" and the "service", "method", logged payload attributes,
+// "duration" and "error" attributes. The context given to the service methods
+// carries a logger with the same request attributes, see Logger. It must be
+// called before the endpoints are mounted on the transport servers.
+func Wrap(e *loggingstreaming.Endpoints, logger *slog.Logger) {
+	e.Watch = endpoint(logger, "Watch", "", WatchAttrs, e.Watch)
+}
+
+// endpoint returns an endpoint that gives a logger with the request attributes
+// to e and writes the log record of the request, or adds the attributes to the
+// record written by Handler.
+func endpoint(logger *slog.Logger, method, route string, fields func(interface{}) []slog.Attr, e goa.Endpoint) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		attrs := []slog.Attr{slog.String("service", "LoggingStreaming"), slog.String("method", method)}
+		if fields != nil {
+			attrs = append(attrs, fields(req)...)
+		}
+		args := make([]interface{}, len(attrs))
+		for i, a := range attrs {
+			args[i] = a
+		}
+		ctx = WithLogger(ctx, logger.With(args...))
+		start := time.Now()
+		res, err := e(ctx, req)
+		if rec, ok := ctx.Value(recordKey).(*record); ok {
+			rec.attrs = append(rec.attrs, attrs[1])
+			if route != "" {
+				rec.attrs = append(rec.attrs, slog.String("route", route))
+			}
+			rec.attrs = append(rec.attrs, attrs[2:]...)
+			rec.err = err
+			return res, err
+		}
+		attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+		level := slog.LevelInfo
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+			level = slog.LevelError
+		}
+		logger.LogAttrs(ctx, level, "request", attrs...)
+		return res, err
+	}
+}
+
+// WatchAttrs returns the payload attributes added to the log records of the
+// requests made to the "Watch" method.
+func WatchAttrs(v interface{}) []slog.Attr {
+	p := v.(*loggingstreaming.WatchEndpointInput).Payload
+	var attrs []slog.Attr
+	if p.Topic != nil {
+		attrs = append(attrs, slog.Any("topic", *p.Topic))
+	}
+	return attrs
+}
+`
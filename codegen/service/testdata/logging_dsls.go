@@ -0,0 +1,59 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var LoggingDSL = func() {
+	Service("LoggedOrders", func() {
+		Meta("log:slog")
+		Method("Create", func() {
+			Payload(func() {
+				Attribute("id", String, func() {
+					Meta("log:field", "order_id")
+				})
+				Attribute("count", Int, func() {
+					Meta("log:field")
+				})
+				Attribute("token", String, func() {
+					Meta("log:field")
+					Meta("log:redact")
+				})
+				Attribute("note", String)
+				Required("id")
+			})
+			HTTP(func() {
+				POST("/orders/{id}")
+			})
+		})
+		Method("List", func() {
+			HTTP(func() {
+				GET("/orders")
+			})
+		})
+	})
+}
+
+var LoggingStreamingDSL = func() {
+	Service("LoggingStreaming", func() {
+		Meta("log:slog")
+		Method("Watch", func() {
+			Payload(func() {
+				Attribute("topic", String, func() {
+					Meta("log:field")
+				})
+			})
+			StreamingResult(String)
+		})
+	})
+}
+
+var LoggingNoneDSL = func() {
+	API("LoggingNone", func() {
+		Meta("log:slog")
+	})
+	Service("LoggingNone", func() {
+		Meta("log:slog", "false")
+		Method("Read", func() {})
+	})
+}
@@ -0,0 +1,350 @@
+package testdata
+
+const MockCode = `// Mock is a mock implementation of the "MockService" service for testing the
+// consumers of the service. Each method calls the corresponding function field
+// if set and returns the example defined in the design otherwise. The mock
+// records the calls made to its methods, see Calls.
+type Mock struct {
+	// ShowFunc implements Show if not nil.
+	ShowFunc func(context.Context, int) (*mockservicesvc.Bottle, string, error)
+	// AddFunc implements Add if not nil.
+	AddFunc func(context.Context, *mockservicesvc.AddPayload) (string, error)
+	// PingFunc implements Ping if not nil.
+	PingFunc func(context.Context) error
+	// BasicAuthFunc implements BasicAuth if not nil, BasicAuth accepts all the
+	// credentials otherwise.
+	BasicAuthFunc func(context.Context, string, string, *security.BasicScheme) (context.Context, error)
+
+	mu    sync.Mutex
+	calls []*Call
+}
+
+// Call describes a call made to a method of the mock.
+type Call struct {
+	// Method is the name of the method as defined in the design.
+	Method string
+	// Payload is the method payload, nil if the method has no payload.
+	Payload interface{}
+}
+
+// New returns a mock that returns the examples defined in the design.
+func New() *Mock {
+	return &Mock{}
+}
+
+// Calls returns the calls made to the mock methods in order.
+func (m *Mock) Calls() []*Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*Call(nil), m.calls...)
+}
+
+// Reset clears the calls recorded by the mock.
+func (m *Mock) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+}
+
+// record records a call made to the given method.
+func (m *Mock) record(method string, payload interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, &Call{Method: method, Payload: payload})
+}
+
+var _ mockservicesvc.Service = (*Mock)(nil)
+
+// Show calls ShowFunc if not nil and returns the result of ShowResult
+// otherwise.
+func (m *Mock) Show(ctx context.Context, p int) (*mockservicesvc.Bottle, string, error) {
+	m.record("Show", p)
+	if m.ShowFunc != nil {
+		return m.ShowFunc(ctx, p)
+	}
+	return ShowResult(), "default", nil
+}
+
+// Add calls AddFunc if not nil and returns the result of AddResult otherwise.
+func (m *Mock) Add(ctx context.Context, p *mockservicesvc.AddPayload) (string, error) {
+	m.record("Add", p)
+	if m.AddFunc != nil {
+		return m.AddFunc(ctx, p)
+	}
+	return AddResult(), nil
+}
+
+// Ping calls PingFunc if not nil and returns nil otherwise.
+func (m *Mock) Ping(ctx context.Context) error {
+	m.record("Ping", nil)
+	if m.PingFunc != nil {
+		return m.PingFunc(ctx)
+	}
+	return nil
+}
+
+// BasicAuth calls BasicAuthFunc if not nil and accepts all the credentials
+// otherwise.
+func (m *Mock) BasicAuth(ctx context.Context, user, pass string, scheme *security.BasicScheme) (context.Context, error) {
+	if m.BasicAuthFunc != nil {
+		return m.BasicAuthFunc(ctx, user, pass, scheme)
+	}
+	return ctx, nil
+}
+
+// ShowResult returns the example result of the "Show" method defined in the
+// design.
+func ShowResult() *mockservicesvc.Bottle {
+	return &mockservicesvc.Bottle{
+		ID:   42,
+		Name: "Blue's",
+		Tags: []string{"red", "dry"},
+	}
+}
+
+// AddResult returns the example result of the "Add" method defined in the
+// design.
+func AddResult() string {
+	return "a1"
+}
+`
+
+const MockStreamingCode = `// Mock is a mock implementation of the "MockStreamingService" service for
+// testing the consumers of the service. Each method calls the corresponding
+// function field if set and returns the example defined in the design
+// otherwise. The mock records the calls made to its methods, see Calls.
+type Mock struct {
+	// WatchFunc implements Watch if not nil.
+	WatchFunc func(context.Context, mockstreamingservicesvc.WatchServerStream) error
+	// SumFunc implements Sum if not nil.
+	SumFunc func(context.Context, mockstreamingservicesvc.SumServerStream) error
+	// EchoFunc implements Echo if not nil.
+	EchoFunc func(context.Context, mockstreamingservicesvc.EchoServerStream) error
+
+	mu    sync.Mutex
+	calls []*Call
+}
+
+// Call describes a call made to a method of the mock.
+type Call struct {
+	// Method is the name of the method as defined in the design.
+	Method string
+	// Payload is the method payload, nil if the method has no payload.
+	Payload interface{}
+}
+
+// New returns a mock that returns the examples defined in the design.
+func New() *Mock {
+	return &Mock{}
+}
+
+// Calls returns the calls made to the mock methods in order.
+func (m *Mock) Calls() []*Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*Call(nil), m.calls...)
+}
+
+// Reset clears the calls recorded by the mock.
+func (m *Mock) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+}
+
+// record records a call made to the given method.
+func (m *Mock) record(method string, payload interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, &Call{Method: method, Payload: payload})
+}
+
+var _ mockstreamingservicesvc.Service = (*Mock)(nil)
+
+// Watch calls WatchFunc if not nil. Otherwise it sends the result of
+// WatchResult and closes the stream.
+func (m *Mock) Watch(ctx context.Context, stream mockstreamingservicesvc.WatchServerStream) error {
+	m.record("Watch", nil)
+	if m.WatchFunc != nil {
+		return m.WatchFunc(ctx, stream)
+	}
+	if err := stream.Send(WatchResult()); err != nil {
+		return err
+	}
+	return stream.Close()
+}
+
+// Sum calls SumFunc if not nil. Otherwise it receives the payloads until the
+// client closes the stream and then sends the result of SumResult.
+func (m *Mock) Sum(ctx context.Context, stream mockstreamingservicesvc.SumServerStream) error {
+	m.record("Sum", nil)
+	if m.SumFunc != nil {
+		return m.SumFunc(ctx, stream)
+	}
+	for {
+		_, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return stream.SendAndClose(SumResult())
+}
+
+// Echo calls EchoFunc if not nil. Otherwise it sends the result of EchoResult
+// for each payload received until the client closes the stream.
+func (m *Mock) Echo(ctx context.Context, stream mockstreamingservicesvc.EchoServerStream) error {
+	m.record("Echo", nil)
+	if m.EchoFunc != nil {
+		return m.EchoFunc(ctx, stream)
+	}
+	for {
+		_, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(EchoResult()); err != nil {
+			return err
+		}
+	}
+	return stream.Close()
+}
+
+// WatchResult returns the example result of the "Watch" method defined in the
+// design.
+func WatchResult() *mockstreamingservicesvc.Event {
+	return &mockstreamingservicesvc.Event{
+		Kind:  stringPtr("created"),
+		Ratio: float64Ptr(0.5),
+	}
+}
+
+// SumResult returns the example result of the "Sum" method defined in the
+// design.
+func SumResult() int {
+	return 3
+}
+
+// EchoResult returns the example result of the "Echo" method defined in the
+// design.
+func EchoResult() *mockstreamingservicesvc.Event {
+	return &mockstreamingservicesvc.Event{
+		Kind:  stringPtr("created"),
+		Ratio: float64Ptr(0.5),
+	}
+}
+
+// float64Ptr returns a pointer to v.
+func float64Ptr(v float64) *float64 {
+	return &v
+}
+
+// stringPtr returns a pointer to v.
+func stringPtr(v string) *string {
+	return &v
+}
+`
+
+const MockServerCode = `// The stub server serves the mocks of the test api API services, the endpoints
+// return the examples defined in the design. Use the -http-addr flag to
+// configure the listen addresses of the servers.
+func main() {
+	var (
+		httpAddr = flag.String("http-addr", ":8080", "HTTP listen address, empty to disable the HTTP server")
+	)
+	flag.Parse()
+
+	var (
+		mockServerAEndpoints = mockservera.NewEndpoints(mockserveramock.New())
+		mockServerBEndpoints = mockserverb.NewEndpoints(mockserverbmock.New())
+	)
+	errc := make(chan error, 2)
+	if *httpAddr != "" {
+		go func() {
+			errc <- serveHTTP(*httpAddr, mockServerAEndpoints, mockServerBEndpoints)
+		}()
+	}
+	log.Fatal(<-errc)
+}
+`
+
+const MockCollisionCode = `// Mock is a mock implementation of the "MockCollisionService" service for
+// testing the consumers of the service. Each method calls the corresponding
+// function field if set and returns the example defined in the design
+// otherwise. The mock records the calls made to its methods, see CallsMade.
+type Mock struct {
+	// CallsFunc implements Calls if not nil.
+	CallsFunc func(context.Context) (int, error)
+	// ResetFunc implements Reset if not nil.
+	ResetFunc func(context.Context) error
+
+	mu    sync.Mutex
+	calls []*Call
+}
+
+// Call describes a call made to a method of the mock.
+type Call struct {
+	// Method is the name of the method as defined in the design.
+	Method string
+	// Payload is the method payload, nil if the method has no payload.
+	Payload interface{}
+}
+
+// New returns a mock that returns the examples defined in the design.
+func New() *Mock {
+	return &Mock{}
+}
+
+// CallsMade returns the calls made to the mock methods in order.
+func (m *Mock) CallsMade() []*Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*Call(nil), m.calls...)
+}
+
+// ResetCalls clears the calls recorded by the mock.
+func (m *Mock) ResetCalls() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+}
+
+// record records a call made to the given method.
+func (m *Mock) record(method string, payload interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, &Call{Method: method, Payload: payload})
+}
+
+var _ mockcollisionservicesvc.Service = (*Mock)(nil)
+
+// Calls calls CallsFunc if not nil and returns the result of CallsResult
+// otherwise.
+func (m *Mock) Calls(ctx context.Context) (int, error) {
+	m.record("Calls", nil)
+	if m.CallsFunc != nil {
+		return m.CallsFunc(ctx)
+	}
+	return CallsResult(), nil
+}
+
+// Reset calls ResetFunc if not nil and returns nil otherwise.
+func (m *Mock) Reset(ctx context.Context) error {
+	m.record("Reset", nil)
+	if m.ResetFunc != nil {
+		return m.ResetFunc(ctx)
+	}
+	return nil
+}
+
+// CallsResult returns the example result of the "Calls" method defined in the
+// design.
+func CallsResult() int {
+	return 1
+}
+`
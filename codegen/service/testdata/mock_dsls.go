@@ -0,0 +1,112 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var MockDSL = func() {
+	var BasicAuth = BasicAuthSecurity("basic")
+	var Bottle = ResultType("application/vnd.mock.bottle", func() {
+		TypeName("Bottle")
+		Attributes(func() {
+			Attribute("id", Int, func() {
+				Example(42)
+			})
+			Attribute("name", String, func() {
+				Example("Blue's")
+			})
+			Attribute("tags", ArrayOf(String), func() {
+				Example([]string{"red", "dry"})
+			})
+			Required("id", "name")
+		})
+		View("default", func() {
+			Attribute("id")
+			Attribute("name")
+		})
+		View("tiny", func() {
+			Attribute("id")
+		})
+	})
+	Service("MockService", func() {
+		Method("Show", func() {
+			Payload(Int)
+			Result(Bottle)
+		})
+		Method("Add", func() {
+			Security(BasicAuth)
+			Payload(func() {
+				Username("user", String)
+				Password("pass", String)
+				Attribute("name", String)
+			})
+			Result(String, func() {
+				Example("a1")
+			})
+		})
+		Method("Ping", func() {})
+	})
+}
+
+var MockStreamingDSL = func() {
+	var Event = Type("Event", func() {
+		Attribute("kind", String, func() {
+			Example("created")
+		})
+		Attribute("ratio", Float64, func() {
+			Example(0.5)
+		})
+	})
+	Service("MockStreamingService", func() {
+		Method("Watch", func() {
+			StreamingResult(Event)
+		})
+		Method("Sum", func() {
+			StreamingPayload(Int)
+			Result(Int, func() {
+				Example(3)
+			})
+		})
+		Method("Echo", func() {
+			StreamingPayload(Event)
+			StreamingResult(Event)
+		})
+	})
+}
+
+var MockServerDSL = func() {
+	Service("MockServerA", func() {
+		Method("Ping", func() {
+			HTTP(func() {
+				GET("/a")
+			})
+		})
+	})
+	Service("MockServerB", func() {
+		Method("Ping", func() {
+			HTTP(func() {
+				GET("/b")
+			})
+		})
+	})
+	Service("MockServerNone", func() {
+		Method("Ping", func() {})
+	})
+}
+
+var MockServerNoneDSL = func() {
+	Service("MockServerUnserved", func() {
+		Method("Ping", func() {})
+	})
+}
+
+var MockCollisionDSL = func() {
+	Service("MockCollisionService", func() {
+		Method("Calls", func() {
+			Result(Int, func() {
+				Example(1)
+			})
+		})
+		Method("Reset", func() {})
+	})
+}
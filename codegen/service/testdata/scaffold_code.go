@@ -0,0 +1,13 @@
+package testdata
+
+const ScaffoldAddCode = `// Add implements add.
+func (s *calcsrvc) Add(ctx context.Context, p *calc.AddPayload) (res int, err error) {
+	// TODO: implement the "add" method.
+	s.logger.Print("calc.add")
+	return
+}
+
+// The "add" method is no longer defined in the design if
+// calc.NewAddEndpoint is undefined, delete this file.
+var _ = calc.NewAddEndpoint
+`
@@ -48,7 +48,10 @@ var EndpointWithRequiredScopesCode = `// NewSecureWithRequiredScopesEndpoint ret
 func NewSecureWithRequiredScopesEndpoint(s Service, authJWTFn security.AuthJWTFunc) goa.Endpoint {
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
 		p := req.(*SecureWithRequiredScopesPayload)
-		var err error
+		var (
+			err  error
+			aerr security.AuthError
+		)
 		sc := security.JWTScheme{
 			Name:           "jwt",
 			Scopes:         []string{"api:read", "api:write", "api:admin"},
@@ -59,8 +62,9 @@ func NewSecureWithRequiredScopesEndpoint(s Service, authJWTFn security.AuthJWTFu
 			token = *p.Token
 		}
 		ctx, err = authJWTFn(ctx, token, &sc)
+		aerr.Record("jwt", "JWT", sc.RequiredScopes, err)
 		if err != nil {
-			return nil, err
+			return nil, aerr.Err()
 		}
 		return nil, s.SecureWithRequiredScopes(ctx, p)
 	}
@@ -73,7 +77,10 @@ var EndpointWithOptionalRequiredScopesCode = `// NewSecureWithOptionalRequiredSc
 func NewSecureWithOptionalRequiredScopesEndpoint(s Service, authBasicFn security.AuthBasicFunc) goa.Endpoint {
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
 		p := req.(*SecureWithOptionalRequiredScopesPayload)
-		var err error
+		var (
+			err  error
+			aerr security.AuthError
+		)
 		sc := security.BasicScheme{
 			Name:           "basic",
 			Scopes:         []string{"api:read", "api:write", "api:admin"},
@@ -88,8 +95,9 @@ func NewSecureWithOptionalRequiredScopesEndpoint(s Service, authBasicFn security
 			pass = *p.Pass
 		}
 		ctx, err = authBasicFn(ctx, user, pass, &sc)
+		aerr.Record("basic", "Basic", sc.RequiredScopes, err)
 		if err != nil {
-			return nil, err
+			return nil, aerr.Err()
 		}
 		return nil, s.SecureWithOptionalRequiredScopes(ctx, p)
 	}
@@ -102,7 +110,10 @@ var EndpointWithAPIKeyOverrideCode = `// NewSecureWithAPIKeyOverrideEndpoint ret
 func NewSecureWithAPIKeyOverrideEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
 		p := req.(*SecureWithAPIKeyOverridePayload)
-		var err error
+		var (
+			err  error
+			aerr security.AuthError
+		)
 		sc := security.APIKeyScheme{
 			Name:           "api_key",
 			Scopes:         []string{"api:read", "api:write", "api:admin"},
@@ -113,8 +124,9 @@ func NewSecureWithAPIKeyOverrideEndpoint(s Service, authAPIKeyFn security.AuthAP
 			key = *p.Key
 		}
 		ctx, err = authAPIKeyFn(ctx, key, &sc)
+		aerr.Record("api_key", "APIKey", sc.RequiredScopes, err)
 		if err != nil {
-			return nil, err
+			return nil, aerr.Err()
 		}
 		return nil, s.SecureWithAPIKeyOverride(ctx, p)
 	}
@@ -126,7 +138,10 @@ var EndpointWithOAuth2Code = `// NewSecureWithOAuth2Endpoint returns an endpoint
 func NewSecureWithOAuth2Endpoint(s Service, authOAuth2Fn security.AuthOAuth2Func) goa.Endpoint {
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
 		p := req.(*SecureWithOAuth2Payload)
-		var err error
+		var (
+			err  error
+			aerr security.AuthError
+		)
 		sc := security.OAuth2Scheme{
 			Name:           "authCode",
 			Scopes:         []string{"api:write", "api:read"},
@@ -145,8 +160,9 @@ func NewSecureWithOAuth2Endpoint(s Service, authOAuth2Fn security.AuthOAuth2Func
 			token = *p.Token
 		}
 		ctx, err = authOAuth2Fn(ctx, token, &sc)
+		aerr.Record("authCode", "OAuth2", sc.RequiredScopes, err)
 		if err != nil {
-			return nil, err
+			return nil, aerr.Err()
 		}
 		return nil, s.SecureWithOAuth2(ctx, p)
 	}
@@ -2195,3 +2195,185 @@ func newMultipleViewsViewTiny(res *MultipleViews) *bidirectionalstreamingresultw
 	return vres
 }
 `
+
+const ResultFilter = `
+// Service is the ResultFilter service interface.
+type Service interface {
+	// Show implements Show.
+	// The "view" return value must have one of the following views
+	//	- "default"
+	//	- "tiny"
+	Show(context.Context) (res *Employee, view string, err error)
+	// List implements List.
+	List(context.Context) (res map[string]*Contact, err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "ResultFilter"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [2]string{"Show", "List"}
+
+// Employee is the result type of the ResultFilter service Show method.
+type Employee struct {
+	Name     string
+	Ssn      *string
+	Salary   *int
+	Contacts []*Contact
+}
+
+type Contact struct {
+	Email *string
+	Phone *string
+}
+
+// NewEmployee initializes result type Employee from viewed result type
+// Employee.
+func NewEmployee(vres *resultfilterviews.Employee) *Employee {
+	var res *Employee
+	switch vres.View {
+	case "default", "":
+		res = newEmployee(vres.Projected)
+	case "tiny":
+		res = newEmployeeTiny(vres.Projected)
+	}
+	return res
+}
+
+// NewViewedEmployee initializes viewed result type Employee from result type
+// Employee using the given view.
+func NewViewedEmployee(res *Employee, view string) *resultfilterviews.Employee {
+	var vres *resultfilterviews.Employee
+	switch view {
+	case "default", "":
+		p := newEmployeeView(res)
+		vres = &resultfilterviews.Employee{p, "default"}
+	case "tiny":
+		p := newEmployeeViewTiny(res)
+		vres = &resultfilterviews.Employee{p, "tiny"}
+	}
+	return vres
+}
+
+// filterEmployee sets the attributes of Employee that require security scopes
+// not granted to the caller to their zero value, see security.HasScopes.
+func filterEmployee(ctx context.Context, v *Employee) {
+	if v == nil {
+		return
+	}
+	if !security.HasScopes(ctx, "pii:read") {
+		v.Ssn = nil
+	}
+	if !security.HasScopes(ctx, "payroll:read", "pii:read") {
+		v.Salary = nil
+	}
+	for _, e1 := range v.Contacts {
+		filterContact(ctx, e1)
+	}
+}
+
+// filterContact sets the attributes of Contact that require security scopes
+// not granted to the caller to their zero value, see security.HasScopes.
+func filterContact(ctx context.Context, v *Contact) {
+	if v == nil {
+		return
+	}
+	if !security.HasScopes(ctx, "pii:read") {
+		v.Phone = nil
+	}
+}
+
+// filterListResult sets the attributes of the List method result that require
+// security scopes not granted to the caller to their zero value, see
+// security.HasScopes.
+func filterListResult(ctx context.Context, v map[string]*Contact) {
+	for _, e0 := range v {
+		filterContact(ctx, e0)
+	}
+}
+
+// newEmployee converts projected type Employee to service type Employee.
+func newEmployee(vres *resultfilterviews.EmployeeView) *Employee {
+	res := &Employee{
+		Ssn:    vres.Ssn,
+		Salary: vres.Salary,
+	}
+	if vres.Name != nil {
+		res.Name = *vres.Name
+	}
+	if vres.Contacts != nil {
+		res.Contacts = make([]*Contact, len(vres.Contacts))
+		for i, val := range vres.Contacts {
+			res.Contacts[i] = transformResultfilterviewsContactViewToContact(val)
+		}
+	}
+	return res
+}
+
+// newEmployeeTiny converts projected type Employee to service type Employee.
+func newEmployeeTiny(vres *resultfilterviews.EmployeeView) *Employee {
+	res := &Employee{}
+	if vres.Name != nil {
+		res.Name = *vres.Name
+	}
+	return res
+}
+
+// newEmployeeView projects result type Employee to projected type EmployeeView
+// using the "default" view.
+func newEmployeeView(res *Employee) *resultfilterviews.EmployeeView {
+	vres := &resultfilterviews.EmployeeView{
+		Name:   &res.Name,
+		Ssn:    res.Ssn,
+		Salary: res.Salary,
+	}
+	if res.Contacts != nil {
+		vres.Contacts = make([]*resultfilterviews.ContactView, len(res.Contacts))
+		for i, val := range res.Contacts {
+			vres.Contacts[i] = transformContactToResultfilterviewsContactView(val)
+		}
+	}
+	return vres
+}
+
+// newEmployeeViewTiny projects result type Employee to projected type
+// EmployeeView using the "tiny" view.
+func newEmployeeViewTiny(res *Employee) *resultfilterviews.EmployeeView {
+	vres := &resultfilterviews.EmployeeView{
+		Name: &res.Name,
+	}
+	return vres
+}
+
+// transformResultfilterviewsContactViewToContact builds a value of type
+// *Contact from a value of type *resultfilterviews.ContactView.
+func transformResultfilterviewsContactViewToContact(v *resultfilterviews.ContactView) *Contact {
+	if v == nil {
+		return nil
+	}
+	res := &Contact{
+		Email: v.Email,
+		Phone: v.Phone,
+	}
+
+	return res
+}
+
+// transformContactToResultfilterviewsContactView builds a value of type
+// *resultfilterviews.ContactView from a value of type *Contact.
+func transformContactToResultfilterviewsContactView(v *Contact) *resultfilterviews.ContactView {
+	if v == nil {
+		return nil
+	}
+	res := &resultfilterviews.ContactView{
+		Email: v.Email,
+		Phone: v.Phone,
+	}
+
+	return res
+}
+`
@@ -548,3 +548,44 @@ var NamesWithSpacesDSL = func() {
 		})
 	})
 }
+
+var ResultFilterDSL = func() {
+	var Contact = Type("Contact", func() {
+		Attribute("email", String)
+		Attribute("phone", String, func() {
+			Scope("pii:read")
+		})
+	})
+	var Employee = ResultType("application/vnd.employee", func() {
+		TypeName("Employee")
+		Attributes(func() {
+			Attribute("name", String)
+			Attribute("ssn", String, func() {
+				Scope("pii:read")
+			})
+			Attribute("salary", Int, func() {
+				Scope("payroll:read")
+				Scope("pii:read")
+			})
+			Attribute("contacts", ArrayOf(Contact))
+			Required("name")
+		})
+		View("default", func() {
+			Attribute("name")
+			Attribute("ssn")
+			Attribute("salary")
+			Attribute("contacts")
+		})
+		View("tiny", func() {
+			Attribute("name")
+		})
+	})
+	Service("ResultFilter", func() {
+		Method("Show", func() {
+			Result(Employee)
+		})
+		Method("List", func() {
+			Result(MapOf(String, Contact))
+		})
+	})
+}
@@ -29,6 +29,8 @@ func ViewsFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 		header := codegen.Header(service.Name+" views", "views",
 			[]*codegen.ImportSpec{
 				codegen.GoaImport(""),
+				{Path: "fmt"},
+				{Path: "strconv"},
 				{Path: "unicode/utf8"},
 			})
 		sections = []*codegen.SectionTemplate{header}
@@ -101,7 +103,7 @@ func ViewsFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 		}
 	}
 
-	return &codegen.File{Path: path, SectionTemplates: sections}
+	return &codegen.File{Path: path, SectionTemplates: sections, Service: service.Name}
 }
 
 // input: ValidateData
@@ -0,0 +1,225 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"sort"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// SourceMap maps the top level declarations of generated Go files to the
+	// design expressions that produced them, see SectionTemplate.Expr.
+	SourceMap struct {
+		// Files lists the mappings of each generated file indexed by
+		// slash separated path.
+		Files map[string][]*SourceMapping `json:"files"`
+	}
+
+	// SourceMapping maps a top level declaration to a design expression.
+	SourceMapping struct {
+		// Decl is the name of the declaration. The names of methods are
+		// prefixed with the name of the receiver type, e.g. "Server.Mount".
+		Decl string `json:"decl"`
+		// Start is the line where the declaration starts including its
+		// doc comment.
+		Start int `json:"start"`
+		// End is the line where the declaration ends.
+		End int `json:"end"`
+		// Expr is the name of the design expression, e.g. `method "add" of
+		// service "calc"`.
+		Expr string `json:"expr"`
+		// Design is the location of the DSL that defines the expression
+		// formatted as "file:line" if known.
+		Design string `json:"design,omitempty"`
+	}
+)
+
+// Lookup returns the mappings of the file with the given slash separated path
+// that contain the given line. Lookup returns all the mappings of the file if
+// line is 0.
+func (m *SourceMap) Lookup(path string, line int) []*SourceMapping {
+	var res []*SourceMapping
+	for _, sm := range m.Files[path] {
+		if line == 0 || sm.Start <= line && line <= sm.End {
+			res = append(res, sm)
+		}
+	}
+	return res
+}
+
+// add records the mappings of the Go source file at path whose path relative
+// to the output directory is rel. exprs maps the names of the declarations to
+// the expressions that produced them. Files that do not parse, e.g. because
+// merging them resulted in conflicts, are not mapped.
+func (m *SourceMap) add(rel, path string, exprs map[string]eval.Expression) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		return
+	}
+	var mappings []*SourceMapping
+	for _, d := range file.Decls {
+		for _, decl := range topLevelDecls(d) {
+			e, ok := exprs[decl.name]
+			if !ok {
+				continue
+			}
+			mappings = append(mappings, &SourceMapping{
+				Decl:   decl.name,
+				Start:  fset.Position(decl.pos).Line,
+				End:    fset.Position(decl.end).Line,
+				Expr:   e.EvalName(),
+				Design: designLocation(e),
+			})
+		}
+	}
+	if len(mappings) == 0 {
+		return
+	}
+	sort.SliceStable(mappings, func(i, j int) bool { return mappings[i].Start < mappings[j].Start })
+	if m.Files == nil {
+		m.Files = make(map[string][]*SourceMapping)
+	}
+	m.Files[filepath.ToSlash(rel)] = mappings
+}
+
+// declSections returns the indexes of the sections of f that have an
+// expression indexed by the names of the top level declarations they render.
+// src is the content rendered by execute.
+func (f *File) declSections(src []byte) map[string]int {
+	var any bool
+	for _, s := range f.SectionTemplates {
+		if s.Expr != nil {
+			any = true
+			break
+		}
+	}
+	if !any || len(f.sectionEnds) != len(f.SectionTemplates) {
+		return nil
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil
+	}
+	sections := make(map[string]int)
+	for _, d := range file.Decls {
+		offset := fset.Position(d.Pos()).Offset
+		i := sort.SearchInts(f.sectionEnds, offset+1)
+		if i == len(f.SectionTemplates) || f.SectionTemplates[i].Expr == nil {
+			continue
+		}
+		for _, decl := range topLevelDecls(d) {
+			sections[decl.name] = i
+		}
+	}
+	return sections
+}
+
+// namedDecl is a named top level declaration.
+type namedDecl struct {
+	name string
+	// pos is the position of the declaration including its doc comment.
+	pos token.Pos
+	// end is the position of the end of the declaration.
+	end token.Pos
+}
+
+// topLevelDecls returns the named declarations of d. The specs of grouped
+// declarations span the spec only while the others span the whole
+// declaration including the doc comment.
+func topLevelDecls(d ast.Decl) []namedDecl {
+	switch d := d.(type) {
+	case *ast.FuncDecl:
+		name := d.Name.Name
+		if d.Recv != nil && len(d.Recv.List) > 0 {
+			if recv := recvTypeName(d.Recv.List[0].Type); recv != "" {
+				name = recv + "." + name
+			}
+		}
+		return []namedDecl{{name, docPos(d, d.Doc), d.End()}}
+	case *ast.GenDecl:
+		var decls []namedDecl
+		for _, s := range d.Specs {
+			pos, end := s.Pos(), s.End()
+			if len(d.Specs) == 1 {
+				pos, end = docPos(d, d.Doc), d.End()
+			}
+			switch s := s.(type) {
+			case *ast.TypeSpec:
+				decls = append(decls, namedDecl{s.Name.Name, pos, end})
+			case *ast.ValueSpec:
+				for _, n := range s.Names {
+					if n.Name != "_" {
+						decls = append(decls, namedDecl{n.Name, pos, end})
+					}
+				}
+			}
+		}
+		return decls
+	}
+	return nil
+}
+
+// recvTypeName returns the name of the receiver type t.
+func recvTypeName(t ast.Expr) string {
+	switch t := t.(type) {
+	case *ast.StarExpr:
+		return recvTypeName(t.X)
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// docPos returns the position of the doc comment of n if any, the position of
+// n otherwise.
+func docPos(n ast.Node, doc *ast.CommentGroup) token.Pos {
+	if doc != nil {
+		return doc.Pos()
+	}
+	return n.Pos()
+}
+
+// designLocation returns the location of the DSL that defines e formatted as
+// "file:line". It falls back to the location of the parent expression, e.g.
+// the method of a HTTP endpoint, if the location of e is not known.
+func designLocation(e eval.Expression) string {
+	for {
+		if l := eval.FormatLocation(e); l != "" {
+			return l
+		}
+		var parent eval.Expression
+		switch x := e.(type) {
+		case *expr.HTTPEndpointExpr:
+			if x.MethodExpr != nil {
+				parent = x.MethodExpr
+			}
+		case *expr.GRPCEndpointExpr:
+			if x.MethodExpr != nil {
+				parent = x.MethodExpr
+			}
+		case *expr.HTTPServiceExpr:
+			if x.ServiceExpr != nil {
+				parent = x.ServiceExpr
+			}
+		case *expr.GRPCServiceExpr:
+			if x.ServiceExpr != nil {
+				parent = x.ServiceExpr
+			}
+		case *expr.MethodExpr:
+			if x.Service != nil {
+				parent = x.Service
+			}
+		}
+		if parent == nil {
+			return ""
+		}
+		e = parent
+	}
+}
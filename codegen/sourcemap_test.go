@@ -0,0 +1,66 @@
+package codegen
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+)
+
+func TestRendererSourceMap(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goa-sourcemap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var (
+		svc    = &expr.ServiceExpr{Name: "svc"}
+		method = &expr.MethodExpr{Name: "m", Service: svc}
+	)
+	files := []*File{{
+		Path: "a.go",
+		SectionTemplates: []*SectionTemplate{
+			{Name: "header", Source: "package a\n"},
+			{Name: "service", Source: "// S is the service.\ntype S struct{}\n\nvar (\n\tA = 1\n\tB = 2\n)\n", Expr: svc},
+			{Name: "method", Source: "// M is the method.\nfunc (s *S)   M() {\n}\n", Expr: method},
+			{Name: "helper", Source: "func Helper() {}\n"},
+		},
+	}, {
+		Path:             "b.go",
+		SectionTemplates: []*SectionTemplate{{Name: "no-expr", Source: "package a\nfunc B() {}\n"}},
+	}}
+	m := &SourceMap{}
+	r := &Renderer{SourceMap: m}
+	if _, err := r.Render(dir, files); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string][]*SourceMapping{
+		"a.go": {
+			{Decl: "S", Start: 3, End: 4, Expr: `service "svc"`},
+			{Decl: "A", Start: 7, End: 7, Expr: `service "svc"`},
+			{Decl: "B", Start: 8, End: 8, Expr: `service "svc"`},
+			{Decl: "S.M", Start: 11, End: 13, Expr: `service "svc" method "m"`},
+		},
+	}
+	if !reflect.DeepEqual(m.Files, expected) {
+		for p, ms := range m.Files {
+			for _, sm := range ms {
+				t.Logf("%s: %+v", p, sm)
+			}
+		}
+		t.Errorf("got unexpected source map")
+	}
+	if l := m.Lookup("a.go", 12); len(l) != 1 || l[0].Decl != "S.M" {
+		t.Errorf("got lookup %v, expected S.M", l)
+	}
+	if l := m.Lookup("a.go", 5); len(l) != 0 {
+		t.Errorf("got lookup %v, expected none", l)
+	}
+	if l := m.Lookup("a.go", 0); len(l) != 4 {
+		t.Errorf("got %d mappings, expected 4", len(l))
+	}
+}
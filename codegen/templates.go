@@ -0,0 +1,193 @@
+package codegen
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// TemplatesDir is the name of the directory located in the output directory
+// that contains the template overrides, see OverrideTemplates.
+const TemplatesDir = "goa-templates"
+
+// templateExt is the extension of the template override files.
+const templateExt = ".tmpl"
+
+// OverrideTemplates replaces the source of the section templates of files
+// with the overrides found in dir. The override of the sections with a given
+// name is the file dir/<name>.tmpl, for example dir/server-handler.tmpl
+// overrides the templates of all the sections named "server-handler". This
+// makes it possible to customize specific parts of the generated code without
+// writing a plugin.
+//
+// An override must be compatible with the template it replaces: it must
+// parse with the same template functions, define the same named templates
+// and only reference fields or methods of the section data that exist.
+// OverrideTemplates returns an error describing the first incompatibility
+// otherwise. OverrideTemplates does nothing if dir does not exist.
+func OverrideTemplates(dir string, files []*File) error {
+	overrides, err := readTemplateOverrides(dir)
+	if err != nil || len(overrides) == 0 {
+		return err
+	}
+	for _, f := range files {
+		for _, s := range f.SectionTemplates {
+			path, ok := overrides[s.Name]
+			if !ok {
+				continue
+			}
+			b, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			if err := checkTemplateOverride(s, string(b)); err != nil {
+				return fmt.Errorf("template override %s: %s", path, err)
+			}
+			s.Source = string(b)
+			s.override = path
+		}
+	}
+	return nil
+}
+
+// readTemplateOverrides returns the paths to the template overrides located in
+// dir indexed by section name.
+func readTemplateOverrides(dir string) (map[string]string, error) {
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	overrides := make(map[string]string)
+	for _, fi := range fis {
+		if fi.IsDir() || filepath.Ext(fi.Name()) != templateExt {
+			continue
+		}
+		overrides[strings.TrimSuffix(fi.Name(), templateExt)] = filepath.Join(dir, fi.Name())
+	}
+	return overrides, nil
+}
+
+// checkTemplateOverride returns an error if the override source is not
+// compatible with the template of section s.
+func checkTemplateOverride(s *SectionTemplate, source string) error {
+	funcs := TemplateFuncs()
+	for k, v := range s.FuncMap {
+		funcs[k] = v
+	}
+	orig, err := template.New(s.Name).Funcs(funcs).Parse(s.Source)
+	if err != nil {
+		return err
+	}
+	tmpl, err := template.New(s.Name).Funcs(funcs).Parse(source)
+	if err != nil {
+		return err
+	}
+	var missing []string
+	for _, t := range orig.Templates() {
+		if t.Name() != s.Name && tmpl.Lookup(t.Name()) == nil {
+			missing = append(missing, fmt.Sprintf("%q", t.Name()))
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("missing definition of template %s used by section %q", strings.Join(missing, ", "), s.Name)
+	}
+	if s.Data == nil || tmpl.Tree == nil {
+		return nil
+	}
+	return checkFields(tmpl.Tree.Root, reflect.TypeOf(s.Data), true)
+}
+
+// checkFields returns an error if a field referenced from the data of the
+// template in node does not exist in type t. top is true if the dot is the
+// template data, only the references to "$" are checked otherwise.
+func checkFields(node parse.Node, t reflect.Type, top bool) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, c := range n.Nodes {
+			if err := checkFields(c, t, top); err != nil {
+				return err
+			}
+		}
+	case *parse.ActionNode:
+		return checkFields(n.Pipe, t, top)
+	case *parse.TemplateNode:
+		return checkFields(n.Pipe, t, top)
+	case *parse.PipeNode:
+		if n == nil {
+			return nil
+		}
+		for _, c := range n.Cmds {
+			for _, a := range c.Args {
+				if err := checkFields(a, t, top); err != nil {
+					return err
+				}
+			}
+		}
+	case *parse.IfNode:
+		return checkBranch(&n.BranchNode, t, top, top)
+	case *parse.RangeNode:
+		return checkBranch(&n.BranchNode, t, top, false)
+	case *parse.WithNode:
+		return checkBranch(&n.BranchNode, t, top, false)
+	case *parse.FieldNode:
+		if top {
+			return checkField(t, n.Ident[0])
+		}
+	case *parse.VariableNode:
+		if n.Ident[0] == "$" && len(n.Ident) > 1 {
+			return checkField(t, n.Ident[1])
+		}
+	case *parse.ChainNode:
+		return checkFields(n.Node, t, top)
+	}
+	return nil
+}
+
+// checkBranch checks the pipeline and lists of a branch node, see checkFields.
+// inner is true if the dot is still the template data in the branch list.
+func checkBranch(n *parse.BranchNode, t reflect.Type, top, inner bool) error {
+	if err := checkFields(n.Pipe, t, top); err != nil {
+		return err
+	}
+	if err := checkFields(n.List, t, inner); err != nil {
+		return err
+	}
+	return checkFields(n.ElseList, t, top)
+}
+
+// checkField returns an error if the values of type t do not have a field or
+// method with the given name.
+func checkField(t reflect.Type, name string) error {
+	if _, ok := t.MethodByName(name); ok {
+		return nil
+	}
+	st := t
+	for st.Kind() == reflect.Ptr {
+		st = st.Elem()
+		if _, ok := st.MethodByName(name); ok {
+			return nil
+		}
+	}
+	switch st.Kind() {
+	case reflect.Struct:
+		if _, ok := st.FieldByName(name); ok {
+			return nil
+		}
+	case reflect.Map, reflect.Interface:
+		return nil
+	}
+	return fmt.Errorf("%s has no field or method %s", t, name)
+}
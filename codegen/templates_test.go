@@ -0,0 +1,74 @@
+package codegen
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOverrideTemplates(t *testing.T) {
+	type data struct {
+		Name  string
+		Items []string
+	}
+	const source = `{{ define "item" }}- {{ . }}{{ end }}{{ .Name }}:{{ range .Items }}{{ template "item" . }}{{ end }}`
+	cases := map[string]struct {
+		Override string
+		Expected string
+		Error    string
+	}{
+		"valid":           {`{{ define "item" }}* {{ . }}{{ end }}{{ toUpper .Name }}{{ range .Items }}{{ template "item" . }}{{ $.Name }}{{ end }}`, "FOO* afoo* bfoo", ""},
+		"missing define":  {`{{ .Name }}`, "", `missing definition of template "item" used by section "section"`},
+		"unknown field":   {`{{ define "item" }}{{ end }}{{ .Title }}`, "", "codegen.data has no field or method Title"},
+		"unknown var":     {`{{ define "item" }}{{ end }}{{ range .Items }}{{ $.Title }}{{ end }}`, "", "codegen.data has no field or method Title"},
+		"unknown func":    {`{{ define "item" }}{{ end }}{{ unknown .Name }}`, "", `function "unknown" not defined`},
+		"field in range":  {`{{ define "item" }}{{ end }}{{ range .Items }}{{ .Title }}{{ end }}`, "", "template override"},
+		"no such section": {"", "foo:- a- b", ""},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "goa-templates")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			file := "section.tmpl"
+			if c.Override == "" {
+				file = "other.tmpl"
+			}
+			if err := ioutil.WriteFile(filepath.Join(dir, file), []byte(c.Override), 0644); err != nil {
+				t.Fatal(err)
+			}
+			s := &SectionTemplate{
+				Name:    "section",
+				Source:  source,
+				FuncMap: map[string]interface{}{"toUpper": strings.ToUpper},
+				Data:    &data{Name: "foo", Items: []string{"a", "b"}},
+			}
+			err = OverrideTemplates(dir, []*File{{Path: "foo.txt", SectionTemplates: []*SectionTemplate{s}}})
+			if err == nil {
+				// Fields referenced in nested scopes are only
+				// checked when the template executes.
+				var buf bytes.Buffer
+				err = s.Write(&buf)
+				if err == nil && buf.String() != c.Expected {
+					t.Errorf("got %q, expected %q", buf.String(), c.Expected)
+				}
+			}
+			if c.Error == "" && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if c.Error != "" {
+				if err == nil {
+					t.Fatalf("expected error containing %q", c.Error)
+				}
+				if !strings.Contains(err.Error(), c.Error) || !strings.Contains(err.Error(), filepath.Join(dir, file)) {
+					t.Errorf("got error %q, expected it to contain %q and the override path", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
@@ -107,10 +107,172 @@ const (
 		}
 	}
 }
+`
+
+	NumberRequiredValidationCode = `func Validate() (err error) {
+	if target.RequiredInteger <= 0 {
+		err = goa.MergeErrors(err, goa.InvalidExclusiveRangeError("target.required_integer", target.RequiredInteger, 0, true))
+	}
+	if target.RequiredInteger%5 != 0 {
+		err = goa.MergeErrors(err, goa.InvalidMultipleOfError("target.required_integer", target.RequiredInteger, 5))
+	}
+	if target.Float != nil {
+		if *target.Float >= 1 {
+			err = goa.MergeErrors(err, goa.InvalidExclusiveRangeError("target.float", *target.Float, 1, false))
+		}
+	}
+	if target.Float != nil {
+		if !goa.IsMultipleOf(*target.Float, 0.25) {
+			err = goa.MergeErrors(err, goa.InvalidMultipleOfError("target.float", *target.Float, 0.25))
+		}
+	}
+}
+`
+
+	NumberPointerValidationCode = `func Validate() (err error) {
+	if target.RequiredInteger == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("required_integer", "target"))
+	}
+	if target.RequiredInteger != nil {
+		if *target.RequiredInteger <= 0 {
+			err = goa.MergeErrors(err, goa.InvalidExclusiveRangeError("target.required_integer", *target.RequiredInteger, 0, true))
+		}
+	}
+	if target.RequiredInteger != nil {
+		if *target.RequiredInteger%5 != 0 {
+			err = goa.MergeErrors(err, goa.InvalidMultipleOfError("target.required_integer", *target.RequiredInteger, 5))
+		}
+	}
+	if target.Float != nil {
+		if *target.Float >= 1 {
+			err = goa.MergeErrors(err, goa.InvalidExclusiveRangeError("target.float", *target.Float, 1, false))
+		}
+	}
+	if target.Float != nil {
+		if !goa.IsMultipleOf(*target.Float, 0.25) {
+			err = goa.MergeErrors(err, goa.InvalidMultipleOfError("target.float", *target.Float, 0.25))
+		}
+	}
+}
+`
+
+	CollectionRequiredValidationCode = `func Validate() (err error) {
+	if len(target.Name) > 10 {
+		err = goa.MergeErrors(err, goa.InvalidMaxBytesError("target.name", target.Name, len(target.Name), 10))
+	}
+	{
+		seen := make(map[interface{}]struct{}, len(target.Items))
+		for _, elem := range target.Items {
+			if elem == nil {
+				continue
+			}
+			if _, ok := seen[elem.RequiredInteger]; ok {
+				err = goa.MergeErrors(err, goa.InvalidUniqueItemsError("target.items", "required_integer", elem.RequiredInteger))
+				continue
+			}
+			seen[elem.RequiredInteger] = struct{}{}
+		}
+	}
+	for _, e := range target.Items {
+		if e != nil {
+			if err2 := ValidateInteger(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	err = goa.MergeErrors(err, goa.ValidateContentMediaType("target.logo", target.Logo, "image/*"))
+}
+`
+
+	CollectionPointerValidationCode = `func Validate() (err error) {
+	if target.Name == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("name", "target"))
+	}
+	if target.Name != nil {
+		if len(*target.Name) > 10 {
+			err = goa.MergeErrors(err, goa.InvalidMaxBytesError("target.name", *target.Name, len(*target.Name), 10))
+		}
+	}
+	{
+		seen := make(map[interface{}]struct{}, len(target.Items))
+		for _, elem := range target.Items {
+			if elem == nil || elem.RequiredInteger == nil {
+				continue
+			}
+			if _, ok := seen[*elem.RequiredInteger]; ok {
+				err = goa.MergeErrors(err, goa.InvalidUniqueItemsError("target.items", "required_integer", *elem.RequiredInteger))
+				continue
+			}
+			seen[*elem.RequiredInteger] = struct{}{}
+		}
+	}
+	for _, e := range target.Items {
+		if e != nil {
+			if err2 := ValidateInteger(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	err = goa.MergeErrors(err, goa.ValidateContentMediaType("target.logo", target.Logo, "image/*"))
+}
+`
+
+	CustomRequiredValidationCode = `func Validate() (err error) {
+	if err2 := iban.Validate(target.Iban); err2 != nil {
+		err = goa.MergeErrors(err, goa.InvalidValueError("target.iban", target.Iban, err2))
+	}
+	if target.Count != nil {
+		if err2 := checks.ValidateCount(int(*target.Count)); err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidValueError("target.count", *target.Count, err2))
+		}
+	}
+}
+`
+
+	CustomPointerValidationCode = `func Validate() (err error) {
+	if target.Iban == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("iban", "target"))
+	}
+	if target.Iban != nil {
+		if err2 := iban.Validate(*target.Iban); err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidValueError("target.iban", *target.Iban, err2))
+		}
+	}
+	if target.Count != nil {
+		if err2 := checks.ValidateCount(int(*target.Count)); err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidValueError("target.count", *target.Count, err2))
+		}
+	}
+}
+`
+
+	LimitedRequiredValidationCode = `func Validate() (err error) {
+	if target.Ids == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("ids", "target"))
+	}
+	if len(target.Ids) > 100 {
+		err = goa.MergeErrors(err, goa.InvalidLengthError("target.ids", target.Ids, len(target.Ids), 100, false))
+	} else {
+		for i, e := range target.Ids {
+			if e < 1 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("target.ids["+strconv.Itoa(i)+"]", e, 1, true))
+			}
+		}
+	}
+	if len(target.Tags) > 10 {
+		err = goa.MergeErrors(err, goa.InvalidLengthError("target.tags", target.Tags, len(target.Tags), 10, false))
+	} else {
+		for k, v := range target.Tags {
+			err = goa.MergeErrors(err, goa.ValidatePattern("target.tags["+k+"]", v, "^[a-z]+$"))
+		}
+	}
+}
 `
 
 	StringRequiredValidationCode = `func Validate() (err error) {
-	err = goa.MergeErrors(err, goa.ValidatePattern("target.required_string", target.RequiredString, "^[A-z].*[a-z]$"))
+	if utf8.RuneCountInString(target.RequiredString) <= 10 {
+		err = goa.MergeErrors(err, goa.ValidatePattern("target.required_string", target.RequiredString, "^[A-z].*[a-z]$"))
+	}
 	if utf8.RuneCountInString(target.RequiredString) < 1 {
 		err = goa.MergeErrors(err, goa.InvalidLengthError("target.required_string", target.RequiredString, utf8.RuneCountInString(target.RequiredString), 1, true))
 	}
@@ -133,7 +295,9 @@ const (
 		err = goa.MergeErrors(err, goa.MissingFieldError("required_string", "target"))
 	}
 	if target.RequiredString != nil {
-		err = goa.MergeErrors(err, goa.ValidatePattern("target.required_string", *target.RequiredString, "^[A-z].*[a-z]$"))
+		if utf8.RuneCountInString(*target.RequiredString) <= 10 {
+			err = goa.MergeErrors(err, goa.ValidatePattern("target.required_string", *target.RequiredString, "^[A-z].*[a-z]$"))
+		}
 	}
 	if target.RequiredString != nil {
 		if utf8.RuneCountInString(*target.RequiredString) < 1 {
@@ -157,7 +321,9 @@ const (
 `
 
 	StringUseDefaultValidationCode = `func Validate() (err error) {
-	err = goa.MergeErrors(err, goa.ValidatePattern("target.required_string", target.RequiredString, "^[A-z].*[a-z]$"))
+	if utf8.RuneCountInString(target.RequiredString) <= 10 {
+		err = goa.MergeErrors(err, goa.ValidatePattern("target.required_string", target.RequiredString, "^[A-z].*[a-z]$"))
+	}
 	if utf8.RuneCountInString(target.RequiredString) < 1 {
 		err = goa.MergeErrors(err, goa.InvalidLengthError("target.required_string", target.RequiredString, utf8.RuneCountInString(target.RequiredString), 1, true))
 	}
@@ -259,9 +425,9 @@ const (
 	if len(target.DefaultArray) > 3 {
 		err = goa.MergeErrors(err, goa.InvalidLengthError("target.default_array", target.DefaultArray, len(target.DefaultArray), 3, false))
 	}
-	for _, e := range target.Array {
+	for i, e := range target.Array {
 		if !(e == 0 || e == 1 || e == 1 || e == 2 || e == 3 || e == 5) {
-			err = goa.MergeErrors(err, goa.InvalidEnumValueError("target.array[*]", e, []interface{}{0, 1, 1, 2, 3, 5}))
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("target.array["+strconv.Itoa(i)+"]", e, []interface{}{0, 1, 1, 2, 3, 5}))
 		}
 	}
 }
@@ -277,9 +443,9 @@ const (
 	if len(target.DefaultArray) > 3 {
 		err = goa.MergeErrors(err, goa.InvalidLengthError("target.default_array", target.DefaultArray, len(target.DefaultArray), 3, false))
 	}
-	for _, e := range target.Array {
+	for i, e := range target.Array {
 		if !(e == 0 || e == 1 || e == 1 || e == 2 || e == 3 || e == 5) {
-			err = goa.MergeErrors(err, goa.InvalidEnumValueError("target.array[*]", e, []interface{}{0, 1, 1, 2, 3, 5}))
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("target.array["+strconv.Itoa(i)+"]", e, []interface{}{0, 1, 1, 2, 3, 5}))
 		}
 	}
 }
@@ -295,9 +461,9 @@ const (
 	if len(target.DefaultArray) > 3 {
 		err = goa.MergeErrors(err, goa.InvalidLengthError("target.default_array", target.DefaultArray, len(target.DefaultArray), 3, false))
 	}
-	for _, e := range target.Array {
+	for i, e := range target.Array {
 		if !(e == 0 || e == 1 || e == 1 || e == 2 || e == 3 || e == 5) {
-			err = goa.MergeErrors(err, goa.InvalidEnumValueError("target.array[*]", e, []interface{}{0, 1, 1, 2, 3, 5}))
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("target.array["+strconv.Itoa(i)+"]", e, []interface{}{0, 1, 1, 2, 3, 5}))
 		}
 	}
 }
@@ -314,9 +480,9 @@ const (
 		err = goa.MergeErrors(err, goa.InvalidLengthError("target.default_map", target.DefaultMap, len(target.DefaultMap), 3, false))
 	}
 	for k, v := range target.Map {
-		err = goa.MergeErrors(err, goa.ValidatePattern("target.map.key", k, "^[A-Z]"))
+		err = goa.MergeErrors(err, goa.ValidatePattern("target.map", k, "^[A-Z]"))
 		if v > 5 {
-			err = goa.MergeErrors(err, goa.InvalidRangeError("target.map[key]", v, 5, false))
+			err = goa.MergeErrors(err, goa.InvalidRangeError("target.map["+k+"]", v, 5, false))
 		}
 	}
 }
@@ -333,9 +499,9 @@ const (
 		err = goa.MergeErrors(err, goa.InvalidLengthError("target.default_map", target.DefaultMap, len(target.DefaultMap), 3, false))
 	}
 	for k, v := range target.Map {
-		err = goa.MergeErrors(err, goa.ValidatePattern("target.map.key", k, "^[A-Z]"))
+		err = goa.MergeErrors(err, goa.ValidatePattern("target.map", k, "^[A-Z]"))
 		if v > 5 {
-			err = goa.MergeErrors(err, goa.InvalidRangeError("target.map[key]", v, 5, false))
+			err = goa.MergeErrors(err, goa.InvalidRangeError("target.map["+k+"]", v, 5, false))
 		}
 	}
 }
@@ -352,9 +518,9 @@ const (
 		err = goa.MergeErrors(err, goa.InvalidLengthError("target.default_map", target.DefaultMap, len(target.DefaultMap), 3, false))
 	}
 	for k, v := range target.Map {
-		err = goa.MergeErrors(err, goa.ValidatePattern("target.map.key", k, "^[A-Z]"))
+		err = goa.MergeErrors(err, goa.ValidatePattern("target.map", k, "^[A-Z]"))
 		if v > 5 {
-			err = goa.MergeErrors(err, goa.InvalidRangeError("target.map[key]", v, 5, false))
+			err = goa.MergeErrors(err, goa.InvalidRangeError("target.map["+k+"]", v, 5, false))
 		}
 	}
 }
@@ -48,6 +48,57 @@ var ValidationTypesDSL = func() {
 			Required("required_string")
 		})
 
+		_ = Type("Number", func() {
+			Attribute("required_integer", Int, func() {
+				ExclusiveMinimum(0)
+				MultipleOf(5)
+			})
+			Attribute("float", Float64, func() {
+				ExclusiveMaximum(1)
+				MultipleOf(0.25)
+			})
+			Required("required_integer")
+		})
+
+		_ = Type("Collection", func() {
+			Attribute("name", String, func() {
+				MaxBytes(10)
+			})
+			Attribute("items", ArrayOf(IntegerT), func() {
+				UniqueBy("required_integer")
+			})
+			Attribute("logo", Bytes, func() {
+				ContentMediaType("image/*")
+			})
+			Required("name")
+		})
+
+		_ = Type("Custom", func() {
+			Attribute("iban", String, func() {
+				ValidateWith("iban.Validate", "example.com/iban")
+			})
+			Attribute("count", Int, func() {
+				ValidateWith("checks.ValidateCount", "example.com/custom/checks", "checks")
+			})
+			Required("iban")
+		})
+
+		_ = Type("Limited", func() {
+			Attribute("ids", ArrayOf(Int), func() {
+				Elem(func() {
+					Minimum(1)
+				})
+				Meta("validation:max-items-validated", "100")
+			})
+			Attribute("tags", MapOf(String, String), func() {
+				Elem(func() {
+					Pattern("^[a-z]+$")
+				})
+				Meta("validation:max-items-validated", "10")
+			})
+			Required("ids")
+		})
+
 		_ = Type("UserType", func() {
 			Attribute("required_integer", IntegerT)
 			Attribute("default_string", StringT, func() {
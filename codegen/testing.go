@@ -98,7 +98,7 @@ func sectionCodeWithPrefix(t *testing.T, section *SectionTemplate, prefix string
 func FormatTestCode(t *testing.T, code string) string {
 	tmp := CreateTempFile(t, code)
 	defer os.Remove(tmp)
-	if err := finalizeGoSource(tmp); err != nil {
+	if err := finalizeGoSource(tmp, nil); err != nil {
 		t.Fatal(err)
 	}
 	content, err := ioutil.ReadFile(tmp)
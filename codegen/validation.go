@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -15,7 +16,13 @@ var (
 	formatValT   *template.Template
 	patternValT  *template.Template
 	minMaxValT   *template.Template
+	exclValT     *template.Template
+	multValT     *template.Template
 	lengthValT   *template.Template
+	bytesValT    *template.Template
+	uniqueValT   *template.Template
+	mediaValT    *template.Template
+	funcValT     *template.Template
 	requiredValT *template.Template
 	arrayValT    *template.Template
 	mapValT      *template.Template
@@ -34,10 +41,16 @@ func init() {
 	formatValT = template.Must(template.New("format").Funcs(fm).Parse(formatValTmpl))
 	patternValT = template.Must(template.New("pattern").Funcs(fm).Parse(patternValTmpl))
 	minMaxValT = template.Must(template.New("minMax").Funcs(fm).Parse(minMaxValTmpl))
+	exclValT = template.Must(template.New("exclusive").Funcs(fm).Parse(exclusiveValTmpl))
+	multValT = template.Must(template.New("multipleOf").Funcs(fm).Parse(multipleOfValTmpl))
 	lengthValT = template.Must(template.New("length").Funcs(fm).Parse(lengthValTmpl))
+	bytesValT = template.Must(template.New("maxBytes").Funcs(fm).Parse(maxBytesValTmpl))
+	uniqueValT = template.Must(template.New("uniqueBy").Funcs(fm).Parse(uniqueByValTmpl))
+	mediaValT = template.Must(template.New("contentMediaType").Funcs(fm).Parse(contentMediaTypeValTmpl))
+	funcValT = template.Must(template.New("func").Funcs(fm).Parse(funcValTmpl))
 	requiredValT = template.Must(template.New("req").Funcs(fm).Parse(requiredValTmpl))
-	arrayValT = template.Must(template.New("array").Funcs(fm).Parse(arrayValTmpl))
-	mapValT = template.Must(template.New("map").Funcs(fm).Parse(mapValTmpl))
+	arrayValT = template.Must(template.Must(template.New("array").Funcs(fm).Parse(arrayValTmpl)).Parse(maxItemsTmpl))
+	mapValT = template.Must(template.Must(template.New("map").Funcs(fm).Parse(mapValTmpl)).Parse(maxItemsTmpl))
 	userValT = template.Must(template.New("user").Funcs(fm).Parse(userValTmpl))
 }
 
@@ -56,6 +69,13 @@ func init() {
 // context is used to produce helpful messages in case of error.
 //
 func ValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool, target, context string) string {
+	return validationCode(att, attCtx, req, target, strconv.Quote(context))
+}
+
+// validationCode is ValidationCode with context being the Go expression that
+// computes the validation context so that the contexts of the array and map
+// elements include the element index or key.
+func validationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool, target, context string) string {
 	validation := att.Validation
 	if validation == nil {
 		if ut, ok := att.Type.(expr.UserType); ok {
@@ -108,6 +128,9 @@ func ValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool,
 	}
 	if pattern := validation.Pattern; pattern != "" {
 		data["pattern"] = pattern
+		// Skip evaluating the pattern on values that are too long, the
+		// length validation reports the error.
+		data["patternMaxLength"] = validation.MaxLength
 		if val := runTemplate(patternValT, data); val != "" {
 			res = append(res, val)
 		}
@@ -128,6 +151,30 @@ func ValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool,
 			res = append(res, val)
 		}
 	}
+	if min := validation.ExclusiveMinimum; min != nil {
+		data["min"] = *min
+		data["isMin"] = true
+		delete(data, "max")
+		if val := runTemplate(exclValT, data); val != "" {
+			res = append(res, val)
+		}
+	}
+	if max := validation.ExclusiveMaximum; max != nil {
+		data["max"] = *max
+		data["isMin"] = false
+		delete(data, "min")
+		if val := runTemplate(exclValT, data); val != "" {
+			res = append(res, val)
+		}
+	}
+	if mult := validation.MultipleOf; mult != nil {
+		data["multipleOf"] = *mult
+		data["float"] = kind == expr.Float32Kind || kind == expr.Float64Kind
+		data["float32"] = kind == expr.Float32Kind
+		if val := runTemplate(multValT, data); val != "" {
+			res = append(res, val)
+		}
+	}
 	if minLength := validation.MinLength; minLength != nil {
 		data["minLength"] = minLength
 		data["isMinLength"] = true
@@ -144,6 +191,49 @@ func ValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool,
 			res = append(res, val)
 		}
 	}
+	if maxBytes := validation.MaxBytes; maxBytes != nil {
+		data["maxBytes"] = *maxBytes
+		if val := runTemplate(bytesValT, data); val != "" {
+			res = append(res, val)
+		}
+	}
+	if key := validation.UniqueBy; key != "" {
+		if ar := expr.AsArray(att.Type); ar != nil {
+			if keyAtt := ar.ElemType.Find(key); keyAtt != nil {
+				data["uniqueBy"] = key
+				data["keyField"] = attCtx.Scope.Field(keyAtt, key, true)
+				data["keyPointer"] = attCtx.IsPrimitivePointer(key, ar.ElemType)
+				if val := runTemplate(uniqueValT, data); val != "" {
+					res = append(res, val)
+				}
+			}
+		}
+	}
+	if mt := validation.ContentMediaType; mt != "" {
+		data["mediaType"] = mt
+		if val := runTemplate(mediaValT, data); val != "" {
+			res = append(res, val)
+		}
+	}
+	for _, f := range validation.Funcs {
+		data["func"] = f.Name
+		if f.ImportName != "" {
+			// The package is imported with a different name.
+			data["func"] = f.ImportName + f.Name[strings.Index(f.Name, "."):]
+		}
+		// The protocol buffer compiler maps the Int and UInt types to
+		// 32-bit integers.
+		data["conversion"] = ""
+		switch kind {
+		case expr.IntKind:
+			data["conversion"] = "int"
+		case expr.UIntKind:
+			data["conversion"] = "uint"
+		}
+		if val := runTemplate(funcValT, data); val != "" {
+			res = append(res, val)
+		}
+	}
 	if req := validation.Required; len(req) > 0 {
 		obj := expr.AsObject(att.Type)
 		for _, r := range req {
@@ -175,10 +265,13 @@ func ValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool,
 //
 func RecursiveValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool, target string) string {
 	seen := make(map[string]*bytes.Buffer)
-	return recurseValidationCode(att, attCtx, req, target, target, seen).String()
+	return recurseValidationCode(att, attCtx, req, target, strconv.Quote(target), 0, seen).String()
 }
 
-func recurseValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool, target, context string, seen map[string]*bytes.Buffer) *bytes.Buffer {
+// recurseValidationCode produces the validation code of att. context is the Go
+// expression that computes the validation context and depth the number of
+// enclosing array and map loops.
+func recurseValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool, target, context string, depth int, seen map[string]*bytes.Buffer) *bytes.Buffer {
 	var (
 		buf   = new(bytes.Buffer)
 		first = true
@@ -192,7 +285,7 @@ func recurseValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, re
 		seen[ut.ID()] = buf
 	}
 
-	validation := ValidationCode(att, attCtx, req, target, context)
+	validation := validationCode(att, attCtx, req, target, context)
 	if validation != "" {
 		buf.WriteString(validation)
 		first = false
@@ -212,7 +305,7 @@ func recurseValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, re
 
 	if o := expr.AsObject(att.Type); o != nil {
 		for _, nat := range *o {
-			validation := recurseAttribute(att, attCtx, nat, target, context, seen)
+			validation := recurseAttribute(att, attCtx, nat, target, context, depth, seen)
 			if validation != "" {
 				if !first {
 					buf.WriteByte('\n')
@@ -228,16 +321,24 @@ func recurseValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, re
 			ctx = attCtx.Dup()
 			ctx.Pointer = false
 		}
-		val := recurseValidationCode(a.ElemType, ctx, true, "e", context+"[*]", seen).String()
+		index := loopVar("i", depth)
+		elem := fmt.Sprintf("strconv.Itoa(%s)", index)
+		val := recurseValidationCode(a.ElemType, ctx, true, "e", elemContext(context, elem), depth+1, seen).String()
 		if val != "" {
 			switch a.ElemType.Type.(type) {
 			case expr.UserType:
 				// For user and result types, call the Validate method
 				val = runUserValT(attCtx.Scope.Name(a.ElemType, ctx.Pkg), "e")
 			}
+			if !strings.Contains(val, " + "+elem+" + ") {
+				index = "_"
+			}
 			data := map[string]interface{}{
 				"target":     target,
+				"context":    context,
+				"index":      index,
 				"validation": val,
+				"maxItems":   expr.MaxItemsValidated(att),
 			}
 			if !first {
 				buf.WriteByte('\n')
@@ -251,12 +352,19 @@ func recurseValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, re
 	} else if m := expr.AsMap(att.Type); m != nil {
 		ctx := attCtx.Dup()
 		ctx.Pointer = false
-		keyVal := recurseValidationCode(m.KeyType, ctx, true, "k", context+".key", seen).String()
-		valueVal := recurseValidationCode(m.ElemType, ctx, true, "v", context+"[key]", seen).String()
+		// The key validations report the map as the invalid value cannot
+		// be addressed.
+		key := loopVar("k", depth)
+		elem := fmt.Sprintf("fmt.Sprint(%s)", key)
+		if _, ok := m.KeyType.Type.(expr.UserType); !ok && m.KeyType.Type.Kind() == expr.StringKind {
+			elem = key
+		}
+		keyVal := recurseValidationCode(m.KeyType, ctx, true, key, context, depth+1, seen).String()
+		valueVal := recurseValidationCode(m.ElemType, ctx, true, "v", elemContext(context, elem), depth+1, seen).String()
 		if keyVal != "" || valueVal != "" {
 			if keyVal != "" {
 				if _, ok := m.KeyType.Type.(expr.UserType); ok {
-					keyVal = runUserValT(ctx.Scope.Name(m.KeyType, ctx.Pkg), "k")
+					keyVal = runUserValT(ctx.Scope.Name(m.KeyType, ctx.Pkg), key)
 				} else {
 					keyVal = "\n" + keyVal
 				}
@@ -268,10 +376,16 @@ func recurseValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, re
 					valueVal = "\n" + valueVal
 				}
 			}
+			if keyVal == "" && !strings.Contains(valueVal, " + "+elem+" + ") {
+				key = "_"
+			}
 			data := map[string]interface{}{
 				"target":          target,
+				"context":         context,
+				"key":             key,
 				"keyValidation":   keyVal,
 				"valueValidation": valueVal,
+				"maxItems":        expr.MaxItemsValidated(att),
 			}
 			if !first {
 				buf.WriteByte('\n')
@@ -286,7 +400,7 @@ func recurseValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, re
 	return buf
 }
 
-func recurseAttribute(att *expr.AttributeExpr, attCtx *AttributeContext, nat *expr.NamedAttributeExpr, target, context string, seen map[string]*bytes.Buffer) string {
+func recurseAttribute(att *expr.AttributeExpr, attCtx *AttributeContext, nat *expr.NamedAttributeExpr, target, context string, depth int, seen map[string]*bytes.Buffer) string {
 	var validation string
 	if ut, ok := nat.Attribute.Type.(expr.UserType); ok {
 		// We need to check empirically whether there are validations to be
@@ -326,7 +440,7 @@ func recurseAttribute(att *expr.AttributeExpr, attCtx *AttributeContext, nat *ex
 			var buf bytes.Buffer
 			tgt := fmt.Sprintf("%s.%s", target, attCtx.Scope.Field(nat.Attribute, nat.Name, true))
 			if expr.IsArray(nat.Attribute.Type) {
-				buf.Write(recurseValidationCode(nat.Attribute, attCtx, att.IsRequired(nat.Name), tgt, context, seen).Bytes())
+				buf.Write(recurseValidationCode(nat.Attribute, attCtx, att.IsRequired(nat.Name), tgt, context, depth, seen).Bytes())
 			} else {
 				if err := userValT.Execute(&buf, map[string]interface{}{"name": Goify(attCtx.Scope.Name(nat.Attribute, attCtx.Pkg), true), "target": tgt}); err != nil {
 					panic(err) // bug
@@ -340,7 +454,8 @@ func recurseAttribute(att *expr.AttributeExpr, attCtx *AttributeContext, nat *ex
 			attCtx,
 			att.IsRequired(nat.Name),
 			fmt.Sprintf("%s.%s", target, attCtx.Scope.Field(nat.Attribute, nat.Name, true)),
-			fmt.Sprintf("%s.%s", context, nat.Name),
+			appendContext(context, "."+nat.Name),
+			depth,
 			seen,
 		).String()
 	}
@@ -353,6 +468,32 @@ func recurseAttribute(att *expr.AttributeExpr, attCtx *AttributeContext, nat *ex
 	return validation
 }
 
+// appendContext returns the Go expression that appends suffix to the
+// validation context computed by the Go expression context.
+func appendContext(context, suffix string) string {
+	q := strconv.Quote(suffix)
+	if strings.HasSuffix(context, `"`) {
+		return context[:len(context)-1] + q[1:]
+	}
+	return context + " + " + q
+}
+
+// elemContext returns the Go expression that computes the validation context
+// of the array or map element whose index or key is computed by elem.
+func elemContext(context, elem string) string {
+	return appendContext(appendContext(context, "[")+" + "+elem, "]")
+}
+
+// loopVar returns the name of the variable holding the index or key of the
+// array or map loop with the given depth so that nested loops do not shadow
+// the variables used to compute the contexts.
+func loopVar(name string, depth int) string {
+	if depth == 0 {
+		return name
+	}
+	return name + strconv.Itoa(depth)
+}
+
 // toSlice returns Go code that represents the given slice.
 func toSlice(val []interface{}) string {
 	elems := make([]string, len(val))
@@ -408,14 +549,22 @@ func constant(formatName string) string {
 }
 
 const (
-	arrayValTmpl = `for _, e := range {{ .target }} {
+	arrayValTmpl = `{{ template "maxItems" . }}for {{ .index }}, e := range {{ .target }} {
 {{ .validation }}
-}`
+}{{ if .maxItems }}
+}{{ end }}`
 
-	mapValTmpl = `for {{if .keyValidation }}k{{ else }}_{{ end }}, {{ if .valueValidation }}v{{ else }}_{{ end }} := range {{ .target }} {
+	mapValTmpl = `{{ template "maxItems" . }}for {{ .key }}, {{ if .valueValidation }}v{{ else }}_{{ end }} := range {{ .target }} {
 {{- .keyValidation }}
 {{- .valueValidation }}
-}`
+}{{ if .maxItems }}
+}{{ end }}`
+
+	maxItemsTmpl = `{{ define "maxItems" }}{{ if .maxItems -}}
+if len({{ .target }}) > {{ .maxItems }} {
+        err = goa.MergeErrors(err, goa.InvalidLengthError({{ .context }}, {{ .target }}, len({{ .target }}), {{ .maxItems }}, false))
+} else {
+{{ end }}{{ end }}`
 
 	userValTmpl = `if err2 := Validate{{ .name }}({{ .target }}); err2 != nil {
         err = goa.MergeErrors(err, err2)
@@ -427,7 +576,7 @@ if {{ .target }} != {{ if and (not .zeroVal) .string }}""{{ else }}{{ .zeroVal }
 if {{ .target }} != nil {
 {{ end -}}
 if !({{ oneof .targetVal .values }}) {
-        err = goa.MergeErrors(err, goa.InvalidEnumValueError({{ printf "%q" .context }}, {{ .targetVal }}, {{ slice .values }}))
+        err = goa.MergeErrors(err, goa.InvalidEnumValueError({{ .context }}, {{ .targetVal }}, {{ slice .values }}))
 {{ if or (isset .zeroVal) .isPointer -}}
 }
 {{ end -}}
@@ -438,7 +587,13 @@ if {{ .target }} != {{ if and (not .zeroVal) .string }}""{{ else }}{{ .zeroVal }
 {{ else if .isPointer -}}
 if {{ .target }} != nil {
 {{ end -}}
-        err = goa.MergeErrors(err, goa.ValidatePattern({{ printf "%q" .context }}, {{ .targetVal }}, {{ printf "%q" .pattern }}))
+{{ if .patternMaxLength -}}
+if utf8.RuneCountInString({{ .targetVal }}) <= {{ .patternMaxLength }} {
+{{ end -}}
+        err = goa.MergeErrors(err, goa.ValidatePattern({{ .context }}, {{ .targetVal }}, {{ printf "%q" .pattern }}))
+{{- if .patternMaxLength }}
+}
+{{- end }}
 {{- if or (isset .zeroVal) .isPointer }}
 }
 {{- end }}`
@@ -448,7 +603,7 @@ if {{ .target }} != {{ if and (not .zeroVal) .string }}""{{ else }}{{ .zeroVal }
 {{ else if .isPointer -}}
 if {{ .target }} != nil {
 {{ end -}}
-        err = goa.MergeErrors(err, goa.ValidateFormat({{ printf "%q" .context }}, {{ .targetVal}}, {{ constant .format }}))
+        err = goa.MergeErrors(err, goa.ValidateFormat({{ .context }}, {{ .targetVal}}, {{ constant .format }}))
 {{ if or (isset .zeroVal) .isPointer -}}
 }
 {{- end }}`
@@ -459,7 +614,31 @@ if {{ .target }} != {{ if and (not .zeroVal) .string }}""{{ else }}{{ .zeroVal }
 if {{ .target }} != nil {
 {{ end -}}
         if {{ .targetVal }} {{ if .isMin }}<{{ else }}>{{ end }} {{ if .isMin }}{{ .min }}{{ else }}{{ .max }}{{ end }} {
-        err = goa.MergeErrors(err, goa.InvalidRangeError({{ printf "%q" .context }}, {{ .targetVal }}, {{ if .isMin }}{{ .min }}, true{{ else }}{{ .max }}, false{{ end }}))
+        err = goa.MergeErrors(err, goa.InvalidRangeError({{ .context }}, {{ .targetVal }}, {{ if .isMin }}{{ .min }}, true{{ else }}{{ .max }}, false{{ end }}))
+{{ if or (isset .zeroVal) .isPointer -}}
+}
+{{ end -}}
+}`
+
+	exclusiveValTmpl = `{{ if isset .zeroVal -}}
+if {{ .target }} != {{ .zeroVal }} {
+{{ else if .isPointer -}}
+if {{ .target }} != nil {
+{{ end -}}
+        if {{ .targetVal }} {{ if .isMin }}<={{ else }}>={{ end }} {{ if .isMin }}{{ .min }}{{ else }}{{ .max }}{{ end }} {
+        err = goa.MergeErrors(err, goa.InvalidExclusiveRangeError({{ .context }}, {{ .targetVal }}, {{ if .isMin }}{{ .min }}, true{{ else }}{{ .max }}, false{{ end }}))
+{{ if or (isset .zeroVal) .isPointer -}}
+}
+{{ end -}}
+}`
+
+	multipleOfValTmpl = `{{ if isset .zeroVal -}}
+if {{ .target }} != {{ .zeroVal }} {
+{{ else if .isPointer -}}
+if {{ .target }} != nil {
+{{ end -}}
+        if {{ if .float }}!goa.IsMultipleOf({{ if .float32 }}float64({{ .targetVal }}){{ else }}{{ .targetVal }}{{ end }}, {{ .multipleOf }}){{ else }}{{ .targetVal }}%{{ .multipleOf }} != 0{{ end }} {
+        err = goa.MergeErrors(err, goa.InvalidMultipleOfError({{ .context }}, {{ .targetVal }}, {{ .multipleOf }}))
 {{ if or (isset .zeroVal) .isPointer -}}
 }
 {{ end -}}
@@ -472,12 +651,49 @@ if {{ .target }} != {{ if and (not .zeroVal) .string }}""{{ else }}{{ .zeroVal }
 if {{ .target }} != nil {
 {{ end -}}
 if {{ if .string }}utf8.RuneCountInString({{ $target }}){{ else }}len({{ $target }}){{ end }} {{ if .isMinLength }}<{{ else }}>{{ end }} {{ if .isMinLength }}{{ .minLength }}{{ else }}{{ .maxLength }}{{ end }} {
-        err = goa.MergeErrors(err, goa.InvalidLengthError({{ printf "%q" .context }}, {{ $target }}, {{ if .string }}utf8.RuneCountInString({{ $target }}){{ else }}len({{ $target }}){{ end }}, {{ if .isMinLength }}{{ .minLength }}, true{{ else }}{{ .maxLength }}, false{{ end }}))
+        err = goa.MergeErrors(err, goa.InvalidLengthError({{ .context }}, {{ $target }}, {{ if .string }}utf8.RuneCountInString({{ $target }}){{ else }}len({{ $target }}){{ end }}, {{ if .isMinLength }}{{ .minLength }}, true{{ else }}{{ .maxLength }}, false{{ end }}))
 }{{- if and (or (isset .zeroVal) .isPointer) .string }}
 }
+{{- end }}`
+
+	maxBytesValTmpl = `{{ if .isPointer -}}
+if {{ .target }} != nil {
+{{ end -}}
+if len({{ .targetVal }}) > {{ .maxBytes }} {
+        err = goa.MergeErrors(err, goa.InvalidMaxBytesError({{ .context }}, {{ .targetVal }}, len({{ .targetVal }}), {{ .maxBytes }}))
+}{{- if .isPointer }}
+}
+{{- end }}`
+
+	uniqueByValTmpl = `{
+        seen := make(map[interface{}]struct{}, len({{ .target }}))
+        for _, elem := range {{ .target }} {
+                if elem == nil{{ if .keyPointer }} || elem.{{ .keyField }} == nil{{ end }} {
+                        continue
+                }
+                if _, ok := seen[{{ if .keyPointer }}*{{ end }}elem.{{ .keyField }}]; ok {
+                        err = goa.MergeErrors(err, goa.InvalidUniqueItemsError({{ .context }}, {{ printf "%q" .uniqueBy }}, {{ if .keyPointer }}*{{ end }}elem.{{ .keyField }}))
+                        continue
+                }
+                seen[{{ if .keyPointer }}*{{ end }}elem.{{ .keyField }}] = struct{}{}
+        }
+}`
+
+	contentMediaTypeValTmpl = `err = goa.MergeErrors(err, goa.ValidateContentMediaType({{ .context }}, {{ .target }}, {{ printf "%q" .mediaType }}))`
+
+	funcValTmpl = `{{ if isset .zeroVal -}}
+if {{ .target }} != {{ if and (not .zeroVal) .string }}""{{ else }}{{ .zeroVal }}{{ end }} {
+{{ else if .isPointer -}}
+if {{ .target }} != nil {
+{{ end -}}
+if err2 := {{ .func }}({{ if .conversion }}{{ .conversion }}({{ .targetVal }}){{ else }}{{ .targetVal }}{{ end }}); err2 != nil {
+        err = goa.MergeErrors(err, goa.InvalidValueError({{ .context }}, {{ .targetVal }}, err2))
+}
+{{- if or (isset .zeroVal) .isPointer }}
+}
 {{- end }}`
 
 	requiredValTmpl = `if {{ $.target }}.{{ .attCtx.Scope.Field $.reqAtt .req true }} == nil {
-        err = goa.MergeErrors(err, goa.MissingFieldError("{{ .req }}", {{ printf "%q" $.context }}))
+        err = goa.MergeErrors(err, goa.MissingFieldError("{{ .req }}", {{ $.context }}))
 }`
 )
@@ -15,6 +15,10 @@ func TestRecursiveValidationCode(t *testing.T) {
 		integerT = root.UserType("Integer")
 		stringT  = root.UserType("String")
 		floatT   = root.UserType("Float")
+		numberT  = root.UserType("Number")
+		collT    = root.UserType("Collection")
+		customT  = root.UserType("Custom")
+		limitedT = root.UserType("Limited")
 		userT    = root.UserType("UserType")
 		arrayUT  = root.UserType("ArrayUserType")
 		arrayT   = root.UserType("Array")
@@ -34,6 +38,13 @@ func TestRecursiveValidationCode(t *testing.T) {
 		{"float-required", floatT, true, false, false, testdata.FloatRequiredValidationCode},
 		{"float-pointer", floatT, false, true, false, testdata.FloatPointerValidationCode},
 		{"float-use-default", floatT, false, false, true, testdata.FloatUseDefaultValidationCode},
+		{"number-required", numberT, true, false, false, testdata.NumberRequiredValidationCode},
+		{"number-pointer", numberT, false, true, false, testdata.NumberPointerValidationCode},
+		{"collection-required", collT, true, false, false, testdata.CollectionRequiredValidationCode},
+		{"collection-pointer", collT, false, true, false, testdata.CollectionPointerValidationCode},
+		{"custom-required", customT, true, false, false, testdata.CustomRequiredValidationCode},
+		{"custom-pointer", customT, false, true, false, testdata.CustomPointerValidationCode},
+		{"limited-required", limitedT, true, false, false, testdata.LimitedRequiredValidationCode},
 		{"string-required", stringT, true, false, false, testdata.StringRequiredValidationCode},
 		{"string-pointer", stringT, false, true, false, testdata.StringPointerValidationCode},
 		{"string-use-default", stringT, false, false, true, testdata.StringUseDefaultValidationCode},
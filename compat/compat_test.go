@@ -0,0 +1,73 @@
+package compat
+
+import (
+	"flag"
+	"fmt"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update .golden files")
+
+// TestLayersFrozen makes sure that the identifiers re-exported by the layers
+// and their types do not change. A change to the runtime packages that alters
+// a re-exported identifier requires an adapter in the layer.
+func TestLayersFrozen(t *testing.T) {
+	imp := importer.ForCompiler(token.NewFileSet(), "source", nil)
+	for _, v := range Versions {
+		var b strings.Builder
+		for _, name := range []string{"goa", "http", "grpc", "security", "cli"} {
+			pkg, err := imp.Import(path.Join("goa.design/goa/v3/compat", v, name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			scope := pkg.Scope()
+			for _, n := range scope.Names() {
+				obj := scope.Lookup(n)
+				if !obj.Exported() {
+					continue
+				}
+				fmt.Fprintf(&b, "%s.%s %s\n", name, n, typeString(obj))
+			}
+		}
+		golden := filepath.Join("testdata", v+".golden")
+		if *update {
+			if err := ioutil.WriteFile(golden, []byte(b.String()), 0644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		expected, err := ioutil.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != string(expected) {
+			t.Errorf("%s: the layer API changed, got:\n%s\nexpected:\n%s", v, b.String(), expected)
+		}
+	}
+}
+
+// typeString returns the type of the exported identifier obj omitting the
+// unexported fields of structs as they are not part of the layer API.
+func typeString(obj types.Object) string {
+	if _, ok := obj.(*types.TypeName); !ok {
+		return types.TypeString(obj.Type(), nil)
+	}
+	s, ok := obj.Type().Underlying().(*types.Struct)
+	if !ok {
+		return types.TypeString(obj.Type().Underlying(), nil)
+	}
+	var fields []string
+	for i := 0; i < s.NumFields(); i++ {
+		if f := s.Field(i); f.Exported() {
+			fields = append(fields, f.Name()+" "+types.TypeString(f.Type(), nil))
+		}
+	}
+	return "struct{" + strings.Join(fields, "; ") + "}"
+}
@@ -0,0 +1,38 @@
+/*
+Package compat contains the compatibility layers of the goa runtime packages.
+
+The code generated by goa depends on the goa runtime packages: the goa package
+(goa.design/goa/v3/pkg), the HTTP package (goa.design/goa/v3/http), the gRPC
+package (goa.design/goa/v3/grpc), the security package
+(goa.design/goa/v3/security) and the CLI package (goa.design/goa/v3/cli). A
+change to the signature of a function or type of these packages used by the
+generated code breaks the code that was generated with a previous version of
+goa until it is generated again.
+
+A compatibility layer is a versioned set of packages that re-export the
+identifiers of the runtime packages used by the generated code. The code generated for a design that sets
+the "goa:compat" API meta to the layer version, e.g. "v1", imports the layer
+packages instead of the runtime packages:
+
+	var _ = API("calc", func() {
+	    Meta("goa:compat", "v1")
+	})
+
+The identifiers of a layer never change. When a runtime package changes the
+signature of an identifier, the layer replaces the re-exported identifier with
+an adapter that preserves the previous signature so that the code generated
+against the layer keeps compiling. The identifiers added to the runtime
+packages after a layer is released are not part of it, a new layer version
+re-exports them together with the new signatures.
+
+The generated code imports the other goa packages, e.g. the security
+subpackages, directly. The "gen" command also writes the runtime.json file in
+the generated packages directory. The file lists the identifiers of all the goa
+packages the generated code uses together with the version of goa that
+generated it.
+*/
+package compat
+
+// Versions lists the versions of the compatibility layer, the last one is the
+// latest.
+var Versions = []string{"v1"}
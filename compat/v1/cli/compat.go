@@ -0,0 +1,24 @@
+// Package cli is the v1 compatibility layer of goa.design/goa/v3/cli.
+package cli
+
+import goacli "goa.design/goa/v3/cli"
+
+// Types re-exported from the goa CLI package. The aliases are identical to the
+// runtime types.
+type (
+	Column  = goacli.Column
+	Command = goacli.Command
+	Flag    = goacli.Flag
+	Output  = goacli.Output
+	Stream  = goacli.Stream
+)
+
+// Functions re-exported from the goa CLI package.
+var (
+	FlagsOf          = goacli.FlagsOf
+	InterruptContext = goacli.InterruptContext
+	Lookup           = goacli.Lookup
+	NewDefaults      = goacli.NewDefaults
+	NewOutput        = goacli.NewOutput
+	WriteCompletion  = goacli.WriteCompletion
+)
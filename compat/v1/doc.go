@@ -0,0 +1,11 @@
+// Package v1 groups the packages of the version 1 compatibility layer. The code
+// generated with the "goa:compat" API meta set to "v1" imports these packages
+// instead of the goa runtime packages of the same name, see package
+// goa.design/goa/v3/compat.
+//
+// The layer re-exports only the identifiers used by the code generated for
+// version 1 and the set is frozen: identifiers added to the runtime packages
+// later are not part of the layer. The functions are re-exported as variables
+// so that an adapter can replace a function whose runtime signature changes
+// without changing the layer API.
+package v1
@@ -0,0 +1,89 @@
+// Package goa is the v1 compatibility layer of goa.design/goa/v3/pkg.
+package goa
+
+import goa "goa.design/goa/v3/pkg"
+
+// Types re-exported from the goa runtime package. The aliases are identical to
+// the runtime types.
+type (
+	CacheStore         = goa.CacheStore
+	Drainer            = goa.Drainer
+	Endpoint           = goa.Endpoint
+	EventPublisher     = goa.EventPublisher
+	EventPublisherFunc = goa.EventPublisherFunc
+	Health             = goa.Health
+	Job                = goa.Job
+	JobFunc            = goa.JobFunc
+	Jobs               = goa.Jobs
+	PanicReporter      = goa.PanicReporter
+	PanicReporterFunc  = goa.PanicReporterFunc
+	Propagation        = goa.Propagation
+	ResultCache        = goa.ResultCache
+	ServiceError       = goa.ServiceError
+	Tenancy            = goa.Tenancy
+	TenantValidator    = goa.TenantValidator
+)
+
+// Constants re-exported from the goa runtime package.
+const (
+	FormatCIDR              = goa.FormatCIDR
+	FormatDate              = goa.FormatDate
+	FormatDateTime          = goa.FormatDateTime
+	FormatEmail             = goa.FormatEmail
+	FormatHostname          = goa.FormatHostname
+	FormatIP                = goa.FormatIP
+	FormatIPv4              = goa.FormatIPv4
+	FormatIPv6              = goa.FormatIPv6
+	FormatJSON              = goa.FormatJSON
+	FormatMAC               = goa.FormatMAC
+	FormatRFC1123           = goa.FormatRFC1123
+	FormatRegexp            = goa.FormatRegexp
+	FormatURI               = goa.FormatURI
+	FormatUUID              = goa.FormatUUID
+	HealthStatusDraining    = goa.HealthStatusDraining
+	HealthStatusOK          = goa.HealthStatusOK
+	HealthStatusUnavailable = goa.HealthStatusUnavailable
+	Major                   = goa.Major
+	MethodKey               = goa.MethodKey
+	PropagatedKey           = goa.PropagatedKey
+	ServiceKey              = goa.ServiceKey
+)
+
+// Functions re-exported from the goa runtime package.
+var (
+	CacheKey                   = goa.CacheKey
+	DecodePayloadError         = goa.DecodePayloadError
+	EmitEvent                  = goa.EmitEvent
+	Fault                      = goa.Fault
+	InvalidEnumValueError      = goa.InvalidEnumValueError
+	InvalidExclusiveRangeError = goa.InvalidExclusiveRangeError
+	InvalidFieldTypeError      = goa.InvalidFieldTypeError
+	InvalidLengthError         = goa.InvalidLengthError
+	InvalidMaxBytesError       = goa.InvalidMaxBytesError
+	InvalidMultipleOfError     = goa.InvalidMultipleOfError
+	InvalidRangeError          = goa.InvalidRangeError
+	InvalidUniqueItemsError    = goa.InvalidUniqueItemsError
+	InvalidValueError          = goa.InvalidValueError
+	IsMultipleOf               = goa.IsMultipleOf
+	LimitEndpoint              = goa.LimitEndpoint
+	LocalizeError              = goa.LocalizeError
+	MergeErrors                = goa.MergeErrors
+	MissingFieldError          = goa.MissingFieldError
+	MissingPayloadError        = goa.MissingPayloadError
+	NewDrainer                 = goa.NewDrainer
+	NewErrorID                 = goa.NewErrorID
+	NewHealth                  = goa.NewHealth
+	NewKafkaPublisher          = goa.NewKafkaPublisher
+	NewLRUCache                = goa.NewLRUCache
+	NewLimiter                 = goa.NewLimiter
+	NewNATSPublisher           = goa.NewNATSPublisher
+	PermanentError             = goa.PermanentError
+	PublishEvents              = goa.PublishEvents
+	RecoverEndpoint            = goa.RecoverEndpoint
+	RequireTenant              = goa.RequireTenant
+	ValidateContentMediaType   = goa.ValidateContentMediaType
+	ValidateFormat             = goa.ValidateFormat
+	ValidatePattern            = goa.ValidatePattern
+	ValidateTenant             = goa.ValidateTenant
+	Version                    = goa.Version
+)
@@ -0,0 +1,32 @@
+// Package grpc is the v1 compatibility layer of goa.design/goa/v3/grpc.
+package grpc
+
+import goagrpc "goa.design/goa/v3/grpc"
+
+// Types re-exported from the goa gRPC runtime package. The aliases are
+// identical to the runtime types.
+type (
+	Invoker       = goagrpc.Invoker
+	RemoteFunc    = goagrpc.RemoteFunc
+	StreamHandler = goagrpc.StreamHandler
+	UnaryHandler  = goagrpc.UnaryHandler
+)
+
+// Functions re-exported from the goa gRPC runtime package.
+var (
+	CallCredentials   = goagrpc.CallCredentials
+	DecodeError       = goagrpc.DecodeError
+	EncodeError       = goagrpc.EncodeError
+	ErrInvalidType    = goagrpc.ErrInvalidType
+	NewErrorResponse  = goagrpc.NewErrorResponse
+	NewHealthServer   = goagrpc.NewHealthServer
+	NewInvoker        = goagrpc.NewInvoker
+	NewServiceError   = goagrpc.NewServiceError
+	NewStatusError    = goagrpc.NewStatusError
+	PeerCertificate   = goagrpc.PeerCertificate
+	PropagateIncoming = goagrpc.PropagateIncoming
+	PropagateOutgoing = goagrpc.PropagateOutgoing
+	RequestMetadata   = goagrpc.RequestMetadata
+	TenantIncoming    = goagrpc.TenantIncoming
+	WithCredentials   = goagrpc.WithCredentials
+)
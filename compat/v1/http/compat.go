@@ -0,0 +1,131 @@
+// Package http is the v1 compatibility layer of goa.design/goa/v3/http.
+package http
+
+import goahttp "goa.design/goa/v3/http"
+
+// Types re-exported from the goa HTTP runtime package. The aliases are
+// identical to the runtime types.
+type (
+	CORSPolicy        = goahttp.CORSPolicy
+	CSRFProtector     = goahttp.CSRFProtector
+	CircuitBreaker    = goahttp.CircuitBreaker
+	ClientError       = goahttp.ClientError
+	ClientPolicy      = goahttp.ClientPolicy
+	CompressOptions   = goahttp.CompressOptions
+	ConnConfigureFunc = goahttp.ConnConfigureFunc
+	CookieOptions     = goahttp.CookieOptions
+	Decoder           = goahttp.Decoder
+	Dialer            = goahttp.Dialer
+	Doer              = goahttp.Doer
+	Encoder           = goahttp.Encoder
+	EncodingFunc      = goahttp.EncodingFunc
+	FileServerConfig  = goahttp.FileServerConfig
+	KeyLocation       = goahttp.KeyLocation
+	Muxer             = goahttp.Muxer
+	Proxy             = goahttp.Proxy
+	RequestSigner     = goahttp.RequestSigner
+	RetryPolicy       = goahttp.RetryPolicy
+	RouteHandler      = goahttp.RouteHandler
+	RouteNode         = goahttp.RouteNode
+	RouteTree         = goahttp.RouteTree
+	SignatureKeyFunc  = goahttp.SignatureKeyFunc
+	SignatureVerifier = goahttp.SignatureVerifier
+	StreamReader      = goahttp.StreamReader
+	StreamWriter      = goahttp.StreamWriter
+	TreeRoute         = goahttp.TreeRoute
+	Upgrader          = goahttp.Upgrader
+	WebSocketConfig   = goahttp.WebSocketConfig
+)
+
+// Constants re-exported from the goa HTTP runtime package.
+const (
+	AcceptTypeKey          = goahttp.AcceptTypeKey
+	ContentTypeKey         = goahttp.ContentTypeKey
+	DefaultPartMemory      = goahttp.DefaultPartMemory
+	DefaultProxyBodyLimit  = goahttp.DefaultProxyBodyLimit
+	SignatureAlgorithm     = goahttp.SignatureAlgorithm
+	SignatureContentHeader = goahttp.SignatureContentHeader
+	SignatureDateHeader    = goahttp.SignatureDateHeader
+	SignatureNonceHeader   = goahttp.SignatureNonceHeader
+	StreamJSONArray        = goahttp.StreamJSONArray
+	StreamNDJSON           = goahttp.StreamNDJSON
+)
+
+// Variables re-exported from the goa HTTP runtime package.
+var (
+	ChiSyntax            = goahttp.ChiSyntax
+	EchoSyntax           = goahttp.EchoSyntax
+	ErrCSRFSecretMissing = goahttp.ErrCSRFSecretMissing
+	GinSyntax            = goahttp.GinSyntax
+	GorillaSyntax        = goahttp.GorillaSyntax
+)
+
+// Functions re-exported from the goa HTTP runtime package.
+var (
+	AuthChallenges        = goahttp.AuthChallenges
+	BodyLimitError        = goahttp.BodyLimitError
+	BufferRequestBody     = goahttp.BufferRequestBody
+	CORSHandler           = goahttp.CORSHandler
+	CORSPreflightHandler  = goahttp.CORSPreflightHandler
+	CacheHeaders          = goahttp.CacheHeaders
+	CachingDoer           = goahttp.CachingDoer
+	CheckAccept           = goahttp.CheckAccept
+	CheckContentType      = goahttp.CheckContentType
+	CheckQueryParams      = goahttp.CheckQueryParams
+	CompressHandler       = goahttp.CompressHandler
+	DrainStreams          = goahttp.DrainStreams
+	EncodeProblem         = goahttp.EncodeProblem
+	ErrDecodingError      = goahttp.ErrDecodingError
+	ErrEncodingError      = goahttp.ErrEncodingError
+	ErrInvalidResponse    = goahttp.ErrInvalidResponse
+	ErrInvalidType        = goahttp.ErrInvalidType
+	ErrInvalidURL         = goahttp.ErrInvalidURL
+	ErrRequestError       = goahttp.ErrRequestError
+	ErrValidationError    = goahttp.ErrValidationError
+	ErrorEncoder          = goahttp.ErrorEncoder
+	FormRequestDecoder    = goahttp.FormRequestDecoder
+	FormRequestEncoder    = goahttp.FormRequestEncoder
+	HeadHandler           = goahttp.HeadHandler
+	HealthHandler         = goahttp.HealthHandler
+	JSON                  = goahttp.JSON
+	LenientDecoder        = goahttp.LenientDecoder
+	LimitRequestBody      = goahttp.LimitRequestBody
+	NewDebugDoer          = goahttp.NewDebugDoer
+	NewFileServer         = goahttp.NewFileServer
+	NewInMemoryClient     = goahttp.NewInMemoryClient
+	NewInMemoryListener   = goahttp.NewInMemoryListener
+	NewMuxAdapter         = goahttp.NewMuxAdapter
+	NewMuxer              = goahttp.NewMuxer
+	NewProxy              = goahttp.NewProxy
+	NewRadixMuxer         = goahttp.NewRadixMuxer
+	NewRouteTree          = goahttp.NewRouteTree
+	NewStreamReader       = goahttp.NewStreamReader
+	NewStreamWriter       = goahttp.NewStreamWriter
+	OptionsHandler        = goahttp.OptionsHandler
+	ParseBool             = goahttp.ParseBool
+	PolicyDoer            = goahttp.PolicyDoer
+	PooledRequestEncoder  = goahttp.PooledRequestEncoder
+	PooledResponseEncoder = goahttp.PooledResponseEncoder
+	ProblemErrorEncoder   = goahttp.ProblemErrorEncoder
+	PropagateIncoming     = goahttp.PropagateIncoming
+	PropagateOutgoing     = goahttp.PropagateOutgoing
+	ReadAPIKey            = goahttp.ReadAPIKey
+	ReadCookie            = goahttp.ReadCookie
+	ReadFixtureRequest    = goahttp.ReadFixtureRequest
+	ReadFixtureResponse   = goahttp.ReadFixtureResponse
+	ReadSignedCookie      = goahttp.ReadSignedCookie
+	ReadyHandler          = goahttp.ReadyHandler
+	ReplayFixture         = goahttp.ReplayFixture
+	RequestDecoder        = goahttp.RequestDecoder
+	RequestMetadata       = goahttp.RequestMetadata
+	ResponseCookie        = goahttp.ResponseCookie
+	ResponseDecoder       = goahttp.ResponseDecoder
+	SendWebhook           = goahttp.SendWebhook
+	SetCookie             = goahttp.SetCookie
+	SignWebhook           = goahttp.SignWebhook
+	SigningDoer           = goahttp.SigningDoer
+	SplitQueryValues      = goahttp.SplitQueryValues
+	SpoolPart             = goahttp.SpoolPart
+	StartLambda           = goahttp.StartLambda
+	StrictDecoder         = goahttp.StrictDecoder
+)
@@ -0,0 +1,51 @@
+// Package security is the v1 compatibility layer of goa.design/goa/v3/security.
+package security
+
+import goasecurity "goa.design/goa/v3/security"
+
+// Types re-exported from the goa security package. The aliases are identical to
+// the runtime types.
+type (
+	APIKeyRing         = goasecurity.APIKeyRing
+	APIKeyScheme       = goasecurity.APIKeyScheme
+	Argon2IDKeyFunc    = goasecurity.Argon2IDKeyFunc
+	AuthError          = goasecurity.AuthError
+	BasicAuthenticator = goasecurity.BasicAuthenticator
+	BasicScheme        = goasecurity.BasicScheme
+	ClientCertificate  = goasecurity.ClientCertificate
+	JWTScheme          = goasecurity.JWTScheme
+	MTLSScheme         = goasecurity.MTLSScheme
+	OAuth2Scheme       = goasecurity.OAuth2Scheme
+	OAuthFlow          = goasecurity.OAuthFlow
+	PasswordVerifier   = goasecurity.PasswordVerifier
+	SignatureScheme    = goasecurity.SignatureScheme
+	TransportMetadata  = goasecurity.TransportMetadata
+	UserStore          = goasecurity.UserStore
+)
+
+// Variables re-exported from the goa security package.
+var (
+	ErrInvalidCredentials = goasecurity.ErrInvalidCredentials
+)
+
+// Functions re-exported from the goa security package.
+var (
+	ContextAPIKeyID          = goasecurity.ContextAPIKeyID
+	ContextBasicUser         = goasecurity.ContextBasicUser
+	ContextScopes            = goasecurity.ContextScopes
+	ContextSignatureKeyID    = goasecurity.ContextSignatureKeyID
+	ContextTransportMetadata = goasecurity.ContextTransportMetadata
+	HasScopes                = goasecurity.HasScopes
+	MTLSConfig               = goasecurity.MTLSConfig
+	NewAPIKeyRing            = goasecurity.NewAPIKeyRing
+	NewBasicAuthenticator    = goasecurity.NewBasicAuthenticator
+	ParseAPIKeys             = goasecurity.ParseAPIKeys
+	ParseClientCertificate   = goasecurity.ParseClientCertificate
+	ParseHtpasswd            = goasecurity.ParseHtpasswd
+	PeerCertificate          = goasecurity.PeerCertificate
+	WithAPIKeyID             = goasecurity.WithAPIKeyID
+	WithBasicUser            = goasecurity.WithBasicUser
+	WithScopeWildcards       = goasecurity.WithScopeWildcards
+	WithScopes               = goasecurity.WithScopes
+	WithTransportMetadata    = goasecurity.WithTransportMetadata
+)
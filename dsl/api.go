@@ -153,7 +153,7 @@ func License(fn func()) {
 // Docs provides external documentation URLs. It is used by the generated
 // OpenAPI specification.
 //
-// Docs must appear in an API, Service, Method or Attribute expr.
+// Docs must appear in an API, Service, Method, Tag or Attribute expr.
 //
 // Docs takes a single argument which is the defining DSL.
 //
@@ -182,11 +182,53 @@ func Docs(fn func()) {
 		e.Docs = docs
 	case *expr.HTTPFileServerExpr:
 		e.Docs = docs
+	case *expr.TagExpr:
+		e.Docs = docs
 	default:
 		eval.IncompatibleDSL()
 	}
 }
 
+// Plugins lists the plugins used to generate the code for the design. The goa
+// tool imports the plugin packages when generating the code so that the design
+// package does not need to import them and so that there is no need for a
+// custom main wrapping the code generator. The plugin packages register their
+// generators with codegen.RegisterPlugin when initialized. Small generators
+// may also be defined directly in the design with codegen.Generate.
+//
+// Plugins must appear in a API expression.
+//
+// Plugins accepts the import paths of the plugin packages as arguments. The
+// import paths must be string literals as the goa tool reads them from the
+// design source code before running the DSL. The plugin modules must be
+// required by the module of the design package.
+//
+// Example:
+//
+//    var _ = API("cellar", func() {
+//        Plugins("goa.design/plugins/v3/cors", "github.com/acme/goa-redis")
+//    })
+//
+func Plugins(paths ...string) {
+	a, ok := eval.Current().(*expr.APIExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	for _, p := range paths {
+		found := false
+		for _, q := range a.Plugins {
+			if p == q {
+				found = true
+				break
+			}
+		}
+		if !found {
+			a.Plugins = append(a.Plugins, p)
+		}
+	}
+}
+
 // TermsOfService describes the API terms of services or links to them.
 //
 // TermsOfService must appear in a API expression.
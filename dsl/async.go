@@ -0,0 +1,104 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Async defines a method that starts a long-running job and returns the status
+// of the job instead of its result. The method result is the JobStatus type
+// and the HTTP servers respond with 202 Accepted and the URL of the job status
+// in the Location header. Async also adds the "get_job_status" method to the
+// service: the method takes the ID of a job and returns its status or a
+// "job_not_found" error. The HTTP endpoint of the job status method is
+// "GET /jobs/{id}" relative to the service base path.
+//
+// The generated service package provides StartJob and LoadJobStatus to
+// implement the async and job status methods with a goa.Jobs value. goa.Jobs
+// records the jobs in a goa.JobStore and runs them with a goa.JobDispatcher
+// such as goa.WorkerPool.
+//
+// Async must appear in Method. The method must not stream nor define a
+// result.
+//
+// Example:
+//
+//    Method("report", func() {
+//        Payload(ReportRequest)
+//        Async()
+//        HTTP(func() {
+//            POST("/reports")
+//        })
+//    })
+//
+func Async() {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if m.Result != nil && m.Result.Type != expr.Empty {
+		eval.ReportError("async methods cannot define a result, the result is the status of the job")
+		return
+	}
+	jobStatus := jobStatusType()
+	if jobStatus == nil {
+		return
+	}
+	m.Async = true
+	m.Result = &expr.AttributeExpr{Type: jobStatus}
+	if m.Service.Method(expr.JobStatusMethodName) != nil {
+		return
+	}
+	sm := &expr.MethodExpr{Name: expr.JobStatusMethodName, Service: m.Service, DSLFunc: func() {
+		Description("Returns the status of a job started by an async method.")
+		Payload(func() {
+			Attribute("id", String, "ID of the job")
+			Required("id")
+		})
+		Result(jobStatus)
+		Error(expr.JobNotFoundErrorName, ErrorResult, "No job with the given ID")
+		HTTP(func() {
+			GET(expr.DefaultJobStatusPath)
+			Response(StatusOK)
+			Response(expr.JobNotFoundErrorName, StatusNotFound)
+		})
+	}}
+	m.Service.Methods = append(m.Service.Methods, sm)
+	eval.Execute(sm.DSLFunc, sm)
+}
+
+// jobStatusType returns the JobStatus type, it defines it the first time it is
+// called. It reports an error and returns nil if the design defines a type
+// with the same name.
+func jobStatusType() expr.UserType {
+	if ut := expr.Root.UserType(expr.JobStatusTypeName); ut != nil {
+		if _, ok := ut.Attribute().Meta["goa:job"]; !ok {
+			eval.ReportError("type %q conflicts with the job status type of the async methods", expr.JobStatusTypeName)
+			return nil
+		}
+		return ut
+	}
+	ut := &expr.UserTypeExpr{
+		TypeName:      expr.JobStatusTypeName,
+		AttributeExpr: &expr.AttributeExpr{Type: &expr.Object{}, Meta: expr.MetaExpr{"goa:job": nil}},
+	}
+	eval.Execute(func() {
+		Description("JobStatus describes the state of a job started by an async method.")
+		Attribute("id", String, "ID of the job")
+		Attribute("status", String, "State of the job", func() {
+			Enum("pending", "running", "succeeded", "failed")
+		})
+		Attribute("result", Any, "Result of the job once it succeeded")
+		Attribute("error", String, "Error message of the job once it failed")
+		Attribute("created_at", String, "Time the job was started", func() {
+			Format(FormatDateTime)
+		})
+		Attribute("updated_at", String, "Time the job state last changed", func() {
+			Format(FormatDateTime)
+		})
+		Required("id", "status", "created_at", "updated_at")
+	}, ut.AttributeExpr)
+	expr.Root.Types = append(expr.Root.Types, ut)
+	return ut
+}
@@ -230,6 +230,16 @@ func Default(def interface{}) {
 // example is generated unless the "swagger:example" meta is set to "false".
 // See Meta.
 //
+// Examples whose summary is not "default" are named examples. The OpenAPI 3
+// specification lists the named examples of the parameters, request bodies and
+// responses indexed by summary. The named examples of an object may also be
+// defined attribute by attribute: the value of the example of the object is
+// then built from the examples of its attributes with the same summary. The
+// generated command line tool makes it possible to use a named example as the
+// value of the flags not given explicitly with the -example flag, e.g.:
+//
+//    cellar-cli storage add -example "The first bottle"
+//
 // Example must appear in a Attributes, Attribute, Params, Param, Headers or
 // Header DSL.
 //
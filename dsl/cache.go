@@ -0,0 +1,85 @@
+package dsl
+
+import (
+	"time"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Cache memoizes the results of the method for the given duration. Once a
+// request is authenticated and authorized, the generated endpoint serves the
+// cached result of a previous request made with the same key if it is less
+// than ttl old. The results of secured methods are cached per principal and
+// granted scopes. The results are stored in the store given to the UseCache
+// method of the generated endpoints, goa.NewLRUCache provides an in-memory LRU
+// store. The generated HTTP servers set the Cache-Control and Age headers of
+// the responses accordingly. The generated caching package of the service
+// defines invalidation functions that mutating methods may call to remove the
+// cached results.
+//
+// Cache must appear in Method. The method must not stream and its HTTP routes
+// if any must use GET or HEAD.
+//
+// Cache accepts the TTL as a duration string (e.g. "5m", see
+// time.ParseDuration) and optionally CacheKey listing the names of the payload
+// attributes whose values key the cached results. The whole payload keys the
+// results by default. The key of a secured method must include the attributes
+// that hold the credentials.
+//
+// Example:
+//
+//    Method("show", func() {
+//        Payload(func() {
+//            Attribute("id", String)
+//            Attribute("view", String)
+//        })
+//        Result(Account)
+//        Cache("5m", CacheKey("id", "view"))
+//        HTTP(func() {
+//            GET("/{id}")
+//            Param("view")
+//        })
+//    })
+//
+func Cache(ttl string, keys ...*expr.CacheKeyExpr) {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	d, err := time.ParseDuration(ttl)
+	if err != nil {
+		eval.ReportError("invalid cache TTL %q: %s", ttl, err)
+		return
+	}
+	c := &expr.CacheExpr{Method: m, TTL: d}
+	for _, k := range keys {
+		if k != nil {
+			c.Keys = append(c.Keys, k.Attributes...)
+		}
+	}
+	m.Cache = c
+}
+
+// CacheKey lists the names of the payload attributes whose values key the
+// cached results of a method, see Cache.
+//
+// CacheKey must appear in Method.
+//
+// Example:
+//
+//    Method("show", func() {
+//        Payload(func() {
+//            Attribute("id", String)
+//        })
+//        Cache("5m", CacheKey("id"))
+//    })
+//
+func CacheKey(attributes ...string) *expr.CacheKeyExpr {
+	if _, ok := eval.Current().(*expr.MethodExpr); !ok {
+		eval.IncompatibleDSL()
+		return nil
+	}
+	return &expr.CacheKeyExpr{Attributes: attributes}
+}
@@ -0,0 +1,165 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Deployment defines the deployment hints of a server. The "gen" command
+// generates a Dockerfile and the Kubernetes Deployment and Service manifests
+// of each server that defines a deployment in the gen/deploy/<server>
+// directory. The container runs the server example main generated by the
+// "example" command and the ports are the ports of the URIs of the server
+// default host (the first host) unless overridden with DeploymentPort.
+//
+// Deployment must appear in Server.
+//
+// Deployment accepts one argument: the DSL function that defines the
+// deployment hints with DeploymentImage, DeploymentReplicas, DeploymentPort,
+// DeploymentEnv, DeploymentCPU and DeploymentMemory.
+//
+// Example:
+//
+//    var _ = API("calc", func() {
+//        Server("calc", func() {
+//            Host("production", func() {
+//                URI("http://calc.goa.design:8000")
+//            })
+//            Deployment(func() {
+//                DeploymentImage("ghcr.io/goadesign/calc")
+//                DeploymentReplicas(3)
+//                DeploymentEnv("LOG_LEVEL", "info")
+//                DeploymentCPU("100m", "500m")
+//                DeploymentMemory("64Mi", "256Mi")
+//            })
+//        })
+//    })
+//
+func Deployment(fn func()) {
+	s, ok := eval.Current().(*expr.ServerExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	d := &expr.DeploymentExpr{Server: s}
+	if !eval.Execute(fn, d) {
+		return
+	}
+	s.Deployment = d
+}
+
+// DeploymentImage defines the name of the container image of the server, it
+// defaults to the server name.
+//
+// DeploymentImage must appear in Deployment.
+//
+// Example:
+//
+//    Deployment(func() {
+//        DeploymentImage("ghcr.io/goadesign/calc:v1.2.0")
+//    })
+//
+func DeploymentImage(name string) {
+	if d := deployment(); d != nil {
+		d.Image = name
+	}
+}
+
+// DeploymentReplicas defines the number of instances of the server, it
+// defaults to 1.
+//
+// DeploymentReplicas must appear in Deployment.
+//
+// Example:
+//
+//    Deployment(func() {
+//        DeploymentReplicas(3)
+//    })
+//
+func DeploymentReplicas(n int) {
+	if d := deployment(); d != nil {
+		d.Replicas = n
+	}
+}
+
+// DeploymentPort defines the port listened to by the server container for the
+// given transport, "http" or "grpc". The port defaults to the port of the first
+// URI of the server default host that uses the transport.
+//
+// DeploymentPort must appear in Deployment.
+//
+// Example:
+//
+//    Deployment(func() {
+//        DeploymentPort("http", 8080)
+//        DeploymentPort("grpc", 8081)
+//    })
+//
+func DeploymentPort(transport string, port int) {
+	if d := deployment(); d != nil {
+		d.Ports = append(d.Ports, &expr.DeploymentPortExpr{Transport: transport, Port: port})
+	}
+}
+
+// DeploymentEnv defines an environment variable set in the server container.
+//
+// DeploymentEnv must appear in Deployment.
+//
+// Example:
+//
+//    Deployment(func() {
+//        DeploymentEnv("LOG_LEVEL", "info")
+//    })
+//
+func DeploymentEnv(name, value string) {
+	if d := deployment(); d != nil {
+		d.Env = append(d.Env, &expr.EnvVarExpr{Name: name, Value: value})
+	}
+}
+
+// DeploymentCPU defines the amount of CPU requested by the server container and
+// the maximum amount it may use using the Kubernetes quantity syntax, e.g.
+// "100m" for a tenth of a CPU. An empty string leaves the value unset.
+//
+// DeploymentCPU must appear in Deployment.
+//
+// Example:
+//
+//    Deployment(func() {
+//        DeploymentCPU("100m", "1")
+//    })
+//
+func DeploymentCPU(request, limit string) {
+	if d := deployment(); d != nil {
+		d.CPURequest, d.CPULimit = request, limit
+	}
+}
+
+// DeploymentMemory defines the amount of memory requested by the server
+// container and the maximum amount it may use using the Kubernetes quantity
+// syntax, e.g. "64Mi". An empty string leaves the value unset.
+//
+// DeploymentMemory must appear in Deployment.
+//
+// Example:
+//
+//    Deployment(func() {
+//        DeploymentMemory("64Mi", "256Mi")
+//    })
+//
+func DeploymentMemory(request, limit string) {
+	if d := deployment(); d != nil {
+		d.MemoryRequest, d.MemoryLimit = request, limit
+	}
+}
+
+// deployment returns the current deployment expression, it reports an error
+// and returns nil if the current expression is not a deployment.
+func deployment() *expr.DeploymentExpr {
+	d, ok := eval.Current().(*expr.DeploymentExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return nil
+	}
+	return d
+}
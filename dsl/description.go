@@ -8,9 +8,15 @@ import (
 // Description sets the expression description.
 //
 // Description may appear in API, Docs, Type or Attribute.
-// Description may also appear in Response and Files.
+// Description may also appear in Response, Files and Tag.
 //
-// Description accepts one arguments: the description string.
+// Description accepts one or two arguments: the description string or the
+// locale followed by the description string. Descriptions may be defined in
+// multiple locales, the "goa gen" command --locale flag selects the locale of
+// the descriptions used in the generated code and documentation. The
+// description defined without locale, or the first localized description if
+// there is none, is used when no locale is selected or when there is no
+// description for the selected locale.
 //
 // Example:
 //
@@ -18,35 +24,64 @@ import (
 //        Description("Adder API")
 //    })
 //
-func Description(d string) {
+//    Service("adder", func() {
+//        Description("en", "The adder service adds numbers.")
+//        Description("ja", "数値を加算するサービスです。")
+//    })
+//
+func Description(d string, text ...string) {
+	var desc *string
 	switch e := eval.Current().(type) {
 	case *expr.APIExpr:
-		e.Description = d
+		desc = &e.Description
 	case *expr.ServerExpr:
-		e.Description = d
+		desc = &e.Description
 	case *expr.HostExpr:
-		e.Description = d
+		desc = &e.Description
 	case *expr.ServiceExpr:
-		e.Description = d
+		desc = &e.Description
 	case *expr.ResultTypeExpr:
-		e.Description = d
+		desc = &e.Description
 	case *expr.AttributeExpr:
-		e.Description = d
+		desc = &e.Description
 	case *expr.DocsExpr:
-		e.Description = d
+		desc = &e.Description
 	case *expr.MethodExpr:
-		e.Description = d
+		desc = &e.Description
 	case *expr.ExampleExpr:
-		e.Description = d
+		desc = &e.Description
 	case *expr.SchemeExpr:
-		e.Description = d
+		desc = &e.Description
 	case *expr.HTTPResponseExpr:
-		e.Description = d
+		desc = &e.Description
 	case *expr.HTTPFileServerExpr:
-		e.Description = d
+		desc = &e.Description
+	case *expr.HTTPWebhookExpr:
+		desc = &e.Description
 	case *expr.GRPCResponseExpr:
-		e.Description = d
+		desc = &e.Description
+	case *expr.TagExpr:
+		desc = &e.Description
 	default:
 		eval.IncompatibleDSL()
+		return
+	}
+	var locale string
+	switch len(text) {
+	case 0:
+		if !expr.Root.IsLocalized(desc) {
+			*desc = d
+			return
+		}
+	case 1:
+		locale, d = d, text[0]
+		if locale == "" {
+			eval.ReportError("locale cannot be empty")
+			return
+		}
+	default:
+		eval.ReportError("too many arguments given to Description")
+		return
 	}
+	expr.Root.Localize(desc, eval.Current().EvalName(), locale, d)
 }
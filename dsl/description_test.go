@@ -39,3 +39,31 @@ func TestDescription(t *testing.T) {
 func apiDesc(e eval.Expression) string  { return e.(*expr.APIExpr).Description }
 func attrDesc(e eval.Expression) string { return e.(*expr.AttributeExpr).Description }
 func docsDesc(e eval.Expression) string { return e.(*expr.DocsExpr).Description }
+
+func TestDescriptionLocale(t *testing.T) {
+	defer func() { expr.Locale = "" }()
+	cases := map[string]struct {
+		Locale   string
+		DSL      func()
+		Expected string
+	}{
+		"selected": {"ja", func() { Description("en", "hello"); Description("ja", "こんにちは") }, "こんにちは"},
+		"default":  {"ja", func() { Description("en", "hello"); Description("fr", "bonjour") }, "hello"},
+		"plain":    {"fr", func() { Description("hello"); Description("ja", "こんにちは") }, "hello"},
+		"override": {"ja", func() { Description("ja", "こんにちは"); Description("hello") }, "こんにちは"},
+	}
+	for k, tc := range cases {
+		eval.Context = &eval.DSLContext{}
+		expr.Locale = tc.Locale
+		att := &expr.AttributeExpr{}
+
+		eval.Execute(tc.DSL, att)
+
+		if eval.Context.Errors != nil {
+			t.Errorf("%s: Description failed unexpectedly with %s", k, eval.Context.Errors)
+		}
+		if att.Description != tc.Expected {
+			t.Errorf("%s: invalid description, expected %q, got %q", k, tc.Expected, att.Description)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Emits declares that the method emits an event of the given type on the given
+// topic. The service implementation records the events with the generated
+// Emit functions and the events are published once the method completes
+// successfully: the generated UsePublisher method of the service endpoints
+// wraps the endpoints of the methods that emit events with a middleware that
+// calls the generated Publisher interface after the method returns without
+// error. The events are described in the AsyncAPI specification alongside the
+// streaming endpoints.
+//
+// Emits must appear in Method. A method may emit events on multiple topics and
+// multiple methods may emit events on the same topic provided they use the
+// same type.
+//
+// Emits takes the topic name, the event type which must be an object user
+// type and an optional description. The events are encoded in JSON using the
+// attribute names.
+//
+// Example:
+//
+//    var OrderEvent = Type("OrderEvent", func() {
+//        Attribute("id", String)
+//        Attribute("total", Float64)
+//        Required("id")
+//    })
+//
+//    Method("create", func() {
+//        Payload(Order)
+//        Emits("order.created", OrderEvent, "Order created")
+//    })
+//
+func Emits(topic string, event expr.DataType, desc ...string) {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(desc) > 1 {
+		eval.ReportError("too many arguments given to Emits")
+		return
+	}
+	e := &expr.EventExpr{Method: m, Topic: topic, Attribute: &expr.AttributeExpr{Type: event}}
+	if len(desc) == 1 {
+		e.Description = desc[0]
+	}
+	m.Events = append(m.Events, e)
+}
@@ -263,16 +263,20 @@ func Metadata(fn func()) {
 	}
 }
 
-// Trailers defines gRPC trailers in response metadata.
+// Trailers defines gRPC trailers in response metadata or HTTP response
+// trailers.
 //
 // Trailers must appear in a gRPC response expression to describe gRPC trailers
-// in response metadata.
+// in response metadata or in a HTTP response expression to describe the header
+// fields sent after the response body.
 //
 // Trailers takes one argument of function type which lists the attributes
 // that must be set in the trailer response metadata instead of the message.
 // If Trailers is set in the gRPC response expression, it inherits the
 // attribute properties (description, type, meta, validations etc.) from the
-// method result.
+// method result. HTTP trailers behave similarly, the attribute names may use
+// the "name of attribute:name of trailer" syntax to define the trailer names.
+// HTTP trailers must be primitive and require a response body.
 //
 // Example:
 //
@@ -301,6 +305,20 @@ func Metadata(fn func()) {
 //         })
 //     })
 //
+//     Method("download", func() {
+//         Payload(String)
+//         Result(Archive)
+//         HTTP(func() {
+//             GET("/archives/{id}")
+//             Response(StatusOK, func() {
+//                 Trailers(func() {
+//                     Attribute("checksum:Digest") // "checksum" sent in the
+//                                                  // "Digest" trailer
+//                 })
+//             })
+//         })
+//     })
+//
 func Trailers(fn func()) {
 	switch e := eval.Current().(type) {
 	case *expr.GRPCResponseExpr:
@@ -308,6 +326,11 @@ func Trailers(fn func()) {
 		if eval.Execute(fn, attr) {
 			e.Trailers = expr.NewMappedAttributeExpr(attr)
 		}
+	case *expr.HTTPResponseExpr:
+		attr := &expr.AttributeExpr{}
+		if eval.Execute(fn, attr) {
+			e.Trailers = expr.NewMappedAttributeExpr(attr)
+		}
 	default:
 		eval.IncompatibleDSL()
 	}
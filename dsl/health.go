@@ -0,0 +1,65 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// HealthCheck generates the liveness and readiness endpoints of the API
+// servers. The HTTP servers serve the liveness endpoint on "/healthz" and the
+// readiness endpoint on "/readyz" by default. The gRPC servers implement the
+// standard gRPC health service (grpc.health.v1.Health). The readiness endpoints
+// aggregate the dependency checks registered with the goa.Health value given
+// to the servers and report the servers as not ready once they start draining.
+//
+// HealthCheck must appear in API.
+//
+// HealthCheck accepts optional arguments: the liveness path, the readiness path
+// and a function that may set the "swagger:generate" meta to "false" to
+// exclude the endpoints from the OpenAPI specifications.
+//
+// Example:
+//
+//    var _ = API("calc", func() {
+//        HealthCheck("/livez", "/readyz", func() {
+//            Meta("swagger:generate", "false")
+//        })
+//    })
+//
+func HealthCheck(args ...interface{}) {
+	a, ok := eval.Current().(*expr.APIExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	h := &expr.HealthCheckExpr{
+		LivenessPath:  expr.DefaultLivenessPath,
+		ReadinessPath: expr.DefaultReadinessPath,
+	}
+	var paths []string
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case string:
+			paths = append(paths, v)
+		case func():
+			if !eval.Execute(v, h) {
+				return
+			}
+		default:
+			eval.InvalidArgError("string or func()", arg)
+			return
+		}
+	}
+	switch len(paths) {
+	case 0:
+	case 2:
+		h.ReadinessPath = paths[1]
+		fallthrough
+	case 1:
+		h.LivenessPath = paths[0]
+	default:
+		eval.ReportError("too many paths in call to HealthCheck")
+		return
+	}
+	a.HealthCheck = h
+}
@@ -219,6 +219,37 @@ func Produces(args ...string) {
 	}
 }
 
+// MuxerAdapters lists the third party routers for which the code generator
+// generates Muxer adapter packages. Each package exposes a New function that
+// wraps the router into a Muxer so that the generated servers can be mounted
+// directly on the router used by an existing application. The handlers are
+// registered natively with the router so that the router middleware runs
+// before the middleware mounted on the generated servers. The supported
+// routers are "chi", "echo", "gin" and "gorilla". The generated packages
+// import the routers so the corresponding modules must be added to the
+// application dependencies.
+//
+// MuxerAdapters must appear in the HTTP expression of API.
+//
+// MuxerAdapters accepts one or more router names.
+//
+// Example:
+//
+//    API("cellar", func() {
+//        HTTP(func() {
+//            MuxerAdapters("chi", "echo")
+//        })
+//    })
+//
+func MuxerAdapters(routers ...string) {
+	switch e := eval.Current().(type) {
+	case *expr.RootExpr:
+		e.API.HTTP.MuxerAdapters = append(e.API.HTTP.MuxerAdapters, routers...)
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
 // Path defines an API or service base path, i.e. a common HTTP path prefix to
 // all the API or service methods. The path may define wildcards (see GET for a
 // description of the wildcard syntax). The corresponding parameters must be
@@ -260,8 +291,17 @@ func Path(val string) {
 // A wildcard that starts with '{' matches a section of the path (the value in
 // between two slashes).
 //
-// A wildcard that starts with '{*' matches the rest of the path. Such wildcards
-// must terminate the path.
+// A wildcard that starts with '{*' or ends with '*}' matches the rest of the
+// path. Such wildcards must terminate the path. The corresponding payload
+// attribute may be an array in which case each path segment is captured in its
+// own element.
+//
+// Wildcards that start with '{.' (label style) or '{;' (matrix style) define
+// optional path parameters. They must terminate the path and the corresponding
+// payload attributes must be strings. Label style wildcards match a value that
+// follows a dot (e.g. "/report{.format}" matches "/report" and "/report.json")
+// while matrix style wildcards match a semicolon separated name value pair
+// (e.g. "/cars{;color}" matches "/cars" and "/cars;color=blue").
 //
 // GET must appear in a method HTTP function.
 //
@@ -275,6 +315,7 @@ func Path(val string) {
 //             Result(Account)
 //             HTTP(func() {
 //                 GET("/{accountID}/details")
+//                 GET("/{accountID}{.format}")
 //                 GET("/{*accountPath}")
 //             })
 //         })
@@ -344,8 +385,9 @@ func route(method, path string) *expr.RouteExpr {
 // define request headers) or a Response expression (to define the response
 // headers). Header may also appear in a method GRPC expression (to define
 // headers sent in message metadata), or in a Response expression (to define
-// headers sent in result metadata). Finally Header may also appear in a Headers
-// expression.
+// headers sent in result metadata). Header may also appear in a Headers
+// expression. Finally Header may appear in Tenant to define the name of the
+// header that carries the tenant, in this case Header accepts only the name.
 //
 // Header accepts the same arguments as the Attribute function. The header name
 // may define a mapping between the attribute name and the HTTP header name when
@@ -370,6 +412,14 @@ func route(method, path string) *expr.RouteExpr {
 //    })
 //
 func Header(name string, args ...interface{}) {
+	if t, ok := eval.Current().(*expr.TenantExpr); ok {
+		if len(args) > 0 {
+			eval.ReportError("too many arguments given to Header")
+			return
+		}
+		t.Header = name
+		return
+	}
 	h := headers(eval.Current())
 	if h == nil {
 		eval.IncompatibleDSL()
@@ -382,6 +432,72 @@ func Header(name string, args ...interface{}) {
 	h.Remap()
 }
 
+// RetryAfter maps the given result or error attribute to the standard
+// Retry-After response header. The attribute must be an integer (number of
+// seconds) or a string (HTTP date).
+//
+// RetryAfter must appear in a Response expression.
+//
+// RetryAfter accepts one argument: the name of the attribute.
+//
+// Example:
+//
+//    var _ = Service("account", func() {
+//        Error("unavailable", Unavailable)
+//        HTTP(func() {
+//            Response("unavailable", StatusServiceUnavailable, func() {
+//                RetryAfter("retry_in")
+//            })
+//        })
+//    })
+//
+func RetryAfter(name string) {
+	if _, ok := eval.Current().(*expr.HTTPResponseExpr); !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	Header(name + ":Retry-After")
+}
+
+// RateLimit maps the given result or error attributes to the standard
+// RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset response headers.
+// The attributes must be integers, the header is omitted if the corresponding
+// attribute name is empty.
+//
+// RateLimit must appear in a Response expression.
+//
+// RateLimit accepts three arguments: the names of the attributes that hold
+// the request quota, the number of remaining requests and the number of
+// seconds until the quota resets.
+//
+// Example:
+//
+//    var _ = Service("account", func() {
+//        Error("rate_limited", RateLimited)
+//        HTTP(func() {
+//            Response("rate_limited", StatusTooManyRequests, func() {
+//                RetryAfter("retry_in")
+//                RateLimit("limit", "remaining", "reset")
+//            })
+//        })
+//    })
+//
+func RateLimit(limit, remaining, reset string) {
+	if _, ok := eval.Current().(*expr.HTTPResponseExpr); !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if limit != "" {
+		Header(limit + ":RateLimit-Limit")
+	}
+	if remaining != "" {
+		Header(remaining + ":RateLimit-Remaining")
+	}
+	if reset != "" {
+		Header(reset + ":RateLimit-Reset")
+	}
+}
+
 // Params groups a set of Param expressions. It makes it possible to list
 // required parameters using the Required function.
 //
@@ -546,6 +662,30 @@ func MapParams(args ...interface{}) {
 // multipart content into the payload. The example command generates a default
 // implementation for the user decoder and encoder.
 //
+// If the payload attributes are primitives, arrays of primitives or types that
+// do not contain objects then goa also generates default functions that are
+// used when the user provided functions are nil. The defaults map each
+// attribute to a part named after the attribute and Bytes attributes to file
+// parts. The default decoder reads the file parts in memory unless the Go type
+// of the attribute is set to io.Reader or io.ReadCloser with the
+// "struct:field:type" meta, in which case the parts larger than 10 MiB are
+// written to temporary files that are removed when the reader is closed.
+//
+// Example:
+//
+//    Method("upload", func() {
+//        Payload(func() {
+//            Attribute("name", String)
+//            Attribute("file", Bytes, func() {
+//                Meta("struct:field:type", "io.ReadCloser", "io")
+//            })
+//        })
+//        HTTP(func() {
+//            POST("/upload")
+//            MultipartRequest()
+//        })
+//    })
+//
 func MultipartRequest() {
 	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
 	if !ok {
@@ -555,12 +695,55 @@ func MultipartRequest() {
 	e.MultipartRequest = true
 }
 
+// FormEncodedRequest indicates that the body of HTTP requests made to the
+// method uses the "application/x-www-form-urlencoded" encoding, for example
+// to implement OAuth2 token endpoints or to handle HTML form submissions.
+//
+// FormEncodedRequest must appear in a HTTP endpoint expression.
+//
+// The request body must be an object. Nested objects and maps are encoded
+// using the bracket syntax (e.g. "address[city]=Paris"), arrays of primitive
+// values repeat the key and arrays of objects use indices (e.g.
+// "items[0][id]=1"). The generated server code decodes the form content
+// prior to applying the default values and validations defined in the design
+// and the generated client code encodes the request body and sets the
+// Content-Type header accordingly. The generated OpenAPI specification lists
+// "application/x-www-form-urlencoded" as the media type consumed by the
+// endpoint.
+//
+// Example:
+//
+//    Method("token", func() {
+//        Payload(func() {
+//            Attribute("grant_type", String)
+//            Attribute("code", String)
+//            Attribute("redirect_uri", String)
+//            Required("grant_type")
+//        })
+//        Result(Token)
+//        HTTP(func() {
+//            POST("/token")
+//            FormEncodedRequest()
+//        })
+//    })
+//
+func FormEncodedRequest() {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	e.FormEncodedRequest = true
+}
+
 // Body describes a HTTP request or response body.
 //
 // Body must appear in a Method HTTP expression to define the request body or in
 // an Error or Result HTTP expression to define the response body. If Body is
 // absent then the body is built using the HTTP endpoint request or response
 // type attributes not used to describe parameters (request only) or headers.
+// Body may also appear in a Webhook expression to define the webhook request
+// body using a user type or a function listing the body attributes.
 //
 // Body accepts one argument which describes the shape of the body, it can be:
 //
@@ -632,6 +815,11 @@ func Body(args ...interface{}) {
 			e.Body = att
 		}
 		kind = "Response"
+	case *expr.HTTPWebhookExpr:
+		setter = func(att *expr.AttributeExpr) {
+			e.Body = att
+		}
+		kind = "Webhook"
 	default:
 		eval.IncompatibleDSL()
 		return
@@ -644,6 +832,10 @@ func Body(args ...interface{}) {
 	)
 	switch a := args[0].(type) {
 	case string:
+		if ref == nil {
+			eval.ReportError("%s body must be defined with a type or a DSL", kind)
+			return
+		}
 		if !expr.IsObject(ref.Type) {
 			eval.ReportError("%s type must be an object with an attribute with name %#v, got %T", kind, a, ref.Type)
 			return
@@ -675,6 +867,11 @@ func Body(args ...interface{}) {
 		}
 	case func():
 		fn = a
+		if kind == "Webhook" {
+			// webhook bodies are not derived from a payload
+			attr = &expr.AttributeExpr{}
+			break
+		}
 		if ref == nil {
 			eval.ReportError("Body is set but Payload is not defined")
 			return
@@ -733,16 +930,33 @@ func CanonicalMethod(name string) {
 	r.CanonicalEndpointName = name
 }
 
-// Tag identifies a method result type field and a value. The algorithm that
-// encodes the result into the HTTP response iterates through the responses and
-// uses the first response that has a matching tag (that is for which the result
-// field with the tag name matches the tag value). There must be one and only
-// one response with no Tag expression, this response is used when no other tag
-// matches.
-//
-// Tag must appear in Response.
-//
-// Tag accepts two arguments: the name of the field and the (string) value.
+// Tag identifies a method result type field and a value when used in a
+// Response expression, defines a documentation tag when used in an API or Tag
+// expression and tags a method or all the methods of a service when used in a
+// Method or Service expression.
+//
+// In a Response expression the algorithm that encodes the result into the
+// HTTP response iterates through the responses and uses the first response
+// that has a matching tag (that is for which the result field with the tag
+// name matches the tag value). There must be one and only one response with no
+// Tag expression, this response is used when no other tag matches.
+//
+// In an API expression Tag defines a tag listed in the OpenAPI specification
+// with its description and external documentation. Tags defined in another
+// tag are listed in the x-tagGroups extension under the name of the top level
+// tag so that the documentation tools that support tag groups (e.g. Redoc)
+// render the tags hierarchically.
+//
+// In a Method or Service expression Tag tags the corresponding OpenAPI
+// operations with the given name instead of the default service name. This is
+// equivalent to setting the "swagger:tag:name" meta.
+//
+// Tag must appear in Response, API, Tag, Service or Method.
+//
+// Tag accepts two arguments in a Response expression: the name of the field
+// and the (string) value. Tag accepts the name of the tag and an optional
+// defining DSL in an API or Tag expression and the name of the tag in a
+// Service or Method expression.
 //
 // Example:
 //
@@ -764,13 +978,76 @@ func CanonicalMethod(name string) {
 //        })
 //    })
 //
-func Tag(name, value string) {
-	res, ok := eval.Current().(*expr.HTTPResponseExpr)
-	if !ok {
+//    var _ = API("cellar", func() {
+//        Tag("billing", func() {
+//            Description("Billing operations")
+//            Docs(func() {
+//                URL("https://example.com/billing")
+//            })
+//            Tag("invoices", func() {
+//                Description("Invoice management")
+//            })
+//        })
+//    })
+//
+//    var _ = Service("invoicing", func() {
+//        Method("pay", func() {
+//            Tag("invoices")
+//        })
+//    })
+//
+func Tag(name string, args ...interface{}) {
+	switch e := eval.Current().(type) {
+	case *expr.HTTPResponseExpr:
+		if len(args) != 1 {
+			eval.ReportError("Tag in a Response expression requires a name and a value")
+			return
+		}
+		value, ok := args[0].(string)
+		if !ok {
+			eval.InvalidArgError("string", args[0])
+			return
+		}
+		e.Tag = [2]string{name, value}
+	case *expr.APIExpr:
+		if t := newTag(name, nil, args); t != nil {
+			e.Tags = append(e.Tags, t)
+		}
+	case *expr.TagExpr:
+		if t := newTag(name, e, args); t != nil {
+			e.Tags = append(e.Tags, t)
+		}
+	case *expr.ServiceExpr, *expr.MethodExpr:
+		if len(args) > 0 {
+			eval.ReportError("too many arguments")
+			return
+		}
+		Meta("swagger:tag:" + name)
+	default:
 		eval.IncompatibleDSL()
-		return
 	}
-	res.Tag = [2]string{name, value}
+}
+
+// newTag returns the tag with the given name and parent initialized with the
+// optional DSL given in args.
+func newTag(name string, parent *expr.TagExpr, args []interface{}) *expr.TagExpr {
+	t := &expr.TagExpr{Name: name, Parent: parent}
+	if len(args) == 0 {
+		return t
+	}
+	if len(args) > 1 {
+		eval.ReportError("too many arguments")
+		return nil
+	}
+	fn, ok := args[0].(func())
+	if !ok {
+		eval.InvalidArgError("function", args[0])
+		return nil
+	}
+	if !eval.Execute(fn, t) {
+		return nil
+	}
+	return t
 }
 
 // ContentType sets the value of the Content-Type response header.
@@ -0,0 +1,73 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Benchmarks enables the generation of benchmarks for the HTTP endpoints. The
+// code generator generates a bench_test.go file in each HTTP server package
+// with three benchmarks per endpoint: one that decodes requests, one that
+// encodes responses and one that runs the complete handler using the net/http
+// httptest package. The requests and responses are built from the design
+// examples. Benchmarks are not generated for streaming, multipart, form encoded
+// and proxied endpoints.
+//
+// Use MaxAllocs to generate tests that fail when the number of allocations
+// made to handle a request exceeds a budget.
+//
+// Benchmarks must appear in the HTTP expression of API.
+//
+// Example:
+//
+//    var _ = API("calc", func() {
+//        HTTP(func() {
+//            Benchmarks()
+//        })
+//    })
+//
+func Benchmarks() {
+	switch e := eval.Current().(type) {
+	case *expr.RootExpr:
+		e.API.HTTP.Benchmarks = true
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// MaxAllocs sets the maximum number of allocations made by the generated server
+// code to handle a request. The code generator generates an alloc_budget_test.go
+// file in the HTTP server package with a test per endpoint that fails when
+// handling a request built from the design examples allocates more. The
+// allocations made to build the request and record the response are not
+// counted.
+//
+// MaxAllocs must appear in a method HTTP expression and requires Benchmarks to
+// be enabled.
+//
+// MaxAllocs accepts a single argument which is the allocation budget.
+//
+// Example:
+//
+//    var _ = Service("calc", func() {
+//        Method("add", func() {
+//            Payload(Operands)
+//            Result(Int)
+//            HTTP(func() {
+//                GET("/add/{a}/{b}")
+//                MaxAllocs(150)
+//            })
+//        })
+//    })
+//
+func MaxAllocs(n int) {
+	if n <= 0 {
+		eval.ReportError("invalid maximum allocations: %d must be greater than zero", n)
+		return
+	}
+	if e, ok := eval.Current().(*expr.HTTPEndpointExpr); ok {
+		e.MaxAllocs = n
+		return
+	}
+	eval.IncompatibleDSL()
+}
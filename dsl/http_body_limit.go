@@ -0,0 +1,93 @@
+package dsl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// BodyLimit sets the maximum size of the HTTP request bodies. The generated
+// server code wraps the request body with a reader that stops reading once the
+// limit is reached and responds with a 413 Request Entity Too Large status. The
+// corresponding "request_too_large" error and HTTP response are added to the
+// design unless already defined so that they appear in the generated code and
+// documentation.
+//
+// BodyLimit must appear in a service or method HTTP expression. The limit set
+// on a method overrides the limit set on the service.
+//
+// BodyLimit accepts a single argument which is either the limit in bytes or a
+// string using one of the "B", "KB", "MB" or "GB" units (e.g. "512KB", "2MB").
+// Units are powers of 1024.
+//
+// Example:
+//
+//    var _ = Service("upload", func() {
+//        HTTP(func() {
+//            BodyLimit("2MB")
+//        })
+//        Method("upload", func() {
+//            Payload(Bytes)
+//            HTTP(func() {
+//                POST("/")
+//                BodyLimit(10 * 1024 * 1024)
+//            })
+//        })
+//    })
+//
+func BodyLimit(limit interface{}) {
+	n, err := parseByteSize(limit)
+	if err != nil {
+		eval.ReportError("invalid body limit: %s", err)
+		return
+	}
+	switch e := eval.Current().(type) {
+	case *expr.HTTPServiceExpr:
+		e.BodyLimit = n
+	case *expr.HTTPEndpointExpr:
+		e.BodyLimit = n
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// parseByteSize converts an integer or a string with an optional unit into a
+// number of bytes.
+func parseByteSize(v interface{}) (int64, error) {
+	var n int64
+	switch actual := v.(type) {
+	case int:
+		n = int64(actual)
+	case int64:
+		n = actual
+	case uint:
+		n = int64(actual)
+	case string:
+		s := strings.ToUpper(strings.TrimSpace(actual))
+		mult := int64(1)
+		for _, u := range []struct {
+			suffix string
+			mult   int64
+		}{{"KB", 1 << 10}, {"MB", 1 << 20}, {"GB", 1 << 30}, {"B", 1}} {
+			if strings.HasSuffix(s, u.suffix) {
+				s = strings.TrimSpace(strings.TrimSuffix(s, u.suffix))
+				mult = u.mult
+				break
+			}
+		}
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a valid size", actual)
+		}
+		n = i * mult
+	default:
+		return 0, fmt.Errorf("%#v must be an integer or a string", v)
+	}
+	if n <= 0 {
+		return 0, fmt.Errorf("%#v must be greater than zero", v)
+	}
+	return n, nil
+}
@@ -0,0 +1,161 @@
+package dsl
+
+import (
+	"time"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// ClientTimeout sets the timeout applied by the generated HTTP clients to the
+// requests made to the endpoints. The timeout bounds the total time spent
+// making a request including retries and reading the response body. Requests
+// that time out fail with an error wrapping context.DeadlineExceeded.
+//
+// ClientTimeout must appear in a service or method HTTP expression. The timeout
+// set on a method overrides the timeout set on the service. ClientTimeout does
+// not apply to streaming methods.
+//
+// ClientTimeout accepts a single argument which is the timeout duration as
+// accepted by time.ParseDuration (e.g. "500ms", "5s").
+//
+// Example:
+//
+//    var _ = Service("catalog", func() {
+//        HTTP(func() {
+//            ClientTimeout("5s")
+//        })
+//        Method("search", func() {
+//            HTTP(func() {
+//                GET("/search")
+//                ClientTimeout("30s")
+//            })
+//        })
+//    })
+//
+func ClientTimeout(timeout string) {
+	d, err := time.ParseDuration(timeout)
+	if err != nil {
+		eval.ReportError("invalid client timeout: %s", err)
+		return
+	}
+	switch e := eval.Current().(type) {
+	case *expr.HTTPServiceExpr:
+		e.ClientTimeout = d
+	case *expr.HTTPEndpointExpr:
+		e.ClientTimeout = d
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// ClientRetry defines how the generated HTTP clients retry failed requests.
+// Requests are retried when the transport returns an error or the response
+// status code is 502, 503 or 504 (see RetryOn). The time waited between two
+// attempts starts at 100ms and doubles up to 1s by default (see Backoff). The
+// request body is buffered so that it can be sent again.
+//
+// ClientRetry must appear in a service or method HTTP expression. The policy
+// set on a method overrides the policy set on the service. Policies set on a
+// service only apply to the methods whose routes all use idempotent HTTP
+// methods (GET, HEAD, PUT, DELETE, OPTIONS or TRACE) while policies set on a
+// method that uses a non-idempotent HTTP method cause a validation error.
+// ClientRetry does not apply to streaming methods.
+//
+// ClientRetry accepts the maximum number of attempts including the initial
+// request as first argument and an optional DSL as second argument. The DSL
+// may use Backoff and RetryOn.
+//
+// Example:
+//
+//    Method("show", func() {
+//        Payload(String)
+//        Result(Account)
+//        HTTP(func() {
+//            GET("/{id}")
+//            ClientRetry(3, func() {
+//                Backoff("200ms", "2s")
+//                RetryOn(StatusTooManyRequests, StatusServiceUnavailable)
+//            })
+//        })
+//    })
+//
+func ClientRetry(maxAttempts int, fns ...func()) {
+	if len(fns) > 1 {
+		eval.ReportError("too many arguments given to ClientRetry")
+		return
+	}
+	r := &expr.RetryExpr{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+	}
+	switch e := eval.Current().(type) {
+	case *expr.HTTPServiceExpr:
+		r.Parent = e
+		e.ClientRetry = r
+	case *expr.HTTPEndpointExpr:
+		r.Parent = e
+		e.ClientRetry = r
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(fns) > 0 {
+		eval.Execute(fns[0], r)
+	}
+}
+
+// Backoff sets the time waited before the first retry and the maximum time
+// waited between two attempts. The time waited doubles after each attempt.
+//
+// Backoff must appear in ClientRetry.
+//
+// Backoff accepts the initial and maximum durations as accepted by
+// time.ParseDuration.
+//
+// Example:
+//
+//    ClientRetry(5, func() {
+//        Backoff("50ms", "5s")
+//    })
+//
+func Backoff(initial, max string) {
+	r, ok := eval.Current().(*expr.RetryExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	i, err := time.ParseDuration(initial)
+	if err != nil {
+		eval.ReportError("invalid initial backoff: %s", err)
+		return
+	}
+	m, err := time.ParseDuration(max)
+	if err != nil {
+		eval.ReportError("invalid maximum backoff: %s", err)
+		return
+	}
+	r.InitialBackoff = i
+	r.MaxBackoff = m
+}
+
+// RetryOn lists the response status codes that cause a retry. Requests are
+// retried on 502, 503 and 504 by default.
+//
+// RetryOn must appear in ClientRetry.
+//
+// Example:
+//
+//    ClientRetry(3, func() {
+//        RetryOn(StatusTooManyRequests, StatusServiceUnavailable)
+//    })
+//
+func RetryOn(codes ...int) {
+	r, ok := eval.Current().(*expr.RetryExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	r.StatusCodes = append(r.StatusCodes, codes...)
+}
@@ -0,0 +1,129 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// defaultCompressMinSize is the default minimum size of compressed responses.
+const defaultCompressMinSize = 1024
+
+// Compress enables the compression of the HTTP responses. The generated server
+// code compresses the responses using the first encoding listed in Compress
+// that the client accepts as indicated by the request Accept-Encoding header.
+// Responses that are smaller than the minimum size (1KB by default), that
+// already have a Content-Encoding header or whose content type is not
+// compressible are sent as is.
+//
+// The "gzip" and "deflate" encodings are implemented by the goa runtime. Other
+// encodings such as "br" require registering an implementation with the goa
+// http package RegisterCompressor function, responses are not compressed with
+// encodings that have no registered implementation.
+//
+// Compress must appear in a HTTP expression of an API, a service or a method.
+// Settings defined on a method override the settings defined on the service
+// which override the settings defined on the API.
+//
+// Compress accepts the list of encodings in order of preference and an
+// optional DSL as last argument. The encodings default to "gzip". The DSL may
+// use CompressTypes and CompressMinSize to further configure the compression.
+//
+// Example:
+//
+//    var _ = API("calc", func() {
+//        HTTP(func() {
+//            Compress("br", "gzip", func() {
+//                CompressTypes("application/json", "text/*")
+//                CompressMinSize("4KB")
+//            })
+//        })
+//    })
+//
+func Compress(args ...interface{}) {
+	c := &expr.HTTPCompressExpr{MinSize: defaultCompressMinSize}
+	var fn func()
+	for i, arg := range args {
+		switch a := arg.(type) {
+		case string:
+			c.Encodings = append(c.Encodings, a)
+		case func():
+			if i != len(args)-1 {
+				eval.ReportError("DSL must be the last argument of Compress")
+				return
+			}
+			fn = a
+		default:
+			eval.InvalidArgError("string or function", arg)
+			return
+		}
+	}
+	if len(c.Encodings) == 0 {
+		c.Encodings = []string{"gzip"}
+	}
+	switch e := eval.Current().(type) {
+	case *expr.RootExpr:
+		c.Parent = e.API.HTTP
+		e.API.HTTP.Compression = c
+	case *expr.HTTPServiceExpr:
+		c.Parent = e
+		e.Compression = c
+	case *expr.HTTPEndpointExpr:
+		c.Parent = e
+		e.Compression = c
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	if fn != nil {
+		eval.Execute(fn, c)
+	}
+}
+
+// CompressTypes lists the media types of the responses that are compressed.
+// A media type may use a wildcard subtype such as "text/*". By default the
+// textual media types, JSON, XML and JavaScript are compressed.
+//
+// CompressTypes must appear in Compress.
+//
+// Example:
+//
+//    Compress("gzip", func() {
+//        CompressTypes("application/json", "text/*")
+//    })
+//
+func CompressTypes(types ...string) {
+	c, ok := eval.Current().(*expr.HTTPCompressExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	c.ContentTypes = append(c.ContentTypes, types...)
+}
+
+// CompressMinSize sets the minimum size of the compressed responses, smaller
+// responses are sent as is. The default is 1KB.
+//
+// CompressMinSize must appear in Compress.
+//
+// CompressMinSize accepts a single argument which is either the size in bytes
+// or a string using one of the "B", "KB", "MB" or "GB" units (e.g. "4KB").
+//
+// Example:
+//
+//    Compress("gzip", func() {
+//        CompressMinSize("4KB")
+//    })
+//
+func CompressMinSize(size interface{}) {
+	c, ok := eval.Current().(*expr.HTTPCompressExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	n, err := parseByteSize(size)
+	if err != nil {
+		eval.ReportError("invalid minimum size: %s", err)
+		return
+	}
+	c.MinSize = n
+}
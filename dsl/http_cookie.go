@@ -0,0 +1,270 @@
+package dsl
+
+import (
+	"strconv"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Values accepted by CookieSameSite.
+const (
+	// CookieSameSiteLax sends the cookie with same site requests and top
+	// level navigations from other sites.
+	CookieSameSiteLax = "Lax"
+	// CookieSameSiteStrict only sends the cookie with same site requests.
+	CookieSameSiteStrict = "Strict"
+	// CookieSameSiteNone sends the cookie with all requests, such cookies
+	// must also be Secure.
+	CookieSameSiteNone = "None"
+)
+
+// Cookie identifies a HTTP request or response cookie attribute. The cookie
+// value is serialized from the attribute value, the attribute must thus be a
+// string, a boolean or a number. The properties (description, type, validation
+// etc.) of a cookie are inherited from the request or response type attribute
+// with the same name by default.
+//
+// Cookie must appear in a method HTTP expression (to define request cookies)
+// or a Response expression (to define the cookies set by the response). The
+// generated server code reads the request cookies and sets the response
+// cookies using the attributes defined with CookieMaxAge, CookieDomain,
+// CookiePath, CookieSecure, CookieHTTPOnly, CookieSameSite and
+// CookiePartitioned. The generated client code sends the request cookies and
+// reads the response cookies, the cookie jar of the HTTP client if any stores
+// the cookies according to their attributes.
+//
+// Cookie accepts the same arguments as the Attribute function. The cookie name
+// may define a mapping between the attribute name and the cookie name when
+// they differ. The mapping syntax is "name of attribute:name of cookie".
+//
+// Example:
+//
+//    var _ = Service("account", func() {
+//        Method("login", func() {
+//            Payload(Credentials)
+//            Result(Session)
+//            HTTP(func() {
+//                POST("/login")
+//                Cookie("locale")
+//                Response(StatusOK, func() {
+//                    Cookie("session_id:SID") // Sets the "SID" cookie with
+//                                             // the session_id attribute
+//                    CookieMaxAge(3600)
+//                    CookieSecure()
+//                    CookieHTTPOnly()
+//                    CookieSameSite(CookieSameSiteLax)
+//                })
+//            })
+//        })
+//    })
+//
+func Cookie(name string, args ...interface{}) {
+	c := cookies(eval.Current())
+	if c == nil {
+		eval.IncompatibleDSL()
+		return
+	}
+	if name == "" {
+		eval.ReportError("cookie name cannot be empty")
+	}
+	eval.Execute(func() { Attribute(name, args...) }, c.AttributeExpr)
+	c.Remap()
+}
+
+// CookieMaxAge sets the cookie Max-Age attribute, the number of seconds until
+// the cookie expires. A negative value deletes the cookie.
+//
+// CookieMaxAge must appear in a Response expression.
+//
+// Example:
+//
+//    Response(StatusOK, func() {
+//        Cookie("session_id:SID")
+//        CookieMaxAge(3600)
+//    })
+//
+func CookieMaxAge(seconds int) {
+	if seconds == 0 {
+		eval.ReportError("invalid cookie max age: must not be zero")
+		return
+	}
+	setCookieMeta(expr.CookieMaxAgeKey, strconv.Itoa(seconds))
+}
+
+// CookieDomain sets the cookie Domain attribute, the host to which the cookie
+// is sent.
+//
+// CookieDomain must appear in a Response expression.
+//
+// Example:
+//
+//    Response(StatusOK, func() {
+//        Cookie("session_id:SID")
+//        CookieDomain("goa.design")
+//    })
+//
+func CookieDomain(domain string) {
+	setCookieMeta(expr.CookieDomainKey, domain)
+}
+
+// CookiePath sets the cookie Path attribute, the path that must exist in the
+// request URL for the client to send the cookie.
+//
+// CookiePath must appear in a Response expression.
+//
+// Example:
+//
+//    Response(StatusOK, func() {
+//        Cookie("session_id:SID")
+//        CookiePath("/account")
+//    })
+//
+func CookiePath(path string) {
+	setCookieMeta(expr.CookiePathKey, path)
+}
+
+// CookieSecure sets the cookie Secure attribute so that clients only send the
+// cookie with requests made over HTTPS.
+//
+// CookieSecure must appear in a Response expression.
+//
+// Example:
+//
+//    Response(StatusOK, func() {
+//        Cookie("session_id:SID")
+//        CookieSecure()
+//    })
+//
+func CookieSecure() {
+	setCookieMeta(expr.CookieSecureKey, "")
+}
+
+// CookieHTTPOnly sets the cookie HttpOnly attribute so that browsers do not
+// expose the cookie to scripts.
+//
+// CookieHTTPOnly must appear in a Response expression.
+//
+// Example:
+//
+//    Response(StatusOK, func() {
+//        Cookie("session_id:SID")
+//        CookieHTTPOnly()
+//    })
+//
+func CookieHTTPOnly() {
+	setCookieMeta(expr.CookieHTTPOnlyKey, "")
+}
+
+// CookieSameSite sets the cookie SameSite attribute which controls whether
+// browsers send the cookie with cross site requests. The value must be one of
+// CookieSameSiteLax, CookieSameSiteStrict or CookieSameSiteNone, cookies using
+// CookieSameSiteNone must also be secure.
+//
+// CookieSameSite must appear in a Response expression.
+//
+// Example:
+//
+//    Response(StatusOK, func() {
+//        Cookie("session_id:SID")
+//        CookieSecure()
+//        CookieSameSite(CookieSameSiteNone)
+//    })
+//
+func CookieSameSite(mode string) {
+	switch mode {
+	case CookieSameSiteLax, CookieSameSiteStrict, CookieSameSiteNone:
+	default:
+		eval.ReportError("invalid cookie SameSite value %q, must be one of %q, %q or %q", mode, CookieSameSiteLax, CookieSameSiteStrict, CookieSameSiteNone)
+		return
+	}
+	setCookieMeta(expr.CookieSameSiteKey, mode)
+}
+
+// CookiePartitioned sets the cookie Partitioned attribute so that browsers
+// store the cookie in a separate jar for each top level site (CHIPS).
+// Partitioned cookies must also be secure.
+//
+// CookiePartitioned must appear in a Response expression.
+//
+// Example:
+//
+//    Response(StatusOK, func() {
+//        Cookie("widget_id")
+//        CookieSecure()
+//        CookiePartitioned()
+//    })
+//
+func CookiePartitioned() {
+	setCookieMeta(expr.CookiePartitionedKey, "")
+}
+
+// CookieSigned indicates that the cookie values are encoded with the cookie
+// codec given to the generated server. The codec signs or encrypts the values
+// written to the response cookies and verifies the values read from the
+// request cookies, see goahttp.CookieCodec. Signed cookies must be strings,
+// the generated clients send and receive their encoded values as is.
+//
+// CookieSigned must appear in a method HTTP expression (to sign the request
+// cookies) or in a Response expression (to sign the response cookies).
+//
+// Example:
+//
+//    Method("logout", func() {
+//        Payload(func() {
+//            Attribute("session_id", String)
+//        })
+//        HTTP(func() {
+//            POST("/logout")
+//            Cookie("session_id:SID")
+//            CookieSigned()
+//        })
+//    })
+//
+func CookieSigned() {
+	switch eval.Current().(type) {
+	case *expr.HTTPEndpointExpr, *expr.HTTPResponseExpr:
+		setMeta(cookies(eval.Current()), expr.CookieSignedKey, "")
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// setCookieMeta records the cookie attribute with the given meta key on the
+// cookies of the current response expression.
+func setCookieMeta(key, value string) {
+	r, ok := eval.Current().(*expr.HTTPResponseExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	setMeta(cookies(r), key, value)
+}
+
+// setMeta sets the value of the given meta key on the cookies mapped
+// attribute, it overrides any previous value.
+func setMeta(c *expr.MappedAttributeExpr, key, value string) {
+	if c.Meta == nil {
+		c.Meta = expr.MetaExpr{}
+	}
+	c.Meta[key] = []string{value}
+}
+
+// cookies returns the mapped attribute containing the cookies for the given
+// expression if it's either an endpoint or a response - nil otherwise.
+func cookies(exp eval.Expression) *expr.MappedAttributeExpr {
+	switch e := exp.(type) {
+	case *expr.HTTPEndpointExpr:
+		if e.Cookies == nil {
+			e.Cookies = expr.NewEmptyMappedAttributeExpr()
+		}
+		return e.Cookies
+	case *expr.HTTPResponseExpr:
+		if e.Cookies == nil {
+			e.Cookies = expr.NewEmptyMappedAttributeExpr()
+		}
+		return e.Cookies
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,190 @@
+package dsl
+
+import (
+	"strings"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Origin defines a Cross-Origin Resource Sharing (CORS) policy for requests
+// made from the given origin. The generated server code takes care of adding
+// the CORS response headers to requests made to the endpoints and mounts
+// handlers that respond to the OPTIONS preflight requests made to the endpoint
+// paths. The policy is also reflected in the generated OpenAPI specification
+// via the "x-cors" extension.
+//
+// Origin must appear in a HTTP expression of an API, a service or a method.
+// Policies defined on a method override the policies defined on the service
+// which override the policies defined on the API.
+//
+// Origin accepts the origin as first argument and an optional DSL as second
+// argument. The origin may be "*" to match all origins, a specific origin such
+// as "https://goa.design" or a regular expression delimited with "/" such as
+// "/.*goa\.design/". The DSL may use AllowMethods, AllowHeaders, ExposeHeaders,
+// MaxAge, AllowCredentials and AllowPrivateNetwork to further configure the
+// policy.
+//
+// Example:
+//
+//    var _ = API("calc", func() {
+//        HTTP(func() {
+//            Origin("/.*localhost.*/", func() {
+//                AllowMethods("GET", "POST")
+//                AllowHeaders("X-Shared-Secret")
+//                ExposeHeaders("X-Time")
+//                MaxAge(600)
+//                AllowCredentials()
+//                AllowPrivateNetwork()
+//            })
+//        })
+//    })
+//
+func Origin(origin string, fns ...func()) {
+	if len(fns) > 1 {
+		eval.ReportError("too many arguments given to Origin")
+		return
+	}
+	cors := &expr.CORSExpr{Origin: origin}
+	switch e := eval.Current().(type) {
+	case *expr.RootExpr:
+		cors.Parent = e.API.HTTP
+		e.API.HTTP.Origins = append(e.API.HTTP.Origins, cors)
+	case *expr.HTTPServiceExpr:
+		cors.Parent = e
+		e.Origins = append(e.Origins, cors)
+	case *expr.HTTPEndpointExpr:
+		cors.Parent = e
+		e.Origins = append(e.Origins, cors)
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(fns) > 0 {
+		eval.Execute(fns[0], cors)
+	}
+}
+
+// AllowMethods lists the HTTP methods that the origin may use. By default the
+// methods of the endpoint routes are allowed.
+//
+// AllowMethods must appear in Origin.
+//
+// Example:
+//
+//    Origin("https://goa.design", func() {
+//        AllowMethods("GET", "PUT")
+//    })
+//
+func AllowMethods(methods ...string) {
+	cors, ok := eval.Current().(*expr.CORSExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	for _, m := range methods {
+		cors.Methods = append(cors.Methods, strings.ToUpper(m))
+	}
+}
+
+// AllowHeaders lists the request headers that the origin may send. The special
+// value "*" allows all headers.
+//
+// AllowHeaders must appear in Origin.
+//
+// Example:
+//
+//    Origin("https://goa.design", func() {
+//        AllowHeaders("Authorization", "X-Request-Id")
+//    })
+//
+func AllowHeaders(headers ...string) {
+	cors, ok := eval.Current().(*expr.CORSExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	cors.Headers = append(cors.Headers, headers...)
+}
+
+// ExposeHeaders lists the response headers that the origin may read.
+//
+// ExposeHeaders must appear in Origin.
+//
+// Example:
+//
+//    Origin("https://goa.design", func() {
+//        ExposeHeaders("X-Time", "Location")
+//    })
+//
+func ExposeHeaders(headers ...string) {
+	cors, ok := eval.Current().(*expr.CORSExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	cors.Exposed = append(cors.Exposed, headers...)
+}
+
+// MaxAge sets the number of seconds clients may cache the response to
+// preflight requests.
+//
+// MaxAge must appear in Origin.
+//
+// Example:
+//
+//    Origin("https://goa.design", func() {
+//        MaxAge(600)
+//    })
+//
+func MaxAge(seconds uint) {
+	cors, ok := eval.Current().(*expr.CORSExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	cors.MaxAge = seconds
+}
+
+// AllowCredentials indicates that the origin may send credentials such as
+// cookies or authorization headers. Credentials cannot be allowed for the
+// wildcard origin "*".
+//
+// AllowCredentials must appear in Origin.
+//
+// Example:
+//
+//    Origin("https://goa.design", func() {
+//        AllowCredentials()
+//    })
+//
+func AllowCredentials() {
+	cors, ok := eval.Current().(*expr.CORSExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	cors.Credentials = true
+}
+
+// AllowPrivateNetwork indicates that the origin may access the endpoints from
+// a less private network. The generated preflight handlers respond with the
+// "Access-Control-Allow-Private-Network" header when the request includes the
+// "Access-Control-Request-Private-Network" header.
+//
+// AllowPrivateNetwork must appear in Origin.
+//
+// Example:
+//
+//    Origin("https://goa.design", func() {
+//        AllowPrivateNetwork()
+//    })
+//
+func AllowPrivateNetwork() {
+	cors, ok := eval.Current().(*expr.CORSExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	cors.PrivateNetwork = true
+}
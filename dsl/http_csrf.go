@@ -0,0 +1,142 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Values accepted by CSRF.
+const (
+	// CSRFDoubleSubmit issues a random token in a cookie and requires
+	// unsafe requests to send the same value in a header.
+	CSRFDoubleSubmit = expr.CSRFDoubleSubmit
+	// CSRFSynchronizer derives the token from the session cookie with a
+	// secret key and requires unsafe requests to send it in a header.
+	CSRFSynchronizer = expr.CSRFSynchronizer
+	// CSRFDisabled disables the CSRF protection.
+	CSRFDisabled = expr.CSRFDisabled
+)
+
+// CSRF configures the Cross-Site Request Forgery protection of the endpoints
+// that authenticate requests with cookies, that is endpoints whose security
+// attribute (API key, JWT or OAuth2 access token) is mapped to a request
+// cookie with Cookie. Such endpoints are protected using the double-submit
+// mode by default.
+//
+// The generated server code includes a ProtectCSRF method that wraps the
+// handlers of the protected endpoints with a middleware that issues the token
+// in the responses to safe requests (GET, HEAD, OPTIONS and TRACE) and rejects
+// the unsafe requests that do not carry a valid token with a 403 response. The
+// generated example server calls it before mounting the handlers. In the
+// synchronizer mode the method accepts the secret used to derive the tokens
+// and fails if it is empty. The token header is also documented in the generated OpenAPI specification. Go
+// clients may use goahttp.CSRFDoer to send the tokens issued by the server.
+//
+// CSRF must appear in a HTTP expression of an API, a service or a method.
+// Settings defined on a method override the settings defined on the service
+// which override the settings defined on the API.
+//
+// CSRF accepts the mode as first argument, one of CSRFDoubleSubmit,
+// CSRFSynchronizer or CSRFDisabled, and an optional DSL as second argument.
+// The DSL may use CSRFHeader and CSRFCookie to change the names of the header
+// and cookie that carry the token.
+//
+// Example:
+//
+//    var _ = Service("account", func() {
+//        Security(SessionAuth)
+//        HTTP(func() {
+//            CSRF(CSRFSynchronizer, func() {
+//                CSRFHeader("X-XSRF-Token")
+//            })
+//        })
+//        Method("update", func() {
+//            Payload(func() {
+//                APIKey("session", "key", String)
+//                Attribute("name", String)
+//            })
+//            HTTP(func() {
+//                PUT("/")
+//                Cookie("key:SID")
+//            })
+//        })
+//        Method("logout", func() {
+//            HTTP(func() {
+//                POST("/logout")
+//                CSRF(CSRFDisabled)
+//            })
+//        })
+//    })
+//
+func CSRF(mode string, fns ...func()) {
+	if len(fns) > 1 {
+		eval.ReportError("too many arguments given to CSRF")
+		return
+	}
+	csrf := &expr.CSRFExpr{Mode: mode}
+	switch e := eval.Current().(type) {
+	case *expr.RootExpr:
+		csrf.Parent = e.API.HTTP
+		e.API.HTTP.CSRF = csrf
+	case *expr.HTTPServiceExpr:
+		csrf.Parent = e
+		e.CSRF = csrf
+	case *expr.HTTPEndpointExpr:
+		csrf.Parent = e
+		e.CSRF = csrf
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(fns) > 0 {
+		eval.Execute(fns[0], csrf)
+	}
+}
+
+// CSRFHeader sets the name of the header that carries the CSRF token,
+// "X-CSRF-Token" by default.
+//
+// CSRFHeader must appear in CSRF.
+//
+// Example:
+//
+//    CSRF(CSRFDoubleSubmit, func() {
+//        CSRFHeader("X-XSRF-Token")
+//    })
+//
+func CSRFHeader(name string) {
+	csrf, ok := eval.Current().(*expr.CSRFExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if name == "" {
+		eval.ReportError("CSRF header name cannot be empty")
+		return
+	}
+	csrf.Header = name
+}
+
+// CSRFCookie sets the name of the cookie that carries the CSRF token in the
+// double-submit mode, "csrf_token" by default.
+//
+// CSRFCookie must appear in CSRF.
+//
+// Example:
+//
+//    CSRF(CSRFDoubleSubmit, func() {
+//        CSRFCookie("XSRF-TOKEN")
+//    })
+//
+func CSRFCookie(name string) {
+	csrf, ok := eval.Current().(*expr.CSRFExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if name == "" {
+		eval.ReportError("CSRF cookie name cannot be empty")
+		return
+	}
+	csrf.Cookie = name
+}
@@ -29,7 +29,8 @@ import (
 // request path which may use a wildcard starting with {* and ending with }.
 // The second argument is the path on disk to the files being served. The
 // file path may be absolute or relative to the current path of the process.
-// The DSL allows specifying a description and documentation as well.
+// The DSL allows specifying a description and documentation as well as the
+// Embed, Fallback, CacheControl, Immutable and ETag options.
 //
 // Example:
 //
@@ -44,6 +45,13 @@ import (
 //        Files("/static/{*path}", "/www/data/static", func() {
 //            Description("Serve static content.")
 //        })
+//        Files("/app/{*path}", "dist", func() {
+//            Description("Serve single page application.")
+//            Embed()
+//            Fallback("index.html")
+//            Immutable()
+//            ETag()
+//        })
 //    })
 //
 func Files(path, filename string, fns ...func()) {
@@ -68,3 +76,122 @@ func Files(path, filename string, fns ...func()) {
 		r.FileServers = append(r.FileServers, server)
 	}
 }
+
+// Embed specifies that the files are read from the http.FileSystem given to
+// the generated server constructor instead of from disk. The file path given to
+// Files is then relative to the root of the file system. This makes it
+// possible to serve files embedded in the binary with the embed package by
+// giving the result of calling http.FS with the embed.FS to the constructor.
+//
+// Embed must appear in a Files expression.
+//
+// Embed takes no argument.
+//
+// Example:
+//
+//    Files("/static/{*path}", "static", func() {
+//        Embed()
+//    })
+//
+func Embed() {
+	fs, ok := eval.Current().(*expr.HTTPFileServerExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	fs.Embed = true
+}
+
+// Fallback specifies the file served when the requested file does not exist,
+// typically "index.html" for single page applications that handle routing on
+// the client side. The fallback file is served with a "no-cache" Cache-Control
+// header when caching is enabled.
+//
+// Fallback must appear in a Files expression that serves a directory.
+//
+// Fallback takes one argument: the path of the file relative to the directory
+// being served.
+//
+// Example:
+//
+//    Files("/{*path}", "/www/app", func() {
+//        Fallback("index.html")
+//    })
+//
+func Fallback(filename string) {
+	fs, ok := eval.Current().(*expr.HTTPFileServerExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	fs.Fallback = filename
+}
+
+// CacheControl sets the value of the Cache-Control header of the responses
+// sent by the file server.
+//
+// CacheControl must appear in a Files expression.
+//
+// CacheControl takes one argument: the header value.
+//
+// Example:
+//
+//    Files("/static/{*path}", "/www/data/static", func() {
+//        CacheControl("public, max-age=3600")
+//    })
+//
+func CacheControl(value string) {
+	fs, ok := eval.Current().(*expr.HTTPFileServerExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	fs.CacheControl = value
+}
+
+// Immutable specifies that the files never change so that clients may cache
+// them indefinitely. Immutable adds the "immutable" directive to the value set
+// with CacheControl, it defaults the value to "public, max-age=31536000" if
+// CacheControl is not used.
+//
+// Immutable must appear in a Files expression.
+//
+// Immutable takes no argument.
+//
+// Example:
+//
+//    Files("/assets/{*path}", "/www/data/assets", func() {
+//        Immutable()
+//    })
+//
+func Immutable() {
+	fs, ok := eval.Current().(*expr.HTTPFileServerExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	fs.Immutable = true
+}
+
+// ETag specifies that the responses include an ETag header computed from the
+// content of the files. Requests whose If-None-Match header matches the ETag
+// result in "304 Not Modified" responses.
+//
+// ETag must appear in a Files expression.
+//
+// ETag takes no argument.
+//
+// Example:
+//
+//    Files("/static/{*path}", "/www/data/static", func() {
+//        ETag()
+//    })
+//
+func ETag() {
+	fs, ok := eval.Current().(*expr.HTTPFileServerExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	fs.ETag = true
+}
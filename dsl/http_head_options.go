@@ -0,0 +1,41 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// AutoHeadOptions makes the generated servers serve HEAD and OPTIONS requests
+// automatically. HEAD requests made to the path of a GET route are handled by
+// the GET endpoint handler and the response body is discarded so that the
+// response has the same status and headers as the GET response. OPTIONS
+// requests are answered with a 204 No Content response whose Allow header lists
+// the methods of all the routes defined for the path.
+//
+// HEAD requests are not served automatically for streaming endpoints and for
+// the paths that already have a HEAD route. OPTIONS requests are not served
+// automatically for the paths that already have an OPTIONS route or that
+// serve CORS preflight requests. The generated OpenAPI specification documents
+// the HEAD and OPTIONS operations.
+//
+// AutoHeadOptions must appear in the HTTP expression of API or in a service HTTP
+// expression.
+//
+// Example:
+//
+//    var _ = API("calc", func() {
+//        HTTP(func() {
+//            AutoHeadOptions()
+//        })
+//    })
+//
+func AutoHeadOptions() {
+	switch e := eval.Current().(type) {
+	case *expr.RootExpr:
+		e.API.HTTP.AutoHeadOptions = true
+	case *expr.HTTPServiceExpr:
+		e.AutoHeadOptions = true
+	default:
+		eval.IncompatibleDSL()
+	}
+}
@@ -0,0 +1,63 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// LenientDecoding makes the generated servers coerce the request values to the
+// types defined in the design when possible instead of rejecting them. This is
+// useful to serve legacy clients that do not encode the values with the proper
+// JSON types. Lenient decoding accepts:
+//
+//   - strings containing numbers (e.g. "123") for integer and float fields,
+//   - the strings "true", "t", "yes", "y", "on" and "1" and the strings
+//     "false", "f", "no", "n", "off" and "0" (case insensitive) as well as the
+//     numbers 1 and 0 for boolean fields.
+//
+// The strings are trimmed of leading and trailing white space prior to being
+// coerced. Use Meta("decode:trim") on an attribute to also trim the values of
+// a string attribute.
+//
+// LenientDecoding may appear in the HTTP expression of API, in a service HTTP
+// expression or in a method HTTP expression in which case it applies to all
+// the fields of the request bodies and to the boolean parameters. It may also
+// appear in an attribute expression in which case it applies only to the
+// attribute and to its elements or fields. The generated OpenAPI specifications
+// document lenient decoding with the "x-lenientDecoding" extension and the
+// trimmed attributes with the "x-trim" extension.
+//
+// Example:
+//
+//    var _ = API("legacy", func() {
+//        HTTP(func() {
+//            LenientDecoding()
+//        })
+//    })
+//
+//    var Order = Type("Order", func() {
+//        Attribute("quantity", Int, func() {
+//            LenientDecoding()
+//        })
+//        Attribute("reference", String, func() {
+//            Meta("decode:trim")
+//        })
+//    })
+//
+func LenientDecoding() {
+	switch e := eval.Current().(type) {
+	case *expr.RootExpr:
+		e.API.HTTP.LenientDecoding = true
+	case *expr.HTTPServiceExpr:
+		e.LenientDecoding = true
+	case *expr.HTTPEndpointExpr:
+		e.LenientDecoding = true
+	case *expr.AttributeExpr:
+		if e.Meta == nil {
+			e.Meta = make(expr.MetaExpr)
+		}
+		e.Meta[expr.DecodeLenientMetaKey] = []string{"true"}
+	default:
+		eval.IncompatibleDSL()
+	}
+}
@@ -0,0 +1,47 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// StrictMediaTypes makes the generated servers check the media types of the
+// requests. Requests whose Content-Type header does not match the media types
+// listed with Consumes (or the multipart and form media types for the
+// endpoints using MultipartRequest and FormEncodedRequest) are rejected with
+// an "unsupported_media_type" error and a HTTP 415 response. Requests whose
+// Accept header does not match any of the response content types or of the
+// media types listed with Produces are rejected with a "not_acceptable" error
+// and a HTTP 406 response. Requests without Content-Type or Accept header are
+// not rejected.
+//
+// The errors and their responses are added to the design unless the design
+// already defines them so that they are documented in the OpenAPI
+// specification and handled by the generated clients. Media types are not
+// checked for streaming and proxied endpoints.
+//
+// StrictMediaTypes must appear in the HTTP expression of API, in a service HTTP
+// expression or in a method HTTP expression.
+//
+// Example:
+//
+//    var _ = API("calc", func() {
+//        HTTP(func() {
+//            Consumes("application/json")
+//            Produces("application/json")
+//            StrictMediaTypes()
+//        })
+//    })
+//
+func StrictMediaTypes() {
+	switch e := eval.Current().(type) {
+	case *expr.RootExpr:
+		e.API.HTTP.StrictMediaTypes = true
+	case *expr.HTTPServiceExpr:
+		e.StrictMediaTypes = true
+	case *expr.HTTPEndpointExpr:
+		e.StrictMediaTypes = true
+	default:
+		eval.IncompatibleDSL()
+	}
+}
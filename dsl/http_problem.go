@@ -0,0 +1,38 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// ProblemDetails makes the generated servers encode errors as problem details
+// documents (RFC 9457) with the "application/problem+json" content type. This
+// applies to the errors that are not described in the design, such as the
+// validation errors, and to the design errors that use the default error type.
+// The type URI of a problem is the given base URI followed by the error name,
+// the type is "about:blank" if the base URI is empty.
+//
+// The problem documents also contain the fields of the default error type so
+// that the generated clients decode the error responses as before. The
+// generated OpenAPI specification defines the ProblemDetails schema used by
+// the error responses.
+//
+// ProblemDetails must appear in the HTTP expression of API.
+//
+// Example:
+//
+//    var _ = API("calc", func() {
+//        HTTP(func() {
+//            ProblemDetails("https://calc.goa.design/problems/")
+//        })
+//    })
+//
+func ProblemDetails(typeBase string) {
+	switch e := eval.Current().(type) {
+	case *expr.RootExpr:
+		e.API.HTTP.ProblemDetails = true
+		e.API.HTTP.ProblemTypeBase = typeBase
+	default:
+		eval.IncompatibleDSL()
+	}
+}
@@ -0,0 +1,135 @@
+package dsl
+
+import (
+	"net/http"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Proxy declares that the endpoint forwards requests to an upstream service.
+// The generated server handler decodes and validates the request and calls the
+// endpoint prior to forwarding the request so that security requirements and
+// the service method implementation may reject it. Once the endpoint returns
+// successfully the request is forwarded unmodified (other than the headers
+// rewritten by the DSL) and the upstream response is copied back to the
+// client. The generated server struct exposes the proxy of each endpoint so
+// that the requests and responses can be further modified via hooks.
+//
+// Request bodies are streamed to the upstream service unless the payload is
+// decoded from the body in which case the body is read in memory, up to the
+// limit set with BodyLimit or 10 MiB by default.
+//
+// Proxy must appear in a HTTP endpoint expression of a method that does not
+// define a result and does not use streaming.
+//
+// Proxy accepts the upstream URL as first argument and an optional DSL as
+// second argument. The request path is appended to the upstream URL path. The
+// DSL may use StripPrefix, SetRequestHeader and RemoveRequestHeader.
+//
+// Example:
+//
+//    Method("list", func() {
+//        Payload(func() {
+//            Token("token", String)
+//            Attribute("page", Int)
+//        })
+//        Security(JWTAuth)
+//        HTTP(func() {
+//            GET("/gateway/accounts")
+//            Param("page")
+//            Proxy("http://accounts.internal:8080/v1", func() {
+//                StripPrefix("/gateway")
+//                SetRequestHeader("X-Gateway", "goa")
+//                RemoveRequestHeader("Authorization")
+//            })
+//        })
+//    })
+//
+func Proxy(upstream string, fns ...func()) {
+	if len(fns) > 1 {
+		eval.ReportError("too many arguments given to Proxy")
+		return
+	}
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p := &expr.ProxyExpr{Upstream: upstream, Endpoint: e}
+	e.Proxy = p
+	if len(fns) > 0 {
+		eval.Execute(fns[0], p)
+	}
+}
+
+// StripPrefix sets a prefix removed from the request path before it is
+// appended to the upstream URL path. Requests whose path does not start with
+// the prefix are forwarded unchanged.
+//
+// StripPrefix must appear in Proxy.
+//
+// Example:
+//
+//    Proxy("http://accounts.internal:8080", func() {
+//        StripPrefix("/gateway")
+//    })
+//
+func StripPrefix(prefix string) {
+	p, ok := eval.Current().(*expr.ProxyExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p.StripPrefix = prefix
+}
+
+// SetRequestHeader sets a header on the requests forwarded to the upstream
+// service, replacing any value sent by the client.
+//
+// SetRequestHeader must appear in Proxy.
+//
+// Example:
+//
+//    Proxy("http://accounts.internal:8080", func() {
+//        SetRequestHeader("X-Gateway", "goa")
+//    })
+//
+func SetRequestHeader(name, value string) {
+	p, ok := eval.Current().(*expr.ProxyExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	name = http.CanonicalHeaderKey(name)
+	for _, h := range p.SetHeaders {
+		if h.Name == name {
+			h.Value = value
+			return
+		}
+	}
+	p.SetHeaders = append(p.SetHeaders, &expr.ProxyHeaderExpr{Name: name, Value: value})
+}
+
+// RemoveRequestHeader removes headers from the requests forwarded to the
+// upstream service. This is typically used to remove credentials that are
+// validated by the endpoint and should not be sent upstream.
+//
+// RemoveRequestHeader must appear in Proxy.
+//
+// Example:
+//
+//    Proxy("http://accounts.internal:8080", func() {
+//        RemoveRequestHeader("Authorization", "Cookie")
+//    })
+//
+func RemoveRequestHeader(names ...string) {
+	p, ok := eval.Current().(*expr.ProxyExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	for _, n := range names {
+		p.RemoveHeaders = append(p.RemoveHeaders, http.CanonicalHeaderKey(n))
+	}
+}
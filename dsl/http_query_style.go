@@ -0,0 +1,89 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// QueryStyle sets the serialization style of a query string parameter. The
+// generated client code encodes the parameter using the style and the
+// generated server code decodes it accordingly. The style is also reflected in
+// the "collectionFormat" field of the generated OpenAPI specification.
+//
+// QueryStyle must appear in the DSL of a query string parameter defined with
+// Param or in the DSL of the corresponding payload attribute.
+//
+// QueryStyle accepts one argument which is one of:
+//
+//    - "form": the default for primitive and array parameters. Array values
+//      are serialized by repeating the parameter (e.g. "id=1&id=2") or as a
+//      comma separated list if Explode(false) is used (e.g. "id=1,2").
+//
+//    - "pipeDelimited": array values are serialized as a pipe separated list
+//      (e.g. "id=1|2").
+//
+//    - "spaceDelimited": array values are serialized as a space separated list
+//      (e.g. "id=1%202").
+//
+//    - "deepObject": the default for map parameters, each key is serialized in
+//      its own parameter (e.g. "filter[name]=goa"). Maps whose values are maps
+//      use nested keys (e.g. "filter[owner][name]=goa"). The style only
+//      applies to maps: query string parameters cannot be objects or user
+//      types so nested keys must be described with nested maps such as
+//      MapOf(String, MapOf(String, String)).
+//
+// Example:
+//
+//    Method("list", func() {
+//        Payload(func() {
+//            Attribute("tags", ArrayOf(String))
+//            Attribute("ids", ArrayOf(Int))
+//        })
+//        HTTP(func() {
+//            GET("/")
+//            Param("tags", func() {
+//                QueryStyle("pipeDelimited")
+//            })
+//            Param("ids", func() {
+//                QueryStyle("form")
+//                Explode(false)
+//            })
+//        })
+//    })
+//
+func QueryStyle(style string) {
+	att, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	switch style {
+	case expr.QueryStyleForm, expr.QueryStylePipeDelimited, expr.QueryStyleSpaceDelimited, expr.QueryStyleDeepObject:
+		expr.SetQueryStyle(att, style)
+	default:
+		eval.ReportError("invalid query string style %q, style must be one of %q, %q, %q or %q", style,
+			expr.QueryStyleForm, expr.QueryStylePipeDelimited, expr.QueryStyleSpaceDelimited, expr.QueryStyleDeepObject)
+	}
+}
+
+// Explode sets whether the values of an array query string parameter using the
+// "form" style are serialized by repeating the parameter (true, the default)
+// or as a single comma separated list (false).
+//
+// Explode must appear in the DSL of a query string parameter defined with Param
+// or in the DSL of the corresponding payload attribute.
+//
+// Example:
+//
+//    Param("ids", func() {
+//        Explode(false)
+//    })
+//
+func Explode(explode bool) {
+	att, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	expr.SetQueryExplode(att, explode)
+}
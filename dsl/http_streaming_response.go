@@ -0,0 +1,57 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+const (
+	// NDJSON streams each result as a JSON value followed by a newline
+	// using the "application/x-ndjson" content type.
+	NDJSON = expr.NDJSONStreamFormat
+
+	// JSONArray streams the results as the elements of a JSON array using
+	// the "application/json" content type.
+	JSONArray = expr.JSONArrayStreamFormat
+)
+
+// StreamingResponse streams the results of a method that defines a
+// StreamingResult in the body of a chunked HTTP response instead of a
+// websocket connection. This makes it possible to stream results to clients
+// that sit behind proxies or firewalls that do not support websockets.
+//
+// StreamingResponse must appear in a HTTP endpoint expression of a method that
+// defines a StreamingResult but no StreamingPayload.
+//
+// StreamingResponse accepts the format of the response body as argument: NDJSON
+// writes each result as a JSON value followed by a newline while JSONArray
+// writes the results as the elements of a JSON array. The generated server
+// code writes and flushes each result as it is sent by the service. The
+// generated client code returns a stream whose Recv method reads the results
+// one at a time and returns io.EOF once the response is complete. Contrary to
+// websocket endpoints the route may use any HTTP method and the request may
+// have a body.
+//
+// Errors returned by the service before it sends the first result are written
+// to the response as usual. Errors returned after that cannot be written to
+// the response since the status code and headers have already been sent.
+//
+// Example:
+//
+//    Method("watch", func() {
+//        Payload(WatchRequest)
+//        StreamingResult(Event)
+//        HTTP(func() {
+//            POST("/watch")
+//            StreamingResponse(NDJSON)
+//        })
+//    })
+//
+func StreamingResponse(format string) {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	e.StreamingResponse = format
+}
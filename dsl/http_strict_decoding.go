@@ -0,0 +1,50 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// StrictDecoding makes the generated servers reject the requests that contain
+// fields not defined in the design instead of ignoring them. Requests whose
+// JSON body contains unknown object fields (at any depth) or whose query
+// string contains unknown parameters are rejected with an "unknown_fields"
+// validation error listing the paths to the unexpected fields and a HTTP 400
+// response.
+//
+// Strict decoding does not apply to the query string of endpoints that map the
+// whole query string to a map with MapParams and to proxied endpoints.
+//
+// StrictDecoding must appear in the HTTP expression of API, in a service HTTP
+// expression or in a method HTTP expression.
+//
+// Example:
+//
+//    var _ = API("calc", func() {
+//        HTTP(func() {
+//            StrictDecoding()
+//        })
+//    })
+//
+//    var _ = Service("calc", func() {
+//        Method("add", func() {
+//            Payload(Operands)
+//            HTTP(func() {
+//                POST("/add")
+//                StrictDecoding()
+//            })
+//        })
+//    })
+//
+func StrictDecoding() {
+	switch e := eval.Current().(type) {
+	case *expr.RootExpr:
+		e.API.HTTP.StrictDecoding = true
+	case *expr.HTTPServiceExpr:
+		e.StrictDecoding = true
+	case *expr.HTTPEndpointExpr:
+		e.StrictDecoding = true
+	default:
+		eval.IncompatibleDSL()
+	}
+}
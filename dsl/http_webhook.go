@@ -0,0 +1,53 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Webhook defines a webhook, that is a HTTP request sent by the service to a
+// URL registered by a third party to notify it of an event.
+//
+// Webhook must appear in Service.
+//
+// Webhook accepts 2 arguments: the name of the webhook event which must be
+// unique in the API and a DSL that defines the request body with Body and
+// optionally a description and meta.
+//
+// The webhook is listed in the webhooks section of the OpenAPI 3.1
+// specification (see the "openapi:version" meta). The generated HTTP client
+// package of the service defines the webhook body type and a function that
+// sends the webhook to a given URL. The request is a POST whose JSON body is
+// signed with a shared secret and the signature is written to the
+// Webhook-Signature header (see goahttp.SignWebhook). Receivers implemented
+// with Go may use the middleware.VerifyWebhook middleware to reject the
+// requests whose signature is invalid.
+//
+// Example:
+//
+//    var _ = Service("orders", func() {
+//        Webhook("order.created", func() {
+//            Description("Sent when an order is created.")
+//            Body(func() {
+//                Attribute("id", String, "Order ID")
+//                Attribute("total", Float64, "Order total")
+//                Required("id", "total")
+//            })
+//        })
+//    })
+//
+// generates the function:
+//
+//    func SendOrderCreatedWebhook(ctx context.Context, doer goahttp.Doer, u string, secret []byte, body *OrderCreatedWebhookBody) error
+//
+func Webhook(name string, fn func()) {
+	s, ok := eval.Current().(*expr.ServiceExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	r := expr.Root.API.HTTP.ServiceFor(s)
+	w := &expr.HTTPWebhookExpr{Name: name, Service: r}
+	eval.Execute(fn, w)
+	r.Webhooks = append(r.Webhooks, w)
+}
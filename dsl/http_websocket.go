@@ -0,0 +1,103 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// WebSocket configures the websocket connections used by a streaming endpoint.
+// The generated server code applies the settings to the connection upgrader
+// and the generated client code applies them to the connection dialer.
+//
+// WebSocket must appear in a HTTP endpoint expression of a method that defines
+// a StreamingPayload or a StreamingResult.
+//
+// WebSocket accepts a single argument which is the defining DSL. The DSL may
+// use Subprotocols, PerMessageDeflate, PingInterval and MaxMessageSize.
+//
+// Example:
+//
+//    Method("chat", func() {
+//        StreamingPayload(Message)
+//        StreamingResult(Message)
+//        HTTP(func() {
+//            GET("/chat")
+//            WebSocket(func() {
+//                Subprotocols("chat.v2", "chat.v1")
+//                PerMessageDeflate()
+//                PingInterval(30)
+//                MaxMessageSize(64 * 1024)
+//            })
+//        })
+//    })
+//
+func WebSocket(fn func()) {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	ws := e.WebSocket
+	if ws == nil {
+		ws = &expr.WebSocketExpr{Endpoint: e}
+		e.WebSocket = ws
+	}
+	eval.Execute(fn, ws)
+}
+
+// Subprotocols lists the websocket subprotocols supported by the endpoint in
+// order of preference. The server selects the first subprotocol also requested
+// by the client.
+//
+// Subprotocols must appear in WebSocket.
+func Subprotocols(protocols ...string) {
+	ws, ok := eval.Current().(*expr.WebSocketExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	ws.Subprotocols = append(ws.Subprotocols, protocols...)
+}
+
+// PerMessageDeflate enables the websocket permessage-deflate compression
+// extension (RFC 7692). Compression is used only if both sides support it.
+//
+// PerMessageDeflate must appear in WebSocket.
+func PerMessageDeflate() {
+	ws, ok := eval.Current().(*expr.WebSocketExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	ws.PerMessageDeflate = true
+}
+
+// PingInterval sets the number of seconds between the keep-alive ping messages
+// sent over the websocket connection. The generated server closes the
+// connection and cancels the request context when the client does not answer
+// the pings for twice the interval. The generated client does the same when
+// it receives streamed results.
+//
+// PingInterval must appear in WebSocket.
+func PingInterval(seconds uint) {
+	ws, ok := eval.Current().(*expr.WebSocketExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	ws.PingInterval = seconds
+}
+
+// MaxMessageSize sets the maximum size in bytes of the messages read from the
+// websocket connection. The connection is closed if a peer sends a larger
+// message.
+//
+// MaxMessageSize must appear in WebSocket.
+func MaxMessageSize(bytes int64) {
+	ws, ok := eval.Current().(*expr.WebSocketExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	ws.MaxMessageSize = bytes
+}
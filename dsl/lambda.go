@@ -0,0 +1,71 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Lambda generates the AWS Lambda entrypoints of a server. The "example"
+// command generates the main package of a function that serves all the HTTP
+// endpoints of the server behind an API Gateway HTTP API (payload format 2.0)
+// in cmd/<server>_lambda. The functions implement the Lambda runtime API
+// without third party dependency and may be deployed with the "provided.al2"
+// custom runtime by naming the executable "bootstrap". The errors returned by
+// the endpoints are encoded as they are by the HTTP servers. The functions
+// buffer the responses so the websocket endpoints are not supported.
+//
+// Lambda must appear in Server.
+//
+// Lambda accepts an optional DSL function that may call PerMethod.
+//
+// Example:
+//
+//    var _ = API("calc", func() {
+//        Server("calc", func() {
+//            Lambda(func() {
+//                PerMethod()
+//            })
+//        })
+//    })
+//
+func Lambda(fn ...func()) {
+	if len(fn) > 1 {
+		eval.ReportError("too many arguments given to Lambda")
+		return
+	}
+	s, ok := eval.Current().(*expr.ServerExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	l := &expr.LambdaExpr{Server: s}
+	if len(fn) == 1 {
+		if !eval.Execute(fn[0], l) {
+			return
+		}
+	}
+	s.Lambda = l
+}
+
+// PerMethod generates the entrypoint of a function for each non-streaming HTTP
+// endpoint of the server in cmd/<server>_lambda/<service>_<method> in addition
+// to the function serving all the endpoints. Each function serves a single
+// endpoint so that the endpoints may be deployed, scaled and secured
+// independently.
+//
+// PerMethod must appear in Lambda.
+//
+// Example:
+//
+//    Lambda(func() {
+//        PerMethod()
+//    })
+//
+func PerMethod() {
+	l, ok := eval.Current().(*expr.LambdaExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	l.PerMethod = true
+}
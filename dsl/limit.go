@@ -0,0 +1,89 @@
+package dsl
+
+import (
+	"time"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// ConcurrencyLimit bounds the number of requests served concurrently by the
+// method endpoints. The generated NewEndpoints function wraps the endpoints of
+// the limited methods with goa.LimitEndpoint. The requests that exceed the
+// limit wait in the queue defined with Queue if any and are rejected with an
+// "overloaded" temporary error when the queue is full or when they waited for
+// longer than the queue timeout. The HTTP servers respond to rejected requests
+// with 503 Service Unavailable and the Retry-After header, the gRPC servers
+// with code RESOURCE_EXHAUSTED.
+//
+// ConcurrencyLimit must appear in Service or Method. A limit defined on a
+// service applies to each of its methods independently, a limit defined on a
+// method overrides the service limit. The streaming requests hold their slot
+// until the stream ends.
+//
+// ConcurrencyLimit takes the maximum number of requests served concurrently
+// and an optional DSL function that may call Queue.
+//
+// Example:
+//
+//    var _ = Service("calc", func() {
+//        ConcurrencyLimit(100)
+//        Method("report", func() {
+//            ConcurrencyLimit(4, func() {
+//                Queue(16, "2s")
+//            })
+//        })
+//    })
+//
+func ConcurrencyLimit(max int, fns ...func()) {
+	if len(fns) > 1 {
+		eval.ReportError("too many arguments given to ConcurrencyLimit")
+		return
+	}
+	var c *expr.ConcurrencyLimitExpr
+	switch e := eval.Current().(type) {
+	case *expr.ServiceExpr:
+		c = &expr.ConcurrencyLimitExpr{Parent: e, Max: max}
+		e.ConcurrencyLimit = c
+	case *expr.MethodExpr:
+		c = &expr.ConcurrencyLimitExpr{Parent: e, Max: max}
+		e.ConcurrencyLimit = c
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(fns) == 1 {
+		eval.Execute(fns[0], c)
+	}
+}
+
+// Queue defines the queue of the requests that exceed a concurrency limit.
+// At most size requests wait for a slot, each for at most timeout. timeout is
+// a duration string (e.g. "500ms", see time.ParseDuration), the requests wait
+// until canceled if it is empty.
+//
+// Queue must appear in ConcurrencyLimit.
+//
+// Example:
+//
+//    ConcurrencyLimit(10, func() {
+//        Queue(50, "1s")
+//    })
+//
+func Queue(size int, timeout string) {
+	c, ok := eval.Current().(*expr.ConcurrencyLimitExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	c.QueueSize = size
+	if timeout == "" {
+		return
+	}
+	d, err := time.ParseDuration(timeout)
+	if err != nil {
+		eval.ReportError("invalid queue timeout %q: %s", timeout, err)
+		return
+	}
+	c.QueueTimeout = d
+}
@@ -9,8 +9,8 @@ import (
 // value consists of a slice of strings so that multiple invocation of the Meta
 // function on the same target using the same key builds up the slice.
 //
-// Meta may appear in attributes, result types, endpoints, responses, services
-// and API definitions.
+// Meta may appear in attributes, result types, endpoints, responses, services,
+// security schemes and API definitions.
 //
 // While keys can have any value the following names have special meanings:
 //
@@ -94,8 +94,8 @@ import (
 //    })
 //
 // - "swagger:generate" specifies whether Swagger specification should be
-// generated. Defaults to true. Applicable to services, methods and file
-// servers.
+// generated. Defaults to true. Applicable to services, methods, file servers
+// and health checks.
 //
 //    var _ = Service("MyService", func() {
 //        Meta("swagger:generate", "false")
@@ -141,6 +141,337 @@ import (
 //        Meta("swagger:extension:x-api", `{"foo":"bar"}`)
 //    })
 //
+// - "openapi:extension:xxx" is equivalent to "swagger:extension:xxx" and takes
+// precedence if both define the same extension. The extensions are also
+// rendered in the OpenAPI 3.1 specification.
+//
+//    var _ = Service("MyService", func() {
+//        Method("MyMethod", func() {
+//            Meta("openapi:extension:x-amazon-apigateway-integration", `{"type":"http_proxy"}`)
+//        })
+//    })
+//
+// - "openapi:version" set to "3.1" generates the OpenAPI 3.1 specification in
+// the openapi3.json and openapi3.yaml files in addition to the OpenAPI 2.0
+// specification. Applicable to API only.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("openapi:version", "3.1")
+//    })
+//
+// - "openapi:split" also generates the OpenAPI 2.0 specification split into
+// multiple files in the openapi directory: an index file, one file per
+// service describing its paths and a file with the shared definitions.
+// Applicable to API only.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("openapi:split")
+//    })
+//
+// - "openapi:docs" also generates the docs package which serves the OpenAPI
+// specification together with a HTML page that renders it with Swagger UI,
+// Redoc or Stoplight Elements. The page loads pinned versions of the UI scripts
+// from their CDN or, when mounted with MountEmbedded, from copies embedded in
+// the binary. The OpenAPI 3.1 specification is served. Applicable to API only.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("openapi:docs")
+//    })
+//
+// The generated package is mounted with:
+//
+//    docs.Mount(mux, "/docs", docs.SwaggerUI)
+//
+// or, to serve copies of the UI assets embedded in the binary:
+//
+//    docs.MountEmbedded(mux, "/docs", docs.Redoc, http.FS(assets))
+//
+// - "openapi:lint" checks the generated OpenAPI specification against lint
+// rules named after the Spectral "spectral:oas" ruleset and generates the
+// matching Spectral ruleset in gen/http/.spectral.yaml. Generation fails if a
+// rule with severity "error" is violated, the error reports the location of
+// the DSL defining the offending element. "openapi:lint:<rule>" overrides the
+// severity of a rule with "error", "warn" or "off". Applicable to API only.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("openapi:lint")
+//        Meta("openapi:lint:operation-description", "error")
+//    })
+//
+// - "jwtauth:jwks" generates the jwtauth package of the services that use the
+// JWT security scheme. The package implements the JWTAuth method of the
+// services by validating the tokens with the keys of the JSON Web Key Set
+// served at the given URL, checking the required scopes and storing the token
+// claims in the context. "jwtauth:issuer" and "jwtauth:audience" define the
+// expected issuer and audience of the tokens. Applicable to JWT security
+// schemes only.
+//
+//    var JWTAuth = JWTSecurity("jwt", func() {
+//        Meta("jwtauth:jwks", "https://auth.example.com/.well-known/jwks.json")
+//        Meta("jwtauth:issuer", "https://auth.example.com/")
+//        Meta("jwtauth:audience", "https://api.example.com")
+//    })
+//
+// - "apikeyauth:env" generates the apikeyauth package of the services that use
+// the API key security scheme. The package implements the APIKeyAuth method of
+// the services by comparing the keys in constant time with the comma separated
+// list of keys read from the given environment variable and storing the ID of
+// the matching key in the context. Keys are rotated by updating the variable
+// and reloading the keys. "apikeyauth:separator" defines the separator between
+// the key IDs and the secrets, keys have no ID by default. Applicable to API
+// key security schemes only.
+//
+//    var APIKeyAuth = APIKeySecurity("api_key", func() {
+//        Meta("apikeyauth:env", "API_KEYS")
+//        Meta("apikeyauth:separator", ".")
+//    })
+//
+// - "basicauth" generates the basicauth package of the services that use the
+// basic auth security scheme. The package implements the BasicAuth method of
+// the services by verifying the passwords with the bcrypt or argon2id hashes
+// of a user store and storing the name of the user in the context. Applicable
+// to basic auth security schemes only.
+//
+//    var BasicAuth = BasicAuthSecurity("basic", func() {
+//        Meta("basicauth")
+//    })
+//
+// - "cli:alias" defines alternative names of the commands of the generated
+// command line clients: the service commands when used in a Service
+// expression and the method commands when used in a Method expression.
+//
+//    var _ = Service("storage", func() {
+//        Meta("cli:alias", "st")
+//        Method("list", func() {
+//            Meta("cli:alias", "ls")
+//        })
+//    })
+//
+// - "audit" generates the audit package of the service which wraps the
+// endpoints of the audited methods with endpoints that record an audit event
+// for each request: the principal and security scheme that authorized the
+// request, the method, the resources and the outcome. The events are recorded
+// in the sink given to the Wrap function of the package. "audit:resource"
+// identifies the payload attributes that contain the IDs of the resources
+// accessed by the method, the optional value is the name of the resource in
+// the events and defaults to the attribute name. Methods that define
+// "audit:resource" attributes are audited even if they do not define "audit".
+// "audit" is applicable to services and methods, "audit:resource" to
+// primitive top-level payload attributes.
+//
+//    var _ = Service("orders", func() {
+//        Meta("audit")
+//        Method("cancel", func() {
+//            Payload(func() {
+//                Attribute("id", String, func() {
+//                    Meta("audit:resource", "order")
+//                })
+//            })
+//        })
+//    })
+//
+// - "authorize" evaluates authorization policies for the requests made to the
+// method once they are authenticated. The generated service package defines an
+// AuthorizationInput struct for each such method, e.g.
+// CancelAuthorizationInput, that describes the request to the policies: the
+// principal and scopes that authenticated the request, a copy of the payload
+// without the credentials and the transport metadata (HTTP method, path and
+// headers or gRPC method and metadata). The generated endpoints give the input
+// to the Authorize method of the Authorizer interface that the service must
+// implement and return the error it returns if any. The inputs encode to JSON
+// so that they can be used as is to query Open Policy Agent, or mapped to the
+// principal, action and resource of Cedar requests. Applicable to services
+// and methods.
+//
+//    var _ = Service("orders", func() {
+//        Meta("authorize")
+//    })
+//
+// - "observability:otel" instruments the generated HTTP and gRPC servers and
+// clients with OpenTelemetry. The gen/http/telemetry and gen/grpc/telemetry
+// packages wrap the handlers, the client doers and invokers and the websocket
+// dialers of the streaming methods so that each request creates a span with
+// the service and method names as attributes and a status derived from the
+// response status code, and records its duration in a histogram. The trace
+// context is propagated in the HTTP headers and gRPC metadata using the global
+// OpenTelemetry propagator, the spans and metrics use the global tracer and
+// meter providers. Applicable to API and services, the value "false" disables
+// the instrumentation of a service when the API enables it.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("observability:otel")
+//    })
+//
+// - "metrics:prometheus" generates the gen/metrics package which records the
+// Prometheus RED metrics of the HTTP requests (request count, duration
+// histogram and in-flight gauge) labeled with the service, the method and the
+// route defined in the design rather than the request URL, and counts the
+// messages sent and received by the server streams. The generated example
+// server registers the metrics, wraps the HTTP servers and streaming
+// endpoints and serves the metrics at /metrics. Applicable to API and
+// services, the value "false" disables the metrics of a service when the API
+// enables them.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("metrics:prometheus")
+//    })
+//
+// - "log:slog" generates the logging package of the service which wraps the
+// service endpoints with endpoints that write a log/slog record for each
+// request with the same schema for all the methods: the "service", "method",
+// "duration" and "error" attributes and the payload attributes that define
+// "log:field". The Handler HTTP middleware of the package adds the "route"
+// and "status" attributes. The service methods retrieve a logger that includes
+// the request attributes from the context with the Logger function of the
+// package, the generated example services use it. "log:field" is applicable
+// to top-level payload attributes, the optional value is the key of the
+// attribute in the log records and defaults to the attribute name.
+// "log:redact" replaces the value of a "log:field" attribute with
+// "[REDACTED]". "log:slog" is applicable to API and services, the value
+// "false" disables the logging of a service when the API enables it.
+//
+//    var _ = Service("orders", func() {
+//        Meta("log:slog")
+//        Method("cancel", func() {
+//            Payload(func() {
+//                Attribute("id", String, func() {
+//                    Meta("log:field", "order_id")
+//                })
+//                Attribute("token", String, func() {
+//                    Meta("log:field")
+//                    Meta("log:redact")
+//                })
+//            })
+//        })
+//    })
+//
+// - "sdk:paginate" identifies the attributes that paginate the method results
+// so that the generated gen/sdk package can iterate over the items of all the
+// pages. The values are the name of the payload attribute that identifies the
+// requested page, the name of the result attribute that identifies the next
+// page and the name of the result array attribute that lists the items of the
+// page. The two page attributes must be of the same String or integer type,
+// the iteration stops once the result does not identify a next page.
+// Applicable to non-streaming methods only.
+//
+//    Method("list", func() {
+//        Payload(func() {
+//            Attribute("cursor", String)
+//        })
+//        Result(func() {
+//            Attribute("next_cursor", String)
+//            Attribute("bottles", ArrayOf(Bottle))
+//        })
+//        Meta("sdk:paginate", "cursor", "next_cursor", "bottles")
+//    })
+//
+// - "postman:collection" generates a Postman collection that describes the
+// HTTP endpoints in gen/http/postman_collection.json and the Postman
+// environment that defines the base URL and the security credentials used by
+// the requests in gen/http/postman_environment.json. The requests use the
+// examples defined in the design. Applicable to API only.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("postman:collection")
+//    })
+//
+// - "typescript:client" generates a TypeScript client for the HTTP endpoints in
+// the gen/http/typescript directory. The client uses the fetch API, declares
+// interfaces matching the design types and a discriminated union of the errors
+// of each method, and streams results over websockets or chunked responses.
+// Applicable to API only.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("typescript:client")
+//    })
+//
+// - "contract:test" generates contract tests for the HTTP endpoints in
+// gen/http/<service>/client/contract_test.go. The tests send requests built
+// from the examples to the server located at the URL defined by the
+// CONTRACT_URL environment variable and verify that the responses conform to
+// the design, invalid requests must be rejected with status code 400.
+// Applicable to API only.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("contract:test")
+//    })
+//
+// - "fuzz:test" generates Go fuzz targets for the HTTP and gRPC request
+// decoders in the fuzz_test.go files of the gen/http/<service>/server and
+// gen/grpc/<service>/server packages. The targets make sure that decoding and
+// validating malformed requests does not panic, the corpus is seeded with the
+// requests built from the examples. The targets require Go 1.18 or later.
+// Applicable to API only.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("fuzz:test")
+//    })
+//
+// - "fixtures:record" generates golden fixtures built from the examples for
+// each method: raw HTTP requests and responses in gen/http/<service>/fixtures
+// and the JSON representations of the gRPC messages in
+// gen/grpc/<service>/fixtures. Each directory contains a package that loads
+// the fixtures and replays the HTTP requests. Applicable to API only.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("fixtures:record")
+//    })
+//
+// - "openapi:nullable" indicates that the attribute value may be null in the
+// OpenAPI 3.1 specification. Applicable to attributes.
+//
+//    var _ = Type("User", func() {
+//        Attribute("nickname", String, func() {
+//            Meta("openapi:nullable")
+//        })
+//    })
+//
+// - "openapi:webhook" lists the method in the webhooks section of the OpenAPI
+// 3.1 specification under the given name instead of the paths. Applicable to
+// methods.
+//
+//    var _ = Service("MyService", func() {
+//        Method("Notify", func() {
+//            Meta("openapi:webhook", "newUser")
+//        })
+//    })
+//
+// - "scaffold" generates the implementation skeleton of the service with one
+// file per method instead of the single example service file. The skeleton is
+// generated by both "goa gen" and "goa example" and the existing files are
+// never overwritten: generating the code after adding a method to the design
+// adds the stub of the new method. The stub files reference the endpoint
+// constructor of the method in the generated service package so that the
+// files of the methods removed from the design fail to compile. Applicable to
+// API and services.
+//
+//    var _ = Service("calc", func() {
+//        Meta("scaffold")
+//    })
+//
+// - "goa:layout:gen", "goa:layout:openapi", "goa:layout:proto" and
+// "goa:layout:example" set the directories relative to the output directory
+// where the generated Go packages, the OpenAPI specifications, the protocol
+// buffer definitions and the files generated by "goa example" are written.
+// The import paths used in the generated code reflect the layout. Applicable
+// to API only.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("goa:layout:gen", "internal/gen")
+//        Meta("goa:layout:openapi", "api")
+//        Meta("goa:layout:proto", "proto")
+//    })
+//
+// - "goa:compat" sets the version of the compatibility layer of the goa runtime
+// packages targeted by the generated code, see package
+// goa.design/goa/v3/compat. The generated packages import the layer packages
+// instead of the runtime packages so that they keep compiling when the runtime
+// packages change. Applicable to API only.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("goa:compat", "v1")
+//    })
+//
 func Meta(name string, value ...string) {
 	appendMeta := func(meta expr.MetaExpr, name string, value ...string) expr.MetaExpr {
 		if meta == nil {
@@ -169,8 +500,14 @@ func Meta(name string, value ...string) {
 		e.Meta = appendMeta(e.Meta, name, value...)
 	case *expr.HTTPFileServerExpr:
 		e.Meta = appendMeta(e.Meta, name, value...)
+	case *expr.HTTPWebhookExpr:
+		e.Meta = appendMeta(e.Meta, name, value...)
 	case *expr.HTTPResponseExpr:
 		e.Meta = appendMeta(e.Meta, name, value...)
+	case *expr.SchemeExpr:
+		e.Meta = appendMeta(e.Meta, name, value...)
+	case *expr.HealthCheckExpr:
+		e.Meta = appendMeta(e.Meta, name, value...)
 	case expr.CompositeExpr:
 		att := e.Attribute()
 		att.Meta = appendMeta(att.Meta, name, value...)
@@ -0,0 +1,127 @@
+package dsl
+
+import (
+	"strings"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Propagate lists the headers that the generated code propagates from the
+// incoming requests to the outgoing requests made by the generated clients. The
+// generated servers record the values of the headers in the request context and
+// the generated clients add them to the requests made with that context. The
+// headers are HTTP headers for the HTTP transport and metadata for the gRPC
+// transport.
+//
+// Propagate defaults to the "X-Request-Id", "traceparent" and "tracestate"
+// headers when called without arguments. The "X-Request-Id" header defaults to
+// the request ID initialized by the RequestID middleware if the incoming
+// request does not have one.
+//
+// Propagate must appear in API or Service. Settings defined on a service
+// override the settings defined on the API.
+//
+// Example:
+//
+//    var _ = API("calc", func() {
+//        Propagate("X-Request-Id", "traceparent", "X-Tenant")
+//        PropagateDeadline()
+//    })
+//
+func Propagate(headers ...string) {
+	p := propagation()
+	if p == nil {
+		return
+	}
+	if len(headers) == 0 {
+		headers = expr.DefaultPropagatedHeaders
+	}
+	p.Headers = append(p.Headers, headers...)
+}
+
+// PropagateDeadline propagates the deadline of the incoming requests to the
+// outgoing requests made by the generated clients. HTTP requests carry the time
+// left until the deadline in milliseconds in the "Goa-Timeout" header and the
+// generated servers apply the corresponding timeout to the request context.
+// gRPC propagates deadlines natively.
+//
+// PropagateDeadline must appear in API or Service.
+//
+// Example:
+//
+//    var _ = Service("calc", func() {
+//        PropagateDeadline()
+//    })
+//
+func PropagateDeadline() {
+	if p := propagation(); p != nil {
+		p.Deadline = true
+	}
+}
+
+// ContextValue defines a typed request context value that the generated
+// servers extract from the incoming requests and that the generated clients
+// forward in the outgoing requests made with the same context. The value is
+// carried by a HTTP header for the HTTP transport and by metadata for the gRPC
+// transport. The generated "contextvalues" package provides typed accessors
+// to read the value from a context and to set it in a context.
+//
+// ContextValue must appear in API.
+//
+// ContextValue takes the same arguments as Attribute. The name may define the
+// name of the header that carries the value using the syntax "name:header",
+// the header name defaults to the context value name. The type must be a
+// primitive type other than Bytes and Any and defaults to String. A default
+// value is returned by the accessor when the request does not carry the value.
+//
+// Example:
+//
+//    var _ = API("calc", func() {
+//        ContextValue("tenant_id:X-Tenant-ID", String, "Tenant making the request")
+//        ContextValue("locale:Accept-Language", String, func() {
+//            Default("en-US")
+//        })
+//    })
+//
+func ContextValue(name string, args ...interface{}) {
+	a, ok := eval.Current().(*expr.APIExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	header := name
+	if i := strings.Index(name, ":"); i > 0 {
+		name, header = name[:i], name[i+1:]
+	}
+	dt, desc, fn := parseAttributeArgs(nil, args...)
+	attr := &expr.AttributeExpr{Type: dt, Description: desc}
+	if fn != nil {
+		eval.Execute(fn, attr)
+	}
+	if attr.Type == nil {
+		attr.Type = expr.String
+	}
+	a.ContextValues = append(a.ContextValues, &expr.ContextValueExpr{Name: name, Header: header, Attribute: attr})
+}
+
+// propagation returns the propagation expression of the current API or service
+// expression, creating it if needed. It reports an error and returns nil if the
+// current expression is neither.
+func propagation() *expr.PropagationExpr {
+	switch e := eval.Current().(type) {
+	case *expr.APIExpr:
+		if e.Propagation == nil {
+			e.Propagation = &expr.PropagationExpr{Parent: e}
+		}
+		return e.Propagation
+	case *expr.ServiceExpr:
+		if e.Propagation == nil {
+			e.Propagation = &expr.PropagationExpr{Parent: e}
+		}
+		return e.Propagation
+	default:
+		eval.IncompatibleDSL()
+		return nil
+	}
+}
@@ -1,6 +1,8 @@
 package dsl
 
 import (
+	"time"
+
 	"goa.design/goa/v3/eval"
 	"goa.design/goa/v3/expr"
 )
@@ -50,7 +52,8 @@ func BasicAuthSecurity(name string, fn ...func()) *expr.SchemeExpr {
 // APIKeySecurity is a top level DSL.
 //
 // APIKeySecurity takes a name as first argument and an optional DSL as
-// second argument.
+// second argument. The DSL may use KeyLocation to accept the key from several
+// locations.
 //
 // Example:
 //
@@ -84,11 +87,45 @@ func APIKeySecurity(name string, fn ...func()) *expr.SchemeExpr {
 	return e
 }
 
+// KeyLocation adds a location of the key of an API key security scheme. The
+// HTTP server accepts the key from any of the locations of the scheme, the
+// locations are read in the order they are defined until a key is found. This
+// makes it possible to move the key, for example from a query string parameter
+// to a header, without breaking existing clients. The key attribute is mapped
+// to the first location unless the HTTP endpoint maps it explicitly, in which
+// case the explicit mapping is read first. The key attribute cannot be required
+// when the key may be read from more than one location. Clients send the key in
+// the location the key attribute is mapped to.
+//
+// KeyLocation must appear in APIKeySecurity.
+//
+// KeyLocation accepts the kind of location as first argument, one of "header",
+// "query" or "cookie", and the name of the header, query string parameter or
+// cookie as second argument.
+//
+// Example:
+//
+//    var APIKey = APIKeySecurity("key", func() {
+//        KeyLocation("header", "X-API-Key")
+//        KeyLocation("query", "api_key") // deprecated location
+//    })
+//
+func KeyLocation(in, name string) {
+	s, ok := eval.Current().(*expr.SchemeExpr)
+	if !ok || s.Kind != expr.APIKeyKind {
+		eval.IncompatibleDSL()
+		return
+	}
+	s.KeyLocations = append(s.KeyLocations, &expr.KeyLocationExpr{In: in, Name: name})
+}
+
 // OAuth2Security defines an OAuth2 security scheme. The DSL provided as second
 // argument defines the specific flows supported by the scheme. The supported
 // flow types are ImplicitFlow, PasswordFlow, ClientCredentialsFlow, and
 // AuthorizationCodeFlow. The DSL also defines the scopes that may be
-// associated with the incoming request tokens.
+// associated with the incoming request tokens. The scopes may also be defined
+// in the DSL of a flow in which case they only apply to that flow in the
+// OpenAPI specifications.
 //
 // OAuth2Security is a top level DSL.
 //
@@ -103,6 +140,16 @@ func APIKeySecurity(name string, fn ...func()) *expr.SchemeExpr {
 //        Scope("api:read", "Read access")
 //    })
 //
+//    var MultiFlow = OAuth2Security("multi", func() {
+//        AuthorizationCodeFlow("/authorization", "/token", "/refresh", func() {
+//            Scope("api:read", "Read access")
+//            Scope("api:write", "Write access")
+//        })
+//        ClientCredentialsFlow("/token", "", func() {
+//            Scope("api:read", "Read access")
+//        })
+//    })
+//
 func OAuth2Security(name string, fn ...func()) *expr.SchemeExpr {
 	if _, ok := eval.Current().(eval.TopExpr); !ok {
 		eval.IncompatibleDSL()
@@ -179,11 +226,207 @@ func JWTSecurity(name string, fn ...func()) *expr.SchemeExpr {
 	return e
 }
 
+// MTLSSecurity defines a mutual TLS security scheme where clients authenticate
+// by presenting a X.509 certificate during the TLS handshake. The scheme is
+// documented as a "mutualTLS" security scheme in OpenAPI 3 specifications.
+//
+// The generated servers retrieve the certificate presented by the client from
+// the TLS connection state (HTTP) or from the peer information (gRPC) and
+// initialize the payload attribute defined with ClientCertificate with its PEM
+// encoding. The generated endpoint parses the certificate and gives its
+// subject organizational units and alternative names to the authorization
+// function. Servers must be configured to request client certificates, see
+// security.MTLSConfig.
+//
+// MTLSSecurity is a top level DSL.
+//
+// MTLSSecurity takes a name as first argument and an optional DSL as second
+// argument.
+//
+// Example:
+//
+//    var MTLS = MTLSSecurity("mtls", func() {
+//        Description("Client certificate issued by the internal CA")
+//        Scope("payments", "Access to the payments API")
+//    })
+//
+func MTLSSecurity(name string, fn ...func()) *expr.SchemeExpr {
+	if _, ok := eval.Current().(eval.TopExpr); !ok {
+		eval.IncompatibleDSL()
+		return nil
+	}
+
+	if securitySchemeRedefined(name) {
+		return nil
+	}
+
+	e := &expr.SchemeExpr{
+		SchemeName: name,
+		Kind:       expr.MTLSKind,
+	}
+
+	if len(fn) != 0 {
+		if !eval.Execute(fn[0], e) {
+			return nil
+		}
+	}
+
+	expr.Root.Schemes = append(expr.Root.Schemes, e)
+
+	return e
+}
+
+// SignatureSecurity defines a security scheme where clients sign the HTTP
+// requests with a secret shared with the server in a way similar to AWS
+// Signature Version 4. The signature covers the request method, path, query
+// string, host, content type and body as well as the time the request was
+// signed. It is sent in the Authorization header together with the ID of the
+// key used to sign the request.
+//
+// The generated HTTP servers expose a Verify<Scheme>Signatures method that
+// installs a middleware verifying the signatures of the requests made to the
+// endpoints secured by the scheme, the generated endpoints give the ID of the
+// key to the authorization function. The generated HTTP clients expose a
+// Sign<Scheme>Requests method that signs the requests made to the same
+// endpoints. SignatureSecurity is not supported by gRPC endpoints.
+//
+// SignatureSecurity is a top level DSL.
+//
+// SignatureSecurity takes a name as first argument and an optional DSL as
+// second argument.
+//
+// Example:
+//
+//    var Signed = SignatureSecurity("signed", func() {
+//        Description("Requests signed with the partner secret")
+//        ClockSkew("2m")
+//        ReplayWindow("10m")
+//    })
+//
+func SignatureSecurity(name string, fn ...func()) *expr.SchemeExpr {
+	if _, ok := eval.Current().(eval.TopExpr); !ok {
+		eval.IncompatibleDSL()
+		return nil
+	}
+
+	if securitySchemeRedefined(name) {
+		return nil
+	}
+
+	e := &expr.SchemeExpr{
+		SchemeName: name,
+		Kind:       expr.SignatureKind,
+		In:         "header",
+		Name:       "Authorization",
+	}
+
+	if len(fn) != 0 {
+		if !eval.Execute(fn[0], e) {
+			return nil
+		}
+	}
+
+	expr.Root.Schemes = append(expr.Root.Schemes, e)
+
+	return e
+}
+
+// ClockSkew sets the maximum difference between the time a request was signed
+// and the time the server verifies its signature. Requests signed outside of
+// this window are rejected. The default is 5 minutes.
+//
+// ClockSkew must appear in SignatureSecurity.
+//
+// ClockSkew accepts a single argument which is the duration as accepted by
+// time.ParseDuration (e.g. "30s", "5m").
+//
+// Example:
+//
+//    var Signed = SignatureSecurity("signed", func() {
+//        ClockSkew("2m")
+//    })
+//
+func ClockSkew(skew string) {
+	s, ok := eval.Current().(*expr.SchemeExpr)
+	if !ok || s.Kind != expr.SignatureKind {
+		eval.IncompatibleDSL()
+		return
+	}
+	d, err := time.ParseDuration(skew)
+	if err != nil {
+		eval.ReportError("invalid clock skew: %s", err)
+		return
+	}
+	s.ClockSkew = d
+}
+
+// ReplayWindow sets the duration during which the server records the
+// signatures of the verified requests in order to reject requests that reuse
+// them. The default is twice the clock skew so that a signature cannot be
+// replayed while its timestamp is valid.
+//
+// ReplayWindow must appear in SignatureSecurity.
+//
+// ReplayWindow accepts a single argument which is the duration as accepted by
+// time.ParseDuration (e.g. "10m").
+//
+// Example:
+//
+//    var Signed = SignatureSecurity("signed", func() {
+//        ReplayWindow("10m")
+//    })
+//
+func ReplayWindow(window string) {
+	s, ok := eval.Current().(*expr.SchemeExpr)
+	if !ok || s.Kind != expr.SignatureKind {
+		eval.IncompatibleDSL()
+		return
+	}
+	d, err := time.ParseDuration(window)
+	if err != nil {
+		eval.ReportError("invalid replay window: %s", err)
+		return
+	}
+	s.ReplayWindow = d
+}
+
+// WildcardScopes enables hierarchical scopes for the security scheme. Scope
+// segments are separated with colons and a last segment "*" defines a wildcard
+// scope that implies all the scopes sharing its prefix, e.g. "api:*" implies
+// "api:read" and "api:write" and "*" implies all scopes. A method may then
+// require any scope implied by a scope of the scheme, the Validate methods of
+// the schemes given to the authorization functions and the result filters take
+// the granted wildcard scopes into account. Scopes are matched literally
+// otherwise.
+//
+// WildcardScopes must appear in BasicAuthSecurity, APIKeySecurity,
+// JWTSecurity, OAuth2Security, MTLSSecurity or SignatureSecurity.
+//
+// WildcardScopes takes no argument.
+//
+// Example:
+//
+//    var JWT = JWTSecurity("JWT", func() {
+//        WildcardScopes()
+//        Scope("api:*", "Full access") // Implies the scopes below
+//        Scope("api:read", "Read access")
+//        Scope("api:write", "Write access")
+//    })
+//
+func WildcardScopes() {
+	s, ok := eval.Current().(*expr.SchemeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	s.WildcardScopes = true
+}
+
 // Security defines authentication requirements to access a service or a service
 // method.
 //
 // The requirement refers to one or more OAuth2Security, BasicAuthSecurity,
-// APIKeySecurity or JWTSecurity security scheme. If the schemes include a
+// APIKeySecurity, JWTSecurity, MTLSSecurity or SignatureSecurity security scheme. If the schemes include a
 // OAuth2Security or JWTSecurity scheme then required scopes may be listed by
 // name in the Security DSL. All the listed schemes must be validated by the
 // client for the request to be authorized. Security may appear multiple times
@@ -306,6 +549,74 @@ func NoSecurity() {
 	}
 }
 
+// Optional allows anonymous access to the methods secured with Security:
+// requests that carry none of the credentials used by the security
+// requirements are authorized without calling the authorization functions.
+// Requests that carry credentials must still satisfy one of the requirements.
+// The requirements are tried in the order they are defined, requests that
+// satisfy none of them are rejected with an error that lists the failures of
+// all the requirements (a 401 response with one WWW-Authenticate challenge
+// per security scheme for HTTP endpoints).
+//
+// Optional must appear in Method or Service. When used in a Service it only
+// applies to the methods that inherit the service security requirements.
+//
+// Example:
+//
+//    Method("show", func() {
+//        Security(JWTAuth)      // tried first
+//        Security(APIKeyAuth)   // tried if the JWT is invalid or missing
+//        Optional()             // anonymous requests are allowed
+//        Payload(func() {
+//            Token("token", String)
+//            APIKey("api_key", "key", String)
+//        })
+//    })
+//
+func Optional() {
+	switch actual := eval.Current().(type) {
+	case *expr.MethodExpr:
+		actual.OptionalSecurity = true
+	case *expr.ServiceExpr:
+		actual.OptionalSecurity = true
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// Realm defines the protection space of the services used in the
+// authentication challenges. The generated HTTP servers set the realm of the
+// WWW-Authenticate headers of the 401 responses to the realm of the service
+// if any and to the name of the security scheme otherwise. The realm is also
+// rendered in the 401 responses of the OpenAPI specifications.
+//
+// Realm must appear in API or Service. The realm defined in a Service
+// overrides the realm defined in the API.
+//
+// Realm accepts a single argument which is the name of the realm.
+//
+// Example:
+//
+//    var _ = Service("admin", func() {
+//        Realm("Administration")
+//        Security(BasicAuth)
+//    })
+//
+func Realm(name string) {
+	if name == "" {
+		eval.ReportError("realm cannot be empty")
+		return
+	}
+	switch actual := eval.Current().(type) {
+	case *expr.APIExpr:
+		actual.Realm = name
+	case *expr.ServiceExpr:
+		actual.Realm = name
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
 // Username defines the attribute used to provide the username to an endpoint
 // secured with basic authentication. The parameters and usage of Username are
 // the same as the goa DSL Attribute function.
@@ -516,15 +827,63 @@ func TokenField(tag interface{}, name string, args ...interface{}) {
 	Field(tag, name, args...)
 }
 
-// Scope has two uses: in JWTSecurity or OAuth2Security it defines a scope
-// supported by the scheme. In Security it lists required scopes.
+// ClientCertificate defines the attribute used to provide the client
+// certificate to an endpoint secured via mutual TLS. The attribute must be a
+// String. The parameters and usage of ClientCertificate are the same as the goa
+// DSL Attribute function.
+//
+// The generated server code initializes the corresponding payload field with
+// the PEM encoding of the certificate presented by the client during the TLS
+// handshake. The attribute is not part of the HTTP request body or of the gRPC
+// request message.
+//
+// Example:
+//
+//    Method("secured", func() {
+//        Security(MTLS)
+//        Payload(func() {
+//            ClientCertificate("cert", String, "PEM encoded client certificate")
+//        })
+//        Result(String)
+//        HTTP(func() {
+//            GET("/")
+//        })
+//    })
+//
+func ClientCertificate(name string, args ...interface{}) {
+	args = useDSL(args, func() { Meta("security:clientcert") })
+	Attribute(name, args...)
+}
+
+// ClientCertificateField is syntactic sugar to define a client certificate
+// attribute with the "rpc:tag" meta set with the value of the first argument.
+//
+// ClientCertificateField takes the same arguments as ClientCertificate with the
+// addition of the tag value as the first argument.
+//
+func ClientCertificateField(tag interface{}, name string, args ...interface{}) {
+	args = useDSL(args, func() { Meta("security:clientcert") })
+	Field(tag, name, args...)
+}
+
+// Scope has three uses: in JWTSecurity or OAuth2Security it defines a scope
+// supported by the scheme. In Security it lists required scopes. In Attribute
+// it lists the scopes the caller must be granted to see the attribute in the
+// method results: the generated endpoints set the attribute to its zero value
+// in the results of callers that lack one of the scopes, see
+// security.WithScopes. Attributes that require scopes cannot be required.
+// The results sent by streaming methods are not filtered.
 //
-// Scope must appear in Security, BasicSecurity, APIKeySecurity, JWTSecurity or OAuth2Security.
+// Scope must appear in Security, BasicSecurity, APIKeySecurity, JWTSecurity, OAuth2Security,
+// MTLSSecurity or Attribute.
 //
 // Scope accepts one or two arguments: the first argument is the scope name and
 // when used in JWTSecurity or OAuth2Security the second argument is a
 // description.
 //
+// Scope names may be hierarchical when the scheme uses WildcardScopes, see
+// WildcardScopes.
+//
 // Example:
 //
 //    var JWT = JWTSecurity("JWT", func() {
@@ -538,6 +897,13 @@ func TokenField(tag interface{}, name string, args ...interface{}) {
 //        })
 //    })
 //
+//    var Employee = Type("Employee", func() {
+//        Attribute("name", String)
+//        Attribute("ssn", String, func() {
+//            Scope("pii:read") // Required scope to see the attribute
+//        })
+//    })
+//
 func Scope(name string, desc ...string) {
 	switch current := eval.Current().(type) {
 	case *expr.SecurityExpr:
@@ -557,6 +923,26 @@ func Scope(name string, desc ...string) {
 		}
 		current.Scopes = append(current.Scopes,
 			&expr.ScopeExpr{Name: name, Description: d})
+	case *expr.FlowExpr:
+		if len(desc) > 1 {
+			eval.ReportError("too many arguments")
+			return
+		}
+		d := "no description"
+		if len(desc) == 1 {
+			d = desc[0]
+		}
+		current.Scopes = append(current.Scopes,
+			&expr.ScopeExpr{Name: name, Description: d})
+	case *expr.AttributeExpr:
+		if len(desc) >= 1 {
+			eval.ReportError("too many arguments")
+			return
+		}
+		if current.Meta == nil {
+			current.Meta = expr.MetaExpr{}
+		}
+		current.Meta["security:scope"] = append(current.Meta["security:scope"], name)
 	default:
 		eval.IncompatibleDSL()
 	}
@@ -568,23 +954,15 @@ func Scope(name string, desc ...string) {
 // AuthorizationCodeFlow must be used in OAuth2Security.
 //
 // AuthorizationCodeFlow accepts three arguments: the authorization, token and
-// refresh URLs.
-func AuthorizationCodeFlow(authorizationURL, tokenURL, refreshURL string) {
-	current, ok := eval.Current().(*expr.SchemeExpr)
-	if !ok {
-		eval.IncompatibleDSL()
-		return
-	}
-	if current.Kind != expr.OAuth2Kind {
-		eval.ReportError("cannot specify flow for non-oauth2 security scheme.")
-		return
-	}
-	current.Flows = append(current.Flows, &expr.FlowExpr{
+// refresh URLs and an optional DSL. The DSL may list the scopes supported by
+// the flow with Scope, see OAuth2Security.
+func AuthorizationCodeFlow(authorizationURL, tokenURL, refreshURL string, fn ...func()) {
+	addFlow(&expr.FlowExpr{
 		Kind:             expr.AuthorizationCodeFlowKind,
 		AuthorizationURL: authorizationURL,
 		TokenURL:         tokenURL,
 		RefreshURL:       refreshURL,
-	})
+	}, fn)
 }
 
 // ImplicitFlow defines an implicit OAuth2 flow as described in section 1.3.2
@@ -592,22 +970,15 @@ func AuthorizationCodeFlow(authorizationURL, tokenURL, refreshURL string) {
 //
 // ImplicitFlow must be used in OAuth2Security.
 //
-// ImplicitFlow accepts two arguments: the authorization and refresh URLs.
-func ImplicitFlow(authorizationURL, refreshURL string) {
-	current, ok := eval.Current().(*expr.SchemeExpr)
-	if !ok {
-		eval.IncompatibleDSL()
-		return
-	}
-	if current.Kind != expr.OAuth2Kind {
-		eval.ReportError("cannot specify flow for non-oauth2 security scheme.")
-		return
-	}
-	current.Flows = append(current.Flows, &expr.FlowExpr{
+// ImplicitFlow accepts two arguments: the authorization and refresh URLs and
+// an optional DSL. The DSL may list the scopes supported by the flow with
+// Scope, see OAuth2Security.
+func ImplicitFlow(authorizationURL, refreshURL string, fn ...func()) {
+	addFlow(&expr.FlowExpr{
 		Kind:             expr.ImplicitFlowKind,
 		AuthorizationURL: authorizationURL,
 		RefreshURL:       refreshURL,
-	})
+	}, fn)
 }
 
 // PasswordFlow defines an Resource Owner Password Credentials OAuth2 flow as
@@ -615,22 +986,15 @@ func ImplicitFlow(authorizationURL, refreshURL string) {
 //
 // PasswordFlow must be used in OAuth2Security.
 //
-// PasswordFlow accepts two arguments: the token and refresh URLs.
-func PasswordFlow(tokenURL, refreshURL string) {
-	current, ok := eval.Current().(*expr.SchemeExpr)
-	if !ok {
-		eval.IncompatibleDSL()
-		return
-	}
-	if current.Kind != expr.OAuth2Kind {
-		eval.ReportError("cannot specify flow for non-oauth2 security scheme.")
-		return
-	}
-	current.Flows = append(current.Flows, &expr.FlowExpr{
+// PasswordFlow accepts two arguments: the token and refresh URLs and an
+// optional DSL. The DSL may list the scopes supported by the flow with Scope,
+// see OAuth2Security.
+func PasswordFlow(tokenURL, refreshURL string, fn ...func()) {
+	addFlow(&expr.FlowExpr{
 		Kind:       expr.PasswordFlowKind,
 		TokenURL:   tokenURL,
 		RefreshURL: refreshURL,
-	})
+	}, fn)
 }
 
 // ClientCredentialsFlow defines an clientCredentials OAuth2 flow as described
@@ -638,8 +1002,51 @@ func PasswordFlow(tokenURL, refreshURL string) {
 //
 // ClientCredentialsFlow must be used in OAuth2Security.
 //
-// ClientCredentialsFlow accepts two arguments: the token and refresh URLs.
-func ClientCredentialsFlow(tokenURL, refreshURL string) {
+// ClientCredentialsFlow accepts two arguments: the token and refresh URLs and
+// an optional DSL. The DSL may list the scopes supported by the flow with
+// Scope, see OAuth2Security.
+func ClientCredentialsFlow(tokenURL, refreshURL string, fn ...func()) {
+	addFlow(&expr.FlowExpr{
+		Kind:       expr.ClientCredentialsFlowKind,
+		TokenURL:   tokenURL,
+		RefreshURL: refreshURL,
+	}, fn)
+}
+
+// OpenIDConnect sets the OpenID Connect discovery URL of the security scheme.
+// The OpenAPI 3.1 specification describes the scheme as an "openIdConnect"
+// scheme whose configuration is retrieved from the URL. The generated code
+// still handles the tokens as JWT or OAuth2 access tokens.
+//
+// OpenIDConnect must be used in JWTSecurity or OAuth2Security.
+//
+// OpenIDConnect accepts one argument: the absolute URL of the OpenID Connect
+// discovery document.
+//
+// Example:
+//
+//    var OIDC = JWTSecurity("oidc", func() {
+//        OpenIDConnect("https://accounts.example.com/.well-known/openid-configuration")
+//        Scope("openid", "OpenID Connect authentication")
+//    })
+//
+func OpenIDConnect(discoveryURL string) {
+	current, ok := eval.Current().(*expr.SchemeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if current.Kind != expr.JWTKind && current.Kind != expr.OAuth2Kind {
+		eval.ReportError("OpenID Connect discovery URL can only be set on JWT or OAuth2 security schemes")
+		return
+	}
+	current.OpenIDConnectURL = discoveryURL
+}
+
+// addFlow adds the given flow to the OAuth2 security scheme being defined
+// after having run the optional flow DSL. The scopes listed in the flow DSL
+// are also added to the scheme scopes if not already defined.
+func addFlow(f *expr.FlowExpr, fn []func()) {
 	current, ok := eval.Current().(*expr.SchemeExpr)
 	if !ok {
 		eval.IncompatibleDSL()
@@ -649,11 +1056,24 @@ func ClientCredentialsFlow(tokenURL, refreshURL string) {
 		eval.ReportError("cannot specify flow for non-oauth2 security scheme.")
 		return
 	}
-	current.Flows = append(current.Flows, &expr.FlowExpr{
-		Kind:       expr.ClientCredentialsFlowKind,
-		TokenURL:   tokenURL,
-		RefreshURL: refreshURL,
-	})
+	if len(fn) > 0 {
+		if !eval.Execute(fn[0], f) {
+			return
+		}
+	}
+	for _, fs := range f.Scopes {
+		found := false
+		for _, s := range current.Scopes {
+			if s.Name == fs.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			current.Scopes = append(current.Scopes, fs)
+		}
+	}
+	current.Flows = append(current.Flows, f)
 }
 
 func securitySchemeRedefined(name string) bool {
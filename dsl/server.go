@@ -169,7 +169,10 @@ func URI(uri string) {
 //
 // The URI expression is leveraged by the example generator to produce the
 // service and client commands. It is also consumed by the OpenAPI specification
-// generator to initialize the server objects.
+// generator to initialize the server objects. The HTTP client packages of the
+// services exposed by the server define a function per host using variables
+// that builds the host URL from the variable values, e.g.
+// CalcsvrProductionURL(version string) (string, error) for the example below.
 //
 // Variable must appear in a Host expression.
 //
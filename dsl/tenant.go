@@ -0,0 +1,82 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Tenant defines how the API servers resolve the tenant of the incoming
+// requests. The tenant is carried by a HTTP header for the HTTP transport and
+// by metadata for the gRPC transport. The header defaults to "X-Tenant-ID" and
+// may be set with Header in the Tenant DSL.
+//
+// The generated endpoints reject the requests that do not carry a tenant with
+// a "missing_tenant" error. If the DSL defines a bearer token claim with Claim
+// then the endpoints also reject the requests whose bearer token claim does
+// not match the tenant with an "invalid_tenant" error. The generated
+// UseTenantValidator method of the service endpoints adds custom validations,
+// e.g. to reject unknown tenants.
+//
+// The generated clients forward the tenant of the incoming requests to the
+// outgoing requests. The generated "contextvalues" package provides the Tenant
+// and WithTenant functions to read the tenant from a context and to set it in
+// a context. The Prometheus metrics of the HTTP requests are labeled with the
+// tenant.
+//
+// Tenant must appear in API.
+//
+// Example:
+//
+//    var _ = API("saas", func() {
+//        Tenant(func() {
+//            Header("X-Tenant-ID")
+//            Claim("tenant_id")
+//        })
+//    })
+//
+func Tenant(fn ...func()) {
+	if len(fn) > 1 {
+		eval.ReportError("too many arguments given to Tenant")
+		return
+	}
+	a, ok := eval.Current().(*expr.APIExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	t := &expr.TenantExpr{Header: expr.DefaultTenantHeader}
+	if len(fn) == 1 {
+		if !eval.Execute(fn[0], t) {
+			return
+		}
+	}
+	a.Tenant = t
+}
+
+// Claim defines the name of the bearer token claim that must match the tenant
+// of the requests. The generated servers decode the claim from the bearer
+// token of the Authorization header (or metadata) and the generated endpoints
+// reject the requests whose tenant does not match. The check is skipped for
+// requests that do not carry a bearer token. The token signature is not
+// verified by the check, it must be verified by the method security schemes.
+//
+// Claim must appear in Tenant.
+//
+// Example:
+//
+//    Tenant(func() {
+//        Claim("tenant_id")
+//    })
+//
+func Claim(name string) {
+	t, ok := eval.Current().(*expr.TenantExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if name == "" {
+		eval.ReportError("claim name cannot be empty")
+		return
+	}
+	t.Claim = name
+}
@@ -1,9 +1,13 @@
 package dsl
 
 import (
+	"fmt"
+	"mime"
 	"reflect"
 	"regexp"
+	"regexp/syntax"
 	"strconv"
+	"unicode"
 
 	"goa.design/goa/v3/eval"
 	"goa.design/goa/v3/expr"
@@ -166,6 +170,13 @@ func Format(f expr.ValidationFormat) {
 // Pattern adds a "pattern" validation to the attribute.
 // See http://json-schema.org/latest/json-schema-validation.html#anchor33.
 //
+// The generated Go code evaluates patterns with package regexp which
+// guarantees linear time matching. Pattern reports a warning for the patterns
+// that cause catastrophic backtracking in the regular expression engines used
+// by other clients of the API (e.g. nested quantifiers such as "(a+)+"), the
+// generated code skips evaluating the pattern on values that exceed the
+// maximum length validation of the attribute if any.
+//
 // Example:
 //
 //    Attribute("pattern", String, func() {
@@ -185,6 +196,9 @@ func Pattern(p string) {
 					a.Validation = &expr.ValidationExpr{}
 				}
 				a.Validation.Pattern = p
+				if h := backtrackingHazard(p); h != "" {
+					eval.ReportWarning("pattern %#v may cause catastrophic backtracking in backtracking regular expression engines: %s", p, h)
+				}
 			}
 		}
 	}
@@ -274,6 +288,65 @@ func Maximum(val interface{}) {
 	}
 }
 
+// ExclusiveMinimum adds an "exclusiveMinimum" validation to the attribute:
+// the attribute value must be strictly greater than val.
+// See https://json-schema.org/draft/2020-12/json-schema-validation.html#name-exclusiveminimum.
+//
+// Example:
+//
+//    Attribute("ratio", Float64, func() {
+//        ExclusiveMinimum(0)
+//    })
+//
+func ExclusiveMinimum(val interface{}) {
+	if a, f, ok := numberValidation("exclusiveMinimum", val); ok {
+		a.Validation.ExclusiveMinimum = &f
+	}
+}
+
+// ExclusiveMaximum adds an "exclusiveMaximum" validation to the attribute:
+// the attribute value must be strictly lesser than val.
+// See https://json-schema.org/draft/2020-12/json-schema-validation.html#name-exclusivemaximum.
+//
+// Example:
+//
+//    Attribute("ratio", Float64, func() {
+//        ExclusiveMaximum(1)
+//    })
+//
+func ExclusiveMaximum(val interface{}) {
+	if a, f, ok := numberValidation("exclusiveMaximum", val); ok {
+		a.Validation.ExclusiveMaximum = &f
+	}
+}
+
+// MultipleOf adds a "multipleOf" validation to the attribute: the attribute
+// value must be a multiple of val. val must be strictly positive and must be
+// an integer if the attribute is an integer.
+// See https://json-schema.org/draft/2020-12/json-schema-validation.html#name-multipleof.
+//
+// Example:
+//
+//    Attribute("quantity", Int, func() {
+//        MultipleOf(10)
+//    })
+//
+func MultipleOf(val interface{}) {
+	a, f, ok := numberValidation("multipleOf", val)
+	if !ok {
+		return
+	}
+	if f <= 0 {
+		eval.ReportError("invalid multipleOf value %#v, must be strictly positive", val)
+		return
+	}
+	if a.Type != nil && a.Type.Kind() != expr.Float32Kind && a.Type.Kind() != expr.Float64Kind && f != float64(int64(f)) {
+		eval.ReportError("invalid multipleOf value %#v, must be an integer", val)
+		return
+	}
+	a.Validation.MultipleOf = &f
+}
+
 // MinLength adds a "minItems" validation to the attribute.
 // See http://json-schema.org/latest/json-schema-validation.html#anchor45.
 //
@@ -341,6 +414,151 @@ func MaxLength(val int) {
 	}
 }
 
+// MaxBytes adds a validation that limits the length of the UTF-8 encoding of
+// string values to the given number of bytes. MaxLength limits the number of
+// runes (characters) instead which makes it unsuitable to enforce storage
+// limits as a single rune may use up to four bytes.
+//
+// MaxBytes must appear in an Attribute expression of type String.
+//
+// Example:
+//
+//    Attribute("name", String, func() {
+//        MaxLength(50)  // at most 50 characters
+//        MaxBytes(100)  // stored in a column of 100 bytes
+//    })
+//
+func MaxBytes(val int) {
+	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
+		if a.Type != nil {
+			if a.Type.Kind() != expr.StringKind {
+				incompatibleAttributeType("maximum bytes", a.Type.Name(), "a string")
+				return
+			}
+		}
+		if val < 0 {
+			eval.ReportError("invalid maximum bytes value %d, must be positive", val)
+			return
+		}
+		if a.Validation == nil {
+			a.Validation = &expr.ValidationExpr{}
+		}
+		a.Validation.MaxBytes = &val
+	}
+}
+
+// UniqueBy adds a validation that requires the elements of an array of objects
+// to have distinct values for the attribute with the given name. The
+// attribute must be a string, a boolean or a number. The elements that do not
+// define the attribute are ignored.
+//
+// UniqueBy must appear in an Attribute expression of type array of objects.
+//
+// Example:
+//
+//    Attribute("items", ArrayOf(Item), func() {
+//        UniqueBy("id") // no two items may have the same id
+//    })
+//
+func UniqueBy(name string) {
+	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
+		if a.Type != nil && a.Type.Kind() != expr.ArrayKind {
+			incompatibleAttributeType("unique by", a.Type.Name(), "an array")
+			return
+		}
+		if a.Validation == nil {
+			a.Validation = &expr.ValidationExpr{}
+		}
+		a.Validation.UniqueBy = name
+	}
+}
+
+// ContentMediaType adds a validation that requires the content of bytes values
+// to match the given media type. The media type of the content is detected
+// with the algorithm described at https://mimesniff.spec.whatwg.org which
+// inspects the first 512 bytes, see http.DetectContentType. The media type may
+// use a wildcard subtype to accept a family of types, e.g. "image/*".
+//
+// ContentMediaType must appear in an Attribute expression of type Bytes.
+//
+// Example:
+//
+//    Attribute("avatar", Bytes, func() {
+//        ContentMediaType("image/png")
+//    })
+//
+func ContentMediaType(mediaType string) {
+	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
+		if a.Type != nil && a.Type.Kind() != expr.BytesKind {
+			incompatibleAttributeType("content media type", a.Type.Name(), "bytes")
+			return
+		}
+		if _, _, err := mime.ParseMediaType(mediaType); err != nil {
+			eval.ReportError("invalid content media type %q: %s", mediaType, err)
+			return
+		}
+		if a.Validation == nil {
+			a.Validation = &expr.ValidationExpr{}
+		}
+		a.Validation.ContentMediaType = mediaType
+	}
+}
+
+// validationFuncRegex matches package qualified function names.
+var validationFuncRegex = regexp.MustCompile(`^[\pL_][\pL\pN_]*\.[\pL_][\pL\pN_]*$`)
+
+// ValidateWith adds a validation that calls the given user function with the
+// attribute values. The function must accept a value of the attribute Go type
+// and return an error, the generated code reports the error as a validation
+// error ("invalid_value") for the attribute unless it is already a
+// goa.ServiceError. ValidateWith may be used multiple times to call multiple
+// functions.
+//
+// The first argument is the package qualified name of the function. The
+// second argument is the import path of the package and the optional third
+// argument the name used to import the package in case of conflicts as with
+// the "struct:field:type" meta.
+//
+// ValidateWith must appear in an Attribute expression of a primitive type. Use
+// Elem to validate the elements of arrays and maps.
+//
+// Example:
+//
+//    Attribute("iban", String, func() {
+//        ValidateWith("iban.Validate", "example.com/banking/iban")
+//    })
+//
+func ValidateWith(fn string, imports ...string) {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if a.Type != nil && !expr.IsPrimitive(a.Type) {
+		incompatibleAttributeType("validation function", a.Type.Name(), "a primitive")
+		return
+	}
+	if !validationFuncRegex.MatchString(fn) {
+		eval.ReportError("invalid validation function name %q, must be of the form package.Function", fn)
+		return
+	}
+	if len(imports) > 2 {
+		eval.ReportError("too many arguments given to ValidateWith")
+		return
+	}
+	f := &expr.ValidationFuncExpr{Name: fn}
+	if len(imports) > 0 {
+		f.ImportPath = imports[0]
+	}
+	if len(imports) > 1 {
+		f.ImportName = imports[1]
+	}
+	if a.Validation == nil {
+		a.Validation = &expr.ValidationExpr{}
+	}
+	a.Validation.Funcs = append(a.Validation.Funcs, f)
+}
+
 // Required adds a "required" validation to the attribute.
 // See http://json-schema.org/latest/json-schema-validation.html#anchor61.
 //
@@ -379,7 +597,231 @@ func Required(names ...string) {
 
 // incompatibleAttributeType reports an error for validations defined on
 // incompatible attributes (e.g. max value on string).
+// numberValidation returns the current attribute and the numeric value val of
+// the validation with the given name. The attribute validation is initialized
+// if needed. It reports an error and returns false if the attribute is not a
+// number or if val is not a number.
+func numberValidation(name string, val interface{}) (*expr.AttributeExpr, float64, bool) {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		return nil, 0, false
+	}
+	if a.Type != nil &&
+		a.Type.Kind() != expr.IntKind && a.Type.Kind() != expr.UIntKind &&
+		a.Type.Kind() != expr.Int32Kind && a.Type.Kind() != expr.UInt32Kind &&
+		a.Type.Kind() != expr.Int64Kind && a.Type.Kind() != expr.UInt64Kind &&
+		a.Type.Kind() != expr.Float32Kind && a.Type.Kind() != expr.Float64Kind {
+
+		incompatibleAttributeType(name, a.Type.Name(), "an integer or a number")
+		return nil, 0, false
+	}
+	var f float64
+	switch v := val.(type) {
+	case float32, float64, int, int8, int16, int32, int64, uint8, uint16, uint32, uint64:
+		f = reflect.ValueOf(v).Convert(reflect.TypeOf(float64(0.0))).Float()
+	case string:
+		var err error
+		f, err = strconv.ParseFloat(v, 64)
+		if err != nil {
+			eval.ReportError("invalid number value %#v", v)
+			return nil, 0, false
+		}
+	default:
+		eval.ReportError("invalid number value %#v", v)
+		return nil, 0, false
+	}
+	if a.Validation == nil {
+		a.Validation = &expr.ValidationExpr{}
+	}
+	return a, f, true
+}
+
 func incompatibleAttributeType(validation, actual, expected string) {
 	eval.ReportError("invalid %s validation definition: attribute must be %s (but type is %s)",
 		validation, expected, actual)
 }
+
+// backtrackingHazard returns a description of the construct of the regular
+// expression p that causes catastrophic backtracking in backtracking regular
+// expression engines, empty string if there is none. The constructs are the
+// unbounded repetitions of expressions that can match the same input in
+// multiple ways (see ambiguous) and of alternations with alternatives that
+// start with the same characters.
+func backtrackingHazard(p string) string {
+	re, err := syntax.Parse(p, syntax.Perl)
+	if err != nil {
+		return ""
+	}
+	var hazard func(re *syntax.Regexp) string
+	hazard = func(re *syntax.Regexp) string {
+		if isUnboundedRepeat(re) {
+			body := uncapture(re.Sub[0])
+			if body.Op == syntax.OpAlternate {
+				for i, alt := range body.Sub {
+					for _, other := range body.Sub[i+1:] {
+						if overlapping(firstRunes(alt), firstRunes(other)) {
+							return fmt.Sprintf("alternatives %q and %q of %q overlap", alt, other, re)
+						}
+					}
+				}
+			} else if ambiguous(body) {
+				return fmt.Sprintf("ambiguous repetition %q", re)
+			}
+		}
+		for _, s := range re.Sub {
+			if h := hazard(s); h != "" {
+				return h
+			}
+		}
+		return ""
+	}
+	return hazard(re)
+}
+
+// isUnboundedRepeat returns true if re is a repetition with no maximum.
+func isUnboundedRepeat(re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpStar, syntax.OpPlus:
+		return true
+	case syntax.OpRepeat:
+		return re.Max == -1
+	}
+	return false
+}
+
+// ambiguous returns true if the repeated expression body can match the same
+// input in multiple ways: if it is itself an unbounded repetition or if it
+// contains a variable length expression whose runes may also start the next
+// expression or the next repetition of body.
+func ambiguous(body *syntax.Regexp) bool {
+	if isUnboundedRepeat(body) {
+		return true
+	}
+	if body.Op != syntax.OpConcat {
+		return false
+	}
+	start := firstRunes(body)
+	for i, s := range body.Sub {
+		s = uncapture(s)
+		if !isVariable(s) {
+			continue
+		}
+		rs := firstRunes(s)
+		last := true
+		for _, next := range body.Sub[i+1:] {
+			if overlapping(rs, firstRunes(next)) {
+				return true
+			}
+			if !matchesEmpty(next) {
+				last = false
+				break
+			}
+		}
+		if last && overlapping(rs, start) {
+			return true
+		}
+	}
+	return false
+}
+
+// isVariable returns true if re is an optional expression or a repetition that
+// may match a variable number of times.
+func isVariable(re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest:
+		return true
+	case syntax.OpRepeat:
+		return re.Min != re.Max
+	case syntax.OpAlternate:
+		return matchesEmpty(re)
+	}
+	return false
+}
+
+// matchesEmpty returns true if re matches the empty string.
+func matchesEmpty(re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpEmptyMatch, syntax.OpStar, syntax.OpQuest,
+		syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText,
+		syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return true
+	case syntax.OpRepeat:
+		return re.Min == 0 || matchesEmpty(re.Sub[0])
+	case syntax.OpCapture, syntax.OpPlus:
+		return matchesEmpty(re.Sub[0])
+	case syntax.OpConcat:
+		for _, s := range re.Sub {
+			if !matchesEmpty(s) {
+				return false
+			}
+		}
+		return true
+	case syntax.OpAlternate:
+		for _, s := range re.Sub {
+			if matchesEmpty(s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// firstRunes returns the ranges of the runes that may start a match of re as
+// pairs of inclusive bounds.
+func firstRunes(re *syntax.Regexp) []rune {
+	switch re.Op {
+	case syntax.OpLiteral:
+		if len(re.Rune) == 0 {
+			return nil
+		}
+		r := re.Rune[0]
+		if re.Flags&syntax.FoldCase != 0 {
+			l, u := unicode.ToLower(r), unicode.ToUpper(r)
+			return []rune{l, l, u, u}
+		}
+		return []rune{r, r}
+	case syntax.OpCharClass:
+		return re.Rune
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		return []rune{0, unicode.MaxRune}
+	case syntax.OpCapture, syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		return firstRunes(re.Sub[0])
+	case syntax.OpConcat:
+		var rs []rune
+		for _, s := range re.Sub {
+			rs = append(rs, firstRunes(s)...)
+			if !matchesEmpty(s) {
+				break
+			}
+		}
+		return rs
+	case syntax.OpAlternate:
+		var rs []rune
+		for _, s := range re.Sub {
+			rs = append(rs, firstRunes(s)...)
+		}
+		return rs
+	}
+	return nil
+}
+
+// overlapping returns true if the rune ranges a and b intersect.
+func overlapping(a, b []rune) bool {
+	for i := 0; i+1 < len(a); i += 2 {
+		for j := 0; j+1 < len(b); j += 2 {
+			if a[i] <= b[j+1] && b[j] <= a[i+1] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// uncapture returns the expression captured by re if re is a capture group, re
+// otherwise.
+func uncapture(re *syntax.Regexp) *syntax.Regexp {
+	for re.Op == syntax.OpCapture {
+		re = re.Sub[0]
+	}
+	return re
+}
@@ -44,3 +44,98 @@ func TestFormat(t *testing.T) {
 		}
 	}
 }
+
+func TestCollectionValidations(t *testing.T) {
+	cases := map[string]struct {
+		Type  expr.DataType
+		DSL   func()
+		Error bool
+	}{
+		"max-bytes":                    {expr.String, func() { MaxBytes(10) }, false},
+		"max-bytes-not-string":         {expr.Int, func() { MaxBytes(10) }, true},
+		"max-bytes-negative":           {expr.String, func() { MaxBytes(-1) }, true},
+		"unique-by":                    {&expr.Array{ElemType: &expr.AttributeExpr{Type: &expr.Object{}}}, func() { UniqueBy("id") }, false},
+		"unique-by-not-array":          {expr.String, func() { UniqueBy("id") }, true},
+		"content-media-type":           {expr.Bytes, func() { ContentMediaType("image/*") }, false},
+		"content-media-type-not-bytes": {expr.String, func() { ContentMediaType("image/png") }, true},
+		"content-media-type-invalid":   {expr.Bytes, func() { ContentMediaType("image/") }, true},
+	}
+	for k, tc := range cases {
+		eval.Context = &eval.DSLContext{}
+		att := &expr.AttributeExpr{Type: tc.Type}
+		eval.Execute(tc.DSL, att)
+		if tc.Error && eval.Context.Errors == nil {
+			t.Errorf("%s: expected an error", k)
+		}
+		if !tc.Error {
+			if eval.Context.Errors != nil {
+				t.Errorf("%s: failed unexpectedly with %s", k, eval.Context.Errors)
+			} else if att.Validation == nil {
+				t.Errorf("%s: validation not initialized", k)
+			}
+		}
+	}
+}
+
+func TestValidateWith(t *testing.T) {
+	cases := map[string]struct {
+		Type  expr.DataType
+		DSL   func()
+		Error bool
+	}{
+		"func":          {expr.String, func() { ValidateWith("iban.Validate", "example.com/iban") }, false},
+		"func-named":    {expr.Int, func() { ValidateWith("checks.Count", "example.com/checks", "checks") }, false},
+		"not-primitive": {&expr.Object{}, func() { ValidateWith("iban.Validate") }, true},
+		"invalid-name":  {expr.String, func() { ValidateWith("Validate") }, true},
+		"too-many-args": {expr.String, func() { ValidateWith("iban.Validate", "a", "b", "c") }, true},
+	}
+	for k, tc := range cases {
+		eval.Context = &eval.DSLContext{}
+		att := &expr.AttributeExpr{Type: tc.Type}
+		eval.Execute(tc.DSL, att)
+		if tc.Error && eval.Context.Errors == nil {
+			t.Errorf("%s: expected an error", k)
+		}
+		if !tc.Error {
+			if eval.Context.Errors != nil {
+				t.Errorf("%s: failed unexpectedly with %s", k, eval.Context.Errors)
+			} else if att.Validation == nil || len(att.Validation.Funcs) != 1 {
+				t.Errorf("%s: validation function not recorded", k)
+			}
+		}
+	}
+}
+
+func TestPatternWarnings(t *testing.T) {
+	cases := map[string]bool{
+		`^[A-z].*[a-z]$`:      false,
+		`^[a-z]+(\.[a-z]+)*@`: false,
+		`^\d+(\.\d+)*$`:       false,
+		`^(\d{3}-?)+$`:        false,
+		`(ab|a)*`:             false,
+		`(a|b)*`:              false,
+		`(.*a){3}`:            false,
+		`(a+)+$`:              true,
+		`^(a*)*$`:             true,
+		`^(\w+\s?)*$`:         true,
+		`(x+x+)+y`:            true,
+		`(a|aa)+`:             true,
+		`(.*,)*`:              true,
+		`^([a-z]+ ?)+$`:       true,
+	}
+	for p, warn := range cases {
+		eval.Context = &eval.DSLContext{}
+		att := &expr.AttributeExpr{Type: expr.String}
+		eval.Execute(func() { Pattern(p) }, att)
+		if eval.Context.Errors != nil {
+			t.Errorf("%s: unexpected error %s", p, eval.Context.Errors)
+			continue
+		}
+		if warned := len(eval.Context.Warnings) > 0; warned != warn {
+			t.Errorf("%s: got warnings %v, expected warning: %v", p, eval.Context.Warnings, warn)
+		}
+		if att.Validation == nil || att.Validation.Pattern != p {
+			t.Errorf("%s: pattern not recorded", p)
+		}
+	}
+}
@@ -15,6 +15,10 @@ type (
 		// Errors contains the DSL execution errors for the current expression set.
 		// Errors is an instance of MultiError.
 		Errors error
+		// Warnings contains the DSL warnings for the current expression
+		// set. Warnings describe design issues that do not prevent code
+		// generation.
+		Warnings MultiError
 
 		// roots is the list of DSL roots as registered by all loaded DSLs.
 		roots []Root
@@ -22,6 +26,9 @@ type (
 		// may skip any callstack frame that belongs to them when computing error
 		// locations.
 		dslPackages []string
+		// locations records the locations of the DSL functions that
+		// initialized the expressions, see Location.
+		locations map[Expression]*location
 	}
 
 	// Stack represents the expression evaluation stack. The stack is appended to
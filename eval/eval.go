@@ -73,6 +73,7 @@ func Execute(fn func(), def Expression) bool {
 	if Context.Errors != nil {
 		startCount = len(Context.Errors.(MultiError))
 	}
+	recordLocation(fn, def)
 	Context.Stack = append(Context.Stack, def)
 	fn()
 	Context.Stack = Context.Stack[:len(Context.Stack)-1]
@@ -113,6 +114,26 @@ func ReportError(fm string, vals ...interface{}) {
 	})
 }
 
+// ReportWarning records a DSL warning for reporting post DSL execution. It
+// accepts a format and values a la fmt.Printf. Warnings do not cause the DSL
+// execution to fail.
+func ReportWarning(fm string, vals ...interface{}) {
+	var suffix string
+	if cur := Context.Stack.Current(); cur != nil {
+		if name := cur.EvalName(); name != "" {
+			suffix = fmt.Sprintf(" in %s", name)
+		}
+	} else {
+		suffix = " (top level)"
+	}
+	file, line := computeErrorLocation()
+	Context.Warnings = append(Context.Warnings, &Error{
+		GoError: fmt.Errorf(fm+suffix, vals...),
+		File:    file,
+		Line:    line,
+	})
+}
+
 // IncompatibleDSL should be called by DSL functions when they are invoked in an
 // incorrect context (e.g. "Params" in "Service").
 func IncompatibleDSL() {
@@ -0,0 +1,59 @@
+package eval
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+)
+
+// location is the location of a DSL function in the design source code.
+type location struct {
+	file string
+	line int
+}
+
+// Location returns the file and line of the DSL function that initialized the
+// given expression, e.g. the anonymous function given to Method. The file path
+// is relative to the working directory if possible. Location returns an empty
+// string and 0 if the expression was not initialized with a DSL function.
+func Location(e Expression) (file string, line int) {
+	if e == nil || reflect.TypeOf(e).Kind() != reflect.Ptr {
+		return "", 0
+	}
+	if l, ok := Context.locations[e]; ok {
+		return l.file, l.line
+	}
+	return "", 0
+}
+
+// FormatLocation returns the location of the DSL function that initialized
+// the given expression formatted as "file:line", an empty string if there is
+// none.
+func FormatLocation(e Expression) string {
+	file, line := Location(e)
+	if file == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", file, line)
+}
+
+// recordLocation records the location of the DSL function fn that initializes
+// def. Only the first DSL function is recorded as expressions may be extended
+// by subsequent DSLs (e.g. HTTP in Service).
+func recordLocation(fn func(), def Expression) {
+	if def == nil || reflect.TypeOf(def).Kind() != reflect.Ptr {
+		return
+	}
+	if _, ok := Context.locations[def]; ok {
+		return
+	}
+	f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer())
+	if f == nil {
+		return
+	}
+	file, line := f.FileLine(f.Entry())
+	if Context.locations == nil {
+		Context.locations = make(map[Expression]*location)
+	}
+	Context.locations[def] = &location{file: relativePath(file), line: line}
+}
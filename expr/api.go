@@ -1,8 +1,13 @@
 package expr
 
 import (
+	"fmt"
+	"path"
+	"path/filepath"
 	"sort"
+	"strings"
 
+	"goa.design/goa/v3/compat"
 	"goa.design/goa/v3/eval"
 )
 
@@ -29,6 +34,9 @@ type (
 		License *LicenseExpr
 		// Docs points to the API external documentation.
 		Docs *DocsExpr
+		// Tags lists the top level tags used to group the API methods in
+		// the generated documentation.
+		Tags []*TagExpr
 		// Meta is a list of key/value pairs.
 		Meta MetaExpr
 		// Requirements contains the security requirements that apply to
@@ -36,10 +44,33 @@ type (
 		// potentially multiple schemes. Incoming requests must validate
 		// at least one requirement to be authorized.
 		Requirements []*SecurityExpr
+		// Realm is the default protection space of the API services
+		// used in the authentication challenges.
+		Realm string
+		// Propagation describes the request context values propagated
+		// by all the API service methods.
+		Propagation *PropagationExpr
+		// ContextValues lists the typed request context values
+		// extracted from the incoming requests and propagated to the
+		// outgoing requests by all the API service methods.
+		ContextValues []*ContextValueExpr
+		// Tenant describes how the API servers resolve the tenant of the
+		// incoming requests if any.
+		Tenant *TenantExpr
+		// HealthCheck describes the liveness and readiness endpoints
+		// served by the API servers if any.
+		HealthCheck *HealthCheckExpr
 		// HTTP contains the HTTP specific API level expressions.
 		HTTP *HTTPExpr
 		// GRPC contains the gRPC specific API level expressions.
 		GRPC *GRPCExpr
+		// Plugins lists the import paths of the plugin packages loaded
+		// by the goa tool when generating code for the design.
+		Plugins []string
+		// Generators lists the functions that generate additional files
+		// defined in the design with codegen.Generate. The functions are
+		// of type codegen.InlineGenerator.
+		Generators []interface{}
 
 		// random generator used to build examples for the API types.
 		random *Random
@@ -70,6 +101,23 @@ type (
 		// URL to documentation.
 		URL string `json:"url,omitempty"`
 	}
+
+	// TagExpr describes a tag used to group the API methods in the
+	// generated documentation. Tags may contain other tags in which case
+	// the OpenAPI specification lists the tags contained in top level
+	// tags in tag groups.
+	TagExpr struct {
+		// Name of tag.
+		Name string
+		// Description of tag.
+		Description string
+		// Docs points to the tag external documentation.
+		Docs *DocsExpr
+		// Parent is the tag containing this tag if any.
+		Parent *TagExpr
+		// Tags lists the tags contained in this tag.
+		Tags []*TagExpr
+	}
 )
 
 // NewAPIExpr initializes an API expression.
@@ -131,9 +179,103 @@ func (a *APIExpr) DefaultServer() *ServerExpr {
 	}
 }
 
+// DefaultHTTPURL returns the scheme and host of the first HTTP URI of the
+// first server with the host variables replaced with their default values. The
+// URI path is ignored as the HTTP routes are not relative to it. It returns
+// "http://localhost:80" if no server defines a HTTP URI.
+func (a *APIExpr) DefaultHTTPURL() string {
+	for _, s := range a.Servers {
+		for _, h := range s.Hosts {
+			for _, u := range h.URIs {
+				uri := string(u)
+				if !strings.HasPrefix(uri, "http://") && !strings.HasPrefix(uri, "https://") {
+					continue
+				}
+				if h.Variables != nil {
+					if obj := AsObject(h.Variables.Type); obj != nil {
+						for _, nat := range *obj {
+							uri = strings.Replace(uri, "{"+nat.Name+"}", fmt.Sprintf("%v", nat.Attribute.DefaultValue), -1)
+						}
+					}
+				}
+				i := strings.Index(uri, "://") + 3
+				if j := strings.Index(uri[i:], "/"); j >= 0 {
+					uri = uri[:i+j]
+				}
+				return uri
+			}
+		}
+	}
+	return "http://localhost:80"
+}
+
 // EvalName is the qualified name of the expression.
 func (a *APIExpr) EvalName() string { return "API " + a.Name }
 
+// Validate makes sure the tag names are unique.
+func (a *APIExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	seen := make(map[string]bool)
+	var validate func([]*TagExpr)
+	validate = func(tags []*TagExpr) {
+		for _, t := range tags {
+			if seen[t.Name] {
+				verr.Add(t, "tag %q is defined more than once", t.Name)
+			}
+			seen[t.Name] = true
+			validate(t.Tags)
+		}
+	}
+	validate(a.Tags)
+	for _, key := range []string{"goa:layout:gen", "goa:layout:openapi", "goa:layout:proto", "goa:layout:example"} {
+		v, ok := a.Meta.Last(key)
+		if !ok {
+			continue
+		}
+		if p := path.Clean(filepath.ToSlash(v)); v == "" || path.IsAbs(p) || filepath.IsAbs(v) || p == ".." || strings.HasPrefix(p, "../") {
+			verr.Add(a, "invalid %s meta %q: must be a directory relative to the output directory", key, v)
+		} else if key == "goa:layout:gen" && p == "." {
+			verr.Add(a, "invalid %s meta %q: the generated packages cannot be located in the output directory", key, v)
+		}
+	}
+	if v, ok := a.Meta.Last("goa:compat"); ok {
+		found := false
+		for _, cv := range compat.Versions {
+			if v == cv {
+				found = true
+				break
+			}
+		}
+		if !found {
+			verr.Add(a, "invalid goa:compat meta %q: must be one of %s", v, strings.Join(compat.Versions, ", "))
+		}
+	}
+	for _, p := range a.Plugins {
+		if p == "" || strings.TrimSpace(p) != p {
+			verr.Add(a, "invalid plugin import path %q", p)
+		}
+	}
+	if len(verr.Errors) == 0 {
+		return nil
+	}
+	return verr
+}
+
+// AllTags returns the tags defined in the API including the tags contained in
+// other tags. Containing tags are listed before the tags they contain.
+func (a *APIExpr) AllTags() []*TagExpr {
+	var tags []*TagExpr
+	var collect func([]*TagExpr)
+	collect = func(ts []*TagExpr) {
+		for _, t := range ts {
+			tags = append(tags, t)
+			collect(t.Tags)
+		}
+	}
+	collect(a.Tags)
+	return tags
+}
+
 // Hash returns a unique hash value for a.
 func (a *APIExpr) Hash() string { return "_api_+" + a.Name }
 
@@ -160,3 +302,6 @@ func (d *DocsExpr) EvalName() string { return "Documentation " + d.URL }
 
 // EvalName is the qualified name of the expression.
 func (c *ContactExpr) EvalName() string { return "Contact " + c.Name }
+
+// EvalName is the qualified name of the expression.
+func (t *TagExpr) EvalName() string { return "Tag " + t.Name }
@@ -1,6 +1,7 @@
 package expr
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -183,3 +184,57 @@ func TestLicenseExprEvalName(t *testing.T) {
 		}
 	}
 }
+
+func TestAPIExprValidate(t *testing.T) {
+	cases := map[string]struct {
+		tags     []*TagExpr
+		meta     MetaExpr
+		plugins  []string
+		expected string
+	}{
+		"no tag":        {},
+		"unique tags":   {tags: []*TagExpr{{Name: "a", Tags: []*TagExpr{{Name: "b"}}}, {Name: "c"}}},
+		"duplicate tag": {tags: []*TagExpr{{Name: "a"}, {Name: "a"}}, expected: `tag "a" is defined more than once`},
+		"duplicate child": {
+			tags:     []*TagExpr{{Name: "a", Tags: []*TagExpr{{Name: "a"}}}},
+			expected: `tag "a" is defined more than once`,
+		},
+		"layout":          {meta: MetaExpr{"goa:layout:gen": {"internal/gen"}, "goa:layout:openapi": {"api"}}},
+		"absolute layout": {meta: MetaExpr{"goa:layout:proto": {"/proto"}}, expected: `invalid goa:layout:proto meta "/proto"`},
+		"outer layout":    {meta: MetaExpr{"goa:layout:openapi": {"a/../../api"}}, expected: `invalid goa:layout:openapi meta "a/../../api"`},
+		"root gen layout": {meta: MetaExpr{"goa:layout:gen": {"."}}, expected: `the generated packages cannot be located in the output directory`},
+		"compat":          {meta: MetaExpr{"goa:compat": {"v1"}}},
+		"invalid compat":  {meta: MetaExpr{"goa:compat": {"v0"}}, expected: `invalid goa:compat meta "v0": must be one of v1`},
+		"plugins":         {plugins: []string{"goa.design/plugins/v3/cors"}},
+		"invalid plugin":  {plugins: []string{""}, expected: `invalid plugin import path ""`},
+	}
+	for k, tc := range cases {
+		api := APIExpr{Name: "test", Tags: tc.tags, Meta: tc.meta, Plugins: tc.plugins}
+		err := api.Validate()
+		if tc.expected == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %s", k, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("%s: expected error %q", k, tc.expected)
+		} else if !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("%s: got error %q, expected %q", k, err.Error(), tc.expected)
+		}
+	}
+}
+
+func TestAPIExprAllTags(t *testing.T) {
+	api := APIExpr{Tags: []*TagExpr{
+		{Name: "a", Tags: []*TagExpr{{Name: "b", Tags: []*TagExpr{{Name: "c"}}}, {Name: "d"}}},
+		{Name: "e"},
+	}}
+	var names []string
+	for _, tag := range api.AllTags() {
+		names = append(names, tag.Name)
+	}
+	if got := strings.Join(names, ","); got != "a,b,c,d,e" {
+		t.Errorf("got %q, expected %q", got, "a,b,c,d,e")
+	}
+}
@@ -0,0 +1,45 @@
+package expr
+
+import (
+	"goa.design/goa/v3/eval"
+)
+
+const (
+	// JobStatusMethodName is the name of the method generated in the
+	// services that define async methods to poll the status of the jobs.
+	JobStatusMethodName = "get_job_status"
+	// JobStatusTypeName is the name of the result type of the async
+	// methods and of the job status method.
+	JobStatusTypeName = "JobStatus"
+	// JobNotFoundErrorName is the name of the error returned by the job
+	// status method when there is no job with the given ID.
+	JobNotFoundErrorName = "job_not_found"
+	// DefaultJobStatusPath is the path of the HTTP endpoint of the job
+	// status method relative to the service base path.
+	DefaultJobStatusPath = "/jobs/{id}"
+)
+
+// JobStatusMethod returns the method generated to poll the status of the jobs
+// started by the async methods of the service, nil if the service has no
+// async method.
+func (s *ServiceExpr) JobStatusMethod() *MethodExpr {
+	for _, m := range s.Methods {
+		if m.Async {
+			return s.Method(JobStatusMethodName)
+		}
+	}
+	return nil
+}
+
+// validateAsync makes sure the async method does not stream and returns the
+// job status.
+func (m *MethodExpr) validateAsync() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if m.IsStreaming() {
+		verr.Add(m, "streaming methods cannot be async")
+	}
+	if ut, ok := m.Result.Type.(UserType); !ok || ut.Name() != JobStatusTypeName {
+		verr.Add(m, "async methods cannot define a result, the result is the status of the job")
+	}
+	return verr
+}
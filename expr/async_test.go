@@ -0,0 +1,54 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestAsync(t *testing.T) {
+	root := expr.RunDSL(t, testdata.AsyncDSL)
+	svc := root.Services[0]
+	if len(svc.Methods) != 3 {
+		t.Fatalf("got %d methods, expected 3", len(svc.Methods))
+	}
+	js := svc.JobStatusMethod()
+	if js == nil || js.Name != expr.JobStatusMethodName {
+		t.Fatalf("got job status method %v, expected %q", js, expr.JobStatusMethodName)
+	}
+	for _, m := range []*expr.MethodExpr{svc.Method("generate"), svc.Method("export"), js} {
+		if ut, ok := m.Result.Type.(expr.UserType); !ok || ut.Name() != expr.JobStatusTypeName {
+			t.Errorf("got result %v for %q, expected %s", m.Result.Type, m.Name, expr.JobStatusTypeName)
+		}
+	}
+	hs := root.API.HTTP.Service("reports")
+	if e := hs.Endpoint("generate"); e.Responses[0].StatusCode != expr.StatusAccepted {
+		t.Errorf("got status %d, expected %d", e.Responses[0].StatusCode, expr.StatusAccepted)
+	}
+	e := hs.Endpoint(expr.JobStatusMethodName)
+	if e == nil || e.Routes[0].Method != "GET" || e.Routes[0].Path != expr.DefaultJobStatusPath {
+		t.Errorf("got job status endpoint %v, expected GET %s", e, expr.DefaultJobStatusPath)
+	}
+}
+
+func TestAsyncInvalid(t *testing.T) {
+	err := expr.RunInvalidDSL(t, testdata.AsyncInvalidDSL)
+	for _, e := range []string{
+		`async methods cannot define a result, the result is the status of the job`,
+		`streaming methods cannot be async`,
+	} {
+		if !strings.Contains(err.Error(), e) {
+			t.Errorf("got error %q, expected it to contain %q", err.Error(), e)
+		}
+	}
+	err = expr.RunInvalidDSL(t, testdata.AsyncResultDSL)
+	if !strings.Contains(err.Error(), `async methods cannot define a result`) {
+		t.Errorf("got error %q, expected result error", err.Error())
+	}
+	err = expr.RunInvalidDSL(t, testdata.AsyncTypeConflictDSL)
+	if !strings.Contains(err.Error(), `type "JobStatus" conflicts with the job status type`) {
+		t.Errorf("got error %q, expected type conflict", err.Error())
+	}
+}
@@ -2,6 +2,7 @@ package expr
 
 import (
 	"fmt"
+	"strconv"
 
 	"goa.design/goa/v3/eval"
 )
@@ -75,6 +76,18 @@ type (
 		// Maximum represents a maximum value validation as described at
 		// http://json-schema.org/latest/json-schema-validation.html#anchor17.
 		Maximum *float64
+		// ExclusiveMinimum represents an exclusive minimum value
+		// validation as described at
+		// https://json-schema.org/draft/2020-12/json-schema-validation.html#name-exclusiveminimum.
+		ExclusiveMinimum *float64
+		// ExclusiveMaximum represents an exclusive maximum value
+		// validation as described at
+		// https://json-schema.org/draft/2020-12/json-schema-validation.html#name-exclusivemaximum.
+		ExclusiveMaximum *float64
+		// MultipleOf represents a multiple of validation as described
+		// at
+		// https://json-schema.org/draft/2020-12/json-schema-validation.html#name-multipleof.
+		MultipleOf *float64
 		// MinLength represents an minimum length validation as
 		// described at
 		// http://json-schema.org/latest/json-schema-validation.html#anchor29.
@@ -83,12 +96,43 @@ type (
 		// described at
 		// http://json-schema.org/latest/json-schema-validation.html#anchor26.
 		MaxLength *int
+		// MaxBytes represents a maximum length of the UTF-8 encoding of
+		// string values in bytes. It differs from MaxLength which
+		// limits the number of runes.
+		MaxBytes *int
+		// UniqueBy is the name of the attribute of the elements of
+		// arrays of objects whose values must be unique across the
+		// elements.
+		UniqueBy string
+		// ContentMediaType represents a content media type validation
+		// of bytes values as described at
+		// https://json-schema.org/draft/2020-12/json-schema-validation.html#name-contentmediatype.
+		// The media type may use a wildcard subtype, e.g. "image/*".
+		ContentMediaType string
+		// Funcs lists the user functions that validate the values in
+		// addition to the validations above.
+		Funcs []*ValidationFuncExpr
 		// Required list the required fields of object attributes as
 		// described at
 		// http://json-schema.org/latest/json-schema-validation.html#anchor61.
 		Required []string
 	}
 
+	// ValidationFuncExpr describes a user function that validates the
+	// values of an attribute. The function accepts the value and returns
+	// an error if it is invalid.
+	ValidationFuncExpr struct {
+		// Name is the package qualified name of the function, e.g.
+		// "iban.Validate".
+		Name string
+		// ImportPath is the import path of the package that defines
+		// the function.
+		ImportPath string
+		// ImportName is the name used to import the package, empty if
+		// the package name is used.
+		ImportName string
+	}
+
 	// ValidationFormat is the type used to enumerate the possible string
 	// formats.
 	ValidationFormat string
@@ -138,11 +182,46 @@ const (
 	FormatRFC1123 = "rfc1123"
 )
 
+// MaxItemsValidatedMetaKey is the meta key that sets the maximum number of
+// elements of an array or map attribute validated by the generated code.
+const MaxItemsValidatedMetaKey = "validation:max-items-validated"
+
+const (
+	// DecodeLenientMetaKey is the meta key that enables the lenient
+	// decoding of the values of an attribute, see LenientDecoding.
+	DecodeLenientMetaKey = "decode:lenient"
+	// DecodeTrimMetaKey is the meta key that makes the generated code
+	// trim the leading and trailing white space of the string values of
+	// an attribute prior to validating them.
+	DecodeTrimMetaKey = "decode:trim"
+)
+
 // EvalName returns the name used by the DSL evaluation.
 func (a *AttributeExpr) EvalName() string {
 	return "attribute"
 }
 
+// MaxItemsValidated returns the maximum number of elements of the array or map
+// described by a that the generated code validates, zero if there is no limit.
+// The limit is set with the "validation:max-items-validated" meta on the
+// attribute or on its user type.
+func MaxItemsValidated(a *AttributeExpr) int {
+	v, ok := a.Meta.Last(MaxItemsValidatedMetaKey)
+	if !ok {
+		if ut, isut := a.Type.(UserType); isut {
+			v, ok = ut.Attribute().Meta.Last(MaxItemsValidatedMetaKey)
+		}
+	}
+	if !ok {
+		return 0
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
 // validated keeps track of validated attributes to handle cyclical definitions.
 var validated = make(map[*AttributeExpr]bool)
 
@@ -197,8 +276,10 @@ func (a *AttributeExpr) Validate(ctx string, parent eval.Expression) *eval.Valid
 	verr.Merge(a.validateEnumDefault(ctx, parent))
 	if o := AsObject(a.Type); o != nil {
 		for _, n := range a.AllRequired() {
-			if a.Find(n) == nil {
+			if att := a.Find(n); att == nil {
 				verr.Add(parent, `%srequired field %q does not exist in type %s`, ctx, n, a.Type.Name())
+			} else if _, ok := att.Meta["security:scope"]; ok {
+				verr.Add(parent, `%sfield %q requires security scopes and cannot be required`, ctx, n)
 			}
 		}
 		for _, nat := range *o {
@@ -209,6 +290,36 @@ func (a *AttributeExpr) Validate(ctx string, parent eval.Expression) *eval.Valid
 		if ar := AsArray(a.Type); ar != nil {
 			elemType := ar.ElemType
 			verr.Merge(elemType.Validate(ctx, a))
+			if a.Validation != nil && a.Validation.UniqueBy != "" {
+				key := a.Validation.UniqueBy
+				if AsObject(elemType.Type) == nil {
+					verr.Add(parent, "%sunique by %q requires an array of objects", ctx, key)
+				} else if att := elemType.Find(key); att == nil {
+					verr.Add(parent, "%sunique by field %q does not exist in type %s", ctx, key, elemType.Type.Name())
+				} else if !IsPrimitive(att.Type) || att.Type.Kind() == BytesKind || att.Type.Kind() == AnyKind {
+					verr.Add(parent, "%sunique by field %q must be a string, a boolean or a number", ctx, key)
+				}
+			}
+		}
+	}
+
+	if a.Validation != nil && len(a.Validation.Funcs) > 0 {
+		if !IsPrimitive(a.Type) {
+			verr.Add(parent, "%svalidation function %s requires a primitive type (but type is %s)", ctx, a.Validation.Funcs[0].Name, a.Type.Name())
+		}
+	}
+
+	if v, ok := a.Meta.Last(MaxItemsValidatedMetaKey); ok {
+		if n, err := strconv.Atoi(v); err != nil || n <= 0 {
+			verr.Add(parent, "%sinvalid %s meta value %q, must be a positive integer", ctx, MaxItemsValidatedMetaKey, v)
+		} else if !IsArray(a.Type) && !IsMap(a.Type) {
+			verr.Add(parent, "%s%s meta requires an array or a map (but type is %s)", ctx, MaxItemsValidatedMetaKey, a.Type.Name())
+		}
+	}
+
+	for _, scope := range a.Meta["security:scope"] {
+		if err := validateScopeName(scope); err != "" {
+			verr.Add(parent, "%sinvalid scope %q: %s", ctx, scope, err)
 		}
 	}
 
@@ -588,12 +699,42 @@ func (v *ValidationExpr) Merge(other *ValidationExpr) {
 	if v.Maximum == nil || (other.Maximum != nil && *v.Maximum < *other.Maximum) {
 		v.Maximum = other.Maximum
 	}
+	if v.ExclusiveMinimum == nil || (other.ExclusiveMinimum != nil && *v.ExclusiveMinimum > *other.ExclusiveMinimum) {
+		v.ExclusiveMinimum = other.ExclusiveMinimum
+	}
+	if v.ExclusiveMaximum == nil || (other.ExclusiveMaximum != nil && *v.ExclusiveMaximum < *other.ExclusiveMaximum) {
+		v.ExclusiveMaximum = other.ExclusiveMaximum
+	}
+	if v.MultipleOf == nil {
+		v.MultipleOf = other.MultipleOf
+	}
 	if v.MinLength == nil || (other.MinLength != nil && *v.MinLength > *other.MinLength) {
 		v.MinLength = other.MinLength
 	}
 	if v.MaxLength == nil || (other.MaxLength != nil && *v.MaxLength < *other.MaxLength) {
 		v.MaxLength = other.MaxLength
 	}
+	if v.MaxBytes == nil || (other.MaxBytes != nil && *v.MaxBytes < *other.MaxBytes) {
+		v.MaxBytes = other.MaxBytes
+	}
+	if v.UniqueBy == "" {
+		v.UniqueBy = other.UniqueBy
+	}
+	if v.ContentMediaType == "" {
+		v.ContentMediaType = other.ContentMediaType
+	}
+	for _, f := range other.Funcs {
+		found := false
+		for _, ff := range v.Funcs {
+			if f.Name == ff.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.Funcs = append(v.Funcs, f)
+		}
+	}
 	v.AddRequired(other.Required...)
 }
 
@@ -635,6 +776,12 @@ func (v *ValidationExpr) HasRequiredOnly() bool {
 	if (v.Minimum != nil) || (v.Maximum != nil) || (v.MinLength != nil) || (v.MaxLength != nil) {
 		return false
 	}
+	if (v.ExclusiveMinimum != nil) || (v.ExclusiveMaximum != nil) || (v.MultipleOf != nil) {
+		return false
+	}
+	if v.MaxBytes != nil || v.UniqueBy != "" || v.ContentMediaType != "" || len(v.Funcs) > 0 {
+		return false
+	}
 	return true
 }
 
@@ -646,14 +793,21 @@ func (v *ValidationExpr) Dup() *ValidationExpr {
 		copy(req, v.Required)
 	}
 	return &ValidationExpr{
-		Values:    v.Values,
-		Format:    v.Format,
-		Pattern:   v.Pattern,
-		Minimum:   v.Minimum,
-		Maximum:   v.Maximum,
-		MinLength: v.MinLength,
-		MaxLength: v.MaxLength,
-		Required:  req,
+		Values:           v.Values,
+		Format:           v.Format,
+		Pattern:          v.Pattern,
+		Minimum:          v.Minimum,
+		Maximum:          v.Maximum,
+		ExclusiveMinimum: v.ExclusiveMinimum,
+		ExclusiveMaximum: v.ExclusiveMaximum,
+		MultipleOf:       v.MultipleOf,
+		MinLength:        v.MinLength,
+		MaxLength:        v.MaxLength,
+		MaxBytes:         v.MaxBytes,
+		UniqueBy:         v.UniqueBy,
+		ContentMediaType: v.ContentMediaType,
+		Funcs:            v.Funcs,
+		Required:         req,
 	}
 }
 
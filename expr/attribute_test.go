@@ -284,6 +284,56 @@ func TestAttributeExprValidate(t *testing.T) {
 			metadata: metadata,
 			expected: &eval.ValidationErrors{Errors: []error{errTypeNotDefineView}},
 		},
+		"required field requires scopes": {
+			typ: &Object{
+				&NamedAttributeExpr{
+					Name: "foo",
+					Attribute: &AttributeExpr{
+						Type: String,
+						Meta: MetaExpr{"security:scope": {"pii:read"}},
+					},
+				},
+			},
+			validation: validation,
+			expected:   &eval.ValidationErrors{Errors: []error{fmt.Errorf(`%sfield %q requires security scopes and cannot be required`, normalizedCtx, "foo")}},
+		},
+		"unique by field does not exist": {
+			typ: &Array{
+				ElemType: &AttributeExpr{
+					Type: &Object{
+						&NamedAttributeExpr{Name: "id", Attribute: &AttributeExpr{Type: String}},
+					},
+				},
+			},
+			validation: &ValidationExpr{UniqueBy: "key"},
+			expected:   &eval.ValidationErrors{Errors: []error{fmt.Errorf(`%sunique by field %q does not exist in type %s`, normalizedCtx, "key", "object")}},
+		},
+		"unique by non primitive field": {
+			typ: &Array{
+				ElemType: &AttributeExpr{
+					Type: &Object{
+						&NamedAttributeExpr{Name: "id", Attribute: &AttributeExpr{Type: Bytes}},
+					},
+				},
+			},
+			validation: &ValidationExpr{UniqueBy: "id"},
+			expected:   &eval.ValidationErrors{Errors: []error{fmt.Errorf(`%sunique by field %q must be a string, a boolean or a number`, normalizedCtx, "id")}},
+		},
+		"invalid max items validated": {
+			typ:      &Array{ElemType: &AttributeExpr{Type: String}},
+			metadata: MetaExpr{"validation:max-items-validated": {"0"}},
+			expected: &eval.ValidationErrors{Errors: []error{fmt.Errorf(`%sinvalid %s meta value %q, must be a positive integer`, normalizedCtx, "validation:max-items-validated", "0")}},
+		},
+		"max items validated on non collection": {
+			typ:      String,
+			metadata: MetaExpr{"validation:max-items-validated": {"10"}},
+			expected: &eval.ValidationErrors{Errors: []error{fmt.Errorf(`%s%s meta requires an array or a map (but type is %s)`, normalizedCtx, "validation:max-items-validated", "string")}},
+		},
+		"invalid field scope": {
+			typ:      String,
+			metadata: MetaExpr{"security:scope": {"pii*"}},
+			expected: &eval.ValidationErrors{Errors: []error{fmt.Errorf(`%sinvalid scope %q: wildcard "*" must be a whole segment`, normalizedCtx, "pii*")}},
+		},
 	}
 
 	for k, tc := range cases {
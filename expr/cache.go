@@ -0,0 +1,92 @@
+package expr
+
+import (
+	"fmt"
+	"time"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// CacheExpr describes the memoization of the results of a method. The
+	// results are cached for TTL keyed by the values of the key payload
+	// attributes.
+	CacheExpr struct {
+		// Method is the cached method.
+		Method *MethodExpr
+		// TTL is the duration during which a cached result is served.
+		TTL time.Duration
+		// Keys lists the names of the payload attributes whose values key
+		// the cached results. The whole payload keys the results if
+		// Keys is empty.
+		Keys []string
+	}
+
+	// CacheKeyExpr lists the payload attributes given to CacheKey in a Cache
+	// expression.
+	CacheKeyExpr struct {
+		// Attributes lists the names of the key payload attributes.
+		Attributes []string
+	}
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (c *CacheExpr) EvalName() string {
+	return fmt.Sprintf("cache of %s", c.Method.EvalName())
+}
+
+// Validate makes sure the method does not stream and that the key attributes
+// are primitive attributes of the payload that include the credentials of
+// secured methods.
+func (c *CacheExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if c.TTL <= 0 {
+		verr.Add(c, "TTL must be greater than zero, got %s", c.TTL)
+	}
+	if c.Method.IsStreaming() {
+		verr.Add(c, "streaming methods cannot be cached")
+	}
+	if len(c.Keys) == 0 {
+		return verr
+	}
+	obj := AsObject(c.Method.Payload.Type)
+	if obj == nil {
+		verr.Add(c, "CacheKey requires an object payload")
+		return verr
+	}
+	for _, k := range c.Keys {
+		att := obj.Attribute(k)
+		if att == nil {
+			verr.Add(c, "key attribute %q is not a payload attribute", k)
+			continue
+		}
+		if !IsPrimitive(att.Type) {
+			verr.Add(c, "key attribute %q must be a primitive", k)
+		}
+	}
+	// Validate runs before the methods inherit the service requirements.
+	reqs := c.Method.Requirements
+	if len(reqs) == 0 {
+		reqs = c.Method.Service.Requirements
+	}
+	for _, r := range reqs {
+		for _, s := range r.Schemes {
+			if s.Kind == NoKind {
+				return verr
+			}
+		}
+	}
+	for _, cred := range securityAttributes(c.Method.Payload, reqs) {
+		found := false
+		for _, k := range c.Keys {
+			if k == cred {
+				found = true
+				break
+			}
+		}
+		if !found {
+			verr.Add(c, "key of secured method must include the credential attribute %q", cred)
+		}
+	}
+	return verr
+}
@@ -0,0 +1,48 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestCache(t *testing.T) {
+	root := expr.RunDSL(t, testdata.CacheDSL)
+	c := root.Services[0].Methods[0].Cache
+	if c == nil {
+		t.Fatal("got nil cache, expected not nil")
+	}
+	if c.TTL != 5*time.Minute {
+		t.Errorf("got TTL %s, expected %s", c.TTL, 5*time.Minute)
+	}
+	if len(c.Keys) != 2 || c.Keys[0] != "id" || c.Keys[1] != "view" {
+		t.Errorf("got keys %v, expected [id view]", c.Keys)
+	}
+}
+
+func TestCacheInvalid(t *testing.T) {
+	err := expr.RunInvalidDSL(t, testdata.CacheInvalidDSL)
+	for _, e := range []string{
+		`key attribute "missing" is not a payload attribute`,
+		`key attribute "tags" must be a primitive`,
+		`cached methods must use the GET or HEAD HTTP method, got POST`,
+		`TTL must be greater than zero, got -1s`,
+		`streaming methods cannot be cached`,
+		`CacheKey requires an object payload`,
+	} {
+		if !strings.Contains(err.Error(), e) {
+			t.Errorf("got error %q, expected it to contain %q", err.Error(), e)
+		}
+	}
+	err = expr.RunInvalidDSL(t, testdata.CacheInvalidSecuredDSL)
+	if got, want := err.Error(), `cache of service "accounts" method "show": key of secured method must include the credential attribute "token"`; got != want {
+		t.Errorf("got error %q, expected %q", got, want)
+	}
+	err = expr.RunInvalidDSL(t, testdata.CacheInvalidTTLDSL)
+	if !strings.Contains(err.Error(), `invalid cache TTL "five minutes"`) {
+		t.Errorf("got error %q, expected invalid TTL", err.Error())
+	}
+}
@@ -0,0 +1,130 @@
+package expr
+
+import (
+	"fmt"
+	"regexp"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// DeploymentExpr describes the deployment of a server. The deployment
+	// hints are used to generate the Dockerfile and the Kubernetes
+	// manifests of the server.
+	DeploymentExpr struct {
+		// Server is the deployed server.
+		Server *ServerExpr
+		// Image is the name of the container image if any.
+		Image string
+		// Replicas is the number of instances of the server if set.
+		Replicas int
+		// Ports lists the container ports that override the ports of the
+		// server default host URIs.
+		Ports []*DeploymentPortExpr
+		// Env lists the environment variables set in the container.
+		Env []*EnvVarExpr
+		// CPURequest is the amount of CPU requested by the container if
+		// any, e.g. "100m".
+		CPURequest string
+		// CPULimit is the maximum amount of CPU used by the container if
+		// any, e.g. "1".
+		CPULimit string
+		// MemoryRequest is the amount of memory requested by the
+		// container if any, e.g. "64Mi".
+		MemoryRequest string
+		// MemoryLimit is the maximum amount of memory used by the
+		// container if any, e.g. "256Mi".
+		MemoryLimit string
+	}
+
+	// DeploymentPortExpr describes the container port of a transport.
+	DeploymentPortExpr struct {
+		// Transport is the transport served on the port, "http" or
+		// "grpc".
+		Transport string
+		// Port is the port number.
+		Port int
+	}
+
+	// EnvVarExpr describes an environment variable.
+	EnvVarExpr struct {
+		// Name is the variable name.
+		Name string
+		// Value is the variable value.
+		Value string
+	}
+)
+
+var (
+	// envVarNameRegex is the regular expression used to validate the
+	// environment variable names.
+	envVarNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+	// quantityRegex is the regular expression used to validate the CPU and
+	// memory quantities, see the Kubernetes resource model.
+	quantityRegex = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?(m|k|Ki|M|Mi|G|Gi|T|Ti)?$`)
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (d *DeploymentExpr) EvalName() string {
+	return fmt.Sprintf("deployment of %s", d.Server.EvalName())
+}
+
+// Validate makes sure the ports, environment variables and resource
+// quantities are valid.
+func (d *DeploymentExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if d.Replicas < 0 {
+		verr.Add(d, "replicas cannot be negative, got %d", d.Replicas)
+	}
+	transports := make(map[string]bool)
+	ports := make(map[int]bool)
+	for _, p := range d.Ports {
+		if p.Transport != "http" && p.Transport != "grpc" {
+			verr.Add(d, "invalid transport %q, transport must be one of 'http' or 'grpc'", p.Transport)
+		}
+		if p.Port < 1 || p.Port > 65535 {
+			verr.Add(d, "invalid port %d for transport %q", p.Port, p.Transport)
+		}
+		if transports[p.Transport] {
+			verr.Add(d, "port of transport %q is defined more than once", p.Transport)
+		}
+		if ports[p.Port] {
+			verr.Add(d, "port %d is used by more than one transport", p.Port)
+		}
+		transports[p.Transport] = true
+		ports[p.Port] = true
+	}
+	names := make(map[string]bool)
+	for _, e := range d.Env {
+		if !envVarNameRegex.MatchString(e.Name) {
+			verr.Add(d, "invalid environment variable name %q", e.Name)
+		}
+		if names[e.Name] {
+			verr.Add(d, "environment variable %q is defined more than once", e.Name)
+		}
+		names[e.Name] = true
+	}
+	for _, q := range []struct{ name, val string }{
+		{"CPU request", d.CPURequest},
+		{"CPU limit", d.CPULimit},
+		{"memory request", d.MemoryRequest},
+		{"memory limit", d.MemoryLimit},
+	} {
+		if q.val != "" && !quantityRegex.MatchString(q.val) {
+			verr.Add(d, "invalid %s %q", q.name, q.val)
+		}
+	}
+	return verr
+}
+
+// Port returns the container port of the given transport defined with
+// DeploymentPort, 0 if there is none.
+func (d *DeploymentExpr) Port(transport string) int {
+	for _, p := range d.Ports {
+		if p.Transport == transport {
+			return p.Port
+		}
+	}
+	return 0
+}
@@ -0,0 +1,51 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestDeployment(t *testing.T) {
+	root := expr.RunDSL(t, testdata.DeploymentDSL)
+	d := root.API.Servers[0].Deployment
+	if d == nil {
+		t.Fatal("got no deployment")
+	}
+	if d.Image != "acme/calc:1.0" || d.Replicas != 3 {
+		t.Errorf("got image %q and %d replicas, expected acme/calc:1.0 and 3", d.Image, d.Replicas)
+	}
+	if p := d.Port("http"); p != 8080 {
+		t.Errorf("got http port %d, expected 8080", p)
+	}
+	if p := d.Port("grpc"); p != 0 {
+		t.Errorf("got grpc port %d, expected 0", p)
+	}
+	if len(d.Env) != 1 || d.Env[0].Name != "LOG_LEVEL" || d.Env[0].Value != "info" {
+		t.Errorf("got env %v, expected LOG_LEVEL=info", d.Env)
+	}
+	if d.CPURequest != "100m" || d.CPULimit != "1" || d.MemoryRequest != "64Mi" || d.MemoryLimit != "256Mi" {
+		t.Errorf("got resources %+v, expected 100m/1 CPU and 64Mi/256Mi memory", d)
+	}
+}
+
+func TestDeploymentInvalid(t *testing.T) {
+	err := expr.RunInvalidDSL(t, testdata.DeploymentInvalidDSL)
+	for _, e := range []string{
+		"replicas cannot be negative, got -1",
+		`invalid transport "tcp"`,
+		`invalid port 0 for transport "http"`,
+		`port of transport "http" is defined more than once`,
+		"port 8080 is used by more than one transport",
+		`invalid environment variable name "LOG-LEVEL"`,
+		`environment variable "TZ" is defined more than once`,
+		`invalid CPU request "lots"`,
+		`invalid memory limit "256MB"`,
+	} {
+		if !strings.Contains(err.Error(), e) {
+			t.Errorf("got error %q, expected it to contain %q", err.Error(), e)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package expr
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// EventExpr describes an event emitted by a method. The events are
+	// published once the method completes successfully.
+	EventExpr struct {
+		// Method is the method that emits the event.
+		Method *MethodExpr
+		// Topic is the name of the topic the event is published to.
+		Topic string
+		// Description of the event.
+		Description string
+		// Attribute describes the event type.
+		Attribute *AttributeExpr
+	}
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (e *EventExpr) EvalName() string {
+	return fmt.Sprintf("event %q of %s", e.Topic, e.Method.EvalName())
+}
+
+// Validate makes sure the topic is unique within the method, that the event
+// type is an object user type and that the other methods that emit events on
+// the same topic use the same type.
+func (e *EventExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if e.Topic == "" {
+		verr.Add(e, "event topic cannot be empty")
+	}
+	ut, ok := e.Attribute.Type.(UserType)
+	if !ok || !IsObject(ut) {
+		verr.Add(e, "event type must be an object user type")
+		return verr
+	}
+	for _, o := range e.Method.Events {
+		if o != e && o.Topic == e.Topic {
+			verr.Add(e, "topic %q is emitted more than once", e.Topic)
+			break
+		}
+	}
+	for _, svc := range Root.Services {
+		for _, m := range svc.Methods {
+			for _, o := range m.Events {
+				if o.Topic != e.Topic {
+					continue
+				}
+				if out, ok := o.Attribute.Type.(UserType); ok && out.Name() != ut.Name() {
+					verr.Add(e, "topic %q is emitted with type %q by %s", e.Topic, out.Name(), m.EvalName())
+				}
+			}
+		}
+	}
+	return verr
+}
+
+// Finalize sets the JSON tags of the fields of the event types so that the
+// events are encoded using the attribute names.
+func (e *EventExpr) Finalize() {
+	tagJSON(e.Attribute, make(map[string]struct{}))
+}
+
+// Topics returns the distinct topics of the events emitted by the service
+// methods in order of definition.
+func (s *ServiceExpr) Topics() []*EventExpr {
+	var events []*EventExpr
+	seen := make(map[string]struct{})
+	for _, m := range s.Methods {
+		for _, e := range m.Events {
+			if _, ok := seen[e.Topic]; ok {
+				continue
+			}
+			seen[e.Topic] = struct{}{}
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// tagJSON sets the "struct:tag:json" meta of the attributes of the object
+// types reachable from att unless already set.
+func tagJSON(att *AttributeExpr, seen map[string]struct{}) {
+	switch dt := att.Type.(type) {
+	case UserType:
+		if _, ok := seen[dt.ID()]; ok {
+			return
+		}
+		seen[dt.ID()] = struct{}{}
+		tagJSON(dt.Attribute(), seen)
+	case *Object:
+		for _, nat := range *dt {
+			if _, ok := nat.Attribute.Meta["struct:tag:json"]; !ok {
+				tag := []string{nat.Name}
+				if !att.IsRequired(nat.Name) {
+					tag = append(tag, "omitempty")
+				}
+				if nat.Attribute.Meta == nil {
+					nat.Attribute.Meta = MetaExpr{}
+				}
+				nat.Attribute.Meta["struct:tag:json"] = tag
+			}
+			tagJSON(nat.Attribute, seen)
+		}
+	case *Array:
+		tagJSON(dt.ElemType, seen)
+	case *Map:
+		tagJSON(dt.KeyType, seen)
+		tagJSON(dt.ElemType, seen)
+	}
+}
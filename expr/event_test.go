@@ -0,0 +1,53 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestEvents(t *testing.T) {
+	root := expr.RunDSL(t, testdata.EventDSL)
+	svc := root.Services[0]
+	topics := svc.Topics()
+	if len(topics) != 2 {
+		t.Fatalf("got %d topics, expected 2", len(topics))
+	}
+	if topics[0].Topic != "order.created" || topics[0].Description != "Order was created" {
+		t.Errorf("got topic %q (%q), expected order.created", topics[0].Topic, topics[0].Description)
+	}
+	if topics[1].Topic != "order.updated" || topics[1].Method.Name != "create" {
+		t.Errorf("got topic %q of %q, expected order.updated of create", topics[1].Topic, topics[1].Method.Name)
+	}
+	cases := map[string][]string{
+		"id":       {"id"},
+		"items":    {"items", "omitempty"},
+		"sku":      {"sku"},
+		"quantity": {"quantity", "omitempty"},
+	}
+	for _, n := range []string{"OrderEvent", "Item"} {
+		obj := expr.AsObject(root.UserType(n))
+		for _, nat := range *obj {
+			tag := nat.Attribute.Meta["struct:tag:json"]
+			if strings.Join(tag, ",") != strings.Join(cases[nat.Name], ",") {
+				t.Errorf("%s.%s: got json tag %v, expected %v", n, nat.Name, tag, cases[nat.Name])
+			}
+		}
+	}
+}
+
+func TestEventsInvalid(t *testing.T) {
+	err := expr.RunInvalidDSL(t, testdata.EventInvalidDSL)
+	for _, e := range []string{
+		`event topic cannot be empty`,
+		`event type must be an object user type`,
+		`topic "order.updated" is emitted more than once`,
+		`topic "order.updated" is emitted with type "OtherEvent"`,
+	} {
+		if !strings.Contains(err.Error(), e) {
+			t.Errorf("got error %q, expected it to contain %q", err.Error(), e)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"regexp"
+	"sort"
 	"time"
 
 	regen "github.com/zach-klippenstein/goregen"
@@ -37,6 +38,11 @@ func (a *AttributeExpr) Example(r *Random) interface{} {
 	if hasEnumValidation(a) {
 		return byEnum(a, r)
 	}
+	// exclusive bounds and multiples restrict the candidate values to a
+	// discrete or open range so compute the example directly
+	if hasNumberValidation(a) {
+		return byNumber(a, r)
+	}
 	// loop until a satisfying example is generated
 	var (
 		hasFormat  = hasFormatValidation(a)
@@ -69,11 +75,43 @@ func (a *AttributeExpr) Example(r *Random) interface{} {
 		if example == nil {
 			example = a.Type.Example(r)
 		}
+		if !checkMaxBytes(a, example) {
+			continue
+		}
 		return example
 	}
 	return a.Type.Example(r)
 }
 
+// ExampleNames returns the sorted names of the examples defined in the design
+// for the attribute or any of its child attributes. The name of an example is
+// its summary, the examples defined without a summary are not named.
+func (a *AttributeExpr) ExampleNames() []string {
+	set := make(map[string]struct{})
+	collectExampleNames(a, set, make(map[string]struct{}))
+	names := make([]string, 0, len(set))
+	for n := range set {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NamedExample returns the value of the example with the given name defined
+// in the design for the attribute, nil if there is none. The value of an
+// object that does not define the example is built from the examples with
+// the same name defined by its child attributes, the required child attributes
+// that do not define the example use their default example. This makes it
+// possible to define named examples attribute by attribute, e.g.:
+//
+//    Attribute("name", String, func() {
+//        Example("minimal", "Lagavulin")
+//    })
+//
+func (a *AttributeExpr) NamedExample(name string, r *Random) interface{} {
+	return namedExample(a, name, r, make(map[string]struct{}))
+}
+
 // NewLength returns an int that validates the generator attribute length
 // validations if any.
 func NewLength(a *AttributeExpr, r *Random) int {
@@ -109,6 +147,77 @@ func NewLength(a *AttributeExpr, r *Random) int {
 	return r.Int()%3 + 2
 }
 
+// collectExampleNames adds the names of the examples defined by a and its
+// child attributes to names. seen records the user types already visited to
+// handle recursive types.
+func collectExampleNames(a *AttributeExpr, names, seen map[string]struct{}) {
+	for _, ex := range a.UserExamples {
+		if ex.Summary != "" && ex.Summary != "default" {
+			names[ex.Summary] = struct{}{}
+		}
+	}
+	switch t := a.Type.(type) {
+	case UserType:
+		if _, ok := seen[t.ID()]; ok {
+			return
+		}
+		seen[t.ID()] = struct{}{}
+		collectExampleNames(t.Attribute(), names, seen)
+	case *Array:
+		collectExampleNames(t.ElemType, names, seen)
+	case *Map:
+		collectExampleNames(t.ElemType, names, seen)
+	case *Object:
+		for _, nat := range *t {
+			collectExampleNames(nat.Attribute, names, seen)
+		}
+	}
+}
+
+// namedExample implements NamedExample. seen records the user types being
+// visited to handle recursive types.
+func namedExample(a *AttributeExpr, name string, r *Random, seen map[string]struct{}) interface{} {
+	for i := len(a.UserExamples) - 1; i >= 0; i-- {
+		if a.UserExamples[i].Summary == name {
+			return a.UserExamples[i].Value
+		}
+	}
+	switch t := a.Type.(type) {
+	case UserType:
+		if _, ok := seen[t.ID()]; ok {
+			return nil
+		}
+		seen[t.ID()] = struct{}{}
+		defer delete(seen, t.ID())
+		return namedExample(t.Attribute(), name, r, seen)
+	case *Array:
+		if v := namedExample(t.ElemType, name, r, seen); v != nil {
+			return []interface{}{v}
+		}
+	case *Object:
+		var (
+			found bool
+			val   = make(map[string]interface{})
+		)
+		for _, nat := range *t {
+			if v := namedExample(nat.Attribute, name, r, seen); v != nil {
+				val[nat.Name] = v
+				found = true
+				continue
+			}
+			if a.IsRequired(nat.Name) {
+				if v := nat.Attribute.Example(r); v != nil {
+					val[nat.Name] = v
+				}
+			}
+		}
+		if found {
+			return val
+		}
+	}
+	return nil
+}
+
 func hasLengthValidation(a *AttributeExpr) bool {
 	if a.Validation == nil {
 		return false
@@ -135,6 +244,14 @@ func hasMinMaxValidation(a *AttributeExpr) bool {
 	return a.Validation.Minimum != nil || a.Validation.Maximum != nil
 }
 
+func hasNumberValidation(a *AttributeExpr) bool {
+	if a.Validation == nil {
+		return false
+	}
+	v := a.Validation
+	return v.ExclusiveMinimum != nil || v.ExclusiveMaximum != nil || v.MultipleOf != nil
+}
+
 // byLength generates a random size array of examples based on what's given.
 func byLength(a *AttributeExpr, r *Random) interface{} {
 	count := NewLength(a, r)
@@ -308,6 +425,97 @@ func byMinMax(a *AttributeExpr, r *Random) interface{} {
 	}
 }
 
+// byNumber generates a number that validates the minimum, maximum, exclusive
+// minimum, exclusive maximum and multiple of validations of the attribute.
+func byNumber(a *AttributeExpr, r *Random) interface{} {
+	var (
+		v      = a.Validation
+		kind   = a.Type.Kind()
+		isInt  = kind != Float32Kind && kind != Float64Kind
+		min    = math.Inf(-1)
+		max    = math.Inf(1)
+		exMin  bool
+		exMax  bool
+		step   float64
+		result float64
+	)
+	if v.Minimum != nil {
+		min = *v.Minimum
+	}
+	if v.ExclusiveMinimum != nil && *v.ExclusiveMinimum >= min {
+		min, exMin = *v.ExclusiveMinimum, true
+	}
+	if v.Maximum != nil {
+		max = *v.Maximum
+	}
+	if v.ExclusiveMaximum != nil && *v.ExclusiveMaximum <= max {
+		max, exMax = *v.ExclusiveMaximum, true
+	}
+	if (kind == UIntKind || kind == UInt32Kind || kind == UInt64Kind) && min < 0 {
+		min, exMin = 0, false
+	}
+	switch {
+	case v.MultipleOf != nil:
+		step = *v.MultipleOf
+	case isInt:
+		step = 1
+	}
+	if step == 0 {
+		switch {
+		case !math.IsInf(min, -1) && !math.IsInf(max, 1):
+			// stay away from the bounds so that exclusive bounds hold
+			result = min + (max-min)*(0.1+0.8*r.Float64())
+		case !math.IsInf(min, -1):
+			result = min + 1 + r.Float64()
+		case !math.IsInf(max, 1):
+			result = max - 1 - r.Float64()
+		default:
+			result = r.Float64()
+		}
+	} else {
+		lo, hi := math.Ceil(min/step), math.Floor(max/step)
+		if exMin && lo*step <= min {
+			lo++
+		}
+		if exMax && hi*step >= max {
+			hi--
+		}
+		var k float64
+		switch {
+		case !math.IsInf(lo, -1) && !math.IsInf(hi, 1):
+			k = lo
+			if hi > lo {
+				k += float64(r.Int() % (int(math.Min(hi-lo, maxAttempts)) + 1))
+			}
+		case !math.IsInf(lo, -1):
+			k = lo + float64(r.Int()%10)
+		case !math.IsInf(hi, 1):
+			k = hi - float64(r.Int()%10)
+		default:
+			k = float64(r.Int() % 10)
+		}
+		result = k * step
+	}
+	switch kind {
+	case IntKind:
+		return int(result)
+	case Int32Kind:
+		return int32(result)
+	case Int64Kind:
+		return int64(result)
+	case UIntKind:
+		return uint(result)
+	case UInt32Kind:
+		return uint32(result)
+	case UInt64Kind:
+		return uint64(result)
+	case Float32Kind:
+		return float32(result)
+	default:
+		return result
+	}
+}
+
 func checkPattern(a *AttributeExpr, example interface{}) bool {
 	if !hasPatternValidation(a) {
 		return true
@@ -323,6 +531,14 @@ func checkPattern(a *AttributeExpr, example interface{}) bool {
 	return true
 }
 
+func checkMaxBytes(a *AttributeExpr, example interface{}) bool {
+	if a.Validation == nil || a.Validation.MaxBytes == nil {
+		return true
+	}
+	s, ok := example.(string)
+	return !ok || len(s) <= *a.Validation.MaxBytes
+}
+
 func checkMinMaxValue(a *AttributeExpr, example interface{}) bool {
 	if !hasMinMaxValidation(a) {
 		return true
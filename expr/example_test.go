@@ -1,6 +1,7 @@
 package expr_test
 
 import (
+	"math"
 	"reflect"
 	"regexp"
 	"strings"
@@ -49,6 +50,56 @@ func TestByFormatUUID(t *testing.T) {
 	}
 }
 
+func TestByNumber(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	cases := []struct {
+		Name       string
+		Type       expr.DataType
+		Validation *expr.ValidationExpr
+	}{
+		{"exclusive-minimum", expr.Int, &expr.ValidationExpr{ExclusiveMinimum: f(0)}},
+		{"exclusive-maximum", expr.Float64, &expr.ValidationExpr{ExclusiveMaximum: f(1)}},
+		{"exclusive-range", expr.Float32, &expr.ValidationExpr{ExclusiveMinimum: f(0), ExclusiveMaximum: f(1)}},
+		{"multiple-of", expr.Int64, &expr.ValidationExpr{Minimum: f(7), MultipleOf: f(5)}},
+		{"float-multiple-of", expr.Float64, &expr.ValidationExpr{ExclusiveMinimum: f(0), Maximum: f(1), MultipleOf: f(0.25)}},
+	}
+	r := expr.NewRandom("test")
+	for _, k := range cases {
+		t.Run(k.Name, func(t *testing.T) {
+			att := expr.AttributeExpr{Type: k.Type, Validation: k.Validation}
+			var v float64
+			switch ex := att.Example(r).(type) {
+			case int:
+				v = float64(ex)
+			case int64:
+				v = float64(ex)
+			case float32:
+				v = float64(ex)
+			case float64:
+				v = ex
+			default:
+				t.Fatalf("got example of type %T", ex)
+			}
+			val := k.Validation
+			if val.Minimum != nil && v < *val.Minimum {
+				t.Errorf("got %v, expected at least %v", v, *val.Minimum)
+			}
+			if val.Maximum != nil && v > *val.Maximum {
+				t.Errorf("got %v, expected at most %v", v, *val.Maximum)
+			}
+			if val.ExclusiveMinimum != nil && v <= *val.ExclusiveMinimum {
+				t.Errorf("got %v, expected greater than %v", v, *val.ExclusiveMinimum)
+			}
+			if val.ExclusiveMaximum != nil && v >= *val.ExclusiveMaximum {
+				t.Errorf("got %v, expected lesser than %v", v, *val.ExclusiveMaximum)
+			}
+			if val.MultipleOf != nil && math.Mod(v, *val.MultipleOf) != 0 {
+				t.Errorf("got %v, expected a multiple of %v", v, *val.MultipleOf)
+			}
+		})
+	}
+}
+
 func TestExample(t *testing.T) {
 	cases := []struct {
 		Name     string
@@ -88,3 +139,28 @@ func TestExample(t *testing.T) {
 		})
 	}
 }
+
+func TestNamedExample(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Expected interface{}
+	}{
+		{"minimal", map[string]interface{}{"bottle": map[string]interface{}{"name": "Lagavulin", "rating": 5}}},
+		{"full", map[string]interface{}{"bottle": map[string]interface{}{"name": "Lagavulin 16", "vintage": 1990, "rating": 5}}},
+		{"tagged", map[string]interface{}{"tags": []string{"peaty"}}},
+		{"unknown", nil},
+	}
+	expr.RunDSL(t, testdata.NamedExamplesDSL)
+	payload := expr.Root.Services[0].Methods[0].Payload
+	if names := payload.ExampleNames(); !reflect.DeepEqual(names, []string{"full", "minimal", "tagged"}) {
+		t.Errorf("invalid example names: got %v, expected [full minimal tagged]", names)
+	}
+	for _, k := range cases {
+		t.Run(k.Name, func(t *testing.T) {
+			example := payload.NamedExample(k.Name, expr.NewRandom("test"))
+			if !reflect.DeepEqual(example, k.Expected) {
+				t.Errorf("invalid example: got %#v, expected %#v", example, k.Expected)
+			}
+		})
+	}
+}
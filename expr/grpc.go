@@ -29,7 +29,13 @@ func (g *GRPCExpr) ServiceFor(s *ServiceExpr) *GRPCServiceExpr {
 	res := &GRPCServiceExpr{
 		ServiceExpr: s,
 	}
-	g.Services = append(g.Services, res)
+	i := len(g.Services)
+	for i > 0 && serviceIndex(g.Services[i-1].ServiceExpr) > serviceIndex(s) {
+		i--
+	}
+	g.Services = append(g.Services, nil)
+	copy(g.Services[i+1:], g.Services[i:])
+	g.Services[i] = res
 	return res
 }
 
@@ -142,6 +142,14 @@ func (e *GRPCEndpointExpr) Validate() error {
 		verr.Merge(validateMetadata(e.Metadata, e.MethodExpr.Payload, e, true))
 	}
 
+	for _, req := range e.MethodExpr.Requirements {
+		for _, sch := range req.Schemes {
+			if sch.Kind == SignatureKind {
+				verr.Add(e, "security scheme %q: request signatures are only supported by HTTP endpoints", sch.SchemeName)
+			}
+		}
+	}
+
 	if pobj := AsObject(e.MethodExpr.Payload.Type); pobj != nil {
 		secAttrs := getSecurityAttributes(e.MethodExpr)
 		switch {
@@ -240,6 +248,14 @@ func (e *GRPCEndpointExpr) Finalize() {
 							addToMetadata(field, "")
 						}
 						continue
+					case MTLSKind:
+						// The client certificate is retrieved from the
+						// peer information.
+						continue
+					case SignatureKind:
+						// Request signatures are not supported by
+						// gRPC endpoints, see Validate.
+						continue
 					case APIKeyKind:
 						field = TaggedAttribute(e.MethodExpr.Payload, "security:apikey:"+sch.SchemeName)
 					case JWTKind:
@@ -278,6 +294,10 @@ func (e *GRPCEndpointExpr) Finalize() {
 			// remove metadata attributes from the message attributes
 			msgObj.Delete(nat.Name)
 		}
+		// the client certificate is not part of the request message
+		if field := TaggedAttribute(e.MethodExpr.Payload, "security:clientcert"); field != "" {
+			msgObj.Delete(field)
+		}
 
 		// add any message attributes to request message if not added already
 		if len(*msgObj) > 0 {
@@ -466,27 +486,37 @@ func validateMetadata(metAtt *MappedAttributeExpr, serviceAtt *AttributeExpr, e
 // getSecurityAttributes returns the attributes that describes a security
 // scheme from a method expression.
 func getSecurityAttributes(m *MethodExpr) []string {
+	return securityAttributes(m.Payload, m.Requirements)
+}
+
+// securityAttributes returns the names of the payload attributes that hold the
+// credentials of the given security requirements.
+func securityAttributes(payload *AttributeExpr, reqs []*SecurityExpr) []string {
 	secAttrs := []string{}
-	for _, req := range m.Requirements {
+	for _, req := range reqs {
 		for _, sch := range req.Schemes {
 			switch sch.Kind {
 			case BasicAuthKind:
-				if field := TaggedAttribute(m.Payload, "security:username"); field != "" {
+				if field := TaggedAttribute(payload, "security:username"); field != "" {
 					secAttrs = append(secAttrs, field)
 				}
-				if field := TaggedAttribute(m.Payload, "security:password"); field != "" {
+				if field := TaggedAttribute(payload, "security:password"); field != "" {
 					secAttrs = append(secAttrs, field)
 				}
 			case APIKeyKind:
-				if field := TaggedAttribute(m.Payload, "security:apikey:"+sch.SchemeName); field != "" {
+				if field := TaggedAttribute(payload, "security:apikey:"+sch.SchemeName); field != "" {
 					secAttrs = append(secAttrs, field)
 				}
 			case JWTKind:
-				if field := TaggedAttribute(m.Payload, "security:token"); field != "" {
+				if field := TaggedAttribute(payload, "security:token"); field != "" {
 					secAttrs = append(secAttrs, field)
 				}
 			case OAuth2Kind:
-				if field := TaggedAttribute(m.Payload, "security:accesstoken"); field != "" {
+				if field := TaggedAttribute(payload, "security:accesstoken"); field != "" {
+					secAttrs = append(secAttrs, field)
+				}
+			case MTLSKind:
+				if field := TaggedAttribute(payload, "security:clientcert"); field != "" {
 					secAttrs = append(secAttrs, field)
 				}
 			}
@@ -23,6 +23,10 @@ service "Service" gRPC endpoint "Method": Error "invalid_error_type" type is Any
 service "Service" gRPC endpoint "Method": Map element type is Any type which is not supported in gRPC`,
 			},
 		},
+		"endpoint-with-signature-security": {
+			DSL:    testdata.GRPCEndpointWithSignatureSecurity,
+			Errors: []string{`service "Service" gRPC endpoint "Method": security scheme "signed": request signatures are only supported by HTTP endpoints`},
+		},
 	}
 	for name, c := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -0,0 +1,47 @@
+package expr
+
+import (
+	"strings"
+
+	"goa.design/goa/v3/eval"
+)
+
+const (
+	// DefaultLivenessPath is the default path of the liveness endpoint.
+	DefaultLivenessPath = "/healthz"
+	// DefaultReadinessPath is the default path of the readiness endpoint.
+	DefaultReadinessPath = "/readyz"
+)
+
+// HealthCheckExpr describes the liveness and readiness endpoints served by the
+// API servers. The HTTP servers serve the endpoints on the liveness and
+// readiness paths, the gRPC servers implement the standard gRPC health
+// service.
+type HealthCheckExpr struct {
+	// LivenessPath is the path of the HTTP liveness endpoint.
+	LivenessPath string
+	// ReadinessPath is the path of the HTTP readiness endpoint.
+	ReadinessPath string
+	// Meta is a list of key/value pairs, "swagger:generate" set to
+	// "false" excludes the endpoints from the OpenAPI specifications.
+	Meta MetaExpr
+}
+
+// EvalName returns the generic definition name used in error messages.
+func (h *HealthCheckExpr) EvalName() string {
+	return "health check"
+}
+
+// Validate makes sure the paths are absolute and distinct.
+func (h *HealthCheckExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	for _, p := range []string{h.LivenessPath, h.ReadinessPath} {
+		if !strings.HasPrefix(p, "/") {
+			verr.Add(h, "path %q must start with /", p)
+		}
+	}
+	if h.LivenessPath == h.ReadinessPath {
+		verr.Add(h, "liveness and readiness paths must be different, got %q", h.LivenessPath)
+	}
+	return verr
+}
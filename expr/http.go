@@ -2,6 +2,8 @@ package expr
 
 import (
 	"regexp"
+
+	"goa.design/goa/v3/eval"
 )
 
 type (
@@ -26,20 +28,72 @@ type (
 		Services []*HTTPServiceExpr
 		// Errors lists the error HTTP responses.
 		Errors []*HTTPErrorExpr
+		// Origins lists the CORS policies that apply to all the API
+		// endpoints.
+		Origins []*CORSExpr
+		// CSRF describes the CSRF protection of the API endpoints that
+		// authenticate requests with cookies.
+		CSRF *CSRFExpr
+		// MuxerAdapters lists the third party routers for which the
+		// code generator generates Muxer adapter packages.
+		MuxerAdapters []string
+		// Compression describes the compression of the responses of
+		// all the API endpoints if any.
+		Compression *HTTPCompressExpr
+		// Benchmarks indicates whether the code generator generates
+		// benchmarks for the HTTP endpoints.
+		Benchmarks bool
+		// ProblemDetails indicates whether the generated servers encode
+		// the errors as problem details documents (RFC 9457).
+		ProblemDetails bool
+		// ProblemTypeBase is the base URI of the problem type URIs, the
+		// type URI of a problem is the base URI followed by the error
+		// name. The problem type is "about:blank" if empty.
+		ProblemTypeBase string
+		// StrictMediaTypes indicates whether the generated servers
+		// reject the requests whose Content-Type or Accept headers do not
+		// match the media types of the API endpoints.
+		StrictMediaTypes bool
+		// StrictDecoding indicates whether the generated servers reject
+		// the requests whose body or query string contain fields that
+		// are not defined in the design.
+		StrictDecoding bool
+		// LenientDecoding indicates whether the generated servers coerce
+		// the request values to the types defined in the design when
+		// possible.
+		LenientDecoding bool
+		// AutoHeadOptions indicates whether the generated servers serve
+		// HEAD requests for the GET routes and OPTIONS requests for all
+		// the routes of the API endpoints.
+		AutoHeadOptions bool
 	}
 )
 
+// HTTPRouters lists the third party routers supported by MuxerAdapters.
+var HTTPRouters = []string{"chi", "echo", "gin", "gorilla"}
+
 // HTTPWildcardRegex is the regular expression used to capture path
 // parameters.
-var HTTPWildcardRegex = regexp.MustCompile(`/{\*?([a-zA-Z0-9_]+)}`)
+var HTTPWildcardRegex = regexp.MustCompile(`/{\*?([a-zA-Z0-9_]+)\*?}`)
+
+// httpAnyWildcardRegex is the regular expression used to capture both simple
+// and styled path parameters.
+var httpAnyWildcardRegex = regexp.MustCompile(`/{\*?([a-zA-Z0-9_]+)\*?}|{[.;]([a-zA-Z0-9_]+)}`)
+
+// httpRouteWildcardRegex is the regular expression used to normalize the
+// wildcards of the routes when detecting conflicts.
+var httpRouteWildcardRegex = regexp.MustCompile(`{([*.;]?)[a-zA-Z0-9_]+(\*?)}`)
 
 // ExtractHTTPWildcards returns the names of the wildcards that appear in
-// a HTTP path.
+// a HTTP path including the label and matrix style wildcards.
 func ExtractHTTPWildcards(path string) []string {
-	matches := HTTPWildcardRegex.FindAllStringSubmatch(path, -1)
+	matches := httpAnyWildcardRegex.FindAllStringSubmatch(path, -1)
 	wcs := make([]string, len(matches))
 	for i, m := range matches {
 		wcs[i] = m[1]
+		if wcs[i] == "" {
+			wcs[i] = m[2]
+		}
 	}
 	return wcs
 }
@@ -63,7 +117,13 @@ func (h *HTTPExpr) ServiceFor(s *ServiceExpr) *HTTPServiceExpr {
 	res := &HTTPServiceExpr{
 		ServiceExpr: s,
 	}
-	h.Services = append(h.Services, res)
+	i := len(h.Services)
+	for i > 0 && serviceIndex(h.Services[i-1].ServiceExpr) > serviceIndex(s) {
+		i--
+	}
+	h.Services = append(h.Services, nil)
+	copy(h.Services[i+1:], h.Services[i:])
+	h.Services[i] = res
 	return res
 }
 
@@ -72,6 +132,93 @@ func (h *HTTPExpr) EvalName() string {
 	return "API HTTP"
 }
 
+// Validate makes sure the CORS policies, the CSRF protection and the muxer
+// adapters are valid.
+func (h *HTTPExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	for _, o := range h.Origins {
+		verr.Merge(o.Validate())
+	}
+	if h.CSRF != nil {
+		verr.Merge(h.CSRF.Validate())
+	}
+	if h.Compression != nil {
+		verr.Merge(h.Compression.Validate())
+	}
+	if h.ProblemDetails {
+		verr.Merge(h.validateProblemDetails())
+	}
+	seen := make(map[string]bool)
+	for _, r := range h.MuxerAdapters {
+		if seen[r] {
+			verr.Add(h, "muxer adapter %q is defined more than once", r)
+		}
+		seen[r] = true
+		valid := false
+		for _, s := range HTTPRouters {
+			if r == s {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			verr.Add(h, "unsupported muxer adapter %q, must be one of %q", r, HTTPRouters)
+		}
+	}
+	if len(h.MuxerAdapters) > 0 {
+		for _, svc := range h.Services {
+			for _, e := range svc.HTTPEndpoints {
+				for _, r := range e.Routes {
+					if _, styled := SplitStyledPath(r.Path); styled != "" {
+						verr.Add(e, "route %q uses label or matrix style wildcards which are not supported by muxer adapters", r.Path)
+					}
+				}
+			}
+		}
+	}
+	verr.Merge(h.validateRouteConflicts())
+	return verr
+}
+
+// validateRouteConflicts makes sure that no two routes of the same service
+// have the same HTTP method and path modulo the names of their wildcards as
+// the requests made to these routes could not be dispatched unambiguously.
+func (h *HTTPExpr) validateRouteConflicts() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	for _, svc := range h.Services {
+		var (
+			seen  = make(map[string]*RouteExpr)
+			paths = make(map[string]string)
+		)
+		for _, e := range svc.HTTPEndpoints {
+			for _, r := range e.Routes {
+				for _, p := range r.FullPaths() {
+					key := r.Method + " " + NormalizeHTTPPath(p)
+					if prev, ok := seen[key]; ok {
+						verr.Add(e, "route %s %q conflicts with route %s %q of %s", r.Method, p, prev.Method, paths[key], prev.Endpoint.EvalName())
+						continue
+					}
+					seen[key] = r
+					paths[key] = p
+				}
+			}
+		}
+	}
+	return verr
+}
+
+// NormalizeHTTPPath returns path with the wildcard names removed so that paths
+// that only differ by the names of their wildcards are equal.
+func NormalizeHTTPPath(path string) string {
+	return httpRouteWildcardRegex.ReplaceAllStringFunc(path, func(w string) string {
+		m := httpRouteWildcardRegex.FindStringSubmatch(w)
+		if m[2] == "*" {
+			return "{*}"
+		}
+		return "{" + m[1] + "}"
+	})
+}
+
 // Finalize initializes Consumes and Produces with defaults if not set.
 func (h *HTTPExpr) Finalize() {
 	if len(h.Consumes) == 0 {
@@ -0,0 +1,35 @@
+package expr
+
+import "goa.design/goa/v3/eval"
+
+// Benchmarkable returns true if the code generator can generate benchmarks for
+// the endpoint. Benchmarks are not generated for streaming, multipart, form
+// encoded and proxied endpoints nor for endpoints whose routes use label or
+// matrix style wildcards.
+func (e *HTTPEndpointExpr) Benchmarkable() bool {
+	if e.MethodExpr.IsStreaming() || e.MultipartRequest || e.FormEncodedRequest || e.Proxy != nil {
+		return false
+	}
+	for _, r := range e.Routes {
+		if _, styled := SplitStyledPath(r.Path); styled != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// validateMaxAllocs makes sure that the endpoint allocation budget is only set
+// when benchmarks are enabled and can be generated for the endpoint.
+func (e *HTTPEndpointExpr) validateMaxAllocs() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if e.MaxAllocs == 0 {
+		return verr
+	}
+	if !Root.API.HTTP.Benchmarks {
+		verr.Add(e, "MaxAllocs requires Benchmarks to be enabled in the API HTTP expression")
+	}
+	if !e.Benchmarkable() {
+		verr.Add(e, "MaxAllocs cannot be used on streaming, multipart, form encoded or proxied endpoints nor on endpoints using label or matrix style wildcards")
+	}
+	return verr
+}
@@ -0,0 +1,43 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestMaxAllocs(t *testing.T) {
+	root := expr.RunDSL(t, testdata.MaxAllocsDSL)
+	if !root.API.HTTP.Benchmarks {
+		t.Error("got benchmarks disabled, expected enabled")
+	}
+	e := root.API.HTTP.Services[0].Endpoint("Method")
+	if e.MaxAllocs != 100 {
+		t.Errorf("got max allocs %d, expected 100", e.MaxAllocs)
+	}
+	if !e.Benchmarkable() {
+		t.Error("got endpoint not benchmarkable, expected benchmarkable")
+	}
+}
+
+func TestMaxAllocsInvalid(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"no-benchmarks", testdata.MaxAllocsNoBenchmarksDSL, "MaxAllocs requires Benchmarks to be enabled in the API HTTP expression"},
+		{"streaming", testdata.MaxAllocsStreamingDSL, "MaxAllocs cannot be used on streaming, multipart, form encoded or proxied endpoints"},
+		{"zero", testdata.MaxAllocsInvalidDSL, "invalid maximum allocations: 0 must be greater than zero"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := expr.RunInvalidDSL(t, c.DSL)
+			if !strings.Contains(err.Error(), c.Error) {
+				t.Errorf("got error %q, expected it to contain %q", err.Error(), c.Error)
+			}
+		})
+	}
+}
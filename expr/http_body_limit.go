@@ -0,0 +1,50 @@
+package expr
+
+// BodyLimitErrorName is the name of the error returned by the generated code
+// when the size of a request body exceeds the limit set in the design.
+const BodyLimitErrorName = "request_too_large"
+
+// MaxBodySize returns the maximum size in bytes of the endpoint request bodies,
+// zero if there is no limit. The limit is the one defined on the endpoint if
+// any, the one defined on the service otherwise. If neither the endpoint nor
+// the service define a limit and the body is a string or bytes with a maximum
+// length validation then MaxBodySize computes a conservative limit from the
+// maximum length and the worst case JSON encoding.
+func (e *HTTPEndpointExpr) MaxBodySize() int64 {
+	if e.MethodExpr.Payload == nil || e.MethodExpr.Payload.Type == Empty || e.MethodExpr.IsPayloadStreaming() {
+		return 0
+	}
+	if e.BodyLimit > 0 {
+		return e.BodyLimit
+	}
+	if e.Service.BodyLimit > 0 {
+		return e.Service.BodyLimit
+	}
+	body := e.Body
+	if body == nil {
+		body = e.MethodExpr.Payload
+	}
+	if body.Validation == nil || body.Validation.MaxLength == nil {
+		return 0
+	}
+	n := int64(*body.Validation.MaxLength)
+	switch body.Type {
+	case Bytes:
+		// base64 encoding plus quotes
+		return 4*((n+2)/3) + 2
+	case String:
+		// each character may be escaped as \uXXXX, plus quotes
+		return 6*n + 2
+	}
+	return 0
+}
+
+// prepareBodyLimit declares the error and the HTTP 413 response returned when
+// the request body exceeds the endpoint body limit unless the design already
+// defines them.
+func (e *HTTPEndpointExpr) prepareBodyLimit() {
+	if e.MaxBodySize() == 0 {
+		return
+	}
+	e.declareError(BodyLimitErrorName, "Request body exceeds the maximum size.", StatusRequestEntityTooLarge)
+}
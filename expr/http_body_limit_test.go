@@ -0,0 +1,58 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestMaxBodySize(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Expected []int64
+	}{
+		{"service", testdata.ServiceBodyLimitDSL, []int64{2 << 20, 1024}},
+		{"max-length", testdata.MaxLengthBodyLimitDSL, []int64{42}},
+		{"none", testdata.NoBodyLimitDSL, []int64{0}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := expr.RunDSL(t, c.DSL)
+			svc := root.API.HTTP.Services[0]
+			for i, e := range svc.HTTPEndpoints {
+				if got := e.MaxBodySize(); got != c.Expected[i] {
+					t.Errorf("%s: got limit %d, expected %d", e.Name(), got, c.Expected[i])
+				}
+				var httpErr *expr.HTTPErrorExpr
+				for _, he := range e.HTTPErrors {
+					if he.Name == expr.BodyLimitErrorName {
+						httpErr = he
+					}
+				}
+				declared := e.MethodExpr.Error(expr.BodyLimitErrorName) != nil
+				if c.Expected[i] == 0 {
+					if declared || httpErr != nil {
+						t.Errorf("%s: unexpected %q error", e.Name(), expr.BodyLimitErrorName)
+					}
+					continue
+				}
+				if !declared {
+					t.Errorf("%s: %q error not declared", e.Name(), expr.BodyLimitErrorName)
+				}
+				if httpErr == nil || httpErr.Response.StatusCode != expr.StatusRequestEntityTooLarge {
+					t.Errorf("%s: missing 413 response for %q error", e.Name(), expr.BodyLimitErrorName)
+				}
+			}
+		})
+	}
+}
+
+func TestBodyLimitInvalid(t *testing.T) {
+	err := expr.RunInvalidDSL(t, testdata.InvalidBodyLimitDSL)
+	if !strings.Contains(err.Error(), `"2XB" is not a valid size`) {
+		t.Errorf("unexpected error %q", err.Error())
+	}
+}
@@ -26,8 +26,10 @@ func httpRequestBody(a *HTTPEndpointExpr) *AttributeExpr {
 		payload   = a.MethodExpr.Payload
 		headers   = a.Headers
 		params    = a.Params
+		cookies   = a.Cookies
 		userField string
 		passField string
+		certField string
 	)
 	{
 		obj := AsObject(payload.Type)
@@ -39,14 +41,14 @@ func httpRequestBody(a *HTTPEndpointExpr) *AttributeExpr {
 				if _, ok := at.Attribute.Meta["security:password"]; ok {
 					passField = at.Name
 				}
-				if userField != "" && passField != "" {
-					break
+				if _, ok := at.Attribute.Meta["security:clientcert"]; ok {
+					certField = at.Name
 				}
 			}
 		}
 	}
 
-	bodyOnly := headers.IsEmpty() && params.IsEmpty() && a.MapQueryParams == nil
+	bodyOnly := headers.IsEmpty() && params.IsEmpty() && cookies.IsEmpty() && a.MapQueryParams == nil
 
 	// 1. If Payload is not an object then check whether there are params or
 	// headers defined and if so return empty type (payload encoded in
@@ -61,10 +63,11 @@ func httpRequestBody(a *HTTPEndpointExpr) *AttributeExpr {
 		return &AttributeExpr{Type: Empty}
 	}
 
-	// 2. Remove header and param attributes
+	// 2. Remove header, param and cookie attributes
 	body := NewMappedAttributeExpr(payload)
 	removeAttributes(body, headers)
 	removeAttributes(body, params)
+	removeAttributes(body, cookies)
 	if a.MapQueryParams != nil && *a.MapQueryParams != "" {
 		removeAttribute(body, *a.MapQueryParams)
 	}
@@ -74,6 +77,9 @@ func httpRequestBody(a *HTTPEndpointExpr) *AttributeExpr {
 	if passField != "" {
 		removeAttribute(body, passField)
 	}
+	if certField != "" {
+		removeAttribute(body, certField)
+	}
 
 	// 3. Return empty type if no attribute left
 	if len(*AsObject(body.Type)) == 0 {
@@ -154,11 +160,11 @@ func buildHTTPResponseBody(name string, attr *AttributeExpr, resp *HTTPResponseE
 	}
 
 	// 1. If attribute is not an object then check whether there are headers
-	// defined and if so return empty type (attr encoded in response
-	// headers) otherwise return renamed attr type (attr encoded in
-	// response body).
+	// or trailers defined and if so return empty type (attr encoded in
+	// response headers or trailers) otherwise return renamed attr type
+	// (attr encoded in response body).
 	if !IsObject(attr.Type) {
-		if resp.Headers.IsEmpty() {
+		if resp.Headers.IsEmpty() && resp.Trailers.IsEmpty() && resp.Cookies.IsEmpty() {
 			attr = DupAtt(attr)
 			renameType(attr, name, "Response") // Do not use ResponseBody as it could clash with name of element
 			return attr
@@ -166,9 +172,11 @@ func buildHTTPResponseBody(name string, attr *AttributeExpr, resp *HTTPResponseE
 		return &AttributeExpr{Type: Empty}
 	}
 
-	// 2. Remove header attributes
+	// 2. Remove header, trailer and cookie attributes
 	body := NewMappedAttributeExpr(attr)
 	removeAttributes(body, resp.Headers)
+	removeAttributes(body, resp.Trailers)
+	removeAttributes(body, resp.Cookies)
 
 	// 3. Return empty type if no attribute left
 	if len(*AsObject(body.Type)) == 0 {
@@ -193,6 +201,8 @@ func buildHTTPResponseBody(name string, attr *AttributeExpr, resp *HTTPResponseE
 	for i, v := range rt.Views {
 		mv := NewMappedAttributeExpr(v.AttributeExpr)
 		removeAttributes(mv, resp.Headers)
+		removeAttributes(mv, resp.Trailers)
+		removeAttributes(mv, resp.Cookies)
 		nv := &ViewExpr{
 			AttributeExpr: mv.Attribute(),
 			Name:          v.Name,
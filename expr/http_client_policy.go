@@ -0,0 +1,123 @@
+package expr
+
+import (
+	"time"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// RetryExpr describes how the generated clients retry failed requests.
+	RetryExpr struct {
+		// MaxAttempts is the maximum number of attempts including the
+		// initial request.
+		MaxAttempts int
+		// InitialBackoff is the time waited before the first retry.
+		InitialBackoff time.Duration
+		// MaxBackoff caps the time waited between two attempts.
+		MaxBackoff time.Duration
+		// StatusCodes lists the response status codes that cause a
+		// retry, the client default applies if empty.
+		StatusCodes []int
+		// Parent is the HTTP service or endpoint expression that
+		// defines the policy.
+		Parent eval.Expression
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (r *RetryExpr) EvalName() string {
+	suffix := "client retry policy"
+	if r.Parent != nil {
+		return r.Parent.EvalName() + " " + suffix
+	}
+	return suffix
+}
+
+// Validate makes sure the number of attempts, the backoff durations and the
+// status codes are valid.
+func (r *RetryExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if r.MaxAttempts < 2 {
+		verr.Add(r, "maximum number of attempts must be at least 2, got %d", r.MaxAttempts)
+	}
+	if r.InitialBackoff < 0 || r.MaxBackoff < 0 {
+		verr.Add(r, "backoff durations cannot be negative")
+	}
+	if r.MaxBackoff > 0 && r.InitialBackoff > r.MaxBackoff {
+		verr.Add(r, "initial backoff %s cannot be greater than maximum backoff %s", r.InitialBackoff, r.MaxBackoff)
+	}
+	for _, c := range r.StatusCodes {
+		if c < 100 || c > 599 {
+			verr.Add(r, "invalid HTTP status code %d", c)
+		}
+	}
+	return verr
+}
+
+// IsIdempotent returns true if all the endpoint routes use idempotent HTTP
+// methods so that requests may safely be retried.
+func (e *HTTPEndpointExpr) IsIdempotent() bool {
+	for _, r := range e.Routes {
+		switch r.Method {
+		case "GET", "HEAD", "PUT", "DELETE", "OPTIONS", "TRACE":
+		default:
+			return false
+		}
+	}
+	return len(e.Routes) > 0
+}
+
+// RequestTimeout returns the timeout applied by the generated clients to the
+// requests made to the endpoint, zero if there is none. The timeout is the one
+// defined on the endpoint if any, the one defined on the service otherwise.
+// Streaming endpoints have no timeout.
+func (e *HTTPEndpointExpr) RequestTimeout() time.Duration {
+	if e.MethodExpr.IsStreaming() {
+		return 0
+	}
+	if e.ClientTimeout > 0 {
+		return e.ClientTimeout
+	}
+	return e.Service.ClientTimeout
+}
+
+// RetryPolicy returns the policy used by the generated clients to retry the
+// requests made to the endpoint, nil if there is none. The policy is the one
+// defined on the endpoint if any, the one defined on the service otherwise.
+// Policies defined on the service only apply to idempotent endpoints.
+// Streaming endpoints have no retry policy.
+func (e *HTTPEndpointExpr) RetryPolicy() *RetryExpr {
+	if e.MethodExpr.IsStreaming() {
+		return nil
+	}
+	if e.ClientRetry != nil {
+		return e.ClientRetry
+	}
+	if e.IsIdempotent() {
+		return e.Service.ClientRetry
+	}
+	return nil
+}
+
+// validateClientPolicy makes sure the client policy defined on the endpoint
+// applies to the endpoint.
+func (e *HTTPEndpointExpr) validateClientPolicy() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if e.ClientRetry == nil && e.ClientTimeout == 0 {
+		return verr
+	}
+	if e.MethodExpr.IsStreaming() {
+		verr.Add(e, "ClientTimeout and ClientRetry cannot be used with streaming")
+	}
+	if e.ClientTimeout < 0 {
+		verr.Add(e, "client timeout cannot be negative")
+	}
+	if e.ClientRetry != nil {
+		verr.Merge(e.ClientRetry.Validate())
+		if !e.IsIdempotent() {
+			verr.Add(e, "ClientRetry can only be used with idempotent HTTP methods (GET, HEAD, PUT, DELETE, OPTIONS or TRACE)")
+		}
+	}
+	return verr
+}
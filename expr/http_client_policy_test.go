@@ -0,0 +1,75 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestClientPolicy(t *testing.T) {
+	root := expr.RunDSL(t, testdata.ClientPolicyDSL)
+	svc := root.API.HTTP.Services[0]
+	cases := []struct {
+		Endpoint    string
+		Timeout     time.Duration
+		MaxAttempts int
+		MaxBackoff  time.Duration
+	}{
+		{"Show", 2 * time.Second, 3, time.Second},
+		{"Update", 500 * time.Millisecond, 4, 50 * time.Millisecond},
+		{"Create", 2 * time.Second, 0, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.Endpoint, func(t *testing.T) {
+			e := svc.Endpoint(c.Endpoint)
+			if got := e.RequestTimeout(); got != c.Timeout {
+				t.Errorf("got timeout %s, expected %s", got, c.Timeout)
+			}
+			r := e.RetryPolicy()
+			if c.MaxAttempts == 0 {
+				if r != nil {
+					t.Errorf("got retry policy with %d attempts, expected none", r.MaxAttempts)
+				}
+				return
+			}
+			if r == nil {
+				t.Fatal("retry policy not defined")
+			}
+			if r.MaxAttempts != c.MaxAttempts {
+				t.Errorf("got %d attempts, expected %d", r.MaxAttempts, c.MaxAttempts)
+			}
+			if r.MaxBackoff != c.MaxBackoff {
+				t.Errorf("got maximum backoff %s, expected %s", r.MaxBackoff, c.MaxBackoff)
+			}
+		})
+	}
+}
+
+func TestClientPolicyInvalid(t *testing.T) {
+	cases := []struct {
+		Name   string
+		DSL    func()
+		Errors []string
+	}{
+		{"non-idempotent", testdata.NonIdempotentRetryDSL, []string{"ClientRetry can only be used with idempotent HTTP methods"}},
+		{"retry", testdata.InvalidRetryDSL, []string{
+			"maximum number of attempts must be at least 2, got 1",
+			"initial backoff 2s cannot be greater than maximum backoff 1s",
+			"invalid HTTP status code 42",
+		}},
+		{"streaming", testdata.StreamingClientPolicyDSL, []string{"ClientTimeout and ClientRetry cannot be used with streaming"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := expr.RunInvalidDSL(t, c.DSL)
+			for _, e := range c.Errors {
+				if !strings.Contains(err.Error(), e) {
+					t.Errorf("got error %q, expected it to contain %q", err.Error(), e)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package expr
+
+import (
+	"mime"
+	"strings"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// HTTPCompressExpr describes how HTTP responses are compressed. The
+	// generated servers compress the responses using the first encoding
+	// listed in Encodings that the client accepts as indicated by the
+	// request Accept-Encoding header.
+	HTTPCompressExpr struct {
+		// Encodings lists the content codings in order of preference.
+		Encodings []string
+		// ContentTypes lists the media types of the responses that are
+		// compressed, a media type may use a wildcard subtype (e.g.
+		// "text/*"). The runtime default list is used when empty.
+		ContentTypes []string
+		// MinSize is the minimum size in bytes of compressed responses.
+		MinSize int64
+		// Parent is the HTTP, service or endpoint expression that
+		// defines the compression.
+		Parent eval.Expression
+	}
+)
+
+// CompressEncodings lists the content codings supported by Compress. The
+// "gzip" and "deflate" codings are implemented by the goa runtime, other
+// codings require registering a compressor with the goa http package.
+var CompressEncodings = []string{"gzip", "deflate", "br", "zstd"}
+
+// EvalName returns the generic definition name used in error messages.
+func (c *HTTPCompressExpr) EvalName() string {
+	suffix := "compression"
+	if c.Parent != nil {
+		return c.Parent.EvalName() + " " + suffix
+	}
+	return suffix
+}
+
+// Validate makes sure the encodings are supported and listed only once and that
+// the content types are valid media types.
+func (c *HTTPCompressExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	seen := make(map[string]bool)
+	for _, enc := range c.Encodings {
+		if seen[enc] {
+			verr.Add(c, "encoding %q is listed more than once", enc)
+		}
+		seen[enc] = true
+		valid := false
+		for _, s := range CompressEncodings {
+			if enc == s {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			verr.Add(c, "unsupported encoding %q, must be one of %q", enc, CompressEncodings)
+		}
+	}
+	for _, ct := range c.ContentTypes {
+		mt, _, err := mime.ParseMediaType(ct)
+		if err != nil || !strings.Contains(mt, "/") || (strings.HasPrefix(mt, "*/") && mt != "*/*") {
+			verr.Add(c, "invalid content type %q", ct)
+		}
+	}
+	if c.MinSize < 0 {
+		verr.Add(c, "minimum size cannot be negative")
+	}
+	return verr
+}
+
+// Compress returns the compression settings that apply to the endpoint, nil if
+// responses are not compressed. Settings defined on the endpoint override the
+// ones defined on the service which override the ones defined on the API.
+func (e *HTTPEndpointExpr) Compress() *HTTPCompressExpr {
+	if e.Compression != nil {
+		return e.Compression
+	}
+	if e.Service.Compression != nil {
+		return e.Service.Compression
+	}
+	return Root.API.HTTP.Compression
+}
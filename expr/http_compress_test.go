@@ -0,0 +1,71 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestCompress(t *testing.T) {
+	root := expr.RunDSL(t, testdata.CompressDSL)
+	svc := root.API.HTTP.Services[0]
+	cases := []struct {
+		Name         string
+		Endpoint     *expr.HTTPEndpointExpr
+		Encodings    string
+		ContentTypes string
+		MinSize      int64
+	}{
+		{"inherited", svc.Endpoint("Inherited"), "br,gzip", "application/json", 2048},
+		{"overridden", svc.Endpoint("Overridden"), "gzip", "", 1024},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			comp := c.Endpoint.Compress()
+			if comp == nil {
+				t.Fatal("got no compression")
+			}
+			if got := strings.Join(comp.Encodings, ","); got != c.Encodings {
+				t.Errorf("got encodings %q, expected %q", got, c.Encodings)
+			}
+			if got := strings.Join(comp.ContentTypes, ","); got != c.ContentTypes {
+				t.Errorf("got content types %q, expected %q", got, c.ContentTypes)
+			}
+			if comp.MinSize != c.MinSize {
+				t.Errorf("got min size %d, expected %d", comp.MinSize, c.MinSize)
+			}
+		})
+	}
+}
+
+func TestCompressInvalid(t *testing.T) {
+	cases := []struct {
+		Name   string
+		DSL    func()
+		Errors []string
+	}{
+		{"encoding", testdata.CompressInvalidEncodingDSL, []string{
+			`unsupported encoding "lzma", must be one of ["gzip" "deflate" "br" "zstd"]`,
+			`encoding "gzip" is listed more than once`,
+		}},
+		{"content-type", testdata.CompressInvalidContentTypeDSL, []string{
+			`invalid content type "json"`,
+			`invalid content type "*/json"`,
+		}},
+		{"min-size", testdata.CompressInvalidMinSizeDSL, []string{
+			`invalid minimum size: "2XB" is not a valid size`,
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := expr.RunInvalidDSL(t, c.DSL)
+			for _, e := range c.Errors {
+				if !strings.Contains(err.Error(), e) {
+					t.Errorf("got error %q, expected it to contain %q", err.Error(), e)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package expr
+
+import (
+	"strconv"
+
+	"goa.design/goa/v3/eval"
+)
+
+// The meta keys below record the cookie attributes defined with the DSL on the
+// Cookies mapped attribute of HTTP endpoints (request cookies) and responses.
+const (
+	// CookieMaxAgeKey is the meta key that holds the cookie Max-Age
+	// attribute value in seconds.
+	CookieMaxAgeKey = "cookie:max-age"
+	// CookieDomainKey is the meta key that holds the cookie Domain
+	// attribute value.
+	CookieDomainKey = "cookie:domain"
+	// CookiePathKey is the meta key that holds the cookie Path attribute
+	// value.
+	CookiePathKey = "cookie:path"
+	// CookieSecureKey is the meta key set when the cookie Secure attribute
+	// is enabled.
+	CookieSecureKey = "cookie:secure"
+	// CookieHTTPOnlyKey is the meta key set when the cookie HttpOnly
+	// attribute is enabled.
+	CookieHTTPOnlyKey = "cookie:http-only"
+	// CookieSameSiteKey is the meta key that holds the cookie SameSite
+	// attribute value.
+	CookieSameSiteKey = "cookie:same-site"
+	// CookiePartitionedKey is the meta key set when the cookie Partitioned
+	// attribute is enabled.
+	CookiePartitionedKey = "cookie:partitioned"
+	// CookieSignedKey is the meta key set when the cookie values are
+	// encoded with the cookie codec of the server.
+	CookieSignedKey = "cookie:signed"
+)
+
+// IsSignedCookies returns true if the values of the cookies described by the
+// given mapped attribute are encoded with the server cookie codec.
+func IsSignedCookies(cookies *MappedAttributeExpr) bool {
+	if cookies == nil {
+		return false
+	}
+	_, ok := cookies.Meta[CookieSignedKey]
+	return ok
+}
+
+// validateCookies makes sure the request cookies map to primitive attributes
+// of the method payload. Endpoints without payload are validated separately.
+func (e *HTTPEndpointExpr) validateCookies() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if e.Cookies.IsEmpty() || isEmpty(e.MethodExpr.Payload) {
+		return verr
+	}
+	if !IsObject(e.MethodExpr.Payload.Type) {
+		verr.Add(e, "Cookies are set but Payload is not an object.")
+		return verr
+	}
+	verr.Merge(e.Cookies.Validate("HTTP request cookies", e))
+	verr.Merge(validateCookieTypes(e, e.Cookies, e.MethodExpr.Payload, "payload"))
+	for _, key := range []string{CookieMaxAgeKey, CookieDomainKey, CookiePathKey, CookieSecureKey, CookieHTTPOnlyKey, CookieSameSiteKey, CookiePartitionedKey} {
+		if _, ok := e.Cookies.Meta[key]; ok {
+			verr.Add(e, "cookie attributes can only be set on responses, %q is set on the request cookies", key)
+		}
+	}
+	return verr
+}
+
+// validateCookies makes sure the response cookies map to primitive attributes
+// of the method result and that their attributes are consistent.
+func (r *HTTPResponseExpr) validateCookies(e *HTTPEndpointExpr) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if !IsObject(e.MethodExpr.Result.Type) {
+		verr.Add(r, "response defines cookies but result type is not an object")
+		return verr
+	}
+	if e.MethodExpr.IsStreaming() {
+		verr.Add(r, "cookies cannot be used with streaming")
+	}
+	verr.Merge(r.Cookies.Validate("HTTP response cookies", r))
+	verr.Merge(validateCookieTypes(r, r.Cookies, e.MethodExpr.Result, "result"))
+	meta := r.Cookies.Meta
+	if v, ok := meta[CookieMaxAgeKey]; ok {
+		if _, err := strconv.Atoi(v[0]); err != nil {
+			verr.Add(r, "invalid cookie max age %q", v[0])
+		}
+	}
+	_, secure := meta[CookieSecureKey]
+	if v, ok := meta[CookieSameSiteKey]; ok {
+		switch v[0] {
+		case "Lax", "Strict":
+		case "None":
+			if !secure {
+				verr.Add(r, "cookies with SameSite set to None must also be Secure")
+			}
+		default:
+			verr.Add(r, "invalid cookie SameSite value %q, must be one of Lax, Strict or None", v[0])
+		}
+	}
+	if _, ok := meta[CookiePartitionedKey]; ok && !secure {
+		verr.Add(r, "partitioned cookies must also be Secure")
+	}
+	return verr
+}
+
+// validateCookieTypes checks that the cookies defined by the given mapped
+// attribute exist in the parent attribute and have a primitive type that can
+// be serialized in a cookie value.
+func validateCookieTypes(e eval.Expression, cookies *MappedAttributeExpr, parent *AttributeExpr, context string) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	signed := IsSignedCookies(cookies)
+	WalkMappedAttr(cookies, func(name, elem string, _ *AttributeExpr) error {
+		if !isHeaderToken(elem) {
+			verr.Add(e, "invalid cookie name %q", elem)
+		}
+		att := parent.Find(name)
+		if att == nil {
+			verr.Add(e, "cookie %q not found in %s.", name, context)
+			return nil
+		}
+		switch att.Type.Kind() {
+		case BooleanKind, IntKind, Int32Kind, Int64Kind, UIntKind, UInt32Kind, UInt64Kind, Float32Kind, Float64Kind:
+			if signed {
+				verr.Add(e, "signed cookie %q must be a string", elem)
+			}
+		case StringKind:
+		default:
+			verr.Add(e, "cookie %q must be a string, boolean or number", elem)
+		}
+		return nil
+	})
+	return verr
+}
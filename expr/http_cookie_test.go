@@ -0,0 +1,86 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestHTTPCookies(t *testing.T) {
+	root := expr.RunDSL(t, testdata.CookiesDSL)
+	e := root.API.HTTP.Services[0].Endpoint("Method")
+	if n := e.Cookies.ElemName("session"); n != "SID" {
+		t.Errorf("got session cookie name %q, expected %q", n, "SID")
+	}
+	if att := e.Cookies.Find("visits"); att == nil || att.Type != expr.Int {
+		t.Errorf("expected visits cookie to inherit the payload attribute type")
+	}
+	if body := expr.AsObject(e.Body.Type); body == nil || len(*body) != 1 || body.Attribute("name") == nil {
+		t.Errorf("expected request body to only contain name, got %v", e.Body.Type)
+	}
+	resp := e.Responses[0]
+	if att := resp.Cookies.Find("remember"); att == nil || att.Type != expr.Boolean {
+		t.Errorf("expected remember cookie to inherit the result attribute type")
+	}
+	if body := expr.AsObject(resp.Body.Type); body == nil || len(*body) != 1 || body.Attribute("content") == nil {
+		t.Errorf("expected response body to only contain content, got %v", resp.Body.Type)
+	}
+	expected := map[string]string{
+		expr.CookieMaxAgeKey:      "3600",
+		expr.CookieDomainKey:      "goa.design",
+		expr.CookiePathKey:        "/",
+		expr.CookieSecureKey:      "",
+		expr.CookieHTTPOnlyKey:    "",
+		expr.CookieSameSiteKey:    "Strict",
+		expr.CookiePartitionedKey: "",
+	}
+	for k, v := range expected {
+		if got, ok := resp.Cookies.Meta.Last(k); !ok || got != v {
+			t.Errorf("got %q meta %q, expected %q", k, got, v)
+		}
+	}
+	if expr.IsSignedCookies(resp.Cookies) {
+		t.Errorf("expected response cookies not to be signed")
+	}
+}
+
+func TestHTTPCookiesSigned(t *testing.T) {
+	root := expr.RunDSL(t, testdata.CookiesSignedDSL)
+	e := root.API.HTTP.Services[0].Endpoint("Method")
+	if !expr.IsSignedCookies(e.Cookies) {
+		t.Errorf("expected request cookies to be signed")
+	}
+	if !expr.IsSignedCookies(e.Responses[0].Cookies) {
+		t.Errorf("expected response cookies to be signed")
+	}
+}
+
+func TestHTTPCookiesInvalid(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"no payload", testdata.CookiesNoPayloadDSL, "Cookies are set but Payload is not defined."},
+		{"not found", testdata.CookiesNotFoundDSL, `cookie "session" not found in payload.`},
+		{"invalid type", testdata.CookiesInvalidTypeDSL, `cookie "tags" must be a string, boolean or number`},
+		{"invalid name", testdata.CookiesInvalidNameDSL, `invalid cookie name "my session"`},
+		{"signed not string", testdata.CookiesSignedNotStringDSL, `signed cookie "visits" must be a string`},
+		{"empty result", testdata.CookiesEmptyResultDSL, "response defines cookies but result is empty"},
+		{"result not object", testdata.CookiesResultNotObjectDSL, "response defines cookies but result type is not an object"},
+		{"same site none", testdata.CookiesSameSiteNoneDSL, "cookies with SameSite set to None must also be Secure"},
+		{"partitioned", testdata.CookiesPartitionedDSL, "partitioned cookies must also be Secure"},
+		{"streaming", testdata.CookiesStreamingDSL, "cookies cannot be used with streaming"},
+		{"error", testdata.CookiesErrorDSL, `Error "bad_request" response defines cookies`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := expr.RunInvalidDSL(t, c.DSL)
+			if !strings.Contains(err.Error(), c.Error) {
+				t.Errorf("got error %q, expected it to contain %q", err.Error(), c.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package expr
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// CORSExpr describes a Cross-Origin Resource Sharing policy. A policy
+	// applies to requests made from a given origin and lists the methods and
+	// headers that the origin may use as well as the response headers it may
+	// read.
+	CORSExpr struct {
+		// Origin is the origin the policy applies to. The special value
+		// "*" matches all origins. If the value starts and ends with a
+		// "/" then it is treated as a regular expression.
+		Origin string
+		// Methods lists the HTTP methods allowed for the origin. The
+		// methods of the endpoint routes are used when empty.
+		Methods []string
+		// Headers lists the request headers allowed for the origin.
+		Headers []string
+		// Exposed lists the response headers exposed to the origin.
+		Exposed []string
+		// MaxAge is the number of seconds preflight responses may be
+		// cached by clients.
+		MaxAge uint
+		// Credentials indicates whether the origin may send credentials
+		// (cookies, authorization headers etc.).
+		Credentials bool
+		// PrivateNetwork indicates whether the origin may access the
+		// endpoints from a public network as described by the Private
+		// Network Access specification.
+		PrivateNetwork bool
+		// Parent is the HTTP, service or endpoint expression that
+		// defines the policy.
+		Parent eval.Expression
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (c *CORSExpr) EvalName() string {
+	suffix := fmt.Sprintf("CORS policy for origin %q", c.Origin)
+	if c.Parent != nil {
+		return c.Parent.EvalName() + " " + suffix
+	}
+	return suffix
+}
+
+// IsRegexp returns true if the policy origin is a regular expression.
+func (c *CORSExpr) IsRegexp() bool {
+	return len(c.Origin) > 1 && strings.HasPrefix(c.Origin, "/") && strings.HasSuffix(c.Origin, "/")
+}
+
+// OriginRegexp returns the regular expression used to match origins if the
+// policy origin is a regular expression, the empty string otherwise.
+func (c *CORSExpr) OriginRegexp() string {
+	if !c.IsRegexp() {
+		return ""
+	}
+	return c.Origin[1 : len(c.Origin)-1]
+}
+
+// Validate makes sure the origin is valid and that credentials are not allowed
+// for all origins.
+func (c *CORSExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if c.Origin == "" {
+		verr.Add(c, "origin cannot be empty")
+	}
+	if c.IsRegexp() {
+		if _, err := regexp.Compile(c.OriginRegexp()); err != nil {
+			verr.Add(c, "invalid origin regular expression: %s", err)
+		}
+	}
+	if c.Origin == "*" && c.Credentials {
+		verr.Add(c, "credentials cannot be allowed for all origins, use a regular expression instead")
+	}
+	for _, m := range c.Methods {
+		switch m {
+		case "GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS", "TRACE", "CONNECT":
+		default:
+			verr.Add(c, "invalid HTTP method %q", m)
+		}
+	}
+	return verr
+}
+
+// CORS returns the CORS policies that apply to the endpoint. Policies defined
+// on the endpoint override the ones defined on the service which override
+// the ones defined on the API.
+func (e *HTTPEndpointExpr) CORS() []*CORSExpr {
+	if len(e.Origins) > 0 {
+		return e.Origins
+	}
+	if len(e.Service.Origins) > 0 {
+		return e.Service.Origins
+	}
+	return Root.API.HTTP.Origins
+}
@@ -0,0 +1,59 @@
+package expr_test
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestCORSValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidCORSDSL, ""},
+		{"invalid-regexp", testdata.InvalidCORSRegexpDSL, "service \"Service\" CORS policy for origin \"/[/\": invalid origin regular expression: error parsing regexp: missing closing ]: `[`"},
+		{"invalid-credentials", testdata.InvalidCORSCredentialsDSL, "API HTTP CORS policy for origin \"*\": credentials cannot be allowed for all origins, use a regular expression instead"},
+		{"invalid-method", testdata.InvalidCORSMethodDSL, "service \"Service\" HTTP endpoint \"Method\" CORS policy for origin \"https://goa.design\": invalid HTTP method \"FETCH\""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPEndpointCORS(t *testing.T) {
+	root := expr.RunDSL(t, testdata.ValidCORSDSL)
+	e := root.API.HTTP.Services[0].HTTPEndpoints[0]
+	cors := e.CORS()
+	if len(cors) != 1 {
+		t.Fatalf("got %d CORS policies, expected 1", len(cors))
+	}
+	if cors[0].Origin != "https://goa.design" {
+		t.Errorf("got origin %q, expected %q", cors[0].Origin, "https://goa.design")
+	}
+	if len(cors[0].Methods) != 2 || cors[0].Methods[0] != "GET" {
+		t.Errorf("got methods %v, expected [GET POST]", cors[0].Methods)
+	}
+	if !cors[0].PrivateNetwork {
+		t.Error("expected private network access to be allowed")
+	}
+	e.Origins = nil
+	if cors := e.CORS(); len(cors) != 1 || cors[0].OriginRegexp() != `.*goa\.design` {
+		t.Errorf("expected service CORS policy, got %v", cors)
+	}
+	e.Service.Origins = nil
+	if cors := e.CORS(); len(cors) != 1 || cors[0].Origin != "*" {
+		t.Errorf("expected API CORS policy, got %v", cors)
+	}
+}
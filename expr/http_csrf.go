@@ -0,0 +1,137 @@
+package expr
+
+import (
+	"net/http"
+
+	"goa.design/goa/v3/eval"
+)
+
+const (
+	// CSRFDoubleSubmit is the CSRF protection mode where the server issues
+	// a random token in a cookie and requires unsafe requests to echo its
+	// value in a header.
+	CSRFDoubleSubmit = "double-submit"
+	// CSRFSynchronizer is the CSRF protection mode where the server derives
+	// the token from the session cookie using a secret key and requires
+	// unsafe requests to send it in a header.
+	CSRFSynchronizer = "synchronizer"
+	// CSRFDisabled disables the CSRF protection.
+	CSRFDisabled = "disabled"
+
+	// DefaultCSRFHeader is the name of the header that carries the CSRF
+	// token by default.
+	DefaultCSRFHeader = "X-CSRF-Token"
+	// DefaultCSRFCookie is the name of the cookie that carries the CSRF
+	// token in the double-submit mode by default.
+	DefaultCSRFCookie = "csrf_token"
+)
+
+type (
+	// CSRFExpr describes the Cross-Site Request Forgery protection of the
+	// endpoints that authenticate requests with cookies.
+	CSRFExpr struct {
+		// Mode is the protection mode, one of CSRFDoubleSubmit,
+		// CSRFSynchronizer or CSRFDisabled.
+		Mode string
+		// Header is the name of the header that carries the token.
+		Header string
+		// Cookie is the name of the cookie that carries the token in
+		// the double-submit mode.
+		Cookie string
+		// Parent is the HTTP, service or endpoint expression that
+		// defines the protection.
+		Parent eval.Expression
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (c *CSRFExpr) EvalName() string {
+	suffix := "CSRF protection"
+	if c.Parent != nil {
+		return c.Parent.EvalName() + " " + suffix
+	}
+	return suffix
+}
+
+// HeaderName returns the name of the header that carries the token.
+func (c *CSRFExpr) HeaderName() string {
+	if c.Header == "" {
+		return DefaultCSRFHeader
+	}
+	return c.Header
+}
+
+// CookieName returns the name of the cookie that carries the token in the
+// double-submit mode.
+func (c *CSRFExpr) CookieName() string {
+	if c.Cookie == "" {
+		return DefaultCSRFCookie
+	}
+	return c.Cookie
+}
+
+// Validate makes sure the mode is valid and that the cookie name is only set
+// for the double-submit mode.
+func (c *CSRFExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	switch c.Mode {
+	case CSRFDoubleSubmit, CSRFDisabled:
+	case CSRFSynchronizer:
+		if c.Cookie != "" {
+			verr.Add(c, "the synchronizer mode derives the token from the session cookie, CSRFCookie cannot be used")
+		}
+	default:
+		verr.Add(c, "invalid CSRF protection mode %q, must be one of %q, %q or %q", c.Mode, CSRFDoubleSubmit, CSRFSynchronizer, CSRFDisabled)
+	}
+	return verr
+}
+
+// CSRFProtection returns the CSRF protection that applies to the endpoint, nil
+// if the endpoint does not authenticate requests with cookies or if the
+// protection is disabled. Protections defined on the endpoint override the
+// ones defined on the service which override the one defined on the API. The
+// double-submit mode is used when none is defined.
+func (e *HTTPEndpointExpr) CSRFProtection() *CSRFExpr {
+	if e.CSRFSessionCookie() == "" {
+		return nil
+	}
+	c := e.CSRF
+	if c == nil {
+		c = e.Service.CSRF
+	}
+	if c == nil {
+		c = Root.API.HTTP.CSRF
+	}
+	if c == nil {
+		c = &CSRFExpr{Mode: CSRFDoubleSubmit}
+	}
+	if c.Mode == CSRFDisabled {
+		return nil
+	}
+	return c
+}
+
+// CSRFSessionCookie returns the name of the first cookie that carries the
+// credentials of a security scheme of the endpoint, the empty string if the
+// endpoint does not authenticate requests with cookies.
+func (e *HTTPEndpointExpr) CSRFSessionCookie() string {
+	for _, req := range e.Requirements {
+		for _, s := range req.Schemes {
+			if s.In == "cookie" {
+				return s.Name
+			}
+		}
+	}
+	return ""
+}
+
+// IsSafeMethod returns true if the given HTTP method is safe as defined by
+// RFC 7231 section 4.2.1. CSRF tokens are issued in the responses to safe
+// requests and validated for the others.
+func IsSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package expr_test
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestCSRFValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.CSRFDSL, ""},
+		{"invalid-mode", testdata.InvalidCSRFModeDSL, "service \"Service\" CSRF protection: invalid CSRF protection mode \"cookie\", must be one of \"double-submit\", \"synchronizer\" or \"disabled\""},
+		{"invalid-cookie", testdata.InvalidCSRFCookieDSL, "service \"Service\" HTTP endpoint \"Method\" CSRF protection: the synchronizer mode derives the token from the session cookie, CSRFCookie cannot be used"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPEndpointCSRFProtection(t *testing.T) {
+	root := expr.RunDSL(t, testdata.CSRFDSL)
+	svc := root.API.HTTP.Services[0]
+	e := svc.HTTPEndpoints[0]
+	if got := e.CSRFSessionCookie(); got != "SID" {
+		t.Errorf("got session cookie %q, expected %q", got, "SID")
+	}
+	if csrf := e.CSRFProtection(); csrf != nil {
+		t.Errorf("got CSRF protection %v for disabled endpoint, expected nil", csrf)
+	}
+	e.CSRF = nil
+	csrf := e.CSRFProtection()
+	if csrf == nil || csrf.Mode != expr.CSRFDoubleSubmit || csrf.HeaderName() != "X-XSRF-Token" || csrf.CookieName() != "XSRF-TOKEN" {
+		t.Errorf("expected service CSRF protection, got %v", csrf)
+	}
+	svc.CSRF = nil
+	if csrf := e.CSRFProtection(); csrf == nil || csrf.Mode != expr.CSRFSynchronizer || csrf.HeaderName() != expr.DefaultCSRFHeader {
+		t.Errorf("expected API CSRF protection, got %v", csrf)
+	}
+	root.API.HTTP.CSRF = nil
+	if csrf := e.CSRFProtection(); csrf == nil || csrf.Mode != expr.CSRFDoubleSubmit || csrf.CookieName() != expr.DefaultCSRFCookie {
+		t.Errorf("expected default double-submit CSRF protection, got %v", csrf)
+	}
+	if csrf := svc.HTTPEndpoints[1].CSRFProtection(); csrf != nil {
+		t.Errorf("got CSRF protection %v for header authenticated endpoint, expected nil", csrf)
+	}
+	sch := e.Requirements[0].Schemes[0]
+	if sch.In != "cookie" || sch.Name != "SID" {
+		t.Errorf("got API key in %s %q, expected cookie %q", sch.In, sch.Name, "SID")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/dimfeld/httppath"
 	"goa.design/goa/v3/eval"
@@ -38,6 +39,8 @@ type (
 		Params *MappedAttributeExpr
 		// Headers defines the HTTP request headers.
 		Headers *MappedAttributeExpr
+		// Cookies defines the HTTP request cookies.
+		Cookies *MappedAttributeExpr
 		// Body describes the HTTP request body.
 		Body *AttributeExpr
 		// StreamingBody describes the body transferred through the websocket
@@ -54,6 +57,54 @@ type (
 		// MultipartRequest indicates that the request content type for
 		// the endpoint is a multipart type.
 		MultipartRequest bool
+		// FormEncodedRequest indicates that the request body is encoded
+		// using the "application/x-www-form-urlencoded" content type.
+		FormEncodedRequest bool
+		// Origins lists the CORS policies specific to the endpoint.
+		Origins []*CORSExpr
+		// CSRF describes the CSRF protection of the endpoint, it
+		// overrides the service and API settings.
+		CSRF *CSRFExpr
+		// Compression describes the compression of the endpoint
+		// responses, it overrides the service and API settings.
+		Compression *HTTPCompressExpr
+		// WebSocket describes the websocket settings of streaming
+		// endpoints.
+		WebSocket *WebSocketExpr
+		// StreamingResponse is the format of the chunked HTTP responses
+		// used to stream the method results instead of a websocket
+		// connection, empty if the results are streamed via websocket.
+		StreamingResponse string
+		// BodyLimit is the maximum size in bytes of request bodies, zero
+		// means the limit is inherited from the service or computed from
+		// the body validations.
+		BodyLimit int64
+		// StrictMediaTypes indicates whether the generated server rejects
+		// the requests whose Content-Type or Accept headers do not match
+		// the endpoint media types.
+		StrictMediaTypes bool
+		// StrictDecoding indicates whether the generated server rejects
+		// the requests whose body or query string contain fields that
+		// are not defined in the design.
+		StrictDecoding bool
+		// LenientDecoding indicates whether the generated server coerces
+		// the request values to the types defined in the design when
+		// possible.
+		LenientDecoding bool
+		// MaxAllocs is the maximum number of allocations made by the
+		// generated server code to handle a request, zero means no
+		// budget.
+		MaxAllocs int
+		// Proxy describes the upstream service requests are forwarded
+		// to if any.
+		Proxy *ProxyExpr
+		// ClientTimeout is the timeout applied by the generated clients
+		// to the requests made to the endpoint, zero means the timeout
+		// is inherited from the service.
+		ClientTimeout time.Duration
+		// ClientRetry is the policy used by the generated clients to
+		// retry failed requests if any.
+		ClientRetry *RetryExpr
 		// Meta is a set of key/value pairs with semantic that is
 		// specific to each generator, see dsl.Meta.
 		Meta MetaExpr
@@ -107,6 +158,14 @@ func (e *HTTPEndpointExpr) HasAbsoluteRoutes() bool {
 	return true
 }
 
+// APIKeyFallbacks returns the locations of the key of the given APIKey scheme
+// read by the server when the request does not carry the key in the location
+// the key attribute is mapped to. s must be one of the schemes of the endpoint
+// requirements.
+func (e *HTTPEndpointExpr) APIKeyFallbacks(s *SchemeExpr) []*KeyLocationExpr {
+	return keyFallbacks(&KeyLocationExpr{In: s.In, Name: s.Name}, s.KeyLocations)
+}
+
 // PathParams computes a mapped attribute containing the subset of e.Params that
 // describe path parameters.
 func (e *HTTPEndpointExpr) PathParams() *MappedAttributeExpr {
@@ -114,9 +173,14 @@ func (e *HTTPEndpointExpr) PathParams() *MappedAttributeExpr {
 	v := &ValidationExpr{}
 	pat := e.Params.Attribute() // need "attribute:name" style keys
 	for _, r := range e.Routes {
+		styled := r.styledParams()
 		for _, p := range r.Params() {
 			att := pat.Find(p)
 			obj.Set(p, att)
+			if _, ok := styled[p]; ok {
+				// label and matrix style parameters are optional
+				continue
+			}
 			if e.Params.IsRequired(p) {
 				v.AddRequired(p)
 			}
@@ -197,6 +261,9 @@ func (e *HTTPEndpointExpr) Prepare() {
 
 	e.Headers = headers
 	e.Params = params
+	if e.Cookies == nil {
+		e.Cookies = NewEmptyMappedAttributeExpr()
+	}
 
 	// Initialize path params that are not defined explicitly in
 	for _, r := range e.Routes {
@@ -220,6 +287,9 @@ func (e *HTTPEndpointExpr) Prepare() {
 		if e.MethodExpr.Payload.Type == Empty {
 			status = StatusNoContent
 		}
+		if e.MethodExpr.Async {
+			status = StatusAccepted
+		}
 		e.Responses = []*HTTPResponseExpr{{StatusCode: status}}
 	}
 
@@ -228,6 +298,12 @@ func (e *HTTPEndpointExpr) Prepare() {
 		e.HTTPErrors = append(e.HTTPErrors, r.Dup())
 	}
 
+	// Declare the error returned when the request body is too large
+	e.prepareBodyLimit()
+
+	// Declare the errors returned when the request media types do not match
+	e.prepareMediaTypes()
+
 	// Prepare responses
 	for _, r := range e.Responses {
 		r.Prepare()
@@ -283,6 +359,13 @@ func (e *HTTPEndpointExpr) Validate() error {
 			}
 		}
 	}
+	if e.MethodExpr.Cache != nil {
+		for _, r := range e.Routes {
+			if r.Method != "GET" && r.Method != "HEAD" {
+				verr.Add(e, "cached methods must use the GET or HEAD HTTP method, got %s", r.Method)
+			}
+		}
+	}
 
 	// Validate responses
 
@@ -323,6 +406,7 @@ func (e *HTTPEndpointExpr) Validate() error {
 	// Make sure parameters and headers use compatible types
 	verr.Merge(e.validateParams())
 	verr.Merge(e.validateHeaders())
+	verr.Merge(e.validateCookies())
 
 	// Validate body attribute (required fields exist etc.)
 	if e.Body != nil {
@@ -334,6 +418,39 @@ func (e *HTTPEndpointExpr) Validate() error {
 		verr.Merge(er.Validate())
 	}
 
+	// Validate CORS policies
+	for _, o := range e.Origins {
+		verr.Merge(o.Validate())
+	}
+	if e.CSRF != nil {
+		verr.Merge(e.CSRF.Validate())
+	}
+	if e.Compression != nil {
+		verr.Merge(e.Compression.Validate())
+	}
+
+	// Validate websocket settings
+	if e.WebSocket != nil {
+		verr.Merge(e.WebSocket.Validate())
+	}
+
+	// Validate proxy settings
+	if e.Proxy != nil {
+		verr.Merge(e.Proxy.Validate())
+	}
+
+	// Validate client policy
+	verr.Merge(e.validateClientPolicy())
+
+	// Validate form encoding
+	verr.Merge(e.validateFormEncoding())
+
+	// Validate streaming response settings
+	verr.Merge(e.validateStreamingResponse())
+
+	// Validate allocation budget
+	verr.Merge(e.validateMaxAllocs())
+
 	// Validate definitions of params, headers and bodies against definition of payload
 	if isEmpty(e.MethodExpr.Payload) {
 		if e.MapQueryParams != nil {
@@ -348,6 +465,9 @@ func (e *HTTPEndpointExpr) Validate() error {
 		if !e.Headers.IsEmpty() {
 			verr.Add(e, "Headers are set but Payload is not defined.")
 		}
+		if !e.Cookies.IsEmpty() {
+			verr.Add(e, "Cookies are set but Payload is not defined.")
+		}
 		return verr
 	}
 	if IsArray(e.MethodExpr.Payload.Type) {
@@ -454,6 +574,8 @@ func (e *HTTPEndpointExpr) Validate() error {
 		}
 	}
 
+	verr.Merge(e.validateKeyLocations())
+
 	return verr
 }
 
@@ -478,8 +600,24 @@ func (e *HTTPEndpointExpr) Finalize() {
 					sch.In = "header"
 					sch.Name = "Authorization"
 					continue
+				case MTLSKind:
+					// The client certificate is retrieved from the TLS
+					// connection state.
+					continue
+				case SignatureKind:
+					// The signature is verified by the transport
+					// middleware.
+					sch.In = "header"
+					sch.Name = "Authorization"
+					continue
 				case APIKeyKind:
 					field = TaggedAttribute(e.MethodExpr.Payload, "security:apikey:"+sch.SchemeName)
+					if len(sch.KeyLocations) > 0 && field != "" && e.primaryKeyLocation(field) == nil {
+						// Map the key to the location with the
+						// highest priority, the generated code reads
+						// the other locations.
+						e.mapKey(field, sch.KeyLocations[0])
+					}
 				case JWTKind:
 					field = TaggedAttribute(e.MethodExpr.Payload, "security:token")
 				case OAuth2Kind:
@@ -507,6 +645,7 @@ func (e *HTTPEndpointExpr) Finalize() {
 	// payload attributes.
 	initAttr(e.Params, e.MethodExpr.Payload)
 	initAttr(e.Headers, e.MethodExpr.Payload)
+	initAttr(e.Cookies, e.MethodExpr.Payload)
 
 	if e.Body != nil {
 		// rename type to add RequestBody suffix so that we don't end with
@@ -577,6 +716,9 @@ func (e *HTTPEndpointExpr) validateParams() *eval.ValidationErrors {
 
 	verr := new(eval.ValidationErrors)
 	WalkMappedAttr(pparams, func(name, _ string, a *AttributeExpr) error {
+		if hasQueryStyle(a) {
+			verr.Add(e, "path parameter %s cannot define a query string style", name)
+		}
 		switch {
 		case IsObject(a.Type):
 			verr.Add(e, "path parameter %s cannot be an object, path parameter types must be primitive, array or map (query string only)", name)
@@ -606,6 +748,7 @@ func (e *HTTPEndpointExpr) validateParams() *eval.ValidationErrors {
 			ctx := fmt.Sprintf("query parameter %s", name)
 			verr.Merge(a.Validate(ctx, e))
 		}
+		e.validateQueryStyle(name, a, verr)
 		return nil
 	})
 	if e.MethodExpr.Payload != nil {
@@ -724,18 +867,21 @@ func (r *RouteExpr) Validate() *eval.ValidationErrors {
 	// Make sure there's no duplicate params in absolute route
 	paths := r.FullPaths()
 	for _, path := range paths {
-		matches := HTTPWildcardRegex.FindAllStringSubmatch(path, -1)
+		matches := ExtractHTTPWildcards(path)
 		wcs := make(map[string]struct{}, len(matches))
 		for _, match := range matches {
-			if _, ok := wcs[match[1]]; ok {
-				verr.Add(r, "Wildcard %q appears multiple times in full path %q", match[1], path)
+			if _, ok := wcs[match]; ok {
+				verr.Add(r, "Wildcard %q appears multiple times in full path %q", match, path)
 			}
-			wcs[match[1]] = struct{}{}
+			wcs[match] = struct{}{}
 		}
 	}
 
+	// Make sure label and matrix style params are trailing strings
+	r.validateStyledParams(verr)
+
 	// For streaming endpoints, websockets does not support verbs other than GET
-	if r.Endpoint.MethodExpr.IsStreaming() {
+	if r.Endpoint.MethodExpr.IsStreaming() && r.Endpoint.StreamingResponse == "" {
 		if r.Method != "GET" {
 			verr.Add(r, "Streaming endpoint supports only \"GET\" method. Got %q.", r.Method)
 		}
@@ -842,6 +988,93 @@ func initAttrFromDesign(att, patt *AttributeExpr) {
 	}
 }
 
+// validateKeyLocations makes sure that the key attributes of the APIKey schemes
+// that accept keys from several locations are not required so that the
+// requests that carry the key in a fallback location are accepted.
+func (e *HTTPEndpointExpr) validateKeyLocations() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	reqs := e.MethodExpr.Requirements
+	if len(reqs) == 0 {
+		reqs = e.MethodExpr.Service.Requirements
+	}
+	for _, req := range reqs {
+		for _, s := range req.Schemes {
+			if s.Kind != APIKeyKind || len(s.KeyLocations) == 0 {
+				continue
+			}
+			field := TaggedAttribute(e.MethodExpr.Payload, "security:apikey:"+s.SchemeName)
+			if field == "" || !e.MethodExpr.Payload.IsRequired(field) {
+				continue
+			}
+			primary := e.primaryKeyLocation(field)
+			if primary == nil {
+				primary = s.KeyLocations[0]
+			}
+			if len(keyFallbacks(primary, s.KeyLocations)) > 0 {
+				verr.Add(e, "API key attribute %q cannot be required as security scheme %q accepts the key from several locations", field, s.SchemeName)
+			}
+		}
+	}
+	return verr
+}
+
+// primaryKeyLocation returns the location of the given key attribute if it is
+// mapped explicitly to a query string parameter, a header, a cookie or the
+// body, nil otherwise.
+func (e *HTTPEndpointExpr) primaryKeyLocation(field string) *KeyLocationExpr {
+	if field == "" {
+		return nil
+	}
+	if n, ok := e.Params.FindKey(field); ok {
+		return &KeyLocationExpr{In: "query", Name: n}
+	}
+	if n, ok := e.Headers.FindKey(field); ok {
+		return &KeyLocationExpr{In: "header", Name: n}
+	}
+	if n, ok := e.Cookies.FindKey(field); ok {
+		return &KeyLocationExpr{In: "cookie", Name: n}
+	}
+	if n, in := findKey(e, field); in == "body" {
+		return &KeyLocationExpr{In: in, Name: n}
+	}
+	return nil
+}
+
+// mapKey maps the given key attribute to the given location.
+func (e *HTTPEndpointExpr) mapKey(field string, l *KeyLocationExpr) {
+	var m *MappedAttributeExpr
+	switch l.In {
+	case "query":
+		m = e.Params
+	case "header":
+		m = e.Headers
+	case "cookie":
+		m = e.Cookies
+	default:
+		return
+	}
+	m.Type.(*Object).Set(field, e.MethodExpr.Payload.Find(field))
+	m.Map(l.Name, field)
+	if e.MethodExpr.Payload.IsRequired(field) {
+		if m.Validation == nil {
+			m.Validation = &ValidationExpr{}
+		}
+		m.Validation.AddRequired(field)
+	}
+}
+
+// keyFallbacks returns the locations of locs other than primary.
+func keyFallbacks(primary *KeyLocationExpr, locs []*KeyLocationExpr) []*KeyLocationExpr {
+	var res []*KeyLocationExpr
+	for _, l := range locs {
+		if l.In == primary.In && (l.Name == primary.Name || l.In == "header" && strings.EqualFold(l.Name, primary.Name)) {
+			continue
+		}
+		res = append(res, l)
+	}
+	return res
+}
+
 // isEmpty returns true if an attribute is Empty type and it has no bases and
 // references, or if an attribute is an empty object.
 func isEmpty(a *AttributeExpr) bool {
@@ -1,6 +1,7 @@
 package expr_test
 
 import (
+	"reflect"
 	"testing"
 
 	"goa.design/goa/v3/eval"
@@ -69,6 +70,15 @@ func TestHTTPEndpointValidation(t *testing.T) {
 		"endpoint-has-parent": {
 			DSL: testdata.EndpointHasParent,
 		},
+		"endpoint-key-locations": {
+			DSL: testdata.EndpointKeyLocations,
+		},
+		"endpoint-required-key-locations": {
+			DSL: testdata.EndpointRequiredKeyLocations,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\": API key attribute \"key\" cannot be required as security scheme \"api_key\" accepts the key from several locations",
+			},
+		},
 	}
 	for name, c := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -102,6 +112,35 @@ func TestHTTPEndpointValidation(t *testing.T) {
 	}
 }
 
+func TestHTTPEndpointAPIKeyFallbacks(t *testing.T) {
+	root := expr.RunDSL(t, testdata.EndpointKeyLocations)
+	cases := []struct {
+		Name      string
+		In        string
+		KeyName   string
+		Fallbacks []string
+	}{
+		{"Method", "header", "X-API-Key", []string{"query:api_key"}},
+		{"Mapped", "query", "api_key", []string{"header:X-API-Key"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			e := root.API.HTTP.Services[0].Endpoint(c.Name)
+			s := e.Requirements[0].Schemes[0]
+			if s.In != c.In || s.Name != c.KeyName {
+				t.Errorf("got key in %s %q, expected %s %q", s.In, s.Name, c.In, c.KeyName)
+			}
+			var fallbacks []string
+			for _, l := range e.APIKeyFallbacks(s) {
+				fallbacks = append(fallbacks, l.In+":"+l.Name)
+			}
+			if !reflect.DeepEqual(fallbacks, c.Fallbacks) {
+				t.Errorf("got fallbacks %v, expected %v", fallbacks, c.Fallbacks)
+			}
+		})
+	}
+}
+
 func TestHTTPEndpointFinalization(t *testing.T) {
 	cases := map[string]struct {
 		DSL          func()
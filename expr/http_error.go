@@ -27,20 +27,30 @@ func (e *HTTPErrorExpr) EvalName() string {
 // expression.
 func (e *HTTPErrorExpr) Validate() *eval.ValidationErrors {
 	verr := new(eval.ValidationErrors)
+	var ee *ErrorExpr
 	switch p := e.Response.Parent.(type) {
 	case *HTTPEndpointExpr:
-		if p.MethodExpr.Error(e.Name) == nil {
+		if ee = p.MethodExpr.Error(e.Name); ee == nil {
 			verr.Add(e, "Error %#v does not match an error defined in the method", e.Name)
 		}
 	case *HTTPServiceExpr:
-		if p.Error(e.Name) == nil {
+		if ee = p.Error(e.Name); ee == nil {
 			verr.Add(e, "Error %#v does not match an error defined in the service", e.Name)
 		}
 	case *RootExpr:
-		if Root.Error(e.Name) == nil {
+		if ee = Root.Error(e.Name); ee == nil {
 			verr.Add(e, "Error %#v does not match an error defined in the API", e.Name)
 		}
 	}
+	if ee != nil {
+		verr.Merge(e.Response.validateStandardHeaders(ee.AttributeExpr))
+	}
+	if e.Response.Trailers != nil && !e.Response.Trailers.IsEmpty() {
+		verr.Add(e, "Error %#v response defines trailers, trailers can only be used in success responses", e.Name)
+	}
+	if e.Response.Cookies != nil && !e.Response.Cookies.IsEmpty() {
+		verr.Add(e, "Error %#v response defines cookies, cookies can only be used in success responses", e.Name)
+	}
 	return verr
 }
 
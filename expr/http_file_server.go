@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"path"
 	"strings"
+
+	"goa.design/goa/v3/eval"
 )
 
 type (
@@ -20,6 +22,19 @@ type (
 		FilePath string
 		// RequestPaths is the list of HTTP paths that serve the assets.
 		RequestPaths []string
+		// Embed is true if the files are read from the file system given
+		// to the server constructor rather than from disk.
+		Embed bool
+		// Fallback is the path relative to FilePath of the file served
+		// when the requested file does not exist.
+		Fallback string
+		// CacheControl is the value of the Cache-Control response header.
+		CacheControl string
+		// Immutable is true if the responses may be cached indefinitely.
+		Immutable bool
+		// ETag is true if the responses include an ETag header computed
+		// from the file content.
+		ETag bool
 		// Meta is a list of key/value pairs
 		Meta MetaExpr
 	}
@@ -35,6 +50,21 @@ func (f *HTTPFileServerExpr) EvalName() string {
 	return prefix + suffix
 }
 
+// Validate makes sure the fallback file is only used with directories and
+// that it does not point outside of the directory.
+func (f *HTTPFileServerExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if f.Fallback != "" {
+		if !f.IsDir() {
+			verr.Add(f, "fallback %q can only be used when serving a directory", f.Fallback)
+		}
+		if path.IsAbs(f.Fallback) || strings.HasPrefix(path.Clean(f.Fallback), "..") {
+			verr.Add(f, "fallback %q must be a path relative to the served directory", f.Fallback)
+		}
+	}
+	return verr
+}
+
 // Finalize normalizes the request path.
 func (f *HTTPFileServerExpr) Finalize() {
 	current := f.RequestPaths[0]
@@ -57,3 +87,21 @@ func (f *HTTPFileServerExpr) Finalize() {
 func (f *HTTPFileServerExpr) IsDir() bool {
 	return HTTPWildcardRegex.MatchString(f.RequestPaths[0])
 }
+
+// CacheControlHeader returns the value of the Cache-Control header of the
+// responses, it is empty if the header is not set.
+func (f *HTTPFileServerExpr) CacheControlHeader() string {
+	if !f.Immutable {
+		return f.CacheControl
+	}
+	if f.CacheControl == "" {
+		return "public, max-age=31536000, immutable"
+	}
+	return f.CacheControl + ", immutable"
+}
+
+// IsConfigured returns true if the file server uses any of the embedded file
+// system, fallback, caching or ETag options.
+func (f *HTTPFileServerExpr) IsConfigured() bool {
+	return f.Embed || f.Fallback != "" || f.CacheControlHeader() != "" || f.ETag
+}
@@ -0,0 +1,59 @@
+package expr_test
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestFileServerOptions(t *testing.T) {
+	cases := []struct {
+		Name         string
+		DSL          func()
+		Fallback     string
+		CacheControl string
+		ETag         bool
+	}{
+		{"options", testdata.FileServerOptionsDSL, "index.html", "public, max-age=3600, immutable", true},
+		{"immutable", testdata.FileServerImmutableDSL, "", "public, max-age=31536000, immutable", false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := expr.RunDSL(t, c.DSL)
+			fs := root.API.HTTP.Services[0].FileServers[0]
+			if fs.Fallback != c.Fallback {
+				t.Errorf("got fallback %q, expected %q", fs.Fallback, c.Fallback)
+			}
+			if got := fs.CacheControlHeader(); got != c.CacheControl {
+				t.Errorf("got Cache-Control %q, expected %q", got, c.CacheControl)
+			}
+			if fs.ETag != c.ETag {
+				t.Errorf("got ETag %v, expected %v", fs.ETag, c.ETag)
+			}
+			if !fs.IsConfigured() {
+				t.Errorf("expected file server to be configured")
+			}
+		})
+	}
+}
+
+func TestFileServerValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"fallback-file", testdata.FileServerFallbackFileDSL, `service "Service" file server index.html: fallback "index.html" can only be used when serving a directory`},
+		{"fallback-outside", testdata.FileServerFallbackOutsideDSL, `service "Service" file server dist: fallback "../index.html" must be a path relative to the served directory`},
+		{"fallback-absolute", testdata.FileServerFallbackAbsoluteDSL, `service "Service" file server dist: fallback "/index.html" must be a path relative to the served directory`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := expr.RunInvalidDSL(t, c.DSL)
+			if err.Error() != c.Error {
+				t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package expr
+
+import "goa.design/goa/v3/eval"
+
+// validateFormEncoding makes sure the request body of endpoints that use
+// FormEncodedRequest can be represented using the
+// "application/x-www-form-urlencoded" encoding.
+func (e *HTTPEndpointExpr) validateFormEncoding() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if !e.FormEncodedRequest {
+		return verr
+	}
+	if e.MultipartRequest {
+		verr.Add(e, "HTTP endpoint defines both FormEncodedRequest and MultipartRequest. At most one of these must be defined.")
+	}
+	if e.MethodExpr.IsStreaming() {
+		verr.Add(e, "FormEncodedRequest cannot be used with streaming")
+	}
+	if isEmpty(e.MethodExpr.Payload) {
+		verr.Add(e, "FormEncodedRequest is set but Payload is not defined")
+		return verr
+	}
+	body := e.MethodExpr.Payload
+	if e.Body != nil {
+		body = e.Body
+	}
+	if !IsObject(body.Type) {
+		verr.Add(e, "FormEncodedRequest is set but the request body is not an object")
+		return verr
+	}
+	walkFormAttributes("", body, make(map[string]bool), func(name string, att *AttributeExpr) {
+		if m := AsMap(att.Type); m != nil && !IsPrimitive(m.KeyType.Type) {
+			verr.Add(e, "FormEncodedRequest is set but the keys of map %q are not primitive", name)
+		}
+	})
+	return verr
+}
+
+// walkFormAttributes calls fn for att and each attribute nested in att. name
+// is the form key of att.
+func walkFormAttributes(name string, att *AttributeExpr, seen map[string]bool, fn func(string, *AttributeExpr)) {
+	if ut, ok := att.Type.(UserType); ok {
+		if seen[ut.ID()] {
+			return
+		}
+		seen[ut.ID()] = true
+	}
+	fn(name, att)
+	switch actual := att.Type.(type) {
+	case UserType:
+		walkFormAttributes(name, actual.Attribute(), seen, fn)
+	case *Object:
+		for _, nat := range *actual {
+			key := nat.Name
+			if name != "" {
+				key = name + "[" + nat.Name + "]"
+			}
+			walkFormAttributes(key, nat.Attribute, seen, fn)
+		}
+	case *Array:
+		walkFormAttributes(name+"[]", actual.ElemType, seen, fn)
+	case *Map:
+		walkFormAttributes(name+"[]", actual.ElemType, seen, fn)
+	}
+}
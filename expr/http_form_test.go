@@ -0,0 +1,39 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestFormEncodedRequest(t *testing.T) {
+	root := expr.RunDSL(t, testdata.FormEncodedDSL)
+	e := root.API.HTTP.Services[0].Endpoint("Token")
+	if !e.FormEncodedRequest {
+		t.Error("expected endpoint to use form encoding")
+	}
+}
+
+func TestFormEncodedRequestInvalid(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"multipart", testdata.FormEncodedMultipartDSL, "HTTP endpoint defines both FormEncodedRequest and MultipartRequest"},
+		{"no payload", testdata.FormEncodedNoPayloadDSL, "FormEncodedRequest is set but Payload is not defined"},
+		{"array body", testdata.FormEncodedArrayBodyDSL, "FormEncodedRequest is set but the request body is not an object"},
+		{"map key", testdata.FormEncodedMapKeyDSL, `FormEncodedRequest is set but the keys of map "values" are not primitive`},
+		{"streaming", testdata.FormEncodedStreamingDSL, "FormEncodedRequest cannot be used with streaming"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := expr.RunInvalidDSL(t, c.DSL)
+			if !strings.Contains(err.Error(), c.Error) {
+				t.Errorf("got error %q, expected it to contain %q", err.Error(), c.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package expr
+
+import "sort"
+
+// IsAutoHeadOptions returns true if the generated server serves HEAD and
+// OPTIONS requests for the service routes, that is if AutoHeadOptions is set
+// on the service or the API.
+func (svc *HTTPServiceExpr) IsAutoHeadOptions() bool {
+	if svc.AutoHeadOptions {
+		return true
+	}
+	return Root.API != nil && Root.API.HTTP != nil && Root.API.HTTP.AutoHeadOptions
+}
+
+// AutoHead returns true if the generated server serves the HEAD requests made
+// to the given full path of the route with the route handler. This is the case
+// for the GET routes of non streaming endpoints whose service uses
+// AutoHeadOptions unless the design defines a HEAD route for the same path.
+func (r *RouteExpr) AutoHead(path string) bool {
+	e := r.Endpoint
+	if r.Method != "GET" || !e.Service.IsAutoHeadOptions() || e.MethodExpr.IsStreaming() {
+		return false
+	}
+	for _, rt := range routesFor(path) {
+		if rt.Method == "HEAD" {
+			return false
+		}
+	}
+	return true
+}
+
+// AutoOptionsPaths returns the full paths of the service routes for which the
+// generated server serves OPTIONS requests, see AutoOptions.
+func (svc *HTTPServiceExpr) AutoOptionsPaths() []string {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, e := range svc.HTTPEndpoints {
+		for _, r := range e.Routes {
+			for _, p := range r.FullPaths() {
+				if seen[p] {
+					continue
+				}
+				seen[p] = true
+				if svc.AutoOptions(p) != nil {
+					paths = append(paths, p)
+				}
+			}
+		}
+	}
+	return paths
+}
+
+// AutoOptions returns the sorted list of methods written in the Allow header of
+// the responses to the OPTIONS requests made to the given full path, nil if the
+// service server does not serve them. The list includes the methods of the
+// routes of all the services for the path. A single server serves the OPTIONS
+// requests for a path: the one of the first service that uses
+// AutoHeadOptions and defines a route for the path. OPTIONS requests are not
+// served for paths that have an OPTIONS route or a CORS preflight handler.
+func (svc *HTTPServiceExpr) AutoOptions(path string) []string {
+	if !svc.IsAutoHeadOptions() {
+		return nil
+	}
+	routes := routesFor(path)
+	var owner *HTTPServiceExpr
+	methods := []string{"OPTIONS"}
+	add := func(m string) {
+		for _, v := range methods {
+			if v == m {
+				return
+			}
+		}
+		methods = append(methods, m)
+	}
+	for _, r := range routes {
+		if r.Method == "OPTIONS" || len(r.Endpoint.CORS()) > 0 {
+			return nil
+		}
+		if owner == nil && r.Endpoint.Service.IsAutoHeadOptions() {
+			owner = r.Endpoint.Service
+		}
+		add(r.Method)
+		if r.AutoHead(path) {
+			add("HEAD")
+		}
+	}
+	if owner != svc {
+		return nil
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// routesFor returns the routes of all the API endpoints that have the given
+// full path.
+func routesFor(path string) []*RouteExpr {
+	var routes []*RouteExpr
+	for _, svc := range Root.API.HTTP.Services {
+		for _, e := range svc.HTTPEndpoints {
+			for _, r := range e.Routes {
+				for _, p := range r.FullPaths() {
+					if p == path {
+						routes = append(routes, r)
+						break
+					}
+				}
+			}
+		}
+	}
+	return routes
+}
@@ -0,0 +1,43 @@
+package expr_test
+
+import (
+	"reflect"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestAutoHeadOptions(t *testing.T) {
+	root := expr.RunDSL(t, testdata.AutoHeadOptionsDSL)
+	svc := root.API.HTTP.Service("Service")
+	other := root.API.HTTP.Service("Other")
+
+	heads := map[string]bool{"List": true, "Create": false, "Show": false, "Stream": false, "Shared": true, "CORS": true}
+	for name, expected := range heads {
+		r := svc.Endpoint(name).Routes[0]
+		if got := r.AutoHead(r.FullPaths()[0]); got != expected {
+			t.Errorf("%s: got auto head %v, expected %v", name, got, expected)
+		}
+	}
+
+	expected := []string{"/items", "/items/{id}", "/stream", "/shared"}
+	if got := svc.AutoOptionsPaths(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("got options paths %v, expected %v", got, expected)
+	}
+	allows := map[string][]string{
+		"/items":      {"GET", "HEAD", "OPTIONS", "POST"},
+		"/items/{id}": {"GET", "HEAD", "OPTIONS"},
+		"/stream":     {"GET", "OPTIONS"},
+		"/shared":     {"DELETE", "GET", "HEAD", "OPTIONS"},
+		"/cors":       nil,
+	}
+	for path, expected := range allows {
+		if got := svc.AutoOptions(path); !reflect.DeepEqual(got, expected) {
+			t.Errorf("%s: got allowed methods %v, expected %v", path, got, expected)
+		}
+	}
+	if got := other.AutoOptionsPaths(); len(got) != 0 {
+		t.Errorf("got options paths %v for the second service sharing the path, expected none", got)
+	}
+}
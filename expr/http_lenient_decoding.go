@@ -0,0 +1,45 @@
+package expr
+
+// IsLenientDecoding returns true if the generated server coerces the request
+// values to the types defined in the design, that is if LenientDecoding is set
+// on the endpoint, its service or the API. Lenient decoding does not apply to
+// proxied endpoints.
+func (e *HTTPEndpointExpr) IsLenientDecoding() bool {
+	if e.Proxy != nil {
+		return false
+	}
+	if e.LenientDecoding || e.Service.LenientDecoding {
+		return true
+	}
+	return Root.API != nil && Root.API.HTTP != nil && Root.API.HTTP.LenientDecoding
+}
+
+// IsLenientAttribute returns true if the values of the attribute are coerced
+// to the attribute type when decoded, that is if the attribute or its user
+// type use the "decode:lenient" meta.
+func IsLenientAttribute(a *AttributeExpr) bool {
+	return hasDecodeMeta(a, DecodeLenientMetaKey)
+}
+
+// IsTrimmedAttribute returns true if the leading and trailing white space of
+// the string values of the attribute is trimmed when decoded, that is if the
+// attribute or its user type use the "decode:trim" meta.
+func IsTrimmedAttribute(a *AttributeExpr) bool {
+	return hasDecodeMeta(a, DecodeTrimMetaKey)
+}
+
+// hasDecodeMeta returns true if the attribute or its user type define the
+// given meta key.
+func hasDecodeMeta(a *AttributeExpr, key string) bool {
+	if a == nil {
+		return false
+	}
+	if _, ok := a.Meta[key]; ok {
+		return true
+	}
+	if ut, ok := a.Type.(UserType); ok {
+		_, ok = ut.Attribute().Meta[key]
+		return ok
+	}
+	return false
+}
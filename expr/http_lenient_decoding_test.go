@@ -0,0 +1,57 @@
+package expr_test
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestIsLenientDecoding(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Service  string
+		Endpoint string
+		Expected bool
+	}{
+		{"api", testdata.LenientDecodingAPIDSL, "Service", "Method", true},
+		{"method", testdata.LenientDecodingDSL, "Service", "Method", true},
+		{"strict", testdata.LenientDecodingDSL, "Service", "Strict", false},
+		{"service", testdata.LenientDecodingDSL, "Lenient", "Method", true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := expr.RunDSL(t, c.DSL)
+			e := root.API.HTTP.Service(c.Service).Endpoint(c.Endpoint)
+			if actual := e.IsLenientDecoding(); actual != c.Expected {
+				t.Errorf("got %v, expected %v", actual, c.Expected)
+			}
+		})
+	}
+}
+
+func TestLenientAttributes(t *testing.T) {
+	root := expr.RunDSL(t, testdata.LenientDecodingDSL)
+	payload := root.Service("Service").Method("Method").Payload
+	cases := []struct {
+		Name    string
+		Lenient bool
+		Trimmed bool
+	}{
+		{"count", true, false},
+		{"name", false, true},
+		{"flag", false, false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			att := payload.Find(c.Name)
+			if actual := expr.IsLenientAttribute(att); actual != c.Lenient {
+				t.Errorf("lenient: got %v, expected %v", actual, c.Lenient)
+			}
+			if actual := expr.IsTrimmedAttribute(att); actual != c.Trimmed {
+				t.Errorf("trimmed: got %v, expected %v", actual, c.Trimmed)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package expr
+
+const (
+	// UnsupportedMediaTypeErrorName is the name of the error returned by
+	// the generated code when the request Content-Type header does not
+	// match the media types consumed by the endpoint.
+	UnsupportedMediaTypeErrorName = "unsupported_media_type"
+	// NotAcceptableErrorName is the name of the error returned by the
+	// generated code when the request Accept header does not match the
+	// media types produced by the endpoint.
+	NotAcceptableErrorName = "not_acceptable"
+)
+
+// IsStrictMediaTypes returns true if the generated server checks the request
+// Content-Type and Accept headers against the endpoint media types, that is if
+// StrictMediaTypes is set on the endpoint, its service or the API.
+func (e *HTTPEndpointExpr) IsStrictMediaTypes() bool {
+	if e.StrictMediaTypes || e.Service.StrictMediaTypes {
+		return true
+	}
+	return Root.API != nil && Root.API.HTTP != nil && Root.API.HTTP.StrictMediaTypes
+}
+
+// RequestMediaTypes returns the media types accepted in the Content-Type header
+// of the endpoint requests when the server checks them, nil otherwise. The
+// Content-Type header is not checked for endpoints without payload and for
+// streaming or proxied endpoints.
+func (e *HTTPEndpointExpr) RequestMediaTypes() []string {
+	if !e.checksContentType() {
+		return nil
+	}
+	switch {
+	case e.MultipartRequest:
+		return []string{"multipart/form-data"}
+	case e.FormEncodedRequest:
+		return []string{"application/x-www-form-urlencoded"}
+	}
+	return Root.API.HTTP.Consumes
+}
+
+// ResponseMediaTypes returns the media types matched against the Accept header
+// of the endpoint requests when the server checks it, nil otherwise. The media
+// types are the content types of the responses and the media types produced
+// by the API for the responses that do not set a content type. The Accept
+// header is not checked for endpoints without result and for streaming or
+// proxied endpoints.
+func (e *HTTPEndpointExpr) ResponseMediaTypes() []string {
+	if !e.checksAccept() {
+		return nil
+	}
+	var types []string
+	add := func(ts ...string) {
+		for _, t := range ts {
+			found := false
+			for _, s := range types {
+				if s == t {
+					found = true
+					break
+				}
+			}
+			if !found {
+				types = append(types, t)
+			}
+		}
+	}
+	for _, r := range e.Responses {
+		if r.ContentType != "" {
+			add(r.ContentType)
+		} else {
+			add(Root.API.HTTP.Produces...)
+		}
+	}
+	return types
+}
+
+// checksContentType returns true if the generated server checks the request
+// Content-Type header.
+func (e *HTTPEndpointExpr) checksContentType() bool {
+	if !e.IsStrictMediaTypes() || e.Proxy != nil || e.MethodExpr.IsStreaming() {
+		return false
+	}
+	return e.MethodExpr.Payload != nil && e.MethodExpr.Payload.Type != Empty
+}
+
+// checksAccept returns true if the generated server checks the request Accept
+// header.
+func (e *HTTPEndpointExpr) checksAccept() bool {
+	if !e.IsStrictMediaTypes() || e.Proxy != nil || e.MethodExpr.IsStreaming() {
+		return false
+	}
+	return e.MethodExpr.Result != nil && e.MethodExpr.Result.Type != Empty
+}
+
+// prepareMediaTypes declares the errors and the HTTP 415 and 406 responses
+// returned when the request media types do not match the endpoint media types
+// unless the design already defines them.
+func (e *HTTPEndpointExpr) prepareMediaTypes() {
+	if e.checksContentType() {
+		e.declareError(UnsupportedMediaTypeErrorName, "Request content type is not supported.", StatusUnsupportedResultType)
+	}
+	if e.checksAccept() {
+		e.declareError(NotAcceptableErrorName, "Response media types are not acceptable.", StatusNotAcceptable)
+	}
+}
+
+// declareError adds an error with the given name using the default error type
+// and its HTTP response with the given status to the endpoint unless they are
+// already defined.
+func (e *HTTPEndpointExpr) declareError(name, desc string, status int) {
+	if e.MethodExpr.Error(name) == nil {
+		e.MethodExpr.Errors = append(e.MethodExpr.Errors, &ErrorExpr{
+			AttributeExpr: &AttributeExpr{
+				Type:        ErrorResult,
+				Description: desc,
+			},
+			Name: name,
+		})
+	}
+	for _, he := range e.HTTPErrors {
+		if he.Name == name {
+			return
+		}
+	}
+	e.HTTPErrors = append(e.HTTPErrors, &HTTPErrorExpr{
+		Name: name,
+		Response: &HTTPResponseExpr{
+			StatusCode: status,
+			Parent:     e,
+		},
+	})
+}
@@ -0,0 +1,72 @@
+package expr_test
+
+import (
+	"reflect"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestStrictMediaTypes(t *testing.T) {
+	root := expr.RunDSL(t, testdata.StrictMediaTypesDSL)
+	svc := root.API.HTTP.Services[0]
+	cases := []struct {
+		Endpoint string
+		Consumes []string
+		Produces []string
+		Errors   map[string]int
+	}{
+		{"Method", []string{"application/json"}, []string{"text/plain"}, map[string]int{
+			expr.UnsupportedMediaTypeErrorName: expr.StatusUnsupportedResultType,
+			expr.NotAcceptableErrorName:        expr.StatusNotAcceptable,
+		}},
+		{"MultipartMethod", []string{"multipart/form-data"}, nil, map[string]int{
+			expr.UnsupportedMediaTypeErrorName: expr.StatusUnsupportedResultType,
+		}},
+		{"NoPayloadMethod", nil, []string{"application/json", "application/xml"}, map[string]int{
+			expr.NotAcceptableErrorName: expr.StatusNotAcceptable,
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.Endpoint, func(t *testing.T) {
+			e := svc.Endpoint(c.Endpoint)
+			if got := e.RequestMediaTypes(); !reflect.DeepEqual(got, c.Consumes) {
+				t.Errorf("got request media types %v, expected %v", got, c.Consumes)
+			}
+			if got := e.ResponseMediaTypes(); !reflect.DeepEqual(got, c.Produces) {
+				t.Errorf("got response media types %v, expected %v", got, c.Produces)
+			}
+			if len(e.HTTPErrors) != len(c.Errors) {
+				t.Fatalf("got %d errors, expected %d", len(e.HTTPErrors), len(c.Errors))
+			}
+			for _, he := range e.HTTPErrors {
+				status, ok := c.Errors[he.Name]
+				if !ok {
+					t.Errorf("got unexpected error %q", he.Name)
+					continue
+				}
+				if he.Response.StatusCode != status {
+					t.Errorf("got status %d for error %q, expected %d", he.Response.StatusCode, he.Name, status)
+				}
+				if e.MethodExpr.Error(he.Name) == nil {
+					t.Errorf("error %q not declared on the method", he.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestStrictMediaTypesDesignError(t *testing.T) {
+	root := expr.RunDSL(t, testdata.StrictMediaTypesErrorDSL)
+	e := root.API.HTTP.Services[0].Endpoint("Method")
+	if len(e.HTTPErrors) != 1 {
+		t.Fatalf("got %d errors, expected 1", len(e.HTTPErrors))
+	}
+	if s := e.HTTPErrors[0].Response.StatusCode; s != expr.StatusBadRequest {
+		t.Errorf("got status %d, expected the design status %d", s, expr.StatusBadRequest)
+	}
+	if e.MethodExpr.Error(expr.NotAcceptableErrorName).Type == expr.ErrorResult {
+		t.Error("got default error type, expected the design error type")
+	}
+}
@@ -0,0 +1,36 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestMuxerAdapters(t *testing.T) {
+	root := expr.RunDSL(t, testdata.MuxerAdaptersDSL)
+	if got := strings.Join(root.API.HTTP.MuxerAdapters, ","); got != "chi,gin" {
+		t.Errorf("got muxer adapters %q, expected %q", got, "chi,gin")
+	}
+}
+
+func TestMuxerAdaptersInvalid(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"unsupported", testdata.MuxerAdaptersUnsupportedDSL, `unsupported muxer adapter "httprouter", must be one of ["chi" "echo" "gin" "gorilla"]`},
+		{"duplicate", testdata.MuxerAdaptersDuplicateDSL, `muxer adapter "echo" is defined more than once`},
+		{"styled", testdata.MuxerAdaptersStyledDSL, `route "/report{.format}" uses label or matrix style wildcards which are not supported by muxer adapters`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := expr.RunInvalidDSL(t, c.DSL)
+			if !strings.Contains(err.Error(), c.Error) {
+				t.Errorf("got error %q, expected it to contain %q", err.Error(), c.Error)
+			}
+		})
+	}
+}
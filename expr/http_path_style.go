@@ -0,0 +1,112 @@
+package expr
+
+import (
+	"regexp"
+	"strings"
+
+	"goa.design/goa/v3/eval"
+)
+
+const (
+	// PathStyleSimple is the style of the path parameters defined with
+	// "{name}" or "{*name}".
+	PathStyleSimple = "simple"
+	// PathStyleLabel is the style of the path parameters defined with
+	// "{.name}". Label parameters are optional and serialized with a leading
+	// dot (e.g. "/report{.format}" matches "/report" and "/report.json").
+	PathStyleLabel = "label"
+	// PathStyleMatrix is the style of the path parameters defined with
+	// "{;name}". Matrix parameters are optional and serialized with a leading
+	// semicolon followed by the parameter name (e.g. "/cars{;color}" matches
+	// "/cars" and "/cars;color=blue").
+	PathStyleMatrix = "matrix"
+)
+
+var (
+	// HTTPStyledWildcardRegex is the regular expression used to capture
+	// label and matrix style path parameters.
+	HTTPStyledWildcardRegex = regexp.MustCompile(`{([.;])([a-zA-Z0-9_]+)}`)
+
+	// httpStyledSuffixRegex matches the label and matrix style path
+	// parameters that appear at the end of a path.
+	httpStyledSuffixRegex = regexp.MustCompile(`({[.;][a-zA-Z0-9_]+})+$`)
+
+	// httpCatchAllRegex matches a path that ends with a wildcard capturing
+	// the rest of the path.
+	httpCatchAllRegex = regexp.MustCompile(`/{(\*[a-zA-Z0-9_]+|[a-zA-Z0-9_]+\*)}$`)
+)
+
+// SplitStyledPath splits path into the part that precedes the trailing label
+// and matrix style path parameters and the styled parameters. For example
+// SplitStyledPath("/report/{id}{.format}") returns "/report/{id}" and
+// "{.format}".
+func SplitStyledPath(path string) (base, styled string) {
+	loc := httpStyledSuffixRegex.FindStringIndex(path)
+	if loc == nil {
+		return path, ""
+	}
+	return path[:loc[0]], path[loc[0]:]
+}
+
+// PathParamStyle returns the style of the path parameter with the given name
+// in path, one of PathStyleSimple, PathStyleLabel or PathStyleMatrix.
+func PathParamStyle(path, name string) string {
+	for _, m := range HTTPStyledWildcardRegex.FindAllStringSubmatch(path, -1) {
+		if m[2] != name {
+			continue
+		}
+		if m[1] == "." {
+			return PathStyleLabel
+		}
+		return PathStyleMatrix
+	}
+	return PathStyleSimple
+}
+
+// IsHTTPCatchAll returns true if the path parameter with the given name
+// captures the rest of the path, that is if it is defined with "{*name}" or
+// "{name*}".
+func IsHTTPCatchAll(path, name string) bool {
+	m := httpCatchAllRegex.FindStringSubmatch(path)
+	return m != nil && strings.Trim(m[1], "*") == name
+}
+
+// validateStyledParams makes sure the label and matrix style path parameters
+// appear at the end of the route paths, do not follow a wildcard capturing the
+// rest of the path and are strings.
+func (r *RouteExpr) validateStyledParams(verr *eval.ValidationErrors) {
+	for _, path := range r.FullPaths() {
+		base, styled := SplitStyledPath(path)
+		if HTTPStyledWildcardRegex.MatchString(base) {
+			verr.Add(r, "label and matrix style parameters must appear at the end of the path %q", path)
+		}
+		if styled == "" {
+			continue
+		}
+		if httpCatchAllRegex.MatchString(base) {
+			verr.Add(r, "label and matrix style parameters cannot follow a wildcard capturing the rest of the path %q", path)
+		}
+		payload := r.Endpoint.MethodExpr.Payload
+		if payload == nil {
+			continue
+		}
+		for _, m := range HTTPStyledWildcardRegex.FindAllStringSubmatch(styled, -1) {
+			if att := payload.Find(m[2]); att != nil && att.Type != String {
+				verr.Add(r, "%s style path parameter %q must be a string", PathParamStyle(styled, m[2]), m[2])
+			}
+		}
+	}
+}
+
+// styledParams returns the names of the label and matrix style parameters of
+// the route.
+func (r *RouteExpr) styledParams() map[string]struct{} {
+	res := make(map[string]struct{})
+	for _, path := range r.FullPaths() {
+		_, styled := SplitStyledPath(path)
+		for _, m := range HTTPStyledWildcardRegex.FindAllStringSubmatch(styled, -1) {
+			res[m[2]] = struct{}{}
+		}
+	}
+	return res
+}
@@ -0,0 +1,69 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestPathParamStyle(t *testing.T) {
+	cases := []struct {
+		Path     string
+		Name     string
+		Style    string
+		CatchAll bool
+	}{
+		{"/{id}", "id", expr.PathStyleSimple, false},
+		{"/report{.format}", "format", expr.PathStyleLabel, false},
+		{"/cars{;color}", "color", expr.PathStyleMatrix, false},
+		{"/files/{*path}", "path", expr.PathStyleSimple, true},
+		{"/files/{path*}", "path", expr.PathStyleSimple, true},
+		{"/files/{path}", "path", expr.PathStyleSimple, false},
+	}
+	for _, c := range cases {
+		t.Run(c.Path, func(t *testing.T) {
+			if style := expr.PathParamStyle(c.Path, c.Name); style != c.Style {
+				t.Errorf("got style %q, expected %q", style, c.Style)
+			}
+			if catchAll := expr.IsHTTPCatchAll(c.Path, c.Name); catchAll != c.CatchAll {
+				t.Errorf("got catch all %v, expected %v", catchAll, c.CatchAll)
+			}
+			if wcs := expr.ExtractHTTPWildcards(c.Path); len(wcs) != 1 || wcs[0] != c.Name {
+				t.Errorf("got wildcards %v, expected [%s]", wcs, c.Name)
+			}
+		})
+	}
+}
+
+func TestPathStyleOptional(t *testing.T) {
+	root := expr.RunDSL(t, testdata.ValidPathStyleDSL)
+	pp := root.API.HTTP.Services[0].HTTPEndpoints[0].PathParams()
+	cases := map[string]bool{"id": true, "format": false, "color": false}
+	for name, required := range cases {
+		if pp.IsRequired(name) != required {
+			t.Errorf("%s: got required %v, expected %v", name, !required, required)
+		}
+	}
+}
+
+func TestPathStyleValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"non-trailing", testdata.NonTrailingPathStyleDSL, `label and matrix style parameters must appear at the end of the path "/report{.format}/{id}"`},
+		{"catch-all", testdata.CatchAllPathStyleDSL, `label and matrix style parameters cannot follow a wildcard capturing the rest of the path "/files/{*path}{.format}"`},
+		{"non-string", testdata.NonStringPathStyleDSL, `matrix style path parameter "version" must be a string`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := expr.RunInvalidDSL(t, c.DSL)
+			if !strings.Contains(err.Error(), c.Error) {
+				t.Errorf("got error %q, expected it to contain %q", err.Error(), c.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package expr
+
+import (
+	"net/url"
+
+	"goa.design/goa/v3/eval"
+)
+
+// IsProblemError returns true if the given error is encoded as a problem
+// details document by the generated servers, that is if the design uses the
+// ProblemDetails DSL and the error uses the default error type.
+func IsProblemError(e *ErrorExpr) bool {
+	if Root == nil || Root.API == nil || Root.API.HTTP == nil || !Root.API.HTTP.ProblemDetails {
+		return false
+	}
+	return e.Type == ErrorResult
+}
+
+// validateProblemDetails makes sure the problem type base is an absolute URI.
+func (h *HTTPExpr) validateProblemDetails() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if h.ProblemTypeBase == "" {
+		return verr
+	}
+	u, err := url.Parse(h.ProblemTypeBase)
+	if err != nil || !u.IsAbs() {
+		verr.Add(h, "invalid problem type base %q, must be an absolute URI", h.ProblemTypeBase)
+	}
+	return verr
+}
@@ -0,0 +1,34 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestProblemDetails(t *testing.T) {
+	root := expr.RunDSL(t, testdata.ProblemDetailsDSL)
+	h := root.API.HTTP
+	if !h.ProblemDetails {
+		t.Fatal("got problem details disabled, expected enabled")
+	}
+	if h.ProblemTypeBase != "https://goa.design/problems/" {
+		t.Errorf("got problem type base %q", h.ProblemTypeBase)
+	}
+	m := root.Services[0].Methods[0]
+	if !expr.IsProblemError(m.Error("not_found")) {
+		t.Error("got not_found not encoded as problem, expected problem")
+	}
+	if expr.IsProblemError(m.Error("conflict")) {
+		t.Error("got conflict encoded as problem, expected custom error type")
+	}
+}
+
+func TestProblemDetailsInvalid(t *testing.T) {
+	err := expr.RunInvalidDSL(t, testdata.ProblemDetailsInvalidTypeBaseDSL)
+	if !strings.Contains(err.Error(), `invalid problem type base "problems/", must be an absolute URI`) {
+		t.Errorf("got error %q", err.Error())
+	}
+}
@@ -0,0 +1,73 @@
+package expr
+
+import (
+	"net/url"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// ProxyExpr describes an endpoint that forwards requests to an upstream
+	// service once they have been authorized and validated.
+	ProxyExpr struct {
+		// Upstream is the URL of the upstream service. The request path
+		// is appended to the upstream URL path.
+		Upstream string
+		// StripPrefix is removed from the request path before it is
+		// appended to the upstream URL path.
+		StripPrefix string
+		// SetHeaders lists the request headers set on the forwarded
+		// requests.
+		SetHeaders []*ProxyHeaderExpr
+		// RemoveHeaders lists the request headers removed from the
+		// forwarded requests.
+		RemoveHeaders []string
+		// Endpoint is the HTTP endpoint that forwards the requests.
+		Endpoint *HTTPEndpointExpr
+	}
+
+	// ProxyHeaderExpr describes a header set on forwarded requests.
+	ProxyHeaderExpr struct {
+		// Name is the header name.
+		Name string
+		// Value is the header value.
+		Value string
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (p *ProxyExpr) EvalName() string {
+	suffix := "proxy to " + p.Upstream
+	if p.Endpoint != nil {
+		return p.Endpoint.EvalName() + " " + suffix
+	}
+	return suffix
+}
+
+// Validate makes sure the upstream URL is valid and that the method does not
+// define a result or use streaming: the response is written by the upstream
+// service.
+func (p *ProxyExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	u, err := url.Parse(p.Upstream)
+	if err != nil {
+		verr.Add(p, "invalid upstream URL: %s", err)
+	} else if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		verr.Add(p, "upstream URL %q must be an absolute http or https URL", p.Upstream)
+	}
+	for _, h := range p.SetHeaders {
+		if h.Name == "" {
+			verr.Add(p, "header name cannot be empty")
+		}
+	}
+	if p.Endpoint == nil {
+		return verr
+	}
+	if !isEmpty(p.Endpoint.MethodExpr.Result) {
+		verr.Add(p, "method cannot define a result, the response is written by the upstream service")
+	}
+	if p.Endpoint.MethodExpr.IsStreaming() {
+		verr.Add(p, "method cannot use streaming")
+	}
+	return verr
+}
@@ -0,0 +1,49 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestProxy(t *testing.T) {
+	root := expr.RunDSL(t, testdata.ProxyDSL)
+	p := root.API.HTTP.Services[0].HTTPEndpoints[0].Proxy
+	if p == nil {
+		t.Fatal("proxy not defined")
+	}
+	if p.Upstream != "https://upstream.internal/v1" {
+		t.Errorf("got upstream %q, expected %q", p.Upstream, "https://upstream.internal/v1")
+	}
+	if p.StripPrefix != "/gateway" {
+		t.Errorf("got strip prefix %q, expected %q", p.StripPrefix, "/gateway")
+	}
+	if len(p.SetHeaders) != 1 || p.SetHeaders[0].Name != "X-Gateway" || p.SetHeaders[0].Value != "goa-v3" {
+		t.Errorf("got set headers %v, expected X-Gateway: goa-v3", p.SetHeaders)
+	}
+	if strings.Join(p.RemoveHeaders, ",") != "Authorization,Cookie" {
+		t.Errorf("got remove headers %v, expected [Authorization Cookie]", p.RemoveHeaders)
+	}
+}
+
+func TestProxyInvalid(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"upstream", testdata.InvalidUpstreamProxyDSL, `upstream URL "upstream.internal/v1" must be an absolute http or https URL`},
+		{"result", testdata.ResultProxyDSL, "method cannot define a result, the response is written by the upstream service"},
+		{"streaming", testdata.StreamingProxyDSL, "method cannot use streaming"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := expr.RunInvalidDSL(t, c.DSL)
+			if !strings.Contains(err.Error(), c.Error) {
+				t.Errorf("got error %q, expected it to contain %q", err.Error(), c.Error)
+			}
+		})
+	}
+}
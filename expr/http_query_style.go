@@ -0,0 +1,134 @@
+package expr
+
+import "goa.design/goa/v3/eval"
+
+const (
+	// QueryStyleForm is the default style for query string parameters.
+	// Exploded arrays are serialized by repeating the parameter
+	// (e.g. "id=1&id=2"), non-exploded arrays use a comma separated list
+	// (e.g. "id=1,2").
+	QueryStyleForm = "form"
+	// QueryStylePipeDelimited serializes arrays as pipe separated lists
+	// (e.g. "id=1|2").
+	QueryStylePipeDelimited = "pipeDelimited"
+	// QueryStyleSpaceDelimited serializes arrays as space separated lists
+	// (e.g. "id=1%202").
+	QueryStyleSpaceDelimited = "spaceDelimited"
+	// QueryStyleDeepObject serializes maps using one parameter per key
+	// (e.g. "filter[name]=goa&filter[lang]=go"). Nested maps use nested
+	// keys (e.g. "filter[owner][name]=goa"). This is the default style for
+	// maps and the style only applies to maps, objects and user types are
+	// not supported.
+	QueryStyleDeepObject = "deepObject"
+
+	// queryStyleMetaKey is the meta key used to store the query string
+	// parameter style.
+	queryStyleMetaKey = "http:query:style"
+	// queryExplodeMetaKey is the meta key used to store the query string
+	// parameter explode flag.
+	queryExplodeMetaKey = "http:query:explode"
+)
+
+// SetQueryStyle records the serialization style of the query string parameter
+// described by att.
+func SetQueryStyle(att *AttributeExpr, style string) {
+	if att.Meta == nil {
+		att.Meta = MetaExpr{}
+	}
+	att.Meta[queryStyleMetaKey] = []string{style}
+}
+
+// SetQueryExplode records whether the values of the query string parameter
+// described by att are exploded.
+func SetQueryExplode(att *AttributeExpr, explode bool) {
+	if att.Meta == nil {
+		att.Meta = MetaExpr{}
+	}
+	v := "false"
+	if explode {
+		v = "true"
+	}
+	att.Meta[queryExplodeMetaKey] = []string{v}
+}
+
+// QueryStyle returns the serialization style and explode flag of the query
+// string parameter described by att. The default is "deepObject" for maps and
+// exploded "form" otherwise. The explode flag defaults to true for the "form"
+// and "deepObject" styles and to false for the delimited styles.
+func QueryStyle(att *AttributeExpr) (style string, explode bool) {
+	style = QueryStyleForm
+	if IsMap(att.Type) {
+		style = QueryStyleDeepObject
+	}
+	if s, ok := att.Meta.Last(queryStyleMetaKey); ok {
+		style = s
+	}
+	explode = style == QueryStyleForm || style == QueryStyleDeepObject
+	if e, ok := att.Meta.Last(queryExplodeMetaKey); ok {
+		explode = e == "true"
+	}
+	return
+}
+
+// QueryDelimiter returns the string used to separate the values of the array
+// query string parameter described by att if the values are serialized in a
+// single parameter, the empty string if the parameter is repeated.
+func QueryDelimiter(att *AttributeExpr) string {
+	if !IsArray(att.Type) {
+		return ""
+	}
+	style, explode := QueryStyle(att)
+	switch style {
+	case QueryStylePipeDelimited:
+		return "|"
+	case QueryStyleSpaceDelimited:
+		return " "
+	case QueryStyleForm:
+		if !explode {
+			return ","
+		}
+	}
+	return ""
+}
+
+// hasQueryStyle returns true if the design sets the style or explode flag of
+// the parameter described by att.
+func hasQueryStyle(att *AttributeExpr) bool {
+	if _, ok := att.Meta[queryStyleMetaKey]; ok {
+		return true
+	}
+	_, ok := att.Meta[queryExplodeMetaKey]
+	return ok
+}
+
+// validateQueryStyle makes sure the style of the query string parameter with
+// the given name is compatible with its type.
+func (e *HTTPEndpointExpr) validateQueryStyle(name string, att *AttributeExpr, verr *eval.ValidationErrors) {
+	if !hasQueryStyle(att) {
+		return
+	}
+	style, explode := QueryStyle(att)
+	switch style {
+	case QueryStyleForm:
+		if IsMap(att.Type) {
+			verr.Add(e, "query parameter %s is a map, maps only support the %q style", name, QueryStyleDeepObject)
+		}
+	case QueryStylePipeDelimited, QueryStyleSpaceDelimited:
+		if !IsArray(att.Type) {
+			verr.Add(e, "query parameter %s uses the %q style which only applies to arrays", name, style)
+		}
+		if explode {
+			verr.Add(e, "query parameter %s uses the %q style which cannot be exploded", name, style)
+		}
+	case QueryStyleDeepObject:
+		if !IsMap(att.Type) {
+			verr.Add(e, "query parameter %s uses the %q style which only applies to maps, use nested maps to describe nested keys", name, style)
+		}
+		if !explode {
+			verr.Add(e, "query parameter %s uses the %q style which must be exploded", name, style)
+		}
+	default:
+		verr.Add(e, "query parameter %s uses invalid style %q, style must be one of %q, %q, %q or %q", name, style,
+			QueryStyleForm, QueryStylePipeDelimited, QueryStyleSpaceDelimited, QueryStyleDeepObject)
+	}
+}
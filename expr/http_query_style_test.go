@@ -0,0 +1,64 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestQueryStyle(t *testing.T) {
+	root := expr.RunDSL(t, testdata.ValidQueryStyleDSL)
+	params := root.API.HTTP.Services[0].HTTPEndpoints[0].QueryParams()
+	cases := []struct {
+		Name      string
+		Style     string
+		Explode   bool
+		Delimiter string
+	}{
+		{"pipes", expr.QueryStylePipeDelimited, false, "|"},
+		{"ids", expr.QueryStyleForm, false, ","},
+		{"filter", expr.QueryStyleDeepObject, true, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			att := params.Find(c.Name)
+			if att == nil {
+				t.Fatalf("query parameter %q not found", c.Name)
+			}
+			style, explode := expr.QueryStyle(att)
+			if style != c.Style {
+				t.Errorf("got style %q, expected %q", style, c.Style)
+			}
+			if explode != c.Explode {
+				t.Errorf("got explode %v, expected %v", explode, c.Explode)
+			}
+			if d := expr.QueryDelimiter(att); d != c.Delimiter {
+				t.Errorf("got delimiter %q, expected %q", d, c.Delimiter)
+			}
+		})
+	}
+}
+
+func TestQueryStyleValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"delimited-non-array", testdata.DelimitedNonArrayQueryStyleDSL, `query parameter q uses the "spaceDelimited" style which only applies to arrays`},
+		{"deep-object-non-map", testdata.DeepObjectNonMapQueryStyleDSL, `query parameter q uses the "deepObject" style which only applies to maps, use nested maps to describe nested keys`},
+		{"exploded-pipe-delimited", testdata.ExplodedPipeDelimitedQueryStyleDSL, `query parameter q uses the "pipeDelimited" style which cannot be exploded`},
+		{"path-param", testdata.PathParamQueryStyleDSL, `path parameter id cannot define a query string style`},
+		{"invalid", testdata.InvalidQueryStyleDSL, `invalid query string style "matrix"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := expr.RunInvalidDSL(t, c.DSL)
+			if !strings.Contains(err.Error(), c.Error) {
+				t.Errorf("got error %q, expected it to contain %q", err.Error(), c.Error)
+			}
+		})
+	}
+}
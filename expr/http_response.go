@@ -84,6 +84,11 @@ type (
 		Description string
 		// Headers describe the HTTP response headers.
 		Headers *MappedAttributeExpr
+		// Trailers describe the HTTP response trailers, the header
+		// fields sent after the response body.
+		Trailers *MappedAttributeExpr
+		// Cookies describe the HTTP response cookies.
+		Cookies *MappedAttributeExpr
 		// Response body if any
 		Body *AttributeExpr
 		// Response Content-Type header value
@@ -114,6 +119,12 @@ func (r *HTTPResponseExpr) Prepare() {
 	if r.Headers == nil {
 		r.Headers = NewEmptyMappedAttributeExpr()
 	}
+	if r.Trailers == nil {
+		r.Trailers = NewEmptyMappedAttributeExpr()
+	}
+	if r.Cookies == nil {
+		r.Cookies = NewEmptyMappedAttributeExpr()
+	}
 }
 
 // Validate checks that the response definition is consistent: its status is set
@@ -131,6 +142,12 @@ func (r *HTTPResponseExpr) Validate(e *HTTPEndpointExpr) *eval.ValidationErrors
 		if !r.Headers.IsEmpty() {
 			verr.Add(r, "response defines headers but result is empty")
 		}
+		if !r.Trailers.IsEmpty() {
+			verr.Add(r, "response defines trailers but result is empty")
+		}
+		if !r.Cookies.IsEmpty() {
+			verr.Add(r, "response defines cookies but result is empty")
+		}
 		return verr
 	}
 
@@ -181,6 +198,23 @@ func (r *HTTPResponseExpr) Validate(e *HTTPEndpointExpr) *eval.ValidationErrors
 		} else if len(*AsObject(r.Headers.Type)) > 1 {
 			verr.Add(r, "response defines more than one header but result type is not an object")
 		}
+		verr.Merge(r.validateStandardHeaders(e.MethodExpr.Result))
+	}
+	if !r.Trailers.IsEmpty() {
+		verr.Merge(r.Trailers.Validate("HTTP response trailers", r))
+		verr.Merge(r.validateTrailers(e))
+		if IsObject(e.MethodExpr.Result.Type) {
+			for _, t := range *AsObject(r.Trailers.Type) {
+				if !hasAttribute(t.Name) {
+					verr.Add(r, "trailer %q has no equivalent attribute in%s result type, use notation 'attribute_name:trailer_name' to identify corresponding result type attribute.", t.Name, inview)
+				}
+			}
+		} else if len(*AsObject(r.Trailers.Type))+len(*AsObject(r.Headers.Type)) > 1 {
+			verr.Add(r, "response defines more than one header or trailer but result type is not an object")
+		}
+	}
+	if !r.Cookies.IsEmpty() {
+		verr.Merge(r.validateCookies(e))
 	}
 	if r.Body != nil {
 		verr.Merge(r.Body.Validate("HTTP response body", r))
@@ -241,6 +275,9 @@ func (r *HTTPResponseExpr) Finalize(a *HTTPEndpointExpr, svcAtt *AttributeExpr)
 		}
 	}
 	initAttr(r.Headers, svcAtt)
+	initAttr(r.Trailers, svcAtt)
+	initAttr(r.Cookies, svcAtt)
+	r.initStandardHeaders()
 }
 
 // Dup creates a copy of the response expression.
@@ -256,6 +293,8 @@ func (r *HTTPResponseExpr) Dup() *HTTPResponseExpr {
 		res.Body = DupAtt(r.Body)
 	}
 	res.Headers = DupMappedAtt(r.Headers)
+	res.Trailers = DupMappedAtt(r.Trailers)
+	res.Cookies = DupMappedAtt(r.Cookies)
 	return &res
 }
 
@@ -0,0 +1,36 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestRouteConflicts(t *testing.T) {
+	expr.RunDSL(t, testdata.RouteNoConflictDSL)
+
+	err := expr.RunInvalidDSL(t, testdata.RouteConflictDSL)
+	expected := `route GET "/items/{name}" conflicts with route GET "/items/{id}" of service "Service" HTTP endpoint "Show"`
+	if !strings.Contains(err.Error(), expected) {
+		t.Errorf("got error %q, expected it to contain %q", err.Error(), expected)
+	}
+}
+
+func TestNormalizeHTTPPath(t *testing.T) {
+	cases := []struct{ Path, Expected string }{
+		{"/", "/"},
+		{"/users/{id}", "/users/{}"},
+		{"/users/{id}/posts/{post}", "/users/{}/posts/{}"},
+		{"/files/{*path}", "/files/{*}"},
+		{"/files/{path*}", "/files/{*}"},
+		{"/report{.format}", "/report{.}"},
+		{"/cars{;color}", "/cars{;}"},
+	}
+	for _, c := range cases {
+		if got := expr.NormalizeHTTPPath(c.Path); got != c.Expected {
+			t.Errorf("%s: got %q, expected %q", c.Path, got, c.Expected)
+		}
+	}
+}
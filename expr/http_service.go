@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/dimfeld/httppath"
 	"goa.design/goa/v3/eval"
@@ -37,6 +38,45 @@ type (
 		HTTPErrors []*HTTPErrorExpr
 		// FileServers is the list of static asset serving endpoints
 		FileServers []*HTTPFileServerExpr
+		// Webhooks lists the webhook requests sent by the service.
+		Webhooks []*HTTPWebhookExpr
+		// Origins lists the CORS policies that apply to all the service
+		// endpoints.
+		Origins []*CORSExpr
+		// CSRF describes the CSRF protection of the service endpoints
+		// that authenticate requests with cookies, it overrides the API
+		// settings.
+		CSRF *CSRFExpr
+		// Compression describes the compression of the responses of
+		// the service endpoints, it overrides the API settings.
+		Compression *HTTPCompressExpr
+		// BodyLimit is the maximum size in bytes of request bodies sent
+		// to the service endpoints, zero means no limit.
+		BodyLimit int64
+		// StrictMediaTypes indicates whether the generated server rejects
+		// the requests whose Content-Type or Accept headers do not match
+		// the media types of the service endpoints.
+		StrictMediaTypes bool
+		// StrictDecoding indicates whether the generated server rejects
+		// the requests whose body or query string contain fields that
+		// are not defined in the design.
+		StrictDecoding bool
+		// LenientDecoding indicates whether the generated server coerces
+		// the request values to the types defined in the design when
+		// possible.
+		LenientDecoding bool
+		// AutoHeadOptions indicates whether the generated server serves
+		// HEAD requests for the GET routes and OPTIONS requests for all
+		// the routes of the service endpoints.
+		AutoHeadOptions bool
+		// ClientTimeout is the timeout applied by the generated clients
+		// to the requests made to the service endpoints, zero means no
+		// timeout.
+		ClientTimeout time.Duration
+		// ClientRetry is the policy used by the generated clients to
+		// retry failed requests made to the idempotent service
+		// endpoints if any.
+		ClientRetry *RetryExpr
 		// Meta is a set of key/value pairs with semantic that is
 		// specific to each generator.
 		Meta MetaExpr
@@ -195,6 +235,22 @@ func (svc *HTTPServiceExpr) Validate() error {
 		}
 	}
 
+	for _, o := range svc.Origins {
+		verr.Merge(o.Validate())
+	}
+	if svc.CSRF != nil {
+		verr.Merge(svc.CSRF.Validate())
+	}
+	if svc.Compression != nil {
+		verr.Merge(svc.Compression.Validate())
+	}
+	if svc.ClientTimeout < 0 {
+		verr.Add(svc, "client timeout cannot be negative")
+	}
+	if svc.ClientRetry != nil {
+		verr.Merge(svc.ClientRetry.Validate())
+	}
+
 	// Validate errors (have status codes and bodies are valid)
 	for _, er := range svc.HTTPErrors {
 		verr.Merge(er.Validate())
@@ -0,0 +1,93 @@
+package expr
+
+import (
+	"net/http"
+
+	"goa.design/goa/v3/eval"
+)
+
+// standardHeader describes a response header whose semantics are standardized.
+type standardHeader struct {
+	// description is the header description used when the mapped
+	// attribute does not define one.
+	description string
+	// date is true if the header value may also be an HTTP date in
+	// which case the attribute may be a string.
+	date bool
+}
+
+// standardHeaders lists the standardized response headers indexed by canonical
+// header name, see RFC 7231 section 7.1.3 and the IETF RateLimit header fields
+// draft.
+var standardHeaders = map[string]*standardHeader{
+	"Retry-After": {
+		description: "Retry-After indicates how long the client should wait before making a follow-up request, in seconds or as an HTTP date.",
+		date:        true,
+	},
+	"Ratelimit-Limit": {
+		description: "RateLimit-Limit is the request quota of the client in the current time window.",
+	},
+	"Ratelimit-Remaining": {
+		description: "RateLimit-Remaining is the number of requests left in the current time window.",
+	},
+	"Ratelimit-Reset": {
+		description: "RateLimit-Reset is the number of seconds until the quota resets.",
+	},
+}
+
+// validateStandardHeaders makes sure the attributes mapped to standardized
+// headers have compatible types. att is the result or error attribute the
+// headers are initialized from.
+func (r *HTTPResponseExpr) validateStandardHeaders(att *AttributeExpr) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if r.Headers.IsEmpty() {
+		return verr
+	}
+	for _, nat := range *AsObject(r.Headers.Type) {
+		elem := http.CanonicalHeaderKey(r.Headers.ElemName(nat.Name))
+		sh, ok := standardHeaders[elem]
+		if !ok {
+			continue
+		}
+		patt := att
+		if IsObject(att.Type) {
+			patt = att.Find(nat.Name)
+		}
+		if patt == nil || patt.Type == Empty {
+			continue
+		}
+		typ := patt.Type
+		switch {
+		case isIntegerType(typ):
+		case sh.date && typ == String:
+		case sh.date:
+			verr.Add(r, "header %q must be mapped to an integer or a string attribute", elem)
+		default:
+			verr.Add(r, "header %q must be mapped to an integer attribute", elem)
+		}
+	}
+	return verr
+}
+
+// initStandardHeaders sets the description of the attributes mapped to
+// standardized headers that do not define one.
+func (r *HTTPResponseExpr) initStandardHeaders() {
+	if r.Headers.IsEmpty() {
+		return
+	}
+	for _, nat := range *AsObject(r.Headers.Type) {
+		elem := http.CanonicalHeaderKey(r.Headers.ElemName(nat.Name))
+		if sh, ok := standardHeaders[elem]; ok && nat.Attribute.Description == "" {
+			nat.Attribute.Description = sh.description
+		}
+	}
+}
+
+// isIntegerType returns true if typ is one of the integer primitive types.
+func isIntegerType(typ DataType) bool {
+	switch typ.Kind() {
+	case IntKind, Int32Kind, Int64Kind, UIntKind, UInt32Kind, UInt64Kind:
+		return true
+	}
+	return false
+}
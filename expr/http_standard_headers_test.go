@@ -0,0 +1,70 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestStandardHeaders(t *testing.T) {
+	root := expr.RunDSL(t, testdata.StandardHeadersDSL)
+	e := root.API.HTTP.Services[0].HTTPEndpoints[0]
+	cases := []struct {
+		Name        string
+		Headers     *expr.MappedAttributeExpr
+		Attribute   string
+		Header      string
+		Description string
+	}{
+		{"limit", e.Responses[0].Headers, "limit", "RateLimit-Limit", "RateLimit-Limit is the request quota of the client in the current time window."},
+		{"remaining", e.Responses[0].Headers, "remaining", "RateLimit-Remaining", "Requests left."},
+		{"retry-at", e.Responses[0].Headers, "retry_at", "Retry-After", "Retry-After indicates how long the client should wait before making a follow-up request, in seconds or as an HTTP date."},
+		{"error-retry-in", e.HTTPErrors[0].Response.Headers, "retry_in", "Retry-After", "Retry-After indicates how long the client should wait before making a follow-up request, in seconds or as an HTTP date."},
+		{"error-reset", e.HTTPErrors[0].Response.Headers, "reset", "RateLimit-Reset", "RateLimit-Reset is the number of seconds until the quota resets."},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if got := c.Headers.ElemName(c.Attribute); got != c.Header {
+				t.Errorf("got header %q, expected %q", got, c.Header)
+			}
+			att := c.Headers.Find(c.Attribute)
+			if att == nil {
+				t.Fatalf("attribute %q not found", c.Attribute)
+			}
+			if att.Description != c.Description {
+				t.Errorf("got description %q, expected %q", att.Description, c.Description)
+			}
+		})
+	}
+	if att := e.Responses[0].Headers.Find("reset"); att != nil {
+		t.Errorf("unexpected reset header")
+	}
+}
+
+func TestStandardHeadersInvalid(t *testing.T) {
+	cases := []struct {
+		Name   string
+		DSL    func()
+		Errors []string
+	}{
+		{"result", testdata.StandardHeadersInvalidResultDSL, []string{
+			`header "Ratelimit-Limit" must be mapped to an integer attribute`,
+			`header "Retry-After" must be mapped to an integer or a string attribute`,
+		}},
+		{"error", testdata.StandardHeadersInvalidErrorDSL, []string{
+			`header "Ratelimit-Reset" must be mapped to an integer attribute`,
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := expr.RunInvalidDSL(t, c.DSL)
+			for _, e := range c.Errors {
+				if !strings.Contains(err.Error(), e) {
+					t.Errorf("got error %q, expected it to contain %q", err.Error(), e)
+				}
+			}
+		})
+	}
+}
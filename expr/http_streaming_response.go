@@ -0,0 +1,33 @@
+package expr
+
+import "goa.design/goa/v3/eval"
+
+const (
+	// NDJSONStreamFormat is the format of streaming responses that write
+	// each result as a JSON value followed by a newline.
+	NDJSONStreamFormat = "ndjson"
+	// JSONArrayStreamFormat is the format of streaming responses that write
+	// the results as the elements of a JSON array.
+	JSONArrayStreamFormat = "json-array"
+)
+
+// validateStreamingResponse makes sure the streaming response settings apply
+// to the endpoint.
+func (e *HTTPEndpointExpr) validateStreamingResponse() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if e.StreamingResponse == "" {
+		return verr
+	}
+	switch e.StreamingResponse {
+	case NDJSONStreamFormat, JSONArrayStreamFormat:
+	default:
+		verr.Add(e, "invalid streaming response format %q, must be %q or %q", e.StreamingResponse, NDJSONStreamFormat, JSONArrayStreamFormat)
+	}
+	if e.MethodExpr.Stream != ServerStreamKind {
+		verr.Add(e, "StreamingResponse is set but the method does not define a StreamingResult or defines a StreamingPayload")
+	}
+	if e.WebSocket != nil {
+		verr.Add(e, "HTTP endpoint defines both StreamingResponse and WebSocket. At most one of these must be defined.")
+	}
+	return verr
+}
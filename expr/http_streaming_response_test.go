@@ -0,0 +1,38 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestStreamingResponse(t *testing.T) {
+	root := expr.RunDSL(t, testdata.StreamingResponseDSL)
+	e := root.API.HTTP.Services[0].Endpoint("Watch")
+	if e.StreamingResponse != expr.NDJSONStreamFormat {
+		t.Errorf("got streaming response format %q, expected %q", e.StreamingResponse, expr.NDJSONStreamFormat)
+	}
+}
+
+func TestStreamingResponseInvalid(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"format", testdata.StreamingResponseInvalidFormatDSL, `invalid streaming response format "xml", must be "ndjson" or "json-array"`},
+		{"no stream", testdata.StreamingResponseNoStreamDSL, "StreamingResponse is set but the method does not define a StreamingResult or defines a StreamingPayload"},
+		{"streaming payload", testdata.StreamingResponsePayloadDSL, "StreamingResponse is set but the method does not define a StreamingResult or defines a StreamingPayload"},
+		{"websocket", testdata.StreamingResponseWebSocketDSL, "HTTP endpoint defines both StreamingResponse and WebSocket"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := expr.RunInvalidDSL(t, c.DSL)
+			if !strings.Contains(err.Error(), c.Error) {
+				t.Errorf("got error %q, expected it to contain %q", err.Error(), c.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package expr
+
+// IsStrictDecoding returns true if the generated server rejects the requests
+// whose body or query string contain fields that are not defined in the
+// design, that is if StrictDecoding is set on the endpoint, its service or the
+// API. Strict decoding does not apply to proxied endpoints.
+func (e *HTTPEndpointExpr) IsStrictDecoding() bool {
+	if e.Proxy != nil {
+		return false
+	}
+	if e.StrictDecoding || e.Service.StrictDecoding {
+		return true
+	}
+	return Root.API != nil && Root.API.HTTP != nil && Root.API.HTTP.StrictDecoding
+}
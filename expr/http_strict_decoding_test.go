@@ -0,0 +1,32 @@
+package expr_test
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestIsStrictDecoding(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Service  string
+		Endpoint string
+		Expected bool
+	}{
+		{"api", testdata.StrictDecodingAPIDSL, "Service", "Method", true},
+		{"method", testdata.StrictDecodingDSL, "Service", "Method", true},
+		{"lenient", testdata.StrictDecodingDSL, "Service", "Lenient", false},
+		{"service", testdata.StrictDecodingDSL, "Strict", "Method", true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := expr.RunDSL(t, c.DSL)
+			e := root.API.HTTP.Service(c.Service).Endpoint(c.Endpoint)
+			if actual := e.IsStrictDecoding(); actual != c.Expected {
+				t.Errorf("got %v, expected %v", actual, c.Expected)
+			}
+		})
+	}
+}
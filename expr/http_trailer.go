@@ -0,0 +1,54 @@
+package expr
+
+import (
+	"net/http"
+
+	"goa.design/goa/v3/eval"
+)
+
+// forbiddenTrailers lists the header fields that cannot be sent in trailers,
+// see RFC 7230 section 4.1.2.
+var forbiddenTrailers = map[string]bool{
+	"Authorization":     true,
+	"Cache-Control":     true,
+	"Content-Encoding":  true,
+	"Content-Length":    true,
+	"Content-Range":     true,
+	"Content-Type":      true,
+	"Host":              true,
+	"Set-Cookie":        true,
+	"Te":                true,
+	"Trailer":           true,
+	"Transfer-Encoding": true,
+}
+
+// validateTrailers makes sure the response trailers can be sent after the
+// response body: the response must have a body, the method may not stream and
+// the trailers must map to primitive attributes that are not also mapped to
+// headers.
+func (r *HTTPResponseExpr) validateTrailers(e *HTTPEndpointExpr) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if e.MethodExpr.IsStreaming() {
+		verr.Add(r, "trailers cannot be used with streaming")
+	}
+	if r.StatusCode != 0 && !bodyAllowedForStatus(r.StatusCode) {
+		verr.Add(r, "trailers defined for status code %d which does not allow response body", r.StatusCode)
+	}
+	for _, nat := range *AsObject(r.Trailers.Type) {
+		elem := r.Trailers.ElemName(nat.Name)
+		if forbiddenTrailers[http.CanonicalHeaderKey(elem)] {
+			verr.Add(r, "%q cannot be sent as a trailer", elem)
+		}
+		if _, ok := r.Headers.FindKey(nat.Name); ok {
+			verr.Add(r, "attribute %q is mapped to both a header and a trailer", nat.Name)
+		}
+		att := e.MethodExpr.Result
+		if IsObject(att.Type) {
+			att = att.Find(nat.Name)
+		}
+		if att != nil && !IsPrimitive(att.Type) {
+			verr.Add(r, "trailer %q must be a primitive type", elem)
+		}
+	}
+	return verr
+}
@@ -0,0 +1,49 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestHTTPResponseTrailers(t *testing.T) {
+	root := expr.RunDSL(t, testdata.TrailersDSL)
+	resp := root.API.HTTP.Services[0].Endpoint("Method").Responses[0]
+	if n := resp.Trailers.ElemName("checksum"); n != "Digest" {
+		t.Errorf("got checksum trailer name %q, expected %q", n, "Digest")
+	}
+	if att := resp.Trailers.Find("count"); att == nil || att.Type != expr.Int {
+		t.Errorf("expected count trailer to inherit the result attribute type")
+	}
+	body := expr.AsObject(resp.Body.Type)
+	if body == nil || len(*body) != 1 || body.Attribute("content") == nil {
+		t.Errorf("expected body to only contain content, got %v", resp.Body.Type)
+	}
+}
+
+func TestHTTPResponseTrailersInvalid(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"empty result", testdata.TrailersEmptyResultDSL, "response defines trailers but result is empty"},
+		{"no attribute", testdata.TrailersNoAttributeDSL, `trailer "checksum" has no equivalent attribute in result type`},
+		{"not primitive", testdata.TrailersNotPrimitiveDSL, `trailer "tags" must be a primitive type`},
+		{"forbidden", testdata.TrailersForbiddenDSL, `"Content-Length" cannot be sent as a trailer`},
+		{"header", testdata.TrailersHeaderDSL, `attribute "checksum" is mapped to both a header and a trailer`},
+		{"no body status", testdata.TrailersNoBodyStatusDSL, "trailers defined for status code 204 which does not allow response body"},
+		{"streaming", testdata.TrailersStreamingDSL, "trailers cannot be used with streaming"},
+		{"error", testdata.TrailersErrorDSL, `Error "bad_request" response defines trailers`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := expr.RunInvalidDSL(t, c.DSL)
+			if !strings.Contains(err.Error(), c.Error) {
+				t.Errorf("got error %q, expected it to contain %q", err.Error(), c.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// HTTPWebhookExpr describes a webhook, that is a HTTP request sent by
+	// the service to a URL registered by a third party when an event
+	// occurs.
+	HTTPWebhookExpr struct {
+		// Name is the name of the webhook event, e.g. "order.created".
+		Name string
+		// Description for docs
+		Description string
+		// Body describes the webhook request body.
+		Body *AttributeExpr
+		// Service is the parent service.
+		Service *HTTPServiceExpr
+		// Meta is a list of key/value pairs
+		Meta MetaExpr
+	}
+)
+
+// Webhook returns the webhook of the service with the given name, nil if
+// there is none.
+func (svc *HTTPServiceExpr) Webhook(name string) *HTTPWebhookExpr {
+	for _, w := range svc.Webhooks {
+		if w.Name == name {
+			return w
+		}
+	}
+	return nil
+}
+
+// EvalName returns the generic definition name used in error messages.
+func (w *HTTPWebhookExpr) EvalName() string {
+	suffix := fmt.Sprintf("webhook %q", w.Name)
+	var prefix string
+	if w.Service != nil {
+		prefix = w.Service.EvalName() + " "
+	}
+	return prefix + suffix
+}
+
+// BodyTypeName returns the name of the user type that describes the webhook
+// request body, e.g. "OrderCreatedWebhookBody" for the webhook "order.created".
+func (w *HTTPWebhookExpr) BodyTypeName() string {
+	fields := strings.FieldsFunc(w.Name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return concat(append(fields, "Webhook", "Body")...)
+}
+
+// Validate makes sure the webhook has a name that is unique in the API and
+// that its body is an object.
+func (w *HTTPWebhookExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if strings.IndexFunc(w.Name, func(r rune) bool { return unicode.IsLetter(r) }) < 0 {
+		verr.Add(w, "webhook name must contain at least one letter")
+	}
+	if o := w.previous(); o != nil {
+		verr.Add(w, "webhook %q is already defined in %s", w.Name, o.Service.EvalName())
+	}
+	if w.Body == nil || w.Body.Type == nil || w.Body.Type == Empty {
+		verr.Add(w, "webhook body must be defined")
+	} else {
+		if !IsObject(w.Body.Type) {
+			verr.Add(w, "webhook body must be an object, got %s", w.Body.Type.Name())
+		}
+		verr.Merge(w.Body.Validate("body", w))
+	}
+	return verr
+}
+
+// Finalize makes the webhook body a user type named after the webhook so that
+// the generated code can use a dedicated type for it. The names of the user
+// types used by the body are suffixed with "WebhookBody".
+func (w *HTTPWebhookExpr) Finalize() {
+	const suffix = "WebhookBody"
+	body := DupAtt(w.Body)
+	if _, ok := body.Type.(UserType); ok {
+		renameType(body, w.BodyTypeName(), suffix)
+		w.Body = body
+		return
+	}
+	appendSuffix(body.Type, suffix)
+	w.Body = &AttributeExpr{
+		Type: &UserTypeExpr{
+			AttributeExpr: body,
+			TypeName:      w.BodyTypeName(),
+		},
+		Description:  body.Description,
+		Validation:   body.Validation,
+		UserExamples: body.UserExamples,
+	}
+}
+
+// previous returns the webhook with the same name as w defined before w in
+// the design, nil if there is none.
+func (w *HTTPWebhookExpr) previous() *HTTPWebhookExpr {
+	for _, svc := range Root.API.HTTP.Services {
+		for _, o := range svc.Webhooks {
+			if o == w {
+				return nil
+			}
+			if o.Name == w.Name {
+				return o
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestWebhook(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Webhook  string
+		TypeName string
+		Nested   string
+	}{
+		{"inline", testdata.WebhookDSL, "order.created", "OrderCreatedWebhookBody", "ItemWebhookBody"},
+		{"user-type", testdata.WebhookUserTypeDSL, "order-shipped", "OrderShippedWebhookBody", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := expr.RunDSL(t, c.DSL)
+			w := root.API.HTTP.Services[0].Webhook(c.Webhook)
+			if w == nil {
+				t.Fatalf("webhook %q not found", c.Webhook)
+			}
+			ut, ok := w.Body.Type.(expr.UserType)
+			if !ok {
+				t.Fatalf("got body type %T, expected a user type", w.Body.Type)
+			}
+			if ut.Name() != c.TypeName {
+				t.Errorf("got body type name %q, expected %q", ut.Name(), c.TypeName)
+			}
+			if c.Nested == "" {
+				return
+			}
+			items := ut.Attribute().Find("items")
+			if items == nil {
+				t.Fatal("items attribute not found")
+			}
+			if n := expr.AsArray(items.Type).ElemType.Type.Name(); n != c.Nested {
+				t.Errorf("got nested type name %q, expected %q", n, c.Nested)
+			}
+		})
+	}
+}
+
+func TestWebhookInvalid(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"no body", testdata.WebhookNoBodyDSL, "webhook body must be defined"},
+		{"array body", testdata.WebhookArrayBodyDSL, "webhook body must be an object"},
+		{"invalid name", testdata.WebhookInvalidNameDSL, "webhook name must contain at least one letter"},
+		{"duplicate", testdata.WebhookDuplicateDSL, `webhook "order.created" is already defined in service "Service"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := expr.RunInvalidDSL(t, c.DSL)
+			if !strings.Contains(err.Error(), c.Error) {
+				t.Errorf("got error %q, expected it to contain %q", err.Error(), c.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package expr
+
+import (
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// WebSocketExpr describes the websocket settings of a streaming HTTP
+	// endpoint.
+	WebSocketExpr struct {
+		// Subprotocols lists the supported subprotocols in order of
+		// preference.
+		Subprotocols []string
+		// PerMessageDeflate enables the permessage-deflate compression
+		// extension.
+		PerMessageDeflate bool
+		// PingInterval is the number of seconds between keep-alive
+		// ping messages, zero disables pings.
+		PingInterval uint
+		// MaxMessageSize is the maximum size in bytes of a message read
+		// from the connection, zero means no limit.
+		MaxMessageSize int64
+		// Endpoint is the HTTP endpoint the settings apply to.
+		Endpoint *HTTPEndpointExpr
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (ws *WebSocketExpr) EvalName() string {
+	suffix := "websocket"
+	if ws.Endpoint != nil {
+		return ws.Endpoint.EvalName() + " " + suffix
+	}
+	return suffix
+}
+
+// Validate makes sure the endpoint uses streaming.
+func (ws *WebSocketExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if ws.Endpoint != nil && !ws.Endpoint.MethodExpr.IsStreaming() {
+		verr.Add(ws, "WebSocket is set but the method does not define a streaming payload or result")
+	}
+	if ws.MaxMessageSize < 0 {
+		verr.Add(ws, "maximum message size cannot be negative")
+	}
+	return verr
+}
@@ -0,0 +1,35 @@
+package expr_test
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestWebSocketValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidWebSocketDSL, ""},
+		{"non-streaming", testdata.NonStreamingWebSocketDSL, `service "Service" HTTP endpoint "Method" websocket: WebSocket is set but the method does not define a streaming payload or result`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				root := expr.RunDSL(t, c.DSL)
+				ws := root.API.HTTP.Services[0].HTTPEndpoints[0].WebSocket
+				if ws == nil || len(ws.Subprotocols) != 1 || ws.PingInterval != 10 {
+					t.Errorf("unexpected websocket settings %+v", ws)
+				}
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package expr
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// LambdaExpr describes the AWS Lambda functions that serve the HTTP
+	// endpoints of a server behind an API Gateway HTTP API.
+	LambdaExpr struct {
+		// Server is the server served by the functions.
+		Server *ServerExpr
+		// PerMethod is true if a function is generated for each method
+		// in addition to the function that serves all the endpoints of
+		// the server.
+		PerMethod bool
+	}
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (l *LambdaExpr) EvalName() string {
+	return fmt.Sprintf("Lambda of %s", l.Server.EvalName())
+}
+
+// Validate makes sure the server serves at least one service over HTTP.
+func (l *LambdaExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	svcs := l.Server.Services
+	if len(svcs) == 0 {
+		for _, svc := range Root.Services {
+			svcs = append(svcs, svc.Name)
+		}
+	}
+	for _, svc := range svcs {
+		if Root.API.HTTP.Service(svc) != nil {
+			return verr
+		}
+	}
+	verr.Add(l, "the server must serve at least one service over HTTP")
+	return verr
+}
@@ -0,0 +1,22 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestLambda(t *testing.T) {
+	root := expr.RunDSL(t, testdata.LambdaDSL)
+	l := root.API.Servers[0].Lambda
+	if l == nil || !l.PerMethod {
+		t.Fatalf("got lambda %+v, expected per method functions", l)
+	}
+
+	err := expr.RunInvalidDSL(t, testdata.LambdaNoHTTPDSL)
+	if e := "the server must serve at least one service over HTTP"; !strings.Contains(err.Error(), e) {
+		t.Errorf("got error %q, expected it to contain %q", err.Error(), e)
+	}
+}
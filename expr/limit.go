@@ -0,0 +1,67 @@
+package expr
+
+import (
+	"time"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// ConcurrencyLimitExpr describes the maximum number of requests served
+	// concurrently by a method and how the requests that exceed it are
+	// queued.
+	ConcurrencyLimitExpr struct {
+		// Parent is the service or method expression the limit applies
+		// to.
+		Parent eval.Expression
+		// Max is the maximum number of requests served concurrently.
+		Max int
+		// QueueSize is the maximum number of requests that wait for a
+		// slot, the requests that exceed the limit are rejected
+		// immediately if zero.
+		QueueSize int
+		// QueueTimeout is the maximum duration a request waits for a
+		// slot, the requests wait until canceled if zero.
+		QueueTimeout time.Duration
+	}
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (c *ConcurrencyLimitExpr) EvalName() string {
+	var suffix string
+	if c.Parent != nil {
+		suffix = " of " + c.Parent.EvalName()
+	}
+	return "concurrency limit" + suffix
+}
+
+// Validate makes sure the limit and the queue settings are valid.
+func (c *ConcurrencyLimitExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if c.Max <= 0 {
+		verr.Add(c, "maximum number of concurrent requests must be greater than zero, got %d", c.Max)
+	}
+	if c.QueueSize < 0 {
+		verr.Add(c, "queue size must not be negative, got %d", c.QueueSize)
+	}
+	if c.QueueTimeout < 0 {
+		verr.Add(c, "queue timeout must not be negative, got %s", c.QueueTimeout)
+	}
+	if c.QueueTimeout > 0 && c.QueueSize == 0 {
+		verr.Add(c, "queue timeout %s requires a queue size greater than zero", c.QueueTimeout)
+	}
+	return verr
+}
+
+// Limit returns the concurrency limit of the method: the limit defined on the
+// method if any, the limit defined on the service otherwise. It returns nil if
+// the method is not limited.
+func (m *MethodExpr) Limit() *ConcurrencyLimitExpr {
+	if m.ConcurrencyLimit != nil {
+		return m.ConcurrencyLimit
+	}
+	if m.Service != nil {
+		return m.Service.ConcurrencyLimit
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestConcurrencyLimit(t *testing.T) {
+	root := expr.RunDSL(t, testdata.ConcurrencyLimitDSL)
+	calc := root.Services[0]
+	if l := calc.Method("add").Limit(); l == nil || l.Max != 100 || l.QueueSize != 0 {
+		t.Errorf("got limit %+v for add, expected service limit", l)
+	}
+	l := calc.Method("report").Limit()
+	if l == nil || l.Max != 4 || l.QueueSize != 16 || l.QueueTimeout != 2*time.Second {
+		t.Errorf("got limit %+v for report, expected method limit", l)
+	}
+	if l := root.Services[1].Method("get").Limit(); l != nil {
+		t.Errorf("got limit %+v for unlimited method, expected nil", l)
+	}
+}
+
+func TestConcurrencyLimitInvalid(t *testing.T) {
+	err := expr.RunInvalidDSL(t, testdata.ConcurrencyLimitInvalidDSL)
+	for _, e := range []string{
+		`maximum number of concurrent requests must be greater than zero, got 0`,
+		`queue size must not be negative, got -1`,
+		`queue timeout 1s requires a queue size greater than zero`,
+	} {
+		if !strings.Contains(err.Error(), e) {
+			t.Errorf("got error %q, expected it to contain %q", err.Error(), e)
+		}
+	}
+	err = expr.RunInvalidDSL(t, testdata.ConcurrencyLimitInvalidTimeoutDSL)
+	if !strings.Contains(err.Error(), `invalid queue timeout "two seconds"`) {
+		t.Errorf("got error %q, expected invalid timeout", err.Error())
+	}
+}
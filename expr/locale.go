@@ -0,0 +1,187 @@
+package expr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Locale is the locale of the descriptions used by the code generators. The
+// descriptions defined for other locales are ignored. Descriptions defined
+// without locale are used when Locale is empty or when there is no description
+// for Locale. Locale is set by the "goa gen" command --locale flag before the
+// DSL runs.
+var Locale string
+
+// LocalizedDescription lists the descriptions of a design element defined in
+// multiple locales.
+type LocalizedDescription struct {
+	// Default is the description defined without locale if any.
+	Default string
+	// Locales lists the locales of the descriptions in order of
+	// definition.
+	Locales []string
+	// Descriptions maps the locales to the descriptions.
+	Descriptions map[string]string
+	// name is the name of the element used when it is not reachable from
+	// the design root.
+	name string
+}
+
+// Localize records the description text defined for the given locale in the
+// description field desc. locale may be empty for the default description.
+// The field is set to the description for Locale if any, the default
+// description otherwise or the first localized description if there is no
+// default. name is the name of the described element used in error messages
+// and in LocalizedDescriptions if it cannot be computed from the design.
+func (r *RootExpr) Localize(desc *string, name, locale, text string) {
+	if r.localized == nil {
+		r.localized = make(map[*string]*LocalizedDescription)
+	}
+	ld, ok := r.localized[desc]
+	if !ok {
+		ld = &LocalizedDescription{
+			Default:      *desc,
+			Descriptions: make(map[string]string),
+			name:         name,
+		}
+		r.localized[desc] = ld
+	}
+	if locale == "" {
+		ld.Default = text
+	} else {
+		if _, ok := ld.Descriptions[locale]; !ok {
+			ld.Locales = append(ld.Locales, locale)
+		}
+		ld.Descriptions[locale] = text
+	}
+	*desc = ld.resolve()
+}
+
+// IsLocalized returns true if a localized description was recorded for the
+// given description field.
+func (r *RootExpr) IsLocalized(desc *string) bool {
+	_, ok := r.localized[desc]
+	return ok
+}
+
+// LocalizedDescriptions returns the localized descriptions of the design
+// indexed by the names of the elements they describe, e.g. "service
+// \"cellar\" method \"list\"".
+func (r *RootExpr) LocalizedDescriptions() map[string]*LocalizedDescription {
+	if len(r.localized) == 0 {
+		return nil
+	}
+	res := make(map[string]*LocalizedDescription, len(r.localized))
+	seen := make(map[*string]bool)
+	add := func(desc *string, name string) {
+		if ld, ok := r.localized[desc]; ok && !seen[desc] {
+			seen[desc] = true
+			res[name] = ld
+		}
+	}
+	var walkAttr func(*AttributeExpr, string)
+	walkAttr = func(att *AttributeExpr, name string) {
+		if att == nil {
+			return
+		}
+		add(&att.Description, name)
+		if _, ok := att.Type.(UserType); ok {
+			return
+		}
+		if obj := AsObject(att.Type); obj != nil {
+			for _, nat := range *obj {
+				walkAttr(nat.Attribute, fmt.Sprintf("%s attribute %q", name, nat.Name))
+			}
+		}
+	}
+	if a := r.API; a != nil {
+		add(&a.Description, "API")
+		if a.Docs != nil {
+			add(&a.Docs.Description, "API docs")
+		}
+		for _, t := range a.AllTags() {
+			add(&t.Description, fmt.Sprintf("tag %q", t.Name))
+		}
+		for _, s := range a.Servers {
+			sn := fmt.Sprintf("server %q", s.Name)
+			add(&s.Description, sn)
+			for _, h := range s.Hosts {
+				add(&h.Description, fmt.Sprintf("%s host %q", sn, h.Name))
+			}
+		}
+	}
+	for _, s := range r.Schemes {
+		add(&s.Description, fmt.Sprintf("security scheme %q", s.SchemeName))
+	}
+	for _, ut := range append(r.Types, r.ResultTypes...) {
+		walkAttr(ut.Attribute(), fmt.Sprintf("type %q", ut.Name()))
+	}
+	for _, s := range r.Services {
+		sn := fmt.Sprintf("service %q", s.Name)
+		add(&s.Description, sn)
+		for _, e := range s.Errors {
+			walkAttr(e.AttributeExpr, fmt.Sprintf("%s error %q", sn, e.Name))
+		}
+		for _, m := range s.Methods {
+			mn := fmt.Sprintf("%s method %q", sn, m.Name)
+			add(&m.Description, mn)
+			walkAttr(m.Payload, mn+" payload")
+			walkAttr(m.Result, mn+" result")
+			for _, e := range m.Errors {
+				walkAttr(e.AttributeExpr, fmt.Sprintf("%s error %q", mn, e.Name))
+			}
+		}
+	}
+	if r.API != nil && r.API.HTTP != nil {
+		for _, s := range r.API.HTTP.Services {
+			for _, e := range s.HTTPEndpoints {
+				mn := fmt.Sprintf("service %q method %q", s.Name(), e.Name())
+				for _, resp := range e.Responses {
+					add(&resp.Description, fmt.Sprintf("%s HTTP response %d", mn, resp.StatusCode))
+				}
+				for _, er := range e.HTTPErrors {
+					add(&er.Response.Description, fmt.Sprintf("%s HTTP error %q response", mn, er.Name))
+				}
+			}
+		}
+	}
+
+	// Elements that are not reachable from the root, e.g. examples, are
+	// named after the expression that defined them.
+	var rest []*LocalizedDescription
+	for desc, ld := range r.localized {
+		if !seen[desc] {
+			rest = append(rest, ld)
+		}
+	}
+	sort.Slice(rest, func(i, j int) bool {
+		if rest[i].name != rest[j].name {
+			return rest[i].name < rest[j].name
+		}
+		if rest[i].Default != rest[j].Default {
+			return rest[i].Default < rest[j].Default
+		}
+		return strings.Join(rest[i].Locales, ",") < strings.Join(rest[j].Locales, ",")
+	})
+	for _, ld := range rest {
+		name := ld.name
+		for i := 2; res[name] != nil; i++ {
+			name = fmt.Sprintf("%s (%d)", ld.name, i)
+		}
+		res[name] = ld
+	}
+	return res
+}
+
+// resolve returns the description for Locale if any, the default description
+// otherwise or the first localized description if there is no default.
+func (ld *LocalizedDescription) resolve() string {
+	if d, ok := ld.Descriptions[Locale]; ok && Locale != "" {
+		return d
+	}
+	if ld.Default != "" || len(ld.Locales) == 0 {
+		return ld.Default
+	}
+	return ld.Descriptions[ld.Locales[0]]
+}
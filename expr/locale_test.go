@@ -0,0 +1,64 @@
+package expr_test
+
+import (
+	"reflect"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestLocalizedDescriptions(t *testing.T) {
+	cases := []struct {
+		Name      string
+		Locale    string
+		Service   string
+		Bottle    string
+		Attribute string
+	}{
+		{"no-locale", "", "The cellar service manages bottles.", "A bottle of wine.", "Name of bottle"},
+		{"ja", "ja", "The cellar service manages bottles.", "ワインのボトル。", "ボトルの名前"},
+		{"fr", "fr", "Le service cellar gère les bouteilles.", "A bottle of wine.", "Name of bottle"},
+		{"unknown", "de", "The cellar service manages bottles.", "A bottle of wine.", "Name of bottle"},
+	}
+	defer func() { expr.Locale = "" }()
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			expr.Locale = c.Locale
+			root := expr.RunDSL(t, testdata.LocalizedDescriptionsDSL)
+			if d := root.Services[0].Description; d != c.Service {
+				t.Errorf("invalid service description: got %q, expected %q", d, c.Service)
+			}
+			bottle := root.UserType("Bottle").Attribute()
+			if d := bottle.Description; d != c.Bottle {
+				t.Errorf("invalid type description: got %q, expected %q", d, c.Bottle)
+			}
+			if d := bottle.Find("name").Description; d != c.Attribute {
+				t.Errorf("invalid attribute description: got %q, expected %q", d, c.Attribute)
+			}
+			if d := root.Services[0].Methods[0].Description; d != "Show a bottle." {
+				t.Errorf("invalid method description: got %q, expected %q", d, "Show a bottle.")
+			}
+		})
+	}
+}
+
+func TestRootExprLocalizedDescriptions(t *testing.T) {
+	root := expr.RunDSL(t, testdata.LocalizedDescriptionsDSL)
+	lds := root.LocalizedDescriptions()
+	expected := map[string]map[string]string{
+		`service "cellar"`:               {"en": "The cellar service manages bottles.", "fr": "Le service cellar gère les bouteilles."},
+		`type "Bottle"`:                  {"en": "A bottle of wine.", "ja": "ワインのボトル。"},
+		`type "Bottle" attribute "name"`: {"ja": "ボトルの名前"},
+	}
+	actual := make(map[string]map[string]string, len(lds))
+	for n, ld := range lds {
+		actual[n] = ld.Descriptions
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("invalid localized descriptions: got %v, expected %v", actual, expected)
+	}
+	if d := lds[`type "Bottle" attribute "name"`].Default; d != "Name of bottle" {
+		t.Errorf("invalid default description: got %q, expected %q", d, "Name of bottle")
+	}
+}
@@ -29,8 +29,13 @@ type (
 		// Requirements contains the security requirements for the
 		// method. One requirement is composed of potentially multiple
 		// schemes. Incoming requests must validate at least one
-		// requirement to be authorized.
+		// requirement to be authorized. The requirements are tried in
+		// order.
 		Requirements []*SecurityExpr
+		// OptionalSecurity indicates that requests that carry none of
+		// the credentials of the requirements are authorized
+		// anonymously.
+		OptionalSecurity bool
 		// Service that owns method.
 		Service *ServiceExpr
 		// Meta is an arbitrary set of key/value pairs, see dsl.Meta
@@ -40,6 +45,18 @@ type (
 		Stream StreamKind
 		// StreamingPayload is the payload sent across the stream.
 		StreamingPayload *AttributeExpr
+		// Cache describes the memoization of the method results if
+		// any.
+		Cache *CacheExpr
+		// ConcurrencyLimit describes the maximum number of requests
+		// served concurrently by the method if any, it overrides the
+		// service limit.
+		ConcurrencyLimit *ConcurrencyLimitExpr
+		// Async is true if the method starts a job and returns its
+		// status, see dsl.Async.
+		Async bool
+		// Events lists the events emitted by the method.
+		Events []*EventExpr
 	}
 )
 
@@ -107,6 +124,19 @@ func (m *MethodExpr) Validate() error {
 	} else if len(m.Service.Requirements) > 0 {
 		requirements = m.Service.Requirements
 	}
+	if m.OptionalSecurity {
+		optional := len(requirements) > 0
+		for _, r := range requirements {
+			for _, s := range r.Schemes {
+				if s.Kind == NoKind {
+					optional = false
+				}
+			}
+		}
+		if !optional {
+			verr.Add(m, "Optional is used but method %q of service %q has no security requirement", m.Name, m.Service.Name)
+		}
+	}
 	for _, r := range requirements {
 		for _, s := range r.Schemes {
 			verr.Merge(s.Validate())
@@ -130,17 +160,21 @@ func (m *MethodExpr) Validate() error {
 				if !hasTag(m.Payload, "security:accesstoken") {
 					verr.Add(m, "payload of method %q of service %q does not define a OAuth2 access token attribute, use AccessToken to define one", m.Name, m.Service.Name)
 				}
+			case MTLSKind:
+				if !hasTag(m.Payload, "security:clientcert") {
+					verr.Add(m, "payload of method %q of service %q does not define a client certificate attribute, use ClientCertificate to define one", m.Name, m.Service.Name)
+				} else if att := m.Payload.Find(TaggedAttribute(m.Payload, "security:clientcert")); att != nil && att.Type != String {
+					verr.Add(m, "client certificate attribute of method %q of service %q must be a String", m.Name, m.Service.Name)
+				}
 			}
 		}
 		for _, scope := range r.Scopes {
 			found := false
 			for _, s := range r.Schemes {
-				if s.Kind == BasicAuthKind || s.Kind == APIKeyKind || s.Kind == OAuth2Kind || s.Kind == JWTKind {
-					for _, se := range s.Scopes {
-						if se.Name == scope {
-							found = true
-							break
-						}
+				if s.Kind == BasicAuthKind || s.Kind == APIKeyKind || s.Kind == OAuth2Kind || s.Kind == JWTKind || s.Kind == MTLSKind || s.Kind == SignatureKind {
+					if s.Supports(scope) {
+						found = true
+						break
 					}
 				}
 			}
@@ -149,6 +183,26 @@ func (m *MethodExpr) Validate() error {
 			}
 		}
 	}
+	for _, nat := range m.AuditResources() {
+		if !IsPrimitive(nat.Attribute.Type) {
+			verr.Add(m, "audit:resource attribute %q of method %q of service %q must be a primitive", nat.Name, m.Name, m.Service.Name)
+		}
+	}
+	if vals, ok := m.Meta["sdk:paginate"]; ok {
+		verr.Merge(m.validatePagination(vals))
+	}
+	if m.Cache != nil {
+		verr.Merge(m.Cache.Validate())
+	}
+	if m.ConcurrencyLimit != nil {
+		verr.Merge(m.ConcurrencyLimit.Validate())
+	}
+	if m.Async {
+		verr.Merge(m.validateAsync())
+	}
+	for _, e := range m.Events {
+		verr.Merge(e.Validate())
+	}
 	if m.StreamingPayload.Type != Empty {
 		verr.Merge(m.StreamingPayload.Validate("streaming_payload", m))
 	}
@@ -183,6 +237,44 @@ func (m *MethodExpr) Validate() error {
 	return verr
 }
 
+// validatePagination validates the values of the "sdk:paginate" meta.
+func (m *MethodExpr) validatePagination(vals []string) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if len(vals) != 3 {
+		verr.Add(m, "sdk:paginate meta of method %q of service %q must define the cursor, next cursor and items attributes", m.Name, m.Service.Name)
+		return verr
+	}
+	if m.IsStreaming() {
+		verr.Add(m, "streaming method %q of service %q cannot be paginated", m.Name, m.Service.Name)
+		return verr
+	}
+	cursor, next, items := vals[0], vals[1], vals[2]
+	var catt, natt *AttributeExpr
+	if IsObject(m.Payload.Type) {
+		catt = m.Payload.Find(cursor)
+	}
+	if IsObject(m.Result.Type) {
+		natt = m.Result.Find(next)
+		if iatt := m.Result.Find(items); iatt == nil || !IsArray(iatt.Type) {
+			verr.Add(m, "result of method %q of service %q must define the array attribute %q listed by sdk:paginate", m.Name, m.Service.Name, items)
+		}
+	}
+	if catt == nil || !isCursor(catt.Type) {
+		verr.Add(m, "payload of method %q of service %q must define the String or Int attribute %q listed by sdk:paginate", m.Name, m.Service.Name, cursor)
+	}
+	if natt == nil || !isCursor(natt.Type) {
+		verr.Add(m, "result of method %q of service %q must define the String or Int attribute %q listed by sdk:paginate", m.Name, m.Service.Name, next)
+	} else if catt != nil && catt.Type != natt.Type {
+		verr.Add(m, "attributes %q and %q listed by sdk:paginate in method %q of service %q must have the same type", cursor, next, m.Name, m.Service.Name)
+	}
+	return verr
+}
+
+// isCursor returns true if dt can be used to identify pages.
+func isCursor(dt DataType) bool {
+	return dt == String || dt == Int || dt == Int32 || dt == Int64 || dt == UInt || dt == UInt32 || dt == UInt64
+}
+
 // hasTag is a helper function that traverses the given attribute and all its
 // bases recursively looking for an attribute with the given tag meta. This
 // recursion is only needed for attributes that have not been finalized yet.
@@ -228,6 +320,9 @@ func (m *MethodExpr) Finalize() {
 	for _, e := range m.Errors {
 		e.Finalize()
 	}
+	for _, e := range m.Events {
+		e.Finalize()
+	}
 
 	// Inherit security requirements
 	noreq := false
@@ -245,8 +340,10 @@ func (m *MethodExpr) Finalize() {
 	}
 	if noreq {
 		m.Requirements = nil
+		m.OptionalSecurity = false
 	} else if len(m.Requirements) == 0 && len(m.Service.Requirements) > 0 {
 		m.Requirements = copyReqs(m.Service.Requirements)
+		m.OptionalSecurity = m.OptionalSecurity || m.Service.OptionalSecurity
 	}
 
 }
@@ -261,6 +358,83 @@ func (m *MethodExpr) IsPayloadStreaming() bool {
 	return m.Stream == ClientStreamKind || m.Stream == BidirectionalStreamKind
 }
 
+// IsAudited returns true if the requests made to the method are audited, that
+// is if the method or its service define the "audit" meta or if the method
+// payload defines attributes with the "audit:resource" meta.
+func (m *MethodExpr) IsAudited() bool {
+	if _, ok := m.Meta["audit"]; ok {
+		return true
+	}
+	if _, ok := m.Service.Meta["audit"]; ok {
+		return true
+	}
+	return len(m.AuditResources()) > 0
+}
+
+// AuditResources returns the payload attributes that identify the resources
+// accessed by the method, that is the attributes that define the
+// "audit:resource" meta.
+func (m *MethodExpr) AuditResources() []*NamedAttributeExpr {
+	if m.Payload == nil {
+		return nil
+	}
+	obj := AsObject(m.Payload.Type)
+	if obj == nil {
+		return nil
+	}
+	var res []*NamedAttributeExpr
+	for _, nat := range *obj {
+		if _, ok := nat.Attribute.Meta["audit:resource"]; ok {
+			res = append(res, nat)
+		}
+	}
+	return res
+}
+
+// LogFields returns the payload attributes whose values are added to the log
+// records of the requests made to the method, that is the attributes that
+// define the "log:field" meta.
+func (m *MethodExpr) LogFields() []*NamedAttributeExpr {
+	if m.Payload == nil {
+		return nil
+	}
+	obj := AsObject(m.Payload.Type)
+	if obj == nil {
+		return nil
+	}
+	var res []*NamedAttributeExpr
+	for _, nat := range *obj {
+		if _, ok := nat.Attribute.Meta["log:field"]; ok {
+			res = append(res, nat)
+		}
+	}
+	return res
+}
+
+// Pagination returns the names of the attributes listed by the "sdk:paginate"
+// meta of the method: the payload attribute that identifies the requested
+// page, the result attribute that identifies the next page and the result
+// attribute that lists the items of the page. It returns empty strings if the
+// method does not define the meta.
+func (m *MethodExpr) Pagination() (cursor, next, items string) {
+	vals := m.Meta["sdk:paginate"]
+	if len(vals) != 3 {
+		return "", "", ""
+	}
+	return vals[0], vals[1], vals[2]
+}
+
+// RequiresAuthorization returns true if the authorization policies must be
+// evaluated for the requests made to the method once they are authenticated,
+// that is if the method or its service defines the "authorize" meta.
+func (m *MethodExpr) RequiresAuthorization() bool {
+	if _, ok := m.Meta["authorize"]; ok {
+		return true
+	}
+	_, ok := m.Service.Meta["authorize"]
+	return ok
+}
+
 // helper function that duplicates just enough of a security expression so that
 // its scheme names can be overridden without affecting the original.
 func copyReqs(reqs []*SecurityExpr) []*SecurityExpr {
@@ -278,6 +452,11 @@ func copyReqs(reqs []*SecurityExpr) []*SecurityExpr {
 				Scopes:      sch.Scopes,
 				Flows:       sch.Flows,
 				Meta:        sch.Meta,
+
+				KeyLocations:     sch.KeyLocations,
+				OpenIDConnectURL: sch.OpenIDConnectURL,
+				ClockSkew:        sch.ClockSkew,
+				ReplayWindow:     sch.ReplayWindow,
 			}
 		}
 		req2.Schemes = schs
@@ -3,6 +3,7 @@ package expr_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"goa.design/goa/v3/expr"
 	"goa.design/goa/v3/expr/testdata"
@@ -26,6 +27,24 @@ service "InvalidSecuritySchemesService" method "InheritedSecureMethod": payload
 service "InvalidSecuritySchemesService" method "InheritedSecureMethod": payload of method "InheritedSecureMethod" of service "InvalidSecuritySchemesService" does not define an API key attribute, use APIKey to define one
 service "InvalidSecuritySchemesService" method "InheritedSecureMethod": security scope "not:found" not found in any of the security schemes.`,
 		},
+		{"invalid-mtls", testdata.InvalidMTLSDSL,
+			`service "InvalidMTLSService" method "MissingCertificate": payload of method "MissingCertificate" of service "InvalidMTLSService" does not define a client certificate attribute, use ClientCertificate to define one
+service "InvalidMTLSService" method "InvalidCertificate": client certificate attribute of method "InvalidCertificate" of service "InvalidMTLSService" must be a String`,
+		},
+		{"invalid-optional-security", testdata.InvalidOptionalSecurityDSL,
+			`service "InvalidOptionalSecurityService2": Optional is used but service "InvalidOptionalSecurityService2" has no security requirement
+service "InvalidOptionalSecurityService" method "Unsecured": Optional is used but method "Unsecured" of service "InvalidOptionalSecurityService" has no security requirement
+service "InvalidOptionalSecurityService" method "NoSecurity": Optional is used but method "NoSecurity" of service "InvalidOptionalSecurityService" has no security requirement`,
+		},
+		{"invalid-audit-resource", testdata.InvalidAuditResourceDSL,
+			`service "InvalidAuditResourceService" method "Method": audit:resource attribute "ids" of method "Method" of service "InvalidAuditResourceService" must be a primitive`,
+		},
+		{"invalid-pagination", testdata.InvalidPaginationDSL,
+			`service "InvalidPaginationService" method "MissingAttributes": result of method "MissingAttributes" of service "InvalidPaginationService" must define the array attribute "items" listed by sdk:paginate
+service "InvalidPaginationService" method "MissingAttributes": result of method "MissingAttributes" of service "InvalidPaginationService" must define the String or Int attribute "next" listed by sdk:paginate
+service "InvalidPaginationService" method "MismatchedTypes": attributes "page" and "next" listed by sdk:paginate in method "MismatchedTypes" of service "InvalidPaginationService" must have the same type
+service "InvalidPaginationService" method "MissingValues": sdk:paginate meta of method "MissingValues" of service "InvalidPaginationService" must define the cursor, next cursor and items attributes`,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.Name, func(t *testing.T) {
@@ -37,6 +56,49 @@ service "InvalidSecuritySchemesService" method "InheritedSecureMethod": security
 	}
 }
 
+func TestMethodExprOptionalSecurity(t *testing.T) {
+	cases := []struct {
+		Name         string
+		Requirements int
+		Expected     bool
+	}{
+		{"Inherited", 2, true},
+		{"Overridden", 1, false},
+		{"Unsecured", 0, false},
+	}
+	expr.RunDSL(t, testdata.OptionalSecurityDSL)
+	svc := expr.Root.Service("OptionalSecurityService")
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			m := svc.Method(tc.Name)
+			if len(m.Requirements) != tc.Requirements {
+				t.Errorf("got %d requirements, expected %d", len(m.Requirements), tc.Requirements)
+			}
+			if m.OptionalSecurity != tc.Expected {
+				t.Errorf("got optional security %v, expected %v", m.OptionalSecurity, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestMethodExprInheritedSchemeSettings(t *testing.T) {
+	expr.RunDSL(t, testdata.InheritedSchemeSettingsDSL)
+	m := expr.Root.Service("InheritedSchemeSettingsService").Method("Method")
+	if len(m.Requirements) != 1 || len(m.Requirements[0].Schemes) != 2 {
+		t.Fatalf("got %d requirements, expected 1 with 2 schemes", len(m.Requirements))
+	}
+	signed, oidc := m.Requirements[0].Schemes[0], m.Requirements[0].Schemes[1]
+	if signed.ClockSkew != time.Minute {
+		t.Errorf("got clock skew %s, expected %s", signed.ClockSkew, time.Minute)
+	}
+	if signed.ReplayWindow != 10*time.Minute {
+		t.Errorf("got replay window %s, expected %s", signed.ReplayWindow, 10*time.Minute)
+	}
+	if oidc.OpenIDConnectURL == "" {
+		t.Error("got empty OpenID Connect URL")
+	}
+}
+
 func TestMethodExprError(t *testing.T) {
 	var (
 		errorFoo = &expr.ErrorExpr{
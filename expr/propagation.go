@@ -0,0 +1,170 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// PropagationExpr describes the request context values that the
+	// generated servers record from incoming requests and that the
+	// generated clients forward in outgoing requests. Propagation applies to
+	// both the HTTP and gRPC transports: the values are HTTP headers or gRPC
+	// metadata.
+	PropagationExpr struct {
+		// Headers lists the names of the propagated headers.
+		Headers []string
+		// Deadline indicates whether the request deadline is
+		// propagated. HTTP requests carry the deadline in the
+		// "Goa-Timeout" header, gRPC propagates deadlines natively.
+		Deadline bool
+		// Parent is the API or service expression that defines the
+		// propagation.
+		Parent eval.Expression
+	}
+
+	// ContextValueExpr describes a typed request context value extracted
+	// from a HTTP header or gRPC metadata by the generated servers and
+	// forwarded by the generated clients.
+	ContextValueExpr struct {
+		// Name is the name of the context value.
+		Name string
+		// Header is the name of the HTTP header or gRPC metadata that
+		// carries the value.
+		Header string
+		// Attribute defines the type, description, default value and
+		// examples of the context value.
+		Attribute *AttributeExpr
+	}
+)
+
+// DefaultPropagatedHeaders lists the headers propagated when Propagate is
+// called without arguments: the request ID and the W3C trace context headers.
+var DefaultPropagatedHeaders = []string{"X-Request-Id", "traceparent", "tracestate"}
+
+// EvalName returns the generic definition name used in error messages.
+func (p *PropagationExpr) EvalName() string {
+	suffix := "propagation"
+	if p.Parent != nil {
+		return p.Parent.EvalName() + " " + suffix
+	}
+	return suffix
+}
+
+// Validate makes sure the header names are valid and listed only once.
+func (p *PropagationExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	seen := make(map[string]bool)
+	for _, h := range p.Headers {
+		if !isHeaderToken(h) {
+			verr.Add(p, "invalid header name %q", h)
+			continue
+		}
+		n := strings.ToLower(h)
+		if seen[n] {
+			verr.Add(p, "header %q is listed more than once", h)
+		}
+		seen[n] = true
+	}
+	return verr
+}
+
+// EvalName returns the generic definition name used in error messages.
+func (c *ContextValueExpr) EvalName() string {
+	return fmt.Sprintf("context value %q", c.Name)
+}
+
+// Validate makes sure the header name is valid and the context value type is
+// a primitive type that can be read from a header.
+func (c *ContextValueExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if !isHeaderToken(c.Header) {
+		verr.Add(c, "invalid header name %q", c.Header)
+	}
+	switch c.Attribute.Type {
+	case String, Boolean, Int, Int32, Int64, UInt, UInt32, UInt64, Float32, Float64:
+	default:
+		verr.Add(c, "type must be a primitive type other than Bytes and Any, got %s", c.Attribute.Type.Name())
+	}
+	return verr
+}
+
+// validateContextValues makes sure the names and headers of the given context
+// values are unique and that each context value is valid.
+func validateContextValues(a *APIExpr) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	names := make(map[string]bool)
+	headers := make(map[string]bool)
+	for _, c := range a.ContextValues {
+		verr.Merge(c.Validate())
+		if names[c.Name] {
+			verr.Add(c, "context value is defined more than once")
+		}
+		names[c.Name] = true
+		h := strings.ToLower(c.Header)
+		if headers[h] {
+			verr.Add(c, "header %q is used by more than one context value", c.Header)
+		}
+		headers[h] = true
+	}
+	return verr
+}
+
+// Propagate returns the propagation settings that apply to the service methods,
+// nil if there are none. Settings defined on the service override the ones
+// defined on the API. The headers of the API context values and the header
+// that carries the tenant are always propagated.
+func (s *ServiceExpr) Propagate() *PropagationExpr {
+	if Root.API == nil {
+		return s.Propagation
+	}
+	p := s.Propagation
+	if p == nil {
+		p = Root.API.Propagation
+	}
+	headers := make([]string, 0, len(Root.API.ContextValues)+1)
+	for _, c := range Root.API.ContextValues {
+		headers = append(headers, c.Header)
+	}
+	if Root.API.Tenant != nil {
+		headers = append(headers, Root.API.Tenant.Header)
+	}
+	if len(headers) == 0 {
+		return p
+	}
+	res := &PropagationExpr{Parent: s}
+	seen := make(map[string]bool)
+	if p != nil {
+		res.Headers = append(res.Headers, p.Headers...)
+		res.Deadline = p.Deadline
+		res.Parent = p.Parent
+		for _, h := range p.Headers {
+			seen[strings.ToLower(h)] = true
+		}
+	}
+	for _, h := range headers {
+		if !seen[strings.ToLower(h)] {
+			res.Headers = append(res.Headers, h)
+		}
+	}
+	return res
+}
+
+// isHeaderToken returns true if name is a valid HTTP header name, see RFC 7230
+// section 3.2.6.
+func isHeaderToken(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", r):
+		default:
+			return false
+		}
+	}
+	return true
+}
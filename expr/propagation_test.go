@@ -0,0 +1,85 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestPropagation(t *testing.T) {
+	root := expr.RunDSL(t, testdata.PropagationDSL)
+	cases := []struct {
+		Name     string
+		Service  string
+		Headers  string
+		Deadline bool
+	}{
+		{"inherited", "Inherited", "X-Request-Id,traceparent,tracestate", true},
+		{"overridden", "Overridden", "X-Tenant", false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			p := root.Service(c.Service).Propagate()
+			if p == nil {
+				t.Fatal("got no propagation")
+			}
+			if got := strings.Join(p.Headers, ","); got != c.Headers {
+				t.Errorf("got headers %q, expected %q", got, c.Headers)
+			}
+			if p.Deadline != c.Deadline {
+				t.Errorf("got deadline %v, expected %v", p.Deadline, c.Deadline)
+			}
+		})
+	}
+}
+
+func TestPropagationInvalid(t *testing.T) {
+	err := expr.RunInvalidDSL(t, testdata.PropagationInvalidDSL)
+	for _, e := range []string{
+		`header "x-tenant" is listed more than once`,
+		`invalid header name "Bad Header"`,
+	} {
+		if !strings.Contains(err.Error(), e) {
+			t.Errorf("got error %q, expected it to contain %q", err.Error(), e)
+		}
+	}
+}
+
+func TestContextValuesPropagation(t *testing.T) {
+	root := expr.RunDSL(t, testdata.ContextValuesDSL)
+	cases := []struct {
+		Name    string
+		Service string
+		Headers string
+	}{
+		{"inherited", "Inherited", "X-Request-Id,X-Tenant"},
+		{"overridden", "Overridden", "traceparent,X-Tenant,x-request-id"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			p := root.Service(c.Service).Propagate()
+			if p == nil {
+				t.Fatal("got no propagation")
+			}
+			if got := strings.Join(p.Headers, ","); got != c.Headers {
+				t.Errorf("got headers %q, expected %q", got, c.Headers)
+			}
+		})
+	}
+}
+
+func TestContextValuesInvalid(t *testing.T) {
+	err := expr.RunInvalidDSL(t, testdata.ContextValuesInvalidDSL)
+	for _, e := range []string{
+		`context value "tenant": context value is defined more than once`,
+		`header "x-tenant" is used by more than one context value`,
+		`invalid header name "Bad Header"`,
+		`type must be a primitive type other than Bytes and Any, got bytes`,
+	} {
+		if !strings.Contains(err.Error(), e) {
+			t.Errorf("got error %q, expected it to contain %q", err.Error(), e)
+		}
+	}
+}
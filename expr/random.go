@@ -19,6 +19,8 @@ type Random struct {
 	Seen  map[string]*interface{}
 	faker *faker.Faker
 	rand  *rand.Rand
+	// root is the seed of the generator r was derived from, see Derive.
+	root string
 }
 
 // NewRandom returns a random value generator seeded from the given string value.
@@ -37,9 +39,25 @@ func NewRandom(seed string) *Random {
 		Seed:  seed,
 		faker: faker,
 		rand:  ran,
+		root:  seed,
 	}
 }
 
+// Derive returns a random value generator seeded from the seed r was created
+// with and the given key that shares the user types processed by r. Deriving
+// a generator per user type makes the examples of a type independent of the
+// order in which the types are processed so that adding a type to a design
+// does not change the examples of the others.
+func (r *Random) Derive(key string) *Random {
+	d := NewRandom(r.root + "/" + key)
+	d.root = r.root
+	if r.Seen == nil {
+		r.Seen = make(map[string]*interface{})
+	}
+	d.Seen = r.Seen
+	return d
+}
+
 // Int produces a random integer.
 func (r *Random) Int() int {
 	return r.rand.Int()
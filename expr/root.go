@@ -32,6 +32,9 @@ type (
 		Creations []*TypeMap
 		// Schemes list the registered security schemes.
 		Schemes []*SchemeExpr
+		// localized records the descriptions defined in multiple
+		// locales indexed by the description field they apply to.
+		localized map[*string]*LocalizedDescription
 	}
 
 	// MetaExpr is a set of key/value pairs
@@ -104,6 +107,7 @@ func (r *RootExpr) WalkSets(walk eval.SetWalker) {
 	})
 	var httpepts eval.ExpressionSet
 	var httpsvrs eval.ExpressionSet
+	var httpwhs eval.ExpressionSet
 	for i, svc := range r.API.HTTP.Services {
 		httpsvcs[i] = svc
 		for _, e := range svc.HTTPEndpoints {
@@ -112,11 +116,15 @@ func (r *RootExpr) WalkSets(walk eval.SetWalker) {
 		for _, s := range svc.FileServers {
 			httpsvrs = append(httpsvrs, s)
 		}
+		for _, w := range svc.Webhooks {
+			httpwhs = append(httpwhs, w)
+		}
 	}
 	walk(eval.ExpressionSet{r.API.HTTP})
 	walk(httpsvcs)
 	walk(httpepts)
 	walk(httpsvrs)
+	walk(httpwhs)
 
 	// GRPC services and endpoints
 	grpcsvcs := make(eval.ExpressionSet, len(r.API.GRPC.Services))
@@ -206,14 +214,7 @@ func (r *RootExpr) HTTPService(name string) *HTTPServiceExpr {
 // HTTPServiceFor creates a new or returns the existing HTTP service definition
 // for the given service.
 func (r *RootExpr) HTTPServiceFor(s *ServiceExpr) *HTTPServiceExpr {
-	if res := r.HTTPService(s.Name); res != nil {
-		return res
-	}
-	res := &HTTPServiceExpr{
-		ServiceExpr: s,
-	}
-	r.API.HTTP.Services = append(r.API.HTTP.Services, res)
-	return res
+	return r.API.HTTP.ServiceFor(s)
 }
 
 // EvalName is the name of the DSL.
@@ -226,6 +227,17 @@ func (r *RootExpr) Validate() error {
 	var verr eval.ValidationErrors
 	if r.API == nil {
 		verr.Add(r, "Missing API declaration")
+	} else {
+		if r.API.Propagation != nil {
+			verr.Merge(r.API.Propagation.Validate())
+		}
+		verr.Merge(validateContextValues(r.API))
+		if r.API.Tenant != nil {
+			verr.Merge(r.API.Tenant.Validate())
+		}
+		if r.API.HealthCheck != nil {
+			verr.Merge(r.API.HealthCheck.Validate())
+		}
 	}
 	return &verr
 }
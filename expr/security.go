@@ -3,8 +3,11 @@ package expr
 import (
 	"fmt"
 	"net/url"
+	"strings"
+	"time"
 
 	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/security"
 )
 
 // SchemeKind is a type of security scheme.
@@ -22,6 +25,12 @@ const (
 	JWTKind
 	// NoKind means to have no security for this endpoint.
 	NoKind
+	// MTLSKind means a "mutualTLS" security scheme where clients
+	// authenticate with a X.509 certificate.
+	MTLSKind
+	// SignatureKind means a request signature security scheme where
+	// clients sign the requests with a secret shared with the server.
+	SignatureKind
 )
 
 // FlowKind is a type of OAuth2 flow.
@@ -59,16 +68,34 @@ type (
 		SchemeName string
 		// Description describes the security scheme e.g. "Google OAuth2"
 		Description string
-		// In determines the location of the API key, one of "header" or
-		// "query".
+		// In determines the location of the API key, one of "header",
+		// "query" or "cookie".
 		In string
 		// Name refers to a header or parameter name, based on In's
 		// value.
 		Name string
-		// Scopes lists the Basic, APIKey, JWT or OAuth2 scopes.
+		// KeyLocations lists the locations of the key of APIKey schemes
+		// that accept the key from more than one location in order of
+		// priority.
+		KeyLocations []*KeyLocationExpr
+		// Scopes lists the Basic, APIKey, JWT, OAuth2, MTLS or Signature
+		// scopes.
 		Scopes []*ScopeExpr
 		// Flows determine the oauth2 flows supported by this scheme.
 		Flows []*FlowExpr
+		// OpenIDConnectURL is the OpenID Connect discovery URL of JWT
+		// or OAuth2 schemes if any.
+		OpenIDConnectURL string
+		// ClockSkew is the maximum difference between the time a request
+		// was signed and the time it is verified for Signature schemes.
+		ClockSkew time.Duration
+		// ReplayWindow is the duration during which the signatures of
+		// verified requests are recorded to reject replays for Signature
+		// schemes.
+		ReplayWindow time.Duration
+		// WildcardScopes is true if the wildcard scopes of the scheme
+		// imply the scopes sharing their prefix, see WildcardScopes.
+		WildcardScopes bool
 		// Meta is a list of key/value pairs
 		Meta MetaExpr
 	}
@@ -85,6 +112,20 @@ type (
 		TokenURL string
 		// RefreshURL to be used for obtaining refresh token.
 		RefreshURL string
+		// Scopes lists the scopes supported by the flow if they differ
+		// from the scheme scopes.
+		Scopes []*ScopeExpr
+	}
+
+	// KeyLocationExpr describes a location of the key of an APIKey
+	// scheme.
+	KeyLocationExpr struct {
+		// In is the location of the key, one of "header", "query" or
+		// "cookie".
+		In string
+		// Name is the name of the header, query string parameter or
+		// cookie.
+		Name string
 	}
 
 	// ScopeExpr defines a security scope.
@@ -120,13 +161,18 @@ func DupRequirement(req *SecurityExpr) *SecurityExpr {
 // DupScheme creates a copy of the given scheme expression.
 func DupScheme(sch *SchemeExpr) *SchemeExpr {
 	dup := SchemeExpr{
-		Kind:        sch.Kind,
-		SchemeName:  sch.SchemeName,
-		Description: sch.Description,
-		In:          sch.In,
-		Scopes:      sch.Scopes,
-		Flows:       sch.Flows,
-		Meta:        sch.Meta,
+		Kind:             sch.Kind,
+		SchemeName:       sch.SchemeName,
+		Description:      sch.Description,
+		In:               sch.In,
+		KeyLocations:     sch.KeyLocations,
+		Scopes:           sch.Scopes,
+		Flows:            sch.Flows,
+		Meta:             sch.Meta,
+		OpenIDConnectURL: sch.OpenIDConnectURL,
+		ClockSkew:        sch.ClockSkew,
+		ReplayWindow:     sch.ReplayWindow,
+		WildcardScopes:   sch.WildcardScopes,
 	}
 	return &dup
 }
@@ -142,6 +188,10 @@ func (s *SchemeExpr) Type() string {
 		return "APIKey"
 	case JWTKind:
 		return "JWT"
+	case MTLSKind:
+		return "MTLS"
+	case SignatureKind:
+		return "Signature"
 	default:
 		panic(fmt.Sprintf("unknown scheme kind: %#v", s.Kind)) // bug
 	}
@@ -161,14 +211,94 @@ func (s *SchemeExpr) Hash() string {
 // by the scheme.
 func (s *SchemeExpr) Validate() *eval.ValidationErrors {
 	verr := new(eval.ValidationErrors)
+	if s.OpenIDConnectURL != "" {
+		if u, err := url.Parse(s.OpenIDConnectURL); err != nil {
+			verr.Add(s, "invalid OpenID Connect URL %q: %s", s.OpenIDConnectURL, err)
+		} else if !u.IsAbs() {
+			verr.Add(s, "OpenID Connect URL %q must be absolute", s.OpenIDConnectURL)
+		}
+	}
 	for _, f := range s.Flows {
 		if err := f.Validate(); err != nil {
 			verr.Merge(err)
 		}
 	}
+	if jwks, ok := s.Meta.Last("jwtauth:jwks"); ok {
+		if s.Kind != JWTKind {
+			verr.Add(s, "jwtauth:jwks meta can only be used on JWT security schemes")
+		} else if u, err := url.Parse(jwks); err != nil || !u.IsAbs() {
+			verr.Add(s, "jwtauth:jwks meta must be an absolute URL, got %q", jwks)
+		}
+	}
+	seen := make(map[string]bool)
+	for _, l := range s.KeyLocations {
+		if s.Kind != APIKeyKind {
+			verr.Add(s, "key locations can only be defined on APIKey security schemes")
+			break
+		}
+		switch l.In {
+		case "header", "query", "cookie":
+		default:
+			verr.Add(s, "invalid key location %q, must be one of \"header\", \"query\" or \"cookie\"", l.In)
+		}
+		if l.Name == "" {
+			verr.Add(s, "name of %s key location cannot be empty", l.In)
+		}
+		if seen[l.In+":"+l.Name] {
+			verr.Add(s, "key location %s %q is defined more than once", l.In, l.Name)
+		}
+		seen[l.In+":"+l.Name] = true
+	}
+	if _, ok := s.Meta.Last("apikeyauth:env"); ok && s.Kind != APIKeyKind {
+		verr.Add(s, "apikeyauth:env meta can only be used on APIKey security schemes")
+	}
+	if s.ClockSkew < 0 {
+		verr.Add(s, "clock skew must be positive, got %s", s.ClockSkew)
+	}
+	if s.ReplayWindow < 0 {
+		verr.Add(s, "replay window must be positive, got %s", s.ReplayWindow)
+	}
+	if s.WildcardScopes {
+		for _, scope := range s.Scopes {
+			if err := validateScopeName(scope.Name); err != "" {
+				verr.Add(s, "invalid scope %q: %s", scope.Name, err)
+			}
+		}
+	}
 	return verr
 }
 
+// Supports returns true if the scheme defines the given scope or, if the
+// scheme enables wildcard scopes, a wildcard scope that implies it, see
+// security.ScopeImplies.
+func (s *SchemeExpr) Supports(scope string) bool {
+	for _, se := range s.Scopes {
+		if se.Name == scope || s.WildcardScopes && security.ScopeImplies(se.Name, scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// ScopeHierarchy maps the wildcard scopes in the given list to the other scopes
+// of the list they imply. It returns nil if no scope implies another.
+func ScopeHierarchy(scopes []*ScopeExpr) map[string][]string {
+	names := make([]string, len(scopes))
+	for i, se := range scopes {
+		names[i] = se.Name
+	}
+	var res map[string][]string
+	for _, n := range names {
+		if implied := security.ImpliedScopes(n, names); len(implied) > 0 {
+			if res == nil {
+				res = make(map[string][]string)
+			}
+			res[n] = implied
+		}
+	}
+	return res
+}
+
 // EvalName returns the name of the expression used in error messages.
 func (f *FlowExpr) EvalName() string {
 	return "flow " + f.Type()
@@ -215,9 +345,31 @@ func (k SchemeKind) String() string {
 		return "JWT"
 	case OAuth2Kind:
 		return "OAuth2"
+	case MTLSKind:
+		return "MTLS"
+	case SignatureKind:
+		return "Signature"
 	case NoKind:
 		return "None"
 	default:
 		panic("unknown kind") // bug
 	}
 }
+
+// validateScopeName returns a description of the problem if the given scope
+// name uses the wildcard segment incorrectly, the empty string otherwise.
+func validateScopeName(name string) string {
+	segs := strings.Split(name, security.ScopeSeparator)
+	for i, seg := range segs {
+		if !strings.Contains(seg, security.ScopeWildcard) {
+			continue
+		}
+		if seg != security.ScopeWildcard {
+			return fmt.Sprintf("wildcard %q must be a whole segment", security.ScopeWildcard)
+		}
+		if i != len(segs)-1 {
+			return fmt.Sprintf("wildcard %q must be the last segment", security.ScopeWildcard)
+		}
+	}
+	return ""
+}
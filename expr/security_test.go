@@ -3,7 +3,9 @@ package expr
 import (
 	"fmt"
 	"net/url"
+	"reflect"
 	"testing"
+	"time"
 
 	"goa.design/goa/v3/eval"
 )
@@ -295,9 +297,19 @@ func TestSchemeExprValidate(t *testing.T) {
 		}
 		errInvalidTokenURL         = fmt.Errorf("invalid token URL %q: %s", invalidURL, parseError.Error())
 		errInvalidAuthorizationURL = fmt.Errorf("invalid authorization URL %q: %s", invalidURL, parseError.Error())
+		errInvalidOIDCURL          = fmt.Errorf("invalid OpenID Connect URL %q: %s", invalidURL, parseError.Error())
+		errRelativeOIDCURL         = fmt.Errorf("OpenID Connect URL %q must be absolute", "/.well-known/openid-configuration")
 	)
 	cases := map[string]struct {
+		kind     SchemeKind
 		flows    []*FlowExpr
+		oidcURL  string
+		scopes   []*ScopeExpr
+		meta     MetaExpr
+		skew     time.Duration
+		window   time.Duration
+		wildcard bool
+		keyLocs  []*KeyLocationExpr
 		expected *eval.ValidationErrors
 	}{
 		"no error": {
@@ -330,11 +342,128 @@ func TestSchemeExprValidate(t *testing.T) {
 				},
 			},
 		},
+		"valid openid connect url": {
+			oidcURL: "https://example.com/.well-known/openid-configuration",
+			expected: &eval.ValidationErrors{
+				Errors: []error{},
+			},
+		},
+		"invalid openid connect url": {
+			oidcURL: invalidURL,
+			expected: &eval.ValidationErrors{
+				Errors: []error{
+					errInvalidOIDCURL,
+				},
+			},
+		},
+		"relative openid connect url": {
+			oidcURL: "/.well-known/openid-configuration",
+			expected: &eval.ValidationErrors{
+				Errors: []error{
+					errRelativeOIDCURL,
+				},
+			},
+		},
+		"wildcard scopes": {
+			scopes:   []*ScopeExpr{{Name: "*"}, {Name: "orders:*"}, {Name: "orders:items:*"}, {Name: "orders:read"}},
+			wildcard: true,
+			expected: &eval.ValidationErrors{
+				Errors: []error{},
+			},
+		},
+		"valid jwks url": {
+			meta: MetaExpr{"jwtauth:jwks": {"https://example.com/jwks.json"}},
+			expected: &eval.ValidationErrors{
+				Errors: []error{},
+			},
+		},
+		"relative jwks url": {
+			meta: MetaExpr{"jwtauth:jwks": {"/jwks.json"}},
+			expected: &eval.ValidationErrors{
+				Errors: []error{
+					fmt.Errorf(`jwtauth:jwks meta must be an absolute URL, got "/jwks.json"`),
+				},
+			},
+		},
+		"signature durations": {
+			skew:   time.Minute,
+			window: 10 * time.Minute,
+			expected: &eval.ValidationErrors{
+				Errors: []error{},
+			},
+		},
+		"negative signature durations": {
+			skew:   -time.Minute,
+			window: -time.Minute,
+			expected: &eval.ValidationErrors{
+				Errors: []error{
+					fmt.Errorf("clock skew must be positive, got -1m0s"),
+					fmt.Errorf("replay window must be positive, got -1m0s"),
+				},
+			},
+		},
+		"key locations": {
+			kind:    APIKeyKind,
+			keyLocs: []*KeyLocationExpr{{In: "header", Name: "X-API-Key"}, {In: "query", Name: "api_key"}, {In: "cookie", Name: "key"}},
+			meta:    MetaExpr{"apikeyauth:env": {"API_KEYS"}},
+			expected: &eval.ValidationErrors{
+				Errors: []error{},
+			},
+		},
+		"invalid key locations": {
+			kind:    APIKeyKind,
+			keyLocs: []*KeyLocationExpr{{In: "body", Name: "key"}, {In: "header"}, {In: "query", Name: "key"}, {In: "query", Name: "key"}},
+			expected: &eval.ValidationErrors{
+				Errors: []error{
+					fmt.Errorf(`invalid key location "body", must be one of "header", "query" or "cookie"`),
+					fmt.Errorf("name of header key location cannot be empty"),
+					fmt.Errorf(`key location query "key" is defined more than once`),
+				},
+			},
+		},
+		"key locations on JWT scheme": {
+			keyLocs: []*KeyLocationExpr{{In: "header", Name: "X-Token"}},
+			meta:    MetaExpr{"apikeyauth:env": {"TOKENS"}},
+			expected: &eval.ValidationErrors{
+				Errors: []error{
+					fmt.Errorf("key locations can only be defined on APIKey security schemes"),
+					fmt.Errorf("apikeyauth:env meta can only be used on APIKey security schemes"),
+				},
+			},
+		},
+		"literal wildcard scopes": {
+			scopes: []*ScopeExpr{{Name: "orders*"}, {Name: "*:read"}},
+			expected: &eval.ValidationErrors{
+				Errors: []error{},
+			},
+		},
+		"invalid wildcard scopes": {
+			scopes:   []*ScopeExpr{{Name: "orders*"}, {Name: "*:read"}},
+			wildcard: true,
+			expected: &eval.ValidationErrors{
+				Errors: []error{
+					fmt.Errorf(`invalid scope "orders*": wildcard "*" must be a whole segment`),
+					fmt.Errorf(`invalid scope "*:read": wildcard "*" must be the last segment`),
+				},
+			},
+		},
 	}
 
 	for k, tc := range cases {
+		kind := tc.kind
+		if kind == 0 {
+			kind = JWTKind
+		}
 		s := SchemeExpr{
-			Flows: tc.flows,
+			Kind:             kind,
+			KeyLocations:     tc.keyLocs,
+			Flows:            tc.flows,
+			OpenIDConnectURL: tc.oidcURL,
+			Scopes:           tc.scopes,
+			Meta:             tc.meta,
+			ClockSkew:        tc.skew,
+			ReplayWindow:     tc.window,
+			WildcardScopes:   tc.wildcard,
 		}
 		if actual := s.Validate(); len(tc.expected.Errors) != len(actual.Errors) {
 			t.Errorf("%s: expected the number of error values to match %d got %d ", k, len(tc.expected.Errors), len(actual.Errors))
@@ -370,6 +499,10 @@ func TestSchemeKindString(t *testing.T) {
 			kind:     OAuth2Kind,
 			expected: "OAuth2",
 		},
+		"signature": {
+			kind:     SignatureKind,
+			expected: "Signature",
+		},
 		"no kind": {
 			kind:     NoKind,
 			expected: "None",
@@ -399,3 +532,32 @@ func TestSchemeKindString(t *testing.T) {
 		}()
 	}
 }
+
+func TestScopeHierarchy(t *testing.T) {
+	cases := map[string]struct {
+		scopes   []string
+		expected map[string][]string
+	}{
+		"flat":   {[]string{"api:read", "api:write"}, nil},
+		"nested": {[]string{"orders:*", "orders:read", "orders:items:*", "orders:items:write", "users:read"}, map[string][]string{"orders:*": {"orders:read", "orders:items:*", "orders:items:write"}, "orders:items:*": {"orders:items:write"}}},
+		"root":   {[]string{"*", "users:read"}, map[string][]string{"*": {"users:read"}}},
+	}
+	for k, tc := range cases {
+		s := &SchemeExpr{Kind: OAuth2Kind, WildcardScopes: true}
+		for _, n := range tc.scopes {
+			s.Scopes = append(s.Scopes, &ScopeExpr{Name: n})
+		}
+		if actual := ScopeHierarchy(s.Scopes); !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("%s: got %v, expected %v", k, actual, tc.expected)
+		}
+		for _, n := range tc.scopes {
+			if !s.Supports(n) {
+				t.Errorf("%s: expected scheme to support %q", k, n)
+			}
+		}
+	}
+	s := &SchemeExpr{Kind: OAuth2Kind, Scopes: []*ScopeExpr{{Name: "orders:*"}}}
+	if s.Supports("orders:read") {
+		t.Error("expected scheme without wildcard scopes not to support implied scope")
+	}
+}
@@ -25,6 +25,11 @@ type (
 		Services []string
 		// Hosts list the server hosts.
 		Hosts []*HostExpr
+		// Deployment describes the deployment of the server if any.
+		Deployment *DeploymentExpr
+		// Lambda describes the AWS Lambda functions serving the server
+		// if any.
+		Lambda *LambdaExpr
 	}
 
 	// HostExpr describes a server host.
@@ -60,6 +65,12 @@ func (s *ServerExpr) Validate() error {
 			verr.Add(s, "service %q undefined", svc)
 		}
 	}
+	if s.Deployment != nil {
+		verr.Merge(s.Deployment.Validate())
+	}
+	if s.Lambda != nil {
+		verr.Merge(s.Lambda.Validate())
+	}
 	return verr
 }
 
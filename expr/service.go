@@ -6,6 +6,18 @@ import (
 	"goa.design/goa/v3/eval"
 )
 
+// OTelMetaKey is the meta key that enables the generation of the OpenTelemetry
+// instrumentation of the HTTP and gRPC servers and clients.
+const OTelMetaKey = "observability:otel"
+
+// SlogMetaKey is the meta key that enables the generation of the slog based
+// request logging of the services.
+const SlogMetaKey = "log:slog"
+
+// PrometheusMetaKey is the meta key that enables the generation of the
+// Prometheus metrics of the services.
+const PrometheusMetaKey = "metrics:prometheus"
+
 type (
 	// ServiceExpr describes a set of related methods.
 	ServiceExpr struct {
@@ -26,6 +38,19 @@ type (
 		// potentially multiple schemes. Incoming requests must validate
 		// at least one requirement to be authorized.
 		Requirements []*SecurityExpr
+		// OptionalSecurity indicates that requests that carry none of
+		// the credentials of the requirements are authorized, see
+		// MethodExpr.OptionalSecurity.
+		OptionalSecurity bool
+		// Realm is the protection space of the service used in the
+		// authentication challenges, it overrides the API realm.
+		Realm string
+		// Propagation describes the request context values propagated
+		// by the service methods, it overrides the API propagation.
+		Propagation *PropagationExpr
+		// ConcurrencyLimit describes the maximum number of requests
+		// served concurrently by each service method if any.
+		ConcurrencyLimit *ConcurrencyLimitExpr
 		// Meta is a set of key/value pairs with semantic that is
 		// specific to each generator.
 		Meta MetaExpr
@@ -70,14 +95,89 @@ func (s *ServiceExpr) Error(name string) *ErrorExpr {
 	return Root.Error(name)
 }
 
+// AuthRealm returns the realm of the service if any, the realm of the API
+// otherwise.
+func (s *ServiceExpr) AuthRealm() string {
+	if s.Realm != "" {
+		return s.Realm
+	}
+	if Root.API != nil {
+		return Root.API.Realm
+	}
+	return ""
+}
+
+// IsScaffolded returns true if the implementation skeleton of the service is
+// generated with one file per method, that is if the service or the API
+// defines the "scaffold" meta.
+func (s *ServiceExpr) IsScaffolded() bool {
+	if _, ok := s.Meta["scaffold"]; ok {
+		return true
+	}
+	if Root.API != nil {
+		_, ok := Root.API.Meta["scaffold"]
+		return ok
+	}
+	return false
+}
+
+// IsOTelInstrumented returns true if the generated HTTP and gRPC servers and
+// clients of the service create OpenTelemetry spans and record OpenTelemetry
+// metrics, that is if the service or the API defines the "observability:otel"
+// meta. The service meta takes precedence, setting its value to "false"
+// disables the instrumentation of the service.
+func (s *ServiceExpr) IsOTelInstrumented() bool {
+	return s.metaSwitch(OTelMetaKey)
+}
+
+// IsLogged returns true if the requests made to the service are logged with
+// the generated slog based logging package, that is if the service or the API
+// defines the "log:slog" meta. The service meta takes precedence, setting its
+// value to "false" disables the logging of the service.
+func (s *ServiceExpr) IsLogged() bool {
+	return s.metaSwitch(SlogMetaKey)
+}
+
+// IsPrometheusInstrumented returns true if the generated metrics package
+// records the Prometheus metrics of the requests made to the service, that is
+// if the service or the API defines the "metrics:prometheus" meta. The service
+// meta takes precedence, setting its value to "false" disables the metrics of
+// the service.
+func (s *ServiceExpr) IsPrometheusInstrumented() bool {
+	return s.metaSwitch(PrometheusMetaKey)
+}
+
 // Hash returns a unique hash value for s.
 func (s *ServiceExpr) Hash() string {
 	return "_service_+" + s.Name
 }
 
-// Validate validates the service methods and errors.
+// serviceIndex returns the position of s in the design services. The
+// transport services are sorted by position so that the generated code lists
+// them in the order the services are defined regardless of the order the
+// transport DSLs run in. Services that are not part of the design come last.
+func serviceIndex(s *ServiceExpr) int {
+	for i, svc := range Root.Services {
+		if svc == s {
+			return i
+		}
+	}
+	return len(Root.Services)
+}
+
+// Validate validates the service methods, errors, propagation and
+// concurrency limit.
 func (s *ServiceExpr) Validate() error {
 	verr := new(eval.ValidationErrors)
+	if s.OptionalSecurity && len(s.Requirements) == 0 {
+		verr.Add(s, "Optional is used but service %q has no security requirement", s.Name)
+	}
+	if s.Propagation != nil {
+		verr.Merge(s.Propagation.Validate())
+	}
+	if s.ConcurrencyLimit != nil {
+		verr.Merge(s.ConcurrencyLimit.Validate())
+	}
 	for _, e := range s.Errors {
 		if err := e.Validate(); err != nil {
 			if verrs, ok := err.(*eval.ValidationErrors); ok {
@@ -147,3 +247,27 @@ func (e *ErrorExpr) Finalize() {
 		e.AttributeExpr = &AttributeExpr{Type: ut}
 	}
 }
+
+// metaSwitch returns true if the service or the API defines the meta with the
+// given key and a value other than "false". The service meta takes precedence.
+func (s *ServiceExpr) metaSwitch(key string) bool {
+	if on, ok := switchMeta(s.Meta, key); ok {
+		return on
+	}
+	if Root.API != nil {
+		on, _ := switchMeta(Root.API.Meta, key)
+		return on
+	}
+	return false
+}
+
+// switchMeta returns whether the meta with the given key is on, that is
+// whether it has no value or a value other than "false", and whether the meta
+// is defined at all.
+func switchMeta(m MetaExpr, key string) (on bool, ok bool) {
+	v, ok := m[key]
+	if !ok {
+		return false, false
+	}
+	return len(v) == 0 || v[len(v)-1] != "false", true
+}
@@ -87,6 +87,105 @@ func TestServiceExprError(t *testing.T) {
 	}
 }
 
+func TestServiceExprAuthRealm(t *testing.T) {
+	api := expr.Root.API
+	defer func() { expr.Root.API = api }()
+	expr.Root.API = &expr.APIExpr{Realm: "api"}
+
+	cases := map[string]struct {
+		realm    string
+		expected string
+	}{
+		"service": {realm: "admin", expected: "admin"},
+		"api":     {realm: "", expected: "api"},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			s := expr.ServiceExpr{Realm: tc.realm}
+			if actual := s.AuthRealm(); actual != tc.expected {
+				t.Errorf("got %q, expected %q", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestServiceExprIsOTelInstrumented(t *testing.T) {
+	api := expr.Root.API
+	defer func() { expr.Root.API = api }()
+
+	cases := map[string]struct {
+		api      expr.MetaExpr
+		service  expr.MetaExpr
+		expected bool
+	}{
+		"none":             {nil, nil, false},
+		"api":              {expr.MetaExpr{"observability:otel": nil}, nil, true},
+		"service":          {nil, expr.MetaExpr{"observability:otel": nil}, true},
+		"service-disabled": {expr.MetaExpr{"observability:otel": nil}, expr.MetaExpr{"observability:otel": {"false"}}, false},
+		"service-enabled":  {expr.MetaExpr{"observability:otel": {"false"}}, expr.MetaExpr{"observability:otel": {"true"}}, true},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			expr.Root.API = &expr.APIExpr{Meta: tc.api}
+			s := expr.ServiceExpr{Meta: tc.service}
+			if actual := s.IsOTelInstrumented(); actual != tc.expected {
+				t.Errorf("got %v, expected %v", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestServiceExprIsLogged(t *testing.T) {
+	api := expr.Root.API
+	defer func() { expr.Root.API = api }()
+
+	cases := map[string]struct {
+		api      expr.MetaExpr
+		service  expr.MetaExpr
+		expected bool
+	}{
+		"none":             {nil, nil, false},
+		"otel":             {expr.MetaExpr{"observability:otel": nil}, nil, false},
+		"api":              {expr.MetaExpr{"log:slog": nil}, nil, true},
+		"service":          {nil, expr.MetaExpr{"log:slog": nil}, true},
+		"service-disabled": {expr.MetaExpr{"log:slog": nil}, expr.MetaExpr{"log:slog": {"false"}}, false},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			expr.Root.API = &expr.APIExpr{Meta: tc.api}
+			s := expr.ServiceExpr{Meta: tc.service}
+			if actual := s.IsLogged(); actual != tc.expected {
+				t.Errorf("got %v, expected %v", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestServiceExprIsPrometheusInstrumented(t *testing.T) {
+	api := expr.Root.API
+	defer func() { expr.Root.API = api }()
+
+	cases := map[string]struct {
+		api      expr.MetaExpr
+		service  expr.MetaExpr
+		expected bool
+	}{
+		"none":             {nil, nil, false},
+		"api":              {expr.MetaExpr{"metrics:prometheus": nil}, nil, true},
+		"service":          {nil, expr.MetaExpr{"metrics:prometheus": nil}, true},
+		"service-disabled": {expr.MetaExpr{"metrics:prometheus": nil}, expr.MetaExpr{"metrics:prometheus": {"false"}}, false},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			expr.Root.API = &expr.APIExpr{Meta: tc.api}
+			s := expr.ServiceExpr{Meta: tc.service}
+			if actual := s.IsPrometheusInstrumented(); actual != tc.expected {
+				t.Errorf("got %v, expected %v", actual, tc.expected)
+			}
+		})
+	}
+}
+
 func TestServiceExprValidate(t *testing.T) {
 	cases := []struct {
 		Name  string
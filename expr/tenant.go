@@ -0,0 +1,53 @@
+package expr
+
+import (
+	"strings"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// TenantExpr describes how the API servers resolve the tenant of the
+	// incoming requests. The tenant is carried by a HTTP header or gRPC
+	// metadata that the generated clients propagate.
+	TenantExpr struct {
+		// Header is the name of the HTTP header or gRPC metadata that
+		// carries the tenant.
+		Header string
+		// Claim is the name of the bearer token claim that must match
+		// the tenant if any.
+		Claim string
+	}
+)
+
+// DefaultTenantHeader is the name of the header that carries the tenant when
+// the design does not specify one.
+const DefaultTenantHeader = "X-Tenant-ID"
+
+// EvalName returns the generic definition name used in error messages.
+func (t *TenantExpr) EvalName() string {
+	return "tenant"
+}
+
+// Validate makes sure the header name is valid and that the header and the
+// "tenant" name are not used by a context value.
+func (t *TenantExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if !isHeaderToken(t.Header) {
+		verr.Add(t, "invalid header name %q", t.Header)
+	}
+	if strings.EqualFold(t.Header, "Authorization") {
+		verr.Add(t, "the tenant cannot be carried by the Authorization header")
+	}
+	if Root.API != nil {
+		for _, c := range Root.API.ContextValues {
+			if strings.EqualFold(c.Header, t.Header) {
+				verr.Add(t, "header %q is also used by context value %q", t.Header, c.Name)
+			}
+			if c.Name == "tenant" {
+				verr.Add(t, "context value %q conflicts with the tenant accessors", c.Name)
+			}
+		}
+	}
+	return verr
+}
@@ -0,0 +1,42 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestTenant(t *testing.T) {
+	root := expr.RunDSL(t, testdata.TenantDSL)
+	tenant := root.API.Tenant
+	if tenant == nil || tenant.Header != "X-Org" || tenant.Claim != "org" {
+		t.Fatalf("got tenant %+v, expected X-Org header and org claim", tenant)
+	}
+	p := root.Services[0].Propagate()
+	if p == nil || strings.Join(p.Headers, ",") != "X-Request-Id,X-Org" {
+		t.Errorf("got propagated headers %v, expected [X-Request-Id X-Org]", p)
+	}
+
+	root = expr.RunDSL(t, testdata.TenantDefaultDSL)
+	if h := root.API.Tenant.Header; h != expr.DefaultTenantHeader {
+		t.Errorf("got header %q, expected %q", h, expr.DefaultTenantHeader)
+	}
+}
+
+func TestTenantInvalid(t *testing.T) {
+	err := expr.RunInvalidDSL(t, testdata.TenantInvalidDSL)
+	for _, e := range []string{
+		`header "X-Org" is also used by context value "tenant"`,
+		`context value "tenant" conflicts with the tenant accessors`,
+	} {
+		if !strings.Contains(err.Error(), e) {
+			t.Errorf("got error %q, expected it to contain %q", err.Error(), e)
+		}
+	}
+	err = expr.RunInvalidDSL(t, testdata.TenantAuthorizationDSL)
+	if !strings.Contains(err.Error(), "the tenant cannot be carried by the Authorization header") {
+		t.Errorf("got error %q, expected Authorization header error", err.Error())
+	}
+}
@@ -0,0 +1,56 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var AsyncDSL = func() {
+	Service("reports", func() {
+		Method("generate", func() {
+			Payload(String)
+			Async()
+			HTTP(func() {
+				POST("/reports")
+			})
+		})
+		Method("export", func() {
+			Async()
+			HTTP(func() {
+				POST("/exports")
+			})
+		})
+	})
+}
+
+var AsyncInvalidDSL = func() {
+	Service("reports", func() {
+		Method("generate", func() {
+			Async()
+			Result(String)
+		})
+		Method("watch", func() {
+			Async()
+			StreamingPayload(String)
+		})
+	})
+}
+
+var AsyncResultDSL = func() {
+	Service("reports", func() {
+		Method("generate", func() {
+			Result(String)
+			Async()
+		})
+	})
+}
+
+var AsyncTypeConflictDSL = func() {
+	var _ = Type("JobStatus", func() {
+		Attribute("id", String)
+	})
+	Service("reports", func() {
+		Method("generate", func() {
+			Async()
+		})
+	})
+}
@@ -0,0 +1,60 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var MaxAllocsDSL = func() {
+	API("API", func() {
+		HTTP(func() {
+			Benchmarks()
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+				MaxAllocs(100)
+			})
+		})
+	})
+}
+
+var MaxAllocsNoBenchmarksDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+				MaxAllocs(100)
+			})
+		})
+	})
+}
+
+var MaxAllocsStreamingDSL = func() {
+	API("API", func() {
+		HTTP(func() {
+			Benchmarks()
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				MaxAllocs(100)
+			})
+		})
+	})
+}
+
+var MaxAllocsInvalidDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+				MaxAllocs(0)
+			})
+		})
+	})
+}
@@ -0,0 +1,58 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ServiceBodyLimitDSL = func() {
+	Service("Service", func() {
+		HTTP(func() {
+			BodyLimit("2MB")
+		})
+		Method("Method", func() {
+			Payload(String)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+		Method("Override", func() {
+			Payload(String)
+			HTTP(func() {
+				POST("/override")
+				BodyLimit(1024)
+			})
+		})
+	})
+}
+
+var MaxLengthBodyLimitDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(Bytes, func() {
+				MaxLength(30)
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var NoBodyLimitDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(String)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var InvalidBodyLimitDSL = func() {
+	Service("Service", func() {
+		HTTP(func() {
+			BodyLimit("2XB")
+		})
+	})
+}
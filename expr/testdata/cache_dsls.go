@@ -0,0 +1,80 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var CacheDSL = func() {
+	Service("accounts", func() {
+		Method("show", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("view", String)
+			})
+			Cache("5m", CacheKey("id"), CacheKey("view"))
+			HTTP(func() {
+				GET("/{id}")
+				Param("view")
+			})
+		})
+	})
+}
+
+var CacheInvalidDSL = func() {
+	Service("accounts", func() {
+		Method("show", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("tags", ArrayOf(String))
+			})
+			Cache("5m", CacheKey("missing", "tags"))
+			HTTP(func() {
+				POST("/")
+			})
+		})
+		Method("watch", func() {
+			StreamingResult(String)
+			Cache("-1s")
+		})
+		Method("count", func() {
+			Payload(String)
+			Cache("1m", CacheKey("id"))
+		})
+	})
+}
+
+var CacheInvalidSecuredDSL = func() {
+	var JWT = JWTSecurity("jwt")
+	Service("accounts", func() {
+		Security(JWT)
+		Method("show", func() {
+			Payload(func() {
+				Token("token", String)
+				Attribute("id", String)
+			})
+			Cache("5m", CacheKey("id"))
+		})
+		Method("list", func() {
+			Payload(func() {
+				Token("token", String)
+				Attribute("id", String)
+			})
+			Cache("5m", CacheKey("id", "token"))
+		})
+		Method("count", func() {
+			NoSecurity()
+			Payload(func() {
+				Attribute("id", String)
+			})
+			Cache("5m", CacheKey("id"))
+		})
+	})
+}
+
+var CacheInvalidTTLDSL = func() {
+	Service("accounts", func() {
+		Method("show", func() {
+			Cache("five minutes")
+		})
+	})
+}
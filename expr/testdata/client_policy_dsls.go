@@ -0,0 +1,72 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ClientPolicyDSL = func() {
+	Service("Service", func() {
+		HTTP(func() {
+			ClientTimeout("2s")
+			ClientRetry(3)
+		})
+		Method("Show", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+		Method("Update", func() {
+			HTTP(func() {
+				PUT("/")
+				ClientTimeout("500ms")
+				ClientRetry(4, func() {
+					Backoff("10ms", "50ms")
+				})
+			})
+		})
+		Method("Create", func() {
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var NonIdempotentRetryDSL = func() {
+	Service("Service", func() {
+		Method("Create", func() {
+			HTTP(func() {
+				POST("/")
+				ClientRetry(3)
+			})
+		})
+	})
+}
+
+var InvalidRetryDSL = func() {
+	Service("Service", func() {
+		HTTP(func() {
+			ClientRetry(1, func() {
+				Backoff("2s", "1s")
+				RetryOn(42)
+			})
+		})
+		Method("Show", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var StreamingClientPolicyDSL = func() {
+	Service("Service", func() {
+		Method("Stream", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				ClientTimeout("1s")
+			})
+		})
+	})
+}
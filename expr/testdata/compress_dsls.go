@@ -0,0 +1,60 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var CompressDSL = func() {
+	API("API", func() {
+		HTTP(func() {
+			Compress("br", "gzip", func() {
+				CompressTypes("application/json")
+				CompressMinSize("2KB")
+			})
+		})
+	})
+	Service("Service", func() {
+		Method("Inherited", func() {
+			HTTP(func() {
+				GET("/inherited")
+			})
+		})
+		Method("Overridden", func() {
+			HTTP(func() {
+				GET("/overridden")
+				Compress()
+			})
+		})
+	})
+}
+
+var CompressInvalidEncodingDSL = func() {
+	Service("Service", func() {
+		HTTP(func() {
+			Compress("gzip", "lzma", "gzip")
+		})
+	})
+}
+
+var CompressInvalidContentTypeDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+				Compress(func() {
+					CompressTypes("json", "*/json")
+				})
+			})
+		})
+	})
+}
+
+var CompressInvalidMinSizeDSL = func() {
+	Service("Service", func() {
+		HTTP(func() {
+			Compress(func() {
+				CompressMinSize("2XB")
+			})
+		})
+	})
+}
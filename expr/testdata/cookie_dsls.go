@@ -0,0 +1,224 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var CookiesDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("name", String)
+				Attribute("session", String)
+				Attribute("visits", Int)
+			})
+			Result(func() {
+				Attribute("content", String)
+				Attribute("session", String)
+				Attribute("remember", Boolean)
+			})
+			HTTP(func() {
+				POST("/")
+				Cookie("session:SID")
+				Cookie("visits")
+				Response(StatusOK, func() {
+					Cookie("session:SID")
+					Cookie("remember")
+					CookieMaxAge(3600)
+					CookieDomain("goa.design")
+					CookiePath("/")
+					CookieSecure()
+					CookieHTTPOnly()
+					CookieSameSite(CookieSameSiteStrict)
+					CookiePartitioned()
+				})
+			})
+		})
+	})
+}
+
+var CookiesSignedDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("session", String)
+			})
+			Result(func() {
+				Attribute("session", String)
+			})
+			HTTP(func() {
+				POST("/")
+				Cookie("session:SID")
+				CookieSigned()
+				Response(StatusOK, func() {
+					Cookie("session:SID")
+					CookieSigned()
+				})
+			})
+		})
+	})
+}
+
+var CookiesNoPayloadDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				POST("/")
+				Cookie("session")
+			})
+		})
+	})
+}
+
+var CookiesNotFoundDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				POST("/")
+				Cookie("session")
+			})
+		})
+	})
+}
+
+var CookiesInvalidTypeDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("tags", ArrayOf(String))
+			})
+			HTTP(func() {
+				POST("/")
+				Cookie("tags")
+			})
+		})
+	})
+}
+
+var CookiesInvalidNameDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("session", String)
+			})
+			HTTP(func() {
+				POST("/")
+				Cookie("session:my session")
+			})
+		})
+	})
+}
+
+var CookiesSignedNotStringDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("visits", Int)
+			})
+			HTTP(func() {
+				POST("/")
+				Cookie("visits")
+				CookieSigned()
+			})
+		})
+	})
+}
+
+var CookiesEmptyResultDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					Cookie("session")
+				})
+			})
+		})
+	})
+}
+
+var CookiesResultNotObjectDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					Cookie("session")
+				})
+			})
+		})
+	})
+}
+
+var CookiesSameSiteNoneDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("session", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					Cookie("session")
+					CookieSameSite(CookieSameSiteNone)
+				})
+			})
+		})
+	})
+}
+
+var CookiesPartitionedDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("session", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					Cookie("session")
+					CookiePartitioned()
+				})
+			})
+		})
+	})
+}
+
+var CookiesStreamingDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			StreamingResult(func() {
+				Attribute("session", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					Cookie("session")
+				})
+			})
+		})
+	})
+}
+
+var CookiesErrorDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("session", String)
+			})
+			Error("bad_request", func() {
+				Attribute("session", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Response("bad_request", StatusBadRequest, func() {
+					Cookie("session")
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,65 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ValidCORSDSL = func() {
+	API("test", func() {
+		HTTP(func() {
+			Origin("*")
+		})
+	})
+	Service("Service", func() {
+		HTTP(func() {
+			Origin("/.*goa\\.design/", func() {
+				AllowCredentials()
+			})
+		})
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+				Origin("https://goa.design", func() {
+					AllowMethods("get", "POST")
+					AllowPrivateNetwork()
+				})
+			})
+		})
+	})
+}
+
+var InvalidCORSRegexpDSL = func() {
+	Service("Service", func() {
+		HTTP(func() {
+			Origin("/[/")
+		})
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var InvalidCORSCredentialsDSL = func() {
+	API("test", func() {
+		HTTP(func() {
+			Origin("*", func() {
+				AllowCredentials()
+			})
+		})
+	})
+}
+
+var InvalidCORSMethodDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+				Origin("https://goa.design", func() {
+					AllowMethods("FETCH")
+				})
+			})
+		})
+	})
+}
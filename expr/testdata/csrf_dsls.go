@@ -0,0 +1,68 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var CSRFDSL = func() {
+	var Session = APIKeySecurity("session")
+	API("test", func() {
+		HTTP(func() {
+			CSRF(CSRFSynchronizer)
+		})
+	})
+	Service("Service", func() {
+		Security(Session)
+		HTTP(func() {
+			CSRF(CSRFDoubleSubmit, func() {
+				CSRFHeader("X-XSRF-Token")
+				CSRFCookie("XSRF-TOKEN")
+			})
+		})
+		Method("Update", func() {
+			Payload(func() {
+				APIKey("session", "key", String)
+			})
+			HTTP(func() {
+				PUT("/")
+				Cookie("key:SID")
+				CSRF(CSRFDisabled)
+			})
+		})
+		Method("Header", func() {
+			Payload(func() {
+				APIKey("session", "key", String)
+			})
+			HTTP(func() {
+				PUT("/header")
+				Header("key:X-Api-Key")
+			})
+		})
+	})
+}
+
+var InvalidCSRFModeDSL = func() {
+	Service("Service", func() {
+		HTTP(func() {
+			CSRF("cookie")
+		})
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var InvalidCSRFCookieDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+				CSRF(CSRFSynchronizer, func() {
+					CSRFCookie("csrf")
+				})
+			})
+		})
+	})
+}
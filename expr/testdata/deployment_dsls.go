@@ -0,0 +1,58 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var DeploymentDSL = func() {
+	API("calc", func() {
+		Server("calc", func() {
+			Host("local", func() {
+				URI("http://localhost:8000")
+			})
+			Deployment(func() {
+				DeploymentImage("acme/calc:1.0")
+				DeploymentReplicas(3)
+				DeploymentPort("http", 8080)
+				DeploymentEnv("LOG_LEVEL", "info")
+				DeploymentCPU("100m", "1")
+				DeploymentMemory("64Mi", "256Mi")
+			})
+		})
+	})
+	Service("calc", func() {
+		Method("add", func() {
+			HTTP(func() {
+				GET("/add")
+			})
+		})
+	})
+}
+
+var DeploymentInvalidDSL = func() {
+	API("calc", func() {
+		Server("calc", func() {
+			Host("local", func() {
+				URI("http://localhost:8000")
+			})
+			Deployment(func() {
+				DeploymentReplicas(-1)
+				DeploymentPort("tcp", 8080)
+				DeploymentPort("http", 0)
+				DeploymentPort("http", 8080)
+				DeploymentEnv("LOG-LEVEL", "info")
+				DeploymentEnv("TZ", "UTC")
+				DeploymentEnv("TZ", "CET")
+				DeploymentCPU("lots", "1")
+				DeploymentMemory("64Mi", "256MB")
+			})
+		})
+	})
+	Service("calc", func() {
+		Method("add", func() {
+			HTTP(func() {
+				GET("/add")
+			})
+		})
+	})
+}
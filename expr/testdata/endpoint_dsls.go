@@ -245,6 +245,19 @@ var FinalizeEndpointBodyAsPropWithExtendedTypeDSL = func() {
 	})
 }
 
+var GRPCEndpointWithSignatureSecurity = func() {
+	var Signed = SignatureSecurity("signed")
+	Service("Service", func() {
+		Method("Method", func() {
+			Security(Signed)
+			Payload(func() {
+				Field(1, "id", String)
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
 var GRPCEndpointWithAnyType = func() {
 	var Recursive = Type("Recursive", func() {
 		Field(1, "invalid_map_key", MapOf(Any, "Recursive"))
@@ -267,3 +280,49 @@ var GRPCEndpointWithAnyType = func() {
 		})
 	})
 }
+
+var EndpointKeyLocations = func() {
+	var Key = APIKeySecurity("api_key", func() {
+		KeyLocation("header", "X-API-Key")
+		KeyLocation("query", "api_key")
+	})
+	Service("Service", func() {
+		Security(Key)
+		Method("Method", func() {
+			Payload(func() {
+				APIKey("api_key", "key", String)
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+		Method("Mapped", func() {
+			Payload(func() {
+				APIKey("api_key", "key", String)
+			})
+			HTTP(func() {
+				GET("/mapped")
+				Param("key:api_key")
+			})
+		})
+	})
+}
+
+var EndpointRequiredKeyLocations = func() {
+	var Key = APIKeySecurity("api_key", func() {
+		KeyLocation("header", "X-API-Key")
+		KeyLocation("cookie", "key")
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Security(Key)
+			Payload(func() {
+				APIKey("api_key", "key", String)
+				Required("key")
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
@@ -0,0 +1,47 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var EventDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("sku", String)
+		Attribute("quantity", Int)
+		Required("sku")
+	})
+	var OrderEvent = Type("OrderEvent", func() {
+		Attribute("id", String)
+		Attribute("items", ArrayOf(Item))
+		Required("id")
+	})
+	Service("orders", func() {
+		Method("create", func() {
+			Emits("order.created", OrderEvent, "Order was created")
+			Emits("order.updated", OrderEvent)
+		})
+		Method("update", func() {
+			Emits("order.updated", OrderEvent)
+		})
+	})
+}
+
+var EventInvalidDSL = func() {
+	var OrderEvent = Type("OrderEvent", func() {
+		Attribute("id", String)
+	})
+	var OtherEvent = Type("OtherEvent", func() {
+		Attribute("id", String)
+	})
+	Service("orders", func() {
+		Method("create", func() {
+			Emits("", OrderEvent)
+			Emits("order.created", String)
+			Emits("order.updated", OrderEvent)
+			Emits("order.updated", OrderEvent)
+		})
+		Method("update", func() {
+			Emits("order.updated", OtherEvent)
+		})
+	})
+}
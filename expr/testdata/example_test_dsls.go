@@ -116,3 +116,29 @@ var OverridingHiddenExamplesDSL = func() {
 		})
 	})
 }
+
+var NamedExamplesDSL = func() {
+	var Bottle = Type("Bottle", func() {
+		Attribute("name", String, func() {
+			Example("minimal", "Lagavulin")
+			Example("full", "Lagavulin 16")
+		})
+		Attribute("vintage", Int, func() {
+			Example("full", 1990)
+		})
+		Attribute("rating", Int, func() {
+			Example(5)
+		})
+		Required("name", "rating")
+	})
+	Service("NamedExamples", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("bottle", Bottle)
+				Attribute("tags", ArrayOf(String), func() {
+					Example("tagged", []string{"peaty"})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,49 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var FileServerOptionsDSL = func() {
+	Service("Service", func() {
+		Files("/app/{*path}", "dist", func() {
+			Embed()
+			Fallback("index.html")
+			CacheControl("public, max-age=3600")
+			Immutable()
+			ETag()
+		})
+	})
+}
+
+var FileServerImmutableDSL = func() {
+	Service("Service", func() {
+		Files("/favicon.ico", "favicon.ico", func() {
+			Immutable()
+		})
+	})
+}
+
+var FileServerFallbackFileDSL = func() {
+	Service("Service", func() {
+		Files("/index.html", "index.html", func() {
+			Fallback("index.html")
+		})
+	})
+}
+
+var FileServerFallbackOutsideDSL = func() {
+	Service("Service", func() {
+		Files("/app/{*path}", "dist", func() {
+			Fallback("../index.html")
+		})
+	})
+}
+
+var FileServerFallbackAbsoluteDSL = func() {
+	Service("Service", func() {
+		Files("/app/{*path}", "dist", func() {
+			Fallback("/index.html")
+		})
+	})
+}
@@ -0,0 +1,97 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var FormEncodedDSL = func() {
+	var Address = Type("Address", func() {
+		Attribute("city", String)
+		Attribute("zip", Int)
+	})
+	Service("Service", func() {
+		Method("Token", func() {
+			Payload(func() {
+				Attribute("grant_type", String)
+				Attribute("scopes", ArrayOf(String))
+				Attribute("address", Address)
+				Attribute("labels", MapOf(String, String))
+				Required("grant_type")
+			})
+			HTTP(func() {
+				POST("/token")
+				FormEncodedRequest()
+			})
+		})
+	})
+}
+
+var FormEncodedMultipartDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				POST("/")
+				FormEncodedRequest()
+				MultipartRequest()
+			})
+		})
+	})
+}
+
+var FormEncodedNoPayloadDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				POST("/")
+				FormEncodedRequest()
+			})
+		})
+	})
+}
+
+var FormEncodedArrayBodyDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(ArrayOf(String))
+			HTTP(func() {
+				POST("/")
+				FormEncodedRequest()
+			})
+		})
+	})
+}
+
+var FormEncodedMapKeyDSL = func() {
+	var Key = Type("Key", func() {
+		Attribute("id", String)
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("values", MapOf(Key, String))
+			})
+			HTTP(func() {
+				POST("/")
+				FormEncodedRequest()
+			})
+		})
+	})
+}
+
+var FormEncodedStreamingDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			StreamingPayload(String)
+			HTTP(func() {
+				GET("/")
+				FormEncodedRequest()
+			})
+		})
+	})
+}
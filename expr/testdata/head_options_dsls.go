@@ -0,0 +1,66 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var AutoHeadOptionsDSL = func() {
+	Service("Service", func() {
+		HTTP(func() {
+			AutoHeadOptions()
+		})
+		Method("List", func() {
+			HTTP(func() {
+				GET("/items")
+			})
+		})
+		Method("Create", func() {
+			HTTP(func() {
+				POST("/items")
+			})
+		})
+		Method("Show", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				GET("/items/{id}")
+			})
+		})
+		Method("Check", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				HEAD("/items/{id}")
+			})
+		})
+		Method("Stream", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/stream")
+			})
+		})
+		Method("Shared", func() {
+			HTTP(func() {
+				GET("/shared")
+			})
+		})
+		Method("CORS", func() {
+			HTTP(func() {
+				GET("/cors")
+				Origin("*")
+			})
+		})
+	})
+	Service("Other", func() {
+		HTTP(func() {
+			AutoHeadOptions()
+		})
+		Method("Shared", func() {
+			HTTP(func() {
+				DELETE("/shared")
+			})
+		})
+	})
+}
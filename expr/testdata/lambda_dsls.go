@@ -0,0 +1,35 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var LambdaDSL = func() {
+	API("calc", func() {
+		Server("calc", func() {
+			Lambda(func() {
+				PerMethod()
+			})
+		})
+	})
+	Service("calc", func() {
+		Method("add", func() {
+			HTTP(func() {
+				GET("/add")
+			})
+		})
+	})
+}
+
+var LambdaNoHTTPDSL = func() {
+	API("calc", func() {
+		Server("calc", func() {
+			Lambda()
+		})
+	})
+	Service("calc", func() {
+		Method("add", func() {
+			GRPC(func() {})
+		})
+	})
+}
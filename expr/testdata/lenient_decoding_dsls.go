@@ -0,0 +1,58 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var LenientDecodingAPIDSL = func() {
+	API("API", func() {
+		HTTP(func() {
+			LenientDecoding()
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(String)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var LenientDecodingDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("count", Int, func() {
+					LenientDecoding()
+				})
+				Attribute("name", String, func() {
+					Meta("decode:trim")
+				})
+				Attribute("flag", Boolean)
+			})
+			HTTP(func() {
+				POST("/")
+				LenientDecoding()
+			})
+		})
+		Method("Strict", func() {
+			Payload(String)
+			HTTP(func() {
+				POST("/strict")
+			})
+		})
+	})
+	Service("Lenient", func() {
+		HTTP(func() {
+			LenientDecoding()
+		})
+		Method("Method", func() {
+			Payload(String)
+			HTTP(func() {
+				POST("/lenient")
+			})
+		})
+	})
+}
@@ -0,0 +1,44 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ConcurrencyLimitDSL = func() {
+	Service("calc", func() {
+		ConcurrencyLimit(100)
+		Method("add", func() {})
+		Method("report", func() {
+			ConcurrencyLimit(4, func() {
+				Queue(16, "2s")
+			})
+		})
+	})
+	Service("other", func() {
+		Method("get", func() {})
+	})
+}
+
+var ConcurrencyLimitInvalidDSL = func() {
+	Service("calc", func() {
+		ConcurrencyLimit(0)
+		Method("report", func() {
+			ConcurrencyLimit(4, func() {
+				Queue(-1, "")
+			})
+		})
+		Method("other", func() {
+			ConcurrencyLimit(4, func() {
+				Queue(0, "1s")
+			})
+		})
+	})
+}
+
+var ConcurrencyLimitInvalidTimeoutDSL = func() {
+	Service("calc", func() {
+		ConcurrencyLimit(1, func() {
+			Queue(1, "two seconds")
+		})
+	})
+}
@@ -0,0 +1,24 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var LocalizedDescriptionsDSL = func() {
+	var Bottle = Type("Bottle", func() {
+		Description("en", "A bottle of wine.")
+		Description("ja", "ワインのボトル。")
+		Attribute("name", String, func() {
+			Description("Name of bottle")
+			Description("ja", "ボトルの名前")
+		})
+	})
+	Service("cellar", func() {
+		Description("en", "The cellar service manages bottles.")
+		Description("fr", "Le service cellar gère les bouteilles.")
+		Method("show", func() {
+			Description("Show a bottle.")
+			Payload(Bottle)
+		})
+	})
+}
@@ -0,0 +1,62 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var StrictMediaTypesDSL = func() {
+	API("API", func() {
+		HTTP(func() {
+			Consumes("application/json")
+			Produces("application/json")
+		})
+	})
+	Service("Service", func() {
+		HTTP(func() {
+			StrictMediaTypes()
+		})
+		Method("Method", func() {
+			Payload(String)
+			Result(String)
+			HTTP(func() {
+				POST("/")
+				Response(StatusOK, func() {
+					ContentType("text/plain")
+				})
+			})
+		})
+		Method("MultipartMethod", func() {
+			Payload(String)
+			HTTP(func() {
+				POST("/multipart")
+				MultipartRequest()
+			})
+		})
+		Method("NoPayloadMethod", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK)
+				Response(StatusAccepted, func() {
+					ContentType("application/xml")
+				})
+			})
+		})
+	})
+}
+
+var StrictMediaTypesErrorDSL = func() {
+	Service("Service", func() {
+		Error("not_acceptable", func() {
+			Attribute("accept", String)
+		})
+		Method("Method", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/")
+				StrictMediaTypes()
+				Response("not_acceptable", StatusBadRequest)
+			})
+		})
+	})
+}
@@ -40,3 +40,130 @@ var InvalidSecuritySchemesDSL = func() {
 		})
 	})
 }
+
+var MTLSAuth = MTLSSecurity("mtls", func() {
+	Scope("payments", "Payments access")
+})
+
+var InvalidMTLSDSL = func() {
+	Service("InvalidMTLSService", func() {
+		Security(MTLSAuth)
+		Method("MissingCertificate", func() {
+			Payload(func() {
+				Attribute("a", String)
+				// invalid: missing client certificate attribute
+			})
+		})
+		Method("InvalidCertificate", func() {
+			Security(MTLSAuth, func() {
+				Scope("payments")
+			})
+			Payload(func() {
+				ClientCertificate("cert", Int) // invalid: not a String
+			})
+		})
+	})
+}
+
+var InvalidOptionalSecurityDSL = func() {
+	Service("InvalidOptionalSecurityService", func() {
+		Method("Unsecured", func() {
+			Optional() // invalid: no security requirement
+		})
+		Method("NoSecurity", func() {
+			Security(APIKeyAuth)
+			NoSecurity()
+			Optional() // invalid: security disabled
+			Payload(func() {
+				APIKey("api_key", "key", String)
+			})
+		})
+	})
+	Service("InvalidOptionalSecurityService2", func() {
+		Optional() // invalid: no security requirement
+		Method("Method", func() {})
+	})
+}
+
+var InvalidAuditResourceDSL = func() {
+	Service("InvalidAuditResourceService", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", String, func() {
+					Meta("audit:resource")
+				})
+				Attribute("ids", ArrayOf(String), func() {
+					Meta("audit:resource") // invalid: not a primitive
+				})
+			})
+		})
+	})
+}
+
+var InvalidPaginationDSL = func() {
+	Service("InvalidPaginationService", func() {
+		Method("MissingAttributes", func() {
+			Payload(func() {
+				Attribute("cursor", String)
+			})
+			Result(func() {
+				Attribute("items", String) // invalid: not an array
+			})
+			Meta("sdk:paginate", "cursor", "next", "items")
+		})
+		Method("MismatchedTypes", func() {
+			Payload(func() {
+				Attribute("page", Int)
+			})
+			Result(func() {
+				Attribute("next", String)
+				Attribute("items", ArrayOf(String))
+			})
+			Meta("sdk:paginate", "page", "next", "items")
+		})
+		Method("MissingValues", func() {
+			Meta("sdk:paginate", "cursor")
+		})
+	})
+}
+
+var OptionalSecurityDSL = func() {
+	Service("OptionalSecurityService", func() {
+		Security(JWTAuth)
+		Security(APIKeyAuth)
+		Optional()
+		Method("Inherited", func() {
+			Payload(func() {
+				Token("token", String)
+				APIKey("api_key", "key", String)
+			})
+		})
+		Method("Overridden", func() {
+			Security(APIKeyAuth)
+			Payload(func() {
+				APIKey("api_key", "key", String)
+			})
+		})
+		Method("Unsecured", func() {
+			NoSecurity()
+		})
+	})
+}
+
+var InheritedSchemeSettingsDSL = func() {
+	var Signed = SignatureSecurity("signed", func() {
+		ClockSkew("1m")
+		ReplayWindow("10m")
+	})
+	var OIDC = JWTSecurity("oidc", func() {
+		OpenIDConnect("https://accounts.example.com/.well-known/openid-configuration")
+	})
+	Service("InheritedSchemeSettingsService", func() {
+		Security(Signed, OIDC)
+		Method("Method", func() {
+			Payload(func() {
+				Token("token", String)
+			})
+		})
+	})
+}
@@ -0,0 +1,55 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var MuxerAdaptersDSL = func() {
+	API("API", func() {
+		HTTP(func() {
+			MuxerAdapters("chi", "gin")
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(String)
+			HTTP(func() {
+				GET("/{*path}")
+			})
+		})
+	})
+}
+
+var MuxerAdaptersUnsupportedDSL = func() {
+	API("API", func() {
+		HTTP(func() {
+			MuxerAdapters("chi", "httprouter")
+		})
+	})
+}
+
+var MuxerAdaptersDuplicateDSL = func() {
+	API("API", func() {
+		HTTP(func() {
+			MuxerAdapters("echo", "echo")
+		})
+	})
+}
+
+var MuxerAdaptersStyledDSL = func() {
+	API("API", func() {
+		HTTP(func() {
+			MuxerAdapters("gorilla")
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("format", String)
+			})
+			HTTP(func() {
+				GET("/report{.format}")
+			})
+		})
+	})
+}
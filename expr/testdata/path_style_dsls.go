@@ -0,0 +1,62 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ValidPathStyleDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("format", String)
+				Attribute("color", String)
+				Required("id", "format")
+			})
+			HTTP(func() {
+				GET("/{id}{.format}{;color}")
+			})
+		})
+	})
+}
+
+var NonTrailingPathStyleDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("format", String)
+			})
+			HTTP(func() {
+				GET("/report{.format}/{id}")
+			})
+		})
+	})
+}
+
+var CatchAllPathStyleDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("path", String)
+				Attribute("format", String)
+			})
+			HTTP(func() {
+				GET("/files/{*path}{.format}")
+			})
+		})
+	})
+}
+
+var NonStringPathStyleDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("version", Int)
+			})
+			HTTP(func() {
+				GET("/report{;version}")
+			})
+		})
+	})
+}
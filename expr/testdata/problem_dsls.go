@@ -0,0 +1,34 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ProblemDetailsDSL = func() {
+	API("API", func() {
+		HTTP(func() {
+			ProblemDetails("https://goa.design/problems/")
+		})
+	})
+	Service("Service", func() {
+		Error("not_found")
+		Error("conflict", func() {
+			Attribute("reason", String)
+		})
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+				Response("not_found", StatusNotFound)
+				Response("conflict", StatusConflict)
+			})
+		})
+	})
+}
+
+var ProblemDetailsInvalidTypeBaseDSL = func() {
+	API("API", func() {
+		HTTP(func() {
+			ProblemDetails("problems/")
+		})
+	})
+}
@@ -0,0 +1,78 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var PropagationDSL = func() {
+	API("API", func() {
+		Propagate()
+		PropagateDeadline()
+	})
+	Service("Inherited", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+	Service("Overridden", func() {
+		Propagate("X-Tenant")
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var PropagationInvalidDSL = func() {
+	Service("Service", func() {
+		Propagate("X-Tenant", "x-tenant", "Bad Header")
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var ContextValuesDSL = func() {
+	API("API", func() {
+		Propagate("X-Request-Id")
+		ContextValue("tenant:X-Tenant", String)
+		ContextValue("request_id:x-request-id", String)
+	})
+	Service("Inherited", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+	Service("Overridden", func() {
+		Propagate("traceparent")
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var ContextValuesInvalidDSL = func() {
+	API("API", func() {
+		ContextValue("tenant:X-Tenant", String)
+		ContextValue("tenant:X-Other-Tenant", String)
+		ContextValue("account:x-tenant", String)
+		ContextValue("bad:Bad Header", String)
+		ContextValue("data:X-Data", Bytes)
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
@@ -0,0 +1,59 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ProxyDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				GET("/gateway/{id}")
+				Proxy("https://upstream.internal/v1", func() {
+					StripPrefix("/gateway")
+					SetRequestHeader("x-gateway", "goa")
+					SetRequestHeader("X-Gateway", "goa-v3")
+					RemoveRequestHeader("authorization", "Cookie")
+				})
+			})
+		})
+	})
+}
+
+var InvalidUpstreamProxyDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+				Proxy("upstream.internal/v1")
+			})
+		})
+	})
+}
+
+var ResultProxyDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/")
+				Proxy("http://upstream.internal")
+			})
+		})
+	})
+}
+
+var StreamingProxyDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			StreamingPayload(String)
+			HTTP(func() {
+				GET("/")
+				Proxy("http://upstream.internal")
+			})
+		})
+	})
+}
@@ -0,0 +1,110 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ValidQueryStyleDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("pipes", ArrayOf(String))
+				Attribute("ids", ArrayOf(Int), func() {
+					Explode(false)
+				})
+				Attribute("filter", MapOf(String, String))
+			})
+			HTTP(func() {
+				GET("/")
+				Param("pipes", func() {
+					QueryStyle("pipeDelimited")
+				})
+				Param("ids")
+				Param("filter", func() {
+					QueryStyle("deepObject")
+				})
+			})
+		})
+	})
+}
+
+var DelimitedNonArrayQueryStyleDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("q", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Param("q", func() {
+					QueryStyle("spaceDelimited")
+				})
+			})
+		})
+	})
+}
+
+var DeepObjectNonMapQueryStyleDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("q", ArrayOf(String))
+			})
+			HTTP(func() {
+				GET("/")
+				Param("q", func() {
+					QueryStyle("deepObject")
+				})
+			})
+		})
+	})
+}
+
+var ExplodedPipeDelimitedQueryStyleDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("q", ArrayOf(String))
+			})
+			HTTP(func() {
+				GET("/")
+				Param("q", func() {
+					QueryStyle("pipeDelimited")
+					Explode(true)
+				})
+			})
+		})
+	})
+}
+
+var PathParamQueryStyleDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", ArrayOf(String))
+			})
+			HTTP(func() {
+				GET("/{id}")
+				Param("id", func() {
+					QueryStyle("pipeDelimited")
+				})
+			})
+		})
+	})
+}
+
+var InvalidQueryStyleDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("q", ArrayOf(String))
+			})
+			HTTP(func() {
+				GET("/")
+				Param("q", func() {
+					QueryStyle("matrix")
+				})
+			})
+		})
+	})
+}
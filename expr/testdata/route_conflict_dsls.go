@@ -0,0 +1,58 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var RouteConflictDSL = func() {
+	Service("Service", func() {
+		HTTP(func() {
+			Path("/items")
+		})
+		Method("Show", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				GET("/{id}")
+			})
+		})
+		Method("Lookup", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				GET("/{name}")
+			})
+		})
+	})
+}
+
+var RouteNoConflictDSL = func() {
+	Service("Service", func() {
+		Method("Show", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				GET("/items/{id}")
+				DELETE("/items/{id}")
+			})
+		})
+		Method("Latest", func() {
+			HTTP(func() {
+				GET("/items/latest")
+			})
+		})
+	})
+	Service("Other", func() {
+		Method("Show", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				GET("/items/{id}")
+			})
+		})
+	})
+}
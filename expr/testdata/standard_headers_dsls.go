@@ -0,0 +1,72 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var StandardHeadersDSL = func() {
+	var RateLimited = Type("RateLimited", func() {
+		Attribute("message", String)
+		Attribute("retry_in", Int)
+		Attribute("limit", Int)
+		Attribute("remaining", Int)
+		Attribute("reset", Int64)
+		Required("message")
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("data", String)
+				Attribute("limit", Int)
+				Attribute("remaining", Int, "Requests left.")
+				Attribute("retry_at", String)
+			})
+			Error("rate_limited", RateLimited)
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					RateLimit("limit", "remaining", "")
+					RetryAfter("retry_at")
+				})
+				Response("rate_limited", StatusTooManyRequests, func() {
+					RetryAfter("retry_in")
+					RateLimit("limit", "remaining", "reset")
+				})
+			})
+		})
+	})
+}
+
+var StandardHeadersInvalidResultDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("limit", String)
+				Attribute("retry_in", Boolean)
+			})
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					RateLimit("limit", "", "")
+					RetryAfter("retry_in")
+				})
+			})
+		})
+	})
+}
+
+var StandardHeadersInvalidErrorDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Error("rate_limited", func() {
+				Attribute("reset", Float64)
+			})
+			HTTP(func() {
+				GET("/")
+				Response("rate_limited", StatusTooManyRequests, func() {
+					RateLimit("", "", "reset")
+				})
+			})
+		})
+	})
+}
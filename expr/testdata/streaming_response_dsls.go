@@ -0,0 +1,72 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var StreamingResponseDSL = func() {
+	Service("Service", func() {
+		Method("Watch", func() {
+			Payload(func() {
+				Attribute("topic", String)
+			})
+			StreamingResult(String)
+			HTTP(func() {
+				POST("/watch")
+				StreamingResponse(NDJSON)
+			})
+		})
+	})
+}
+
+var StreamingResponseInvalidFormatDSL = func() {
+	Service("Service", func() {
+		Method("Watch", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/watch")
+				StreamingResponse("xml")
+			})
+		})
+	})
+}
+
+var StreamingResponseNoStreamDSL = func() {
+	Service("Service", func() {
+		Method("Show", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/")
+				StreamingResponse(NDJSON)
+			})
+		})
+	})
+}
+
+var StreamingResponsePayloadDSL = func() {
+	Service("Service", func() {
+		Method("Chat", func() {
+			StreamingPayload(String)
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/chat")
+				StreamingResponse(NDJSON)
+			})
+		})
+	})
+}
+
+var StreamingResponseWebSocketDSL = func() {
+	Service("Service", func() {
+		Method("Watch", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/watch")
+				StreamingResponse(JSONArray)
+				WebSocket(func() {
+					PingInterval(10)
+				})
+			})
+		})
+	})
+}
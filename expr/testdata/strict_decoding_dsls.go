@@ -0,0 +1,50 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var StrictDecodingAPIDSL = func() {
+	API("API", func() {
+		HTTP(func() {
+			StrictDecoding()
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(String)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var StrictDecodingDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(String)
+			HTTP(func() {
+				POST("/")
+				StrictDecoding()
+			})
+		})
+		Method("Lenient", func() {
+			Payload(String)
+			HTTP(func() {
+				POST("/lenient")
+			})
+		})
+	})
+	Service("Strict", func() {
+		HTTP(func() {
+			StrictDecoding()
+		})
+		Method("Method", func() {
+			Payload(String)
+			HTTP(func() {
+				POST("/strict")
+			})
+		})
+	})
+}
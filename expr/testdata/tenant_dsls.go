@@ -0,0 +1,50 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var TenantDSL = func() {
+	API("saas", func() {
+		Propagate("X-Request-Id")
+		Tenant(func() {
+			Header("X-Org")
+			Claim("org")
+		})
+	})
+	Service("orders", func() {
+		Method("list", func() {})
+	})
+}
+
+var TenantDefaultDSL = func() {
+	API("saas", func() {
+		Tenant()
+	})
+	Service("orders", func() {
+		Method("list", func() {})
+	})
+}
+
+var TenantInvalidDSL = func() {
+	API("saas", func() {
+		ContextValue("tenant:X-Org")
+		Tenant(func() {
+			Header("X-Org")
+		})
+	})
+	Service("orders", func() {
+		Method("list", func() {})
+	})
+}
+
+var TenantAuthorizationDSL = func() {
+	API("saas", func() {
+		Tenant(func() {
+			Header("Authorization")
+		})
+	})
+	Service("orders", func() {
+		Method("list", func() {})
+	})
+}
@@ -0,0 +1,170 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var TrailersDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("content", String)
+				Attribute("checksum", String)
+				Attribute("count", Int)
+			})
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					Trailers(func() {
+						Attribute("checksum:Digest")
+						Attribute("count")
+					})
+				})
+			})
+		})
+	})
+}
+
+var TrailersEmptyResultDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					Trailers(func() {
+						Attribute("checksum", String)
+					})
+				})
+			})
+		})
+	})
+}
+
+var TrailersNoAttributeDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("content", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					Trailers(func() {
+						Attribute("checksum", String)
+					})
+				})
+			})
+		})
+	})
+}
+
+var TrailersNotPrimitiveDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("content", String)
+				Attribute("tags", ArrayOf(String))
+			})
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					Trailers(func() {
+						Attribute("tags")
+					})
+				})
+			})
+		})
+	})
+}
+
+var TrailersForbiddenDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("content", String)
+				Attribute("length", Int)
+			})
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					Trailers(func() {
+						Attribute("length:Content-Length")
+					})
+				})
+			})
+		})
+	})
+}
+
+var TrailersHeaderDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("content", String)
+				Attribute("checksum", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					Header("checksum")
+					Trailers(func() {
+						Attribute("checksum")
+					})
+				})
+			})
+		})
+	})
+}
+
+var TrailersNoBodyStatusDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("checksum", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Response(StatusNoContent, func() {
+					Trailers(func() {
+						Attribute("checksum")
+					})
+				})
+			})
+		})
+	})
+}
+
+var TrailersStreamingDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			StreamingResult(func() {
+				Attribute("content", String)
+				Attribute("checksum", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					Trailers(func() {
+						Attribute("checksum")
+					})
+				})
+			})
+		})
+	})
+}
+
+var TrailersErrorDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Error("bad_request")
+			HTTP(func() {
+				GET("/")
+				Response("bad_request", StatusBadRequest, func() {
+					Trailers(func() {
+						Attribute("message")
+					})
+				})
+			})
+		})
+	})
+}
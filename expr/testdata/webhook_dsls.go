@@ -0,0 +1,76 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var WebhookDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("sku", String)
+	})
+	Service("Service", func() {
+		Webhook("order.created", func() {
+			Description("Order created")
+			Body(func() {
+				Attribute("id", String)
+				Attribute("items", ArrayOf(Item))
+				Required("id")
+			})
+		})
+	})
+}
+
+var WebhookUserTypeDSL = func() {
+	var Order = Type("Order", func() {
+		Attribute("id", String)
+	})
+	Service("Service", func() {
+		Webhook("order-shipped", func() {
+			Body(Order)
+		})
+	})
+}
+
+var WebhookNoBodyDSL = func() {
+	Service("Service", func() {
+		Webhook("order.created", func() {
+			Description("Order created")
+		})
+	})
+}
+
+var WebhookArrayBodyDSL = func() {
+	var Orders = Type("Orders", ArrayOf(String))
+	Service("Service", func() {
+		Webhook("order.created", func() {
+			Body(Orders)
+		})
+	})
+}
+
+var WebhookInvalidNameDSL = func() {
+	Service("Service", func() {
+		Webhook("1.2", func() {
+			Body(func() {
+				Attribute("id", String)
+			})
+		})
+	})
+}
+
+var WebhookDuplicateDSL = func() {
+	Service("Service", func() {
+		Webhook("order.created", func() {
+			Body(func() {
+				Attribute("id", String)
+			})
+		})
+	})
+	Service("Other", func() {
+		Webhook("order.created", func() {
+			Body(func() {
+				Attribute("id", String)
+			})
+		})
+	})
+}
@@ -0,0 +1,33 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ValidWebSocketDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				WebSocket(func() {
+					Subprotocols("v1")
+					PingInterval(10)
+				})
+			})
+		})
+	})
+}
+
+var NonStreamingWebSocketDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+				WebSocket(func() {
+					PerMessageDeflate()
+				})
+			})
+		})
+	})
+}
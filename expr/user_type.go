@@ -91,7 +91,7 @@ func (u *UserTypeExpr) recExample(r *Random) *interface{} {
 	var ex interface{}
 	pex := &ex
 	r.Seen[u.ID()] = pex
-	actual := u.Type.Example(r)
+	actual := u.Type.Example(r.Derive(u.ID()))
 	*pex = actual
 	return pex
 }
@@ -1,6 +1,9 @@
 package expr
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestUserTypeExprName(t *testing.T) {
 	var (
@@ -91,3 +94,27 @@ func TestUserTypeExprIsCompatible(t *testing.T) {
 		}
 	}
 }
+
+func TestUserTypeExprExampleStable(t *testing.T) {
+	ut := &UserTypeExpr{
+		TypeName: "Stable",
+		AttributeExpr: &AttributeExpr{Type: &Object{
+			{"name", &AttributeExpr{Type: String}},
+			{"count", &AttributeExpr{Type: Int}},
+		}},
+	}
+	other := &UserTypeExpr{
+		TypeName:      "Other",
+		AttributeExpr: &AttributeExpr{Type: &Array{ElemType: &AttributeExpr{Type: String}}},
+	}
+	expected := ut.Example(NewRandom("test"))
+
+	// Generating the example of another type first must not change the
+	// example of ut.
+	r := NewRandom("test")
+	other.Example(r)
+	r.Int()
+	if actual := ut.Example(r); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("got example %#v, expected %#v", actual, expected)
+	}
+}
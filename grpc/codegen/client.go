@@ -46,6 +46,7 @@ func client(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 				{Path: path.Join(genpkg, svcName), Name: data.Service.PkgName},
 				{Path: path.Join(genpkg, svcName, "views"), Name: data.Service.ViewsPkg},
 				{Path: path.Join(genpkg, "grpc", svcName, pbPkgName), Name: data.PkgName},
+				{Path: path.Join(genpkg, "grpc", "telemetry")},
 			}),
 		}
 		sections = append(sections, &codegen.SectionTemplate{
@@ -66,12 +67,14 @@ func client(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 			Name:   "client-init",
 			Source: clientInitT,
 			Data:   data,
+			Expr:   svc,
 		})
 		for _, e := range data.Endpoints {
 			sections = append(sections, &codegen.SectionTemplate{
 				Name:   "client-endpoint-init",
 				Source: clientEndpointInitT,
 				Data:   e,
+				Expr:   svc.Endpoint(e.Method.Name),
 			})
 		}
 		for _, e := range data.Endpoints {
@@ -107,7 +110,7 @@ func client(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 			}
 		}
 	}
-	return &codegen.File{Path: fpath, SectionTemplates: sections}
+	return &codegen.File{Path: fpath, SectionTemplates: sections, Service: svc.Name()}
 }
 
 func clientEncodeDecode(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
@@ -138,10 +141,12 @@ func clientEncodeDecode(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File
 		fm["typeConversionData"] = typeConversionData
 		fm["isBearer"] = isBearer
 		for _, e := range data.Endpoints {
+			ee := svc.Endpoint(e.Method.Name)
 			sections = append(sections, &codegen.SectionTemplate{
 				Name:   "remote-method-builder",
 				Source: remoteMethodBuilderT,
 				Data:   e,
+				Expr:   ee,
 			})
 			if e.PayloadRef != "" {
 				sections = append(sections, &codegen.SectionTemplate{
@@ -149,6 +154,7 @@ func clientEncodeDecode(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File
 					Source:  requestEncoderT,
 					Data:    e,
 					FuncMap: fm,
+					Expr:    ee,
 				})
 			}
 			if e.ResultRef != "" || e.ClientStream != nil {
@@ -157,11 +163,12 @@ func clientEncodeDecode(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File
 					Source:  responseDecoderT,
 					Data:    e,
 					FuncMap: fm,
+					Expr:    ee,
 				})
 			}
 		}
 	}
-	return &codegen.File{Path: fpath, SectionTemplates: sections}
+	return &codegen.File{Path: fpath, SectionTemplates: sections, Service: svc.Name()}
 }
 
 // isBearer returns true if the security scheme uses a Bearer scheme.
@@ -199,10 +206,13 @@ func New{{ .ClientStruct }}(cc *grpc.ClientConn, opts ...grpc.CallOption) *{{ .C
 const clientEndpointInitT = `{{ printf "%s calls the %q function in %s.%s interface." .Method.VarName .Method.VarName .PkgName .ClientInterface | comment }}
 func (c *{{ .ClientStruct }}) {{ .Method.VarName }}() goa.Endpoint {
 	return func(ctx context.Context, v interface{}) (interface{}, error) {
-		inv := goagrpc.NewInvoker(
+		inv := {{ if .Telemetry }}telemetry.Invoker({{ end }}goagrpc.NewInvoker(
 			Build{{ .Method.VarName }}Func(c.grpccli, c.opts...),
 			{{ if .PayloadRef }}Encode{{ .Method.VarName }}Request{{ else }}nil{{ end }},
-			{{ if or .ResultRef .ClientStream }}Decode{{ .Method.VarName }}Response{{ else }}nil{{ end }})
+			{{ if or .ResultRef .ClientStream }}Decode{{ .Method.VarName }}Response{{ else }}nil{{ end }}){{ if .Telemetry }}, {{ printf "%q" .ServiceName }}, {{ printf "%q" .Method.Name }}){{ end }}
+	{{- if .PropagatedHeaders }}
+		ctx = goagrpc.PropagateOutgoing(ctx, &goa.Propagation{Headers: []string{ {{- range $i, $h := .PropagatedHeaders }}{{ if $i }}, {{ end }}{{ printf "%q" $h }}{{ end }} }})
+	{{- end }}
 		res, err := inv.Invoke(ctx, v)
 		if err != nil {
 		{{- if .Errors }}
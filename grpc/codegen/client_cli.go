@@ -29,6 +29,15 @@ func ClientCLIFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 			for _, e := range sd.Endpoints {
 				flags, buildFunction := buildFlags(sd, e)
 				subcmd := cli.BuildSubcommandData(sd.Service.Name, e.Method, buildFunction, flags)
+				if s := e.ClientStream; s != nil {
+					subcmd.Stream = &cli.StreamData{
+						TypeRef:     "*" + sd.Service.PkgName + "c." + s.VarName,
+						SendTypeRef: s.SendRef,
+						RecvName:    s.RecvName,
+						MustClose:   s.MustClose,
+						HalfClose:   s.MustClose,
+					}
+				}
 				command.Subcommands = append(command.Subcommands, subcmd)
 			}
 			command.Example = command.Subcommands[0].Example
@@ -56,11 +65,14 @@ func endpointParser(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr, da
 	title := svr.Name + " gRPC client CLI support package"
 	specs := []*codegen.ImportSpec{
 		{Path: "context"},
+		{Path: "encoding/json"},
 		{Path: "flag"},
 		{Path: "fmt"},
 		{Path: "os"},
 		codegen.GoaImport(""),
 		codegen.GoaNamedImport("grpc", "goagrpc"),
+		codegen.GoaNamedImport("cli", "goacli"),
+		codegen.GoaImport("security/credentials"),
 		{Path: "google.golang.org/grpc", Name: "grpc"},
 	}
 	for _, svc := range root.API.GRPC.Services {
@@ -77,6 +89,10 @@ func endpointParser(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr, da
 			Path: path.Join(genpkg, "grpc", svcName, pbPkgName),
 			Name: svcName + pbPkgName,
 		})
+		specs = append(specs, &codegen.ImportSpec{
+			Path: path.Join(genpkg, svcName),
+			Name: sd.Service.PkgName,
+		})
 	}
 
 	sections := []*codegen.SectionTemplate{
@@ -87,17 +103,21 @@ func endpointParser(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr, da
 			Name:   "parse-endpoint",
 			Source: parseEndpointT,
 			Data: struct {
-				FlagsCode string
-				Commands  []*cli.CommandData
+				FlagsCode      string
+				Commands       []*cli.CommandData
+				HasCredentials bool
 			}{
 				cli.FlagsCode(data),
 				data,
+				cli.HasCredentials(data),
 			},
 		},
+		cli.Commands(data),
 	}
 	for _, cmd := range data {
 		sections = append(sections, cli.CommandUsage(cmd))
 	}
+	sections = append(sections, cli.Stream(data))
 	return &codegen.File{Path: fpath, SectionTemplates: sections}
 }
 
@@ -122,7 +142,7 @@ func payloadBuilders(genpkg string, svc *expr.GRPCServiceExpr, data *cli.Command
 			sections = append(sections, cli.PayloadBuilderSection(sub.BuildFunction))
 		}
 	}
-	return &codegen.File{Path: fpath, SectionTemplates: sections}
+	return &codegen.File{Path: fpath, SectionTemplates: sections, Service: svc.Name()}
 }
 
 func buildFlags(svc *ServiceData, e *EndpointData) ([]*cli.FlagData, *cli.BuildFunctionData) {
@@ -154,6 +174,7 @@ func makeFlags(e *EndpointData, args []*InitArgData) ([]*cli.FlagData, *cli.Buil
 		}
 
 		f := cli.NewFlagData(e.ServiceName, e.Method.Name, arg.Name, arg.TypeName, arg.Description, arg.Required, arg.Example)
+		f.SetCredential(e.Method, arg.FieldName)
 		flags[i] = f
 		params[i] = f.FullName
 		code, chek := cli.FieldLoadCode(f, arg.Name, arg.TypeName, arg.Validate, arg.DefaultValue)
@@ -198,7 +219,15 @@ func makeFlags(e *EndpointData, args []*InitArgData) ([]*cli.FlagData, *cli.Buil
 
 const parseEndpointT = `// ParseEndpoint returns the endpoint and payload as specified on the command
 // line.
+{{- if .HasCredentials }}
+//
+// The security flags that are not given explicitly are initialized with the
+// credentials of the profile given with goagrpc.WithCredentials if any.
+{{- end }}
 func ParseEndpoint(cc *grpc.ClientConn, opts ...grpc.CallOption) (goa.Endpoint, interface{}, error) {
+	{{- if .HasCredentials }}
+	creds := goagrpc.CallCredentials(opts)
+	{{- end }}
 	{{ .FlagsCode }}
 	var (
 		data     interface{}
@@ -0,0 +1,37 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/grpc/codegen/testdata"
+)
+
+func TestClientCLIStream(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"unary-rpcs", testdata.UnaryRPCsDSL, testdata.UnaryRPCsClientCLIStreamCode},
+		{"server-streaming-rpc", testdata.ServerStreamingRPCDSL, testdata.ServerStreamingRPCClientCLIStreamCode},
+		{"client-streaming-rpc", testdata.ClientStreamingRPCDSL, testdata.ClientStreamingRPCClientCLIStreamCode},
+		{"client-streaming-rpc-no-result", testdata.ClientStreamingNoResultDSL, testdata.ClientStreamingNoResultClientCLIStreamCode},
+		{"bidirectional-streaming-rpc", testdata.BidirectionalStreamingRPCDSL, testdata.BidirectionalStreamingRPCClientCLIStreamCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunGRPCDSL(t, c.DSL)
+			fs := ClientCLIFiles("", expr.Root)
+			sections := fs[0].Section("cli-stream")
+			if len(sections) != 1 {
+				t.Fatalf("got %d sections, expected 1", len(sections))
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
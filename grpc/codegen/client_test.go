@@ -25,6 +25,7 @@ func TestClientEndpointInit(t *testing.T) {
 		{"bidirectional-streaming-rpc", testdata.BidirectionalStreamingRPCDSL, testdata.BidirectionalStreamingRPCClientEndpointInitCode},
 		{"bidirectional-streaming-rpc-with-payload", testdata.BidirectionalStreamingRPCWithPayloadDSL, testdata.BidirectionalStreamingRPCWithPayloadClientEndpointInitCode},
 		{"bidirectional-streaming-rpc-with-errors", testdata.BidirectionalStreamingRPCWithErrorsDSL, testdata.BidirectionalStreamingRPCWithErrorsClientEndpointInitCode},
+		{"unary-rpc-with-propagation", testdata.UnaryRPCWithPropagationDSL, testdata.UnaryRPCWithPropagationClientEndpointInitCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -74,6 +74,8 @@ func clientType(genpkg string, svc *expr.GRPCServiceExpr, seen map[string]struct
 		sections = []*codegen.SectionTemplate{
 			codegen.Header(svc.Name()+" gRPC client types", "client",
 				[]*codegen.ImportSpec{
+					{Path: "fmt"},
+					{Path: "strconv"},
 					{Path: "unicode/utf8"},
 					codegen.GoaImport(""),
 					{Path: path.Join(genpkg, svcName), Name: sd.Service.PkgName},
@@ -107,5 +109,5 @@ func clientType(genpkg string, svc *expr.GRPCServiceExpr, seen map[string]struct
 		}
 	}
 
-	return &codegen.File{Path: fpath, SectionTemplates: sections}
+	return &codegen.File{Path: fpath, SectionTemplates: sections, Service: svc.Name()}
 }
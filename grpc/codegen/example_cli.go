@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/cli"
 	"goa.design/goa/v3/codegen/example"
 	"goa.design/goa/v3/expr"
 )
@@ -44,12 +45,7 @@ func exampleCLI(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *codeg
 		scope = codegen.NewNameScope()
 	)
 	{
-		// genpkg is created by path.Join so the separator is / regardless of operating system
-		idx := strings.LastIndex(genpkg, string("/"))
-		rootPath = "."
-		if idx > 0 {
-			rootPath = genpkg[:idx]
-		}
+		rootPath = codegen.Layout.RootPackage(genpkg)
 		apiPkg = scope.Unique(strings.ToLower(codegen.Goify(root.API.Name, false)), "api")
 	}
 
@@ -67,6 +63,8 @@ func exampleCLI(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *codeg
 			{Path: "time"},
 			codegen.GoaImport(""),
 			codegen.GoaNamedImport("grpc", "goagrpc"),
+			codegen.GoaNamedImport("cli", "goacli"),
+			codegen.GoaImport("security/credentials"),
 			{Path: rootPath, Name: apiPkg},
 			{Path: path.Join(genpkg, "grpc", "cli", svrdata.Dir), Name: "cli"},
 		}
@@ -78,20 +76,60 @@ func exampleCLI(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *codeg
 	{
 		sections = []*codegen.SectionTemplate{
 			codegen.Header("", "main", specs),
-			&codegen.SectionTemplate{Name: "do-grpc-cli", Source: grpcCLIDoT, Data: svrdata},
+			&codegen.SectionTemplate{
+				Name:   "do-grpc-cli",
+				Source: grpcCLIDoT,
+				Data: struct {
+					*example.Data
+					APIName     string
+					Credentials bool
+				}{
+					svrdata,
+					cli.APIName(root.API),
+					needCredentials(svr),
+				},
+			},
 		}
 	}
 
 	return &codegen.File{Path: mainPath, SectionTemplates: sections, SkipExist: true}
 }
 
+// needCredentials returns true if the command line client initializes security
+// flags of the endpoints of the given server with stored credentials.
+func needCredentials(svr *expr.ServerExpr) bool {
+	for _, svc := range svr.Services {
+		sd := GRPCServices.Get(svc)
+		if sd == nil {
+			continue
+		}
+		for _, e := range sd.Endpoints {
+			flags, _ := buildFlags(sd, e)
+			for _, f := range flags {
+				if f.Credential != nil {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 const (
 	grpcCLIDoT = `func doGRPC(scheme, host string, timeout int, debug bool) (goa.Endpoint, interface{}, error) {
 	conn, err := grpc.Dial(host, grpc.WithInsecure())
 	if err != nil {
     fmt.Fprintln(os.Stderr, fmt.Sprintf("could not connect to gRPC server at %s: %v", host, err))
   }
+	{{- if .Credentials }}
+	// The security flags not given explicitly are initialized with the
+	// credentials of the host stored in the environment or in the
+	// credentials file, see credentials.DefaultStore.
+	creds := credentials.NewProfile(host, credentials.DefaultStore({{ printf "%q" .APIName }}))
+	return cli.ParseEndpoint(conn, goagrpc.WithCredentials(creds))
+	{{- else }}
 	return cli.ParseEndpoint(conn)
+	{{- end }}
 }
 
 {{ if eq .DefaultTransport.Type "grpc" }}
@@ -102,6 +140,14 @@ func grpcUsageCommands() string {
 func grpcUsageExamples() string {
 	return cli.UsageExamples()
 }
+
+func grpcCommands() []*goacli.Command {
+	return cli.Commands()
+}
 {{- end }}
+
+func grpcStream(data interface{}) *goacli.Stream {
+	return cli.Stream(data)
+}
 `
 )
@@ -24,6 +24,7 @@ func TestExampleCLIFiles(t *testing.T) {
 		{"no-server-pkgpath", ctestdata.NoServerDSL, "my/pkg/path", testdata.ExamplePkgPathCLIImport + "\n" + testdata.ExampleCLICode},
 		{"server-hosting-service-subset-pkgpath", ctestdata.ServerHostingServiceSubsetDSL, "my/pkg/path", testdata.ExampleSingleHostPkgPathCLIImport + "\n" + testdata.ExampleCLICode},
 		{"server-hosting-multiple-services-pkgpath", ctestdata.ServerHostingMultipleServicesDSL, "my/pkg/path", testdata.ExampleSingleHostPkgPathCLIImport + "\n" + testdata.ExampleCLICode},
+		{"credentials", testdata.MessageWithSecurityAttrsDSL, "", testdata.ExampleCredentialsCLICode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -49,10 +49,13 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 			{Path: "net/url"},
 			{Path: "os"},
 			{Path: "sync"},
+			{Path: "time"},
+			codegen.GoaImport(""),
 			codegen.GoaImport("middleware"),
 			codegen.GoaNamedImport("grpc", "goagrpc"),
 			codegen.GoaNamedImport("grpc/middleware", "grpcmdlwr"),
 			{Path: "google.golang.org/grpc"},
+			{Path: "google.golang.org/grpc/health/grpc_health_v1"},
 			{Path: "github.com/grpc-ecosystem/go-grpc-middleware", Name: "grpcmiddleware"},
 		}
 		for _, svc := range root.API.GRPC.Services {
@@ -78,12 +81,7 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 		apiPkg   string
 	)
 	{
-		// genpkg is created by path.Join so the separator is / regardless of operating system
-		idx := strings.LastIndex(genpkg, string("/"))
-		rootPath = "."
-		if idx > 0 {
-			rootPath = genpkg[:idx]
-		}
+		rootPath = codegen.Layout.RootPackage(genpkg)
 		apiPkg = scope.Unique(strings.ToLower(codegen.Goify(root.API.Name, false)), "api")
 	}
 	specs = append(specs, &codegen.ImportSpec{Path: rootPath, Name: apiPkg})
@@ -105,6 +103,7 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 				Source: grpcSvrStartT,
 				Data: map[string]interface{}{
 					"Services": svcdata,
+					"Health":   root.API.HealthCheck != nil,
 				},
 			},
 			&codegen.SectionTemplate{Name: "server-grpc-logger", Source: grpcSvrLoggerT},
@@ -120,6 +119,7 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 				Source: grpcRegisterSvrT,
 				Data: map[string]interface{}{
 					"Services": svcdata,
+					"Health":   root.API.HealthCheck != nil,
 				},
 				FuncMap: map[string]interface{}{
 					"goify":      codegen.Goify,
@@ -152,9 +152,9 @@ func needStream(data []*ServiceData) bool {
 }
 
 const (
-	// input: map[string]interface{}{"Services":[]*ServiceData}
+	// input: map[string]interface{}{"Services":[]*ServiceData, "Health": bool}
 	grpcSvrStartT = `{{ comment "handleGRPCServer starts configures and starts a gRPC server on the given URL. It shuts down the server if any error is received in the error channel." }}
-func handleGRPCServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if .Service.Methods }}, {{ .Service.VarName }}Endpoints *{{ .Service.PkgName }}.Endpoints{{ end }}{{ end }}, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+func handleGRPCServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if .Service.Methods }}, {{ .Service.VarName }}Endpoints *{{ .Service.PkgName }}.Endpoints{{ end }}{{ end }}, wg *sync.WaitGroup, errc chan error, drainer *goa.Drainer, {{ if .Health }}health *goa.Health, {{ end }}reporter goa.PanicReporter, logger *log.Logger, debug bool) {
 `
 
 	grpcSvrLoggerT = `
@@ -189,18 +189,20 @@ func handleGRPCServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 	}
 `
 
-	// input: map[string]interface{}{"Services":[]*ServiceData}
+	// input: map[string]interface{}{"Services":[]*ServiceData, "Health": bool}
 	grpcRegisterSvrT = `
 	// Initialize gRPC server with the middleware.
 	srv := grpc.NewServer(
 		grpcmiddleware.WithUnaryServerChain(
 			grpcmdlwr.UnaryRequestID(),
 			grpcmdlwr.UnaryServerLog(adapter),
+			grpcmdlwr.UnaryServerRecover(reporter),
 		),
 	{{- if needStream .Services }}
 		grpcmiddleware.WithStreamServerChain(
 			grpcmdlwr.StreamRequestID(),
 			grpcmdlwr.StreamServerLog(adapter),
+			grpcmdlwr.StreamServerRecover(reporter),
 		),
 	{{- end }}
 	)
@@ -209,6 +211,9 @@ func handleGRPCServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 	{{- range .Services }}
 	{{ .PkgName }}.Register{{ goify .Service.VarName true }}Server(srv, {{ .Service.VarName }}Server)
 	{{- end }}
+	{{- if .Health }}
+	grpc_health_v1.RegisterHealthServer(srv, goagrpc.NewHealthServer(health))
+	{{- end }}
 
 	for svc, info := range srv.GetServiceInfo() {
 		for _, m := range info.Methods {
@@ -235,8 +240,21 @@ func handleGRPCServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 
 		<-ctx.Done()
 		logger.Printf("shutting down gRPC server at %q", u.Host)
-		srv.Stop()
-  }()
+
+		{{ comment "Stop accepting new connections and wait for the in-flight requests and streams to complete within the grace period, then close the remaining connections." }}
+		drainer.Drain()
+		stopped := make(chan struct{})
+		go func() {
+			srv.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(drainer.Grace):
+			logger.Printf("gRPC server at %q did not drain within %v", u.Host, drainer.Grace)
+			srv.Stop()
+		}
+	}()
 }
 `
 )
@@ -0,0 +1,189 @@
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// fixturesData contains the data needed to render the fixtures of the
+	// service endpoints.
+	fixturesData struct {
+		// Name is the name of the service.
+		Name string
+		// Fixtures lists the endpoint fixtures.
+		Fixtures []*fixtureData
+	}
+
+	// fixtureData describes the fixtures of an endpoint.
+	fixtureData struct {
+		// Name is the name of the method.
+		Name string
+		// File is the base name of the fixture files.
+		File string
+		// Request is the JSON representation of the request message.
+		Request string
+		// Response is the JSON representation of the response message.
+		Response string
+	}
+)
+
+// FixturesFiles returns the golden fixtures of the gRPC endpoints if the API
+// defines the "fixtures:record" meta. The fixtures are the JSON
+// representations of the request and response messages built from the design
+// examples, they are written to gen/grpc/<service>/fixtures alongside a
+// package that loads them. The examples are generated with a random generator
+// seeded with the service and method names so that the fixtures only change
+// when the design does.
+func FixturesFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	if _, ok := root.API.Meta["fixtures:record"]; !ok {
+		return nil
+	}
+	var fw []*codegen.File
+	for _, svc := range root.API.GRPC.Services {
+		data := buildFixturesData(svc)
+		if len(data.Fixtures) == 0 {
+			continue
+		}
+		svcName := codegen.SnakeCase(GRPCServices.Get(svc.Name()).Service.VarName)
+		dir := filepath.Join(codegen.Gendir, "grpc", svcName, "fixtures")
+		for _, f := range data.Fixtures {
+			fw = append(fw,
+				fixtureFile(filepath.Join(dir, f.File+".request.json"), f.Request),
+				fixtureFile(filepath.Join(dir, f.File+".response.json"), f.Response),
+			)
+		}
+		title := fmt.Sprintf("%s gRPC fixtures", svc.Name())
+		fw = append(fw, &codegen.File{
+			Path: filepath.Join(dir, "fixtures.go"),
+			SectionTemplates: []*codegen.SectionTemplate{
+				codegen.Header(title, "fixtures", []*codegen.ImportSpec{
+					{Path: "encoding/json"},
+					{Path: "fmt"},
+					{Path: "io/ioutil"},
+					{Path: "path/filepath"},
+					{Path: "runtime"},
+				}),
+				{Name: "fixtures", Source: fixturesT, Data: data},
+			},
+		})
+	}
+	return fw
+}
+
+// fixtureFile returns the file with the given path that contains content.
+func fixtureFile(path, content string) *codegen.File {
+	return &codegen.File{
+		Path: path,
+		SectionTemplates: []*codegen.SectionTemplate{{
+			Name:   "fixture",
+			Source: "{{ . }}",
+			Data:   content,
+		}},
+	}
+}
+
+// buildFixturesData returns the fixtures of the service endpoints. The
+// messages sent through streams are recorded in place of the request and
+// response messages of streaming endpoints.
+func buildFixturesData(svc *expr.GRPCServiceExpr) *fixturesData {
+	fd := &fixturesData{Name: svc.Name()}
+	for _, e := range svc.GRPCEndpoints {
+		r := expr.NewRandom(svc.Name() + "#" + e.Name())
+		req := e.Request
+		if e.MethodExpr.Stream == expr.ClientStreamKind || e.MethodExpr.Stream == expr.BidirectionalStreamKind {
+			req = e.StreamingRequest
+		}
+		reqJSON, ok := fixtureMessage(req, r)
+		if !ok {
+			continue
+		}
+		var resp *expr.AttributeExpr
+		if e.Response != nil {
+			resp = e.Response.Message
+		}
+		respJSON, ok := fixtureMessage(resp, r)
+		if !ok {
+			continue
+		}
+		fd.Fixtures = append(fd.Fixtures, &fixtureData{
+			Name:     e.Name(),
+			File:     codegen.SnakeCase(e.Name()),
+			Request:  reqJSON,
+			Response: respJSON,
+		})
+	}
+	return fd
+}
+
+// fixtureMessage returns the indented JSON representation of the message
+// described by att built from the examples generated with r. It returns false
+// if the example cannot be represented in JSON. The nested arrays and maps
+// are not wrapped into messages so that the fixtures may not map to all the
+// fields of such messages.
+func fixtureMessage(att *expr.AttributeExpr, r *expr.Random) (string, bool) {
+	if att == nil || att.Type == expr.Empty {
+		return "{}\n", true
+	}
+	v := protoJSONValue(att.Example(r))
+	if !expr.IsObject(att.Type) {
+		// Primitives, arrays and maps are wrapped into a message with a
+		// single field, see makeProtoBufMessage.
+		v = map[string]interface{}{"field": v}
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", false
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, b, "", "  "); err != nil {
+		return "", false
+	}
+	buf.WriteString("\n")
+	return buf.String(), true
+}
+
+// input: fixturesData
+const fixturesT = `{{ printf "Methods lists the %q service methods that have recorded fixtures." .Name | comment }}
+var Methods = []string{ {{- range $i, $f := .Fixtures }}{{ if $i }}, {{ end }}{{ printf "%q" $f.Name }}{{ end }} }
+
+// files maps the methods to the base names of their fixture files.
+var files = map[string]string{
+{{- range .Fixtures }}
+	{{ printf "%q" .Name }}: {{ printf "%q" .File }},
+{{- end }}
+}
+
+// Request unmarshals the request message recorded for the given method into
+// msg, a pointer to the protocol buffer message type.
+func Request(method string, msg interface{}) error {
+	return load(method, "request", msg)
+}
+
+// Response unmarshals the response message recorded for the given method into
+// msg, a pointer to the protocol buffer message type.
+func Response(method string, msg interface{}) error {
+	return load(method, "response", msg)
+}
+
+// load unmarshals the fixture file of the given kind recorded for the given
+// method into msg. The fixture files are located in the directory of this
+// source file.
+func load(method, kind string, msg interface{}) error {
+	f, ok := files[method]
+	if !ok {
+		return fmt.Errorf("no fixture recorded for method %q", method)
+	}
+	_, src, _, _ := runtime.Caller(0)
+	b, err := ioutil.ReadFile(filepath.Join(filepath.Dir(src), f+"."+kind+".json"))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, msg)
+}
+`
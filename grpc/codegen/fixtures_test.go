@@ -0,0 +1,61 @@
+package codegen
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/grpc/codegen/testdata"
+)
+
+func TestFixturesFiles(t *testing.T) {
+	RunGRPCDSL(t, testdata.FixturesDSL)
+	fs := FixturesFiles("", expr.Root)
+	dir := filepath.Join("gen", "grpc", "service_fixtures", "fixtures")
+	expected := []struct {
+		Path    string
+		Content string
+	}{
+		{filepath.Join(dir, "method_message.request.json"), testdata.FixturesMethodMessageRequest},
+		{filepath.Join(dir, "method_message.response.json"), testdata.FixturesMethodMessageResponse},
+		{filepath.Join(dir, "method_no_payload.request.json"), "{}\n"},
+		{filepath.Join(dir, "method_no_payload.response.json"), "{}\n"},
+		{filepath.Join(dir, "method_streaming_payload.request.json"), testdata.FixturesMethodStreamingPayloadRequest},
+		{filepath.Join(dir, "method_streaming_payload.response.json"), "{}\n"},
+	}
+	if len(fs) != len(expected)+1 {
+		t.Fatalf("got %d files, expected %d", len(fs), len(expected)+1)
+	}
+	for i, e := range expected {
+		if fs[i].Path != e.Path {
+			t.Errorf("got path %q, expected %q", fs[i].Path, e.Path)
+		}
+		var buf bytes.Buffer
+		if err := fs[i].SectionTemplates[0].Write(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if content := buf.String(); content != e.Content {
+			t.Errorf("%s: invalid content, got:\n%s\ngot vs. expected:\n%s", e.Path, content, codegen.Diff(t, content, e.Content))
+		}
+	}
+	if p := filepath.Join(dir, "fixtures.go"); fs[len(expected)].Path != p {
+		t.Errorf("got path %q, expected %q", fs[len(expected)].Path, p)
+	}
+	sections := fs[len(expected)].Section("fixtures")
+	if len(sections) != 1 {
+		t.Fatalf("got %d sections, expected one", len(sections))
+	}
+	code := codegen.SectionCode(t, sections[0])
+	if code != testdata.FixturesCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.FixturesCode))
+	}
+}
+
+func TestFixturesFilesDisabled(t *testing.T) {
+	RunGRPCDSL(t, testdata.UnaryRPCsDSL)
+	if fs := FixturesFiles("", expr.Root); len(fs) != 0 {
+		t.Errorf("got %d files, expected none", len(fs))
+	}
+}
@@ -0,0 +1,183 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// fuzzData contains the data needed to render the fuzz target of an
+	// endpoint request decoder.
+	fuzzData struct {
+		*EndpointData
+		// Message is the name of the request message type.
+		Message string
+		// Seed is the JSON representation of the request message built
+		// from the design examples.
+		Seed string
+		// Metadata lists the fuzzed request metadata.
+		Metadata []*fuzzMetadataData
+	}
+
+	// fuzzMetadataData describes a fuzzed request metadata.
+	fuzzMetadataData struct {
+		// Name is the metadata key.
+		Name string
+		// Arg is the name of the fuzzed argument holding the value.
+		Arg string
+		// Seed is the value used to seed the corpus.
+		Seed string
+	}
+)
+
+// FuzzFiles returns the files that contain the fuzz targets of the gRPC
+// request decoders if the API defines the "fuzz:test" meta. The targets
+// unmarshal fuzzed protocol buffer messages and metadata and make sure that
+// transforming and validating the requests does not panic. The corpus is
+// seeded with the messages built from the design examples. The files require
+// Go 1.18 or later.
+func FuzzFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	if _, ok := root.API.Meta["fuzz:test"]; !ok {
+		return nil
+	}
+	var fw []*codegen.File
+	for _, svc := range root.API.GRPC.Services {
+		data := GRPCServices.Get(svc.Name())
+		var fuzzs []*fuzzData
+		for _, e := range data.Endpoints {
+			if e.PayloadRef == "" || e.Method.StreamingPayload != "" {
+				continue
+			}
+			fuzzs = append(fuzzs, buildFuzzData(e))
+		}
+		if len(fuzzs) == 0 {
+			continue
+		}
+		svcName := codegen.SnakeCase(data.Service.VarName)
+		fpath := filepath.Join(codegen.Gendir, "grpc", svcName, "server", "fuzz_test.go")
+		title := fmt.Sprintf("%s gRPC request decoders fuzz targets", svc.Name())
+		header := codegen.Header(title, "server", []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "encoding/json"},
+			{Path: "testing"},
+			{Path: "github.com/golang/protobuf/proto"},
+			{Path: "google.golang.org/grpc/metadata"},
+			{Path: path.Join(genpkg, "grpc", svcName, pbPkgName), Name: data.PkgName},
+		})
+		codegen.AddBuildConstraint(header, "go1.18")
+		sections := []*codegen.SectionTemplate{header}
+		for _, f := range fuzzs {
+			sections = append(sections, &codegen.SectionTemplate{Name: "fuzz-decode", Source: fuzzDecodeT, Data: f})
+		}
+		fw = append(fw, &codegen.File{Path: fpath, SectionTemplates: sections})
+	}
+	return fw
+}
+
+// buildFuzzData returns the data needed to render the fuzz target of the
+// given endpoint request decoder.
+func buildFuzzData(e *EndpointData) *fuzzData {
+	var (
+		scope = codegen.NewNameScope()
+		f     = &fuzzData{EndpointData: e, Message: strings.TrimPrefix(e.Request.Message.Ref, "*"), Seed: "{}"}
+	)
+	for _, n := range []string{"t", "b", "md", "message", "err"} {
+		scope.Unique(n)
+	}
+	args := e.Request.CLIArgs
+	if len(args) > 0 && args[0].Name == "message" {
+		if b, err := json.Marshal(protoJSONValue(args[0].Example)); err == nil {
+			f.Seed = string(b)
+		}
+		args = args[1:]
+	}
+	for i, md := range e.Request.Metadata {
+		seed := ""
+		if i < len(args) {
+			seed = fuzzSeed(args[i].Example)
+		}
+		f.Metadata = append(f.Metadata, &fuzzMetadataData{
+			Name: md.Name,
+			Arg:  scope.Unique(md.VarName),
+			Seed: seed,
+		})
+	}
+	return f
+}
+
+// protoJSONValue converts the keys of the objects contained in the given
+// example to the names of the protocol buffer message fields so that the JSON
+// representation of the example may be unmarshaled into the message.
+func protoJSONValue(v interface{}) interface{} {
+	switch actual := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(actual))
+		for k, e := range actual {
+			m[codegen.SnakeCase(protoBufify(k, false))] = protoJSONValue(e)
+		}
+		return m
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(actual))
+		for k, e := range actual {
+			m[fmt.Sprint(k)] = protoJSONValue(e)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(actual))
+		for i, e := range actual {
+			s[i] = protoJSONValue(e)
+		}
+		return s
+	}
+	return v
+}
+
+// fuzzSeed returns the metadata value holding the given example.
+func fuzzSeed(v interface{}) string {
+	switch actual := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return actual
+	case []interface{}:
+		vals := make([]string, len(actual))
+		for i, e := range actual {
+			vals[i] = fuzzSeed(e)
+		}
+		return strings.Join(vals, ",")
+	}
+	return fmt.Sprint(v)
+}
+
+// input: fuzzData
+const fuzzDecodeT = `{{ printf "Fuzz%sDecodeRequest makes sure that transforming and validating the %q service %q requests does not panic. The corpus is seeded with the message built from the design examples." .Method.VarName .ServiceName .Method.Name | comment }}
+func Fuzz{{ .Method.VarName }}DecodeRequest(f *testing.F) {
+	var seed {{ .Message }}
+	// The examples may not map to all the message fields, the fields that
+	// cannot be unmarshaled are left empty.
+	json.Unmarshal([]byte({{ printf "%q" .Seed }}), &seed)
+	b, err := proto.Marshal(&seed)
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(b{{ range .Metadata }}, {{ printf "%q" .Seed }}{{ end }})
+	f.Fuzz(func(t *testing.T, b []byte{{ range .Metadata }}, {{ .Arg }} string{{ end }}) {
+		var message {{ .Message }}
+		if err := proto.Unmarshal(b, &message); err != nil {
+			return
+		}
+		md := metadata.MD{}
+	{{- range .Metadata }}
+		md.Set({{ printf "%q" .Name }}, {{ .Arg }})
+	{{- end }}
+		// Invalid requests must be rejected with an error.
+		Decode{{ .Method.VarName }}Request(context.Background(), &message, md)
+	})
+}
+`
@@ -0,0 +1,71 @@
+package codegen
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// InMemoryFiles returns the files that contain the helpers which wire the
+// generated gRPC clients to the generated gRPC servers through an in-memory
+// connection. The helpers make it possible for unit tests to call the typed
+// client methods against a service implementation without opening network
+// ports.
+func InMemoryFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var fw []*codegen.File
+	for _, svc := range root.API.GRPC.Services {
+		data := GRPCServices.Get(svc.Name())
+		if len(data.Endpoints) == 0 {
+			continue
+		}
+		svcName := codegen.SnakeCase(data.Service.VarName)
+		fpath := filepath.Join(codegen.Gendir, "grpc", svcName, "inmem", "inmem.go")
+		title := fmt.Sprintf("%s gRPC in-memory client", svc.Name())
+		sections := []*codegen.SectionTemplate{
+			codegen.Header(title, "inmem", []*codegen.ImportSpec{
+				{Path: "context"},
+				{Path: "net"},
+				{Path: "google.golang.org/grpc"},
+				{Path: "google.golang.org/grpc/test/bufconn"},
+				{Path: path.Join(genpkg, svcName), Name: data.Service.PkgName},
+				{Path: path.Join(genpkg, "grpc", svcName, "client"), Name: data.Service.PkgName + "c"},
+				{Path: path.Join(genpkg, "grpc", svcName, "server"), Name: data.Service.PkgName + "svr"},
+				{Path: path.Join(genpkg, "grpc", svcName, pbPkgName), Name: data.PkgName},
+			}),
+			{
+				Name:    "inmem-client",
+				Source:  inMemoryClientT,
+				Data:    data,
+				FuncMap: map[string]interface{}{"goify": codegen.Goify},
+			},
+		}
+		fw = append(fw, &codegen.File{Path: fpath, SectionTemplates: sections})
+	}
+	return fw
+}
+
+// input: ServiceData
+const inMemoryClientT = `{{ printf "NewClient returns a %q service client whose requests are served by svc over gRPC through an in-memory connection, without opening network ports. The function returned alongside the client closes the connection and stops the server, it must be called once the client is no longer needed." .Service.Name | comment }}
+func NewClient(svc {{ .Service.PkgName }}.Service) (*{{ .Service.PkgName }}.Client, func()) {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	{{ .PkgName }}.Register{{ goify .Service.VarName true }}Server(srv, {{ .Service.PkgName }}svr.New({{ .Service.PkgName }}.NewEndpoints(svc){{ if .HasUnaryEndpoint }}, nil{{ end }}{{ if .HasStreamingEndpoint }}, nil{{ end }}))
+	go srv.Serve(lis)
+
+	dial := func(context.Context, string) (net.Conn, error) { return lis.Dial() }
+	cc, err := grpc.DialContext(context.Background(), "inmem", grpc.WithContextDialer(dial), grpc.WithInsecure())
+	if err != nil {
+		// The connection is established lazily, dialing fails only if
+		// the options are invalid.
+		panic(err)
+	}
+	c := {{ .Service.PkgName }}c.NewClient(cc)
+	return {{ .Service.PkgName }}.NewClient({{ range $i, $m := .Service.Methods }}{{ if $i }}, {{ end }}c.{{ $m.VarName }}(){{ end }}), func() {
+		cc.Close()
+		srv.Stop()
+	}
+}
+`
@@ -0,0 +1,42 @@
+package codegen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/grpc/codegen/testdata"
+)
+
+func TestInMemoryFiles(t *testing.T) {
+	cases := []struct {
+		Name    string
+		DSL     func()
+		Service string
+		Code    string
+	}{
+		{"unary-rpcs", testdata.UnaryRPCsDSL, "service_unary_rp_cs", testdata.UnaryRPCsInMemoryCode},
+		{"bidirectional-streaming-rpc", testdata.BidirectionalStreamingRPCDSL, "service_bidirectional_streaming_rpc", testdata.BidirectionalStreamingRPCInMemoryCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunGRPCDSL(t, c.DSL)
+			fs := InMemoryFiles("", expr.Root)
+			if len(fs) != 1 {
+				t.Fatalf("got %d files, expected one", len(fs))
+			}
+			if p := filepath.Join("gen", "grpc", c.Service, "inmem", "inmem.go"); fs[0].Path != p {
+				t.Errorf("got path %q, expected %q", fs[0].Path, p)
+			}
+			sections := fs[0].Section("inmem-client")
+			if len(sections) != 1 {
+				t.Fatalf("got %d sections, expected one", len(sections))
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
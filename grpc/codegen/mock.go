@@ -0,0 +1,69 @@
+package codegen
+
+import (
+	"path"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// MockServerFiles returns the file that serves the service mocks over gRPC in
+// the stub server command generated in gen/mock/stub, see
+// service.MockServerFile.
+func MockServerFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	if len(root.API.GRPC.Services) == 0 {
+		return nil
+	}
+	var (
+		svcs  []*ServiceData
+		specs = []*codegen.ImportSpec{
+			{Path: "log"},
+			{Path: "net"},
+			{Path: "google.golang.org/grpc"},
+		}
+	)
+	for _, svc := range root.API.GRPC.Services {
+		data := GRPCServices.Get(svc.Name())
+		svcName := codegen.SnakeCase(data.Service.VarName)
+		specs = append(specs,
+			&codegen.ImportSpec{Path: path.Join(genpkg, svcName), Name: data.Service.PkgName},
+			&codegen.ImportSpec{Path: path.Join(genpkg, "grpc", svcName, "server"), Name: data.Service.PkgName + "svr"},
+			&codegen.ImportSpec{Path: path.Join(genpkg, "grpc", svcName, pbPkgName), Name: svcName + pbPkgName},
+		)
+		svcs = append(svcs, data)
+	}
+	return []*codegen.File{{
+		Path: filepath.Join(codegen.Gendir, "mock", "stub", "grpc.go"),
+		SectionTemplates: []*codegen.SectionTemplate{
+			codegen.Header(root.API.Name+" gRPC stub server", "main", specs),
+			{
+				Name:    "mock-server-grpc",
+				Source:  mockServerGRPCT,
+				Data:    svcs,
+				FuncMap: map[string]interface{}{"goify": codegen.Goify},
+			},
+		},
+	}}
+}
+
+// input: []*ServiceData
+const mockServerGRPCT = `// serveGRPC serves the service mocks over gRPC on the given address.
+func serveGRPC(addr string{{ range . }}, {{ .Service.VarName }}Endpoints *{{ .Service.PkgName }}.Endpoints{{ end }}) error {
+	srv := grpc.NewServer()
+{{- range . }}
+	{{ .PkgName }}.Register{{ goify .Service.VarName true }}Server(srv, {{ .Service.PkgName }}svr.New({{ if .Endpoints }}{{ .Service.VarName }}Endpoints{{ else }}nil{{ end }}{{ if .HasUnaryEndpoint }}, nil{{ end }}{{ if .HasStreamingEndpoint }}, nil{{ end }}))
+{{- end }}
+	for svc, info := range srv.GetServiceInfo() {
+		for _, m := range info.Methods {
+			log.Printf("serving gRPC method %s", svc+"/"+m.Name)
+		}
+	}
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	log.Printf("gRPC stub server listening on %q", addr)
+	return srv.Serve(lis)
+}
+`
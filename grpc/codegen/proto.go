@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/expr"
@@ -27,6 +28,7 @@ func ProtoFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 
 func protoFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 	data := GRPCServices.Get(svc.Name())
+	includes, validate := protoValidate()
 	svcName := codegen.SnakeCase(data.Service.VarName)
 	path := filepath.Join(codegen.Gendir, "grpc", svcName, pbPkgName, svcName+".proto")
 
@@ -47,6 +49,7 @@ func protoFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 			Data: map[string]interface{}{
 				"ProtoVersion": ProtoVersion,
 				"Pkg":          codegen.SnakeCase(codegen.Goify(svcName, false)),
+				"Validate":     validate,
 			},
 		},
 		// service definition
@@ -61,15 +64,30 @@ func protoFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 	return &codegen.File{
 		Path:             path,
 		SectionTemplates: sections,
-		FinalizeFunc:     protoc,
+		Service:          svc.Name(),
+		FinalizeFunc: func(abs string) error {
+			// The output layout may locate the .proto file outside of
+			// the pb package directory, see codegen.OutputLayout.
+			base := strings.TrimSuffix(abs, codegen.Layout.Relocate(path))
+			return protoc(abs, filepath.Join(base, codegen.Layout.Relocate(filepath.Dir(path))), includes...)
+		},
 	}
 }
 
-func protoc(path string) error {
+// protoc compiles the .proto file at path and writes the generated Go code to
+// the directory out. includes lists additional directories searched for the
+// imported .proto files.
+func protoc(path, out string, includes ...string) error {
 	dir := filepath.Dir(path)
 	os.MkdirAll(dir, 0777)
+	os.MkdirAll(out, 0777)
 
-	args := []string{"--go_out=plugins=grpc:.", path, "--proto_path", dir}
+	args := []string{"--go_out=plugins=grpc:" + out, path, "--proto_path", dir}
+	for _, inc := range includes {
+		if inc != "" {
+			args = append(args, "--proto_path", inc)
+		}
+	}
 	cmd := exec.Command("protoc", args...)
 	cmd.Dir = filepath.Dir(path)
 
@@ -97,6 +115,10 @@ syntax = {{ printf "%q" .ProtoVersion }};
 package {{ .Pkg }};
 
 option go_package = "{{ .Pkg }}pb";
+{{- if .Validate }}
+
+import "validate/validate.proto";
+{{- end }}
 `
 
 	// input: ServiceData
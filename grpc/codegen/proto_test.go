@@ -1,6 +1,7 @@
 package codegen
 
 import (
+	"path/filepath"
 	"testing"
 
 	"goa.design/goa/v3/codegen"
@@ -40,7 +41,7 @@ func TestProtoFiles(t *testing.T) {
 				t.Errorf("%s: got\n%s\ngot vs. expected:\n%s", c.Name, code, codegen.Diff(t, code, c.Code))
 			}
 			fpath := codegen.CreateTempFile(t, code)
-			if err := protoc(fpath); err != nil {
+			if err := protoc(fpath, filepath.Dir(fpath)); err != nil {
 				t.Fatalf("error occurred when compiling proto file %q: %s", fpath, err)
 			}
 		})
@@ -80,9 +81,29 @@ func TestMessageDefSection(t *testing.T) {
 				t.Errorf("%s: got\n%s\ngot vs. expected:\n%s", c.Name, msgCode, codegen.Diff(t, msgCode, c.Code))
 			}
 			fpath := codegen.CreateTempFile(t, code+msgCode)
-			if err := protoc(fpath); err != nil {
+			if err := protoc(fpath, filepath.Dir(fpath)); err != nil {
 				t.Fatalf("error occurred when compiling proto file %q: %s", fpath, err)
 			}
 		})
 	}
 }
+
+func TestProtoValidateRules(t *testing.T) {
+	RunGRPCDSL(t, testdata.MessageWithValidateRulesDSL)
+	fs := ProtoFiles("", expr.Root)
+	if len(fs) != 1 {
+		t.Fatalf("got %d files, expected one", len(fs))
+	}
+	sections := fs[0].SectionTemplates
+	if len(sections) < 3 {
+		t.Fatalf("got %d sections, expected at least three", len(sections))
+	}
+	startCode := sectionCode(t, sections[1])
+	if startCode != testdata.MessageWithValidateRulesStartCode {
+		t.Errorf("got\n%s\ngot vs. expected:\n%s", startCode, codegen.Diff(t, startCode, testdata.MessageWithValidateRulesStartCode))
+	}
+	msgCode := sectionCode(t, sections[3:]...)
+	if msgCode != testdata.MessageWithValidateRulesCode {
+		t.Errorf("got\n%s\ngot vs. expected:\n%s", msgCode, codegen.Diff(t, msgCode, testdata.MessageWithValidateRulesCode))
+	}
+}
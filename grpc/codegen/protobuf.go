@@ -255,7 +255,7 @@ func protoBufMessageDef(att *expr.AttributeExpr, s *codegen.NameScope) string {
 					desc = codegen.Comment(nat.Attribute.Description) + "\n\t"
 				}
 			}
-			ss = append(ss, fmt.Sprintf("\t%s%s %s = %d;", desc, typ, fn, fnum))
+			ss = append(ss, fmt.Sprintf("\t%s%s %s = %d%s;", desc, typ, fn, fnum, protoBufValidateRules(nat.Attribute)))
 		}
 		ss = append(ss, "}")
 		return strings.Join(ss, "\n")
@@ -264,6 +264,71 @@ func protoBufMessageDef(att *expr.AttributeExpr, s *codegen.NameScope) string {
 	}
 }
 
+// protoValidate returns true if the design sets the "rpc:validate" API meta in
+// which case the generated .proto files annotate the message fields with the
+// protoc-gen-validate rules that correspond to the attribute validations. The
+// meta values are the directories containing the "validate/validate.proto"
+// file added to the protoc proto paths, e.g.:
+//
+//    Meta("rpc:validate", "/usr/local/include/protoc-gen-validate")
+//
+func protoValidate() ([]string, bool) {
+	if expr.Root == nil || expr.Root.API == nil {
+		return nil, false
+	}
+	v, ok := expr.Root.API.Meta["rpc:validate"]
+	return v, ok
+}
+
+// protoBufValidateRules returns the protoc-gen-validate field options that
+// correspond to the numeric validations of the given attribute, the empty
+// string if the "rpc:validate" API meta is not set or if there is none.
+// protoc-gen-validate does not support multiple of rules so these are only
+// enforced by the generated Go validation code.
+func protoBufValidateRules(att *expr.AttributeExpr) string {
+	if _, ok := protoValidate(); !ok || att.Validation == nil {
+		return ""
+	}
+	p, ok := att.Type.(expr.Primitive)
+	if !ok {
+		return ""
+	}
+	isInt := true
+	switch p.Kind() {
+	case expr.IntKind, expr.Int32Kind, expr.Int64Kind, expr.UIntKind, expr.UInt32Kind, expr.UInt64Kind:
+	case expr.Float32Kind, expr.Float64Kind:
+		isInt = false
+	default:
+		return ""
+	}
+	num := func(f float64) string {
+		if isInt {
+			return strconv.FormatInt(int64(f), 10)
+		}
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	var (
+		v     = att.Validation
+		rules []string
+	)
+	switch {
+	case v.ExclusiveMinimum != nil && (v.Minimum == nil || *v.ExclusiveMinimum >= *v.Minimum):
+		rules = append(rules, "gt: "+num(*v.ExclusiveMinimum))
+	case v.Minimum != nil:
+		rules = append(rules, "gte: "+num(*v.Minimum))
+	}
+	switch {
+	case v.ExclusiveMaximum != nil && (v.Maximum == nil || *v.ExclusiveMaximum <= *v.Maximum):
+		rules = append(rules, "lt: "+num(*v.ExclusiveMaximum))
+	case v.Maximum != nil:
+		rules = append(rules, "lte: "+num(*v.Maximum))
+	}
+	if len(rules) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" [(validate.rules).%s = {%s}]", protoBufNativeMessageTypeName(p), strings.Join(rules, ", "))
+}
+
 // protoBufGoFullTypeRef returns the Go code qualified with package name that
 // refers to the Go type generated by compiling the protocol buffer
 // (in *.pb.go) for the given attribute.
@@ -42,12 +42,14 @@ func serverFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 				{Path: "context"},
 				codegen.GoaImport(""),
 				codegen.GoaNamedImport("grpc", "goagrpc"),
+				codegen.GoaImport("security"),
 				{Path: "google.golang.org/grpc/codes"},
 				{Path: path.Join(genpkg, svcName), Name: data.Service.PkgName},
 				{Path: path.Join(genpkg, svcName, "views"), Name: data.Service.ViewsPkg},
 				{Path: path.Join(genpkg, "grpc", svcName, pbPkgName), Name: data.PkgName},
+				{Path: path.Join(genpkg, "grpc", "telemetry")},
 			}),
-			&codegen.SectionTemplate{Name: "server-struct", Source: serverStructT, Data: data},
+			&codegen.SectionTemplate{Name: "server-struct", Source: serverStructT, Data: data, Expr: svc},
 		}
 		for _, e := range data.Endpoints {
 			if e.ServerStream != nil {
@@ -62,17 +64,21 @@ func serverFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 			Name:   "server-init",
 			Source: serverInitT,
 			Data:   data,
+			Expr:   svc,
 		})
 		for _, e := range data.Endpoints {
+			ee := svc.Endpoint(e.Method.Name)
 			sections = append(sections, &codegen.SectionTemplate{
 				Name:   "handler-init",
 				Source: handlerInitT,
 				Data:   e,
+				Expr:   ee,
 			})
 			sections = append(sections, &codegen.SectionTemplate{
 				Name:   "server-grpc-interface",
 				Source: serverGRPCInterfaceT,
 				Data:   e,
+				Expr:   ee,
 			})
 		}
 		for _, e := range data.Endpoints {
@@ -108,7 +114,7 @@ func serverFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 			}
 		}
 	}
-	return &codegen.File{Path: fpath, SectionTemplates: sections}
+	return &codegen.File{Path: fpath, SectionTemplates: sections, Service: svc.Name()}
 }
 
 // serverEncodeDecode returns the file defining the gRPC server encoding and
@@ -140,11 +146,13 @@ func serverEncodeDecode(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File
 		}
 
 		for _, e := range data.Endpoints {
+			ee := svc.Endpoint(e.Method.Name)
 			if e.Response.ServerConvert != nil {
 				sections = append(sections, &codegen.SectionTemplate{
 					Name:   "response-encoder",
 					Source: responseEncoderT,
 					Data:   e,
+					Expr:   ee,
 					FuncMap: map[string]interface{}{
 						"typeConversionData":       typeConversionData,
 						"metadataEncodeDecodeData": metadataEncodeDecodeData,
@@ -159,11 +167,12 @@ func serverEncodeDecode(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File
 					Source:  requestDecoderT,
 					Data:    e,
 					FuncMap: fm,
+					Expr:    ee,
 				})
 			}
 		}
 	}
-	return &codegen.File{Path: fpath, SectionTemplates: sections}
+	return &codegen.File{Path: fpath, SectionTemplates: sections, Service: svc.Name()}
 }
 
 func transTmplFuncs(s *expr.GRPCServiceExpr) map[string]interface{} {
@@ -213,7 +222,7 @@ const serverInitT = `{{ printf "%s instantiates the server struct with the %s se
 func {{ .ServerInit }}(e *{{ .Service.PkgName }}.Endpoints{{ if .HasUnaryEndpoint }}, uh goagrpc.UnaryHandler{{ end }}{{ if .HasStreamingEndpoint }}, sh goagrpc.StreamHandler{{ end }}) *{{ .ServerStruct }} {
 	return &{{ .ServerStruct }}{
 	{{- range .Endpoints }}
-		{{ .Method.VarName }}H: New{{ .Method.VarName }}Handler(e.{{ .Method.VarName }}{{ if .ServerStream }}, sh{{ else }}, uh{{ end }}),
+		{{ .Method.VarName }}H: {{ if .Telemetry }}telemetry.{{ if .ServerStream }}Stream{{ else }}Unary{{ end }}Handler({{ end }}New{{ .Method.VarName }}Handler(e.{{ .Method.VarName }}{{ if .ServerStream }}, sh{{ else }}, uh{{ end }}){{ if .Telemetry }}, {{ printf "%q" .ServiceName }}, {{ printf "%q" .Method.Name }}){{ end }},
 	{{- end }}
 	}
 }
@@ -240,6 +249,15 @@ func (s *{{ .ServerStruct }}) {{ .Method.VarName }}(
 {{- end }}
 	ctx = context.WithValue(ctx, goa.MethodKey, {{ printf "%q" .Method.Name }})
 	ctx = context.WithValue(ctx, goa.ServiceKey, {{ printf "%q" .ServiceName }})
+{{- if .Method.AuthorizationInput }}
+	ctx = security.WithTransportMetadata(ctx, goagrpc.RequestMetadata(ctx{{ range .CredentialMetadata }}, {{ printf "%q" . }}{{ end }}))
+{{- end }}
+{{- if .PropagatedHeaders }}
+	ctx = goagrpc.PropagateIncoming(ctx, &goa.Propagation{Headers: []string{ {{- range $i, $h := .PropagatedHeaders }}{{ if $i }}, {{ end }}{{ printf "%q" $h }}{{ end }} }})
+{{- end }}
+{{- with .Tenancy }}
+	ctx = goagrpc.TenantIncoming(ctx, &goa.Tenancy{Header: {{ printf "%q" .Header }}, Claim: {{ printf "%q" .Claim }}})
+{{- end }}
 
 {{- if .ServerStream }}
 	p, err := s.{{ .Method.VarName }}H.Decode(ctx, {{ if .Method.StreamingPayload }}nil{{ else }}message{{ end }})
@@ -260,6 +278,17 @@ func (s *{{ .ServerStruct }}) {{ .Method.VarName }}(
 
 {{- define "handle_error" }}
 	if err != nil {
+	{{- if and .Errors .Method.Requirements }}
+		if aerr, ok := err.(*security.AuthError); ok {
+			{{ comment "Encode the error returned by the last authorization function if it is described in the design." }}
+			if en, ok := aerr.Last().(ErrorNamer); ok {
+				switch en.ErrorName() {
+				case {{ range $i, $err := .Errors }}{{ if $i }}, {{ end }}{{ printf "%q" .Name }}{{ end }}:
+					err = aerr.Last()
+				}
+			}
+		}
+	{{- end }}
 	{{- if .Errors }}
 		if en, ok := err.(ErrorNamer); ok {
 			switch en.ErrorName() {
@@ -273,7 +302,7 @@ func (s *{{ .ServerStruct }}) {{ .Method.VarName }}(
 			}
 		}
 	{{- end }}
-		return {{ if not $.ServerStream }}nil, {{ end }}goagrpc.EncodeError(err)
+		return {{ if not $.ServerStream }}nil, {{ end }}goagrpc.EncodeError(goa.LocalizeError(ctx, err))
 	}
 {{- end }}
 `
@@ -385,6 +414,25 @@ func Decode{{ .Method.VarName }}Request(ctx context.Context, v interface{}, md m
 			}
 		{{- end }}
 	{{- end }}
+{{- end }}
+{{- with .MTLSScheme }}
+	{{- if or .CredPointer .CredRequired }}
+		cert := goagrpc.PeerCertificate(ctx)
+		{{- if .CredRequired }}
+		if cert == "" {
+			return nil, goa.MissingFieldError({{ printf "%q" .KeyAttr }}, "TLS client certificate")
+		}
+		{{- end }}
+		{{- if .CredPointer }}
+		if cert != "" {
+			payload.{{ .CredField }} = &cert
+		}
+		{{- else }}
+		payload.{{ .CredField }} = cert
+		{{- end }}
+	{{- else }}
+		payload.{{ .CredField }} = goagrpc.PeerCertificate(ctx)
+	{{- end }}
 {{- end }}
 	}
 	return payload, nil
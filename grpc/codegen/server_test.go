@@ -25,6 +25,9 @@ func TestServerGRPCInterface(t *testing.T) {
 		{"bidirectional-streaming-rpc", testdata.BidirectionalStreamingRPCDSL, testdata.BidirectionalStreamingRPCServerInterfaceCode},
 		{"bidirectional-streaming-rpc-with-payload", testdata.BidirectionalStreamingRPCWithPayloadDSL, testdata.BidirectionalStreamingRPCWithPayloadServerInterfaceCode},
 		{"bidirectional-streaming-rpc-with-errors", testdata.BidirectionalStreamingRPCWithErrorsDSL, testdata.BidirectionalStreamingRPCWithErrorsServerInterfaceCode},
+		{"unary-rpc-with-propagation", testdata.UnaryRPCWithPropagationDSL, testdata.UnaryRPCWithPropagationServerInterfaceCode},
+		{"unary-rpc-with-security-errors", testdata.UnaryRPCWithSecurityErrorsDSL, testdata.UnaryRPCWithSecurityErrorsServerInterfaceCode},
+		{"unary-rpc-with-authorization", testdata.UnaryRPCWithAuthorizationDSL, testdata.UnaryRPCWithAuthorizationServerInterfaceCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -94,6 +97,7 @@ func TestRequestDecoder(t *testing.T) {
 		{"payload-with-metadata", testdata.MessageWithMetadataDSL, testdata.PayloadWithMetadataRequestDecoderCode},
 		{"payload-with-validate", testdata.MessageWithValidateDSL, testdata.PayloadWithValidateRequestDecoderCode},
 		{"payload-with-security-attributes", testdata.MessageWithSecurityAttrsDSL, testdata.PayloadWithSecurityAttrsRequestDecoderCode},
+		{"payload-with-client-certificate", testdata.MessageWithClientCertificateDSL, testdata.PayloadWithClientCertificateRequestDecoderCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
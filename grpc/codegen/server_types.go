@@ -75,6 +75,8 @@ func serverType(genpkg string, svc *expr.GRPCServiceExpr, seen map[string]struct
 		sections = []*codegen.SectionTemplate{
 			codegen.Header(svc.Name()+" gRPC server types", "server",
 				[]*codegen.ImportSpec{
+					{Path: "fmt"},
+					{Path: "strconv"},
 					{Path: "unicode/utf8"},
 					codegen.GoaImport(""),
 					{Path: path.Join(genpkg, svcName), Name: sd.Service.PkgName},
@@ -111,7 +113,7 @@ func serverType(genpkg string, svc *expr.GRPCServiceExpr, seen map[string]struct
 			})
 		}
 	}
-	return &codegen.File{Path: fpath, SectionTemplates: sections}
+	return &codegen.File{Path: fpath, SectionTemplates: sections, Service: svc.Name()}
 }
 
 // input: TransformFunctionData
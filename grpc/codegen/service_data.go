@@ -2,6 +2,7 @@ package codegen
 
 import (
 	"fmt"
+	"strings"
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/codegen/service"
@@ -87,8 +88,24 @@ type (
 		// MessageSchemes lists all the security requirement schemes that
 		// apply to the method and are encoded in the request message.
 		MessageSchemes service.SchemesData
+		// CredentialMetadata lists the request metadata keys other than
+		// "authorization" that carry the credentials of the service
+		// security schemes. They are omitted from the transport metadata
+		// given to the authorization policies.
+		CredentialMetadata []string
+		// MTLSScheme is the mutual TLS security scheme if any.
+		MTLSScheme *service.SchemeData
 		// Errors describes the method gRPC errors.
 		Errors []*ErrorData
+		// PropagatedHeaders lists the names of the metadata propagated
+		// from the incoming requests to the outgoing requests.
+		PropagatedHeaders []string
+		// Tenancy is the tenant settings used by the server to record
+		// the tenant claim of the bearer token of the requests if any.
+		Tenancy *expr.TenantExpr
+		// Telemetry is true if the endpoint server and client are
+		// instrumented with OpenTelemetry.
+		Telemetry bool
 
 		// server side
 
@@ -571,14 +588,17 @@ func (d ServicesData) analyze(gs *expr.GRPCServiceExpr) *ServiceData {
 		var (
 			msgSch service.SchemesData
 			metSch service.SchemesData
+			mSch   *service.SchemeData
 		)
 		{
 			for _, req := range e.Requirements {
 				for _, sch := range req.Schemes {
 					s := md.Requirements.Scheme(sch.SchemeName).Dup()
 					s.In = sch.In
-					switch s.In {
-					case "message":
+					switch {
+					case s.Type == "MTLS":
+						mSch = s
+					case s.In == "message":
 						msgSch = msgSch.Append(s)
 					default:
 						metSch = metSch.Append(s)
@@ -586,23 +606,39 @@ func (d ServicesData) analyze(gs *expr.GRPCServiceExpr) *ServiceData {
 				}
 			}
 		}
+		// gather the tenant claim settings
+		var tenancy *expr.TenantExpr
+		if t := expr.Root.API.Tenant; t != nil && t.Claim != "" {
+			tenancy = t
+		}
+
+		// gather propagated metadata, deadlines are propagated natively
+		var propagated []string
+		if p := e.MethodExpr.Service.Propagate(); p != nil {
+			propagated = p.Headers
+		}
+
 		ed := &EndpointData{
-			ServiceName:     svc.Name,
-			PkgName:         sd.PkgName,
-			ServicePkgName:  svc.PkgName,
-			Method:          md,
-			PayloadRef:      payloadRef,
-			ResultRef:       resultRef,
-			ViewedResultRef: viewedResultRef,
-			Request:         request,
-			Response:        response,
-			MessageSchemes:  msgSch,
-			MetadataSchemes: metSch,
-			Errors:          errors,
-			ServerStruct:    sd.ServerStruct,
-			ServerInterface: sd.ServerInterface,
-			ClientStruct:    sd.ClientStruct,
-			ClientInterface: sd.ClientInterface,
+			ServiceName:       svc.Name,
+			PkgName:           sd.PkgName,
+			ServicePkgName:    svc.PkgName,
+			Method:            md,
+			PayloadRef:        payloadRef,
+			ResultRef:         resultRef,
+			ViewedResultRef:   viewedResultRef,
+			Request:           request,
+			Response:          response,
+			MessageSchemes:    msgSch,
+			MetadataSchemes:   metSch,
+			MTLSScheme:        mSch,
+			Errors:            errors,
+			PropagatedHeaders: propagated,
+			Tenancy:           tenancy,
+			Telemetry:         e.MethodExpr.Service.IsOTelInstrumented(),
+			ServerStruct:      sd.ServerStruct,
+			ServerInterface:   sd.ServerInterface,
+			ClientStruct:      sd.ClientStruct,
+			ClientInterface:   sd.ClientInterface,
 		}
 		sd.Endpoints = append(sd.Endpoints, ed)
 		if e.MethodExpr.IsStreaming() {
@@ -610,9 +646,37 @@ func (d ServicesData) analyze(gs *expr.GRPCServiceExpr) *ServiceData {
 			ed.ClientStream = buildStreamData(e, sd, false)
 		}
 	}
+	buildCredentialMetadata(sd, gs)
 	return sd
 }
 
+// buildCredentialMetadata initializes the request metadata keys that carry the
+// credentials of the service security schemes. The keys are omitted from the
+// transport metadata of all the service endpoints so that credentials sent to
+// endpoints that do not use them are not leaked either.
+func buildCredentialMetadata(sd *ServiceData, gs *expr.GRPCServiceExpr) {
+	var keys []string
+	seen := map[string]struct{}{"authorization": {}}
+	for _, e := range gs.GRPCEndpoints {
+		for _, req := range e.Requirements {
+			for _, s := range req.Schemes {
+				key := strings.ToLower(s.Name)
+				if s.In != "metadata" || key == "" {
+					continue
+				}
+				if _, ok := seen[key]; ok {
+					continue
+				}
+				seen[key] = struct{}{}
+				keys = append(keys, key)
+			}
+		}
+	}
+	for _, ed := range sd.Endpoints {
+		ed.CredentialMetadata = keys
+	}
+}
+
 // collectMessages recurses through the attribute to gather all the messages.
 func collectMessages(at *expr.AttributeExpr, sd *ServiceData, seen map[string]struct{}) (data []*service.UserTypeData) {
 	if at == nil {
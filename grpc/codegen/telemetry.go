@@ -0,0 +1,244 @@
+package codegen
+
+import (
+	"path"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// TelemetryFiles returns the file that implements the OpenTelemetry
+// instrumentation of the gRPC servers and clients of the services that use the
+// "observability:otel" meta. The generated package wraps the unary and stream
+// handlers and the client invokers so that each RPC creates a span that
+// continues the trace propagated in the request metadata and records the RPC
+// duration.
+func TelemetryFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	if !otelInstrumented(root) {
+		return nil
+	}
+	fpath := filepath.Join(codegen.Gendir, "grpc", "telemetry", "telemetry.go")
+	sections := []*codegen.SectionTemplate{
+		codegen.Header("gRPC OpenTelemetry instrumentation", "telemetry", []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "sync"},
+			{Path: "time"},
+			{Path: "go.opentelemetry.io/otel"},
+			{Path: "go.opentelemetry.io/otel/attribute"},
+			{Path: "go.opentelemetry.io/otel/codes", Name: "otelcodes"},
+			{Path: "go.opentelemetry.io/otel/metric"},
+			{Path: "go.opentelemetry.io/otel/trace"},
+			{Path: "google.golang.org/grpc/codes"},
+			{Path: "google.golang.org/grpc/metadata"},
+			{Path: "google.golang.org/grpc/status"},
+			codegen.GoaNamedImport("grpc", "goagrpc"),
+		}),
+		{
+			Name:   "grpc-telemetry",
+			Source: telemetryT,
+			Data:   map[string]interface{}{"ScopeName": path.Join(genpkg, "grpc", "telemetry")},
+		},
+	}
+	return []*codegen.File{{Path: fpath, SectionTemplates: sections}}
+}
+
+// otelInstrumented returns true if at least one of the gRPC services is
+// instrumented with OpenTelemetry.
+func otelInstrumented(root *expr.RootExpr) bool {
+	for _, svc := range root.API.GRPC.Services {
+		if svc.ServiceExpr.IsOTelInstrumented() {
+			return true
+		}
+	}
+	return false
+}
+
+// input: map[string]interface{}{"ScopeName": string}
+const telemetryT = `// ScopeName is the name of the instrumentation scope of the tracer and of the
+// meter used by the generated gRPC servers and clients.
+const ScopeName = {{ printf "%q" .ScopeName }}
+
+var (
+	instrumentsOnce sync.Once
+	serverDuration  metric.Float64Histogram
+	clientDuration  metric.Float64Histogram
+)
+
+type (
+	// unaryHandler is a goagrpc.UnaryHandler that creates a server span
+	// for each request.
+	unaryHandler struct {
+		goagrpc.UnaryHandler
+		service, method string
+	}
+
+	// streamHandler is a goagrpc.StreamHandler that creates a server span
+	// for each stream.
+	streamHandler struct {
+		goagrpc.StreamHandler
+		service, method string
+	}
+
+	// invoker is a goagrpc.Invoker that creates a client span for each
+	// request.
+	invoker struct {
+		goagrpc.Invoker
+		service, method string
+	}
+
+	// metadataCarrier adapts gRPC metadata to the OpenTelemetry
+	// propagation.TextMapCarrier interface.
+	metadataCarrier metadata.MD
+)
+
+// UnaryHandler wraps the handler of the given unary service method so that
+// each request creates a server span and records the request duration in the
+// "rpc.server.duration" histogram. The span continues the trace propagated in
+// the incoming metadata if any. Errors mapped to server failure status codes
+// (e.g. Internal or Unavailable) set the span status to error.
+func UnaryHandler(h goagrpc.UnaryHandler, service, method string) goagrpc.UnaryHandler {
+	return &unaryHandler{UnaryHandler: h, service: service, method: method}
+}
+
+// StreamHandler wraps the handler of the given streaming service method so
+// that each stream creates a server span that lasts until the method returns
+// and records the stream duration in the "rpc.server.duration" histogram.
+func StreamHandler(h goagrpc.StreamHandler, service, method string) goagrpc.StreamHandler {
+	return &streamHandler{StreamHandler: h, service: service, method: method}
+}
+
+// Invoker wraps the invoker used to make the requests to the given service
+// method so that each request creates a client span, propagates the trace in
+// the outgoing metadata and records the request duration in the
+// "rpc.client.duration" histogram. For streaming methods the span covers the
+// stream creation. Errors set the span status to error.
+func Invoker(inv goagrpc.Invoker, service, method string) goagrpc.Invoker {
+	return &invoker{Invoker: inv, service: service, method: method}
+}
+
+// Handle creates a server span and calls the wrapped handler.
+func (h *unaryHandler) Handle(ctx context.Context, reqpb interface{}) (interface{}, error) {
+	ctx, span, attrs := startServer(ctx, h.service, h.method)
+	defer span.End()
+	start := time.Now()
+	res, err := h.UnaryHandler.Handle(ctx, reqpb)
+	endServer(ctx, span, attrs, start, err)
+	return res, err
+}
+
+// Handle creates a server span and calls the wrapped handler.
+func (h *streamHandler) Handle(ctx context.Context, input interface{}) error {
+	ctx, span, attrs := startServer(ctx, h.service, h.method)
+	defer span.End()
+	start := time.Now()
+	err := h.StreamHandler.Handle(ctx, input)
+	endServer(ctx, span, attrs, start, err)
+	return err
+}
+
+// Invoke creates a client span, propagates the trace in the outgoing metadata
+// and calls the wrapped invoker.
+func (i *invoker) Invoke(ctx context.Context, req interface{}) (interface{}, error) {
+	attrs := rpcAttributes(i.service, i.method)
+	ctx, span := otel.Tracer(ScopeName).Start(ctx, i.service+"/"+i.method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+	defer span.End()
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	start := time.Now()
+	res, err := i.Invoker.Invoke(ctx, req)
+	code := status.Code(err)
+	attrs = append(attrs, attribute.Int("rpc.grpc.status_code", int(code)))
+	span.SetAttributes(attrs[len(attrs)-1])
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	instruments()
+	clientDuration.Record(ctx, float64(time.Since(start))/float64(time.Millisecond), metric.WithAttributes(attrs...))
+	return res, err
+}
+
+// Get returns the first value of the metadata with the given key.
+func (c metadataCarrier) Get(key string) string {
+	if vals := metadata.MD(c).Get(key); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
+// Set sets the metadata with the given key.
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys returns the metadata keys.
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// startServer starts a server span for a request made to the given service
+// method.
+func startServer(ctx context.Context, service, method string) (context.Context, trace.Span, []attribute.KeyValue) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	attrs := rpcAttributes(service, method)
+	ctx, span := otel.Tracer(ScopeName).Start(ctx, service+"/"+method,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attrs...))
+	return ctx, span, attrs
+}
+
+// endServer records the outcome of a server request in the span and in the
+// server duration histogram.
+func endServer(ctx context.Context, span trace.Span, attrs []attribute.KeyValue, start time.Time, err error) {
+	code := codes.OK
+	if err != nil {
+		code = status.Code(goagrpc.EncodeError(err))
+	}
+	attrs = append(attrs, attribute.Int("rpc.grpc.status_code", int(code)))
+	span.SetAttributes(attrs[len(attrs)-1])
+	switch code {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented, codes.Internal, codes.Unavailable, codes.DataLoss:
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	instruments()
+	serverDuration.Record(ctx, float64(time.Since(start))/float64(time.Millisecond), metric.WithAttributes(attrs...))
+}
+
+// rpcAttributes returns the span and metric attributes that identify the
+// given service method.
+func rpcAttributes(service, method string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.service", service),
+		attribute.String("rpc.method", method),
+		attribute.String("goa.service", service),
+		attribute.String("goa.method", method),
+	}
+}
+
+// instruments initializes the metric instruments using the global meter
+// provider.
+func instruments() {
+	instrumentsOnce.Do(func() {
+		meter := otel.Meter(ScopeName)
+		serverDuration, _ = meter.Float64Histogram("rpc.server.duration",
+			metric.WithUnit("ms"),
+			metric.WithDescription("Duration of the gRPC server requests."))
+		clientDuration, _ = meter.Float64Histogram("rpc.client.duration",
+			metric.WithUnit("ms"),
+			metric.WithDescription("Duration of the gRPC client requests."))
+	})
+}
+`
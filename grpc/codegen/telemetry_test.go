@@ -0,0 +1,63 @@
+package codegen
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/grpc/codegen/testdata"
+)
+
+func TestTelemetryFiles(t *testing.T) {
+	RunGRPCDSL(t, testdata.UnaryRPCsDSL)
+	if fs := TelemetryFiles("gen", expr.Root); len(fs) != 0 {
+		t.Errorf("got %d files for a service that is not instrumented, expected none", len(fs))
+	}
+
+	RunGRPCDSL(t, testdata.TelemetryDSL)
+	fs := TelemetryFiles("gen", expr.Root)
+	if len(fs) != 1 {
+		t.Fatalf("got %d files, expected one", len(fs))
+	}
+	if p := filepath.Join("gen", "grpc", "telemetry", "telemetry.go"); fs[0].Path != p {
+		t.Errorf("got path %q, expected %q", fs[0].Path, p)
+	}
+	sections := fs[0].Section("grpc-telemetry")
+	if len(sections) != 1 {
+		t.Fatalf("got %d sections, expected one", len(sections))
+	}
+	code := codegen.SectionCode(t, sections[0])
+	for _, s := range []string{`const ScopeName = "gen/grpc/telemetry"`, "func UnaryHandler(", "func StreamHandler(", "func Invoker("} {
+		if !strings.Contains(code, s) {
+			t.Errorf("got code missing %q:\n%s", s, code)
+		}
+	}
+}
+
+func TestTelemetry(t *testing.T) {
+	RunGRPCDSL(t, testdata.TelemetryDSL)
+	fs := ClientFiles("", expr.Root)
+	cases := []struct {
+		Name    string
+		File    *codegen.File
+		Section string
+		Code    string
+	}{
+		{"server-init", ServerFiles("", expr.Root)[0], "server-init", testdata.TelemetryServerInitCode},
+		{"client-endpoint-init", fs[0], "client-endpoint-init", testdata.TelemetryClientUnaryEndpointInitCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			sections := c.File.Section(c.Section)
+			if len(sections) == 0 {
+				t.Fatalf("got no %s section", c.Section)
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
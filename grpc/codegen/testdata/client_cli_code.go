@@ -0,0 +1,84 @@
+package testdata
+
+var UnaryRPCsClientCLIStreamCode = `// Stream returns the client stream returned by a streaming endpoint adapted to
+// be run by the CLI tool, nil if data is not a client stream.
+func Stream(data interface{}) *goacli.Stream {
+	return nil
+}
+`
+
+var ServerStreamingRPCClientCLIStreamCode = `// Stream returns the client stream returned by a streaming endpoint adapted to
+// be run by the CLI tool, nil if data is not a client stream.
+func Stream(data interface{}) *goacli.Stream {
+	switch stream := data.(type) {
+	case *serviceserverstreamingrpcc.MethodServerStreamingRPCClientStream:
+		return &goacli.Stream{
+			Recv: func() (interface{}, error) { return stream.Recv() },
+		}
+	}
+	return nil
+}
+`
+
+var ClientStreamingRPCClientCLIStreamCode = `// Stream returns the client stream returned by a streaming endpoint adapted to
+// be run by the CLI tool, nil if data is not a client stream.
+func Stream(data interface{}) *goacli.Stream {
+	switch stream := data.(type) {
+	case *serviceclientstreamingrpcc.MethodClientStreamingRPCClientStream:
+		return &goacli.Stream{
+			Send: func(data []byte) error {
+				var v int
+				if err := json.Unmarshal(data, &v); err != nil {
+					return err
+				}
+				return stream.Send(v)
+			},
+			Close: func() (interface{}, error) { return stream.CloseAndRecv() },
+		}
+	}
+	return nil
+}
+`
+
+var ClientStreamingNoResultClientCLIStreamCode = `// Stream returns the client stream returned by a streaming endpoint adapted to
+// be run by the CLI tool, nil if data is not a client stream.
+func Stream(data interface{}) *goacli.Stream {
+	switch stream := data.(type) {
+	case *serviceclientstreamingnoresultc.MethodClientStreamingNoResultClientStream:
+		return &goacli.Stream{
+			Send: func(data []byte) error {
+				var v int
+				if err := json.Unmarshal(data, &v); err != nil {
+					return err
+				}
+				return stream.Send(v)
+			},
+			Close:     func() (interface{}, error) { return nil, stream.Close() },
+			HalfClose: true,
+		}
+	}
+	return nil
+}
+`
+
+var BidirectionalStreamingRPCClientCLIStreamCode = `// Stream returns the client stream returned by a streaming endpoint adapted to
+// be run by the CLI tool, nil if data is not a client stream.
+func Stream(data interface{}) *goacli.Stream {
+	switch stream := data.(type) {
+	case *servicebidirectionalstreamingrpcc.MethodBidirectionalStreamingRPCClientStream:
+		return &goacli.Stream{
+			Send: func(data []byte) error {
+				var v int
+				if err := json.Unmarshal(data, &v); err != nil {
+					return err
+				}
+				return stream.Send(v)
+			},
+			Recv:      func() (interface{}, error) { return stream.Recv() },
+			Close:     func() (interface{}, error) { return nil, stream.Close() },
+			HalfClose: true,
+		}
+	}
+	return nil
+}
+`
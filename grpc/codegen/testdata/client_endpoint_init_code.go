@@ -236,3 +236,23 @@ func (c *Client) MethodBidirectionalStreamingRPCWithErrors() goa.Endpoint {
 	}
 }
 `
+
+var UnaryRPCWithPropagationClientEndpointInitCode = `// MethodUnaryRPCWithPropagation calls the "MethodUnaryRPCWithPropagation"
+// function in
+// service_unary_rpc_with_propagationpb.ServiceUnaryRPCWithPropagationClient
+// interface.
+func (c *Client) MethodUnaryRPCWithPropagation() goa.Endpoint {
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		inv := goagrpc.NewInvoker(
+			BuildMethodUnaryRPCWithPropagationFunc(c.grpccli, c.opts...),
+			EncodeMethodUnaryRPCWithPropagationRequest,
+			DecodeMethodUnaryRPCWithPropagationResponse)
+		ctx = goagrpc.PropagateOutgoing(ctx, &goa.Propagation{Headers: []string{"X-Request-Id", "traceparent"}})
+		res, err := inv.Invoke(ctx, v)
+		if err != nil {
+			return nil, goa.Fault(err.Error())
+		}
+		return res, nil
+	}
+}
+`
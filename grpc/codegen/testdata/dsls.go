@@ -526,6 +526,30 @@ var MessagePrimitiveDSL = func() {
 	})
 }
 
+var MessageWithValidateRulesDSL = func() {
+	var _ = API("test", func() {
+		Meta("rpc:validate", "")
+	})
+	var RequestUT = Type("RequestUT", func() {
+		Field(1, "Count", Int, func() {
+			Minimum(1)
+			ExclusiveMaximum(100)
+		})
+		Field(2, "Ratio", Float64, func() {
+			ExclusiveMinimum(0)
+			Maximum(1)
+			MultipleOf(0.25)
+		})
+		Field(3, "Name", String)
+	})
+	Service("ServiceMessageWithValidateRules", func() {
+		Method("MethodMessageWithValidateRules", func() {
+			Payload(RequestUT)
+			GRPC(func() {})
+		})
+	})
+}
+
 var MessageWithMetadataDSL = func() {
 	var UTLevel1 = Type("UTLevel1", func() {
 		Field(1, "Int32Field", Int32)
@@ -676,3 +700,150 @@ var MultipleMethodsSameResultCollectionDSL = func() {
 		})
 	})
 }
+
+var UnaryRPCWithPropagationDSL = func() {
+	API("API", func() {
+		Propagate("X-Request-Id", "traceparent")
+	})
+	Service("ServiceUnaryRPCWithPropagation", func() {
+		Method("MethodUnaryRPCWithPropagation", func() {
+			Payload(String)
+			Result(String)
+			GRPC(func() {})
+		})
+	})
+}
+
+var MessageWithClientCertificateDSL = func() {
+	var MTLS = MTLSSecurity("mtls")
+	Service("ServiceMessageWithClientCertificate", func() {
+		Method("MethodMessageWithClientCertificate", func() {
+			Security(MTLS)
+			Payload(func() {
+				Field(1, "amount", Int)
+				ClientCertificate("cert", String)
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
+var UnaryRPCWithSecurityErrorsDSL = func() {
+	var JWT = JWTSecurity("jwt")
+	Service("ServiceUnaryRPCWithSecurityErrors", func() {
+		Method("MethodUnaryRPCWithSecurityErrors", func() {
+			Security(JWT)
+			Payload(func() {
+				Token("token", String)
+			})
+			Result(String)
+			Error("unauthenticated")
+			GRPC(func() {
+				Response("unauthenticated", CodeUnauthenticated)
+			})
+		})
+	})
+}
+
+var UnaryRPCWithAuthorizationDSL = func() {
+	var APIKeyAuth = APIKeySecurity("api_key")
+	Service("ServiceUnaryRPCWithAuthorization", func() {
+		Method("MethodUnaryRPCWithAuthorization", func() {
+			Meta("authorize")
+			Security(APIKeyAuth)
+			Payload(func() {
+				APIKey("api_key", "key", String)
+			})
+			Result(String)
+			GRPC(func() {
+				Metadata(func() {
+					Attribute("key:x-api-key")
+				})
+			})
+		})
+	})
+}
+
+var FuzzDSL = func() {
+	API("fuzz", func() {
+		Meta("fuzz:test")
+	})
+	Service("ServiceFuzz", func() {
+		Method("MethodMessage", func() {
+			Payload(func() {
+				Field(1, "vintage_year", Int, func() {
+					Minimum(1900)
+					Example(1999)
+				})
+				Field(2, "name", String, func() {
+					Example("wine")
+				})
+				Attribute("trace", String, func() {
+					Example("abc")
+				})
+				Required("name")
+			})
+			GRPC(func() {
+				Metadata(func() {
+					Attribute("trace:x-trace")
+				})
+			})
+		})
+		Method("MethodNoPayload", func() {
+			GRPC(func() {})
+		})
+		Method("MethodStreamingPayload", func() {
+			StreamingPayload(String)
+			GRPC(func() {})
+		})
+	})
+}
+
+var FixturesDSL = func() {
+	API("fixtures", func() {
+		Meta("fixtures:record")
+	})
+	Service("ServiceFixtures", func() {
+		Method("MethodMessage", func() {
+			Payload(func() {
+				Field(1, "vintage_year", Int, func() {
+					Example(1999)
+				})
+				Field(2, "name", String, func() {
+					Example("wine")
+				})
+			})
+			Result(func() {
+				Field(1, "id", String, func() {
+					Example("abc")
+				})
+			})
+			GRPC(func() {})
+		})
+		Method("MethodNoPayload", func() {
+			GRPC(func() {})
+		})
+		Method("MethodStreamingPayload", func() {
+			StreamingPayload(String, func() {
+				Example("chunk")
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
+var TelemetryDSL = func() {
+	Service("ServiceTelemetry", func() {
+		Meta("observability:otel")
+		Method("MethodUnary", func() {
+			Payload(String)
+			Result(String)
+			GRPC(func() {})
+		})
+		Method("MethodStreaming", func() {
+			StreamingPayload(String)
+			StreamingResult(String)
+			GRPC(func() {})
+		})
+	})
+}
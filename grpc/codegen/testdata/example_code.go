@@ -2,7 +2,7 @@ package testdata
 
 const NoServerServerHandleCode = `// handleGRPCServer starts configures and starts a gRPC server on the given
 // URL. It shuts down the server if any error is received in the error channel.
-func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service.Endpoints, wg *sync.WaitGroup, errc chan error, drainer *goa.Drainer, reporter goa.PanicReporter, logger *log.Logger, debug bool) {
 
 	// Setup goa log adapter.
 	var (
@@ -28,6 +28,7 @@ func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 		grpcmiddleware.WithUnaryServerChain(
 			grpcmdlwr.UnaryRequestID(),
 			grpcmdlwr.UnaryServerLog(adapter),
+			grpcmdlwr.UnaryServerRecover(reporter),
 		),
 	)
 
@@ -56,14 +57,29 @@ func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 
 		<-ctx.Done()
 		logger.Printf("shutting down gRPC server at %q", u.Host)
-		srv.Stop()
+
+		// Stop accepting new connections and wait for the in-flight requests and
+		// streams to complete within the grace period, then close the remaining
+		// connections.
+		drainer.Drain()
+		stopped := make(chan struct{})
+		go func() {
+			srv.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(drainer.Grace):
+			logger.Printf("gRPC server at %q did not drain within %v", u.Host, drainer.Grace)
+			srv.Stop()
+		}
 	}()
 }
 `
 
 const ServerHostingServiceSubsetServerHandleCode = `// handleGRPCServer starts configures and starts a gRPC server on the given
 // URL. It shuts down the server if any error is received in the error channel.
-func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service.Endpoints, wg *sync.WaitGroup, errc chan error, drainer *goa.Drainer, reporter goa.PanicReporter, logger *log.Logger, debug bool) {
 
 	// Setup goa log adapter.
 	var (
@@ -89,6 +105,7 @@ func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 		grpcmiddleware.WithUnaryServerChain(
 			grpcmdlwr.UnaryRequestID(),
 			grpcmdlwr.UnaryServerLog(adapter),
+			grpcmdlwr.UnaryServerRecover(reporter),
 		),
 	)
 
@@ -117,14 +134,29 @@ func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 
 		<-ctx.Done()
 		logger.Printf("shutting down gRPC server at %q", u.Host)
-		srv.Stop()
+
+		// Stop accepting new connections and wait for the in-flight requests and
+		// streams to complete within the grace period, then close the remaining
+		// connections.
+		drainer.Drain()
+		stopped := make(chan struct{})
+		go func() {
+			srv.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(drainer.Grace):
+			logger.Printf("gRPC server at %q did not drain within %v", u.Host, drainer.Grace)
+			srv.Stop()
+		}
 	}()
 }
 `
 
 const ServerHostingMultipleServicesServerHandleCode = `// handleGRPCServer starts configures and starts a gRPC server on the given
 // URL. It shuts down the server if any error is received in the error channel.
-func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service.Endpoints, anotherServiceEndpoints *anotherservice.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service.Endpoints, anotherServiceEndpoints *anotherservice.Endpoints, wg *sync.WaitGroup, errc chan error, drainer *goa.Drainer, reporter goa.PanicReporter, logger *log.Logger, debug bool) {
 
 	// Setup goa log adapter.
 	var (
@@ -152,6 +184,7 @@ func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 		grpcmiddleware.WithUnaryServerChain(
 			grpcmdlwr.UnaryRequestID(),
 			grpcmdlwr.UnaryServerLog(adapter),
+			grpcmdlwr.UnaryServerRecover(reporter),
 		),
 	)
 
@@ -181,7 +214,22 @@ func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 
 		<-ctx.Done()
 		logger.Printf("shutting down gRPC server at %q", u.Host)
-		srv.Stop()
+
+		// Stop accepting new connections and wait for the in-flight requests and
+		// streams to complete within the grace period, then close the remaining
+		// connections.
+		drainer.Drain()
+		stopped := make(chan struct{})
+		go func() {
+			srv.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(drainer.Grace):
+			logger.Printf("gRPC server at %q did not drain within %v", u.Host, drainer.Grace)
+			srv.Stop()
+		}
 	}()
 }
 `
@@ -191,6 +239,7 @@ const ExampleCLIImport = `import (
 	cli "grpc/cli/test_api"
 	"os"
 
+	goacli "goa.design/goa/v3/cli"
 	goa "goa.design/goa/v3/pkg"
 	"google.golang.org/grpc"
 )
@@ -201,6 +250,7 @@ const ExampleSingleHostCLIImport = `import (
 	cli "grpc/cli/single_host"
 	"os"
 
+	goacli "goa.design/goa/v3/cli"
 	goa "goa.design/goa/v3/pkg"
 	"google.golang.org/grpc"
 )
@@ -211,6 +261,7 @@ const ExamplePkgPathCLIImport = `import (
 	cli "my/pkg/path/grpc/cli/test_api"
 	"os"
 
+	goacli "goa.design/goa/v3/cli"
 	goa "goa.design/goa/v3/pkg"
 	"google.golang.org/grpc"
 )
@@ -221,6 +272,7 @@ const ExampleSingleHostPkgPathCLIImport = `import (
 	cli "my/pkg/path/grpc/cli/single_host"
 	"os"
 
+	goacli "goa.design/goa/v3/cli"
 	goa "goa.design/goa/v3/pkg"
 	"google.golang.org/grpc"
 )
@@ -233,4 +285,49 @@ const ExampleCLICode = `func doGRPC(scheme, host string, timeout int, debug bool
 	}
 	return cli.ParseEndpoint(conn)
 }
+
+func grpcStream(data interface{}) *goacli.Stream {
+	return cli.Stream(data)
+}
+`
+
+const ExampleCredentialsCLICode = `import (
+	"fmt"
+	cli "grpc/cli/test_api"
+	"os"
+
+	goacli "goa.design/goa/v3/cli"
+	goagrpc "goa.design/goa/v3/grpc"
+	goa "goa.design/goa/v3/pkg"
+	"goa.design/goa/v3/security/credentials"
+	"google.golang.org/grpc"
+)
+
+func doGRPC(scheme, host string, timeout int, debug bool) (goa.Endpoint, interface{}, error) {
+	conn, err := grpc.Dial(host, grpc.WithInsecure())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, fmt.Sprintf("could not connect to gRPC server at %s: %v", host, err))
+	}
+	// The security flags not given explicitly are initialized with the
+	// credentials of the host stored in the environment or in the
+	// credentials file, see credentials.DefaultStore.
+	creds := credentials.NewProfile(host, credentials.DefaultStore("test_api"))
+	return cli.ParseEndpoint(conn, goagrpc.WithCredentials(creds))
+}
+
+func grpcUsageCommands() string {
+	return cli.UsageCommands()
+}
+
+func grpcUsageExamples() string {
+	return cli.UsageExamples()
+}
+
+func grpcCommands() []*goacli.Command {
+	return cli.Commands()
+}
+
+func grpcStream(data interface{}) *goacli.Stream {
+	return cli.Stream(data)
+}
 `
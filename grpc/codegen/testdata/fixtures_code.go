@@ -0,0 +1,57 @@
+package testdata
+
+const FixturesMethodMessageRequest = `{
+  "name": "wine",
+  "vintage_year": 1999
+}
+`
+
+const FixturesMethodMessageResponse = `{
+  "id": "abc"
+}
+`
+
+const FixturesMethodStreamingPayloadRequest = `{
+  "field": "chunk"
+}
+`
+
+const FixturesCode = `// Methods lists the "ServiceFixtures" service methods that have recorded
+// fixtures.
+var Methods = []string{"MethodMessage", "MethodNoPayload", "MethodStreamingPayload"}
+
+// files maps the methods to the base names of their fixture files.
+var files = map[string]string{
+	"MethodMessage":          "method_message",
+	"MethodNoPayload":        "method_no_payload",
+	"MethodStreamingPayload": "method_streaming_payload",
+}
+
+// Request unmarshals the request message recorded for the given method into
+// msg, a pointer to the protocol buffer message type.
+func Request(method string, msg interface{}) error {
+	return load(method, "request", msg)
+}
+
+// Response unmarshals the response message recorded for the given method into
+// msg, a pointer to the protocol buffer message type.
+func Response(method string, msg interface{}) error {
+	return load(method, "response", msg)
+}
+
+// load unmarshals the fixture file of the given kind recorded for the given
+// method into msg. The fixture files are located in the directory of this
+// source file.
+func load(method, kind string, msg interface{}) error {
+	f, ok := files[method]
+	if !ok {
+		return fmt.Errorf("no fixture recorded for method %q", method)
+	}
+	_, src, _, _ := runtime.Caller(0)
+	b, err := ioutil.ReadFile(filepath.Join(filepath.Dir(src), f+"."+kind+".json"))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, msg)
+}
+`
@@ -0,0 +1,27 @@
+package testdata
+
+const FuzzCode = `// FuzzMethodMessageDecodeRequest makes sure that transforming and validating
+// the "ServiceFuzz" service "MethodMessage" requests does not panic. The
+// corpus is seeded with the message built from the design examples.
+func FuzzMethodMessageDecodeRequest(f *testing.F) {
+	var seed service_fuzzpb.MethodMessageRequest
+	// The examples may not map to all the message fields, the fields that
+	// cannot be unmarshaled are left empty.
+	json.Unmarshal([]byte("{\"name\":\"wine\",\"vintage_year\":1999}"), &seed)
+	b, err := proto.Marshal(&seed)
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(b, "abc")
+	f.Fuzz(func(t *testing.T, b []byte, trace string) {
+		var message service_fuzzpb.MethodMessageRequest
+		if err := proto.Unmarshal(b, &message); err != nil {
+			return
+		}
+		md := metadata.MD{}
+		md.Set("x-trace", trace)
+		// Invalid requests must be rejected with an error.
+		DecodeMethodMessageRequest(context.Background(), &message, md)
+	})
+}
+`
@@ -0,0 +1,53 @@
+package testdata
+
+const UnaryRPCsInMemoryCode = `// NewClient returns a "ServiceUnaryRPCs" service client whose requests are
+// served by svc over gRPC through an in-memory connection, without opening
+// network ports. The function returned alongside the client closes the
+// connection and stops the server, it must be called once the client is no
+// longer needed.
+func NewClient(svc serviceunaryrpcs.Service) (*serviceunaryrpcs.Client, func()) {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	service_unary_rp_cspb.RegisterServiceUnaryRPCsServer(srv, serviceunaryrpcssvr.New(serviceunaryrpcs.NewEndpoints(svc), nil))
+	go srv.Serve(lis)
+
+	dial := func(context.Context, string) (net.Conn, error) { return lis.Dial() }
+	cc, err := grpc.DialContext(context.Background(), "inmem", grpc.WithContextDialer(dial), grpc.WithInsecure())
+	if err != nil {
+		// The connection is established lazily, dialing fails only if
+		// the options are invalid.
+		panic(err)
+	}
+	c := serviceunaryrpcsc.NewClient(cc)
+	return serviceunaryrpcs.NewClient(c.MethodUnaryRPCA(), c.MethodUnaryRPCB()), func() {
+		cc.Close()
+		srv.Stop()
+	}
+}
+`
+
+const BidirectionalStreamingRPCInMemoryCode = `// NewClient returns a "ServiceBidirectionalStreamingRPC" service client whose
+// requests are served by svc over gRPC through an in-memory connection,
+// without opening network ports. The function returned alongside the client
+// closes the connection and stops the server, it must be called once the
+// client is no longer needed.
+func NewClient(svc servicebidirectionalstreamingrpc.Service) (*servicebidirectionalstreamingrpc.Client, func()) {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	service_bidirectional_streaming_rpcpb.RegisterServiceBidirectionalStreamingRPCServer(srv, servicebidirectionalstreamingrpcsvr.New(servicebidirectionalstreamingrpc.NewEndpoints(svc), nil))
+	go srv.Serve(lis)
+
+	dial := func(context.Context, string) (net.Conn, error) { return lis.Dial() }
+	cc, err := grpc.DialContext(context.Background(), "inmem", grpc.WithContextDialer(dial), grpc.WithInsecure())
+	if err != nil {
+		// The connection is established lazily, dialing fails only if
+		// the options are invalid.
+		panic(err)
+	}
+	c := servicebidirectionalstreamingrpcc.NewClient(cc)
+	return servicebidirectionalstreamingrpc.NewClient(c.MethodBidirectionalStreamingRPC()), func() {
+		cc.Close()
+		srv.Stop()
+	}
+}
+`
@@ -0,0 +1,37 @@
+package testdata
+
+const UnaryRPCsMockServerCode = `// serveGRPC serves the service mocks over gRPC on the given address.
+func serveGRPC(addr string, serviceUnaryRPCsEndpoints *serviceunaryrpcs.Endpoints) error {
+	srv := grpc.NewServer()
+	service_unary_rp_cspb.RegisterServiceUnaryRPCsServer(srv, serviceunaryrpcssvr.New(serviceUnaryRPCsEndpoints, nil))
+	for svc, info := range srv.GetServiceInfo() {
+		for _, m := range info.Methods {
+			log.Printf("serving gRPC method %s", svc+"/"+m.Name)
+		}
+	}
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	log.Printf("gRPC stub server listening on %q", addr)
+	return srv.Serve(lis)
+}
+`
+
+const BidirectionalStreamingRPCMockServerCode = `// serveGRPC serves the service mocks over gRPC on the given address.
+func serveGRPC(addr string, serviceBidirectionalStreamingRPCEndpoints *servicebidirectionalstreamingrpc.Endpoints) error {
+	srv := grpc.NewServer()
+	service_bidirectional_streaming_rpcpb.RegisterServiceBidirectionalStreamingRPCServer(srv, servicebidirectionalstreamingrpcsvr.New(serviceBidirectionalStreamingRPCEndpoints, nil))
+	for svc, info := range srv.GetServiceInfo() {
+		for _, m := range info.Methods {
+			log.Printf("serving gRPC method %s", svc+"/"+m.Name)
+		}
+	}
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	log.Printf("gRPC stub server listening on %q", addr)
+	return srv.Serve(lis)
+}
+`
@@ -316,6 +316,27 @@ message MethodMessagePrimitiveResponse {
 }
 `
 
+const MessageWithValidateRulesStartCode = `
+syntax = "proto3";
+
+package service_message_with_validate_rules;
+
+option go_package = "service_message_with_validate_rulespb";
+
+import "validate/validate.proto";
+`
+
+const MessageWithValidateRulesCode = `
+message MethodMessageWithValidateRulesRequest {
+	sint32 count = 1 [(validate.rules).sint32 = {gte: 1, lt: 100}];
+	double ratio = 2 [(validate.rules).double = {gt: 0, lte: 1}];
+	string name = 3;
+}
+
+message MethodMessageWithValidateRulesResponse {
+}
+`
+
 const MessageWithMetadataCode = `
 message MethodMessageWithMetadataRequest {
 	bool boolean_field = 1;
@@ -291,3 +291,28 @@ func DecodeMethodMessageWithSecurityRequest(ctx context.Context, v interface{},
 	return payload, nil
 }
 `
+
+const PayloadWithClientCertificateRequestDecoderCode = `// DecodeMethodMessageWithClientCertificateRequest decodes requests sent to
+// "ServiceMessageWithClientCertificate" service
+// "MethodMessageWithClientCertificate" endpoint.
+func DecodeMethodMessageWithClientCertificateRequest(ctx context.Context, v interface{}, md metadata.MD) (interface{}, error) {
+	var (
+		message *service_message_with_client_certificatepb.MethodMessageWithClientCertificateRequest
+		ok      bool
+	)
+	{
+		if message, ok = v.(*service_message_with_client_certificatepb.MethodMessageWithClientCertificateRequest); !ok {
+			return nil, goagrpc.ErrInvalidType("ServiceMessageWithClientCertificate", "MethodMessageWithClientCertificate", "*service_message_with_client_certificatepb.MethodMessageWithClientCertificateRequest", v)
+		}
+	}
+	var payload *servicemessagewithclientcertificate.MethodMessageWithClientCertificatePayload
+	{
+		payload = NewMethodMessageWithClientCertificatePayload(message)
+		cert := goagrpc.PeerCertificate(ctx)
+		if cert != "" {
+			payload.Cert = &cert
+		}
+	}
+	return payload, nil
+}
+`
@@ -7,7 +7,7 @@ func (s *Server) MethodUnaryRPCA(ctx context.Context, message *service_unary_rp_
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCs")
 	resp, err := s.MethodUnaryRPCAH.Handle(ctx, message)
 	if err != nil {
-		return nil, goagrpc.EncodeError(err)
+		return nil, goagrpc.EncodeError(goa.LocalizeError(ctx, err))
 	}
 	return resp.(*service_unary_rp_cspb.MethodUnaryRPCAResponse), nil
 }
@@ -19,7 +19,7 @@ func (s *Server) MethodUnaryRPCB(ctx context.Context, message *service_unary_rp_
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCs")
 	resp, err := s.MethodUnaryRPCBH.Handle(ctx, message)
 	if err != nil {
-		return nil, goagrpc.EncodeError(err)
+		return nil, goagrpc.EncodeError(goa.LocalizeError(ctx, err))
 	}
 	return resp.(*service_unary_rp_cspb.MethodUnaryRPCBResponse), nil
 }
@@ -32,7 +32,7 @@ func (s *Server) MethodUnaryRPCNoPayload(ctx context.Context, message *service_u
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCNoPayload")
 	resp, err := s.MethodUnaryRPCNoPayloadH.Handle(ctx, message)
 	if err != nil {
-		return nil, goagrpc.EncodeError(err)
+		return nil, goagrpc.EncodeError(goa.LocalizeError(ctx, err))
 	}
 	return resp.(*service_unary_rpc_no_payloadpb.MethodUnaryRPCNoPayloadResponse), nil
 }
@@ -45,7 +45,7 @@ func (s *Server) MethodUnaryRPCNoResult(ctx context.Context, message *service_un
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCNoResult")
 	resp, err := s.MethodUnaryRPCNoResultH.Handle(ctx, message)
 	if err != nil {
-		return nil, goagrpc.EncodeError(err)
+		return nil, goagrpc.EncodeError(goa.LocalizeError(ctx, err))
 	}
 	return resp.(*service_unary_rpc_no_resultpb.MethodUnaryRPCNoResultResponse), nil
 }
@@ -73,7 +73,7 @@ func (s *Server) MethodUnaryRPCWithErrors(ctx context.Context, message *service_
 				return nil, goagrpc.NewStatusError(codes.Unknown, err, NewMethodUnaryRPCWithErrorsCustomErrorError(er))
 			}
 		}
-		return nil, goagrpc.EncodeError(err)
+		return nil, goagrpc.EncodeError(goa.LocalizeError(ctx, err))
 	}
 	return resp.(*service_unary_rpc_with_errorspb.MethodUnaryRPCWithErrorsResponse), nil
 }
@@ -96,7 +96,7 @@ func (s *Server) MethodUnaryRPCWithOverridingErrors(ctx context.Context, message
 				return nil, goagrpc.NewStatusError(codes.Unknown, err, goagrpc.NewErrorResponse(err))
 			}
 		}
-		return nil, goagrpc.EncodeError(err)
+		return nil, goagrpc.EncodeError(goa.LocalizeError(ctx, err))
 	}
 	return resp.(*service_unary_rpc_with_overriding_errorspb.MethodUnaryRPCWithOverridingErrorsResponse), nil
 }
@@ -110,7 +110,7 @@ func (s *Server) MethodServerStreamingRPC(message *service_server_streaming_rpcp
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceServerStreamingRPC")
 	p, err := s.MethodServerStreamingRPCH.Decode(ctx, message)
 	if err != nil {
-		return goagrpc.EncodeError(err)
+		return goagrpc.EncodeError(goa.LocalizeError(ctx, err))
 	}
 	ep := &serviceserverstreamingrpc.MethodServerStreamingRPCEndpointInput{
 		Stream:  &MethodServerStreamingRPCServerStream{stream: stream},
@@ -118,7 +118,7 @@ func (s *Server) MethodServerStreamingRPC(message *service_server_streaming_rpcp
 	}
 	err = s.MethodServerStreamingRPCH.Handle(ctx, ep)
 	if err != nil {
-		return goagrpc.EncodeError(err)
+		return goagrpc.EncodeError(goa.LocalizeError(ctx, err))
 	}
 	return nil
 }
@@ -132,14 +132,14 @@ func (s *Server) MethodClientStreamingRPC(stream service_client_streaming_rpcpb.
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceClientStreamingRPC")
 	p, err := s.MethodClientStreamingRPCH.Decode(ctx, nil)
 	if err != nil {
-		return goagrpc.EncodeError(err)
+		return goagrpc.EncodeError(goa.LocalizeError(ctx, err))
 	}
 	ep := &serviceclientstreamingrpc.MethodClientStreamingRPCEndpointInput{
 		Stream: &MethodClientStreamingRPCServerStream{stream: stream},
 	}
 	err = s.MethodClientStreamingRPCH.Handle(ctx, ep)
 	if err != nil {
-		return goagrpc.EncodeError(err)
+		return goagrpc.EncodeError(goa.LocalizeError(ctx, err))
 	}
 	return nil
 }
@@ -155,7 +155,7 @@ func (s *Server) MethodClientStreamingRPCWithPayload(stream service_client_strea
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceClientStreamingRPCWithPayload")
 	p, err := s.MethodClientStreamingRPCWithPayloadH.Decode(ctx, nil)
 	if err != nil {
-		return goagrpc.EncodeError(err)
+		return goagrpc.EncodeError(goa.LocalizeError(ctx, err))
 	}
 	ep := &serviceclientstreamingrpcwithpayload.MethodClientStreamingRPCWithPayloadEndpointInput{
 		Stream:  &MethodClientStreamingRPCWithPayloadServerStream{stream: stream},
@@ -163,7 +163,7 @@ func (s *Server) MethodClientStreamingRPCWithPayload(stream service_client_strea
 	}
 	err = s.MethodClientStreamingRPCWithPayloadH.Handle(ctx, ep)
 	if err != nil {
-		return goagrpc.EncodeError(err)
+		return goagrpc.EncodeError(goa.LocalizeError(ctx, err))
 	}
 	return nil
 }
@@ -179,14 +179,14 @@ func (s *Server) MethodBidirectionalStreamingRPC(stream service_bidirectional_st
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceBidirectionalStreamingRPC")
 	p, err := s.MethodBidirectionalStreamingRPCH.Decode(ctx, nil)
 	if err != nil {
-		return goagrpc.EncodeError(err)
+		return goagrpc.EncodeError(goa.LocalizeError(ctx, err))
 	}
 	ep := &servicebidirectionalstreamingrpc.MethodBidirectionalStreamingRPCEndpointInput{
 		Stream: &MethodBidirectionalStreamingRPCServerStream{stream: stream},
 	}
 	err = s.MethodBidirectionalStreamingRPCH.Handle(ctx, ep)
 	if err != nil {
-		return goagrpc.EncodeError(err)
+		return goagrpc.EncodeError(goa.LocalizeError(ctx, err))
 	}
 	return nil
 }
@@ -202,7 +202,7 @@ func (s *Server) MethodBidirectionalStreamingRPCWithPayload(stream service_bidir
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceBidirectionalStreamingRPCWithPayload")
 	p, err := s.MethodBidirectionalStreamingRPCWithPayloadH.Decode(ctx, nil)
 	if err != nil {
-		return goagrpc.EncodeError(err)
+		return goagrpc.EncodeError(goa.LocalizeError(ctx, err))
 	}
 	ep := &servicebidirectionalstreamingrpcwithpayload.MethodBidirectionalStreamingRPCWithPayloadEndpointInput{
 		Stream:  &MethodBidirectionalStreamingRPCWithPayloadServerStream{stream: stream},
@@ -210,7 +210,7 @@ func (s *Server) MethodBidirectionalStreamingRPCWithPayload(stream service_bidir
 	}
 	err = s.MethodBidirectionalStreamingRPCWithPayloadH.Handle(ctx, ep)
 	if err != nil {
-		return goagrpc.EncodeError(err)
+		return goagrpc.EncodeError(goa.LocalizeError(ctx, err))
 	}
 	return nil
 }
@@ -236,7 +236,7 @@ func (s *Server) MethodBidirectionalStreamingRPCWithErrors(stream service_bidire
 				return goagrpc.NewStatusError(codes.InvalidArgument, err, goagrpc.NewErrorResponse(err))
 			}
 		}
-		return goagrpc.EncodeError(err)
+		return goagrpc.EncodeError(goa.LocalizeError(ctx, err))
 	}
 	ep := &servicebidirectionalstreamingrpcwitherrors.MethodBidirectionalStreamingRPCWithErrorsEndpointInput{
 		Stream: &MethodBidirectionalStreamingRPCWithErrorsServerStream{stream: stream},
@@ -253,8 +253,71 @@ func (s *Server) MethodBidirectionalStreamingRPCWithErrors(stream service_bidire
 				return goagrpc.NewStatusError(codes.InvalidArgument, err, goagrpc.NewErrorResponse(err))
 			}
 		}
-		return goagrpc.EncodeError(err)
+		return goagrpc.EncodeError(goa.LocalizeError(ctx, err))
 	}
 	return nil
 }
 `
+
+var UnaryRPCWithPropagationServerInterfaceCode = `// MethodUnaryRPCWithPropagation implements the "MethodUnaryRPCWithPropagation"
+// method in
+// service_unary_rpc_with_propagationpb.ServiceUnaryRPCWithPropagationServer
+// interface.
+func (s *Server) MethodUnaryRPCWithPropagation(ctx context.Context, message *service_unary_rpc_with_propagationpb.MethodUnaryRPCWithPropagationRequest) (*service_unary_rpc_with_propagationpb.MethodUnaryRPCWithPropagationResponse, error) {
+	ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnaryRPCWithPropagation")
+	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCWithPropagation")
+	ctx = goagrpc.PropagateIncoming(ctx, &goa.Propagation{Headers: []string{"X-Request-Id", "traceparent"}})
+	resp, err := s.MethodUnaryRPCWithPropagationH.Handle(ctx, message)
+	if err != nil {
+		return nil, goagrpc.EncodeError(goa.LocalizeError(ctx, err))
+	}
+	return resp.(*service_unary_rpc_with_propagationpb.MethodUnaryRPCWithPropagationResponse), nil
+}
+`
+
+const UnaryRPCWithSecurityErrorsServerInterfaceCode = `// MethodUnaryRPCWithSecurityErrors implements the
+// "MethodUnaryRPCWithSecurityErrors" method in
+// service_unary_rpc_with_security_errorspb.ServiceUnaryRPCWithSecurityErrorsServer
+// interface.
+func (s *Server) MethodUnaryRPCWithSecurityErrors(ctx context.Context, message *service_unary_rpc_with_security_errorspb.MethodUnaryRPCWithSecurityErrorsRequest) (*service_unary_rpc_with_security_errorspb.MethodUnaryRPCWithSecurityErrorsResponse, error) {
+	ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnaryRPCWithSecurityErrors")
+	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCWithSecurityErrors")
+	resp, err := s.MethodUnaryRPCWithSecurityErrorsH.Handle(ctx, message)
+	if err != nil {
+		if aerr, ok := err.(*security.AuthError); ok {
+			// Encode the error returned by the last authorization function if it is
+			// described in the design.
+			if en, ok := aerr.Last().(ErrorNamer); ok {
+				switch en.ErrorName() {
+				case "unauthenticated":
+					err = aerr.Last()
+				}
+			}
+		}
+		if en, ok := err.(ErrorNamer); ok {
+			switch en.ErrorName() {
+			case "unauthenticated":
+				return nil, goagrpc.NewStatusError(codes.Unauthenticated, err, goagrpc.NewErrorResponse(err))
+			}
+		}
+		return nil, goagrpc.EncodeError(goa.LocalizeError(ctx, err))
+	}
+	return resp.(*service_unary_rpc_with_security_errorspb.MethodUnaryRPCWithSecurityErrorsResponse), nil
+}
+`
+
+const UnaryRPCWithAuthorizationServerInterfaceCode = `// MethodUnaryRPCWithAuthorization implements the
+// "MethodUnaryRPCWithAuthorization" method in
+// service_unary_rpc_with_authorizationpb.ServiceUnaryRPCWithAuthorizationServer
+// interface.
+func (s *Server) MethodUnaryRPCWithAuthorization(ctx context.Context, message *service_unary_rpc_with_authorizationpb.MethodUnaryRPCWithAuthorizationRequest) (*service_unary_rpc_with_authorizationpb.MethodUnaryRPCWithAuthorizationResponse, error) {
+	ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnaryRPCWithAuthorization")
+	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCWithAuthorization")
+	ctx = security.WithTransportMetadata(ctx, goagrpc.RequestMetadata(ctx, "x-api-key"))
+	resp, err := s.MethodUnaryRPCWithAuthorizationH.Handle(ctx, message)
+	if err != nil {
+		return nil, goagrpc.EncodeError(goa.LocalizeError(ctx, err))
+	}
+	return resp.(*service_unary_rpc_with_authorizationpb.MethodUnaryRPCWithAuthorizationResponse), nil
+}
+`
@@ -0,0 +1,28 @@
+package testdata
+
+const TelemetryServerInitCode = `// New instantiates the server struct with the ServiceTelemetry service
+// endpoints.
+func New(e *servicetelemetry.Endpoints, uh goagrpc.UnaryHandler, sh goagrpc.StreamHandler) *Server {
+	return &Server{
+		MethodUnaryH:     telemetry.UnaryHandler(NewMethodUnaryHandler(e.MethodUnary, uh), "ServiceTelemetry", "MethodUnary"),
+		MethodStreamingH: telemetry.StreamHandler(NewMethodStreamingHandler(e.MethodStreaming, sh), "ServiceTelemetry", "MethodStreaming"),
+	}
+}
+`
+
+const TelemetryClientUnaryEndpointInitCode = `// MethodUnary calls the "MethodUnary" function in
+// service_telemetrypb.ServiceTelemetryClient interface.
+func (c *Client) MethodUnary() goa.Endpoint {
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		inv := telemetry.Invoker(goagrpc.NewInvoker(
+			BuildMethodUnaryFunc(c.grpccli, c.opts...),
+			EncodeMethodUnaryRequest,
+			DecodeMethodUnaryResponse), "ServiceTelemetry", "MethodUnary")
+		res, err := inv.Invoke(ctx, v)
+		if err != nil {
+			return nil, goa.Fault(err.Error())
+		}
+		return res, nil
+	}
+}
+`
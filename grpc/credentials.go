@@ -0,0 +1,31 @@
+package grpc
+
+import (
+	"goa.design/goa/v3/security/credentials"
+	"google.golang.org/grpc"
+)
+
+// credentialsOption is the call option that carries the credentials profile.
+type credentialsOption struct {
+	grpc.EmptyCallOption
+	profile *credentials.Profile
+}
+
+// WithCredentials returns a call option that gives the credentials profile to
+// the ParseEndpoint function of the generated command line clients. The
+// generated clients use the profile to initialize the security flags that are
+// not given explicitly. The option has no effect on the gRPC calls.
+func WithCredentials(p *credentials.Profile) grpc.CallOption {
+	return credentialsOption{profile: p}
+}
+
+// CallCredentials returns the credentials profile given with WithCredentials,
+// nil if there is none.
+func CallCredentials(opts []grpc.CallOption) *credentials.Profile {
+	for _, o := range opts {
+		if c, ok := o.(credentialsOption); ok {
+			return c.profile
+		}
+	}
+	return nil
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/golang/protobuf/proto"
 	goapb "goa.design/goa/v3/grpc/pb"
 	goa "goa.design/goa/v3/pkg"
+	"goa.design/goa/v3/security"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -36,6 +38,9 @@ type (
 // characteristics. If the error is not a goa ServiceError, it creates an
 // ErrorResponse message with the Fault field set to true.
 func NewErrorResponse(err error) *goapb.ErrorResponse {
+	if aerr, ok := err.(*security.AuthError); ok {
+		err = aerr.ServiceError()
+	}
 	if gerr, ok := err.(*goa.ServiceError); ok {
 		return &goapb.ErrorResponse{
 			Name:      gerr.Name,
@@ -62,6 +67,24 @@ func NewServiceError(resp *goapb.ErrorResponse) *goa.ServiceError {
 	}
 }
 
+// NewBadRequest returns the google.rpc.BadRequest message that lists the
+// validation rules violated by the request if err is a validation error, nil
+// otherwise. The field paths are computed with goa.ProtoFieldPath.
+func NewBadRequest(err *goa.ServiceError) *errdetails.BadRequest {
+	vs := err.Violations()
+	if len(vs) == 0 {
+		return nil
+	}
+	fvs := make([]*errdetails.BadRequest_FieldViolation, len(vs))
+	for i, v := range vs {
+		fvs[i] = &errdetails.BadRequest_FieldViolation{
+			Field:       goa.ProtoFieldPath(v.Field),
+			Description: v.Message,
+		}
+	}
+	return &errdetails.BadRequest{FieldViolations: fvs}
+}
+
 // NewStatusError creates a gRPC status error with the error response
 // messages added to its details.
 func NewStatusError(code codes.Code, err error, details ...proto.Message) error {
@@ -75,9 +98,13 @@ func NewStatusError(code codes.Code, err error, details ...proto.Message) error
 // EncodeError returns a gRPC status error from the given error with the error
 // response encoded in the status details. If error is a goa ServiceError type
 // it implements a heuristic to compute the status code from the Timeout,
-// Fault, and Temporary characteristics of the ServiceError. If error is not a
-// ServiceError or a gRPC status error it returns a gRPC status error with
-// Unknown code and Fault characteristic set.
+// Fault, and Temporary characteristics of the ServiceError, overloaded errors
+// (see goa.OverloadedError) map to ResourceExhausted. If error is a
+// security AuthError it returns a gRPC status error with Unauthenticated code.
+// If error is not a ServiceError or a gRPC status error it returns a gRPC
+// status error with Unknown code and Fault characteristic set. The details of
+// the status errors created from validation errors also include a
+// google.rpc.BadRequest message listing the violations, see NewBadRequest.
 func EncodeError(err error) error {
 	if st, ok := status.FromError(err); ok {
 		if s, err := st.WithDetails(NewErrorResponse(err)); err == nil {
@@ -85,6 +112,10 @@ func EncodeError(err error) error {
 		}
 		return st.Err()
 	}
+	if _, ok := err.(*security.AuthError); ok {
+		// None of the security requirements of the method is satisfied.
+		return NewStatusError(codes.Unauthenticated, err, NewErrorResponse(err))
+	}
 	if gerr, ok := err.(*goa.ServiceError); ok {
 		// goa service error type. Compute the status code from the service error
 		// characteristics and create a new detailed gRPC status error.
@@ -100,6 +131,12 @@ func EncodeError(err error) error {
 			if gerr.Temporary {
 				code = codes.Unavailable
 			}
+			if gerr.Name == goa.OverloadedErrorName {
+				code = codes.ResourceExhausted
+			}
+		}
+		if br := NewBadRequest(gerr); br != nil {
+			return NewStatusError(code, err, NewErrorResponse(err), br)
 		}
 		return NewStatusError(code, err, NewErrorResponse(err))
 	}
@@ -0,0 +1,63 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// HealthWatchInterval is the interval at which the health servers created with
+// NewHealthServer refresh the status sent to the Watch streams.
+var HealthWatchInterval = 5 * time.Second
+
+// healthServer implements the standard gRPC health service with a goa.Health.
+type healthServer struct {
+	health *goa.Health
+}
+
+// NewHealthServer returns an implementation of the standard gRPC health
+// service (grpc.health.v1.Health) that reports the status of the server as
+// SERVING if the readiness report of h is ok and NOT_SERVING otherwise. The
+// status is the same for all the services served by the server. The code
+// generated for the HealthCheck DSL registers the health server with the gRPC
+// servers.
+func NewHealthServer(h *goa.Health) grpc_health_v1.HealthServer {
+	return &healthServer{health: h}
+}
+
+// Check returns the serving status of the server.
+func (s *healthServer) Check(ctx context.Context, _ *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	return &grpc_health_v1.HealthCheckResponse{Status: s.status(ctx)}, nil
+}
+
+// Watch sends the serving status of the server and then sends it again each
+// time it changes until the stream is canceled.
+func (s *healthServer) Watch(_ *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	ctx := stream.Context()
+	ticker := time.NewTicker(HealthWatchInterval)
+	defer ticker.Stop()
+	last := grpc_health_v1.HealthCheckResponse_UNKNOWN
+	for {
+		if st := s.status(ctx); st != last {
+			if err := stream.Send(&grpc_health_v1.HealthCheckResponse{Status: st}); err != nil {
+				return err
+			}
+			last = st
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// status returns the serving status matching the readiness report.
+func (s *healthServer) status(ctx context.Context) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	if s.health.Ready(ctx).OK() {
+		return grpc_health_v1.HealthCheckResponse_SERVING
+	}
+	return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"context"
+
+	goagrpc "goa.design/goa/v3/grpc"
+	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/grpc"
+)
+
+// UnaryServerRecover returns a middleware that recovers from the panics raised
+// by the unary handlers, reports them with r and returns the fault error
+// encoded as a gRPC status error with code Internal.
+func UnaryServerRecover(r goa.PanicReporter) grpc.UnaryServerInterceptor {
+	return grpc.UnaryServerInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				resp, err = nil, goagrpc.EncodeError(goa.PanicError(ctx, r, p))
+			}
+		}()
+		return handler(ctx, req)
+	})
+}
+
+// StreamServerRecover returns a middleware that recovers from the panics
+// raised by the streaming handlers, reports them with r and returns the fault
+// error encoded as a gRPC status error with code Internal.
+func StreamServerRecover(r goa.PanicReporter) grpc.StreamServerInterceptor {
+	return grpc.StreamServerInterceptor(func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = goagrpc.EncodeError(goa.PanicError(stream.Context(), r, p))
+			}
+		}()
+		return handler(srv, stream)
+	})
+}
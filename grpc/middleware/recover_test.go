@@ -0,0 +1,45 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+
+	grpcm "goa.design/goa/v3/grpc/middleware"
+	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServerRecover(t *testing.T) {
+	var reported []interface{}
+	reporter := goa.PanicReporterFunc(func(_ context.Context, p interface{}, stack []byte) {
+		if len(stack) == 0 {
+			t.Error("got empty stack trace")
+		}
+		reported = append(reported, p)
+	})
+
+	unary := grpcm.UnaryServerRecover(reporter)
+	_, err := unary(context.Background(), "request", &grpc.UnaryServerInfo{FullMethod: "Test.Test"},
+		func(context.Context, interface{}) (interface{}, error) { panic("unary") })
+	if c := status.Code(err); c != codes.Internal {
+		t.Errorf("got unary status code %s, expected %s", c, codes.Internal)
+	}
+	res, err := unary(context.Background(), "request", &grpc.UnaryServerInfo{FullMethod: "Test.Test"},
+		func(context.Context, interface{}) (interface{}, error) { return "response", nil })
+	if err != nil || res != "response" {
+		t.Errorf("got unary result %v and error %v, expected response and no error", res, err)
+	}
+
+	stream := grpcm.StreamServerRecover(reporter)
+	err = stream(nil, grpcm.NewWrappedServerStream(context.Background(), &testServerStream{}), &grpc.StreamServerInfo{FullMethod: "Test.Test"},
+		func(interface{}, grpc.ServerStream) error { panic("stream") })
+	if c := status.Code(err); c != codes.Internal {
+		t.Errorf("got stream status code %s, expected %s", c, codes.Internal)
+	}
+
+	if len(reported) != 2 || reported[0] != "unary" || reported[1] != "stream" {
+		t.Errorf("got reported panics %v, expected [unary stream]", reported)
+	}
+}
@@ -0,0 +1,50 @@
+package grpc
+
+import (
+	"context"
+
+	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/grpc/metadata"
+)
+
+// PropagateIncoming returns a copy of ctx that holds the values of the incoming
+// metadata listed in p. Deadlines are propagated natively by gRPC.
+func PropagateIncoming(ctx context.Context, p *goa.Propagation) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return p.Incoming(ctx, func(name string) string {
+		if vals := md.Get(name); len(vals) > 0 {
+			return vals[0]
+		}
+		return ""
+	})
+}
+
+// PropagateOutgoing returns a copy of ctx whose outgoing metadata includes the
+// values listed in p held by ctx.
+func PropagateOutgoing(ctx context.Context, p *goa.Propagation) context.Context {
+	vals := p.Outgoing(ctx)
+	if len(vals) == 0 {
+		return ctx
+	}
+	md, _ := metadata.FromOutgoingContext(ctx)
+	kv := make([]string, 0, 2*len(vals))
+	for n, v := range vals {
+		if len(md.Get(n)) == 0 {
+			kv = append(kv, n, v)
+		}
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+// TenantIncoming returns a copy of ctx that holds the value of the tenant claim
+// of the bearer token carried by the "authorization" metadata of the incoming
+// request, see goa.Tenancy.
+func TenantIncoming(ctx context.Context, t *goa.Tenancy) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return t.Incoming(ctx, func(name string) string {
+		if vals := md.Get(name); len(vals) > 0 {
+			return vals[0]
+		}
+		return ""
+	})
+}
@@ -0,0 +1,62 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"goa.design/goa/v3/security"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// PeerCertificate returns the PEM encoding of the certificate presented by the
+// client of the request during the TLS handshake. It returns the empty string
+// if the connection does not use TLS or if the client did not present a
+// certificate. The generated server code uses PeerCertificate to initialize the
+// payload attributes defined with ClientCertificate.
+func PeerCertificate(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return ""
+	}
+	return security.PeerCertificate(&info.State)
+}
+
+// RequestMetadata returns the transport metadata of the request given to the
+// authorization policies. The metadata omits the "authorization" key as well as
+// the keys listed in redact since they carry credentials. The generated
+// handlers of the methods that require authorization record it in the request
+// context, see security.WithTransportMetadata.
+func RequestMetadata(ctx context.Context, redact ...string) *security.TransportMetadata {
+	md := &security.TransportMetadata{Protocol: "grpc"}
+	md.Method, _ = grpc.Method(ctx)
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		md.RemoteAddr = p.Addr.String()
+	}
+	in, _ := metadata.FromIncomingContext(ctx)
+	md.Headers = make(map[string][]string, len(in))
+	for key, vals := range in {
+		key = strings.ToLower(key)
+		if key == "authorization" || isRedacted(key, redact) {
+			continue
+		}
+		md.Headers[key] = append(md.Headers[key], vals...)
+	}
+	return md
+}
+
+// isRedacted returns true if key is one of keys ignoring case.
+func isRedacted(key string, keys []string) bool {
+	for _, k := range keys {
+		if strings.EqualFold(key, k) {
+			return true
+		}
+	}
+	return false
+}
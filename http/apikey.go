@@ -0,0 +1,34 @@
+package http
+
+import "net/http"
+
+// KeyLocation is a location of the key of an API key security scheme in a
+// request.
+type KeyLocation struct {
+	// In is the location of the key, one of "header", "query" or "cookie".
+	In string
+	// Name is the name of the header, query string parameter or cookie.
+	Name string
+}
+
+// ReadAPIKey returns the first non-empty key found in the given locations of
+// the request, the empty string if there is none. The generated request
+// decoders use it to read the keys of the API key security schemes that accept
+// keys from more than one location.
+func ReadAPIKey(r *http.Request, locs ...KeyLocation) string {
+	for _, l := range locs {
+		var key string
+		switch l.In {
+		case "header":
+			key = r.Header.Get(l.Name)
+		case "query":
+			key = r.URL.Query().Get(l.Name)
+		case "cookie":
+			key = ReadCookie(r, l.Name)
+		}
+		if key != "" {
+			return key
+		}
+	}
+	return ""
+}
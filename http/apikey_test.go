@@ -0,0 +1,44 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReadAPIKey(t *testing.T) {
+	locs := []KeyLocation{
+		{In: "header", Name: "X-API-Key"},
+		{In: "query", Name: "api_key"},
+		{In: "cookie", Name: "key"},
+	}
+	cases := []struct {
+		Name     string
+		Header   string
+		Query    string
+		Cookie   string
+		Expected string
+	}{
+		{"none", "", "", "", ""},
+		{"header", "h", "q", "c", "h"},
+		{"query", "", "q", "c", "q"},
+		{"cookie", "", "", "c", "c"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			if c.Header != "" {
+				r.Header.Set("X-API-Key", c.Header)
+			}
+			if c.Query != "" {
+				r.URL.RawQuery = "api_key=" + c.Query
+			}
+			if c.Cookie != "" {
+				r.AddCookie(&http.Cookie{Name: "key", Value: c.Cookie})
+			}
+			if key := ReadAPIKey(r, locs...); key != c.Expected {
+				t.Errorf("got %q, expected %q", key, c.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"goa.design/goa/v3/security"
+)
+
+// AuthChallenges returns the values of the WWW-Authenticate headers that
+// describe the security schemes of the requirements that failed, one per
+// scheme in the order the requirements were tried. The realm of the challenges
+// is the realm of the service if any and the name of the security scheme
+// otherwise. Mutual TLS schemes have no challenge as the client certificate is
+// requested by the TLS handshake.
+func AuthChallenges(err *security.AuthError) []string {
+	var challenges []string
+	seen := make(map[string]struct{})
+	for _, f := range err.Failures {
+		var scheme string
+		switch f.Kind {
+		case "Basic":
+			scheme = "Basic"
+		case "JWT", "OAuth2":
+			scheme = "Bearer"
+		case "APIKey":
+			scheme = "APIKey"
+		case "Signature":
+			scheme = SignatureAlgorithm
+		default:
+			continue
+		}
+		if _, ok := seen[f.Scheme]; ok {
+			continue
+		}
+		seen[f.Scheme] = struct{}{}
+		realm := err.Realm
+		if realm == "" {
+			realm = f.Scheme
+		}
+		c := fmt.Sprintf("%s realm=%q", scheme, realm)
+		if scheme == "Bearer" && len(f.RequiredScopes) > 0 {
+			c += fmt.Sprintf(", scope=%q", strings.Join(f.RequiredScopes, " "))
+		}
+		challenges = append(challenges, c)
+	}
+	return challenges
+}
+
+// writeAuthChallenges adds the WWW-Authenticate headers describing the
+// failures of err to w if err is a security.AuthError. It returns the status
+// of the authorization failure responses if so, zero otherwise.
+func writeAuthChallenges(w http.ResponseWriter, err error) int {
+	aerr, ok := err.(*security.AuthError)
+	if !ok {
+		return 0
+	}
+	for _, c := range AuthChallenges(aerr) {
+		w.Header().Add("WWW-Authenticate", c)
+	}
+	return http.StatusUnauthorized
+}
@@ -0,0 +1,72 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"goa.design/goa/v3/security"
+)
+
+func TestAuthChallenges(t *testing.T) {
+	var aerr security.AuthError
+	aerr.Record("basic", "Basic", nil, errors.New("invalid"))
+	aerr.Record("jwt", "JWT", []string{"api:read", "api:write"}, errors.New("invalid"))
+	aerr.Record("oauth", "OAuth2", nil, errors.New("invalid"))
+	aerr.Record("api_key", "APIKey", nil, errors.New("invalid"))
+	aerr.Record("mtls", "MTLS", nil, errors.New("invalid"))
+	aerr.Record("signed", "Signature", nil, errors.New("invalid"))
+	aerr.Record("jwt", "JWT", []string{"api:admin"}, errors.New("invalid"))
+	expected := []string{
+		`Basic realm="basic"`,
+		`Bearer realm="jwt", scope="api:read api:write"`,
+		`Bearer realm="oauth"`,
+		`APIKey realm="api_key"`,
+		`GOA-HMAC-SHA256 realm="signed"`,
+	}
+	if actual := AuthChallenges(&aerr); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("got %v, expected %v", actual, expected)
+	}
+
+	aerr = security.AuthError{Realm: `Admin "console"`}
+	aerr.Record("basic", "Basic", nil, errors.New("invalid"))
+	aerr.Record("jwt", "JWT", []string{"api:read"}, errors.New("invalid"))
+	expected = []string{
+		`Basic realm="Admin \"console\""`,
+		`Bearer realm="Admin \"console\"", scope="api:read"`,
+	}
+	if actual := AuthChallenges(&aerr); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("got %v with realm, expected %v", actual, expected)
+	}
+}
+
+func TestErrorEncoderAuthError(t *testing.T) {
+	var aerr security.AuthError
+	aerr.Record("jwt", "JWT", nil, errors.New("invalid token"))
+	aerr.Record("api_key", "APIKey", nil, errors.New("unknown key"))
+	cases := []struct {
+		Name   string
+		Encode func(context.Context, http.ResponseWriter, error) error
+	}{
+		{"error", ErrorEncoder(ResponseEncoder)},
+		{"problem", ProblemErrorEncoder(ResponseEncoder, "")},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			if err := c.Encode(context.Background(), w, &aerr); err != nil {
+				t.Fatal(err)
+			}
+			if w.Code != http.StatusUnauthorized {
+				t.Errorf("got status %d, expected %d", w.Code, http.StatusUnauthorized)
+			}
+			expected := []string{`Bearer realm="jwt"`, `APIKey realm="api_key"`}
+			if actual := w.Header()["Www-Authenticate"]; !reflect.DeepEqual(actual, expected) {
+				t.Errorf("got challenges %v, expected %v", actual, expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"goa.design/goa/v3/security"
+)
+
+// credentialHeaders lists the headers that always carry credentials.
+var credentialHeaders = []string{"authorization", "proxy-authorization", "cookie"}
+
+// RequestMetadata returns the transport metadata of the request given to the
+// authorization policies. The metadata omits the query string and the headers
+// that carry credentials: the Authorization, Proxy-Authorization and Cookie
+// headers as well as the headers listed in redact. The generated handlers of
+// the methods that require authorization record it in the request context,
+// see security.WithTransportMetadata.
+func RequestMetadata(r *http.Request, redact ...string) *security.TransportMetadata {
+	headers := make(map[string][]string, len(r.Header))
+	for name, vals := range r.Header {
+		name = strings.ToLower(name)
+		if isRedacted(name, credentialHeaders) || isRedacted(name, redact) {
+			continue
+		}
+		headers[name] = append(headers[name], vals...)
+	}
+	return &security.TransportMetadata{
+		Protocol:   "http",
+		Method:     r.Method,
+		Path:       r.URL.Path,
+		RemoteAddr: r.RemoteAddr,
+		Headers:    headers,
+	}
+}
+
+// isRedacted returns true if name is one of names ignoring case.
+func isRedacted(name string, names []string) bool {
+	for _, n := range names {
+		if strings.EqualFold(name, n) {
+			return true
+		}
+	}
+	return false
+}
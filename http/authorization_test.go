@@ -0,0 +1,33 @@
+package http
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestRequestMetadata(t *testing.T) {
+	r := httptest.NewRequest("POST", "/orders/1?api_key=secret", nil)
+	r.Header.Set("Authorization", "Bearer secret")
+	r.Header.Set("Cookie", "session=secret")
+	r.Header.Set("X-API-Key", "secret")
+	r.Header.Set("X-Tenant", "acme")
+	r.Header.Add("Accept", "application/json")
+	r.Header.Add("Accept", "text/plain")
+
+	md := RequestMetadata(r, "x-api-key")
+
+	if md.Protocol != "http" || md.Method != "POST" || md.Path != "/orders/1" {
+		t.Errorf("got protocol %q, method %q and path %q, expected %q, %q and %q", md.Protocol, md.Method, md.Path, "http", "POST", "/orders/1")
+	}
+	if md.RemoteAddr != r.RemoteAddr {
+		t.Errorf("got remote address %q, expected %q", md.RemoteAddr, r.RemoteAddr)
+	}
+	expected := map[string][]string{
+		"x-tenant": {"acme"},
+		"accept":   {"application/json", "text/plain"},
+	}
+	if !reflect.DeepEqual(md.Headers, expected) {
+		t.Errorf("got headers %v, expected %v", md.Headers, expected)
+	}
+}
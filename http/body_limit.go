@@ -0,0 +1,75 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// limitedBody is a request body reader that fails once more than limit
+	// bytes have been read.
+	limitedBody struct {
+		io.ReadCloser
+		w         http.ResponseWriter
+		limit     int64
+		remaining int64
+		exceeded  bool
+	}
+)
+
+// errBodyTooLarge is the error returned by the limited body reader when the
+// limit is exceeded.
+var errBodyTooLarge = errors.New("http: request body too large")
+
+// LimitRequestBody replaces the body of r with a reader that fails once more
+// than n bytes have been read. Requests whose Content-Length header exceeds n
+// fail on the first read. The response is flagged so that the server closes
+// the connection after writing it which protects against slow clients
+// streaming large bodies.
+func LimitRequestBody(w http.ResponseWriter, r *http.Request, n int64) {
+	b := &limitedBody{ReadCloser: r.Body, w: w, limit: n, remaining: n}
+	if r.ContentLength > n {
+		b.fail()
+	}
+	r.Body = b
+}
+
+// BodyLimitError returns a "request_too_large" error if the body of r was
+// limited with LimitRequestBody and the limit was exceeded, err otherwise. The
+// generated code calls BodyLimitError with the error returned by the request
+// decoder.
+func BodyLimitError(r *http.Request, err error) error {
+	if b, ok := r.Body.(*limitedBody); ok && b.exceeded {
+		return goa.PermanentError("request_too_large", "request body exceeds the maximum size of %d bytes", b.limit)
+	}
+	return err
+}
+
+// Read reads up to the remaining number of bytes allowed.
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.exceeded {
+		return 0, errBodyTooLarge
+	}
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) <= b.remaining {
+		b.remaining -= int64(n)
+		return n, err
+	}
+	n = int(b.remaining)
+	b.remaining = 0
+	b.fail()
+	return n, errBodyTooLarge
+}
+
+// fail records that the limit was exceeded and asks the server to close the
+// connection once the response is written.
+func (b *limitedBody) fail() {
+	b.exceeded = true
+	b.w.Header().Set("Connection", "close")
+}
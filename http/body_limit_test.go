@@ -0,0 +1,62 @@
+package http
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestLimitRequestBody(t *testing.T) {
+	decodeErr := errors.New("decode error")
+	cases := []struct {
+		Name          string
+		Body          string
+		ContentLength int64
+		Limit         int64
+		TooLarge      bool
+	}{
+		{"under limit", "hello", 5, 10, false},
+		{"at limit", "hello", 5, 5, false},
+		{"over limit", "hello world", 11, 5, true},
+		{"over limit content length", "hello", 100, 10, true},
+		{"over limit unknown length", "hello world", -1, 5, true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/", strings.NewReader(c.Body))
+			r.ContentLength = c.ContentLength
+			w := httptest.NewRecorder()
+			LimitRequestBody(w, r, c.Limit)
+			b, err := ioutil.ReadAll(r.Body)
+			if c.TooLarge {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if int64(len(b)) > c.Limit {
+					t.Errorf("read %d bytes, limit is %d", len(b), c.Limit)
+				}
+				if w.Header().Get("Connection") != "close" {
+					t.Error("expected connection to be closed")
+				}
+				serr, ok := BodyLimitError(r, decodeErr).(*goa.ServiceError)
+				if !ok || serr.Name != "request_too_large" {
+					t.Errorf("got error %v, expected request_too_large", serr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			if string(b) != c.Body {
+				t.Errorf("got body %q, expected %q", string(b), c.Body)
+			}
+			if err := BodyLimitError(r, decodeErr); err != decodeErr {
+				t.Errorf("got error %v, expected %v", err, decodeErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// cacheHeadersWriter is a response writer that sets the caching headers
+	// of the successful responses from the cache status of the request.
+	cacheHeadersWriter struct {
+		http.ResponseWriter
+		status      *goa.CacheStatus
+		wroteHeader bool
+	}
+)
+
+// CacheHeaders returns a handler that initializes the cache status of the
+// requests, see goa.WithCacheStatus, and sets the Cache-Control header of the
+// successful responses to the TTL of the endpoint results. The directive is
+// private if the results are cached per caller. The responses served from the
+// cache also get the Age header. The generated servers wrap
+// the handlers of the methods defined with the Cache DSL with CacheHeaders.
+func CacheHeaders(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, st := goa.WithCacheStatus(r.Context())
+		h.ServeHTTP(&cacheHeadersWriter{ResponseWriter: w, status: st}, r.WithContext(ctx))
+	})
+}
+
+// WriteHeader sets the caching headers if the response is successful and
+// writes the status code.
+func (w *cacheHeadersWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if code == http.StatusOK && w.status.TTL > 0 {
+			cc := "max-age=" + strconv.Itoa(int(w.status.TTL/time.Second))
+			if w.status.Private {
+				cc = "private, " + cc
+			}
+			w.Header().Set("Cache-Control", cc)
+			if w.status.Hit {
+				w.Header().Set("Age", strconv.Itoa(int(w.status.Age/time.Second)))
+			}
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write writes the header if not written yet and then the data.
+func (w *cacheHeadersWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
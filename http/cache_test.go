@@ -0,0 +1,52 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestCacheHeaders(t *testing.T) {
+	var (
+		c      = &goa.ResultCache{Store: goa.NewLRUCache(10)}
+		caller []interface{}
+	)
+	h := CacheHeaders(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, _ := c.Do(r.Context(), "key", 5*time.Minute, caller, func() (interface{}, error) { return "ok", nil })
+		w.Write([]byte(res.(string)))
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if cc := w.Header().Get("Cache-Control"); cc != "max-age=300" {
+		t.Errorf("got Cache-Control %q, expected %q", cc, "max-age=300")
+	}
+	if _, ok := w.Header()["Age"]; ok {
+		t.Error("got Age header on miss, expected none")
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if age := w.Header().Get("Age"); age != "0" {
+		t.Errorf("got Age %q on hit, expected %q", age, "0")
+	}
+
+	caller = []interface{}{"alice"}
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if cc := w.Header().Get("Cache-Control"); cc != "private, max-age=300" {
+		t.Errorf("got Cache-Control %q for caller, expected %q", cc, "private, max-age=300")
+	}
+
+	h = CacheHeaders(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if cc := w.Header().Get("Cache-Control"); cc != "" {
+		t.Errorf("got Cache-Control %q on error, expected none", cc)
+	}
+}
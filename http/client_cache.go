@@ -0,0 +1,276 @@
+package http
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// cachingDoer is a Doer that caches the responses in a store.
+	cachingDoer struct {
+		Doer
+		store goa.CacheStore
+		// keyHeaders lists the canonical names of the request headers
+		// whose values key the responses.
+		keyHeaders []string
+	}
+
+	// cachedResponse is a response stored by a cachingDoer.
+	cachedResponse struct {
+		// StatusCode is the response status code.
+		StatusCode int
+		// Header is the response header.
+		Header http.Header
+		// Body is the response body.
+		Body []byte
+		// Fresh is the time until which the response is served without
+		// revalidation.
+		Fresh time.Time
+		// Vary lists the values of the request headers listed in the
+		// Vary response header indexed by header name.
+		Vary map[string]string
+	}
+)
+
+// clientCacheRetention is the duration during which the stale responses that
+// have an ETag or a Last-Modified header are kept by CachingDoer so that they
+// may be revalidated.
+const clientCacheRetention = 24 * time.Hour
+
+// CachingDoer returns a Doer that caches the successful responses to the GET
+// requests made with d in store. The responses are keyed by the request URL
+// which includes the path and query string parameters and by the values of the
+// request Authorization and Cookie headers and of the given key headers, so
+// that the responses to the requests made with different credentials or for
+// different tenants are not shared. The responses whose Vary header lists
+// other request headers are only served to the requests that have the same
+// values for these headers. The responses are served from the cache as long as
+// their Cache-Control max-age directive allows it. Once stale the responses
+// that have an ETag or a Last-Modified header are revalidated by sending the
+// request with the If-None-Match or If-Modified-Since header: the cached
+// response is returned when the server responds with 304 Not Modified so that
+// the generated clients decode it as usual. The responses with the no-store
+// directive or with no max-age and no validator are not cached. The requests
+// with the no-cache directive are always revalidated.
+//
+// The generated clients of the services that define GET endpoints expose a
+// UseResponseCache method that wraps the doers of these endpoints with
+// CachingDoer given the headers that carry the credentials of the service
+// security schemes and the tenant of the requests.
+func CachingDoer(d Doer, store goa.CacheStore, keyHeaders ...string) Doer {
+	keys := []string{"Authorization", "Cookie"}
+	for _, h := range keyHeaders {
+		h = http.CanonicalHeaderKey(h)
+		found := false
+		for _, k := range keys {
+			if k == h {
+				found = true
+				break
+			}
+		}
+		if !found {
+			keys = append(keys, h)
+		}
+	}
+	return &cachingDoer{Doer: d, store: store, keyHeaders: keys}
+}
+
+// Do returns the cached response to req if fresh, revalidates it if stale and
+// caches the response otherwise.
+func (d *cachingDoer) Do(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("If-None-Match") != "" ||
+		req.Header.Get("If-Modified-Since") != "" || req.Header.Get("Range") != "" {
+		return d.Doer.Do(req)
+	}
+	rcc := parseCacheControl(req.Header.Get("Cache-Control"))
+	if _, ok := rcc["no-store"]; ok {
+		return d.Doer.Do(req)
+	}
+	var (
+		ctx    = req.Context()
+		key    = clientCacheKey(req, d.keyHeaders)
+		cached *cachedResponse
+		stored time.Time
+	)
+	if e, err := d.store.Get(ctx, key); err == nil && e != nil {
+		if cr, ok := e.Value.(*cachedResponse); ok && cr.matches(req) {
+			cached, stored = cr, e.Stored
+		}
+	}
+	if cached != nil {
+		if _, ok := rcc["no-cache"]; !ok && time.Now().Before(cached.Fresh) {
+			return cached.response(req, time.Since(stored)), nil
+		}
+		etag, lm := cached.Header.Get("ETag"), cached.Header.Get("Last-Modified")
+		if etag != "" || lm != "" {
+			header := cloneHeader(req.Header)
+			req = req.WithContext(ctx)
+			req.Header = header
+			if etag != "" {
+				req.Header.Set("If-None-Match", etag)
+			}
+			if lm != "" {
+				req.Header.Set("If-Modified-Since", lm)
+			}
+		}
+	}
+	resp, err := d.Doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		io.Copy(ioutil.Discard, resp.Body) // nolint: errcheck
+		resp.Body.Close()
+		header := cloneHeader(cached.Header)
+		for _, h := range []string{"Cache-Control", "Date", "ETag", "Expires", "Last-Modified"} {
+			if v := resp.Header.Get(h); v != "" {
+				header.Set(h, v)
+			}
+		}
+		updated := &cachedResponse{StatusCode: cached.StatusCode, Header: header, Body: cached.Body}
+		d.set(req, key, updated)
+		return updated.response(req, 0), nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+	cc := parseCacheControl(resp.Header.Get("Cache-Control"))
+	if _, ok := cc["no-store"]; ok || strings.TrimSpace(resp.Header.Get("Vary")) == "*" {
+		return resp, nil
+	}
+	if resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "" && maxAge(cc) <= 0 {
+		return resp, nil
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	d.set(req, key, &cachedResponse{StatusCode: resp.StatusCode, Header: cloneHeader(resp.Header), Body: body})
+	return resp, nil
+}
+
+// set computes the freshness of res and stores it under key. Errors are
+// ignored: a store failure only prevents the response from being reused.
+func (d *cachingDoer) set(req *http.Request, key string, res *cachedResponse) {
+	now := time.Now()
+	res.Fresh = now.Add(maxAge(parseCacheControl(res.Header.Get("Cache-Control"))))
+	for _, h := range strings.Split(res.Header.Get("Vary"), ",") {
+		if h = http.CanonicalHeaderKey(strings.TrimSpace(h)); h != "" {
+			if res.Vary == nil {
+				res.Vary = make(map[string]string)
+			}
+			res.Vary[h] = req.Header.Get(h)
+		}
+	}
+	expires := res.Fresh
+	if res.Header.Get("ETag") != "" || res.Header.Get("Last-Modified") != "" {
+		if keep := now.Add(clientCacheRetention); keep.After(expires) {
+			expires = keep
+		}
+	}
+	d.store.Set(req.Context(), key, &goa.CacheEntry{Value: res, Stored: now, Expires: expires}) // nolint: errcheck
+}
+
+// matches returns true if the values of the request headers listed in the Vary
+// header of the cached response match the values of req.
+func (r *cachedResponse) matches(req *http.Request) bool {
+	for h, v := range r.Vary {
+		if req.Header.Get(h) != v {
+			return false
+		}
+	}
+	return true
+}
+
+// response returns a copy of the cached response to req with the given age.
+func (r *cachedResponse) response(req *http.Request, age time.Duration) *http.Response {
+	header := cloneHeader(r.Header)
+	header.Set("Age", strconv.Itoa(int(age/time.Second)))
+	return &http.Response{
+		Status:        strconv.Itoa(r.StatusCode) + " " + http.StatusText(r.StatusCode),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// cloneHeader returns a deep copy of h.
+func cloneHeader(h http.Header) http.Header {
+	c := make(http.Header, len(h))
+	for k, v := range h {
+		c[k] = append([]string(nil), v...)
+	}
+	return c
+}
+
+// clientCacheKey returns the key of the responses to req. The values of the
+// given headers are hashed so that the credentials are not kept by the store.
+func clientCacheKey(req *http.Request, headers []string) string {
+	key := req.Method + " " + req.URL.String()
+	h := sha256.New()
+	set := false
+	for _, name := range headers {
+		vals := req.Header[name]
+		if len(vals) == 0 {
+			continue
+		}
+		set = true
+		h.Write([]byte(name)) // nolint: errcheck
+		for _, v := range vals {
+			h.Write([]byte{0}) // nolint: errcheck
+			h.Write([]byte(v)) // nolint: errcheck
+		}
+		h.Write([]byte{'\n'}) // nolint: errcheck
+	}
+	if set {
+		key += " " + hex.EncodeToString(h.Sum(nil)[:16])
+	}
+	return key
+}
+
+// parseCacheControl returns the directives of the given Cache-Control header
+// value indexed by lower-cased name.
+func parseCacheControl(v string) map[string]string {
+	cc := make(map[string]string)
+	for _, d := range strings.Split(v, ",") {
+		d = strings.TrimSpace(d)
+		if d == "" {
+			continue
+		}
+		name, val := d, ""
+		if i := strings.Index(d, "="); i >= 0 {
+			name, val = d[:i], strings.Trim(d[i+1:], `"`)
+		}
+		cc[strings.ToLower(name)] = val
+	}
+	return cc
+}
+
+// maxAge returns the duration during which a response with the given
+// Cache-Control directives is fresh.
+func maxAge(cc map[string]string) time.Duration {
+	if _, ok := cc["no-cache"]; ok {
+		return 0
+	}
+	secs, err := strconv.Atoi(cc["max-age"])
+	if err != nil || secs <= 0 {
+		return 0
+	}
+	return time.Duration(secs) * time.Second
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestCachingDoer(t *testing.T) {
+	cases := []struct {
+		Name         string
+		CacheControl string
+		ETag         string
+		Requests     int
+		Revalidated  int
+	}{
+		{"max-age", "max-age=60", "", 1, 0},
+		{"etag", "no-cache", `"v1"`, 3, 2},
+		{"max-age-and-etag", "max-age=60", `"v1"`, 1, 0},
+		{"no-store", "no-store", `"v1"`, 3, 0},
+		{"no-validator", "", "", 3, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var requests, revalidated int
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if c.CacheControl != "" {
+					w.Header().Set("Cache-Control", c.CacheControl)
+				}
+				if c.ETag != "" {
+					w.Header().Set("ETag", c.ETag)
+					if r.Header.Get("If-None-Match") == c.ETag {
+						revalidated++
+						w.WriteHeader(http.StatusNotModified)
+						return
+					}
+				}
+				w.Write([]byte("result"))
+			}))
+			defer svr.Close()
+			doer := CachingDoer(http.DefaultClient, goa.NewLRUCache(10))
+			for i := 0; i < 3; i++ {
+				req, _ := http.NewRequest("GET", svr.URL+"/items?page=1", nil)
+				resp, err := doer.Do(req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				body, _ := ioutil.ReadAll(resp.Body)
+				resp.Body.Close()
+				if resp.StatusCode != http.StatusOK || string(body) != "result" {
+					t.Errorf("got %d %q, expected 200 %q", resp.StatusCode, body, "result")
+				}
+			}
+			if requests != c.Requests {
+				t.Errorf("got %d requests, expected %d", requests, c.Requests)
+			}
+			if revalidated != c.Revalidated {
+				t.Errorf("got %d revalidations, expected %d", revalidated, c.Revalidated)
+			}
+		})
+	}
+}
+
+func TestCachingDoerKeys(t *testing.T) {
+	var requests int
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Header().Set("Vary", "Accept-Language")
+		w.Write([]byte("result"))
+	}))
+	defer svr.Close()
+	doer := CachingDoer(http.DefaultClient, goa.NewLRUCache(10), "X-API-Key", "x-tenant-id")
+	do := func(method, path, auth, lang string) {
+		req, _ := http.NewRequest(method, svr.URL+path, nil)
+		if auth != "" {
+			name, val := "Authorization", auth
+			if i := strings.Index(auth, ": "); i > 0 {
+				name, val = auth[:i], auth[i+2:]
+			}
+			req.Header.Set(name, val)
+		}
+		if lang != "" {
+			req.Header.Set("Accept-Language", lang)
+		}
+		resp, err := doer.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	do("GET", "/items?page=1", "", "")
+	do("GET", "/items?page=1", "", "")
+	do("GET", "/items?page=2", "", "")
+	do("GET", "/items?page=1", "Bearer a", "")
+	do("GET", "/items?page=1", "Bearer a", "")
+	do("GET", "/items?page=1", "Bearer b", "")
+	do("GET", "/items?page=1", "Cookie: session=a", "")
+	do("GET", "/items?page=1", "Cookie: session=a", "")
+	do("GET", "/items?page=1", "Cookie: session=b", "")
+	do("GET", "/items?page=1", "X-Api-Key: a", "")
+	do("GET", "/items?page=1", "X-Api-Key: b", "")
+	do("GET", "/items?page=1", "X-Tenant-Id: acme", "")
+	do("GET", "/items?page=1", "X-Tenant-Id: initech", "")
+	do("GET", "/items?page=1", "X-Tenant-Id: acme", "")
+	do("GET", "/items?page=1", "", "fr")
+	do("POST", "/items?page=1", "", "")
+	do("POST", "/items?page=1", "", "")
+
+	if requests != 13 {
+		t.Errorf("got %d requests, expected 13", requests)
+	}
+}
@@ -0,0 +1,197 @@
+package http
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+type (
+	// ClientPolicy describes how a client makes the requests to an endpoint.
+	// The generated clients initialize one policy per endpoint from the
+	// design and make it possible to override it via client options.
+	ClientPolicy struct {
+		// Timeout bounds the total time spent making the request
+		// including retries and reading the response body, zero means
+		// no timeout.
+		Timeout time.Duration
+		// Retry is the policy used to retry failed requests if any.
+		Retry *RetryPolicy
+		// Breaker is the circuit breaker that guards the requests if
+		// any.
+		Breaker CircuitBreaker
+	}
+
+	// RetryPolicy describes how failed requests are retried. Requests are
+	// retried when the transport returns an error or when the response
+	// status code is one of StatusCodes.
+	RetryPolicy struct {
+		// MaxAttempts is the maximum number of attempts including the
+		// initial request.
+		MaxAttempts int
+		// InitialBackoff is the time waited before the first retry.
+		// The time is doubled for each subsequent retry.
+		InitialBackoff time.Duration
+		// MaxBackoff caps the time waited between two attempts, zero
+		// means no cap.
+		MaxBackoff time.Duration
+		// StatusCodes lists the response status codes that cause a
+		// retry. DefaultRetryStatusCodes is used if empty.
+		StatusCodes []int
+	}
+
+	// CircuitBreaker guards the requests made by a client. Implementations
+	// typically stop allowing requests for some time once too many
+	// requests have failed.
+	CircuitBreaker interface {
+		// Allow returns a non-nil error if the request must not be
+		// made. The error is returned to the caller.
+		Allow() error
+		// Record records the outcome of a request that was allowed.
+		// Requests fail when the transport returns an error or the
+		// response status code is 5xx.
+		Record(success bool)
+	}
+
+	// policyDoer is a Doer that applies a client policy.
+	policyDoer struct {
+		Doer
+		policy *ClientPolicy
+	}
+
+	// cancelBody is a response body that cancels the request context once
+	// closed.
+	cancelBody struct {
+		io.ReadCloser
+		cancel context.CancelFunc
+	}
+)
+
+// DefaultRetryStatusCodes lists the response status codes that cause a retry
+// when the retry policy does not specify any.
+var DefaultRetryStatusCodes = []int{
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// PolicyDoer returns a Doer that applies p to the requests made with d. It
+// returns d if p is nil. The generated clients use PolicyDoer to make requests
+// to endpoints that define a client policy.
+func PolicyDoer(d Doer, p *ClientPolicy) Doer {
+	if p == nil {
+		return d
+	}
+	return &policyDoer{Doer: d, policy: p}
+}
+
+// WithBreaker returns a copy of p that uses the given circuit breaker. p may be
+// nil.
+func (p *ClientPolicy) WithBreaker(cb CircuitBreaker) *ClientPolicy {
+	var cp ClientPolicy
+	if p != nil {
+		cp = *p
+	}
+	cp.Breaker = cb
+	return &cp
+}
+
+// Do makes the request applying the timeout, retry and circuit breaker
+// settings of the policy.
+func (d *policyDoer) Do(req *http.Request) (*http.Response, error) {
+	var cancel context.CancelFunc = func() {}
+	if d.policy.Timeout > 0 {
+		var ctx context.Context
+		ctx, cancel = context.WithTimeout(req.Context(), d.policy.Timeout)
+		req = req.WithContext(ctx)
+	}
+	attempts := 1
+	if r := d.policy.Retry; r != nil && r.MaxAttempts > 1 {
+		attempts = r.MaxAttempts
+		if err := BufferRequestBody(req); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+	cb := d.policy.Breaker
+	for i := 1; ; i++ {
+		if cb != nil {
+			if err := cb.Allow(); err != nil {
+				cancel()
+				return nil, err
+			}
+		}
+		resp, err := d.Doer.Do(req)
+		if cb != nil {
+			cb.Record(err == nil && resp.StatusCode < 500)
+		}
+		if i >= attempts || !d.policy.Retry.retry(resp, err) {
+			if err != nil || resp == nil {
+				cancel()
+				return resp, err
+			}
+			resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+			return resp, nil
+		}
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body) // nolint: errcheck
+			resp.Body.Close()
+		}
+		select {
+		case <-time.After(d.policy.Retry.backoff(i)):
+		case <-req.Context().Done():
+			cancel()
+			return nil, req.Context().Err()
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
+
+// retry returns true if the request that produced resp and err should be
+// retried.
+func (r *RetryPolicy) retry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	codes := r.StatusCodes
+	if len(codes) == 0 {
+		codes = DefaultRetryStatusCodes
+	}
+	for _, c := range codes {
+		if resp.StatusCode == c {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the time to wait before the given retry.
+func (r *RetryPolicy) backoff(retry int) time.Duration {
+	b := r.InitialBackoff
+	for i := 1; i < retry; i++ {
+		b *= 2
+		if r.MaxBackoff > 0 && b >= r.MaxBackoff {
+			break
+		}
+	}
+	if r.MaxBackoff > 0 && b > r.MaxBackoff {
+		b = r.MaxBackoff
+	}
+	return b
+}
+
+// Close closes the body and cancels the request context.
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
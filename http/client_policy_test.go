@@ -0,0 +1,152 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type (
+	// statusDoer responds with the given status codes in order and
+	// records the request bodies.
+	statusDoer struct {
+		codes  []int
+		bodies []string
+	}
+
+	// doerFunc is a Doer implemented by a function.
+	doerFunc func(*http.Request) (*http.Response, error)
+
+	// countingBreaker records the request outcomes and disallows requests
+	// once open is true.
+	countingBreaker struct {
+		open      bool
+		successes int
+		failures  int
+	}
+)
+
+func (d *statusDoer) Do(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		b, _ := ioutil.ReadAll(req.Body)
+		d.bodies = append(d.bodies, string(b))
+	}
+	code := d.codes[0]
+	if len(d.codes) > 1 {
+		d.codes = d.codes[1:]
+	}
+	return &http.Response{StatusCode: code, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+}
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func (b *countingBreaker) Allow() error {
+	if b.open {
+		return errors.New("circuit open")
+	}
+	return nil
+}
+
+func (b *countingBreaker) Record(success bool) {
+	if success {
+		b.successes++
+	} else {
+		b.failures++
+	}
+}
+
+func TestPolicyDoer(t *testing.T) {
+	retry := &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	cases := []struct {
+		Name     string
+		Policy   *ClientPolicy
+		Codes    []int
+		Status   int
+		Attempts int
+	}{
+		{"no retry", &ClientPolicy{}, []int{503, 200}, 503, 1},
+		{"retry success", &ClientPolicy{Retry: retry}, []int{503, 502, 200}, 200, 3},
+		{"retry exhausted", &ClientPolicy{Retry: retry}, []int{503}, 503, 3},
+		{"not retried", &ClientPolicy{Retry: retry}, []int{500, 200}, 500, 1},
+		{"custom codes", &ClientPolicy{Retry: &RetryPolicy{MaxAttempts: 2, StatusCodes: []int{429}}}, []int{429, 200}, 200, 2},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			d := &statusDoer{codes: c.Codes}
+			req, _ := http.NewRequest("PUT", "http://example.com", nil)
+			req.Body = ioutil.NopCloser(strings.NewReader("body"))
+			resp, err := PolicyDoer(d, c.Policy).Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != c.Status {
+				t.Errorf("got status %d, expected %d", resp.StatusCode, c.Status)
+			}
+			if len(d.bodies) != c.Attempts {
+				t.Fatalf("got %d attempts, expected %d", len(d.bodies), c.Attempts)
+			}
+			for i, b := range d.bodies {
+				if b != "body" {
+					t.Errorf("attempt %d: got body %q, expected %q", i+1, b, "body")
+				}
+			}
+		})
+	}
+}
+
+func TestPolicyDoerBreaker(t *testing.T) {
+	cb := &countingBreaker{}
+	p := (&ClientPolicy{Retry: &RetryPolicy{MaxAttempts: 3}}).WithBreaker(cb)
+	d := PolicyDoer(&statusDoer{codes: []int{503, 200}}, p)
+	req, _ := http.NewRequest("GET", "http://example.com", nil)
+	if _, err := d.Do(req); err != nil {
+		t.Fatal(err)
+	}
+	if cb.failures != 1 || cb.successes != 1 {
+		t.Errorf("got %d failures and %d successes, expected 1 and 1", cb.failures, cb.successes)
+	}
+	cb.open = true
+	if _, err := d.Do(req); err == nil || err.Error() != "circuit open" {
+		t.Errorf("got error %v, expected circuit open", err)
+	}
+}
+
+func TestPolicyDoerTimeout(t *testing.T) {
+	var ctx context.Context
+	d := PolicyDoer(doerFunc(func(req *http.Request) (*http.Response, error) {
+		ctx = req.Context()
+		return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+	}), &ClientPolicy{Timeout: time.Minute})
+	req, _ := http.NewRequest("GET", "http://example.com", nil)
+	resp, err := d.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		t.Error("request context has no deadline")
+	}
+	if ctx.Err() != nil {
+		t.Errorf("request context canceled before the body is closed: %s", ctx.Err())
+	}
+	resp.Body.Close()
+	if ctx.Err() != context.Canceled {
+		t.Errorf("got context error %v after the body is closed, expected %v", ctx.Err(), context.Canceled)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	r := &RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+	expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond}
+	for i, e := range expected {
+		if got := r.backoff(i + 1); got != e {
+			t.Errorf("retry %d: got backoff %s, expected %s", i+1, got, e)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestAPIKeyLocations(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Endpoint string
+		Code     string
+	}{
+		{"default", "MethodDefault", testdata.APIKeyLocationsDefaultDecodeCode},
+		{"mapped", "MethodMapped", testdata.APIKeyLocationsMappedDecodeCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, testdata.APIKeyLocationsDSL)
+			fs := ServerFiles("", expr.Root)
+			var code string
+			for _, s := range fs[1].Section("request-decoder") {
+				if s.Data.(*EndpointData).Method.VarName == c.Endpoint {
+					code = codegen.SectionCode(t, s)
+				}
+			}
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
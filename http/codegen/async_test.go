@@ -0,0 +1,21 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestAsyncResponseEncoder(t *testing.T) {
+	RunHTTPDSL(t, testdata.AsyncDSL)
+	sections := ServerFiles("gen", expr.Root)[1].Section("response-encoder")
+	if len(sections) != 2 {
+		t.Fatalf("got %d sections, expected 2", len(sections))
+	}
+	code := codegen.SectionCode(t, sections[1])
+	if code != testdata.AsyncResponseEncoderCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.AsyncResponseEncoderCode))
+	}
+}
@@ -0,0 +1,139 @@
+// Package asyncapi produces AsyncAPI 3.0 documents (https://www.asyncapi.com/docs/reference/specification/v3.0.0)
+// that describe the streaming HTTP endpoints.
+package asyncapi
+
+import "goa.design/goa/v3/http/codegen/openapi"
+
+type (
+	// V3 represents an instance of an AsyncAPI 3.0 document.
+	V3 struct {
+		AsyncAPI   string                `json:"asyncapi" yaml:"asyncapi"`
+		Info       *Info                 `json:"info" yaml:"info"`
+		Servers    map[string]*Server    `json:"servers,omitempty" yaml:"servers,omitempty"`
+		Channels   map[string]*Channel   `json:"channels,omitempty" yaml:"channels,omitempty"`
+		Operations map[string]*Operation `json:"operations,omitempty" yaml:"operations,omitempty"`
+		Components *Components           `json:"components,omitempty" yaml:"components,omitempty"`
+	}
+
+	// Info provides metadata about the API.
+	Info struct {
+		Title       string `json:"title" yaml:"title"`
+		Version     string `json:"version" yaml:"version"`
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	}
+
+	// Server describes a message broker, here the API server.
+	Server struct {
+		// Host is the server host name, may include the port number.
+		Host string `json:"host" yaml:"host"`
+		// Protocol is the protocol used by the channels on this server:
+		// "ws", "wss", "http" or "https".
+		Protocol string `json:"protocol" yaml:"protocol"`
+		// Pathname is the path to the API on the server.
+		Pathname string `json:"pathname,omitempty" yaml:"pathname,omitempty"`
+		// Description of the server.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	}
+
+	// Channel describes the connection used to exchange the messages of a
+	// method.
+	Channel struct {
+		// Address is the request path, may contain parameters using
+		// the "{name}" syntax.
+		Address string `json:"address" yaml:"address"`
+		// Description of the channel.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		// Servers lists the servers that provide the channel.
+		Servers []*Ref `json:"servers,omitempty" yaml:"servers,omitempty"`
+		// Messages lists the messages sent on the channel.
+		Messages map[string]*Message `json:"messages,omitempty" yaml:"messages,omitempty"`
+		// Parameters describes the parameters used in Address.
+		Parameters map[string]*Parameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+		// Bindings describes the protocol specific settings.
+		Bindings *ChannelBindings `json:"bindings,omitempty" yaml:"bindings,omitempty"`
+	}
+
+	// Message describes a message sent on a channel.
+	Message struct {
+		// Name is a machine friendly name of the message.
+		Name string `json:"name,omitempty" yaml:"name,omitempty"`
+		// Title is a human friendly name of the message.
+		Title string `json:"title,omitempty" yaml:"title,omitempty"`
+		// ContentType is the content type of the message payload.
+		ContentType string `json:"contentType,omitempty" yaml:"contentType,omitempty"`
+		// Payload is the schema of the message payload.
+		Payload *openapi.Schema `json:"payload,omitempty" yaml:"payload,omitempty"`
+	}
+
+	// Parameter describes a channel address parameter.
+	Parameter struct {
+		// Description of the parameter.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		// Enum lists the possible values of the parameter if limited.
+		Enum []string `json:"enum,omitempty" yaml:"enum,omitempty"`
+		// Default is the parameter default value.
+		Default string `json:"default,omitempty" yaml:"default,omitempty"`
+	}
+
+	// ChannelBindings lists the protocol specific settings of a channel.
+	ChannelBindings struct {
+		// WS describes the websocket connection.
+		WS *WebSocketBinding `json:"ws,omitempty" yaml:"ws,omitempty"`
+	}
+
+	// WebSocketBinding describes the HTTP request that establishes a
+	// websocket connection.
+	WebSocketBinding struct {
+		// Method is the HTTP method of the request.
+		Method string `json:"method,omitempty" yaml:"method,omitempty"`
+		// Query is the schema of the query string parameters.
+		Query *openapi.Schema `json:"query,omitempty" yaml:"query,omitempty"`
+		// Headers is the schema of the request headers.
+		Headers *openapi.Schema `json:"headers,omitempty" yaml:"headers,omitempty"`
+		// BindingVersion is the version of the binding.
+		BindingVersion string `json:"bindingVersion,omitempty" yaml:"bindingVersion,omitempty"`
+	}
+
+	// Operation describes an action performed by the API on a channel.
+	Operation struct {
+		// Action is "send" if the API sends the messages, "receive" if
+		// the API receives them.
+		Action string `json:"action" yaml:"action"`
+		// Channel references the channel of the operation.
+		Channel *Ref `json:"channel" yaml:"channel"`
+		// Summary is a short summary of what the operation does.
+		Summary string `json:"summary,omitempty" yaml:"summary,omitempty"`
+		// Description is a verbose explanation of the operation.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		// Messages references the channel messages of the operation.
+		Messages []*Ref `json:"messages,omitempty" yaml:"messages,omitempty"`
+		// Bindings describes the protocol specific settings.
+		Bindings *OperationBindings `json:"bindings,omitempty" yaml:"bindings,omitempty"`
+	}
+
+	// OperationBindings lists the protocol specific settings of an
+	// operation.
+	OperationBindings struct {
+		// HTTP describes the HTTP request of the streaming responses.
+		HTTP *HTTPBinding `json:"http,omitempty" yaml:"http,omitempty"`
+	}
+
+	// HTTPBinding describes the HTTP request of an operation.
+	HTTPBinding struct {
+		// Method is the HTTP method of the request.
+		Method string `json:"method,omitempty" yaml:"method,omitempty"`
+		// BindingVersion is the version of the binding.
+		BindingVersion string `json:"bindingVersion,omitempty" yaml:"bindingVersion,omitempty"`
+	}
+
+	// Components holds the reusable objects of the document.
+	Components struct {
+		// Schemas maps the names of the types to their schema.
+		Schemas map[string]*openapi.Schema `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+	}
+
+	// Ref is a reference to another object of the document.
+	Ref struct {
+		Ref string `json:"$ref" yaml:"$ref"`
+	}
+)
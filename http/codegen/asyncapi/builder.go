@@ -0,0 +1,467 @@
+package asyncapi
+
+import (
+	"fmt"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/openapi"
+)
+
+// bindingVersion is the version of the AsyncAPI websocket and HTTP bindings.
+const bindingVersion = "0.1.0"
+
+// schemaBuilder copies the JSON schemas produced by the openapi package so
+// that they reference the document components and records the referenced
+// definitions.
+type schemaBuilder struct {
+	refs []string
+	seen map[string]bool
+}
+
+// New returns the AsyncAPI document that describes the streaming endpoints and
+// the events of the given API, nil if the API does not define streaming
+// endpoints nor events. The websocket endpoints use the "ws" channel bindings
+// and the endpoints that stream their results in the HTTP responses (see
+// StreamingResponse) use the "http" operation bindings. The gRPC streaming
+// endpoints are described by channels whose addresses are the gRPC method
+// paths served by the "grpc" servers, AsyncAPI does not define gRPC bindings.
+// Each event topic (see Emits) is described by a channel whose address is the
+// topic and each method that emits an event by a "send" operation on the
+// channel.
+func New(root *expr.RootExpr) *V3 {
+	if root == nil || root.API == nil || root.API.HTTP == nil {
+		return nil
+	}
+	var (
+		channels   = make(map[string]*Channel)
+		operations = make(map[string]*Operation)
+		schemas    = &schemaBuilder{seen: make(map[string]bool)}
+		api        = root.API
+
+		ws, http, grpc bool
+	)
+	for _, svc := range api.HTTP.Services {
+		if !mustGenerate(svc.Meta) || !mustGenerate(svc.ServiceExpr.Meta) {
+			continue
+		}
+		for _, e := range svc.HTTPEndpoints {
+			if !e.MethodExpr.IsStreaming() || len(e.Routes) == 0 {
+				continue
+			}
+			if !mustGenerate(e.Meta) || !mustGenerate(e.MethodExpr.Meta) {
+				continue
+			}
+			id := codegen.SnakeCase(svc.Name()) + "_" + codegen.SnakeCase(e.Name())
+			route := e.Routes[0]
+			address := route.FullPaths()[0]
+			ch := &Channel{
+				Address:     address,
+				Description: e.Description(),
+				Messages:    make(map[string]*Message),
+				Parameters:  parametersFromExpr(e, address),
+			}
+			summary := fmt.Sprintf("%s %s", e.Name(), svc.Name())
+			if e.StreamingResponse == "" {
+				ws = true
+				ch.Servers = serverRefs(api, "ws")
+				ch.Bindings = &ChannelBindings{WS: &WebSocketBinding{
+					Method:         route.Method,
+					Query:          schemas.paramsSchema(api, e.QueryParams()),
+					Headers:        schemas.paramsSchema(api, e.Headers),
+					BindingVersion: bindingVersion,
+				}}
+			} else {
+				http = true
+				ch.Servers = serverRefs(api, "http")
+			}
+			if e.MethodExpr.IsPayloadStreaming() && e.StreamingBody != nil {
+				ch.Messages["payload"] = &Message{
+					Name:        codegen.Goify(e.Name(), true) + "Payload",
+					Title:       fmt.Sprintf("%s %s streaming payload", e.Name(), svc.Name()),
+					ContentType: "application/json",
+					Payload:     schemas.schema(bodySchema(api, e.StreamingBody, svc.Name())),
+				}
+				operations[id+"_receive"] = &Operation{
+					Action:   "receive",
+					Channel:  &Ref{Ref: "#/channels/" + id},
+					Summary:  summary,
+					Messages: []*Ref{{Ref: "#/channels/" + id + "/messages/payload"}},
+				}
+			}
+			if e.MethodExpr.Stream == expr.ServerStreamKind || e.MethodExpr.Stream == expr.BidirectionalStreamKind {
+				if body := resultBody(e); body != nil {
+					ch.Messages["result"] = &Message{
+						Name:        codegen.Goify(e.Name(), true) + "Result",
+						Title:       fmt.Sprintf("%s %s streaming result", e.Name(), svc.Name()),
+						ContentType: "application/json",
+						Payload:     schemas.schema(bodySchema(api, body, svc.Name())),
+					}
+				}
+				op := &Operation{
+					Action:  "send",
+					Channel: &Ref{Ref: "#/channels/" + id},
+					Summary: summary,
+				}
+				if _, ok := ch.Messages["result"]; ok {
+					op.Messages = []*Ref{{Ref: "#/channels/" + id + "/messages/result"}}
+				}
+				if e.StreamingResponse != "" {
+					op.Bindings = &OperationBindings{HTTP: &HTTPBinding{
+						Method:         route.Method,
+						BindingVersion: bindingVersion,
+					}}
+				}
+				operations[id+"_send"] = op
+			}
+			channels[id] = ch
+		}
+		eventChannels(svc.ServiceExpr, api, channels, operations, schemas)
+	}
+	if api.GRPC != nil {
+		grpc = grpcChannels(api, channels, operations, schemas)
+	}
+	if len(channels) == 0 {
+		return nil
+	}
+	doc := &V3{
+		AsyncAPI: "3.0.0",
+		Info: &Info{
+			Title:       api.Title,
+			Version:     api.Version,
+			Description: api.Description,
+		},
+		Servers:    serversFromExpr(api, ws, http, grpc),
+		Channels:   channels,
+		Operations: operations,
+	}
+	if defs := schemas.definitions(); len(defs) > 0 {
+		doc.Components = &Components{Schemas: defs}
+	}
+	return doc
+}
+
+// grpcChannels adds the channels describing the gRPC streaming endpoints of
+// the given API and the corresponding operations. It returns true if it added
+// any channel.
+func grpcChannels(api *expr.APIExpr, channels map[string]*Channel, operations map[string]*Operation, schemas *schemaBuilder) bool {
+	var found bool
+	for _, svc := range api.GRPC.Services {
+		if !mustGenerate(svc.Meta) || !mustGenerate(svc.ServiceExpr.Meta) {
+			continue
+		}
+		pkg := codegen.SnakeCase(codegen.Goify(codegen.SnakeCase(codegen.Goify(svc.Name(), false)), false))
+		for _, e := range svc.GRPCEndpoints {
+			m := e.MethodExpr
+			if !m.IsStreaming() || !mustGenerate(e.Meta) || !mustGenerate(m.Meta) {
+				continue
+			}
+			found = true
+			id := "grpc_" + codegen.SnakeCase(svc.Name()) + "_" + codegen.SnakeCase(e.Name())
+			ch := &Channel{
+				Address:     fmt.Sprintf("/%s.%s/%s", pkg, codegen.Goify(svc.Name(), true), codegen.Goify(e.Name(), true)),
+				Description: e.Description(),
+				Messages:    make(map[string]*Message),
+				Servers:     serverRefs(api, "grpc"),
+			}
+			summary := fmt.Sprintf("%s %s", e.Name(), svc.Name())
+			if m.IsPayloadStreaming() && m.StreamingPayload.Type != expr.Empty {
+				ch.Messages["payload"] = &Message{
+					Name:        codegen.Goify(e.Name(), true) + "Payload",
+					Title:       fmt.Sprintf("%s %s streaming payload", e.Name(), svc.Name()),
+					ContentType: "application/grpc",
+					Payload:     schemas.schema(bodySchema(api, m.StreamingPayload, svc.Name())),
+				}
+				operations[id+"_receive"] = &Operation{
+					Action:   "receive",
+					Channel:  &Ref{Ref: "#/channels/" + id},
+					Summary:  summary,
+					Messages: []*Ref{{Ref: "#/channels/" + id + "/messages/payload"}},
+				}
+			}
+			if m.Stream == expr.ServerStreamKind || m.Stream == expr.BidirectionalStreamKind {
+				op := &Operation{
+					Action:  "send",
+					Channel: &Ref{Ref: "#/channels/" + id},
+					Summary: summary,
+				}
+				if m.Result.Type != expr.Empty {
+					ch.Messages["result"] = &Message{
+						Name:        codegen.Goify(e.Name(), true) + "Result",
+						Title:       fmt.Sprintf("%s %s streaming result", e.Name(), svc.Name()),
+						ContentType: "application/grpc",
+						Payload:     schemas.schema(bodySchema(api, m.Result, svc.Name())),
+					}
+					op.Messages = []*Ref{{Ref: "#/channels/" + id + "/messages/result"}}
+				}
+				operations[id+"_send"] = op
+			}
+			channels[id] = ch
+		}
+	}
+	return found
+}
+
+// eventChannels adds the channels describing the topics of the events emitted
+// by the methods of the given service and the corresponding send operations.
+func eventChannels(svc *expr.ServiceExpr, api *expr.APIExpr, channels map[string]*Channel, operations map[string]*Operation, schemas *schemaBuilder) {
+	for _, m := range svc.Methods {
+		if !mustGenerate(m.Meta) {
+			continue
+		}
+		for _, e := range m.Events {
+			id := codegen.SnakeCase(codegen.Goify(e.Topic, true))
+			if _, ok := channels[id]; !ok {
+				channels[id] = &Channel{
+					Address:     e.Topic,
+					Description: e.Description,
+					Messages: map[string]*Message{"event": {
+						Name:        codegen.Goify(e.Attribute.Type.Name(), true),
+						Title:       fmt.Sprintf("%s event", e.Topic),
+						ContentType: "application/json",
+						Payload:     schemas.schema(bodySchema(api, e.Attribute, svc.Name)),
+					}},
+				}
+			}
+			operations[codegen.SnakeCase(svc.Name)+"_"+codegen.SnakeCase(m.Name)+"_"+id] = &Operation{
+				Action:   "send",
+				Channel:  &Ref{Ref: "#/channels/" + id},
+				Summary:  fmt.Sprintf("%s %s emits %s", m.Name, svc.Name, e.Topic),
+				Messages: []*Ref{{Ref: "#/channels/" + id + "/messages/event"}},
+			}
+		}
+	}
+}
+
+// serversFromExpr returns the servers of the document indexed by name. The
+// websocket servers are listed if ws is true, the HTTP servers if http is true
+// and the gRPC servers if grpc is true.
+func serversFromExpr(api *expr.APIExpr, ws, http, grpc bool) map[string]*Server {
+	servers := make(map[string]*Server)
+	for _, s := range api.Servers {
+		for _, h := range s.Hosts {
+			desc := h.Description
+			if desc == "" {
+				desc = s.Description
+			}
+			for _, u := range h.URIs {
+				scheme, host, pathname := splitURI(string(u))
+				var protocols []string
+				if ws {
+					protocols = appendProtocol(protocols, scheme, "ws")
+				}
+				if http {
+					protocols = appendProtocol(protocols, scheme, "http")
+				}
+				if grpc {
+					protocols = appendProtocol(protocols, scheme, "grpc")
+				}
+				for _, p := range protocols {
+					servers[serverName(s, h, p)] = &Server{
+						Host:        host,
+						Protocol:    p,
+						Pathname:    pathname,
+						Description: desc,
+					}
+				}
+			}
+		}
+	}
+	return servers
+}
+
+// serverRefs returns the references to the servers of the given transport,
+// one of "ws", "http" or "grpc".
+func serverRefs(api *expr.APIExpr, transport string) []*Ref {
+	var refs []*Ref
+	for _, s := range api.Servers {
+		for _, h := range s.Hosts {
+			for _, u := range h.URIs {
+				scheme, _, _ := splitURI(string(u))
+				if p := protocol(scheme, transport); p != "" {
+					refs = append(refs, &Ref{Ref: "#/servers/" + serverName(s, h, p)})
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// appendProtocol appends the protocol of the given transport corresponding to
+// the given URI scheme to protocols if any.
+func appendProtocol(protocols []string, scheme, transport string) []string {
+	if p := protocol(scheme, transport); p != "" {
+		return append(protocols, p)
+	}
+	return protocols
+}
+
+// protocol returns the AsyncAPI protocol of the given transport for the given
+// URI scheme, the empty string if the scheme does not apply to the transport.
+func protocol(scheme, transport string) string {
+	switch transport {
+	case "ws":
+		switch scheme {
+		case "http":
+			return "ws"
+		case "https":
+			return "wss"
+		}
+	case "http":
+		if scheme == "http" || scheme == "https" {
+			return scheme
+		}
+	case "grpc":
+		if scheme == "grpc" || scheme == "grpcs" {
+			return scheme
+		}
+	}
+	return ""
+}
+
+// serverName returns the name of the document server corresponding to the
+// given design host and protocol.
+func serverName(s *expr.ServerExpr, h *expr.HostExpr, protocol string) string {
+	return codegen.SnakeCase(s.Name) + "_" + codegen.SnakeCase(h.Name) + "_" + protocol
+}
+
+// splitURI returns the scheme, host and path of the given URI.
+func splitURI(u string) (scheme, host, path string) {
+	i := strings.Index(u, "://")
+	if i < 0 {
+		return "", u, ""
+	}
+	scheme, host = u[:i], u[i+3:]
+	if j := strings.Index(host, "/"); j >= 0 {
+		host, path = host[:j], host[j:]
+	}
+	return scheme, host, strings.TrimSuffix(path, "/")
+}
+
+// parametersFromExpr returns the parameters of the given channel address.
+func parametersFromExpr(e *expr.HTTPEndpointExpr, address string) map[string]*Parameter {
+	wildcards := expr.ExtractHTTPWildcards(address)
+	if len(wildcards) == 0 {
+		return nil
+	}
+	params := make(map[string]*Parameter, len(wildcards))
+	pp := e.PathParams()
+	for _, w := range wildcards {
+		p := &Parameter{}
+		for _, nat := range *expr.AsObject(pp.Type) {
+			if pp.ElemName(nat.Name) != w {
+				continue
+			}
+			at := nat.Attribute
+			p.Description = at.Description
+			if at.Validation != nil {
+				for _, v := range at.Validation.Values {
+					p.Enum = append(p.Enum, fmt.Sprintf("%v", v))
+				}
+			}
+			if at.DefaultValue != nil {
+				p.Default = fmt.Sprintf("%v", at.DefaultValue)
+			}
+		}
+		params[w] = p
+	}
+	return params
+}
+
+// resultBody returns the body of the successful response of the given
+// endpoint, nil if there is none.
+func resultBody(e *expr.HTTPEndpointExpr) *expr.AttributeExpr {
+	for _, r := range e.Responses {
+		if r.StatusCode < 400 && r.Body != nil && r.Body.Type != expr.Empty {
+			return r.Body
+		}
+	}
+	return nil
+}
+
+// bodySchema returns the JSON schema of the given body.
+func bodySchema(api *expr.APIExpr, body *expr.AttributeExpr, prefix string) *openapi.Schema {
+	if mt, ok := body.Type.(*expr.ResultTypeExpr); ok {
+		view := expr.DefaultView
+		if v, ok := body.Meta["view"]; ok {
+			view = v[0]
+		}
+		return &openapi.Schema{Ref: openapi.ResultTypeRefWithPrefix(api, mt, view, prefix)}
+	}
+	return openapi.AttributeTypeSchemaWithPrefix(api, body, prefix)
+}
+
+// paramsSchema returns the JSON schema of the object whose properties are the
+// given query string parameters or headers, nil if there are none.
+func (b *schemaBuilder) paramsSchema(api *expr.APIExpr, params *expr.MappedAttributeExpr) *openapi.Schema {
+	if params == nil || params.IsEmpty() {
+		return nil
+	}
+	s := &openapi.Schema{Type: openapi.Object, Properties: make(map[string]*openapi.Schema)}
+	codegen.WalkMappedAttr(params, func(_, elem string, required bool, at *expr.AttributeExpr) error {
+		p := openapi.AttributeTypeSchema(api, at)
+		p.Description = at.Description
+		s.Properties[elem] = b.schema(p)
+		if required {
+			s.Required = append(s.Required, elem)
+		}
+		return nil
+	})
+	return s
+}
+
+// schema returns a copy of s where the references to the definitions use the
+// components of the document.
+func (b *schemaBuilder) schema(s *openapi.Schema) *openapi.Schema {
+	if s == nil {
+		return nil
+	}
+	if strings.HasPrefix(s.Ref, "#/definitions/") {
+		// Ref is exclusive with other fields
+		name := strings.TrimPrefix(s.Ref, "#/definitions/")
+		if !b.seen[name] {
+			b.seen[name] = true
+			b.refs = append(b.refs, name)
+		}
+		return &openapi.Schema{Ref: "#/components/schemas/" + name}
+	}
+	c := *s
+	c.Media = nil
+	c.Links = nil
+	c.Definitions = nil
+	c.Items = b.schema(s.Items)
+	if len(s.Properties) > 0 {
+		c.Properties = make(map[string]*openapi.Schema, len(s.Properties))
+		for n, p := range s.Properties {
+			c.Properties[n] = b.schema(p)
+		}
+	}
+	c.AnyOf = nil
+	for _, a := range s.AnyOf {
+		c.AnyOf = append(c.AnyOf, b.schema(a))
+	}
+	return &c
+}
+
+// definitions returns the schemas of the definitions referenced by the
+// schemas copied with schema and their own references.
+func (b *schemaBuilder) definitions() map[string]*openapi.Schema {
+	defs := make(map[string]*openapi.Schema)
+	for i := 0; i < len(b.refs); i++ {
+		name := b.refs[i]
+		if d, ok := openapi.Definitions[name]; ok {
+			defs[name] = b.schema(d)
+		}
+	}
+	return defs
+}
+
+// mustGenerate returns true if the meta does not exclude the element from
+// the generated specifications.
+func mustGenerate(meta expr.MetaExpr) bool {
+	if m, ok := meta["swagger:generate"]; ok && len(m) > 0 && m[0] == "false" {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,30 @@
+package asyncapi
+
+import (
+	"testing"
+)
+
+func TestSplitURI(t *testing.T) {
+	cases := []struct {
+		uri, scheme, host, path string
+	}{
+		{"http://localhost:8080", "http", "localhost:8080", ""},
+		{"https://goa.design/api/", "https", "goa.design", "/api"},
+		{"https://{version}.goa.design/api/v1", "https", "{version}.goa.design", "/api/v1"},
+		{"localhost", "", "localhost", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.uri, func(t *testing.T) {
+			scheme, host, path := splitURI(c.uri)
+			if scheme != c.scheme || host != c.host || path != c.path {
+				t.Errorf("got (%q, %q, %q), expected (%q, %q, %q)", scheme, host, path, c.scheme, c.host, c.path)
+			}
+		})
+	}
+}
+
+func TestNewNil(t *testing.T) {
+	if doc := New(nil); doc != nil {
+		t.Errorf("got %v, expected nil", doc)
+	}
+}
@@ -0,0 +1,73 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"text/template"
+
+	"goa.design/goa/v3/http/codegen/openapi"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestAsyncAPI(t *testing.T) {
+	var (
+		goldenPath = filepath.Join("testdata", "asyncapi", t.Name())
+	)
+	cases := []struct {
+		Name string
+		DSL  func()
+	}{
+		{"streaming", testdata.AsyncAPIDSL},
+		{"events", testdata.AsyncAPIEventsDSL},
+		{"grpc", testdata.AsyncAPIGRPCDSL},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			// Reset global variables
+			openapi.Definitions = make(map[string]*openapi.Schema)
+			root := RunHTTPDSL(t, c.DSL)
+			oFiles, err := OpenAPIFiles(root)
+			if err != nil {
+				t.Fatalf("OpenAPI failed with %s", err)
+			}
+			if len(oFiles) != 4 {
+				t.Fatalf("got %d files, expected 4", len(oFiles))
+			}
+			if oFiles[2].Path != filepath.Join("gen", "http", "asyncapi.json") {
+				t.Errorf("invalid output path %#v", oFiles[2].Path)
+			}
+			if oFiles[3].Path != filepath.Join("gen", "http", "asyncapi.yaml") {
+				t.Errorf("invalid output path %#v", oFiles[3].Path)
+			}
+			for i, o := range oFiles[2:] {
+				tname := fmt.Sprintf("file%d", i)
+				s := o.SectionTemplates
+				t.Run(tname, func(t *testing.T) {
+					var buf bytes.Buffer
+					tmpl := template.Must(template.New("asyncapi").Funcs(s[0].FuncMap).Parse(s[0].Source))
+					if err := tmpl.Execute(&buf, s[0].Data); err != nil {
+						t.Fatalf("failed to render template: %s", err)
+					}
+
+					golden := filepath.Join(goldenPath, fmt.Sprintf("%s_%s.golden", c.Name, tname))
+					if *update {
+						if err := ioutil.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+							t.Fatalf("failed to update golden file: %s", err)
+						}
+					}
+
+					want, err := ioutil.ReadFile(golden)
+					if err != nil {
+						t.Fatalf("failed to read golden file: %s", err)
+					}
+					if !bytes.Equal(buf.Bytes(), want) {
+						t.Errorf("result do not match the golden file:\n--BEGIN--\n%s\n--END--\n", buf.Bytes())
+					}
+				})
+			}
+		})
+	}
+}
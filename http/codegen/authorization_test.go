@@ -0,0 +1,35 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestAuthorizationHandler(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Endpoint string
+		Code     string
+	}{
+		{"secure", "MethodSecure", testdata.AuthorizationSecureHandlerCode},
+		{"public", "MethodPublic", testdata.AuthorizationPublicHandlerCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, testdata.AuthorizationDSL)
+			fs := ServerFiles("", expr.Root)
+			var code string
+			for _, s := range fs[0].Section("server-handler-init") {
+				if s.Data.(*EndpointData).Method.VarName == c.Endpoint {
+					code = codegen.SectionCode(t, s)
+				}
+			}
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
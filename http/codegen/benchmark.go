@@ -0,0 +1,483 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// benchmarkData contains the data needed to render the benchmarks of
+	// an endpoint.
+	benchmarkData struct {
+		*EndpointData
+		// Request describes the request built from the design examples.
+		Request *benchmarkRequestData
+		// Response describes the response decoded to build the result
+		// returned by the benchmarked endpoint, nil if the method has no
+		// result.
+		Response *benchmarkResponseData
+		// View is the view used to render the result if the result is a
+		// result type.
+		View string
+		// MaxAllocs is the endpoint allocation budget, zero if none.
+		MaxAllocs int
+	}
+
+	// benchmarkRequestData describes a HTTP request built from the design
+	// examples.
+	benchmarkRequestData struct {
+		// Method is the HTTP method.
+		Method string
+		// Pattern is the route pattern.
+		Pattern string
+		// URL is the request URL including the query string.
+		URL string
+		// Headers lists the request headers.
+		Headers []*benchmarkHeaderData
+		// Body is the JSON encoded request body if any.
+		Body string
+		// Username is the basic auth user name if any.
+		Username string
+		// Password is the basic auth password.
+		Password string
+	}
+
+	// benchmarkResponseData describes a HTTP response built from the
+	// design examples.
+	benchmarkResponseData struct {
+		// StatusCode is the response status code.
+		StatusCode int
+		// Headers lists the response headers.
+		Headers []*benchmarkHeaderData
+		// Trailers lists the response trailers.
+		Trailers []*benchmarkHeaderData
+		// Body is the JSON encoded response body if any.
+		Body string
+	}
+
+	// benchmarkHeaderData describes a HTTP header and its values.
+	benchmarkHeaderData struct {
+		// Name is the header name.
+		Name string
+		// Values lists the header values.
+		Values []string
+	}
+)
+
+// BenchmarkFiles returns the files that contain the benchmarks of the HTTP
+// endpoints and the allocation budget tests. It returns nil if the design does
+// not enable benchmarks.
+func BenchmarkFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	if !root.API.HTTP.Benchmarks {
+		return nil
+	}
+	var fw []*codegen.File
+	for _, svc := range root.API.HTTP.Services {
+		benchs := buildBenchmarksData(svc)
+		if len(benchs) == 0 {
+			continue
+		}
+		fw = append(fw, benchmarkFile(genpkg, svc, benchs))
+		if f := allocBudgetFile(genpkg, svc, benchs); f != nil {
+			fw = append(fw, f)
+		}
+	}
+	return fw
+}
+
+// benchmarkFile returns the file containing the benchmarks of the service
+// endpoints.
+func benchmarkFile(genpkg string, svc *expr.HTTPServiceExpr, benchs []*benchmarkData) *codegen.File {
+	data := HTTPServices.Get(svc.Name())
+	svcName := codegen.SnakeCase(data.Service.VarName)
+	path := filepath.Join(codegen.Gendir, "http", svcName, "server", "bench_test.go")
+	title := fmt.Sprintf("%s HTTP server benchmarks", svc.Name())
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "server", []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "io/ioutil"},
+			{Path: "net/http"},
+			{Path: "net/http/httptest"},
+			{Path: "strings"},
+			{Path: "testing"},
+			codegen.GoaNamedImport("http", "goahttp"),
+			{Path: genpkg + "/" + svcName, Name: data.Service.PkgName},
+			{Path: genpkg + "/http/" + svcName + "/client"},
+		}),
+	}
+	for _, b := range benchs {
+		sections = append(sections, &codegen.SectionTemplate{Name: "benchmark-request", Source: benchmarkRequestT, Data: b})
+		if b.Response != nil {
+			sections = append(sections, &codegen.SectionTemplate{Name: "benchmark-result", Source: benchmarkResultT, Data: b})
+		}
+		sections = append(sections, &codegen.SectionTemplate{Name: "benchmark-handler", Source: benchmarkHandlerT, Data: b})
+		if b.Payload.Ref != "" {
+			sections = append(sections, &codegen.SectionTemplate{Name: "benchmark-decode", Source: benchmarkDecodeT, Data: b})
+		}
+		if b.Response != nil {
+			sections = append(sections, &codegen.SectionTemplate{Name: "benchmark-encode", Source: benchmarkEncodeT, Data: b})
+		}
+		sections = append(sections, &codegen.SectionTemplate{Name: "benchmark-serve", Source: benchmarkServeT, Data: b})
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections, Service: svc.Name()}
+}
+
+// allocBudgetFile returns the file containing the allocation budget tests of
+// the service endpoints, nil if no endpoint defines a budget.
+func allocBudgetFile(genpkg string, svc *expr.HTTPServiceExpr, benchs []*benchmarkData) *codegen.File {
+	var sections []*codegen.SectionTemplate
+	for _, b := range benchs {
+		if b.MaxAllocs > 0 {
+			sections = append(sections, &codegen.SectionTemplate{Name: "alloc-budget", Source: allocBudgetT, Data: b})
+		}
+	}
+	if len(sections) == 0 {
+		return nil
+	}
+	data := HTTPServices.Get(svc.Name())
+	svcName := codegen.SnakeCase(data.Service.VarName)
+	path := filepath.Join(codegen.Gendir, "http", svcName, "server", "alloc_budget_test.go")
+	title := fmt.Sprintf("%s HTTP server allocation budget tests", svc.Name())
+	header := codegen.Header(title, "server", []*codegen.ImportSpec{
+		{Path: "net/http"},
+		{Path: "net/http/httptest"},
+		{Path: "testing"},
+	})
+	return &codegen.File{Path: path, SectionTemplates: append([]*codegen.SectionTemplate{header}, sections...), Service: svc.Name()}
+}
+
+// buildBenchmarksData returns the data needed to render the benchmarks of the
+// service endpoints. Endpoints that cannot be benchmarked or whose examples
+// cannot be used to build requests and responses are skipped.
+func buildBenchmarksData(svc *expr.HTTPServiceExpr) []*benchmarkData {
+	data := HTTPServices.Get(svc.Name())
+	var benchs []*benchmarkData
+	for _, e := range svc.HTTPEndpoints {
+		if !e.Benchmarkable() {
+			continue
+		}
+		var ed *EndpointData
+		for _, d := range data.Endpoints {
+			if d.Method.Name == e.Name() {
+				ed = d
+				break
+			}
+		}
+		if ed == nil || len(ed.Routes) == 0 {
+			continue
+		}
+		req, ok := buildBenchmarkRequest(e, ed.Routes[0])
+		if !ok {
+			continue
+		}
+		b := &benchmarkData{EndpointData: ed, Request: req, MaxAllocs: e.MaxAllocs}
+		if ed.Result != nil && len(e.Responses) > 0 {
+			b.Response, ok = buildBenchmarkResponse(e.Responses[0])
+			if !ok {
+				continue
+			}
+			if vr := ed.Method.ViewedResult; vr != nil {
+				b.View = vr.ViewName
+				if b.View == "" {
+					b.View = expr.DefaultView
+					b.Response.Headers = append(b.Response.Headers, &benchmarkHeaderData{Name: "Goa-View", Values: []string{b.View}})
+				}
+			}
+		}
+		benchs = append(benchs, b)
+	}
+	return benchs
+}
+
+// buildBenchmarkRequest builds the request sent to the endpoint from the
+// design examples. It returns false if the examples cannot be used to build the
+// request.
+func buildBenchmarkRequest(e *expr.HTTPEndpointExpr, route *RouteData) (*benchmarkRequestData, bool) {
+	if e.MapQueryParams != nil {
+		return nil, false
+	}
+	r := expr.Root.API.Random()
+	req := &benchmarkRequestData{Method: route.Verb, Pattern: route.Path}
+
+	// path
+	params := e.PathParams()
+	p := expr.HTTPWildcardRegex.ReplaceAllStringFunc(route.Path, func(wc string) string {
+		name := expr.HTTPWildcardRegex.FindStringSubmatch(wc)[1]
+		att := expr.AsObject(params.Type).Attribute(params.KeyName(name))
+		if att == nil {
+			return wc
+		}
+		return "/" + url.PathEscape(strings.Join(benchmarkValues(att.Example(r)), ","))
+	})
+	if strings.Contains(p, "{") {
+		return nil, false
+	}
+
+	// query string
+	query := e.QueryParams()
+	var qs []string
+	for _, nat := range *expr.AsObject(query.Type) {
+		if expr.IsMap(nat.Attribute.Type) || expr.IsObject(nat.Attribute.Type) {
+			if query.IsRequired(nat.Name) {
+				return nil, false
+			}
+			continue
+		}
+		name := url.QueryEscape(query.ElemName(nat.Name))
+		vals := benchmarkValues(nat.Attribute.Example(r))
+		if d := expr.QueryDelimiter(nat.Attribute); d != "" {
+			vals = []string{strings.Join(vals, d)}
+		}
+		for _, v := range vals {
+			qs = append(qs, name+"="+url.QueryEscape(v))
+		}
+	}
+	req.URL = p
+	if len(qs) > 0 {
+		req.URL += "?" + strings.Join(qs, "&")
+	}
+
+	// headers
+	hdrs, ok := benchmarkHeaders(e.Headers, r)
+	if !ok {
+		return nil, false
+	}
+	req.Headers = hdrs
+
+	// basic auth
+	if user := expr.TaggedAttribute(e.MethodExpr.Payload, "security:username"); user != "" {
+		obj := expr.AsObject(e.MethodExpr.Payload.Type)
+		req.Username = strings.Join(benchmarkValues(obj.Attribute(user).Example(r)), "")
+		if pass := expr.TaggedAttribute(e.MethodExpr.Payload, "security:password"); pass != "" {
+			req.Password = strings.Join(benchmarkValues(obj.Attribute(pass).Example(r)), "")
+		}
+	}
+
+	// body
+	if e.Body != nil && e.Body.Type != expr.Empty {
+		body, err := json.Marshal(e.Body.Example(r))
+		if err != nil {
+			return nil, false
+		}
+		req.Body = string(body)
+	}
+	return req, true
+}
+
+// buildBenchmarkResponse builds the response decoded by the client code to
+// initialize the result returned by the benchmarked endpoint. It returns false
+// if the examples cannot be used to build the response.
+func buildBenchmarkResponse(resp *expr.HTTPResponseExpr) (*benchmarkResponseData, bool) {
+	r := expr.Root.API.Random()
+	res := &benchmarkResponseData{StatusCode: resp.StatusCode}
+	var ok bool
+	if res.Headers, ok = benchmarkHeaders(resp.Headers, r); !ok {
+		return nil, false
+	}
+	if res.Trailers, ok = benchmarkHeaders(resp.Trailers, r); !ok {
+		return nil, false
+	}
+	if resp.Body != nil && resp.Body.Type != expr.Empty {
+		body, err := json.Marshal(resp.Body.Example(r))
+		if err != nil {
+			return nil, false
+		}
+		res.Body = string(body)
+	}
+	return res, true
+}
+
+// benchmarkHeaders returns the headers built from the examples of the given
+// mapped attribute. It returns false if a required header cannot be built.
+func benchmarkHeaders(ma *expr.MappedAttributeExpr, r *expr.Random) ([]*benchmarkHeaderData, bool) {
+	if ma == nil || ma.IsEmpty() {
+		return nil, true
+	}
+	var hdrs []*benchmarkHeaderData
+	for _, nat := range *expr.AsObject(ma.Type) {
+		if expr.IsMap(nat.Attribute.Type) || expr.IsObject(nat.Attribute.Type) {
+			if ma.IsRequired(nat.Name) {
+				return nil, false
+			}
+			continue
+		}
+		hdrs = append(hdrs, &benchmarkHeaderData{
+			Name:   ma.ElemName(nat.Name),
+			Values: benchmarkValues(nat.Attribute.Example(r)),
+		})
+	}
+	return hdrs, true
+}
+
+// benchmarkValues returns the string representations of the given example
+// value, one per element if the value is an array.
+func benchmarkValues(v interface{}) []string {
+	if b, ok := v.([]byte); ok {
+		return []string{string(b)}
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return []string{fmt.Sprint(v)}
+	}
+	vals := make([]string, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		vals[i] = fmt.Sprint(rv.Index(i).Interface())
+	}
+	return vals
+}
+
+// input: benchmarkData
+const benchmarkRequestT = `{{ printf "new%sBenchRequest returns a %q service %q request built from the design examples." .Method.VarName .ServiceName .Method.Name | comment }}
+func new{{ .Method.VarName }}BenchRequest() *http.Request {
+	req := httptest.NewRequest({{ printf "%q" .Request.Method }}, {{ printf "%q" .Request.URL }}, {{ if .Request.Body }}strings.NewReader({{ printf "%q" .Request.Body }}){{ else }}nil{{ end }})
+{{- if .Request.Body }}
+	req.Header.Set("Content-Type", "application/json")
+{{- end }}
+{{- range $h := .Request.Headers }}
+	{{- range $h.Values }}
+	req.Header.Add({{ printf "%q" $h.Name }}, {{ printf "%q" . }})
+	{{- end }}
+{{- end }}
+{{- if .Request.Username }}
+	req.SetBasicAuth({{ printf "%q" .Request.Username }}, {{ printf "%q" .Request.Password }})
+{{- end }}
+	return req
+}
+`
+
+// input: benchmarkData
+const benchmarkResultT = `{{ printf "new%sBenchResult returns a %q service %q result built from the design examples." .Method.VarName .ServiceName .Method.Name | comment }}
+func new{{ .Method.VarName }}BenchResult(tb testing.TB) interface{} {
+	resp := &http.Response{
+		StatusCode: {{ .Response.StatusCode }},
+		Header:     make(http.Header),
+	{{- if .Response.Trailers }}
+		Trailer:    make(http.Header),
+	{{- end }}
+	{{- if .Response.Body }}
+		Body:       ioutil.NopCloser(strings.NewReader({{ printf "%q" .Response.Body }})),
+	{{- else }}
+		Body:       http.NoBody,
+	{{- end }}
+	}
+	resp.Header.Set("Content-Type", "application/json")
+{{- range $h := .Response.Headers }}
+	{{- range $h.Values }}
+	resp.Header.Add({{ printf "%q" $h.Name }}, {{ printf "%q" . }})
+	{{- end }}
+{{- end }}
+{{- range $h := .Response.Trailers }}
+	{{- range $h.Values }}
+	resp.Trailer.Add({{ printf "%q" $h.Name }}, {{ printf "%q" . }})
+	{{- end }}
+{{- end }}
+	res, err := client.{{ .ResponseDecoder }}(goahttp.ResponseDecoder, false)(resp)
+	if err != nil {
+		tb.Fatal(err)
+	}
+{{- if .Method.ViewedResult }}
+	return {{ .ServicePkgName }}.{{ .Method.ViewedResult.Init.Name }}(res.({{ .Result.Ref }}), {{ printf "%q" .View }})
+{{- else }}
+	return res
+{{- end }}
+}
+`
+
+// input: benchmarkData
+const benchmarkHandlerT = `{{ printf "new%sBenchHandler returns a HTTP handler that serves the %q service %q requests using an endpoint that returns %s." .Method.VarName .ServiceName .Method.Name (or (and .Response "the example result") "immediately") | comment }}
+func new{{ .Method.VarName }}BenchHandler(tb testing.TB) http.Handler {
+{{- if .Response }}
+	res := new{{ .Method.VarName }}BenchResult(tb)
+{{- end }}
+	var (
+		endpoint = func(context.Context, interface{}) (interface{}, error) { return {{ if .Response }}res{{ else }}nil{{ end }}, nil }
+		eh       = func(_ context.Context, _ http.ResponseWriter, err error) { tb.Error(err) }
+		mux      = goahttp.NewMuxer()
+	)
+	{{ .MountHandler }}(mux, {{ .HandlerInit }}(endpoint, mux, goahttp.RequestDecoder, goahttp.PooledResponseEncoder, eh))
+	return mux
+}
+`
+
+// input: benchmarkData
+const benchmarkDecodeT = `{{ printf "Benchmark%sDecodeRequest measures the decoding of the %q service %q requests." .Method.VarName .ServiceName .Method.Name | comment }}
+func Benchmark{{ .Method.VarName }}DecodeRequest(b *testing.B) {
+	var (
+		mux    = goahttp.NewMuxer()
+		decode = {{ .RequestDecoder }}(mux, goahttp.RequestDecoder)
+		err    error
+	)
+	mux.Handle({{ printf "%q" .Request.Method }}, {{ printf "%q" .Request.Pattern }}, func(w http.ResponseWriter, r *http.Request) {
+		_, err = decode(r)
+	})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		mux.ServeHTTP(httptest.NewRecorder(), new{{ .Method.VarName }}BenchRequest())
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+`
+
+// input: benchmarkData
+const benchmarkEncodeT = `{{ printf "Benchmark%sEncodeResponse measures the encoding of the %q service %q responses." .Method.VarName .ServiceName .Method.Name | comment }}
+func Benchmark{{ .Method.VarName }}EncodeResponse(b *testing.B) {
+	var (
+		res    = new{{ .Method.VarName }}BenchResult(b)
+		encode = {{ .ResponseEncoder }}(goahttp.PooledResponseEncoder)
+		ctx    = context.Background()
+	)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := encode(ctx, httptest.NewRecorder(), res); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+`
+
+// input: benchmarkData
+const benchmarkServeT = `{{ printf "Benchmark%sHandler measures the handling of the %q service %q requests including the decoding of the request and the encoding of the response." .Method.VarName .ServiceName .Method.Name | comment }}
+func Benchmark{{ .Method.VarName }}Handler(b *testing.B) {
+	h := new{{ .Method.VarName }}BenchHandler(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, new{{ .Method.VarName }}BenchRequest())
+		if w.Code >= http.StatusBadRequest {
+			b.Fatalf("got status %d: %s", w.Code, w.Body.String())
+		}
+	}
+}
+`
+
+// input: benchmarkData
+const allocBudgetT = `{{ printf "Test%sAllocBudget makes sure that handling a %q service %q request allocates at most %d times. The allocations made to build the request and to record the response are not counted." .Method.VarName .ServiceName .Method.Name .MaxAllocs | comment }}
+func Test{{ .Method.VarName }}AllocBudget(t *testing.T) {
+	var (
+		h   = new{{ .Method.VarName }}BenchHandler(t)
+		req *http.Request
+		w   *httptest.ResponseRecorder
+	)
+	base := testing.AllocsPerRun(100, func() {
+		req, w = new{{ .Method.VarName }}BenchRequest(), httptest.NewRecorder()
+	})
+	allocs := testing.AllocsPerRun(100, func() {
+		req, w = new{{ .Method.VarName }}BenchRequest(), httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+	}) - base
+	if allocs > {{ .MaxAllocs }} {
+		t.Errorf("got %v allocations per request, expected at most {{ .MaxAllocs }}", allocs)
+	}
+}
+`
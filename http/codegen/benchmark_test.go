@@ -0,0 +1,44 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestBenchmarkFiles(t *testing.T) {
+	RunHTTPDSL(t, testdata.BenchmarkDSL)
+	fs := BenchmarkFiles("gen", expr.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected 2", len(fs))
+	}
+	cases := []struct {
+		Name string
+		File *codegen.File
+		Path string
+		Code string
+	}{
+		{"benchmarks", fs[0], "gen/http/service_benchmark/server/bench_test.go", testdata.BenchmarkCode},
+		{"alloc-budget", fs[1], "gen/http/service_benchmark/server/alloc_budget_test.go", testdata.AllocBudgetCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.File.Path != c.Path {
+				t.Errorf("got path %q, expected %q", c.File.Path, c.Path)
+			}
+			code := codegen.SectionsCode(t, c.File.SectionTemplates[1:])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestBenchmarkFilesDisabled(t *testing.T) {
+	RunHTTPDSL(t, testdata.ServerConfiguredFileServerDSL)
+	if fs := BenchmarkFiles("gen", expr.Root); len(fs) != 0 {
+		t.Errorf("got %d files, expected none", len(fs))
+	}
+}
@@ -0,0 +1,42 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestServerBodyLimit(t *testing.T) {
+	const genpkg = "gen"
+	RunHTTPDSL(t, testdata.ServerBodyLimitDSL)
+	fs := ServerFiles(genpkg, expr.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected two", len(fs))
+	}
+	cases := []struct {
+		Name    string
+		File    *codegen.File
+		Section string
+		Code    string
+	}{
+		{"handler-init", fs[0], "server-handler-init", testdata.ServerBodyLimitHandlerConstructorCode},
+		{"error-encoder", fs[1], "error-encoder", testdata.ServerBodyLimitErrorEncoderCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			for _, s := range c.File.SectionTemplates {
+				if s.Name != c.Section {
+					continue
+				}
+				code := codegen.SectionCode(t, s)
+				if code != c.Code {
+					t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+				}
+				return
+			}
+			t.Fatalf("section %q not found", c.Section)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestCacheServerInit(t *testing.T) {
+	RunHTTPDSL(t, testdata.CacheDSL)
+	sections := ServerFiles("gen", expr.Root)[0].Section("server-init")
+	if len(sections) != 1 {
+		t.Fatalf("got %d sections, expected one", len(sections))
+	}
+	code := codegen.SectionCode(t, sections[0])
+	if code != testdata.CacheServerInitCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.CacheServerInitCode))
+	}
+}
+
+func TestCacheClientResponseCache(t *testing.T) {
+	RunHTTPDSL(t, testdata.ClientCacheDSL)
+	sections := ClientFiles("gen", expr.Root)[0].Section("client-response-cache")
+	if len(sections) != 1 {
+		t.Fatalf("got %d sections, expected one", len(sections))
+	}
+	code := codegen.SectionCode(t, sections[0])
+	if code != testdata.ClientResponseCacheCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ClientResponseCacheCode))
+	}
+}
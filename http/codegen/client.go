@@ -3,6 +3,7 @@ package codegen
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/codegen/service"
@@ -18,6 +19,11 @@ func ClientFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 	for i, r := range root.API.HTTP.Services {
 		fw[i+len(root.API.HTTP.Services)] = clientEncodeDecode(genpkg, r)
 	}
+	for _, r := range root.API.HTTP.Services {
+		if f := clientWebhooks(r); f != nil {
+			fw = append(fw, f)
+		}
+	}
 	return fw
 }
 
@@ -43,6 +49,7 @@ func client(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 			codegen.GoaNamedImport("http", "goahttp"),
 			{Path: genpkg + "/" + svcName, Name: data.Service.PkgName},
 			{Path: genpkg + "/" + svcName + "/" + "views", Name: data.Service.ViewsPkg},
+			{Path: genpkg + "/http/telemetry"},
 		}),
 	}
 	sections = append(sections, &codegen.SectionTemplate{
@@ -52,7 +59,15 @@ func client(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 		FuncMap: map[string]interface{}{
 			"streamingEndpointExists": streamingEndpointExists,
 		},
+		Expr: svc,
 	})
+	if clientPolicyExists(data) {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "client-options",
+			Source: clientOptionsT,
+			Data:   data,
+		})
+	}
 	if streamingEndpointExists(data) {
 		sections = append(sections, &codegen.SectionTemplate{
 			Name:   "client-stream-conn-configurer-struct",
@@ -89,9 +104,39 @@ func client(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 		Data:   data,
 		FuncMap: map[string]interface{}{
 			"streamingEndpointExists": streamingEndpointExists,
+			"clientPolicyExists":      clientPolicyExists,
 		},
+		Expr: svc,
 	})
 
+	for _, u := range data.ServerURLs {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:    "client-server-url",
+			Source:  serverURLT,
+			Data:    u,
+			FuncMap: map[string]interface{}{"join": strings.Join},
+		})
+	}
+
+	for _, ss := range data.SignatureSchemes {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "client-sign-requests",
+			Source: clientSignRequestsT,
+			Data:   ss,
+		})
+	}
+
+	if cacheableEndpointExists(data) {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "client-response-cache",
+			Source: clientResponseCacheT,
+			Data:   data,
+			FuncMap: map[string]interface{}{
+				"isCacheableEndpoint": isCacheableEndpoint,
+			},
+		})
+	}
+
 	if streamingEndpointExists(data) {
 		sections = append(sections, &codegen.SectionTemplate{
 			Name:   "client-stream-conn-configurer-struct-init",
@@ -108,6 +153,7 @@ func client(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 			Name:   "client-endpoint-init",
 			Source: endpointInitT,
 			Data:   e,
+			Expr:   svc.Endpoint(e.Method.Name),
 		})
 		if e.ClientStream != nil {
 			if e.ClientStream.RecvTypeRef != "" {
@@ -152,7 +198,7 @@ func client(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 		}
 	}
 
-	return &codegen.File{Path: path, SectionTemplates: sections}
+	return &codegen.File{Path: path, SectionTemplates: sections, Service: svc.Name()}
 }
 
 // clientEncodeDecode returns the file containing the HTTP client encoding and
@@ -166,6 +212,7 @@ func clientEncodeDecode(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File
 		codegen.Header(title, "client", []*codegen.ImportSpec{
 			{Path: "bytes"},
 			{Path: "context"},
+			{Path: "encoding/json"},
 			{Path: "fmt"},
 			{Path: "io"},
 			{Path: "io/ioutil"},
@@ -183,10 +230,12 @@ func clientEncodeDecode(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File
 	}
 
 	for _, e := range data.Endpoints {
+		ee := svc.Endpoint(e.Method.Name)
 		sections = append(sections, &codegen.SectionTemplate{
 			Name:   "request-builder",
 			Source: requestBuilderT,
 			Data:   e,
+			Expr:   ee,
 		})
 		if e.RequestEncoder != "" {
 			sections = append(sections, &codegen.SectionTemplate{
@@ -201,6 +250,7 @@ func clientEncodeDecode(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File
 					"isBearer": isBearer,
 				},
 				Data: e,
+				Expr: ee,
 			})
 		}
 		if e.MultipartRequestEncoder != nil {
@@ -209,12 +259,20 @@ func clientEncodeDecode(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File
 				Source: multipartRequestEncoderT,
 				Data:   e.MultipartRequestEncoder,
 			})
+			if e.MultipartRequestEncoder.Default != "" {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "multipart-request-default-encoder",
+					Source: multipartRequestDefaultEncoderT,
+					Data:   e.MultipartRequestEncoder,
+				})
+			}
 		}
 		if e.Result != nil || len(e.Errors) > 0 {
 			sections = append(sections, &codegen.SectionTemplate{
 				Name:   "response-decoder",
 				Source: responseDecoderT,
 				Data:   e,
+				Expr:   ee,
 				FuncMap: map[string]interface{}{
 					"goTypeRef": func(dt expr.DataType) string {
 						return service.Services.Get(svc.Name()).Scope.GoTypeRef(&expr.AttributeExpr{Type: dt})
@@ -231,7 +289,33 @@ func clientEncodeDecode(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File
 		})
 	}
 
-	return &codegen.File{Path: path, SectionTemplates: sections}
+	return &codegen.File{Path: path, SectionTemplates: sections, Service: svc.Name()}
+}
+
+// clientWebhooks returns the file containing the functions that send the
+// webhooks of the given service, nil if the service defines no webhook.
+func clientWebhooks(svc *expr.HTTPServiceExpr) *codegen.File {
+	data := HTTPServices.Get(svc.Name())
+	if len(data.Webhooks) == 0 {
+		return nil
+	}
+	svcName := codegen.SnakeCase(data.Service.VarName)
+	path := filepath.Join(codegen.Gendir, "http", svcName, "client", "webhooks.go")
+	title := fmt.Sprintf("%s HTTP client webhooks", svc.Name())
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "client", []*codegen.ImportSpec{
+			{Path: "context"},
+			codegen.GoaNamedImport("http", "goahttp"),
+		}),
+	}
+	for _, w := range data.Webhooks {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "client-webhook-send",
+			Source: webhookSendT,
+			Data:   w,
+		})
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections, Service: svc.Name()}
 }
 
 // typeConversionData produces the template data suitable for executing the
@@ -286,6 +370,60 @@ type {{ .ClientStruct }} struct {
 	dialer goahttp.Dialer
 	configurer *ConnConfigurer
 	{{- end }}
+	{{- range .Endpoints }}
+		{{- if .ClientPolicy }}
+	{{ .ClientPolicy.VarName }} *goahttp.ClientPolicy
+		{{- end }}
+	{{- end }}
+}
+`
+
+// input: SignatureSchemeData
+const clientSignRequestsT = `{{ printf "Sign%sRequests signs the requests made to the endpoints secured by the %q security scheme with signer. The requests are signed on each attempt when a client policy retries them." .VarName .SchemeName | comment }}
+func (c *{{ .ClientStruct }}) Sign{{ .VarName }}Requests(signer *goahttp.RequestSigner) {
+{{- range .Endpoints }}
+	{{- if not .ClientStream }}
+	c.{{ .Method.VarName }}Doer = goahttp.SigningDoer(c.{{ .Method.VarName }}Doer, signer)
+	{{- end }}
+{{- end }}
+}
+`
+
+// input: ServiceData
+const clientResponseCacheT = `{{ printf "UseResponseCache caches the responses to the requests made to the %s service endpoints that use the GET method in store. The responses are cached per credentials and tenant as allowed by their Cache-Control header, the stale responses that have an ETag are revalidated with If-None-Match and decoded again when the server responds with 304 Not Modified." .Service.Name | comment }}
+func (c *{{ .ClientStruct }}) UseResponseCache(store goa.CacheStore) {
+{{- range .Endpoints }}
+	{{- if isCacheableEndpoint . }}
+	c.{{ .Method.VarName }}Doer = goahttp.CachingDoer(c.{{ .Method.VarName }}Doer, store{{ range .CacheKeyHeaders }}, {{ printf "%q" . }}{{ end }})
+	{{- end }}
+{{- end }}
+}
+`
+
+// input: ServiceData
+const clientOptionsT = `{{ printf "ClientOption customizes the %s created by New%s." .ClientStruct .ClientStruct | comment }}
+type ClientOption func(*{{ .ClientStruct }})
+{{- range .Endpoints }}
+	{{- if .ClientPolicy }}
+
+{{ printf "%s sets the policy applied to the requests made to the %s endpoint, it overrides the policy defined in the design." .ClientPolicy.Option .Method.Name | comment }}
+func {{ .ClientPolicy.Option }}(p *goahttp.ClientPolicy) ClientOption {
+	return func(c *{{ .ClientStruct }}) {
+		c.{{ .ClientPolicy.VarName }} = p
+	}
+}
+	{{- end }}
+{{- end }}
+
+{{ printf "WithCircuitBreaker sets the circuit breaker that guards the requests made to the %s service endpoints." .Service.Name | comment }}
+func WithCircuitBreaker(cb goahttp.CircuitBreaker) ClientOption {
+	return func(c *{{ .ClientStruct }}) {
+	{{- range .Endpoints }}
+		{{- if .ClientPolicy }}
+		c.{{ .ClientPolicy.VarName }} = c.{{ .ClientPolicy.VarName }}.WithBreaker(cb)
+		{{- end }}
+	{{- end }}
+	}
 }
 `
 
@@ -302,15 +440,18 @@ func New{{ .ClientStruct }}(
 	dialer goahttp.Dialer,
 	cfn *ConnConfigurer,
 	{{- end }}
+	{{- if clientPolicyExists . }}
+	opts ...ClientOption,
+	{{- end }}
 ) *{{ .ClientStruct }} {
 {{- if streamingEndpointExists . }}
 	if cfn == nil {
 		cfn = &ConnConfigurer{}
 	}
 {{- end }}
-	return &{{ .ClientStruct }}{
+	{{ if clientPolicyExists . }}c := {{ else }}return {{ end }}&{{ .ClientStruct }}{
 		{{- range .Endpoints }}
-		{{ .Method.VarName }}Doer: doer,
+		{{ .Method.VarName }}Doer: {{ if .Telemetry }}telemetry.Doer(doer, {{ printf "%q" .ServiceName }}, {{ printf "%q" .Method.Name }}){{ else }}doer{{ end }},
 		{{- end }}
 		RestoreResponseBody: restoreBody,
 		scheme:            scheme,
@@ -321,7 +462,59 @@ func New{{ .ClientStruct }}(
 		dialer: dialer,
 		configurer: cfn,
 		{{- end }}
+		{{- range .Endpoints }}
+			{{- if .ClientPolicy }}
+				{{- if or .ClientPolicy.Timeout .ClientPolicy.Retry }}
+		{{ .ClientPolicy.VarName }}: &goahttp.ClientPolicy{
+					{{- if .ClientPolicy.Timeout }}
+			Timeout: {{ .ClientPolicy.Timeout }},
+					{{- end }}
+					{{- with .ClientPolicy.Retry }}
+			Retry: &goahttp.RetryPolicy{
+				MaxAttempts:    {{ .MaxAttempts }},
+				InitialBackoff: {{ .InitialBackoff }},
+				MaxBackoff:     {{ .MaxBackoff }},
+						{{- if .StatusCodes }}
+				StatusCodes:    []int{ {{- range $i, $c := .StatusCodes }}{{ if $i }}, {{ end }}{{ $c }}{{ end }}},
+						{{- end }}
+			},
+					{{- end }}
+		},
+				{{- end }}
+			{{- end }}
+		{{- end }}
+	}
+	{{- if clientPolicyExists . }}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
+	{{- end }}
+}
+`
+
+// input: ServerURLData
+const serverURLT = `{{ printf "%s returns the URL of the %q host of the %q server built with the given values of the URL variables. It returns an error if a value is not allowed by the variable enum." .Name .Host .Server | comment }}
+{{- if .Description }}
+//
+{{ comment .Description }}
+{{- end }}
+func {{ .Name }}({{ range $i, $v := .Variables }}{{ if $i }}, {{ end }}{{ .VarName }} {{ .TypeRef }}{{ end }}) (string, error) {
+	u := {{ printf "%q" .URL }}
+{{- range .Variables }}
+	{
+		v := fmt.Sprintf("%v", {{ .VarName }})
+	{{- if .Values }}
+		switch v {
+		case {{ range $i, $e := .Values }}{{ if $i }}, {{ end }}{{ printf "%q" $e }}{{ end }}:
+		default:
+			return "", fmt.Errorf("invalid value for URL variable %q: %q (valid values: {{ join .Values ", " }})", {{ printf "%q" .Name }}, v)
+		}
+	{{- end }}
+		u = strings.Replace(u, {{ printf "{%s}" .Name | printf "%q" }}, v, -1)
+	}
+{{- end }}
+	return u, nil
 }
 `
 
@@ -330,13 +523,16 @@ const endpointInitT = `{{ printf "%s returns an endpoint that makes HTTP request
 func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder }}{{ .MultipartRequestEncoder.VarName }} {{ .MultipartRequestEncoder.FuncName }}{{ end }}) goa.Endpoint {
 	var (
 		{{- if and .ClientStream .RequestEncoder }}
-		encodeRequest  = {{ .RequestEncoder }}({{ if .MultipartRequestEncoder }}{{ .MultipartRequestEncoder.InitName }}({{ .MultipartRequestEncoder.VarName }}){{ else }}c.encoder{{ end }})
+		encodeRequest  = {{ .RequestEncoder }}({{ if .MultipartRequestEncoder }}{{ .MultipartRequestEncoder.InitName }}({{ .MultipartRequestEncoder.VarName }}){{ else if .FormEncoded }}goahttp.FormRequestEncoder{{ else }}c.encoder{{ end }})
 		{{- else }}
 			{{- if .RequestEncoder }}
-		encodeRequest  = {{ .RequestEncoder }}({{ if .MultipartRequestEncoder }}{{ .MultipartRequestEncoder.InitName }}({{ .MultipartRequestEncoder.VarName }}){{ else }}c.encoder{{ end }})
+		encodeRequest  = {{ .RequestEncoder }}({{ if .MultipartRequestEncoder }}{{ .MultipartRequestEncoder.InitName }}({{ .MultipartRequestEncoder.VarName }}){{ else if .FormEncoded }}goahttp.FormRequestEncoder{{ else }}c.encoder{{ end }})
 			{{- end }}
 		{{- end }}
 		decodeResponse = {{ .ResponseDecoder }}(c.decoder, c.RestoreResponseBody)
+		{{- if .Propagation }}
+		propagation    = {{ template "propagation" .Propagation }}
+		{{- end }}
 	)
 	return func(ctx context.Context, v interface{}) (interface{}, error) {
 		req, err := c.{{ .RequestInit.Name }}(ctx, {{ range .RequestInit.ClientArgs }}{{ .Ref }}{{ end }})
@@ -349,22 +545,44 @@ func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder
 			return nil, err
 		}
 	{{- end }}
+	{{- if .Propagation }}
+		goahttp.PropagateOutgoing(ctx, req, propagation)
+	{{- end }}
 
 	{{- if .ClientStream }}
+	{{- if .ClientStream.ChunkedFormat }}
+		resp, err := c.{{ .Method.VarName }}Doer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("{{ .ServiceName }}", "{{ .Method.Name }}", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return decodeResponse(resp)
+		}
+		stream := &{{ .ClientStream.VarName }}{r: goahttp.NewStreamReader(resp.Body, {{ .ClientStream.ChunkedFormat }})}
+	{{- else }}
 		var cancel context.CancelFunc
 		{
 			ctx, cancel = context.WithCancel(ctx)
 		}
-		conn, resp, err := c.dialer.DialContext(ctx, req.URL.String(), req.Header)
+	{{- if .ClientWebSocket }}
+	{{- template "websocket_config" .ClientWebSocket }}
+		conn, resp, err := {{ if .Telemetry }}telemetry.Dialer({{ end }}wsc.Dialer(c.dialer){{ if .Telemetry }}, {{ printf "%q" .ServiceName }}, {{ printf "%q" .Method.Name }}){{ end }}.DialContext(ctx, req.URL.String(), req.Header)
+	{{- else }}
+		conn, resp, err := {{ if .Telemetry }}telemetry.Dialer(c.dialer, {{ printf "%q" .ServiceName }}, {{ printf "%q" .Method.Name }}){{ else }}c.dialer{{ end }}.DialContext(ctx, req.URL.String(), req.Header)
+	{{- end }}
 		if err != nil {
 			if resp != nil {
 				return decodeResponse(resp)
 			}
 			return nil, goahttp.ErrRequestError("{{ .ServiceName }}", "{{ .Method.Name }}", err)
 		}
+	{{- if .ClientWebSocket }}
+		conn = wsc.ConnConfigureFunc(c.configurer.{{ .Method.VarName }}Fn)(conn, cancel)
+	{{- else }}
 		if c.configurer.{{ .Method.VarName }}Fn != nil {
 			conn = c.configurer.{{ .Method.VarName }}Fn(conn, cancel)
 		}
+	{{- end }}
 	{{- if eq .ClientStream.SendName "" }}
 		go func() {
 			<-ctx.Done()
@@ -377,6 +595,7 @@ func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder
 		}()
 	{{- end }}
 		stream := &{{ .ClientStream.VarName }}{conn: conn}
+	{{- end }}
 		{{- if .Method.ViewedResult }}
 			{{- if not .Method.ViewedResult.ViewName }}
 		view := resp.Header.Get("goa-view")
@@ -385,7 +604,11 @@ func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder
 		{{- end }}
 		return stream, nil
 	{{- else }}
+		{{- if .ClientPolicy }}
+		resp, err := goahttp.PolicyDoer(c.{{ .Method.VarName }}Doer, c.{{ .ClientPolicy.VarName }}).Do(req)
+		{{- else }}
 		resp, err := c.{{ .Method.VarName }}Doer.Do(req)
+		{{- end }}
 
 		if err != nil {
 			return nil, goahttp.ErrRequestError("{{ .ServiceName }}", "{{ .Method.Name }}", err)
@@ -394,7 +617,7 @@ func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder
 	{{- end }}
 	}
 }
-`
+` + webSocketConfigT + propagationT
 
 // input: EndpointData
 const requestBuilderT = `{{ comment .RequestInit.Description }}
@@ -430,6 +653,18 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 			{{- end }}
 		{{- end }}
 	{{- end }}
+	{{- range .Payload.Request.Cookies }}
+		{{- if .FieldPointer }}
+		if p.{{ .FieldName }} != nil {
+		{{- end }}
+		req.AddCookie(&http.Cookie{
+			Name: {{ printf "%q" .Name }},
+			Value: {{ if eq .Type.Name "string" }}{{ if .FieldPointer }}*{{ end }}p.{{ .FieldName }}{{ else }}fmt.Sprintf("%v", {{ if .FieldPointer }}*{{ end }}p.{{ .FieldName }}){{ end }},
+		})
+		{{- if .FieldPointer }}
+		}
+		{{- end }}
+	{{- end }}
 	{{- if or .Payload.Request.QueryParams }}
 		values := req.URL.Query()
 	{{- end }}
@@ -447,10 +682,23 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 			values.Add(keyStr, valueStr)
 			{{- end }}
     }
+		{{- else if and .StringSlice .Delimiter }}
+			if len(p{{ if .FieldName }}.{{ .FieldName }}{{ end }}) > 0 {
+				values.Add("{{ .Name }}", strings.Join(p{{ if .FieldName }}.{{ .FieldName }}{{ end }}, {{ printf "%q" .Delimiter }}))
+			}
 		{{- else if .StringSlice }}
 			for _, value := range p{{ if .FieldName }}.{{ .FieldName }}{{ end }} {
 				values.Add("{{ .Name }}", value)
 			}
+		{{- else if and .Slice .Delimiter }}
+			if len(p{{ if .FieldName }}.{{ .FieldName }}{{ end }}) > 0 {
+				{{ .VarName }}Strs := make([]string, len(p{{ if .FieldName }}.{{ .FieldName }}{{ end }}))
+				for i, value := range p{{ if .FieldName }}.{{ .FieldName }}{{ end }} {
+					{{ template "type_conversion" (typeConversionData .Type.ElemType.Type "valueStr" "value") }}
+					{{ .VarName }}Strs[i] = valueStr
+				}
+				values.Add("{{ .Name }}", strings.Join({{ .VarName }}Strs, {{ printf "%q" .Delimiter }}))
+			}
 		{{- else if .Slice }}
 			for _, value := range p{{ if .FieldName }}.{{ .FieldName }}{{ end }} {
 				{{ template "type_conversion" (typeConversionData .Type.ElemType.Type "valueStr" "value") }}
@@ -787,6 +1035,95 @@ const singleResponseT = ` {{- if .ClientBody }}
 		{{- end }}{{/* range .Headers */}}
 	{{- end }}
 
+	{{- if .Cookies }}
+			var (
+		{{- range .Cookies }}
+				{{ .VarName }} {{ .TypeRef }}
+		{{- end }}
+		{{- if not .ClientBody }}
+			{{- if not .Headers }}
+				{{- if .MustValidate }}
+				err error
+				{{- end }}
+			{{- end }}
+		{{- end }}
+			)
+		{{- range .Cookies }}
+		{
+			{{ .VarName }}Raw := goahttp.ResponseCookie(resp, {{ printf "%q" .Name }})
+			{{- if .Required }}
+			if {{ .VarName }}Raw == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError({{ printf "%q" .Name }}, "cookie"))
+			}
+			{{- end }}
+			{{- if and (eq .Type.Name "string") (not .Pointer) (not .DefaultValue) }}
+			{{ .VarName }} = {{ .VarName }}Raw
+			{{- else }}
+			if {{ .VarName }}Raw != "" {
+				{{- if eq .Type.Name "string" }}
+				{{ .VarName }} = {{ if .Pointer }}&{{ end }}{{ .VarName }}Raw
+				{{- else }}
+				{{- template "type_conversion" . }}
+				{{- end }}
+			}
+				{{- if .DefaultValue }} else {
+				{{ .VarName }} = {{ if eq .Type.Name "string" }}{{ printf "%q" .DefaultValue }}{{ else }}{{ printf "%#v" .DefaultValue }}{{ end }}
+			}
+				{{- end }}
+			{{- end }}
+		}
+		{{- if .Validate }}
+			{{ .Validate }}
+		{{- end }}
+		{{- end }}{{/* range .Cookies */}}
+	{{- end }}
+
+	{{- if .Trailers }}
+			// The trailers are only available once the response body has
+			// been read entirely.
+			io.Copy(ioutil.Discard, resp.Body)
+			var (
+		{{- range .Trailers }}
+				{{ .VarName }} {{ .TypeRef }}
+		{{- end }}
+		{{- if not .ClientBody }}
+			{{- if not (or .Headers .Cookies) }}
+				{{- if .MustValidate }}
+				err error
+				{{- end }}
+			{{- end }}
+		{{- end }}
+			)
+		{{- range .Trailers }}
+		{
+			{{ .VarName }}Raw := resp.Trailer.Get("{{ .CanonicalName }}")
+			{{- if .Required }}
+			if {{ .VarName }}Raw == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "trailer"))
+			}
+			{{- else if .DefaultValue }}
+			if {{ .VarName }}Raw == "" {
+				{{ .VarName }} = {{ if eq .Type.Name "string" }}{{ printf "%q" .DefaultValue }}{{ else }}{{ printf "%#v" .DefaultValue }}{{ end }}
+			}
+			{{- end }}
+			{{- if and (eq .Type.Name "string") (not .Pointer) (not .DefaultValue) }}
+			{{ .VarName }} = {{ .VarName }}Raw
+			{{- else }}
+			if {{ .VarName }}Raw != "" {
+			{{- if (or (eq .Type.Name "string") (eq .Type.Name "any")) }}
+				{{ .VarName }} = {{ if and (eq .Type.Name "string") .Pointer }}&{{ end }}{{ .VarName }}Raw
+			{{- else }}
+				{{- template "type_conversion" . }}
+			{{- end }}
+			}
+			{{- end }}
+		}
+		{{- if .Validate }}
+			{{ .Validate }}
+		{{- end }}
+		{{- end }}{{/* range .Trailers */}}
+	{{- end }}
+
 	{{- if .MustValidate }}
 			if err != nil {
 				return nil, goahttp.ErrValidationError("{{ $.ServiceName }}", "{{ $.Method.Name }}", err)
@@ -800,8 +1137,67 @@ type {{ .FuncName }} func(*multipart.Writer, {{ .Payload.Ref }}) error
 `
 
 // input: multipartData
+const multipartRequestDefaultEncoderT = `{{ printf "%s is the default encoder of the multipart requests sent to the %q service %q endpoint. It is used when the client endpoint is given a nil encoder function. It writes one part per payload attribute using the attribute name as form name." .Default .ServiceName .MethodName | comment }}
+func {{ .Default }}(mw *multipart.Writer, p {{ .Payload.Ref }}) error {
+{{- range .Parts }}
+	{{- if .File }}
+	if p.{{ .FieldName }} != nil {
+		fw, err := mw.CreateFormFile({{ printf "%q" .Name }}, {{ printf "%q" .Name }})
+		if err != nil {
+			return err
+		}
+		{{- if .Stream }}
+		if _, err := io.Copy(fw, p.{{ .FieldName }}); err != nil {
+		{{- else }}
+		if _, err := fw.Write(p.{{ .FieldName }}); err != nil {
+		{{- end }}
+			return err
+		}
+	}
+	{{- else if .JSON }}
+	if p.{{ .FieldName }} != nil {
+		b, err := goahttp.JSON().Marshal(p.{{ .FieldName }})
+		if err != nil {
+			return err
+		}
+		if err := mw.WriteField({{ printf "%q" .Name }}, string(b)); err != nil {
+			return err
+		}
+	}
+	{{- else if .Array }}
+	for _, v := range p.{{ .FieldName }} {
+		if err := mw.WriteField({{ printf "%q" .Name }}, {{ .Encode }}); err != nil {
+			return err
+		}
+	}
+	{{- else if .Pointer }}
+	if p.{{ .FieldName }} != nil {
+		v := *p.{{ .FieldName }}
+		if err := mw.WriteField({{ printf "%q" .Name }}, {{ .Encode }}); err != nil {
+			return err
+		}
+	}
+	{{- else }}
+	{
+		v := p.{{ .FieldName }}
+		if err := mw.WriteField({{ printf "%q" .Name }}, {{ .Encode }}); err != nil {
+			return err
+		}
+	}
+	{{- end }}
+{{- end }}
+	return nil
+}
+`
+
+// input: MultipartData
 const multipartRequestEncoderT = `{{ printf "%s returns an encoder to encode the multipart request for the %q service %q endpoint." .InitName .ServiceName .MethodName | comment }}
 func {{ .InitName }}(encoderFn {{ .FuncName }}) func(r *http.Request) goahttp.Encoder {
+	{{- if .Default }}
+	if encoderFn == nil {
+		encoderFn = {{ .Default }}
+	}
+	{{- end }}
 	return func(r *http.Request) goahttp.Encoder {
 		body := &bytes.Buffer{}
 		mw := multipart.NewWriter(body)
@@ -817,3 +1213,19 @@ func {{ .InitName }}(encoderFn {{ .FuncName }}) func(r *http.Request) goahttp.En
 	}
 }
 `
+
+// input: WebhookData
+const webhookSendT = `{{ printf "%s sends the %q webhook request to the URL u using doer. The request body is signed with secret, see goahttp.SignWebhook." .SendFunc .Name | comment }}
+{{- if .Description }}
+//
+{{ comment .Description }}
+{{- end }}
+func {{ .SendFunc }}(ctx context.Context, doer goahttp.Doer, u string, secret []byte, body {{ .Body.Ref }}) error {
+{{- if .Body.ValidateDef }}
+	if err := Validate{{ .Body.VarName }}(body); err != nil {
+		return err
+	}
+{{- end }}
+	return goahttp.SendWebhook(ctx, doer, u, secret, {{ printf "%q" .Name }}, body)
+}
+`
@@ -71,6 +71,7 @@ func TestClientTypes(t *testing.T) {
 		{"mixed-payload-attrs", testdata.MixedPayloadInBodyDSL, MixedPayloadInBodyClientTypesFile},
 		{"multiple-methods", testdata.MultipleMethodsDSL, MultipleMethodsClientTypesFile},
 		{"payload-extend-validate", testdata.PayloadExtendedValidateDSL, PayloadExtendedValidateClientTypesFile},
+		{"rate-limited-error-headers", testdata.RateLimitedErrorResponseDSL, RateLimitedErrorClientTypesFile},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -299,3 +300,35 @@ func NewMethodQueryStringExtendedValidatePayloadRequestBody(p *servicequerystrin
 	return body
 }
 `
+
+const RateLimitedErrorClientTypesFile = `// MethodRateLimitedErrorResponseRateLimitedResponseBody is the type of the
+// "ServiceRateLimitedErrorResponse" service "MethodRateLimitedErrorResponse"
+// endpoint HTTP response body for the "rate_limited" error.
+type MethodRateLimitedErrorResponseRateLimitedResponseBody struct {
+	Message *string ` + "`" + `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"` + "`" + `
+}
+
+// NewMethodRateLimitedErrorResponseRateLimited builds a
+// ServiceRateLimitedErrorResponse service MethodRateLimitedErrorResponse
+// endpoint rate_limited error.
+func NewMethodRateLimitedErrorResponseRateLimited(body *MethodRateLimitedErrorResponseRateLimitedResponseBody, retryIn int, limit int, remaining *int, reset *int64) *serviceratelimitederrorresponse.RateLimited {
+	v := &serviceratelimitederrorresponse.RateLimited{
+		Message: *body.Message,
+	}
+	v.RetryIn = retryIn
+	v.Limit = limit
+	v.Remaining = remaining
+	v.Reset = reset
+	return v
+}
+
+// ValidateMethodRateLimitedErrorResponseRateLimitedResponseBody runs the
+// validations defined on
+// MethodRateLimitedErrorResponse_rate_limited_Response_Body
+func ValidateMethodRateLimitedErrorResponseRateLimitedResponseBody(body *MethodRateLimitedErrorResponseRateLimitedResponseBody) (err error) {
+	if body.Message == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("message", "body"))
+	}
+	return
+}
+`
@@ -76,6 +76,17 @@ func buildSubcommandData(sd *ServiceData, e *EndpointData) *subcommandData {
 		sub.MultipartVarName = e.MultipartRequestEncoder.VarName
 		sub.MultipartFuncName = e.MultipartRequestEncoder.FuncName
 	}
+	if s := e.ClientStream; s != nil {
+		stream := &cli.StreamData{
+			TypeRef:     "*" + sd.Service.PkgName + "c." + s.VarName,
+			SendTypeRef: s.SendTypeRef,
+			MustClose:   s.MustClose,
+		}
+		if s.RecvTypeRef != "" {
+			stream.RecvName = s.RecvName
+		}
+		sub.Stream = stream
+	}
 	return sub
 }
 
@@ -86,6 +97,7 @@ func endpointParser(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr, da
 	path := filepath.Join(codegen.Gendir, "http", "cli", pkg, "cli.go")
 	title := fmt.Sprintf("%s HTTP client CLI support package", svr.Name)
 	specs := []*codegen.ImportSpec{
+		{Path: "context"},
 		{Path: "encoding/json"},
 		{Path: "flag"},
 		{Path: "fmt"},
@@ -95,6 +107,8 @@ func endpointParser(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr, da
 		{Path: "unicode/utf8"},
 		codegen.GoaImport(""),
 		codegen.GoaNamedImport("http", "goahttp"),
+		codegen.GoaNamedImport("cli", "goacli"),
+		codegen.GoaImport("security/credentials"),
 	}
 	for _, sv := range svr.Services {
 		svc := root.Service(sv)
@@ -106,6 +120,10 @@ func endpointParser(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr, da
 			Path: genpkg + "/http/" + codegen.SnakeCase(sd.Service.VarName) + "/client",
 			Name: sd.Service.PkgName + "c",
 		})
+		specs = append(specs, &codegen.ImportSpec{
+			Path: genpkg + "/" + codegen.SnakeCase(sd.Service.VarName),
+			Name: sd.Service.PkgName,
+		})
 	}
 
 	cliData := make([]*cli.CommandData, len(data))
@@ -121,20 +139,24 @@ func endpointParser(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr, da
 			Name:   "parse-endpoint",
 			Source: parseEndpointT,
 			Data: struct {
-				FlagsCode string
-				Commands  []*commandData
+				FlagsCode      string
+				Commands       []*commandData
+				HasCredentials bool
 			}{
 				cli.FlagsCode(cliData),
 				data,
+				cli.HasCredentials(cliData),
 			},
 			FuncMap: map[string]interface{}{
 				"streamingCmdExists": streamingCmdExists,
 			},
 		},
+		cli.Commands(cliData),
 	}
 	for _, cmd := range cliData {
 		sections = append(sections, cli.CommandUsage(cmd))
 	}
+	sections = append(sections, cli.Stream(cliData))
 	return &codegen.File{Path: path, SectionTemplates: sections}
 }
 
@@ -164,7 +186,7 @@ func payloadBuilders(genpkg string, svc *expr.HTTPServiceExpr, data *cli.Command
 		}
 	}
 
-	return &codegen.File{Path: path, SectionTemplates: sections}
+	return &codegen.File{Path: path, SectionTemplates: sections, Service: svc.Name()}
 }
 
 func buildFlags(svc *ServiceData, e *EndpointData) ([]*cli.FlagData, *cli.BuildFunctionData) {
@@ -205,6 +227,8 @@ func makeFlags(e *EndpointData, args []*InitArgData) ([]*cli.FlagData, *cli.Buil
 		}
 
 		f := cli.NewFlagData(e.ServiceName, e.Method.Name, arg.Name, arg.TypeName, arg.Description, arg.Required, arg.Example)
+		f.SetNamedExamples(arg.NamedExamples)
+		f.SetCredential(e.Method, arg.FieldName)
 		flags[i] = f
 		params[i] = f.FullName
 		if arg.FieldName == "" && arg.Name != "body" {
@@ -261,6 +285,11 @@ func streamingCmdExists(data []*commandData) bool {
 
 const parseEndpointT = `// ParseEndpoint returns the endpoint and payload as specified on the command
 // line.
+{{- if .HasCredentials }}
+//
+// The security flags that are not given explicitly are initialized with the
+// credentials of the given profile if any.
+{{- end }}
 func ParseEndpoint(
 	scheme, host string,
 	doer goahttp.Doer,
@@ -282,7 +311,16 @@ func ParseEndpoint(
 		{{- end }}
 	{{- end }}
 	{{- end }}
+	{{- if .HasCredentials }}
+	profiles ...*credentials.Profile,
+	{{- end }}
 ) (goa.Endpoint, interface{}, error) {
+	{{- if .HasCredentials }}
+	var creds *credentials.Profile
+	if len(profiles) > 0 {
+		creds = profiles[0]
+	}
+	{{- end }}
 	{{ .FlagsCode }}
     var (
 		data     interface{}
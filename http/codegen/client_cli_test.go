@@ -39,6 +39,14 @@ func TestClientCLIFiles(t *testing.T) {
 		{"map-query-object", testdata.PayloadMapQueryObjectDSL, testdata.MapQueryObjectBuildCode, 1, 1},
 		{"empty-body-build", testdata.PayloadBodyPrimitiveFieldEmptyDSL, testdata.EmptyBodyBuildCode, 1, 1},
 		{"with-params-and-headers-dsl", testdata.WithParamsAndHeadersBlockDSL, testdata.WithParamsAndHeadersBlockBuildCode, 1, 1},
+		{"named-examples-parse", testdata.NamedExamplesDSL, testdata.NamedExamplesParseCode, 0, 3},
+		{"credentials-parse", testdata.CredentialsDSL, testdata.CredentialsParseCode, 0, 3},
+		{"aliases-parse", testdata.AliasesDSL, testdata.AliasesParseCode, 0, 3},
+		{"aliases-commands", testdata.AliasesDSL, testdata.AliasesCommandsCode, 0, 4},
+		{"aliases-usage", testdata.AliasesDSL, testdata.AliasesUsageCode, 0, 5},
+		{"columns-commands", testdata.ColumnsDSL, testdata.ColumnsCommandsCode, 0, 4},
+		{"streaming-stream", testdata.StreamingCLIDSL, testdata.StreamingCLIStreamCode, 0, 6},
+		{"no-stream", testdata.MultiSimpleDSL, testdata.NoStreamCode, 0, 7},
 	}
 
 	for _, c := range cases {
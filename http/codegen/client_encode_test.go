@@ -59,6 +59,8 @@ func TestClientEncode(t *testing.T) {
 		{"query-array-float64-validate", testdata.PayloadQueryArrayFloat64ValidateDSL, testdata.PayloadQueryArrayFloat64ValidateEncodeCode},
 		{"query-array-string", testdata.PayloadQueryArrayStringDSL, testdata.PayloadQueryArrayStringEncodeCode},
 		{"query-array-string-validate", testdata.PayloadQueryArrayStringValidateDSL, testdata.PayloadQueryArrayStringValidateEncodeCode},
+		{"query-array-string-pipe-delimited", testdata.PayloadQueryArrayStringPipeDelimitedDSL, testdata.PayloadQueryArrayStringPipeDelimitedEncodeCode},
+		{"query-array-int-comma-delimited", testdata.PayloadQueryArrayIntCommaDelimitedDSL, testdata.PayloadQueryArrayIntCommaDelimitedEncodeCode},
 		{"query-array-bytes", testdata.PayloadQueryArrayBytesDSL, testdata.PayloadQueryArrayBytesEncodeCode},
 		{"query-array-bytes-validate", testdata.PayloadQueryArrayBytesValidateDSL, testdata.PayloadQueryArrayBytesValidateEncodeCode},
 		{"query-array-any", testdata.PayloadQueryArrayAnyDSL, testdata.PayloadQueryArrayAnyEncodeCode},
@@ -0,0 +1,46 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestClientPolicy(t *testing.T) {
+	const genpkg = "gen"
+	RunHTTPDSL(t, testdata.ClientPolicyDSL)
+	fs := ClientFiles(genpkg, expr.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected two", len(fs))
+	}
+	cases := []struct {
+		Name    string
+		Section string
+		Code    string
+	}{
+		{"client-struct", "client-struct", testdata.ClientPolicyStructCode},
+		{"client-options", "client-options", testdata.ClientPolicyOptionsCode},
+		{"client-init", "client-init", testdata.ClientPolicyInitCode},
+		{"client-endpoint-init", "client-endpoint-init", testdata.ClientPolicyEndpointInitCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var codes []string
+			for _, s := range fs[0].SectionTemplates {
+				if s.Name == c.Section {
+					codes = append(codes, codegen.SectionCode(t, s))
+				}
+			}
+			if len(codes) == 0 {
+				t.Fatalf("section %q not found", c.Section)
+			}
+			code := strings.Join(codes, "\n")
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
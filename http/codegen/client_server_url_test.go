@@ -0,0 +1,32 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestClientServerURL(t *testing.T) {
+	const genpkg = "gen"
+	RunHTTPDSL(t, testdata.ClientServerURLDSL)
+	fs := ClientFiles(genpkg, expr.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected two", len(fs))
+	}
+	var codes []string
+	for _, s := range fs[0].SectionTemplates {
+		if s.Name == "client-server-url" {
+			codes = append(codes, codegen.SectionCode(t, s))
+		}
+	}
+	if len(codes) != 1 {
+		t.Fatalf("got %d client-server-url sections, expected one", len(codes))
+	}
+	code := strings.Join(codes, "\n")
+	if code != testdata.ClientServerURLCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ClientServerURLCode))
+	}
+}
@@ -51,6 +51,8 @@ func clientType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 	path = filepath.Join(codegen.Gendir, "http", svcName, "client", "types.go")
 	header := codegen.Header(svc.Name()+" HTTP client types", "client",
 		[]*codegen.ImportSpec{
+			{Path: "fmt"},
+			{Path: "strconv"},
 			{Path: "unicode/utf8"},
 			{Path: genpkg + "/" + svcName, Name: data.Service.PkgName},
 			{Path: genpkg + "/" + svcName + "/" + "views", Name: data.Service.ViewsPkg},
@@ -200,7 +202,7 @@ func clientType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 			Data:   data,
 		})
 	}
-	return &codegen.File{Path: path, SectionTemplates: sections}
+	return &codegen.File{Path: path, SectionTemplates: sections, Service: svc.Name()}
 }
 
 // input: InitData
@@ -0,0 +1,35 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestServerCompress(t *testing.T) {
+	const genpkg = "gen"
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"api", testdata.CompressAPIDSL, testdata.CompressAPIHandlerCode},
+		{"endpoint", testdata.CompressEndpointDSL, testdata.CompressEndpointHandlerCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := ServerFiles(genpkg, expr.Root)
+			sections := fs[0].Section("server-handler")
+			if len(sections) == 0 {
+				t.Fatal("server-handler section not found")
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
@@ -0,0 +1,687 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// contractData contains the data needed to render the contract tests of
+	// an endpoint.
+	contractData struct {
+		// Name is the name of the method.
+		Name string
+		// TestName is the name of the test function.
+		TestName string
+		// ResponseDecoder is the name of the client response decoder
+		// function used to verify the responses of the valid requests.
+		ResponseDecoder string
+		// Auth lists the credentials sent with the requests.
+		Auth []*contractAuthData
+		// Cases lists the requests sent to the server.
+		Cases []*contractCaseData
+	}
+
+	// contractCaseData describes a request sent to the server by a contract
+	// test.
+	contractCaseData struct {
+		// Name is the name of the subtest.
+		Name string
+		// Method is the HTTP method.
+		Method string
+		// URL is the request URL including the query string.
+		URL string
+		// Headers lists the request headers.
+		Headers []*benchmarkHeaderData
+		// Body is the JSON encoded request body if any.
+		Body string
+		// Status is the expected response status code, zero if the
+		// response is verified with the client response decoder.
+		Status int
+	}
+
+	// contractAuthData describes the credentials of a security scheme.
+	contractAuthData struct {
+		// In is the location of the credentials: "basic", "header" or
+		// "query".
+		In string
+		// Name is the name of the header or query string parameter.
+		Name string
+		// Env is the name of the environment variable that overrides the
+		// example credentials.
+		Env string
+		// Username is the example basic auth user name.
+		Username string
+		// Password is the example basic auth password.
+		Password string
+	}
+
+	// contractRequest holds the parts of a request built from the design
+	// examples.
+	contractRequest struct {
+		verb    string
+		route   string
+		params  map[string]string
+		query   []*benchmarkHeaderData
+		headers []*benchmarkHeaderData
+		body    interface{}
+		hasBody bool
+	}
+)
+
+// ContractFiles returns the files that contain the contract tests of the HTTP
+// services if the API defines the "contract:test" meta. The tests send
+// requests built from the design examples to the server located at the URL
+// defined by the CONTRACT_URL environment variable and verify that the
+// responses conform to the design. Invalid requests built by removing
+// required values or violating the attribute validations must be rejected
+// with status code 400.
+func ContractFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	if _, ok := root.API.Meta["contract:test"]; !ok {
+		return nil
+	}
+	var fw []*codegen.File
+	for _, svc := range root.API.HTTP.Services {
+		contracts := buildContractsData(svc)
+		if len(contracts) == 0 {
+			continue
+		}
+		fw = append(fw, contractFile(svc, contracts))
+	}
+	return fw
+}
+
+// contractFile returns the file containing the contract tests of the service
+// endpoints.
+func contractFile(svc *expr.HTTPServiceExpr, contracts []*contractData) *codegen.File {
+	data := HTTPServices.Get(svc.Name())
+	svcName := codegen.SnakeCase(data.Service.VarName)
+	path := filepath.Join(codegen.Gendir, "http", svcName, "client", "contract_test.go")
+	title := fmt.Sprintf("%s HTTP contract tests", svc.Name())
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "client", []*codegen.ImportSpec{
+			{Path: "io"},
+			{Path: "io/ioutil"},
+			{Path: "net/http"},
+			{Path: "os"},
+			{Path: "strings"},
+			{Path: "testing"},
+			codegen.GoaNamedImport("http", "goahttp"),
+		}),
+	}
+	for _, c := range contracts {
+		sections = append(sections, &codegen.SectionTemplate{Name: "contract-test", Source: contractTestT, Data: c})
+	}
+	sections = append(sections, &codegen.SectionTemplate{Name: "contract-verify", Source: contractVerifyT})
+	return &codegen.File{Path: path, SectionTemplates: sections, Service: svc.Name()}
+}
+
+// buildContractsData returns the data needed to render the contract tests of
+// the service endpoints. Endpoints whose requests cannot be built from the
+// design examples are skipped.
+func buildContractsData(svc *expr.HTTPServiceExpr) []*contractData {
+	data := HTTPServices.Get(svc.Name())
+	var contracts []*contractData
+	for _, e := range svc.HTTPEndpoints {
+		if !e.Benchmarkable() || e.MapQueryParams != nil {
+			continue
+		}
+		ed := data.Endpoint(e.Name())
+		if ed == nil || len(ed.Routes) == 0 {
+			continue
+		}
+		req, ok := buildContractRequest(e, ed.Routes[0], expr.Root.API.Random())
+		if !ok {
+			continue
+		}
+		c := &contractData{
+			Name:            e.Name(),
+			TestName:        "TestContract" + ed.Method.VarName,
+			ResponseDecoder: ed.ResponseDecoder,
+			Auth:            contractAuth(e),
+		}
+		cases, ok := req.cases(e)
+		if !ok {
+			continue
+		}
+		c.Cases = cases
+		contracts = append(contracts, c)
+	}
+	return contracts
+}
+
+// buildContractRequest builds the valid request sent to the endpoint from the
+// design examples generated with r. It returns false if the examples cannot
+// be used to build the request.
+func buildContractRequest(e *expr.HTTPEndpointExpr, route *RouteData, r *expr.Random) (*contractRequest, bool) {
+	req := &contractRequest{verb: route.Verb, route: route.Path, params: make(map[string]string)}
+
+	params := e.PathParams()
+	for _, nat := range *expr.AsObject(params.Type) {
+		req.params[params.ElemName(nat.Name)] = url.PathEscape(strings.Join(benchmarkValues(nat.Attribute.Example(r)), ","))
+	}
+
+	query := e.QueryParams()
+	for _, nat := range *expr.AsObject(query.Type) {
+		if expr.IsMap(nat.Attribute.Type) || expr.IsObject(nat.Attribute.Type) {
+			if query.IsRequired(nat.Name) {
+				return nil, false
+			}
+			continue
+		}
+		vals := benchmarkValues(nat.Attribute.Example(r))
+		if d := expr.QueryDelimiter(nat.Attribute); d != "" {
+			vals = []string{strings.Join(vals, d)}
+		}
+		req.query = append(req.query, &benchmarkHeaderData{Name: query.ElemName(nat.Name), Values: vals})
+	}
+
+	hdrs, ok := benchmarkHeaders(e.Headers, r)
+	if !ok {
+		return nil, false
+	}
+	req.headers = hdrs
+
+	if e.Body != nil && e.Body.Type != expr.Empty {
+		req.body = e.Body.Example(r)
+		req.hasBody = true
+	}
+	return req, true
+}
+
+// cases returns the valid and invalid requests sent to the endpoint. The
+// invalid requests omit a required value, use a value of the wrong type or
+// violate a validation of a path or query string parameter, a header or a
+// top-level body attribute. Boundary values that satisfy the validations are
+// sent as valid requests.
+func (req *contractRequest) cases(e *expr.HTTPEndpointExpr) ([]*contractCaseData, bool) {
+	valid, ok := req.build("valid", 0)
+	if !ok {
+		return nil, false
+	}
+	cases := []*contractCaseData{valid}
+	add := func(name string, r *contractRequest, status int) {
+		if c, ok := r.build(name, status); ok {
+			cases = append(cases, c)
+		}
+	}
+
+	params := e.PathParams()
+	for _, nat := range *expr.AsObject(params.Type) {
+		if isContractTyped(nat.Attribute) {
+			r := req.clone()
+			r.params[params.ElemName(nat.Name)] = "invalid"
+			add("invalid type path "+params.ElemName(nat.Name), r, 400)
+		}
+	}
+
+	// The credentials are set by the contract tests, see contractAuth.
+	secured := make(map[string]bool)
+	for _, a := range contractAuth(e) {
+		secured[a.In+":"+a.Name] = true
+	}
+
+	query := e.QueryParams()
+	for i, q := range req.query {
+		name := query.KeyName(q.Name)
+		att := expr.AsObject(query.Type).Attribute(name)
+		if att == nil || secured["query:"+q.Name] {
+			continue
+		}
+		if query.IsRequiredNoDefault(name) {
+			r := req.clone()
+			r.query = append(r.query[:i:i], r.query[i+1:]...)
+			add("missing query "+q.Name, r, 400)
+		}
+		if isContractTyped(att) {
+			r := req.clone()
+			r.query[i] = &benchmarkHeaderData{Name: q.Name, Values: []string{"invalid"}}
+			add("invalid type query "+q.Name, r, 400)
+		}
+		for _, v := range contractViolations(att) {
+			r := req.clone()
+			r.query[i] = &benchmarkHeaderData{Name: q.Name, Values: benchmarkValues(v.value)}
+			add(v.name+" query "+q.Name, r, v.status)
+		}
+	}
+
+	for i, h := range req.headers {
+		name := e.Headers.KeyName(h.Name)
+		att := expr.AsObject(e.Headers.Type).Attribute(name)
+		if att == nil || secured["header:"+h.Name] {
+			continue
+		}
+		if e.Headers.IsRequiredNoDefault(name) {
+			r := req.clone()
+			r.headers = append(r.headers[:i:i], r.headers[i+1:]...)
+			add("missing header "+h.Name, r, 400)
+		}
+		if isContractTyped(att) {
+			r := req.clone()
+			r.headers[i] = &benchmarkHeaderData{Name: h.Name, Values: []string{"invalid"}}
+			add("invalid type header "+h.Name, r, 400)
+		}
+	}
+
+	if req.hasBody {
+		r := req.clone()
+		r.body = json.RawMessage("{")
+		add("malformed body", r, 400)
+		if obj, ok := req.body.(map[string]interface{}); ok && expr.IsObject(e.Body.Type) {
+			for _, nat := range *expr.AsObject(e.Body.Type) {
+				if _, ok := nat.Attribute.Meta["struct:tag:json"]; ok {
+					continue
+				}
+				if _, ok := obj[nat.Name]; !ok {
+					continue
+				}
+				set := func(v interface{}) *contractRequest {
+					r := req.clone()
+					body := make(map[string]interface{}, len(obj))
+					for k, e := range obj {
+						body[k] = e
+					}
+					if v == nil {
+						delete(body, nat.Name)
+					} else {
+						body[nat.Name] = v
+					}
+					r.body = body
+					return r
+				}
+				if e.Body.IsRequiredNoDefault(nat.Name) {
+					add("missing body "+nat.Name, set(nil), 400)
+				}
+				if p, ok := nat.Attribute.Type.(expr.Primitive); ok && p != expr.Any {
+					wrong := interface{}("invalid")
+					if p.Kind() == expr.StringKind || p.Kind() == expr.BytesKind {
+						wrong = 42
+					}
+					add("invalid type body "+nat.Name, set(wrong), 400)
+				}
+				for _, v := range contractViolations(nat.Attribute) {
+					add(v.name+" body "+nat.Name, set(v.value), v.status)
+				}
+			}
+		}
+	}
+	return cases, true
+}
+
+// contractViolation describes a value that violates or is at the boundary of
+// an attribute validation.
+type contractViolation struct {
+	name   string
+	value  interface{}
+	status int
+}
+
+// contractViolations returns the values that violate the validations of the
+// given primitive attribute together with the valid values at the boundaries
+// of the validated ranges.
+func contractViolations(att *expr.AttributeExpr) []*contractViolation {
+	v := att.Validation
+	p, ok := att.Type.(expr.Primitive)
+	if v == nil || !ok {
+		return nil
+	}
+	var vs []*contractViolation
+	switch p.Kind() {
+	case expr.StringKind:
+		if len(v.Values) > 0 {
+			val := "invalid"
+			for contains(v.Values, val) {
+				val += "_"
+			}
+			vs = append(vs, &contractViolation{"invalid enum", val, 400})
+		}
+		if len(v.Values) > 0 || v.Pattern != "" || v.Format != "" {
+			// Strings built to test the length validations would not
+			// satisfy the other validations.
+			break
+		}
+		// The ASCII strings used below are valid if their length does
+		// not exceed the maximum number of bytes.
+		fits := func(n int) bool { return v.MaxBytes == nil || n <= *v.MaxBytes }
+		if v.MinLength != nil && *v.MinLength > 0 {
+			vs = append(vs, &contractViolation{"below min length", strings.Repeat("a", *v.MinLength-1), 400})
+			if fits(*v.MinLength) {
+				vs = append(vs, &contractViolation{"min length", strings.Repeat("a", *v.MinLength), 0})
+			}
+		}
+		if v.MaxLength != nil && *v.MaxLength <= 1024 {
+			vs = append(vs, &contractViolation{"above max length", strings.Repeat("a", *v.MaxLength+1), 400})
+			if fits(*v.MaxLength) {
+				vs = append(vs, &contractViolation{"max length", strings.Repeat("a", *v.MaxLength), 0})
+			}
+		}
+		if v.MaxBytes != nil && *v.MaxBytes <= 1024 {
+			vs = append(vs, &contractViolation{"above max bytes", strings.Repeat("a", *v.MaxBytes+1), 400})
+		}
+	case expr.IntKind, expr.Int32Kind, expr.Int64Kind, expr.UIntKind, expr.UInt32Kind, expr.UInt64Kind, expr.Float32Kind, expr.Float64Kind:
+		if len(v.Values) > 0 {
+			break
+		}
+		isInt := p.Kind() != expr.Float32Kind && p.Kind() != expr.Float64Kind
+		num := func(f float64) interface{} {
+			if isInt {
+				return int64(f)
+			}
+			return f
+		}
+		isUnsigned := p.Kind() == expr.UIntKind || p.Kind() == expr.UInt32Kind || p.Kind() == expr.UInt64Kind
+		// The bounds are only valid values if they are multiples of
+		// the multiple of validation.
+		valid := func(f float64) bool {
+			return v.MultipleOf == nil || goa.IsMultipleOf(f, *v.MultipleOf)
+		}
+		if v.Minimum != nil {
+			// Negative values cannot be decoded into unsigned integers
+			// but the decoding error is also reported with status 400.
+			if !isUnsigned || *v.Minimum >= 1 {
+				vs = append(vs, &contractViolation{"below minimum", num(*v.Minimum - 1), 400})
+			}
+			if valid(*v.Minimum) && v.ExclusiveMinimum == nil {
+				vs = append(vs, &contractViolation{"minimum", num(*v.Minimum), 0})
+			}
+		}
+		if v.Maximum != nil {
+			vs = append(vs, &contractViolation{"above maximum", num(*v.Maximum + 1), 400})
+			if valid(*v.Maximum) && v.ExclusiveMaximum == nil {
+				vs = append(vs, &contractViolation{"maximum", num(*v.Maximum), 0})
+			}
+		}
+		if v.ExclusiveMinimum != nil && (!isUnsigned || *v.ExclusiveMinimum >= 0) {
+			vs = append(vs, &contractViolation{"exclusive minimum", num(*v.ExclusiveMinimum), 400})
+		}
+		if v.ExclusiveMaximum != nil {
+			vs = append(vs, &contractViolation{"exclusive maximum", num(*v.ExclusiveMaximum), 400})
+		}
+	}
+	return vs
+}
+
+// clone returns a copy of the request that may be modified without altering
+// r.
+func (req *contractRequest) clone() *contractRequest {
+	r := *req
+	r.params = make(map[string]string, len(req.params))
+	for k, v := range req.params {
+		r.params[k] = v
+	}
+	r.query = append([]*benchmarkHeaderData(nil), req.query...)
+	r.headers = append([]*benchmarkHeaderData(nil), req.headers...)
+	return &r
+}
+
+// build returns the contract test case sending the request. It returns false
+// if the request cannot be built.
+func (req *contractRequest) build(name string, status int) (*contractCaseData, bool) {
+	p := expr.HTTPWildcardRegex.ReplaceAllStringFunc(req.route, func(wc string) string {
+		n := expr.HTTPWildcardRegex.FindStringSubmatch(wc)[1]
+		if v, ok := req.params[n]; ok {
+			return "/" + v
+		}
+		return wc
+	})
+	if strings.Contains(p, "{") {
+		return nil, false
+	}
+	var qs []string
+	for _, q := range req.query {
+		for _, v := range q.Values {
+			qs = append(qs, url.QueryEscape(q.Name)+"="+url.QueryEscape(v))
+		}
+	}
+	if len(qs) > 0 {
+		p += "?" + strings.Join(qs, "&")
+	}
+	c := &contractCaseData{Name: name, Method: req.verb, URL: p, Headers: req.headers, Status: status}
+	if req.hasBody {
+		body, err := json.Marshal(req.body)
+		if err != nil {
+			if raw, ok := req.body.(json.RawMessage); ok {
+				body = raw
+			} else {
+				return nil, false
+			}
+		}
+		c.Body = string(body)
+	}
+	return c, true
+}
+
+// contractAuth returns the credentials sent with the requests made to the
+// given endpoint. The credentials default to the design examples and may be
+// overridden with environment variables named after the security schemes,
+// e.g. CONTRACT_JWT_TOKEN.
+func contractAuth(e *expr.HTTPEndpointExpr) []*contractAuthData {
+	if len(e.Requirements) == 0 {
+		return nil
+	}
+	var auths []*contractAuthData
+	for _, s := range e.Requirements[0].Schemes {
+		env := "CONTRACT_" + strings.ToUpper(codegen.SnakeCase(s.SchemeName))
+		switch {
+		case s.Kind == expr.BasicAuthKind:
+			a := &contractAuthData{In: "basic", Env: env}
+			r := expr.Root.API.Random()
+			obj := expr.AsObject(e.MethodExpr.Payload.Type)
+			if user := expr.TaggedAttribute(e.MethodExpr.Payload, "security:username"); user != "" {
+				a.Username = strings.Join(benchmarkValues(obj.Attribute(user).Example(r)), "")
+			}
+			if pass := expr.TaggedAttribute(e.MethodExpr.Payload, "security:password"); pass != "" {
+				a.Password = strings.Join(benchmarkValues(obj.Attribute(pass).Example(r)), "")
+			}
+			auths = append(auths, a)
+		case s.In == "header" || s.In == "query":
+			auths = append(auths, &contractAuthData{In: s.In, Name: s.Name, Env: env + "_" + strings.ToUpper(tokenName(s))})
+		}
+	}
+	return auths
+}
+
+// tokenName returns the name of the credential of the given scheme.
+func tokenName(s *expr.SchemeExpr) string {
+	switch s.Kind {
+	case expr.JWTKind, expr.OAuth2Kind:
+		return "token"
+	default:
+		return "key"
+	}
+}
+
+// isContractTyped returns true if the values of the given attribute cannot be
+// any string, i.e. if "invalid" is not a valid value.
+func isContractTyped(att *expr.AttributeExpr) bool {
+	p, ok := att.Type.(expr.Primitive)
+	if !ok {
+		return false
+	}
+	switch p.Kind() {
+	case expr.BooleanKind, expr.IntKind, expr.Int32Kind, expr.Int64Kind, expr.UIntKind, expr.UInt32Kind, expr.UInt64Kind, expr.Float32Kind, expr.Float64Kind:
+		return true
+	}
+	return false
+}
+
+// contains returns true if vals contains v.
+func contains(vals []interface{}, v interface{}) bool {
+	for _, val := range vals {
+		if val == v {
+			return true
+		}
+	}
+	return false
+}
+
+// input: contractData
+const contractTestT = `{{ printf "%s verifies that the server implements the contract of the %q method." .TestName .Name | comment }}
+func {{ .TestName }}(t *testing.T) {
+	{{- if .Auth }}
+	auth := []*contractAuth{
+	{{- range .Auth }}
+		{In: {{ printf "%q" .In }}{{ if .Name }}, Name: {{ printf "%q" .Name }}{{ end }}, Env: {{ printf "%q" .Env }}{{ if .Username }}, Username: {{ printf "%q" .Username }}{{ end }}{{ if .Password }}, Password: {{ printf "%q" .Password }}{{ end }}},
+	{{- end }}
+	}
+	{{- end }}
+	verifyContract(t, []*contractCase{
+	{{- range .Cases }}
+		{
+			Name:   {{ printf "%q" .Name }},
+			Method: {{ printf "%q" .Method }},
+			URL:    {{ printf "%q" .URL }},
+		{{- if .Headers }}
+			Header: http.Header{
+			{{- range .Headers }}
+				{{ printf "%q" .Name }}: { {{- range $i, $v := .Values }}{{ if $i }}, {{ end }}{{ printf "%q" $v }}{{ end -}} },
+			{{- end }}
+			},
+		{{- end }}
+		{{- if .Body }}
+			Body:   {{ printf "%q" .Body }},
+		{{- end }}
+		{{- if $.Auth }}
+			Auth:   auth,
+		{{- end }}
+		{{- if .Status }}
+			Status: {{ .Status }},
+		{{- else }}
+			Decode: {{ $.ResponseDecoder }}(goahttp.ResponseDecoder, false),
+		{{- end }}
+		},
+	{{- end }}
+	})
+}
+`
+
+// input: nil
+const contractVerifyT = `type (
+	// contractCase describes a request sent to the server and the expected
+	// response.
+	contractCase struct {
+		// Name is the name of the subtest.
+		Name string
+		// Method is the HTTP method.
+		Method string
+		// URL is the request path including the query string.
+		URL string
+		// Header lists the request headers.
+		Header http.Header
+		// Body is the JSON encoded request body if any.
+		Body string
+		// Auth lists the credentials sent with the request.
+		Auth []*contractAuth
+		// Status is the expected response status code if Decode is nil.
+		Status int
+		// Decode decodes and validates the response, it returns a
+		// *goahttp.ClientError if the response does not conform to the
+		// design.
+		Decode func(*http.Response) (interface{}, error)
+	}
+
+	// contractAuth describes the credentials of a security scheme.
+	contractAuth struct {
+		// In is the location of the credentials: "basic", "header" or
+		// "query".
+		In string
+		// Name is the name of the header or query string parameter.
+		Name string
+		// Env is the name of the environment variable that overrides
+		// the example credentials. The basic auth credentials are
+		// overridden by the variables suffixed with _USERNAME and
+		// _PASSWORD.
+		Env string
+		// Username is the example basic auth user name.
+		Username string
+		// Password is the example basic auth password.
+		Password string
+	}
+)
+
+// verifyContract sends the requests to the server located at the URL defined
+// by the CONTRACT_URL environment variable and verifies the responses. The
+// test is skipped if CONTRACT_URL is not set.
+func verifyContract(t *testing.T, cases []*contractCase) {
+	host := os.Getenv("CONTRACT_URL")
+	if host == "" {
+		t.Skip("set CONTRACT_URL to the URL of the server to verify")
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			var body io.Reader
+			if c.Body != "" {
+				body = strings.NewReader(c.Body)
+			}
+			req, err := http.NewRequest(c.Method, strings.TrimSuffix(host, "/")+c.URL, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for k, vs := range c.Header {
+				for _, v := range vs {
+					req.Header.Add(k, v)
+				}
+			}
+			if c.Body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			for _, a := range c.Auth {
+				a.apply(req)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if c.Decode == nil {
+				if resp.StatusCode != c.Status {
+					b, _ := ioutil.ReadAll(resp.Body)
+					t.Errorf("got status %d, expected %d, body: %s", resp.StatusCode, c.Status, b)
+				}
+				return
+			}
+			if _, err := c.Decode(resp); err != nil {
+				// Errors other than client errors are the errors
+				// defined in the design.
+				if _, ok := err.(*goahttp.ClientError); ok {
+					t.Errorf("response does not conform to the design: %s", err)
+				}
+			}
+		})
+	}
+}
+
+// apply sets the credentials of the request, the example credentials are
+// overridden by the values of the environment variables if set.
+func (a *contractAuth) apply(req *http.Request) {
+	switch a.In {
+	case "basic":
+		user, pass := a.Username, a.Password
+		if v := os.Getenv(a.Env + "_USERNAME"); v != "" {
+			user = v
+		}
+		if v := os.Getenv(a.Env + "_PASSWORD"); v != "" {
+			pass = v
+		}
+		req.SetBasicAuth(user, pass)
+	case "header":
+		if v := os.Getenv(a.Env); v != "" {
+			req.Header.Set(a.Name, v)
+		}
+	case "query":
+		if v := os.Getenv(a.Env); v != "" {
+			q := req.URL.Query()
+			q.Set(a.Name, v)
+			req.URL.RawQuery = q.Encode()
+		}
+	}
+}
+`
@@ -0,0 +1,119 @@
+package codegen
+
+import (
+	"strconv"
+
+	"goa.design/goa/v3/expr"
+)
+
+// CookieOptionsData describes the attributes of the cookies set by a response.
+type CookieOptionsData struct {
+	// MaxAge is the cookie Max-Age attribute value, zero if not set.
+	MaxAge int
+	// Domain is the cookie Domain attribute value.
+	Domain string
+	// Path is the cookie Path attribute value.
+	Path string
+	// Secure is true if the cookie Secure attribute is set.
+	Secure bool
+	// HTTPOnly is true if the cookie HttpOnly attribute is set.
+	HTTPOnly bool
+	// SameSite is the cookie SameSite attribute value.
+	SameSite string
+	// Partitioned is true if the cookie Partitioned attribute is set.
+	Partitioned bool
+	// Signed is true if the cookie values are encoded with the server
+	// cookie codec.
+	Signed bool
+}
+
+// buildCookieOptions returns the attributes of the cookies described by the
+// given mapped attribute, nil if there are no cookies.
+func buildCookieOptions(cookies *expr.MappedAttributeExpr) *CookieOptionsData {
+	if cookies == nil || cookies.IsEmpty() {
+		return nil
+	}
+	meta := cookies.Meta
+	opts := &CookieOptionsData{Signed: expr.IsSignedCookies(cookies)}
+	if v, ok := meta.Last(expr.CookieMaxAgeKey); ok {
+		opts.MaxAge, _ = strconv.Atoi(v)
+	}
+	opts.Domain, _ = meta.Last(expr.CookieDomainKey)
+	opts.Path, _ = meta.Last(expr.CookiePathKey)
+	_, opts.Secure = meta[expr.CookieSecureKey]
+	_, opts.HTTPOnly = meta[expr.CookieHTTPOnlyKey]
+	opts.SameSite, _ = meta.Last(expr.CookieSameSiteKey)
+	_, opts.Partitioned = meta[expr.CookiePartitionedKey]
+	return opts
+}
+
+// needCookieJar returns true if at least one endpoint of the given services
+// sets cookies in its responses.
+func needCookieJar(data []*ServiceData) bool {
+	for _, svc := range data {
+		for _, e := range svc.Endpoints {
+			if e.Result == nil {
+				continue
+			}
+			for _, r := range e.Result.Responses {
+				if len(r.Cookies) > 0 {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// input: ResponseData
+const cookiesT = `{{- define "cookies" }}
+	{{- if .Cookies }}
+	cookieOpts := &goahttp.CookieOptions{
+		{{- with .CookieOptions }}
+			{{- if .MaxAge }}
+		MaxAge: {{ .MaxAge }},
+			{{- end }}
+			{{- if .Domain }}
+		Domain: {{ printf "%q" .Domain }},
+			{{- end }}
+			{{- if .Path }}
+		Path: {{ printf "%q" .Path }},
+			{{- end }}
+			{{- if .Secure }}
+		Secure: true,
+			{{- end }}
+			{{- if .HTTPOnly }}
+		HTTPOnly: true,
+			{{- end }}
+			{{- if .SameSite }}
+		SameSite: {{ printf "%q" .SameSite }},
+			{{- end }}
+			{{- if .Partitioned }}
+		Partitioned: true,
+			{{- end }}
+			{{- if .Signed }}
+		Signed: true,
+			{{- end }}
+		{{- end }}
+	}
+	{{- end }}
+	{{- range .Cookies }}
+		{{- $checkNil := and .FieldPointer (not $.TagName) }}
+		{{- if $checkNil }}
+	if res.{{ if $.ViewedResult }}Projected.{{ end }}{{ .FieldName }} != nil {
+		{{- else }}
+	{
+		{{- end }}
+		{{- if eq .Type.Name "string" }}
+		val := {{ if or .FieldPointer $.ViewedResult }}*{{ end }}res{{ if $.ViewedResult }}.Projected{{ end }}.{{ .FieldName }}
+		{{- else }}
+		val := res{{ if $.ViewedResult }}.Projected{{ end }}.{{ .FieldName }}
+		{{ template "header_conversion" (headerConversionData .Type "cookieVal" (not .FieldPointer) "val") }}
+		{{- end }}
+		if err := goahttp.SetCookie(ctx, w, {{ printf "%q" .Name }}, {{ if eq .Type.Name "string" }}val{{ else }}cookieVal{{ end }}, cookieOpts); err != nil {
+			return err
+		}
+	}
+	{{- end }}
+{{- end }}
+`
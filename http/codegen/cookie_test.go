@@ -0,0 +1,53 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestCookies(t *testing.T) {
+	const genpkg = "gen"
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code map[string]string
+	}{
+		{"cookies", testdata.CookiesDSL, map[string]string{
+			"server/request-decoder":  testdata.CookiesRequestDecoderCode,
+			"server/response-encoder": testdata.CookiesResponseEncoderCode,
+			"client/request-encoder":  testdata.CookiesRequestEncoderCode,
+			"client/response-decoder": testdata.CookiesResponseDecoderCode,
+		}},
+		{"signed-cookies", testdata.SignedCookiesDSL, map[string]string{
+			"server/request-decoder":  testdata.SignedCookiesRequestDecoderCode,
+			"server/response-encoder": testdata.SignedCookiesResponseEncoderCode,
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			files := map[string]*codegen.File{
+				"server": ServerFiles(genpkg, expr.Root)[1],
+				"client": ClientFiles(genpkg, expr.Root)[1],
+			}
+			for _, k := range []string{"server/request-decoder", "server/response-encoder", "client/request-encoder", "client/response-decoder"} {
+				expected, ok := c.Code[k]
+				if !ok {
+					continue
+				}
+				f, section := files[k[:6]], k[7:]
+				sections := f.Section(section)
+				if len(sections) == 0 {
+					t.Fatalf("section %q not found", section)
+				}
+				code := codegen.SectionCode(t, sections[0])
+				if code != expected {
+					t.Errorf("invalid %s code, got:\n%s\ngot vs. expected:\n%s", k, code, codegen.Diff(t, code, expected))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestServerCORS(t *testing.T) {
+	const genpkg = "gen"
+	cases := []struct {
+		Name      string
+		DSL       func()
+		MountCode string
+		CORSCode  string
+	}{
+		{"api-origin", testdata.CORSAPIOriginDSL, testdata.CORSAPIOriginMountCode, testdata.CORSAPIOriginCode},
+		{"endpoint-origin", testdata.CORSEndpointOriginDSL, testdata.CORSEndpointOriginMountCode, testdata.CORSEndpointOriginCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := ServerFiles(genpkg, expr.Root)
+			if len(fs) != 2 {
+				t.Fatalf("got %d files, expected two", len(fs))
+			}
+			var mount, cors *codegen.SectionTemplate
+			for _, s := range fs[0].SectionTemplates {
+				switch s.Name {
+				case "server-mount":
+					mount = s
+				case "server-cors":
+					cors = s
+				}
+			}
+			if cors == nil {
+				t.Fatal("server-cors section not found")
+			}
+			code := codegen.SectionCode(t, mount)
+			if code != c.MountCode {
+				t.Errorf("invalid mount code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.MountCode))
+			}
+			code = codegen.SectionCode(t, cors)
+			if code != c.CORSCode {
+				t.Errorf("invalid CORS code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.CORSCode))
+			}
+		})
+	}
+}
+
+func TestServerCORSHandler(t *testing.T) {
+	const genpkg = "gen"
+	RunHTTPDSL(t, testdata.CORSEndpointOriginDSL)
+	fs := ServerFiles(genpkg, expr.Root)
+	for _, s := range fs[0].SectionTemplates {
+		if s.Name != "server-handler" {
+			continue
+		}
+		code := codegen.SectionCode(t, s)
+		if code != testdata.CORSEndpointOriginHandlerCode {
+			t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.CORSEndpointOriginHandlerCode))
+		}
+		return
+	}
+	t.Fatal("server-handler section not found")
+}
@@ -0,0 +1,22 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestCSRF(t *testing.T) {
+	RunHTTPDSL(t, testdata.CSRFDSL)
+	fs := ServerFiles("gen", expr.Root)
+	sections := fs[0].Section("server-protect-csrf")
+	if len(sections) != 1 {
+		t.Fatalf("got %d server-protect-csrf sections, expected one", len(sections))
+	}
+	code := codegen.SectionCode(t, sections[0])
+	if code != testdata.CSRFServerProtectCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.CSRFServerProtectCode))
+	}
+}
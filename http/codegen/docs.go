@@ -0,0 +1,185 @@
+package codegen
+
+import (
+	"html"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// docsFile returns the file that implements the HTTP handlers serving the
+// given OpenAPI 3 specification and the page rendering it with the selected
+// documentation UI.
+func docsFile(root *expr.RootExpr, spec interface{}) *codegen.File {
+	path := filepath.Join(codegen.Gendir, "http", "docs", "docs.go")
+	title := root.API.Title
+	if title == "" {
+		title = root.API.Name
+	}
+	sections := []*codegen.SectionTemplate{
+		codegen.Header("API documentation HTTP handlers", "docs", []*codegen.ImportSpec{
+			{Path: "html"},
+			{Path: "net/http"},
+			{Path: "strings"},
+			codegen.GoaNamedImport("http", "goahttp"),
+		}),
+		{
+			Name:   "docs-handlers",
+			Source: docsT,
+			Data: map[string]interface{}{
+				// The backquotes are escaped so that the title can
+				// be rendered in the raw string literals.
+				"Title": strings.Replace(html.EscapeString(title+" API documentation"), "`", "&#96;", -1),
+				"Spec":  toJSON(spec),
+			},
+		},
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// input: map[string]interface{}{"Title": string, "Spec": string}
+const docsT = `// UI is the user interface used to render the API documentation.
+type UI string
+
+const (
+	// SwaggerUI renders the documentation with Swagger UI.
+	SwaggerUI UI = "swagger-ui"
+	// Redoc renders the documentation with Redoc.
+	Redoc UI = "redoc"
+	// Elements renders the documentation with Stoplight Elements.
+	Elements UI = "elements"
+)
+
+// OpenAPI is the OpenAPI specification of the API in JSON.
+const OpenAPI = {{ printf "%q" .Spec }}
+
+// CDN lists the base URLs of the pinned versions of the assets loaded by the
+// documentation pages indexed by UI. The pages load the assets from these URLs
+// unless the documentation is mounted with MountEmbedded.
+var CDN = map[UI]string{
+	SwaggerUI: "https://unpkg.com/swagger-ui-dist@5.17.14",
+	Redoc:     "https://unpkg.com/redoc@2.1.5/bundles",
+	Elements:  "https://unpkg.com/@stoplight/elements@8.0.0",
+}
+
+// pages lists the HTML pages that render the documentation indexed by UI. The
+// "{{ "{{" }}spec{{ "}}" }}" placeholder is replaced with the path to the specification
+// and the "{{ "{{" }}assets{{ "}}" }}" placeholder with the base URL of the UI assets.
+var pages = map[UI]string{
+	SwaggerUI: ` + "`" + `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{ .Title }}</title>
+<link rel="stylesheet" href="{{ "{{" }}assets{{ "}}" }}/swagger-ui.css">
+</head>
+<body>
+<div id="swagger-ui" data-url="{{ "{{" }}spec{{ "}}" }}"></div>
+<script src="{{ "{{" }}assets{{ "}}" }}/swagger-ui-bundle.js"></script>
+<script>
+window.onload = function() {
+  var el = document.getElementById("swagger-ui");
+  SwaggerUIBundle({url: el.getAttribute("data-url"), dom_id: "#swagger-ui"});
+};
+</script>
+</body>
+</html>
+` + "`" + `,
+	Redoc: ` + "`" + `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{ .Title }}</title>
+</head>
+<body>
+<redoc spec-url="{{ "{{" }}spec{{ "}}" }}"></redoc>
+<script src="{{ "{{" }}assets{{ "}}" }}/redoc.standalone.js"></script>
+</body>
+</html>
+` + "`" + `,
+	Elements: ` + "`" + `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{ .Title }}</title>
+<link rel="stylesheet" href="{{ "{{" }}assets{{ "}}" }}/styles.min.css">
+<script src="{{ "{{" }}assets{{ "}}" }}/web-components.min.js"></script>
+</head>
+<body>
+<elements-api apiDescriptionUrl="{{ "{{" }}spec{{ "}}" }}" router="hash" layout="sidebar"></elements-api>
+</body>
+</html>
+` + "`" + `,
+}
+
+// Mount configures the mux to serve the API documentation rendered with ui
+// under the given path, e.g. "/docs", and the OpenAPI specification at the
+// path followed by "/openapi.json". The documentation uses Swagger UI if ui is
+// not one of SwaggerUI, Redoc or Elements. The pages load the UI assets from
+// the pinned versions listed in CDN.
+func Mount(mux goahttp.Muxer, path string, ui UI) {
+	mount(mux, path, ui, nil)
+}
+
+// MountEmbedded is similar to Mount but serves the UI assets from the given
+// file system at the path followed by "/assets" so that the documentation
+// does not depend on a CDN. The file system is typically created with http.FS
+// from an embed.FS holding copies of the assets downloaded from the URLs
+// listed in CDN:
+//
+//   - Swagger UI: swagger-ui.css and swagger-ui-bundle.js
+//   - Redoc: redoc.standalone.js
+//   - Elements: styles.min.css and web-components.min.js
+//
+// The files must be at the root of the file system.
+func MountEmbedded(mux goahttp.Muxer, path string, ui UI, assets http.FileSystem) {
+	mount(mux, path, ui, assets)
+}
+
+// PageHandler returns a HTTP handler that writes the HTML page that renders
+// the documentation with ui. spec is the URL of the OpenAPI specification
+// loaded by the page and assets the base URL of the UI assets, the page uses
+// the URL listed in CDN if assets is empty.
+func PageHandler(ui UI, spec, assets string) http.HandlerFunc {
+	page, ok := pages[ui]
+	if !ok {
+		ui, page = SwaggerUI, pages[SwaggerUI]
+	}
+	if assets == "" {
+		assets = CDN[ui]
+	}
+	page = strings.Replace(page, "{{ "{{" }}spec{{ "}}" }}", html.EscapeString(spec), -1)
+	page = strings.Replace(page, "{{ "{{" }}assets{{ "}}" }}", html.EscapeString(assets), -1)
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(page))
+	}
+}
+
+// SpecHandler returns a HTTP handler that writes the OpenAPI specification.
+func SpecHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(OpenAPI))
+	}
+}
+
+// mount configures the mux to serve the documentation, the specification and
+// the assets if not nil.
+func mount(mux goahttp.Muxer, path string, ui UI, assets http.FileSystem) {
+	path = strings.TrimSuffix(path, "/")
+	spec := path + "/openapi.json"
+	var base string
+	if assets != nil {
+		base = path + "/assets"
+		mux.Handle("GET", base+"/{*file}", http.StripPrefix(base, http.FileServer(assets)).ServeHTTP)
+	}
+	if path == "" {
+		path = "/"
+	}
+	mux.Handle("GET", path, PageHandler(ui, spec, base))
+	mux.Handle("GET", spec, SpecHandler())
+}
+`
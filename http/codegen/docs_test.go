@@ -0,0 +1,69 @@
+package codegen
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/http/codegen/openapi"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestDocs(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Spec string
+	}{
+		{"default", testdata.DocsDSL, `\"openapi\":\"3.1.0\"`},
+		{"v3", testdata.DocsV3DSL, `\"openapi\":\"3.1.0\"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			openapi.Definitions = make(map[string]*openapi.Schema)
+			root := RunHTTPDSL(t, c.DSL)
+			files, err := OpenAPIFiles(root)
+			if err != nil {
+				t.Fatalf("OpenAPI failed with %s", err)
+			}
+			f := files[len(files)-1]
+			if f.Path != filepath.Join("gen", "http", "docs", "docs.go") {
+				t.Fatalf("got path %q, expected gen/http/docs/docs.go", f.Path)
+			}
+			sections := f.Section("docs-handlers")
+			if len(sections) != 1 {
+				t.Fatalf("got %d sections, expected 1", len(sections))
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if !strings.Contains(code, "const OpenAPI = \"{"+c.Spec) {
+				t.Errorf("got code:\n%s\nexpected the OpenAPI constant to contain %s", code, c.Spec)
+			}
+			for _, url := range []string{"swagger-ui-dist@5.17.14", "redoc@2.1.5", "elements@8.0.0"} {
+				if !strings.Contains(code, url) {
+					t.Errorf("got code:\n%s\nexpected pinned asset URL %s", code, url)
+				}
+			}
+			if strings.Contains(code, "latest") {
+				t.Errorf("got code:\n%s\nexpected no floating version", code)
+			}
+			if c.Name == "default" && !strings.Contains(code, "<title>Test &lt;&#96;API&#96;&gt; API documentation</title>") {
+				t.Errorf("got code:\n%s\nexpected escaped title", code)
+			}
+		})
+	}
+}
+
+func TestDocsNotGenerated(t *testing.T) {
+	openapi.Definitions = make(map[string]*openapi.Schema)
+	root := RunHTTPDSL(t, testdata.OpenAPIV3DSL)
+	files, err := OpenAPIFiles(root)
+	if err != nil {
+		t.Fatalf("OpenAPI failed with %s", err)
+	}
+	for _, f := range files {
+		if strings.HasSuffix(f.Path, ".go") {
+			t.Errorf("unexpected file %q", f.Path)
+		}
+	}
+}
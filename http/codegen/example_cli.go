@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/cli"
 	"goa.design/goa/v3/codegen/example"
 	"goa.design/goa/v3/expr"
 )
@@ -37,12 +38,7 @@ func exampleCLI(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *codeg
 		scope = codegen.NewNameScope()
 	)
 	{
-		// genpkg is created by path.Join so the separator is / regardless of operating system
-		idx := strings.LastIndex(genpkg, string("/"))
-		rootPath = "."
-		if idx > 0 {
-			rootPath = genpkg[:idx]
-		}
+		rootPath = codegen.Layout.RootPackage(genpkg)
 		apiPkg = scope.Unique(strings.ToLower(codegen.Goify(root.API.Name, false)), "api")
 	}
 	specs := []*codegen.ImportSpec{
@@ -51,6 +47,7 @@ func exampleCLI(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *codeg
 		{Path: "flag"},
 		{Path: "fmt"},
 		{Path: "net/http"},
+		{Path: "net/http/cookiejar"},
 		{Path: "net/url"},
 		{Path: "os"},
 		{Path: "strings"},
@@ -58,6 +55,8 @@ func exampleCLI(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *codeg
 		{Path: "github.com/gorilla/websocket"},
 		codegen.GoaImport(""),
 		codegen.GoaNamedImport("http", "goahttp"),
+		codegen.GoaNamedImport("cli", "goacli"),
+		codegen.GoaImport("security/credentials"),
 		{Path: genpkg + "/http/cli/" + svrdata.Dir, Name: "cli"},
 		{Path: rootPath, Name: apiPkg},
 	}
@@ -70,7 +69,13 @@ func exampleCLI(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *codeg
 	}
 	sections := []*codegen.SectionTemplate{
 		codegen.Header("", "main", specs),
-		&codegen.SectionTemplate{Name: "cli-http-start", Source: httpCLIStartT},
+		&codegen.SectionTemplate{
+			Name:   "cli-http-start",
+			Source: httpCLIStartT,
+			Data: map[string]interface{}{
+				"CookieJar": needCookieJar(svcData),
+			},
+		},
 		&codegen.SectionTemplate{
 			Name:   "cli-http-streaming",
 			Source: httpCLIStreamingT,
@@ -85,8 +90,10 @@ func exampleCLI(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *codeg
 			Name:   "cli-http-end",
 			Source: httpCLIEndT,
 			Data: map[string]interface{}{
-				"Services": svcData,
-				"APIPkg":   apiPkg,
+				"Services":    svcData,
+				"APIPkg":      apiPkg,
+				"APIName":     cli.APIName(root.API),
+				"Credentials": needCredentials(svcData),
 			},
 			FuncMap: map[string]interface{}{
 				"needStream": needStream,
@@ -101,13 +108,40 @@ func exampleCLI(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *codeg
 	}
 }
 
+// needCredentials returns true if the command line client initializes security
+// flags of the endpoints of the given services with stored credentials.
+func needCredentials(data []*ServiceData) bool {
+	for _, sd := range data {
+		for _, e := range sd.Endpoints {
+			flags, _ := buildFlags(sd, e)
+			for _, f := range flags {
+				if f.Credential != nil {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 const (
 	httpCLIStartT = `func doHTTP(scheme, host string, timeout int, debug bool) (goa.Endpoint, interface{}, error) {
 	var (
 		doer goahttp.Doer
 	)
 	{
+	{{- if .CookieJar }}
+		// The cookie jar stores the cookies set by the responses and
+		// sends them back with the following requests according to
+		// their attributes.
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		doer = &http.Client{Timeout: time.Duration(timeout) * time.Second, Jar: jar}
+	{{- else }}
 		doer = &http.Client{Timeout: time.Duration(timeout) * time.Second}
+	{{- end }}
 		if debug {
 			doer = goahttp.NewDebugDoer(doer)
 		}
@@ -125,12 +159,20 @@ const (
 	{{ end }}
 `
 
-	// input: map[string]interface{}{"Services": []*ServiceData}
-	httpCLIEndT = `return cli.ParseEndpoint(
+	// input: map[string]interface{}{"Services": []*ServiceData, "APIPkg": string, "APIName": string, "Credentials": bool}
+	httpCLIEndT = `{{- if .Credentials }}
+	// The security flags not given explicitly are initialized with the
+	// credentials of the host stored in the environment or in the
+	// credentials file, see credentials.DefaultStore.
+	creds := credentials.NewProfile(host, credentials.DefaultStore({{ printf "%q" .APIName }}))
+	creds.BaseURL = scheme + "://" + host
+	creds.Client = &http.Client{Timeout: time.Duration(timeout) * time.Second}
+	{{- end }}
+	return cli.ParseEndpoint(
 		scheme,
 		host,
 		doer,
-		goahttp.RequestEncoder,
+		goahttp.PooledRequestEncoder,
 		goahttp.ResponseDecoder,
 		debug,
 		{{- if needStream .Services }}
@@ -146,6 +188,9 @@ const (
 				{{- end }}
 			{{- end }}
 		{{- end }}
+		{{- if .Credentials }}
+		creds,
+		{{- end }}
 	)
 }
 `
@@ -158,5 +203,13 @@ func httpUsageCommands() string {
 func httpUsageExamples() string {
   return cli.UsageExamples()
 }
+
+func httpCommands() []*goacli.Command {
+  return cli.Commands()
+}
+
+func httpStream(data interface{}) *goacli.Stream {
+  return cli.Stream(data)
+}
 `
 )
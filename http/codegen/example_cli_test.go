@@ -22,6 +22,8 @@ func TestExampleCLIFiles(t *testing.T) {
 		{"server-hosting-multiple-services", ctestdata.ServerHostingMultipleServicesDSL, testdata.ExampleCLICode},
 		{"streaming", testdata.StreamingResultDSL, testdata.StreamingExampleCLICode},
 		{"streaming-multiple-services", testdata.StreamingMultipleServicesDSL, testdata.StreamingMultipleServicesExampleCLICode},
+		{"cookies", testdata.CookiesDSL, testdata.CookiesExampleCLICode},
+		{"credentials", testdata.CredentialsDSL, testdata.CredentialsExampleCLICode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -0,0 +1,168 @@
+package codegen
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/example"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// lambdaData contains the data used to render the main package of an
+	// AWS Lambda function.
+	lambdaData struct {
+		// Server is the name of the server served by the function.
+		Server string
+		// Cmd is the path to the function main package.
+		Cmd string
+		// APIPkg is the name of the package implementing the services.
+		APIPkg string
+		// Services lists the services served by the function.
+		Services []*ServiceData
+		// Endpoint is the endpoint served by the function if it serves
+		// a single endpoint, nil otherwise.
+		Endpoint *EndpointData
+	}
+)
+
+// ExampleLambdaFiles returns the main packages of the AWS Lambda functions of
+// the servers that define Lambda, see dsl.Lambda.
+func ExampleLambdaFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var fw []*codegen.File
+	for _, svr := range root.API.Servers {
+		if svr.Lambda == nil {
+			continue
+		}
+		var svcs []*ServiceData
+		for _, svc := range svr.Services {
+			if data := HTTPServices.Get(svc); data != nil {
+				svcs = append(svcs, data)
+			}
+		}
+		dir := example.Servers.Get(svr).Dir + "_lambda"
+		fw = append(fw, exampleLambda(genpkg, root, svr, dir, svcs, nil))
+		if !svr.Lambda.PerMethod {
+			continue
+		}
+		for _, sd := range svcs {
+			for _, e := range sd.Endpoints {
+				if isStreamingEndpoint(e) {
+					continue
+				}
+				edir := path.Join(dir, codegen.SnakeCase(sd.Service.VarName)+"_"+codegen.SnakeCase(e.Method.VarName))
+				fw = append(fw, exampleLambda(genpkg, root, svr, edir, []*ServiceData{sd}, e))
+			}
+		}
+	}
+	return fw
+}
+
+// exampleLambda returns the main package of the function serving the given
+// services or the given endpoint if not nil.
+func exampleLambda(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr, dir string, svcs []*ServiceData, e *EndpointData) *codegen.File {
+	specs := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "log"},
+		{Path: "net/http"},
+		{Path: "os"},
+		codegen.GoaNamedImport("http", "goahttp"),
+		codegen.GoaNamedImport("http/middleware", "httpmdlwr"),
+		codegen.GoaImport(""),
+		codegen.GoaImport("middleware"),
+	}
+	scope := codegen.NewNameScope()
+	for _, sd := range svcs {
+		svcName := codegen.SnakeCase(sd.Service.VarName)
+		specs = append(specs,
+			&codegen.ImportSpec{
+				Path: path.Join(genpkg, "http", svcName, "server"),
+				Name: scope.Unique(sd.Service.PkgName + "svr"),
+			},
+			&codegen.ImportSpec{
+				Path: path.Join(genpkg, svcName),
+				Name: scope.Unique(sd.Service.PkgName),
+			})
+	}
+	apiPkg := scope.Unique(strings.ToLower(codegen.Goify(root.API.Name, false)), "api")
+	specs = append(specs, &codegen.ImportSpec{Path: codegen.Layout.RootPackage(genpkg), Name: apiPkg})
+
+	data := &lambdaData{
+		Server:   svr.Name,
+		Cmd:      "./" + path.Join("cmd", dir),
+		APIPkg:   apiPkg,
+		Services: svcs,
+		Endpoint: e,
+	}
+	sections := []*codegen.SectionTemplate{
+		codegen.Header("", "main", specs),
+		{
+			Name:   "lambda-main",
+			Source: lambdaMainT,
+			Data:   data,
+			FuncMap: map[string]interface{}{
+				"streamingEndpointExists": streamingEndpointExists,
+			},
+		},
+		{Name: "server-http-errorhandler", Source: httpSvrErrorHandlerT},
+	}
+	return &codegen.File{
+		Path:             filepath.Join("cmd", filepath.FromSlash(dir), "main.go"),
+		SectionTemplates: sections,
+		SkipExist:        true,
+	}
+}
+
+// input: lambdaData
+const lambdaMainT = `
+{{- if .Endpoint }}
+{{ printf "main serves the %q endpoint of the %q service as an AWS Lambda function invoked by an API Gateway HTTP API (payload format 2.0). Build the executable of the function for the \"provided.al2\" runtime with:" .Endpoint.Method.Name .Endpoint.ServiceName | comment }}
+{{- else }}
+{{ printf "main serves the HTTP endpoints of the %q server as an AWS Lambda function invoked by an API Gateway HTTP API (payload format 2.0). Build the executable of the function for the \"provided.al2\" runtime with:" .Server | comment }}
+{{- end }}
+//
+//    GOOS=linux GOARCH=arm64 CGO_ENABLED=0 go build -o bootstrap {{ .Cmd }}
+func main() {
+	{{ comment "Setup logger. Replace logger with your own log package of choice." }}
+	logger := log.New(os.Stderr, "[{{ .APIPkg }}] ", log.Ltime)
+	reporter := goa.PanicReporterFunc(func(_ context.Context, p interface{}, stack []byte) {
+		logger.Printf("panic: %v\n%s", p, stack)
+	})
+
+	var (
+		dec = goahttp.RequestDecoder
+		enc = goahttp.PooledResponseEncoder
+		mux = goahttp.NewMuxer()
+		eh  = errorHandler(logger)
+	)
+{{- range .Services }}
+	{{- if .Endpoints }}
+	{
+		svc := {{ $.APIPkg }}.New{{ .Service.StructName }}(logger)
+		endpoints := {{ .Service.PkgName }}.NewEndpoints(svc)
+		endpoints.Use(goa.RecoverEndpoint(reporter))
+		server := {{ .Service.PkgName }}svr.New(endpoints, mux, dec, enc, eh{{ if streamingEndpointExists . }}, nil, nil{{ end }}{{ range .Endpoints }}{{ if .MultipartRequestDecoder }}, {{ $.APIPkg }}.{{ .MultipartRequestDecoder.FuncName }}{{ end }}{{ end }}{{ if .FileSystem }}, nil{{ end }})
+		{{- if $.Endpoint }}
+		{{ .Service.PkgName }}svr.{{ $.Endpoint.MountHandler }}(mux, server.{{ $.Endpoint.Method.VarName }})
+		{{- else }}
+		{{ .Service.PkgName }}svr.Mount(mux, server)
+		{{- end }}
+	}
+	{{- end }}
+{{- end }}
+
+	var handler http.Handler = mux
+	{
+		handler = httpmdlwr.Recover(reporter, enc)(handler)
+		handler = httpmdlwr.Log(middleware.NewLogger(logger))(handler)
+		handler = httpmdlwr.RequestID(httpmdlwr.UseXRequestIDHeaderOption(true))(handler)
+	}
+
+	{{ comment "Poll the Lambda runtime API for the API Gateway events and serve them with handler." }}
+	if err := goahttp.StartLambda(handler); err != nil {
+		logger.Fatalf("lambda runtime: %v", err)
+	}
+}
+`
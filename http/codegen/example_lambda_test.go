@@ -0,0 +1,49 @@
+package codegen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/example"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestExampleLambdaFiles(t *testing.T) {
+	example.Servers = make(example.ServersData)
+	RunHTTPDSL(t, testdata.LambdaDSL)
+	fs := ExampleLambdaFiles("", expr.Root)
+	paths := []string{
+		filepath.Join("cmd", "calc_lambda", "main.go"),
+		filepath.Join("cmd", "calc_lambda", "calc_add", "main.go"),
+	}
+	if len(fs) != len(paths) {
+		t.Fatalf("got %d files, expected %d", len(fs), len(paths))
+	}
+	for i, p := range paths {
+		if fs[i].Path != p {
+			t.Errorf("got path %q, expected %q", fs[i].Path, p)
+		}
+	}
+	cases := []struct {
+		Name string
+		File *codegen.File
+		Code string
+	}{
+		{"server", fs[0], testdata.LambdaServerMainCode},
+		{"method", fs[1], testdata.LambdaMethodMainCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			sections := c.File.Section("lambda-main")
+			if len(sections) != 1 {
+				t.Fatalf("got %d lambda-main sections, expected one", len(sections))
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
@@ -24,6 +24,8 @@ func ExampleServerFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 			fw = append(fw, f)
 		}
 	}
+	fw = append(fw, dummyOAuth2IssuerFiles(genpkg, root)...)
+	fw = append(fw, ExampleLambdaFiles(genpkg, root)...)
 	return fw
 }
 
@@ -41,11 +43,13 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 		{Path: "time"},
 		codegen.GoaNamedImport("http", "goahttp"),
 		codegen.GoaNamedImport("http/middleware", "httpmdlwr"),
+		codegen.GoaImport(""),
 		codegen.GoaImport("middleware"),
 		{Path: "github.com/gorilla/websocket"},
 	}
 
 	scope := codegen.NewNameScope()
+	logPkgs := make(map[string]string)
 	for _, svc := range root.API.HTTP.Services {
 		sd := HTTPServices.Get(svc.Name())
 		svcName := codegen.SnakeCase(sd.Service.VarName)
@@ -57,6 +61,26 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 			Path: path.Join(genpkg, svcName),
 			Name: scope.Unique(sd.Service.PkgName),
 		})
+		if svc.ServiceExpr.IsLogged() && len(sd.Endpoints) > 0 {
+			logPkgs[svc.Name()] = scope.Unique(sd.Service.PkgName + "log")
+			specs = append(specs, &codegen.ImportSpec{
+				Path: path.Join(genpkg, svcName, "logging"),
+				Name: logPkgs[svc.Name()],
+			})
+		}
+	}
+	if len(logPkgs) > 0 {
+		specs = append(specs, &codegen.ImportSpec{Path: "log/slog"})
+	}
+	var metricsPkg string
+	for _, svc := range root.API.HTTP.Services {
+		if svc.ServiceExpr.IsPrometheusInstrumented() && len(svc.ServiceExpr.Methods) > 0 {
+			metricsPkg = scope.Unique("metrics")
+			specs = append(specs,
+				&codegen.ImportSpec{Path: path.Join(genpkg, "metrics"), Name: metricsPkg},
+				&codegen.ImportSpec{Path: "github.com/prometheus/client_golang/prometheus/promhttp"})
+			break
+		}
 	}
 
 	var (
@@ -64,12 +88,7 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 		apiPkg   string
 	)
 	{
-		// genpkg is created by path.Join so the separator is / regardless of operating system
-		idx := strings.LastIndex(genpkg, string("/"))
-		rootPath = "."
-		if idx > 0 {
-			rootPath = genpkg[:idx]
-		}
+		rootPath = codegen.Layout.RootPackage(genpkg)
 		apiPkg = scope.Unique(strings.ToLower(codegen.Goify(root.API.Name, false)), "api")
 	}
 	specs = append(specs, &codegen.ImportSpec{Path: rootPath, Name: apiPkg})
@@ -88,6 +107,7 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 			Source: httpSvrStartT,
 			Data: map[string]interface{}{
 				"Services": svcdata,
+				"Health":   root.API.HealthCheck,
 			},
 		},
 		&codegen.SectionTemplate{Name: "server-http-logger", Source: httpSvrLoggerT},
@@ -99,8 +119,17 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 			Data: map[string]interface{}{
 				"Services": svcdata,
 				"APIPkg":   apiPkg,
+				"Health":   root.API.HealthCheck,
+			},
+			FuncMap: map[string]interface{}{
+				"needStream": needStream,
+				"loggingPkg": func(svc string) string { return logPkgs[svc] },
+				"metricsPkg": func() string { return metricsPkg },
+				"isMetered": func(svc string) bool {
+					s := root.Service(svc)
+					return metricsPkg != "" && s != nil && s.IsPrometheusInstrumented() && len(s.Methods) > 0
+				},
 			},
-			FuncMap: map[string]interface{}{"needStream": needStream},
 		},
 		&codegen.SectionTemplate{Name: "server-http-middleware", Source: httpSvrMiddlewareT},
 		&codegen.SectionTemplate{
@@ -198,9 +227,9 @@ func {{ .FuncName }}(mw *multipart.Writer, p {{ .Payload.Ref }}) error {
 }
 `
 
-	// input: map[string]interface{}{"Services":[]*ServiceData}
+	// input: map[string]interface{}{"Services":[]*ServiceData, "Health": *expr.HealthCheckExpr}
 	httpSvrStartT = `{{ comment "handleHTTPServer starts configures and starts a HTTP server on the given URL. It shuts down the server if any error is received in the error channel." }}
-func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if .Service.Methods }}, {{ .Service.VarName }}Endpoints *{{ .Service.PkgName }}.Endpoints{{ end }}{{ end }}, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if .Service.Methods }}, {{ .Service.VarName }}Endpoints *{{ .Service.PkgName }}.Endpoints{{ end }}{{ end }}, wg *sync.WaitGroup, errc chan error, drainer *goa.Drainer, {{ if .Health }}health *goa.Health, {{ end }}reporter goa.PanicReporter, logger *log.Logger, debug bool) {
 `
 
 	httpSvrLoggerT = `
@@ -220,7 +249,7 @@ func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 	// see goa.design/implement/encoding.
 	var (
 		dec = goahttp.RequestDecoder
-		enc = goahttp.ResponseEncoder
+		enc = goahttp.PooledResponseEncoder
 	)
 `
 
@@ -233,7 +262,7 @@ func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 	}
 `
 
-	// input: map[string]interface{}{"APIPkg":string, "Services":[]*ServiceData}
+	// input: map[string]interface{}{"APIPkg":string, "Services":[]*ServiceData, "Health": *expr.HealthCheckExpr}
 	httpSvrInitT = `
 	// Wrap the endpoints with the transport specific layers. The generated
 	// server packages contains code generated from the design which maps
@@ -251,15 +280,47 @@ func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 	{{- end }}
 	{{- range .Services }}
 		{{-  if .Endpoints }}
-		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New({{ .Service.VarName }}Endpoints, mux, dec, enc, eh{{ if needStream $.Services }}, upgrader, nil{{ end }}{{ range .Endpoints }}{{ if .MultipartRequestDecoder }}, {{ $.APIPkg }}.{{ .MultipartRequestDecoder.FuncName }}{{ end }}{{ end }})
+		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New({{ .Service.VarName }}Endpoints, mux, dec, enc, eh{{ if needStream $.Services }}, upgrader, nil{{ end }}{{ range .Endpoints }}{{ if .MultipartRequestDecoder }}, {{ $.APIPkg }}.{{ .MultipartRequestDecoder.FuncName }}{{ end }}{{ end }}{{ if .FileSystem }}, nil{{ end }})
 		{{-  else }}
-		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New(nil, mux, dec, enc, eh)
+		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New(nil, mux, dec, enc, eh{{ if .FileSystem }}, nil{{ end }})
 		{{-  end }}
 	{{- end }}
 	}
+	{{- range .Services }}
+		{{- if isMetered .Service.Name }}
+	{{ metricsPkg }}.Wrap{{ .Service.StructName }}HTTP({{ .Service.VarName }}Server)
+		{{- end }}
+	{{- end }}
+	{{- if metricsPkg }}
+	mux.Handle("GET", "/metrics", promhttp.Handler().ServeHTTP)
+	{{- end }}
+	{{- range .Services }}
+		{{- if loggingPkg .Service.Name }}
+	{{ .Service.VarName }}Server.Use({{ loggingPkg .Service.Name }}.Handler(slog.Default()))
+		{{- end }}
+	{{- end }}
+	{{- range .Services }}
+		{{- if .CSRF }}
+			{{- if .CSRF.Synchronizer }}
+	{{ comment "Protect the endpoints that authenticate requests with cookies against CSRF, the tokens are derived from the session cookies with the secret read from the CSRF_SECRET environment variable." }}
+	if err := {{ .Service.VarName }}Server.ProtectCSRF([]byte(os.Getenv("CSRF_SECRET"))); err != nil {
+		logger.Fatalf("failed to protect the {{ .Service.Name }} endpoints against CSRF: %v", err)
+	}
+			{{- else }}
+	{{ comment "Protect the endpoints that authenticate requests with cookies against CSRF." }}
+	{{ .Service.VarName }}Server.ProtectCSRF()
+			{{- end }}
+		{{- end }}
+	{{- end }}
 	// Configure the mux.
 	{{- range .Services }}
-		{{ .Service.PkgName }}svr.Mount(mux{{ if .Endpoints }}, {{ .Service.VarName }}Server{{ end }})
+		{{ .Service.PkgName }}svr.Mount(mux{{ if or .Endpoints .FileSystem }}, {{ .Service.VarName }}Server{{ end }})
+	{{- end }}
+	{{- if .Health }}
+	mux.Handle("GET", {{ printf "%q" .Health.LivenessPath }}, goahttp.HealthHandler(health.Live))
+	mux.Handle("GET", {{ printf "%q" .Health.ReadinessPath }}, goahttp.HealthHandler(health.Ready))
+	{{- else }}
+	mux.Handle("GET", "/readyz", goahttp.ReadyHandler(drainer))
 	{{- end }}
 `
 
@@ -268,11 +329,13 @@ func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 	// here apply to all the service endpoints.
 	var handler http.Handler = mux
 	{
+		handler = httpmdlwr.Recover(reporter, enc)(handler)
 		if debug {
 			handler = httpmdlwr.Debug(mux, os.Stdout)(handler)
 		}
 		handler = httpmdlwr.Log(adapter)(handler)
 		handler = httpmdlwr.RequestID()(handler)
+		handler = goahttp.DrainStreams(drainer)(handler)
 	}
 `
 
@@ -280,7 +343,7 @@ func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 	httpSvrEndT = `
 	// Start HTTP server using default configuration, change the code to
 	// configure the server as required by your service.
-	srv := &http.Server{Addr: u.Host, Handler: handler}
+	srv := &http.Server{Addr: u.Host, Handler: handler, ReadHeaderTimeout: 60 * time.Second}
 
 	{{- range .Services }}
 		for _, m := range {{ .Service.VarName }}Server.Mounts {
@@ -301,11 +364,17 @@ func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 		<-ctx.Done()
 		logger.Printf("shutting down HTTP server at %q", u.Host)
 
-		{{ comment "Shutdown gracefully with a 30s timeout." }}
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		{{ comment "Report the server as not ready, stop accepting new connections and wait for the in-flight requests and the websocket streams to complete within the grace period." }}
+		drainer.Drain()
+		ctx, cancel := context.WithTimeout(context.Background(), drainer.Grace)
 		defer cancel()
 
-		srv.Shutdown(ctx)
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Printf("failed to shutdown HTTP server: %v", err)
+		}
+		if err := drainer.Wait(ctx); err != nil {
+			logger.Printf("failed to drain HTTP streams: %v", err)
+		}
 	}()
 }
 `
@@ -0,0 +1,38 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestFileServer(t *testing.T) {
+	cases := []struct {
+		Name    string
+		DSL     func()
+		Section string
+		Code    string
+	}{
+		{"dir", testdata.ServerDirFileServerDSL, "server-files", testdata.DirFileServerCode},
+		{"configured-mount", testdata.ServerConfiguredFileServerDSL, "server-mount", testdata.ConfiguredFileServerMountCode},
+		{"embed-struct", testdata.ServerEmbedFileServerDSL, "server-struct", testdata.EmbedFileServerStructCode},
+		{"embed-init", testdata.ServerEmbedFileServerDSL, "server-init", testdata.EmbedFileServerInitCode},
+		{"embed-mount", testdata.ServerEmbedFileServerDSL, "server-mount", testdata.EmbedFileServerMountCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := ServerFiles("", expr.Root)
+			sections := fs[0].Section(c.Section)
+			if len(sections) == 0 {
+				t.Fatalf("section %s not found", c.Section)
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
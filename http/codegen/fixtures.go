@@ -0,0 +1,266 @@
+package codegen
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// fixturesData contains the data needed to render the fixtures of the
+	// service endpoints.
+	fixturesData struct {
+		// Name is the name of the service.
+		Name string
+		// Fixtures lists the endpoint fixtures.
+		Fixtures []*fixtureData
+	}
+
+	// fixtureData describes the fixtures of an endpoint.
+	fixtureData struct {
+		// Name is the name of the method.
+		Name string
+		// File is the base name of the fixture files.
+		File string
+		// Request is the raw HTTP request.
+		Request string
+		// Response is the raw HTTP response.
+		Response string
+	}
+)
+
+// FixturesFiles returns the golden fixtures of the HTTP endpoints if the API
+// defines the "fixtures:record" meta. The fixtures are raw HTTP/1.1 requests
+// and responses built from the design examples, they are written to
+// gen/http/<service>/fixtures alongside a package that replays them. The
+// examples are generated with a random generator seeded with the service and
+// method names so that the fixtures only change when the design does.
+func FixturesFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	if _, ok := root.API.Meta["fixtures:record"]; !ok {
+		return nil
+	}
+	var fw []*codegen.File
+	for _, svc := range root.API.HTTP.Services {
+		data := buildFixturesData(svc)
+		if len(data.Fixtures) == 0 {
+			continue
+		}
+		svcName := codegen.SnakeCase(HTTPServices.Get(svc.Name()).Service.VarName)
+		dir := filepath.Join(codegen.Gendir, "http", svcName, "fixtures")
+		for _, f := range data.Fixtures {
+			fw = append(fw,
+				fixtureFile(filepath.Join(dir, f.File+".request.http"), f.Request),
+				fixtureFile(filepath.Join(dir, f.File+".response.http"), f.Response),
+			)
+		}
+		title := fmt.Sprintf("%s HTTP fixtures", svc.Name())
+		fw = append(fw, &codegen.File{
+			Path: filepath.Join(dir, "fixtures.go"),
+			SectionTemplates: []*codegen.SectionTemplate{
+				codegen.Header(title, "fixtures", []*codegen.ImportSpec{
+					{Path: "fmt"},
+					{Path: "net/http"},
+					{Path: "path/filepath"},
+					{Path: "runtime"},
+					codegen.GoaNamedImport("http", "goahttp"),
+				}),
+				{Name: "fixtures", Source: fixturesT, Data: data},
+			},
+		})
+	}
+	return fw
+}
+
+// fixtureFile returns the file with the given path that contains content.
+func fixtureFile(path, content string) *codegen.File {
+	return &codegen.File{
+		Path: path,
+		SectionTemplates: []*codegen.SectionTemplate{{
+			Name:   "fixture",
+			Source: "{{ . }}",
+			Data:   content,
+		}},
+	}
+}
+
+// buildFixturesData returns the fixtures of the service endpoints. Endpoints
+// whose requests cannot be represented by a raw HTTP message, e.g. streaming
+// or multipart requests, or cannot be built from the design examples are
+// skipped.
+func buildFixturesData(svc *expr.HTTPServiceExpr) *fixturesData {
+	data := HTTPServices.Get(svc.Name())
+	fd := &fixturesData{Name: svc.Name()}
+	for _, e := range svc.HTTPEndpoints {
+		if !e.Benchmarkable() || e.MapQueryParams != nil || len(e.Responses) == 0 {
+			continue
+		}
+		ed := data.Endpoint(e.Name())
+		if ed == nil || len(ed.Routes) == 0 {
+			continue
+		}
+		r := expr.NewRandom(svc.Name() + "#" + e.Name())
+		req, ok := fixtureRequest(e, ed.Routes[0], r)
+		if !ok {
+			continue
+		}
+		resp, ok := fixtureResponse(e.Responses[0], r)
+		if !ok {
+			continue
+		}
+		fd.Fixtures = append(fd.Fixtures, &fixtureData{
+			Name:     e.Name(),
+			File:     codegen.SnakeCase(e.Name()),
+			Request:  req,
+			Response: resp,
+		})
+	}
+	return fd
+}
+
+// fixtureRequest returns the raw HTTP request sent to the endpoint built from
+// the examples generated with r. It returns false if the request cannot be
+// built.
+func fixtureRequest(e *expr.HTTPEndpointExpr, route *RouteData, r *expr.Random) (string, bool) {
+	req, ok := buildContractRequest(e, route, r)
+	if !ok {
+		return "", false
+	}
+	c, ok := req.build("", 0)
+	if !ok {
+		return "", false
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s %s HTTP/1.1\nHost: localhost\n", c.Method, c.URL)
+	for _, h := range c.Headers {
+		for _, v := range h.Values {
+			fmt.Fprintf(&b, "%s: %s\n", h.Name, v)
+		}
+	}
+	for _, a := range contractAuth(e) {
+		if a.In == "basic" {
+			fmt.Fprintf(&b, "Authorization: Basic %s\n", base64.StdEncoding.EncodeToString([]byte(a.Username+":"+a.Password)))
+		}
+	}
+	if req.hasBody {
+		body := indentJSON(c.Body)
+		fmt.Fprintf(&b, "Content-Type: application/json\nContent-Length: %d\n\n%s", len(body), body)
+	} else {
+		b.WriteString("\n")
+	}
+	return b.String(), true
+}
+
+// fixtureResponse returns the raw HTTP response built from the examples
+// generated with r. It returns false if the response cannot be built.
+func fixtureResponse(resp *expr.HTTPResponseExpr, r *expr.Random) (string, bool) {
+	hdrs, ok := benchmarkHeaders(resp.Headers, r)
+	if !ok {
+		return "", false
+	}
+	cookies, ok := benchmarkHeaders(resp.Cookies, r)
+	if !ok {
+		return "", false
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "HTTP/1.1 %d %s\n", resp.StatusCode, http.StatusText(resp.StatusCode))
+	for _, h := range hdrs {
+		for _, v := range h.Values {
+			fmt.Fprintf(&b, "%s: %s\n", h.Name, v)
+		}
+	}
+	for _, c := range cookies {
+		fmt.Fprintf(&b, "Set-Cookie: %s=%s\n", c.Name, strings.Join(c.Values, ","))
+	}
+	if resp.Body == nil || resp.Body.Type == expr.Empty {
+		b.WriteString("\n")
+		return b.String(), true
+	}
+	body, err := json.Marshal(resp.Body.Example(r))
+	if err != nil {
+		return "", false
+	}
+	ct := resp.ContentType
+	if ct == "" {
+		ct = "application/json"
+	}
+	indented := indentJSON(string(body))
+	fmt.Fprintf(&b, "Content-Type: %s\nContent-Length: %d\n\n%s", ct, len(indented), indented)
+	return b.String(), true
+}
+
+// indentJSON returns the indented representation of the given JSON document
+// followed by a newline.
+func indentJSON(doc string) string {
+	var b bytes.Buffer
+	if err := json.Indent(&b, []byte(doc), "", "  "); err != nil {
+		return doc + "\n"
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// input: fixturesData
+const fixturesT = `{{ printf "Methods lists the %q service methods that have recorded fixtures." .Name | comment }}
+var Methods = []string{ {{- range $i, $f := .Fixtures }}{{ if $i }}, {{ end }}{{ printf "%q" $f.Name }}{{ end }} }
+
+// files maps the methods to the base names of their fixture files.
+var files = map[string]string{
+{{- range .Fixtures }}
+	{{ printf "%q" .Name }}: {{ printf "%q" .File }},
+{{- end }}
+}
+
+// Request returns the request recorded for the given method.
+func Request(method string) (*http.Request, error) {
+	p, err := path(method, "request")
+	if err != nil {
+		return nil, err
+	}
+	return goahttp.ReadFixtureRequest(p)
+}
+
+// Response returns the response recorded for the given method. The response
+// may be given to the client response decoder of the method to make sure that
+// the recorded responses can still be decoded.
+func Response(method string) (*http.Response, error) {
+	p, err := path(method, "response")
+	if err != nil {
+		return nil, err
+	}
+	return goahttp.ReadFixtureResponse(p)
+}
+
+// Replay sends the request recorded for the given method to h and returns an
+// error if the status code or the content type of the response differ from
+// the recorded response.
+func Replay(h http.Handler, method string) error {
+	req, err := path(method, "request")
+	if err != nil {
+		return err
+	}
+	resp, err := path(method, "response")
+	if err != nil {
+		return err
+	}
+	return goahttp.ReplayFixture(h, req, resp)
+}
+
+// path returns the path to the fixture file of the given kind recorded for the
+// given method. The fixture files are located in the directory of this source
+// file.
+func path(method, kind string) (string, error) {
+	f, ok := files[method]
+	if !ok {
+		return "", fmt.Errorf("no fixture recorded for method %q", method)
+	}
+	_, src, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(src), f+"."+kind+".http"), nil
+}
+`
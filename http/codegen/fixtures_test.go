@@ -0,0 +1,60 @@
+package codegen
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestFixturesFiles(t *testing.T) {
+	RunHTTPDSL(t, testdata.FixturesDSL)
+	fs := FixturesFiles("gen", expr.Root)
+	dir := filepath.Join("gen", "http", "service_fixtures", "fixtures")
+	expected := []struct {
+		Path    string
+		Content string
+	}{
+		{filepath.Join(dir, "show.request.http"), testdata.FixturesShowRequest},
+		{filepath.Join(dir, "show.response.http"), testdata.FixturesShowResponse},
+		{filepath.Join(dir, "create.request.http"), testdata.FixturesCreateRequest},
+		{filepath.Join(dir, "create.response.http"), testdata.FixturesCreateResponse},
+		{filepath.Join(dir, "fixtures.go"), ""},
+	}
+	if len(fs) != len(expected) {
+		t.Fatalf("got %d files, expected %d", len(fs), len(expected))
+	}
+	for i, e := range expected {
+		if fs[i].Path != e.Path {
+			t.Errorf("got path %q, expected %q", fs[i].Path, e.Path)
+		}
+		if e.Content == "" {
+			continue
+		}
+		var buf bytes.Buffer
+		if err := fs[i].SectionTemplates[0].Write(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if content := buf.String(); content != e.Content {
+			t.Errorf("%s: invalid content, got:\n%s\ngot vs. expected:\n%s", e.Path, content, codegen.Diff(t, content, e.Content))
+		}
+	}
+	sections := fs[4].Section("fixtures")
+	if len(sections) != 1 {
+		t.Fatalf("got %d sections, expected one", len(sections))
+	}
+	code := codegen.SectionCode(t, sections[0])
+	if code != testdata.FixturesCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.FixturesCode))
+	}
+}
+
+func TestFixturesFilesDisabled(t *testing.T) {
+	RunHTTPDSL(t, testdata.BenchmarkDSL)
+	if fs := FixturesFiles("gen", expr.Root); len(fs) != 0 {
+		t.Errorf("got %d files, expected none", len(fs))
+	}
+}
@@ -0,0 +1,38 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestFormEncoded(t *testing.T) {
+	const genpkg = "gen"
+	RunHTTPDSL(t, testdata.FormEncodedDSL)
+	cases := []struct {
+		Name    string
+		File    *codegen.File
+		Section string
+		Code    string
+	}{
+		{"server-init", ServerFiles(genpkg, expr.Root)[0], "server-init", testdata.FormEncodedServerInitCode},
+		{"client-endpoint-init", ClientFiles(genpkg, expr.Root)[0], "client-endpoint-init", testdata.FormEncodedClientEndpointInitCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			for _, s := range c.File.SectionTemplates {
+				if s.Name != c.Section {
+					continue
+				}
+				code := codegen.SectionCode(t, s)
+				if code != c.Code {
+					t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+				}
+				return
+			}
+			t.Fatalf("section %q not found", c.Section)
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package codegen
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// fuzzData contains the data needed to render the fuzz target of an
+	// endpoint request decoder.
+	fuzzData struct {
+		*EndpointData
+		// Verb is the HTTP method.
+		Verb string
+		// Pattern is the route pattern.
+		Pattern string
+		// URL is the expression that computes the request URL from the
+		// fuzzed path parameters and query string.
+		URL string
+		// Args lists the fuzzed values.
+		Args []*fuzzArgData
+		// Headers lists the fuzzed headers.
+		Headers []*fuzzHeaderData
+		// Body is the name of the fuzzed request body argument if any.
+		Body string
+	}
+
+	// fuzzArgData describes an argument of a fuzz function.
+	fuzzArgData struct {
+		// Name is the name of the argument.
+		Name string
+		// Type is the type of the argument, "string" or "[]byte".
+		Type string
+		// Seed is the Go literal of the value used to seed the corpus.
+		Seed string
+	}
+
+	// fuzzHeaderData describes a fuzzed request header.
+	fuzzHeaderData struct {
+		// Name is the header name.
+		Name string
+		// Arg is the name of the fuzzed argument holding the value.
+		Arg string
+	}
+)
+
+// FuzzFiles returns the files that contain the fuzz targets of the HTTP
+// request decoders if the API defines the "fuzz:test" meta. The targets fuzz
+// the path parameters, query string, headers and body of the requests and
+// make sure that decoding and validating the requests does not panic. The
+// corpus is seeded with the requests built from the design examples. The
+// files require Go 1.18 or later.
+func FuzzFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	if _, ok := root.API.Meta["fuzz:test"]; !ok {
+		return nil
+	}
+	var fw []*codegen.File
+	for _, svc := range root.API.HTTP.Services {
+		fuzzs := buildFuzzData(svc)
+		if len(fuzzs) == 0 {
+			continue
+		}
+		data := HTTPServices.Get(svc.Name())
+		svcName := codegen.SnakeCase(data.Service.VarName)
+		path := filepath.Join(codegen.Gendir, "http", svcName, "server", "fuzz_test.go")
+		title := fmt.Sprintf("%s HTTP request decoders fuzz targets", svc.Name())
+		header := codegen.Header(title, "server", []*codegen.ImportSpec{
+			{Path: "bytes"},
+			{Path: "net/http"},
+			{Path: "net/http/httptest"},
+			{Path: "net/url"},
+			{Path: "testing"},
+			codegen.GoaNamedImport("http", "goahttp"),
+		})
+		codegen.AddBuildConstraint(header, "go1.18")
+		sections := []*codegen.SectionTemplate{header}
+		for _, f := range fuzzs {
+			sections = append(sections, &codegen.SectionTemplate{Name: "fuzz-decode", Source: fuzzDecodeT, Data: f})
+		}
+		fw = append(fw, &codegen.File{Path: path, SectionTemplates: sections})
+	}
+	return fw
+}
+
+// buildFuzzData returns the data needed to render the fuzz targets of the
+// service endpoint request decoders. Endpoints without payload or whose
+// requests cannot be built from the design examples are skipped.
+func buildFuzzData(svc *expr.HTTPServiceExpr) []*fuzzData {
+	data := HTTPServices.Get(svc.Name())
+	var fuzzs []*fuzzData
+	for _, e := range svc.HTTPEndpoints {
+		if !e.Benchmarkable() || e.MapQueryParams != nil {
+			continue
+		}
+		ed := data.Endpoint(e.Name())
+		if ed == nil || len(ed.Routes) == 0 || ed.Payload.Ref == "" {
+			continue
+		}
+		req, ok := buildContractRequest(e, ed.Routes[0], expr.Root.API.Random())
+		if !ok {
+			continue
+		}
+		var (
+			scope = codegen.NewNameScope()
+			f     = &fuzzData{EndpointData: ed, Verb: req.verb, Pattern: req.route}
+		)
+		scope.Unique("t")
+
+		// path
+		var (
+			u    []string
+			last int
+		)
+		for _, m := range expr.HTTPWildcardRegex.FindAllStringSubmatchIndex(req.route, -1) {
+			name := req.route[m[2]:m[3]]
+			seed, err := url.PathUnescape(req.params[name])
+			if err != nil {
+				seed = req.params[name]
+			}
+			arg := scope.Unique(codegen.Goify(name, false))
+			f.Args = append(f.Args, &fuzzArgData{Name: arg, Type: "string", Seed: strconv.Quote(seed)})
+			u = append(u, strconv.Quote(req.route[last:m[0]]+"/"), "url.PathEscape("+arg+")")
+			last = m[1]
+		}
+		if last < len(req.route) {
+			u = append(u, strconv.Quote(req.route[last:]))
+		}
+
+		// query string
+		if len(req.query) > 0 {
+			var qs []string
+			for _, q := range req.query {
+				for _, v := range q.Values {
+					qs = append(qs, url.QueryEscape(q.Name)+"="+url.QueryEscape(v))
+				}
+			}
+			arg := scope.Unique("query")
+			f.Args = append(f.Args, &fuzzArgData{Name: arg, Type: "string", Seed: strconv.Quote(strings.Join(qs, "&"))})
+			u = append(u, `"?"`, arg)
+		}
+		f.URL = strings.Join(u, " + ")
+
+		// headers
+		for _, h := range req.headers {
+			arg := scope.Unique(codegen.Goify(h.Name, false))
+			f.Args = append(f.Args, &fuzzArgData{Name: arg, Type: "string", Seed: strconv.Quote(strings.Join(h.Values, ","))})
+			f.Headers = append(f.Headers, &fuzzHeaderData{Name: h.Name, Arg: arg})
+		}
+		for _, a := range contractAuth(e) {
+			if a.In != "basic" {
+				continue
+			}
+			arg := scope.Unique("authorization")
+			seed := "Basic " + base64.StdEncoding.EncodeToString([]byte(a.Username+":"+a.Password))
+			f.Args = append(f.Args, &fuzzArgData{Name: arg, Type: "string", Seed: strconv.Quote(seed)})
+			f.Headers = append(f.Headers, &fuzzHeaderData{Name: "Authorization", Arg: arg})
+		}
+
+		// body
+		if req.hasBody {
+			c, ok := req.build("", 0)
+			if !ok {
+				continue
+			}
+			f.Body = scope.Unique("body")
+			f.Args = append(f.Args, &fuzzArgData{Name: f.Body, Type: "[]byte", Seed: "[]byte(" + strconv.Quote(c.Body) + ")"})
+		}
+		if len(f.Args) == 0 {
+			continue
+		}
+		fuzzs = append(fuzzs, f)
+	}
+	return fuzzs
+}
+
+// input: fuzzData
+const fuzzDecodeT = `{{ printf "Fuzz%sDecodeRequest makes sure that decoding and validating the %q service %q requests does not panic. The corpus is seeded with the request built from the design examples." .Method.VarName .ServiceName .Method.Name | comment }}
+func Fuzz{{ .Method.VarName }}DecodeRequest(f *testing.F) {
+	var (
+		mux    = goahttp.NewMuxer()
+		decode = {{ .RequestDecoder }}(mux, goahttp.RequestDecoder)
+	)
+	mux.Handle({{ printf "%q" .Verb }}, {{ printf "%q" .Pattern }}, func(w http.ResponseWriter, r *http.Request) {
+		// Invalid requests must be rejected with an error.
+		decode(r)
+	})
+	f.Add({{ range $i, $a := .Args }}{{ if $i }}, {{ end }}{{ $a.Seed }}{{ end }})
+	f.Fuzz(func(t *testing.T{{ range .Args }}, {{ .Name }} {{ .Type }}{{ end }}) {
+		req, err := http.NewRequest({{ printf "%q" .Verb }}, {{ .URL }}, {{ if .Body }}bytes.NewReader({{ .Body }}){{ else }}nil{{ end }})
+		if err != nil {
+			return
+		}
+	{{- range .Headers }}
+		req.Header.Set({{ printf "%q" .Name }}, {{ .Arg }})
+	{{- end }}
+	{{- if .Body }}
+		req.Header.Set("Content-Type", "application/json")
+	{{- end }}
+		mux.ServeHTTP(httptest.NewRecorder(), req)
+	})
+}
+`
@@ -0,0 +1,32 @@
+package codegen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestFuzzFiles(t *testing.T) {
+	RunHTTPDSL(t, testdata.FuzzDSL)
+	fs := FuzzFiles("gen", expr.Root)
+	if len(fs) != 1 {
+		t.Fatalf("got %d files, expected one", len(fs))
+	}
+	if p := filepath.Join("gen", "http", "service_fuzz", "server", "fuzz_test.go"); fs[0].Path != p {
+		t.Errorf("got path %q, expected %q", fs[0].Path, p)
+	}
+	code := codegen.SectionsCode(t, fs[0].Section("fuzz-decode"))
+	if code != testdata.FuzzCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.FuzzCode))
+	}
+}
+
+func TestFuzzFilesDisabled(t *testing.T) {
+	RunHTTPDSL(t, testdata.BenchmarkDSL)
+	if fs := FuzzFiles("gen", expr.Root); len(fs) != 0 {
+		t.Errorf("got %d files, expected none", len(fs))
+	}
+}
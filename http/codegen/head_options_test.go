@@ -0,0 +1,35 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestAutoHeadOptions(t *testing.T) {
+	const genpkg = "gen"
+	RunHTTPDSL(t, testdata.AutoHeadOptionsDSL)
+	f := ServerFiles(genpkg, expr.Root)[0]
+	cases := []struct {
+		Section string
+		Code    string
+	}{
+		{"server-mount", testdata.AutoHeadOptionsMountCode},
+		{"server-handler", testdata.AutoHeadOptionsHandlerCode},
+		{"server-options", testdata.AutoHeadOptionsOptionsCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Section, func(t *testing.T) {
+			sections := f.Section(c.Section)
+			if len(sections) == 0 {
+				t.Fatalf("section %q not found", c.Section)
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package codegen
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// inMemoryData contains the data needed to render the in-memory client
+// helper of a service.
+type inMemoryData struct {
+	*ServiceData
+	// EndpointInits lists the code that builds the client endpoints in the
+	// order of the service methods.
+	EndpointInits []string
+}
+
+// InMemoryFiles returns the files that contain the helpers which wire the
+// generated HTTP clients to the generated HTTP servers in memory. The helpers
+// make it possible for unit tests to call the typed client methods against a
+// service implementation without opening network ports.
+func InMemoryFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var fw []*codegen.File
+	for _, svc := range root.API.HTTP.Services {
+		data := HTTPServices.Get(svc.Name())
+		if len(data.Endpoints) == 0 {
+			continue
+		}
+		svcName := codegen.SnakeCase(data.Service.VarName)
+		fpath := filepath.Join(codegen.Gendir, "http", svcName, "inmem", "inmem.go")
+		title := fmt.Sprintf("%s HTTP in-memory client", svc.Name())
+		sections := []*codegen.SectionTemplate{
+			codegen.Header(title, "inmem", []*codegen.ImportSpec{
+				{Path: "context"},
+				{Path: "fmt"},
+				{Path: "mime/multipart"},
+				{Path: "net/http"},
+				codegen.GoaNamedImport("http", "goahttp"),
+				{Path: "github.com/gorilla/websocket"},
+				{Path: path.Join(genpkg, svcName), Name: data.Service.PkgName},
+				{Path: path.Join(genpkg, "http", svcName, "client"), Name: data.Service.PkgName + "c"},
+				{Path: path.Join(genpkg, "http", svcName, "server"), Name: data.Service.PkgName + "svr"},
+			}),
+			{
+				Name:    "inmem-client",
+				Source:  inMemoryClientT,
+				Data:    buildInMemoryData(data),
+				FuncMap: map[string]interface{}{"streamingEndpointExists": streamingEndpointExists},
+			},
+		}
+		fw = append(fw, &codegen.File{Path: fpath, SectionTemplates: sections})
+	}
+	return fw
+}
+
+// buildInMemoryData returns the data needed to render the in-memory client
+// helper of the given service.
+func buildInMemoryData(data *ServiceData) *inMemoryData {
+	inits := make([]string, len(data.Service.Methods))
+	for i, m := range data.Service.Methods {
+		ed := data.Endpoint(m.Name)
+		switch {
+		case ed == nil:
+			inits[i] = "nil"
+		case ed.MultipartRequestEncoder != nil && ed.MultipartRequestEncoder.Default != "":
+			inits[i] = "c." + m.VarName + "(nil)"
+		case ed.MultipartRequestEncoder != nil:
+			inits[i] = fmt.Sprintf("c.%s(func(*multipart.Writer, %s) error {\n\treturn fmt.Errorf(\"the in-memory client does not encode the multipart requests of the %%q method\", %q)\n})", m.VarName, ed.MultipartRequestEncoder.Payload.Ref, m.Name)
+		default:
+			inits[i] = "c." + m.VarName + "()"
+		}
+	}
+	return &inMemoryData{ServiceData: data, EndpointInits: inits}
+}
+
+// input: inMemoryData
+const inMemoryClientT = `{{ printf "NewClient returns a %q service client whose requests are served by svc over HTTP in memory, without opening network ports. The function returned alongside the client shuts down the server, it must be called once the client is no longer needed." .Service.Name | comment }}
+func NewClient(svc {{ .Service.PkgName }}.Service) (*{{ .Service.PkgName }}.Client, func()) {
+	var (
+		l   = goahttp.NewInMemoryListener()
+		mux = goahttp.NewMuxer()
+		// The errors that occur while encoding the responses are reported
+		// to the client by the response decoders.
+		eh = func(context.Context, http.ResponseWriter, error) {}
+	)
+	server := {{ .Service.PkgName }}svr.New({{ .Service.PkgName }}.NewEndpoints(svc), mux, goahttp.RequestDecoder, goahttp.PooledResponseEncoder, eh
+	{{- if streamingEndpointExists .ServiceData }}, &websocket.Upgrader{}, nil{{ end }}
+	{{- range .Endpoints }}
+		{{- with .MultipartRequestDecoder }}, {{ if .Default }}nil{{ else }}func(*multipart.Reader, *{{ .Payload.Ref }}) error {
+		return fmt.Errorf("the in-memory server does not decode the multipart requests of the %q method", {{ printf "%q" .MethodName }})
+	}{{ end }}
+		{{- end }}
+	{{- end }}
+	{{- if .FileSystem }}, nil{{ end }})
+	{{ .Service.PkgName }}svr.Mount(mux, server)
+	hs := &http.Server{Handler: mux}
+	go hs.Serve(l)
+
+	c := {{ .Service.PkgName }}c.NewClient("http", "inmem", goahttp.NewInMemoryClient(l), goahttp.PooledRequestEncoder, goahttp.ResponseDecoder, false
+	{{- if streamingEndpointExists .ServiceData }}, &websocket.Dialer{NetDialContext: l.DialContext}, nil{{ end }})
+	return {{ .Service.PkgName }}.NewClient({{ range $i, $init := .EndpointInits }}{{ if $i }}, {{ end }}{{ $init }}{{ end }}), func() { hs.Close() }
+}
+`
@@ -0,0 +1,42 @@
+package codegen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestInMemoryFiles(t *testing.T) {
+	cases := []struct {
+		Name    string
+		DSL     func()
+		Service string
+		Code    string
+	}{
+		{"unary", testdata.InMemoryDSL, "in_memory_http", testdata.InMemoryCode},
+		{"streaming-multipart-files", testdata.InMemoryStreamingDSL, "in_memory_streaming", testdata.InMemoryStreamingCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := InMemoryFiles("gen", expr.Root)
+			if len(fs) != 1 {
+				t.Fatalf("got %d files, expected one", len(fs))
+			}
+			if p := filepath.Join("gen", "http", c.Service, "inmem", "inmem.go"); fs[0].Path != p {
+				t.Errorf("got path %q, expected %q", fs[0].Path, p)
+			}
+			sections := fs[0].Section("inmem-client")
+			if len(sections) != 1 {
+				t.Fatalf("got %d sections, expected one", len(sections))
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
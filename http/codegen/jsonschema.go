@@ -0,0 +1,36 @@
+package codegen
+
+import (
+	"path/filepath"
+	"sort"
+	"text/template"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/openapi"
+)
+
+// JSONSchemaFiles returns the files containing the standalone JSON schemas
+// (draft 2020-12) of the user and result types of the design. There is one
+// file per type in the gen/schemas directory named after the type.
+func JSONSchemaFiles(root *expr.RootExpr) []*codegen.File {
+	schemas := openapi.StandaloneSchemas(root)
+	names := make([]string, 0, len(schemas))
+	for n := range schemas {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	files := make([]*codegen.File, len(names))
+	for i, n := range names {
+		files[i] = &codegen.File{
+			Path: filepath.Join(codegen.Gendir, "schemas", n+".json"),
+			SectionTemplates: []*codegen.SectionTemplate{{
+				Name:    "json-schema",
+				FuncMap: template.FuncMap{"toJSON": toJSON},
+				Source:  "{{ toJSON .}}",
+				Data:    schemas[n],
+			}},
+		}
+	}
+	return files
+}
@@ -0,0 +1,56 @@
+package codegen
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestJSONSchema(t *testing.T) {
+	var (
+		goldenPath = filepath.Join("testdata", "jsonschema", t.Name())
+	)
+	root := RunHTTPDSL(t, testdata.JSONSchemaDSL)
+	files := JSONSchemaFiles(root)
+	var names []string
+	for _, f := range files {
+		names = append(names, filepath.Base(f.Path))
+		if filepath.Dir(f.Path) != filepath.Join("gen", "schemas") {
+			t.Errorf("invalid output path %#v", f.Path)
+		}
+	}
+	if got := strings.Join(names, ","); got != "Bottle.json,Cellar.json,Node.json" {
+		t.Fatalf("got files %s, expected Bottle.json,Cellar.json,Node.json", got)
+	}
+	for _, o := range files {
+		name := strings.TrimSuffix(filepath.Base(o.Path), ".json")
+		s := o.SectionTemplates
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tmpl := template.Must(template.New("jsonschema").Funcs(s[0].FuncMap).Parse(s[0].Source))
+			if err := tmpl.Execute(&buf, s[0].Data); err != nil {
+				t.Fatalf("failed to render template: %s", err)
+			}
+
+			golden := filepath.Join(goldenPath, name+".golden")
+			if *update {
+				if err := ioutil.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+					t.Fatalf("failed to update golden file: %s", err)
+				}
+			}
+
+			want, err := ioutil.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %s", err)
+			}
+			if !bytes.Equal(buf.Bytes(), want) {
+				t.Errorf("result do not match the golden file:\n--BEGIN--\n%s\n--END--\n", buf.Bytes())
+			}
+		})
+	}
+}
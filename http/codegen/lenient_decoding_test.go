@@ -0,0 +1,38 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestLenientDecoding(t *testing.T) {
+	const genpkg = "gen"
+	RunHTTPDSL(t, testdata.LenientDecodingDSL)
+	fs := ServerFiles(genpkg, expr.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected two", len(fs))
+	}
+	sections := fs[1].Section("request-decoder")
+	if len(sections) != 2 {
+		t.Fatalf("got %d request-decoder sections, expected 2", len(sections))
+	}
+	cases := []struct {
+		Name  string
+		Code  string
+		Index int
+	}{
+		{"lenient", testdata.LenientDecodingRequestDecoderCode, 0},
+		{"trim", testdata.TrimDecodingRequestDecoderCode, 1},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			code := codegen.SectionCode(t, sections[c.Index])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestStrictMediaTypes(t *testing.T) {
+	const genpkg = "gen"
+	RunHTTPDSL(t, testdata.StrictMediaTypesDSL)
+	fs := ServerFiles(genpkg, expr.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected two", len(fs))
+	}
+	sections := fs[0].Section("server-handler-init")
+	if len(sections) != 1 {
+		t.Fatalf("got %d handler init sections, expected 1", len(sections))
+	}
+	code := codegen.SectionCode(t, sections[0])
+	if code != testdata.StrictMediaTypesHandlerInitCode {
+		t.Errorf("invalid handler init code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.StrictMediaTypesHandlerInitCode))
+	}
+}
@@ -0,0 +1,347 @@
+package codegen
+
+import (
+	"path"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// metricsServiceData contains the data used to render the metrics of a
+	// service.
+	metricsServiceData struct {
+		// Name is the service name.
+		Name string
+		// StructName is the service struct name.
+		StructName string
+		// PkgName is the name of the service package import.
+		PkgName string
+		// ServerPkgName is the name of the HTTP server package import if
+		// the service is exposed over HTTP.
+		ServerPkgName string
+		// Handlers lists the HTTP handlers of the service methods.
+		Handlers []*metricsHandlerData
+		// Streams lists the server streams of the service methods.
+		Streams []*metricsStreamData
+	}
+
+	// metricsHandlerData describes the HTTP handler of a method.
+	metricsHandlerData struct {
+		// Name is the method name.
+		Name string
+		// VarName is the name of the server handler field.
+		VarName string
+		// Routes lists the HTTP routes of the method.
+		Routes []*RouteData
+	}
+
+	// metricsStreamData describes the server stream of a streaming method.
+	metricsStreamData struct {
+		// Name is the method name.
+		Name string
+		// VarName is the name of the method endpoint field.
+		VarName string
+		// TypeName is the name of the generated stream type.
+		TypeName string
+		// Interface is the fully qualified name of the server stream
+		// interface.
+		Interface string
+		// Field is the name of the embedded server stream field.
+		Field string
+		// EndpointStruct is the fully qualified name of the endpoint
+		// input struct.
+		EndpointStruct string
+		// SendName is the name of the send method if any.
+		SendName string
+		// SendTypeRef is the fully qualified reference to the type sent
+		// through the stream.
+		SendTypeRef string
+		// RecvName is the name of the receive method if any.
+		RecvName string
+		// RecvTypeRef is the fully qualified reference to the type
+		// received from the stream.
+		RecvTypeRef string
+	}
+)
+
+// MetricsFiles returns the file that implements the gen/metrics package which
+// records the Prometheus metrics of the services that use the
+// "metrics:prometheus" meta. The metrics of the HTTP requests are labeled with
+// the routes defined in the design rather than with the request URLs so that
+// their cardinality is bounded. The request counts and durations are also
+// labeled with the tenant of the requests if the design defines one, see
+// Tenant.
+func MetricsFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var (
+		svcs  []*metricsServiceData
+		scope = codegen.NewNameScope()
+		specs = []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "net/http"},
+			{Path: "strconv"},
+			{Path: "strings"},
+			{Path: "time"},
+			{Path: "github.com/prometheus/client_golang/prometheus"},
+			codegen.GoaImport(""),
+			codegen.GoaNamedImport("http/middleware", "httpmdlwr"),
+		}
+	)
+	for _, svc := range root.Services {
+		if !svc.IsPrometheusInstrumented() || len(svc.Methods) == 0 {
+			continue
+		}
+		sd := service.Services.Get(svc.Name)
+		svcName := codegen.SnakeCase(sd.VarName)
+		data := &metricsServiceData{
+			Name:       svc.Name,
+			StructName: sd.StructName,
+			PkgName:    scope.Unique(sd.PkgName),
+		}
+		specs = append(specs, &codegen.ImportSpec{Path: path.Join(genpkg, svcName), Name: data.PkgName})
+		if hsvc := root.API.HTTP.Service(svc.Name); hsvc != nil {
+			hd := HTTPServices.Get(svc.Name)
+			data.ServerPkgName = scope.Unique(sd.PkgName + "svr")
+			specs = append(specs, &codegen.ImportSpec{Path: path.Join(genpkg, "http", svcName, "server"), Name: data.ServerPkgName})
+			for _, e := range hd.Endpoints {
+				data.Handlers = append(data.Handlers, &metricsHandlerData{
+					Name:    e.Method.Name,
+					VarName: e.Method.VarName,
+					Routes:  e.Routes,
+				})
+			}
+		}
+		for _, m := range svc.Methods {
+			md := sd.Method(m.Name)
+			if md.ServerStream == nil {
+				continue
+			}
+			ss := md.ServerStream
+			msd := &metricsStreamData{
+				Name:           m.Name,
+				VarName:        md.VarName,
+				TypeName:       codegen.Goify(sd.VarName, false) + ss.Interface,
+				Interface:      data.PkgName + "." + ss.Interface,
+				Field:          ss.Interface,
+				EndpointStruct: data.PkgName + "." + ss.EndpointStruct,
+			}
+			if ss.SendName != "" {
+				msd.SendName = ss.SendName
+				msd.SendTypeRef = sd.Scope.GoFullTypeRef(m.Result, data.PkgName)
+			}
+			if ss.RecvName != "" {
+				msd.RecvName = ss.RecvName
+				msd.RecvTypeRef = sd.Scope.GoFullTypeRef(m.StreamingPayload, data.PkgName)
+			}
+			data.Streams = append(data.Streams, msd)
+		}
+		svcs = append(svcs, data)
+	}
+	if len(svcs) == 0 {
+		return nil
+	}
+	var tenantHeader string
+	if root.API.Tenant != nil {
+		tenantHeader = root.API.Tenant.Header
+	}
+	fpath := filepath.Join(codegen.Gendir, "metrics", "metrics.go")
+	sections := []*codegen.SectionTemplate{
+		codegen.Header("Prometheus metrics", "metrics", specs),
+		{
+			Name:   "metrics-collectors",
+			Source: metricsCollectorsT,
+			Data:   map[string]interface{}{"Namespace": codegen.SnakeCase(root.API.Name), "TenantHeader": tenantHeader},
+		},
+	}
+	for _, data := range svcs {
+		if data.ServerPkgName != "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "metrics-wrap-http",
+				Source: metricsWrapHTTPT,
+				Data:   data,
+			})
+		}
+		if len(data.Streams) > 0 {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "metrics-wrap-streams",
+				Source: metricsWrapStreamsT,
+				Data:   data,
+			})
+			for _, s := range data.Streams {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "metrics-stream",
+					Source: metricsStreamT,
+					Data:   map[string]interface{}{"Service": data.Name, "Stream": s},
+				})
+			}
+		}
+	}
+	return []*codegen.File{{Path: fpath, SectionTemplates: sections}}
+}
+
+// input: map[string]interface{}{"Namespace": string, "TenantHeader": string}
+const metricsCollectorsT = `// Namespace is the namespace of the metrics.
+const Namespace = {{ printf "%q" .Namespace }}
+
+var (
+	// Requests counts the HTTP requests by service, method, route{{ if .TenantHeader }},
+	// status code and tenant.{{ else }} and status
+	// code.{{ end }}
+	Requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests.",
+	}, []string{"service", "method", "route", "code"{{ if .TenantHeader }}, "tenant"{{ end }}})
+
+	// Duration observes the duration of the HTTP requests by service,
+	// method, route{{ if .TenantHeader }}, status code and tenant{{ else }} and status code{{ end }}.
+	Duration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Duration of the HTTP requests.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"service", "method", "route", "code"{{ if .TenantHeader }}, "tenant"{{ end }}})
+
+	// InFlight gauges the HTTP requests being served by service, method and
+	// route.
+	InFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Name:      "http_requests_in_flight",
+		Help:      "Number of HTTP requests being served.",
+	}, []string{"service", "method", "route"})
+
+	// StreamMessages counts the messages sent and received by the server
+	// streams by service, method and direction ("sent" or "received").
+	StreamMessages = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "stream_messages_total",
+		Help:      "Number of messages sent and received by the server streams.",
+	}, []string{"service", "method", "direction"})
+)
+
+// route describes a HTTP route defined in the design.
+type route struct {
+	verb, path string
+}
+
+// matches returns true if the method and path of the request match the route.
+func (rt route) matches(r *http.Request) bool {
+	if rt.verb != r.Method && (r.Method != http.MethodHead || rt.verb != http.MethodGet) {
+		return false
+	}
+	segs := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	psegs := strings.Split(strings.Trim(rt.path, "/"), "/")
+	for i, ps := range psegs {
+		if strings.HasPrefix(ps, "{*") {
+			return true
+		}
+		if i >= len(segs) || ps != segs[i] && !strings.HasPrefix(ps, "{") {
+			return false
+		}
+	}
+	return len(segs) == len(psegs)
+}
+
+// Register registers the metrics with reg, e.g. prometheus.DefaultRegisterer.
+func Register(reg prometheus.Registerer) error {
+	for _, c := range []prometheus.Collector{Requests, Duration, InFlight, StreamMessages} {
+		if err := reg.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handler wraps the HTTP handler of the given service method so that it
+// records the request metrics. The route label is the path of the route that
+// matches the request.{{ if .TenantHeader }} The tenant label is the value of the {{ printf "%q" .TenantHeader }}
+// header.{{ end }}
+func handler(h http.Handler, service, method string, routes ...route) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := routes[0].path
+		for _, rt := range routes {
+			if rt.matches(r) {
+				path = rt.path
+				break
+			}
+		}
+		inFlight := InFlight.WithLabelValues(service, method, path)
+		inFlight.Inc()
+		defer inFlight.Dec()
+		start := time.Now()
+		rw := httpmdlwr.CaptureResponse(w)
+		h.ServeHTTP(rw, r)
+		status := rw.StatusCode
+		if status == 0 {
+			status = http.StatusOK
+		}
+		code := strconv.Itoa(status)
+	{{- if .TenantHeader }}
+		tenant := r.Header.Get({{ printf "%q" .TenantHeader }})
+		Requests.WithLabelValues(service, method, path, code, tenant).Inc()
+		Duration.WithLabelValues(service, method, path, code, tenant).Observe(time.Since(start).Seconds())
+	{{- else }}
+		Requests.WithLabelValues(service, method, path, code).Inc()
+		Duration.WithLabelValues(service, method, path, code).Observe(time.Since(start).Seconds())
+	{{- end }}
+	})
+}
+`
+
+// input: metricsServiceData
+const metricsWrapHTTPT = `{{ printf "Wrap%sHTTP wraps the handlers of the %q service HTTP server so that they record the metrics of the requests labeled with the routes defined in the design. It must be called before the server is mounted." .StructName .Name | comment }}
+func Wrap{{ .StructName }}HTTP(s *{{ .ServerPkgName }}.Server) {
+{{- range .Handlers }}
+	s.{{ .VarName }} = handler(s.{{ .VarName }}, {{ printf "%q" $.Name }}, {{ printf "%q" .Name }}{{ range .Routes }}, route{ {{- printf "%q" .Verb }}, {{ printf "%q" .Path }}}{{ end }})
+{{- end }}
+}
+`
+
+// input: metricsServiceData
+const metricsWrapStreamsT = `{{ printf "Wrap%s wraps the streaming endpoints of the %q service so that they count the messages sent and received by the server streams. It must be called before the endpoints are mounted on the transport servers." .StructName .Name | comment }}
+func Wrap{{ .StructName }}(e *{{ .PkgName }}.Endpoints) {
+{{- range .Streams }}
+	e.{{ .VarName }} = {{ .TypeName }}Endpoint(e.{{ .VarName }})
+{{- end }}
+}
+`
+
+// input: map[string]interface{}{"Service": string, "Stream": *metricsStreamData}
+const metricsStreamT = `{{ with .Stream }}{{ printf "%s counts the messages sent and received by the %q method server stream." .TypeName .Name | comment }}
+type {{ .TypeName }} struct {
+	{{ .Interface }}
+}
+
+{{ printf "%sEndpoint wraps the server stream given to e with a %s." .TypeName .TypeName | comment }}
+func {{ .TypeName }}Endpoint(e goa.Endpoint) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		ep := req.(*{{ .EndpointStruct }})
+		ep.Stream = &{{ .TypeName }}{ep.Stream}
+		return e(ctx, req)
+	}
+}
+{{- if .SendName }}
+
+{{ printf "%s counts the messages sent by the stream." .SendName | comment }}
+func (s *{{ .TypeName }}) {{ .SendName }}(v {{ .SendTypeRef }}) error {
+	if err := s.{{ .Field }}.{{ .SendName }}(v); err != nil {
+		return err
+	}
+	StreamMessages.WithLabelValues({{ printf "%q" $.Service }}, {{ printf "%q" .Name }}, "sent").Inc()
+	return nil
+}
+{{- end }}
+{{- if .RecvName }}
+
+{{ printf "%s counts the messages received by the stream." .RecvName | comment }}
+func (s *{{ .TypeName }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
+	v, err := s.{{ .Field }}.{{ .RecvName }}()
+	if err == nil {
+		StreamMessages.WithLabelValues({{ printf "%q" $.Service }}, {{ printf "%q" .Name }}, "received").Inc()
+	}
+	return v, err
+}
+{{- end }}
+{{ end }}`
@@ -0,0 +1,47 @@
+package codegen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestMetricsFiles(t *testing.T) {
+	RunHTTPDSL(t, testdata.LenientDecodingDSL)
+	if fs := MetricsFiles("gen", expr.Root); len(fs) != 0 {
+		t.Errorf("got %d files for services that are not instrumented, expected none", len(fs))
+	}
+
+	RunHTTPDSL(t, testdata.MetricsDSL)
+	fs := MetricsFiles("gen", expr.Root)
+	if len(fs) != 1 {
+		t.Fatalf("got %d files, expected one", len(fs))
+	}
+	if p := filepath.Join("gen", "metrics", "metrics.go"); fs[0].Path != p {
+		t.Errorf("got path %q, expected %q", fs[0].Path, p)
+	}
+	cases := []struct {
+		Name    string
+		Section string
+		Code    string
+	}{
+		{"wrap-http", "metrics-wrap-http", testdata.MetricsWrapHTTPCode},
+		{"wrap-streams", "metrics-wrap-streams", testdata.MetricsWrapStreamsCode},
+		{"stream", "metrics-stream", testdata.MetricsStreamCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			sections := fs[0].Section(c.Section)
+			if len(sections) != 1 {
+				t.Fatalf("got %d sections, expected one", len(sections))
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
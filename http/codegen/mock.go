@@ -0,0 +1,82 @@
+package codegen
+
+import (
+	"path"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// MockServerFiles returns the file that serves the service mocks over HTTP in
+// the stub server command generated in gen/mock/stub, see
+// service.MockServerFile.
+func MockServerFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	if len(root.API.HTTP.Services) == 0 {
+		return nil
+	}
+	var (
+		svcs  []*ServiceData
+		specs = []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "fmt"},
+			{Path: "log"},
+			{Path: "mime/multipart"},
+			{Path: "net/http"},
+			codegen.GoaNamedImport("http", "goahttp"),
+			{Path: "github.com/gorilla/websocket"},
+		}
+	)
+	for _, svc := range root.API.HTTP.Services {
+		data := HTTPServices.Get(svc.Name())
+		svcName := codegen.SnakeCase(data.Service.VarName)
+		specs = append(specs,
+			&codegen.ImportSpec{Path: path.Join(genpkg, svcName), Name: data.Service.PkgName},
+			&codegen.ImportSpec{Path: path.Join(genpkg, "http", svcName, "server"), Name: data.Service.PkgName + "svr"},
+		)
+		svcs = append(svcs, data)
+	}
+	return []*codegen.File{{
+		Path: filepath.Join(codegen.Gendir, "mock", "stub", "http.go"),
+		SectionTemplates: []*codegen.SectionTemplate{
+			codegen.Header(root.API.Name+" HTTP stub server", "main", specs),
+			{
+				Name:    "mock-server-http",
+				Source:  mockServerHTTPT,
+				Data:    svcs,
+				FuncMap: map[string]interface{}{"streamingEndpointExists": streamingEndpointExists},
+			},
+		},
+	}}
+}
+
+// input: []*ServiceData
+const mockServerHTTPT = `// serveHTTP serves the service mocks over HTTP on the given address.
+func serveHTTP(addr string{{ range . }}, {{ .Service.VarName }}Endpoints *{{ .Service.PkgName }}.Endpoints{{ end }}) error {
+	var (
+		dec = goahttp.RequestDecoder
+		enc = goahttp.PooledResponseEncoder
+		mux = goahttp.NewMuxer()
+		eh  = func(ctx context.Context, w http.ResponseWriter, err error) {
+			log.Printf("HTTP error: %s", err.Error())
+		}
+	)
+{{- range . }}
+	{{ .Service.VarName }}Server := {{ .Service.PkgName }}svr.New({{ if .Endpoints }}{{ .Service.VarName }}Endpoints{{ else }}nil{{ end }}, mux, dec, enc, eh
+	{{- if streamingEndpointExists . }}, &websocket.Upgrader{}, nil{{ end }}
+	{{- range .Endpoints }}
+		{{- with .MultipartRequestDecoder }}, {{ if .Default }}nil{{ else }}func(*multipart.Reader, *{{ .Payload.Ref }}) error {
+		return fmt.Errorf("the stub server does not decode the multipart requests of the %q method", {{ printf "%q" .MethodName }})
+	}{{ end }}
+		{{- end }}
+	{{- end }}
+	{{- if .FileSystem }}, nil{{ end }})
+	{{ .Service.PkgName }}svr.Mount(mux{{ if or .Endpoints .FileSystem }}, {{ .Service.VarName }}Server{{ end }})
+	for _, m := range {{ .Service.VarName }}Server.Mounts {
+		log.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
+	}
+{{- end }}
+	log.Printf("HTTP stub server listening on %q", addr)
+	return http.ListenAndServe(addr, mux)
+}
+`
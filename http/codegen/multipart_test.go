@@ -1,6 +1,7 @@
 package codegen
 
 import (
+	"strings"
 	"testing"
 
 	"goa.design/goa/v3/codegen"
@@ -135,3 +136,66 @@ func TestClientMultipartNewFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestMultipartDefault(t *testing.T) {
+	const genpkg = "gen"
+	cases := []struct {
+		Name    string
+		DSL     func()
+		Server  bool
+		Section string
+		Code    string
+	}{
+		{"decoder", testdata.PayloadMultipartDefaultDSL, true, "multipart-request-default-decoder", testdata.MultipartDefaultDecoderCode},
+		{"encoder", testdata.PayloadMultipartDefaultDSL, false, "multipart-request-default-encoder", testdata.MultipartDefaultEncoderCode},
+		{"stream-decoder", testdata.PayloadMultipartStreamDSL, true, "multipart-request-default-decoder", testdata.MultipartStreamDecoderCode},
+		{"stream-encoder", testdata.PayloadMultipartStreamDSL, false, "multipart-request-default-encoder", testdata.MultipartStreamEncoderCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			files := ClientFiles(genpkg, expr.Root)
+			if c.Server {
+				files = ServerFiles(genpkg, expr.Root)
+			}
+			if len(files) != 2 {
+				t.Fatalf("got %d files, expected two", len(files))
+			}
+			for _, s := range files[1].SectionTemplates {
+				if s.Name != c.Section {
+					continue
+				}
+				code := codegen.SectionCode(t, s)
+				if code != c.Code {
+					t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+				}
+				return
+			}
+			t.Fatalf("section %q not found", c.Section)
+		})
+	}
+}
+
+func TestMultipartNoDefault(t *testing.T) {
+	const genpkg = "gen"
+	cases := []struct {
+		Name string
+		DSL  func()
+	}{
+		{"primitive", testdata.PayloadMultipartPrimitiveDSL},
+		{"array", testdata.PayloadMultipartArrayTypeDSL},
+		{"map", testdata.PayloadMultipartMapTypeDSL},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			for _, f := range append(ServerFiles(genpkg, expr.Root), ClientFiles(genpkg, expr.Root)...) {
+				for _, s := range f.SectionTemplates {
+					if strings.HasPrefix(s.Name, "multipart-request-default") {
+						t.Errorf("unexpected section %q", s.Name)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package codegen
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// muxerAdapter describes the code generated for a third party router adapter.
+type muxerAdapter struct {
+	// Import is the router package import.
+	Import *codegen.ImportSpec
+	// Source is the adapter template.
+	Source string
+}
+
+// muxerAdapters lists the adapters indexed by router name.
+var muxerAdapters = map[string]*muxerAdapter{
+	"chi":     {&codegen.ImportSpec{Path: "github.com/go-chi/chi/v5", Name: "chi"}, chiAdapterT},
+	"echo":    {&codegen.ImportSpec{Path: "github.com/labstack/echo/v4", Name: "echo"}, echoAdapterT},
+	"gin":     {&codegen.ImportSpec{Path: "github.com/gin-gonic/gin"}, ginAdapterT},
+	"gorilla": {&codegen.ImportSpec{Path: "github.com/gorilla/mux"}, gorillaAdapterT},
+}
+
+// MuxerAdapterFiles returns the files that implement the Muxer adapters of
+// the third party routers listed in the design, one package per router.
+func MuxerAdapterFiles(root *expr.RootExpr) []*codegen.File {
+	var fw []*codegen.File
+	for _, r := range root.API.HTTP.MuxerAdapters {
+		a, ok := muxerAdapters[r]
+		if !ok {
+			continue
+		}
+		pkg := r + "mux"
+		path := filepath.Join(codegen.Gendir, "http", "muxers", pkg, "muxer.go")
+		title := fmt.Sprintf("%s router adapter", r)
+		sections := []*codegen.SectionTemplate{
+			codegen.Header(title, pkg, []*codegen.ImportSpec{
+				{Path: "net/http"},
+				a.Import,
+				codegen.GoaNamedImport("http", "goahttp"),
+			}),
+			{Name: "muxer-adapter", Source: a.Source},
+		}
+		fw = append(fw, &codegen.File{Path: path, SectionTemplates: sections})
+	}
+	return fw
+}
+
+const chiAdapterT = `// New returns a goa Muxer that registers the handlers of the generated servers
+// with r. Middleware added to r runs before the middleware mounted on the
+// generated servers.
+func New(r chi.Router) goahttp.Muxer {
+	return goahttp.NewMuxAdapter(goahttp.ChiSyntax, r, func(method, pattern string, h goahttp.RouteHandler) {
+		r.Method(method, pattern, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			h(w, req, func(name string) string { return chi.URLParam(req, name) })
+		}))
+	})
+}
+`
+
+const echoAdapterT = `// New returns a goa Muxer that registers the handlers of the generated servers
+// with e. Middleware added to e runs before the middleware mounted on the
+// generated servers.
+func New(e *echo.Echo) goahttp.Muxer {
+	return goahttp.NewMuxAdapter(goahttp.EchoSyntax, e, func(method, pattern string, h goahttp.RouteHandler) {
+		e.Add(method, pattern, func(c echo.Context) error {
+			h(c.Response(), c.Request(), c.Param)
+			return nil
+		})
+	})
+}
+`
+
+const ginAdapterT = `// New returns a goa Muxer that registers the handlers of the generated servers
+// with g. Middleware added to g runs before the middleware mounted on the
+// generated servers.
+func New(g *gin.Engine) goahttp.Muxer {
+	return goahttp.NewMuxAdapter(goahttp.GinSyntax, g, func(method, pattern string, h goahttp.RouteHandler) {
+		g.Handle(method, pattern, func(c *gin.Context) {
+			h(c.Writer, c.Request, c.Param)
+		})
+	})
+}
+`
+
+const gorillaAdapterT = `// New returns a goa Muxer that registers the handlers of the generated servers
+// with r. Middleware added to r runs before the middleware mounted on the
+// generated servers.
+func New(r *mux.Router) goahttp.Muxer {
+	return goahttp.NewMuxAdapter(goahttp.GorillaSyntax, r, func(method, pattern string, h goahttp.RouteHandler) {
+		r.Methods(method).Path(pattern).HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			vars := mux.Vars(req)
+			h(w, req, func(name string) string { return vars[name] })
+		})
+	})
+}
+`
@@ -0,0 +1,63 @@
+package codegen
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+// TestMuxerAdapterFiles also checks that the adapters tested against the actual
+// routers by the testdata/muxers module match the generated code, run "go test"
+// in that directory to run these tests.
+func TestMuxerAdapterFiles(t *testing.T) {
+	RunHTTPDSL(t, testdata.MuxerAdaptersDSL)
+	fs := MuxerAdapterFiles(expr.Root)
+	cases := []struct {
+		Name string
+		Path string
+		Code string
+	}{
+		{"chi", filepath.Join("gen", "http", "muxers", "chimux", "muxer.go"), testdata.ChiMuxerAdapterCode},
+		{"echo", filepath.Join("gen", "http", "muxers", "echomux", "muxer.go"), testdata.EchoMuxerAdapterCode},
+		{"gin", filepath.Join("gen", "http", "muxers", "ginmux", "muxer.go"), testdata.GinMuxerAdapterCode},
+		{"gorilla", filepath.Join("gen", "http", "muxers", "gorillamux", "muxer.go"), testdata.GorillaMuxerAdapterCode},
+	}
+	if len(fs) != len(cases) {
+		t.Fatalf("got %d files, expected %d", len(fs), len(cases))
+	}
+	for i, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			f := fs[i]
+			if f.Path != c.Path {
+				t.Errorf("got path %q, expected %q", f.Path, c.Path)
+			}
+			sections := f.Section("muxer-adapter")
+			if len(sections) != 1 {
+				t.Fatalf("got %d muxer-adapter sections, expected 1", len(sections))
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+			tested, err := ioutil.ReadFile(filepath.Join("testdata", "muxers", c.Name+"mux", "muxer.go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(tested), code) {
+				t.Errorf("the adapter tested in testdata/muxers does not match the generated code")
+			}
+		})
+	}
+}
+
+func TestMuxerAdapterFilesNone(t *testing.T) {
+	RunHTTPDSL(t, testdata.ServerMultiEndpointsDSL)
+	if fs := MuxerAdapterFiles(expr.Root); len(fs) != 0 {
+		t.Errorf("got %d files, expected none", len(fs))
+	}
+}
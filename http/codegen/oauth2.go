@@ -0,0 +1,568 @@
+package codegen
+
+import (
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// oauth2Data contains the data used to render the token endpoint of
+	// an OAuth2 security scheme.
+	oauth2Data struct {
+		// SchemeName is the name of the security scheme.
+		SchemeName string
+		// PkgName is the name of the generated package.
+		PkgName string
+		// TokenPath is the path of the token endpoint.
+		TokenPath string
+		// IssuerName is the name of the example issuer type.
+		IssuerName string
+		// Grants lists the grant types supported by the token endpoint.
+		Grants []*oauth2GrantData
+		// AuthorizationCode is true if the authorization_code grant is
+		// supported.
+		AuthorizationCode bool
+		// Password is true if the password grant is supported.
+		Password bool
+		// ClientCredentials is true if the client_credentials grant is
+		// supported, the token client is generated in this case.
+		ClientCredentials bool
+		// Refresh is true if the refresh_token grant is supported.
+		Refresh bool
+	}
+
+	// oauth2GrantData describes a grant type of the token endpoint.
+	oauth2GrantData struct {
+		// GrantType is the value of the grant_type parameter.
+		GrantType string
+		// MethodName is the name of the Issuer method that handles the
+		// grant.
+		MethodName string
+		// Description describes the grant.
+		Description string
+	}
+)
+
+// OAuth2Files returns the files implementing the token endpoints of the OAuth2
+// security schemes whose token URL is hosted by the API. The token URL is
+// hosted by the API if it is relative or if its host is one of the hosts of the
+// API servers. The generated package implements the token endpoint HTTP
+// handler that decodes the token requests and dispatches them by grant type,
+// as well as a client that retrieves and refreshes tokens with the client
+// credentials grant.
+func OAuth2Files(root *expr.RootExpr) []*codegen.File {
+	var files []*codegen.File
+	for _, data := range oauth2Schemes(root) {
+		files = append(files, oauth2File(data))
+	}
+	return files
+}
+
+// oauth2File returns the file implementing the token endpoint of the given
+// scheme.
+func oauth2File(data *oauth2Data) *codegen.File {
+	fpath := filepath.Join(codegen.Gendir, "http", "oauth2", data.PkgName, "oauth2.go")
+	specs := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "encoding/json"},
+		{Path: "fmt"},
+		{Path: "net/http"},
+		{Path: "net/url"},
+		{Path: "strings"},
+		codegen.GoaNamedImport("http", "goahttp"),
+	}
+	if data.ClientCredentials {
+		specs = append(specs,
+			&codegen.ImportSpec{Path: "sync"},
+			&codegen.ImportSpec{Path: "time"},
+		)
+	}
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(data.SchemeName+" OAuth2 token endpoint", data.PkgName, specs),
+		{
+			Name:   "oauth2-token-server",
+			Source: oauth2ServerT,
+			Data:   data,
+		},
+	}
+	if data.ClientCredentials {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "oauth2-token-client",
+			Source: oauth2ClientT,
+			Data:   data,
+		})
+	}
+	return &codegen.File{Path: fpath, SectionTemplates: sections}
+}
+
+// dummyOAuth2IssuerFiles returns the example implementations of the token
+// issuers of the OAuth2 security schemes whose token URL is hosted by the API.
+func dummyOAuth2IssuerFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var files []*codegen.File
+	for _, data := range oauth2Schemes(root) {
+		fpath := data.PkgName + "_oauth2.go"
+		if _, err := os.Stat(fpath); !os.IsNotExist(err) {
+			continue // file already exists, skip it.
+		}
+		scope := codegen.NewNameScope()
+		for _, svc := range root.Services {
+			if s := HTTPServices.Get(svc.Name); s != nil {
+				scope.Unique(s.Service.PkgName)
+			}
+		}
+		apiPkg := scope.Unique(strings.ToLower(codegen.Goify(root.API.Name, false)), "api")
+		specs := []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "fmt"},
+			{Path: path.Join(genpkg, "http", "oauth2", data.PkgName)},
+		}
+		files = append(files, &codegen.File{
+			Path: fpath,
+			SectionTemplates: []*codegen.SectionTemplate{
+				codegen.Header("", apiPkg, specs),
+				{
+					Name:   "dummy-oauth2-issuer",
+					Source: dummyOAuth2IssuerT,
+					Data:   data,
+				},
+			},
+			SkipExist: true,
+		})
+	}
+	return files
+}
+
+// oauth2Schemes returns the data of the OAuth2 security schemes whose token
+// URL is hosted by the API.
+func oauth2Schemes(root *expr.RootExpr) []*oauth2Data {
+	var (
+		res   []*oauth2Data
+		scope = codegen.NewNameScope()
+	)
+	for _, s := range root.Schemes {
+		if s.Kind != expr.OAuth2Kind {
+			continue
+		}
+		var data *oauth2Data
+		for _, f := range s.Flows {
+			p, ok := hostedPath(root, f.TokenURL)
+			if !ok {
+				continue
+			}
+			if data == nil {
+				data = &oauth2Data{
+					SchemeName: s.SchemeName,
+					PkgName:    scope.Unique(strings.ToLower(codegen.Goify(s.SchemeName, false))),
+					TokenPath:  p,
+					IssuerName: codegen.Goify(s.SchemeName, true) + "Issuer",
+				}
+			}
+			switch f.Kind {
+			case expr.AuthorizationCodeFlowKind:
+				data.AuthorizationCode = true
+			case expr.PasswordFlowKind:
+				data.Password = true
+			case expr.ClientCredentialsFlowKind:
+				data.ClientCredentials = true
+			}
+			if _, ok := hostedPath(root, f.RefreshURL); ok {
+				data.Refresh = true
+			}
+		}
+		if data == nil {
+			continue
+		}
+		if data.AuthorizationCode {
+			data.Grants = append(data.Grants, &oauth2GrantData{"authorization_code", "AuthorizationCode", "exchanges an authorization code for an access token (RFC 6749 section 4.1.3)"})
+		}
+		if data.Password {
+			data.Grants = append(data.Grants, &oauth2GrantData{"password", "Password", "issues an access token for the resource owner credentials (RFC 6749 section 4.3.2)"})
+		}
+		if data.ClientCredentials {
+			data.Grants = append(data.Grants, &oauth2GrantData{"client_credentials", "ClientCredentials", "issues an access token for the client credentials (RFC 6749 section 4.4.2)"})
+		}
+		if data.Refresh {
+			data.Grants = append(data.Grants, &oauth2GrantData{"refresh_token", "RefreshToken", "issues a new access token for a refresh token (RFC 6749 section 6)"})
+		}
+		if len(data.Grants) == 0 {
+			continue // implicit flow only, there is no token request
+		}
+		res = append(res, data)
+	}
+	return res
+}
+
+// hostedPath returns the path of the given URL and true if the URL is hosted by
+// the API, that is if it is relative or if its host is the host of one of the
+// API server URIs.
+func hostedPath(root *expr.RootExpr, u string) (string, bool) {
+	if u == "" {
+		return "", false
+	}
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return "", false
+	}
+	p := parsed.Path
+	if p == "" {
+		p = "/"
+	}
+	if parsed.Host == "" {
+		return p, parsed.Scheme == ""
+	}
+	for _, svr := range root.API.Servers {
+		for _, h := range svr.Hosts {
+			for _, uri := range h.URIs {
+				hu, err := url.Parse(string(uri))
+				if err == nil && strings.EqualFold(hu.Host, parsed.Host) {
+					return p, true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+// input: oauth2Data
+const oauth2ServerT = `{{ printf "TokenPath is the path of the %q security scheme token endpoint." .SchemeName | comment }}
+const TokenPath = {{ printf "%q" .TokenPath }}
+
+type (
+	// TokenRequest is an access token request, see RFC 6749 section 4.
+	TokenRequest struct {
+		// GrantType is the value of the grant_type parameter.
+		GrantType string
+		// ClientID is the client identifier read from the basic
+		// authorization header or from the client_id parameter.
+		ClientID string
+		// ClientSecret is the client secret read from the basic
+		// authorization header or from the client_secret parameter.
+		ClientSecret string
+		// Scopes lists the requested scopes.
+		Scopes []string
+	{{- if .AuthorizationCode }}
+		// Code is the authorization code of authorization_code requests.
+		Code string
+		// RedirectURI is the redirect URI of authorization_code requests.
+		RedirectURI string
+		// CodeVerifier is the PKCE code verifier of authorization_code
+		// requests if any.
+		CodeVerifier string
+	{{- end }}
+	{{- if .Password }}
+		// Username is the resource owner username of password requests.
+		Username string
+		// Password is the resource owner password of password requests.
+		Password string
+	{{- end }}
+	{{- if .Refresh }}
+		// RefreshToken is the refresh token of refresh_token requests.
+		RefreshToken string
+	{{- end }}
+	}
+
+	// TokenResponse is a successful access token response, see RFC 6749
+	// section 5.1.
+	TokenResponse struct {
+		// AccessToken is the issued access token.
+		AccessToken string ` + "`" + `json:"access_token"` + "`" + `
+		// TokenType is the type of the token, "Bearer" if empty.
+		TokenType string ` + "`" + `json:"token_type"` + "`" + `
+		// ExpiresIn is the lifetime of the access token in seconds.
+		ExpiresIn int ` + "`" + `json:"expires_in,omitempty"` + "`" + `
+		// RefreshToken is the refresh token if any.
+		RefreshToken string ` + "`" + `json:"refresh_token,omitempty"` + "`" + `
+		// Scope lists the granted scopes separated with spaces if they
+		// differ from the requested scopes.
+		Scope string ` + "`" + `json:"scope,omitempty"` + "`" + `
+	}
+
+	// TokenError is an access token error response, see RFC 6749 section
+	// 5.2.
+	TokenError struct {
+		// Code is the error code, e.g. "invalid_grant".
+		Code string ` + "`" + `json:"error"` + "`" + `
+		// Description describes the error.
+		Description string ` + "`" + `json:"error_description,omitempty"` + "`" + `
+	}
+
+	{{ printf "Issuer issues the access tokens of the %q security scheme." .SchemeName | comment }}
+	Issuer interface {
+	{{- range .Grants }}
+		{{ printf "%s %s." .MethodName .Description | comment }}
+		{{ .MethodName }}(ctx context.Context, req *TokenRequest) (*TokenResponse, error)
+	{{- end }}
+	}
+)
+
+// NewTokenError returns a token error with the given code and description.
+func NewTokenError(code, description string) *TokenError {
+	return &TokenError{Code: code, Description: description}
+}
+
+// Error returns the error code and description.
+func (e *TokenError) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
+
+// NewTokenHandler returns the HTTP handler of the token endpoint. The handler
+// decodes the form encoded token requests and dispatches them to the issuer
+// according to their grant type. The issuer should return a *TokenError to
+// reject a request, other errors produce "server_error" responses.
+func NewTokenHandler(issuer Issuer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			w.Header().Set("Allow", "POST")
+			writeTokenJSON(w, http.StatusMethodNotAllowed, NewTokenError("invalid_request", "token requests must use POST"))
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			writeTokenJSON(w, http.StatusBadRequest, NewTokenError("invalid_request", err.Error()))
+			return
+		}
+		req := &TokenRequest{
+			GrantType:    r.PostForm.Get("grant_type"),
+			ClientID:     r.PostForm.Get("client_id"),
+			ClientSecret: r.PostForm.Get("client_secret"),
+			Scopes:       strings.Fields(r.PostForm.Get("scope")),
+		{{- if .AuthorizationCode }}
+			Code:         r.PostForm.Get("code"),
+			RedirectURI:  r.PostForm.Get("redirect_uri"),
+			CodeVerifier: r.PostForm.Get("code_verifier"),
+		{{- end }}
+		{{- if .Password }}
+			Username:     r.PostForm.Get("username"),
+			Password:     r.PostForm.Get("password"),
+		{{- end }}
+		{{- if .Refresh }}
+			RefreshToken: r.PostForm.Get("refresh_token"),
+		{{- end }}
+		}
+		if id, secret, ok := r.BasicAuth(); ok {
+			// The client credentials are form encoded, see RFC 6749
+			// section 2.3.1.
+			var err1, err2 error
+			req.ClientID, err1 = url.QueryUnescape(id)
+			req.ClientSecret, err2 = url.QueryUnescape(secret)
+			if err1 != nil || err2 != nil {
+				writeTokenJSON(w, http.StatusUnauthorized, NewTokenError("invalid_client", "invalid client credentials encoding"))
+				return
+			}
+		}
+		var (
+			res *TokenResponse
+			err error
+		)
+		switch req.GrantType {
+	{{- range .Grants }}
+		case {{ printf "%q" .GrantType }}:
+			res, err = issuer.{{ .MethodName }}(r.Context(), req)
+	{{- end }}
+		case "":
+			err = NewTokenError("invalid_request", "missing grant_type parameter")
+		default:
+			err = NewTokenError("unsupported_grant_type", fmt.Sprintf("unsupported grant type %q", req.GrantType))
+		}
+		if err != nil {
+			terr, ok := err.(*TokenError)
+			if !ok {
+				writeTokenJSON(w, http.StatusInternalServerError, NewTokenError("server_error", ""))
+				return
+			}
+			status := http.StatusBadRequest
+			if terr.Code == "invalid_client" {
+				status = http.StatusUnauthorized
+			}
+			writeTokenJSON(w, status, terr)
+			return
+		}
+		if res.TokenType == "" {
+			res.TokenType = "Bearer"
+		}
+		writeTokenJSON(w, http.StatusOK, res)
+	})
+}
+
+// Mount configures the mux to serve the token endpoint with the given handler.
+func Mount(mux goahttp.Muxer, h http.Handler) {
+	mux.Handle("POST", TokenPath, h.ServeHTTP)
+}
+
+// writeTokenJSON writes a token endpoint response, the responses must not be
+// cached (RFC 6749 section 5.1).
+func writeTokenJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+`
+
+// input: oauth2Data
+const oauth2ClientT = `// expiryDelta is the duration before the expiration of the access tokens at
+// which Client retrieves new tokens.
+const expiryDelta = 10 * time.Second
+
+type (
+	// Client retrieves access tokens from the token endpoint with the client
+	// credentials grant and caches them until they expire.
+	Client struct {
+		// URL is the URL of the token endpoint.
+		URL string
+		// ClientID is the client identifier.
+		ClientID string
+		// ClientSecret is the client secret.
+		ClientSecret string
+		// Scopes lists the requested scopes.
+		Scopes []string
+		// Doer is the HTTP client used to send the token requests.
+		Doer goahttp.Doer
+
+		mu     sync.Mutex
+		token  *TokenResponse
+		expiry time.Time
+	}
+
+	// tokenDoer is a Doer that sets the access token of the requests.
+	tokenDoer struct {
+		client *Client
+		doer   goahttp.Doer
+	}
+)
+
+// NewClient returns a client that retrieves access tokens from the token
+// endpoint of the API served at the given scheme and host.
+func NewClient(scheme, host string, doer goahttp.Doer, clientID, clientSecret string, scopes ...string) *Client {
+	u := &url.URL{Scheme: scheme, Host: host, Path: TokenPath}
+	return &Client{
+		URL:          u.String(),
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scopes:       scopes,
+		Doer:         doer,
+	}
+}
+
+// Token returns the cached access token or retrieves a new one if the token
+// expired{{ if .Refresh }}, using the refresh token if any{{ end }}.
+func (c *Client) Token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token != nil && (c.expiry.IsZero() || time.Now().Before(c.expiry)) {
+		return c.token.AccessToken, nil
+	}
+	var (
+		tok *TokenResponse
+		err error
+	)
+{{- if .Refresh }}
+	if c.token != nil && c.token.RefreshToken != "" {
+		tok, err = c.request(ctx, url.Values{"grant_type": {"refresh_token"}, "refresh_token": {c.token.RefreshToken}})
+	}
+	if tok == nil {
+		// No refresh token or the refresh failed, use the client
+		// credentials.
+		tok, err = c.request(ctx, url.Values{"grant_type": {"client_credentials"}})
+	}
+{{- else }}
+	tok, err = c.request(ctx, url.Values{"grant_type": {"client_credentials"}})
+{{- end }}
+	if err != nil {
+		c.token = nil
+		return "", err
+	}
+	c.token = tok
+	c.expiry = time.Time{}
+	if exp := time.Duration(tok.ExpiresIn) * time.Second; exp > 0 {
+		if exp > 2*expiryDelta {
+			exp -= expiryDelta
+		}
+		c.expiry = time.Now().Add(exp)
+	}
+	return tok.AccessToken, nil
+}
+
+// Wrap returns a Doer that sets the Authorization header of the requests that
+// do not define an access token with a bearer token retrieved with Token. The
+// service clients created with the returned Doer retrieve and refresh the
+// access tokens automatically.
+func (c *Client) Wrap(doer goahttp.Doer) goahttp.Doer {
+	return &tokenDoer{client: c, doer: doer}
+}
+
+// Do sets the access token if needed and sends the request.
+func (d *tokenDoer) Do(req *http.Request) (*http.Response, error) {
+	if strings.TrimSpace(strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer")) == "" {
+		tok, err := d.client.Token(req.Context())
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+tok)
+	}
+	return d.doer.Do(req)
+}
+
+// request sends a token request with the given parameters.
+func (c *Client) request(ctx context.Context, form url.Values) (*TokenResponse, error) {
+	if len(c.Scopes) > 0 {
+		form.Set("scope", strings.Join(c.Scopes, " "))
+	}
+	req, err := http.NewRequest("POST", c.URL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(c.ClientID), url.QueryEscape(c.ClientSecret))
+	resp, err := c.Doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var terr TokenError
+		if err := json.NewDecoder(resp.Body).Decode(&terr); err != nil || terr.Code == "" {
+			return nil, fmt.Errorf("token request failed: %s", resp.Status)
+		}
+		return nil, &terr
+	}
+	var tok TokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tok); err != nil {
+		return nil, fmt.Errorf("invalid token response: %s", err)
+	}
+	if tok.AccessToken == "" {
+		return nil, fmt.Errorf("invalid token response: missing access token")
+	}
+	return &tok, nil
+}
+`
+
+// input: oauth2Data
+const dummyOAuth2IssuerT = `{{ printf "%s implements the token endpoint of the %q security scheme, mount it in the HTTP server with:" .IssuerName .SchemeName | comment }}
+//
+//    {{ .PkgName }}.Mount(mux, {{ .PkgName }}.NewTokenHandler(&{{ .IssuerName }}{}))
+type {{ .IssuerName }} struct{}
+{{ range .Grants }}
+{{ printf "%s %s." .MethodName .Description | comment }}
+func (i *{{ $.IssuerName }}) {{ .MethodName }}(ctx context.Context, req *{{ $.PkgName }}.TokenRequest) (*{{ $.PkgName }}.TokenResponse, error) {
+	//
+	// TBD: validate the request and issue the access token.
+	//
+	// Invalid requests should return a token error, e.g.:
+	//
+	//    return nil, {{ $.PkgName }}.NewTokenError("invalid_grant", "invalid credentials")
+	//
+	return nil, fmt.Errorf("not implemented")
+}
+{{ end }}`
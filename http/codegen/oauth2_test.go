@@ -0,0 +1,91 @@
+package codegen
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestOAuth2Files(t *testing.T) {
+	RunHTTPDSL(t, testdata.OAuth2ClientCredentialsDSL)
+	fs := OAuth2Files(expr.Root)
+	if len(fs) != 1 {
+		t.Fatalf("got %d files, expected 1", len(fs))
+	}
+	if p := filepath.Join("gen", "http", "oauth2", "oauth2", "oauth2.go"); fs[0].Path != p {
+		t.Errorf("got path %q, expected %q", fs[0].Path, p)
+	}
+	cases := []struct {
+		Section string
+		Code    string
+	}{
+		{"oauth2-token-server", testdata.OAuth2TokenServerCode},
+		{"oauth2-token-client", testdata.OAuth2TokenClientCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Section, func(t *testing.T) {
+			sections := fs[0].Section(c.Section)
+			if len(sections) != 1 {
+				t.Fatalf("got %d %s sections, expected 1", len(sections), c.Section)
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestOAuth2Schemes(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Path     string
+		Grants   []string
+		Client   bool
+		Expected int
+	}{
+		{"client-credentials", testdata.OAuth2ClientCredentialsDSL, "/oauth/token", []string{"client_credentials", "refresh_token"}, true, 1},
+		{"hosted-flows", testdata.OAuth2HostedFlowsDSL, "/token", []string{"authorization_code", "password"}, false, 1},
+		{"external", testdata.OAuth2ExternalDSL, "", nil, false, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			data := oauth2Schemes(expr.Root)
+			if len(data) != c.Expected {
+				t.Fatalf("got %d schemes, expected %d", len(data), c.Expected)
+			}
+			if c.Expected == 0 {
+				return
+			}
+			d := data[0]
+			if d.TokenPath != c.Path {
+				t.Errorf("got token path %q, expected %q", d.TokenPath, c.Path)
+			}
+			var grants []string
+			for _, g := range d.Grants {
+				grants = append(grants, g.GrantType)
+			}
+			if !reflect.DeepEqual(grants, c.Grants) {
+				t.Errorf("got grants %v, expected %v", grants, c.Grants)
+			}
+			if d.ClientCredentials != c.Client {
+				t.Errorf("got client %v, expected %v", d.ClientCredentials, c.Client)
+			}
+			fs := dummyOAuth2IssuerFiles("goa.design/goa/example", expr.Root)
+			if len(fs) != 1 {
+				t.Fatalf("got %d example files, expected 1", len(fs))
+			}
+			if sections := fs[0].Section("dummy-oauth2-issuer"); len(sections) != 1 {
+				t.Errorf("got %d dummy-oauth2-issuer sections, expected 1", len(sections))
+			} else {
+				codegen.SectionCode(t, sections[0])
+			}
+		})
+	}
+}
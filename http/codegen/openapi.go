@@ -2,48 +2,135 @@ package codegen
 
 import (
 	"encoding/json"
+	"fmt"
 	"path/filepath"
+	"sort"
+	"strings"
 	"text/template"
 
 	"gopkg.in/yaml.v2"
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/asyncapi"
 	"goa.design/goa/v3/http/codegen/openapi"
 )
 
 // OpenAPIFiles returns the files for the OpenAPIFile spec of the given HTTP API.
+// The OpenAPI 3.1 specification is also generated in the openapi3.json and
+// openapi3.yaml files if the API defines the "openapi:version" meta with value
+// "3.1". The OpenAPI 2.0 specification is also split into one file per service
+// plus the shared definitions in the openapi directory if the API defines the
+// "openapi:split" meta. The AsyncAPI document describing the streaming
+// endpoints and the events is generated in the asyncapi.json and asyncapi.yaml
+// files if the API defines such endpoints or events. The docs package that serves the OpenAPI
+// 3.1 specification and the documentation UI is generated if the API defines
+// the "openapi:docs" meta. The OpenAPI 2.0 specification is checked against the
+// lint rules (see openapi.Lint) if the API defines the "openapi:lint" meta, the
+// generation fails if a rule with severity "error" is violated. The Spectral
+// ruleset that checks the same rules is then generated in the .spectral.yaml
+// file.
 func OpenAPIFiles(root *expr.RootExpr) ([]*codegen.File, error) {
 	// Only create a OpenAPI specification if there are HTTP services.
 	if len(root.API.HTTP.Services) == 0 {
 		return nil, nil
 	}
 
-	jsonPath := filepath.Join(codegen.Gendir, "http", "openapi.json")
-	yamlPath := filepath.Join(codegen.Gendir, "http", "openapi.yaml")
-	var (
-		jsonSection *codegen.SectionTemplate
-		yamlSection *codegen.SectionTemplate
-	)
-	{
-		spec, err := openapi.NewV2(root, root.API.Servers[0].Hosts[0])
+	var v3 bool
+	if v, ok := root.API.Meta.Last("openapi:version"); ok {
+		switch v {
+		case "2", "2.0":
+		case "3.1", "3.1.0":
+			v3 = true
+		default:
+			return nil, fmt.Errorf("unsupported OpenAPI version %q, must be one of 2.0 or 3.1", v)
+		}
+	}
+
+	spec, err := openapi.NewV2(root, root.API.Servers[0].Hosts[0])
+	if err != nil {
+		return nil, err
+	}
+	files := openAPIFiles("openapi", spec)
+	if _, ok := root.API.Meta["openapi:lint"]; ok {
+		f, err := lintOpenAPI(root, spec)
 		if err != nil {
 			return nil, err
 		}
-		jsonSection = &codegen.SectionTemplate{
-			Name:    "openapi",
-			FuncMap: template.FuncMap{"toJSON": toJSON},
-			Source:  "{{ toJSON .}}",
-			Data:    spec,
+		files = append(files, f)
+	}
+	if doc := asyncapi.New(root); doc != nil {
+		files = append(files, openAPIFiles("asyncapi", doc)...)
+	}
+	if _, ok := root.API.Meta["openapi:split"]; ok {
+		split, err := openAPISplitFiles(root, spec)
+		if err != nil {
+			return nil, err
 		}
-		yamlSection = &codegen.SectionTemplate{
-			Name:    "openapi",
-			FuncMap: template.FuncMap{"toYAML": toYAML},
-			Source:  "{{ toYAML .}}",
-			Data:    spec,
+		files = append(files, split...)
+	}
+	_, docs := root.API.Meta["openapi:docs"]
+	if v3 || docs {
+		spec, err := openapi.NewV3(root, root.API.Servers[0].Hosts[0])
+		if err != nil {
+			return nil, err
+		}
+		if v3 {
+			files = append(files, openAPIFiles("openapi3", spec)...)
+		}
+		if docs {
+			// The documentation UIs all support OpenAPI 3.1.
+			files = append(files, docsFile(root, spec))
 		}
 	}
+	return files, nil
+}
 
+// lintOpenAPI checks the given specification against the lint rules and
+// returns the file containing the corresponding Spectral ruleset. It returns
+// an error listing the violations of the rules with severity "error" if any.
+func lintOpenAPI(root *expr.RootExpr, spec *openapi.V2) (*codegen.File, error) {
+	if err := openapi.ValidateRuleSeverities(root.API); err != nil {
+		return nil, err
+	}
+	severities := openapi.RuleSeverities(root.API)
+	var msgs []string
+	for _, v := range openapi.Lint(root, spec, severities) {
+		if v.Severity == openapi.SeverityError {
+			msgs = append(msgs, v.String())
+		}
+	}
+	if len(msgs) > 0 {
+		return nil, fmt.Errorf("OpenAPI specification does not pass lint rules:\n%s", strings.Join(msgs, "\n"))
+	}
+	return &codegen.File{
+		Path: filepath.Join(codegen.Gendir, "http", ".spectral.yaml"),
+		SectionTemplates: []*codegen.SectionTemplate{{
+			Name:    "spectral-ruleset",
+			FuncMap: template.FuncMap{"toYAML": toYAML},
+			Source:  "{{ toYAML .}}",
+			Data:    openapi.NewRuleset(severities),
+		}},
+	}, nil
+}
+
+// openAPIFiles returns the JSON and YAML files with the given name that
+// contain the given specification.
+func openAPIFiles(name string, spec interface{}) []*codegen.File {
+	jsonPath := filepath.Join(codegen.Gendir, "http", name+".json")
+	yamlPath := filepath.Join(codegen.Gendir, "http", name+".yaml")
+	jsonSection := &codegen.SectionTemplate{
+		Name:    "openapi",
+		FuncMap: template.FuncMap{"toJSON": toJSON},
+		Source:  "{{ toJSON .}}",
+		Data:    spec,
+	}
+	yamlSection := &codegen.SectionTemplate{
+		Name:    "openapi",
+		FuncMap: template.FuncMap{"toYAML": toYAML},
+		Source:  "{{ toYAML .}}",
+		Data:    spec,
+	}
 	return []*codegen.File{
 		{
 			Path:             jsonPath,
@@ -53,7 +140,41 @@ func OpenAPIFiles(root *expr.RootExpr) ([]*codegen.File, error) {
 			Path:             yamlPath,
 			SectionTemplates: []*codegen.SectionTemplate{yamlSection},
 		},
-	}, nil
+	}
+}
+
+// openAPISplitFiles returns the JSON and YAML files of the split OpenAPI 2.0
+// specification.
+func openAPISplitFiles(root *expr.RootExpr, spec *openapi.V2) ([]*codegen.File, error) {
+	var files []*codegen.File
+	for _, ext := range []string{".json", ".yaml"} {
+		docs, err := openapi.SplitV2(root, spec, ext)
+		if err != nil {
+			return nil, err
+		}
+		paths := make([]string, 0, len(docs))
+		for p := range docs {
+			paths = append(paths, p)
+		}
+		sort.Strings(paths)
+		funcs := template.FuncMap{"toJSON": toJSON, "toYAML": toYAML}
+		source := "{{ toJSON .}}"
+		if ext == ".yaml" {
+			source = "{{ toYAML .}}"
+		}
+		for _, p := range paths {
+			files = append(files, &codegen.File{
+				Path: filepath.Join(codegen.Gendir, "http", "openapi", filepath.FromSlash(p)),
+				SectionTemplates: []*codegen.SectionTemplate{{
+					Name:    "openapi",
+					FuncMap: funcs,
+					Source:  source,
+					Data:    docs[p],
+				}},
+			})
+		}
+	}
+	return files, nil
 }
 
 func toJSON(d interface{}) string {
@@ -39,16 +39,34 @@ type (
 		Pattern              string        `json:"pattern,omitempty" yaml:"pattern,omitempty"`
 		Minimum              *float64      `json:"minimum,omitempty" yaml:"minimum,omitempty"`
 		Maximum              *float64      `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+		ExclusiveMinimum     bool          `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"`
+		ExclusiveMaximum     bool          `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
+		MultipleOf           *float64      `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
 		MinLength            *int          `json:"minLength,omitempty" yaml:"minLength,omitempty"`
 		MaxLength            *int          `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
 		MinItems             *int          `json:"minItems,omitempty" yaml:"minItems,omitempty"`
 		MaxItems             *int          `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+		UniqueItems          bool          `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
 		Required             []string      `json:"required,omitempty" yaml:"required,omitempty"`
 		AdditionalProperties bool          `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
 
 		// Union
 		AnyOf []*Schema `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
 
+		// Nullable is true if the value may be null. It is set for the
+		// attributes that define the "openapi:nullable" meta and only
+		// used by the OpenAPI 3.1 specification.
+		Nullable bool `json:"-" yaml:"-"`
+
+		// MaxBytes, UniqueBy and ContentMediaType describe validations
+		// that JSON schema draft 4 does not define. They are rendered
+		// as the "x-maxBytes", "x-uniqueBy" and "x-contentMediaType"
+		// extensions, the OpenAPI 3.1 specification uses the
+		// "contentMediaType" keyword instead of the last extension.
+		MaxBytes         *int   `json:"-" yaml:"-"`
+		UniqueBy         string `json:"-" yaml:"-"`
+		ContentMediaType string `json:"-" yaml:"-"`
+
 		// Extensions defines the swagger extensions.
 		Extensions map[string]interface{} `json:"-" yaml:"-"`
 	}
@@ -288,7 +306,7 @@ func GenerateTypeDefinitionWithName(api *expr.APIExpr, ut *expr.UserTypeExpr, ty
 
 	s.Title = typeName
 	Definitions[typeName] = s
-	buildAttributeSchema(api, s, ut.AttributeExpr)
+	buildAttributeSchema(api, api.Random().Derive(typeName), s, ut.AttributeExpr)
 }
 
 // TypeSchema produces the JSON schema corresponding to the given data type.
@@ -299,6 +317,12 @@ func TypeSchema(api *expr.APIExpr, t expr.DataType) *Schema {
 // TypeSchemaWithPrefix produces the JSON schema corresponding to the given data type
 // and adds the provided prefix to the type name
 func TypeSchemaWithPrefix(api *expr.APIExpr, t expr.DataType, prefix string) *Schema {
+	return typeSchema(api, api.Random(), t, prefix)
+}
+
+// typeSchema produces the JSON schema corresponding to the given data type
+// using r to generate the examples.
+func typeSchema(api *expr.APIExpr, r *expr.Random, t expr.DataType, prefix string) *Schema {
 	s := NewSchema()
 	switch actual := t.(type) {
 	case expr.Primitive:
@@ -326,12 +350,12 @@ func TypeSchemaWithPrefix(api *expr.APIExpr, t expr.DataType, prefix string) *Sc
 	case *expr.Array:
 		s.Type = Array
 		s.Items = NewSchema()
-		buildAttributeSchema(api, s.Items, actual.ElemType)
+		buildAttributeSchema(api, r, s.Items, actual.ElemType)
 	case *expr.Object:
 		s.Type = Object
 		for _, nat := range *actual {
 			prop := NewSchema()
-			buildAttributeSchema(api, prop, nat.Attribute)
+			buildAttributeSchema(api, r, prop, nat.Attribute)
 			s.Properties[nat.Name] = prop
 		}
 	case *expr.Map:
@@ -373,10 +397,17 @@ func (s *Schema) createMergeItems(other *Schema) mergeItems {
 		{&s.AdditionalProperties, other.AdditionalProperties, !s.AdditionalProperties},
 		{&s.Minimum, other.Minimum, minFloat64(s.Minimum, other.Minimum)},
 		{&s.Maximum, other.Maximum, maxFloat64(s.Maximum, other.Maximum)},
+		{&s.ExclusiveMinimum, other.ExclusiveMinimum, minFloat64(s.Minimum, other.Minimum)},
+		{&s.ExclusiveMaximum, other.ExclusiveMaximum, maxFloat64(s.Maximum, other.Maximum)},
+		{&s.MultipleOf, other.MultipleOf, s.MultipleOf == nil},
 		{&s.MinLength, other.MinLength, minInt(s.MinLength, other.MinLength)},
 		{&s.MaxLength, other.MaxLength, maxInt(s.MaxLength, other.MaxLength)},
 		{&s.MinItems, other.MinItems, minInt(s.MinItems, other.MinItems)},
 		{&s.MaxItems, other.MaxItems, maxInt(s.MaxItems, other.MaxItems)},
+		{&s.UniqueItems, other.UniqueItems, !s.UniqueItems},
+		{&s.MaxBytes, other.MaxBytes, maxInt(s.MaxBytes, other.MaxBytes)},
+		{&s.UniqueBy, other.UniqueBy, s.UniqueBy == ""},
+		{&s.ContentMediaType, other.ContentMediaType, s.ContentMediaType == ""},
 	}
 }
 
@@ -427,12 +458,20 @@ func (s *Schema) Dup() *Schema {
 		Pattern:              s.Pattern,
 		Minimum:              s.Minimum,
 		Maximum:              s.Maximum,
+		ExclusiveMinimum:     s.ExclusiveMinimum,
+		ExclusiveMaximum:     s.ExclusiveMaximum,
+		MultipleOf:           s.MultipleOf,
 		MinLength:            s.MinLength,
 		MaxLength:            s.MaxLength,
 		MinItems:             s.MinItems,
 		MaxItems:             s.MaxItems,
+		UniqueItems:          s.UniqueItems,
+		MaxBytes:             s.MaxBytes,
+		UniqueBy:             s.UniqueBy,
+		ContentMediaType:     s.ContentMediaType,
 		Required:             s.Required,
 		AdditionalProperties: s.AdditionalProperties,
+		Nullable:             s.Nullable,
 	}
 	for n, p := range s.Properties {
 		js.Properties[n] = p.Dup()
@@ -447,17 +486,18 @@ func (s *Schema) Dup() *Schema {
 }
 
 // buildAttributeSchema initializes the given JSON schema that corresponds to
-// the given attribute.
-func buildAttributeSchema(api *expr.APIExpr, s *Schema, at *expr.AttributeExpr) *Schema {
-	s.Merge(TypeSchema(api, at.Type))
+// the given attribute using r to generate the examples.
+func buildAttributeSchema(api *expr.APIExpr, r *expr.Random, s *Schema, at *expr.AttributeExpr) *Schema {
+	s.Merge(typeSchema(api, r, at.Type, ""))
+	_, s.Nullable = at.Meta["openapi:nullable"]
 	if s.Ref != "" {
 		// Ref is exclusive with other fields
 		return s
 	}
 	s.DefaultValue = toStringMap(at.DefaultValue)
 	s.Description = at.Description
-	s.Example = at.Example(api.Random())
-	s.Extensions = ExtensionsFromExpr(at.Meta)
+	s.Example = at.Example(r)
+	s.Extensions = decodingExtensions(ExtensionsFromExpr(at.Meta), at)
 	initAttributeValidation(s, at)
 
 	return s
@@ -478,6 +518,15 @@ func initAttributeValidation(s *Schema, at *expr.AttributeExpr) {
 	if val.Maximum != nil {
 		s.Maximum = val.Maximum
 	}
+	if min := val.ExclusiveMinimum; min != nil && (s.Minimum == nil || *min >= *s.Minimum) {
+		s.Minimum = min
+		s.ExclusiveMinimum = true
+	}
+	if max := val.ExclusiveMaximum; max != nil && (s.Maximum == nil || *max <= *s.Maximum) {
+		s.Maximum = max
+		s.ExclusiveMaximum = true
+	}
+	s.MultipleOf = val.MultipleOf
 	if val.MinLength != nil {
 		if _, ok := at.Type.(*expr.Array); ok {
 			s.MinItems = val.MinLength
@@ -492,6 +541,12 @@ func initAttributeValidation(s *Schema, at *expr.AttributeExpr) {
 			s.MaxLength = val.MaxLength
 		}
 	}
+	s.MaxBytes = val.MaxBytes
+	if val.UniqueBy != "" {
+		s.UniqueItems = true
+		s.UniqueBy = val.UniqueBy
+	}
+	s.ContentMediaType = val.ContentMediaType
 	s.Required = val.Required
 }
 
@@ -505,6 +560,7 @@ func AttributeTypeSchema(api *expr.APIExpr, at *expr.AttributeExpr) *Schema {
 func AttributeTypeSchemaWithPrefix(api *expr.APIExpr, at *expr.AttributeExpr, prefix string) *Schema {
 	s := TypeSchemaWithPrefix(api, at.Type, prefix)
 	initAttributeValidation(s, at)
+	s.Extensions = decodingExtensions(ExtensionsFromExpr(at.Meta), at)
 	return s
 }
 
@@ -585,15 +641,38 @@ func buildResultTypeSchema(api *expr.APIExpr, mt *expr.ResultTypeExpr, view stri
 	if err != nil {
 		panic(fmt.Sprintf("failed to project media type %#v: %s", mt.Identifier, err)) // bug
 	}
-	buildAttributeSchema(api, s, projected.AttributeExpr)
+	buildAttributeSchema(api, api.Random().Derive(mt.TypeName), s, projected.AttributeExpr)
 }
 
 // MarshalJSON returns the JSON encoding of s.
 func (s *Schema) MarshalJSON() ([]byte, error) {
-	return marshalJSON((*_Schema)(s), s.Extensions)
+	return marshalJSON((*_Schema)(s), s.validationExtensions(true))
 }
 
 // MarshalYAML returns value which marshaled in place of the original value
 func (s *Schema) MarshalYAML() (interface{}, error) {
-	return marshalYAML((*_Schema)(s), s.Extensions)
+	return marshalYAML((*_Schema)(s), s.validationExtensions(true))
+}
+
+// validationExtensions returns the schema extensions completed with the
+// extensions that describe the MaxBytes, UniqueBy and, if mediaType is true,
+// ContentMediaType validations.
+func (s *Schema) validationExtensions(mediaType bool) map[string]interface{} {
+	if s.MaxBytes == nil && s.UniqueBy == "" && (!mediaType || s.ContentMediaType == "") {
+		return s.Extensions
+	}
+	ext := make(map[string]interface{}, len(s.Extensions)+3)
+	for k, v := range s.Extensions {
+		ext[k] = v
+	}
+	if s.MaxBytes != nil {
+		ext["x-maxBytes"] = *s.MaxBytes
+	}
+	if s.UniqueBy != "" {
+		ext["x-uniqueBy"] = s.UniqueBy
+	}
+	if mediaType && s.ContentMediaType != "" {
+		ext["x-contentMediaType"] = s.ContentMediaType
+	}
+	return ext
 }
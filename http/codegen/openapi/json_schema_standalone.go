@@ -0,0 +1,86 @@
+package openapi
+
+import (
+	"strings"
+
+	"goa.design/goa/v3/expr"
+)
+
+// StandaloneSchemas returns the JSON schemas using the 2020-12 dialect of the
+// user and result types of the design indexed by type name. The schemas are
+// standalone: each schema defines the types it references in "$defs". Result
+// types are described using their default view. Types that define the
+// "swagger:generate" meta with value "false" are skipped.
+func StandaloneSchemas(root *expr.RootExpr) map[string]*Schema3 {
+	// Use a separate set of definitions so that the ones used by the
+	// OpenAPI specifications are left untouched.
+	defs := Definitions
+	Definitions = make(map[string]*Schema)
+	defer func() { Definitions = defs }()
+
+	schemas := make(map[string]*Schema3)
+	for _, ut := range append(append([]expr.UserType{}, root.Types...), root.ResultTypes...) {
+		if !mustGenerate(ut.Attribute().Meta) {
+			continue
+		}
+		ref := TypeSchema(root.API, ut).Ref
+		if !strings.HasPrefix(ref, "#/definitions/") {
+			continue
+		}
+		name := strings.TrimPrefix(ref, "#/definitions/")
+		if _, ok := schemas[name]; ok {
+			continue
+		}
+		schemas[name] = standaloneSchema(name)
+	}
+	return schemas
+}
+
+// standaloneSchema returns the standalone schema of the definition with the
+// given name.
+func standaloneSchema(name string) *Schema3 {
+	var (
+		refs = []string{name}
+		seen = map[string]bool{name: true}
+	)
+	var resolve func(*Schema3)
+	resolve = func(s *Schema3) {
+		if s == nil {
+			return
+		}
+		if strings.HasPrefix(s.Ref, "#/components/schemas/") {
+			n := strings.TrimPrefix(s.Ref, "#/components/schemas/")
+			if n == name {
+				s.Ref = "#"
+			} else {
+				s.Ref = "#/$defs/" + n
+			}
+			if !seen[n] {
+				seen[n] = true
+				refs = append(refs, n)
+			}
+		}
+		resolve(s.Items)
+		for _, p := range s.Properties {
+			resolve(p)
+		}
+		for _, a := range s.AnyOf {
+			resolve(a)
+		}
+	}
+	res := schemaFromV2(Definitions[name])
+	resolve(res)
+	for i := 1; i < len(refs); i++ {
+		d := schemaFromV2(Definitions[refs[i]])
+		if d == nil {
+			continue
+		}
+		resolve(d)
+		if res.Defs == nil {
+			res.Defs = make(map[string]*Schema3)
+		}
+		res.Defs[refs[i]] = d
+	}
+	res.Schema = JSONSchemaDialect
+	return res
+}
@@ -0,0 +1,405 @@
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Lint severities.
+const (
+	// SeverityError causes the generation to fail.
+	SeverityError Severity = "error"
+	// SeverityWarn is reported by external linters only.
+	SeverityWarn Severity = "warn"
+	// SeverityOff disables the rule.
+	SeverityOff Severity = "off"
+)
+
+type (
+	// Severity is the severity of the violations of a lint rule.
+	Severity string
+
+	// Rule is a rule checked by Lint. The rules are named after the
+	// corresponding Spectral (https://stoplight.io/open-source/spectral)
+	// "spectral:oas" ruleset rules when there is one.
+	Rule struct {
+		// Name is the name of the rule.
+		Name string
+		// Description describes what the rule checks.
+		Description string
+		// Severity is the default severity of the rule.
+		Severity Severity
+		// Spectral is the definition of the rule in a Spectral ruleset
+		// for the rules that are not part of the "spectral:oas" ruleset.
+		Spectral *SpectralRule
+		// check reports the violations of the rule found in the
+		// specification.
+		check func(l *linter)
+	}
+
+	// SpectralRule is the definition of a custom Spectral rule.
+	SpectralRule struct {
+		// Description describes what the rule checks.
+		Description string `yaml:"description"`
+		// Severity is the rule severity.
+		Severity Severity `yaml:"severity"`
+		// Given is the JSON path of the values checked by the rule.
+		Given string `yaml:"given"`
+		// Then describes the check.
+		Then *SpectralThen `yaml:"then"`
+	}
+
+	// SpectralThen describes the check made by a custom Spectral rule.
+	SpectralThen struct {
+		// Field is the name of the checked field.
+		Field string `yaml:"field,omitempty"`
+		// Function is the name of the Spectral function applied to the
+		// field.
+		Function string `yaml:"function"`
+	}
+
+	// Ruleset is a Spectral ruleset that extends the "spectral:oas" ruleset
+	// with the rules checked by Lint.
+	Ruleset struct {
+		// Extends lists the extended rulesets.
+		Extends []string `yaml:"extends"`
+		// Rules maps the rule names to their severity or to their
+		// definition for custom rules.
+		Rules map[string]interface{} `yaml:"rules"`
+	}
+
+	// Violation describes a violation of a lint rule.
+	Violation struct {
+		// Rule is the name of the violated rule.
+		Rule string
+		// Severity is the severity of the rule.
+		Severity Severity
+		// Message describes the violation.
+		Message string
+		// Location is the location of the design DSL that defines the
+		// element violating the rule ("file:line") if known.
+		Location string
+	}
+
+	// linter checks the rules against a specification.
+	linter struct {
+		root       *expr.RootExpr
+		spec       *V2
+		rule       *Rule
+		violations []*Violation
+	}
+)
+
+// Rules lists the rules checked by Lint.
+var Rules = []*Rule{
+	{
+		Name:        "info-description",
+		Description: "The API must have a description.",
+		Severity:    SeverityWarn,
+		check: func(l *linter) {
+			if l.spec.Info == nil || l.spec.Info.Description == "" {
+				l.report(l.root.API, "API %q has no description, use Description in the API DSL", l.root.API.Name)
+			}
+		},
+	},
+	{
+		Name:        "info-contact",
+		Description: "The API must define a contact.",
+		Severity:    SeverityWarn,
+		check: func(l *linter) {
+			if l.spec.Info == nil || l.spec.Info.Contact == nil {
+				l.report(l.root.API, "API %q has no contact, use Contact in the API DSL", l.root.API.Name)
+			}
+		},
+	},
+	{
+		Name:        "info-license",
+		Description: "The API must define a license.",
+		Severity:    SeverityWarn,
+		check: func(l *linter) {
+			if l.spec.Info == nil || l.spec.Info.License == nil {
+				l.report(l.root.API, "API %q has no license, use License in the API DSL", l.root.API.Name)
+			}
+		},
+	},
+	{
+		Name:        "operation-description",
+		Description: "Operations must have a description.",
+		Severity:    SeverityWarn,
+		check: func(l *linter) {
+			l.operations(func(path, method string, op *Operation) {
+				if op.Description == "" {
+					l.report(l.expression(op), "operation %q (%s %s) has no description, use Description in the method DSL", op.OperationID, method, path)
+				}
+			})
+		},
+	},
+	{
+		Name:        "operation-tags",
+		Description: "Operations must have at least one tag.",
+		Severity:    SeverityWarn,
+		check: func(l *linter) {
+			l.operations(func(path, method string, op *Operation) {
+				if len(op.Tags) == 0 {
+					l.report(l.expression(op), "operation %q (%s %s) has no tag", op.OperationID, method, path)
+				}
+			})
+		},
+	},
+	{
+		Name:        "operation-operationId-unique",
+		Description: "Operation IDs must be unique.",
+		Severity:    SeverityError,
+		check: func(l *linter) {
+			seen := make(map[string]bool)
+			l.operations(func(path, method string, op *Operation) {
+				if seen[op.OperationID] {
+					l.report(l.expression(op), "operation ID %q of %s %s is not unique", op.OperationID, method, path)
+				}
+				seen[op.OperationID] = true
+			})
+		},
+	},
+	{
+		Name:        "operation-success-response",
+		Description: "Operations must define at least one 2xx or 3xx response.",
+		Severity:    SeverityWarn,
+		check: func(l *linter) {
+			l.operations(func(path, method string, op *Operation) {
+				for code := range op.Responses {
+					if strings.HasPrefix(code, "2") || strings.HasPrefix(code, "3") {
+						return
+					}
+				}
+				l.report(l.expression(op), "operation %q (%s %s) has no success response", op.OperationID, method, path)
+			})
+		},
+	},
+	{
+		Name:        "path-params",
+		Description: "Path parameters must be defined and used in the path.",
+		Severity:    SeverityError,
+		check: func(l *linter) {
+			l.operations(func(path, method string, op *Operation) {
+				defined := make(map[string]bool)
+				for _, p := range op.Parameters {
+					if p.In == "path" {
+						defined[p.Name] = true
+					}
+				}
+				used := make(map[string]bool)
+				for _, m := range pathParamRegex.FindAllStringSubmatch(path, -1) {
+					used[m[1]] = true
+					if !defined[m[1]] {
+						l.report(l.expression(op), "operation %q (%s %s) does not define path parameter %q", op.OperationID, method, path, m[1])
+					}
+				}
+				var unused []string
+				for n := range defined {
+					if !used[n] {
+						unused = append(unused, n)
+					}
+				}
+				sort.Strings(unused)
+				for _, n := range unused {
+					l.report(l.expression(op), "path parameter %q of operation %q is not used in path %s", n, op.OperationID, path)
+				}
+			})
+		},
+	},
+	{
+		Name:        "goa-property-description",
+		Description: "Schema properties must have a description.",
+		Severity:    SeverityOff,
+		Spectral: &SpectralRule{
+			Description: "Schema properties must have a description.",
+			Given:       "$.definitions[*].properties[*]",
+			Then:        &SpectralThen{Field: "description", Function: "truthy"},
+		},
+		check: func(l *linter) {
+			names := make([]string, 0, len(l.spec.Definitions))
+			for n := range l.spec.Definitions {
+				names = append(names, n)
+			}
+			sort.Strings(names)
+			for _, n := range names {
+				d := l.spec.Definitions[n]
+				props := make([]string, 0, len(d.Properties))
+				for p := range d.Properties {
+					props = append(props, p)
+				}
+				sort.Strings(props)
+				var e eval.Expression = l.root.API
+				if ut := l.root.UserType(n); ut != nil {
+					e = ut
+				}
+				for _, p := range props {
+					if d.Properties[p].Description == "" && d.Properties[p].Ref == "" {
+						l.report(e, "property %q of definition %q has no description", p, n)
+					}
+				}
+			}
+		},
+	},
+}
+
+// pathParamRegex matches the parameters of OpenAPI paths.
+var pathParamRegex = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// RuleSeverities returns the severities of the rules taking into account the
+// overrides defined in the API "openapi:lint:<rule>" meta, e.g.:
+//
+//	Meta("openapi:lint:operation-description", "error")
+func RuleSeverities(api *expr.APIExpr) map[string]Severity {
+	res := make(map[string]Severity, len(Rules))
+	for _, r := range Rules {
+		res[r.Name] = r.Severity
+		if s, ok := api.Meta.Last("openapi:lint:" + r.Name); ok {
+			res[r.Name] = Severity(s)
+		}
+	}
+	return res
+}
+
+// ValidateRuleSeverities returns an error if the API meta overrides the
+// severity of an unknown rule or uses an invalid severity.
+func ValidateRuleSeverities(api *expr.APIExpr) error {
+	var keys []string
+	for k := range api.Meta {
+		if strings.HasPrefix(k, "openapi:lint:") {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		name := strings.TrimPrefix(k, "openapi:lint:")
+		var found bool
+		for _, r := range Rules {
+			if r.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown OpenAPI lint rule %q in meta %q", name, k)
+		}
+		switch s, _ := api.Meta.Last(k); Severity(s) {
+		case SeverityError, SeverityWarn, SeverityOff:
+		default:
+			return fmt.Errorf("invalid severity %q for OpenAPI lint rule %q, must be one of error, warn or off", s, name)
+		}
+	}
+	return nil
+}
+
+// NewRuleset returns the Spectral ruleset that checks the rules of Lint with
+// the given severities.
+func NewRuleset(severities map[string]Severity) *Ruleset {
+	rs := &Ruleset{Extends: []string{"spectral:oas"}, Rules: make(map[string]interface{}, len(Rules))}
+	for _, r := range Rules {
+		sev := severities[r.Name]
+		if r.Spectral == nil {
+			rs.Rules[r.Name] = sev
+			continue
+		}
+		sr := *r.Spectral
+		sr.Severity = sev
+		rs.Rules[r.Name] = &sr
+	}
+	return rs
+}
+
+// Lint checks the given specification against the rules whose severity is
+// not off and returns the violations sorted by location. The locations of the
+// violations are the locations of the DSL that defines the design elements
+// violating the rules.
+func Lint(root *expr.RootExpr, spec *V2, severities map[string]Severity) []*Violation {
+	l := &linter{root: root, spec: spec}
+	for _, r := range Rules {
+		sev, ok := severities[r.Name]
+		if !ok {
+			sev = r.Severity
+		}
+		if sev == SeverityOff {
+			continue
+		}
+		l.rule = &Rule{Name: r.Name, Severity: sev}
+		r.check(l)
+	}
+	sort.SliceStable(l.violations, func(i, j int) bool {
+		return l.violations[i].Location < l.violations[j].Location
+	})
+	return l.violations
+}
+
+// String returns the violation formatted as "location: rule: message".
+func (v *Violation) String() string {
+	if v.Location == "" {
+		return fmt.Sprintf("%s: %s", v.Rule, v.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", v.Location, v.Rule, v.Message)
+}
+
+// report records a violation of the current rule by the design element e.
+func (l *linter) report(e eval.Expression, format string, args ...interface{}) {
+	l.violations = append(l.violations, &Violation{
+		Rule:     l.rule.Name,
+		Severity: l.rule.Severity,
+		Message:  fmt.Sprintf(format, args...),
+		Location: eval.FormatLocation(e),
+	})
+}
+
+// operations calls fn with each operation of the specification in order of
+// path and method.
+func (l *linter) operations(fn func(path, method string, op *Operation)) {
+	paths := make([]string, 0, len(l.spec.Paths))
+	for p := range l.spec.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		path, ok := l.spec.Paths[p].(*Path)
+		if !ok {
+			continue
+		}
+		ops := []struct {
+			method string
+			op     *Operation
+		}{
+			{"DELETE", path.Delete}, {"GET", path.Get}, {"HEAD", path.Head},
+			{"OPTIONS", path.Options}, {"PATCH", path.Patch}, {"POST", path.Post},
+			{"PUT", path.Put},
+		}
+		for _, o := range ops {
+			if o.op != nil {
+				fn(p, o.method, o.op)
+			}
+		}
+	}
+}
+
+// expression returns the design expression that defines the given operation:
+// the method for endpoint operations, the service for the other operations
+// and the API if the service cannot be found.
+func (l *linter) expression(op *Operation) eval.Expression {
+	parts := strings.Split(op.OperationID, "#")
+	if l.root.API.HTTP == nil {
+		return l.root.API
+	}
+	svc := l.root.API.HTTP.Service(parts[0])
+	if svc == nil {
+		return l.root.API
+	}
+	if len(parts) > 1 {
+		if e := svc.Endpoint(parts[1]); e != nil {
+			return e.MethodExpr
+		}
+	}
+	return svc.ServiceExpr
+}
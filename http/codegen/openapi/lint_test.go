@@ -0,0 +1,60 @@
+package openapi
+
+import (
+	"reflect"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+)
+
+func TestLint(t *testing.T) {
+	root := &expr.RootExpr{API: &expr.APIExpr{Name: "test", HTTP: &expr.HTTPExpr{}}}
+	spec := &V2{
+		Info: &Info{Description: "test"},
+		Paths: map[string]interface{}{
+			"/{id}": &Path{
+				Get: &Operation{
+					OperationID: "svc#show",
+					Description: "Show",
+					Tags:        []string{"svc"},
+					Parameters:  []*Parameter{{Name: "name", In: "path"}},
+					Responses:   map[string]*Response{"404": {}},
+				},
+			},
+			"x-extension": "value",
+		},
+	}
+	cases := []struct {
+		Name       string
+		Severities map[string]Severity
+		Expected   []string
+	}{
+		{"defaults", nil, []string{
+			`info-contact: API "test" has no contact, use Contact in the API DSL`,
+			`info-license: API "test" has no license, use License in the API DSL`,
+			`operation-success-response: operation "svc#show" (GET /{id}) has no success response`,
+			`path-params: operation "svc#show" (GET /{id}) does not define path parameter "id"`,
+			`path-params: path parameter "name" of operation "svc#show" is not used in path /{id}`,
+		}},
+		{"overrides", map[string]Severity{
+			"info-contact":               SeverityOff,
+			"info-license":               SeverityOff,
+			"operation-success-response": SeverityOff,
+			"path-params":                SeverityWarn,
+		}, []string{
+			`path-params: operation "svc#show" (GET /{id}) does not define path parameter "id"`,
+			`path-params: path parameter "name" of operation "svc#show" is not used in path /{id}`,
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var actual []string
+			for _, v := range Lint(root, spec, c.Severities) {
+				actual = append(actual, v.String())
+			}
+			if !reflect.DeepEqual(actual, c.Expected) {
+				t.Errorf("invalid violations:\ngot:      %q\nexpected: %q", actual, c.Expected)
+			}
+		})
+	}
+}
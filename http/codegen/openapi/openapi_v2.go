@@ -1,5 +1,5 @@
 // Package openapi produces OpenAPI Specification 2.0 (https://github.com/OAI/OpenAPI-Specification/blob/master/versions/2.0.md)
-// for the HTTP endpoints.
+// and 3.1 (https://spec.openapis.org/oas/v3.1.0) for the HTTP endpoints.
 package openapi
 
 import (
@@ -26,6 +26,7 @@ type (
 		Responses           map[string]*Response           `json:"responses,omitempty" yaml:"responses,omitempty"`
 		SecurityDefinitions map[string]*SecurityDefinition `json:"securityDefinitions,omitempty" yaml:"securityDefinitions,omitempty"`
 		Tags                []*Tag                         `json:"tags,omitempty" yaml:"tags,omitempty"`
+		TagGroups           []*TagGroup                    `json:"x-tagGroups,omitempty" yaml:"x-tagGroups,omitempty"`
 		ExternalDocs        *ExternalDocs                  `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
 	}
 
@@ -99,6 +100,9 @@ type (
 		Security []map[string][]string `json:"security,omitempty" yaml:"security,omitempty"`
 		// Extensions defines the swagger extensions.
 		Extensions map[string]interface{} `json:"-" yaml:"-"`
+		// requirements lists the security requirements as defined in
+		// the design, see designRequirementsFromExpr.
+		requirements []map[string][]string
 	}
 
 	// Parameter describes a single operation parameter.
@@ -150,6 +154,9 @@ type (
 		MultipleOf       float64       `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
 		// Extensions defines the swagger extensions.
 		Extensions map[string]interface{} `json:"-" yaml:"-"`
+		// examples lists the named examples of the parameter used by
+		// the OpenAPI 3.1 specification, see examplesFromExpr.
+		examples map[string]*Example3
 	}
 
 	// Response describes an operation response.
@@ -168,6 +175,12 @@ type (
 		Ref string `json:"$ref,omitempty" yaml:"$ref,omitempty"`
 		// Extensions defines the swagger extensions.
 		Extensions map[string]interface{} `json:"-" yaml:"-"`
+		// examples lists the named examples of the response body used
+		// by the OpenAPI 3.1 specification, see examplesFromExpr.
+		examples map[string]*Example3
+		// contentType is the media type of the response body if set in
+		// the design, used by the OpenAPI 3.1 specification.
+		contentType string
 	}
 
 	// Header represents a header parameter.
@@ -289,6 +302,15 @@ type (
 		Extensions map[string]interface{} `json:"-" yaml:"-"`
 	}
 
+	// TagGroup groups tags in the documentation generated by the tools that
+	// support the x-tagGroups extension.
+	TagGroup struct {
+		// Name of the group.
+		Name string `json:"name" yaml:"name"`
+		// Tags lists the names of the tags in the group.
+		Tags []string `json:"tags" yaml:"tags"`
+	}
+
 	// These types are used in marshalJSON() to avoid recursive call of json.Marshal().
 	_Info               Info
 	_Path               Path
@@ -11,6 +11,22 @@ import (
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/expr"
+	goahttp "goa.design/goa/v3/http"
+	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// problemDetailsName is the name of the definition of the problem
+	// details documents.
+	problemDetailsName = "ProblemDetails"
+	// problemDetailsRef is the reference to the problem details definition.
+	problemDetailsRef = "#/definitions/" + problemDetailsName
+	// healthReportName is the name of the definition of the health reports
+	// written by the liveness and readiness endpoints.
+	healthReportName = "HealthReport"
+	// healthReportRef is the reference to the health report definition.
+	healthReportRef = "#/definitions/" + healthReportName
 )
 
 // NewV2 returns the OpenAPI v2 specification for the given API.
@@ -18,7 +34,7 @@ func NewV2(root *expr.RootExpr, h *expr.HostExpr) (*V2, error) {
 	if root == nil {
 		return nil, nil
 	}
-	tags := tagsFromExpr(root.API.Meta)
+	tags := append(tagsFromExpr(root.API.Meta), tagsFromTagExprs(root.API.AllTags())...)
 	u, err := url.Parse(defaultURI(h))
 	if err != nil {
 		// This should never happen because server expression must have been
@@ -57,12 +73,17 @@ func NewV2(root *expr.RootExpr, h *expr.HostExpr) (*V2, error) {
 		Produces:            root.API.HTTP.Produces,
 		Parameters:          paramMap,
 		Tags:                tags,
+		TagGroups:           tagGroupsFromExpr(root.API.Tags),
 		SecurityDefinitions: securitySpecFromExpr(root),
 		ExternalDocs:        docsFromExpr(root.API.Docs),
 	}
 
+	var grpcErrors []*expr.GRPCErrorExpr
+	if root.API.GRPC != nil {
+		grpcErrors = root.API.GRPC.Errors
+	}
 	for _, he := range root.API.HTTP.Errors {
-		res := responseSpecFromExpr(s, root, he.Response, "")
+		res := errorResponseSpecFromExpr(s, root, he, "", grpcErrors)
 		if s.Responses == nil {
 			s.Responses = make(map[string]*Response)
 		}
@@ -73,6 +94,9 @@ func NewV2(root *expr.RootExpr, h *expr.HostExpr) (*V2, error) {
 		if !mustGenerate(res.Meta) || !mustGenerate(res.ServiceExpr.Meta) {
 			continue
 		}
+		for k, v := range ExtensionsFromExpr(res.ServiceExpr.Meta) {
+			s.Paths[k] = v
+		}
 		for k, v := range ExtensionsFromExpr(res.Meta) {
 			s.Paths[k] = v
 		}
@@ -91,6 +115,13 @@ func NewV2(root *expr.RootExpr, h *expr.HostExpr) (*V2, error) {
 			}
 		}
 	}
+	if hc := root.API.HealthCheck; hc != nil && mustGenerate(hc.Meta) {
+		buildPathsFromHealthCheck(s, root, hc)
+		Definitions[healthReportName] = healthReportSchema()
+	}
+	if hasProblemDetails(root) {
+		Definitions[problemDetailsName] = problemDetailsSchema()
+	}
 	if len(Definitions) > 0 {
 		s.Definitions = make(map[string]*Schema)
 		for n, d := range Definitions {
@@ -104,9 +135,18 @@ func NewV2(root *expr.RootExpr, h *expr.HostExpr) (*V2, error) {
 }
 
 // ExtensionsFromExpr generates swagger extensions from the given meta
-// expression.
+// expression. The extensions are defined with meta keys starting with either
+// "swagger:extension:" or "openapi:extension:", the latter taking precedence
+// if both define the same extension.
 func ExtensionsFromExpr(mdata expr.MetaExpr) map[string]interface{} {
-	return extensionsFromExprWithPrefix(mdata, "swagger:extension:")
+	extensions := extensionsFromExprWithPrefix(mdata, "swagger:extension:")
+	for k, v := range extensionsFromExprWithPrefix(mdata, "openapi:extension:") {
+		if extensions == nil {
+			extensions = make(map[string]interface{})
+		}
+		extensions[k] = v
+	}
+	return extensions
 }
 
 // extensionsFromExprWithPrefix generates swagger extensions from
@@ -178,6 +218,200 @@ func mustGenerate(meta expr.MetaExpr) bool {
 	return true
 }
 
+// scopesFromExpr returns the descriptions of the given scopes indexed by name,
+// nil if there are no scopes.
+func scopesFromExpr(scopes []*expr.ScopeExpr) map[string]string {
+	if len(scopes) == 0 {
+		return nil
+	}
+	res := make(map[string]string, len(scopes))
+	for _, scope := range scopes {
+		res[scope.Name] = scope.Description
+	}
+	return res
+}
+
+// flowDefinitionName returns the name of the OpenAPI v2 security definition
+// that describes the flow of the given OAuth2 scheme at index i. The
+// definition of the first flow uses the name of the scheme.
+func flowDefinitionName(s *expr.SchemeExpr, i int) string {
+	if i == 0 {
+		return s.Hash()
+	}
+	return s.Hash() + "_" + s.Flows[i].Type()
+}
+
+// apiKeyFallbackSchemes returns the schemes that describe the locations of the
+// key of the given API key scheme read by the endpoint server when the request
+// does not carry the key in the location the key attribute is mapped to. The
+// schemes are copies of s with the In and Name fields set to the location.
+func apiKeyFallbackSchemes(e *expr.HTTPEndpointExpr, s *expr.SchemeExpr) []*expr.SchemeExpr {
+	if s.Kind != expr.APIKeyKind {
+		return nil
+	}
+	var res []*expr.SchemeExpr
+	for _, l := range e.APIKeyFallbacks(s) {
+		fs := *s
+		fs.In = l.In
+		fs.Name = l.Name
+		res = append(res, &fs)
+	}
+	return res
+}
+
+// requirementSchemes returns the schemes of the given requirement followed by
+// the schemes that describe the fallback locations of its API keys.
+func requirementSchemes(e *expr.HTTPEndpointExpr, req *expr.SecurityExpr) []*expr.SchemeExpr {
+	schemes := req.Schemes
+	for _, s := range req.Schemes {
+		if fs := apiKeyFallbackSchemes(e, s); len(fs) > 0 {
+			schemes = append(append([]*expr.SchemeExpr{}, schemes...), fs...)
+		}
+	}
+	return schemes
+}
+
+// securityRequirementsFromExpr returns the security requirements of the
+// given endpoint. A requirement maps the names of the security definitions
+// that must all be satisfied to the required scopes, the endpoint may be
+// accessed if any of the requirements is satisfied. The requirements that
+// use OAuth2 schemes with multiple flows are expanded into one requirement
+// per flow that supports the required scopes since OpenAPI v2 security
+// definitions describe a single flow. The scopes of the other schemes and the
+// mutual TLS schemes, which OpenAPI v2 does not support, are listed in the
+// returned description.
+func securityRequirementsFromExpr(endpoint *expr.HTTPEndpointExpr, description string) ([]map[string][]string, string) {
+	var requirements []map[string][]string
+	for _, req := range endpoint.Requirements {
+		alternatives := []map[string][]string{{}}
+		for _, s := range req.Schemes {
+			if s.Kind == expr.MTLSKind {
+				// OpenAPI v2 does not support mutual TLS, document
+				// the requirement in the description instead.
+				if description != "" {
+					description += "\n"
+				}
+				description += fmt.Sprintf("\n**Requires a client certificate (mutual TLS) for %s**", s.SchemeName)
+				continue
+			}
+			options := []map[string][]string{{s.Hash(): {}}}
+			for _, fs := range apiKeyFallbackSchemes(endpoint, s) {
+				// The key may be sent in any of its locations.
+				options = append(options, map[string][]string{fs.Hash(): {}})
+			}
+			switch s.Kind {
+			case expr.OAuth2Kind:
+				options = nil
+				for i, f := range s.Flows {
+					if !flowSupports(f, req.Scopes) {
+						continue
+					}
+					options = append(options, map[string][]string{flowDefinitionName(s, i): append([]string{}, req.Scopes...)})
+				}
+				if len(options) == 0 {
+					options = []map[string][]string{{s.Hash(): append([]string{}, req.Scopes...)}}
+				}
+			case expr.BasicAuthKind, expr.APIKeyKind, expr.JWTKind:
+				lines := make([]string, 0, len(req.Scopes))
+				for _, scope := range req.Scopes {
+					lines = append(lines, fmt.Sprintf("  * `%s`", scope))
+				}
+				// List scopes only if they are defined
+				if len(lines) > 0 {
+					if description != "" {
+						description += "\n"
+					}
+					description += fmt.Sprintf("\n**Required security scopes for %s**:\n%s", s.SchemeName, strings.Join(lines, "\n"))
+				}
+			}
+			var expanded []map[string][]string
+			for _, a := range alternatives {
+				for _, o := range options {
+					r := make(map[string][]string, len(a)+len(o))
+					for k, v := range a {
+						r[k] = v
+					}
+					for k, v := range o {
+						r[k] = v
+					}
+					expanded = append(expanded, r)
+				}
+			}
+			alternatives = expanded
+		}
+		for _, a := range alternatives {
+			if len(a) > 0 {
+				requirements = append(requirements, a)
+			}
+		}
+	}
+	if endpoint.MethodExpr.OptionalSecurity && len(requirements) > 0 {
+		// An empty requirement allows anonymous requests.
+		requirements = append(requirements, map[string][]string{})
+	}
+	return requirements, description
+}
+
+// flowSupports returns true if the given flow supports all the given scopes.
+// A flow that does not list scopes supports the scopes of its scheme.
+func flowSupports(f *expr.FlowExpr, scopes []string) bool {
+	if len(f.Scopes) == 0 {
+		return true
+	}
+	for _, name := range scopes {
+		found := false
+		for _, s := range f.Scopes {
+			if s.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// designRequirementsFromExpr returns the security requirements of the given
+// endpoint as defined in the design: one requirement per Security expression
+// that maps the names of the schemes to the required scopes. The scopes are
+// listed for the OAuth2 and JWT schemes.
+func designRequirementsFromExpr(endpoint *expr.HTTPEndpointExpr) []map[string][]string {
+	requirements := make([]map[string][]string, 0, len(endpoint.Requirements))
+	for _, req := range endpoint.Requirements {
+		requirement := make(map[string][]string, len(req.Schemes))
+		var fallbacks []*expr.SchemeExpr
+		for _, s := range req.Schemes {
+			requirement[s.Hash()] = []string{}
+			if s.Kind == expr.OAuth2Kind || s.Kind == expr.JWTKind {
+				requirement[s.Hash()] = append(requirement[s.Hash()], req.Scopes...)
+			}
+			fallbacks = append(fallbacks, apiKeyFallbackSchemes(endpoint, s)...)
+		}
+		requirements = append(requirements, requirement)
+		// Add one requirement per fallback location of the API keys
+		// where the fallback replaces the primary location.
+		for _, fs := range fallbacks {
+			alt := make(map[string][]string, len(requirement))
+			for k, v := range requirement {
+				alt[k] = v
+			}
+			for _, s := range req.Schemes {
+				if s.SchemeName == fs.SchemeName {
+					delete(alt, s.Hash())
+				}
+			}
+			alt[fs.Hash()] = []string{}
+			requirements = append(requirements, alt)
+		}
+	}
+	if endpoint.MethodExpr.OptionalSecurity && len(requirements) > 0 {
+		requirements = append(requirements, map[string][]string{})
+	}
+	return requirements
+}
+
 // addScopeDescription generates and adds required scopes to the scheme's description.
 func addScopeDescription(scopes []*expr.ScopeExpr, sd *SecurityDefinition) {
 	// Generate scopes to add to description
@@ -194,6 +428,64 @@ func addScopeDescription(scopes []*expr.ScopeExpr, sd *SecurityDefinition) {
 	}
 }
 
+// addScopeHierarchy documents the scopes implied by the wildcard scopes (e.g.
+// "orders:*") of the schemes that enable them in the "x-scope-hierarchy"
+// extension of the security definition.
+func addScopeHierarchy(scopes []*expr.ScopeExpr, sd *SecurityDefinition) {
+	h := expr.ScopeHierarchy(scopes)
+	ext := make(map[string]interface{}, len(sd.Extensions)+1)
+	for k, v := range sd.Extensions {
+		ext[k] = v
+	}
+	delete(ext, "x-scope-hierarchy")
+	if h != nil {
+		ext["x-scope-hierarchy"] = h
+	}
+	if len(ext) == 0 {
+		ext = nil
+	}
+	sd.Extensions = ext
+}
+
+// addSignatureDescription describes how to sign the requests for the given
+// request signature scheme in the security definition description and in its
+// "x-goa-signature" extension.
+func addSignatureDescription(s *expr.SchemeExpr, sd *SecurityDefinition) {
+	if sd.Description != "" {
+		sd.Description += "\n"
+	}
+	sd.Description += fmt.Sprintf("\n**Signed requests**: the %s header contains the %s HMAC of the canonical request computed with the secret identified by the Credential parameter, see the %s, %s and %s headers.",
+		s.Name, goahttp.SignatureAlgorithm, goahttp.SignatureDateHeader, goahttp.SignatureNonceHeader, goahttp.SignatureContentHeader)
+	sig := map[string]interface{}{"algorithm": goahttp.SignatureAlgorithm}
+	if s.ClockSkew > 0 {
+		sig["clockSkew"] = s.ClockSkew.String()
+	}
+	if s.ReplayWindow > 0 {
+		sig["replayWindow"] = s.ReplayWindow.String()
+	}
+	ext := make(map[string]interface{}, len(sd.Extensions)+1)
+	for k, v := range sd.Extensions {
+		ext[k] = v
+	}
+	ext["x-goa-signature"] = sig
+	sd.Extensions = ext
+}
+
+// addCookieLocation describes the cookie that carries the credentials of the
+// API key and JWT schemes that use cookies. OpenAPI v2 does not support cookie
+// API keys so such keys are described as the Cookie header.
+func addCookieLocation(s *expr.SchemeExpr, sd *SecurityDefinition) {
+	if s.In != "cookie" {
+		return
+	}
+	if sd.Description != "" {
+		sd.Description += "\n"
+	}
+	sd.Description += fmt.Sprintf("\n**Cookie**: the credentials are sent in the %q cookie.", s.Name)
+	sd.In = "header"
+	sd.Name = "Cookie"
+}
+
 // securitySpecFromExpr generates the OpenAPI security definitions from the
 // security design.
 func securitySpecFromExpr(root *expr.RootExpr) map[string]*SecurityDefinition {
@@ -201,11 +493,18 @@ func securitySpecFromExpr(root *expr.RootExpr) map[string]*SecurityDefinition {
 	for _, svc := range root.API.HTTP.Services {
 		for _, e := range svc.HTTPEndpoints {
 			for _, req := range e.Requirements {
-				for _, s := range req.Schemes {
+				for _, s := range requirementSchemes(e, req) {
+					if s.Kind == expr.MTLSKind {
+						// not supported by OpenAPI v2
+						continue
+					}
 					sd := SecurityDefinition{
 						Description: s.Description,
 						Extensions:  ExtensionsFromExpr(s.Meta),
 					}
+					if s.WildcardScopes {
+						addScopeHierarchy(s.Scopes, &sd)
+					}
 
 					switch s.Kind {
 					case expr.BasicAuthKind:
@@ -216,6 +515,7 @@ func securitySpecFromExpr(root *expr.RootExpr) map[string]*SecurityDefinition {
 						sd.In = s.In
 						sd.Name = s.Name
 						addScopeDescription(s.Scopes, &sd)
+						addCookieLocation(s, &sd)
 					case expr.JWTKind:
 						sd.Type = "apiKey"
 						// OpenAPI V2 spec does not support JWT scheme. Hence we add the scheme
@@ -223,31 +523,48 @@ func securitySpecFromExpr(root *expr.RootExpr) map[string]*SecurityDefinition {
 						addScopeDescription(s.Scopes, &sd)
 						sd.In = s.In
 						sd.Name = s.Name
+						addCookieLocation(s, &sd)
+					case expr.SignatureKind:
+						sd.Type = "apiKey"
+						// OpenAPI does not support request
+						// signatures, describe the signing
+						// algorithm instead.
+						addSignatureDescription(s, &sd)
+						addScopeDescription(s.Scopes, &sd)
+						sd.In = s.In
+						sd.Name = s.Name
 					case expr.OAuth2Kind:
 						sd.Type = "oauth2"
-						if scopesLen := len(s.Scopes); scopesLen > 0 {
-							scopes := make(map[string]string, scopesLen)
-							for _, scope := range s.Scopes {
-								scopes[scope.Name] = scope.Description
-							}
-							sd.Scopes = scopes
-						}
+						sd.Scopes = scopesFromExpr(s.Scopes)
+					}
+					if len(s.Flows) == 0 {
+						sds[s.Hash()] = &sd
+						continue
 					}
-					if len(s.Flows) > 0 {
-						switch s.Flows[0].Kind {
+					// OpenAPI v2 supports a single flow per security
+					// definition so define one per flow.
+					for i, f := range s.Flows {
+						fd := sd
+						switch f.Kind {
 						case expr.AuthorizationCodeFlowKind:
-							sd.Flow = "accessCode"
+							fd.Flow = "accessCode"
 						case expr.ImplicitFlowKind:
-							sd.Flow = "implicit"
+							fd.Flow = "implicit"
 						case expr.PasswordFlowKind:
-							sd.Flow = "password"
+							fd.Flow = "password"
 						case expr.ClientCredentialsFlowKind:
-							sd.Flow = "application"
+							fd.Flow = "application"
 						}
-						sd.AuthorizationURL = s.Flows[0].AuthorizationURL
-						sd.TokenURL = s.Flows[0].TokenURL
+						fd.AuthorizationURL = f.AuthorizationURL
+						fd.TokenURL = f.TokenURL
+						if len(f.Scopes) > 0 {
+							fd.Scopes = scopesFromExpr(f.Scopes)
+							if s.WildcardScopes {
+								addScopeHierarchy(f.Scopes, &fd)
+							}
+						}
+						sds[flowDefinitionName(s, i)] = &fd
 					}
-					sds[s.Hash()] = &sd
 				}
 			}
 		}
@@ -344,15 +661,66 @@ func tagsFromExpr(mdata expr.MetaExpr) (tags []*Tag) {
 }
 
 func tagNamesFromExpr(mdatas ...expr.MetaExpr) (tagNames []string) {
+	seen := make(map[string]bool)
 	for _, mdata := range mdatas {
 		tags := tagsFromExpr(mdata)
 		for _, tag := range tags {
+			if seen[tag.Name] {
+				continue
+			}
+			seen[tag.Name] = true
 			tagNames = append(tagNames, tag.Name)
 		}
 	}
 	return
 }
 
+// tagsFromTagExprs returns the tags defined with the Tag DSL.
+func tagsFromTagExprs(tes []*expr.TagExpr) []*Tag {
+	var tags []*Tag
+	for _, t := range tes {
+		tags = append(tags, &Tag{
+			Name:         t.Name,
+			Description:  t.Description,
+			ExternalDocs: docsFromExpr(t.Docs),
+		})
+	}
+	return tags
+}
+
+// tagGroupsFromExpr returns the x-tagGroups extension value that groups the
+// tags contained in the given top level tags, nil if none of the tags contain
+// other tags. A group lists the top level tag followed by the tags it
+// contains recursively. The top level tags that do not contain other tags
+// get their own group as the documentation tools do not render the tags that
+// do not belong to a group.
+func tagGroupsFromExpr(tes []*expr.TagExpr) []*TagGroup {
+	nested := false
+	for _, t := range tes {
+		if len(t.Tags) > 0 {
+			nested = true
+			break
+		}
+	}
+	if !nested {
+		return nil
+	}
+	var groups []*TagGroup
+	for _, t := range tes {
+		g := &TagGroup{Name: t.Name, Tags: []string{t.Name}}
+		var collect func([]*expr.TagExpr)
+		collect = func(children []*expr.TagExpr) {
+			for _, c := range children {
+				g.Tags = append(g.Tags, c.Name)
+				collect(c.Tags)
+			}
+		}
+		collect(t.Tags)
+		groups = append(groups, g)
+	}
+	return groups
+}
+
 func summaryFromExpr(name string, e *expr.HTTPEndpointExpr) string {
 	for n, mdata := range e.Meta {
 		if n == "swagger:summary" && len(mdata) > 0 {
@@ -395,6 +763,14 @@ func paramsFromExpr(params *expr.MappedAttributeExpr, path string) []*Parameter
 			}
 		}
 		param := paramFor(at, pn, in, required)
+		if in == "path" {
+			if style := expr.PathParamStyle(path, n); style != expr.PathStyleSimple {
+				if param.Extensions == nil {
+					param.Extensions = make(map[string]interface{})
+				}
+				param.Extensions["x-style"] = style
+			}
+		}
 		res = append(res, param)
 		i++
 		return nil
@@ -402,6 +778,17 @@ func paramsFromExpr(params *expr.MappedAttributeExpr, path string) []*Parameter
 	return res
 }
 
+// styledPathParam converts the label ("{.name}") or matrix ("{;name}") style
+// path parameter w to the OpenAPI path template syntax (".{name}" and
+// ";name={name}" respectively).
+func styledPathParam(w string) string {
+	name := w[2 : len(w)-1]
+	if w[1] == '.' {
+		return ".{" + name + "}"
+	}
+	return ";" + name + "={" + name + "}"
+}
+
 func paramsFromHeaders(endpoint *expr.HTTPEndpointExpr) []*Parameter {
 	params := []*Parameter{}
 	var (
@@ -444,6 +831,20 @@ func paramsFromHeaders(endpoint *expr.HTTPEndpointExpr) []*Parameter {
 	return params
 }
 
+// collectionFormat returns the OpenAPI collection format corresponding to the
+// style of the array query string parameter described by at.
+func collectionFormat(at *expr.AttributeExpr) string {
+	switch expr.QueryDelimiter(at) {
+	case ",":
+		return "csv"
+	case "|":
+		return "pipes"
+	case " ":
+		return "ssv"
+	}
+	return "multi"
+}
+
 func paramFor(at *expr.AttributeExpr, name, in string, required bool) *Parameter {
 	p := &Parameter{
 		In:          in,
@@ -456,6 +857,9 @@ func paramFor(at *expr.AttributeExpr, name, in string, required bool) *Parameter
 	if expr.IsArray(at.Type) {
 		p.Items = itemsFromExpr(expr.AsArray(at.Type).ElemType)
 		p.CollectionFormat = "multi"
+		if in == "query" {
+			p.CollectionFormat = collectionFormat(at)
+		}
 	}
 	switch at.Type {
 	case expr.Int, expr.UInt, expr.UInt32, expr.UInt64:
@@ -473,11 +877,46 @@ func paramFor(at *expr.AttributeExpr, name, in string, required bool) *Parameter
 		p.Type = "string"
 		p.Format = "byte"
 	}
-	p.Extensions = ExtensionsFromExpr(at.Meta)
+	p.Extensions = decodingExtensions(ExtensionsFromExpr(at.Meta), at)
+	p.examples = examplesFromExpr(at)
 	initValidations(at, p)
 	return p
 }
 
+// examplesFromExpr returns the named examples of the given attribute indexed
+// by name, nil if the attribute does not define named examples. The examples
+// with the same name defined for the request and the response of an operation
+// describe a request and the corresponding response.
+func examplesFromExpr(at *expr.AttributeExpr) map[string]*Example3 {
+	names := at.ExampleNames()
+	if len(names) == 0 {
+		return nil
+	}
+	exs := make(map[string]*Example3, len(names))
+	for _, n := range names {
+		// Use a dedicated random generator so that the named examples
+		// do not change the other examples.
+		v := at.NamedExample(n, expr.NewRandom(n))
+		if v == nil {
+			continue
+		}
+		ex := &Example3{Summary: n, Value: v}
+		atts := []*expr.AttributeExpr{at}
+		if ut, ok := at.Type.(expr.UserType); ok {
+			atts = append(atts, ut.Attribute())
+		}
+		for _, att := range atts {
+			for _, ue := range att.UserExamples {
+				if ue.Summary == n && ex.Description == "" {
+					ex.Description = ue.Description
+				}
+			}
+		}
+		exs[n] = ex
+	}
+	return exs
+}
+
 func itemsFromExpr(at *expr.AttributeExpr) *Items {
 	items := &Items{Type: at.Type.Name()}
 	initValidations(at, items)
@@ -507,12 +946,206 @@ func responseSpecFromExpr(s *V2, root *expr.RootExpr, r *expr.HTTPResponseExpr,
 	if desc == "" {
 		desc = fmt.Sprintf("%s response.", http.StatusText(r.StatusCode))
 	}
-	return &Response{
+	resp := &Response{
 		Description: desc,
 		Schema:      schema,
 		Headers:     headers,
 		Extensions:  ExtensionsFromExpr(r.Meta),
 	}
+	if schema != nil {
+		resp.examples = examplesFromExpr(r.Body)
+	}
+	if trailers := headersFromExpr(r.Trailers); trailers != nil {
+		if resp.Extensions == nil {
+			resp.Extensions = make(map[string]interface{})
+		}
+		resp.Extensions["x-trailers"] = trailers
+	}
+	return resp
+}
+
+// errorResponseSpecFromExpr returns the OpenAPI response of the given HTTP
+// error. The errors encoded as problem details documents use the
+// ProblemDetails definition. The response description defaults to the error
+// description and the response body of the errors using the default error
+// type is illustrated with an example built from the error name and
+// description. The gRPC status code of the error if any, looked up in
+// grpcErrors, is recorded in the x-grpc-status extension.
+func errorResponseSpecFromExpr(s *V2, root *expr.RootExpr, er *expr.HTTPErrorExpr, typeNamePrefix string, grpcErrors []*expr.GRPCErrorExpr) *Response {
+	var (
+		resp *Response
+		r    = er.Response
+	)
+	if !expr.IsProblemError(er.ErrorExpr) || (r.Headers != nil && !r.Headers.IsEmpty()) {
+		resp = responseSpecFromExpr(s, root, r, typeNamePrefix)
+		resp.contentType = r.ContentType
+		if resp.Schema != nil && len(resp.examples) == 0 {
+			resp.examples = errorExamplesFromExpr(er)
+		}
+	} else {
+		resp = &Response{
+			Schema:     &Schema{Ref: problemDetailsRef},
+			Extensions: ExtensionsFromExpr(r.Meta),
+		}
+	}
+	resp.Description = r.Description
+	if resp.Description == "" {
+		resp.Description = er.Description
+	}
+	if resp.Description == "" {
+		resp.Description = fmt.Sprintf("%s response.", http.StatusText(r.StatusCode))
+	}
+	for _, ge := range grpcErrors {
+		if ge.Name != er.Name {
+			continue
+		}
+		if resp.Extensions == nil {
+			resp.Extensions = make(map[string]interface{})
+		}
+		resp.Extensions["x-grpc-status"] = map[string]interface{}{
+			"code": ge.Response.StatusCode,
+			"name": codes.Code(ge.Response.StatusCode).String(),
+		}
+		break
+	}
+	return resp
+}
+
+// errorExamplesFromExpr returns the example of the response body of the given
+// error if it uses the default error type, nil otherwise. The example fields
+// are initialized with the error name, description and properties.
+func errorExamplesFromExpr(er *expr.HTTPErrorExpr) map[string]*Example3 {
+	if er.Type != expr.ErrorResult {
+		return nil
+	}
+	obj := expr.AsObject(er.Response.Body.Type)
+	if obj == nil {
+		return nil
+	}
+	msg := er.Description
+	if msg == "" {
+		msg = fmt.Sprintf("%s error.", er.Name)
+	}
+	_, temporary := er.Meta["goa:error:temporary"]
+	_, timeout := er.Meta["goa:error:timeout"]
+	_, fault := er.Meta["goa:error:fault"]
+	fields := map[string]interface{}{
+		"name":      er.Name,
+		"id":        "3F1FKVRR",
+		"message":   msg,
+		"temporary": temporary,
+		"timeout":   timeout,
+		"fault":     fault,
+	}
+	value := make(map[string]interface{})
+	for n, v := range fields {
+		if obj.Attribute(n) != nil {
+			value[n] = v
+		}
+	}
+	if len(value) == 0 {
+		return nil
+	}
+	return map[string]*Example3{er.Name: {Summary: er.Description, Value: value}}
+}
+
+// hasProblemDetails returns true if the generated servers encode errors as
+// problem details documents.
+func hasProblemDetails(root *expr.RootExpr) bool {
+	return root.API.HTTP != nil && root.API.HTTP.ProblemDetails
+}
+
+// problemDetailsSchema returns the JSON schema of the problem details
+// documents (RFC 9457) encoded by the generated servers.
+func problemDetailsSchema() *Schema {
+	prop := func(t Type, format, desc string) *Schema {
+		return &Schema{Type: t, Format: format, Description: desc}
+	}
+	s := NewSchema()
+	s.Type = Object
+	s.Title = problemDetailsName
+	s.Description = "Problem details document (RFC 9457) describing an error."
+	s.Properties = map[string]*Schema{
+		"type":      prop(String, "uri-reference", "URI reference that identifies the problem type."),
+		"title":     prop(String, "", "Short summary of the problem type."),
+		"status":    prop(Integer, "", "HTTP status code of the response."),
+		"detail":    prop(String, "", "Description of the specific problem occurrence."),
+		"instance":  prop(String, "uri-reference", "URI reference that identifies the specific problem occurrence."),
+		"name":      prop(String, "", "Name is the name of this class of errors."),
+		"id":        prop(String, "", "ID is a unique identifier for this particular occurrence of the problem."),
+		"message":   prop(String, "", "Message is a human-readable explanation specific to this occurrence of the problem."),
+		"temporary": prop(Boolean, "", "Is the error temporary?"),
+		"timeout":   prop(Boolean, "", "Is the error a timeout?"),
+		"fault":     prop(Boolean, "", "Is the error a server-side fault?"),
+	}
+	violation := NewSchema()
+	violation.Type = Object
+	violation.Properties = map[string]*Schema{
+		"pointer":    prop(String, "json-pointer", "JSON Pointer to the invalid value."),
+		"rule":       prop(String, "", "Name of the violated validation rule."),
+		"constraint": {Description: "Value of the constraint defined by the rule."},
+		"message":    prop(String, "", "Description of the violation."),
+	}
+	violation.Required = []string{"pointer", "rule", "message"}
+	violations := prop(Array, "", "Validation rules violated by the request.")
+	violations.Items = violation
+	s.Properties["violations"] = violations
+	s.Required = []string{"type", "title", "status", "name", "id", "message", "temporary", "timeout", "fault"}
+	return s
+}
+
+// healthReportSchema returns the JSON schema of the health reports written by
+// the liveness and readiness endpoints generated with the HealthCheck DSL.
+func healthReportSchema() *Schema {
+	s := NewSchema()
+	s.Type = Object
+	s.Title = healthReportName
+	s.Description = "Health of the server and of its dependencies."
+	status := &Schema{Type: String, Description: "Overall status of the server."}
+	status.Enum = []interface{}{goa.HealthStatusOK, goa.HealthStatusUnavailable, goa.HealthStatusDraining}
+	checks := &Schema{Type: Object, Description: "Results of the dependency checks indexed by name, \"ok\" or the check error."}
+	checks.AdditionalProperties = true
+	s.Properties = map[string]*Schema{"status": status, "checks": checks}
+	s.Required = []string{"status"}
+	return s
+}
+
+// buildPathsFromHealthCheck adds the liveness and readiness endpoints generated
+// with the HealthCheck DSL to the paths of s.
+func buildPathsFromHealthCheck(s *V2, root *expr.RootExpr, hc *expr.HealthCheckExpr) {
+	schemes := root.API.Schemes()
+	for i := len(schemes) - 1; i >= 0; i-- {
+		if schemes[i] == "grpc" || schemes[i] == "grpcs" {
+			schemes = append(schemes[:i], schemes[i+1:]...)
+		}
+	}
+	endpoints := []struct {
+		path, id, summary, description string
+	}{
+		{hc.LivenessPath, "liveness", "Liveness probe", "Reports whether the server is alive."},
+		{hc.ReadinessPath, "readiness", "Readiness probe", "Reports whether the server and its dependencies are ready to serve requests. The server is not ready while it drains requests on shutdown."},
+	}
+	for _, e := range endpoints {
+		op := &Operation{
+			Tags:        []string{"health"},
+			Summary:     e.summary,
+			Description: e.description,
+			OperationID: "health#" + e.id,
+			Produces:    []string{"application/json"},
+			Responses: map[string]*Response{
+				"200": {Description: "OK response.", Schema: &Schema{Ref: healthReportRef}},
+				"503": {Description: "Service Unavailable response.", Schema: &Schema{Ref: healthReportRef}},
+			},
+			Schemes: schemes,
+		}
+		path, ok := s.Paths[e.path].(*Path)
+		if !ok {
+			path = new(Path)
+			s.Paths[e.path] = path
+		}
+		path.Get = op
+		path.Extensions = ExtensionsFromExpr(hc.Meta)
+	}
 }
 
 func headersFromExpr(headers *expr.MappedAttributeExpr) map[string]*Header {
@@ -526,6 +1159,22 @@ func headersFromExpr(headers *expr.MappedAttributeExpr) map[string]*Header {
 			Description: at.Description,
 			Type:        at.Type.Name(),
 		}
+		switch at.Type {
+		case expr.Int, expr.UInt, expr.UInt32, expr.UInt64:
+			header.Type = "integer"
+		case expr.Int32, expr.Int64:
+			header.Type = "integer"
+			header.Format = at.Type.Name()
+		case expr.Float32:
+			header.Type = "number"
+			header.Format = "float"
+		case expr.Float64:
+			header.Type = "number"
+			header.Format = "double"
+		case expr.Bytes:
+			header.Type = "string"
+			header.Format = "byte"
+		}
 		initValidations(at, header)
 		res[n] = header
 		return nil
@@ -600,14 +1249,24 @@ func buildPathFromFileServer(s *V2, root *expr.RootExpr, fs *expr.HTTPFileServer
 func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr.RouteExpr, basePath string) {
 	endpoint := route.Endpoint
 
-	tagNames := tagNamesFromExpr(endpoint.Service.Meta, endpoint.Meta)
+	tagNames := tagNamesFromExpr(endpoint.Service.ServiceExpr.Meta, endpoint.Service.Meta, endpoint.MethodExpr.Meta, endpoint.Meta)
 	if len(tagNames) == 0 {
 		// By default tag with service name
 		tagNames = []string{route.Endpoint.Service.Name()}
 	}
 	for _, key := range route.FullPaths() {
+		fullPath := key
 		params := paramsFromExpr(endpoint.Params, key)
 		params = append(params, paramsFromHeaders(endpoint)...)
+		if csrf := endpoint.CSRFProtection(); csrf != nil && !expr.IsSafeMethod(route.Method) {
+			params = append(params, &Parameter{
+				In:          "header",
+				Name:        csrf.HeaderName(),
+				Required:    true,
+				Description: "CSRF token returned by the responses to safe requests.",
+				Type:        "string",
+			})
+		}
 		produces := []string{}
 		responses := make(map[string]*Response, len(endpoint.Responses))
 		for _, r := range endpoint.Responses {
@@ -635,19 +1294,38 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 				}
 			}
 		}
+		var grpcErrors []*expr.GRPCErrorExpr
+		if root.API.GRPC != nil {
+			if gs := root.API.GRPC.Service(endpoint.Service.Name()); gs != nil {
+				if ge := gs.Endpoint(endpoint.Name()); ge != nil {
+					grpcErrors = ge.GRPCErrors
+				}
+			}
+		}
 		for _, er := range endpoint.HTTPErrors {
-			resp := responseSpecFromExpr(s, root, er.Response, endpoint.Service.Name())
+			resp := errorResponseSpecFromExpr(s, root, er, endpoint.Service.Name(), grpcErrors)
 			responses[strconv.Itoa(er.Response.StatusCode)] = resp
 		}
+		if _, ok := responses["401"]; !ok && len(endpoint.Requirements) > 0 {
+			responses["401"] = unauthorizedResponse(endpoint.Service.ServiceExpr.AuthRealm())
+		}
+		if hasProblemDetails(root) {
+			responses["default"] = &Response{
+				Description: "Problem details of the errors not described above.",
+				Schema:      &Schema{Ref: problemDetailsRef},
+			}
+		}
 
 		var consumes []string
 		if endpoint.MultipartRequest {
 			consumes = []string{"multipart/form-data"}
+		} else if endpoint.FormEncodedRequest {
+			consumes = []string{"application/x-www-form-urlencoded"}
 		}
 
 		if endpoint.Body.Type != expr.Empty {
 			in := "body"
-			if endpoint.MultipartRequest {
+			if endpoint.MultipartRequest || endpoint.FormEncodedRequest {
 				in = "formData"
 			}
 			pp := &Parameter{
@@ -656,6 +1334,7 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 				Description: endpoint.Body.Description,
 				Required:    true,
 				Schema:      AttributeTypeSchemaWithPrefix(root.API, endpoint.Body, codegen.Goify(endpoint.Service.Name(), true)),
+				examples:    examplesFromExpr(endpoint.Body),
 			}
 			params = append(params, pp)
 		}
@@ -695,32 +1374,7 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 			}
 		}
 
-		description := endpoint.Description()
-
-		requirements := make([]map[string][]string, len(endpoint.Requirements))
-		for i, req := range endpoint.Requirements {
-			requirement := make(map[string][]string)
-			for _, s := range req.Schemes {
-				requirement[s.Hash()] = []string{}
-				switch s.Kind {
-				case expr.OAuth2Kind:
-					requirement[s.Hash()] = append(requirement[s.Hash()], req.Scopes...)
-				case expr.BasicAuthKind, expr.APIKeyKind, expr.JWTKind:
-					lines := make([]string, 0, len(req.Scopes))
-					for _, scope := range req.Scopes {
-						lines = append(lines, fmt.Sprintf("  * `%s`", scope))
-					}
-					// List scopes only if they are defined
-					if len(lines) > 0 {
-						if description != "" {
-							description += "\n"
-						}
-						description += fmt.Sprintf("\n**Required security scopes for %s**:\n%s", s.SchemeName, strings.Join(lines, "\n"))
-					}
-				}
-			}
-			requirements[i] = requirement
-		}
+		requirements, description := securityRequirementsFromExpr(endpoint, endpoint.Description())
 
 		operation := &Operation{
 			Tags:         tagNames,
@@ -736,11 +1390,31 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 			Deprecated:   false,
 			Extensions:   ExtensionsFromExpr(endpoint.MethodExpr.Meta),
 			Security:     requirements,
+			requirements: designRequirementsFromExpr(endpoint),
+		}
+		if cors := corsFromExpr(endpoint); cors != nil {
+			if operation.Extensions == nil {
+				operation.Extensions = make(map[string]interface{})
+			}
+			operation.Extensions["x-cors"] = cors
+		}
+		if csrf := endpoint.CSRFProtection(); csrf != nil {
+			if operation.Extensions == nil {
+				operation.Extensions = make(map[string]interface{})
+			}
+			operation.Extensions["x-csrf"] = csrfFromExpr(csrf)
+		}
+		if endpoint.IsLenientDecoding() {
+			if operation.Extensions == nil {
+				operation.Extensions = make(map[string]interface{})
+			}
+			operation.Extensions["x-lenientDecoding"] = true
 		}
 
 		if key == "" {
 			key = "/"
 		}
+		key = expr.HTTPStyledWildcardRegex.ReplaceAllStringFunc(key, styledPathParam)
 		bp := expr.HTTPWildcardRegex.ReplaceAllStringFunc(
 			basePath,
 			func(w string) string {
@@ -773,10 +1447,116 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 		case "PATCH":
 			p.Patch = operation
 		}
+		if p.Head == nil && route.AutoHead(fullPath) {
+			p.Head = headOperation(operation)
+		}
+		if methods := endpoint.Service.AutoOptions(fullPath); methods != nil && p.Options == nil {
+			p.Options = optionsOperation(endpoint.Service.Name(), fullPath, tagNames, schemes, methods)
+		}
 		p.Extensions = ExtensionsFromExpr(route.Endpoint.Meta)
 	}
 }
 
+// headOperation returns the operation describing the HEAD requests served by
+// the handler of the given GET operation. The responses are the same as the
+// GET responses without body.
+func headOperation(get *Operation) *Operation {
+	head := *get
+	head.OperationID = get.OperationID + "#head"
+	head.Produces = nil
+	head.Responses = make(map[string]*Response, len(get.Responses))
+	for code, r := range get.Responses {
+		resp := *r
+		resp.Schema = nil
+		head.Responses[code] = &resp
+	}
+	return &head
+}
+
+// optionsOperation returns the operation describing the OPTIONS requests
+// served by the generated server for the given path.
+func optionsOperation(svc, path string, tags, schemes, methods []string) *Operation {
+	allow := strings.Join(methods, ", ")
+	return &Operation{
+		Tags:        tags,
+		Summary:     fmt.Sprintf("Allowed methods for %s", path),
+		OperationID: fmt.Sprintf("%s#options%s", svc, path),
+		Responses: map[string]*Response{
+			"204": {
+				Description: "No Content response.",
+				Headers: map[string]*Header{
+					"Allow": {Description: fmt.Sprintf("Methods allowed on the path: %s.", allow), Type: "string"},
+				},
+			},
+		},
+		Schemes: schemes,
+	}
+}
+
+// unauthorizedResponse returns the response returned by the generated servers
+// when a request satisfies none of the security requirements of an endpoint.
+// The response records the realm of the challenges in the "x-realm" extension
+// if the service defines one.
+func unauthorizedResponse(realm string) *Response {
+	resp := &Response{
+		Description: "Unauthorized response, none of the security requirements is satisfied.",
+		Headers: map[string]*Header{
+			"WWW-Authenticate": {
+				Description: "Challenges of the security schemes tried in order.",
+				Type:        "string",
+			},
+		},
+	}
+	if realm != "" {
+		resp.Headers["WWW-Authenticate"].Description = fmt.Sprintf("Challenges of the security schemes tried in order with realm %q.", realm)
+		resp.Extensions = map[string]interface{}{"x-realm": realm}
+	}
+	return resp
+}
+
+// csrfFromExpr returns the value of the "x-csrf" extension describing the CSRF
+// protection of an endpoint.
+func csrfFromExpr(csrf *expr.CSRFExpr) map[string]interface{} {
+	ext := map[string]interface{}{"mode": csrf.Mode, "header": csrf.HeaderName()}
+	if csrf.Mode == expr.CSRFDoubleSubmit {
+		ext["cookie"] = csrf.CookieName()
+	}
+	return ext
+}
+
+// corsFromExpr returns the value of the "x-cors" extension describing the CORS
+// policies that apply to the endpoint, nil if there are none.
+func corsFromExpr(endpoint *expr.HTTPEndpointExpr) []map[string]interface{} {
+	origins := endpoint.CORS()
+	if len(origins) == 0 {
+		return nil
+	}
+	cors := make([]map[string]interface{}, len(origins))
+	for i, o := range origins {
+		c := map[string]interface{}{"origin": o.Origin}
+		if len(o.Methods) > 0 {
+			c["methods"] = o.Methods
+		}
+		if len(o.Headers) > 0 {
+			c["headers"] = o.Headers
+		}
+		if len(o.Exposed) > 0 {
+			c["expose"] = o.Exposed
+		}
+		if o.MaxAge > 0 {
+			c["maxAge"] = o.MaxAge
+		}
+		if o.Credentials {
+			c["credentials"] = true
+		}
+		if o.PrivateNetwork {
+			c["privateNetwork"] = true
+		}
+		cors[i] = c
+	}
+	return cors
+}
+
 func docsFromExpr(docs *expr.DocsExpr) *ExternalDocs {
 	if docs == nil {
 		return nil
@@ -848,6 +1628,77 @@ func initMaximumValidation(def interface{}, max *float64) {
 	}
 }
 
+func initExclusiveMinimumValidation(def interface{}, min *float64) {
+	switch actual := def.(type) {
+	case *Parameter:
+		actual.Minimum = min
+		actual.ExclusiveMinimum = true
+	case *Header:
+		actual.Minimum = min
+		actual.ExclusiveMinimum = true
+	case *Items:
+		actual.Minimum = min
+		actual.ExclusiveMinimum = true
+	}
+}
+
+func initExclusiveMaximumValidation(def interface{}, max *float64) {
+	switch actual := def.(type) {
+	case *Parameter:
+		actual.Maximum = max
+		actual.ExclusiveMaximum = true
+	case *Header:
+		actual.Maximum = max
+		actual.ExclusiveMaximum = true
+	case *Items:
+		actual.Maximum = max
+		actual.ExclusiveMaximum = true
+	}
+}
+
+func initMultipleOfValidation(def interface{}, mult float64) {
+	switch actual := def.(type) {
+	case *Parameter:
+		actual.MultipleOf = mult
+	case *Header:
+		actual.MultipleOf = mult
+	case *Items:
+		actual.MultipleOf = mult
+	}
+}
+
+// decodingExtensions adds the "x-lenientDecoding" and "x-trim" extensions that
+// document the lenient decoding and the trimming of the values of the given
+// attribute to ext.
+func decodingExtensions(ext map[string]interface{}, at *expr.AttributeExpr) map[string]interface{} {
+	lenient, trim := expr.IsLenientAttribute(at), expr.IsTrimmedAttribute(at)
+	if !lenient && !trim {
+		return ext
+	}
+	if ext == nil {
+		ext = make(map[string]interface{})
+	}
+	if lenient {
+		ext["x-lenientDecoding"] = true
+	}
+	if trim {
+		ext["x-trim"] = true
+	}
+	return ext
+}
+
+// initMaxBytesValidation documents the MaxBytes validation of parameters with
+// the "x-maxBytes" extension as OpenAPI does not define an equivalent
+// keyword.
+func initMaxBytesValidation(def interface{}, max int) {
+	if p, ok := def.(*Parameter); ok {
+		if p.Extensions == nil {
+			p.Extensions = make(map[string]interface{})
+		}
+		p.Extensions["x-maxBytes"] = max
+	}
+}
+
 func initMinLengthValidation(def interface{}, isArray bool, min *int) {
 	switch actual := def.(type) {
 	case *Parameter:
@@ -892,10 +1743,24 @@ func initValidations(attr *expr.AttributeExpr, def interface{}) {
 	if val.Maximum != nil {
 		initMaximumValidation(def, val.Maximum)
 	}
+	// OpenAPI 2.0 flags the minimum and maximum as exclusive so only the
+	// most restrictive bounds are kept.
+	if min := val.ExclusiveMinimum; min != nil && (val.Minimum == nil || *min >= *val.Minimum) {
+		initExclusiveMinimumValidation(def, min)
+	}
+	if max := val.ExclusiveMaximum; max != nil && (val.Maximum == nil || *max <= *val.Maximum) {
+		initExclusiveMaximumValidation(def, max)
+	}
+	if val.MultipleOf != nil {
+		initMultipleOfValidation(def, *val.MultipleOf)
+	}
 	if val.MinLength != nil {
 		initMinLengthValidation(def, expr.IsArray(attr.Type), val.MinLength)
 	}
 	if val.MaxLength != nil {
 		initMaxLengthValidation(def, expr.IsArray(attr.Type), val.MaxLength)
 	}
+	if val.MaxBytes != nil {
+		initMaxBytesValidation(def, *val.MaxBytes)
+	}
 }
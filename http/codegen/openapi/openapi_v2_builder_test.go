@@ -52,8 +52,9 @@ func TestBuildPathFromFileServer(t *testing.T) {
 
 func TestBuildPathFromExpr(t *testing.T) {
 	cases := map[string]struct {
-		multipartRequest bool
-		expected         Operation
+		multipartRequest   bool
+		formEncodedRequest bool
+		expected           Operation
 	}{
 		"multipart request": {
 			multipartRequest: true,
@@ -66,6 +67,17 @@ func TestBuildPathFromExpr(t *testing.T) {
 				},
 			},
 		},
+		"form encoded request": {
+			formEncodedRequest: true,
+			expected: Operation{
+				Consumes: []string{"application/x-www-form-urlencoded"},
+				Parameters: []*Parameter{
+					&Parameter{
+						In: "formData",
+					},
+				},
+			},
+		},
 		"non multipart request": {
 			multipartRequest: false,
 			expected: Operation{
@@ -108,7 +120,8 @@ func TestBuildPathFromExpr(t *testing.T) {
 					Body: &expr.AttributeExpr{
 						Type: expr.String,
 					},
-					MultipartRequest: tc.multipartRequest,
+					MultipartRequest:   tc.multipartRequest,
+					FormEncodedRequest: tc.formEncodedRequest,
 				},
 			}
 			basePath := "/"
@@ -137,3 +150,160 @@ func TestBuildPathFromExpr(t *testing.T) {
 		})
 	}
 }
+
+func TestParamForCollectionFormat(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Style    string
+		Explode  *bool
+		In       string
+		Expected string
+	}{
+		{"default", "", nil, "query", "multi"},
+		{"form", expr.QueryStyleForm, nil, "query", "multi"},
+		{"form-no-explode", expr.QueryStyleForm, new(bool), "query", "csv"},
+		{"pipe-delimited", expr.QueryStylePipeDelimited, nil, "query", "pipes"},
+		{"space-delimited", expr.QueryStyleSpaceDelimited, nil, "query", "ssv"},
+		{"header", expr.QueryStylePipeDelimited, nil, "header", "multi"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			at := &expr.AttributeExpr{Type: &expr.Array{ElemType: &expr.AttributeExpr{Type: expr.String}}}
+			if c.Style != "" {
+				expr.SetQueryStyle(at, c.Style)
+			}
+			if c.Explode != nil {
+				expr.SetQueryExplode(at, *c.Explode)
+			}
+			p := paramFor(at, "q", c.In, false)
+			if p.CollectionFormat != c.Expected {
+				t.Errorf("got collection format %q, expected %q", p.CollectionFormat, c.Expected)
+			}
+		})
+	}
+}
+
+func TestParamForDecodingExtensions(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Meta     expr.MetaExpr
+		Expected map[string]interface{}
+	}{
+		{"none", nil, nil},
+		{"lenient", expr.MetaExpr{"decode:lenient": {"true"}}, map[string]interface{}{"x-lenientDecoding": true}},
+		{"trim", expr.MetaExpr{"decode:trim": nil}, map[string]interface{}{"x-trim": true}},
+		{"both", expr.MetaExpr{"decode:lenient": {"true"}, "decode:trim": nil}, map[string]interface{}{"x-lenientDecoding": true, "x-trim": true}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			at := &expr.AttributeExpr{Type: expr.String, Meta: c.Meta}
+			p := paramFor(at, "q", "query", false)
+			if len(p.Extensions) != len(c.Expected) {
+				t.Fatalf("got extensions %v, expected %v", p.Extensions, c.Expected)
+			}
+			for k, v := range c.Expected {
+				if p.Extensions[k] != v {
+					t.Errorf("got extension %s = %v, expected %v", k, p.Extensions[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestStyledPathParam(t *testing.T) {
+	cases := []struct {
+		Path     string
+		Expected string
+	}{
+		{"/report{.format}", "/report.{format}"},
+		{"/cars{;color}", "/cars;color={color}"},
+		{"/cars/{id}{.format}{;color}", "/cars/{id}.{format};color={color}"},
+	}
+	for _, c := range cases {
+		t.Run(c.Path, func(t *testing.T) {
+			actual := expr.HTTPStyledWildcardRegex.ReplaceAllStringFunc(c.Path, styledPathParam)
+			if actual != c.Expected {
+				t.Errorf("got %q, expected %q", actual, c.Expected)
+			}
+		})
+	}
+}
+
+func TestResponseSpecFromExprTrailers(t *testing.T) {
+	trailers := expr.NewMappedAttributeExpr(&expr.AttributeExpr{
+		Type: &expr.Object{{Name: "checksum:Digest", Attribute: &expr.AttributeExpr{Type: expr.String, Description: "Body checksum"}}},
+	})
+	cases := map[string]struct {
+		trailers *expr.MappedAttributeExpr
+		expected map[string]*Header
+	}{
+		"no trailers": {expr.NewEmptyMappedAttributeExpr(), nil},
+		"trailers":    {trailers, map[string]*Header{"Digest": {Type: "string", Description: "Body checksum"}}},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			r := &expr.HTTPResponseExpr{
+				StatusCode: expr.StatusOK,
+				Headers:    expr.NewEmptyMappedAttributeExpr(),
+				Trailers:   tc.trailers,
+				Body:       &expr.AttributeExpr{Type: expr.Empty},
+			}
+			resp := responseSpecFromExpr(&V2{}, &expr.RootExpr{API: &expr.APIExpr{}}, r, "")
+			actual, ok := resp.Extensions["x-trailers"]
+			if tc.expected == nil {
+				if ok {
+					t.Errorf("got unexpected trailers %#v", actual)
+				}
+				return
+			}
+			headers, ok := actual.(map[string]*Header)
+			if !ok {
+				t.Fatalf("got x-trailers %#v, expected a map of headers", actual)
+			}
+			if len(headers) != len(tc.expected) {
+				t.Fatalf("got %d trailers, expected %d", len(headers), len(tc.expected))
+			}
+			for n, h := range tc.expected {
+				a, ok := headers[n]
+				if !ok {
+					t.Errorf("trailer %q not found", n)
+					continue
+				}
+				if a.Type != h.Type || a.Description != h.Description {
+					t.Errorf("got trailer %q %#v, expected %#v", n, a, h)
+				}
+			}
+		})
+	}
+}
+
+func TestHeadersFromExpr(t *testing.T) {
+	headers := expr.NewMappedAttributeExpr(&expr.AttributeExpr{
+		Type: &expr.Object{
+			{Name: "retry_in:Retry-After", Attribute: &expr.AttributeExpr{Type: expr.Int}},
+			{Name: "reset:RateLimit-Reset", Attribute: &expr.AttributeExpr{Type: expr.Int64}},
+			{Name: "ratio:X-Ratio", Attribute: &expr.AttributeExpr{Type: expr.Float32}},
+			{Name: "id:X-Id", Attribute: &expr.AttributeExpr{Type: expr.String}},
+		},
+	})
+	expected := map[string]*Header{
+		"Retry-After":     {Type: "integer"},
+		"RateLimit-Reset": {Type: "integer", Format: "int64"},
+		"X-Ratio":         {Type: "number", Format: "float"},
+		"X-Id":            {Type: "string"},
+	}
+	actual := headersFromExpr(headers)
+	if len(actual) != len(expected) {
+		t.Fatalf("got %d headers, expected %d", len(actual), len(expected))
+	}
+	for n, h := range expected {
+		a, ok := actual[n]
+		if !ok {
+			t.Errorf("header %q not found", n)
+			continue
+		}
+		if a.Type != h.Type || a.Format != h.Format {
+			t.Errorf("got header %q type %q format %q, expected type %q format %q", n, a.Type, a.Format, h.Type, h.Format)
+		}
+	}
+}
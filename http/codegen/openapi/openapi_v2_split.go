@@ -0,0 +1,139 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/url"
+	"path"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// SplitDefinitions is the name of the file that contains the definitions of
+// a split specification without extension.
+const SplitDefinitions = "definitions"
+
+// SplitV2 splits the given OpenAPI 2.0 specification into multiple documents:
+//
+//   - the index document "openapi" which contains the API information and
+//     references the paths described in the service documents,
+//   - one document per service "paths/<service>" which contains the paths of
+//     the service operations,
+//   - the document "definitions" which contains the schema definitions shared
+//     by the other documents.
+//
+// The documents are returned indexed by file path relative to the directory
+// of the index document. ext is the extension of the files (e.g. ".json")
+// used to build the references between the documents. A path used by
+// operations of multiple services is described in the document of the first
+// service.
+func SplitV2(root *expr.RootExpr, spec *V2, ext string) (map[string]interface{}, error) {
+	var (
+		docs     = make(map[string]interface{})
+		index    = *spec
+		defsFile = SplitDefinitions + ext
+	)
+	index.Paths = make(map[string]interface{}, len(spec.Paths))
+	index.Definitions = nil
+	for key, p := range spec.Paths {
+		item, ok := p.(*Path)
+		if !ok {
+			// extension
+			index.Paths[key] = p
+			continue
+		}
+		file := path.Join("paths", codegen.SnakeCase(pathService(root, item))+ext)
+		doc, ok := docs[file].(map[string]interface{})
+		if !ok {
+			doc = make(map[string]interface{})
+			docs[file] = doc
+		}
+		v, err := splitRefs(item, "../"+defsFile+"#/")
+		if err != nil {
+			return nil, err
+		}
+		doc[key] = v
+		index.Paths[key] = &Path{Ref: file + "#/" + jsonPointerEscape(key)}
+	}
+	v, err := splitRefs(&index, defsFile+"#/")
+	if err != nil {
+		return nil, err
+	}
+	docs["openapi"+ext] = v
+	if len(spec.Definitions) > 0 {
+		v, err := splitRefs(spec.Definitions, "#/")
+		if err != nil {
+			return nil, err
+		}
+		docs[defsFile] = v
+	}
+	return docs, nil
+}
+
+// pathService returns the name of the service that owns the given path, that
+// is the first service in the design that defines an operation on the path.
+func pathService(root *expr.RootExpr, p *Path) string {
+	var names []string
+	for _, op := range []*Operation{p.Get, p.Put, p.Post, p.Delete, p.Options, p.Head, p.Patch} {
+		if op == nil {
+			continue
+		}
+		if i := strings.Index(op.OperationID, "#"); i > 0 {
+			names = append(names, op.OperationID[:i])
+		}
+	}
+	for _, svc := range root.API.HTTP.Services {
+		for _, n := range names {
+			if n == svc.Name() {
+				return n
+			}
+		}
+	}
+	if len(names) > 0 {
+		return names[0]
+	}
+	return "paths"
+}
+
+// splitRefs returns the generic JSON representation of v where the references
+// to the definitions use the given prefix instead of "#/definitions/".
+func splitRefs(v interface{}, prefix string) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var res interface{}
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil, err
+	}
+	rewriteRefs(res, prefix)
+	return res, nil
+}
+
+// rewriteRefs replaces the "#/definitions/" prefix of the references found in
+// the generic JSON value v with prefix.
+func rewriteRefs(v interface{}, prefix string) {
+	switch actual := v.(type) {
+	case map[string]interface{}:
+		for k, e := range actual {
+			if ref, ok := e.(string); ok && k == "$ref" && strings.HasPrefix(ref, "#/definitions/") {
+				actual[k] = prefix + strings.TrimPrefix(ref, "#/definitions/")
+				continue
+			}
+			rewriteRefs(e, prefix)
+		}
+	case []interface{}:
+		for _, e := range actual {
+			rewriteRefs(e, prefix)
+		}
+	}
+}
+
+// jsonPointerEscape escapes the given JSON object key so that it can be used
+// in the JSON pointer of a reference fragment.
+func jsonPointerEscape(key string) string {
+	key = strings.Replace(key, "~", "~0", -1)
+	key = strings.Replace(key, "/", "~1", -1)
+	return url.PathEscape(key)
+}
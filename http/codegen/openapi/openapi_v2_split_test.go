@@ -0,0 +1,49 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+)
+
+func TestSplitV2(t *testing.T) {
+	root := &expr.RootExpr{API: &expr.APIExpr{HTTP: &expr.HTTPExpr{}}}
+	spec := &V2{
+		Swagger: "2.0",
+		Paths: map[string]interface{}{
+			"/foo/{id}": &Path{Get: &Operation{
+				OperationID: "svc#show",
+				Responses: map[string]*Response{
+					"200": {Schema: &Schema{Ref: "#/definitions/Foo"}},
+				},
+			}},
+			"x-foo": "bar",
+		},
+		Definitions: map[string]*Schema{
+			"Foo": {Type: Object, Properties: map[string]*Schema{"bar": {Ref: "#/definitions/Bar"}}},
+			"Bar": {Type: String},
+		},
+	}
+	docs, err := SplitV2(root, spec, ".json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		"openapi.json":     `{"paths":{"/foo/{id}":{"$ref":"paths/svc.json#/~1foo~1%7Bid%7D"},"x-foo":"bar"},"swagger":"2.0"}`,
+		"paths/svc.json":   `{"/foo/{id}":{"get":{"operationId":"svc#show","responses":{"200":{"schema":{"$ref":"../definitions.json#/Foo"}}}}}}`,
+		"definitions.json": `{"Bar":{"type":"string"},"Foo":{"properties":{"bar":{"$ref":"#/Bar"}},"type":"object"}}`,
+	}
+	if len(docs) != len(expected) {
+		t.Errorf("got %d documents, expected %d", len(docs), len(expected))
+	}
+	for name, exp := range expected {
+		b, err := json.Marshal(docs[name])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != exp {
+			t.Errorf("%s: got %s, expected %s", name, string(b), exp)
+		}
+	}
+}
@@ -0,0 +1,361 @@
+package openapi
+
+// JSONSchemaDialect is the JSON schema dialect of the schemas of the OpenAPI
+// 3.1 specifications.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+type (
+	// V3 represents an instance of an OpenAPI 3.1 object.
+	// See https://spec.openapis.org/oas/v3.1.0
+	V3 struct {
+		OpenAPI           string                 `json:"openapi" yaml:"openapi"`
+		Info              *Info                  `json:"info,omitempty" yaml:"info,omitempty"`
+		JSONSchemaDialect string                 `json:"jsonSchemaDialect,omitempty" yaml:"jsonSchemaDialect,omitempty"`
+		Servers           []*Server              `json:"servers,omitempty" yaml:"servers,omitempty"`
+		Paths             map[string]interface{} `json:"paths" yaml:"paths"`
+		Webhooks          map[string]*PathItem   `json:"webhooks,omitempty" yaml:"webhooks,omitempty"`
+		Components        *Components            `json:"components,omitempty" yaml:"components,omitempty"`
+		Tags              []*Tag                 `json:"tags,omitempty" yaml:"tags,omitempty"`
+		TagGroups         []*TagGroup            `json:"x-tagGroups,omitempty" yaml:"x-tagGroups,omitempty"`
+		ExternalDocs      *ExternalDocs          `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+	}
+
+	// Server represents a server hosting the API.
+	Server struct {
+		// URL of the server, may contain variables using the "{name}"
+		// syntax.
+		URL string `json:"url" yaml:"url"`
+		// Description of the server.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		// Variables maps the variables used in URL to their values.
+		Variables map[string]*ServerVariable `json:"variables,omitempty" yaml:"variables,omitempty"`
+	}
+
+	// ServerVariable describes a server URL variable.
+	ServerVariable struct {
+		// Enum lists the possible values of the variable if limited.
+		Enum []string `json:"enum,omitempty" yaml:"enum,omitempty"`
+		// Default is the value used when no value is provided.
+		Default string `json:"default" yaml:"default"`
+		// Description of the variable.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	}
+
+	// PathItem describes the operations available on a single path or
+	// webhook.
+	PathItem struct {
+		// Get defines a GET operation on this path.
+		Get *Operation3 `json:"get,omitempty" yaml:"get,omitempty"`
+		// Put defines a PUT operation on this path.
+		Put *Operation3 `json:"put,omitempty" yaml:"put,omitempty"`
+		// Post defines a POST operation on this path.
+		Post *Operation3 `json:"post,omitempty" yaml:"post,omitempty"`
+		// Delete defines a DELETE operation on this path.
+		Delete *Operation3 `json:"delete,omitempty" yaml:"delete,omitempty"`
+		// Options defines a OPTIONS operation on this path.
+		Options *Operation3 `json:"options,omitempty" yaml:"options,omitempty"`
+		// Head defines a HEAD operation on this path.
+		Head *Operation3 `json:"head,omitempty" yaml:"head,omitempty"`
+		// Patch defines a PATCH operation on this path.
+		Patch *Operation3 `json:"patch,omitempty" yaml:"patch,omitempty"`
+		// Extensions defines the OpenAPI extensions.
+		Extensions map[string]interface{} `json:"-" yaml:"-"`
+	}
+
+	// Operation3 describes a single API operation on a path.
+	Operation3 struct {
+		// Tags is a list of tags for API documentation control.
+		Tags []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+		// Summary is a short summary of what the operation does.
+		Summary string `json:"summary,omitempty" yaml:"summary,omitempty"`
+		// Description is a verbose explanation of the operation behavior.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		// ExternalDocs points to additional external documentation for this operation.
+		ExternalDocs *ExternalDocs `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+		// OperationID is a unique string used to identify the operation.
+		OperationID string `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+		// Parameters is a list of parameters that are applicable for this operation.
+		Parameters []*Parameter3 `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+		// RequestBody describes the request body.
+		RequestBody *RequestBody `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+		// Responses is the list of possible responses as they are returned from executing
+		// this operation.
+		Responses map[string]*Response3 `json:"responses,omitempty" yaml:"responses,omitempty"`
+		// Deprecated declares this operation to be deprecated.
+		Deprecated bool `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+		// Security is a declaration of which security schemes are applied for this operation.
+		Security []map[string][]string `json:"security,omitempty" yaml:"security,omitempty"`
+		// Extensions defines the OpenAPI extensions.
+		Extensions map[string]interface{} `json:"-" yaml:"-"`
+	}
+
+	// Parameter3 describes a single operation parameter.
+	Parameter3 struct {
+		// Name of the parameter. Parameter names are case sensitive.
+		Name string `json:"name" yaml:"name"`
+		// In is the location of the parameter.
+		// Possible values are "query", "header", "path" or "cookie".
+		In string `json:"in" yaml:"in"`
+		// Description is a brief description of the parameter.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		// Required determines whether this parameter is mandatory.
+		Required bool `json:"required,omitempty" yaml:"required,omitempty"`
+		// AllowEmptyValue sets the ability to pass empty-valued query
+		// parameters.
+		AllowEmptyValue bool `json:"allowEmptyValue,omitempty" yaml:"allowEmptyValue,omitempty"`
+		// Style describes how the parameter value is serialized.
+		Style string `json:"style,omitempty" yaml:"style,omitempty"`
+		// Explode specifies whether array values generate separate
+		// parameters.
+		Explode *bool `json:"explode,omitempty" yaml:"explode,omitempty"`
+		// Schema defining the type used for the parameter.
+		Schema *Schema3 `json:"schema,omitempty" yaml:"schema,omitempty"`
+		// Examples maps the names of the examples to their value.
+		Examples map[string]*Example3 `json:"examples,omitempty" yaml:"examples,omitempty"`
+		// Extensions defines the OpenAPI extensions.
+		Extensions map[string]interface{} `json:"-" yaml:"-"`
+	}
+
+	// RequestBody describes a single request body.
+	RequestBody struct {
+		// Description is a brief description of the request body.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		// Content maps the media types to their schema.
+		Content map[string]*MediaType `json:"content" yaml:"content"`
+		// Required determines if the request body is required.
+		Required bool `json:"required,omitempty" yaml:"required,omitempty"`
+	}
+
+	// MediaType provides the schema of a request or response content.
+	MediaType struct {
+		// Schema defining the content.
+		Schema *Schema3 `json:"schema,omitempty" yaml:"schema,omitempty"`
+		// Examples maps the names of the examples to their value.
+		Examples map[string]*Example3 `json:"examples,omitempty" yaml:"examples,omitempty"`
+	}
+
+	// Example3 describes a named example.
+	Example3 struct {
+		// Summary is a short description of the example.
+		Summary string `json:"summary,omitempty" yaml:"summary,omitempty"`
+		// Description is a long description of the example.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		// Value is the example value.
+		Value interface{} `json:"value,omitempty" yaml:"value,omitempty"`
+	}
+
+	// Response3 describes an operation response.
+	Response3 struct {
+		// Description of the response.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		// Headers maps the names of the headers sent with the response
+		// to their description.
+		Headers map[string]*Header3 `json:"headers,omitempty" yaml:"headers,omitempty"`
+		// Content maps the media types to their schema, nil if the
+		// response has no body.
+		Content map[string]*MediaType `json:"content,omitempty" yaml:"content,omitempty"`
+		// Ref references a response defined in the components.
+		// This field is exclusive with the other fields of Response3.
+		Ref string `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+		// Extensions defines the OpenAPI extensions.
+		Extensions map[string]interface{} `json:"-" yaml:"-"`
+	}
+
+	// Header3 describes a response header.
+	Header3 struct {
+		// Description is a brief description of the header.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		// Schema defining the type used for the header.
+		Schema *Schema3 `json:"schema,omitempty" yaml:"schema,omitempty"`
+	}
+
+	// Components holds the reusable objects of the specification.
+	Components struct {
+		// Schemas maps the names of the types to their schema.
+		Schemas map[string]*Schema3 `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+		// Responses maps names to reusable responses.
+		Responses map[string]*Response3 `json:"responses,omitempty" yaml:"responses,omitempty"`
+		// Parameters maps names to reusable parameters.
+		Parameters map[string]*Parameter3 `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+		// SecuritySchemes maps the security scheme names to their
+		// description.
+		SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty" yaml:"securitySchemes,omitempty"`
+	}
+
+	// SecurityScheme defines a security scheme that can be used by the
+	// operations.
+	SecurityScheme struct {
+		// Type of the security scheme. Valid values are "apiKey", "http",
+		// "oauth2" or "openIdConnect".
+		Type string `json:"type" yaml:"type"`
+		// Description for security scheme.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		// Name of the header or query parameter to be used when type is "apiKey".
+		Name string `json:"name,omitempty" yaml:"name,omitempty"`
+		// In is the location of the API key when type is "apiKey".
+		In string `json:"in,omitempty" yaml:"in,omitempty"`
+		// Scheme is the HTTP authorization scheme when type is "http".
+		Scheme string `json:"scheme,omitempty" yaml:"scheme,omitempty"`
+		// BearerFormat describes the format of bearer tokens.
+		BearerFormat string `json:"bearerFormat,omitempty" yaml:"bearerFormat,omitempty"`
+		// Flows lists the OAuth2 flows when type is "oauth2".
+		Flows *OAuthFlows `json:"flows,omitempty" yaml:"flows,omitempty"`
+		// OpenIDConnectURL is the OpenID Connect discovery URL when type
+		// is "openIdConnect".
+		OpenIDConnectURL string `json:"openIdConnectUrl,omitempty" yaml:"openIdConnectUrl,omitempty"`
+		// Extensions defines the OpenAPI extensions.
+		Extensions map[string]interface{} `json:"-" yaml:"-"`
+	}
+
+	// OAuthFlows lists the OAuth2 flows supported by a security scheme.
+	OAuthFlows struct {
+		Implicit          *OAuthFlow `json:"implicit,omitempty" yaml:"implicit,omitempty"`
+		Password          *OAuthFlow `json:"password,omitempty" yaml:"password,omitempty"`
+		ClientCredentials *OAuthFlow `json:"clientCredentials,omitempty" yaml:"clientCredentials,omitempty"`
+		AuthorizationCode *OAuthFlow `json:"authorizationCode,omitempty" yaml:"authorizationCode,omitempty"`
+	}
+
+	// OAuthFlow describes an OAuth2 flow.
+	OAuthFlow struct {
+		// AuthorizationURL is the authorization URL of the implicit and
+		// authorization code flows.
+		AuthorizationURL string `json:"authorizationUrl,omitempty" yaml:"authorizationUrl,omitempty"`
+		// TokenURL is the token URL of the password, client credentials
+		// and authorization code flows.
+		TokenURL string `json:"tokenUrl,omitempty" yaml:"tokenUrl,omitempty"`
+		// RefreshURL is the URL used to obtain refresh tokens.
+		RefreshURL string `json:"refreshUrl,omitempty" yaml:"refreshUrl,omitempty"`
+		// Scopes maps the available scopes to their description.
+		Scopes map[string]string `json:"scopes" yaml:"scopes"`
+	}
+
+	// Schema3 represents a JSON schema using the 2020-12 dialect.
+	Schema3 struct {
+		// Schema is the URI of the dialect of standalone schemas.
+		Schema string `json:"$schema,omitempty" yaml:"$schema,omitempty"`
+		// Type is either a JSON type or a list of JSON types for
+		// nullable values.
+		Type                 interface{}         `json:"type,omitempty" yaml:"type,omitempty"`
+		Title                string              `json:"title,omitempty" yaml:"title,omitempty"`
+		Description          string              `json:"description,omitempty" yaml:"description,omitempty"`
+		Ref                  string              `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+		Items                *Schema3            `json:"items,omitempty" yaml:"items,omitempty"`
+		Properties           map[string]*Schema3 `json:"properties,omitempty" yaml:"properties,omitempty"`
+		Required             []string            `json:"required,omitempty" yaml:"required,omitempty"`
+		AdditionalProperties bool                `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+		DefaultValue         interface{}         `json:"default,omitempty" yaml:"default,omitempty"`
+		Examples             []interface{}       `json:"examples,omitempty" yaml:"examples,omitempty"`
+		ReadOnly             bool                `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`
+		Const                interface{}         `json:"const,omitempty" yaml:"const,omitempty"`
+		Enum                 []interface{}       `json:"enum,omitempty" yaml:"enum,omitempty"`
+		Format               string              `json:"format,omitempty" yaml:"format,omitempty"`
+		ContentMediaType     string              `json:"contentMediaType,omitempty" yaml:"contentMediaType,omitempty"`
+		Pattern              string              `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+		Minimum              *float64            `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+		Maximum              *float64            `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+		ExclusiveMinimum     *float64            `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"`
+		ExclusiveMaximum     *float64            `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
+		MultipleOf           *float64            `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
+		MinLength            *int                `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+		MaxLength            *int                `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+		MinItems             *int                `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+		MaxItems             *int                `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+		UniqueItems          bool                `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
+		AnyOf                []*Schema3          `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
+		// Defs contains the schemas referenced by standalone schemas.
+		Defs map[string]*Schema3 `json:"$defs,omitempty" yaml:"$defs,omitempty"`
+		// Extensions defines the OpenAPI extensions.
+		Extensions map[string]interface{} `json:"-" yaml:"-"`
+	}
+
+	// These types are used in marshalJSON() to avoid recursive call of json.Marshal().
+	_PathItem       PathItem
+	_Operation3     Operation3
+	_Parameter3     Parameter3
+	_Response3      Response3
+	_SecurityScheme SecurityScheme
+	_Schema3        Schema3
+)
+
+// MarshalJSON returns the JSON encoding of p.
+func (p PathItem) MarshalJSON() ([]byte, error) {
+	return marshalJSON(_PathItem(p), p.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of o.
+func (o Operation3) MarshalJSON() ([]byte, error) {
+	return marshalJSON(_Operation3(o), o.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of p.
+func (p Parameter3) MarshalJSON() ([]byte, error) {
+	return marshalJSON(_Parameter3(p), p.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of r.
+func (r Response3) MarshalJSON() ([]byte, error) {
+	return marshalJSON(_Response3(r), r.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of s.
+func (s SecurityScheme) MarshalJSON() ([]byte, error) {
+	return marshalJSON(_SecurityScheme(s), s.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of s.
+func (s Schema3) MarshalJSON() ([]byte, error) {
+	return marshalJSON(_Schema3(s), s.Extensions)
+}
+
+// MarshalYAML returns value which marshaled in place of the original value
+func (p PathItem) MarshalYAML() (interface{}, error) {
+	return marshalYAML(_PathItem(p), p.Extensions)
+}
+
+// MarshalYAML returns value which marshaled in place of the original value
+func (o Operation3) MarshalYAML() (interface{}, error) {
+	return marshalYAML(_Operation3(o), o.Extensions)
+}
+
+// MarshalYAML returns value which marshaled in place of the original value
+func (p Parameter3) MarshalYAML() (interface{}, error) {
+	return marshalYAML(_Parameter3(p), p.Extensions)
+}
+
+// MarshalYAML returns value which marshaled in place of the original value
+func (r Response3) MarshalYAML() (interface{}, error) {
+	return marshalYAML(_Response3(r), r.Extensions)
+}
+
+// MarshalYAML returns value which marshaled in place of the original value
+func (s SecurityScheme) MarshalYAML() (interface{}, error) {
+	return marshalYAML(_SecurityScheme(s), s.Extensions)
+}
+
+// MarshalYAML returns value which marshaled in place of the original value
+func (s Schema3) MarshalYAML() (interface{}, error) {
+	return marshalYAML(_Schema3(s), s.Extensions)
+}
+
+// operations returns the operations of the path item indexed by HTTP method.
+func (p *PathItem) operations() map[string]**Operation3 {
+	return map[string]**Operation3{
+		"GET":     &p.Get,
+		"PUT":     &p.Put,
+		"POST":    &p.Post,
+		"DELETE":  &p.Delete,
+		"OPTIONS": &p.Options,
+		"HEAD":    &p.Head,
+		"PATCH":   &p.Patch,
+	}
+}
+
+// isEmpty returns true if the path item defines no operation.
+func (p *PathItem) isEmpty() bool {
+	for _, op := range p.operations() {
+		if *op != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,633 @@
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"goa.design/goa/v3/expr"
+)
+
+// NewV3 returns the OpenAPI 3.1 specification for the given API. The
+// specification describes the same operations and types as the OpenAPI 2.0
+// specification returned by NewV2 using the OpenAPI 3.1 objects and the JSON
+// schema 2020-12 dialect:
+//
+//   - the attributes that define the "openapi:nullable" meta use a list of
+//     types that includes "null",
+//   - examples are listed with "examples" and single value enums use "const",
+//   - the methods that define the "openapi:webhook" meta are listed in the
+//     webhooks section under the meta value instead of the paths.
+//   - the webhooks defined with the Webhook DSL are listed in the webhooks
+//     section as POST operations whose request body is signed.
+func NewV3(root *expr.RootExpr, h *expr.HostExpr) (*V3, error) {
+	v2, err := NewV2(root, h)
+	if err != nil || v2 == nil {
+		return nil, err
+	}
+	s := &V3{
+		OpenAPI:           "3.1.0",
+		Info:              v2.Info,
+		JSONSchemaDialect: JSONSchemaDialect,
+		Servers:           serversFromRoot(root, h, v2.BasePath),
+		Paths:             make(map[string]interface{}),
+		Components:        componentsFromV2(root, v2),
+		Tags:              v2.Tags,
+		TagGroups:         v2.TagGroups,
+		ExternalDocs:      v2.ExternalDocs,
+	}
+	webhooks := webhooksFromExpr(root)
+	for key, p := range v2.Paths {
+		path, ok := p.(*Path)
+		if !ok {
+			// extension
+			s.Paths[key] = p
+			continue
+		}
+		item := &PathItem{Extensions: path.Extensions}
+		ops := map[string]*Operation{
+			"GET":     path.Get,
+			"PUT":     path.Put,
+			"POST":    path.Post,
+			"DELETE":  path.Delete,
+			"OPTIONS": path.Options,
+			"HEAD":    path.Head,
+			"PATCH":   path.Patch,
+		}
+		for method, op := range ops {
+			if op == nil {
+				continue
+			}
+			o := operationFromV2(v2, op)
+			name, ok := webhooks[strings.TrimSuffix(op.OperationID, "#head")]
+			if !ok {
+				*item.operations()[method] = o
+				continue
+			}
+			// Webhook requests are not sent to the API so the path
+			// parameters do not apply.
+			params := o.Parameters[:0]
+			for _, p := range o.Parameters {
+				if p.In != "path" {
+					params = append(params, p)
+				}
+			}
+			o.Parameters = params
+			if s.Webhooks == nil {
+				s.Webhooks = make(map[string]*PathItem)
+			}
+			wh, ok := s.Webhooks[name]
+			if !ok {
+				wh = &PathItem{}
+				s.Webhooks[name] = wh
+			}
+			*wh.operations()[method] = o
+		}
+		if !item.isEmpty() {
+			s.Paths[key] = item
+		}
+	}
+	webhooksFromDSL(root, s)
+	return s, nil
+}
+
+// webhooksFromDSL adds the webhooks defined with the Webhook DSL to the webhooks
+// section of s. The schemas of the webhook bodies are added to the components.
+func webhooksFromDSL(root *expr.RootExpr, s *V3) {
+	var found bool
+	for _, svc := range root.API.HTTP.Services {
+		if !mustGenerate(svc.Meta) || !mustGenerate(svc.ServiceExpr.Meta) {
+			continue
+		}
+		for _, w := range svc.Webhooks {
+			if !mustGenerate(w.Meta) {
+				continue
+			}
+			found = true
+			if s.Webhooks == nil {
+				s.Webhooks = make(map[string]*PathItem)
+			}
+			s.Webhooks[w.Name] = &PathItem{Post: &Operation3{
+				Tags:        []string{svc.Name()},
+				Summary:     fmt.Sprintf("%s webhook", w.Name),
+				Description: w.Description,
+				OperationID: fmt.Sprintf("%s#webhook#%s", svc.Name(), w.Name),
+				Parameters: []*Parameter3{{
+					Name:        "Webhook-Signature",
+					In:          "header",
+					Description: "Timestamp and HMAC-SHA256 signature of the request body, e.g. t=1600000000,v1=5257a869...",
+					Required:    true,
+					Schema:      &Schema3{Type: "string"},
+				}},
+				RequestBody: &RequestBody{
+					Required: true,
+					Content: map[string]*MediaType{
+						"application/json": {Schema: schemaFromV2(AttributeTypeSchemaWithPrefix(root.API, w.Body, svc.Name()))},
+					},
+				},
+				Responses: map[string]*Response3{
+					"2XX": {Description: "The webhook was received."},
+				},
+				Extensions: ExtensionsFromExpr(w.Meta),
+			}}
+		}
+	}
+	if !found {
+		return
+	}
+	// Add the definitions of the webhook body types.
+	for n, d := range Definitions {
+		if s.Components == nil {
+			s.Components = &Components{}
+		}
+		if s.Components.Schemas == nil {
+			s.Components.Schemas = make(map[string]*Schema3)
+		}
+		if _, ok := s.Components.Schemas[n]; !ok {
+			s.Components.Schemas[n] = schemaFromV2(d)
+		}
+	}
+}
+
+// webhooksFromExpr returns the names of the webhooks indexed by the ID of the
+// corresponding operations.
+func webhooksFromExpr(root *expr.RootExpr) map[string]string {
+	res := make(map[string]string)
+	for _, svc := range root.API.HTTP.Services {
+		for _, e := range svc.HTTPEndpoints {
+			name, ok := e.MethodExpr.Meta.Last("openapi:webhook")
+			if !ok {
+				continue
+			}
+			id := fmt.Sprintf("%s#%s", svc.Name(), e.Name())
+			for i := range e.Routes {
+				if i == 0 {
+					res[id] = name
+				} else {
+					res[fmt.Sprintf("%s#%d", id, i)] = name
+				}
+			}
+		}
+	}
+	return res
+}
+
+// serversFromRoot returns the servers corresponding to the HTTP URIs of the
+// given host followed by the servers corresponding to the other hosts of the
+// design servers so that the specification lists all the environments. The
+// servers of hosts without description are described with the host name when
+// there are multiple hosts.
+func serversFromRoot(root *expr.RootExpr, h *expr.HostExpr, basePath string) []*Server {
+	hosts := []*expr.HostExpr{h}
+	for _, svr := range root.API.Servers {
+		for _, sh := range svr.Hosts {
+			if sh != h {
+				hosts = append(hosts, sh)
+			}
+		}
+	}
+	var servers []*Server
+	for _, sh := range hosts {
+		srvs := serversFromExpr(sh, basePath)
+		if len(hosts) > 1 {
+			for _, srv := range srvs {
+				if srv.Description == "" {
+					srv.Description = sh.Name
+				}
+			}
+		}
+		servers = append(servers, srvs...)
+	}
+	return servers
+}
+
+// serversFromExpr returns the servers corresponding to the HTTP URIs of the
+// given host.
+func serversFromExpr(h *expr.HostExpr, basePath string) []*Server {
+	var servers []*Server
+	for _, u := range h.URIs {
+		ustr := string(u)
+		if !strings.HasPrefix(ustr, "http://") && !strings.HasPrefix(ustr, "https://") {
+			continue
+		}
+		srv := &Server{
+			URL:         strings.TrimSuffix(ustr, "/") + strings.TrimSuffix(basePath, "/"),
+			Description: h.Description,
+		}
+		for _, p := range u.Params() {
+			v := h.Variables.Find(p)
+			if v == nil {
+				continue
+			}
+			sv := &ServerVariable{Description: v.Description}
+			if v.Validation != nil {
+				for _, e := range v.Validation.Values {
+					sv.Enum = append(sv.Enum, fmt.Sprintf("%v", e))
+				}
+			}
+			if v.DefaultValue != nil {
+				sv.Default = fmt.Sprintf("%v", v.DefaultValue)
+			} else if len(sv.Enum) > 0 {
+				sv.Default = sv.Enum[0]
+			}
+			if srv.Variables == nil {
+				srv.Variables = make(map[string]*ServerVariable)
+			}
+			srv.Variables[p] = sv
+		}
+		servers = append(servers, srv)
+	}
+	return servers
+}
+
+// componentsFromV2 returns the components of the OpenAPI 3.1 specification
+// corresponding to the definitions of the given OpenAPI 2.0 specification.
+func componentsFromV2(root *expr.RootExpr, v2 *V2) *Components {
+	c := &Components{SecuritySchemes: securitySchemesFromExpr(root, v2.SecurityDefinitions)}
+	if len(v2.Definitions) > 0 {
+		c.Schemas = make(map[string]*Schema3, len(v2.Definitions))
+		for n, d := range v2.Definitions {
+			c.Schemas[n] = schemaFromV2(d)
+		}
+	}
+	if len(v2.Responses) > 0 {
+		c.Responses = make(map[string]*Response3, len(v2.Responses))
+		for n, r := range v2.Responses {
+			c.Responses[n] = responseFromV2(r, v2.Produces)
+		}
+	}
+	if len(v2.Parameters) > 0 {
+		c.Parameters = make(map[string]*Parameter3, len(v2.Parameters))
+		for n, p := range v2.Parameters {
+			c.Parameters[n] = parameterFromV2(p)
+		}
+	}
+	if c.Schemas == nil && c.Responses == nil && c.Parameters == nil && c.SecuritySchemes == nil {
+		return nil
+	}
+	return c
+}
+
+// securitySchemesFromExpr returns the OpenAPI 3.1 security schemes of the
+// security design. The descriptions are the ones of the given OpenAPI 2.0
+// security definitions.
+func securitySchemesFromExpr(root *expr.RootExpr, defs map[string]*SecurityDefinition) map[string]*SecurityScheme {
+	var res map[string]*SecurityScheme
+	for _, svc := range root.API.HTTP.Services {
+		for _, e := range svc.HTTPEndpoints {
+			for _, req := range e.Requirements {
+				for _, s := range requirementSchemes(e, req) {
+					sd, ok := defs[s.Hash()]
+					if !ok {
+						if s.Kind != expr.MTLSKind {
+							continue
+						}
+						// OpenAPI v2 does not define mutual TLS
+						// security schemes.
+						sd = &SecurityDefinition{
+							Description: s.Description,
+							Extensions:  ExtensionsFromExpr(s.Meta),
+						}
+						addScopeDescription(s.Scopes, sd)
+					}
+					ss := &SecurityScheme{
+						Description: sd.Description,
+						Extensions:  sd.Extensions,
+					}
+					switch s.Kind {
+					case expr.BasicAuthKind:
+						ss.Type = "http"
+						ss.Scheme = "basic"
+					case expr.APIKeyKind:
+						ss.Type = "apiKey"
+						ss.In = s.In
+						ss.Name = s.Name
+					case expr.JWTKind:
+						if s.In == "header" && s.Name == "Authorization" {
+							ss.Type = "http"
+							ss.Scheme = "bearer"
+							ss.BearerFormat = "JWT"
+						} else {
+							ss.Type = "apiKey"
+							ss.In = s.In
+							ss.Name = s.Name
+						}
+					case expr.OAuth2Kind:
+						ss.Type = "oauth2"
+						ss.Flows = flowsFromExpr(s)
+					case expr.MTLSKind:
+						ss.Type = "mutualTLS"
+					case expr.SignatureKind:
+						ss.Type = "apiKey"
+						ss.In = s.In
+						ss.Name = s.Name
+					}
+					if s.OpenIDConnectURL != "" {
+						ss = &SecurityScheme{
+							Type:             "openIdConnect",
+							Description:      ss.Description,
+							OpenIDConnectURL: s.OpenIDConnectURL,
+							Extensions:       ss.Extensions,
+						}
+					}
+					if res == nil {
+						res = make(map[string]*SecurityScheme)
+					}
+					res[s.Hash()] = ss
+				}
+			}
+		}
+	}
+	return res
+}
+
+// flowsFromExpr returns the OAuth2 flows of the given security scheme.
+func flowsFromExpr(s *expr.SchemeExpr) *OAuthFlows {
+	scopes := make(map[string]string, len(s.Scopes))
+	for _, scope := range s.Scopes {
+		scopes[scope.Name] = scope.Description
+	}
+	flows := &OAuthFlows{}
+	for _, f := range s.Flows {
+		flow := &OAuthFlow{
+			AuthorizationURL: f.AuthorizationURL,
+			TokenURL:         f.TokenURL,
+			RefreshURL:       f.RefreshURL,
+			Scopes:           scopes,
+		}
+		if len(f.Scopes) > 0 {
+			flow.Scopes = scopesFromExpr(f.Scopes)
+		}
+		switch f.Kind {
+		case expr.AuthorizationCodeFlowKind:
+			flows.AuthorizationCode = flow
+		case expr.ImplicitFlowKind:
+			flows.Implicit = flow
+		case expr.PasswordFlowKind:
+			flows.Password = flow
+		case expr.ClientCredentialsFlowKind:
+			flows.ClientCredentials = flow
+		}
+	}
+	return flows
+}
+
+// operationFromV2 returns the OpenAPI 3.1 operation corresponding to the given
+// OpenAPI 2.0 operation. The body parameters become the request body.
+func operationFromV2(v2 *V2, op *Operation) *Operation3 {
+	o := &Operation3{
+		Tags:         op.Tags,
+		Summary:      op.Summary,
+		Description:  op.Description,
+		ExternalDocs: op.ExternalDocs,
+		OperationID:  op.OperationID,
+		Deprecated:   op.Deprecated,
+		Security:     op.requirements,
+		Extensions:   op.Extensions,
+	}
+	for _, p := range op.Parameters {
+		if p.In != "body" && p.In != "formData" {
+			o.Parameters = append(o.Parameters, parameterFromV2(p))
+			continue
+		}
+		consumes := op.Consumes
+		if len(consumes) == 0 {
+			consumes = v2.Consumes
+		}
+		o.RequestBody = &RequestBody{
+			Description: p.Description,
+			Content:     contentFromV2(p.Schema, consumes, p.examples),
+			Required:    p.Required,
+		}
+	}
+	produces := op.Produces
+	if len(produces) == 0 {
+		produces = v2.Produces
+	}
+	if len(op.Responses) > 0 {
+		o.Responses = make(map[string]*Response3, len(op.Responses))
+		for code, r := range op.Responses {
+			o.Responses[code] = responseFromV2(r, produces)
+		}
+	}
+	return o
+}
+
+// responseFromV2 returns the OpenAPI 3.1 response corresponding to the given
+// OpenAPI 2.0 response. The response body uses the given media types.
+func responseFromV2(r *Response, produces []string) *Response3 {
+	if r.Ref != "" {
+		return &Response3{Ref: strings.Replace(r.Ref, "#/responses/", "#/components/responses/", 1)}
+	}
+	resp := &Response3{
+		Description: r.Description,
+		Extensions:  r.Extensions,
+	}
+	if r.Schema != nil {
+		if r.Schema.Ref == problemDetailsRef {
+			produces = []string{"application/problem+json"}
+		} else if r.contentType != "" {
+			produces = []string{r.contentType}
+		}
+		resp.Content = contentFromV2(r.Schema, produces, r.examples)
+	}
+	if len(r.Headers) > 0 {
+		resp.Headers = make(map[string]*Header3, len(r.Headers))
+		for n, h := range r.Headers {
+			resp.Headers[n] = &Header3{
+				Description: h.Description,
+				Schema: simpleSchemaFromV2(&Items{
+					Type:             h.Type,
+					Format:           h.Format,
+					Items:            h.Items,
+					Default:          h.Default,
+					Maximum:          h.Maximum,
+					ExclusiveMaximum: h.ExclusiveMaximum,
+					Minimum:          h.Minimum,
+					ExclusiveMinimum: h.ExclusiveMinimum,
+					MaxLength:        h.MaxLength,
+					MinLength:        h.MinLength,
+					Pattern:          h.Pattern,
+					MaxItems:         h.MaxItems,
+					MinItems:         h.MinItems,
+					Enum:             h.Enum,
+					MultipleOf:       h.MultipleOf,
+				}),
+			}
+		}
+	}
+	return resp
+}
+
+// contentFromV2 returns the content using the given schema and examples for
+// each media type. The content uses the JSON media type if there is no media
+// type.
+func contentFromV2(s *Schema, mediaTypes []string, examples map[string]*Example3) map[string]*MediaType {
+	if len(mediaTypes) == 0 {
+		mediaTypes = []string{"application/json"}
+	}
+	content := make(map[string]*MediaType, len(mediaTypes))
+	for _, mt := range mediaTypes {
+		content[mt] = &MediaType{Schema: schemaFromV2(s), Examples: examples}
+	}
+	return content
+}
+
+// parameterFromV2 returns the OpenAPI 3.1 parameter corresponding to the given
+// OpenAPI 2.0 non-body parameter.
+func parameterFromV2(p *Parameter) *Parameter3 {
+	param := &Parameter3{
+		Name:            p.Name,
+		In:              p.In,
+		Description:     p.Description,
+		Required:        p.Required,
+		AllowEmptyValue: p.AllowEmptyValue,
+		Examples:        p.examples,
+		Extensions:      p.Extensions,
+		Schema: simpleSchemaFromV2(&Items{
+			Type:             p.Type,
+			Format:           p.Format,
+			Items:            p.Items,
+			Default:          p.Default,
+			Maximum:          p.Maximum,
+			ExclusiveMaximum: p.ExclusiveMaximum,
+			Minimum:          p.Minimum,
+			ExclusiveMinimum: p.ExclusiveMinimum,
+			MaxLength:        p.MaxLength,
+			MinLength:        p.MinLength,
+			Pattern:          p.Pattern,
+			MaxItems:         p.MaxItems,
+			MinItems:         p.MinItems,
+			Enum:             p.Enum,
+			MultipleOf:       p.MultipleOf,
+		}),
+	}
+	if p.In == "query" {
+		explode := false
+		switch p.CollectionFormat {
+		case "csv":
+			param.Explode = &explode
+		case "ssv":
+			param.Style = "spaceDelimited"
+			param.Explode = &explode
+		case "pipes":
+			param.Style = "pipeDelimited"
+			param.Explode = &explode
+		}
+	}
+	return param
+}
+
+// simpleSchemaFromV2 returns the JSON schema described by the given OpenAPI
+// 2.0 items.
+func simpleSchemaFromV2(items *Items) *Schema3 {
+	if items == nil {
+		return nil
+	}
+	s := &Schema3{
+		Format:       items.Format,
+		Items:        simpleSchemaFromV2(items.Items),
+		DefaultValue: items.Default,
+		Minimum:      items.Minimum,
+		Maximum:      items.Maximum,
+		MinLength:    items.MinLength,
+		MaxLength:    items.MaxLength,
+		MinItems:     items.MinItems,
+		MaxItems:     items.MaxItems,
+		Pattern:      items.Pattern,
+	}
+	if items.Type != "" {
+		s.Type = items.Type
+	}
+	if items.ExclusiveMinimum {
+		s.ExclusiveMinimum, s.Minimum = s.Minimum, nil
+	}
+	if items.ExclusiveMaximum {
+		s.ExclusiveMaximum, s.Maximum = s.Maximum, nil
+	}
+	if items.MultipleOf != 0 {
+		mult := items.MultipleOf
+		s.MultipleOf = &mult
+	}
+	setEnum(s, items.Enum, false)
+	return s
+}
+
+// schemaFromV2 returns the JSON schema using the 2020-12 dialect corresponding
+// to the given JSON schema.
+func schemaFromV2(s *Schema) *Schema3 {
+	if s == nil {
+		return nil
+	}
+	if s.Ref != "" {
+		ref := &Schema3{Ref: strings.Replace(s.Ref, "#/definitions/", "#/components/schemas/", 1)}
+		if s.Nullable {
+			return &Schema3{AnyOf: []*Schema3{ref, {Type: Null}}, Extensions: s.Extensions}
+		}
+		ref.Extensions = s.Extensions
+		return ref
+	}
+	res := &Schema3{
+		Title:                s.Title,
+		Description:          s.Description,
+		Items:                schemaFromV2(s.Items),
+		Required:             s.Required,
+		AdditionalProperties: s.AdditionalProperties,
+		DefaultValue:         s.DefaultValue,
+		ReadOnly:             s.ReadOnly,
+		Format:               s.Format,
+		Pattern:              s.Pattern,
+		Minimum:              s.Minimum,
+		Maximum:              s.Maximum,
+		MinLength:            s.MinLength,
+		MaxLength:            s.MaxLength,
+		MinItems:             s.MinItems,
+		MaxItems:             s.MaxItems,
+		MultipleOf:           s.MultipleOf,
+		UniqueItems:          s.UniqueItems,
+		ContentMediaType:     s.ContentMediaType,
+		Extensions:           s.validationExtensions(false),
+	}
+	if s.ExclusiveMinimum {
+		res.ExclusiveMinimum, res.Minimum = res.Minimum, nil
+	}
+	if s.ExclusiveMaximum {
+		res.ExclusiveMaximum, res.Maximum = res.Maximum, nil
+	}
+	switch {
+	case s.Type == File:
+		res.Type = String
+		res.ContentMediaType = "application/octet-stream"
+	case s.Type != "" && s.Nullable:
+		res.Type = []string{string(s.Type), Null}
+	case s.Type != "":
+		res.Type = string(s.Type)
+	}
+	if s.Example != nil {
+		res.Examples = []interface{}{s.Example}
+	}
+	setEnum(res, s.Enum, s.Nullable)
+	if len(s.Properties) > 0 {
+		res.Properties = make(map[string]*Schema3, len(s.Properties))
+		for n, p := range s.Properties {
+			res.Properties[n] = schemaFromV2(p)
+		}
+	}
+	for _, a := range s.AnyOf {
+		res.AnyOf = append(res.AnyOf, schemaFromV2(a))
+	}
+	return res
+}
+
+// setEnum initializes the enum validation of s with the given values. A single
+// value is set with const unless the value may be null.
+func setEnum(s *Schema3, values []interface{}, nullable bool) {
+	switch {
+	case len(values) == 0:
+	case nullable:
+		s.Enum = append(append([]interface{}{}, values...), nil)
+	case len(values) == 1:
+		s.Const = values[0]
+	default:
+		s.Enum = values
+	}
+}
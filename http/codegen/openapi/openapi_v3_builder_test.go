@@ -0,0 +1,105 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSchemaFromV2(t *testing.T) {
+	ten := 10
+	cases := map[string]struct {
+		schema   *Schema
+		expected string
+	}{
+		"ref": {
+			schema:   &Schema{Ref: "#/definitions/Foo"},
+			expected: `{"$ref":"#/components/schemas/Foo"}`,
+		},
+		"nullable-ref": {
+			schema:   &Schema{Ref: "#/definitions/Foo", Nullable: true},
+			expected: `{"anyOf":[{"$ref":"#/components/schemas/Foo"},{"type":"null"}]}`,
+		},
+		"nullable": {
+			schema:   &Schema{Type: String, Nullable: true},
+			expected: `{"type":["string","null"]}`,
+		},
+		"example": {
+			schema:   &Schema{Type: Integer, Example: 1},
+			expected: `{"type":"integer","examples":[1]}`,
+		},
+		"const": {
+			schema:   &Schema{Type: String, Enum: []interface{}{"a"}},
+			expected: `{"type":"string","const":"a"}`,
+		},
+		"nullable-enum": {
+			schema:   &Schema{Type: String, Enum: []interface{}{"a"}, Nullable: true},
+			expected: `{"type":["string","null"],"enum":["a",null]}`,
+		},
+		"file": {
+			schema:   &Schema{Type: File},
+			expected: `{"type":"string","contentMediaType":"application/octet-stream"}`,
+		},
+		"array": {
+			schema:   &Schema{Type: Array, Items: &Schema{Ref: "#/definitions/Foo"}},
+			expected: `{"type":"array","items":{"$ref":"#/components/schemas/Foo"}}`,
+		},
+		"unique-by": {
+			schema:   &Schema{Type: Array, Items: &Schema{Ref: "#/definitions/Foo"}, UniqueItems: true, UniqueBy: "id"},
+			expected: `{"items":{"$ref":"#/components/schemas/Foo"},"type":"array","uniqueItems":true,"x-uniqueBy":"id"}`,
+		},
+		"max-bytes": {
+			schema:   &Schema{Type: String, MaxBytes: &ten},
+			expected: `{"type":"string","x-maxBytes":10}`,
+		},
+		"content-media-type": {
+			schema:   &Schema{Type: String, Format: "byte", ContentMediaType: "image/*"},
+			expected: `{"type":"string","format":"byte","contentMediaType":"image/*"}`,
+		},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			b, err := json.Marshal(schemaFromV2(tc.schema))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tc.expected {
+				t.Errorf("got %s, expected %s", string(b), tc.expected)
+			}
+		})
+	}
+}
+
+func TestParameterFromV2(t *testing.T) {
+	cases := map[string]struct {
+		param    *Parameter
+		expected string
+	}{
+		"path": {
+			param:    &Parameter{Name: "id", In: "path", Required: true, Type: "string"},
+			expected: `{"name":"id","in":"path","required":true,"schema":{"type":"string"}}`,
+		},
+		"multi": {
+			param:    &Parameter{Name: "ids", In: "query", Type: "array", Items: &Items{Type: "integer"}, CollectionFormat: "multi"},
+			expected: `{"name":"ids","in":"query","schema":{"type":"array","items":{"type":"integer"}}}`,
+		},
+		"csv": {
+			param:    &Parameter{Name: "ids", In: "query", Type: "array", Items: &Items{Type: "integer"}, CollectionFormat: "csv"},
+			expected: `{"name":"ids","in":"query","explode":false,"schema":{"type":"array","items":{"type":"integer"}}}`,
+		},
+		"pipes": {
+			param:    &Parameter{Name: "ids", In: "query", Type: "array", Items: &Items{Type: "integer"}, CollectionFormat: "pipes"},
+			expected: `{"name":"ids","in":"query","style":"pipeDelimited","explode":false,"schema":{"type":"array","items":{"type":"integer"}}}`,
+		},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			b, err := json.Marshal(parameterFromV2(tc.param))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tc.expected {
+				t.Errorf("got %s, expected %s", string(b), tc.expected)
+			}
+		})
+	}
+}
@@ -8,10 +8,12 @@ import (
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
+	"strings"
 	"testing"
 	"text/template"
 
 	"github.com/go-openapi/loads"
+	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/http/codegen/openapi"
 	"goa.design/goa/v3/http/codegen/testdata"
 )
@@ -77,9 +79,22 @@ func TestSections(t *testing.T) {
 		{"multiple-views", testdata.MultipleViewsDSL},
 		{"explicit-view", testdata.ExplicitViewDSL},
 		{"security", testdata.SecurityDSL},
+		{"security-flows", testdata.SecurityFlowsDSL},
+		{"security-scopes", testdata.SecurityScopesDSL},
+		{"security-mtls", testdata.SecurityMTLSDSL},
+		{"security-signature", testdata.SecuritySignatureDSL},
+		{"security-csrf", testdata.SecurityCSRFDSL},
+		{"security-chain", testdata.SecurityChainDSL},
+		{"apikey-locations", testdata.APIKeyLocationsOpenAPIDSL},
+		{"security-realm", testdata.SecurityRealmDSL},
+		{"tag-groups", testdata.TagGroupsDSL},
 		{"server-host-with-variables", testdata.ServerHostWithVariablesDSL},
 		{"with-spaces", testdata.WithSpacesDSL},
 		{"with-map", testdata.WithMapDSL},
+		{"problem-details", testdata.ProblemDetailsDSL},
+		{"auto-head-options", testdata.AutoHeadOptionsDSL},
+		{"health-check", testdata.HealthCheckDSL},
+		{"health-check-hidden", testdata.HealthCheckHiddenDSL},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -132,6 +147,198 @@ func TestSections(t *testing.T) {
 	}
 }
 
+func TestSectionsV3(t *testing.T) {
+	var (
+		goldenPath = filepath.Join("testdata", "openapi_v3", t.Name())
+	)
+	cases := []struct {
+		Name string
+		DSL  func()
+	}{
+		{"openapi-v3", testdata.OpenAPIV3DSL},
+		{"webhooks", testdata.OpenAPIV3WebhookDSL},
+		{"named-examples", testdata.OpenAPIV3NamedExamplesDSL},
+		{"error-responses", testdata.OpenAPIV3ErrorResponsesDSL},
+		{"servers", testdata.OpenAPIV3ServersDSL},
+		{"health-check", testdata.HealthCheckV3DSL},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			// Reset global variables
+			openapi.Definitions = make(map[string]*openapi.Schema)
+			root := RunHTTPDSL(t, c.DSL)
+			oFiles, err := OpenAPIFiles(root)
+			if err != nil {
+				t.Fatalf("OpenAPI failed with %s", err)
+			}
+			if len(oFiles) != 4 {
+				t.Fatalf("got %d files, expected 4", len(oFiles))
+			}
+			if oFiles[2].Path != filepath.Join("gen", "http", "openapi3.json") {
+				t.Errorf("invalid output path %#v", oFiles[2].Path)
+			}
+			if oFiles[3].Path != filepath.Join("gen", "http", "openapi3.yaml") {
+				t.Errorf("invalid output path %#v", oFiles[3].Path)
+			}
+			for i, o := range oFiles[2:] {
+				tname := fmt.Sprintf("file%d", i)
+				s := o.SectionTemplates
+				t.Run(tname, func(t *testing.T) {
+					if len(s) != 1 {
+						t.Fatalf("expected 1 section, got %d", len(s))
+					}
+					var buf bytes.Buffer
+					tmpl := template.Must(template.New("openapi").Funcs(s[0].FuncMap).Parse(s[0].Source))
+					if err := tmpl.Execute(&buf, s[0].Data); err != nil {
+						t.Fatalf("failed to render template: %s", err)
+					}
+
+					golden := filepath.Join(goldenPath, fmt.Sprintf("%s_%s.golden", c.Name, tname))
+					if *update {
+						if err := ioutil.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+							t.Fatalf("failed to update golden file: %s", err)
+						}
+					}
+
+					want, err := ioutil.ReadFile(golden)
+					if err != nil {
+						t.Fatalf("failed to read golden file: %s", err)
+					}
+					if !bytes.Equal(buf.Bytes(), want) {
+						t.Errorf("result do not match the golden file:\n--BEGIN--\n%s\n--END--\n", buf.Bytes())
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	var (
+		goldenPath = filepath.Join("testdata", "openapi_v2", t.Name())
+	)
+	// Reset global variables
+	openapi.Definitions = make(map[string]*openapi.Schema)
+	root := RunHTTPDSL(t, testdata.SplitDSL)
+	oFiles, err := OpenAPIFiles(root)
+	if err != nil {
+		t.Fatalf("OpenAPI failed with %s", err)
+	}
+	var paths []string
+	for _, o := range oFiles[2:] {
+		paths = append(paths, filepath.ToSlash(o.Path))
+	}
+	expected := []string{
+		"gen/http/openapi/definitions.json",
+		"gen/http/openapi/openapi.json",
+		"gen/http/openapi/paths/pets.json",
+		"gen/http/openapi/paths/store.json",
+		"gen/http/openapi/definitions.yaml",
+		"gen/http/openapi/openapi.yaml",
+		"gen/http/openapi/paths/pets.yaml",
+		"gen/http/openapi/paths/store.yaml",
+	}
+	if fmt.Sprint(paths) != fmt.Sprint(expected) {
+		t.Fatalf("got files %v, expected %v", paths, expected)
+	}
+	for _, o := range oFiles[2:] {
+		name := strings.Replace(strings.TrimPrefix(filepath.ToSlash(o.Path), "gen/http/openapi/"), "/", "_", -1)
+		s := o.SectionTemplates
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tmpl := template.Must(template.New("openapi").Funcs(s[0].FuncMap).Parse(s[0].Source))
+			if err := tmpl.Execute(&buf, s[0].Data); err != nil {
+				t.Fatalf("failed to render template: %s", err)
+			}
+
+			golden := filepath.Join(goldenPath, name+".golden")
+			if *update {
+				if err := ioutil.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+					t.Fatalf("failed to update golden file: %s", err)
+				}
+			}
+
+			want, err := ioutil.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %s", err)
+			}
+			if !bytes.Equal(buf.Bytes(), want) {
+				t.Errorf("result do not match the golden file:\n--BEGIN--\n%s\n--END--\n", buf.Bytes())
+			}
+		})
+	}
+}
+
+func TestOpenAPIInvalidVersion(t *testing.T) {
+	// Reset global variables
+	openapi.Definitions = make(map[string]*openapi.Schema)
+	root := RunHTTPDSL(t, testdata.OpenAPIInvalidVersionDSL)
+	if _, err := OpenAPIFiles(root); err == nil {
+		t.Error("got no error, expected unsupported OpenAPI version error")
+	}
+}
+
+func TestOpenAPILint(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.OpenAPILintDSL, ""},
+		{"error", testdata.OpenAPILintErrorDSL, "OpenAPI specification does not pass lint rules:\n" +
+			filepath.Join("testdata", "openapi_lint_dsls.go") + `:42: operation-description: operation "testService#undescribed" (GET /undescribed) has no description, use Description in the method DSL`},
+		{"invalid-rule", testdata.OpenAPILintInvalidRuleDSL, `unknown OpenAPI lint rule "unknown" in meta "openapi:lint:unknown"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			// Reset global variables
+			openapi.Definitions = make(map[string]*openapi.Schema)
+			root := RunHTTPDSL(t, c.DSL)
+			files, err := OpenAPIFiles(root)
+			if c.Error != "" {
+				if err == nil {
+					t.Fatalf("got no error, expected %q", c.Error)
+				}
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %q", err)
+			}
+			var ruleset *codegen.File
+			for _, f := range files {
+				if f.Path == filepath.Join("gen", "http", ".spectral.yaml") {
+					ruleset = f
+				}
+			}
+			if ruleset == nil {
+				t.Fatal("ruleset file not generated")
+			}
+			s := ruleset.SectionTemplates[0]
+			var buf bytes.Buffer
+			tmpl := template.Must(template.New("ruleset").Funcs(s.FuncMap).Parse(s.Source))
+			if err := tmpl.Execute(&buf, s.Data); err != nil {
+				t.Fatalf("failed to render template: %s", err)
+			}
+			golden := filepath.Join("testdata", "openapi_v2", t.Name()+".golden")
+			if *update {
+				if err := ioutil.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+					t.Fatalf("failed to update golden file: %s", err)
+				}
+			}
+			want, err := ioutil.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %s", err)
+			}
+			if !bytes.Equal(buf.Bytes(), want) {
+				t.Errorf("result do not match the golden file:\n--BEGIN--\n%s\n--END--\n", buf.Bytes())
+			}
+		})
+	}
+}
+
 func TestValidations(t *testing.T) {
 	var (
 		goldenPath = filepath.Join("testdata", "openapi_v2", t.Name())
@@ -207,6 +414,8 @@ func TestExtensions(t *testing.T) {
 		DSL  func()
 	}{
 		{"endpoint", testdata.ExtensionDSL},
+		{"cors", testdata.CORSExtensionDSL},
+		{"openapi-prefix", testdata.OpenAPIExtensionDSL},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -23,7 +23,7 @@ func PathFiles(root *expr.RootExpr) []*codegen.File {
 func serverPath(svc *expr.HTTPServiceExpr) *codegen.File {
 	sd := HTTPServices.Get(svc.Name())
 	path := filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(sd.Service.VarName), "server", "paths.go")
-	return &codegen.File{Path: path, SectionTemplates: pathSections(svc, "server")}
+	return &codegen.File{Path: path, SectionTemplates: pathSections(svc, "server"), Service: svc.Name()}
 }
 
 // clientPath returns the client file containing the request path constructors
@@ -31,7 +31,7 @@ func serverPath(svc *expr.HTTPServiceExpr) *codegen.File {
 func clientPath(svc *expr.HTTPServiceExpr) *codegen.File {
 	sd := HTTPServices.Get(svc.Name())
 	path := filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(sd.Service.VarName), "client", "paths.go")
-	return &codegen.File{Path: path, SectionTemplates: pathSections(svc, "client")}
+	return &codegen.File{Path: path, SectionTemplates: pathSections(svc, "client"), Service: svc.Name()}
 }
 
 // pathSections returns the sections of the file of the pkg package that
@@ -29,6 +29,9 @@ func TestPaths(t *testing.T) {
 		{"path-with-float64-slice-param", testdata.PathFloat64SliceParamDSL, testdata.PathFloat64SliceParamCode},
 		{"path-with-bool-slice-param", testdata.PathBoolSliceParamDSL, testdata.PathBoolSliceParamCode},
 		{"path-with-interface-slice-param", testdata.PathInterfaceSliceParamDSL, testdata.PathInterfaceSliceParamCode},
+		{"path-with-styled-params", testdata.PathStyledParamsDSL, testdata.PathStyledParamsCode},
+		{"path-with-label-param", testdata.PathLabelParamDSL, testdata.PathLabelParamCode},
+		{"path-with-catch-all-slice-param", testdata.PathCatchAllSliceParamDSL, testdata.PathCatchAllSliceParamCode},
 	}
 
 	for _, c := range cases {
@@ -0,0 +1,42 @@
+package codegen
+
+import (
+	"path/filepath"
+	"text/template"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/postman"
+)
+
+// PostmanFiles returns the files containing the Postman collection that
+// describes the HTTP endpoints of the given API and the Postman environment
+// that defines the base URL and the security credentials used by the
+// collection requests. The files are generated only if the API defines the
+// "postman:collection" meta.
+func PostmanFiles(root *expr.RootExpr) []*codegen.File {
+	if _, ok := root.API.Meta["postman:collection"]; !ok {
+		return nil
+	}
+	if len(root.API.HTTP.Services) == 0 {
+		return nil
+	}
+	col, env := postman.New(root)
+	return []*codegen.File{
+		postmanFile("postman_collection.json", col),
+		postmanFile("postman_environment.json", env),
+	}
+}
+
+// postmanFile returns the JSON file with the given name that contains data.
+func postmanFile(name string, data interface{}) *codegen.File {
+	return &codegen.File{
+		Path: filepath.Join(codegen.Gendir, "http", name),
+		SectionTemplates: []*codegen.SectionTemplate{{
+			Name:    "postman",
+			FuncMap: template.FuncMap{"toJSON": toJSON},
+			Source:  "{{ toJSON .}}",
+			Data:    data,
+		}},
+	}
+}
@@ -0,0 +1,330 @@
+package postman
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// SchemaURL is the URL of the JSON schema of the Postman collection format
+// produced by New.
+const SchemaURL = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// baseURL is the name of the variable that holds the scheme, host and base
+// path of the requests.
+const baseURL = "baseUrl"
+
+// builder records the variables used by the collection requests.
+type builder struct {
+	random *expr.Random
+	vars   []*EnvironmentValue
+	seen   map[string]bool
+}
+
+// New returns the Postman collection that describes the HTTP endpoints of the
+// given API together with the environment that defines the variables used by
+// the requests: the base URL and the credentials of the security schemes. The
+// requests are grouped in one folder per service, they use the examples
+// defined in the design to initialize the parameters, headers and bodies.
+func New(root *expr.RootExpr) (*Collection, *Environment) {
+	if root == nil || root.API == nil || root.API.HTTP == nil {
+		return nil, nil
+	}
+	var (
+		api = root.API
+		b   = &builder{random: api.Random(), seen: make(map[string]bool)}
+		url = api.DefaultHTTPURL()
+	)
+	b.variable(baseURL, url, false)
+	col := &Collection{
+		Info: &Info{
+			Name:        title(api),
+			Description: api.Description,
+			Version:     api.Version,
+			Schema:      SchemaURL,
+		},
+		Item:     []*Item{},
+		Variable: []*Variable{{Key: baseURL, Value: url, Type: "string"}},
+	}
+	for _, svc := range api.HTTP.Services {
+		if !mustGenerate(svc.Meta) || !mustGenerate(svc.ServiceExpr.Meta) {
+			continue
+		}
+		folder := &Item{Name: svc.Name(), Description: svc.Description(), Item: []*Item{}}
+		for _, e := range svc.HTTPEndpoints {
+			if !mustGenerate(e.Meta) || !mustGenerate(e.MethodExpr.Meta) {
+				continue
+			}
+			for _, r := range e.Routes {
+				for _, p := range r.FullPaths() {
+					folder.Item = append(folder.Item, b.item(e, r.Method, p))
+				}
+			}
+		}
+		if len(folder.Item) > 0 {
+			col.Item = append(col.Item, folder)
+		}
+	}
+	env := &Environment{
+		Name:   title(api) + " environment",
+		Values: b.vars,
+		Scope:  "environment",
+	}
+	return col, env
+}
+
+// item returns the collection item describing the request sent to the given
+// endpoint with the given HTTP method and path.
+func (b *builder) item(e *expr.HTTPEndpointExpr, method, path string) *Item {
+	var (
+		auth    *Auth
+		secured = make(map[string]string)
+	)
+	if len(e.Requirements) > 0 {
+		for i, s := range e.Requirements[0].Schemes {
+			if i == 0 {
+				auth = b.auth(s)
+				if s.Kind != expr.BasicAuthKind {
+					// Postman sets the credentials.
+					secured[s.In+":"+s.Name] = ""
+				}
+				continue
+			}
+			if s.Kind != expr.BasicAuthKind {
+				secured[s.In+":"+s.Name] = "{{" + b.credential(s, tokenSuffix(s)) + "}}"
+			}
+		}
+	}
+
+	u := &URL{Host: []string{"{{" + baseURL + "}}"}}
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i, s := range segments {
+		if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+			segments[i] = ":" + strings.TrimPrefix(s[1:len(s)-1], "*")
+		}
+	}
+	if path != "/" {
+		u.Path = segments
+	}
+	u.Variable = b.params(e.PathParams(), "path", secured)
+	u.Query = b.params(e.QueryParams(), "query", secured)
+	u.Raw = "{{" + baseURL + "}}/" + strings.Join(u.Path, "/")
+	if len(u.Query) > 0 {
+		var qs []string
+		for _, q := range u.Query {
+			if !q.Disabled {
+				qs = append(qs, q.Key+"="+q.Value)
+			}
+		}
+		if len(qs) > 0 {
+			u.Raw += "?" + strings.Join(qs, "&")
+		}
+	}
+
+	req := &Request{
+		Method:      method,
+		URL:         u,
+		Header:      b.params(e.Headers, "header", secured),
+		Auth:        auth,
+		Description: e.Description(),
+	}
+	if e.Body != nil && e.Body.Type != expr.Empty {
+		raw, err := json.MarshalIndent(jsonValue(e.Body.Example(b.random)), "", "  ")
+		if err == nil {
+			req.Body = &Body{
+				Mode:    "raw",
+				Raw:     string(raw),
+				Options: &BodyOptions{Raw: &RawOptions{Language: "json"}},
+			}
+			req.Header = append(req.Header, &KeyValue{Key: "Content-Type", Value: "application/json"})
+		}
+	}
+	return &Item{
+		Name:        e.Name(),
+		Description: e.Description(),
+		Request:     req,
+	}
+}
+
+// params returns the request values described by the given mapped attribute
+// located in in. The values of the security schemes credentials are taken
+// from secured and omitted if empty.
+func (b *builder) params(ma *expr.MappedAttributeExpr, in string, secured map[string]string) []*KeyValue {
+	if ma == nil || ma.Type == nil || ma.IsEmpty() {
+		return nil
+	}
+	var kvs []*KeyValue
+	expr.WalkMappedAttr(ma, func(name, elem string, att *expr.AttributeExpr) error {
+		if v, ok := secured[in+":"+elem]; ok {
+			if v != "" {
+				kvs = append(kvs, &KeyValue{Key: elem, Value: v, Description: att.Description})
+			}
+			return nil
+		}
+		var (
+			ex       = att.Example(b.random)
+			disabled = !ma.IsRequired(name) && in != "path"
+		)
+		if v := reflect.ValueOf(ex); v.Kind() == reflect.Slice && in == "query" && expr.IsArray(att.Type) {
+			for i := 0; i < v.Len(); i++ {
+				kvs = append(kvs, &KeyValue{Key: elem, Value: toString(v.Index(i).Interface()), Description: att.Description, Disabled: disabled})
+			}
+			return nil
+		}
+		kvs = append(kvs, &KeyValue{Key: elem, Value: toString(ex), Description: att.Description, Disabled: disabled})
+		return nil
+	})
+	return kvs
+}
+
+// auth returns the Postman authentication settings for the given security
+// scheme.
+func (b *builder) auth(s *expr.SchemeExpr) *Auth {
+	switch s.Kind {
+	case expr.BasicAuthKind:
+		return &Auth{Type: "basic", Basic: []*AuthAttribute{
+			{Key: "username", Value: "{{" + b.credential(s, "Username") + "}}", Type: "string"},
+			{Key: "password", Value: "{{" + b.credential(s, "Password") + "}}", Type: "string"},
+		}}
+	case expr.JWTKind, expr.OAuth2Kind:
+		token := "{{" + b.credential(s, tokenSuffix(s)) + "}}"
+		if s.In == "header" && s.Name == "Authorization" {
+			if s.Kind == expr.OAuth2Kind {
+				return &Auth{Type: "oauth2", OAuth2: []*AuthAttribute{
+					{Key: "accessToken", Value: token, Type: "string"},
+					{Key: "addTokenTo", Value: "header", Type: "string"},
+				}}
+			}
+			return &Auth{Type: "bearer", Bearer: []*AuthAttribute{
+				{Key: "token", Value: token, Type: "string"},
+			}}
+		}
+		return apiKeyAuth(s, token)
+	case expr.APIKeyKind:
+		return apiKeyAuth(s, "{{"+b.credential(s, tokenSuffix(s))+"}}")
+	}
+	return nil
+}
+
+// apiKeyAuth returns the Postman settings that send the given value in the
+// header or query string parameter of the scheme.
+func apiKeyAuth(s *expr.SchemeExpr, value string) *Auth {
+	in := "header"
+	if s.In == "query" {
+		in = "query"
+	}
+	return &Auth{Type: "apikey", APIKey: []*AuthAttribute{
+		{Key: "key", Value: s.Name, Type: "string"},
+		{Key: "value", Value: value, Type: "string"},
+		{Key: "in", Value: in, Type: "string"},
+	}}
+}
+
+// tokenSuffix returns the suffix of the name of the variable that holds the
+// key or token of the given scheme.
+func tokenSuffix(s *expr.SchemeExpr) string {
+	switch s.Kind {
+	case expr.JWTKind:
+		return "Token"
+	case expr.OAuth2Kind:
+		return "AccessToken"
+	default:
+		return "Key"
+	}
+}
+
+// credential records and returns the name of the environment variable that
+// holds the credential of the given scheme, e.g. "basicUsername".
+func (b *builder) credential(s *expr.SchemeExpr, suffix string) string {
+	name := codegen.Goify(s.SchemeName, false) + suffix
+	b.variable(name, "", true)
+	return name
+}
+
+// variable records the environment variable with the given name and default
+// value.
+func (b *builder) variable(name, value string, secret bool) {
+	if b.seen[name] {
+		return
+	}
+	b.seen[name] = true
+	typ := "default"
+	if secret {
+		typ = "secret"
+	}
+	b.vars = append(b.vars, &EnvironmentValue{Key: name, Value: value, Type: typ, Enabled: true})
+}
+
+// title returns the title of the API.
+func title(api *expr.APIExpr) string {
+	if api.Title != "" {
+		return api.Title
+	}
+	return api.Name
+}
+
+// toString returns the value of a header or parameter holding the given
+// example.
+func toString(v interface{}) string {
+	switch actual := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return actual
+	case []byte:
+		return string(actual)
+	case bool, int, int32, int64, uint, uint32, uint64, float32, float64:
+		return fmt.Sprintf("%v", actual)
+	}
+	if r := reflect.ValueOf(v); r.Kind() == reflect.Slice {
+		elems := make([]string, r.Len())
+		for i := range elems {
+			elems[i] = toString(r.Index(i).Interface())
+		}
+		return strings.Join(elems, ",")
+	}
+	b, err := json.Marshal(jsonValue(v))
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+// jsonValue converts the maps with non string keys contained in v so that v
+// may be serialized to JSON.
+func jsonValue(v interface{}) interface{} {
+	switch actual := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(actual))
+		for k, e := range actual {
+			m[fmt.Sprintf("%v", k)] = jsonValue(e)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(actual))
+		for k, e := range actual {
+			m[k] = jsonValue(e)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(actual))
+		for i, e := range actual {
+			s[i] = jsonValue(e)
+		}
+		return s
+	}
+	return v
+}
+
+// mustGenerate returns true if the meta indicates that a collection item
+// should be generated, false otherwise.
+func mustGenerate(meta expr.MetaExpr) bool {
+	if m, ok := meta["swagger:generate"]; ok && len(m) > 0 && m[0] == "false" {
+		return false
+	}
+	return true
+}
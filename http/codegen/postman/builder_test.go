@@ -0,0 +1,34 @@
+package postman
+
+import (
+	"testing"
+)
+
+func TestToString(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Value    interface{}
+		Expected string
+	}{
+		{"nil", nil, ""},
+		{"string", "foo", "foo"},
+		{"int", 42, "42"},
+		{"bool", true, "true"},
+		{"strings", []string{"a", "b"}, "a,b"},
+		{"interfaces", []interface{}{1, "b"}, "1,b"},
+		{"map", map[interface{}]interface{}{1: "a"}, `{"1":"a"}`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if got := toString(c.Value); got != c.Expected {
+				t.Errorf("got %q, expected %q", got, c.Expected)
+			}
+		})
+	}
+}
+
+func TestNewNil(t *testing.T) {
+	if col, env := New(nil); col != nil || env != nil {
+		t.Errorf("got %v and %v, expected nil", col, env)
+	}
+}
@@ -0,0 +1,156 @@
+// Package postman produces Postman collections (https://schema.postman.com/collection/json/v2.1.0/draft-07/docs/index.html)
+// and environments that describe the HTTP endpoints of an API. The generated
+// collections may also be imported in Insomnia.
+package postman
+
+type (
+	// Collection represents a Postman collection v2.1.
+	Collection struct {
+		Info     *Info       `json:"info"`
+		Item     []*Item     `json:"item"`
+		Variable []*Variable `json:"variable,omitempty"`
+	}
+
+	// Info provides metadata about the collection.
+	Info struct {
+		// Name is the name of the collection.
+		Name string `json:"name"`
+		// Description of the collection.
+		Description string `json:"description,omitempty"`
+		// Version is the API version.
+		Version string `json:"version,omitempty"`
+		// Schema is the URL of the collection format JSON schema.
+		Schema string `json:"schema"`
+	}
+
+	// Item is either a folder that groups the requests of a service or a
+	// request.
+	Item struct {
+		// Name is the name of the folder or request.
+		Name string `json:"name"`
+		// Description of the folder or request.
+		Description string `json:"description,omitempty"`
+		// Item lists the requests of a folder.
+		Item []*Item `json:"item,omitempty"`
+		// Request describes the request of a request item.
+		Request *Request `json:"request,omitempty"`
+	}
+
+	// Request describes a HTTP request.
+	Request struct {
+		// Method is the HTTP method.
+		Method string `json:"method"`
+		// URL is the request URL.
+		URL *URL `json:"url"`
+		// Header lists the request headers.
+		Header []*KeyValue `json:"header,omitempty"`
+		// Body is the request body if any.
+		Body *Body `json:"body,omitempty"`
+		// Auth configures the request authentication if any.
+		Auth *Auth `json:"auth,omitempty"`
+		// Description of the request.
+		Description string `json:"description,omitempty"`
+	}
+
+	// URL describes a request URL.
+	URL struct {
+		// Raw is the complete URL, e.g. "{{baseUrl}}/accounts/:id".
+		Raw string `json:"raw"`
+		// Host lists the parts of the URL host.
+		Host []string `json:"host"`
+		// Path lists the segments of the URL path, path parameters are
+		// prefixed with ":".
+		Path []string `json:"path,omitempty"`
+		// Query lists the query string parameters.
+		Query []*KeyValue `json:"query,omitempty"`
+		// Variable lists the values of the path parameters.
+		Variable []*KeyValue `json:"variable,omitempty"`
+	}
+
+	// KeyValue describes a header, a query string parameter or a path
+	// parameter.
+	KeyValue struct {
+		// Key is the name of the value.
+		Key string `json:"key"`
+		// Value is the example value.
+		Value string `json:"value"`
+		// Description of the value.
+		Description string `json:"description,omitempty"`
+		// Disabled is true for the optional values so that they are
+		// not sent unless explicitly enabled.
+		Disabled bool `json:"disabled,omitempty"`
+	}
+
+	// Body describes a request body.
+	Body struct {
+		// Mode is the body mode, always "raw".
+		Mode string `json:"mode"`
+		// Raw is the example body.
+		Raw string `json:"raw"`
+		// Options describes the format of Raw.
+		Options *BodyOptions `json:"options,omitempty"`
+	}
+
+	// BodyOptions describes the format of a raw body.
+	BodyOptions struct {
+		Raw *RawOptions `json:"raw"`
+	}
+
+	// RawOptions describes the language of a raw body.
+	RawOptions struct {
+		// Language is the body language, e.g. "json".
+		Language string `json:"language"`
+	}
+
+	// Auth configures the authentication of a request.
+	Auth struct {
+		// Type is the authentication type, one of "basic", "bearer",
+		// "apikey" or "oauth2".
+		Type string `json:"type"`
+		// Basic lists the basic auth settings.
+		Basic []*AuthAttribute `json:"basic,omitempty"`
+		// Bearer lists the bearer token settings.
+		Bearer []*AuthAttribute `json:"bearer,omitempty"`
+		// APIKey lists the API key settings.
+		APIKey []*AuthAttribute `json:"apikey,omitempty"`
+		// OAuth2 lists the OAuth2 settings.
+		OAuth2 []*AuthAttribute `json:"oauth2,omitempty"`
+	}
+
+	// AuthAttribute is an authentication setting.
+	AuthAttribute struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+		Type  string `json:"type"`
+	}
+
+	// Variable is a collection variable.
+	Variable struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+		Type  string `json:"type,omitempty"`
+	}
+
+	// Environment represents a Postman environment, it defines the values
+	// of the variables used by the collection requests.
+	Environment struct {
+		// Name is the name of the environment.
+		Name string `json:"name"`
+		// Values lists the environment variables.
+		Values []*EnvironmentValue `json:"values"`
+		// Scope is always "environment".
+		Scope string `json:"_postman_variable_scope"`
+	}
+
+	// EnvironmentValue is an environment variable.
+	EnvironmentValue struct {
+		// Key is the name of the variable.
+		Key string `json:"key"`
+		// Value is the value of the variable.
+		Value string `json:"value"`
+		// Type is "default" or "secret" for the security credentials.
+		Type string `json:"type"`
+		// Enabled is true if the variable is used.
+		Enabled bool `json:"enabled"`
+	}
+)
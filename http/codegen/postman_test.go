@@ -0,0 +1,72 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"text/template"
+
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestPostman(t *testing.T) {
+	var (
+		goldenPath = filepath.Join("testdata", "postman", t.Name())
+	)
+	cases := []struct {
+		Name string
+		DSL  func()
+	}{
+		{"collection", testdata.PostmanDSL},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := RunHTTPDSL(t, c.DSL)
+			files := PostmanFiles(root)
+			if len(files) != 2 {
+				t.Fatalf("got %d files, expected 2", len(files))
+			}
+			if files[0].Path != filepath.Join("gen", "http", "postman_collection.json") {
+				t.Errorf("invalid output path %#v", files[0].Path)
+			}
+			if files[1].Path != filepath.Join("gen", "http", "postman_environment.json") {
+				t.Errorf("invalid output path %#v", files[1].Path)
+			}
+			for i, o := range files {
+				tname := fmt.Sprintf("file%d", i)
+				s := o.SectionTemplates
+				t.Run(tname, func(t *testing.T) {
+					var buf bytes.Buffer
+					tmpl := template.Must(template.New("postman").Funcs(s[0].FuncMap).Parse(s[0].Source))
+					if err := tmpl.Execute(&buf, s[0].Data); err != nil {
+						t.Fatalf("failed to render template: %s", err)
+					}
+
+					golden := filepath.Join(goldenPath, fmt.Sprintf("%s_%s.golden", c.Name, tname))
+					if *update {
+						if err := ioutil.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+							t.Fatalf("failed to update golden file: %s", err)
+						}
+					}
+
+					want, err := ioutil.ReadFile(golden)
+					if err != nil {
+						t.Fatalf("failed to read golden file: %s", err)
+					}
+					if !bytes.Equal(buf.Bytes(), want) {
+						t.Errorf("result do not match the golden file:\n--BEGIN--\n%s\n--END--\n", buf.Bytes())
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestPostmanNoMeta(t *testing.T) {
+	root := RunHTTPDSL(t, testdata.AsyncAPIDSL)
+	if files := PostmanFiles(root); files != nil {
+		t.Errorf("got %d files, expected none", len(files))
+	}
+}
@@ -0,0 +1,34 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestProblemDetails(t *testing.T) {
+	const genpkg = "gen"
+	RunHTTPDSL(t, testdata.ProblemDetailsDSL)
+	fs := ServerFiles(genpkg, expr.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected two", len(fs))
+	}
+	sections := fs[1].Section("error-encoder")
+	if len(sections) != 1 {
+		t.Fatalf("got %d error encoder sections, expected 1", len(sections))
+	}
+	code := codegen.SectionCode(t, sections[0])
+	if code != testdata.ProblemDetailsErrorEncoderCode {
+		t.Errorf("invalid error encoder code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ProblemDetailsErrorEncoderCode))
+	}
+	sections = fs[0].Section("server-handler-init")
+	if len(sections) != 2 {
+		t.Fatalf("got %d handler init sections, expected 2", len(sections))
+	}
+	code = codegen.SectionCode(t, sections[1])
+	if code != testdata.ProblemDetailsHandlerInitCode {
+		t.Errorf("invalid handler init code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ProblemDetailsHandlerInitCode))
+	}
+}
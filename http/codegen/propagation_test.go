@@ -0,0 +1,35 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestPropagation(t *testing.T) {
+	const genpkg = "gen"
+	RunHTTPDSL(t, testdata.PropagationDSL)
+	cases := []struct {
+		Name    string
+		Files   []*codegen.File
+		Section string
+		Code    string
+	}{
+		{"server", ServerFiles(genpkg, expr.Root), "server-handler-init", testdata.PropagationServerHandlerInitCode},
+		{"client", ClientFiles(genpkg, expr.Root), "client-endpoint-init", testdata.PropagationClientEndpointInitCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			sections := c.Files[0].Section(c.Section)
+			if len(sections) == 0 {
+				t.Fatalf("%s section not found", c.Section)
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestServerProxy(t *testing.T) {
+	const genpkg = "gen"
+	RunHTTPDSL(t, testdata.ProxyDSL)
+	fs := ServerFiles(genpkg, expr.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected two", len(fs))
+	}
+	cases := []struct {
+		Name    string
+		Section string
+		Code    string
+	}{
+		{"server-struct", "server-struct", testdata.ProxyServerStructCode},
+		{"server-init", "server-init", testdata.ProxyServerInitCode},
+		{"handler-init", "server-handler-init", testdata.ProxyHandlerInitCode},
+		{"proxy-init", "server-proxy-init", testdata.ProxyInitCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var codes []string
+			for _, s := range fs[0].SectionTemplates {
+				if s.Name == c.Section {
+					codes = append(codes, codegen.SectionCode(t, s))
+				}
+			}
+			if len(codes) == 0 {
+				t.Fatalf("section %q not found", c.Section)
+			}
+			code := strings.Join(codes, "\n")
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+	for _, s := range fs[1].SectionTemplates {
+		if s.Name == "response-encoder" {
+			t.Errorf("unexpected response encoder for proxy endpoint:\n%s", codegen.SectionCode(t, s))
+		}
+	}
+}
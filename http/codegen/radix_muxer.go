@@ -0,0 +1,161 @@
+package codegen
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	goahttp "goa.design/goa/v3/http"
+)
+
+// radixMuxerData contains the data used to render the radix muxer package.
+type radixMuxerData struct {
+	// Routes lists the compiled routes.
+	Routes []*goahttp.TreeRoute
+	// Root is the code of the root node of the compiled route tree.
+	Root string
+	// Conflicts lists the routes of different services with the same
+	// method and path modulo the names of their wildcards.
+	Conflicts []string
+	// Unsupported lists the routes that cannot be compiled.
+	Unsupported []string
+}
+
+// RadixMuxerFiles returns the file that implements the radixmux package. The
+// package exposes a Muxer that dispatches the requests using a route tree
+// compiled from the HTTP routes of the design so that the servers do not have
+// to build the tree at startup nor run regular expressions when matching the
+// request paths. The routes of different services that conflict and the
+// routes that cannot be compiled are listed in the generated code.
+func RadixMuxerFiles(root *expr.RootExpr) []*codegen.File {
+	if len(root.API.HTTP.Services) == 0 {
+		return nil
+	}
+	var (
+		tree = goahttp.NewRouteTree()
+		seen = make(map[string]string)
+		data = &radixMuxerData{}
+	)
+	for _, svc := range root.API.HTTP.Services {
+		for _, e := range svc.HTTPEndpoints {
+			for _, r := range e.Routes {
+				for _, p := range r.FullPaths() {
+					route := fmt.Sprintf("%s %s (%s)", r.Method, p, e.EvalName())
+					key := r.Method + " " + expr.NormalizeHTTPPath(p)
+					if prev, ok := seen[key]; ok {
+						data.Conflicts = append(data.Conflicts, fmt.Sprintf("%s conflicts with %s", route, prev))
+					} else {
+						seen[key] = route
+					}
+					if _, err := tree.Add(r.Method, p); err != nil {
+						data.Unsupported = append(data.Unsupported, route)
+					}
+				}
+			}
+		}
+	}
+	if len(tree.Routes) == 0 && len(data.Unsupported) == 0 {
+		return nil
+	}
+	data.Routes = tree.Routes
+	data.Root = routeNodeCode(tree.Root, "\t\t")
+	path := filepath.Join(codegen.Gendir, "http", "muxers", "radixmux", "muxer.go")
+	title := fmt.Sprintf("%s radix tree muxer", root.API.Name)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "radixmux", []*codegen.ImportSpec{
+			codegen.GoaNamedImport("http", "goahttp"),
+		}),
+		{Name: "radix-muxer", Source: radixMuxerT, Data: data},
+	}
+	return []*codegen.File{{Path: path, SectionTemplates: sections}}
+}
+
+// routeNodeCode returns the Go code of the composite literal that initializes
+// n, indent is the indentation of the closing brace.
+func routeNodeCode(n *goahttp.RouteNode, indent string) string {
+	var b strings.Builder
+	b.WriteString("&goahttp.RouteNode{\n")
+	in := indent + "\t"
+	if len(n.Static) > 0 {
+		segs := make([]string, 0, len(n.Static))
+		for seg := range n.Static {
+			segs = append(segs, seg)
+		}
+		sort.Strings(segs)
+		b.WriteString(in + "Static: map[string]*goahttp.RouteNode{\n")
+		for _, seg := range segs {
+			child := strings.TrimPrefix(routeNodeCode(n.Static[seg], in+"\t"), "&goahttp.RouteNode")
+			fmt.Fprintf(&b, "%s\t%q: %s,\n", in, seg, child)
+		}
+		b.WriteString(in + "},\n")
+	}
+	if n.Param != nil {
+		fmt.Fprintf(&b, "%sParam: %s,\n", in, routeNodeCode(n.Param, in))
+	}
+	if len(n.Routes) > 0 {
+		fmt.Fprintf(&b, "%sRoutes: %s,\n", in, methodIndexCode(n.Routes))
+	}
+	if len(n.CatchAll) > 0 {
+		fmt.Fprintf(&b, "%sCatchAll: %s,\n", in, methodIndexCode(n.CatchAll))
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+// methodIndexCode returns the Go code of the given map of HTTP methods to
+// route indices.
+func methodIndexCode(m map[string]int) string {
+	methods := make([]string, 0, len(m))
+	for method := range m {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	elems := make([]string, len(methods))
+	for i, method := range methods {
+		elems[i] = fmt.Sprintf("%q: %d", method, m[method])
+	}
+	return "map[string]int{" + strings.Join(elems, ", ") + "}"
+}
+
+// input: radixMuxerData
+const radixMuxerT = `// New returns a goa Muxer that dispatches the requests using a route tree
+// compiled from the HTTP routes of the design. The requests that the tree does
+// not match are dispatched to fallback, New uses the muxer returned by
+// goahttp.NewMuxer if fallback is nil.
+{{- if .Unsupported }}
+//
+// The following routes cannot be compiled so that the muxer dispatches all the
+// requests to fallback:
+//
+{{- range .Unsupported }}
+//   - {{ . }}
+{{- end }}
+{{- end }}
+{{- if .Conflicts }}
+//
+// The following routes of different services conflict, they cannot be mounted
+// on the same muxer:
+//
+{{- range .Conflicts }}
+//   - {{ . }}
+{{- end }}
+{{- end }}
+func New(fallback goahttp.Muxer) goahttp.Muxer {
+	return goahttp.NewRadixMuxer(RouteTree(), fallback)
+}
+
+// RouteTree returns the route tree compiled from the HTTP routes of the design.
+func RouteTree() *goahttp.RouteTree {
+	return &goahttp.RouteTree{
+		Routes: []*goahttp.TreeRoute{
+		{{- range .Routes }}
+			{Method: {{ printf "%q" .Method }}, Pattern: {{ printf "%q" .Pattern }}{{ if .Vars }}, Vars: {{ printf "%#v" .Vars }}{{ end }}},
+		{{- end }}
+		},
+		Root: {{ .Root }},
+	}
+}
+`
@@ -0,0 +1,29 @@
+package codegen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestRadixMuxerFiles(t *testing.T) {
+	RunHTTPDSL(t, testdata.RadixMuxerDSL)
+	fs := RadixMuxerFiles(expr.Root)
+	if len(fs) != 1 {
+		t.Fatalf("got %d files, expected 1", len(fs))
+	}
+	if expected := filepath.Join("gen", "http", "muxers", "radixmux", "muxer.go"); fs[0].Path != expected {
+		t.Errorf("got path %q, expected %q", fs[0].Path, expected)
+	}
+	sections := fs[0].Section("radix-muxer")
+	if len(sections) != 1 {
+		t.Fatalf("got %d radix-muxer sections, expected 1", len(sections))
+	}
+	code := codegen.SectionCode(t, sections[0])
+	if code != testdata.RadixMuxerCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.RadixMuxerCode))
+	}
+}
@@ -0,0 +1,595 @@
+package codegen
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/cli"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// sdkData contains the data needed to render the SDK client.
+	sdkData struct {
+		// API is the name of the API.
+		API string
+		// BaseURL is the default base URL of the client.
+		BaseURL string
+		// Services lists the services exposed by the client.
+		Services []*sdkServiceData
+	}
+
+	// sdkServiceData contains the data needed to render the client of a
+	// service.
+	sdkServiceData struct {
+		// Name is the name of the service.
+		Name string
+		// VarName is the name of the Client field that holds the
+		// service client.
+		VarName string
+		// ClientStruct is the name of the service client struct.
+		ClientStruct string
+		// ClientInit is the name of the service client constructor.
+		ClientInit string
+		// ClientPkg is the name of the HTTP client package.
+		ClientPkg string
+		// NeedStream is true if the HTTP client requires a websocket
+		// dialer.
+		NeedStream bool
+		// Cacheable is true if the HTTP client may cache the responses
+		// of some endpoints.
+		Cacheable bool
+		// Methods lists the service methods.
+		Methods []*sdkMethodData
+	}
+
+	// sdkMethodData contains the data needed to render a service client
+	// method.
+	sdkMethodData struct {
+		// Name is the name of the method.
+		Name string
+		// VarName is the name of the client method.
+		VarName string
+		// ServiceName is the name of the service.
+		ServiceName string
+		// ClientStruct is the name of the service client struct.
+		ClientStruct string
+		// EndpointVar is the name of the client field that holds the
+		// endpoint.
+		EndpointVar string
+		// EndpointInit is the code that builds the endpoint from the
+		// HTTP client c.
+		EndpointInit string
+		// PayloadRef is the reference to the payload type if any.
+		PayloadRef string
+		// PayloadInit is the code that initializes an empty payload.
+		PayloadInit string
+		// ResultRef is the reference to the result type or to the
+		// client stream interface if any.
+		ResultRef string
+		// Errors lists the errors returned by the method.
+		Errors []*sdkErrorData
+		// Credentials lists the payload fields initialized with the
+		// credentials given by the auth provider.
+		Credentials []*sdkCredentialData
+		// Pagination describes how the method results are paginated
+		// if they are, see the "sdk:paginate" meta.
+		Pagination *sdkPaginationData
+	}
+
+	// sdkErrorData describes an error returned by a method.
+	sdkErrorData struct {
+		// Name is the name of the error.
+		Name string
+		// TypeRef is the reference to the error type.
+		TypeRef string
+		// Description is the error description.
+		Description string
+	}
+
+	// sdkCredentialData describes a payload field initialized with a
+	// credential.
+	sdkCredentialData struct {
+		// Field is the name of the payload field.
+		Field string
+		// Pointer is true if the field is a pointer.
+		Pointer bool
+		// Credential describes the credential.
+		Credential *cli.CredentialData
+	}
+
+	// sdkPaginationData describes the pagination of the results of a
+	// method.
+	sdkPaginationData struct {
+		// Iterator is the name of the iterator struct.
+		Iterator string
+		// IterMethod is the name of the client method that returns
+		// the iterator.
+		IterMethod string
+		// CursorField is the name of the payload field that identifies
+		// the requested page.
+		CursorField string
+		// CursorPointer is true if the cursor field is a pointer.
+		CursorPointer bool
+		// NextField is the name of the result field that identifies the
+		// next page.
+		NextField string
+		// NextPointer is true if the next field is a pointer.
+		NextPointer bool
+		// ItemsField is the name of the result field that lists the
+		// items of the page.
+		ItemsField string
+		// ItemRef is the reference to the type of the items.
+		ItemRef string
+		// Zero is the zero value of the cursor type.
+		Zero string
+	}
+)
+
+// SDKFiles returns the files that implement the SDK package: a single client
+// giving access to all the services exposed over HTTP with functional options,
+// typed methods and iterators for the paginated methods. It returns nil if the
+// design does not define HTTP services.
+func SDKFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	data := &sdkData{API: root.API.Name, BaseURL: root.API.DefaultHTTPURL()}
+	for _, svc := range root.API.HTTP.Services {
+		sd := HTTPServices.Get(svc.Name())
+		if s := buildSDKServiceData(sd, svc.ServiceExpr); s != nil {
+			data.Services = append(data.Services, s)
+		}
+	}
+	if len(data.Services) == 0 {
+		return nil
+	}
+	files := []*codegen.File{sdkClientFile(genpkg, root, data)}
+	for _, s := range data.Services {
+		files = append(files, sdkServiceFile(genpkg, HTTPServices.Get(s.Name), s))
+	}
+	return files
+}
+
+// sdkClientFile returns the file that implements the SDK client and options.
+func sdkClientFile(genpkg string, root *expr.RootExpr, data *sdkData) *codegen.File {
+	specs := []*codegen.ImportSpec{
+		{Path: "fmt"},
+		{Path: "net/http"},
+		{Path: "net/url"},
+		{Path: "github.com/gorilla/websocket"},
+		codegen.GoaImport(""),
+		codegen.GoaNamedImport("http", "goahttp"),
+		codegen.GoaImport("security/credentials"),
+	}
+	for _, svc := range root.API.HTTP.Services {
+		sd := HTTPServices.Get(svc.Name())
+		specs = append(specs, &codegen.ImportSpec{
+			Path: genpkg + "/http/" + codegen.SnakeCase(sd.Service.VarName) + "/client",
+			Name: sd.Service.PkgName + "c",
+		})
+	}
+	title := fmt.Sprintf("%s SDK client", root.API.Name)
+	return &codegen.File{
+		Path: filepath.Join(codegen.Gendir, "sdk", "client.go"),
+		SectionTemplates: []*codegen.SectionTemplate{
+			codegen.Header(title, "sdk", specs),
+			{Name: "sdk-client", Source: sdkClientT, Data: data},
+		},
+	}
+}
+
+// sdkServiceFile returns the file that implements the SDK client of a service.
+func sdkServiceFile(genpkg string, sd *ServiceData, data *sdkServiceData) *codegen.File {
+	svcName := codegen.SnakeCase(sd.Service.VarName)
+	title := fmt.Sprintf("%s SDK client", sd.Service.Name)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "sdk", []*codegen.ImportSpec{
+			{Path: "context"},
+			codegen.GoaImport(""),
+			codegen.GoaImport("security/credentials"),
+			{Path: genpkg + "/" + svcName, Name: sd.Service.PkgName},
+			{Path: genpkg + "/http/" + svcName + "/client", Name: data.ClientPkg},
+		}),
+		{Name: "sdk-service-client", Source: sdkServiceClientT, Data: data},
+	}
+	for _, m := range data.Methods {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "sdk-service-method",
+			Source: sdkServiceMethodT,
+			Data:   m,
+		})
+		if m.Pagination != nil {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "sdk-iterator",
+				Source: sdkIteratorT,
+				Data:   m,
+			})
+		}
+	}
+	return &codegen.File{
+		Path:             filepath.Join(codegen.Gendir, "sdk", svcName+"_client.go"),
+		SectionTemplates: sections,
+		Service:          sd.Service.Name,
+	}
+}
+
+// buildSDKServiceData returns the data needed to render the SDK client of the
+// given service, nil if the service does not define HTTP endpoints.
+func buildSDKServiceData(sd *ServiceData, svc *expr.ServiceExpr) *sdkServiceData {
+	if len(sd.Endpoints) == 0 {
+		return nil
+	}
+	varName := codegen.Goify(sd.Service.Name, true)
+	data := &sdkServiceData{
+		Name:         sd.Service.Name,
+		VarName:      varName,
+		ClientStruct: varName + "Client",
+		ClientInit:   "new" + varName + "Client",
+		ClientPkg:    sd.Service.PkgName + "c",
+		NeedStream:   streamingEndpointExists(sd),
+		Cacheable:    cacheableEndpointExists(sd),
+	}
+	for _, e := range sd.Endpoints {
+		if e.MultipartRequestEncoder != nil && e.MultipartRequestEncoder.Default == "" {
+			// The SDK cannot build the multipart requests without a
+			// default encoder.
+			continue
+		}
+		data.Methods = append(data.Methods, buildSDKMethodData(sd, svc.Method(e.Method.Name), e, data))
+	}
+	return data
+}
+
+// buildSDKMethodData returns the data needed to render the SDK client method
+// that calls the given endpoint.
+func buildSDKMethodData(sd *ServiceData, m *expr.MethodExpr, e *EndpointData, svc *sdkServiceData) *sdkMethodData {
+	var (
+		md    = e.Method
+		scope = sd.Service.Scope
+		pkg   = sd.Service.PkgName
+	)
+	data := &sdkMethodData{
+		Name:         md.Name,
+		VarName:      md.VarName,
+		ServiceName:  sd.Service.Name,
+		ClientStruct: svc.ClientStruct,
+		EndpointVar:  codegen.Goify(md.Name, false) + "Endpoint",
+		EndpointInit: "c." + md.VarName + "()",
+	}
+	if e.MultipartRequestEncoder != nil {
+		data.EndpointInit = "c." + md.VarName + "(nil)"
+	}
+	if md.PayloadRef != "" {
+		data.PayloadRef = scope.GoFullTypeRef(m.Payload, pkg)
+		if expr.IsObject(m.Payload.Type) {
+			data.PayloadInit = "&" + scope.GoFullTypeName(m.Payload, pkg) + "{}"
+		}
+	}
+	if md.ClientStream != nil {
+		data.ResultRef = pkg + "." + md.ClientStream.Interface
+	} else if md.ResultRef != "" {
+		data.ResultRef = scope.GoFullTypeRef(m.Result, pkg)
+	}
+	for _, er := range m.Errors {
+		data.Errors = append(data.Errors, &sdkErrorData{
+			Name:        er.Name,
+			TypeRef:     scope.GoFullTypeRef(er.AttributeExpr, pkg),
+			Description: er.Description,
+		})
+	}
+	data.Credentials = sdkCredentials(md)
+	if cursor, next, items := m.Pagination(); cursor != "" {
+		ratt := m.Result.Find(items)
+		data.Pagination = &sdkPaginationData{
+			Iterator:      svc.VarName + md.VarName + "Iterator",
+			IterMethod:    md.VarName + "Iter",
+			CursorField:   codegen.GoifyAtt(m.Payload.Find(cursor), cursor, true),
+			CursorPointer: m.Payload.IsPrimitivePointer(cursor, true),
+			NextField:     codegen.GoifyAtt(m.Result.Find(next), next, true),
+			NextPointer:   m.Result.IsPrimitivePointer(next, true),
+			ItemsField:    codegen.GoifyAtt(ratt, items, true),
+			ItemRef:       scope.GoFullTypeRef(expr.AsArray(ratt.Type).ElemType, pkg),
+			Zero:          `""`,
+		}
+		if m.Payload.Find(cursor).Type != expr.String {
+			data.Pagination.Zero = "0"
+		}
+	}
+	return data
+}
+
+// sdkCredentials returns the payload fields of the method that hold the
+// credentials of its security schemes.
+func sdkCredentials(md *service.MethodData) []*sdkCredentialData {
+	var (
+		creds []*sdkCredentialData
+		seen  = make(map[string]struct{})
+	)
+	add := func(field string, pointer bool, name string, sch *service.SchemeData, scopes []string) {
+		if _, ok := seen[field]; ok || field == "" {
+			return
+		}
+		seen[field] = struct{}{}
+		creds = append(creds, &sdkCredentialData{
+			Field:      field,
+			Pointer:    pointer,
+			Credential: cli.NewCredentialData(name, sch, scopes),
+		})
+	}
+	for _, req := range md.Requirements {
+		for _, sch := range req.Schemes {
+			switch sch.Type {
+			case "Basic":
+				add(sch.UsernameField, sch.UsernamePointer, sch.SchemeName+".username", sch, req.Scopes)
+				add(sch.PasswordField, sch.PasswordPointer, sch.SchemeName+".password", sch, req.Scopes)
+			case "APIKey", "JWT", "OAuth2":
+				add(sch.CredField, sch.CredPointer, sch.SchemeName, sch, req.Scopes)
+			}
+		}
+	}
+	return creds
+}
+
+// input: sdkData
+const sdkClientT = `type (
+	{{ printf "Client is the %s API client, it gives access to the clients of the services exposed over HTTP." .API | comment }}
+	Client struct {
+	{{- range .Services }}
+		{{ printf "%s is the %q service client." .VarName .Name | comment }}
+		{{ .VarName }} *{{ .ClientStruct }}
+	{{- end }}
+	}
+
+	// Option customizes the client created by New.
+	Option func(*options)
+
+	// options holds the settings of the client.
+	options struct {
+		baseURL string
+		doer    goahttp.Doer
+		auth    credentials.Provider
+		retry   *goahttp.RetryPolicy
+		cache   goa.CacheStore
+	}
+)
+
+{{ printf "WithBaseURL sets the scheme and host of the API, the default is %q. The path of the URL is ignored as the HTTP routes are not relative to it." .BaseURL | comment }}
+func WithBaseURL(u string) Option {
+	return func(o *options) {
+		o.baseURL = u
+	}
+}
+
+// WithDoer sets the HTTP client used to make the requests, the default is
+// http.DefaultClient.
+func WithDoer(doer goahttp.Doer) Option {
+	return func(o *options) {
+		o.doer = doer
+	}
+}
+
+// WithAuth sets the provider of the credentials used to secure the requests.
+// The clients initialize the security attributes of the payloads that are not
+// set with the credentials given by the provider, see credentials.Profile.
+func WithAuth(p credentials.Provider) Option {
+	return func(o *options) {
+		o.auth = p
+	}
+}
+
+// WithRetry sets the policy used to retry the failed requests made to all the
+// endpoints. The client policies defined in the design still apply to their
+// endpoints.
+func WithRetry(p *goahttp.RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = p
+	}
+}
+
+// WithResponseCache sets the store used to cache the responses to the requests
+// made to the endpoints that use the GET method. The responses are cached as
+// allowed by their Cache-Control header and revalidated with their ETag, see
+// goahttp.CachingDoer.
+func WithResponseCache(store goa.CacheStore) Option {
+	return func(o *options) {
+		o.cache = store
+	}
+}
+
+// New returns a client that makes the requests to the base URL configured with
+// the given options. It returns an error if the base URL is invalid.
+func New(opts ...Option) (*Client, error) {
+	o := &options{baseURL: {{ printf "%q" .BaseURL }}, doer: http.DefaultClient}
+	for _, opt := range opts {
+		opt(o)
+	}
+	u, err := url.Parse(o.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL %q: %s", o.baseURL, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q: missing scheme or host", o.baseURL)
+	}
+	doer := o.doer
+	if o.retry != nil {
+		doer = goahttp.PolicyDoer(doer, &goahttp.ClientPolicy{Retry: o.retry})
+	}
+	c := &Client{}
+	{{- range .Services }}
+	{
+		hc := {{ .ClientPkg }}.NewClient(
+			u.Scheme,
+			u.Host,
+			doer,
+			goahttp.PooledRequestEncoder,
+			goahttp.ResponseDecoder,
+			false,
+			{{- if .NeedStream }}
+			websocket.DefaultDialer,
+			nil,
+			{{- end }}
+		)
+		{{- if .Cacheable }}
+		if o.cache != nil {
+			hc.UseResponseCache(o.cache)
+		}
+		{{- end }}
+		c.{{ .VarName }} = {{ .ClientInit }}(hc, o.auth)
+	}
+	{{- end }}
+	return c, nil
+}
+`
+
+// input: sdkServiceData
+const sdkServiceClientT = `{{ printf "%s is the %q service client." .ClientStruct .Name | comment }}
+type {{ .ClientStruct }} struct {
+{{- range .Methods }}
+	{{ .EndpointVar }} goa.Endpoint
+{{- end }}
+	auth credentials.Provider
+}
+
+{{ printf "%s returns the %q service client that makes the requests with c and initializes the payload credentials with auth if not nil." .ClientInit .Name | comment }}
+func {{ .ClientInit }}(c *{{ .ClientPkg }}.Client, auth credentials.Provider) *{{ .ClientStruct }} {
+	return &{{ .ClientStruct }}{
+	{{- range .Methods }}
+		{{ .EndpointVar }}: {{ .EndpointInit }},
+	{{- end }}
+		auth: auth,
+	}
+}
+`
+
+// input: sdkMethodData
+const sdkServiceMethodT = `{{ printf "%s calls the %q endpoint of the %q service." .VarName .Name .ServiceName | comment }}
+{{- if .Errors }}
+{{ printf "%s may return the following errors:" .VarName | comment }}
+	{{- range .Errors }}
+//	- {{ printf "%q" .Name }} (type {{ .TypeRef }}){{ if .Description }}: {{ .Description }}{{ end }}
+	{{- end }}
+//	- error: internal error
+{{- end }}
+func (c *{{ .ClientStruct }}) {{ .VarName }}(ctx context.Context{{ if .PayloadRef }}, p {{ .PayloadRef }}{{ end }}) ({{ if .ResultRef }}res {{ .ResultRef }}, {{ end }}err error) {
+{{- if .Credentials }}
+	if c.auth != nil && p != nil {
+		cp := *p
+		p = &cp
+	{{- range .Credentials }}
+		{{- if .Pointer }}
+		if p.{{ .Field }} == nil {
+			var v string
+			if v, err = c.auth.Value(ctx, {{ template "credential" .Credential }}); err != nil {
+				return
+			}
+			if v != "" {
+				p.{{ .Field }} = &v
+			}
+		}
+		{{- else }}
+		if p.{{ .Field }} == "" {
+			if p.{{ .Field }}, err = c.auth.Value(ctx, {{ template "credential" .Credential }}); err != nil {
+				return
+			}
+		}
+		{{- end }}
+	{{- end }}
+	}
+{{- end }}
+{{- if .ResultRef }}
+	var ires interface{}
+	ires, err = c.{{ .EndpointVar }}(ctx, {{ if .PayloadRef }}p{{ else }}nil{{ end }})
+	if err != nil {
+		return
+	}
+	return ires.({{ .ResultRef }}), nil
+{{- else }}
+	_, err = c.{{ .EndpointVar }}(ctx, {{ if .PayloadRef }}p{{ else }}nil{{ end }})
+	return
+{{- end }}
+}
+
+{{- define "credential" }}&credentials.Credential{Name: {{ printf "%q" .Name }}, Kind: {{ printf "%q" .Kind }}
+	{{- if .TokenURL }}, TokenURL: {{ printf "%q" .TokenURL }}{{ end }}
+	{{- if .ClientCredentials }}, ClientCredentials: true{{ end }}
+	{{- if .Scopes }}, Scopes: []string{ {{- range $i, $s := .Scopes }}{{ if $i }}, {{ end }}{{ printf "%q" $s }}{{ end }}}{{ end }}}
+{{- end }}
+`
+
+// input: sdkMethodData
+const sdkIteratorT = `{{ with .Pagination }}{{ printf "%s iterates over the items of the pages returned by the %q method of the %q service, see %s." .Iterator $.Name $.ServiceName .IterMethod | comment }}
+type {{ .Iterator }} struct {
+	client *{{ $.ClientStruct }}
+	ctx    context.Context
+	p      {{ $.PayloadRef }}
+	page   {{ $.ResultRef }}
+	items  []{{ .ItemRef }}
+	item   {{ .ItemRef }}
+	err    error
+	done   bool
+}
+
+{{ printf "%s returns an iterator over the items of the pages returned by the %q method starting with the page requested by p. The iterator requests the next pages as needed until a page does not identify a next page." .IterMethod $.Name | comment }}
+func (c *{{ $.ClientStruct }}) {{ .IterMethod }}(ctx context.Context, p {{ $.PayloadRef }}) *{{ .Iterator }} {
+	if p == nil {
+		p = {{ $.PayloadInit }}
+	}
+	return &{{ .Iterator }}{client: c, ctx: ctx, p: p}
+}
+
+// Next advances the iterator to the next item and requests the next page if
+// needed. It returns false once all the items have been iterated over or if a
+// request fails, see Err.
+func (it *{{ .Iterator }}) Next() bool {
+	for len(it.items) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		if it.page != nil {
+			{{- if .NextPointer }}
+			if it.page.{{ .NextField }} == nil || *it.page.{{ .NextField }} == {{ .Zero }} {
+			{{- else }}
+			if it.page.{{ .NextField }} == {{ .Zero }} {
+			{{- end }}
+				it.done = true
+				return false
+			}
+			cp := *it.p
+			{{- if and .CursorPointer .NextPointer }}
+			cp.{{ .CursorField }} = it.page.{{ .NextField }}
+			{{- else if .CursorPointer }}
+			next := it.page.{{ .NextField }}
+			cp.{{ .CursorField }} = &next
+			{{- else if .NextPointer }}
+			cp.{{ .CursorField }} = *it.page.{{ .NextField }}
+			{{- else }}
+			cp.{{ .CursorField }} = it.page.{{ .NextField }}
+			{{- end }}
+			it.p = &cp
+		}
+		it.page, it.err = it.client.{{ $.VarName }}(it.ctx, it.p)
+		if it.err != nil {
+			return false
+		}
+		it.items = it.page.{{ .ItemsField }}
+	}
+	it.item, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Item returns the current item.
+func (it *{{ .Iterator }}) Item() {{ .ItemRef }} {
+	return it.item
+}
+
+// Page returns the page that contains the current item.
+func (it *{{ .Iterator }}) Page() {{ $.ResultRef }} {
+	return it.page
+}
+
+// Err returns the error returned by the last request if any.
+func (it *{{ .Iterator }}) Err() error {
+	return it.err
+}
+{{- end }}
+`
@@ -0,0 +1,47 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestSDK(t *testing.T) {
+	const genpkg = "gen"
+	RunHTTPDSL(t, testdata.SDKDSL)
+	fs := SDKFiles(genpkg, expr.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected two", len(fs))
+	}
+	cases := []struct {
+		Name    string
+		File    int
+		Section string
+		Code    string
+	}{
+		{"sdk-client", 0, "sdk-client", testdata.SDKClientCode},
+		{"sdk-service-client", 1, "sdk-service-client", testdata.SDKServiceClientCode},
+		{"sdk-service-method", 1, "sdk-service-method", testdata.SDKServiceMethodCode},
+		{"sdk-iterator", 1, "sdk-iterator", testdata.SDKIteratorCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var codes []string
+			for _, s := range fs[c.File].SectionTemplates {
+				if s.Name == c.Section {
+					codes = append(codes, codegen.SectionCode(t, s))
+				}
+			}
+			if len(codes) == 0 {
+				t.Fatalf("section %q not found", c.Section)
+			}
+			code := strings.Join(codes, "\n")
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
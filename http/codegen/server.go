@@ -32,6 +32,7 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 	funcs := map[string]interface{}{
 		"join":                    func(ss []string, s string) string { return strings.Join(ss, s) },
 		"streamingEndpointExists": streamingEndpointExists,
+		"isStreamingEndpoint":     isStreamingEndpoint,
 		"upgradeParams":           upgradeParams,
 		"viewedServerBody":        viewedServerBody,
 	}
@@ -43,18 +44,22 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 			{Path: "mime/multipart"},
 			{Path: "net/http"},
 			{Path: "path"},
+			{Path: "regexp"},
 			{Path: "strings"},
 			{Path: "sync"},
 			{Path: "time"},
 			{Path: "github.com/gorilla/websocket"},
 			codegen.GoaImport(""),
 			codegen.GoaNamedImport("http", "goahttp"),
+			codegen.GoaNamedImport("http/middleware", "httpmdlwr"),
+			codegen.GoaImport("security"),
 			{Path: genpkg + "/" + svcName, Name: data.Service.PkgName},
 			{Path: genpkg + "/" + svcName + "/" + "views", Name: data.Service.ViewsPkg},
+			{Path: genpkg + "/http/telemetry"},
 		}),
 	}
 
-	sections = append(sections, &codegen.SectionTemplate{Name: "server-struct", Source: serverStructT, Data: data})
+	sections = append(sections, &codegen.SectionTemplate{Name: "server-struct", Source: serverStructT, Data: data, Expr: svc})
 	sections = append(sections, &codegen.SectionTemplate{Name: "server-mountpoint", Source: mountPointStructT, Data: data})
 
 	// public types
@@ -89,7 +94,7 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 		}
 	}
 
-	sections = append(sections, &codegen.SectionTemplate{Name: "server-init", Source: serverInitT, Data: data, FuncMap: funcs})
+	sections = append(sections, &codegen.SectionTemplate{Name: "server-init", Source: serverInitT, Data: data, FuncMap: funcs, Expr: svc})
 	if streamingEndpointExists(data) {
 		sections = append(sections, &codegen.SectionTemplate{
 			Name:   "server-stream-conn-configurer-struct-init",
@@ -102,11 +107,27 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 	}
 	sections = append(sections, &codegen.SectionTemplate{Name: "server-service", Source: serverServiceT, Data: data})
 	sections = append(sections, &codegen.SectionTemplate{Name: "server-use", Source: serverUseT, Data: data})
-	sections = append(sections, &codegen.SectionTemplate{Name: "server-mount", Source: serverMountT, Data: data})
+	for _, ss := range data.SignatureSchemes {
+		sections = append(sections, &codegen.SectionTemplate{Name: "server-verify-signatures", Source: serverVerifySignaturesT, Data: ss})
+	}
+	if data.CSRF != nil {
+		sections = append(sections, &codegen.SectionTemplate{Name: "server-protect-csrf", Source: serverProtectCSRFT, Data: data.CSRF})
+	}
+	sections = append(sections, &codegen.SectionTemplate{Name: "server-mount", Source: serverMountT, Data: data, Expr: svc})
+	if len(data.CORSPaths) > 0 {
+		sections = append(sections, &codegen.SectionTemplate{Name: "server-cors", Source: serverCORST, Data: data, FuncMap: funcs})
+	}
+	if len(data.OptionsPaths) > 0 {
+		sections = append(sections, &codegen.SectionTemplate{Name: "server-options", Source: serverOptionsT, Data: data})
+	}
 
 	for _, e := range data.Endpoints {
-		sections = append(sections, &codegen.SectionTemplate{Name: "server-handler", Source: serverHandlerT, Data: e})
-		sections = append(sections, &codegen.SectionTemplate{Name: "server-handler-init", Source: serverHandlerInitT, Data: e})
+		ee := svc.Endpoint(e.Method.Name)
+		sections = append(sections, &codegen.SectionTemplate{Name: "server-handler", Source: serverHandlerT, Data: e, Expr: ee})
+		sections = append(sections, &codegen.SectionTemplate{Name: "server-handler-init", Source: serverHandlerInitT, Data: e, FuncMap: funcs, Expr: ee})
+		if e.Proxy != nil {
+			sections = append(sections, &codegen.SectionTemplate{Name: "server-proxy-init", Source: serverProxyInitT, Data: e, Expr: ee})
+		}
 	}
 	for _, s := range data.FileServers {
 		sections = append(sections, &codegen.SectionTemplate{Name: "server-files", Source: fileServerT, FuncMap: funcs, Data: s})
@@ -129,7 +150,7 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 		}
 	}
 
-	return &codegen.File{Path: path, SectionTemplates: sections}
+	return &codegen.File{Path: path, SectionTemplates: sections, Service: svc.Name()}
 }
 
 // serverEncodeDecode returns the file defining the HTTP server encoding and
@@ -144,6 +165,7 @@ func serverEncodeDecode(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File
 			{Path: "context"},
 			{Path: "fmt"},
 			{Path: "io"},
+			{Path: "io/ioutil"},
 			{Path: "net/http"},
 			{Path: "strconv"},
 			{Path: "strings"},
@@ -151,6 +173,7 @@ func serverEncodeDecode(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File
 			{Path: "mime/multipart"},
 			{Path: "unicode/utf8"},
 			codegen.GoaImport(""),
+			codegen.GoaImport("security"),
 			codegen.GoaNamedImport("http", "goahttp"),
 			{Path: genpkg + "/" + svcName, Name: data.Service.PkgName},
 			{Path: genpkg + "/" + svcName + "/" + "views", Name: data.Service.ViewsPkg},
@@ -158,12 +181,14 @@ func serverEncodeDecode(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File
 	}
 
 	for _, e := range data.Endpoints {
-		if e.ServerStream == nil {
+		ee := svc.Endpoint(e.Method.Name)
+		if e.ServerStream == nil && e.Proxy == nil {
 			sections = append(sections, &codegen.SectionTemplate{
 				Name:    "response-encoder",
 				FuncMap: transTmplFuncs(svc),
 				Source:  responseEncoderT,
 				Data:    e,
+				Expr:    ee,
 			})
 		}
 		if e.Payload.Ref != "" {
@@ -174,6 +199,7 @@ func serverEncodeDecode(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File
 				Source:  requestDecoderT,
 				FuncMap: fm,
 				Data:    e,
+				Expr:    ee,
 			})
 		}
 		if e.MultipartRequestDecoder != nil {
@@ -185,6 +211,13 @@ func serverEncodeDecode(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File
 				FuncMap: fm,
 				Data:    e.MultipartRequestDecoder,
 			})
+			if e.MultipartRequestDecoder.Default != "" {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "multipart-request-default-decoder",
+					Source: multipartRequestDefaultDecoderT,
+					Data:   e.MultipartRequestDecoder,
+				})
+			}
 		}
 
 		if len(e.Errors) > 0 {
@@ -193,6 +226,7 @@ func serverEncodeDecode(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File
 				Source:  errorEncoderT,
 				FuncMap: transTmplFuncs(svc),
 				Data:    e,
+				Expr:    ee,
 			})
 		}
 	}
@@ -204,7 +238,7 @@ func serverEncodeDecode(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File
 		})
 	}
 
-	return &codegen.File{Path: path, SectionTemplates: sections}
+	return &codegen.File{Path: path, SectionTemplates: sections, Service: svc.Name()}
 }
 
 func transTmplFuncs(s *expr.HTTPServiceExpr) map[string]interface{} {
@@ -286,6 +320,16 @@ type {{ .ServerStruct }} struct {
 	{{- range .Endpoints }}
 	{{ .Method.VarName }} http.Handler
 	{{- end }}
+	{{- range .Endpoints }}
+		{{- if .Proxy }}
+	{{ printf "%s forwards the requests made to the %s endpoint, its hooks may be set prior to serving requests." .Proxy.FieldName .Method.Name | comment }}
+	{{ .Proxy.FieldName }} *goahttp.Proxy
+		{{- end }}
+	{{- end }}
+	{{- if .FileSystem }}
+	{{ printf "FileSystem is the file system the embedded files are served from." | comment }}
+	FileSystem http.FileSystem
+	{{- end }}
 }
 
 // ErrorNamer is an interface implemented by generated error structs that
@@ -324,17 +368,28 @@ func {{ .ServerInit }}(
 	{{ .MultipartRequestDecoder.VarName }} {{ .MultipartRequestDecoder.FuncName }},
 		{{- end }}
 	{{- end }}
+	{{- if .FileSystem }}
+	fsys http.FileSystem,
+	{{- end }}
 ) *{{ .ServerStruct }} {
 {{- if streamingEndpointExists . }}
 	if cfn == nil {
 		cfn = &ConnConfigurer{}
 	}
+{{- end }}
+{{- range .Endpoints }}
+	{{- if .Proxy }}
+	{{ .Proxy.VarName }} := {{ .Proxy.Init }}()
+	{{- end }}
 {{- end }}
 	return &{{ .ServerStruct }}{
 		Mounts: []*{{ .MountPointStruct }}{
 			{{- range $e := .Endpoints }}
 				{{- range $e.Routes }}
 			{"{{ $e.Method.VarName }}", "{{ .Verb }}", "{{ .Path }}"},
+					{{- if .Head }}
+			{"{{ $e.Method.VarName }}", "HEAD", "{{ .Path }}"},
+					{{- end }}
 				{{- end }}
 			{{- end }}
 			{{- range .FileServers }}
@@ -345,7 +400,15 @@ func {{ .ServerInit }}(
 			{{- end }}
 		},
 		{{- range .Endpoints }}
-		{{ .Method.VarName }}: {{ .HandlerInit }}(e.{{ .Method.VarName }}, mux, {{ if .MultipartRequestDecoder }}{{ .MultipartRequestDecoder.InitName }}(mux, {{ .MultipartRequestDecoder.VarName }}){{ else }}dec{{ end }}, enc, eh{{ if .ServerStream }}, up, cfn.{{ .Method.VarName }}Fn{{ end }}),
+		{{ .Method.VarName }}: {{ if .Telemetry }}telemetry.Handler({{ end }}{{ if .Cached }}goahttp.CacheHeaders({{ end }}{{ .HandlerInit }}(e.{{ .Method.VarName }}, mux, {{ if .MultipartRequestDecoder }}{{ .MultipartRequestDecoder.InitName }}(mux, {{ .MultipartRequestDecoder.VarName }}){{ else if .FormEncoded }}goahttp.FormRequestDecoder{{ else }}dec{{ end }}, enc, eh{{ if isStreamingEndpoint . }}, up, cfn.{{ .Method.VarName }}Fn{{ end }}{{ if .Proxy }}, {{ .Proxy.VarName }}{{ end }}){{ if .Cached }}){{ end }}{{ if .Telemetry }}, {{ printf "%q" .ServiceName }}, {{ printf "%q" .Method.Name }}, {{ printf "%q" (index .Routes 0).Path }}){{ end }},
+		{{- end }}
+		{{- range .Endpoints }}
+			{{- if .Proxy }}
+		{{ .Proxy.FieldName }}: {{ .Proxy.VarName }},
+			{{- end }}
+		{{- end }}
+		{{- if .FileSystem }}
+		FileSystem: fsys,
 		{{- end }}
 	}
 }
@@ -365,14 +428,90 @@ func (s *{{ .ServerStruct }}) Use(m func(http.Handler) http.Handler) {
 }
 `
 
+// input: SignatureSchemeData
+const serverVerifySignaturesT = `{{ printf "Verify%sSignatures wraps the handlers of the endpoints secured by the %q security scheme with a middleware that verifies the request signatures using the secrets returned by keys. It must be called before the handlers are mounted." .VarName .SchemeName | comment }}
+func (s *{{ .ServerStruct }}) Verify{{ .VarName }}Signatures(keys goahttp.SignatureKeyFunc) {
+	m := httpmdlwr.VerifySignature(&goahttp.SignatureVerifier{
+		Keys: keys,
+	{{- if .ClockSkew }}
+		ClockSkew: {{ .ClockSkew }},
+	{{- end }}
+	{{- if .ReplayWindow }}
+		ReplayWindow: {{ .ReplayWindow }},
+	{{- end }}
+	})
+{{- range .Endpoints }}
+	s.{{ .Method.VarName }} = m(s.{{ .Method.VarName }})
+{{- end }}
+}
+`
+
+// input: CSRFData
+const serverProtectCSRFT = `{{- if .Synchronizer }}
+{{ comment "ProtectCSRF wraps the handlers of the endpoints that authenticate requests with cookies with a middleware that protects them against Cross-Site Request Forgery. secret is the key used to derive the CSRF tokens from the session cookies, ProtectCSRF returns goahttp.ErrCSRFSecretMissing if it is empty. It must be called before the handlers are mounted." }}
+{{- else }}
+{{ comment "ProtectCSRF wraps the handlers of the endpoints that authenticate requests with cookies with a middleware that protects them against Cross-Site Request Forgery. It must be called before the handlers are mounted." }}
+{{- end }}
+func (s *{{ .ServerStruct }}) ProtectCSRF({{ if .Synchronizer }}secret []byte{{ end }}){{ if .Synchronizer }} error{{ end }} {
+{{- if .Synchronizer }}
+	if len(secret) == 0 {
+		return goahttp.ErrCSRFSecretMissing
+	}
+{{- end }}
+{{- range $i, $p := .Protections }}
+	m {{ if $i }}={{ else }}:={{ end }} httpmdlwr.ProtectCSRF(&goahttp.CSRFProtector{
+		SessionCookie: {{ printf "%q" .SessionCookie }},
+	{{- if .Synchronizer }}
+		Secret: secret,
+	{{- end }}
+	{{- if .Header }}
+		Header: {{ printf "%q" .Header }},
+	{{- end }}
+	{{- if .Cookie }}
+		Cookie: {{ printf "%q" .Cookie }},
+	{{- end }}
+	})
+	{{- range .Endpoints }}
+	s.{{ .Method.VarName }} = m(s.{{ .Method.VarName }})
+	{{- end }}
+{{- end }}
+{{- if .Synchronizer }}
+	return nil
+{{- end }}
+}
+`
+
 // input: ServiceData
 const serverMountT = `{{ printf "%s configures the mux to serve the %s endpoints." .MountServer .Service.Name | comment }}
-func {{ .MountServer }}(mux goahttp.Muxer{{ if .Endpoints }}, h *{{ .ServerStruct }}{{ end }}) {
+func {{ .MountServer }}(mux goahttp.Muxer{{ if or .Endpoints .FileSystem }}, h *{{ .ServerStruct }}{{ end }}) {
 	{{- range .Endpoints }}
 	{{ .MountHandler }}(mux, h.{{ .Method.VarName }})
 	{{- end }}
+	{{- if .CORSPaths }}
+	{{ .MountCORS }}(mux)
+	{{- end }}
+	{{- if .OptionsPaths }}
+	{{ .MountOptions }}(mux)
+	{{- end }}
 	{{- range .FileServers }}
-		{{- if .IsDir }}
+		{{- if .Configured }}
+	{{ .MountHandler }}(mux, goahttp.NewFileServer({{ if .Embed }}h.FileSystem{{ else }}nil{{ end }}, &goahttp.FileServerConfig{
+		FilePath: {{ printf "%q" .FilePath }},
+			{{- if .IsDir }}
+		IsDir: true,
+		Prefixes: []string{ {{- range $i, $p := .RequestPaths }}{{ if $i }}, {{ end }}{{ printf "%q" $p }}{{ end }} },
+			{{- end }}
+			{{- if .Fallback }}
+		Fallback: {{ printf "%q" .Fallback }},
+			{{- end }}
+			{{- if .CacheControl }}
+		CacheControl: {{ printf "%q" .CacheControl }},
+			{{- end }}
+			{{- if .ETag }}
+		ETag: true,
+			{{- end }}
+	}))
+		{{- else if .IsDir }}
 	{{ .MountHandler }}(mux, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			upath := path.Clean(r.URL.Path)
 			rpath := upath
@@ -401,10 +540,77 @@ func {{ .MountHandler }}(mux goahttp.Muxer, h http.Handler) {
 			h.ServeHTTP(w, r)
 		}
 	}
+	{{- if .CORS }}
+	f = goahttp.CORSHandler(f, {{ .CORSVarName }})
+	{{- end }}
+	{{- if .Compress }}
+	f = goahttp.CompressHandler(f, &goahttp.CompressOptions{
+		Encodings: []string{ {{- range $i, $e := .Compress.Encodings }}{{ if $i }}, {{ end }}{{ printf "%q" $e }}{{ end }} },
+		{{- if .Compress.ContentTypes }}
+		ContentTypes: []string{ {{- range $i, $t := .Compress.ContentTypes }}{{ if $i }}, {{ end }}{{ printf "%q" $t }}{{ end }} },
+		{{- end }}
+		MinSize: {{ .Compress.MinSize }},
+	})
+	{{- end }}
 	{{- range .Routes }}
 	mux.Handle("{{ .Verb }}", "{{ .Path }}", f)
+		{{- if .Head }}
+	mux.Handle("HEAD", "{{ .Path }}", goahttp.HeadHandler(f))
+		{{- end }}
+	{{- end }}
+}
+`
+
+// input: ServiceData
+const serverOptionsT = `{{ printf "%s configures the mux to serve the OPTIONS requests made to the %s endpoints." .MountOptions .Service.Name | comment }}
+func {{ .MountOptions }}(mux goahttp.Muxer) {
+	{{- range .OptionsPaths }}
+	mux.Handle("OPTIONS", "{{ .Path }}", goahttp.OptionsHandler({{ range $i, $m := .Methods }}{{ if $i }}, {{ end }}{{ printf "%q" $m }}{{ end }}))
+	{{- end }}
+}
+`
+
+// input: ServiceData
+const serverCORST = `{{ printf "%s configures the mux to serve the CORS preflight requests made to the %s endpoints." .MountCORS .Service.Name | comment }}
+func {{ .MountCORS }}(mux goahttp.Muxer) {
+	{{- range .CORSPaths }}
+	mux.Handle("OPTIONS", "{{ .Path }}", goahttp.CORSPreflightHandler({{ join .Policies ", " }}))
 	{{- end }}
 }
+
+var (
+{{- range .Endpoints }}
+	{{- if .CORS }}
+	{{ printf "%s lists the CORS policies of the %q service %q endpoint." .CORSVarName .ServiceName .Method.Name | comment }}
+	{{ .CORSVarName }} = []*goahttp.CORSPolicy{
+		{{- range .CORS }}
+		{
+			Origin: {{ printf "%q" .Origin }},
+			{{- if .OriginRegexp }}
+			OriginRegexp: regexp.MustCompile({{ printf "%q" .OriginRegexp }}),
+			{{- end }}
+			Methods: []string{ {{- range $i, $m := .Methods }}{{ if $i }}, {{ end }}{{ printf "%q" $m }}{{ end }} },
+			{{- if .Headers }}
+			Headers: []string{ {{- range $i, $h := .Headers }}{{ if $i }}, {{ end }}{{ printf "%q" $h }}{{ end }} },
+			{{- end }}
+			{{- if .Expose }}
+			Expose: []string{ {{- range $i, $h := .Expose }}{{ if $i }}, {{ end }}{{ printf "%q" $h }}{{ end }} },
+			{{- end }}
+			{{- if .MaxAge }}
+			MaxAge: {{ .MaxAge }},
+			{{- end }}
+			{{- if .Credentials }}
+			Credentials: true,
+			{{- end }}
+			{{- if .PrivateNetwork }}
+			PrivateNetwork: true,
+			{{- end }}
+		},
+		{{- end }}
+	}
+	{{- end }}
+{{- end }}
+)
 `
 
 // input: FileServerData
@@ -412,8 +618,8 @@ const fileServerT = `{{ printf "%s configures the mux to serve GET request made
 func {{ .MountHandler }}(mux goahttp.Muxer, h http.Handler) {
 	{{- if .IsDir }}
 		{{- range .RequestPaths }}
+	mux.Handle("GET", "{{ . }}/{*{{ $.PathParam }}}", h.ServeHTTP)
 	mux.Handle("GET", "{{ . }}/", h.ServeHTTP)
-	mux.Handle("GET", "{{ . }}/*{{ $.PathParam }}", h.ServeHTTP)
 		{{- end }}
 	{{- else }}
 		{{- range .RequestPaths }}
@@ -431,11 +637,19 @@ func {{ .HandlerInit }}(
 	dec func(*http.Request) goahttp.Decoder,
 	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
 	eh func(context.Context, http.ResponseWriter, error),
-	{{- if .ServerStream }}
+	{{- if isStreamingEndpoint . }}
 	up goahttp.Upgrader,
 	connConfigFn goahttp.ConnConfigureFunc,
 	{{- end }}
+	{{- if .Proxy }}
+	proxy *goahttp.Proxy,
+	{{- end }}
 ) http.Handler {
+	{{- if .WebSocket }}
+	{{- template "websocket_config" .WebSocket }}
+	up = wsc.Upgrader(up)
+	connConfigFn = wsc.ConnConfigureFunc(connConfigFn)
+	{{- end }}
 	var (
 		{{- if .ServerStream }}
 			{{- if .Payload.Ref }}
@@ -445,18 +659,88 @@ func {{ .HandlerInit }}(
 			{{- if .Payload.Ref }}
 		decodeRequest  = {{ .RequestDecoder }}(mux, dec)
 			{{- end }}
+			{{- if not .Proxy }}
 		encodeResponse = {{ .ResponseEncoder }}(enc)
+			{{- end }}
+		{{- end }}
+		{{- if .Errors }}
+		encodeError    = {{ .ErrorEncoder }}(enc)
+		{{- else if .ProblemDetails }}
+		encodeError    = goahttp.ProblemErrorEncoder(enc, {{ printf "%q" .ProblemDetails.TypeBase }})
+		{{- else }}
+		encodeError    = goahttp.ErrorEncoder(enc)
+		{{- end }}
+		{{- if .Propagation }}
+		propagation    = {{ template "propagation" .Propagation }}
+		{{- end }}
+		{{- with .Tenancy }}
+		tenancy        = &goa.Tenancy{Header: {{ printf "%q" .Header }}, Claim: {{ printf "%q" .Claim }}}
 		{{- end }}
-		encodeError    = {{ if .Errors }}{{ .ErrorEncoder }}{{ else }}goahttp.ErrorEncoder{{ end }}(enc)
 	)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, {{ printf "%q" .Method.Name }})
 		ctx = context.WithValue(ctx, goa.ServiceKey, {{ printf "%q" .ServiceName }})
+	{{- if .Method.AuthorizationInput }}
+		ctx = security.WithTransportMetadata(ctx, goahttp.RequestMetadata(r{{ range .CredentialHeaders }}, {{ printf "%q" . }}{{ end }}))
+	{{- end }}
+	{{- if .Propagation }}
+		ctx, cancelTimeout := goahttp.PropagateIncoming(ctx, r, propagation)
+		defer cancelTimeout()
+	{{- end }}
+	{{- if .Tenancy }}
+		ctx = tenancy.Incoming(ctx, r.Header.Get)
+	{{- end }}
+	{{- with .MediaTypes }}
+		{{- if .Consumes }}
+		if err := goahttp.CheckContentType(r, {{ range $i, $t := .Consumes }}{{ if $i }}, {{ end }}{{ printf "%q" $t }}{{ end }}); err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		{{- end }}
+		{{- if .Produces }}
+		if err := goahttp.CheckAccept(r, {{ range $i, $t := .Produces }}{{ if $i }}, {{ end }}{{ printf "%q" $t }}{{ end }}); err != nil {
+			// The error is encoded with the default encoder since none of
+			// the accepted media types is produced.
+			ctx = context.WithValue(ctx, goahttp.AcceptTypeKey, "")
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		{{- end }}
+	{{- end }}
+	{{- with .StrictDecoding }}{{ if .CheckQuery }}
+		if err := goahttp.CheckQueryParams(r{{ range .QueryParams }}, {{ printf "%q" . }}{{ end }}); err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+	{{- end }}{{ end }}
 
 	{{- if .Payload.Ref }}
+		{{- if .BodyLimit }}
+		goahttp.LimitRequestBody(w, r, {{ .BodyLimit }})
+		{{- else if and .Proxy .Proxy.BufferBody }}
+		goahttp.LimitRequestBody(w, r, goahttp.DefaultProxyBodyLimit)
+		{{- end }}
+		{{- if and .Proxy .Proxy.BufferBody }}
+		if err := goahttp.BufferRequestBody(r); err != nil {
+			err = goahttp.BodyLimitError(r, err)
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		{{- end }}
 		payload, err := decodeRequest(r)
 		if err != nil {
+			{{- if or .BodyLimit (and .Proxy .Proxy.BufferBody) }}
+			err = goahttp.BodyLimitError(r, err)
+			{{- end }}
 			if err := encodeError(ctx, w, err); err != nil {
 				eh(ctx, w, err)
 			}
@@ -467,6 +751,16 @@ func {{ .HandlerInit }}(
 	{{- end }}
 
 	{{ if .ServerStream }}
+		{{- if .ServerStream.ChunkedFormat }}
+		stream := &{{ .ServerStream.VarName }}{w: goahttp.NewStreamWriter(w, {{ .ServerStream.ChunkedFormat }})}
+		v := &{{ .ServicePkgName }}.{{ .Method.ServerStream.EndpointStruct }}{
+			Stream: stream,
+		{{- if .Payload.Ref }}
+			Payload: payload.({{ .Payload.Ref }}),
+		{{- end }}
+		}
+		_, err = endpoint(ctx, v)
+		{{- else }}
 		var cancel context.CancelFunc
 		{
 			ctx, cancel = context.WithCancel(ctx)
@@ -484,28 +778,67 @@ func {{ .HandlerInit }}(
 		{{- end }}
 		}
 		_, err = endpoint(ctx, v)
+		{{- end }}
+	{{- else if .Proxy }}
+		_, err = endpoint(ctx, {{ if .Payload.Ref }}payload{{ else }}nil{{ end }})
 	{{- else }}
 		res, err := endpoint(ctx, {{ if .Payload.Ref }}payload{{ else }}nil{{ end }})
 	{{- end }}
 
 		if err != nil {
 			{{- if .ServerStream }}
+				{{- if .ServerStream.ChunkedFormat }}
+			if stream.w.Started() {
+				eh(ctx, w, err)
+				return
+			}
+				{{- else }}
 			if _, ok := err.(websocket.HandshakeError); ok {
 				return
 			}
+				{{- end }}
 			{{- end }}
 			if err := encodeError(ctx, w, err); err != nil {
 				eh(ctx, w, err)
 			}
 			return
 		}
-	{{- if not .ServerStream }}
+	{{- if .Proxy }}
+		proxy.ServeHTTP(w, r.WithContext(ctx))
+	{{- else if .ServerStream }}
+		{{- if .ServerStream.ChunkedFormat }}
+		if err := stream.Close(); err != nil {
+			eh(ctx, w, err)
+		}
+		{{- end }}
+	{{- else }}
 		if err := encodeResponse(ctx, w, res); err != nil {
 			eh(ctx, w, err)
 		}
 	{{- end }}
 	})
 }
+` + webSocketConfigT + propagationT
+
+// input: EndpointData
+const serverProxyInitT = `{{ printf "%s creates the proxy that forwards the requests made to the %q service %q endpoint to %q." .Proxy.Init .ServiceName .Method.Name .Proxy.Upstream | comment }}
+func {{ .Proxy.Init }}() *goahttp.Proxy {
+	p := goahttp.NewProxy({{ printf "%q" .Proxy.Upstream }})
+	{{- if .Proxy.StripPrefix }}
+	p.StripPrefix = {{ printf "%q" .Proxy.StripPrefix }}
+	{{- end }}
+	{{- if .Proxy.Headers }}
+	p.Header = http.Header{
+		{{- range .Proxy.Headers }}
+		{{ printf "%q" .Name }}: { {{- printf "%q" .Value }}},
+		{{- end }}
+	}
+	{{- end }}
+	{{- if .Proxy.RemoveHeaders }}
+	p.RemoveHeaders = []string{ {{- range $i, $h := .Proxy.RemoveHeaders }}{{ if $i }}, {{ end }}{{ printf "%q" $h }}{{ end }} }
+	{{- end }}
+	return p
+}
 `
 
 // input: TransformFunctionData
@@ -530,11 +863,16 @@ func {{ .RequestDecoder }}(mux goahttp.Muxer, decoder func(*http.Request) goahtt
 			body {{ .Payload.Request.ServerBody.VarName }}
 			err  error
 		)
-		err = decoder(r).Decode(&body)
+		err = {{ if .StrictDecoding }}goahttp.StrictDecoder({{ end }}{{ with .LenientDecoding }}goahttp.LenientDecoder(decoder, {{ .All }}){{ else }}decoder{{ end }}{{ if .StrictDecoding }}){{ end }}(r).Decode(&body)
 		if err != nil {
 			if err == io.EOF {
 				return nil, goa.MissingPayloadError()
 			}
+			{{- if .StrictDecoding }}
+			if _, ok := err.(*goa.ServiceError); ok {
+				return nil, err
+			}
+			{{- end }}
 			return nil, goa.DecodePayloadError(err.Error())
 		}
 		{{- if .Payload.Request.ServerBody.ValidateRef }}
@@ -569,6 +907,32 @@ func {{ .RequestDecoder }}(mux goahttp.Muxer, decoder func(*http.Request) goahtt
 	payload.{{ .UsernameField }} = {{ if .UsernamePointer }}&{{ end }}user
 	payload.{{ .PasswordField }} = {{ if .PasswordPointer }}&{{ end }}pass
 {{- end }}{{ end }}
+{{- if .MTLSScheme }}{{ with .MTLSScheme }}
+	{{- if or .CredPointer .CredRequired }}
+	cert := security.PeerCertificate(r.TLS)
+		{{- if .CredRequired }}
+	if cert == "" {
+		return nil, goa.MissingFieldError({{ printf "%q" .KeyAttr }}, "TLS client certificate")
+	}
+		{{- end }}
+		{{- if .CredPointer }}
+	if cert != "" {
+		payload.{{ .CredField }} = &cert
+	}
+		{{- else }}
+	payload.{{ .CredField }} = cert
+		{{- end }}
+	{{- else }}
+	payload.{{ .CredField }} = security.PeerCertificate(r.TLS)
+	{{- end }}
+{{- end }}{{ end }}
+{{- range .APIKeyFallbacks }}
+	if payload.{{ .CredField }} == {{ if .CredPointer }}nil{{ else }}""{{ end }} {
+		if key := goahttp.ReadAPIKey(r, {{ range $i, $l := .Locations }}{{ if $i }}, {{ end }}goahttp.KeyLocation{In: {{ printf "%q" $l.In }}, Name: {{ printf "%q" $l.Name }}}{{ end }}); key != "" {
+			payload.{{ .CredField }} = {{ if .CredPointer }}&{{ end }}key
+		}
+	}
+{{- end }}
 {{- range .HeaderSchemes }}
 	{{- if not .CredRequired }}
 	if payload.{{ .CredField }} != nil {
@@ -590,7 +954,7 @@ func {{ .RequestDecoder }}(mux goahttp.Muxer, decoder func(*http.Request) goahtt
 
 // input: RequestData
 const requestParamsHeadersT = `{{- define "request_params_headers" }}
-{{- if or .PathParams .QueryParams .Headers }}
+{{- if or .PathParams .QueryParams .Headers .Cookies }}
 {{- if .ServerBody }}{{/* we want a newline only if there was code before */}}
 {{ end }}
 		var (
@@ -603,6 +967,9 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 		{{- range .Headers }}
 			{{ .VarName }} {{ .TypeRef }}
 		{{- end }}
+		{{- range .Cookies }}
+			{{ .VarName }} {{ .TypeRef }}
+		{{- end }}
 		{{- if and .MustValidate (or (not .ServerBody) .Multipart) }}
 			err error
 		{{- end }}
@@ -613,8 +980,13 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 		)
 
 {{- range .PathParams }}
-	{{- if and (or (eq .Type.Name "string") (eq .Type.Name "any")) }}
-		{{ .VarName }} = params["{{ .Name }}"]
+	{{- if and (eq .Type.Name "string") .Pointer }}
+		if {{ .VarName }}Raw := {{ if .Trim }}strings.TrimSpace(params["{{ .Name }}"]){{ else }}params["{{ .Name }}"]{{ end }}; {{ .VarName }}Raw != "" {
+			{{ .VarName }} = &{{ .VarName }}Raw
+		}
+
+	{{- else if and (or (eq .Type.Name "string") (eq .Type.Name "any")) }}
+		{{ .VarName }} = {{ if .Trim }}strings.TrimSpace(params["{{ .Name }}"]){{ else }}params["{{ .Name }}"]{{ end }}
 
 	{{- else }}{{/* not string and not any */}}
 		{
@@ -630,13 +1002,13 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 
 {{- range .QueryParams }}
 	{{- if and (or (eq .Type.Name "string") (eq .Type.Name "any")) .Required }}
-		{{ .VarName }} = r.URL.Query().Get("{{ .Name }}")
+		{{ .VarName }} = {{ if .Trim }}strings.TrimSpace(r.URL.Query().Get("{{ .Name }}")){{ else }}r.URL.Query().Get("{{ .Name }}"){{ end }}
 		if {{ .VarName }} == "" {
 			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "query string"))
 		}
 
 	{{- else if (or (eq .Type.Name "string") (eq .Type.Name "any")) }}
-		{{ .VarName }}Raw := r.URL.Query().Get("{{ .Name }}")
+		{{ .VarName }}Raw := {{ if .Trim }}strings.TrimSpace(r.URL.Query().Get("{{ .Name }}")){{ else }}r.URL.Query().Get("{{ .Name }}"){{ end }}
 		if {{ .VarName }}Raw != "" {
 			{{ .VarName }} = {{ if and (eq .Type.Name "string") .Pointer }}&{{ end }}{{ .VarName }}Raw
 		}
@@ -646,7 +1018,7 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 		{{- end }}
 
 	{{- else if .StringSlice }}
-		{{ .VarName }} = r.URL.Query()["{{ .Name }}"]
+		{{ .VarName }} = {{ template "query_slice" . }}
 		{{- if .Required }}
 		if {{ .VarName }} == nil {
 			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "query string"))
@@ -659,7 +1031,7 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 
 	{{- else if .Slice }}
 	{
-		{{ .VarName }}Raw := r.URL.Query()["{{ .Name }}"]
+		{{ .VarName }}Raw := {{ template "query_slice" . }}
 		{{- if .Required }}
 		if {{ .VarName }}Raw == nil {
 			return goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "query string"))
@@ -765,13 +1137,13 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 
 {{- range .Headers }}
 	{{- if and (or (eq .Type.Name "string") (eq .Type.Name "any")) .Required }}
-		{{ .VarName }} = r.Header.Get("{{ .Name }}")
+		{{ .VarName }} = {{ if .Trim }}strings.TrimSpace(r.Header.Get("{{ .Name }}")){{ else }}r.Header.Get("{{ .Name }}"){{ end }}
 		if {{ .VarName }} == "" {
 			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "header"))
 		}
 
 	{{- else if (or (eq .Type.Name "string") (eq .Type.Name "any")) }}
-		{{ .VarName }}Raw := r.Header.Get("{{ .Name }}")
+		{{ .VarName }}Raw := {{ if .Trim }}strings.TrimSpace(r.Header.Get("{{ .Name }}")){{ else }}r.Header.Get("{{ .Name }}"){{ end }}
 		if {{ .VarName }}Raw != "" {
 			{{ .VarName }} = {{ if and (eq .Type.Name "string") .Pointer }}&{{ end }}{{ .VarName }}Raw
 		}
@@ -841,12 +1213,54 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 		{{ .Validate }}
 	{{- end }}
 {{- end }}
+
+{{- range .Cookies }}
+	{
+	{{- if $.SignedCookies }}
+		{{ .VarName }}Raw, err2 := goahttp.ReadSignedCookie(r, {{ printf "%q" .Name }})
+		if err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}{{ if .Required }} else if {{ .VarName }}Raw == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError({{ printf "%q" .Name }}, "cookie"))
+		}{{ end }}
+	{{- else }}
+		{{ .VarName }}Raw := goahttp.ReadCookie(r, {{ printf "%q" .Name }})
+		{{- if .Required }}
+		if {{ .VarName }}Raw == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError({{ printf "%q" .Name }}, "cookie"))
+		}
+		{{- end }}
+	{{- end }}
+	{{- if and (eq .Type.Name "string") (not .Pointer) (not .DefaultValue) }}
+		{{ .VarName }} = {{ .VarName }}Raw
+	{{- else }}
+		if {{ .VarName }}Raw != "" {
+		{{- if eq .Type.Name "string" }}
+			{{ .VarName }} = {{ if .Pointer }}&{{ end }}{{ .VarName }}Raw
+		{{- else }}
+			{{- template "type_conversion" . }}
+		{{- end }}
+		}
+		{{- if .DefaultValue }} else {
+			{{ .VarName }} = {{ if eq .Type.Name "string" }}{{ printf "%q" .DefaultValue }}{{ else }}{{ printf "%#v" .DefaultValue }}{{ end }}
+		}
+		{{- end }}
+	{{- end }}
+	}
+	{{- if .Validate }}
+		{{ .Validate }}
+	{{- end }}
+{{- end }}
 {{- end }}
 {{- end }}
 
+{{- define "query_slice" -}}
+	{{ if .Delimiter }}goahttp.SplitQueryValues(r.URL.Query()["{{ .Name }}"], {{ printf "%q" .Delimiter }}){{ else }}r.URL.Query()["{{ .Name }}"]{{ end }}
+{{- end }}
+
 {{- define "path_conversion" }}
 	{{- if eq .Type.Name "array" }}
-		{{ .VarName }}RawSlice := strings.Split({{ .VarName }}Raw, ",")
+		{{ .VarName }}RawSlice := strings.Split({{ .VarName }}Raw, {{ printf "%q" .Delimiter }})
 		{{ .VarName }} = make({{ goTypeRef .Type }}, len({{ .VarName }}RawSlice))
 		for i, rv := range {{ .VarName }}RawSlice {
 			{{- template "slice_item_conversion" . }}
@@ -982,7 +1396,7 @@ const typeConversionT = `{{- define "slice_conversion" }}
 		}
 		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
 	{{- else if eq .Type.Name "boolean" }}
-		v, err2 := strconv.ParseBool({{ .VarName }}Raw)
+		v, err2 := {{ if .Lenient }}goahttp.ParseBool{{ else }}strconv.ParseBool{{ end }}({{ .VarName }}Raw)
 		if err2 != nil {
 			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "boolean"))
 		}
@@ -1045,7 +1459,7 @@ const typeConversionT = `{{- define "slice_conversion" }}
 			}
 			{{ .VarName }}[i] = v
 		{{- else if eq .Type.ElemType.Type.Name "boolean" }}
-			v, err2 := strconv.ParseBool(rv)
+			v, err2 := {{ if .Lenient }}goahttp.ParseBool{{ else }}strconv.ParseBool{{ end }}(rv)
 			if err2 != nil {
 				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of booleans"))
 			}
@@ -1071,6 +1485,9 @@ func {{ .ResponseEncoder }}(encoder func(context.Context, http.ResponseWriter) g
 		{{- else }}
 			res := v.({{ .Result.Ref }})
 		{{- end }}
+		{{- if .JobStatusPath }}
+			w.Header().Set("Location", {{ .JobStatusPath }}(res.ID))
+		{{- end }}
 		{{- range .Result.Responses }}
 			{{- if .ContentType }}
 				ctx = context.WithValue(ctx, goahttp.ContentTypeKey, "{{ .ContentType }}")
@@ -1083,7 +1500,15 @@ func {{ .ResponseEncoder }}(encoder func(context.Context, http.ResponseWriter) g
 				{{- end }}
 			{{- end -}}
 			{{ template "response" . }}
-			{{- if .ServerBody }}
+			{{- if .Trailers }}
+				{{- if .ServerBody }}
+				if err := enc.Encode(body); err != nil {
+					return err
+				}
+				{{- end }}
+				{{- template "trailers" . }}
+				return nil
+			{{- else if .ServerBody }}
 				return enc.Encode(body)
 			{{- else }}
 				return nil
@@ -1100,13 +1525,31 @@ func {{ .ResponseEncoder }}(encoder func(context.Context, http.ResponseWriter) g
 	{{- end }}
 	}
 }
-` + responseT
+` + responseT + cookiesT
 
 // input: EndpointData
 const errorEncoderT = `{{ printf "%s returns an encoder for errors returned by the %s %s endpoint." .ErrorEncoder .Method.Name .ServiceName | comment }}
 func {{ .ErrorEncoder }}(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, error) error {
+	{{- if .ProblemDetails }}
+	encodeError := goahttp.ProblemErrorEncoder(encoder, {{ printf "%q" .ProblemDetails.TypeBase }})
+	{{- else }}
 	encodeError := goahttp.ErrorEncoder(encoder)
+	{{- end }}
 	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+	{{- if .Method.Requirements }}
+		if aerr, ok := v.(*security.AuthError); ok {
+			{{ comment "Encode the error returned by the last authorization function if it is described in the design." }}
+			if en, ok := aerr.Last().(ErrorNamer); ok {
+				switch en.ErrorName() {
+				case {{ range $i, $gerr := .Errors }}{{ range $j, $err := .Errors }}{{ if or $i $j }}, {{ end }}{{ printf "%q" .Name }}{{ end }}{{ end }}:
+					for _, c := range goahttp.AuthChallenges(aerr) {
+						w.Header().Add("WWW-Authenticate", c)
+					}
+					v = aerr.Last()
+				}
+			}
+		}
+	{{- end }}
 		en, ok := v.(ErrorNamer)
 		if !ok {
 			return encodeError(ctx, w, v)
@@ -1116,12 +1559,17 @@ func {{ .ErrorEncoder }}(encoder func(context.Context, http.ResponseWriter) goah
 	{{- range $err := .Errors }}
 		case {{ printf "%q" .Name }}:
 			res := v.({{ $err.Ref }})
+			{{- if .Problem }}
+			w.Header().Set("goa-error", {{ printf "%q" .Name }})
+			return goahttp.EncodeProblem(ctx, encoder, w, res, {{ printf "%q" $.ProblemDetails.TypeBase }}, {{ .Response.StatusCode }})
+			{{- else }}
 			{{- with .Response}}
 				{{- template "response" . }}
 				{{- if .ServerBody }}
 				return enc.Encode(body)
 				{{- end }}
 			{{- end }}
+			{{- end }}
 	{{- end }}
 	{{- end }}
 		default:
@@ -1129,7 +1577,7 @@ func {{ .ErrorEncoder }}(encoder func(context.Context, http.ResponseWriter) goah
 		}
 	}
 }
-` + responseT
+` + responseT + cookiesT
 
 // input: ResponseData
 const responseT = `{{ define "response" -}}
@@ -1156,8 +1604,11 @@ const responseT = `{{ define "response" -}}
 	{{- range .Headers }}
 		{{- $initDef := and (or .FieldPointer .Slice) .DefaultValue (not $.TagName) }}
 		{{- $checkNil := and (or .FieldPointer .Slice (eq .Type.Name "bytes") (eq .Type.Name "any") $initDef) (not $.TagName) }}
+		{{- $block := and (not $checkNil) (not $initDef) (ne .Type.Name "string") }}
 		{{- if $checkNil }}
 	if res.{{ if $.ViewedResult }}Projected.{{ end }}{{ .FieldName }} != nil {
+		{{- else if $block }}
+	{
 		{{- end }}
 
 		{{- if eq .Type.Name "string" }}
@@ -1173,7 +1624,7 @@ const responseT = `{{ define "response" -}}
 		w.Header().Set("{{ .CanonicalName }}", "{{ printValue .Type .DefaultValue }}")
 		{{- end }}
 
-		{{- if or $checkNil $initDef }}
+		{{- if or $checkNil $initDef $block }}
 	}
 		{{- end }}
 
@@ -1182,9 +1633,35 @@ const responseT = `{{ define "response" -}}
 	{{- if .ErrorHeader }}
 	w.Header().Set("goa-error", {{ printf "%q" .ErrorHeader }})
 	{{- end }}
+	{{- if .Trailers }}
+	w.Header().Set("Trailer", "{{ range $i, $t := .Trailers }}{{ if $i }}, {{ end }}{{ .CanonicalName }}{{ end }}")
+	{{- end }}
+	{{- template "cookies" . }}
 	w.WriteHeader({{ .StatusCode }})
 {{- end }}
 
+{{- define "trailers" }}
+	{{- range .Trailers }}
+		{{- $checkNil := and (or .FieldPointer (eq .Type.Name "bytes") (eq .Type.Name "any")) (not $.TagName) }}
+		{{- $block := or $checkNil (ne .Type.Name "string") }}
+		{{- if $checkNil }}
+	if res.{{ if $.ViewedResult }}Projected.{{ end }}{{ .FieldName }} != nil {
+		{{- else if $block }}
+	{
+		{{- end }}
+		{{- if eq .Type.Name "string" }}
+	w.Header().Set("{{ .CanonicalName }}", {{ if or .FieldPointer $.ViewedResult }}*{{ end }}res{{ if $.ViewedResult }}.Projected{{ end }}{{ if .FieldName }}.{{ .FieldName }}{{ end }})
+		{{- else }}
+	val := res{{ if $.ViewedResult }}.Projected{{ end }}{{ if .FieldName }}.{{ .FieldName }}{{ end }}
+	{{ template "header_conversion" (headerConversionData .Type (printf "%ss" .VarName) (not .FieldPointer) "val") }}
+	w.Header().Set("{{ .CanonicalName }}", {{ .VarName }}s)
+		{{- end }}
+		{{- if $block }}
+	}
+		{{- end }}
+	{{- end }}
+{{- end }}
+
 {{- define "header_conversion" }}
 	{{- if eq .Type.Name "boolean" -}}
 		{{ .VarName }} := strconv.FormatBool({{ if not .Required }}*{{ end }}{{ .Target }})
@@ -1232,9 +1709,91 @@ const multipartRequestDecoderTypeT = `{{ printf "%s is the type to decode multip
 type {{ .FuncName }} func(*multipart.Reader, *{{ .Payload.Ref }}) error
 `
 
+// input: MultipartData
+const multipartRequestDefaultDecoderT = `{{ printf "%s is the default decoder of the multipart requests sent to the %q service %q endpoint. It is used when New is given a nil decoder function. The parts are mapped to the payload attributes using their form names, parts with other names are ignored." .Default .ServiceName .MethodName | comment }}
+func {{ .Default }}(mr *multipart.Reader, p *{{ .Payload.Ref }}) error {
+	var (
+	{{- range .Parts }}
+		{{ .VarName }} {{ if .Pointer }}*{{ end }}{{ .TypeRef }}{{ if .DefaultValue }} = {{ .DefaultValue }}{{ end }}
+		{{- if .Required }}
+		{{ .VarName }}Found bool
+		{{- end }}
+	{{- end }}
+		err error
+	)
+	{{- range .Parts }}
+		{{- if .Stream }}
+	defer func() {
+		if c, ok := {{ .VarName }}.(io.Closer); ok && *p == nil {
+			c.Close()
+		}
+	}()
+		{{- end }}
+	{{- end }}
+	for {
+		part, err2 := mr.NextPart()
+		if err2 == io.EOF {
+			break
+		}
+		if err2 != nil {
+			return goa.DecodePayloadError(err2.Error())
+		}
+		switch part.FormName() {
+	{{- range .Parts }}
+		case {{ printf "%q" .Name }}:
+		{{- if .Stream }}
+			if c, ok := {{ .VarName }}.(io.Closer); ok {
+				c.Close()
+			}
+			v, err2 := goahttp.SpoolPart(part, goahttp.DefaultPartMemory)
+			if err2 != nil {
+				return goa.DecodePayloadError(err2.Error())
+			}
+		{{- else }}
+			b, err2 := ioutil.ReadAll(part)
+			if err2 != nil {
+				return goa.DecodePayloadError(err2.Error())
+			}
+			{{ .Decode }}
+		{{- end }}
+			{{- if .Array }}
+			{{ .VarName }} = append({{ .VarName }}, v)
+			{{- else }}
+			{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
+			{{- end }}
+			{{- if .Required }}
+			{{ .VarName }}Found = true
+			{{- end }}
+	{{- end }}
+		}
+	}
+	{{- range .Parts }}
+		{{- if .Required }}
+	if !{{ .VarName }}Found {
+		err = goa.MergeErrors(err, goa.MissingFieldError({{ printf "%q" .Name }}, "multipart request"))
+	}
+		{{- end }}
+	{{- end }}
+	if err != nil {
+		return err
+	}
+	*p = &{{ .PayloadType }}{
+	{{- range .Parts }}
+		{{ .FieldName }}: {{ .VarName }},
+	{{- end }}
+	}
+	return nil
+}
+`
+
 // input: multipartData
 const multipartRequestDecoderT = `{{ printf "%s returns a decoder to decode the multipart request for the %q service %q endpoint." .InitName .ServiceName .MethodName | comment }}
 func {{ .InitName }}(mux goahttp.Muxer, {{ .VarName }} {{ .FuncName }}) func(r *http.Request) goahttp.Decoder {
+	{{- if .Default }}
+	if {{ .VarName }} == nil {
+		{{ .VarName }} = {{ .Default }}
+	}
+	{{- end }}
 	return func(r *http.Request) goahttp.Decoder {
 		return goahttp.EncodingFunc(func(v interface{}) error {
 			mr, merr := r.MultipartReader()
@@ -60,6 +60,8 @@ func TestDecode(t *testing.T) {
 		{"query-array-float64-validate", testdata.PayloadQueryArrayFloat64ValidateDSL, testdata.PayloadQueryArrayFloat64ValidateDecodeCode},
 		{"query-array-string", testdata.PayloadQueryArrayStringDSL, testdata.PayloadQueryArrayStringDecodeCode},
 		{"query-array-string-validate", testdata.PayloadQueryArrayStringValidateDSL, testdata.PayloadQueryArrayStringValidateDecodeCode},
+		{"query-array-string-pipe-delimited", testdata.PayloadQueryArrayStringPipeDelimitedDSL, testdata.PayloadQueryArrayStringPipeDelimitedDecodeCode},
+		{"query-array-int-comma-delimited", testdata.PayloadQueryArrayIntCommaDelimitedDSL, testdata.PayloadQueryArrayIntCommaDelimitedDecodeCode},
 		{"query-array-bytes", testdata.PayloadQueryArrayBytesDSL, testdata.PayloadQueryArrayBytesDecodeCode},
 		{"query-array-bytes-validate", testdata.PayloadQueryArrayBytesValidateDSL, testdata.PayloadQueryArrayBytesValidateDecodeCode},
 		{"query-array-any", testdata.PayloadQueryArrayAnyDSL, testdata.PayloadQueryArrayAnyDecodeCode},
@@ -107,6 +109,8 @@ func TestDecode(t *testing.T) {
 		{"path-primitive-bool-validate", testdata.PayloadPathPrimitiveBoolValidateDSL, testdata.PayloadPathPrimitiveBoolValidateDecodeCode},
 		{"path-primitive-array-string-validate", testdata.PayloadPathPrimitiveArrayStringValidateDSL, testdata.PayloadPathPrimitiveArrayStringValidateDecodeCode},
 		{"path-primitive-array-bool-validate", testdata.PayloadPathPrimitiveArrayBoolValidateDSL, testdata.PayloadPathPrimitiveArrayBoolValidateDecodeCode},
+		{"path-styled-params", testdata.PathStyledParamsDSL, testdata.PayloadPathStyledParamsDecodeCode},
+		{"path-catch-all-slice", testdata.PathCatchAllSliceParamDSL, testdata.PayloadPathCatchAllSliceParamDecodeCode},
 
 		{"header-string", testdata.PayloadHeaderStringDSL, testdata.PayloadHeaderStringDecodeCode},
 		{"header-string-validate", testdata.PayloadHeaderStringValidateDSL, testdata.PayloadHeaderStringValidateDecodeCode},
@@ -171,6 +175,7 @@ func TestDecode(t *testing.T) {
 		{"multipart-body-array-type", testdata.PayloadMultipartArrayTypeDSL, testdata.PayloadMultipartArrayTypeDecodeCode},
 		{"multipart-body-map-type", testdata.PayloadMultipartMapTypeDSL, testdata.PayloadMultipartMapTypeDecodeCode},
 		{"with-params-and-headers-dsl", testdata.WithParamsAndHeadersBlockDSL, testdata.WithParamsAndHeadersBlockDecodeCode},
+		{"client-certificate", testdata.PayloadClientCertificateDSL, testdata.PayloadClientCertificateDecodeCode},
 	}
 	golden := makeGolden(t, "testdata/payload_decode_functions.go")
 	if golden != nil {
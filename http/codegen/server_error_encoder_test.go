@@ -17,6 +17,8 @@ func TestEncodeError(t *testing.T) {
 		{"primitive-error-response", testdata.PrimitiveErrorResponseDSL, testdata.PrimitiveErrorResponseEncoderCode},
 		{"default-error-response", testdata.DefaultErrorResponseDSL, testdata.DefaultErrorResponseEncoderCode},
 		{"service-error-response", testdata.ServiceErrorResponseDSL, testdata.ServiceErrorResponseEncoderCode},
+		{"rate-limited-error-response", testdata.RateLimitedErrorResponseDSL, testdata.RateLimitedErrorResponseEncoderCode},
+		{"secured-error-response", testdata.SecuredErrorResponseDSL, testdata.SecuredErrorResponseEncoderCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -25,11 +27,11 @@ func TestEncodeError(t *testing.T) {
 			if len(fs) != 2 {
 				t.Fatalf("got %d files, expected two", len(fs))
 			}
-			sections := fs[1].SectionTemplates
-			if len(sections) < 2 {
-				t.Fatalf("got %d sections, expected at least 2", len(sections))
+			sections := fs[1].Section("error-encoder")
+			if len(sections) != 1 {
+				t.Fatalf("got %d error encoder sections, expected 1", len(sections))
 			}
-			code := codegen.SectionCode(t, sections[2])
+			code := codegen.SectionCode(t, sections[0])
 			if code != c.Code {
 				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
 			}
@@ -51,6 +51,8 @@ func serverType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 	path = filepath.Join(codegen.Gendir, "http", svcName, "server", "types.go")
 	header := codegen.Header(svc.Name()+" HTTP server types", "server",
 		[]*codegen.ImportSpec{
+			{Path: "fmt"},
+			{Path: "strconv"},
 			{Path: "unicode/utf8"},
 			{Path: genpkg + "/" + svcName, Name: data.Service.PkgName},
 			codegen.GoaImport(""),
@@ -198,7 +200,7 @@ func serverType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 		})
 	}
 
-	return &codegen.File{Path: path, SectionTemplates: sections}
+	return &codegen.File{Path: path, SectionTemplates: sections, Service: svc.Name()}
 }
 
 // input: TypeData
@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 	"text/template"
+	"time"
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/codegen/service"
@@ -38,6 +39,9 @@ type (
 		Endpoints []*EndpointData
 		// FileServers lists the file servers for this service.
 		FileServers []*FileServerData
+		// FileSystem is true if at least one file server reads its files
+		// from the file system given to the server constructor.
+		FileSystem bool
 		// ServerStruct is the name of the HTTP server struct.
 		ServerStruct string
 		// MountPointStruct is the name of the mount point struct.
@@ -49,6 +53,28 @@ type (
 		MountServer string
 		// ServerService is the name of service function.
 		ServerService string
+		// MountCORS is the name of the function that mounts the CORS
+		// preflight handlers.
+		MountCORS string
+		// CORSPaths lists the paths that require a CORS preflight
+		// handler.
+		CORSPaths []*CORSPathData
+		// MountOptions is the name of the function that mounts the
+		// OPTIONS handlers.
+		MountOptions string
+		// OptionsPaths lists the paths served by an OPTIONS handler.
+		OptionsPaths []*OptionsPathData
+		// Webhooks lists the webhooks sent by the service.
+		Webhooks []*WebhookData
+		// ServerURLs lists the functions generated in the client package
+		// that build the URLs of the hosts defining URL variables.
+		ServerURLs []*ServerURLData
+		// SignatureSchemes lists the request signature security schemes
+		// used by the service endpoints.
+		SignatureSchemes []*SignatureSchemeData
+		// CSRF describes the CSRF protection of the endpoints that
+		// authenticate requests with cookies, nil if there is none.
+		CSRF *CSRFData
 		// ClientStruct is the name of the HTTP client struct.
 		ClientStruct string
 		// ServerBodyAttributeTypes is the list of user types used to
@@ -103,6 +129,8 @@ type (
 		Routes []*RouteData
 		// BasicScheme is the basic auth security scheme if any.
 		BasicScheme *service.SchemeData
+		// MTLSScheme is the mutual TLS security scheme if any.
+		MTLSScheme *service.SchemeData
 		// HeaderSchemes lists all the security requirement schemes that
 		// apply to the method and are encoded in the request header.
 		HeaderSchemes service.SchemesData
@@ -113,6 +141,23 @@ type (
 		// apply to the method and are encoded in the request query
 		// string.
 		QuerySchemes service.SchemesData
+		// APIKeyFallbacks lists the API keys read from other locations
+		// when the request does not carry them in the location the key
+		// attribute is mapped to.
+		APIKeyFallbacks []*APIKeyFallbackData
+		// CredentialHeaders lists the names of the request headers other
+		// than Authorization that carry the credentials of the service
+		// security schemes. They are omitted from the transport metadata
+		// given to the authorization policies.
+		CredentialHeaders []string
+		// CacheKeyHeaders lists the names of the request headers other
+		// than Authorization and Cookie whose values key the responses
+		// cached by the client: the credential headers and the tenant
+		// header.
+		CacheKeyHeaders []string
+		// FormEncoded indicates that the request body uses the
+		// "application/x-www-form-urlencoded" encoding.
+		FormEncoded bool
 
 		// server
 
@@ -133,6 +178,56 @@ type (
 		// ServerStream holds the data to render the server struct which
 		// implements the server stream interface.
 		ServerStream *StreamData
+		// CORS lists the CORS policies that apply to the endpoint.
+		CORS []*CORSData
+		// CORSVarName is the name of the variable holding the endpoint
+		// CORS policies.
+		CORSVarName string
+		// WebSocket holds the websocket settings of the server side of
+		// streaming endpoints if any.
+		WebSocket *WebSocketData
+		// ClientWebSocket holds the websocket settings of the client
+		// side of streaming endpoints if any.
+		ClientWebSocket *WebSocketData
+		// BodyLimit is the maximum size in bytes of the request body,
+		// zero means no limit.
+		BodyLimit int64
+		// Compress describes the compression of the endpoint responses
+		// if any.
+		Compress *CompressData
+		// Propagation describes the request context values propagated
+		// by the endpoint server and client if any.
+		Propagation *PropagationData
+		// Tenancy describes the tenancy settings used by the endpoint
+		// server to record the tenant claim of the bearer token of the
+		// requests if any.
+		Tenancy *TenancyData
+		// ProblemDetails describes how the errors are encoded as problem
+		// details documents, nil if the errors use the default encoding.
+		ProblemDetails *ProblemDetailsData
+		// MediaTypes lists the media types checked by the endpoint server
+		// if any.
+		MediaTypes *MediaTypesData
+		// StrictDecoding describes how the endpoint server rejects the
+		// requests containing fields not defined in the design if it
+		// does.
+		StrictDecoding *StrictDecodingData
+		// LenientDecoding describes how the endpoint server coerces the
+		// request body values if it does.
+		LenientDecoding *LenientDecodingData
+		// Proxy holds the settings of the proxy that forwards the
+		// requests to the upstream service if any.
+		Proxy *ProxyData
+		// Telemetry is true if the endpoint server and client are
+		// instrumented with OpenTelemetry.
+		Telemetry bool
+		// Cached is true if the method results are cached in which
+		// case the server sets the caching headers of the responses.
+		Cached bool
+		// JobStatusPath is the name of the function that computes the
+		// path of the job status endpoint if the method is async. The
+		// server sets the Location header of the responses to the path.
+		JobStatusPath string
 
 		// client
 
@@ -153,6 +248,9 @@ type (
 		// ClientStream holds the data to render the client struct which
 		// implements the client stream interface.
 		ClientStream *StreamData
+		// ClientPolicy holds the timeout and retry settings applied by
+		// the client if any.
+		ClientPolicy *ClientPolicyData
 	}
 
 	// FileServerData lists the data needed to generate file servers.
@@ -169,6 +267,317 @@ type (
 		// PathParam is the name of the parameter used to capture the
 		// path for file servers that serve files under a directory.
 		PathParam string
+		// Configured is true if the file server is generated using the
+		// goa file server handler, false if it uses http.ServeFile.
+		Configured bool
+		// Embed is true if the files are read from the file system given
+		// to the server constructor.
+		Embed bool
+		// Fallback is the path of the file served when the requested
+		// file does not exist.
+		Fallback string
+		// CacheControl is the value of the Cache-Control header.
+		CacheControl string
+		// ETag is true if the responses include an ETag header.
+		ETag bool
+	}
+
+	// CORSData describes a CORS policy.
+	CORSData struct {
+		// Origin is the origin the policy applies to.
+		Origin string
+		// OriginRegexp is the regular expression used to match the
+		// origin if any.
+		OriginRegexp string
+		// Methods lists the allowed HTTP methods.
+		Methods []string
+		// Headers lists the allowed request headers.
+		Headers []string
+		// Expose lists the response headers exposed to the client.
+		Expose []string
+		// MaxAge is the preflight response cache duration in seconds.
+		MaxAge uint
+		// Credentials indicates whether credentials are allowed.
+		Credentials bool
+		// PrivateNetwork indicates whether private network access is
+		// allowed.
+		PrivateNetwork bool
+	}
+
+	// WebSocketData contains the websocket settings of a streaming
+	// endpoint.
+	WebSocketData struct {
+		// Subprotocols lists the supported subprotocols.
+		Subprotocols []string
+		// PerMessageDeflate enables compression.
+		PerMessageDeflate bool
+		// PingInterval is the number of seconds between pings.
+		PingInterval uint
+		// PongWait is the number of seconds after which the peer is
+		// considered dead if it does not answer the pings, zero if the
+		// connection is not read continuously.
+		PongWait uint
+		// SendOnly is true if the stream never reads the connection.
+		SendOnly bool
+		// MaxMessageSize is the maximum size of messages read from the
+		// connection.
+		MaxMessageSize int64
+	}
+
+	// ProxyData describes the proxy that forwards the requests made to an
+	// endpoint to an upstream service.
+	ProxyData struct {
+		// Upstream is the URL of the upstream service.
+		Upstream string
+		// StripPrefix is removed from the request path prior to
+		// forwarding.
+		StripPrefix string
+		// Headers lists the headers set on forwarded requests.
+		Headers []*ProxyHeaderData
+		// RemoveHeaders lists the headers removed from forwarded
+		// requests.
+		RemoveHeaders []string
+		// Init is the name of the function that creates the proxy.
+		Init string
+		// VarName is the name of the variable holding the proxy in the
+		// server constructor.
+		VarName string
+		// FieldName is the name of the server struct field that exposes
+		// the proxy.
+		FieldName string
+		// BufferBody is true if the payload is decoded from the request
+		// body in which case the body is buffered so that it can be
+		// forwarded.
+		BufferBody bool
+	}
+
+	// ProxyHeaderData describes a header set on forwarded requests.
+	ProxyHeaderData struct {
+		// Name is the canonical header name.
+		Name string
+		// Value is the header value.
+		Value string
+	}
+
+	// CompressData describes the compression of the endpoint responses.
+	CompressData struct {
+		// Encodings lists the content codings in order of preference.
+		Encodings []string
+		// ContentTypes lists the media types of compressed responses.
+		ContentTypes []string
+		// MinSize is the minimum size of compressed responses.
+		MinSize int64
+	}
+
+	// PropagationData describes the request context values propagated
+	// by an endpoint.
+	PropagationData struct {
+		// Headers lists the names of the propagated headers.
+		Headers []string
+		// Deadline is true if the request deadline is propagated.
+		Deadline bool
+	}
+
+	// ProblemDetailsData describes how the errors are encoded as problem
+	// details documents.
+	ProblemDetailsData struct {
+		// TypeBase is the base URI of the problem types.
+		TypeBase string
+	}
+
+	// MediaTypesData lists the media types matched against the request
+	// Content-Type and Accept headers.
+	MediaTypesData struct {
+		// Consumes lists the media types accepted in the Content-Type
+		// header, the header is not checked if empty.
+		Consumes []string
+		// Produces lists the media types matched against the Accept
+		// header, the header is not checked if empty.
+		Produces []string
+	}
+
+	// StrictDecodingData describes the request fields accepted by an
+	// endpoint that uses strict decoding.
+	StrictDecodingData struct {
+		// CheckQuery is true if the server checks the request query
+		// string parameters, false if the endpoint maps the whole query
+		// string.
+		CheckQuery bool
+		// QueryParams lists the names of the accepted query string
+		// parameters. Names ending with "[" match the keys of deepObject
+		// style parameters.
+		QueryParams []string
+	}
+
+	// LenientDecodingData describes how an endpoint server coerces the
+	// values of the request bodies.
+	LenientDecodingData struct {
+		// All is true if the server coerces all the fields of the request
+		// body, false if it only coerces the fields defined with the
+		// "decode:lenient" meta.
+		All bool
+	}
+
+	// TenancyData describes how the tenant of the requests is resolved.
+	TenancyData struct {
+		// Header is the name of the header that carries the tenant.
+		Header string
+		// Claim is the name of the bearer token claim that must match
+		// the tenant.
+		Claim string
+	}
+
+	// ClientPolicyData describes the policy applied by the client to the
+	// requests made to an endpoint.
+	ClientPolicyData struct {
+		// Timeout is the code initializing the request timeout, empty
+		// if there is none.
+		Timeout string
+		// Retry describes the retry policy if any.
+		Retry *RetryData
+		// VarName is the name of the client struct field holding the
+		// policy.
+		VarName string
+		// Option is the name of the client option function that
+		// overrides the policy.
+		Option string
+	}
+
+	// RetryData describes a client retry policy.
+	RetryData struct {
+		// MaxAttempts is the maximum number of attempts.
+		MaxAttempts int
+		// InitialBackoff is the code initializing the initial backoff.
+		InitialBackoff string
+		// MaxBackoff is the code initializing the maximum backoff.
+		MaxBackoff string
+		// StatusCodes lists the status codes that cause a retry.
+		StatusCodes []int
+	}
+
+	// CORSPathData describes a path served by a CORS preflight handler.
+	CORSPathData struct {
+		// Path is the request path.
+		Path string
+		// Policies lists the names of the variables holding the CORS
+		// policies of the endpoints served under the path.
+		Policies []string
+	}
+
+	// OptionsPathData describes a path served by an OPTIONS handler.
+	OptionsPathData struct {
+		// Path is the request path.
+		Path string
+		// Methods lists the methods written in the Allow header.
+		Methods []string
+	}
+
+	// WebhookData describes a webhook sent by the service.
+	WebhookData struct {
+		// Name is the name of the webhook event.
+		Name string
+		// Description is the webhook description.
+		Description string
+		// SendFunc is the name of the client function that sends the
+		// webhook.
+		SendFunc string
+		// Body describes the webhook request body type.
+		Body *TypeData
+	}
+
+	// ServerURLData describes the function that builds the URL of a host
+	// from the values of its URL variables.
+	ServerURLData struct {
+		// Name is the name of the function.
+		Name string
+		// Server is the name of the design server.
+		Server string
+		// Host is the name of the server host.
+		Host string
+		// Description is the host description.
+		Description string
+		// URL is the host URI, e.g. "https://{region}.example.com".
+		URL string
+		// Variables lists the URL variables in order of appearance.
+		Variables []*ServerURLVariableData
+	}
+
+	// SignatureSchemeData describes a request signature security scheme
+	// used by the service endpoints.
+	SignatureSchemeData struct {
+		// SchemeName is the name of the scheme.
+		SchemeName string
+		// VarName is the Go name of the scheme used to build the names
+		// of the generated server and client methods.
+		VarName string
+		// ClockSkew is the code initializing the maximum clock skew,
+		// empty if the design does not set one.
+		ClockSkew string
+		// ReplayWindow is the code initializing the replay window, empty
+		// if the design does not set one.
+		ReplayWindow string
+		// ServerStruct is the name of the HTTP server struct.
+		ServerStruct string
+		// ClientStruct is the name of the HTTP client struct.
+		ClientStruct string
+		// Endpoints lists the endpoints secured by the scheme.
+		Endpoints []*EndpointData
+	}
+
+	// CSRFData describes the CSRF protection of the service endpoints.
+	CSRFData struct {
+		// ServerStruct is the name of the HTTP server struct.
+		ServerStruct string
+		// Synchronizer is true if at least one protection uses the
+		// synchronizer mode and thus requires a secret key.
+		Synchronizer bool
+		// Protections lists the distinct protections of the endpoints.
+		Protections []*CSRFProtectionData
+	}
+
+	// CSRFProtectionData describes the CSRF protection shared by a set of
+	// endpoints.
+	CSRFProtectionData struct {
+		// Synchronizer is true if the protection uses the synchronizer
+		// mode, false for the double-submit mode.
+		Synchronizer bool
+		// SessionCookie is the name of the cookie that carries the
+		// request credentials.
+		SessionCookie string
+		// Header is the name of the header that carries the token if
+		// not the default.
+		Header string
+		// Cookie is the name of the double-submit cookie if not the
+		// default.
+		Cookie string
+		// Endpoints lists the protected endpoints.
+		Endpoints []*EndpointData
+	}
+
+	// APIKeyFallbackData describes the locations of the key of an API key
+	// security scheme read by the request decoder when the request does not
+	// carry the key in the location the key attribute is mapped to.
+	APIKeyFallbackData struct {
+		// SchemeName is the name of the security scheme.
+		SchemeName string
+		// CredField is the name of the payload field that holds the key.
+		CredField string
+		// CredPointer is true if the payload field is a pointer.
+		CredPointer bool
+		// Locations lists the locations in order of priority.
+		Locations []*expr.KeyLocationExpr
+	}
+
+	// ServerURLVariableData describes a URL variable.
+	ServerURLVariableData struct {
+		// Name is the name of the variable in the URL.
+		Name string
+		// VarName is the name of the function argument.
+		VarName string
+		// TypeRef is the Go type of the function argument.
+		TypeRef string
+		// Values lists the values allowed by the variable enum if any.
+		Values []string
 	}
 
 	// PayloadData contains the payload information required to generate the
@@ -229,6 +638,9 @@ type (
 		Ref string
 		// Response is the error response data.
 		Response *ResponseData
+		// Problem is true if the error is encoded as a problem details
+		// document.
+		Problem bool
 	}
 
 	// RequestData describes a request.
@@ -242,6 +654,12 @@ type (
 		// Headers contains the HTTP request headers used to build the
 		// method payload.
 		Headers []*HeaderData
+		// Cookies contains the HTTP request cookies used to build the
+		// method payload.
+		Cookies []*HeaderData
+		// SignedCookies is true if the request cookie values are encoded
+		// with the server cookie codec.
+		SignedCookies bool
 		// ServerBody describes the request body type used by server
 		// code. The type is generated using pointers for all fields so
 		// that it can be validated.
@@ -270,6 +688,15 @@ type (
 		// Headers provides information about the headers in the
 		// response.
 		Headers []*HeaderData
+		// Trailers provides information about the trailers sent after
+		// the response body.
+		Trailers []*HeaderData
+		// Cookies provides information about the cookies set by the
+		// response.
+		Cookies []*HeaderData
+		// CookieOptions describes the attributes of the cookies set by
+		// the response if any.
+		CookieOptions *CookieOptionsData
 		// ContentType contains the value of the response
 		// "Content-Type" header.
 		ContentType string
@@ -386,6 +813,10 @@ type (
 		Validate string
 		// Example is a example value
 		Example interface{}
+		// NamedExamples maps the names of the examples defined in the
+		// design to the corresponding argument values, see
+		// expr.AttributeExpr.NamedExample.
+		NamedExamples map[string]interface{}
 	}
 
 	// RouteData describes a route.
@@ -397,6 +828,9 @@ type (
 		// PathInit contains the information needed to render and call
 		// the path constructor for the route.
 		PathInit *InitData
+		// Head is true if the route handler also serves the HEAD requests
+		// made to the path.
+		Head bool
 	}
 
 	// ParamData describes a HTTP request parameter.
@@ -438,6 +872,11 @@ type (
 		MapStringSlice bool
 		// Map is true if the param type is a map.
 		Map bool
+		// Delimiter is the string used to separate the values of an
+		// array path parameter or of an array query string parameter
+		// serialized in a single value, empty if the query string
+		// parameter is repeated.
+		Delimiter string
 		// Validate contains the validation code if any.
 		Validate string
 		// DefaultValue contains the default value if any.
@@ -448,6 +887,12 @@ type (
 		// to the entire payload (empty string) or a payload attribute
 		// (attribute name).
 		MapQueryParams *string
+		// Lenient is true if the boolean param values are decoded
+		// with goahttp.ParseBool.
+		Lenient bool
+		// Trim is true if the leading and trailing white space of the
+		// string param value is trimmed.
+		Trim bool
 	}
 
 	// HeaderData describes a HTTP request or response header.
@@ -490,6 +935,12 @@ type (
 		DefaultValue interface{}
 		// Example is an example value.
 		Example interface{}
+		// Lenient is true if the boolean header values are decoded
+		// with goahttp.ParseBool.
+		Lenient bool
+		// Trim is true if the leading and trailing white space of the
+		// string header value is trimmed.
+		Trim bool
 	}
 
 	// TypeData contains the data needed to render a type definition.
@@ -533,6 +984,56 @@ type (
 		// Payload is the payload data required to generate
 		// encoder/decoder.
 		Payload *PayloadData
+		// Default is the name of the default decoder or encoder
+		// generated from the payload type, empty if the payload type
+		// cannot be mapped to request parts.
+		Default string
+		// PayloadType is the name of the service payload struct
+		// initialized by the default decoder.
+		PayloadType string
+		// Parts lists the request parts mapped to the payload
+		// attributes by the default decoder and encoder.
+		Parts []*MultipartPartData
+	}
+
+	// MultipartPartData describes a request part mapped to a payload
+	// attribute.
+	MultipartPartData struct {
+		// Name is the part form name.
+		Name string
+		// VarName is the name of the variable holding the decoded
+		// value.
+		VarName string
+		// FieldName is the name of the payload struct field.
+		FieldName string
+		// TypeRef is the reference to the field type.
+		TypeRef string
+		// Pointer is true if the field is a pointer to a primitive.
+		Pointer bool
+		// Required is true if the part is required.
+		Required bool
+		// Array is true if the attribute is an array of primitives
+		// mapped to repeated parts.
+		Array bool
+		// File is true if the attribute is of type Bytes and is
+		// mapped to a file part.
+		File bool
+		// Stream is true if the attribute is mapped to a file part and
+		// its Go type is io.Reader or io.ReadCloser in which case the
+		// part content is streamed rather than read in memory.
+		Stream bool
+		// JSON is true if the attribute is mapped to a JSON encoded
+		// part.
+		JSON bool
+		// DefaultValue is the code initializing the variable holding
+		// the decoded value if any.
+		DefaultValue string
+		// Decode is the code that initializes the variable v with the
+		// value read from the part content b.
+		Decode string
+		// Encode is the code that converts the value v into the part
+		// content string.
+		Encode string
 	}
 
 	// StreamData contains the data needed to render struct type that
@@ -581,6 +1082,10 @@ type (
 		// Kind is the kind of the stream (payload, result or
 		// bidirectional).
 		Kind expr.StreamKind
+		// ChunkedFormat is the goahttp constant that identifies the
+		// format of the chunked HTTP response used to stream the
+		// results, empty if the stream uses a websocket connection.
+		ChunkedFormat string
 	}
 )
 
@@ -624,6 +1129,8 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 		ServerInit:       "New",
 		MountServer:      "Mount",
 		ServerService:    "Service",
+		MountCORS:        "MountCORSHandler",
+		MountOptions:     "MountOptionsHandler",
 		ClientStruct:     "Client",
 		ServerTypeNames:  make(map[string]bool),
 		ClientTypeNames:  make(map[string]bool),
@@ -650,8 +1157,16 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 			FilePath:     s.FilePath,
 			IsDir:        s.IsDir(),
 			PathParam:    pp,
+			Configured:   s.IsConfigured(),
+			Embed:        s.Embed,
+			Fallback:     s.Fallback,
+			CacheControl: s.CacheControlHeader(),
+			ETag:         s.ETag,
 		}
 		rd.FileServers = append(rd.FileServers, data)
+		if s.Embed {
+			rd.FileSystem = true
+		}
 	}
 
 	for _, a := range hs.HTTPEndpoints {
@@ -697,12 +1212,36 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 						}
 					}
 
-					var buffer bytes.Buffer
-					pf := expr.HTTPWildcardRegex.ReplaceAllString(rpath, "/%v")
+					var (
+						buffer bytes.Buffer
+						styled []map[string]string
+					)
+					var catchAll string
+					for j, arg := range params {
+						if expr.IsHTTPCatchAll(rpath, arg) {
+							catchAll = initArgs[j].Name
+						}
+						switch expr.PathParamStyle(rpath, arg) {
+						case expr.PathStyleLabel:
+							styled = append(styled, map[string]string{"Name": initArgs[j].Name, "Prefix": "."})
+						case expr.PathStyleMatrix:
+							styled = append(styled, map[string]string{"Name": initArgs[j].Name, "Prefix": ";" + arg + "="})
+						}
+					}
+					pathVar := "path"
+					for k := 2; hasInitArg(initArgs, pathVar); k++ {
+						pathVar = "path" + strconv.Itoa(k)
+					}
+					base, _ := expr.SplitStyledPath(rpath)
+					pf := expr.HTTPWildcardRegex.ReplaceAllString(base, "/%v")
 					err := pathInitTmpl.Execute(&buffer, map[string]interface{}{
 						"Args":       initArgs,
 						"PathParams": pathParamsObj,
 						"PathFormat": pf,
+						"Styled":     styled,
+						"NumSimple":  len(initArgs) - len(styled),
+						"CatchAll":   catchAll,
+						"PathVar":    pathVar,
 					})
 					if err != nil {
 						panic(err)
@@ -723,6 +1262,7 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 					Verb:     strings.ToUpper(r.Method),
 					Path:     rpath,
 					PathInit: init,
+					Head:     r.AutoHead(rpath),
 				})
 			}
 		}
@@ -734,6 +1274,7 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 			bosch service.SchemesData
 			qsch  service.SchemesData
 			basch *service.SchemeData
+			msch  *service.SchemeData
 		)
 		{
 			for _, req := range ep.Requirements {
@@ -741,8 +1282,16 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 					switch s.Type {
 					case "Basic":
 						basch = s
+					case "MTLS":
+						msch = s
+					case "Signature":
+						// The signature is verified by the
+						// server middleware.
 					default:
 						switch s.In {
+						case "cookie":
+							// The credentials are decoded
+							// with the request cookies.
 						case "query":
 							qsch = qsch.Append(s)
 						case "header":
@@ -757,7 +1306,7 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 
 		var requestEncoder string
 		{
-			if payload.Request.ClientBody != nil || len(payload.Request.Headers) > 0 || len(payload.Request.QueryParams) > 0 || basch != nil {
+			if payload.Request.ClientBody != nil || len(payload.Request.Headers) > 0 || len(payload.Request.Cookies) > 0 || len(payload.Request.QueryParams) > 0 || basch != nil {
 				requestEncoder = fmt.Sprintf("Encode%sRequest", ep.VarName)
 			}
 		}
@@ -791,7 +1340,7 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 				"Args":         args,
 				"PathInit":     routes[0].PathInit,
 				"Verb":         routes[0].Verb,
-				"IsStreaming":  a.MethodExpr.IsStreaming(),
+				"IsStreaming":  a.MethodExpr.IsStreaming() && a.StreamingResponse == "",
 			}
 			var buf bytes.Buffer
 			if err := requestInitTmpl.Execute(&buf, data); err != nil {
@@ -821,6 +1370,8 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 			BodySchemes:     bosch,
 			QuerySchemes:    qsch,
 			BasicScheme:     basch,
+			MTLSScheme:      msch,
+			FormEncoded:     a.FormEncodedRequest,
 			Routes:          routes,
 			MountHandler:    fmt.Sprintf("Mount%sHandler", ep.VarName),
 			HandlerInit:     fmt.Sprintf("New%sHandler", ep.VarName),
@@ -832,8 +1383,21 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 			RequestInit:     requestInit,
 			RequestEncoder:  requestEncoder,
 			ResponseDecoder: fmt.Sprintf("Decode%sResponse", ep.VarName),
+			BodyLimit:       a.MaxBodySize(),
+			Compress:        buildCompressData(a),
+			Propagation:     buildPropagationData(a),
+			Tenancy:         buildTenancyData(),
+			ProblemDetails:  buildProblemDetailsData(),
+			MediaTypes:      buildMediaTypesData(a),
+			Telemetry:       hs.ServiceExpr.IsOTelInstrumented(),
+			Cached:          a.MethodExpr.Cache != nil,
 		}
 		buildStreamData(ad, a, rd)
+		buildCORSData(ad, a, rd)
+		buildProxyData(ad, a)
+		buildAPIKeyFallbacks(ad, a)
+		buildStrictDecodingData(ad, a)
+		buildLenientDecodingData(ad, a)
 
 		if a.MultipartRequest {
 			ad.MultipartRequestDecoder = &MultipartData{
@@ -852,10 +1416,23 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 				MethodName:  ep.Name,
 				Payload:     ad.Payload,
 			}
+			buildMultipartData(ad, a, svc)
 		}
 
 		rd.Endpoints = append(rd.Endpoints, ad)
 	}
+	buildClientPolicyData(rd, hs)
+	buildServerURLData(rd, hs)
+	buildSignatureSchemeData(rd, hs)
+	buildCSRFData(rd, hs)
+	buildJobStatusData(rd, hs)
+	buildCredentialHeaders(rd, hs)
+	for _, p := range hs.AutoOptionsPaths() {
+		rd.OptionsPaths = append(rd.OptionsPaths, &OptionsPathData{Path: p, Methods: hs.AutoOptions(p)})
+	}
+	for _, w := range hs.Webhooks {
+		rd.Webhooks = append(rd.Webhooks, buildWebhookData(w, rd))
+	}
 
 	for _, a := range hs.HTTPEndpoints {
 		collectUserTypes(a.Body.Type, func(ut expr.UserType) {
@@ -926,9 +1503,11 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 		var (
 			serverBodyData = buildRequestBodyType(e.Body, payload, e, true, sd)
 			clientBodyData = buildRequestBodyType(e.Body, payload, e, false, sd)
-			paramsData     = extractPathParams(e.PathParams(), payload, sd.Scope)
+			paramsData     = extractPathParams(e, payload, sd.Scope)
 			queryData      = extractQueryParams(e.QueryParams(), payload, sd.Scope)
 			headersData    = extractHeaders(e.Headers, payload, svcctx, sd.Scope)
+			cookiesData    = extractHeaders(e.Cookies, payload, svcctx, sd.Scope)
+			signedCookies  = len(cookiesData) > 0 && expr.IsSignedCookies(e.Cookies)
 
 			mustValidate bool
 		)
@@ -982,22 +1561,27 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 				}
 			}
 			if !mustValidate {
-				for _, h := range headersData {
+				for _, h := range append(headersData, cookiesData...) {
 					if h.Validate != "" || h.Required || needConversion(h.Type) {
 						mustValidate = true
 						break
 					}
 				}
 			}
+			if signedCookies {
+				mustValidate = true
+			}
 		}
 		request = &RequestData{
-			PathParams:   paramsData,
-			QueryParams:  queryData,
-			Headers:      headersData,
-			ServerBody:   serverBodyData,
-			ClientBody:   clientBodyData,
-			MustValidate: mustValidate,
-			Multipart:    e.MultipartRequest,
+			PathParams:    paramsData,
+			QueryParams:   queryData,
+			Headers:       headersData,
+			Cookies:       cookiesData,
+			SignedCookies: signedCookies,
+			ServerBody:    serverBodyData,
+			ClientBody:    clientBodyData,
+			MustValidate:  mustValidate,
+			Multipart:     e.MultipartRequest,
 		}
 	}
 
@@ -1046,59 +1630,63 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 				Validate: svcode,
 			}}
 			clientArgs = []*InitArgData{{
-				Name:     "body",
-				Ref:      sd.Scope.GoVar("body", body),
-				TypeName: sd.Scope.GoTypeName(&expr.AttributeExpr{Type: body}),
-				TypeRef:  sd.Scope.GoTypeRef(&expr.AttributeExpr{Type: body}),
-				Required: true,
-				Example:  e.Body.Example(expr.Root.API.Random()),
-				Validate: cvcode,
+				Name:          "body",
+				Ref:           sd.Scope.GoVar("body", body),
+				TypeName:      sd.Scope.GoTypeName(&expr.AttributeExpr{Type: body}),
+				TypeRef:       sd.Scope.GoTypeRef(&expr.AttributeExpr{Type: body}),
+				Required:      true,
+				Example:       e.Body.Example(expr.Root.API.Random()),
+				NamedExamples: namedExamples(e.Body, ""),
+				Validate:      cvcode,
 			}}
 		}
 		var args []*InitArgData
 		for _, p := range request.PathParams {
 			args = append(args, &InitArgData{
-				Name:         p.VarName,
-				Description:  p.Description,
-				Ref:          p.VarName,
-				FieldName:    p.FieldName,
-				FieldPointer: p.FieldPointer,
-				TypeName:     p.TypeName,
-				TypeRef:      p.TypeRef,
-				Pointer:      p.Pointer,
-				Required:     p.Required,
-				Validate:     p.Validate,
-				Example:      p.Example,
+				Name:          p.VarName,
+				Description:   p.Description,
+				Ref:           p.VarName,
+				FieldName:     p.FieldName,
+				FieldPointer:  p.FieldPointer,
+				TypeName:      p.TypeName,
+				TypeRef:       p.TypeRef,
+				Pointer:       p.Pointer,
+				Required:      p.Required,
+				Validate:      p.Validate,
+				Example:       p.Example,
+				NamedExamples: namedExamples(payload, p.AttributeName),
 			})
 		}
 		for _, p := range request.QueryParams {
 			args = append(args, &InitArgData{
-				Name:         p.VarName,
-				Ref:          p.VarName,
-				FieldName:    p.FieldName,
-				FieldPointer: p.FieldPointer,
-				TypeName:     p.TypeName,
-				TypeRef:      p.TypeRef,
-				Pointer:      p.Pointer,
-				Required:     p.Required,
-				DefaultValue: p.DefaultValue,
-				Validate:     p.Validate,
-				Example:      p.Example,
+				Name:          p.VarName,
+				Ref:           p.VarName,
+				FieldName:     p.FieldName,
+				FieldPointer:  p.FieldPointer,
+				TypeName:      p.TypeName,
+				TypeRef:       p.TypeRef,
+				Pointer:       p.Pointer,
+				Required:      p.Required,
+				DefaultValue:  p.DefaultValue,
+				Validate:      p.Validate,
+				Example:       p.Example,
+				NamedExamples: namedExamples(payload, p.AttributeName),
 			})
 		}
-		for _, h := range request.Headers {
+		for _, h := range append(request.Headers, request.Cookies...) {
 			args = append(args, &InitArgData{
-				Name:         h.VarName,
-				Ref:          h.VarName,
-				FieldName:    h.FieldName,
-				FieldPointer: h.FieldPointer,
-				TypeName:     h.TypeName,
-				TypeRef:      h.TypeRef,
-				Pointer:      h.Pointer,
-				Required:     h.Required,
-				DefaultValue: h.DefaultValue,
-				Validate:     h.Validate,
-				Example:      h.Example,
+				Name:          h.VarName,
+				Ref:           h.VarName,
+				FieldName:     h.FieldName,
+				FieldPointer:  h.FieldPointer,
+				TypeName:      h.TypeName,
+				TypeRef:       h.TypeRef,
+				Pointer:       h.Pointer,
+				Required:      h.Required,
+				DefaultValue:  h.DefaultValue,
+				Validate:      h.Validate,
+				Example:       h.Example,
+				NamedExamples: namedExamples(payload, h.AttributeName),
 			})
 		}
 		serverArgs = append(serverArgs, args...)
@@ -1285,8 +1873,8 @@ func buildResultData(e *expr.HTTPEndpointExpr, sd *ServiceData) *ResultData {
 		}
 		responses = buildResponses(e, result, viewed, sd)
 		for _, r := range responses {
-			// response has a body or headers or tag
-			if len(r.ServerBody) > 0 || len(r.Headers) > 0 || r.TagName != "" {
+			// response has a body or headers or trailers or tag
+			if len(r.ServerBody) > 0 || len(r.Headers) > 0 || len(r.Trailers) > 0 || len(r.Cookies) > 0 || r.TagName != "" {
 				mustInit = true
 			}
 		}
@@ -1333,6 +1921,8 @@ func buildResponses(e *expr.HTTPEndpointExpr, result *expr.AttributeExpr, viewed
 			}
 			var (
 				headersData    []*HeaderData
+				trailersData   []*HeaderData
+				cookiesData    []*HeaderData
 				serverBodyData []*TypeData
 				clientBodyData *TypeData
 				init           *InitData
@@ -1343,6 +1933,8 @@ func buildResponses(e *expr.HTTPEndpointExpr, result *expr.AttributeExpr, viewed
 			)
 			{
 				headersData = extractHeaders(resp.Headers, result, svcctx, scope)
+				trailersData = extractHeaders(resp.Trailers, result, svcctx, scope)
+				cookiesData = extractHeaders(resp.Cookies, result, svcctx, scope)
 				if resp.Body.Type != expr.Empty {
 					// If design uses Body("name") syntax we need to use the
 					// corresponding attribute in the result type for body
@@ -1392,7 +1984,7 @@ func buildResponses(e *expr.HTTPEndpointExpr, result *expr.AttributeExpr, viewed
 				if clientBodyData != nil {
 					sd.ClientTypeNames[clientBodyData.Name] = false
 				}
-				for _, h := range headersData {
+				for _, h := range append(append(headersData, trailersData...), cookiesData...) {
 					if h.Validate != "" || h.Required || needConversion(h.Type) {
 						mustValidate = true
 						break
@@ -1476,7 +2068,7 @@ func buildResponses(e *expr.HTTPEndpointExpr, result *expr.AttributeExpr, viewed
 						if err != nil {
 							fmt.Println(err.Error()) // TBD validate DSL so errors are not possible
 						}
-						for _, h := range headersData {
+						for _, h := range append(append(headersData, trailersData...), cookiesData...) {
 							clientArgs = append(clientArgs, &InitArgData{
 								Name:         h.VarName,
 								Ref:          h.VarName,
@@ -1516,19 +2108,22 @@ func buildResponses(e *expr.HTTPEndpointExpr, result *expr.AttributeExpr, viewed
 					}
 				}
 				responses = append(responses, &ResponseData{
-					StatusCode:   statusCodeToHTTPConst(resp.StatusCode),
-					Description:  resp.Description,
-					Headers:      headersData,
-					ContentType:  resp.ContentType,
-					ServerBody:   serverBodyData,
-					ClientBody:   clientBodyData,
-					ResultInit:   init,
-					TagName:      tagName,
-					TagValue:     tagVal,
-					TagPointer:   tagPtr,
-					MustValidate: mustValidate,
-					ResultAttr:   codegen.Goify(origin, true),
-					ViewedResult: md.ViewedResult,
+					StatusCode:    statusCodeToHTTPConst(resp.StatusCode),
+					Description:   resp.Description,
+					Headers:       headersData,
+					Trailers:      trailersData,
+					Cookies:       cookiesData,
+					CookieOptions: buildCookieOptions(resp.Cookies),
+					ContentType:   resp.ContentType,
+					ServerBody:    serverBodyData,
+					ClientBody:    clientBodyData,
+					ResultInit:    init,
+					TagName:       tagName,
+					TagValue:      tagVal,
+					TagPointer:    tagPtr,
+					MustValidate:  mustValidate,
+					ResultAttr:    codegen.Goify(origin, true),
+					ViewedResult:  md.ViewedResult,
 				})
 			}
 		}
@@ -1588,6 +2183,8 @@ func buildErrorsData(e *expr.HTTPEndpointExpr, sd *ServiceData) []*ErrorGroupDat
 						Ref:          h.VarName,
 						FieldName:    h.FieldName,
 						FieldPointer: h.FieldPointer,
+						Required:     h.Required,
+						Pointer:      h.Pointer,
 						TypeRef:      h.TypeRef,
 						Validate:     h.Validate,
 						Example:      h.Example,
@@ -1689,6 +2286,9 @@ func buildErrorsData(e *expr.HTTPEndpointExpr, sd *ServiceData) []*ErrorGroupDat
 			Name:     v.Name,
 			Response: responseData,
 			Ref:      ref,
+			// Errors whose attributes are mapped to response headers
+			// keep the default encoding.
+			Problem: expr.IsProblemError(v.ErrorExpr) && len(responseData.Headers) == 0,
 		})
 	}
 	keys := make([]string, len(data))
@@ -1722,10 +2322,714 @@ func buildErrorsData(e *expr.HTTPEndpointExpr, sd *ServiceData) []*ErrorGroupDat
 	return vals
 }
 
+// buildCompressData returns the compression settings of the endpoint, nil if
+// the endpoint responses are not compressed.
+func buildCompressData(e *expr.HTTPEndpointExpr) *CompressData {
+	c := e.Compress()
+	if c == nil {
+		return nil
+	}
+	return &CompressData{
+		Encodings:    c.Encodings,
+		ContentTypes: c.ContentTypes,
+		MinSize:      c.MinSize,
+	}
+}
+
+// buildPropagationData returns the propagation settings of the endpoint, nil if
+// the endpoint does not propagate any request context value.
+func buildPropagationData(e *expr.HTTPEndpointExpr) *PropagationData {
+	p := e.Service.ServiceExpr.Propagate()
+	if p == nil || (len(p.Headers) == 0 && !p.Deadline) {
+		return nil
+	}
+	return &PropagationData{Headers: p.Headers, Deadline: p.Deadline}
+}
+
+// buildProblemDetailsData returns the problem details settings of the API, nil
+// if the errors use the default encoding.
+func buildProblemDetailsData() *ProblemDetailsData {
+	h := expr.Root.API.HTTP
+	if !h.ProblemDetails {
+		return nil
+	}
+	return &ProblemDetailsData{TypeBase: h.ProblemTypeBase}
+}
+
+// buildMediaTypesData returns the media types checked by the endpoint server,
+// nil if the server does not check the request media types.
+func buildMediaTypesData(e *expr.HTTPEndpointExpr) *MediaTypesData {
+	consumes, produces := e.RequestMediaTypes(), e.ResponseMediaTypes()
+	if len(consumes) == 0 && len(produces) == 0 {
+		return nil
+	}
+	return &MediaTypesData{Consumes: consumes, Produces: produces}
+}
+
+// buildStrictDecodingData initializes the strict decoding settings of the
+// endpoint if it uses strict decoding. It must be called once the payload data
+// and the API key fallbacks are initialized.
+func buildStrictDecodingData(ed *EndpointData, e *expr.HTTPEndpointExpr) {
+	if !e.IsStrictDecoding() {
+		return
+	}
+	sd := &StrictDecodingData{CheckQuery: e.MapQueryParams == nil}
+	if req := ed.Payload.Request; req != nil {
+		for _, p := range req.QueryParams {
+			sd.QueryParams = appendUnique(sd.QueryParams, p.Name)
+			if p.Map || p.MapStringSlice {
+				sd.QueryParams = appendUnique(sd.QueryParams, p.Name+"[")
+			}
+		}
+	}
+	for _, s := range ed.QuerySchemes {
+		sd.QueryParams = appendUnique(sd.QueryParams, s.Name)
+	}
+	for _, f := range ed.APIKeyFallbacks {
+		for _, l := range f.Locations {
+			if l.In == "query" {
+				sd.QueryParams = appendUnique(sd.QueryParams, l.Name)
+			}
+		}
+	}
+	ed.StrictDecoding = sd
+}
+
+// buildLenientDecodingData initializes the lenient decoding settings of the
+// endpoint if it uses lenient decoding or if its request body defines
+// attributes that are decoded leniently or trimmed. It must be called once the
+// payload data is initialized.
+func buildLenientDecodingData(ed *EndpointData, e *expr.HTTPEndpointExpr) {
+	if e.Proxy != nil {
+		return
+	}
+	all := e.IsLenientDecoding()
+	if req := ed.Payload.Request; req != nil && all {
+		for _, p := range req.PathParams {
+			p.Lenient = true
+		}
+		for _, p := range req.QueryParams {
+			p.Lenient = true
+		}
+		for _, h := range req.Headers {
+			h.Lenient = true
+		}
+		for _, c := range req.Cookies {
+			c.Lenient = true
+		}
+	}
+	if ed.Payload.Request == nil || ed.Payload.Request.ServerBody == nil {
+		return
+	}
+	if !all && !hasDecodeMeta(e.Body) {
+		return
+	}
+	ed.LenientDecoding = &LenientDecodingData{All: all}
+}
+
+// hasDecodeMeta returns true if the given attribute or any of its child
+// attributes are decoded leniently or trimmed.
+func hasDecodeMeta(att *expr.AttributeExpr) bool {
+	if att == nil {
+		return false
+	}
+	var found bool
+	codegen.Walk(att, func(a *expr.AttributeExpr) error {
+		if expr.IsLenientAttribute(a) || expr.IsTrimmedAttribute(a) {
+			found = true
+		}
+		return nil
+	})
+	return found
+}
+
+// buildTenancyData returns the tenancy settings used by the endpoint servers to
+// record the tenant claim of the requests, nil if the design does not define a
+// tenant claim.
+func buildTenancyData() *TenancyData {
+	t := expr.Root.API.Tenant
+	if t == nil || t.Claim == "" {
+		return nil
+	}
+	return &TenancyData{Header: t.Header, Claim: t.Claim}
+}
+
+// buildCORSData initializes the CORS policies of the endpoint and records the
+// endpoint paths that require a CORS preflight handler.
+func buildCORSData(ed *EndpointData, e *expr.HTTPEndpointExpr, sd *ServiceData) {
+	origins := e.CORS()
+	if len(origins) == 0 {
+		return
+	}
+	var verbs []string
+	for _, r := range ed.Routes {
+		verbs = appendUnique(verbs, r.Verb)
+	}
+	ed.CORSVarName = codegen.Goify(ed.Method.VarName, false) + "CORSPolicies"
+	for _, o := range origins {
+		methods := o.Methods
+		if len(methods) == 0 {
+			methods = verbs
+		}
+		ed.CORS = append(ed.CORS, &CORSData{
+			Origin:         o.Origin,
+			OriginRegexp:   o.OriginRegexp(),
+			Methods:        methods,
+			Headers:        o.Headers,
+			Expose:         o.Exposed,
+			MaxAge:         o.MaxAge,
+			Credentials:    o.Credentials,
+			PrivateNetwork: o.PrivateNetwork,
+		})
+	}
+	for _, r := range ed.Routes {
+		if r.Verb == "OPTIONS" {
+			continue
+		}
+		if hasOptionsRoute(e.Service, r.Path) {
+			continue
+		}
+		var pd *CORSPathData
+		for _, p := range sd.CORSPaths {
+			if p.Path == r.Path {
+				pd = p
+				break
+			}
+		}
+		if pd == nil {
+			pd = &CORSPathData{Path: r.Path}
+			sd.CORSPaths = append(sd.CORSPaths, pd)
+		}
+		pd.Policies = appendUnique(pd.Policies, ed.CORSVarName)
+	}
+}
+
+// hasOptionsRoute returns true if the service defines an endpoint with an
+// OPTIONS route for the given path.
+func hasOptionsRoute(svc *expr.HTTPServiceExpr, path string) bool {
+	for _, e := range svc.HTTPEndpoints {
+		for _, r := range e.Routes {
+			if r.Method != "OPTIONS" {
+				continue
+			}
+			for _, p := range r.FullPaths() {
+				if p == path {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// appendUnique appends the values of vals not already in s to s.
+func appendUnique(s []string, vals ...string) []string {
+	for _, v := range vals {
+		found := false
+		for _, e := range s {
+			if e == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			s = append(s, v)
+		}
+	}
+	return s
+}
+
+// buildMultipartData initializes the data used to generate the default
+// multipart decoder and encoder of the endpoint. The defaults are only
+// generated for object payloads whose body attributes are primitives, arrays
+// of primitives or types that do not contain objects (encoded in JSON). Bytes
+// attributes are mapped to file parts, their content is streamed if the Go
+// type of the field is io.Reader or io.ReadCloser.
+func buildMultipartData(ed *EndpointData, e *expr.HTTPEndpointExpr, svc *service.Data) {
+	payload := e.MethodExpr.Payload
+	if !expr.IsObject(payload.Type) || e.Body == nil {
+		return
+	}
+	body := expr.AsObject(e.Body.Type)
+	if body == nil {
+		return
+	}
+	var parts []*MultipartPartData
+	for _, nat := range *body {
+		att := payload.Find(nat.Name)
+		if att == nil || hasObject(att.Type) {
+			return
+		}
+		part := &MultipartPartData{
+			Name:      nat.Name,
+			VarName:   codegen.Goify(nat.Name, false),
+			FieldName: codegen.GoifyAtt(att, nat.Name, true),
+			TypeRef:   svc.Scope.GoFullTypeRef(att, svc.PkgName),
+			Pointer:   payload.IsPrimitivePointer(nat.Name, true),
+			Required:  payload.IsRequiredNoDefault(nat.Name),
+		}
+		switch {
+		case att.Type == expr.Bytes:
+			part.File = true
+			part.Stream = isReaderField(att)
+			part.Decode = "v := b"
+		case expr.IsPrimitive(att.Type) && att.Type != expr.Any:
+			part.Decode = multipartDecodeCode(nat.Name, att.Type)
+			part.Encode = multipartEncodeCode(att.Type)
+			if payload.HasDefaultValue(nat.Name) {
+				part.DefaultValue = fmt.Sprintf("%#v", att.DefaultValue)
+			}
+		case expr.IsArray(att.Type) && expr.IsPrimitive(expr.AsArray(att.Type).ElemType.Type) && expr.AsArray(att.Type).ElemType.Type != expr.Any:
+			elem := expr.AsArray(att.Type).ElemType.Type
+			part.Array = true
+			part.Decode = multipartDecodeCode(nat.Name, elem)
+			part.Encode = multipartEncodeCode(elem)
+		default:
+			part.JSON = true
+			part.Decode = fmt.Sprintf("var v %s\nif err2 := goahttp.JSON().Unmarshal(b, &v); err2 != nil {\n\terr = goa.MergeErrors(err, goa.InvalidFieldTypeError(%q, string(b), \"JSON\"))\n\tcontinue\n}", part.TypeRef, nat.Name)
+		}
+		parts = append(parts, part)
+	}
+	ptype := svc.Scope.GoFullTypeName(payload, svc.PkgName)
+	ed.MultipartRequestDecoder.Default = "Default" + ed.MultipartRequestDecoder.FuncName
+	ed.MultipartRequestDecoder.PayloadType = ptype
+	ed.MultipartRequestDecoder.Parts = parts
+	ed.MultipartRequestEncoder.Default = "Default" + ed.MultipartRequestEncoder.FuncName
+	ed.MultipartRequestEncoder.PayloadType = ptype
+	ed.MultipartRequestEncoder.Parts = parts
+}
+
+// isReaderField returns true if the "struct:field:type" meta of att sets the
+// Go type of the corresponding field to io.Reader or io.ReadCloser.
+func isReaderField(att *expr.AttributeExpr) bool {
+	args, ok := att.Meta["struct:field:type"]
+	if !ok || len(args) == 0 {
+		return false
+	}
+	return args[0] == "io.Reader" || args[0] == "io.ReadCloser"
+}
+
+// hasObject returns true if dt is or contains an object.
+func hasObject(dt expr.DataType) bool {
+	switch actual := dt.(type) {
+	case expr.UserType:
+		return hasObject(actual.Attribute().Type)
+	case *expr.Object:
+		return true
+	case *expr.Array:
+		return hasObject(actual.ElemType.Type)
+	case *expr.Map:
+		return hasObject(actual.KeyType.Type) || hasObject(actual.ElemType.Type)
+	}
+	return false
+}
+
+// multipartDecodeCode returns the code that initializes the variable v with
+// the primitive value of type dt read from the part content b.
+func multipartDecodeCode(name string, dt expr.DataType) string {
+	var parse, expected, conv string
+	switch dt.Kind() {
+	case expr.StringKind:
+		return "v := string(b)"
+	case expr.BytesKind:
+		return "v := b"
+	case expr.BooleanKind:
+		parse, expected = "strconv.ParseBool(string(b))", "boolean"
+	case expr.IntKind:
+		parse, expected, conv = "strconv.ParseInt(string(b), 10, strconv.IntSize)", "integer", "int"
+	case expr.Int32Kind:
+		parse, expected, conv = "strconv.ParseInt(string(b), 10, 32)", "integer", "int32"
+	case expr.Int64Kind:
+		parse, expected = "strconv.ParseInt(string(b), 10, 64)", "integer"
+	case expr.UIntKind:
+		parse, expected, conv = "strconv.ParseUint(string(b), 10, strconv.IntSize)", "unsigned integer", "uint"
+	case expr.UInt32Kind:
+		parse, expected, conv = "strconv.ParseUint(string(b), 10, 32)", "unsigned integer", "uint32"
+	case expr.UInt64Kind:
+		parse, expected = "strconv.ParseUint(string(b), 10, 64)", "unsigned integer"
+	case expr.Float32Kind:
+		parse, expected, conv = "strconv.ParseFloat(string(b), 32)", "float", "float32"
+	case expr.Float64Kind:
+		parse, expected = "strconv.ParseFloat(string(b), 64)", "float"
+	default:
+		panic("unsupported multipart part type " + dt.Name()) // bug
+	}
+	val := "pv"
+	if conv != "" {
+		val = conv + "(pv)"
+	}
+	return fmt.Sprintf("pv, err2 := %s\nif err2 != nil {\n\terr = goa.MergeErrors(err, goa.InvalidFieldTypeError(%q, string(b), %q))\n\tcontinue\n}\nv := %s", parse, name, expected, val)
+}
+
+// multipartEncodeCode returns the code that converts the primitive value v of
+// type dt into the part content string.
+func multipartEncodeCode(dt expr.DataType) string {
+	switch dt.Kind() {
+	case expr.StringKind:
+		return "v"
+	case expr.BooleanKind:
+		return "strconv.FormatBool(v)"
+	case expr.IntKind:
+		return "strconv.Itoa(v)"
+	case expr.Int32Kind, expr.Int64Kind:
+		return "strconv.FormatInt(int64(v), 10)"
+	case expr.UIntKind, expr.UInt32Kind, expr.UInt64Kind:
+		return "strconv.FormatUint(uint64(v), 10)"
+	case expr.Float32Kind:
+		return "strconv.FormatFloat(float64(v), 'f', -1, 32)"
+	case expr.Float64Kind:
+		return "strconv.FormatFloat(v, 'f', -1, 64)"
+	}
+	panic("unsupported multipart part type " + dt.Name()) // bug
+}
+
+// buildProxyData initializes the proxy data of endpoints that forward requests
+// to an upstream service.
+func buildProxyData(ed *EndpointData, e *expr.HTTPEndpointExpr) {
+	p := e.Proxy
+	if p == nil {
+		return
+	}
+	headers := make([]*ProxyHeaderData, len(p.SetHeaders))
+	for i, h := range p.SetHeaders {
+		headers[i] = &ProxyHeaderData{Name: h.Name, Value: h.Value}
+	}
+	ed.Proxy = &ProxyData{
+		Upstream:      p.Upstream,
+		StripPrefix:   p.StripPrefix,
+		Headers:       headers,
+		RemoveHeaders: p.RemoveHeaders,
+		Init:          fmt.Sprintf("New%sProxy", ed.Method.VarName),
+		VarName:       codegen.Goify(ed.Method.VarName, false) + "Proxy",
+		FieldName:     ed.Method.VarName + "Proxy",
+		BufferBody:    e.MultipartRequest || e.FormEncodedRequest || ed.Payload.Request.ServerBody != nil,
+	}
+}
+
+// buildClientPolicyData initializes the client policy data of the service
+// endpoints. If any endpoint defines a client timeout or retry policy then all
+// the non-streaming endpoints get a policy so that the generated client
+// options can override them.
+func buildClientPolicyData(sd *ServiceData, hs *expr.HTTPServiceExpr) {
+	var found bool
+	for _, e := range hs.HTTPEndpoints {
+		if e.RequestTimeout() > 0 || e.RetryPolicy() != nil {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return
+	}
+	for i, e := range hs.HTTPEndpoints {
+		ed := sd.Endpoints[i]
+		if e.MethodExpr.IsStreaming() {
+			continue
+		}
+		p := &ClientPolicyData{
+			VarName: codegen.Goify(ed.Method.VarName, false) + "Policy",
+			Option:  fmt.Sprintf("With%sPolicy", ed.Method.VarName),
+		}
+		if t := e.RequestTimeout(); t > 0 {
+			p.Timeout = durationCode(t)
+		}
+		if r := e.RetryPolicy(); r != nil {
+			p.Retry = &RetryData{
+				MaxAttempts:    r.MaxAttempts,
+				InitialBackoff: durationCode(r.InitialBackoff),
+				MaxBackoff:     durationCode(r.MaxBackoff),
+				StatusCodes:    r.StatusCodes,
+			}
+		}
+		ed.ClientPolicy = p
+	}
+}
+
+// buildServerURLData initializes the data needed to generate the functions
+// that build the URLs of the hosts of the servers exposing the service. Only
+// the hosts whose first HTTP URI uses variables get a function.
+func buildServerURLData(sd *ServiceData, hs *expr.HTTPServiceExpr) {
+	for _, svr := range expr.Root.API.Servers {
+		var found bool
+		for _, s := range svr.Services {
+			if s == hs.Name() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			continue
+		}
+		for _, h := range svr.Hosts {
+			for _, u := range h.URIs {
+				uri := string(u)
+				if !strings.HasPrefix(uri, "http://") && !strings.HasPrefix(uri, "https://") {
+					continue
+				}
+				var vars []*ServerURLVariableData
+				for _, p := range u.Params() {
+					att := h.Variables.Find(p)
+					if att == nil {
+						continue
+					}
+					v := &ServerURLVariableData{
+						Name:    p,
+						VarName: codegen.Goify(p, false),
+						TypeRef: codegen.GoNativeTypeName(att.Type),
+					}
+					if att.Validation != nil {
+						for _, e := range att.Validation.Values {
+							v.Values = append(v.Values, fmt.Sprintf("%v", e))
+						}
+					}
+					vars = append(vars, v)
+				}
+				if len(vars) > 0 {
+					sd.ServerURLs = append(sd.ServerURLs, &ServerURLData{
+						Name:        codegen.Goify(svr.Name, true) + codegen.Goify(h.Name, true) + "URL",
+						Server:      svr.Name,
+						Host:        h.Name,
+						Description: h.Description,
+						URL:         uri,
+						Variables:   vars,
+					})
+				}
+				break
+			}
+		}
+	}
+}
+
+// buildSignatureSchemeData initializes the data needed to generate the server
+// and client methods that verify and sign the requests made to the endpoints
+// secured by request signature schemes.
+func buildSignatureSchemeData(sd *ServiceData, hs *expr.HTTPServiceExpr) {
+	for i, e := range hs.HTTPEndpoints {
+		for _, req := range e.MethodExpr.Requirements {
+			for _, sch := range req.Schemes {
+				if sch.Kind != expr.SignatureKind {
+					continue
+				}
+				var data *SignatureSchemeData
+				for _, ss := range sd.SignatureSchemes {
+					if ss.SchemeName == sch.SchemeName {
+						data = ss
+						break
+					}
+				}
+				if data == nil {
+					data = &SignatureSchemeData{
+						SchemeName:   sch.SchemeName,
+						VarName:      codegen.Goify(sch.SchemeName, true),
+						ServerStruct: sd.ServerStruct,
+						ClientStruct: sd.ClientStruct,
+					}
+					if sch.ClockSkew > 0 {
+						data.ClockSkew = durationCode(sch.ClockSkew)
+					}
+					if sch.ReplayWindow > 0 {
+						data.ReplayWindow = durationCode(sch.ReplayWindow)
+					}
+					sd.SignatureSchemes = append(sd.SignatureSchemes, data)
+				}
+				found := false
+				for _, ed := range data.Endpoints {
+					if ed == sd.Endpoints[i] {
+						found = true
+						break
+					}
+				}
+				if !found {
+					data.Endpoints = append(data.Endpoints, sd.Endpoints[i])
+				}
+			}
+		}
+	}
+}
+
+// buildAPIKeyFallbacks initializes the locations of the API keys read by the
+// request decoder of the endpoint when the request does not carry the keys in
+// the locations the key attributes are mapped to.
+func buildAPIKeyFallbacks(ed *EndpointData, e *expr.HTTPEndpointExpr) {
+	for _, req := range e.Requirements {
+		for _, s := range req.Schemes {
+			if s.Kind != expr.APIKeyKind {
+				continue
+			}
+			locs := e.APIKeyFallbacks(s)
+			if len(locs) == 0 {
+				continue
+			}
+			sd := ed.Method.Requirements.Scheme(s.SchemeName)
+			if sd == nil {
+				continue
+			}
+			ed.APIKeyFallbacks = append(ed.APIKeyFallbacks, &APIKeyFallbackData{
+				SchemeName:  s.SchemeName,
+				CredField:   sd.CredField,
+				CredPointer: sd.CredPointer,
+				Locations:   locs,
+			})
+		}
+	}
+}
+
+// buildCredentialHeaders initializes the names of the request headers that
+// carry the credentials of the service security schemes. The headers are
+// omitted from the transport metadata of all the service endpoints so that
+// credentials sent to endpoints that do not use them are not leaked either.
+// The client response caches key the responses by these headers and by the
+// tenant header.
+func buildCredentialHeaders(sd *ServiceData, hs *expr.HTTPServiceExpr) {
+	var names []string
+	seen := map[string]struct{}{"authorization": {}}
+	add := func(name string) {
+		if _, ok := seen[strings.ToLower(name)]; ok {
+			return
+		}
+		seen[strings.ToLower(name)] = struct{}{}
+		names = append(names, name)
+	}
+	for i, e := range hs.HTTPEndpoints {
+		for _, req := range e.Requirements {
+			for _, s := range req.Schemes {
+				if s.In == "header" {
+					add(s.Name)
+				}
+			}
+		}
+		for _, f := range sd.Endpoints[i].APIKeyFallbacks {
+			for _, l := range f.Locations {
+				if l.In == "header" {
+					add(l.Name)
+				}
+			}
+		}
+	}
+	keys := names
+	if t := expr.Root.API.Tenant; t != nil && t.Header != "" {
+		if _, ok := seen[strings.ToLower(t.Header)]; !ok {
+			keys = append(append([]string{}, names...), t.Header)
+		}
+	}
+	for _, ed := range sd.Endpoints {
+		ed.CredentialHeaders = names
+		ed.CacheKeyHeaders = keys
+	}
+}
+
+// buildJobStatusData initializes the name of the function that computes the
+// path of the job status endpoint for the async endpoints.
+func buildJobStatusData(sd *ServiceData, hs *expr.HTTPServiceExpr) {
+	m := hs.ServiceExpr.JobStatusMethod()
+	if m == nil {
+		return
+	}
+	js := sd.Endpoint(m.Name)
+	if js == nil || js.Routes[0].PathInit == nil {
+		return
+	}
+	for i, e := range hs.HTTPEndpoints {
+		if e.MethodExpr.Async {
+			sd.Endpoints[i].JobStatusPath = js.Routes[0].PathInit.Name
+		}
+	}
+}
+
+// buildCSRFData initializes the data needed to generate the server method that
+// protects the endpoints that authenticate requests with cookies against
+// Cross-Site Request Forgery.
+func buildCSRFData(sd *ServiceData, hs *expr.HTTPServiceExpr) {
+	for i, e := range hs.HTTPEndpoints {
+		c := e.CSRFProtection()
+		if c == nil {
+			continue
+		}
+		p := &CSRFProtectionData{
+			Synchronizer:  c.Mode == expr.CSRFSynchronizer,
+			SessionCookie: e.CSRFSessionCookie(),
+		}
+		if c.HeaderName() != expr.DefaultCSRFHeader {
+			p.Header = c.HeaderName()
+		}
+		if !p.Synchronizer && c.CookieName() != expr.DefaultCSRFCookie {
+			p.Cookie = c.CookieName()
+		}
+		if sd.CSRF == nil {
+			sd.CSRF = &CSRFData{ServerStruct: sd.ServerStruct}
+		}
+		var found *CSRFProtectionData
+		for _, existing := range sd.CSRF.Protections {
+			if existing.Synchronizer == p.Synchronizer && existing.SessionCookie == p.SessionCookie &&
+				existing.Header == p.Header && existing.Cookie == p.Cookie {
+				found = existing
+				break
+			}
+		}
+		if found == nil {
+			found = p
+			sd.CSRF.Protections = append(sd.CSRF.Protections, p)
+		}
+		found.Endpoints = append(found.Endpoints, sd.Endpoints[i])
+		if p.Synchronizer {
+			sd.CSRF.Synchronizer = true
+		}
+	}
+}
+
+// durationCode returns the Go code that initializes a time.Duration with the
+// value of d.
+func durationCode(d time.Duration) string {
+	units := []struct {
+		unit time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+		{time.Microsecond, "time.Microsecond"},
+	}
+	if d == 0 {
+		return "0"
+	}
+	for _, u := range units {
+		if d%u.unit == 0 {
+			if n := d / u.unit; n != 1 {
+				return fmt.Sprintf("%d * %s", n, u.name)
+			}
+			return u.name
+		}
+	}
+	return fmt.Sprintf("time.Duration(%d)", int64(d))
+}
+
 func buildStreamData(ed *EndpointData, e *expr.HTTPEndpointExpr, sd *ServiceData) {
 	if !e.MethodExpr.IsStreaming() {
 		return
 	}
+	if ws := e.WebSocket; ws != nil {
+		ed.WebSocket = &WebSocketData{
+			Subprotocols:      ws.Subprotocols,
+			PerMessageDeflate: ws.PerMessageDeflate,
+			PingInterval:      ws.PingInterval,
+			MaxMessageSize:    ws.MaxMessageSize,
+		}
+		cws := *ed.WebSocket
+		ed.ClientWebSocket = &cws
+		if ws.PingInterval > 0 {
+			// The server reads the streamed payloads or discards the
+			// messages if it only sends results so it always
+			// processes the pong messages. The client only reads the
+			// connection continuously if it receives streamed
+			// results.
+			ed.WebSocket.PongWait = 2 * ws.PingInterval
+			ed.WebSocket.SendOnly = e.MethodExpr.Stream == expr.ServerStreamKind
+			if e.MethodExpr.Stream != expr.ClientStreamKind {
+				ed.ClientWebSocket.PongWait = 2 * ws.PingInterval
+			}
+		}
+	}
 	var (
 		svrSendTypeName string
 		svrSendTypeRef  string
@@ -1737,16 +3041,27 @@ func buildStreamData(ed *EndpointData, e *expr.HTTPEndpointExpr, sd *ServiceData
 		cliSendDesc     string
 		cliRecvDesc     string
 		cliPayload      *TypeData
+		chunked         string
 
 		md     = ed.Method
 		svc    = sd.Service
 		svcctx = serviceContext(sd.Service.PkgName, sd.Service.Scope)
 	)
+	switch e.StreamingResponse {
+	case expr.NDJSONStreamFormat:
+		chunked = "goahttp.StreamNDJSON"
+	case expr.JSONArrayStreamFormat:
+		chunked = "goahttp.StreamJSONArray"
+	}
 	{
 		svrSendTypeName = ed.Result.Name
 		svrSendTypeRef = ed.Result.Ref
 		svrSendDesc = fmt.Sprintf("%s streams instances of %q to the %q endpoint websocket connection.", md.ServerStream.SendName, svrSendTypeName, md.Name)
 		cliRecvDesc = fmt.Sprintf("%s reads instances of %q from the %q endpoint websocket connection.", md.ClientStream.RecvName, svrSendTypeName, md.Name)
+		if chunked != "" {
+			svrSendDesc = fmt.Sprintf("%s streams instances of %q to the %q endpoint HTTP response.", md.ServerStream.SendName, svrSendTypeName, md.Name)
+			cliRecvDesc = fmt.Sprintf("%s reads instances of %q from the %q endpoint HTTP response.", md.ClientStream.RecvName, svrSendTypeName, md.Name)
+		}
 		if e.MethodExpr.Stream == expr.ClientStreamKind || e.MethodExpr.Stream == expr.BidirectionalStreamKind {
 			svrRecvTypeName = sd.Scope.GoFullTypeName(e.MethodExpr.StreamingPayload, svc.PkgName)
 			svrRecvTypeRef = sd.Scope.GoFullTypeRef(e.MethodExpr.StreamingPayload, svc.PkgName)
@@ -1838,42 +3153,44 @@ func buildStreamData(ed *EndpointData, e *expr.HTTPEndpointExpr, sd *ServiceData
 		}
 	}
 	ed.ServerStream = &StreamData{
-		VarName:      md.ServerStream.VarName,
-		Interface:    fmt.Sprintf("%s.%s", svc.PkgName, md.ServerStream.Interface),
-		Endpoint:     ed,
-		Payload:      svrPayload,
-		Response:     ed.Result.Responses[0],
-		PkgName:      svc.PkgName,
-		Type:         "server",
-		Kind:         md.ServerStream.Kind,
-		SendName:     md.ServerStream.SendName,
-		SendDesc:     svrSendDesc,
-		SendTypeName: svrSendTypeName,
-		SendTypeRef:  svrSendTypeRef,
-		RecvName:     md.ServerStream.RecvName,
-		RecvDesc:     svrRecvDesc,
-		RecvTypeName: svrRecvTypeName,
-		RecvTypeRef:  svrRecvTypeRef,
-		MustClose:    md.ServerStream.MustClose,
+		VarName:       md.ServerStream.VarName,
+		Interface:     fmt.Sprintf("%s.%s", svc.PkgName, md.ServerStream.Interface),
+		Endpoint:      ed,
+		Payload:       svrPayload,
+		Response:      ed.Result.Responses[0],
+		PkgName:       svc.PkgName,
+		Type:          "server",
+		Kind:          md.ServerStream.Kind,
+		SendName:      md.ServerStream.SendName,
+		SendDesc:      svrSendDesc,
+		SendTypeName:  svrSendTypeName,
+		SendTypeRef:   svrSendTypeRef,
+		RecvName:      md.ServerStream.RecvName,
+		RecvDesc:      svrRecvDesc,
+		RecvTypeName:  svrRecvTypeName,
+		RecvTypeRef:   svrRecvTypeRef,
+		MustClose:     md.ServerStream.MustClose,
+		ChunkedFormat: chunked,
 	}
 	ed.ClientStream = &StreamData{
-		VarName:      md.ClientStream.VarName,
-		Interface:    fmt.Sprintf("%s.%s", svc.PkgName, md.ClientStream.Interface),
-		Endpoint:     ed,
-		Payload:      cliPayload,
-		Response:     ed.Result.Responses[0],
-		PkgName:      svc.PkgName,
-		Type:         "client",
-		Kind:         md.ClientStream.Kind,
-		SendName:     md.ClientStream.SendName,
-		SendDesc:     cliSendDesc,
-		SendTypeName: svrRecvTypeName,
-		SendTypeRef:  svrRecvTypeRef,
-		RecvName:     md.ClientStream.RecvName,
-		RecvDesc:     cliRecvDesc,
-		RecvTypeName: svrSendTypeName,
-		RecvTypeRef:  svrSendTypeRef,
-		MustClose:    md.ClientStream.MustClose,
+		VarName:       md.ClientStream.VarName,
+		Interface:     fmt.Sprintf("%s.%s", svc.PkgName, md.ClientStream.Interface),
+		Endpoint:      ed,
+		Payload:       cliPayload,
+		Response:      ed.Result.Responses[0],
+		PkgName:       svc.PkgName,
+		Type:          "client",
+		Kind:          md.ClientStream.Kind,
+		SendName:      md.ClientStream.SendName,
+		SendDesc:      cliSendDesc,
+		SendTypeName:  svrRecvTypeName,
+		SendTypeRef:   svrRecvTypeRef,
+		RecvName:      md.ClientStream.RecvName,
+		RecvDesc:      cliRecvDesc,
+		RecvTypeName:  svrSendTypeName,
+		RecvTypeRef:   svrSendTypeRef,
+		MustClose:     md.ClientStream.MustClose,
+		ChunkedFormat: chunked,
 	}
 }
 
@@ -2181,14 +3498,52 @@ func buildResponseBodyType(body, att *expr.AttributeExpr, e *expr.HTTPEndpointEx
 	}
 }
 
-func extractPathParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr, scope *codegen.NameScope) []*ParamData {
-	var params []*ParamData
+// hasInitArg returns true if args contains an argument with the given name.
+func hasInitArg(args []*InitArgData, name string) bool {
+	for _, a := range args {
+		if a.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func extractPathParams(e *expr.HTTPEndpointExpr, service *expr.AttributeExpr, scope *codegen.NameScope) []*ParamData {
+	var (
+		params []*ParamData
+		paths  []string
+	)
+	for _, r := range e.Routes {
+		paths = append(paths, r.FullPaths()...)
+	}
+	a := e.PathParams()
 	codegen.WalkMappedAttr(a, func(name, elem string, _ bool, c *expr.AttributeExpr) error {
 		var (
-			varn = scope.Name(codegen.Goify(name, false))
-			arr  = expr.AsArray(c.Type)
-			ctx  = serviceContext("", scope)
+			varn     = scope.Name(codegen.Goify(name, false))
+			arr      = expr.AsArray(c.Type)
+			ctx      = serviceContext("", scope)
+			typeRef  = scope.GoTypeRef(c)
+			required = true
+			delim    string
+			pointer  bool
 		)
+		for _, p := range paths {
+			if expr.PathParamStyle(p, elem) != expr.PathStyleSimple {
+				// label and matrix style parameters are optional
+				required = a.IsRequired(name)
+			}
+			if arr != nil {
+				delim = ","
+				if expr.IsHTTPCatchAll(p, elem) {
+					delim = "/"
+				}
+			}
+		}
+		if !required {
+			if pointer = a.IsPrimitivePointer(name, true); pointer {
+				typeRef = "*" + typeRef
+			}
+		}
 		fieldName := codegen.Goify(name, true)
 		if !expr.IsObject(service.Type) {
 			fieldName = ""
@@ -2200,18 +3555,21 @@ func extractPathParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr,
 			FieldName:      fieldName,
 			FieldPointer:   expr.IsObject(service.Type) && service.IsPrimitivePointer(name, true),
 			VarName:        varn,
-			Required:       true,
+			Required:       required,
 			Type:           c.Type,
 			TypeName:       scope.GoTypeName(c),
-			TypeRef:        scope.GoTypeRef(c),
-			Pointer:        false,
+			TypeRef:        typeRef,
+			Pointer:        pointer,
 			Slice:          arr != nil,
 			StringSlice:    arr != nil && arr.ElemType.Type.Kind() == expr.StringKind,
 			Map:            false,
 			MapStringSlice: false,
-			Validate:       codegen.RecursiveValidationCode(c, ctx, true, varn),
+			Delimiter:      delim,
+			Validate:       codegen.RecursiveValidationCode(c, ctx, required, varn),
 			DefaultValue:   c.DefaultValue,
 			Example:        c.Example(expr.Root.API.Random()),
+			Lenient:        expr.IsLenientAttribute(c),
+			Trim:           expr.IsTrimmedAttribute(c),
 		})
 		return nil
 	})
@@ -2257,9 +3615,12 @@ func extractQueryParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr
 				mp.KeyType.Type.Kind() == expr.StringKind &&
 				mp.ElemType.Type.Kind() == expr.ArrayKind &&
 				expr.AsArray(mp.ElemType.Type).ElemType.Type.Kind() == expr.StringKind,
+			Delimiter:    expr.QueryDelimiter(c),
 			Validate:     codegen.RecursiveValidationCode(c, ctx, required, varn),
 			DefaultValue: c.DefaultValue,
 			Example:      c.Example(expr.Root.API.Random()),
+			Lenient:      expr.IsLenientAttribute(c),
+			Trim:         expr.IsTrimmedAttribute(c),
 		})
 		return nil
 	})
@@ -2313,6 +3674,8 @@ func extractHeaders(a *expr.MappedAttributeExpr, svcAtt *expr.AttributeExpr, svc
 			Validate:      codegen.RecursiveValidationCode(hattr, svcCtx, required, varn),
 			DefaultValue:  hattr.DefaultValue,
 			Example:       hattr.Example(expr.Root.API.Random()),
+			Lenient:       expr.IsLenientAttribute(hattr),
+			Trim:          expr.IsTrimmedAttribute(hattr),
 		})
 		return nil
 	})
@@ -2351,6 +3714,31 @@ func collectUserTypes(dt expr.DataType, cb func(expr.UserType), seen ...map[stri
 	}
 }
 
+// buildWebhookData returns the data used to render the client code that sends
+// the given webhook. The webhook body type and the user types it uses are
+// added to the client body types.
+func buildWebhookData(w *expr.HTTPWebhookExpr, rd *ServiceData) *WebhookData {
+	ut := w.Body.Type.(expr.UserType)
+	var body *TypeData
+	collectUserTypes(ut, func(t expr.UserType) {
+		d := attributeTypeData(t, true, false, false, rd)
+		if d == nil {
+			return
+		}
+		if t == ut {
+			d.Description = fmt.Sprintf("%s is the body of the %q webhook requests.", d.VarName, w.Name)
+			body = d
+		}
+		rd.ClientBodyAttributeTypes = append(rd.ClientBodyAttributeTypes, d)
+	})
+	return &WebhookData{
+		Name:        w.Name,
+		Description: w.Description,
+		SendFunc:    "Send" + codegen.Goify(strings.TrimSuffix(ut.Name(), "Body"), true),
+		Body:        body,
+	}
+}
+
 func attributeTypeData(ut expr.UserType, req, ptr, server bool, rd *ServiceData) *TypeData {
 	if ut == expr.Empty {
 		return nil
@@ -2483,6 +3871,36 @@ func needConversion(dt expr.DataType) bool {
 	}
 }
 
+// namedExamples returns the values of the named examples defined in the
+// design for the attribute indexed by example name. If attName is not empty
+// then att must be an object and namedExamples returns the values of the
+// child attribute with that name. namedExamples returns nil if the design
+// does not define any named example.
+func namedExamples(att *expr.AttributeExpr, attName string) map[string]interface{} {
+	var res map[string]interface{}
+	for _, n := range att.ExampleNames() {
+		v := att.NamedExample(n, expr.NewRandom(n))
+		if attName != "" && expr.IsObject(att.Type) {
+			switch m := v.(type) {
+			case map[string]interface{}:
+				v = m[attName]
+			case expr.Val:
+				v = m[attName]
+			default:
+				v = nil
+			}
+		}
+		if v == nil {
+			continue
+		}
+		if res == nil {
+			res = make(map[string]interface{})
+		}
+		res[n] = v
+	}
+	return res
+}
+
 // needInit returns true if and only if the given type is or makes use of user
 // types.
 func needInit(dt expr.DataType) bool {
@@ -2542,9 +3960,41 @@ func streamingEndpointExists(sd *ServiceData) bool {
 	return false
 }
 
+// clientPolicyExists returns true if at least one endpoint of the service
+// defines a client policy.
+func clientPolicyExists(sd *ServiceData) bool {
+	for _, e := range sd.Endpoints {
+		if e.ClientPolicy != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// cacheableEndpointExists returns true if at least one of the endpoints in the
+// service responses may be cached by the client, see isCacheableEndpoint.
+func cacheableEndpointExists(sd *ServiceData) bool {
+	for _, e := range sd.Endpoints {
+		if isCacheableEndpoint(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCacheableEndpoint returns true if the client requests to the endpoint use
+// the GET method and the endpoint returns a result that is not streamed.
+func isCacheableEndpoint(ed *EndpointData) bool {
+	return ed.Method.ResultRef != "" && ed.ClientStream == nil && ed.ServerStream == nil &&
+		len(ed.Routes) > 0 && ed.Routes[0].Verb == "GET"
+}
+
 // isStreamingEndpoint returns true if the endpoint defines a streaming payload
-// or result.
+// or result carried over a websocket connection.
 func isStreamingEndpoint(ed *EndpointData) bool {
+	if ed.ServerStream != nil && ed.ServerStream.ChunkedFormat != "" {
+		return false
+	}
 	return ed.ServerStream != nil || ed.ClientStream != nil
 }
 
@@ -2561,10 +4011,27 @@ const (
 	}
 		{{- end }}
 	{{- end }}
+	{{- if .Styled }}
+		{{- if .NumSimple }}
+	{{ .PathVar }} := fmt.Sprintf("{{ .PathFormat }}", {{ range $i, $arg := .Args }}{{ if lt $i $.NumSimple }}
+		{{- if eq (index $.PathParams $i).Attribute.Type.Name "array" }}strings.Join({{ .Name }}Slice, {{ if eq .Name $.CatchAll }}"/"{{ else }}", "{{ end }})
+		{{- else }}{{ .Name }}
+		{{- end }}, {{ end }}{{ end }})
+		{{- else }}
+	{{ .PathVar }} := "{{ .PathFormat }}"
+		{{- end }}
+		{{- range .Styled }}
+	if {{ .Name }} != "" {
+		{{ $.PathVar }} += {{ printf "%q" .Prefix }} + {{ .Name }}
+	}
+		{{- end }}
+	return {{ .PathVar }}
+	{{- else }}
 	return fmt.Sprintf("{{ .PathFormat }}", {{ range $i, $arg := .Args }}
-	{{- if eq (index $.PathParams $i).Attribute.Type.Name "array" }}strings.Join({{ .Name }}Slice, ", ")
+	{{- if eq (index $.PathParams $i).Attribute.Type.Name "array" }}strings.Join({{ .Name }}Slice, {{ if eq .Name $.CatchAll }}"/"{{ else }}", "{{ end }})
 	{{- else }}{{ .Name }}
 	{{- end }}, {{ end }})
+	{{- end }}
 {{- else }}
 	return "{{ .PathFormat }}"
 {{- end }}
@@ -2635,6 +4102,15 @@ const (
 	// input: StreamData
 	streamStructTypeT = `{{ printf "%s implements the %s interface." .VarName .Interface | comment }}
 type {{ .VarName }} struct {
+{{- if .ChunkedFormat }}
+	{{- if eq .Type "server" }}
+	{{ comment "w writes the results to the chunked HTTP response." }}
+	w *goahttp.StreamWriter
+	{{- else }}
+	{{ comment "r reads the results from the chunked HTTP response." }}
+	r *goahttp.StreamReader
+	{{- end }}
+{{- else }}
 {{- if eq .Type "server" }}
 	once sync.Once
 	{{ comment "upgrader is the websocket connection upgrader." }}
@@ -2650,9 +4126,14 @@ type {{ .VarName }} struct {
 {{- end }}
 	{{ comment "conn is the underlying websocket connection." }}
 	conn *websocket.Conn
+{{- end }}
 	{{- if .Endpoint.Method.ViewedResult }}
 		{{- if not .Endpoint.Method.ViewedResult.ViewName }}
+	{{- if .ChunkedFormat }}
+	{{ printf "view is the view to render %s result type before sending to the HTTP response." .SendTypeName | comment }}
+	{{- else }}
 	{{ printf "view is the view to render %s result type before sending to the websocket connection." .SendTypeName | comment }}
+	{{- end }}
 	view string
 		{{- end }}
 	{{- end }}
@@ -2693,8 +4174,11 @@ func NewConnConfigurer(fn goahttp.ConnConfigureFunc) *ConnConfigurer {
 	// input: StreamData
 	streamSendT = `{{ comment .SendDesc }}
 func (s *{{ .VarName }}) {{ .SendName }}(v {{ .SendTypeRef }}) error {
+{{- $write := "s.conn.WriteJSON" }}
+{{- if .ChunkedFormat }}{{ $write = "s.w.Send" }}{{ end }}
 {{- if eq .Type "server" }}
-	{{- if eq .SendName "Send" }}
+	{{- if .ChunkedFormat }}
+	{{- else if eq .SendName "Send" }}
 		var err error
 		{{- template "websocket_upgrade" (upgradeParams .Endpoint .SendName) }}
 	{{- else }} {{/* SendAndClose */}}
@@ -2729,17 +4213,17 @@ func (s *{{ .VarName }}) {{ .SendName }}(v {{ .SendTypeRef }}) error {
 			{{- else }}
 				body := {{ (index .Response.ServerBody 0).Init.Name }}({{ range (index .Response.ServerBody 0).Init.ServerArgs }}{{ .Ref }}, {{ end }})
 			{{- end }}
-			return s.conn.WriteJSON(body)
+			return {{ $write }}(body)
 		{{- else }}
-			return s.conn.WriteJSON(res)
+			return {{ $write }}(res)
 		{{- end }}
 	{{- else }}
-		return s.conn.WriteJSON(res)
+		return {{ $write }}(res)
 	{{- end }}
 {{- else }}
 	{{- if .Payload.Init }}
 		body := {{ .Payload.Init.Name }}(v)
-		return s.conn.WriteJSON(body)
+		return {{ $write }}(body)
 	{{- else }}
 		return s.conn.WriteJSON(v)
 	{{- end }}
@@ -2782,6 +4266,11 @@ func (s *{{ .VarName }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
 		return body, nil
 	{{- end }}
 {{- else }} {{/* client side code */}}
+	{{- if .ChunkedFormat }}
+	if err = s.r.Recv(&body); err != nil {
+		return rv, err
+	}
+	{{- else }}
 	{{- if eq .RecvName "CloseAndRecv" }}
 		defer s.conn.Close()
 		{{ comment "Send a nil payload to the server implying end of message" }}
@@ -2799,6 +4288,7 @@ func (s *{{ .VarName }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
 	if err != nil {
 		return rv, err
 	}
+	{{- end }}
 	{{- if and .Response.ClientBody.ValidateRef (not .Endpoint.Method.ViewedResult) }}
 	{{ .Response.ClientBody.ValidateRef }}
 	if err != nil {
@@ -2823,6 +4313,45 @@ func (s *{{ .VarName }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
 }
 ` + upgradeT
 
+	// webSocketConfigT renders the websocket configuration of a streaming
+	// endpoint.
+	webSocketConfigT = `{{- define "websocket_config" }}
+	wsc := &goahttp.WebSocketConfig{
+		{{- if .Subprotocols }}
+		Subprotocols: []string{ {{- range $i, $p := .Subprotocols }}{{ if $i }}, {{ end }}{{ printf "%q" $p }}{{ end }} },
+		{{- end }}
+		{{- if .PerMessageDeflate }}
+		EnableCompression: true,
+		{{- end }}
+		{{- if .PingInterval }}
+		PingInterval: {{ .PingInterval }} * time.Second,
+		{{- end }}
+		{{- if .PongWait }}
+		PongWait: {{ .PongWait }} * time.Second,
+		{{- end }}
+		{{- if .SendOnly }}
+		SendOnly: true,
+		{{- end }}
+		{{- if .MaxMessageSize }}
+		ReadLimit: {{ .MaxMessageSize }},
+		{{- end }}
+	}
+{{- end }}
+`
+
+	// propagationT renders the initialization of the request context
+	// propagation of an endpoint.
+	propagationT = `{{- define "propagation" }}&goa.Propagation{
+		{{- if .Headers }}
+		Headers: []string{ {{- range $i, $h := .Headers }}{{ if $i }}, {{ end }}{{ printf "%q" $h }}{{ end }} },
+		{{- end }}
+		{{- if .Deadline }}
+		Deadline: true,
+		{{- end }}
+	}
+{{- end }}
+`
+
 	// upgradeT renders the code to upgrade the HTTP connection to a gorilla
 	// websocket connection.
 	upgradeT = `{{- define "websocket_upgrade" }}
@@ -2865,7 +4394,13 @@ func (s *{{ .VarName }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
 	// streamCloseT renders the function implementing the Close method in
 	// stream interface.
 	// input: StreamData
-	streamCloseT = `{{ printf "Close closes the %q endpoint websocket connection." .Endpoint.Method.Name | comment }}
+	streamCloseT = `{{- if .ChunkedFormat }}
+{{- printf "Close terminates the %q endpoint HTTP response." .Endpoint.Method.Name | comment }}
+func (s *{{ .VarName }}) Close() error {
+	return s.w.Close()
+}
+{{- else }}
+{{- printf "Close closes the %q endpoint websocket connection." .Endpoint.Method.Name | comment }}
 func (s *{{ .VarName }}) Close() error {
 	var err error
 {{- if eq .Type "server" }}
@@ -2887,14 +4422,18 @@ func (s *{{ .VarName }}) Close() error {
 {{- end }}
 	return s.conn.Close()
 }
+{{- end }}
 ` + upgradeT
 
 	// streamSetViewT renders the function implementing the SetView method in
 	// server stream interface.
 	// input: StreamData
-	streamSetViewT = `{{ printf "SetView sets the view to render the %s type before sending to the %q endpoint websocket connection." .SendTypeName .Endpoint.Method.Name | comment }}
+	streamSetViewT = `{{ if .ChunkedFormat }}{{ printf "SetView sets the view to render the %s type before sending to the %q endpoint HTTP response." .SendTypeName .Endpoint.Method.Name | comment }}{{ else }}{{ printf "SetView sets the view to render the %s type before sending to the %q endpoint websocket connection." .SendTypeName .Endpoint.Method.Name | comment }}{{ end }}
 func (s *{{ .VarName }}) SetView(view string) {
 	s.view = view
+	{{- if and .ChunkedFormat (eq .Type "server") }}
+	s.w.Header().Set("goa-view", view)
+	{{- end }}
 }
 `
 )
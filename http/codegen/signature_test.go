@@ -0,0 +1,36 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestSignatureSecurity(t *testing.T) {
+	const genpkg = "gen"
+	cases := []struct {
+		Name    string
+		Files   func(string, *expr.RootExpr) []*codegen.File
+		Section string
+		Code    string
+	}{
+		{"server-verify-signatures", ServerFiles, "server-verify-signatures", testdata.SignatureServerVerifyCode},
+		{"client-sign-requests", ClientFiles, "client-sign-requests", testdata.SignatureClientSignCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, testdata.SignatureSecurityDSL)
+			fs := c.Files(genpkg, expr.Root)
+			sections := fs[0].Section(c.Section)
+			if len(sections) != 1 {
+				t.Fatalf("got %d %s sections, expected one", len(sections), c.Section)
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestStreamingResponse(t *testing.T) {
+	const genpkg = "gen"
+	RunHTTPDSL(t, testdata.StreamingResponseDSL)
+	svr := ServerFiles(genpkg, expr.Root)[0]
+	cli := ClientFiles(genpkg, expr.Root)[0]
+	cases := []struct {
+		Name    string
+		File    *codegen.File
+		Section string
+		Code    string
+	}{
+		{"server-init", svr, "server-init", testdata.StreamingResponseServerInitCode},
+		{"server-handler-init", svr, "server-handler-init", testdata.StreamingResponseHandlerInitCode},
+		{"server-stream-struct-type", svr, "server-stream-struct-type", testdata.StreamingResponseServerStructCode},
+		{"server-stream-send", svr, "server-stream-send", testdata.StreamingResponseServerSendCode},
+		{"server-stream-close", svr, "server-stream-close", testdata.StreamingResponseServerCloseCode},
+		{"server-stream-set-view", svr, "server-stream-set-view", testdata.StreamingResponseServerSetViewCode},
+		{"client-init", cli, "client-init", testdata.StreamingResponseClientInitCode},
+		{"client-endpoint-init", cli, "client-endpoint-init", testdata.StreamingResponseClientEndpointInitCode},
+		{"request-builder", ClientFiles(genpkg, expr.Root)[1], "request-builder", testdata.StreamingResponseRequestBuilderCode},
+		{"client-stream-struct-type", cli, "client-stream-struct-type", testdata.StreamingResponseClientStructCode},
+		{"client-stream-recv", cli, "client-stream-recv", testdata.StreamingResponseClientRecvCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var codes []string
+			for _, s := range c.File.SectionTemplates {
+				if s.Name == c.Section {
+					codes = append(codes, codegen.SectionCode(t, s))
+				}
+			}
+			if len(codes) == 0 {
+				t.Fatalf("section %q not found", c.Section)
+			}
+			code := strings.Join(codes, "\n")
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+	for _, f := range []*codegen.File{svr, cli} {
+		for _, s := range f.SectionTemplates {
+			if strings.Contains(s.Name, "conn-configurer") {
+				t.Errorf("unexpected websocket section %q", s.Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestStrictDecoding(t *testing.T) {
+	const genpkg = "gen"
+	RunHTTPDSL(t, testdata.StrictDecodingDSL)
+	fs := ServerFiles(genpkg, expr.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected two", len(fs))
+	}
+	cases := []struct {
+		Section string
+		Code    string
+		File    int
+	}{
+		{"server-handler-init", testdata.StrictDecodingHandlerInitCode, 0},
+		{"request-decoder", testdata.StrictDecodingRequestDecoderCode, 1},
+	}
+	for _, c := range cases {
+		t.Run(c.Section, func(t *testing.T) {
+			sections := fs[c.File].Section(c.Section)
+			if len(sections) != 1 {
+				t.Fatalf("got %d %s sections, expected 1", len(sections), c.Section)
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
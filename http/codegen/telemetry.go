@@ -0,0 +1,204 @@
+package codegen
+
+import (
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// TelemetryFiles returns the file that implements the OpenTelemetry
+// instrumentation of the HTTP servers and clients of the services that use the
+// "observability:otel" meta. The generated package wraps the server handlers,
+// the client doers and the websocket dialers so that each request creates a
+// span that continues the trace propagated in the request headers and records
+// the request duration.
+func TelemetryFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	if !otelInstrumented(root) {
+		return nil
+	}
+	fpath := filepath.Join(codegen.Gendir, "http", "telemetry", "telemetry.go")
+	sections := []*codegen.SectionTemplate{
+		codegen.Header("HTTP OpenTelemetry instrumentation", "telemetry", []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "net/http"},
+			{Path: "sync"},
+			{Path: "time"},
+			{Path: "github.com/gorilla/websocket"},
+			{Path: "go.opentelemetry.io/otel"},
+			{Path: "go.opentelemetry.io/otel/attribute"},
+			{Path: "go.opentelemetry.io/otel/codes"},
+			{Path: "go.opentelemetry.io/otel/metric"},
+			{Path: "go.opentelemetry.io/otel/propagation"},
+			{Path: "go.opentelemetry.io/otel/trace"},
+			codegen.GoaNamedImport("http", "goahttp"),
+			codegen.GoaNamedImport("http/middleware", "httpmdlwr"),
+		}),
+		{
+			Name:   "http-telemetry",
+			Source: telemetryT,
+			Data:   map[string]interface{}{"ScopeName": genpkg + "/http/telemetry"},
+		},
+	}
+	return []*codegen.File{{Path: fpath, SectionTemplates: sections}}
+}
+
+// otelInstrumented returns true if at least one of the HTTP services is
+// instrumented with OpenTelemetry.
+func otelInstrumented(root *expr.RootExpr) bool {
+	for _, svc := range root.API.HTTP.Services {
+		if svc.ServiceExpr.IsOTelInstrumented() {
+			return true
+		}
+	}
+	return false
+}
+
+// input: map[string]interface{}{"ScopeName": string}
+const telemetryT = `// ScopeName is the name of the instrumentation scope of the tracer and of the
+// meter used by the generated HTTP servers and clients.
+const ScopeName = {{ printf "%q" .ScopeName }}
+
+var (
+	instrumentsOnce sync.Once
+	serverDuration  metric.Float64Histogram
+	clientDuration  metric.Float64Histogram
+)
+
+// Handler wraps the HTTP handler of the given service method so that each
+// request creates a server span and records the request duration in the
+// "http.server.request.duration" histogram. The span continues the trace
+// propagated in the request headers if any. Responses with a 5xx status code
+// set the span status to error.
+func Handler(h http.Handler, service, method, route string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		attrs := []attribute.KeyValue{
+			attribute.String("goa.service", service),
+			attribute.String("goa.method", method),
+			attribute.String("http.request.method", r.Method),
+			attribute.String("http.route", route),
+		}
+		ctx, span := otel.Tracer(ScopeName).Start(ctx, service+"."+method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attrs...))
+		defer span.End()
+
+		start := time.Now()
+		rw := httpmdlwr.CaptureResponse(w)
+		h.ServeHTTP(rw, r.WithContext(ctx))
+		status := rw.StatusCode
+		if status == 0 {
+			status = http.StatusOK
+		}
+		attrs = append(attrs, attribute.Int("http.response.status_code", status))
+		span.SetAttributes(attrs[len(attrs)-1])
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+		instruments()
+		serverDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+	})
+}
+
+// Doer wraps the HTTP client used to make the requests to the given service
+// method so that each request creates a client span, propagates the trace in
+// the request headers and records the request duration in the
+// "http.client.request.duration" histogram. Errors and responses with a 4xx
+// or 5xx status code set the span status to error.
+func Doer(d goahttp.Doer, service, method string) goahttp.Doer {
+	return doerFunc(func(req *http.Request) (*http.Response, error) {
+		ctx, span, attrs := startClient(req.Context(), service, method, req.Method)
+		defer span.End()
+		req = req.WithContext(ctx)
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+		start := time.Now()
+		resp, err := d.Do(req)
+		endClient(ctx, span, attrs, start, resp, err)
+		return resp, err
+	})
+}
+
+// Dialer wraps the websocket dialer used to connect to the given streaming
+// service method so that each connection handshake creates a client span,
+// propagates the trace in the handshake request headers and records the
+// handshake duration in the "http.client.request.duration" histogram.
+func Dialer(d goahttp.Dialer, service, method string) goahttp.Dialer {
+	return dialerFunc(func(ctx context.Context, url string, h http.Header) (*websocket.Conn, *http.Response, error) {
+		ctx, span, attrs := startClient(ctx, service, method, http.MethodGet)
+		defer span.End()
+		if h == nil {
+			h = make(http.Header)
+		}
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(h))
+
+		start := time.Now()
+		conn, resp, err := d.DialContext(ctx, url, h)
+		endClient(ctx, span, attrs, start, resp, err)
+		return conn, resp, err
+	})
+}
+
+type (
+	// doerFunc is a goahttp.Doer implemented by a function.
+	doerFunc func(*http.Request) (*http.Response, error)
+
+	// dialerFunc is a goahttp.Dialer implemented by a function.
+	dialerFunc func(context.Context, string, http.Header) (*websocket.Conn, *http.Response, error)
+)
+
+// Do calls f.
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) { return f(req) }
+
+// DialContext calls f.
+func (f dialerFunc) DialContext(ctx context.Context, url string, h http.Header) (*websocket.Conn, *http.Response, error) {
+	return f(ctx, url, h)
+}
+
+// startClient starts a client span for a request made to the given service
+// method.
+func startClient(ctx context.Context, service, method, verb string) (context.Context, trace.Span, []attribute.KeyValue) {
+	attrs := []attribute.KeyValue{
+		attribute.String("goa.service", service),
+		attribute.String("goa.method", method),
+		attribute.String("http.request.method", verb),
+	}
+	ctx, span := otel.Tracer(ScopeName).Start(ctx, service+"."+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+	return ctx, span, attrs
+}
+
+// endClient records the outcome of a client request in the span and in the
+// client request duration histogram.
+func endClient(ctx context.Context, span trace.Span, attrs []attribute.KeyValue, start time.Time, resp *http.Response, err error) {
+	if resp != nil {
+		attrs = append(attrs, attribute.Int("http.response.status_code", resp.StatusCode))
+		span.SetAttributes(attrs[len(attrs)-1])
+		if resp.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+		}
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	instruments()
+	clientDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+}
+
+// instruments initializes the metric instruments using the global meter
+// provider.
+func instruments() {
+	instrumentsOnce.Do(func() {
+		meter := otel.Meter(ScopeName)
+		serverDuration, _ = meter.Float64Histogram("http.server.request.duration",
+			metric.WithUnit("s"),
+			metric.WithDescription("Duration of the HTTP server requests."))
+		clientDuration, _ = meter.Float64Histogram("http.client.request.duration",
+			metric.WithUnit("s"),
+			metric.WithDescription("Duration of the HTTP client requests."))
+	})
+}
+`
@@ -0,0 +1,67 @@
+package codegen
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestTelemetryFiles(t *testing.T) {
+	RunHTTPDSL(t, testdata.LenientDecodingDSL)
+	if fs := TelemetryFiles("gen", expr.Root); len(fs) != 0 {
+		t.Errorf("got %d files for a service that is not instrumented, expected none", len(fs))
+	}
+
+	RunHTTPDSL(t, testdata.TelemetryDSL)
+	fs := TelemetryFiles("gen", expr.Root)
+	if len(fs) != 1 {
+		t.Fatalf("got %d files, expected one", len(fs))
+	}
+	if p := filepath.Join("gen", "http", "telemetry", "telemetry.go"); fs[0].Path != p {
+		t.Errorf("got path %q, expected %q", fs[0].Path, p)
+	}
+	sections := fs[0].Section("http-telemetry")
+	if len(sections) != 1 {
+		t.Fatalf("got %d sections, expected one", len(sections))
+	}
+	code := codegen.SectionCode(t, sections[0])
+	for _, s := range []string{`const ScopeName = "gen/http/telemetry"`, "func Handler(", "func Doer(", "func Dialer("} {
+		if !strings.Contains(code, s) {
+			t.Errorf("got code missing %q:\n%s", s, code)
+		}
+	}
+}
+
+func TestTelemetry(t *testing.T) {
+	const genpkg = "gen"
+	RunHTTPDSL(t, testdata.TelemetryDSL)
+	server := ServerFiles(genpkg, expr.Root)[0]
+	client := ClientFiles(genpkg, expr.Root)[0]
+	cases := []struct {
+		Name    string
+		File    *codegen.File
+		Section string
+		Index   int
+		Code    string
+	}{
+		{"server-init", server, "server-init", 0, testdata.TelemetryServerInitCode},
+		{"client-init", client, "client-init", 0, testdata.TelemetryClientInitCode},
+		{"client-streaming-endpoint-init", client, "client-endpoint-init", 1, testdata.TelemetryClientStreamingEndpointInitCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			sections := c.File.Section(c.Section)
+			if len(sections) <= c.Index {
+				t.Fatalf("got %d sections, expected at least %d", len(sections), c.Index+1)
+			}
+			code := codegen.SectionCode(t, sections[c.Index])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
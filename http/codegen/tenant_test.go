@@ -0,0 +1,22 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestTenant(t *testing.T) {
+	RunHTTPDSL(t, testdata.TenantDSL)
+	fs := ServerFiles("gen", expr.Root)
+	sections := fs[0].Section("server-handler-init")
+	if len(sections) == 0 {
+		t.Fatalf("server-handler-init section not found")
+	}
+	code := codegen.SectionCode(t, sections[0])
+	if code != testdata.TenantServerHandlerInitCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.TenantServerHandlerInitCode))
+	}
+}
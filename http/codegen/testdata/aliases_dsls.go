@@ -0,0 +1,31 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var AliasesDSL = func() {
+	Service("ServiceAliases", func() {
+		Description("Manage the wine bottles")
+		Meta("cli:alias", "sa", "al")
+		Method("List", func() {
+			Meta("cli:alias", "ls")
+			Payload(func() {
+				Attribute("limit", Int, "Maximum number of bottles", func() {
+					Example("small", 5)
+				})
+			})
+			HTTP(func() {
+				GET("/")
+				Param("limit")
+			})
+		})
+		Method("Show", func() {
+			Description("Show a bottle")
+			Payload(String)
+			HTTP(func() {
+				GET("/{id}")
+			})
+		})
+	})
+}
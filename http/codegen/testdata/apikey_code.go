@@ -0,0 +1,52 @@
+package testdata
+
+var APIKeyLocationsDefaultDecodeCode = `// DecodeMethodDefaultRequest returns a decoder for requests sent to the
+// ServiceAPIKeyLocations MethodDefault endpoint.
+func DecodeMethodDefaultRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			key *string
+		)
+		keyRaw := r.Header.Get("X-API-Key")
+		if keyRaw != "" {
+			key = &keyRaw
+		}
+		payload := NewMethodDefaultPayload(key)
+		if payload.Key == nil {
+			if key := goahttp.ReadAPIKey(r, goahttp.KeyLocation{In: "query", Name: "api_key"}, goahttp.KeyLocation{In: "cookie", Name: "key"}); key != "" {
+				payload.Key = &key
+			}
+		}
+
+		return payload, nil
+	}
+}
+`
+
+var APIKeyLocationsMappedDecodeCode = `// DecodeMethodMappedRequest returns a decoder for requests sent to the
+// ServiceAPIKeyLocations MethodMapped endpoint.
+func DecodeMethodMappedRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			id  *string
+			key *string
+		)
+		idRaw := r.URL.Query().Get("id")
+		if idRaw != "" {
+			id = &idRaw
+		}
+		keyRaw := r.Header.Get("Authorization")
+		if keyRaw != "" {
+			key = &keyRaw
+		}
+		payload := NewMethodMappedPayload(id, key)
+		if payload.Key == nil {
+			if key := goahttp.ReadAPIKey(r, goahttp.KeyLocation{In: "header", Name: "X-API-Key"}, goahttp.KeyLocation{In: "query", Name: "api_key"}, goahttp.KeyLocation{In: "cookie", Name: "key"}); key != "" {
+				payload.Key = &key
+			}
+		}
+
+		return payload, nil
+	}
+}
+`
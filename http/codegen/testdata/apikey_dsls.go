@@ -0,0 +1,35 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var APIKeyLocationsDSL = func() {
+	var Key = APIKeySecurity("api_key", func() {
+		KeyLocation("header", "X-API-Key")
+		KeyLocation("query", "api_key")
+		KeyLocation("cookie", "key")
+	})
+	Service("ServiceAPIKeyLocations", func() {
+		Security(Key)
+		Method("MethodDefault", func() {
+			Payload(func() {
+				APIKey("api_key", "key", String)
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+		Method("MethodMapped", func() {
+			Payload(func() {
+				APIKey("api_key", "key", String)
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				GET("/mapped")
+				Param("id")
+				Header("key:Authorization")
+			})
+		})
+	})
+}
@@ -0,0 +1,15 @@
+package testdata
+
+const AsyncResponseEncoderCode = `// EncodeMethodAsyncResponse returns an encoder for responses returned by the
+// ServiceAsync MethodAsync endpoint.
+func EncodeMethodAsyncResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res := v.(*serviceasync.JobStatus)
+		w.Header().Set("Location", GetJobStatusServiceAsyncPath(res.ID))
+		enc := encoder(ctx, w)
+		body := NewMethodAsyncResponseBody(res)
+		w.WriteHeader(http.StatusAccepted)
+		return enc.Encode(body)
+	}
+}
+`
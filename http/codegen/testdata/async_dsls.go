@@ -0,0 +1,17 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var AsyncDSL = func() {
+	Service("ServiceAsync", func() {
+		Method("MethodAsync", func() {
+			Payload(String)
+			Async()
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
@@ -0,0 +1,125 @@
+package testdata
+
+import . "goa.design/goa/v3/dsl"
+
+var AsyncAPIDSL = func() {
+	var Author = Type("Author", func() {
+		Attribute("name", String)
+	})
+	var Message = Type("Message", func() {
+		Attribute("text", String, "Message text", func() {
+			Example("hello")
+		})
+		Attribute("author", Author)
+		Required("text")
+	})
+	var _ = API("chat", func() {
+		Title("Chat API")
+		Version("1.0")
+		Server("chat", func() {
+			Host("production", func() {
+				URI("https://chat.goa.design/api")
+			})
+		})
+	})
+	Service("chat", func() {
+		Method("listen", func() {
+			Description("Exchange messages in a room.")
+			Payload(func() {
+				Attribute("room", String, "Room name", func() {
+					Enum("general", "random")
+				})
+				Attribute("since", Int, "Start of history")
+				Attribute("token", String)
+				Required("room", "token")
+			})
+			StreamingPayload(Message)
+			StreamingResult(Message)
+			HTTP(func() {
+				GET("/rooms/{room}")
+				Param("since")
+				Header("token:Authorization")
+			})
+		})
+		Method("history", func() {
+			StreamingResult(Message)
+			HTTP(func() {
+				GET("/history")
+				StreamingResponse(NDJSON)
+			})
+		})
+		Method("post", func() {
+			Payload(Message)
+			HTTP(func() {
+				POST("/messages")
+			})
+		})
+	})
+}
+
+var AsyncAPIEventsDSL = func() {
+	var Order = Type("Order", func() {
+		Attribute("id", String, "Order ID")
+		Attribute("items", ArrayOf(String))
+		Required("id")
+	})
+	Service("orders", func() {
+		Method("create", func() {
+			Payload(Order)
+			Emits("order.created", Order, "Order was created")
+			HTTP(func() {
+				POST("/orders")
+			})
+		})
+		Method("update", func() {
+			Payload(Order)
+			Emits("order.created", Order)
+			Emits("order.updated", Order)
+			HTTP(func() {
+				PUT("/orders")
+			})
+		})
+	})
+}
+
+var AsyncAPIGRPCDSL = func() {
+	var Reading = Type("Reading", func() {
+		Attribute("sensor", String, "Sensor ID")
+		Attribute("value", Float64)
+		Required("sensor", "value")
+	})
+	var _ = API("telemetry", func() {
+		Title("Telemetry API")
+		Version("1.0")
+		Server("telemetry", func() {
+			Host("production", func() {
+				URI("https://telemetry.goa.design")
+				URI("grpcs://telemetry.goa.design:8443")
+			})
+		})
+	})
+	Service("telemetry", func() {
+		Method("record", func() {
+			StreamingPayload(Reading)
+			Result(func() {
+				Attribute("count", Int)
+			})
+			GRPC(func() {})
+		})
+		Method("watch", func() {
+			Payload(func() {
+				Attribute("sensor", String)
+			})
+			StreamingResult(Reading)
+			GRPC(func() {})
+		})
+		Method("relay", func() {
+			StreamingPayload(Reading)
+			StreamingResult(Reading)
+			GRPC(func() {})
+			HTTP(func() {
+				GET("/relay")
+			})
+		})
+	})
+}
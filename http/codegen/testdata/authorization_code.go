@@ -0,0 +1,78 @@
+package testdata
+
+const AuthorizationSecureHandlerCode = `// NewMethodSecureHandler creates a HTTP handler which loads the HTTP request
+// and calls the "ServiceAuthorization" service "MethodSecure" endpoint.
+func NewMethodSecureHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		decodeRequest  = DecodeMethodSecureRequest(mux, dec)
+		encodeResponse = EncodeMethodSecureResponse(enc)
+		encodeError    = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodSecure")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceAuthorization")
+		ctx = security.WithTransportMetadata(ctx, goahttp.RequestMetadata(r, "X-API-Key"))
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+
+		res, err := endpoint(ctx, payload)
+
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
+
+const AuthorizationPublicHandlerCode = `// NewMethodPublicHandler creates a HTTP handler which loads the HTTP request
+// and calls the "ServiceAuthorization" service "MethodPublic" endpoint.
+func NewMethodPublicHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		encodeResponse = EncodeMethodPublicResponse(enc)
+		encodeError    = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodPublic")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceAuthorization")
+		ctx = security.WithTransportMetadata(ctx, goahttp.RequestMetadata(r, "X-API-Key"))
+		var err error
+
+		res, err := endpoint(ctx, nil)
+
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
@@ -0,0 +1,28 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var AuthorizationDSL = func() {
+	var APIKeyAuth = APIKeySecurity("api_key")
+	Service("ServiceAuthorization", func() {
+		Meta("authorize")
+		Method("MethodSecure", func() {
+			Security(APIKeyAuth)
+			Payload(func() {
+				APIKey("api_key", "key", String)
+			})
+			HTTP(func() {
+				GET("/secure")
+				Header("key:X-API-Key")
+			})
+		})
+		Method("MethodPublic", func() {
+			NoSecurity()
+			HTTP(func() {
+				GET("/public")
+			})
+		})
+	})
+}
@@ -0,0 +1,117 @@
+package testdata
+
+var BenchmarkCode = `// newMethodBenchRequest returns a "ServiceBenchmark" service "Method" request
+// built from the design examples.
+func newMethodBenchRequest() *http.Request {
+	req := httptest.NewRequest("POST", "/abc?tags=a&tags=b", strings.NewReader("{\"name\":\"name\"}"))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Add("X-Token", "secret")
+	return req
+}
+
+// newMethodBenchResult returns a "ServiceBenchmark" service "Method" result
+// built from the design examples.
+func newMethodBenchResult(tb testing.TB) interface{} {
+	resp := &http.Response{
+		StatusCode: 201,
+		Header:     make(http.Header),
+		Body:       ioutil.NopCloser(strings.NewReader("{\"id\":\"abc\"}")),
+	}
+	resp.Header.Set("Content-Type", "application/json")
+	resp.Header.Add("X-Count", "3")
+	res, err := client.DecodeMethodResponse(goahttp.ResponseDecoder, false)(resp)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return servicebenchmark.NewViewedBenchResult(res.(*servicebenchmark.BenchResult), "default")
+}
+
+// newMethodBenchHandler returns a HTTP handler that serves the
+// "ServiceBenchmark" service "Method" requests using an endpoint that returns
+// the example result.
+func newMethodBenchHandler(tb testing.TB) http.Handler {
+	res := newMethodBenchResult(tb)
+	var (
+		endpoint = func(context.Context, interface{}) (interface{}, error) { return res, nil }
+		eh       = func(_ context.Context, _ http.ResponseWriter, err error) { tb.Error(err) }
+		mux      = goahttp.NewMuxer()
+	)
+	MountMethodHandler(mux, NewMethodHandler(endpoint, mux, goahttp.RequestDecoder, goahttp.PooledResponseEncoder, eh))
+	return mux
+}
+
+// BenchmarkMethodDecodeRequest measures the decoding of the "ServiceBenchmark"
+// service "Method" requests.
+func BenchmarkMethodDecodeRequest(b *testing.B) {
+	var (
+		mux    = goahttp.NewMuxer()
+		decode = DecodeMethodRequest(mux, goahttp.RequestDecoder)
+		err    error
+	)
+	mux.Handle("POST", "/{id}", func(w http.ResponseWriter, r *http.Request) {
+		_, err = decode(r)
+	})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		mux.ServeHTTP(httptest.NewRecorder(), newMethodBenchRequest())
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkMethodEncodeResponse measures the encoding of the
+// "ServiceBenchmark" service "Method" responses.
+func BenchmarkMethodEncodeResponse(b *testing.B) {
+	var (
+		res    = newMethodBenchResult(b)
+		encode = EncodeMethodResponse(goahttp.PooledResponseEncoder)
+		ctx    = context.Background()
+	)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := encode(ctx, httptest.NewRecorder(), res); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkMethodHandler measures the handling of the "ServiceBenchmark"
+// service "Method" requests including the decoding of the request and the
+// encoding of the response.
+func BenchmarkMethodHandler(b *testing.B) {
+	h := newMethodBenchHandler(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, newMethodBenchRequest())
+		if w.Code >= http.StatusBadRequest {
+			b.Fatalf("got status %d: %s", w.Code, w.Body.String())
+		}
+	}
+}
+`
+
+var AllocBudgetCode = `// TestMethodAllocBudget makes sure that handling a "ServiceBenchmark" service
+// "Method" request allocates at most 200 times. The allocations made to build
+// the request and to record the response are not counted.
+func TestMethodAllocBudget(t *testing.T) {
+	var (
+		h   = newMethodBenchHandler(t)
+		req *http.Request
+		w   *httptest.ResponseRecorder
+	)
+	base := testing.AllocsPerRun(100, func() {
+		req, w = newMethodBenchRequest(), httptest.NewRecorder()
+	})
+	allocs := testing.AllocsPerRun(100, func() {
+		req, w = newMethodBenchRequest(), httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+	}) - base
+	if allocs > 200 {
+		t.Errorf("got %v allocations per request, expected at most 200", allocs)
+	}
+}
+`
@@ -0,0 +1,60 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var BenchmarkDSL = func() {
+	var BenchResult = ResultType("application/vnd.bench.result", func() {
+		TypeName("BenchResult")
+		Attributes(func() {
+			Attribute("id", String, func() {
+				Example("abc")
+			})
+			Attribute("count", Int, func() {
+				Example(3)
+			})
+			Required("id")
+		})
+	})
+	API("API", func() {
+		HTTP(func() {
+			Benchmarks()
+		})
+	})
+	Service("ServiceBenchmark", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", String, func() {
+					Example("abc")
+				})
+				Attribute("tags", ArrayOf(String), func() {
+					Example([]string{"a", "b"})
+				})
+				Attribute("token", String, func() {
+					Example("secret")
+				})
+				Attribute("name", String, func() {
+					Example("name")
+				})
+				Required("id", "token")
+			})
+			Result(BenchResult)
+			HTTP(func() {
+				POST("/{id}")
+				Param("tags")
+				Header("token:X-Token")
+				Response(StatusCreated, func() {
+					Header("count:X-Count")
+				})
+				MaxAllocs(200)
+			})
+		})
+		Method("Stream", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/stream")
+			})
+		})
+	})
+}
@@ -0,0 +1,31 @@
+package testdata
+
+const CacheServerInitCode = `// New instantiates HTTP handlers for all the ServiceCache service endpoints.
+func New(
+	e *servicecache.Endpoints,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) *Server {
+	return &Server{
+		Mounts: []*MountPoint{
+			{"MethodCached", "GET", "/{id}"},
+			{"MethodNotCached", "POST", "/"},
+		},
+		MethodCached:    goahttp.CacheHeaders(NewMethodCachedHandler(e.MethodCached, mux, dec, enc, eh)),
+		MethodNotCached: NewMethodNotCachedHandler(e.MethodNotCached, mux, dec, enc, eh),
+	}
+}
+`
+
+const ClientResponseCacheCode = `// UseResponseCache caches the responses to the requests made to the
+// ServiceClientCache service endpoints that use the GET method in store. The
+// responses are cached per credentials and tenant as allowed by their
+// Cache-Control header, the stale responses that have an ETag are revalidated
+// with If-None-Match and decoded again when the server responds with 304 Not
+// Modified.
+func (c *Client) UseResponseCache(store goa.CacheStore) {
+	c.MethodShowDoer = goahttp.CachingDoer(c.MethodShowDoer, store, "X-API-Key", "X-Tenant-ID")
+}
+`
@@ -0,0 +1,58 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var CacheDSL = func() {
+	Service("ServiceCache", func() {
+		Method("MethodCached", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			Result(String)
+			Cache("5m", CacheKey("id"))
+			HTTP(func() {
+				GET("/{id}")
+			})
+		})
+		Method("MethodNotCached", func() {
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var ClientCacheDSL = func() {
+	API("ClientCacheAPI", func() {
+		Tenant()
+	})
+	var Key = APIKeySecurity("api_key")
+	Service("ServiceClientCache", func() {
+		Method("MethodShow", func() {
+			Security(Key)
+			Payload(func() {
+				APIKey("api_key", "key", String)
+				Attribute("id", String)
+			})
+			Result(String)
+			HTTP(func() {
+				GET("/{id}")
+				Header("key:X-API-Key")
+			})
+		})
+		Method("MethodCreate", func() {
+			Payload(String)
+			Result(String)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+		Method("MethodPing", func() {
+			HTTP(func() {
+				GET("/ping")
+			})
+		})
+	})
+}
@@ -0,0 +1,138 @@
+package testdata
+
+var ClientPolicyStructCode = `// Client lists the ServiceClientPolicy service endpoint HTTP clients.
+type Client struct {
+	// MethodShow Doer is the HTTP client used to make requests to the MethodShow
+	// endpoint.
+	MethodShowDoer goahttp.Doer
+
+	// MethodCreate Doer is the HTTP client used to make requests to the
+	// MethodCreate endpoint.
+	MethodCreateDoer goahttp.Doer
+
+	// RestoreResponseBody controls whether the response bodies are reset after
+	// decoding so they can be read again.
+	RestoreResponseBody bool
+
+	scheme             string
+	host               string
+	encoder            func(*http.Request) goahttp.Encoder
+	decoder            func(*http.Response) goahttp.Decoder
+	methodShowPolicy   *goahttp.ClientPolicy
+	methodCreatePolicy *goahttp.ClientPolicy
+}
+`
+
+var ClientPolicyOptionsCode = `// ClientOption customizes the Client created by NewClient.
+type ClientOption func(*Client)
+
+// WithMethodShowPolicy sets the policy applied to the requests made to the
+// MethodShow endpoint, it overrides the policy defined in the design.
+func WithMethodShowPolicy(p *goahttp.ClientPolicy) ClientOption {
+	return func(c *Client) {
+		c.methodShowPolicy = p
+	}
+}
+
+// WithMethodCreatePolicy sets the policy applied to the requests made to the
+// MethodCreate endpoint, it overrides the policy defined in the design.
+func WithMethodCreatePolicy(p *goahttp.ClientPolicy) ClientOption {
+	return func(c *Client) {
+		c.methodCreatePolicy = p
+	}
+}
+
+// WithCircuitBreaker sets the circuit breaker that guards the requests made to
+// the ServiceClientPolicy service endpoints.
+func WithCircuitBreaker(cb goahttp.CircuitBreaker) ClientOption {
+	return func(c *Client) {
+		c.methodShowPolicy = c.methodShowPolicy.WithBreaker(cb)
+		c.methodCreatePolicy = c.methodCreatePolicy.WithBreaker(cb)
+	}
+}
+`
+
+var ClientPolicyInitCode = `// NewClient instantiates HTTP clients for all the ServiceClientPolicy service
+// servers.
+func NewClient(
+	scheme string,
+	host string,
+	doer goahttp.Doer,
+	enc func(*http.Request) goahttp.Encoder,
+	dec func(*http.Response) goahttp.Decoder,
+	restoreBody bool,
+	opts ...ClientOption,
+) *Client {
+	c := &Client{
+		MethodShowDoer:      doer,
+		MethodCreateDoer:    doer,
+		RestoreResponseBody: restoreBody,
+		scheme:              scheme,
+		host:                host,
+		decoder:             dec,
+		encoder:             enc,
+		methodShowPolicy: &goahttp.ClientPolicy{
+			Timeout: 5 * time.Second,
+			Retry: &goahttp.RetryPolicy{
+				MaxAttempts:    5,
+				InitialBackoff: 200 * time.Millisecond,
+				MaxBackoff:     2 * time.Second,
+				StatusCodes:    []int{429, 503},
+			},
+		},
+		methodCreatePolicy: &goahttp.ClientPolicy{
+			Timeout: 90 * time.Second,
+		},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+`
+
+var ClientPolicyEndpointInitCode = `// MethodShow returns an endpoint that makes HTTP requests to the
+// ServiceClientPolicy service MethodShow server.
+func (c *Client) MethodShow() goa.Endpoint {
+	var (
+		decodeResponse = DecodeMethodShowResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		req, err := c.BuildMethodShowRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := goahttp.PolicyDoer(c.MethodShowDoer, c.methodShowPolicy).Do(req)
+
+		if err != nil {
+			return nil, goahttp.ErrRequestError("ServiceClientPolicy", "MethodShow", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// MethodCreate returns an endpoint that makes HTTP requests to the
+// ServiceClientPolicy service MethodCreate server.
+func (c *Client) MethodCreate() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeMethodCreateRequest(c.encoder)
+		decodeResponse = DecodeMethodCreateResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		req, err := c.BuildMethodCreateRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := goahttp.PolicyDoer(c.MethodCreateDoer, c.methodCreatePolicy).Do(req)
+
+		if err != nil {
+			return nil, goahttp.ErrRequestError("ServiceClientPolicy", "MethodCreate", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+`
@@ -0,0 +1,32 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ClientPolicyDSL = func() {
+	Service("ServiceClientPolicy", func() {
+		HTTP(func() {
+			ClientTimeout("5s")
+			ClientRetry(3)
+		})
+		Method("MethodShow", func() {
+			Payload(String)
+			Result(String)
+			HTTP(func() {
+				GET("/{p}")
+				ClientRetry(5, func() {
+					Backoff("200ms", "2s")
+					RetryOn(StatusTooManyRequests, StatusServiceUnavailable)
+				})
+			})
+		})
+		Method("MethodCreate", func() {
+			Payload(String)
+			HTTP(func() {
+				POST("/")
+				ClientTimeout("1m30s")
+			})
+		})
+	})
+}
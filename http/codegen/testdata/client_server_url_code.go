@@ -0,0 +1,25 @@
+package testdata
+
+var ClientServerURLCode = `// CellarProductionURL returns the URL of the "production" host of the "cellar"
+// server built with the given values of the URL variables. It returns an error
+// if a value is not allowed by the variable enum.
+//
+// Regional production hosts.
+func CellarProductionURL(region string, port int) (string, error) {
+	u := "https://{region}.example.com:{port}/cellar"
+	{
+		v := fmt.Sprintf("%v", region)
+		switch v {
+		case "us", "eu":
+		default:
+			return "", fmt.Errorf("invalid value for URL variable %q: %q (valid values: us, eu)", "region", v)
+		}
+		u = strings.Replace(u, "{region}", v, -1)
+	}
+	{
+		v := fmt.Sprintf("%v", port)
+		u = strings.Replace(u, "{port}", v, -1)
+	}
+	return u, nil
+}
+`
@@ -0,0 +1,34 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ClientServerURLDSL = func() {
+	API("cellar", func() {
+		Server("cellar", func() {
+			Services("ServiceServerURL")
+			Host("production", func() {
+				Description("Regional production hosts.")
+				URI("https://{region}.example.com:{port}/cellar")
+				URI("grpcs://{region}.example.com:8443")
+				Variable("region", String, "Deployment region", func() {
+					Enum("us", "eu")
+				})
+				Variable("port", Int, "Port", func() {
+					Default(443)
+				})
+			})
+			Host("local", func() {
+				URI("http://localhost:8080")
+			})
+		})
+	})
+	Service("ServiceServerURL", func() {
+		Method("MethodServerURL", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
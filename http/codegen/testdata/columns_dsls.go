@@ -0,0 +1,59 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ColumnsDSL = func() {
+	var Bottle = ResultType("application/vnd.columns.bottle", func() {
+		TypeName("ColumnsBottle")
+		Attributes(func() {
+			Attribute("id", String)
+			Attribute("name", String)
+			Attribute("vintage_year", Int)
+			Attribute("rating", Float64)
+		})
+		View("default", func() {
+			Attribute("id")
+			Attribute("name")
+			Attribute("vintage_year")
+		})
+		View("tiny", func() {
+			Attribute("id")
+			Attribute("name")
+		})
+	})
+	Service("ServiceColumns", func() {
+		Method("List", func() {
+			Result(CollectionOf(Bottle), func() {
+				View("tiny")
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+		Method("Show", func() {
+			Payload(String)
+			Result(Bottle)
+			HTTP(func() {
+				GET("/{id}")
+			})
+		})
+		Method("Rate", func() {
+			Payload(String)
+			Result(func() {
+				Attribute("bottle_id", String)
+				Attribute("average", Float64)
+			})
+			HTTP(func() {
+				POST("/{id}/rate")
+			})
+		})
+		Method("Count", func() {
+			Result(Int)
+			HTTP(func() {
+				GET("/count")
+			})
+		})
+	})
+}
@@ -0,0 +1,36 @@
+package testdata
+
+var CompressAPIHandlerCode = `// MountMethodHandler configures the mux to serve the "ServiceCompressAPI"
+// service "Method" endpoint.
+func MountMethodHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	f = goahttp.CompressHandler(f, &goahttp.CompressOptions{
+		Encodings: []string{"gzip"},
+		MinSize:   1024,
+	})
+	mux.Handle("GET", "/", f)
+}
+`
+
+var CompressEndpointHandlerCode = `// MountMethodHandler configures the mux to serve the "ServiceCompressEndpoint"
+// service "Method" endpoint.
+func MountMethodHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	f = goahttp.CompressHandler(f, &goahttp.CompressOptions{
+		Encodings:    []string{"br", "gzip"},
+		ContentTypes: []string{"application/json", "text/*"},
+		MinSize:      4096,
+	})
+	mux.Handle("GET", "/", f)
+}
+`
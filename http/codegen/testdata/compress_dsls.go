@@ -0,0 +1,44 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var CompressAPIDSL = func() {
+	API("API", func() {
+		HTTP(func() {
+			Compress()
+		})
+	})
+	Service("ServiceCompressAPI", func() {
+		Method("Method", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var CompressEndpointDSL = func() {
+	API("API", func() {
+		HTTP(func() {
+			Compress()
+		})
+	})
+	Service("ServiceCompressEndpoint", func() {
+		HTTP(func() {
+			Compress("deflate")
+		})
+		Method("Method", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/")
+				Compress("br", "gzip", func() {
+					CompressTypes("application/json", "text/*")
+					CompressMinSize("4KB")
+				})
+			})
+		})
+	})
+}
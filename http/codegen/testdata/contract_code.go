@@ -0,0 +1,140 @@
+package testdata
+
+const ContractCode = `// TestContractShow verifies that the server implements the contract of the
+// "Show" method.
+func TestContractShow(t *testing.T) {
+	verifyContract(t, []*contractCase{
+		{
+			Name:   "valid",
+			Method: "GET",
+			URL:    "/3?view=tiny",
+			Decode: DecodeShowResponse(goahttp.ResponseDecoder, false),
+		},
+		{
+			Name:   "invalid type path id",
+			Method: "GET",
+			URL:    "/invalid?view=tiny",
+			Status: 400,
+		},
+		{
+			Name:   "invalid enum query view",
+			Method: "GET",
+			URL:    "/3?view=invalid",
+			Status: 400,
+		},
+	})
+}
+
+// TestContractCreate verifies that the server implements the contract of the
+// "Create" method.
+func TestContractCreate(t *testing.T) {
+	auth := []*contractAuth{
+		{In: "basic", Env: "CONTRACT_BASIC", Username: "user", Password: "pass"},
+	}
+	verifyContract(t, []*contractCase{
+		{
+			Name:   "valid",
+			Method: "POST",
+			URL:    "/",
+			Body:   "{\"count\":2,\"name\":\"abc\"}",
+			Auth:   auth,
+			Decode: DecodeCreateResponse(goahttp.ResponseDecoder, false),
+		},
+		{
+			Name:   "malformed body",
+			Method: "POST",
+			URL:    "/",
+			Body:   "{",
+			Auth:   auth,
+			Status: 400,
+		},
+		{
+			Name:   "missing body name",
+			Method: "POST",
+			URL:    "/",
+			Body:   "{\"count\":2}",
+			Auth:   auth,
+			Status: 400,
+		},
+		{
+			Name:   "invalid type body name",
+			Method: "POST",
+			URL:    "/",
+			Body:   "{\"count\":2,\"name\":42}",
+			Auth:   auth,
+			Status: 400,
+		},
+		{
+			Name:   "above max length body name",
+			Method: "POST",
+			URL:    "/",
+			Body:   "{\"count\":2,\"name\":\"aaaa\"}",
+			Auth:   auth,
+			Status: 400,
+		},
+		{
+			Name:   "max length body name",
+			Method: "POST",
+			URL:    "/",
+			Body:   "{\"count\":2,\"name\":\"aaa\"}",
+			Auth:   auth,
+			Decode: DecodeCreateResponse(goahttp.ResponseDecoder, false),
+		},
+		{
+			Name:   "invalid type body count",
+			Method: "POST",
+			URL:    "/",
+			Body:   "{\"count\":\"invalid\",\"name\":\"abc\"}",
+			Auth:   auth,
+			Status: 400,
+		},
+		{
+			Name:   "below minimum body count",
+			Method: "POST",
+			URL:    "/",
+			Body:   "{\"count\":0,\"name\":\"abc\"}",
+			Auth:   auth,
+			Status: 400,
+		},
+		{
+			Name:   "minimum body count",
+			Method: "POST",
+			URL:    "/",
+			Body:   "{\"count\":1,\"name\":\"abc\"}",
+			Auth:   auth,
+			Decode: DecodeCreateResponse(goahttp.ResponseDecoder, false),
+		},
+	})
+}
+
+// TestContractSecret verifies that the server implements the contract of the
+// "Secret" method.
+func TestContractSecret(t *testing.T) {
+	auth := []*contractAuth{
+		{In: "header", Name: "Authorization", Env: "CONTRACT_JWT_TOKEN"},
+	}
+	verifyContract(t, []*contractCase{
+		{
+			Name:   "valid",
+			Method: "GET",
+			URL:    "/secret",
+			Header: http.Header{
+				"X-Trace":       {"trace"},
+				"Authorization": {"token"},
+			},
+			Auth:   auth,
+			Decode: DecodeSecretResponse(goahttp.ResponseDecoder, false),
+		},
+		{
+			Name:   "missing header X-Trace",
+			Method: "GET",
+			URL:    "/secret",
+			Header: http.Header{
+				"Authorization": {"token"},
+			},
+			Auth:   auth,
+			Status: 400,
+		},
+	})
+}
+`
@@ -0,0 +1,77 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ContractDSL = func() {
+	API("contract", func() {
+		Meta("contract:test")
+	})
+	var BasicAuth = BasicAuthSecurity("basic")
+	var JWTAuth = JWTSecurity("jwt")
+	Service("ServiceContract", func() {
+		Method("Show", func() {
+			Payload(func() {
+				Attribute("id", Int, func() {
+					Example(3)
+				})
+				Attribute("view", String, func() {
+					Enum("default", "tiny")
+					Example("tiny")
+				})
+				Required("id")
+			})
+			Result(String)
+			HTTP(func() {
+				GET("/{id}")
+				Param("view")
+			})
+		})
+		Method("Create", func() {
+			Security(BasicAuth)
+			Payload(func() {
+				Username("user", String, func() {
+					Example("user")
+				})
+				Password("pass", String, func() {
+					Example("pass")
+				})
+				Attribute("name", String, func() {
+					MaxLength(3)
+					Example("abc")
+				})
+				Attribute("count", Int, func() {
+					Minimum(1)
+					Example(2)
+				})
+				Required("name")
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+		Method("Secret", func() {
+			Security(JWTAuth)
+			Payload(func() {
+				Token("token", String, func() {
+					Example("token")
+				})
+				Attribute("trace", String, func() {
+					Example("trace")
+				})
+				Required("token", "trace")
+			})
+			HTTP(func() {
+				GET("/secret")
+				Header("trace:X-Trace")
+			})
+		})
+		Method("Stream", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/stream")
+			})
+		})
+	})
+}
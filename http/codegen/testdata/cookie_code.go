@@ -0,0 +1,228 @@
+package testdata
+
+var CookiesRequestDecoderCode = `// DecodeLoginRequest returns a decoder for requests sent to the CookiesService
+// Login endpoint.
+func DecodeLoginRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			body LoginRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+
+		var (
+			locale *string
+			visits int
+		)
+		{
+			localeRaw := goahttp.ReadCookie(r, "locale")
+			if localeRaw != "" {
+				locale = &localeRaw
+			}
+		}
+		{
+			visitsRaw := goahttp.ReadCookie(r, "v")
+			if visitsRaw == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError("v", "cookie"))
+			}
+			if visitsRaw != "" {
+				v, err2 := strconv.ParseInt(visitsRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("visits", visitsRaw, "integer"))
+				}
+				visits = int(v)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewLoginPayload(&body, locale, visits)
+
+		return payload, nil
+	}
+}
+`
+
+var CookiesResponseEncoderCode = `// EncodeLoginResponse returns an encoder for responses returned by the
+// CookiesService Login endpoint.
+func EncodeLoginResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res := v.(*cookiesservice.LoginResult)
+		enc := encoder(ctx, w)
+		body := NewLoginResponseBody(res)
+		cookieOpts := &goahttp.CookieOptions{
+			MaxAge:      3600,
+			Path:        "/",
+			Secure:      true,
+			HTTPOnly:    true,
+			SameSite:    "None",
+			Partitioned: true,
+		}
+		{
+			val := res.Visits
+			cookieVal := strconv.Itoa(val)
+			if err := goahttp.SetCookie(ctx, w, "v", cookieVal, cookieOpts); err != nil {
+				return err
+			}
+		}
+		if res.Premium != nil {
+			val := res.Premium
+			cookieVal := strconv.FormatBool(*val)
+			if err := goahttp.SetCookie(ctx, w, "premium", cookieVal, cookieOpts); err != nil {
+				return err
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+`
+
+var CookiesRequestEncoderCode = `// EncodeLoginRequest returns an encoder for requests sent to the
+// CookiesService Login server.
+func EncodeLoginRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
+	return func(req *http.Request, v interface{}) error {
+		p, ok := v.(*cookiesservice.LoginPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("CookiesService", "Login", "*cookiesservice.LoginPayload", v)
+		}
+		if p.Locale != nil {
+			req.AddCookie(&http.Cookie{
+				Name:  "locale",
+				Value: *p.Locale,
+			})
+		}
+		req.AddCookie(&http.Cookie{
+			Name:  "v",
+			Value: fmt.Sprintf("%v", p.Visits),
+		})
+		body := NewLoginRequestBody(p)
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("CookiesService", "Login", err)
+		}
+		return nil
+	}
+}
+`
+
+var CookiesResponseDecoderCode = `// DecodeLoginResponse returns a decoder for responses returned by the
+// CookiesService Login endpoint. restoreBody controls whether the response
+// body should be restored after having been read.
+func DecodeLoginResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (interface{}, error) {
+	return func(resp *http.Response) (interface{}, error) {
+		if restoreBody {
+			b, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body LoginResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("CookiesService", "Login", err)
+			}
+			var (
+				visits  int
+				premium *bool
+			)
+			{
+				visitsRaw := goahttp.ResponseCookie(resp, "v")
+				if visitsRaw == "" {
+					err = goa.MergeErrors(err, goa.MissingFieldError("v", "cookie"))
+				}
+				if visitsRaw != "" {
+					v, err2 := strconv.ParseInt(visitsRaw, 10, strconv.IntSize)
+					if err2 != nil {
+						err = goa.MergeErrors(err, goa.InvalidFieldTypeError("visits", visitsRaw, "integer"))
+					}
+					visits = int(v)
+				}
+			}
+			{
+				premiumRaw := goahttp.ResponseCookie(resp, "premium")
+				if premiumRaw != "" {
+					v, err2 := strconv.ParseBool(premiumRaw)
+					if err2 != nil {
+						err = goa.MergeErrors(err, goa.InvalidFieldTypeError("premium", premiumRaw, "boolean"))
+					}
+					premium = &v
+				}
+			}
+			if err != nil {
+				return nil, goahttp.ErrValidationError("CookiesService", "Login", err)
+			}
+			res := NewLoginResultOK(&body, visits, premium)
+			return res, nil
+		default:
+			body, _ := ioutil.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("CookiesService", "Login", resp.StatusCode, string(body))
+		}
+	}
+}
+`
+
+var SignedCookiesRequestDecoderCode = `// DecodeSessionRequest returns a decoder for requests sent to the
+// SignedCookiesService Session endpoint.
+func DecodeSessionRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			sid string
+			err error
+		)
+		{
+			sidRaw, err2 := goahttp.ReadSignedCookie(r, "SID")
+			if err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			} else if sidRaw == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError("SID", "cookie"))
+			}
+			sid = sidRaw
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewSessionPayload(sid)
+
+		return payload, nil
+	}
+}
+`
+
+var SignedCookiesResponseEncoderCode = `// EncodeSessionResponse returns an encoder for responses returned by the
+// SignedCookiesService Session endpoint.
+func EncodeSessionResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res := v.(*signedcookiesservice.SessionResult)
+		enc := encoder(ctx, w)
+		body := NewSessionResponseBody(res)
+		cookieOpts := &goahttp.CookieOptions{
+			Signed: true,
+		}
+		if res.Sid != nil {
+			val := *res.Sid
+			if err := goahttp.SetCookie(ctx, w, "SID", val, cookieOpts); err != nil {
+				return err
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+`
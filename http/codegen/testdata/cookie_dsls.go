@@ -0,0 +1,63 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var CookiesDSL = func() {
+	Service("CookiesService", func() {
+		Method("Login", func() {
+			Payload(func() {
+				Attribute("user", String)
+				Attribute("locale", String)
+				Attribute("visits", Int)
+				Required("visits")
+			})
+			Result(func() {
+				Attribute("name", String)
+				Attribute("visits", Int)
+				Attribute("premium", Boolean)
+				Required("visits")
+			})
+			HTTP(func() {
+				POST("/login")
+				Cookie("locale")
+				Cookie("visits:v")
+				Response(StatusOK, func() {
+					Cookie("visits:v")
+					Cookie("premium")
+					CookieMaxAge(3600)
+					CookiePath("/")
+					CookieSecure()
+					CookieHTTPOnly()
+					CookieSameSite(CookieSameSiteNone)
+					CookiePartitioned()
+				})
+			})
+		})
+	})
+}
+
+var SignedCookiesDSL = func() {
+	Service("SignedCookiesService", func() {
+		Method("Session", func() {
+			Payload(func() {
+				Attribute("sid", String)
+				Required("sid")
+			})
+			Result(func() {
+				Attribute("sid", String)
+				Attribute("user", String)
+			})
+			HTTP(func() {
+				GET("/session")
+				Cookie("sid:SID")
+				CookieSigned()
+				Response(StatusOK, func() {
+					Cookie("sid:SID")
+					CookieSigned()
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,91 @@
+package testdata
+
+var CORSAPIOriginMountCode = `// Mount configures the mux to serve the ServiceCORS endpoints.
+func Mount(mux goahttp.Muxer, h *Server) {
+	MountMethodGetHandler(mux, h.MethodGet)
+	MountMethodPostHandler(mux, h.MethodPost)
+	MountCORSHandler(mux)
+}
+`
+
+var CORSAPIOriginCode = `// MountCORSHandler configures the mux to serve the CORS preflight requests
+// made to the ServiceCORS endpoints.
+func MountCORSHandler(mux goahttp.Muxer) {
+	mux.Handle("OPTIONS", "/items", goahttp.CORSPreflightHandler(methodGetCORSPolicies, methodPostCORSPolicies))
+}
+
+var (
+	// methodGetCORSPolicies lists the CORS policies of the "ServiceCORS" service
+	// "MethodGet" endpoint.
+	methodGetCORSPolicies = []*goahttp.CORSPolicy{
+		{
+			Origin:  "*",
+			Methods: []string{"GET"},
+			MaxAge:  600,
+		},
+	}
+	// methodPostCORSPolicies lists the CORS policies of the "ServiceCORS" service
+	// "MethodPost" endpoint.
+	methodPostCORSPolicies = []*goahttp.CORSPolicy{
+		{
+			Origin:  "*",
+			Methods: []string{"POST"},
+			MaxAge:  600,
+		},
+	}
+)
+`
+
+var CORSEndpointOriginMountCode = `// Mount configures the mux to serve the ServiceCORS endpoints.
+func Mount(mux goahttp.Muxer, h *Server) {
+	MountMethodShowHandler(mux, h.MethodShow)
+	MountMethodListHandler(mux, h.MethodList)
+	MountCORSHandler(mux)
+}
+`
+
+var CORSEndpointOriginCode = `// MountCORSHandler configures the mux to serve the CORS preflight requests
+// made to the ServiceCORS endpoints.
+func MountCORSHandler(mux goahttp.Muxer) {
+	mux.Handle("OPTIONS", "/items/{id}", goahttp.CORSPreflightHandler(methodShowCORSPolicies))
+	mux.Handle("OPTIONS", "/items", goahttp.CORSPreflightHandler(methodListCORSPolicies))
+}
+
+var (
+	// methodShowCORSPolicies lists the CORS policies of the "ServiceCORS" service
+	// "MethodShow" endpoint.
+	methodShowCORSPolicies = []*goahttp.CORSPolicy{
+		{
+			Origin:         "/.*localhost.*/",
+			OriginRegexp:   regexp.MustCompile(".*localhost.*"),
+			Methods:        []string{"GET", "DELETE"},
+			Headers:        []string{"X-Shared-Secret"},
+			Expose:         []string{"X-Time"},
+			Credentials:    true,
+			PrivateNetwork: true,
+		},
+	}
+	// methodListCORSPolicies lists the CORS policies of the "ServiceCORS" service
+	// "MethodList" endpoint.
+	methodListCORSPolicies = []*goahttp.CORSPolicy{
+		{
+			Origin:  "https://goa.design",
+			Methods: []string{"GET"},
+		},
+	}
+)
+`
+
+var CORSEndpointOriginHandlerCode = `// MountMethodShowHandler configures the mux to serve the "ServiceCORS" service
+// "MethodShow" endpoint.
+func MountMethodShowHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	f = goahttp.CORSHandler(f, methodShowCORSPolicies)
+	mux.Handle("GET", "/items/{id}", f)
+}
+`
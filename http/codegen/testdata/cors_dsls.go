@@ -0,0 +1,55 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var CORSAPIOriginDSL = func() {
+	API("test", func() {
+		HTTP(func() {
+			Origin("*", func() {
+				MaxAge(600)
+			})
+		})
+	})
+	Service("ServiceCORS", func() {
+		Method("MethodGet", func() {
+			HTTP(func() {
+				GET("/items")
+			})
+		})
+		Method("MethodPost", func() {
+			HTTP(func() {
+				POST("/items")
+			})
+		})
+	})
+}
+
+var CORSEndpointOriginDSL = func() {
+	Service("ServiceCORS", func() {
+		HTTP(func() {
+			Origin("https://goa.design")
+		})
+		Method("MethodShow", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				GET("/items/{id}")
+				Origin("/.*localhost.*/", func() {
+					AllowMethods("GET", "DELETE")
+					AllowHeaders("X-Shared-Secret")
+					ExposeHeaders("X-Time")
+					AllowCredentials()
+					AllowPrivateNetwork()
+				})
+			})
+		})
+		Method("MethodList", func() {
+			HTTP(func() {
+				GET("/items")
+			})
+		})
+	})
+}
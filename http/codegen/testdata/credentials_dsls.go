@@ -0,0 +1,43 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var CredentialsDSL = func() {
+	var Basic = BasicAuthSecurity("basic")
+	var Key = APIKeySecurity("api_key")
+	var OAuth2 = OAuth2Security("oauth2", func() {
+		ClientCredentialsFlow("/oauth/token", "")
+		Scope("api:read", "Read access")
+	})
+	Service("ServiceCredentials", func() {
+		Method("Login", func() {
+			Security(Basic)
+			Payload(func() {
+				Username("user", String)
+				Password("pass", String)
+				Required("user", "pass")
+			})
+			HTTP(func() {
+				POST("/login")
+			})
+		})
+		Method("Show", func() {
+			Security(Key)
+			Security(OAuth2, func() {
+				Scope("api:read")
+			})
+			Payload(func() {
+				APIKey("api_key", "key", String)
+				AccessToken("token", String)
+				Attribute("id", String)
+				Required("id")
+			})
+			HTTP(func() {
+				GET("/{id}")
+				Header("key:X-API-Key")
+			})
+		})
+	})
+}
@@ -0,0 +1,26 @@
+package testdata
+
+var CSRFServerProtectCode = `// ProtectCSRF wraps the handlers of the endpoints that authenticate requests
+// with cookies with a middleware that protects them against Cross-Site Request
+// Forgery. secret is the key used to derive the CSRF tokens from the session
+// cookies, ProtectCSRF returns goahttp.ErrCSRFSecretMissing if it is empty. It
+// must be called before the handlers are mounted.
+func (s *Server) ProtectCSRF(secret []byte) error {
+	if len(secret) == 0 {
+		return goahttp.ErrCSRFSecretMissing
+	}
+	m := httpmdlwr.ProtectCSRF(&goahttp.CSRFProtector{
+		SessionCookie: "SID",
+		Secret:        secret,
+	})
+	s.Show = m(s.Show)
+	s.Update = m(s.Update)
+	m = httpmdlwr.ProtectCSRF(&goahttp.CSRFProtector{
+		SessionCookie: "SID",
+		Header:        "X-XSRF-Token",
+		Cookie:        "XSRF-TOKEN",
+	})
+	s.Upload = m(s.Upload)
+	return nil
+}
+`
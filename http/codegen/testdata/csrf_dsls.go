@@ -0,0 +1,65 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var CSRFDSL = func() {
+	var JWTAuth = JWTSecurity("jwt")
+	Service("ServiceCSRF", func() {
+		Security(JWTAuth)
+		HTTP(func() {
+			CSRF(CSRFSynchronizer)
+		})
+		Method("Show", func() {
+			Payload(func() {
+				Token("token", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Cookie("token:SID")
+			})
+		})
+		Method("Update", func() {
+			Payload(func() {
+				Token("token", String)
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				PUT("/")
+				Cookie("token:SID")
+			})
+		})
+		Method("Upload", func() {
+			Payload(func() {
+				Token("token", String)
+			})
+			HTTP(func() {
+				POST("/upload")
+				Cookie("token:SID")
+				CSRF(CSRFDoubleSubmit, func() {
+					CSRFHeader("X-XSRF-Token")
+					CSRFCookie("XSRF-TOKEN")
+				})
+			})
+		})
+		Method("Logout", func() {
+			Payload(func() {
+				Token("token", String)
+			})
+			HTTP(func() {
+				POST("/logout")
+				Cookie("token:SID")
+				CSRF(CSRFDisabled)
+			})
+		})
+		Method("Bearer", func() {
+			Payload(func() {
+				Token("token", String)
+			})
+			HTTP(func() {
+				POST("/bearer")
+			})
+		})
+	})
+}
@@ -0,0 +1,31 @@
+package testdata
+
+import . "goa.design/goa/v3/dsl"
+
+var DocsDSL = func() {
+	var _ = API("test", func() {
+		Title("Test <`API`>")
+		Meta("openapi:docs")
+	})
+	Service("svc", func() {
+		Method("m", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var DocsV3DSL = func() {
+	var _ = API("test", func() {
+		Meta("openapi:docs")
+		Meta("openapi:version", "3.1")
+	})
+	Service("svc", func() {
+		Method("m", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
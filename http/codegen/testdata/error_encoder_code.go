@@ -86,3 +86,85 @@ func EncodeMethodServiceErrorResponseError(encoder func(context.Context, http.Re
 	}
 }
 `
+
+var RateLimitedErrorResponseEncoderCode = `// EncodeMethodRateLimitedErrorResponseError returns an encoder for errors
+// returned by the MethodRateLimitedErrorResponse
+// ServiceRateLimitedErrorResponse endpoint.
+func EncodeMethodRateLimitedErrorResponseError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		en, ok := v.(ErrorNamer)
+		if !ok {
+			return encodeError(ctx, w, v)
+		}
+		switch en.ErrorName() {
+		case "rate_limited":
+			res := v.(*serviceratelimitederrorresponse.RateLimited)
+			enc := encoder(ctx, w)
+			body := NewMethodRateLimitedErrorResponseRateLimitedResponseBody(res)
+			{
+				val := res.RetryIn
+				retryIns := strconv.Itoa(val)
+				w.Header().Set("Retry-After", retryIns)
+			}
+			{
+				val := res.Limit
+				limits := strconv.Itoa(val)
+				w.Header().Set("Ratelimit-Limit", limits)
+			}
+			if res.Remaining != nil {
+				val := res.Remaining
+				remainings := strconv.Itoa(*val)
+				w.Header().Set("Ratelimit-Remaining", remainings)
+			}
+			if res.Reset != nil {
+				val := res.Reset
+				resets := strconv.FormatInt(*val, 10)
+				w.Header().Set("Ratelimit-Reset", resets)
+			}
+			w.Header().Set("goa-error", "rate_limited")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+`
+
+var SecuredErrorResponseEncoderCode = `// EncodeMethodSecuredErrorResponseError returns an encoder for errors returned
+// by the MethodSecuredErrorResponse ServiceSecuredErrorResponse endpoint.
+func EncodeMethodSecuredErrorResponseError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		if aerr, ok := v.(*security.AuthError); ok {
+			// Encode the error returned by the last authorization function if it is
+			// described in the design.
+			if en, ok := aerr.Last().(ErrorNamer); ok {
+				switch en.ErrorName() {
+				case "unauthorized":
+					for _, c := range goahttp.AuthChallenges(aerr) {
+						w.Header().Add("WWW-Authenticate", c)
+					}
+					v = aerr.Last()
+				}
+			}
+		}
+		en, ok := v.(ErrorNamer)
+		if !ok {
+			return encodeError(ctx, w, v)
+		}
+		switch en.ErrorName() {
+		case "unauthorized":
+			res := v.(servicesecurederrorresponse.Unauthorized)
+			enc := encoder(ctx, w)
+			body := NewMethodSecuredErrorResponseUnauthorizedResponseBody(res)
+			w.Header().Set("goa-error", "unauthorized")
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+`
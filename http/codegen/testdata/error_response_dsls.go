@@ -45,3 +45,51 @@ var ServiceErrorResponseDSL = func() {
 		})
 	})
 }
+
+var RateLimitedErrorResponseDSL = func() {
+	var RateLimited = Type("RateLimited", func() {
+		Attribute("message", String)
+		Attribute("retry_in", Int)
+		Attribute("limit", Int)
+		Attribute("remaining", Int)
+		Attribute("reset", Int64)
+		Required("message", "retry_in", "limit")
+	})
+	Service("ServiceRateLimitedErrorResponse", func() {
+		Method("MethodRateLimitedErrorResponse", func() {
+			Error("rate_limited", RateLimited)
+			HTTP(func() {
+				GET("/one/two")
+				Response("rate_limited", StatusTooManyRequests, func() {
+					RetryAfter("retry_in")
+					RateLimit("limit", "remaining", "reset")
+				})
+			})
+		})
+	})
+}
+
+var SecuredErrorResponseDSL = func() {
+	var JWT = JWTSecurity("jwt", func() {
+		Scope("api:read")
+	})
+	var Key = APIKeySecurity("api_key")
+	Service("ServiceSecuredErrorResponse", func() {
+		Method("MethodSecuredErrorResponse", func() {
+			Security(JWT, func() {
+				Scope("api:read")
+			})
+			Security(Key)
+			Payload(func() {
+				Token("token", String)
+				APIKey("api_key", "key", String)
+			})
+			Error("unauthorized", String)
+			HTTP(func() {
+				GET("/one/two")
+				Header("key:X-API-Key")
+				Response("unauthorized", StatusUnauthorized)
+			})
+		})
+	})
+}
@@ -3,7 +3,7 @@ package testdata
 const (
 	NoServerServerHandleCode = `// handleHTTPServer starts configures and starts a HTTP server on the given
 // URL. It shuts down the server if any error is received in the error channel.
-func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service.Endpoints, wg *sync.WaitGroup, errc chan error, drainer *goa.Drainer, reporter goa.PanicReporter, logger *log.Logger, debug bool) {
 
 	// Setup goa log adapter.
 	var (
@@ -19,7 +19,7 @@ func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 	// see goa.design/implement/encoding.
 	var (
 		dec = goahttp.RequestDecoder
-		enc = goahttp.ResponseEncoder
+		enc = goahttp.PooledResponseEncoder
 	)
 
 	// Build the service HTTP request multiplexer and configure it to serve
@@ -42,21 +42,24 @@ func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 	}
 	// Configure the mux.
 	servicesvr.Mount(mux, serviceServer)
+	mux.Handle("GET", "/readyz", goahttp.ReadyHandler(drainer))
 
 	// Wrap the multiplexer with additional middlewares. Middlewares mounted
 	// here apply to all the service endpoints.
 	var handler http.Handler = mux
 	{
+		handler = httpmdlwr.Recover(reporter, enc)(handler)
 		if debug {
 			handler = httpmdlwr.Debug(mux, os.Stdout)(handler)
 		}
 		handler = httpmdlwr.Log(adapter)(handler)
 		handler = httpmdlwr.RequestID()(handler)
+		handler = goahttp.DrainStreams(drainer)(handler)
 	}
 
 	// Start HTTP server using default configuration, change the code to
 	// configure the server as required by your service.
-	srv := &http.Server{Addr: u.Host, Handler: handler}
+	srv := &http.Server{Addr: u.Host, Handler: handler, ReadHeaderTimeout: 60 * time.Second}
 	for _, m := range serviceServer.Mounts {
 		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
 	}
@@ -74,11 +77,19 @@ func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 		<-ctx.Done()
 		logger.Printf("shutting down HTTP server at %q", u.Host)
 
-		// Shutdown gracefully with a 30s timeout.
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		// Report the server as not ready, stop accepting new connections and wait for
+		// the in-flight requests and the websocket streams to complete within the
+		// grace period.
+		drainer.Drain()
+		ctx, cancel := context.WithTimeout(context.Background(), drainer.Grace)
 		defer cancel()
 
-		srv.Shutdown(ctx)
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Printf("failed to shutdown HTTP server: %v", err)
+		}
+		if err := drainer.Wait(ctx); err != nil {
+			logger.Printf("failed to drain HTTP streams: %v", err)
+		}
 	}()
 }
 
@@ -96,7 +107,7 @@ func errorHandler(logger *log.Logger) func(context.Context, http.ResponseWriter,
 
 	ServerHostingServiceWithFileServerHandlerCode = `// handleHTTPServer starts configures and starts a HTTP server on the given
 // URL. It shuts down the server if any error is received in the error channel.
-func handleHTTPServer(ctx context.Context, u *url.URL, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+func handleHTTPServer(ctx context.Context, u *url.URL, wg *sync.WaitGroup, errc chan error, drainer *goa.Drainer, reporter goa.PanicReporter, logger *log.Logger, debug bool) {
 
 	// Setup goa log adapter.
 	var (
@@ -112,7 +123,7 @@ func handleHTTPServer(ctx context.Context, u *url.URL, wg *sync.WaitGroup, errc
 	// see goa.design/implement/encoding.
 	var (
 		dec = goahttp.RequestDecoder
-		enc = goahttp.ResponseEncoder
+		enc = goahttp.PooledResponseEncoder
 	)
 
 	// Build the service HTTP request multiplexer and configure it to serve
@@ -135,21 +146,24 @@ func handleHTTPServer(ctx context.Context, u *url.URL, wg *sync.WaitGroup, errc
 	}
 	// Configure the mux.
 	servicesvr.Mount(mux)
+	mux.Handle("GET", "/readyz", goahttp.ReadyHandler(drainer))
 
 	// Wrap the multiplexer with additional middlewares. Middlewares mounted
 	// here apply to all the service endpoints.
 	var handler http.Handler = mux
 	{
+		handler = httpmdlwr.Recover(reporter, enc)(handler)
 		if debug {
 			handler = httpmdlwr.Debug(mux, os.Stdout)(handler)
 		}
 		handler = httpmdlwr.Log(adapter)(handler)
 		handler = httpmdlwr.RequestID()(handler)
+		handler = goahttp.DrainStreams(drainer)(handler)
 	}
 
 	// Start HTTP server using default configuration, change the code to
 	// configure the server as required by your service.
-	srv := &http.Server{Addr: u.Host, Handler: handler}
+	srv := &http.Server{Addr: u.Host, Handler: handler, ReadHeaderTimeout: 60 * time.Second}
 	for _, m := range serviceServer.Mounts {
 		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
 	}
@@ -167,11 +181,19 @@ func handleHTTPServer(ctx context.Context, u *url.URL, wg *sync.WaitGroup, errc
 		<-ctx.Done()
 		logger.Printf("shutting down HTTP server at %q", u.Host)
 
-		// Shutdown gracefully with a 30s timeout.
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		// Report the server as not ready, stop accepting new connections and wait for
+		// the in-flight requests and the websocket streams to complete within the
+		// grace period.
+		drainer.Drain()
+		ctx, cancel := context.WithTimeout(context.Background(), drainer.Grace)
 		defer cancel()
 
-		srv.Shutdown(ctx)
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Printf("failed to shutdown HTTP server: %v", err)
+		}
+		if err := drainer.Wait(ctx); err != nil {
+			logger.Printf("failed to drain HTTP streams: %v", err)
+		}
 	}()
 }
 
@@ -189,7 +211,7 @@ func errorHandler(logger *log.Logger) func(context.Context, http.ResponseWriter,
 
 	ServerHostingServiceSubsetServerHandleCode = `// handleHTTPServer starts configures and starts a HTTP server on the given
 // URL. It shuts down the server if any error is received in the error channel.
-func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service.Endpoints, wg *sync.WaitGroup, errc chan error, drainer *goa.Drainer, reporter goa.PanicReporter, logger *log.Logger, debug bool) {
 
 	// Setup goa log adapter.
 	var (
@@ -205,7 +227,7 @@ func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 	// see goa.design/implement/encoding.
 	var (
 		dec = goahttp.RequestDecoder
-		enc = goahttp.ResponseEncoder
+		enc = goahttp.PooledResponseEncoder
 	)
 
 	// Build the service HTTP request multiplexer and configure it to serve
@@ -228,21 +250,24 @@ func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 	}
 	// Configure the mux.
 	servicesvr.Mount(mux, serviceServer)
+	mux.Handle("GET", "/readyz", goahttp.ReadyHandler(drainer))
 
 	// Wrap the multiplexer with additional middlewares. Middlewares mounted
 	// here apply to all the service endpoints.
 	var handler http.Handler = mux
 	{
+		handler = httpmdlwr.Recover(reporter, enc)(handler)
 		if debug {
 			handler = httpmdlwr.Debug(mux, os.Stdout)(handler)
 		}
 		handler = httpmdlwr.Log(adapter)(handler)
 		handler = httpmdlwr.RequestID()(handler)
+		handler = goahttp.DrainStreams(drainer)(handler)
 	}
 
 	// Start HTTP server using default configuration, change the code to
 	// configure the server as required by your service.
-	srv := &http.Server{Addr: u.Host, Handler: handler}
+	srv := &http.Server{Addr: u.Host, Handler: handler, ReadHeaderTimeout: 60 * time.Second}
 	for _, m := range serviceServer.Mounts {
 		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
 	}
@@ -260,11 +285,19 @@ func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 		<-ctx.Done()
 		logger.Printf("shutting down HTTP server at %q", u.Host)
 
-		// Shutdown gracefully with a 30s timeout.
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		// Report the server as not ready, stop accepting new connections and wait for
+		// the in-flight requests and the websocket streams to complete within the
+		// grace period.
+		drainer.Drain()
+		ctx, cancel := context.WithTimeout(context.Background(), drainer.Grace)
 		defer cancel()
 
-		srv.Shutdown(ctx)
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Printf("failed to shutdown HTTP server: %v", err)
+		}
+		if err := drainer.Wait(ctx); err != nil {
+			logger.Printf("failed to drain HTTP streams: %v", err)
+		}
 	}()
 }
 
@@ -282,7 +315,7 @@ func errorHandler(logger *log.Logger) func(context.Context, http.ResponseWriter,
 
 	ServerHostingMultipleServicesServerHandleCode = `// handleHTTPServer starts configures and starts a HTTP server on the given
 // URL. It shuts down the server if any error is received in the error channel.
-func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service.Endpoints, anotherServiceEndpoints *anotherservice.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service.Endpoints, anotherServiceEndpoints *anotherservice.Endpoints, wg *sync.WaitGroup, errc chan error, drainer *goa.Drainer, reporter goa.PanicReporter, logger *log.Logger, debug bool) {
 
 	// Setup goa log adapter.
 	var (
@@ -298,7 +331,7 @@ func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 	// see goa.design/implement/encoding.
 	var (
 		dec = goahttp.RequestDecoder
-		enc = goahttp.ResponseEncoder
+		enc = goahttp.PooledResponseEncoder
 	)
 
 	// Build the service HTTP request multiplexer and configure it to serve
@@ -324,21 +357,24 @@ func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 	// Configure the mux.
 	servicesvr.Mount(mux, serviceServer)
 	anotherservicesvr.Mount(mux, anotherServiceServer)
+	mux.Handle("GET", "/readyz", goahttp.ReadyHandler(drainer))
 
 	// Wrap the multiplexer with additional middlewares. Middlewares mounted
 	// here apply to all the service endpoints.
 	var handler http.Handler = mux
 	{
+		handler = httpmdlwr.Recover(reporter, enc)(handler)
 		if debug {
 			handler = httpmdlwr.Debug(mux, os.Stdout)(handler)
 		}
 		handler = httpmdlwr.Log(adapter)(handler)
 		handler = httpmdlwr.RequestID()(handler)
+		handler = goahttp.DrainStreams(drainer)(handler)
 	}
 
 	// Start HTTP server using default configuration, change the code to
 	// configure the server as required by your service.
-	srv := &http.Server{Addr: u.Host, Handler: handler}
+	srv := &http.Server{Addr: u.Host, Handler: handler, ReadHeaderTimeout: 60 * time.Second}
 	for _, m := range serviceServer.Mounts {
 		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
 	}
@@ -359,11 +395,19 @@ func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 		<-ctx.Done()
 		logger.Printf("shutting down HTTP server at %q", u.Host)
 
-		// Shutdown gracefully with a 30s timeout.
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		// Report the server as not ready, stop accepting new connections and wait for
+		// the in-flight requests and the websocket streams to complete within the
+		// grace period.
+		drainer.Drain()
+		ctx, cancel := context.WithTimeout(context.Background(), drainer.Grace)
 		defer cancel()
 
-		srv.Shutdown(ctx)
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Printf("failed to shutdown HTTP server: %v", err)
+		}
+		if err := drainer.Wait(ctx); err != nil {
+			logger.Printf("failed to drain HTTP streams: %v", err)
+		}
 	}()
 }
 
@@ -381,7 +425,7 @@ func errorHandler(logger *log.Logger) func(context.Context, http.ResponseWriter,
 
 	StreamingServerHandleCode = `// handleHTTPServer starts configures and starts a HTTP server on the given
 // URL. It shuts down the server if any error is received in the error channel.
-func handleHTTPServer(ctx context.Context, u *url.URL, streamingServiceAEndpoints *streamingservicea.Endpoints, streamingServiceBEndpoints *streamingserviceb.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+func handleHTTPServer(ctx context.Context, u *url.URL, streamingServiceAEndpoints *streamingservicea.Endpoints, streamingServiceBEndpoints *streamingserviceb.Endpoints, wg *sync.WaitGroup, errc chan error, drainer *goa.Drainer, reporter goa.PanicReporter, logger *log.Logger, debug bool) {
 
 	// Setup goa log adapter.
 	var (
@@ -397,7 +441,7 @@ func handleHTTPServer(ctx context.Context, u *url.URL, streamingServiceAEndpoint
 	// see goa.design/implement/encoding.
 	var (
 		dec = goahttp.RequestDecoder
-		enc = goahttp.ResponseEncoder
+		enc = goahttp.PooledResponseEncoder
 	)
 
 	// Build the service HTTP request multiplexer and configure it to serve
@@ -424,21 +468,24 @@ func handleHTTPServer(ctx context.Context, u *url.URL, streamingServiceAEndpoint
 	// Configure the mux.
 	streamingserviceasvr.Mount(mux, streamingServiceAServer)
 	streamingservicebsvr.Mount(mux, streamingServiceBServer)
+	mux.Handle("GET", "/readyz", goahttp.ReadyHandler(drainer))
 
 	// Wrap the multiplexer with additional middlewares. Middlewares mounted
 	// here apply to all the service endpoints.
 	var handler http.Handler = mux
 	{
+		handler = httpmdlwr.Recover(reporter, enc)(handler)
 		if debug {
 			handler = httpmdlwr.Debug(mux, os.Stdout)(handler)
 		}
 		handler = httpmdlwr.Log(adapter)(handler)
 		handler = httpmdlwr.RequestID()(handler)
+		handler = goahttp.DrainStreams(drainer)(handler)
 	}
 
 	// Start HTTP server using default configuration, change the code to
 	// configure the server as required by your service.
-	srv := &http.Server{Addr: u.Host, Handler: handler}
+	srv := &http.Server{Addr: u.Host, Handler: handler, ReadHeaderTimeout: 60 * time.Second}
 	for _, m := range streamingServiceAServer.Mounts {
 		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
 	}
@@ -459,11 +506,19 @@ func handleHTTPServer(ctx context.Context, u *url.URL, streamingServiceAEndpoint
 		<-ctx.Done()
 		logger.Printf("shutting down HTTP server at %q", u.Host)
 
-		// Shutdown gracefully with a 30s timeout.
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		// Report the server as not ready, stop accepting new connections and wait for
+		// the in-flight requests and the websocket streams to complete within the
+		// grace period.
+		drainer.Drain()
+		ctx, cancel := context.WithTimeout(context.Background(), drainer.Grace)
 		defer cancel()
 
-		srv.Shutdown(ctx)
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Printf("failed to shutdown HTTP server: %v", err)
+		}
+		if err := drainer.Wait(ctx); err != nil {
+			logger.Printf("failed to drain HTTP streams: %v", err)
+		}
 	}()
 }
 
@@ -494,7 +549,7 @@ func errorHandler(logger *log.Logger) func(context.Context, http.ResponseWriter,
 		scheme,
 		host,
 		doer,
-		goahttp.RequestEncoder,
+		goahttp.PooledRequestEncoder,
 		goahttp.ResponseDecoder,
 		debug,
 	)
@@ -507,6 +562,14 @@ func httpUsageCommands() string {
 func httpUsageExamples() string {
 	return cli.UsageExamples()
 }
+
+func httpCommands() []*goacli.Command {
+	return cli.Commands()
+}
+
+func httpStream(data interface{}) *goacli.Stream {
+	return cli.Stream(data)
+}
 `
 
 	StreamingExampleCLICode = `func doHTTP(scheme, host string, timeout int, debug bool) (goa.Endpoint, interface{}, error) {
@@ -531,7 +594,7 @@ func httpUsageExamples() string {
 		scheme,
 		host,
 		doer,
-		goahttp.RequestEncoder,
+		goahttp.PooledRequestEncoder,
 		goahttp.ResponseDecoder,
 		debug,
 		dialer,
@@ -546,6 +609,14 @@ func httpUsageCommands() string {
 func httpUsageExamples() string {
 	return cli.UsageExamples()
 }
+
+func httpCommands() []*goacli.Command {
+	return cli.Commands()
+}
+
+func httpStream(data interface{}) *goacli.Stream {
+	return cli.Stream(data)
+}
 `
 
 	StreamingMultipleServicesExampleCLICode = `func doHTTP(scheme, host string, timeout int, debug bool) (goa.Endpoint, interface{}, error) {
@@ -570,7 +641,7 @@ func httpUsageExamples() string {
 		scheme,
 		host,
 		doer,
-		goahttp.RequestEncoder,
+		goahttp.PooledRequestEncoder,
 		goahttp.ResponseDecoder,
 		debug,
 		dialer,
@@ -586,5 +657,213 @@ func httpUsageCommands() string {
 func httpUsageExamples() string {
 	return cli.UsageExamples()
 }
+
+func httpCommands() []*goacli.Command {
+	return cli.Commands()
+}
+
+func httpStream(data interface{}) *goacli.Stream {
+	return cli.Stream(data)
+}
 `
 )
+
+var CookiesExampleCLICode = `func doHTTP(scheme, host string, timeout int, debug bool) (goa.Endpoint, interface{}, error) {
+	var (
+		doer goahttp.Doer
+	)
+	{
+		// The cookie jar stores the cookies set by the responses and
+		// sends them back with the following requests according to
+		// their attributes.
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		doer = &http.Client{Timeout: time.Duration(timeout) * time.Second, Jar: jar}
+		if debug {
+			doer = goahttp.NewDebugDoer(doer)
+		}
+	}
+
+	return cli.ParseEndpoint(
+		scheme,
+		host,
+		doer,
+		goahttp.PooledRequestEncoder,
+		goahttp.ResponseDecoder,
+		debug,
+	)
+}
+
+func httpUsageCommands() string {
+	return cli.UsageCommands()
+}
+
+func httpUsageExamples() string {
+	return cli.UsageExamples()
+}
+
+func httpCommands() []*goacli.Command {
+	return cli.Commands()
+}
+
+func httpStream(data interface{}) *goacli.Stream {
+	return cli.Stream(data)
+}
+`
+
+var CredentialsExampleCLICode = `func doHTTP(scheme, host string, timeout int, debug bool) (goa.Endpoint, interface{}, error) {
+	var (
+		doer goahttp.Doer
+	)
+	{
+		doer = &http.Client{Timeout: time.Duration(timeout) * time.Second}
+		if debug {
+			doer = goahttp.NewDebugDoer(doer)
+		}
+	}
+
+	// The security flags not given explicitly are initialized with the
+	// credentials of the host stored in the environment or in the
+	// credentials file, see credentials.DefaultStore.
+	creds := credentials.NewProfile(host, credentials.DefaultStore("test_api"))
+	creds.BaseURL = scheme + "://" + host
+	creds.Client = &http.Client{Timeout: time.Duration(timeout) * time.Second}
+	return cli.ParseEndpoint(
+		scheme,
+		host,
+		doer,
+		goahttp.PooledRequestEncoder,
+		goahttp.ResponseDecoder,
+		debug,
+		creds,
+	)
+}
+
+func httpUsageCommands() string {
+	return cli.UsageCommands()
+}
+
+func httpUsageExamples() string {
+	return cli.UsageExamples()
+}
+
+func httpCommands() []*goacli.Command {
+	return cli.Commands()
+}
+
+func httpStream(data interface{}) *goacli.Stream {
+	return cli.Stream(data)
+}
+`
+
+const CSRFServerHandleCode = `// handleHTTPServer starts configures and starts a HTTP server on the given
+// URL. It shuts down the server if any error is received in the error channel.
+func handleHTTPServer(ctx context.Context, u *url.URL, serviceCSRFEndpoints *servicecsrf.Endpoints, wg *sync.WaitGroup, errc chan error, drainer *goa.Drainer, reporter goa.PanicReporter, logger *log.Logger, debug bool) {
+
+	// Setup goa log adapter.
+	var (
+		adapter middleware.Logger
+	)
+	{
+		adapter = middleware.NewLogger(logger)
+	}
+
+	// Provide the transport specific request decoder and response encoder.
+	// The goa http package has built-in support for JSON, XML and gob.
+	// Other encodings can be used by providing the corresponding functions,
+	// see goa.design/implement/encoding.
+	var (
+		dec = goahttp.RequestDecoder
+		enc = goahttp.PooledResponseEncoder
+	)
+
+	// Build the service HTTP request multiplexer and configure it to serve
+	// HTTP requests to the service endpoints.
+	var mux goahttp.Muxer
+	{
+		mux = goahttp.NewMuxer()
+	}
+
+	// Wrap the endpoints with the transport specific layers. The generated
+	// server packages contains code generated from the design which maps
+	// the service input and output data structures to HTTP requests and
+	// responses.
+	var (
+		serviceCSRFServer *servicecsrfsvr.Server
+	)
+	{
+		eh := errorHandler(logger)
+		serviceCSRFServer = servicecsrfsvr.New(serviceCSRFEndpoints, mux, dec, enc, eh)
+	}
+	// Protect the endpoints that authenticate requests with cookies against CSRF,
+	// the tokens are derived from the session cookies with the secret read from
+	// the CSRF_SECRET environment variable.
+	if err := serviceCSRFServer.ProtectCSRF([]byte(os.Getenv("CSRF_SECRET"))); err != nil {
+		logger.Fatalf("failed to protect the ServiceCSRF endpoints against CSRF: %v", err)
+	}
+	// Configure the mux.
+	servicecsrfsvr.Mount(mux, serviceCSRFServer)
+	mux.Handle("GET", "/readyz", goahttp.ReadyHandler(drainer))
+
+	// Wrap the multiplexer with additional middlewares. Middlewares mounted
+	// here apply to all the service endpoints.
+	var handler http.Handler = mux
+	{
+		handler = httpmdlwr.Recover(reporter, enc)(handler)
+		if debug {
+			handler = httpmdlwr.Debug(mux, os.Stdout)(handler)
+		}
+		handler = httpmdlwr.Log(adapter)(handler)
+		handler = httpmdlwr.RequestID()(handler)
+		handler = goahttp.DrainStreams(drainer)(handler)
+	}
+
+	// Start HTTP server using default configuration, change the code to
+	// configure the server as required by your service.
+	srv := &http.Server{Addr: u.Host, Handler: handler, ReadHeaderTimeout: 60 * time.Second}
+	for _, m := range serviceCSRFServer.Mounts {
+		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
+	}
+
+	(*wg).Add(1)
+	go func() {
+		defer (*wg).Done()
+
+		// Start HTTP server in a separate goroutine.
+		go func() {
+			logger.Printf("HTTP server listening on %q", u.Host)
+			errc <- srv.ListenAndServe()
+		}()
+
+		<-ctx.Done()
+		logger.Printf("shutting down HTTP server at %q", u.Host)
+
+		// Report the server as not ready, stop accepting new connections and wait for
+		// the in-flight requests and the websocket streams to complete within the
+		// grace period.
+		drainer.Drain()
+		ctx, cancel := context.WithTimeout(context.Background(), drainer.Grace)
+		defer cancel()
+
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Printf("failed to shutdown HTTP server: %v", err)
+		}
+		if err := drainer.Wait(ctx); err != nil {
+			logger.Printf("failed to drain HTTP streams: %v", err)
+		}
+	}()
+}
+
+// errorHandler returns a function that writes and logs the given error.
+// The function also writes and logs the error unique ID so that it's possible
+// to correlate.
+func errorHandler(logger *log.Logger) func(context.Context, http.ResponseWriter, error) {
+	return func(ctx context.Context, w http.ResponseWriter, err error) {
+		id := ctx.Value(middleware.RequestIDKey).(string)
+		w.Write([]byte("[" + id + "] encoding: " + err.Error()))
+		logger.Printf("[%s] ERROR: %s", id, err.Error())
+	}
+}
+`
@@ -0,0 +1,67 @@
+package testdata
+
+var DirFileServerCode = `// MountPathToStatic configures the mux to serve GET request made to "/static".
+func MountPathToStatic(mux goahttp.Muxer, h http.Handler) {
+	mux.Handle("GET", "/static/{*path}", h.ServeHTTP)
+	mux.Handle("GET", "/static/", h.ServeHTTP)
+}
+`
+
+var ConfiguredFileServerMountCode = `// Mount configures the mux to serve the ServiceConfiguredFileServer endpoints.
+func Mount(mux goahttp.Muxer) {
+	MountPathToApp(mux, goahttp.NewFileServer(nil, &goahttp.FileServerConfig{
+		FilePath:     "/path/to/app",
+		IsDir:        true,
+		Prefixes:     []string{"/app"},
+		Fallback:     "index.html",
+		CacheControl: "public, max-age=31536000, immutable",
+		ETag:         true,
+	}))
+}
+`
+
+var EmbedFileServerStructCode = `// Server lists the ServiceEmbedFileServer service endpoint HTTP handlers.
+type Server struct {
+	Mounts []*MountPoint
+	// FileSystem is the file system the embedded files are served from.
+	FileSystem http.FileSystem
+}
+
+// ErrorNamer is an interface implemented by generated error structs that
+// exposes the name of the error as defined in the design.
+type ErrorNamer interface {
+	ErrorName() string
+}
+`
+
+var EmbedFileServerInitCode = `// New instantiates HTTP handlers for all the ServiceEmbedFileServer service
+// endpoints.
+func New(
+	e *serviceembedfileserver.Endpoints,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+	fsys http.FileSystem,
+) *Server {
+	return &Server{
+		Mounts: []*MountPoint{
+			{"public/favicon.ico", "GET", "/favicon.ico"},
+			{"/path/to/robots.txt", "GET", "/robots.txt"},
+		},
+		FileSystem: fsys,
+	}
+}
+`
+
+var EmbedFileServerMountCode = `// Mount configures the mux to serve the ServiceEmbedFileServer endpoints.
+func Mount(mux goahttp.Muxer, h *Server) {
+	MountPublicFaviconIco(mux, goahttp.NewFileServer(h.FileSystem, &goahttp.FileServerConfig{
+		FilePath:     "public/favicon.ico",
+		CacheControl: "public, max-age=86400",
+	}))
+	MountPathToRobotsTxt(mux, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "/path/to/robots.txt")
+	}))
+}
+`
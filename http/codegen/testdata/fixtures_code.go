@@ -0,0 +1,90 @@
+package testdata
+
+const FixturesShowRequest = `GET /3?view=tiny HTTP/1.1
+Host: localhost
+
+`
+
+const FixturesShowResponse = `HTTP/1.1 200 OK
+ETag: abc
+Content-Type: application/json
+Content-Length: 31
+
+{
+  "id": 3,
+  "name": "goa"
+}
+`
+
+const FixturesCreateRequest = `POST / HTTP/1.1
+Host: localhost
+Authorization: Basic dXNlcjpwYXNz
+Content-Type: application/json
+Content-Length: 20
+
+{
+  "name": "goa"
+}
+`
+
+const FixturesCreateResponse = `HTTP/1.1 201 Created
+
+`
+
+const FixturesCode = `// Methods lists the "ServiceFixtures" service methods that have recorded
+// fixtures.
+var Methods = []string{"Show", "Create"}
+
+// files maps the methods to the base names of their fixture files.
+var files = map[string]string{
+	"Show":   "show",
+	"Create": "create",
+}
+
+// Request returns the request recorded for the given method.
+func Request(method string) (*http.Request, error) {
+	p, err := path(method, "request")
+	if err != nil {
+		return nil, err
+	}
+	return goahttp.ReadFixtureRequest(p)
+}
+
+// Response returns the response recorded for the given method. The response
+// may be given to the client response decoder of the method to make sure that
+// the recorded responses can still be decoded.
+func Response(method string) (*http.Response, error) {
+	p, err := path(method, "response")
+	if err != nil {
+		return nil, err
+	}
+	return goahttp.ReadFixtureResponse(p)
+}
+
+// Replay sends the request recorded for the given method to h and returns an
+// error if the status code or the content type of the response differ from
+// the recorded response.
+func Replay(h http.Handler, method string) error {
+	req, err := path(method, "request")
+	if err != nil {
+		return err
+	}
+	resp, err := path(method, "response")
+	if err != nil {
+		return err
+	}
+	return goahttp.ReplayFixture(h, req, resp)
+}
+
+// path returns the path to the fixture file of the given kind recorded for the
+// given method. The fixture files are located in the directory of this source
+// file.
+func path(method, kind string) (string, error) {
+	f, ok := files[method]
+	if !ok {
+		return "", fmt.Errorf("no fixture recorded for method %q", method)
+	}
+	_, src, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(src), f+"."+kind+".http"), nil
+}
+`
@@ -0,0 +1,69 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var FixturesDSL = func() {
+	API("fixtures", func() {
+		Meta("fixtures:record")
+	})
+	var BasicAuth = BasicAuthSecurity("basic")
+	var Account = ResultType("application/vnd.account", func() {
+		Attribute("id", Int, func() {
+			Example(3)
+		})
+		Attribute("name", String, func() {
+			Example("goa")
+		})
+		Attribute("etag", String, func() {
+			Example("abc")
+		})
+	})
+	Service("ServiceFixtures", func() {
+		Method("Show", func() {
+			Payload(func() {
+				Attribute("id", Int, func() {
+					Example(3)
+				})
+				Attribute("view", String, func() {
+					Example("tiny")
+				})
+				Required("id")
+			})
+			Result(Account)
+			HTTP(func() {
+				GET("/{id}")
+				Param("view")
+				Response(StatusOK, func() {
+					Header("etag:ETag")
+				})
+			})
+		})
+		Method("Create", func() {
+			Security(BasicAuth)
+			Payload(func() {
+				Username("user", String, func() {
+					Example("user")
+				})
+				Password("pass", String, func() {
+					Example("pass")
+				})
+				Attribute("name", String, func() {
+					Example("goa")
+				})
+				Required("name")
+			})
+			HTTP(func() {
+				POST("/")
+				Response(StatusCreated)
+			})
+		})
+		Method("Stream", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/stream")
+			})
+		})
+	})
+}
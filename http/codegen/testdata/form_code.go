@@ -0,0 +1,45 @@
+package testdata
+
+var FormEncodedServerInitCode = `// New instantiates HTTP handlers for all the ServiceFormEncoded service
+// endpoints.
+func New(
+	e *serviceformencoded.Endpoints,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) *Server {
+	return &Server{
+		Mounts: []*MountPoint{
+			{"Token", "POST", "/token"},
+		},
+		Token: NewTokenHandler(e.Token, mux, goahttp.FormRequestDecoder, enc, eh),
+	}
+}
+`
+
+var FormEncodedClientEndpointInitCode = `// Token returns an endpoint that makes HTTP requests to the ServiceFormEncoded
+// service Token server.
+func (c *Client) Token() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeTokenRequest(goahttp.FormRequestEncoder)
+		decodeResponse = DecodeTokenResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		req, err := c.BuildTokenRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.TokenDoer.Do(req)
+
+		if err != nil {
+			return nil, goahttp.ErrRequestError("ServiceFormEncoded", "Token", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+`
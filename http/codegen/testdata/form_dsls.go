@@ -0,0 +1,22 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var FormEncodedDSL = func() {
+	Service("ServiceFormEncoded", func() {
+		Method("Token", func() {
+			Payload(func() {
+				Attribute("grant_type", String)
+				Attribute("code", String)
+				Attribute("scopes", ArrayOf(String))
+				Required("grant_type")
+			})
+			HTTP(func() {
+				POST("/token")
+				FormEncodedRequest()
+			})
+		})
+	})
+}
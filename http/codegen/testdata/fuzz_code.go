@@ -0,0 +1,49 @@
+package testdata
+
+const FuzzCode = `// FuzzShowDecodeRequest makes sure that decoding and validating the
+// "ServiceFuzz" service "Show" requests does not panic. The corpus is seeded
+// with the request built from the design examples.
+func FuzzShowDecodeRequest(f *testing.F) {
+	var (
+		mux    = goahttp.NewMuxer()
+		decode = DecodeShowRequest(mux, goahttp.RequestDecoder)
+	)
+	mux.Handle("GET", "/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		// Invalid requests must be rejected with an error.
+		decode(r)
+	})
+	f.Add("3", "view=tiny", "abc")
+	f.Fuzz(func(t *testing.T, id string, query string, xTrace string) {
+		req, err := http.NewRequest("GET", "/items/"+url.PathEscape(id)+"?"+query, nil)
+		if err != nil {
+			return
+		}
+		req.Header.Set("X-Trace", xTrace)
+		mux.ServeHTTP(httptest.NewRecorder(), req)
+	})
+}
+
+// FuzzCreateDecodeRequest makes sure that decoding and validating the
+// "ServiceFuzz" service "Create" requests does not panic. The corpus is seeded
+// with the request built from the design examples.
+func FuzzCreateDecodeRequest(f *testing.F) {
+	var (
+		mux    = goahttp.NewMuxer()
+		decode = DecodeCreateRequest(mux, goahttp.RequestDecoder)
+	)
+	mux.Handle("POST", "/items", func(w http.ResponseWriter, r *http.Request) {
+		// Invalid requests must be rejected with an error.
+		decode(r)
+	})
+	f.Add("Basic dXNlcjpwYXNz", []byte("{\"name\":\"abc\"}"))
+	f.Fuzz(func(t *testing.T, authorization string, body []byte) {
+		req, err := http.NewRequest("POST", "/items", bytes.NewReader(body))
+		if err != nil {
+			return
+		}
+		req.Header.Set("Authorization", authorization)
+		req.Header.Set("Content-Type", "application/json")
+		mux.ServeHTTP(httptest.NewRecorder(), req)
+	})
+}
+`
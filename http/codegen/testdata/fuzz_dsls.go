@@ -0,0 +1,55 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var FuzzDSL = func() {
+	API("fuzz", func() {
+		Meta("fuzz:test")
+	})
+	var BasicAuth = BasicAuthSecurity("basic")
+	Service("ServiceFuzz", func() {
+		Method("Show", func() {
+			Payload(func() {
+				Attribute("id", Int, func() {
+					Example(3)
+				})
+				Attribute("view", String, func() {
+					Example("tiny")
+				})
+				Attribute("trace", String, func() {
+					Example("abc")
+				})
+				Required("id")
+			})
+			HTTP(func() {
+				GET("/items/{id}")
+				Param("view")
+				Header("trace:X-Trace")
+			})
+		})
+		Method("Create", func() {
+			Security(BasicAuth)
+			Payload(func() {
+				Username("user", String, func() {
+					Example("user")
+				})
+				Password("pass", String, func() {
+					Example("pass")
+				})
+				Attribute("name", String, func() {
+					Example("abc")
+				})
+			})
+			HTTP(func() {
+				POST("/items")
+			})
+		})
+		Method("List", func() {
+			HTTP(func() {
+				GET("/items")
+			})
+		})
+	})
+}
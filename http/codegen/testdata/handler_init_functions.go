@@ -195,3 +195,70 @@ func NewMethodPayloadResultErrorHandler(
 	})
 }
 `
+
+var ServerBodyLimitHandlerConstructorCode = `// NewMethodBodyLimitHandler creates a HTTP handler which loads the HTTP
+// request and calls the "ServiceBodyLimit" service "MethodBodyLimit" endpoint.
+func NewMethodBodyLimitHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		decodeRequest  = DecodeMethodBodyLimitRequest(mux, dec)
+		encodeResponse = EncodeMethodBodyLimitResponse(enc)
+		encodeError    = EncodeMethodBodyLimitError(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodBodyLimit")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceBodyLimit")
+		goahttp.LimitRequestBody(w, r, 2097152)
+		payload, err := decodeRequest(r)
+		if err != nil {
+			err = goahttp.BodyLimitError(r, err)
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+
+		res, err := endpoint(ctx, payload)
+
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
+
+var ServerBodyLimitErrorEncoderCode = `// EncodeMethodBodyLimitError returns an encoder for errors returned by the
+// MethodBodyLimit ServiceBodyLimit endpoint.
+func EncodeMethodBodyLimitError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		en, ok := v.(ErrorNamer)
+		if !ok {
+			return encodeError(ctx, w, v)
+		}
+		switch en.ErrorName() {
+		case "request_too_large":
+			res := v.(*goa.ServiceError)
+			enc := encoder(ctx, w)
+			body := NewMethodBodyLimitRequestTooLargeResponseBody(res)
+			w.Header().Set("goa-error", "request_too_large")
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+`
@@ -0,0 +1,32 @@
+package testdata
+
+var AutoHeadOptionsMountCode = `// Mount configures the mux to serve the ServiceAutoHeadOptions endpoints.
+func Mount(mux goahttp.Muxer, h *Server) {
+	MountListHandler(mux, h.List)
+	MountCreateHandler(mux, h.Create)
+	MountShowHandler(mux, h.Show)
+	MountOptionsHandler(mux)
+}
+`
+
+var AutoHeadOptionsHandlerCode = `// MountListHandler configures the mux to serve the "ServiceAutoHeadOptions"
+// service "List" endpoint.
+func MountListHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/items", f)
+	mux.Handle("HEAD", "/items", goahttp.HeadHandler(f))
+}
+`
+
+var AutoHeadOptionsOptionsCode = `// MountOptionsHandler configures the mux to serve the OPTIONS requests made to
+// the ServiceAutoHeadOptions endpoints.
+func MountOptionsHandler(mux goahttp.Muxer) {
+	mux.Handle("OPTIONS", "/items", goahttp.OptionsHandler("GET", "HEAD", "OPTIONS", "POST"))
+	mux.Handle("OPTIONS", "/items/{id}", goahttp.OptionsHandler("GET", "HEAD", "OPTIONS"))
+}
+`
@@ -0,0 +1,36 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var AutoHeadOptionsDSL = func() {
+	API("API", func() {
+		HTTP(func() {
+			AutoHeadOptions()
+		})
+	})
+	Service("ServiceAutoHeadOptions", func() {
+		Method("List", func() {
+			Result(ArrayOf(String))
+			HTTP(func() {
+				GET("/items")
+			})
+		})
+		Method("Create", func() {
+			Payload(String)
+			HTTP(func() {
+				POST("/items")
+			})
+		})
+		Method("Show", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			Result(String)
+			HTTP(func() {
+				GET("/items/{id}")
+			})
+		})
+	})
+}
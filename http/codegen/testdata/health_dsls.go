@@ -0,0 +1,47 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var HealthCheckDSL = func() {
+	API("test", func() {
+		HealthCheck("/livez")
+	})
+	Service("test", func() {
+		Method("show", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var HealthCheckV3DSL = func() {
+	API("test", func() {
+		Meta("openapi:version", "3.1")
+		HealthCheck()
+	})
+	Service("test", func() {
+		Method("show", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var HealthCheckHiddenDSL = func() {
+	API("test", func() {
+		HealthCheck(func() {
+			Meta("swagger:generate", "false")
+		})
+	})
+	Service("test", func() {
+		Method("show", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
@@ -0,0 +1,49 @@
+package testdata
+
+const InMemoryCode = `// NewClient returns a "InMemoryHTTP" service client whose requests are served
+// by svc over HTTP in memory, without opening network ports. The function
+// returned alongside the client shuts down the server, it must be called once
+// the client is no longer needed.
+func NewClient(svc inmemoryhttp.Service) (*inmemoryhttp.Client, func()) {
+	var (
+		l   = goahttp.NewInMemoryListener()
+		mux = goahttp.NewMuxer()
+		// The errors that occur while encoding the responses are reported
+		// to the client by the response decoders.
+		eh = func(context.Context, http.ResponseWriter, error) {}
+	)
+	server := inmemoryhttpsvr.New(inmemoryhttp.NewEndpoints(svc), mux, goahttp.RequestDecoder, goahttp.PooledResponseEncoder, eh)
+	inmemoryhttpsvr.Mount(mux, server)
+	hs := &http.Server{Handler: mux}
+	go hs.Serve(l)
+
+	c := inmemoryhttpc.NewClient("http", "inmem", goahttp.NewInMemoryClient(l), goahttp.PooledRequestEncoder, goahttp.ResponseDecoder, false)
+	return inmemoryhttp.NewClient(c.Show(), c.Reset()), func() { hs.Close() }
+}
+`
+
+const InMemoryStreamingCode = `// NewClient returns a "InMemoryStreaming" service client whose requests are
+// served by svc over HTTP in memory, without opening network ports. The
+// function returned alongside the client shuts down the server, it must be
+// called once the client is no longer needed.
+func NewClient(svc inmemorystreaming.Service) (*inmemorystreaming.Client, func()) {
+	var (
+		l   = goahttp.NewInMemoryListener()
+		mux = goahttp.NewMuxer()
+		// The errors that occur while encoding the responses are reported
+		// to the client by the response decoders.
+		eh = func(context.Context, http.ResponseWriter, error) {}
+	)
+	server := inmemorystreamingsvr.New(inmemorystreaming.NewEndpoints(svc), mux, goahttp.RequestDecoder, goahttp.PooledResponseEncoder, eh, &websocket.Upgrader{}, nil, nil, func(*multipart.Reader, *map[string]int) error {
+		return fmt.Errorf("the in-memory server does not decode the multipart requests of the %q method", "Import")
+	})
+	inmemorystreamingsvr.Mount(mux, server)
+	hs := &http.Server{Handler: mux}
+	go hs.Serve(l)
+
+	c := inmemorystreamingc.NewClient("http", "inmem", goahttp.NewInMemoryClient(l), goahttp.PooledRequestEncoder, goahttp.ResponseDecoder, false, &websocket.Dialer{NetDialContext: l.DialContext}, nil)
+	return inmemorystreaming.NewClient(c.Watch(), c.Upload(nil), c.Import(func(*multipart.Writer, map[string]int) error {
+		return fmt.Errorf("the in-memory client does not encode the multipart requests of the %q method", "Import")
+	})), func() { hs.Close() }
+}
+`
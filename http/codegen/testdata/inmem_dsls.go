@@ -0,0 +1,50 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var InMemoryDSL = func() {
+	Service("InMemoryHTTP", func() {
+		Method("Show", func() {
+			Payload(Int)
+			Result(String)
+			HTTP(func() {
+				GET("/{id}")
+			})
+		})
+		Method("Reset", func() {
+			HTTP(func() {
+				POST("/reset")
+			})
+		})
+	})
+}
+
+var InMemoryStreamingDSL = func() {
+	Service("InMemoryStreaming", func() {
+		Method("Watch", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/watch")
+			})
+		})
+		Method("Upload", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				POST("/upload")
+				MultipartRequest()
+			})
+		})
+		Method("Import", func() {
+			Payload(MapOf(String, Int))
+			HTTP(func() {
+				POST("/import")
+				MultipartRequest()
+			})
+		})
+		Files("/index.html", "index.html")
+	})
+}
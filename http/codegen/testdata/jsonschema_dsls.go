@@ -0,0 +1,41 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var JSONSchemaDSL = func() {
+	var Bottle = Type("Bottle", func() {
+		Attribute("name", String, func() {
+			MinLength(1)
+		})
+		Attribute("vintage", Int, func() {
+			Minimum(1900)
+		})
+		Required("name")
+	})
+	var Node = Type("Node", func() {
+		Attribute("value", Bottle)
+		Attribute("children", ArrayOf("Node"))
+	})
+	var Hidden = Type("Hidden", func() {
+		Meta("swagger:generate", "false")
+		Attribute("secret", String)
+	})
+	var Cellar = ResultType("application/vnd.cellar", func() {
+		TypeName("Cellar")
+		Attributes(func() {
+			Attribute("bottles", ArrayOf(Bottle))
+			Attribute("root", Node)
+		})
+	})
+	Service("cellar", func() {
+		Method("list", func() {
+			Payload(Hidden)
+			Result(Cellar)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
@@ -0,0 +1,83 @@
+package testdata
+
+const LambdaServerMainCode = `// main serves the HTTP endpoints of the "calc" server as an AWS Lambda
+// function invoked by an API Gateway HTTP API (payload format 2.0). Build the
+// executable of the function for the "provided.al2" runtime with:
+//
+//	GOOS=linux GOARCH=arm64 CGO_ENABLED=0 go build -o bootstrap ./cmd/calc_lambda
+func main() {
+	// Setup logger. Replace logger with your own log package of choice.
+	logger := log.New(os.Stderr, "[calcapi] ", log.Ltime)
+	reporter := goa.PanicReporterFunc(func(_ context.Context, p interface{}, stack []byte) {
+		logger.Printf("panic: %v\n%s", p, stack)
+	})
+
+	var (
+		dec = goahttp.RequestDecoder
+		enc = goahttp.PooledResponseEncoder
+		mux = goahttp.NewMuxer()
+		eh  = errorHandler(logger)
+	)
+	{
+		svc := calcapi.NewCalc(logger)
+		endpoints := calc.NewEndpoints(svc)
+		endpoints.Use(goa.RecoverEndpoint(reporter))
+		server := calcsvr.New(endpoints, mux, dec, enc, eh, nil, nil)
+		calcsvr.Mount(mux, server)
+	}
+
+	var handler http.Handler = mux
+	{
+		handler = httpmdlwr.Recover(reporter, enc)(handler)
+		handler = httpmdlwr.Log(middleware.NewLogger(logger))(handler)
+		handler = httpmdlwr.RequestID(httpmdlwr.UseXRequestIDHeaderOption(true))(handler)
+	}
+
+	// Poll the Lambda runtime API for the API Gateway events and serve them with
+	// handler.
+	if err := goahttp.StartLambda(handler); err != nil {
+		logger.Fatalf("lambda runtime: %v", err)
+	}
+}
+`
+
+const LambdaMethodMainCode = `// main serves the "add" endpoint of the "calc" service as an AWS Lambda
+// function invoked by an API Gateway HTTP API (payload format 2.0). Build the
+// executable of the function for the "provided.al2" runtime with:
+//
+//	GOOS=linux GOARCH=arm64 CGO_ENABLED=0 go build -o bootstrap ./cmd/calc_lambda/calc_add
+func main() {
+	// Setup logger. Replace logger with your own log package of choice.
+	logger := log.New(os.Stderr, "[calcapi] ", log.Ltime)
+	reporter := goa.PanicReporterFunc(func(_ context.Context, p interface{}, stack []byte) {
+		logger.Printf("panic: %v\n%s", p, stack)
+	})
+
+	var (
+		dec = goahttp.RequestDecoder
+		enc = goahttp.PooledResponseEncoder
+		mux = goahttp.NewMuxer()
+		eh  = errorHandler(logger)
+	)
+	{
+		svc := calcapi.NewCalc(logger)
+		endpoints := calc.NewEndpoints(svc)
+		endpoints.Use(goa.RecoverEndpoint(reporter))
+		server := calcsvr.New(endpoints, mux, dec, enc, eh, nil, nil)
+		calcsvr.MountAddHandler(mux, server.Add)
+	}
+
+	var handler http.Handler = mux
+	{
+		handler = httpmdlwr.Recover(reporter, enc)(handler)
+		handler = httpmdlwr.Log(middleware.NewLogger(logger))(handler)
+		handler = httpmdlwr.RequestID(httpmdlwr.UseXRequestIDHeaderOption(true))(handler)
+	}
+
+	// Poll the Lambda runtime API for the API Gateway events and serve them with
+	// handler.
+	if err := goahttp.StartLambda(handler); err != nil {
+		logger.Fatalf("lambda runtime: %v", err)
+	}
+}
+`
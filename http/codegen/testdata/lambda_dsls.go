@@ -0,0 +1,34 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var LambdaDSL = func() {
+	API("calc", func() {
+		Server("calc", func() {
+			Services("calc")
+			Lambda(func() {
+				PerMethod()
+			})
+		})
+	})
+	Service("calc", func() {
+		Method("add", func() {
+			Payload(func() {
+				Attribute("a", Int)
+				Attribute("b", Int)
+			})
+			Result(Int)
+			HTTP(func() {
+				GET("/add/{a}/{b}")
+			})
+		})
+		Method("watch", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/watch")
+			})
+		})
+	})
+}
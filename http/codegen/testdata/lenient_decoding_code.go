@@ -0,0 +1,81 @@
+package testdata
+
+var LenientDecodingRequestDecoderCode = `// DecodeMethodLenientDecodingRequest returns a decoder for requests sent to
+// the ServiceLenientDecoding MethodLenientDecoding endpoint.
+func DecodeMethodLenientDecodingRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			body MethodLenientDecodingRequestBody
+			err  error
+		)
+		err = goahttp.LenientDecoder(decoder, true)(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+
+		var (
+			active *bool
+			tags   []bool
+			ref    *string
+		)
+		{
+			activeRaw := r.URL.Query().Get("active")
+			if activeRaw != "" {
+				v, err2 := goahttp.ParseBool(activeRaw)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("active", activeRaw, "boolean"))
+				}
+				active = &v
+			}
+		}
+		{
+			tagsRaw := r.URL.Query()["tags"]
+			if tagsRaw != nil {
+				tags = make([]bool, len(tagsRaw))
+				for i, rv := range tagsRaw {
+					v, err2 := goahttp.ParseBool(rv)
+					if err2 != nil {
+						err = goa.MergeErrors(err, goa.InvalidFieldTypeError("tags", tagsRaw, "array of booleans"))
+					}
+					tags[i] = v
+				}
+			}
+		}
+		refRaw := strings.TrimSpace(r.Header.Get("X-Ref"))
+		if refRaw != "" {
+			ref = &refRaw
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodLenientDecodingPayload(&body, active, tags, ref)
+
+		return payload, nil
+	}
+}
+`
+
+var TrimDecodingRequestDecoderCode = `// DecodeMethodTrimDecodingRequest returns a decoder for requests sent to the
+// ServiceLenientDecoding MethodTrimDecoding endpoint.
+func DecodeMethodTrimDecodingRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			body MethodTrimDecodingRequestBody
+			err  error
+		)
+		err = goahttp.LenientDecoder(decoder, false)(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		payload := NewMethodTrimDecodingPayload(&body)
+
+		return payload, nil
+	}
+}
+`
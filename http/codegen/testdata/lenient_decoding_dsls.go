@@ -0,0 +1,39 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var LenientDecodingDSL = func() {
+	Service("ServiceLenientDecoding", func() {
+		Method("MethodLenientDecoding", func() {
+			Payload(func() {
+				Attribute("name", String)
+				Attribute("count", Int)
+				Attribute("active", Boolean)
+				Attribute("tags", ArrayOf(Boolean))
+				Attribute("ref", String, func() {
+					Meta("decode:trim")
+				})
+			})
+			HTTP(func() {
+				POST("/")
+				Param("active")
+				Param("tags")
+				Header("ref:X-Ref")
+				LenientDecoding()
+			})
+		})
+		Method("MethodTrimDecoding", func() {
+			Payload(func() {
+				Attribute("name", String, func() {
+					Meta("decode:trim")
+				})
+				Attribute("count", Int)
+			})
+			HTTP(func() {
+				POST("/trim")
+			})
+		})
+	})
+}
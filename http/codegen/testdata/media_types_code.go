@@ -0,0 +1,58 @@
+package testdata
+
+var StrictMediaTypesHandlerInitCode = `// NewMethodStrictMediaTypesHandler creates a HTTP handler which loads the HTTP
+// request and calls the "ServiceStrictMediaTypes" service
+// "MethodStrictMediaTypes" endpoint.
+func NewMethodStrictMediaTypesHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		decodeRequest  = DecodeMethodStrictMediaTypesRequest(mux, dec)
+		encodeResponse = EncodeMethodStrictMediaTypesResponse(enc)
+		encodeError    = EncodeMethodStrictMediaTypesError(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodStrictMediaTypes")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceStrictMediaTypes")
+		if err := goahttp.CheckContentType(r, "application/json", "application/xml"); err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		if err := goahttp.CheckAccept(r, "application/json"); err != nil {
+			// The error is encoded with the default encoder since none of
+			// the accepted media types is produced.
+			ctx = context.WithValue(ctx, goahttp.AcceptTypeKey, "")
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+
+		res, err := endpoint(ctx, payload)
+
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
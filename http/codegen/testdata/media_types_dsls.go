@@ -0,0 +1,24 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var StrictMediaTypesDSL = func() {
+	API("API", func() {
+		HTTP(func() {
+			Consumes("application/json", "application/xml")
+			Produces("application/json")
+			StrictMediaTypes()
+		})
+	})
+	Service("ServiceStrictMediaTypes", func() {
+		Method("MethodStrictMediaTypes", func() {
+			Payload(String)
+			Result(String)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
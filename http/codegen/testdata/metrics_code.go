@@ -0,0 +1,54 @@
+package testdata
+
+const MetricsWrapHTTPCode = `// WrapServiceMetricsHTTP wraps the handlers of the "ServiceMetrics" service
+// HTTP server so that they record the metrics of the requests labeled with the
+// routes defined in the design. It must be called before the server is mounted.
+func WrapServiceMetricsHTTP(s *servicemetricssvr.Server) {
+	s.MethodUnary = handler(s.MethodUnary, "ServiceMetrics", "MethodUnary", route{"POST", "/unary/{id}"}, route{"POST", "/other/{id}"})
+	s.MethodStreaming = handler(s.MethodStreaming, "ServiceMetrics", "MethodStreaming", route{"GET", "/streaming"})
+}
+`
+
+const MetricsWrapStreamsCode = `// WrapServiceMetrics wraps the streaming endpoints of the "ServiceMetrics"
+// service so that they count the messages sent and received by the server
+// streams. It must be called before the endpoints are mounted on the transport
+// servers.
+func WrapServiceMetrics(e *servicemetrics.Endpoints) {
+	e.MethodStreaming = serviceMetricsMethodStreamingServerStreamEndpoint(e.MethodStreaming)
+}
+`
+
+const MetricsStreamCode = `// serviceMetricsMethodStreamingServerStream counts the messages sent and
+// received by the "MethodStreaming" method server stream.
+type serviceMetricsMethodStreamingServerStream struct {
+	servicemetrics.MethodStreamingServerStream
+}
+
+// serviceMetricsMethodStreamingServerStreamEndpoint wraps the server stream
+// given to e with a serviceMetricsMethodStreamingServerStream.
+func serviceMetricsMethodStreamingServerStreamEndpoint(e goa.Endpoint) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		ep := req.(*servicemetrics.MethodStreamingEndpointInput)
+		ep.Stream = &serviceMetricsMethodStreamingServerStream{ep.Stream}
+		return e(ctx, req)
+	}
+}
+
+// Send counts the messages sent by the stream.
+func (s *serviceMetricsMethodStreamingServerStream) Send(v int) error {
+	if err := s.MethodStreamingServerStream.Send(v); err != nil {
+		return err
+	}
+	StreamMessages.WithLabelValues("ServiceMetrics", "MethodStreaming", "sent").Inc()
+	return nil
+}
+
+// Recv counts the messages received by the stream.
+func (s *serviceMetricsMethodStreamingServerStream) Recv() (string, error) {
+	v, err := s.MethodStreamingServerStream.Recv()
+	if err == nil {
+		StreamMessages.WithLabelValues("ServiceMetrics", "MethodStreaming", "received").Inc()
+	}
+	return v, err
+}
+`
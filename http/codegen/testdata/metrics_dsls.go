@@ -0,0 +1,35 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var MetricsDSL = func() {
+	API("MetricsAPI", func() {
+		Meta("metrics:prometheus")
+	})
+	Service("ServiceMetrics", func() {
+		Method("MethodUnary", func() {
+			Payload(String)
+			HTTP(func() {
+				POST("/unary/{id}")
+				POST("/other/{id}")
+			})
+		})
+		Method("MethodStreaming", func() {
+			StreamingPayload(String)
+			StreamingResult(Int)
+			HTTP(func() {
+				GET("/streaming")
+			})
+		})
+	})
+	Service("ServiceNoMetrics", func() {
+		Meta("metrics:prometheus", "false")
+		Method("MethodUnary", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
@@ -0,0 +1,26 @@
+package testdata
+
+const MockServerHTTPCode = `// serveHTTP serves the service mocks over HTTP on the given address.
+func serveHTTP(addr string, mockServerHTTPEndpoints *mockserverhttp.Endpoints, mockServerFilesEndpoints *mockserverfiles.Endpoints) error {
+	var (
+		dec = goahttp.RequestDecoder
+		enc = goahttp.PooledResponseEncoder
+		mux = goahttp.NewMuxer()
+		eh  = func(ctx context.Context, w http.ResponseWriter, err error) {
+			log.Printf("HTTP error: %s", err.Error())
+		}
+	)
+	mockServerHTTPServer := mockserverhttpsvr.New(mockServerHTTPEndpoints, mux, dec, enc, eh, &websocket.Upgrader{}, nil, nil)
+	mockserverhttpsvr.Mount(mux, mockServerHTTPServer)
+	for _, m := range mockServerHTTPServer.Mounts {
+		log.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
+	}
+	mockServerFilesServer := mockserverfilessvr.New(nil, mux, dec, enc, eh)
+	mockserverfilessvr.Mount(mux)
+	for _, m := range mockServerFilesServer.Mounts {
+		log.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
+	}
+	log.Printf("HTTP stub server listening on %q", addr)
+	return http.ListenAndServe(addr, mux)
+}
+`
@@ -0,0 +1,35 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var MockServerDSL = func() {
+	Service("MockServerHTTP", func() {
+		Method("Show", func() {
+			Payload(Int)
+			Result(String)
+			HTTP(func() {
+				GET("/{id}")
+			})
+		})
+		Method("Watch", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/watch")
+			})
+		})
+		Method("Upload", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				POST("/upload")
+				MultipartRequest()
+			})
+		})
+	})
+	Service("MockServerFiles", func() {
+		Files("/index.html", "index.html")
+	})
+}
@@ -137,3 +137,28 @@ var MultiDSL = func() {
 		})
 	})
 }
+
+var NamedExamplesDSL = func() {
+	Service("ServiceNamedExamples", func() {
+		Method("MethodNamedExamples", func() {
+			Payload(func() {
+				Attribute("id", String, func() {
+					Example("minimal", "a1")
+					Example("full", "b2")
+				})
+				Attribute("rating", Int, func() {
+					Example("full", 5)
+				})
+				Attribute("name", String, func() {
+					Example("minimal", "Lagavulin")
+					Example("full", "Lagavulin 16")
+				})
+				Required("id", "name")
+			})
+			HTTP(func() {
+				POST("/{id}")
+				Param("rating")
+			})
+		})
+	})
+}
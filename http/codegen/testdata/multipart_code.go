@@ -72,6 +72,9 @@ var MultipartUserTypeDecoderFuncCode = `// NewServiceMultipartUserTypeMethodMult
 // to decode the multipart request for the "ServiceMultipartUserType" service
 // "MethodMultipartUserType" endpoint.
 func NewServiceMultipartUserTypeMethodMultipartUserTypeDecoder(mux goahttp.Muxer, serviceMultipartUserTypeMethodMultipartUserTypeDecoderFn ServiceMultipartUserTypeMethodMultipartUserTypeDecoderFunc) func(r *http.Request) goahttp.Decoder {
+	if serviceMultipartUserTypeMethodMultipartUserTypeDecoderFn == nil {
+		serviceMultipartUserTypeMethodMultipartUserTypeDecoderFn = DefaultServiceMultipartUserTypeMethodMultipartUserTypeDecoderFunc
+	}
 	return func(r *http.Request) goahttp.Decoder {
 		return goahttp.EncodingFunc(func(v interface{}) error {
 			mr, merr := r.MultipartReader()
@@ -132,6 +135,9 @@ var MultipartWithParamDecoderFuncCode = `// NewServiceMultipartWithParamMethodMu
 // decoder to decode the multipart request for the "ServiceMultipartWithParam"
 // service "MethodMultipartWithParam" endpoint.
 func NewServiceMultipartWithParamMethodMultipartWithParamDecoder(mux goahttp.Muxer, serviceMultipartWithParamMethodMultipartWithParamDecoderFn ServiceMultipartWithParamMethodMultipartWithParamDecoderFunc) func(r *http.Request) goahttp.Decoder {
+	if serviceMultipartWithParamMethodMultipartWithParamDecoderFn == nil {
+		serviceMultipartWithParamMethodMultipartWithParamDecoderFn = DefaultServiceMultipartWithParamMethodMultipartWithParamDecoderFunc
+	}
 	return func(r *http.Request) goahttp.Decoder {
 		return goahttp.EncodingFunc(func(v interface{}) error {
 			mr, merr := r.MultipartReader()
@@ -187,6 +193,9 @@ var MultipartWithParamsAndHeadersDecoderFuncCode = `// NewServiceMultipartWithPa
 // "ServiceMultipartWithParamsAndHeaders" service
 // "MethodMultipartWithParamsAndHeaders" endpoint.
 func NewServiceMultipartWithParamsAndHeadersMethodMultipartWithParamsAndHeadersDecoder(mux goahttp.Muxer, serviceMultipartWithParamsAndHeadersMethodMultipartWithParamsAndHeadersDecoderFn ServiceMultipartWithParamsAndHeadersMethodMultipartWithParamsAndHeadersDecoderFunc) func(r *http.Request) goahttp.Decoder {
+	if serviceMultipartWithParamsAndHeadersMethodMultipartWithParamsAndHeadersDecoderFn == nil {
+		serviceMultipartWithParamsAndHeadersMethodMultipartWithParamsAndHeadersDecoderFn = DefaultServiceMultipartWithParamsAndHeadersMethodMultipartWithParamsAndHeadersDecoderFunc
+	}
 	return func(r *http.Request) goahttp.Decoder {
 		return goahttp.EncodingFunc(func(v interface{}) error {
 			mr, merr := r.MultipartReader()
@@ -275,6 +284,9 @@ var MultipartUserTypeEncoderFuncCode = `// NewServiceMultipartUserTypeMethodMult
 // to encode the multipart request for the "ServiceMultipartUserType" service
 // "MethodMultipartUserType" endpoint.
 func NewServiceMultipartUserTypeMethodMultipartUserTypeEncoder(encoderFn ServiceMultipartUserTypeMethodMultipartUserTypeEncoderFunc) func(r *http.Request) goahttp.Encoder {
+	if encoderFn == nil {
+		encoderFn = DefaultServiceMultipartUserTypeMethodMultipartUserTypeEncoderFunc
+	}
 	return func(r *http.Request) goahttp.Encoder {
 		body := &bytes.Buffer{}
 		mw := multipart.NewWriter(body)
@@ -334,6 +346,9 @@ var MultipartWithParamEncoderFuncCode = `// NewServiceMultipartWithParamMethodMu
 // encoder to encode the multipart request for the "ServiceMultipartWithParam"
 // service "MethodMultipartWithParam" endpoint.
 func NewServiceMultipartWithParamMethodMultipartWithParamEncoder(encoderFn ServiceMultipartWithParamMethodMultipartWithParamEncoderFunc) func(r *http.Request) goahttp.Encoder {
+	if encoderFn == nil {
+		encoderFn = DefaultServiceMultipartWithParamMethodMultipartWithParamEncoderFunc
+	}
 	return func(r *http.Request) goahttp.Encoder {
 		body := &bytes.Buffer{}
 		mw := multipart.NewWriter(body)
@@ -355,6 +370,9 @@ var MultipartWithParamsAndHeadersEncoderFuncCode = `// NewServiceMultipartWithPa
 // "ServiceMultipartWithParamsAndHeaders" service
 // "MethodMultipartWithParamsAndHeaders" endpoint.
 func NewServiceMultipartWithParamsAndHeadersMethodMultipartWithParamsAndHeadersEncoder(encoderFn ServiceMultipartWithParamsAndHeadersMethodMultipartWithParamsAndHeadersEncoderFunc) func(r *http.Request) goahttp.Encoder {
+	if encoderFn == nil {
+		encoderFn = DefaultServiceMultipartWithParamsAndHeadersMethodMultipartWithParamsAndHeadersEncoderFunc
+	}
 	return func(r *http.Request) goahttp.Encoder {
 		body := &bytes.Buffer{}
 		mw := multipart.NewWriter(body)
@@ -370,3 +388,251 @@ func NewServiceMultipartWithParamsAndHeadersMethodMultipartWithParamsAndHeadersE
 	}
 }
 `
+
+var MultipartDefaultDecoderCode = `// DefaultServiceMultipartDefaultMethodMultipartDefaultDecoderFunc is the
+// default decoder of the multipart requests sent to the
+// "ServiceMultipartDefault" service "MethodMultipartDefault" endpoint. It is
+// used when New is given a nil decoder function. The parts are mapped to the
+// payload attributes using their form names, parts with other names are
+// ignored.
+func DefaultServiceMultipartDefaultMethodMultipartDefaultDecoderFunc(mr *multipart.Reader, p **servicemultipartdefault.MethodMultipartDefaultPayload) error {
+	var (
+		name       *string
+		count      int
+		countFound bool
+		public     bool = true
+		file       []byte
+		fileFound  bool
+		tags       []int32
+		labels     map[string]string
+		err        error
+	)
+	for {
+		part, err2 := mr.NextPart()
+		if err2 == io.EOF {
+			break
+		}
+		if err2 != nil {
+			return goa.DecodePayloadError(err2.Error())
+		}
+		switch part.FormName() {
+		case "name":
+			b, err2 := ioutil.ReadAll(part)
+			if err2 != nil {
+				return goa.DecodePayloadError(err2.Error())
+			}
+			v := string(b)
+			name = &v
+		case "count":
+			b, err2 := ioutil.ReadAll(part)
+			if err2 != nil {
+				return goa.DecodePayloadError(err2.Error())
+			}
+			pv, err2 := strconv.ParseInt(string(b), 10, strconv.IntSize)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("count", string(b), "integer"))
+				continue
+			}
+			v := int(pv)
+			count = v
+			countFound = true
+		case "public":
+			b, err2 := ioutil.ReadAll(part)
+			if err2 != nil {
+				return goa.DecodePayloadError(err2.Error())
+			}
+			pv, err2 := strconv.ParseBool(string(b))
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("public", string(b), "boolean"))
+				continue
+			}
+			v := pv
+			public = v
+		case "file":
+			b, err2 := ioutil.ReadAll(part)
+			if err2 != nil {
+				return goa.DecodePayloadError(err2.Error())
+			}
+			v := b
+			file = v
+			fileFound = true
+		case "tags":
+			b, err2 := ioutil.ReadAll(part)
+			if err2 != nil {
+				return goa.DecodePayloadError(err2.Error())
+			}
+			pv, err2 := strconv.ParseInt(string(b), 10, 32)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("tags", string(b), "integer"))
+				continue
+			}
+			v := int32(pv)
+			tags = append(tags, v)
+		case "labels":
+			b, err2 := ioutil.ReadAll(part)
+			if err2 != nil {
+				return goa.DecodePayloadError(err2.Error())
+			}
+			var v map[string]string
+			if err2 := goahttp.JSON().Unmarshal(b, &v); err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("labels", string(b), "JSON"))
+				continue
+			}
+			labels = v
+		}
+	}
+	if !countFound {
+		err = goa.MergeErrors(err, goa.MissingFieldError("count", "multipart request"))
+	}
+	if !fileFound {
+		err = goa.MergeErrors(err, goa.MissingFieldError("file", "multipart request"))
+	}
+	if err != nil {
+		return err
+	}
+	*p = &servicemultipartdefault.MethodMultipartDefaultPayload{
+		Name:   name,
+		Count:  count,
+		Public: public,
+		File:   file,
+		Tags:   tags,
+		Labels: labels,
+	}
+	return nil
+}
+`
+
+var MultipartDefaultEncoderCode = `// DefaultServiceMultipartDefaultMethodMultipartDefaultEncoderFunc is the
+// default encoder of the multipart requests sent to the
+// "ServiceMultipartDefault" service "MethodMultipartDefault" endpoint. It is
+// used when the client endpoint is given a nil encoder function. It writes one
+// part per payload attribute using the attribute name as form name.
+func DefaultServiceMultipartDefaultMethodMultipartDefaultEncoderFunc(mw *multipart.Writer, p *servicemultipartdefault.MethodMultipartDefaultPayload) error {
+	if p.Name != nil {
+		v := *p.Name
+		if err := mw.WriteField("name", v); err != nil {
+			return err
+		}
+	}
+	{
+		v := p.Count
+		if err := mw.WriteField("count", strconv.Itoa(v)); err != nil {
+			return err
+		}
+	}
+	{
+		v := p.Public
+		if err := mw.WriteField("public", strconv.FormatBool(v)); err != nil {
+			return err
+		}
+	}
+	if p.File != nil {
+		fw, err := mw.CreateFormFile("file", "file")
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(p.File); err != nil {
+			return err
+		}
+	}
+	for _, v := range p.Tags {
+		if err := mw.WriteField("tags", strconv.FormatInt(int64(v), 10)); err != nil {
+			return err
+		}
+	}
+	if p.Labels != nil {
+		b, err := goahttp.JSON().Marshal(p.Labels)
+		if err != nil {
+			return err
+		}
+		if err := mw.WriteField("labels", string(b)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+`
+
+var MultipartStreamDecoderCode = `// DefaultServiceMultipartStreamMethodMultipartStreamDecoderFunc is the default
+// decoder of the multipart requests sent to the "ServiceMultipartStream"
+// service "MethodMultipartStream" endpoint. It is used when New is given a nil
+// decoder function. The parts are mapped to the payload attributes using their
+// form names, parts with other names are ignored.
+func DefaultServiceMultipartStreamMethodMultipartStreamDecoderFunc(mr *multipart.Reader, p **servicemultipartstream.MethodMultipartStreamPayload) error {
+	var (
+		name      *string
+		file      io.ReadCloser
+		fileFound bool
+		err       error
+	)
+	defer func() {
+		if c, ok := file.(io.Closer); ok && *p == nil {
+			c.Close()
+		}
+	}()
+	for {
+		part, err2 := mr.NextPart()
+		if err2 == io.EOF {
+			break
+		}
+		if err2 != nil {
+			return goa.DecodePayloadError(err2.Error())
+		}
+		switch part.FormName() {
+		case "name":
+			b, err2 := ioutil.ReadAll(part)
+			if err2 != nil {
+				return goa.DecodePayloadError(err2.Error())
+			}
+			v := string(b)
+			name = &v
+		case "file":
+			if c, ok := file.(io.Closer); ok {
+				c.Close()
+			}
+			v, err2 := goahttp.SpoolPart(part, goahttp.DefaultPartMemory)
+			if err2 != nil {
+				return goa.DecodePayloadError(err2.Error())
+			}
+			file = v
+			fileFound = true
+		}
+	}
+	if !fileFound {
+		err = goa.MergeErrors(err, goa.MissingFieldError("file", "multipart request"))
+	}
+	if err != nil {
+		return err
+	}
+	*p = &servicemultipartstream.MethodMultipartStreamPayload{
+		Name: name,
+		File: file,
+	}
+	return nil
+}
+`
+
+var MultipartStreamEncoderCode = `// DefaultServiceMultipartStreamMethodMultipartStreamEncoderFunc is the default
+// encoder of the multipart requests sent to the "ServiceMultipartStream"
+// service "MethodMultipartStream" endpoint. It is used when the client
+// endpoint is given a nil encoder function. It writes one part per payload
+// attribute using the attribute name as form name.
+func DefaultServiceMultipartStreamMethodMultipartStreamEncoderFunc(mw *multipart.Writer, p *servicemultipartstream.MethodMultipartStreamPayload) error {
+	if p.Name != nil {
+		v := *p.Name
+		if err := mw.WriteField("name", v); err != nil {
+			return err
+		}
+	}
+	if p.File != nil {
+		fw, err := mw.CreateFormFile("file", "file")
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(fw, p.File); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+`
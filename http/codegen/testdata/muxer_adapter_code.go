@@ -0,0 +1,51 @@
+package testdata
+
+var ChiMuxerAdapterCode = `// New returns a goa Muxer that registers the handlers of the generated servers
+// with r. Middleware added to r runs before the middleware mounted on the
+// generated servers.
+func New(r chi.Router) goahttp.Muxer {
+	return goahttp.NewMuxAdapter(goahttp.ChiSyntax, r, func(method, pattern string, h goahttp.RouteHandler) {
+		r.Method(method, pattern, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			h(w, req, func(name string) string { return chi.URLParam(req, name) })
+		}))
+	})
+}
+`
+
+var EchoMuxerAdapterCode = `// New returns a goa Muxer that registers the handlers of the generated servers
+// with e. Middleware added to e runs before the middleware mounted on the
+// generated servers.
+func New(e *echo.Echo) goahttp.Muxer {
+	return goahttp.NewMuxAdapter(goahttp.EchoSyntax, e, func(method, pattern string, h goahttp.RouteHandler) {
+		e.Add(method, pattern, func(c echo.Context) error {
+			h(c.Response(), c.Request(), c.Param)
+			return nil
+		})
+	})
+}
+`
+
+var GinMuxerAdapterCode = `// New returns a goa Muxer that registers the handlers of the generated servers
+// with g. Middleware added to g runs before the middleware mounted on the
+// generated servers.
+func New(g *gin.Engine) goahttp.Muxer {
+	return goahttp.NewMuxAdapter(goahttp.GinSyntax, g, func(method, pattern string, h goahttp.RouteHandler) {
+		g.Handle(method, pattern, func(c *gin.Context) {
+			h(c.Writer, c.Request, c.Param)
+		})
+	})
+}
+`
+
+var GorillaMuxerAdapterCode = `// New returns a goa Muxer that registers the handlers of the generated servers
+// with r. Middleware added to r runs before the middleware mounted on the
+// generated servers.
+func New(r *mux.Router) goahttp.Muxer {
+	return goahttp.NewMuxAdapter(goahttp.GorillaSyntax, r, func(method, pattern string, h goahttp.RouteHandler) {
+		r.Methods(method).Path(pattern).HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			vars := mux.Vars(req)
+			h(w, req, func(name string) string { return vars[name] })
+		})
+	})
+}
+`
@@ -0,0 +1,20 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var MuxerAdaptersDSL = func() {
+	API("API", func() {
+		HTTP(func() {
+			MuxerAdapters("chi", "echo", "gin", "gorilla")
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
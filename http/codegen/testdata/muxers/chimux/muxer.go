@@ -0,0 +1,23 @@
+// Code generated by goa, DO NOT EDIT.
+//
+// chi router adapter
+
+package chimux
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	goahttp "goa.design/goa/v3/http"
+)
+
+// New returns a goa Muxer that registers the handlers of the generated servers
+// with r. Middleware added to r runs before the middleware mounted on the
+// generated servers.
+func New(r chi.Router) goahttp.Muxer {
+	return goahttp.NewMuxAdapter(goahttp.ChiSyntax, r, func(method, pattern string, h goahttp.RouteHandler) {
+		r.Method(method, pattern, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			h(w, req, func(name string) string { return chi.URLParam(req, name) })
+		}))
+	})
+}
@@ -0,0 +1,22 @@
+// Code generated by goa, DO NOT EDIT.
+//
+// echo router adapter
+
+package echomux
+
+import (
+	"github.com/labstack/echo/v4"
+	goahttp "goa.design/goa/v3/http"
+)
+
+// New returns a goa Muxer that registers the handlers of the generated servers
+// with e. Middleware added to e runs before the middleware mounted on the
+// generated servers.
+func New(e *echo.Echo) goahttp.Muxer {
+	return goahttp.NewMuxAdapter(goahttp.EchoSyntax, e, func(method, pattern string, h goahttp.RouteHandler) {
+		e.Add(method, pattern, func(c echo.Context) error {
+			h(c.Response(), c.Request(), c.Param)
+			return nil
+		})
+	})
+}
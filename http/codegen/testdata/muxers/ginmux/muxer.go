@@ -0,0 +1,21 @@
+// Code generated by goa, DO NOT EDIT.
+//
+// gin router adapter
+
+package ginmux
+
+import (
+	"github.com/gin-gonic/gin"
+	goahttp "goa.design/goa/v3/http"
+)
+
+// New returns a goa Muxer that registers the handlers of the generated servers
+// with g. Middleware added to g runs before the middleware mounted on the
+// generated servers.
+func New(g *gin.Engine) goahttp.Muxer {
+	return goahttp.NewMuxAdapter(goahttp.GinSyntax, g, func(method, pattern string, h goahttp.RouteHandler) {
+		g.Handle(method, pattern, func(c *gin.Context) {
+			h(c.Writer, c.Request, c.Param)
+		})
+	})
+}
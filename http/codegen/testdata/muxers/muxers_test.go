@@ -0,0 +1,83 @@
+package muxers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/mux"
+	"github.com/labstack/echo/v4"
+	goahttp "goa.design/goa/v3/http"
+	"goa.design/goa/v3/http/codegen/testdata/muxers/chimux"
+	"goa.design/goa/v3/http/codegen/testdata/muxers/echomux"
+	"goa.design/goa/v3/http/codegen/testdata/muxers/ginmux"
+	"goa.design/goa/v3/http/codegen/testdata/muxers/gorillamux"
+)
+
+// routes lists the patterns registered by the generated servers in the order
+// the generated code mounts them, see the Mount functions generated for the
+// endpoints and for the file servers.
+var routes = []struct{ Method, Pattern string }{
+	{"GET", "/users/{id}"},
+	{"POST", "/users/{id}"},
+	{"GET", "/users/{id}/files/{*path}"},
+	{"GET", "/favicon.ico"},
+	{"GET", "/static/{*path}"},
+	{"GET", "/static/"},
+	{"GET", "/app/{*filepath}"},
+	{"GET", "/app/"},
+}
+
+func TestMuxerAdapters(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	paths := []struct{ Method, Path string }{
+		{"GET", "/users/42"},
+		{"POST", "/users/42"},
+		{"GET", "/users/42/files/a/b.txt"},
+		{"GET", "/favicon.ico"},
+		{"GET", "/static/css/main.css"},
+		{"GET", "/static/"},
+		{"GET", "/app/index.html"},
+		{"GET", "/app/"},
+	}
+	adapters := map[string]func() goahttp.Muxer{
+		"chi":     func() goahttp.Muxer { return chimux.New(chi.NewRouter()) },
+		"echo":    func() goahttp.Muxer { return echomux.New(echo.New()) },
+		"gin":     func() goahttp.Muxer { return ginmux.New(gin.New()) },
+		"gorilla": func() goahttp.Muxer { return gorillamux.New(mux.NewRouter()) },
+	}
+	serve := func(m goahttp.Muxer, method, path string) string {
+		w := httptest.NewRecorder()
+		m.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return fmt.Sprintf("%d %s", w.Code, w.Body.String())
+	}
+	register := func(m goahttp.Muxer) {
+		for _, r := range routes {
+			method := r.Method
+			m.Handle(r.Method, r.Pattern, func(w http.ResponseWriter, req *http.Request) {
+				vars := m.Vars(req)
+				// The directory file servers mount the same handler
+				// for both patterns and serve the index when the
+				// path variable is empty or missing.
+				fmt.Fprintf(w, "%s id=%q path=%q filepath=%q", method, vars["id"], vars["path"], vars["filepath"])
+			})
+		}
+	}
+
+	expected := goahttp.NewMuxer()
+	register(expected)
+	for name, newMuxer := range adapters {
+		t.Run(name, func(t *testing.T) {
+			m := newMuxer()
+			register(m)
+			for _, p := range paths {
+				if got, want := serve(m, p.Method, p.Path), serve(expected, p.Method, p.Path); got != want {
+					t.Errorf("%s %s: got %q, expected %q", p.Method, p.Path, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package testdata
+
+const OAuth2TokenServerCode = `// TokenPath is the path of the "oauth2" security scheme token endpoint.
+const TokenPath = "/oauth/token"
+
+type (
+	// TokenRequest is an access token request, see RFC 6749 section 4.
+	TokenRequest struct {
+		// GrantType is the value of the grant_type parameter.
+		GrantType string
+		// ClientID is the client identifier read from the basic
+		// authorization header or from the client_id parameter.
+		ClientID string
+		// ClientSecret is the client secret read from the basic
+		// authorization header or from the client_secret parameter.
+		ClientSecret string
+		// Scopes lists the requested scopes.
+		Scopes []string
+		// RefreshToken is the refresh token of refresh_token requests.
+		RefreshToken string
+	}
+
+	// TokenResponse is a successful access token response, see RFC 6749
+	// section 5.1.
+	TokenResponse struct {
+		// AccessToken is the issued access token.
+		AccessToken string ` + "`" + `json:"access_token"` + "`" + `
+		// TokenType is the type of the token, "Bearer" if empty.
+		TokenType string ` + "`" + `json:"token_type"` + "`" + `
+		// ExpiresIn is the lifetime of the access token in seconds.
+		ExpiresIn int ` + "`" + `json:"expires_in,omitempty"` + "`" + `
+		// RefreshToken is the refresh token if any.
+		RefreshToken string ` + "`" + `json:"refresh_token,omitempty"` + "`" + `
+		// Scope lists the granted scopes separated with spaces if they
+		// differ from the requested scopes.
+		Scope string ` + "`" + `json:"scope,omitempty"` + "`" + `
+	}
+
+	// TokenError is an access token error response, see RFC 6749 section
+	// 5.2.
+	TokenError struct {
+		// Code is the error code, e.g. "invalid_grant".
+		Code string ` + "`" + `json:"error"` + "`" + `
+		// Description describes the error.
+		Description string ` + "`" + `json:"error_description,omitempty"` + "`" + `
+	}
+
+	// Issuer issues the access tokens of the "oauth2" security scheme.
+	Issuer interface {
+		// ClientCredentials issues an access token for the client credentials (RFC
+		// 6749 section 4.4.2).
+		ClientCredentials(ctx context.Context, req *TokenRequest) (*TokenResponse, error)
+		// RefreshToken issues a new access token for a refresh token (RFC 6749 section
+		// 6).
+		RefreshToken(ctx context.Context, req *TokenRequest) (*TokenResponse, error)
+	}
+)
+
+// NewTokenError returns a token error with the given code and description.
+func NewTokenError(code, description string) *TokenError {
+	return &TokenError{Code: code, Description: description}
+}
+
+// Error returns the error code and description.
+func (e *TokenError) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
+
+// NewTokenHandler returns the HTTP handler of the token endpoint. The handler
+// decodes the form encoded token requests and dispatches them to the issuer
+// according to their grant type. The issuer should return a *TokenError to
+// reject a request, other errors produce "server_error" responses.
+func NewTokenHandler(issuer Issuer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			w.Header().Set("Allow", "POST")
+			writeTokenJSON(w, http.StatusMethodNotAllowed, NewTokenError("invalid_request", "token requests must use POST"))
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			writeTokenJSON(w, http.StatusBadRequest, NewTokenError("invalid_request", err.Error()))
+			return
+		}
+		req := &TokenRequest{
+			GrantType:    r.PostForm.Get("grant_type"),
+			ClientID:     r.PostForm.Get("client_id"),
+			ClientSecret: r.PostForm.Get("client_secret"),
+			Scopes:       strings.Fields(r.PostForm.Get("scope")),
+			RefreshToken: r.PostForm.Get("refresh_token"),
+		}
+		if id, secret, ok := r.BasicAuth(); ok {
+			// The client credentials are form encoded, see RFC 6749
+			// section 2.3.1.
+			var err1, err2 error
+			req.ClientID, err1 = url.QueryUnescape(id)
+			req.ClientSecret, err2 = url.QueryUnescape(secret)
+			if err1 != nil || err2 != nil {
+				writeTokenJSON(w, http.StatusUnauthorized, NewTokenError("invalid_client", "invalid client credentials encoding"))
+				return
+			}
+		}
+		var (
+			res *TokenResponse
+			err error
+		)
+		switch req.GrantType {
+		case "client_credentials":
+			res, err = issuer.ClientCredentials(r.Context(), req)
+		case "refresh_token":
+			res, err = issuer.RefreshToken(r.Context(), req)
+		case "":
+			err = NewTokenError("invalid_request", "missing grant_type parameter")
+		default:
+			err = NewTokenError("unsupported_grant_type", fmt.Sprintf("unsupported grant type %q", req.GrantType))
+		}
+		if err != nil {
+			terr, ok := err.(*TokenError)
+			if !ok {
+				writeTokenJSON(w, http.StatusInternalServerError, NewTokenError("server_error", ""))
+				return
+			}
+			status := http.StatusBadRequest
+			if terr.Code == "invalid_client" {
+				status = http.StatusUnauthorized
+			}
+			writeTokenJSON(w, status, terr)
+			return
+		}
+		if res.TokenType == "" {
+			res.TokenType = "Bearer"
+		}
+		writeTokenJSON(w, http.StatusOK, res)
+	})
+}
+
+// Mount configures the mux to serve the token endpoint with the given handler.
+func Mount(mux goahttp.Muxer, h http.Handler) {
+	mux.Handle("POST", TokenPath, h.ServeHTTP)
+}
+
+// writeTokenJSON writes a token endpoint response, the responses must not be
+// cached (RFC 6749 section 5.1).
+func writeTokenJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+`
+
+const OAuth2TokenClientCode = `// expiryDelta is the duration before the expiration of the access tokens at
+// which Client retrieves new tokens.
+const expiryDelta = 10 * time.Second
+
+type (
+	// Client retrieves access tokens from the token endpoint with the client
+	// credentials grant and caches them until they expire.
+	Client struct {
+		// URL is the URL of the token endpoint.
+		URL string
+		// ClientID is the client identifier.
+		ClientID string
+		// ClientSecret is the client secret.
+		ClientSecret string
+		// Scopes lists the requested scopes.
+		Scopes []string
+		// Doer is the HTTP client used to send the token requests.
+		Doer goahttp.Doer
+
+		mu     sync.Mutex
+		token  *TokenResponse
+		expiry time.Time
+	}
+
+	// tokenDoer is a Doer that sets the access token of the requests.
+	tokenDoer struct {
+		client *Client
+		doer   goahttp.Doer
+	}
+)
+
+// NewClient returns a client that retrieves access tokens from the token
+// endpoint of the API served at the given scheme and host.
+func NewClient(scheme, host string, doer goahttp.Doer, clientID, clientSecret string, scopes ...string) *Client {
+	u := &url.URL{Scheme: scheme, Host: host, Path: TokenPath}
+	return &Client{
+		URL:          u.String(),
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scopes:       scopes,
+		Doer:         doer,
+	}
+}
+
+// Token returns the cached access token or retrieves a new one if the token
+// expired, using the refresh token if any.
+func (c *Client) Token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token != nil && (c.expiry.IsZero() || time.Now().Before(c.expiry)) {
+		return c.token.AccessToken, nil
+	}
+	var (
+		tok *TokenResponse
+		err error
+	)
+	if c.token != nil && c.token.RefreshToken != "" {
+		tok, err = c.request(ctx, url.Values{"grant_type": {"refresh_token"}, "refresh_token": {c.token.RefreshToken}})
+	}
+	if tok == nil {
+		// No refresh token or the refresh failed, use the client
+		// credentials.
+		tok, err = c.request(ctx, url.Values{"grant_type": {"client_credentials"}})
+	}
+	if err != nil {
+		c.token = nil
+		return "", err
+	}
+	c.token = tok
+	c.expiry = time.Time{}
+	if exp := time.Duration(tok.ExpiresIn) * time.Second; exp > 0 {
+		if exp > 2*expiryDelta {
+			exp -= expiryDelta
+		}
+		c.expiry = time.Now().Add(exp)
+	}
+	return tok.AccessToken, nil
+}
+
+// Wrap returns a Doer that sets the Authorization header of the requests that
+// do not define an access token with a bearer token retrieved with Token. The
+// service clients created with the returned Doer retrieve and refresh the
+// access tokens automatically.
+func (c *Client) Wrap(doer goahttp.Doer) goahttp.Doer {
+	return &tokenDoer{client: c, doer: doer}
+}
+
+// Do sets the access token if needed and sends the request.
+func (d *tokenDoer) Do(req *http.Request) (*http.Response, error) {
+	if strings.TrimSpace(strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer")) == "" {
+		tok, err := d.client.Token(req.Context())
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+tok)
+	}
+	return d.doer.Do(req)
+}
+
+// request sends a token request with the given parameters.
+func (c *Client) request(ctx context.Context, form url.Values) (*TokenResponse, error) {
+	if len(c.Scopes) > 0 {
+		form.Set("scope", strings.Join(c.Scopes, " "))
+	}
+	req, err := http.NewRequest("POST", c.URL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(c.ClientID), url.QueryEscape(c.ClientSecret))
+	resp, err := c.Doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var terr TokenError
+		if err := json.NewDecoder(resp.Body).Decode(&terr); err != nil || terr.Code == "" {
+			return nil, fmt.Errorf("token request failed: %s", resp.Status)
+		}
+		return nil, &terr
+	}
+	var tok TokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tok); err != nil {
+		return nil, fmt.Errorf("invalid token response: %s", err)
+	}
+	if tok.AccessToken == "" {
+		return nil, fmt.Errorf("invalid token response: missing access token")
+	}
+	return &tok, nil
+}
+`
@@ -0,0 +1,67 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var OAuth2ClientCredentialsDSL = func() {
+	var OAuth2 = OAuth2Security("oauth2", func() {
+		ClientCredentialsFlow("/oauth/token", "/oauth/token")
+		Scope("api:read", "Read access")
+	})
+	Service("ServiceOAuth2", func() {
+		Method("Method", func() {
+			Security(OAuth2)
+			Payload(func() {
+				AccessToken("token", String)
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var OAuth2HostedFlowsDSL = func() {
+	var _ = API("test", func() {
+		Server("test", func() {
+			Host("prod", func() {
+				URI("https://api.goa.design")
+			})
+		})
+	})
+	var OAuth2 = OAuth2Security("my-auth", func() {
+		AuthorizationCodeFlow("https://auth.goa.design/authorize", "https://api.goa.design/token", "")
+		PasswordFlow("https://api.goa.design/token", "")
+		ImplicitFlow("https://auth.goa.design/authorize", "")
+	})
+	Service("ServiceOAuth2", func() {
+		Method("Method", func() {
+			Security(OAuth2)
+			Payload(func() {
+				AccessToken("token", String)
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var OAuth2ExternalDSL = func() {
+	var OAuth2 = OAuth2Security("oauth2", func() {
+		ClientCredentialsFlow("https://auth.goa.design/token", "")
+		ImplicitFlow("/authorize", "")
+	})
+	Service("ServiceOAuth2", func() {
+		Method("Method", func() {
+			Security(OAuth2)
+			Payload(func() {
+				AccessToken("token", String)
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
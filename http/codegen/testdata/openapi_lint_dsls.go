@@ -0,0 +1,62 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var OpenAPILintDSL = func() {
+	API("test", func() {
+		Description("Lint test API")
+		Contact(func() {
+			Name("goa")
+		})
+		License(func() {
+			Name("MIT")
+		})
+		Meta("openapi:lint")
+		Meta("openapi:lint:goa-property-description", "warn")
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Description("Test endpoint")
+			Payload(String)
+			HTTP(func() {
+				GET("/{p}")
+			})
+		})
+	})
+}
+
+var OpenAPILintErrorDSL = func() {
+	API("test", func() {
+		Meta("openapi:lint")
+		Meta("openapi:lint:operation-description", "error")
+	})
+	Service("testService", func() {
+		Method("described", func() {
+			Description("Described endpoint")
+			HTTP(func() {
+				GET("/described")
+			})
+		})
+		Method("undescribed", func() {
+			HTTP(func() {
+				GET("/undescribed")
+			})
+		})
+	})
+}
+
+var OpenAPILintInvalidRuleDSL = func() {
+	API("test", func() {
+		Meta("openapi:lint")
+		Meta("openapi:lint:unknown", "error")
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
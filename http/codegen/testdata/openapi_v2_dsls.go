@@ -305,6 +305,398 @@ var ExtensionDSL = func() {
 	})
 }
 
+var OpenAPIExtensionDSL = func() {
+	var PayloadT = Type("Payload", func() {
+		Attribute("string", String, func() {
+			Example("")
+			Meta("openapi:extension:x-test-schema", "Payload")
+		})
+	})
+	var _ = API("test", func() {
+		Server("test", func() {
+			Host("localhost", func() {
+				URI("https://goa.design")
+			})
+		})
+		Meta("openapi:extension:x-test-api", "API")
+	})
+	Service("testService", func() {
+		Meta("openapi:extension:x-test-service", "Service")
+		Method("testEndpoint", func() {
+			Payload(PayloadT)
+			Result(String)
+			HTTP(func() {
+				POST("/")
+				Meta("openapi:extension:x-test-path", "Path")
+				Response(StatusOK, func() {
+					Meta("openapi:extension:x-test-response", "Response")
+				})
+			})
+			Meta("openapi:extension:x-amazon-apigateway-integration", `{"type":"http_proxy","httpMethod":"POST","uri":"https://backend.goa.design"}`)
+			Meta("swagger:extension:x-test-operation", "Swagger")
+			Meta("openapi:extension:x-test-operation", "OpenAPI")
+		})
+	})
+}
+
+var CORSExtensionDSL = func() {
+	var _ = API("test", func() {
+		Server("test", func() {
+			Host("localhost", func() {
+				URI("https://goa.design")
+			})
+		})
+		HTTP(func() {
+			Origin("*")
+		})
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+		Method("testPrivateEndpoint", func() {
+			HTTP(func() {
+				POST("/private")
+				Origin("https://goa.design", func() {
+					AllowMethods("POST")
+					AllowHeaders("X-Secret")
+					MaxAge(600)
+					AllowCredentials()
+					AllowPrivateNetwork()
+				})
+			})
+		})
+	})
+}
+
+var SecurityFlowsDSL = func() {
+	var _ = API("test", func() {
+		Meta("openapi:version", "3.1")
+	})
+
+	var OIDCAuth = JWTSecurity("oidc", func() {
+		OpenIDConnect("https://accounts.goa.design/.well-known/openid-configuration")
+		Scope("openid", "OpenID Connect authentication")
+	})
+
+	var OAuth2Auth = OAuth2Security("oauth2", func() {
+		AuthorizationCodeFlow("http://goa.design/authorization", "http://goa.design/token", "http://goa.design/refresh", func() {
+			Scope("api:read", "Read-only access")
+			Scope("api:write", "Read and write access")
+		})
+		ClientCredentialsFlow("http://goa.design/token", "", func() {
+			Scope("api:read", "Read-only access")
+		})
+	})
+
+	var APIKeyAuth = APIKeySecurity("api_key")
+
+	Service("testService", func() {
+		Method("read", func() {
+			Security(OAuth2Auth, APIKeyAuth, func() {
+				Scope("api:read")
+			})
+			Security(OIDCAuth, func() {
+				Scope("openid")
+			})
+			Payload(func() {
+				APIKey("api_key", "key", String)
+				AccessToken("oauth_token", String)
+				Token("token", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Param("key")
+				Header("oauth_token:X-Access-Token")
+				Header("token:Authorization")
+			})
+		})
+		Method("write", func() {
+			Security(OAuth2Auth, func() {
+				Scope("api:write")
+			})
+			Payload(func() {
+				AccessToken("oauth_token", String)
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var SecurityScopesDSL = func() {
+	var _ = API("test", func() {
+		Meta("openapi:version", "3.1")
+	})
+
+	var JWTAuth = JWTSecurity("jwt", func() {
+		WildcardScopes()
+		Scope("orders:*", "Full access to orders")
+		Scope("orders:read", "Read orders")
+		Scope("orders:write", "Write orders")
+	})
+
+	var OAuth2Auth = OAuth2Security("oauth2", func() {
+		WildcardScopes()
+		AuthorizationCodeFlow("http://goa.design/authorization", "http://goa.design/token", "http://goa.design/refresh", func() {
+			Scope("*", "Full access")
+			Scope("orders:read", "Read orders")
+		})
+	})
+
+	Service("testService", func() {
+		Method("read", func() {
+			Security(JWTAuth, func() {
+				Scope("orders:read")
+			})
+			Security(OAuth2Auth, func() {
+				Scope("orders:read")
+			})
+			Payload(func() {
+				Token("token", String)
+				AccessToken("oauth_token", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Header("oauth_token:X-Access-Token")
+			})
+		})
+	})
+}
+
+var SecurityMTLSDSL = func() {
+	var _ = API("test", func() {
+		Meta("openapi:version", "3.1")
+	})
+
+	var MTLS = MTLSSecurity("mtls", func() {
+		Description("Client certificate issued by the internal CA")
+	})
+
+	var JWTAuth = JWTSecurity("jwt")
+
+	Service("testService", func() {
+		Method("charge", func() {
+			Security(MTLS)
+			Payload(func() {
+				ClientCertificate("cert", String)
+				Attribute("amount", Int)
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+		Method("status", func() {
+			Security(MTLS)
+			Security(JWTAuth)
+			Payload(func() {
+				ClientCertificate("cert", String)
+				Token("token", String)
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var SecuritySignatureDSL = func() {
+	var _ = API("test", func() {
+		Meta("openapi:version", "3.1")
+	})
+
+	var Signed = SignatureSecurity("signed", func() {
+		Description("Requests signed with the partner secret")
+		ClockSkew("2m")
+		ReplayWindow("10m")
+	})
+
+	Service("testService", func() {
+		Method("transfer", func() {
+			Security(Signed)
+			Payload(func() {
+				Attribute("amount", Int)
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var SecurityCSRFDSL = func() {
+	var _ = API("test", func() {
+		Meta("openapi:version", "3.1")
+	})
+
+	var Session = APIKeySecurity("session", func() {
+		Description("Session cookie")
+	})
+
+	Service("testService", func() {
+		Security(Session)
+		Method("show", func() {
+			Payload(func() {
+				APIKey("session", "key", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Cookie("key:SID")
+			})
+		})
+		Method("update", func() {
+			Payload(func() {
+				APIKey("session", "key", String)
+			})
+			HTTP(func() {
+				PUT("/")
+				Cookie("key:SID")
+				CSRF(CSRFSynchronizer, func() {
+					CSRFHeader("X-XSRF-Token")
+				})
+			})
+		})
+	})
+}
+
+var SecurityChainDSL = func() {
+	var _ = API("test", func() {
+		Meta("openapi:version", "3.1")
+	})
+
+	var JWT = JWTSecurity("jwt", func() {
+		Scope("api:read")
+	})
+	var Key = APIKeySecurity("api_key")
+
+	Service("testService", func() {
+		Method("list", func() {
+			Security(JWT, func() {
+				Scope("api:read")
+			})
+			Security(Key)
+			Optional()
+			Payload(func() {
+				Token("token", String)
+				APIKey("api_key", "key", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Header("key:X-API-Key")
+			})
+		})
+	})
+}
+
+var APIKeyLocationsOpenAPIDSL = func() {
+	var _ = API("test", func() {
+		Meta("openapi:version", "3.1")
+	})
+
+	var Key = APIKeySecurity("api_key", func() {
+		KeyLocation("header", "X-API-Key")
+		KeyLocation("query", "api_key")
+	})
+
+	Service("testService", func() {
+		Method("list", func() {
+			Security(Key)
+			Payload(func() {
+				APIKey("api_key", "key", String)
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var SecurityRealmDSL = func() {
+	var _ = API("test", func() {
+		Realm("Test API")
+	})
+
+	var Basic = BasicAuthSecurity("basic")
+
+	Service("admin", func() {
+		Realm("Administration")
+		Method("login", func() {
+			Security(Basic)
+			Payload(func() {
+				Username("user", String)
+				Password("pass", String)
+			})
+			HTTP(func() {
+				POST("/admin/login")
+			})
+		})
+	})
+
+	Service("reports", func() {
+		Method("list", func() {
+			Security(Basic)
+			Payload(func() {
+				Username("user", String)
+				Password("pass", String)
+			})
+			HTTP(func() {
+				GET("/reports")
+			})
+		})
+	})
+}
+
+var TagGroupsDSL = func() {
+	var _ = API("test", func() {
+		Meta("openapi:version", "3.1")
+		Tag("billing", func() {
+			Description("Billing operations")
+			Docs(func() {
+				Description("Billing guide")
+				URL("https://goa.design/billing")
+			})
+			Tag("invoices", func() {
+				Description("Invoice management")
+			})
+			Tag("payments")
+		})
+		Tag("accounts", func() {
+			Description("Account management")
+		})
+	})
+	Service("billingService", func() {
+		Tag("billing")
+		Method("pay", func() {
+			Tag("payments")
+			Docs(func() {
+				Description("Payment guide")
+				URL("https://goa.design/billing/payments")
+			})
+			HTTP(func() {
+				POST("/payments")
+			})
+		})
+		Method("invoice", func() {
+			Tag("invoices")
+			Tag("billing")
+			HTTP(func() {
+				GET("/invoices")
+			})
+		})
+	})
+	Service("accountService", func() {
+		Method("show", func() {
+			Tag("accounts")
+			HTTP(func() {
+				GET("/accounts")
+			})
+		})
+	})
+}
+
 var SecurityDSL = func() {
 	var JWTAuth = JWTSecurity("jwt", func() {
 		Description(`Secures endpoint by requiring a valid JWT token retrieved via the signin endpoint. Supports scopes "api:read" and "api:write".`)
@@ -432,3 +824,43 @@ var WithMapDSL = func() {
 		})
 	})
 }
+
+var SplitDSL = func() {
+	var _ = API("test", func() {
+		Meta("openapi:split")
+	})
+	var Owner = Type("Owner", func() {
+		Attribute("name", String)
+	})
+	var Pet = Type("Pet", func() {
+		Attribute("name", String)
+		Attribute("owner", Owner)
+	})
+	Service("pets", func() {
+		Method("show", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			Result(Pet)
+			HTTP(func() {
+				GET("/pets/{id}")
+			})
+		})
+	})
+	Service("store", func() {
+		Method("list", func() {
+			Result(ArrayOf(Pet))
+			HTTP(func() {
+				GET("/store/pets")
+			})
+		})
+		Method("delete", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				DELETE("/pets/{id}")
+			})
+		})
+	})
+}
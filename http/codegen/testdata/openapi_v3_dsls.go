@@ -0,0 +1,207 @@
+package testdata
+
+import . "goa.design/goa/v3/dsl"
+
+var OpenAPIV3DSL = func() {
+	var JWTAuth = JWTSecurity("jwt", func() {
+		Description("Secures endpoint by requiring a valid JWT token.")
+		Scope("api:read", "Read-only access")
+	})
+	var _ = API("test", func() {
+		Meta("openapi:version", "3.1")
+		Server("test", func() {
+			Host("localhost", func() {
+				URI("https://{version}.goa.design")
+				Variable("version", String, "API Version", func() {
+					Enum("v1", "v2")
+				})
+			})
+		})
+	})
+	var Pet = Type("Pet", func() {
+		Attribute("name", String, func() {
+			Example("Fido")
+		})
+		Attribute("kind", String, func() {
+			Enum("dog")
+		})
+		Attribute("owner", String, func() {
+			Meta("openapi:nullable")
+			Example("Jane")
+		})
+		Required("name", "kind")
+	})
+	Service("pets", func() {
+		Method("create", func() {
+			Security(JWTAuth)
+			Payload(func() {
+				Token("token", String)
+				Attribute("pet", Pet)
+				Attribute("tags", ArrayOf(String))
+				Required("pet")
+			})
+			Result(Pet)
+			HTTP(func() {
+				POST("/pets")
+				Param("tags")
+				Body("pet")
+				Response(StatusCreated)
+			})
+		})
+		Method("adopted", func() {
+			Meta("openapi:webhook", "petAdopted")
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("pet", Pet)
+			})
+			HTTP(func() {
+				POST("/adopted/{id}")
+				Body("pet")
+			})
+		})
+	})
+}
+
+var OpenAPIInvalidVersionDSL = func() {
+	var _ = API("test", func() {
+		Meta("openapi:version", "4.0")
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var OpenAPIV3WebhookDSL = func() {
+	var _ = API("test", func() {
+		Meta("openapi:version", "3.1")
+	})
+	var Item = Type("Item", func() {
+		Attribute("sku", String)
+		Required("sku")
+	})
+	Service("orders", func() {
+		Webhook("order.created", func() {
+			Description("Sent when an order is created.")
+			Body(func() {
+				Attribute("id", String, "Order ID")
+				Attribute("items", ArrayOf(Item))
+				Required("id")
+			})
+		})
+		Method("show", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/orders")
+			})
+		})
+	})
+}
+
+var OpenAPIV3NamedExamplesDSL = func() {
+	var _ = API("test", func() {
+		Meta("openapi:version", "3.1")
+	})
+	var Bottle = Type("Bottle", func() {
+		Attribute("name", String, func() {
+			Example("minimal", "Lagavulin")
+			Example("full", "Lagavulin 16")
+		})
+		Attribute("vintage", Int, func() {
+			Example("full", 2004)
+		})
+		Attribute("rating", Int, func() {
+			Example(4)
+		})
+		Required("name")
+	})
+	Service("storage", func() {
+		Method("add", func() {
+			Payload(func() {
+				Attribute("account", String, func() {
+					Example("minimal", "acme")
+					Example("full", "acme-corp")
+				})
+				Attribute("bottle", Bottle)
+				Required("account", "bottle")
+			})
+			Result(Bottle, func() {
+				Example("minimal", func() {
+					Description("The bottle as stored.")
+					Value(map[string]interface{}{"name": "Lagavulin", "vintage": 1990})
+				})
+			})
+			HTTP(func() {
+				POST("/{account}")
+				Body("bottle")
+			})
+		})
+	})
+}
+
+var OpenAPIV3ErrorResponsesDSL = func() {
+	var NotFound = Type("NotFound", func() {
+		Attribute("id", String, "ID of missing bottle", func() {
+			Example("1")
+		})
+		Required("id")
+	})
+	API("test", func() {
+		Meta("openapi:version", "3.1")
+	})
+	Service("cellar", func() {
+		Error("unavailable", func() {
+			Description("Service is temporarily unavailable.")
+			Temporary()
+		})
+		Method("show", func() {
+			Payload(String)
+			Result(String)
+			Error("not_found", NotFound, "Bottle not found.")
+			Error("bad_request", ErrorResult, "Invalid bottle ID.")
+			HTTP(func() {
+				GET("/{id}")
+				Response("not_found", StatusNotFound, func() {
+					ContentType("application/vnd.cellar.error+json")
+				})
+				Response("bad_request", StatusBadRequest)
+				Response("unavailable", StatusServiceUnavailable)
+			})
+			GRPC(func() {
+				Response("not_found", CodeNotFound)
+				Response("bad_request", CodeInvalidArgument)
+			})
+		})
+	})
+}
+
+var OpenAPIV3ServersDSL = func() {
+	API("test", func() {
+		Meta("openapi:version", "3.1")
+		Server("test", func() {
+			Host("production", func() {
+				Description("Regional production hosts.")
+				URI("https://{region}.example.com:{port}")
+				Variable("region", String, "Deployment region", func() {
+					Enum("us", "eu")
+				})
+				Variable("port", String, "Port", func() {
+					Default("443")
+				})
+			})
+			Host("staging", func() {
+				URI("https://staging.example.com")
+			})
+		})
+	})
+	Service("test", func() {
+		Method("show", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
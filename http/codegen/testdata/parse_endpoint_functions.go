@@ -97,6 +97,18 @@ func ParseEndpoint(
 		}
 	}
 
+	// Set the flags not given explicitly to the values of the environment
+	// variables or of the configuration file if any.
+	{
+		defaults, err := goacli.NewDefaults("test_api", flag.CommandLine)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := defaults.Apply(epf, svcn, epn); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	var (
 		data     interface{}
 		endpoint goa.Endpoint
@@ -233,6 +245,18 @@ func ParseEndpoint(
 		}
 	}
 
+	// Set the flags not given explicitly to the values of the environment
+	// variables or of the configuration file if any.
+	{
+		defaults, err := goacli.NewDefaults("test_api", flag.CommandLine)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := defaults.Apply(epf, svcn, epn); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	var (
 		data     interface{}
 		endpoint goa.Endpoint
@@ -363,6 +387,18 @@ func ParseEndpoint(
 		}
 	}
 
+	// Set the flags not given explicitly to the values of the environment
+	// variables or of the configuration file if any.
+	{
+		defaults, err := goacli.NewDefaults("test_api", flag.CommandLine)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := defaults.Apply(epf, svcn, epn); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	var (
 		data     interface{}
 		endpoint goa.Endpoint
@@ -475,6 +511,18 @@ func ParseEndpoint(
 		}
 	}
 
+	// Set the flags not given explicitly to the values of the environment
+	// variables or of the configuration file if any.
+	{
+		defaults, err := goacli.NewDefaults("test_api", flag.CommandLine)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := defaults.Apply(epf, svcn, epn); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	var (
 		data     interface{}
 		endpoint goa.Endpoint
@@ -590,6 +638,18 @@ func ParseEndpoint(
 		}
 	}
 
+	// Set the flags not given explicitly to the values of the environment
+	// variables or of the configuration file if any.
+	{
+		defaults, err := goacli.NewDefaults("test_api", flag.CommandLine)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := defaults.Apply(epf, svcn, epn); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	var (
 		data     interface{}
 		endpoint goa.Endpoint
@@ -647,7 +707,7 @@ func BuildMethodMultiPayloadPayload(serviceMultiMethodMultiPayloadBody string, s
 	{
 		err = json.Unmarshal([]byte(serviceMultiMethodMultiPayloadBody), &body)
 		if err != nil {
-			return nil, fmt.Errorf("invalid JSON for body, example of valid JSON:\n%s", "'{\n      \"c\": {\n         \"att\": false,\n         \"att10\": \"Aspernatur quo error explicabo pariatur.\",\n         \"att11\": \"Q3VtcXVlIHZvbHVwdGF0ZW0u\",\n         \"att12\": \"Distinctio aliquam nihil blanditiis ut.\",\n         \"att13\": [\n            \"Nihil excepturi deserunt quasi omnis sed.\",\n            \"Sit maiores aperiam autem non ea rem.\"\n         ],\n         \"att14\": {\n            \"Excepturi totam.\": \"Ut aut facilis vel ipsam.\",\n            \"Minima et aut non sunt consequuntur.\": \"Et consequuntur porro quasi.\",\n            \"Quis voluptates quaerat et temporibus facere.\": \"Ipsam eaque sunt maxime suscipit.\"\n         },\n         \"att15\": {\n            \"inline\": \"Ea alias repellat nobis veritatis.\"\n         },\n         \"att2\": 3504438334001971349,\n         \"att3\": 2005839040,\n         \"att4\": 5845720715558772393,\n         \"att5\": 12124006045301819638,\n         \"att6\": 3731236027,\n         \"att7\": 10708117302649141570,\n         \"att8\": 0.11815318,\n         \"att9\": 0.30907290919538355\n      }\n   }'")
+			return nil, fmt.Errorf("invalid JSON for body, example of valid JSON:\n%s", "'{\n      \"c\": {\n         \"att\": false,\n         \"att10\": \"Qui voluptatum magnam.\",\n         \"att11\": \"TmVtbyByYXRpb25lIHF1aWJ1c2RhbSBwcm92aWRlbnQgdGVtcG9yYS4=\",\n         \"att12\": \"Non et est.\",\n         \"att13\": [\n            \"Ipsum porro perspiciatis et dolores quia.\",\n            \"Atque ratione cupiditate.\",\n            \"Quia et consequuntur nam omnis non corporis.\",\n            \"Nulla praesentium harum sit eligendi.\"\n         ],\n         \"att14\": {\n            \"Ut vero doloremque.\": \"Et ut sunt.\"\n         },\n         \"att15\": {\n            \"inline\": \"Porro quaerat ipsa autem.\"\n         },\n         \"att2\": 7383324657837539461,\n         \"att3\": 1734146600,\n         \"att4\": 3368923973410471018,\n         \"att5\": 13810633237283090143,\n         \"att6\": 388253347,\n         \"att7\": 18321815050558481493,\n         \"att8\": 0.22821684,\n         \"att9\": 0.7985025532709547\n      }\n   }'")
 		}
 	}
 	var b *string
@@ -707,7 +767,7 @@ func BuildMethodBodyQueryPathObjectPayload(serviceBodyQueryPathObjectMethodBodyQ
 	{
 		err = json.Unmarshal([]byte(serviceBodyQueryPathObjectMethodBodyQueryPathObjectBody), &body)
 		if err != nil {
-			return nil, fmt.Errorf("invalid JSON for body, example of valid JSON:\n%s", "'{\n      \"a\": \"Ullam aut.\"\n   }'")
+			return nil, fmt.Errorf("invalid JSON for body, example of valid JSON:\n%s", "'{\n      \"a\": \"Quia tempore quia quaerat hic.\"\n   }'")
 		}
 	}
 	var c2 string
@@ -799,6 +859,18 @@ func ParseEndpoint(
 		}
 	}
 
+	// Set the flags not given explicitly to the values of the environment
+	// variables or of the configuration file if any.
+	{
+		defaults, err := goacli.NewDefaults("test_api", flag.CommandLine)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := defaults.Apply(epf, svcn, epn); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	var (
 		data     interface{}
 		endpoint goa.Endpoint
@@ -897,6 +969,18 @@ func ParseEndpoint(
 		}
 	}
 
+	// Set the flags not given explicitly to the values of the environment
+	// variables or of the configuration file if any.
+	{
+		defaults, err := goacli.NewDefaults("test_api", flag.CommandLine)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := defaults.Apply(epf, svcn, epn); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	var (
 		data     interface{}
 		endpoint goa.Endpoint
@@ -1046,6 +1130,18 @@ func ParseEndpoint(
 		}
 	}
 
+	// Set the flags not given explicitly to the values of the environment
+	// variables or of the configuration file if any.
+	{
+		defaults, err := goacli.NewDefaults("test_api", flag.CommandLine)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := defaults.Apply(epf, svcn, epn); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	var (
 		data     interface{}
 		endpoint goa.Endpoint
@@ -1101,7 +1197,7 @@ func BuildMethodMapQueryObjectPayload(serviceMapQueryObjectMethodMapQueryObjectB
 	{
 		err = json.Unmarshal([]byte(serviceMapQueryObjectMethodMapQueryObjectC), &c)
 		if err != nil {
-			return nil, fmt.Errorf("invalid JSON for c, example of valid JSON:\n%s", "'{\n      \"1484745265794365762\": [\n         \"Similique aspernatur.\",\n         \"Error explicabo.\",\n         \"Minima cumque voluptatem et distinctio aliquam.\",\n         \"Blanditiis ut eaque.\"\n      ],\n      \"4925854623691091547\": [\n         \"Eos aut ipsam.\",\n         \"Aliquam tempora.\"\n      ],\n      \"7174751143827362498\": [\n         \"Facilis minus explicabo nemo eos vel repellat.\",\n         \"Voluptatum magni aperiam qui.\"\n      ]\n   }'")
+			return nil, fmt.Errorf("invalid JSON for c, example of valid JSON:\n%s", "'{\n      \"1933576090881074823\": [\n         \"Doloribus qui quia.\",\n         \"Et tempora et quae.\"\n      ],\n      \"2139806046876113332\": [\n         \"Optio quia ullam aut.\",\n         \"Iste perspiciatis.\",\n         \"Harum et.\",\n         \"Neque nisi quibusdam nisi sint sunt.\"\n      ],\n      \"2929115566830881500\": [\n         \"Assumenda fuga est sint maxime.\",\n         \"Qui molestiae iure.\",\n         \"Consequuntur sint voluptate.\"\n      ]\n   }'")
 		}
 	}
 	v := &servicemapqueryobject.PayloadType{
@@ -1215,7 +1311,7 @@ func BuildMethodBodyPrimitiveArrayUserPayload(serviceBodyPrimitiveArrayUserMetho
 		if serviceBodyPrimitiveArrayUserMethodBodyPrimitiveArrayUserA != "" {
 			err = json.Unmarshal([]byte(serviceBodyPrimitiveArrayUserMethodBodyPrimitiveArrayUserA), &a)
 			if err != nil {
-				return nil, fmt.Errorf("invalid JSON for a, example of valid JSON:\n%s", "'[\n      \"Perspiciatis repellendus harum et est.\",\n      \"Nisi quibusdam nisi sint sunt beatae.\"\n   ]'")
+				return nil, fmt.Errorf("invalid JSON for a, example of valid JSON:\n%s", "'[\n      \"Molestias recusandae doloribus qui quia.\",\n      \"Et tempora et quae.\",\n      \"Itaque inventore optio.\",\n      \"Ullam aut.\"\n   ]'")
 			}
 		}
 	}
@@ -1234,7 +1330,7 @@ func BuildMethodAPayload(serviceWithParamsAndHeadersBlockMethodABody string, ser
 	{
 		err = json.Unmarshal([]byte(serviceWithParamsAndHeadersBlockMethodABody), &body)
 		if err != nil {
-			return nil, fmt.Errorf("invalid JSON for body, example of valid JSON:\n%s", "'{\n      \"body\": \"Inventore optio quia ullam aut iste iste.\"\n   }'")
+			return nil, fmt.Errorf("invalid JSON for body, example of valid JSON:\n%s", "'{\n      \"body\": \"Exercitationem labore doloribus dolores.\"\n   }'")
 		}
 	}
 	var path uint
@@ -1291,3 +1387,642 @@ func BuildMethodAPayload(serviceWithParamsAndHeadersBlockMethodABody string, ser
 	return v, nil
 }
 `
+
+var NamedExamplesParseCode = `// ParseEndpoint returns the endpoint and payload as specified on the command
+// line.
+func ParseEndpoint(
+	scheme, host string,
+	doer goahttp.Doer,
+	enc func(*http.Request) goahttp.Encoder,
+	dec func(*http.Response) goahttp.Decoder,
+	restore bool,
+) (goa.Endpoint, interface{}, error) {
+	var (
+		serviceNamedExamplesFlags = flag.NewFlagSet("service-named-examples", flag.ContinueOnError)
+
+		serviceNamedExamplesMethodNamedExamplesFlags       = flag.NewFlagSet("method-named-examples", flag.ExitOnError)
+		serviceNamedExamplesMethodNamedExamplesBodyFlag    = serviceNamedExamplesMethodNamedExamplesFlags.String("body", "REQUIRED", "")
+		serviceNamedExamplesMethodNamedExamplesIDFlag      = serviceNamedExamplesMethodNamedExamplesFlags.String("id", "REQUIRED", "")
+		serviceNamedExamplesMethodNamedExamplesRatingFlag  = serviceNamedExamplesMethodNamedExamplesFlags.String("rating", "", "")
+		serviceNamedExamplesMethodNamedExamplesExampleFlag = serviceNamedExamplesMethodNamedExamplesFlags.String("example", "", "Name of example used to set the flags not given explicitly, one of full, minimal")
+	)
+	serviceNamedExamplesFlags.Usage = serviceNamedExamplesUsage
+	serviceNamedExamplesMethodNamedExamplesFlags.Usage = serviceNamedExamplesMethodNamedExamplesUsage
+
+	if err := flag.CommandLine.Parse(os.Args[1:]); err != nil {
+		return nil, nil, err
+	}
+
+	if flag.NArg() < 2 { // two non flag args are required: SERVICE and ENDPOINT (aka COMMAND)
+		return nil, nil, fmt.Errorf("not enough arguments")
+	}
+
+	var (
+		svcn string
+		svcf *flag.FlagSet
+	)
+	{
+		svcn = flag.Arg(0)
+		switch svcn {
+		case "service-named-examples":
+			svcf = serviceNamedExamplesFlags
+		default:
+			return nil, nil, fmt.Errorf("unknown service %q", svcn)
+		}
+	}
+	if err := svcf.Parse(flag.Args()[1:]); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		epn string
+		epf *flag.FlagSet
+	)
+	{
+		epn = svcf.Arg(0)
+		switch svcn {
+		case "service-named-examples":
+			switch epn {
+			case "method-named-examples":
+				epf = serviceNamedExamplesMethodNamedExamplesFlags
+
+			}
+
+		}
+	}
+	if epf == nil {
+		return nil, nil, fmt.Errorf("unknown %q endpoint %q", svcn, epn)
+	}
+
+	// Parse endpoint flags if any
+	if svcf.NArg() > 1 {
+		if err := epf.Parse(svcf.Args()[1:]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Set the flags not given explicitly to the values of the environment
+	// variables or of the configuration file if any.
+	{
+		defaults, err := goacli.NewDefaults("test_api", flag.CommandLine)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := defaults.Apply(epf, svcn, epn); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Set the flags not given explicitly to the values of the example
+	// selected with -example if any.
+	{
+		var (
+			exn string
+			exs map[string]map[string]string
+		)
+		switch epf {
+		case serviceNamedExamplesMethodNamedExamplesFlags:
+			exn = *serviceNamedExamplesMethodNamedExamplesExampleFlag
+			exs = map[string]map[string]string{
+				"full": {
+					"body":   "{\"name\":\"Lagavulin 16\"}",
+					"id":     "b2",
+					"rating": "5",
+				},
+				"minimal": {
+					"body": "{\"name\":\"Lagavulin\"}",
+					"id":   "a1",
+				},
+			}
+		}
+		if exn != "" {
+			ex, ok := exs[exn]
+			if !ok {
+				return nil, nil, fmt.Errorf("unknown example %q for %q endpoint %q", exn, svcn, epn)
+			}
+			set := make(map[string]bool)
+			epf.Visit(func(f *flag.Flag) { set[f.Name] = true })
+			for name, val := range ex {
+				if !set[name] {
+					if err := epf.Set(name, val); err != nil {
+						return nil, nil, err
+					}
+				}
+			}
+		}
+	}
+
+	var (
+		data     interface{}
+		endpoint goa.Endpoint
+		err      error
+	)
+	{
+		switch svcn {
+		case "service-named-examples":
+			c := servicenamedexamplesc.NewClient(scheme, host, doer, enc, dec, restore)
+			switch epn {
+			case "method-named-examples":
+				endpoint = c.MethodNamedExamples()
+				data, err = servicenamedexamplesc.BuildMethodNamedExamplesPayload(*serviceNamedExamplesMethodNamedExamplesBodyFlag, *serviceNamedExamplesMethodNamedExamplesIDFlag, *serviceNamedExamplesMethodNamedExamplesRatingFlag)
+			}
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return endpoint, data, nil
+}
+`
+
+var CredentialsParseCode = `// ParseEndpoint returns the endpoint and payload as specified on the command
+// line.
+//
+// The security flags that are not given explicitly are initialized with the
+// credentials of the given profile if any.
+func ParseEndpoint(
+	scheme, host string,
+	doer goahttp.Doer,
+	enc func(*http.Request) goahttp.Encoder,
+	dec func(*http.Response) goahttp.Decoder,
+	restore bool,
+	profiles ...*credentials.Profile,
+) (goa.Endpoint, interface{}, error) {
+	var creds *credentials.Profile
+	if len(profiles) > 0 {
+		creds = profiles[0]
+	}
+	var (
+		serviceCredentialsFlags = flag.NewFlagSet("service-credentials", flag.ContinueOnError)
+
+		serviceCredentialsLoginFlags    = flag.NewFlagSet("login", flag.ExitOnError)
+		serviceCredentialsLoginUserFlag = serviceCredentialsLoginFlags.String("user", "REQUIRED", "")
+		serviceCredentialsLoginPassFlag = serviceCredentialsLoginFlags.String("pass", "REQUIRED", "")
+
+		serviceCredentialsShowFlags     = flag.NewFlagSet("show", flag.ExitOnError)
+		serviceCredentialsShowIDFlag    = serviceCredentialsShowFlags.String("id", "REQUIRED", "")
+		serviceCredentialsShowKeyFlag   = serviceCredentialsShowFlags.String("key", "", "")
+		serviceCredentialsShowTokenFlag = serviceCredentialsShowFlags.String("token", "", "")
+	)
+	serviceCredentialsFlags.Usage = serviceCredentialsUsage
+	serviceCredentialsLoginFlags.Usage = serviceCredentialsLoginUsage
+	serviceCredentialsShowFlags.Usage = serviceCredentialsShowUsage
+
+	if err := flag.CommandLine.Parse(os.Args[1:]); err != nil {
+		return nil, nil, err
+	}
+
+	if flag.NArg() < 2 { // two non flag args are required: SERVICE and ENDPOINT (aka COMMAND)
+		return nil, nil, fmt.Errorf("not enough arguments")
+	}
+
+	var (
+		svcn string
+		svcf *flag.FlagSet
+	)
+	{
+		svcn = flag.Arg(0)
+		switch svcn {
+		case "service-credentials":
+			svcf = serviceCredentialsFlags
+		default:
+			return nil, nil, fmt.Errorf("unknown service %q", svcn)
+		}
+	}
+	if err := svcf.Parse(flag.Args()[1:]); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		epn string
+		epf *flag.FlagSet
+	)
+	{
+		epn = svcf.Arg(0)
+		switch svcn {
+		case "service-credentials":
+			switch epn {
+			case "login":
+				epf = serviceCredentialsLoginFlags
+
+			case "show":
+				epf = serviceCredentialsShowFlags
+
+			}
+
+		}
+	}
+	if epf == nil {
+		return nil, nil, fmt.Errorf("unknown %q endpoint %q", svcn, epn)
+	}
+
+	// Parse endpoint flags if any
+	if svcf.NArg() > 1 {
+		if err := epf.Parse(svcf.Args()[1:]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Set the flags not given explicitly to the values of the environment
+	// variables or of the configuration file if any.
+	{
+		defaults, err := goacli.NewDefaults("test_api", flag.CommandLine)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := defaults.Apply(epf, svcn, epn); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Set the security flags not given explicitly to the credentials
+	// stored in the profile if any.
+	if creds != nil {
+		var cs map[string]*credentials.Credential
+		switch epf {
+		case serviceCredentialsLoginFlags:
+			cs = map[string]*credentials.Credential{
+				"user": {Name: "basic.username", Kind: "Basic"},
+				"pass": {Name: "basic.password", Kind: "Basic"},
+			}
+		case serviceCredentialsShowFlags:
+			cs = map[string]*credentials.Credential{
+				"key":   {Name: "api_key", Kind: "APIKey"},
+				"token": {Name: "oauth2", Kind: "OAuth2", TokenURL: "/oauth/token", ClientCredentials: true, Scopes: []string{"api:read"}},
+			}
+		}
+		set := make(map[string]bool)
+		epf.Visit(func(f *flag.Flag) { set[f.Name] = true })
+		for name, c := range cs {
+			if set[name] {
+				continue
+			}
+			val, err := creds.Value(context.Background(), c)
+			if err != nil {
+				return nil, nil, err
+			}
+			if val != "" {
+				if err := epf.Set(name, val); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+	}
+
+	var (
+		data     interface{}
+		endpoint goa.Endpoint
+		err      error
+	)
+	{
+		switch svcn {
+		case "service-credentials":
+			c := servicecredentialsc.NewClient(scheme, host, doer, enc, dec, restore)
+			switch epn {
+			case "login":
+				endpoint = c.Login()
+				data, err = servicecredentialsc.BuildLoginPayload(*serviceCredentialsLoginUserFlag, *serviceCredentialsLoginPassFlag)
+			case "show":
+				endpoint = c.Show()
+				data, err = servicecredentialsc.BuildShowPayload(*serviceCredentialsShowIDFlag, *serviceCredentialsShowKeyFlag, *serviceCredentialsShowTokenFlag)
+			}
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return endpoint, data, nil
+}
+`
+
+var AliasesParseCode = `// ParseEndpoint returns the endpoint and payload as specified on the command
+// line.
+func ParseEndpoint(
+	scheme, host string,
+	doer goahttp.Doer,
+	enc func(*http.Request) goahttp.Encoder,
+	dec func(*http.Response) goahttp.Decoder,
+	restore bool,
+) (goa.Endpoint, interface{}, error) {
+	var (
+		serviceAliasesFlags = flag.NewFlagSet("service-aliases", flag.ContinueOnError)
+
+		serviceAliasesListFlags       = flag.NewFlagSet("list", flag.ExitOnError)
+		serviceAliasesListLimitFlag   = serviceAliasesListFlags.String("limit", "", "")
+		serviceAliasesListExampleFlag = serviceAliasesListFlags.String("example", "", "Name of example used to set the flags not given explicitly, one of small")
+
+		serviceAliasesShowFlags = flag.NewFlagSet("show", flag.ExitOnError)
+		serviceAliasesShowPFlag = serviceAliasesShowFlags.String("p", "REQUIRED", "string is the payload type of the ServiceAliases service Show method.")
+	)
+	serviceAliasesFlags.Usage = serviceAliasesUsage
+	serviceAliasesListFlags.Usage = serviceAliasesListUsage
+	serviceAliasesShowFlags.Usage = serviceAliasesShowUsage
+
+	if err := flag.CommandLine.Parse(os.Args[1:]); err != nil {
+		return nil, nil, err
+	}
+
+	if flag.NArg() < 2 { // two non flag args are required: SERVICE and ENDPOINT (aka COMMAND)
+		return nil, nil, fmt.Errorf("not enough arguments")
+	}
+
+	var (
+		svcn string
+		svcf *flag.FlagSet
+	)
+	{
+		svcn = flag.Arg(0)
+		switch svcn {
+		case "service-aliases", "sa", "al":
+			svcn = "service-aliases"
+			svcf = serviceAliasesFlags
+		default:
+			return nil, nil, fmt.Errorf("unknown service %q", svcn)
+		}
+	}
+	if err := svcf.Parse(flag.Args()[1:]); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		epn string
+		epf *flag.FlagSet
+	)
+	{
+		epn = svcf.Arg(0)
+		switch svcn {
+		case "service-aliases":
+			switch epn {
+			case "list", "ls":
+				epn = "list"
+				epf = serviceAliasesListFlags
+
+			case "show":
+				epf = serviceAliasesShowFlags
+
+			}
+
+		}
+	}
+	if epf == nil {
+		return nil, nil, fmt.Errorf("unknown %q endpoint %q", svcn, epn)
+	}
+
+	// Parse endpoint flags if any
+	if svcf.NArg() > 1 {
+		if err := epf.Parse(svcf.Args()[1:]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Set the flags not given explicitly to the values of the environment
+	// variables or of the configuration file if any.
+	{
+		defaults, err := goacli.NewDefaults("test_api", flag.CommandLine)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := defaults.Apply(epf, svcn, epn); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Set the flags not given explicitly to the values of the example
+	// selected with -example if any.
+	{
+		var (
+			exn string
+			exs map[string]map[string]string
+		)
+		switch epf {
+		case serviceAliasesListFlags:
+			exn = *serviceAliasesListExampleFlag
+			exs = map[string]map[string]string{
+				"small": {
+					"limit": "5",
+				},
+			}
+		}
+		if exn != "" {
+			ex, ok := exs[exn]
+			if !ok {
+				return nil, nil, fmt.Errorf("unknown example %q for %q endpoint %q", exn, svcn, epn)
+			}
+			set := make(map[string]bool)
+			epf.Visit(func(f *flag.Flag) { set[f.Name] = true })
+			for name, val := range ex {
+				if !set[name] {
+					if err := epf.Set(name, val); err != nil {
+						return nil, nil, err
+					}
+				}
+			}
+		}
+	}
+
+	var (
+		data     interface{}
+		endpoint goa.Endpoint
+		err      error
+	)
+	{
+		switch svcn {
+		case "service-aliases":
+			c := servicealiasesc.NewClient(scheme, host, doer, enc, dec, restore)
+			switch epn {
+			case "list":
+				endpoint = c.List()
+				data, err = servicealiasesc.BuildListPayload(*serviceAliasesListLimitFlag)
+			case "show":
+				endpoint = c.Show()
+				data = *serviceAliasesShowPFlag
+			}
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return endpoint, data, nil
+}
+`
+
+var AliasesCommandsCode = `// Commands returns the description of the commands, sub-commands and flags of
+// the CLI tool used to generate the shell completion scripts and the table
+// output of the results.
+func Commands() []*goacli.Command {
+	return []*goacli.Command{
+		{
+			Name:        "service-aliases",
+			Aliases:     []string{"sa", "al"},
+			Description: "Manage the wine bottles",
+			Subcommands: []*goacli.Command{
+				{
+					Name:        "list",
+					Aliases:     []string{"ls"},
+					Description: "List implements List.",
+					Flags: []*goacli.Flag{
+						{Name: "limit", Description: ""},
+						{Name: "example", Description: "Name of example used to set the flags not given explicitly"},
+					},
+				},
+				{
+					Name:        "show",
+					Description: "Show a bottle",
+					Flags: []*goacli.Flag{
+						{Name: "p", Description: "string is the payload type of the ServiceAliases service Show method."},
+					},
+				},
+			},
+		},
+	}
+}
+`
+
+var AliasesUsageCode = `// service-aliasesUsage displays the usage of the service-aliases command and
+// its subcommands.
+func serviceAliasesUsage() {
+	fmt.Fprintf(os.Stderr, ` + "`" + `Manage the wine bottles
+Usage:
+    %s [globalflags] service-aliases COMMAND [flags]
+
+Aliases:
+    sa, al
+
+COMMAND:
+    list (ls): List implements List.
+    show: Show a bottle
+
+Additional help:
+    %s service-aliases COMMAND --help
+` + "`" + `, os.Args[0], os.Args[0])
+}
+func serviceAliasesListUsage() {
+	fmt.Fprintf(os.Stderr, ` + "`" + `%s [flags] service-aliases list -limit INT -example STRING
+
+List implements List.
+    -limit INT: 
+    -example STRING: Name of example used to set the flags not given explicitly, one of small
+
+Example:
+    ` + "`" + `+os.Args[0]+` + "`" + ` service-aliases list --limit 5
+` + "`" + `, os.Args[0])
+}
+
+func serviceAliasesShowUsage() {
+	fmt.Fprintf(os.Stderr, ` + "`" + `%s [flags] service-aliases show -p STRING
+
+Show a bottle
+    -p STRING: string is the payload type of the ServiceAliases service Show method.
+
+Example:
+    ` + "`" + `+os.Args[0]+` + "`" + ` service-aliases show --p "Quia molestias."
+` + "`" + `, os.Args[0])
+}
+`
+
+var ColumnsCommandsCode = `// Commands returns the description of the commands, sub-commands and flags of
+// the CLI tool used to generate the shell completion scripts and the table
+// output of the results.
+func Commands() []*goacli.Command {
+	return []*goacli.Command{
+		{
+			Name:        "service-columns",
+			Description: "Service is the ServiceColumns service interface.",
+			Subcommands: []*goacli.Command{
+				{
+					Name:        "list",
+					Description: "List implements List.",
+					Columns: []*goacli.Column{
+						{Header: "ID", Field: "ID"},
+						{Header: "NAME", Field: "Name"},
+					},
+				},
+				{
+					Name:        "show",
+					Description: "Show implements Show.",
+					Flags: []*goacli.Flag{
+						{Name: "p", Description: "string is the payload type of the ServiceColumns service Show method."},
+					},
+					Columns: []*goacli.Column{
+						{Header: "ID", Field: "ID"},
+						{Header: "NAME", Field: "Name"},
+						{Header: "VINTAGE YEAR", Field: "VintageYear"},
+					},
+				},
+				{
+					Name:        "rate",
+					Description: "Rate implements Rate.",
+					Flags: []*goacli.Flag{
+						{Name: "p", Description: "string is the payload type of the ServiceColumns service Rate method."},
+					},
+					Columns: []*goacli.Column{
+						{Header: "BOTTLE ID", Field: "BottleID"},
+						{Header: "AVERAGE", Field: "Average"},
+					},
+				},
+				{
+					Name:        "count",
+					Description: "Count implements Count.",
+				},
+			},
+		},
+	}
+}
+`
+
+var StreamingCLIStreamCode = `// Stream returns the client stream returned by a streaming endpoint adapted to
+// be run by the CLI tool, nil if data is not a client stream.
+func Stream(data interface{}) *goacli.Stream {
+	switch stream := data.(type) {
+	case *streamingcliservicec.ListenClientStream:
+		return &goacli.Stream{
+			Recv: func() (interface{}, error) { return stream.Recv() },
+		}
+	case *streamingcliservicec.SummaryClientStream:
+		return &goacli.Stream{
+			Send: func(data []byte) error {
+				var v *streamingcliservice.Message
+				if err := json.Unmarshal(data, &v); err != nil {
+					return err
+				}
+				return stream.Send(v)
+			},
+			Close: func() (interface{}, error) { return stream.CloseAndRecv() },
+		}
+	case *streamingcliservicec.UploadClientStream:
+		return &goacli.Stream{
+			Send: func(data []byte) error {
+				var v *streamingcliservice.Message
+				if err := json.Unmarshal(data, &v); err != nil {
+					return err
+				}
+				return stream.Send(v)
+			},
+			Close: func() (interface{}, error) { return nil, stream.Close() },
+		}
+	case *streamingcliservicec.EchoClientStream:
+		return &goacli.Stream{
+			Send: func(data []byte) error {
+				var v *streamingcliservice.Message
+				if err := json.Unmarshal(data, &v); err != nil {
+					return err
+				}
+				return stream.Send(v)
+			},
+			Recv:  func() (interface{}, error) { return stream.Recv() },
+			Close: func() (interface{}, error) { return nil, stream.Close() },
+		}
+	}
+	return nil
+}
+`
+
+var NoStreamCode = `// Stream returns the client stream returned by a streaming endpoint adapted to
+// be run by the CLI tool, nil if data is not a client stream.
+func Stream(data interface{}) *goacli.Stream {
+	return nil
+}
+`
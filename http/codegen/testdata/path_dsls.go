@@ -174,3 +174,45 @@ var PathInterfaceSliceParamDSL = func() {
 		})
 	})
 }
+
+var PathStyledParamsDSL = func() {
+	Service("ServicePathStyledParams", func() {
+		Method("MethodPathStyledParams", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("format", String)
+				Attribute("color", String)
+				Required("id")
+			})
+			HTTP(func() {
+				GET("/reports/{id}{.format}{;color}")
+			})
+		})
+	})
+}
+
+var PathLabelParamDSL = func() {
+	Service("ServicePathLabelParam", func() {
+		Method("MethodPathLabelParam", func() {
+			Payload(func() {
+				Attribute("format", String)
+			})
+			HTTP(func() {
+				GET("/report{.format}")
+			})
+		})
+	})
+}
+
+var PathCatchAllSliceParamDSL = func() {
+	Service("ServicePathCatchAllSliceParam", func() {
+		Method("MethodPathCatchAllSliceParam", func() {
+			Payload(func() {
+				Attribute("path", ArrayOf(String))
+			})
+			HTTP(func() {
+				GET("/files/{path*}")
+			})
+		})
+	})
+}
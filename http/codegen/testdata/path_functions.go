@@ -138,3 +138,36 @@ func MethodPathInterfaceSliceParamServicePathInterfaceSliceParamPath(a []interfa
 	return fmt.Sprintf("/one/%v/two", strings.Join(aSlice, ", "))
 }
 `
+
+var PathStyledParamsCode = `// MethodPathStyledParamsServicePathStyledParamsPath returns the URL path to the ServicePathStyledParams service MethodPathStyledParams HTTP endpoint.
+func MethodPathStyledParamsServicePathStyledParamsPath(id string, format string, color string) string {
+	path := fmt.Sprintf("/reports/%v", id)
+	if format != "" {
+		path += "." + format
+	}
+	if color != "" {
+		path += ";color=" + color
+	}
+	return path
+}
+`
+
+var PathLabelParamCode = `// MethodPathLabelParamServicePathLabelParamPath returns the URL path to the ServicePathLabelParam service MethodPathLabelParam HTTP endpoint.
+func MethodPathLabelParamServicePathLabelParamPath(format string) string {
+	path := "/report"
+	if format != "" {
+		path += "." + format
+	}
+	return path
+}
+`
+
+var PathCatchAllSliceParamCode = `// MethodPathCatchAllSliceParamServicePathCatchAllSliceParamPath returns the URL path to the ServicePathCatchAllSliceParam service MethodPathCatchAllSliceParam HTTP endpoint.
+func MethodPathCatchAllSliceParamServicePathCatchAllSliceParamPath(path []string) string {
+	pathSlice := make([]string, len(path))
+	for i, v := range path {
+		pathSlice[i] = url.QueryEscape(v)
+	}
+	return fmt.Sprintf("/files/%v", strings.Join(pathSlice, "/"))
+}
+`
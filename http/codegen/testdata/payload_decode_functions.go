@@ -764,9 +764,9 @@ func DecodeMethodQueryArrayBoolValidateRequest(mux goahttp.Muxer, decoder func(*
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if !(e == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[*]", e, []interface{}{true}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q["+strconv.Itoa(i)+"]", e, []interface{}{true}))
 			}
 		}
 		if err != nil {
@@ -835,9 +835,9 @@ func DecodeMethodQueryArrayIntValidateRequest(mux goahttp.Muxer, decoder func(*h
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if e < 1 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
+				err = goa.MergeErrors(err, goa.InvalidRangeError("q["+strconv.Itoa(i)+"]", e, 1, true))
 			}
 		}
 		if err != nil {
@@ -907,9 +907,9 @@ func DecodeMethodQueryArrayInt32ValidateRequest(mux goahttp.Muxer, decoder func(
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if e < 1 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
+				err = goa.MergeErrors(err, goa.InvalidRangeError("q["+strconv.Itoa(i)+"]", e, 1, true))
 			}
 		}
 		if err != nil {
@@ -979,9 +979,9 @@ func DecodeMethodQueryArrayInt64ValidateRequest(mux goahttp.Muxer, decoder func(
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if e < 1 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
+				err = goa.MergeErrors(err, goa.InvalidRangeError("q["+strconv.Itoa(i)+"]", e, 1, true))
 			}
 		}
 		if err != nil {
@@ -1051,9 +1051,9 @@ func DecodeMethodQueryArrayUIntValidateRequest(mux goahttp.Muxer, decoder func(*
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if e < 1 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
+				err = goa.MergeErrors(err, goa.InvalidRangeError("q["+strconv.Itoa(i)+"]", e, 1, true))
 			}
 		}
 		if err != nil {
@@ -1123,9 +1123,9 @@ func DecodeMethodQueryArrayUInt32ValidateRequest(mux goahttp.Muxer, decoder func
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if e < 1 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
+				err = goa.MergeErrors(err, goa.InvalidRangeError("q["+strconv.Itoa(i)+"]", e, 1, true))
 			}
 		}
 		if err != nil {
@@ -1195,9 +1195,9 @@ func DecodeMethodQueryArrayUInt64ValidateRequest(mux goahttp.Muxer, decoder func
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if e < 1 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
+				err = goa.MergeErrors(err, goa.InvalidRangeError("q["+strconv.Itoa(i)+"]", e, 1, true))
 			}
 		}
 		if err != nil {
@@ -1267,9 +1267,9 @@ func DecodeMethodQueryArrayFloat32ValidateRequest(mux goahttp.Muxer, decoder fun
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if e < 1 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
+				err = goa.MergeErrors(err, goa.InvalidRangeError("q["+strconv.Itoa(i)+"]", e, 1, true))
 			}
 		}
 		if err != nil {
@@ -1339,9 +1339,9 @@ func DecodeMethodQueryArrayFloat64ValidateRequest(mux goahttp.Muxer, decoder fun
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if e < 1 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
+				err = goa.MergeErrors(err, goa.InvalidRangeError("q["+strconv.Itoa(i)+"]", e, 1, true))
 			}
 		}
 		if err != nil {
@@ -1385,9 +1385,9 @@ func DecodeMethodQueryArrayStringValidateRequest(mux goahttp.Muxer, decoder func
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if !(e == "val") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[*]", e, []interface{}{"val"}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q["+strconv.Itoa(i)+"]", e, []interface{}{"val"}))
 			}
 		}
 		if err != nil {
@@ -1400,6 +1400,55 @@ func DecodeMethodQueryArrayStringValidateRequest(mux goahttp.Muxer, decoder func
 }
 `
 
+var PayloadQueryArrayStringPipeDelimitedDecodeCode = `// DecodeMethodQueryArrayStringPipeDelimitedRequest returns a decoder for
+// requests sent to the ServiceQueryArrayStringPipeDelimited
+// MethodQueryArrayStringPipeDelimited endpoint.
+func DecodeMethodQueryArrayStringPipeDelimitedRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			q []string
+		)
+		q = goahttp.SplitQueryValues(r.URL.Query()["q"], "|")
+		payload := NewMethodQueryArrayStringPipeDelimitedPayload(q)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadQueryArrayIntCommaDelimitedDecodeCode = `// DecodeMethodQueryArrayIntCommaDelimitedRequest returns a decoder for
+// requests sent to the ServiceQueryArrayIntCommaDelimited
+// MethodQueryArrayIntCommaDelimited endpoint.
+func DecodeMethodQueryArrayIntCommaDelimitedRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			q   []int
+			err error
+		)
+		{
+			qRaw := goahttp.SplitQueryValues(r.URL.Query()["q"], ",")
+			if qRaw == nil {
+				return goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+			}
+			q = make([]int, len(qRaw))
+			for i, rv := range qRaw {
+				v, err2 := strconv.ParseInt(rv, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "array of integers"))
+				}
+				q[i] = int(v)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodQueryArrayIntCommaDelimitedPayload(q)
+
+		return payload, nil
+	}
+}
+`
+
 var PayloadQueryArrayBytesDecodeCode = `// DecodeMethodQueryArrayBytesRequest returns a decoder for requests sent to
 // the ServiceQueryArrayBytes MethodQueryArrayBytes endpoint.
 func DecodeMethodQueryArrayBytesRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
@@ -1445,9 +1494,9 @@ func DecodeMethodQueryArrayBytesValidateRequest(mux goahttp.Muxer, decoder func(
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if len(e) < 2 {
-				err = goa.MergeErrors(err, goa.InvalidLengthError("q[*]", e, len(e), 2, true))
+				err = goa.MergeErrors(err, goa.InvalidLengthError("q["+strconv.Itoa(i)+"]", e, len(e), 2, true))
 			}
 		}
 		if err != nil {
@@ -1504,9 +1553,9 @@ func DecodeMethodQueryArrayAnyValidateRequest(mux goahttp.Muxer, decoder func(*h
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if !(e == "val" || e == 1) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[*]", e, []interface{}{"val", 1}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q["+strconv.Itoa(i)+"]", e, []interface{}{"val", 1}))
 			}
 		}
 		if err != nil {
@@ -1586,10 +1635,10 @@ func DecodeMethodQueryMapStringStringValidateRequest(mux goahttp.Muxer, decoder
 		}
 		for k, v := range q {
 			if !(k == "key") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{"key"}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q", k, []interface{}{"key"}))
 			}
 			if !(v == "val") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[key]", v, []interface{}{"val"}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q["+k+"]", v, []interface{}{"val"}))
 			}
 		}
 		if err != nil {
@@ -1689,10 +1738,10 @@ func DecodeMethodQueryMapStringBoolValidateRequest(mux goahttp.Muxer, decoder fu
 		}
 		for k, v := range q {
 			if !(k == "key") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{"key"}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q", k, []interface{}{"key"}))
 			}
 			if !(v == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[key]", v, []interface{}{true}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q["+k+"]", v, []interface{}{true}))
 			}
 		}
 		if err != nil {
@@ -1786,10 +1835,10 @@ func DecodeMethodQueryMapBoolStringValidateRequest(mux goahttp.Muxer, decoder fu
 		}
 		for k, v := range q {
 			if !(k == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{true}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q", k, []interface{}{true}))
 			}
 			if !(v == "val") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[key]", v, []interface{}{"val"}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q["+fmt.Sprint(k)+"]", v, []interface{}{"val"}))
 			}
 		}
 		if err != nil {
@@ -1899,10 +1948,10 @@ func DecodeMethodQueryMapBoolBoolValidateRequest(mux goahttp.Muxer, decoder func
 		}
 		for k, v := range q {
 			if !(k == false) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{false}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q", k, []interface{}{false}))
 			}
 			if !(v == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[key]", v, []interface{}{true}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q["+fmt.Sprint(k)+"]", v, []interface{}{true}))
 			}
 		}
 		if err != nil {
@@ -1983,10 +2032,10 @@ func DecodeMethodQueryMapStringArrayStringValidateRequest(mux goahttp.Muxer, dec
 		}
 		for k, v := range q {
 			if !(k == "key") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{"key"}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q", k, []interface{}{"key"}))
 			}
 			if len(v) < 2 {
-				err = goa.MergeErrors(err, goa.InvalidLengthError("q[key]", v, len(v), 2, true))
+				err = goa.MergeErrors(err, goa.InvalidLengthError("q["+k+"]", v, len(v), 2, true))
 			}
 		}
 		if err != nil {
@@ -2093,10 +2142,10 @@ func DecodeMethodQueryMapStringArrayBoolValidateRequest(mux goahttp.Muxer, decod
 		}
 		for k, v := range q {
 			if !(k == "key") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{"key"}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q", k, []interface{}{"key"}))
 			}
 			if len(v) < 2 {
-				err = goa.MergeErrors(err, goa.InvalidLengthError("q[key]", v, len(v), 2, true))
+				err = goa.MergeErrors(err, goa.InvalidLengthError("q["+k+"]", v, len(v), 2, true))
 			}
 		}
 		if err != nil {
@@ -2190,10 +2239,10 @@ func DecodeMethodQueryMapBoolArrayStringValidateRequest(mux goahttp.Muxer, decod
 		}
 		for k, v := range q {
 			if !(k == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{true}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q", k, []interface{}{true}))
 			}
 			if len(v) < 2 {
-				err = goa.MergeErrors(err, goa.InvalidLengthError("q[key]", v, len(v), 2, true))
+				err = goa.MergeErrors(err, goa.InvalidLengthError("q["+fmt.Sprint(k)+"]", v, len(v), 2, true))
 			}
 		}
 		if err != nil {
@@ -2309,10 +2358,10 @@ func DecodeMethodQueryMapBoolArrayBoolValidateRequest(mux goahttp.Muxer, decoder
 		}
 		for k, v := range q {
 			if !(k == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{true}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q", k, []interface{}{true}))
 			}
 			if len(v) < 2 {
-				err = goa.MergeErrors(err, goa.InvalidLengthError("q[key]", v, len(v), 2, true))
+				err = goa.MergeErrors(err, goa.InvalidLengthError("q["+fmt.Sprint(k)+"]", v, len(v), 2, true))
 			}
 		}
 		if err != nil {
@@ -2400,9 +2449,9 @@ func DecodeMethodQueryPrimitiveArrayStringValidateRequest(mux goahttp.Muxer, dec
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if !(e == "val") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[*]", e, []interface{}{"val"}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q["+strconv.Itoa(i)+"]", e, []interface{}{"val"}))
 			}
 		}
 		if err != nil {
@@ -2441,9 +2490,9 @@ func DecodeMethodQueryPrimitiveArrayBoolValidateRequest(mux goahttp.Muxer, decod
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if !(e == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[*]", e, []interface{}{true}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q["+strconv.Itoa(i)+"]", e, []interface{}{true}))
 			}
 		}
 		if err != nil {
@@ -2490,12 +2539,12 @@ func DecodeMethodQueryPrimitiveMapStringArrayStringValidateRequest(mux goahttp.M
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
 		for k, v := range q {
-			err = goa.MergeErrors(err, goa.ValidatePattern("q.key", k, "key"))
+			err = goa.MergeErrors(err, goa.ValidatePattern("q", k, "key"))
 			if len(v) < 2 {
-				err = goa.MergeErrors(err, goa.InvalidLengthError("q[key]", v, len(v), 2, true))
+				err = goa.MergeErrors(err, goa.InvalidLengthError("q["+k+"]", v, len(v), 2, true))
 			}
-			for _, e := range v {
-				err = goa.MergeErrors(err, goa.ValidatePattern("q[key][*]", e, "val"))
+			for i1, e := range v {
+				err = goa.MergeErrors(err, goa.ValidatePattern("q["+k+"]["+strconv.Itoa(i1)+"]", e, "val"))
 			}
 		}
 		if err != nil {
@@ -2549,9 +2598,9 @@ func DecodeMethodQueryPrimitiveMapStringBoolValidateRequest(mux goahttp.Muxer, d
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
 		for k, v := range q {
-			err = goa.MergeErrors(err, goa.ValidatePattern("q.key", k, "key"))
+			err = goa.MergeErrors(err, goa.ValidatePattern("q", k, "key"))
 			if !(v == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[key]", v, []interface{}{true}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q["+k+"]", v, []interface{}{true}))
 			}
 		}
 		if err != nil {
@@ -2614,14 +2663,14 @@ func DecodeMethodQueryPrimitiveMapBoolArrayBoolValidateRequest(mux goahttp.Muxer
 		}
 		for k, v := range q {
 			if !(k == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{true}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q", k, []interface{}{true}))
 			}
 			if len(v) < 2 {
-				err = goa.MergeErrors(err, goa.InvalidLengthError("q[key]", v, len(v), 2, true))
+				err = goa.MergeErrors(err, goa.InvalidLengthError("q["+fmt.Sprint(k)+"]", v, len(v), 2, true))
 			}
-			for _, e := range v {
+			for i1, e := range v {
 				if !(e == false) {
-					err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[key][*]", e, []interface{}{false}))
+					err = goa.MergeErrors(err, goa.InvalidEnumValueError("q["+fmt.Sprint(k)+"]["+strconv.Itoa(i1)+"]", e, []interface{}{false}))
 				}
 			}
 		}
@@ -2681,7 +2730,7 @@ func DecodeMethodQueryMapStringMapIntStringValidateRequest(mux goahttp.Muxer, de
 		}
 		for k, _ := range q {
 			if !(k == "foo") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{"foo"}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q", k, []interface{}{"foo"}))
 			}
 		}
 		if err != nil {
@@ -2751,7 +2800,7 @@ func DecodeMethodQueryMapIntMapStringArrayIntValidateRequest(mux goahttp.Muxer,
 		}
 		for k, _ := range q {
 			if !(k == 1 || k == 2 || k == 3) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{1, 2, 3}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q", k, []interface{}{1, 2, 3}))
 			}
 		}
 		if err != nil {
@@ -3048,9 +3097,9 @@ func DecodeMethodPathPrimitiveArrayStringValidateRequest(mux goahttp.Muxer, deco
 		if len(p) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("p", p, len(p), 1, true))
 		}
-		for _, e := range p {
+		for i, e := range p {
 			if !(e == "val") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("p[*]", e, []interface{}{"val"}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("p["+strconv.Itoa(i)+"]", e, []interface{}{"val"}))
 			}
 		}
 		if err != nil {
@@ -3089,9 +3138,9 @@ func DecodeMethodPathPrimitiveArrayBoolValidateRequest(mux goahttp.Muxer, decode
 		if len(p) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("p", p, len(p), 1, true))
 		}
-		for _, e := range p {
+		for i, e := range p {
 			if !(e == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("p[*]", e, []interface{}{true}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("p["+strconv.Itoa(i)+"]", e, []interface{}{true}))
 			}
 		}
 		if err != nil {
@@ -3172,9 +3221,9 @@ func DecodeMethodHeaderArrayStringValidateRequest(mux goahttp.Muxer, decoder fun
 			err error
 		)
 		h = r.Header["H"]
-		for _, e := range h {
+		for i, e := range h {
 			if !(e == "val") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("h[*]", e, []interface{}{"val"}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("h["+strconv.Itoa(i)+"]", e, []interface{}{"val"}))
 			}
 		}
 		if err != nil {
@@ -3262,8 +3311,8 @@ func DecodeMethodHeaderPrimitiveArrayStringValidateRequest(mux goahttp.Muxer, de
 		if len(h) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("h", h, len(h), 1, true))
 		}
-		for _, e := range h {
-			err = goa.MergeErrors(err, goa.ValidatePattern("h[*]", e, "val"))
+		for i, e := range h {
+			err = goa.MergeErrors(err, goa.ValidatePattern("h["+strconv.Itoa(i)+"]", e, "val"))
 		}
 		if err != nil {
 			return nil, err
@@ -3301,9 +3350,9 @@ func DecodeMethodHeaderPrimitiveArrayBoolValidateRequest(mux goahttp.Muxer, deco
 		if len(h) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("h", h, len(h), 1, true))
 		}
-		for _, e := range h {
+		for i, e := range h {
 			if !(e == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("h[*]", e, []interface{}{true}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("h["+strconv.Itoa(i)+"]", e, []interface{}{true}))
 			}
 		}
 		if err != nil {
@@ -3817,9 +3866,9 @@ func DecodeMethodBodyPrimitiveArrayStringValidateRequest(mux goahttp.Muxer, deco
 		if len(body) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("body", body, len(body), 1, true))
 		}
-		for _, e := range body {
+		for i, e := range body {
 			if !(e == "val") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("body[*]", e, []interface{}{"val"}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("body["+strconv.Itoa(i)+"]", e, []interface{}{"val"}))
 			}
 		}
 		if err != nil {
@@ -3851,9 +3900,9 @@ func DecodeMethodBodyPrimitiveArrayBoolValidateRequest(mux goahttp.Muxer, decode
 		if len(body) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("body", body, len(body), 1, true))
 		}
-		for _, e := range body {
+		for i, e := range body {
 			if !(e == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("body[*]", e, []interface{}{true}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("body["+strconv.Itoa(i)+"]", e, []interface{}{true}))
 			}
 		}
 		if err != nil {
@@ -3967,8 +4016,8 @@ func DecodeMethodBodyPrimitiveArrayUserValidateRequest(mux goahttp.Muxer, decode
 		if len(body) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("body", body, len(body), 1, true))
 		}
-		for _, e := range body {
-			err = goa.MergeErrors(err, goa.ValidatePattern("body[*]", e, "pattern"))
+		for i, e := range body {
+			err = goa.MergeErrors(err, goa.ValidatePattern("body["+strconv.Itoa(i)+"]", e, "pattern"))
 		}
 		if err != nil {
 			return nil, err
@@ -4691,3 +4740,80 @@ func DecodeMethodARequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp
 	}
 }
 `
+
+var PayloadPathStyledParamsDecodeCode = `// DecodeMethodPathStyledParamsRequest returns a decoder for requests sent to
+// the ServicePathStyledParams MethodPathStyledParams endpoint.
+func DecodeMethodPathStyledParamsRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			id     string
+			format *string
+			color  *string
+
+			params = mux.Vars(r)
+		)
+		id = params["id"]
+		if formatRaw := params["format"]; formatRaw != "" {
+			format = &formatRaw
+		}
+		if colorRaw := params["color"]; colorRaw != "" {
+			color = &colorRaw
+		}
+		payload := NewMethodPathStyledParamsPayload(id, format, color)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadPathCatchAllSliceParamDecodeCode = `// DecodeMethodPathCatchAllSliceParamRequest returns a decoder for requests
+// sent to the ServicePathCatchAllSliceParam MethodPathCatchAllSliceParam
+// endpoint.
+func DecodeMethodPathCatchAllSliceParamRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			path []string
+
+			params = mux.Vars(r)
+		)
+		{
+			pathRaw := params["path"]
+			pathRawSlice := strings.Split(pathRaw, "/")
+			path = make([]string, len(pathRawSlice))
+			for i, rv := range pathRawSlice {
+				path[i] = rv
+			}
+		}
+		payload := NewMethodPathCatchAllSliceParamPayload(path)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadClientCertificateDecodeCode = `// DecodeMethodClientCertificateRequest returns a decoder for requests sent to
+// the ServiceClientCertificate MethodClientCertificate endpoint.
+func DecodeMethodClientCertificateRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			body MethodClientCertificateRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		payload := NewMethodClientCertificatePayload(&body)
+		cert := security.PeerCertificate(r.TLS)
+		if cert == "" {
+			return nil, goa.MissingFieldError("cert", "TLS client certificate")
+		}
+		payload.Cert = cert
+
+		return payload, nil
+	}
+}
+`
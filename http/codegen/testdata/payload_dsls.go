@@ -738,6 +738,39 @@ var PayloadQueryArrayStringValidateDSL = func() {
 	})
 }
 
+var PayloadQueryArrayStringPipeDelimitedDSL = func() {
+	Service("ServiceQueryArrayStringPipeDelimited", func() {
+		Method("MethodQueryArrayStringPipeDelimited", func() {
+			Payload(func() {
+				Attribute("q", ArrayOf(String))
+			})
+			HTTP(func() {
+				GET("/")
+				Param("q", func() {
+					QueryStyle("pipeDelimited")
+				})
+			})
+		})
+	})
+}
+
+var PayloadQueryArrayIntCommaDelimitedDSL = func() {
+	Service("ServiceQueryArrayIntCommaDelimited", func() {
+		Method("MethodQueryArrayIntCommaDelimited", func() {
+			Payload(func() {
+				Attribute("q", ArrayOf(Int))
+				Required("q")
+			})
+			HTTP(func() {
+				GET("/")
+				Param("q", func() {
+					Explode(false)
+				})
+			})
+		})
+	})
+}
+
 var PayloadQueryArrayBytesDSL = func() {
 	Service("ServiceQueryArrayBytes", func() {
 		Method("MethodQueryArrayBytes", func() {
@@ -2646,3 +2679,61 @@ var WithParamsAndHeadersBlockDSL = func() {
 		})
 	})
 }
+
+var PayloadMultipartDefaultDSL = func() {
+	Service("ServiceMultipartDefault", func() {
+		Method("MethodMultipartDefault", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("name", String)
+				Attribute("count", Int)
+				Attribute("public", Boolean, func() {
+					Default(true)
+				})
+				Attribute("file", Bytes)
+				Attribute("tags", ArrayOf(Int32))
+				Attribute("labels", MapOf(String, String))
+				Required("id", "count", "file")
+			})
+			HTTP(func() {
+				POST("/{id}")
+				MultipartRequest()
+			})
+		})
+	})
+}
+
+var PayloadMultipartStreamDSL = func() {
+	Service("ServiceMultipartStream", func() {
+		Method("MethodMultipartStream", func() {
+			Payload(func() {
+				Attribute("name", String)
+				Attribute("file", Bytes, func() {
+					Meta("struct:field:type", "io.ReadCloser", "io")
+				})
+				Required("file")
+			})
+			HTTP(func() {
+				POST("/")
+				MultipartRequest()
+			})
+		})
+	})
+}
+
+var PayloadClientCertificateDSL = func() {
+	var MTLS = MTLSSecurity("mtls")
+	Service("ServiceClientCertificate", func() {
+		Method("MethodClientCertificate", func() {
+			Security(MTLS)
+			Payload(func() {
+				ClientCertificate("cert", String)
+				Attribute("amount", Int)
+				Required("cert")
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
@@ -791,6 +791,49 @@ func EncodeMethodQueryArrayStringValidateRequest(encoder func(*http.Request) goa
 }
 `
 
+var PayloadQueryArrayStringPipeDelimitedEncodeCode = `// EncodeMethodQueryArrayStringPipeDelimitedRequest returns an encoder for
+// requests sent to the ServiceQueryArrayStringPipeDelimited
+// MethodQueryArrayStringPipeDelimited server.
+func EncodeMethodQueryArrayStringPipeDelimitedRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
+	return func(req *http.Request, v interface{}) error {
+		p, ok := v.(*servicequeryarraystringpipedelimited.MethodQueryArrayStringPipeDelimitedPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceQueryArrayStringPipeDelimited", "MethodQueryArrayStringPipeDelimited", "*servicequeryarraystringpipedelimited.MethodQueryArrayStringPipeDelimitedPayload", v)
+		}
+		values := req.URL.Query()
+		if len(p.Q) > 0 {
+			values.Add("q", strings.Join(p.Q, "|"))
+		}
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+`
+
+var PayloadQueryArrayIntCommaDelimitedEncodeCode = `// EncodeMethodQueryArrayIntCommaDelimitedRequest returns an encoder for
+// requests sent to the ServiceQueryArrayIntCommaDelimited
+// MethodQueryArrayIntCommaDelimited server.
+func EncodeMethodQueryArrayIntCommaDelimitedRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
+	return func(req *http.Request, v interface{}) error {
+		p, ok := v.(*servicequeryarrayintcommadelimited.MethodQueryArrayIntCommaDelimitedPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceQueryArrayIntCommaDelimited", "MethodQueryArrayIntCommaDelimited", "*servicequeryarrayintcommadelimited.MethodQueryArrayIntCommaDelimitedPayload", v)
+		}
+		values := req.URL.Query()
+		if len(p.Q) > 0 {
+			qStrs := make([]string, len(p.Q))
+			for i, value := range p.Q {
+				valueStr := strconv.Itoa(value)
+				qStrs[i] = valueStr
+			}
+			values.Add("q", strings.Join(qStrs, ","))
+		}
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+`
+
 var PayloadQueryArrayBytesEncodeCode = `// EncodeMethodQueryArrayBytesRequest returns an encoder for requests sent to
 // the ServiceQueryArrayBytes MethodQueryArrayBytes server.
 func EncodeMethodQueryArrayBytesRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
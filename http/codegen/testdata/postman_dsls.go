@@ -0,0 +1,79 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var PostmanDSL = func() {
+	var BasicAuth = BasicAuthSecurity("basic")
+	var APIKeyAuth = APIKeySecurity("api_key")
+	var JWTAuth = JWTSecurity("jwt")
+	var Bottle = Type("Bottle", func() {
+		Attribute("name", String, func() {
+			Example("Lagavulin")
+		})
+		Attribute("vintage", Int, func() {
+			Example(1990)
+		})
+		Required("name")
+	})
+	API("cellar", func() {
+		Title("Cellar")
+		Version("1.0")
+		Meta("postman:collection")
+		Server("cellar", func() {
+			Host("dev", func() {
+				URI("http://{host}:8080/api")
+				Variable("host", String, func() {
+					Default("localhost")
+				})
+			})
+		})
+	})
+	Service("storage", func() {
+		Method("show", func() {
+			Security(JWTAuth)
+			Payload(func() {
+				Token("token", String)
+				Attribute("id", String, func() {
+					Example("a1")
+				})
+				Attribute("view", String, func() {
+					Example("tiny")
+				})
+				Required("id")
+			})
+			HTTP(func() {
+				GET("/bottles/{id}")
+				Param("view")
+			})
+		})
+		Method("add", func() {
+			Security(APIKeyAuth)
+			Payload(func() {
+				APIKey("api_key", "key", String)
+				Attribute("bottle", Bottle)
+				Attribute("tags", ArrayOf(String), func() {
+					Example([]string{"peaty", "smoky"})
+				})
+				Required("tags")
+			})
+			HTTP(func() {
+				POST("/bottles")
+				Param("key:k")
+				Param("tags")
+				Body("bottle")
+			})
+		})
+		Method("list", func() {
+			Security(BasicAuth)
+			Payload(func() {
+				Username("user", String)
+				Password("pass", String)
+			})
+			HTTP(func() {
+				GET("/bottles")
+			})
+		})
+	})
+}
@@ -0,0 +1,63 @@
+package testdata
+
+var ProblemDetailsErrorEncoderCode = `// EncodeMethodErrorsError returns an encoder for errors returned by the
+// MethodErrors ServiceProblemDetails endpoint.
+func EncodeMethodErrorsError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ProblemErrorEncoder(encoder, "https://goa.design/problems/")
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		en, ok := v.(ErrorNamer)
+		if !ok {
+			return encodeError(ctx, w, v)
+		}
+		switch en.ErrorName() {
+		case "not_found":
+			res := v.(*goa.ServiceError)
+			w.Header().Set("goa-error", "not_found")
+			return goahttp.EncodeProblem(ctx, encoder, w, res, "https://goa.design/problems/", http.StatusNotFound)
+		case "conflict":
+			res := v.(*serviceproblemdetails.Conflict)
+			enc := encoder(ctx, w)
+			body := NewMethodErrorsConflictResponseBody(res)
+			w.Header().Set("goa-error", "conflict")
+			w.WriteHeader(http.StatusConflict)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+`
+
+var ProblemDetailsHandlerInitCode = `// NewMethodNoErrorsHandler creates a HTTP handler which loads the HTTP request
+// and calls the "ServiceProblemDetails" service "MethodNoErrors" endpoint.
+func NewMethodNoErrorsHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		encodeResponse = EncodeMethodNoErrorsResponse(enc)
+		encodeError    = goahttp.ProblemErrorEncoder(enc, "https://goa.design/problems/")
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodNoErrors")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceProblemDetails")
+		var err error
+
+		res, err := endpoint(ctx, nil)
+
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
@@ -0,0 +1,31 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ProblemDetailsDSL = func() {
+	API("API", func() {
+		HTTP(func() {
+			ProblemDetails("https://goa.design/problems/")
+		})
+	})
+	Service("ServiceProblemDetails", func() {
+		Method("MethodErrors", func() {
+			Error("not_found")
+			Error("conflict", func() {
+				Attribute("reason", String)
+			})
+			HTTP(func() {
+				GET("/errors")
+				Response("not_found", StatusNotFound)
+				Response("conflict", StatusConflict)
+			})
+		})
+		Method("MethodNoErrors", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
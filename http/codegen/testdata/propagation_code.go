@@ -0,0 +1,79 @@
+package testdata
+
+var PropagationServerHandlerInitCode = `// NewMethodHandler creates a HTTP handler which loads the HTTP request and
+// calls the "ServicePropagation" service "Method" endpoint.
+func NewMethodHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		decodeRequest  = DecodeMethodRequest(mux, dec)
+		encodeResponse = EncodeMethodResponse(enc)
+		encodeError    = goahttp.ErrorEncoder(enc)
+		propagation    = &goa.Propagation{
+			Headers:  []string{"X-Request-Id", "traceparent", "tracestate"},
+			Deadline: true,
+		}
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "Method")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServicePropagation")
+		ctx, cancelTimeout := goahttp.PropagateIncoming(ctx, r, propagation)
+		defer cancelTimeout()
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+
+		res, err := endpoint(ctx, payload)
+
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
+
+var PropagationClientEndpointInitCode = `// Method returns an endpoint that makes HTTP requests to the
+// ServicePropagation service Method server.
+func (c *Client) Method() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeMethodRequest(c.encoder)
+		decodeResponse = DecodeMethodResponse(c.decoder, c.RestoreResponseBody)
+		propagation    = &goa.Propagation{
+			Headers:  []string{"X-Request-Id", "traceparent", "tracestate"},
+			Deadline: true,
+		}
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		req, err := c.BuildMethodRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		goahttp.PropagateOutgoing(ctx, req, propagation)
+		resp, err := c.MethodDoer.Do(req)
+
+		if err != nil {
+			return nil, goahttp.ErrRequestError("ServicePropagation", "Method", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+`
@@ -0,0 +1,20 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var PropagationDSL = func() {
+	API("API", func() {
+		Propagate()
+		PropagateDeadline()
+	})
+	Service("ServicePropagation", func() {
+		Method("Method", func() {
+			Payload(String)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
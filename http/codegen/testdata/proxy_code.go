@@ -0,0 +1,200 @@
+package testdata
+
+var ProxyServerStructCode = `// Server lists the ServiceProxy service endpoint HTTP handlers.
+type Server struct {
+	Mounts               []*MountPoint
+	MethodProxy          http.Handler
+	MethodProxyBody      http.Handler
+	MethodProxyNoPayload http.Handler
+	// MethodProxyProxy forwards the requests made to the MethodProxy endpoint, its
+	// hooks may be set prior to serving requests.
+	MethodProxyProxy *goahttp.Proxy
+	// MethodProxyBodyProxy forwards the requests made to the MethodProxyBody
+	// endpoint, its hooks may be set prior to serving requests.
+	MethodProxyBodyProxy *goahttp.Proxy
+	// MethodProxyNoPayloadProxy forwards the requests made to the
+	// MethodProxyNoPayload endpoint, its hooks may be set prior to serving
+	// requests.
+	MethodProxyNoPayloadProxy *goahttp.Proxy
+}
+
+// ErrorNamer is an interface implemented by generated error structs that
+// exposes the name of the error as defined in the design.
+type ErrorNamer interface {
+	ErrorName() string
+}
+`
+
+var ProxyServerInitCode = `// New instantiates HTTP handlers for all the ServiceProxy service endpoints.
+func New(
+	e *serviceproxy.Endpoints,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) *Server {
+	methodProxyProxy := NewMethodProxyProxy()
+	methodProxyBodyProxy := NewMethodProxyBodyProxy()
+	methodProxyNoPayloadProxy := NewMethodProxyNoPayloadProxy()
+	return &Server{
+		Mounts: []*MountPoint{
+			{"MethodProxy", "GET", "/gateway/accounts"},
+			{"MethodProxyBody", "POST", "/gateway/accounts"},
+			{"MethodProxyNoPayload", "GET", "/gateway/status"},
+		},
+		MethodProxy:               NewMethodProxyHandler(e.MethodProxy, mux, dec, enc, eh, methodProxyProxy),
+		MethodProxyBody:           NewMethodProxyBodyHandler(e.MethodProxyBody, mux, dec, enc, eh, methodProxyBodyProxy),
+		MethodProxyNoPayload:      NewMethodProxyNoPayloadHandler(e.MethodProxyNoPayload, mux, dec, enc, eh, methodProxyNoPayloadProxy),
+		MethodProxyProxy:          methodProxyProxy,
+		MethodProxyBodyProxy:      methodProxyBodyProxy,
+		MethodProxyNoPayloadProxy: methodProxyNoPayloadProxy,
+	}
+}
+`
+
+var ProxyHandlerInitCode = `// NewMethodProxyHandler creates a HTTP handler which loads the HTTP request
+// and calls the "ServiceProxy" service "MethodProxy" endpoint.
+func NewMethodProxyHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+	proxy *goahttp.Proxy,
+) http.Handler {
+	var (
+		decodeRequest = DecodeMethodProxyRequest(mux, dec)
+		encodeError   = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodProxy")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceProxy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+
+		_, err = endpoint(ctx, payload)
+
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		proxy.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// NewMethodProxyBodyHandler creates a HTTP handler which loads the HTTP
+// request and calls the "ServiceProxy" service "MethodProxyBody" endpoint.
+func NewMethodProxyBodyHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+	proxy *goahttp.Proxy,
+) http.Handler {
+	var (
+		decodeRequest = DecodeMethodProxyBodyRequest(mux, dec)
+		encodeError   = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodProxyBody")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceProxy")
+		goahttp.LimitRequestBody(w, r, goahttp.DefaultProxyBodyLimit)
+		if err := goahttp.BufferRequestBody(r); err != nil {
+			err = goahttp.BodyLimitError(r, err)
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		payload, err := decodeRequest(r)
+		if err != nil {
+			err = goahttp.BodyLimitError(r, err)
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+
+		_, err = endpoint(ctx, payload)
+
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		proxy.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// NewMethodProxyNoPayloadHandler creates a HTTP handler which loads the HTTP
+// request and calls the "ServiceProxy" service "MethodProxyNoPayload" endpoint.
+func NewMethodProxyNoPayloadHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+	proxy *goahttp.Proxy,
+) http.Handler {
+	var (
+		encodeError = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodProxyNoPayload")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceProxy")
+		var err error
+
+		_, err = endpoint(ctx, nil)
+
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		proxy.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+`
+
+var ProxyInitCode = `// NewMethodProxyProxy creates the proxy that forwards the requests made to the
+// "ServiceProxy" service "MethodProxy" endpoint to
+// "http://accounts.internal:8080/v1".
+func NewMethodProxyProxy() *goahttp.Proxy {
+	p := goahttp.NewProxy("http://accounts.internal:8080/v1")
+	p.StripPrefix = "/gateway"
+	p.Header = http.Header{
+		"X-Gateway": {"goa"},
+	}
+	p.RemoveHeaders = []string{"Authorization"}
+	return p
+}
+
+// NewMethodProxyBodyProxy creates the proxy that forwards the requests made to
+// the "ServiceProxy" service "MethodProxyBody" endpoint to
+// "http://accounts.internal:8080/v1".
+func NewMethodProxyBodyProxy() *goahttp.Proxy {
+	p := goahttp.NewProxy("http://accounts.internal:8080/v1")
+	return p
+}
+
+// NewMethodProxyNoPayloadProxy creates the proxy that forwards the requests
+// made to the "ServiceProxy" service "MethodProxyNoPayload" endpoint to
+// "http://status.internal".
+func NewMethodProxyNoPayloadProxy() *goahttp.Proxy {
+	p := goahttp.NewProxy("http://status.internal")
+	return p
+}
+`
@@ -0,0 +1,42 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ProxyDSL = func() {
+	Service("ServiceProxy", func() {
+		Method("MethodProxy", func() {
+			Payload(func() {
+				Attribute("token", String)
+				Attribute("page", Int)
+				Required("token")
+			})
+			HTTP(func() {
+				GET("/gateway/accounts")
+				Header("token:Authorization")
+				Param("page")
+				Proxy("http://accounts.internal:8080/v1", func() {
+					StripPrefix("/gateway")
+					SetRequestHeader("x-gateway", "goa")
+					RemoveRequestHeader("Authorization")
+				})
+			})
+		})
+		Method("MethodProxyBody", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				POST("/gateway/accounts")
+				Proxy("http://accounts.internal:8080/v1")
+			})
+		})
+		Method("MethodProxyNoPayload", func() {
+			HTTP(func() {
+				GET("/gateway/status")
+				Proxy("http://status.internal")
+			})
+		})
+	})
+}
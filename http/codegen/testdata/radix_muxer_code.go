@@ -0,0 +1,53 @@
+package testdata
+
+const RadixMuxerCode = `// New returns a goa Muxer that dispatches the requests using a route tree
+// compiled from the HTTP routes of the design. The requests that the tree does
+// not match are dispatched to fallback, New uses the muxer returned by
+// goahttp.NewMuxer if fallback is nil.
+//
+// The following routes cannot be compiled so that the muxer dispatches all the
+// requests to fallback:
+//
+//   - GET /report{.format} (service "Files" HTTP endpoint "Report")
+//
+// The following routes of different services conflict, they cannot be mounted
+// on the same muxer:
+//
+//   - GET /users/{name} (service "Accounts" HTTP endpoint "Show") conflicts with GET /users/{id} (service "Users" HTTP endpoint "Show")
+func New(fallback goahttp.Muxer) goahttp.Muxer {
+	return goahttp.NewRadixMuxer(RouteTree(), fallback)
+}
+
+// RouteTree returns the route tree compiled from the HTTP routes of the design.
+func RouteTree() *goahttp.RouteTree {
+	return &goahttp.RouteTree{
+		Routes: []*goahttp.TreeRoute{
+			{Method: "GET", Pattern: "/users"},
+			{Method: "GET", Pattern: "/users/{id}", Vars: []string{"id"}},
+			{Method: "GET", Pattern: "/users/me"},
+			{Method: "PUT", Pattern: "/users/{id}", Vars: []string{"id"}},
+			{Method: "PATCH", Pattern: "/users/{id}", Vars: []string{"id"}},
+			{Method: "GET", Pattern: "/files/{*path}", Vars: []string{"path"}},
+			{Method: "GET", Pattern: "/users/{name}", Vars: []string{"name"}},
+		},
+		Root: &goahttp.RouteNode{
+			Static: map[string]*goahttp.RouteNode{
+				"files": {
+					CatchAll: map[string]int{"GET": 5},
+				},
+				"users": {
+					Static: map[string]*goahttp.RouteNode{
+						"me": {
+							Routes: map[string]int{"GET": 2},
+						},
+					},
+					Param: &goahttp.RouteNode{
+						Routes: map[string]int{"GET": 6, "PATCH": 4, "PUT": 3},
+					},
+					Routes: map[string]int{"GET": 0},
+				},
+			},
+		},
+	}
+}
+`
@@ -0,0 +1,68 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var RadixMuxerDSL = func() {
+	Service("Users", func() {
+		HTTP(func() {
+			Path("/users")
+		})
+		Method("List", func() {
+			HTTP(func() {
+				GET("")
+			})
+		})
+		Method("Show", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				GET("/{id}")
+			})
+		})
+		Method("Me", func() {
+			HTTP(func() {
+				GET("/me")
+			})
+		})
+		Method("Update", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				PUT("/{id}")
+				PATCH("/{id}")
+			})
+		})
+	})
+	Service("Files", func() {
+		Method("Download", func() {
+			Payload(func() {
+				Attribute("path", String)
+			})
+			HTTP(func() {
+				GET("/files/{*path}")
+			})
+		})
+		Method("Report", func() {
+			Payload(func() {
+				Attribute("format", String)
+			})
+			HTTP(func() {
+				GET("/report{.format}")
+			})
+		})
+	})
+	Service("Accounts", func() {
+		Method("Show", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				GET("/users/{name}")
+			})
+		})
+	})
+}
@@ -538,9 +538,9 @@ func DecodeMethodAResponse(decoder func(*http.Response) goahttp.Decoder, restore
 					}
 				}
 			}
-			for _, e := range array {
+			for i, e := range array {
 				if e < 5 {
-					err = goa.MergeErrors(err, goa.InvalidRangeError("array[*]", e, 5, true))
+					err = goa.MergeErrors(err, goa.InvalidRangeError("array["+strconv.Itoa(i)+"]", e, 5, true))
 				}
 			}
 			if err != nil {
@@ -0,0 +1,324 @@
+package testdata
+
+var SDKClientCode = `type (
+	// Client is the test api API client, it gives access to the clients of the
+	// services exposed over HTTP.
+	Client struct {
+		// ServiceSDK is the "ServiceSDK" service client.
+		ServiceSDK *ServiceSDKClient
+	}
+
+	// Option customizes the client created by New.
+	Option func(*options)
+
+	// options holds the settings of the client.
+	options struct {
+		baseURL string
+		doer    goahttp.Doer
+		auth    credentials.Provider
+		retry   *goahttp.RetryPolicy
+		cache   goa.CacheStore
+	}
+)
+
+// WithBaseURL sets the scheme and host of the API, the default is
+// "http://localhost:80". The path of the URL is ignored as the HTTP routes are
+// not relative to it.
+func WithBaseURL(u string) Option {
+	return func(o *options) {
+		o.baseURL = u
+	}
+}
+
+// WithDoer sets the HTTP client used to make the requests, the default is
+// http.DefaultClient.
+func WithDoer(doer goahttp.Doer) Option {
+	return func(o *options) {
+		o.doer = doer
+	}
+}
+
+// WithAuth sets the provider of the credentials used to secure the requests.
+// The clients initialize the security attributes of the payloads that are not
+// set with the credentials given by the provider, see credentials.Profile.
+func WithAuth(p credentials.Provider) Option {
+	return func(o *options) {
+		o.auth = p
+	}
+}
+
+// WithRetry sets the policy used to retry the failed requests made to all the
+// endpoints. The client policies defined in the design still apply to their
+// endpoints.
+func WithRetry(p *goahttp.RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = p
+	}
+}
+
+// WithResponseCache sets the store used to cache the responses to the requests
+// made to the endpoints that use the GET method. The responses are cached as
+// allowed by their Cache-Control header and revalidated with their ETag, see
+// goahttp.CachingDoer.
+func WithResponseCache(store goa.CacheStore) Option {
+	return func(o *options) {
+		o.cache = store
+	}
+}
+
+// New returns a client that makes the requests to the base URL configured with
+// the given options. It returns an error if the base URL is invalid.
+func New(opts ...Option) (*Client, error) {
+	o := &options{baseURL: "http://localhost:80", doer: http.DefaultClient}
+	for _, opt := range opts {
+		opt(o)
+	}
+	u, err := url.Parse(o.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL %q: %s", o.baseURL, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q: missing scheme or host", o.baseURL)
+	}
+	doer := o.doer
+	if o.retry != nil {
+		doer = goahttp.PolicyDoer(doer, &goahttp.ClientPolicy{Retry: o.retry})
+	}
+	c := &Client{}
+	{
+		hc := servicesdkc.NewClient(
+			u.Scheme,
+			u.Host,
+			doer,
+			goahttp.PooledRequestEncoder,
+			goahttp.ResponseDecoder,
+			false,
+		)
+		if o.cache != nil {
+			hc.UseResponseCache(o.cache)
+		}
+		c.ServiceSDK = newServiceSDKClient(hc, o.auth)
+	}
+	return c, nil
+}
+`
+
+var SDKServiceClientCode = `// ServiceSDKClient is the "ServiceSDK" service client.
+type ServiceSDKClient struct {
+	methodListEndpoint  goa.Endpoint
+	methodPagesEndpoint goa.Endpoint
+	methodShowEndpoint  goa.Endpoint
+	methodPingEndpoint  goa.Endpoint
+	auth                credentials.Provider
+}
+
+// newServiceSDKClient returns the "ServiceSDK" service client that makes the
+// requests with c and initializes the payload credentials with auth if not nil.
+func newServiceSDKClient(c *servicesdkc.Client, auth credentials.Provider) *ServiceSDKClient {
+	return &ServiceSDKClient{
+		methodListEndpoint:  c.MethodList(),
+		methodPagesEndpoint: c.MethodPages(),
+		methodShowEndpoint:  c.MethodShow(),
+		methodPingEndpoint:  c.MethodPing(),
+		auth:                auth,
+	}
+}
+`
+
+var SDKServiceMethodCode = `// MethodList calls the "MethodList" endpoint of the "ServiceSDK" service.
+func (c *ServiceSDKClient) MethodList(ctx context.Context, p *servicesdk.MethodListPayload) (res *servicesdk.MethodListResult, err error) {
+	if c.auth != nil && p != nil {
+		cp := *p
+		p = &cp
+		if p.Token == nil {
+			var v string
+			if v, err = c.auth.Value(ctx, &credentials.Credential{Name: "jwt", Kind: "JWT", Scopes: []string{"api:read"}}); err != nil {
+				return
+			}
+			if v != "" {
+				p.Token = &v
+			}
+		}
+	}
+	var ires interface{}
+	ires, err = c.methodListEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*servicesdk.MethodListResult), nil
+}
+
+// MethodPages calls the "MethodPages" endpoint of the "ServiceSDK" service.
+func (c *ServiceSDKClient) MethodPages(ctx context.Context, p *servicesdk.MethodPagesPayload) (res *servicesdk.MethodPagesResult, err error) {
+	var ires interface{}
+	ires, err = c.methodPagesEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*servicesdk.MethodPagesResult), nil
+}
+
+// MethodShow calls the "MethodShow" endpoint of the "ServiceSDK" service.
+// MethodShow may return the following errors:
+//   - "not_found" (type *goa.ServiceError)
+//   - error: internal error
+func (c *ServiceSDKClient) MethodShow(ctx context.Context, p *servicesdk.MethodShowPayload) (res *servicesdk.SDKItem, err error) {
+	if c.auth != nil && p != nil {
+		cp := *p
+		p = &cp
+		if p.User == "" {
+			if p.User, err = c.auth.Value(ctx, &credentials.Credential{Name: "basic.username", Kind: "Basic"}); err != nil {
+				return
+			}
+		}
+		if p.Pass == "" {
+			if p.Pass, err = c.auth.Value(ctx, &credentials.Credential{Name: "basic.password", Kind: "Basic"}); err != nil {
+				return
+			}
+		}
+	}
+	var ires interface{}
+	ires, err = c.methodShowEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*servicesdk.SDKItem), nil
+}
+
+// MethodPing calls the "MethodPing" endpoint of the "ServiceSDK" service.
+func (c *ServiceSDKClient) MethodPing(ctx context.Context) (err error) {
+	_, err = c.methodPingEndpoint(ctx, nil)
+	return
+}
+`
+
+var SDKIteratorCode = `// ServiceSDKMethodListIterator iterates over the items of the pages returned
+// by the "MethodList" method of the "ServiceSDK" service, see MethodListIter.
+type ServiceSDKMethodListIterator struct {
+	client *ServiceSDKClient
+	ctx    context.Context
+	p      *servicesdk.MethodListPayload
+	page   *servicesdk.MethodListResult
+	items  []*servicesdk.SDKItem
+	item   *servicesdk.SDKItem
+	err    error
+	done   bool
+}
+
+// MethodListIter returns an iterator over the items of the pages returned by
+// the "MethodList" method starting with the page requested by p. The iterator
+// requests the next pages as needed until a page does not identify a next page.
+func (c *ServiceSDKClient) MethodListIter(ctx context.Context, p *servicesdk.MethodListPayload) *ServiceSDKMethodListIterator {
+	if p == nil {
+		p = &servicesdk.MethodListPayload{}
+	}
+	return &ServiceSDKMethodListIterator{client: c, ctx: ctx, p: p}
+}
+
+// Next advances the iterator to the next item and requests the next page if
+// needed. It returns false once all the items have been iterated over or if a
+// request fails, see Err.
+func (it *ServiceSDKMethodListIterator) Next() bool {
+	for len(it.items) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		if it.page != nil {
+			if it.page.NextCursor == nil || *it.page.NextCursor == "" {
+				it.done = true
+				return false
+			}
+			cp := *it.p
+			cp.Cursor = it.page.NextCursor
+			it.p = &cp
+		}
+		it.page, it.err = it.client.MethodList(it.ctx, it.p)
+		if it.err != nil {
+			return false
+		}
+		it.items = it.page.Items
+	}
+	it.item, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Item returns the current item.
+func (it *ServiceSDKMethodListIterator) Item() *servicesdk.SDKItem {
+	return it.item
+}
+
+// Page returns the page that contains the current item.
+func (it *ServiceSDKMethodListIterator) Page() *servicesdk.MethodListResult {
+	return it.page
+}
+
+// Err returns the error returned by the last request if any.
+func (it *ServiceSDKMethodListIterator) Err() error {
+	return it.err
+}
+
+// ServiceSDKMethodPagesIterator iterates over the items of the pages returned
+// by the "MethodPages" method of the "ServiceSDK" service, see MethodPagesIter.
+type ServiceSDKMethodPagesIterator struct {
+	client *ServiceSDKClient
+	ctx    context.Context
+	p      *servicesdk.MethodPagesPayload
+	page   *servicesdk.MethodPagesResult
+	items  []string
+	item   string
+	err    error
+	done   bool
+}
+
+// MethodPagesIter returns an iterator over the items of the pages returned by
+// the "MethodPages" method starting with the page requested by p. The iterator
+// requests the next pages as needed until a page does not identify a next page.
+func (c *ServiceSDKClient) MethodPagesIter(ctx context.Context, p *servicesdk.MethodPagesPayload) *ServiceSDKMethodPagesIterator {
+	if p == nil {
+		p = &servicesdk.MethodPagesPayload{}
+	}
+	return &ServiceSDKMethodPagesIterator{client: c, ctx: ctx, p: p}
+}
+
+// Next advances the iterator to the next item and requests the next page if
+// needed. It returns false once all the items have been iterated over or if a
+// request fails, see Err.
+func (it *ServiceSDKMethodPagesIterator) Next() bool {
+	for len(it.items) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		if it.page != nil {
+			if it.page.Next == 0 {
+				it.done = true
+				return false
+			}
+			cp := *it.p
+			cp.Page = it.page.Next
+			it.p = &cp
+		}
+		it.page, it.err = it.client.MethodPages(it.ctx, it.p)
+		if it.err != nil {
+			return false
+		}
+		it.items = it.page.Items
+	}
+	it.item, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Item returns the current item.
+func (it *ServiceSDKMethodPagesIterator) Item() string {
+	return it.item
+}
+
+// Page returns the page that contains the current item.
+func (it *ServiceSDKMethodPagesIterator) Page() *servicesdk.MethodPagesResult {
+	return it.page
+}
+
+// Err returns the error returned by the last request if any.
+func (it *ServiceSDKMethodPagesIterator) Err() error {
+	return it.err
+}
+`
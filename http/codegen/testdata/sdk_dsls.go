@@ -0,0 +1,72 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var SDKDSL = func() {
+	var JWTAuth = JWTSecurity("jwt", func() {
+		Scope("api:read")
+	})
+	var BasicAuth = BasicAuthSecurity("basic")
+	var Item = Type("SDKItem", func() {
+		Attribute("name", String)
+	})
+	Service("ServiceSDK", func() {
+		Error("not_found", String, "Item not found")
+		Method("MethodList", func() {
+			Security(JWTAuth, func() {
+				Scope("api:read")
+			})
+			Payload(func() {
+				Token("token", String)
+				Attribute("cursor", String)
+			})
+			Result(func() {
+				Attribute("next_cursor", String)
+				Attribute("items", ArrayOf(Item))
+			})
+			Meta("sdk:paginate", "cursor", "next_cursor", "items")
+			HTTP(func() {
+				GET("/")
+				Param("cursor")
+			})
+		})
+		Method("MethodPages", func() {
+			Payload(func() {
+				Attribute("page", Int)
+				Required("page")
+			})
+			Result(func() {
+				Attribute("next", Int)
+				Attribute("items", ArrayOf(String))
+				Required("next")
+			})
+			Meta("sdk:paginate", "page", "next", "items")
+			HTTP(func() {
+				GET("/pages")
+				Param("page")
+			})
+		})
+		Method("MethodShow", func() {
+			Security(BasicAuth)
+			Payload(func() {
+				Username("user", String)
+				Password("pass", String)
+				Attribute("id", String)
+				Required("user", "pass")
+			})
+			Result(Item)
+			Error("not_found")
+			HTTP(func() {
+				GET("/{id}")
+				Response("not_found", StatusNotFound)
+			})
+		})
+		Method("MethodPing", func() {
+			HTTP(func() {
+				GET("/ping")
+			})
+		})
+	})
+}
@@ -130,6 +130,32 @@ var ServerFileServerDSL = func() {
 	})
 }
 
+var ServerDirFileServerDSL = func() {
+	Service("ServiceDirFileServer", func() {
+		Files("/static/{*path}", "/path/to/static")
+	})
+}
+
+var ServerConfiguredFileServerDSL = func() {
+	Service("ServiceConfiguredFileServer", func() {
+		Files("/app/{*path}", "/path/to/app", func() {
+			Fallback("index.html")
+			Immutable()
+			ETag()
+		})
+	})
+}
+
+var ServerEmbedFileServerDSL = func() {
+	Service("ServiceEmbedFileServer", func() {
+		Files("/favicon.ico", "public/favicon.ico", func() {
+			Embed()
+			CacheControl("public, max-age=86400")
+		})
+		Files("/robots.txt", "/path/to/robots.txt")
+	})
+}
+
 var ServerMixedDSL = func() {
 	Service("ServerMixed", func() {
 		Method("MethodMixed", func() {
@@ -156,3 +182,19 @@ var ServerMultipartDSL = func() {
 		})
 	})
 }
+
+var ServerBodyLimitDSL = func() {
+	Service("ServiceBodyLimit", func() {
+		HTTP(func() {
+			BodyLimit("2MB")
+		})
+		Method("MethodBodyLimit", func() {
+			Payload(func() {
+				Attribute("a", String)
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
@@ -0,0 +1,25 @@
+package testdata
+
+var SignatureServerVerifyCode = `// VerifyPartnerSignatures wraps the handlers of the endpoints secured by the
+// "partner" security scheme with a middleware that verifies the request
+// signatures using the secrets returned by keys. It must be called before the
+// handlers are mounted.
+func (s *Server) VerifyPartnerSignatures(keys goahttp.SignatureKeyFunc) {
+	m := httpmdlwr.VerifySignature(&goahttp.SignatureVerifier{
+		Keys:         keys,
+		ClockSkew:    2 * time.Minute,
+		ReplayWindow: 10 * time.Minute,
+	})
+	s.MethodSigned = m(s.MethodSigned)
+	s.MethodSignedOrKey = m(s.MethodSignedOrKey)
+}
+`
+
+var SignatureClientSignCode = `// SignPartnerRequests signs the requests made to the endpoints secured by the
+// "partner" security scheme with signer. The requests are signed on each
+// attempt when a client policy retries them.
+func (c *Client) SignPartnerRequests(signer *goahttp.RequestSigner) {
+	c.MethodSignedDoer = goahttp.SigningDoer(c.MethodSignedDoer, signer)
+	c.MethodSignedOrKeyDoer = goahttp.SigningDoer(c.MethodSignedOrKeyDoer, signer)
+}
+`
@@ -0,0 +1,40 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var SignatureSecurityDSL = func() {
+	var Signed = SignatureSecurity("partner", func() {
+		ClockSkew("2m")
+		ReplayWindow("10m")
+	})
+	var APIKeyAuth = APIKeySecurity("api_key")
+	Service("ServiceSignature", func() {
+		Method("MethodSigned", func() {
+			Security(Signed)
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+		Method("MethodSignedOrKey", func() {
+			Security(Signed)
+			Security(APIKeyAuth)
+			Payload(func() {
+				APIKey("api_key", "key", String)
+			})
+			HTTP(func() {
+				GET("/key")
+				Header("key:X-Api-Key")
+			})
+		})
+		Method("MethodUnsigned", func() {
+			HTTP(func() {
+				GET("/unsigned")
+			})
+		})
+	})
+}
@@ -3389,3 +3389,185 @@ func (s *BidirectionalStreamingUserTypeMapMethodClientStream) Recv() (map[string
 	return res, nil
 }
 `
+
+var StreamingWebSocketServerHandlerInitCode = `// NewStreamingWebSocketMethodHandler creates a HTTP handler which loads the
+// HTTP request and calls the "StreamingWebSocketService" service
+// "StreamingWebSocketMethod" endpoint.
+func NewStreamingWebSocketMethodHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+	up goahttp.Upgrader,
+	connConfigFn goahttp.ConnConfigureFunc,
+) http.Handler {
+	wsc := &goahttp.WebSocketConfig{
+		Subprotocols:      []string{"chat.v2", "chat.v1"},
+		EnableCompression: true,
+		PingInterval:      30 * time.Second,
+		PongWait:          60 * time.Second,
+		ReadLimit:         65536,
+	}
+	up = wsc.Upgrader(up)
+	connConfigFn = wsc.ConnConfigureFunc(connConfigFn)
+	var (
+		encodeError = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingWebSocketMethod")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingWebSocketService")
+		var err error
+
+		var cancel context.CancelFunc
+		{
+			ctx, cancel = context.WithCancel(ctx)
+		}
+		v := &streamingwebsocketservice.StreamingWebSocketMethodEndpointInput{
+			Stream: &StreamingWebSocketMethodServerStream{
+				upgrader:     up,
+				connConfigFn: connConfigFn,
+				cancel:       cancel,
+				w:            w,
+				r:            r,
+			},
+		}
+		_, err = endpoint(ctx, v)
+
+		if err != nil {
+			if _, ok := err.(websocket.HandshakeError); ok {
+				return
+			}
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+	})
+}
+`
+
+var StreamingWebSocketSendOnlyServerHandlerInitCode = `// NewStreamingWebSocketMethodHandler creates a HTTP handler which loads the
+// HTTP request and calls the "StreamingWebSocketService" service
+// "StreamingWebSocketMethod" endpoint.
+func NewStreamingWebSocketMethodHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+	up goahttp.Upgrader,
+	connConfigFn goahttp.ConnConfigureFunc,
+) http.Handler {
+	wsc := &goahttp.WebSocketConfig{
+		PingInterval: 30 * time.Second,
+		PongWait:     60 * time.Second,
+		SendOnly:     true,
+	}
+	up = wsc.Upgrader(up)
+	connConfigFn = wsc.ConnConfigureFunc(connConfigFn)
+	var (
+		encodeError = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingWebSocketMethod")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingWebSocketService")
+		var err error
+
+		var cancel context.CancelFunc
+		{
+			ctx, cancel = context.WithCancel(ctx)
+		}
+		v := &streamingwebsocketservice.StreamingWebSocketMethodEndpointInput{
+			Stream: &StreamingWebSocketMethodServerStream{
+				upgrader:     up,
+				connConfigFn: connConfigFn,
+				cancel:       cancel,
+				w:            w,
+				r:            r,
+			},
+		}
+		_, err = endpoint(ctx, v)
+
+		if err != nil {
+			if _, ok := err.(websocket.HandshakeError); ok {
+				return
+			}
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+	})
+}
+`
+
+var StreamingWebSocketPayloadClientEndpointInitCode = `// StreamingWebSocketMethod returns an endpoint that makes HTTP requests to the
+// StreamingWebSocketService service StreamingWebSocketMethod server.
+func (c *Client) StreamingWebSocketMethod() goa.Endpoint {
+	var (
+		decodeResponse = DecodeStreamingWebSocketMethodResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		req, err := c.BuildStreamingWebSocketMethodRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		var cancel context.CancelFunc
+		{
+			ctx, cancel = context.WithCancel(ctx)
+		}
+		wsc := &goahttp.WebSocketConfig{
+			PingInterval: 30 * time.Second,
+		}
+		conn, resp, err := wsc.Dialer(c.dialer).DialContext(ctx, req.URL.String(), req.Header)
+		if err != nil {
+			if resp != nil {
+				return decodeResponse(resp)
+			}
+			return nil, goahttp.ErrRequestError("StreamingWebSocketService", "StreamingWebSocketMethod", err)
+		}
+		conn = wsc.ConnConfigureFunc(c.configurer.StreamingWebSocketMethodFn)(conn, cancel)
+		stream := &StreamingWebSocketMethodClientStream{conn: conn}
+		return stream, nil
+	}
+}
+`
+
+var StreamingWebSocketClientEndpointInitCode = `// StreamingWebSocketMethod returns an endpoint that makes HTTP requests to the
+// StreamingWebSocketService service StreamingWebSocketMethod server.
+func (c *Client) StreamingWebSocketMethod() goa.Endpoint {
+	var (
+		decodeResponse = DecodeStreamingWebSocketMethodResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		req, err := c.BuildStreamingWebSocketMethodRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		var cancel context.CancelFunc
+		{
+			ctx, cancel = context.WithCancel(ctx)
+		}
+		wsc := &goahttp.WebSocketConfig{
+			Subprotocols:      []string{"chat.v2", "chat.v1"},
+			EnableCompression: true,
+			PingInterval:      30 * time.Second,
+			PongWait:          60 * time.Second,
+			ReadLimit:         65536,
+		}
+		conn, resp, err := wsc.Dialer(c.dialer).DialContext(ctx, req.URL.String(), req.Header)
+		if err != nil {
+			if resp != nil {
+				return decodeResponse(resp)
+			}
+			return nil, goahttp.ErrRequestError("StreamingWebSocketService", "StreamingWebSocketMethod", err)
+		}
+		conn = wsc.ConnConfigureFunc(c.configurer.StreamingWebSocketMethodFn)(conn, cancel)
+		stream := &StreamingWebSocketMethodClientStream{conn: conn}
+		return stream, nil
+	}
+}
+`
@@ -754,3 +754,91 @@ var BidirectionalStreamingResultCollectionWithExplicitViewDSL = func() {
 		})
 	})
 }
+
+var StreamingWebSocketDSL = func() {
+	Service("StreamingWebSocketService", func() {
+		Method("StreamingWebSocketMethod", func() {
+			StreamingPayload(String)
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				WebSocket(func() {
+					Subprotocols("chat.v2", "chat.v1")
+					PerMessageDeflate()
+					PingInterval(30)
+					MaxMessageSize(65536)
+				})
+			})
+		})
+	})
+}
+
+var StreamingWebSocketResultDSL = func() {
+	Service("StreamingWebSocketService", func() {
+		Method("StreamingWebSocketMethod", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				WebSocket(func() {
+					PingInterval(30)
+				})
+			})
+		})
+	})
+}
+
+var StreamingWebSocketPayloadDSL = func() {
+	Service("StreamingWebSocketService", func() {
+		Method("StreamingWebSocketMethod", func() {
+			StreamingPayload(String)
+			Result(String)
+			HTTP(func() {
+				GET("/")
+				WebSocket(func() {
+					PingInterval(30)
+				})
+			})
+		})
+	})
+}
+
+var StreamingCLIDSL = func() {
+	var Message = Type("Message", func() {
+		Attribute("text", String)
+	})
+	Service("StreamingCLIService", func() {
+		Method("Listen", func() {
+			Payload(String)
+			StreamingResult(Message)
+			HTTP(func() {
+				GET("/listen/{p}")
+			})
+		})
+		Method("Summary", func() {
+			StreamingPayload(Message)
+			Result(Int)
+			HTTP(func() {
+				GET("/summary")
+			})
+		})
+		Method("Upload", func() {
+			StreamingPayload(Message)
+			HTTP(func() {
+				GET("/upload")
+			})
+		})
+		Method("Echo", func() {
+			StreamingPayload(Message)
+			StreamingResult(Message)
+			HTTP(func() {
+				GET("/echo")
+			})
+		})
+		Method("Count", func() {
+			Result(Int)
+			HTTP(func() {
+				GET("/count")
+			})
+		})
+	})
+}
@@ -0,0 +1,325 @@
+package testdata
+
+var StreamingResponseServerInitCode = `// New instantiates HTTP handlers for all the StreamingResponseService service
+// endpoints.
+func New(
+	e *streamingresponseservice.Endpoints,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) *Server {
+	return &Server{
+		Mounts: []*MountPoint{
+			{"Watch", "POST", "/watch"},
+			{"List", "GET", "/events"},
+		},
+		Watch: NewWatchHandler(e.Watch, mux, dec, enc, eh),
+		List:  NewListHandler(e.List, mux, dec, enc, eh),
+	}
+}
+`
+
+var StreamingResponseHandlerInitCode = `// NewWatchHandler creates a HTTP handler which loads the HTTP request and
+// calls the "StreamingResponseService" service "Watch" endpoint.
+func NewWatchHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		decodeRequest = DecodeWatchRequest(mux, dec)
+		encodeError   = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "Watch")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingResponseService")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+
+		stream := &WatchServerStream{w: goahttp.NewStreamWriter(w, goahttp.StreamNDJSON)}
+		v := &streamingresponseservice.WatchEndpointInput{
+			Stream:  stream,
+			Payload: payload.(*streamingresponseservice.WatchPayload),
+		}
+		_, err = endpoint(ctx, v)
+
+		if err != nil {
+			if stream.w.Started() {
+				eh(ctx, w, err)
+				return
+			}
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		if err := stream.Close(); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+
+// NewListHandler creates a HTTP handler which loads the HTTP request and calls
+// the "StreamingResponseService" service "List" endpoint.
+func NewListHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		encodeError = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "List")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingResponseService")
+		var err error
+
+		stream := &ListServerStream{w: goahttp.NewStreamWriter(w, goahttp.StreamJSONArray)}
+		v := &streamingresponseservice.ListEndpointInput{
+			Stream: stream,
+		}
+		_, err = endpoint(ctx, v)
+
+		if err != nil {
+			if stream.w.Started() {
+				eh(ctx, w, err)
+				return
+			}
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		if err := stream.Close(); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
+
+var StreamingResponseServerStructCode = `// WatchServerStream implements the streamingresponseservice.WatchServerStream
+// interface.
+type WatchServerStream struct {
+	// w writes the results to the chunked HTTP response.
+	w *goahttp.StreamWriter
+}
+
+// ListServerStream implements the streamingresponseservice.ListServerStream
+// interface.
+type ListServerStream struct {
+	// w writes the results to the chunked HTTP response.
+	w *goahttp.StreamWriter
+	// view is the view to render streamingresponseservice.Event result type before
+	// sending to the HTTP response.
+	view string
+}
+`
+
+var StreamingResponseServerSendCode = `// Send streams instances of "string" to the "Watch" endpoint HTTP response.
+func (s *WatchServerStream) Send(v string) error {
+	res := v
+	return s.w.Send(res)
+}
+
+// Send streams instances of "streamingresponseservice.Event" to the "List"
+// endpoint HTTP response.
+func (s *ListServerStream) Send(v *streamingresponseservice.Event) error {
+	res := streamingresponseservice.NewViewedEvent(v, s.view)
+	var body interface{}
+	switch s.view {
+	case "default", "":
+		body = NewListResponseBody(res.Projected)
+	case "tiny":
+		body = NewListResponseBodyTiny(res.Projected)
+	}
+	return s.w.Send(body)
+}
+`
+
+var StreamingResponseServerCloseCode = `// Close terminates the "Watch" endpoint HTTP response.
+func (s *WatchServerStream) Close() error {
+	return s.w.Close()
+}
+
+// Close terminates the "List" endpoint HTTP response.
+func (s *ListServerStream) Close() error {
+	return s.w.Close()
+}
+`
+
+var StreamingResponseServerSetViewCode = `// SetView sets the view to render the streamingresponseservice.Event type
+// before sending to the "List" endpoint HTTP response.
+func (s *ListServerStream) SetView(view string) {
+	s.view = view
+	s.w.Header().Set("goa-view", view)
+}
+`
+
+var StreamingResponseClientInitCode = `// NewClient instantiates HTTP clients for all the StreamingResponseService
+// service servers.
+func NewClient(
+	scheme string,
+	host string,
+	doer goahttp.Doer,
+	enc func(*http.Request) goahttp.Encoder,
+	dec func(*http.Response) goahttp.Decoder,
+	restoreBody bool,
+) *Client {
+	return &Client{
+		WatchDoer:           doer,
+		ListDoer:            doer,
+		RestoreResponseBody: restoreBody,
+		scheme:              scheme,
+		host:                host,
+		decoder:             dec,
+		encoder:             enc,
+	}
+}
+`
+
+var StreamingResponseClientEndpointInitCode = `// Watch returns an endpoint that makes HTTP requests to the
+// StreamingResponseService service Watch server.
+func (c *Client) Watch() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeWatchRequest(c.encoder)
+		decodeResponse = DecodeWatchResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		req, err := c.BuildWatchRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.WatchDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("StreamingResponseService", "Watch", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return decodeResponse(resp)
+		}
+		stream := &WatchClientStream{r: goahttp.NewStreamReader(resp.Body, goahttp.StreamNDJSON)}
+		return stream, nil
+	}
+}
+
+// List returns an endpoint that makes HTTP requests to the
+// StreamingResponseService service List server.
+func (c *Client) List() goa.Endpoint {
+	var (
+		decodeResponse = DecodeListResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		req, err := c.BuildListRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.ListDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("StreamingResponseService", "List", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return decodeResponse(resp)
+		}
+		stream := &ListClientStream{r: goahttp.NewStreamReader(resp.Body, goahttp.StreamJSONArray)}
+		view := resp.Header.Get("goa-view")
+		stream.SetView(view)
+		return stream, nil
+	}
+}
+`
+
+var StreamingResponseRequestBuilderCode = `// BuildWatchRequest instantiates a HTTP request object with method and path
+// set to call the "StreamingResponseService" service "Watch" endpoint
+func (c *Client) BuildWatchRequest(ctx context.Context, v interface{}) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: WatchStreamingResponseServicePath()}
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("StreamingResponseService", "Watch", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// BuildListRequest instantiates a HTTP request object with method and path set
+// to call the "StreamingResponseService" service "List" endpoint
+func (c *Client) BuildListRequest(ctx context.Context, v interface{}) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: ListStreamingResponseServicePath()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("StreamingResponseService", "List", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+`
+
+var StreamingResponseClientStructCode = `// WatchClientStream implements the streamingresponseservice.WatchClientStream
+// interface.
+type WatchClientStream struct {
+	// r reads the results from the chunked HTTP response.
+	r *goahttp.StreamReader
+}
+
+// ListClientStream implements the streamingresponseservice.ListClientStream
+// interface.
+type ListClientStream struct {
+	// r reads the results from the chunked HTTP response.
+	r *goahttp.StreamReader
+	// view is the view to render  result type before sending to the HTTP response.
+	view string
+}
+`
+
+var StreamingResponseClientRecvCode = `// Recv reads instances of "string" from the "Watch" endpoint HTTP response.
+func (s *WatchClientStream) Recv() (string, error) {
+	var (
+		rv   string
+		body string
+		err  error
+	)
+	if err = s.r.Recv(&body); err != nil {
+		return rv, err
+	}
+	return body, nil
+}
+
+// Recv reads instances of "streamingresponseservice.Event" from the "List"
+// endpoint HTTP response.
+func (s *ListClientStream) Recv() (*streamingresponseservice.Event, error) {
+	var (
+		rv   *streamingresponseservice.Event
+		body ListResponseBody
+		err  error
+	)
+	if err = s.r.Recv(&body); err != nil {
+		return rv, err
+	}
+	res := NewListEventNoContent(&body)
+	vres := &streamingresponseserviceviews.Event{res, s.view}
+	if err := streamingresponseserviceviews.ValidateEvent(vres); err != nil {
+		return rv, goahttp.ErrValidationError("StreamingResponseService", "List", err)
+	}
+	return streamingresponseservice.NewEvent(vres), nil
+}
+`
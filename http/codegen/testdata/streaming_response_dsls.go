@@ -0,0 +1,42 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var StreamingResponseDSL = func() {
+	var Event = ResultType("application/vnd.event", func() {
+		Attributes(func() {
+			Attribute("id", String)
+			Attribute("name", String)
+			Required("id")
+		})
+		View("default", func() {
+			Attribute("id")
+			Attribute("name")
+		})
+		View("tiny", func() {
+			Attribute("id")
+		})
+	})
+	Service("StreamingResponseService", func() {
+		Method("Watch", func() {
+			Payload(func() {
+				Attribute("topic", String)
+				Required("topic")
+			})
+			StreamingResult(String)
+			HTTP(func() {
+				POST("/watch")
+				StreamingResponse(NDJSON)
+			})
+		})
+		Method("List", func() {
+			StreamingResult(Event)
+			HTTP(func() {
+				GET("/events")
+				StreamingResponse(JSONArray)
+			})
+		})
+	})
+}
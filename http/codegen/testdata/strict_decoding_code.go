@@ -0,0 +1,116 @@
+package testdata
+
+var StrictDecodingHandlerInitCode = `// NewMethodStrictDecodingHandler creates a HTTP handler which loads the HTTP
+// request and calls the "ServiceStrictDecoding" service "MethodStrictDecoding"
+// endpoint.
+func NewMethodStrictDecodingHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		decodeRequest  = DecodeMethodStrictDecodingRequest(mux, dec)
+		encodeResponse = EncodeMethodStrictDecodingResponse(enc)
+		encodeError    = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodStrictDecoding")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceStrictDecoding")
+		if err := goahttp.CheckQueryParams(r, "page", "filter", "filter["); err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+
+		res, err := endpoint(ctx, payload)
+
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
+
+var StrictDecodingRequestDecoderCode = `// DecodeMethodStrictDecodingRequest returns a decoder for requests sent to the
+// ServiceStrictDecoding MethodStrictDecoding endpoint.
+func DecodeMethodStrictDecodingRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			body MethodStrictDecodingRequestBody
+			err  error
+		)
+		err = goahttp.StrictDecoder(decoder)(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			if _, ok := err.(*goa.ServiceError); ok {
+				return nil, err
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = ValidateMethodStrictDecodingRequestBody(&body)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			page   *int
+			filter map[string]string
+		)
+		{
+			pageRaw := r.URL.Query().Get("page")
+			if pageRaw != "" {
+				v, err2 := strconv.ParseInt(pageRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("page", pageRaw, "integer"))
+				}
+				pv := int(v)
+				page = &pv
+			}
+		}
+		{
+			filterRaw := r.URL.Query()
+			if len(filterRaw) != 0 {
+				for keyRaw, valRaw := range filterRaw {
+					if strings.HasPrefix(keyRaw, "filter[") {
+						if filter == nil {
+							filter = make(map[string]string)
+						}
+						var keya string
+						{
+							openIdx := strings.IndexRune(keyRaw, '[')
+							closeIdx := strings.IndexRune(keyRaw, ']')
+							keya = keyRaw[openIdx+1 : closeIdx]
+						}
+						filter[keya] = valRaw[0]
+					}
+				}
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodStrictDecodingPayload(&body, page, filter)
+
+		return payload, nil
+	}
+}
+`
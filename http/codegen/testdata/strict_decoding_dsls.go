@@ -0,0 +1,26 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var StrictDecodingDSL = func() {
+	Service("ServiceStrictDecoding", func() {
+		HTTP(func() {
+			StrictDecoding()
+		})
+		Method("MethodStrictDecoding", func() {
+			Payload(func() {
+				Attribute("name", String)
+				Attribute("page", Int)
+				Attribute("filter", MapOf(String, String))
+				Required("name")
+			})
+			HTTP(func() {
+				POST("/")
+				Param("page")
+				Param("filter")
+			})
+		})
+	})
+}
@@ -0,0 +1,86 @@
+package testdata
+
+const TelemetryServerInitCode = `// New instantiates HTTP handlers for all the ServiceTelemetry service
+// endpoints.
+func New(
+	e *servicetelemetry.Endpoints,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+	up goahttp.Upgrader,
+	cfn *ConnConfigurer,
+) *Server {
+	if cfn == nil {
+		cfn = &ConnConfigurer{}
+	}
+	return &Server{
+		Mounts: []*MountPoint{
+			{"MethodUnary", "POST", "/unary/{id}"},
+			{"MethodStreaming", "GET", "/streaming"},
+		},
+		MethodUnary:     telemetry.Handler(NewMethodUnaryHandler(e.MethodUnary, mux, dec, enc, eh), "ServiceTelemetry", "MethodUnary", "/unary/{id}"),
+		MethodStreaming: telemetry.Handler(NewMethodStreamingHandler(e.MethodStreaming, mux, dec, enc, eh, up, cfn.MethodStreamingFn), "ServiceTelemetry", "MethodStreaming", "/streaming"),
+	}
+}
+`
+
+const TelemetryClientInitCode = `// NewClient instantiates HTTP clients for all the ServiceTelemetry service
+// servers.
+func NewClient(
+	scheme string,
+	host string,
+	doer goahttp.Doer,
+	enc func(*http.Request) goahttp.Encoder,
+	dec func(*http.Response) goahttp.Decoder,
+	restoreBody bool,
+	dialer goahttp.Dialer,
+	cfn *ConnConfigurer,
+) *Client {
+	if cfn == nil {
+		cfn = &ConnConfigurer{}
+	}
+	return &Client{
+		MethodUnaryDoer:     telemetry.Doer(doer, "ServiceTelemetry", "MethodUnary"),
+		MethodStreamingDoer: telemetry.Doer(doer, "ServiceTelemetry", "MethodStreaming"),
+		RestoreResponseBody: restoreBody,
+		scheme:              scheme,
+		host:                host,
+		decoder:             dec,
+		encoder:             enc,
+		dialer:              dialer,
+		configurer:          cfn,
+	}
+}
+`
+
+const TelemetryClientStreamingEndpointInitCode = `// MethodStreaming returns an endpoint that makes HTTP requests to the
+// ServiceTelemetry service MethodStreaming server.
+func (c *Client) MethodStreaming() goa.Endpoint {
+	var (
+		decodeResponse = DecodeMethodStreamingResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		req, err := c.BuildMethodStreamingRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		var cancel context.CancelFunc
+		{
+			ctx, cancel = context.WithCancel(ctx)
+		}
+		conn, resp, err := telemetry.Dialer(c.dialer, "ServiceTelemetry", "MethodStreaming").DialContext(ctx, req.URL.String(), req.Header)
+		if err != nil {
+			if resp != nil {
+				return decodeResponse(resp)
+			}
+			return nil, goahttp.ErrRequestError("ServiceTelemetry", "MethodStreaming", err)
+		}
+		if c.configurer.MethodStreamingFn != nil {
+			conn = c.configurer.MethodStreamingFn(conn, cancel)
+		}
+		stream := &MethodStreamingClientStream{conn: conn}
+		return stream, nil
+	}
+}
+`
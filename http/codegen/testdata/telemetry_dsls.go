@@ -0,0 +1,25 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var TelemetryDSL = func() {
+	Service("ServiceTelemetry", func() {
+		Meta("observability:otel")
+		Method("MethodUnary", func() {
+			Payload(String)
+			Result(String)
+			HTTP(func() {
+				POST("/unary/{id}")
+			})
+		})
+		Method("MethodStreaming", func() {
+			StreamingPayload(String)
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/streaming")
+			})
+		})
+	})
+}
@@ -0,0 +1,49 @@
+package testdata
+
+const TenantServerHandlerInitCode = `// NewMethodHandler creates a HTTP handler which loads the HTTP request and
+// calls the "ServiceTenant" service "Method" endpoint.
+func NewMethodHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		decodeRequest  = DecodeMethodRequest(mux, dec)
+		encodeResponse = EncodeMethodResponse(enc)
+		encodeError    = goahttp.ErrorEncoder(enc)
+		propagation    = &goa.Propagation{
+			Headers: []string{"X-Tenant-ID"},
+		}
+		tenancy = &goa.Tenancy{Header: "X-Tenant-ID", Claim: "tenant_id"}
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "Method")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceTenant")
+		ctx, cancelTimeout := goahttp.PropagateIncoming(ctx, r, propagation)
+		defer cancelTimeout()
+		ctx = tenancy.Incoming(ctx, r.Header.Get)
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+
+		res, err := endpoint(ctx, payload)
+
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
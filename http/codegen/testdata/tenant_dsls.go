@@ -0,0 +1,21 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var TenantDSL = func() {
+	API("API", func() {
+		Tenant(func() {
+			Claim("tenant_id")
+		})
+	})
+	Service("ServiceTenant", func() {
+		Method("Method", func() {
+			Payload(String)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
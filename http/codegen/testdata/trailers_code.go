@@ -0,0 +1,145 @@
+package testdata
+
+var TrailersResponseEncoderCode = `// EncodeDownloadResponse returns an encoder for responses returned by the
+// TrailersService Download endpoint.
+func EncodeDownloadResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res := v.(*trailersservice.Download1)
+		enc := encoder(ctx, w)
+		body := NewDownloadResponseBody(res)
+		w.Header().Set("Trailer", "Digest, X-Count")
+		w.WriteHeader(http.StatusOK)
+		if err := enc.Encode(body); err != nil {
+			return err
+		}
+		w.Header().Set("Digest", res.Checksum)
+		if res.Count != nil {
+			val := res.Count
+			counts := strconv.Itoa(*val)
+			w.Header().Set("X-Count", counts)
+		}
+		return nil
+	}
+}
+`
+
+var TrailersResponseDecoderCode = `// DecodeDownloadResponse returns a decoder for responses returned by the
+// TrailersService Download endpoint. restoreBody controls whether the response
+// body should be restored after having been read.
+func DecodeDownloadResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (interface{}, error) {
+	return func(resp *http.Response) (interface{}, error) {
+		if restoreBody {
+			b, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body DownloadResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("TrailersService", "Download", err)
+			}
+			err = ValidateDownloadResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("TrailersService", "Download", err)
+			}
+			// The trailers are only available once the response body has
+			// been read entirely.
+			io.Copy(ioutil.Discard, resp.Body)
+			var (
+				checksum string
+				count    *int
+			)
+			{
+				checksumRaw := resp.Trailer.Get("Digest")
+				if checksumRaw == "" {
+					err = goa.MergeErrors(err, goa.MissingFieldError("Digest", "trailer"))
+				}
+				checksum = checksumRaw
+			}
+			{
+				countRaw := resp.Trailer.Get("X-Count")
+				if countRaw != "" {
+					v, err2 := strconv.ParseInt(countRaw, 10, strconv.IntSize)
+					if err2 != nil {
+						err = goa.MergeErrors(err, goa.InvalidFieldTypeError("count", countRaw, "integer"))
+					}
+					pv := int(v)
+					count = &pv
+				}
+			}
+			if err != nil {
+				return nil, goahttp.ErrValidationError("TrailersService", "Download", err)
+			}
+			res := NewDownload1OK(&body, checksum, count)
+			return res, nil
+		default:
+			body, _ := ioutil.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("TrailersService", "Download", resp.StatusCode, string(body))
+		}
+	}
+}
+`
+
+var TrailersDefaultResponseDecoderCode = `// DecodeDownloadResponse returns a decoder for responses returned by the
+// TrailersDefaultService Download endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+func DecodeDownloadResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (interface{}, error) {
+	return func(resp *http.Response) (interface{}, error) {
+		if restoreBody {
+			b, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body DownloadResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("TrailersDefaultService", "Download", err)
+			}
+			// The trailers are only available once the response body has
+			// been read entirely.
+			io.Copy(ioutil.Discard, resp.Body)
+			var (
+				status string
+			)
+			{
+				statusRaw := resp.Trailer.Get("X-Status")
+				if statusRaw == "" {
+					status = "complete"
+				}
+				if statusRaw != "" {
+					status = statusRaw
+				}
+			}
+			res := NewDownloadResultOK(&body, status)
+			return res, nil
+		default:
+			body, _ := ioutil.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("TrailersDefaultService", "Download", resp.StatusCode, string(body))
+		}
+	}
+}
+`
@@ -0,0 +1,49 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var TrailersDSL = func() {
+	var Download = Type("Download", func() {
+		Attribute("content", String)
+		Attribute("checksum", String)
+		Attribute("count", Int)
+		Required("content", "checksum")
+	})
+	Service("TrailersService", func() {
+		Method("Download", func() {
+			Result(Download)
+			HTTP(func() {
+				GET("/download")
+				Response(StatusOK, func() {
+					Trailers(func() {
+						Attribute("checksum:Digest")
+						Attribute("count:X-Count")
+					})
+				})
+			})
+		})
+	})
+}
+
+var TrailersDefaultDSL = func() {
+	Service("TrailersDefaultService", func() {
+		Method("Download", func() {
+			Result(func() {
+				Attribute("content", String)
+				Attribute("status", String, func() {
+					Default("complete")
+				})
+			})
+			HTTP(func() {
+				GET("/download")
+				Response(StatusOK, func() {
+					Trailers(func() {
+						Attribute("status:X-Status")
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,31 @@
+package testdata
+
+var WebhookOrderCreatedCode = `// SendOrderCreatedWebhook sends the "order.created" webhook request to the URL
+// u using doer. The request body is signed with secret, see
+// goahttp.SignWebhook.
+//
+// Sent when an order is created.
+func SendOrderCreatedWebhook(ctx context.Context, doer goahttp.Doer, u string, secret []byte, body *OrderCreatedWebhookBody) error {
+	if err := ValidateOrderCreatedWebhookBody(body); err != nil {
+		return err
+	}
+	return goahttp.SendWebhook(ctx, doer, u, secret, "order.created", body)
+}
+`
+
+var WebhookOrderDeletedCode = `// SendOrderDeletedWebhook sends the "order.deleted" webhook request to the URL
+// u using doer. The request body is signed with secret, see
+// goahttp.SignWebhook.
+func SendOrderDeletedWebhook(ctx context.Context, doer goahttp.Doer, u string, secret []byte, body *OrderDeletedWebhookBody) error {
+	return goahttp.SendWebhook(ctx, doer, u, secret, "order.deleted", body)
+}
+`
+
+var WebhookOrderCreatedBodyCode = `// OrderCreatedWebhookBody is the body of the "order.created" webhook requests.
+type OrderCreatedWebhookBody struct {
+	// Order ID
+	ID string ` + "`" + `form:"id" json:"id" xml:"id"` + "`" + `
+	// Order items
+	Items []*ItemWebhookBody ` + "`" + `form:"items" json:"items" xml:"items"` + "`" + `
+}
+`
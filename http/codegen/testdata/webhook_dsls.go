@@ -0,0 +1,33 @@
+package testdata
+
+import . "goa.design/goa/v3/dsl"
+
+var WebhookDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("sku", String, func() {
+			MinLength(1)
+		})
+		Attribute("quantity", Int)
+		Required("sku")
+	})
+	Service("Orders", func() {
+		Webhook("order.created", func() {
+			Description("Sent when an order is created.")
+			Body(func() {
+				Attribute("id", String, "Order ID")
+				Attribute("items", ArrayOf(Item), "Order items")
+				Required("id", "items")
+			})
+		})
+		Webhook("order.deleted", func() {
+			Body(func() {
+				Attribute("id", String, "Order ID")
+			})
+		})
+		Method("Show", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
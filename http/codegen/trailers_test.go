@@ -0,0 +1,47 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestTrailers(t *testing.T) {
+	const genpkg = "gen"
+	RunHTTPDSL(t, testdata.TrailersDSL)
+	cases := []struct {
+		Name    string
+		File    *codegen.File
+		Section string
+		Code    string
+	}{
+		{"response-encoder", ServerFiles(genpkg, expr.Root)[1], "response-encoder", testdata.TrailersResponseEncoderCode},
+		{"response-decoder", ClientFiles(genpkg, expr.Root)[1], "response-decoder", testdata.TrailersResponseDecoderCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			sections := c.File.Section(c.Section)
+			if len(sections) == 0 {
+				t.Fatalf("section %q not found", c.Section)
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestTrailersDefault(t *testing.T) {
+	RunHTTPDSL(t, testdata.TrailersDefaultDSL)
+	sections := ClientFiles("gen", expr.Root)[1].Section("response-decoder")
+	if len(sections) == 0 {
+		t.Fatalf("section %q not found", "response-decoder")
+	}
+	code := codegen.SectionCode(t, sections[0])
+	if code != testdata.TrailersDefaultResponseDecoderCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.TrailersDefaultResponseDecoderCode))
+	}
+}
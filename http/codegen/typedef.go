@@ -18,6 +18,9 @@ import (
 //      required when ptr is true so that the generated code may validate
 //      explicitly.
 //
+//    - It ignores the "struct:field:type" meta except for the Bytes attributes
+//      streamed as io.Reader or io.ReadCloser in multipart requests.
+//
 // useDefault directs whether fields holding primitive types with default values
 // should hold pointers when ptr is false. If it is true then the fields are
 // values even when not required (to account for the fact that they have a
@@ -26,6 +29,9 @@ import (
 func goTypeDef(scope *codegen.NameScope, att *expr.AttributeExpr, ptr, useDefault bool) string {
 	switch actual := att.Type.(type) {
 	case expr.Primitive:
+		if actual == expr.Bytes && isReaderField(att) {
+			return att.Meta["struct:field:type"][0]
+		}
 		return codegen.GoNativeTypeName(actual)
 	case *expr.Array:
 		d := goTypeDef(scope, actual.ElemType, ptr, useDefault)
@@ -82,14 +88,26 @@ func goTypeDef(scope *codegen.NameScope, att *expr.AttributeExpr, ptr, useDefaul
 	}
 }
 
-// attributeTags computes the struct field tags.
+// attributeTags computes the struct field tags. The "decode" tag lists the
+// "lenient" and "trim" options used by goahttp.LenientDecoder.
 func attributeTags(parent, att *expr.AttributeExpr, t string, optional bool) string {
-	if tags := codegen.AttributeTags(parent, att); tags != "" {
-		return tags
+	tags := codegen.AttributeTags(parent, att)
+	if tags == "" {
+		var o string
+		if optional {
+			o = ",omitempty"
+		}
+		tags = fmt.Sprintf(" `form:\"%s%s\" json:\"%s%s\" xml:\"%s%s\"`", t, o, t, o, t, o)
+	}
+	var opts []string
+	if expr.IsLenientAttribute(att) {
+		opts = append(opts, "lenient")
+	}
+	if expr.IsTrimmedAttribute(att) {
+		opts = append(opts, "trim")
 	}
-	var o string
-	if optional {
-		o = ",omitempty"
+	if len(opts) > 0 {
+		tags = fmt.Sprintf("%s decode:\"%s\"`", strings.TrimSuffix(tags, "`"), strings.Join(opts, ","))
 	}
-	return fmt.Sprintf(" `form:\"%s%s\" json:\"%s%s\" xml:\"%s%s\"`", t, o, t, o, t, o)
+	return tags
 }
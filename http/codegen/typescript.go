@@ -0,0 +1,18 @@
+package codegen
+
+import (
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/typescript"
+)
+
+// TypeScriptFiles returns the files containing the TypeScript client of the
+// HTTP endpoints of the given API. The client uses the fetch API and streams
+// results over websockets or chunked responses. The files are generated only
+// if the API defines the "typescript:client" meta.
+func TypeScriptFiles(root *expr.RootExpr) []*codegen.File {
+	if _, ok := root.API.Meta["typescript:client"]; !ok {
+		return nil
+	}
+	return typescript.Files(root)
+}
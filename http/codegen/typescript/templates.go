@@ -0,0 +1,509 @@
+package typescript
+
+// input: map[string]interface{}{"Title":string, "ToolVersion":string}
+const headerT = `// Code generated by goa {{ .ToolVersion }}, DO NOT EDIT.
+//
+// {{ .Title }}
+//
+// Command:
+{{ comment commandLine }}
+
+`
+
+// input: serviceData
+const serviceT = `{{ if .TypeImports }}import type { {{ join .TypeImports ", " }} } from "./goa";
+{{ end }}import { {{ join .Imports ", " }} } from "./goa";
+{{- range .Types }}
+
+{{ . }}
+{{- end }}
+{{- range .Methods }}
+	{{- if .Errors }}
+
+/** {{ .Errors }} lists the errors returned by the {{ .Name }} method, the name discriminates the errors. */
+export type {{ .Errors }} =
+		{{- range .ErrorCases }}
+	| { name: {{ quote .Name }}; status: {{ .Status }}; body: {{ .Type }} }
+		{{- end }};
+	{{- end }}
+{{- end }}
+
+{{ jsdoc (printf "%s is the client of the %s service.%s" .ClassName .Name (suffix .Description)) "" }}
+export class {{ .ClassName }} {
+	private readonly transport: Transport;
+
+	constructor(options: ClientOptions = {}) {
+		this.transport = new Transport({{ quote .BaseURL }}, options);
+	}
+{{- range .Methods }}
+
+{{ jsdoc (methodDoc .) "\t" }}
+	{{- if .WebSocket }}
+	{{ .FuncName }}({{ if .Payload }}p: {{ .Payload }}{{ end }}): Promise<Stream<{{ .Send }}, {{ .Recv }}>> {
+		return this.transport.websocket<{{ .Send }}, {{ .Recv }}>({{ .Request }}{{ if .Protocols }}, {{ .Protocols }}{{ end }});
+	}
+	{{- else if .Stream }}
+	{{ .FuncName }}({{ if .Payload }}p: {{ .Payload }}{{ end }}): AsyncIterable<{{ .Result }}> {
+		return this.transport.stream<{{ .Result }}>({{ .Request }}, {{ quote .Stream }});
+	}
+	{{- else }}
+	async {{ .FuncName }}({{ if .Payload }}p: {{ .Payload }}{{ end }}): Promise<{{ .Result }}> {
+		{{ if .ResultCode }}const res = {{ end }}await this.transport.send({{ .Request }});
+		{{- range .ResultCode }}
+		{{ . }}
+		{{- end }}
+	}
+	{{- end }}
+{{- end }}
+}
+`
+
+// input: map[string]interface{}{"API":string, "Services":[]*serviceData}
+const indexT = `import type { ClientOptions } from "./goa";
+{{- range .Services }}
+import { {{ .ClassName }} } from "./{{ .Module }}";
+{{- end }}
+
+export * from "./goa";
+{{- range .Services }}
+export * as {{ .Property }} from "./{{ .Module }}";
+{{- end }}
+
+/** Client is the {{ .API }} client, it exposes the client of each service. */
+export class Client {
+{{- range .Services }}
+	/** {{ .Property }} is the client of the {{ .Name }} service. */
+	readonly {{ .Property }}: {{ .ClassName }};
+{{- end }}
+
+	constructor(options: ClientOptions = {}) {
+{{- range .Services }}
+		this.{{ .Property }} = new {{ .ClassName }}(options);
+{{- end }}
+	}
+}
+`
+
+// runtimeT is the runtime module shared by the service clients.
+const runtimeT = `/** ClientOptions configures the service clients. */
+export interface ClientOptions {
+	/** baseURL is the scheme, host and base path of the requests. */
+	baseURL?: string;
+	/** fetch makes the HTTP requests, it defaults to the global fetch function. */
+	fetch?: typeof fetch;
+	/** headers are added to every HTTP request. */
+	headers?: Record<string, string>;
+	/** WebSocket opens the websockets, it defaults to the global WebSocket class. */
+	WebSocket?: typeof WebSocket;
+}
+
+/** ErrorResult is the body of the error responses of the default error type. */
+export interface ErrorResult {
+	/** name is the name of the error. */
+	name: string;
+	/** id is the unique error instance identifier. */
+	id: string;
+	/** message describes the specific error occurrence. */
+	message: string;
+	/** temporary indicates whether the error is temporary. */
+	temporary: boolean;
+	/** timeout indicates whether the error is a timeout. */
+	timeout: boolean;
+	/** fault indicates whether the error is a server-side fault. */
+	fault: boolean;
+}
+
+/**
+ * ServiceError is thrown when the server returns one of the errors defined in
+ * the design for the method. The error name discriminates the error union E.
+ */
+export class ServiceError<E extends { name: string; status: number; body: unknown }> extends Error {
+	readonly error: E;
+
+	constructor(error: E) {
+		super(describe(error.body) || error.name);
+		this.name = "ServiceError";
+		this.error = error;
+	}
+}
+
+/** ClientError is thrown when the server returns an unexpected response. */
+export class ClientError extends Error {
+	readonly status: number;
+	readonly body: unknown;
+
+	constructor(status: number, body: unknown) {
+		super(describe(body) || "unexpected response status " + status);
+		this.name = "ClientError";
+		this.status = status;
+		this.body = body;
+	}
+}
+
+/** Value is the value of a query string parameter, header or cookie. */
+export type Value = string | number | boolean | null | undefined | readonly (string | number | boolean)[];
+
+/** Request describes a request made by a service client. */
+export interface Request {
+	method: string;
+	path: string;
+	query?: [string, Value][];
+	headers?: [string, Value][];
+	cookies?: [string, Value][];
+	body?: unknown;
+	form?: boolean;
+	status: number[];
+	errors?: [string, number][];
+}
+
+/** Transport makes the requests of a service client. */
+export class Transport {
+	private readonly baseURL: string;
+	private readonly fetcher: typeof fetch;
+	private readonly headers: Record<string, string>;
+	private readonly socket?: typeof WebSocket;
+
+	constructor(defaultURL: string, options: ClientOptions = {}) {
+		this.baseURL = (options.baseURL ?? defaultURL).replace(/\/+$/, "");
+		this.fetcher = options.fetch ?? ((input, init) => fetch(input, init));
+		this.headers = options.headers ?? {};
+		this.socket = options.WebSocket ?? (typeof WebSocket === "undefined" ? undefined : WebSocket);
+	}
+
+	/** url returns the URL of the request. */
+	url(req: Request): string {
+		const query = new URLSearchParams();
+		for (const [name, value] of req.query ?? []) {
+			for (const v of values(value)) {
+				query.append(name, v);
+			}
+		}
+		const qs = query.toString();
+		return this.baseURL + req.path + (qs ? "?" + qs : "");
+	}
+
+	/**
+	 * send makes the request and returns the response. It throws a ServiceError
+	 * if the response describes one of the request errors and a ClientError if
+	 * the response status is not expected otherwise.
+	 */
+	async send(req: Request): Promise<Response> {
+		const headers: Record<string, string> = { ...this.headers };
+		for (const [name, value] of req.headers ?? []) {
+			const vs = values(value);
+			if (vs.length > 0) {
+				headers[name] = vs.join(", ");
+			}
+		}
+		const cookies: string[] = [];
+		for (const [name, value] of req.cookies ?? []) {
+			for (const v of values(value)) {
+				cookies.push(name + "=" + encodeURIComponent(v));
+			}
+		}
+		if (cookies.length > 0) {
+			headers["Cookie"] = cookies.join("; ");
+		}
+		let body: string | URLSearchParams | undefined;
+		if (req.body !== undefined && req.form) {
+			body = new URLSearchParams();
+			for (const [name, value] of Object.entries(req.body as Record<string, Value>)) {
+				for (const v of values(value)) {
+					body.append(name, v);
+				}
+			}
+		} else if (req.body !== undefined) {
+			headers["Content-Type"] = "application/json";
+			body = JSON.stringify(req.body);
+		}
+		const res = await this.fetcher(this.url(req), { method: req.method, headers, body });
+		if (!req.status.includes(res.status)) {
+			throw await responseError(res, req.errors ?? []);
+		}
+		return res;
+	}
+
+	/**
+	 * stream makes the request and yields the values streamed in the response
+	 * body using the given format, "ndjson" or "json-array".
+	 */
+	async *stream<T>(req: Request, format: string): AsyncGenerator<T> {
+		const res = await this.send(req);
+		if (!res.body) {
+			return;
+		}
+		const reader = res.body.getReader();
+		const decoder = new TextDecoder();
+		const parser = format === "ndjson" ? new LineParser() : new ArrayParser();
+		for (;;) {
+			const { done, value } = await reader.read();
+			const text = done ? decoder.decode() : decoder.decode(value, { stream: true });
+			for (const v of parser.push(text, done === true)) {
+				yield v as T;
+			}
+			if (done) {
+				return;
+			}
+		}
+	}
+
+	/**
+	 * websocket opens a websocket connection for the request. Browsers do not
+	 * allow setting the headers of websocket requests so only the path and query
+	 * string of the request are used.
+	 */
+	websocket<S, R>(req: Request, protocols?: string[]): Promise<Stream<S, R>> {
+		const Socket = this.socket;
+		if (!Socket) {
+			return Promise.reject(new Error("WebSocket is not available, set the WebSocket client option"));
+		}
+		const url = this.url(req).replace(/^http/, "ws");
+		return new Promise((resolve, reject) => {
+			const ws = new Socket(url, protocols);
+			const fail = () => reject(new Error("failed to open websocket " + url));
+			ws.addEventListener("error", fail);
+			ws.addEventListener("open", () => {
+				ws.removeEventListener("error", fail);
+				resolve(new Stream<S, R>(ws));
+			});
+		});
+	}
+}
+
+/**
+ * Stream is a websocket stream that sends values of type S and receives values
+ * of type R. Iterating over the stream receives the values until the server
+ * closes the connection.
+ */
+export class Stream<S, R> implements AsyncIterable<R> {
+	private readonly ws: WebSocket;
+	private readonly received: R[] = [];
+	private readonly waiting: [(v: R | undefined) => void, (err: Error) => void][] = [];
+	private done = false;
+	private err?: Error;
+
+	constructor(ws: WebSocket) {
+		this.ws = ws;
+		ws.addEventListener("message", (ev) => {
+			const v = JSON.parse(String(ev.data)) as R;
+			const w = this.waiting.shift();
+			if (w) {
+				w[0](v);
+			} else {
+				this.received.push(v);
+			}
+		});
+		ws.addEventListener("close", (ev) => {
+			this.done = true;
+			if (ev.code !== 1000 && ev.code !== 1005) {
+				this.err = new Error(ev.reason || "websocket closed with code " + ev.code);
+			}
+			for (const [resolve, reject] of this.waiting.splice(0)) {
+				if (this.err) {
+					reject(this.err);
+				} else {
+					resolve(undefined);
+				}
+			}
+		});
+	}
+
+	/** send sends a value to the server. */
+	send(v: S): void {
+		this.ws.send(JSON.stringify(v));
+	}
+
+	/** recv receives the next value, it returns undefined once the server closes the stream. */
+	recv(): Promise<R | undefined> {
+		if (this.received.length > 0) {
+			return Promise.resolve(this.received.shift());
+		}
+		if (this.err) {
+			return Promise.reject(this.err);
+		}
+		if (this.done) {
+			return Promise.resolve(undefined);
+		}
+		return new Promise((resolve, reject) => this.waiting.push([resolve, reject]));
+	}
+
+	/** closeAndRecv tells the server that no more values are sent and returns the result. */
+	async closeAndRecv(): Promise<R> {
+		this.ws.send("null");
+		try {
+			const v = await this.recv();
+			if (v === undefined) {
+				throw new Error("stream closed before receiving the result");
+			}
+			return v;
+		} finally {
+			this.ws.close();
+		}
+	}
+
+	/** close closes the stream. */
+	close(): void {
+		if (this.ws.readyState === this.ws.OPEN) {
+			this.ws.send("null");
+		}
+		this.ws.close();
+	}
+
+	async *[Symbol.asyncIterator](): AsyncIterator<R> {
+		for (;;) {
+			const v = await this.recv();
+			if (v === undefined) {
+				return;
+			}
+			yield v;
+		}
+	}
+}
+
+/** pathValue encodes a path parameter, wildcard parameters may contain slashes. */
+export function pathValue(v: Value, wildcard = false): string {
+	const s = values(v).map(encodeURIComponent).join(",");
+	return wildcard ? s.replace(/%2F/g, "/") : s;
+}
+
+/** bearer returns the Authorization header value of the token. */
+export function bearer(token: string | undefined): string | undefined {
+	if (token === undefined || token.includes(" ")) {
+		return token;
+	}
+	return "Bearer " + token;
+}
+
+/** basic returns the Authorization header value of the basic auth credentials. */
+export function basic(username: string | undefined, password: string | undefined): string | undefined {
+	if (username === undefined && password === undefined) {
+		return undefined;
+	}
+	const bytes = new TextEncoder().encode((username ?? "") + ":" + (password ?? ""));
+	return "Basic " + btoa(String.fromCharCode(...bytes));
+}
+
+/** header reads the response header with the given name. */
+export function header(res: Response, name: string, type: "string" | "number" | "boolean", array = false): any {
+	const v = res.headers.get(name);
+	if (v === null) {
+		return undefined;
+	}
+	const parse = (s: string) => (type === "number" ? Number(s) : type === "boolean" ? s === "true" : s);
+	return array ? v.split(",").map((s) => parse(s.trim())) : parse(v);
+}
+
+/** decode decodes the response body. */
+export async function decode(res: Response): Promise<unknown> {
+	const text = await res.text();
+	if (text === "") {
+		return undefined;
+	}
+	const type = res.headers.get("Content-Type") ?? "";
+	return type.includes("json") || type === "" ? JSON.parse(text) : text;
+}
+
+/**
+ * responseError returns the error described by the response. The errors list
+ * the names and status codes of the request errors, the "goa-error" header
+ * discriminates the errors that share the same status code.
+ */
+async function responseError(res: Response, errors: [string, number][]): Promise<Error> {
+	let body: unknown;
+	try {
+		body = await decode(res);
+	} catch {
+		body = undefined;
+	}
+	const candidates = errors.filter(([, status]) => status === res.status);
+	const name = res.headers.get("goa-error");
+	const match = candidates.find(([n]) => n === name) ?? (candidates.length === 1 ? candidates[0] : undefined);
+	if (match) {
+		return new ServiceError({ name: match[0], status: res.status, body });
+	}
+	return new ClientError(res.status, body);
+}
+
+/** values returns the string representations of v. */
+function values(v: Value): string[] {
+	if (v === undefined || v === null) {
+		return [];
+	}
+	if (Array.isArray(v)) {
+		return v.map(String);
+	}
+	return [String(v)];
+}
+
+/** describe returns the message of an error response body if any. */
+function describe(body: unknown): string {
+	if (typeof body === "string") {
+		return body;
+	}
+	if (body && typeof body === "object" && typeof (body as { message?: unknown }).message === "string") {
+		return (body as { message: string }).message;
+	}
+	return "";
+}
+
+/** LineParser parses newline delimited JSON values. */
+class LineParser {
+	private buf = "";
+
+	push(text: string, done: boolean): unknown[] {
+		this.buf += text;
+		const lines = this.buf.split("\n");
+		this.buf = done ? "" : lines.pop() ?? "";
+		return lines.filter((l) => l.trim() !== "").map((l) => JSON.parse(l));
+	}
+}
+
+/** ArrayParser parses the elements of a JSON array as they are received. */
+class ArrayParser {
+	private buf = "";
+	private pos = 0;
+	private start = -1;
+	private depth = 0;
+	private inString = false;
+	private escaped = false;
+
+	push(text: string, done: boolean): unknown[] {
+		this.buf += text;
+		const out: unknown[] = [];
+		for (; this.pos < this.buf.length; this.pos++) {
+			const c = this.buf[this.pos];
+			if (this.inString) {
+				if (this.escaped) {
+					this.escaped = false;
+				} else if (c === "\\") {
+					this.escaped = true;
+				} else if (c === '"') {
+					this.inString = false;
+				}
+				continue;
+			}
+			if (this.depth === 1 && this.start < 0 && !/[\s,\]]/.test(c)) {
+				this.start = this.pos;
+			}
+			if (c === '"') {
+				this.inString = true;
+			} else if (c === "[" || c === "{") {
+				this.depth++;
+			} else if (c === "]" || c === "}") {
+				this.depth--;
+			}
+			if (this.start >= 0 && (this.depth === 0 || (this.depth === 1 && c === ","))) {
+				out.push(JSON.parse(this.buf.slice(this.start, this.pos)));
+				this.start = -1;
+			}
+		}
+		const keep = this.start < 0 ? this.pos : this.start;
+		this.buf = done ? "" : this.buf.slice(keep);
+		this.pos -= keep;
+		if (this.start >= 0) {
+			this.start = 0;
+		}
+		return out;
+	}
+}
+`
@@ -0,0 +1,182 @@
+package testdata
+
+const TypeScriptServiceCode = `import type { ClientOptions, ErrorResult } from "./goa";
+import { Transport, basic, bearer, decode, header, pathValue } from "./goa";
+
+export interface ShowPayload {
+	token?: string;
+	id: number;
+	views?: string[];
+	session?: string;
+}
+
+/** Bottle of wine. */
+export interface Bottle {
+	/** Unique ID */
+	id: number;
+	name: string;
+	"vintage-year"?: number;
+	tags?: string[];
+	ratings?: Record<string, number>;
+	winery?: Winery;
+	origin?: {
+		country: string;
+	};
+}
+
+export interface Winery {
+	name?: string;
+}
+
+export interface AddPayload {
+	user?: string;
+	pass?: string;
+	bottle: Bottle;
+}
+
+export interface AddResult {
+	id?: number;
+	location?: string;
+	rate?: number;
+}
+
+export interface FilesPayload {
+	path?: string;
+	note?: string;
+}
+
+/** ShowError lists the errors returned by the show method, the name discriminates the errors. */
+export type ShowError =
+	| { name: "not_found"; status: 404; body: ErrorResult }
+	| { name: "gone"; status: 404; body: ErrorResult };
+
+/** TSServiceClient is the client of the TSService service. Manages the wine cellar. */
+export class TSServiceClient {
+	private readonly transport: Transport;
+
+	constructor(options: ClientOptions = {}) {
+		this.transport = new Transport("http://localhost:8088", options);
+	}
+
+	/**
+	 * Show a bottle.
+	 *
+	 * @throws {ServiceError<ShowError>} if the server returns one of the method errors.
+	 */
+	async show(p: ShowPayload): Promise<Bottle> {
+		const res = await this.transport.send({
+			method: "GET",
+			path: "/bottles/" + pathValue(p.id),
+			query: [["view", p.views]],
+			headers: [["Authorization", bearer(p.token)]],
+			cookies: [["session", p.session]],
+			status: [200],
+			errors: [["not_found", 404], ["gone", 404]],
+		});
+		return (await decode(res)) as Bottle;
+	}
+
+	/** add calls the "add" method of the "TSService" service. */
+	async add(p: AddPayload): Promise<AddResult> {
+		const res = await this.transport.send({
+			method: "POST",
+			path: "/bottles",
+			headers: [["Authorization", basic(p.user, p.pass)]],
+			body: p.bottle,
+			status: [201],
+		});
+		return { ...((await decode(res)) as object), location: header(res, "Location", "string"), rate: header(res, "X-Rate", "number") } as AddResult;
+	}
+
+	/** files calls the "files" method of the "TSService" service. */
+	async files(p: FilesPayload): Promise<string> {
+		const res = await this.transport.send({
+			method: "POST",
+			path: "/files/" + pathValue(p.path, true),
+			body: { note: p.note },
+			form: true,
+			status: [200],
+		});
+		return (await decode(res)) as string;
+	}
+
+	/** ping calls the "ping" method of the "TSService" service. */
+	async ping(): Promise<void> {
+		await this.transport.send({
+			method: "GET",
+			path: "/ping",
+			status: [204],
+		});
+	}
+}
+`
+
+const TypeScriptIndexCode = `import type { ClientOptions } from "./goa";
+import { TSServiceClient } from "./ts_service";
+
+export * from "./goa";
+export * as tsService from "./ts_service";
+
+/** Client is the TypeScriptAPI client, it exposes the client of each service. */
+export class Client {
+	/** tsService is the client of the TSService service. */
+	readonly tsService: TSServiceClient;
+
+	constructor(options: ClientOptions = {}) {
+		this.tsService = new TSServiceClient(options);
+	}
+}
+`
+
+const TypeScriptStreamingServiceCode = `import type { ClientOptions, Stream } from "./goa";
+import { Transport } from "./goa";
+
+export interface WatchPayload {
+	since?: number;
+}
+
+export interface Event {
+	kind?: string;
+}
+
+export interface ChatResult {
+	text?: string;
+}
+
+/** TSStreamingServiceClient is the client of the TSStreamingService service. */
+export class TSStreamingServiceClient {
+	private readonly transport: Transport;
+
+	constructor(options: ClientOptions = {}) {
+		this.transport = new Transport("http://localhost:80", options);
+	}
+
+	/** watch calls the "watch" method of the "TSStreamingService" service. */
+	watch(p: WatchPayload): AsyncIterable<Event> {
+		return this.transport.stream<Event>({
+			method: "GET",
+			path: "/watch",
+			query: [["since", p.since]],
+			status: [200],
+		}, "ndjson");
+	}
+
+	/** chat calls the "chat" method of the "TSStreamingService" service. */
+	chat(): Promise<Stream<Event, ChatResult>> {
+		return this.transport.websocket<Event, ChatResult>({
+			method: "GET",
+			path: "/chat",
+			status: [101],
+		}, ["chat.v1"]);
+	}
+
+	/** upload calls the "upload" method of the "TSStreamingService" service. */
+	upload(): Promise<Stream<Event, number>> {
+		return this.transport.websocket<Event, number>({
+			method: "GET",
+			path: "/upload",
+			status: [101],
+		});
+	}
+}
+`
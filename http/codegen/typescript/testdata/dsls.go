@@ -0,0 +1,143 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var TypeScriptDSL = func() {
+	var Basic = BasicAuthSecurity("basic")
+	var JWT = JWTSecurity("jwt", func() {
+		Scope("api:read")
+	})
+	var Winery = Type("Winery", func() {
+		Attribute("name", String)
+	})
+	var Bottle = Type("Bottle", func() {
+		Description("Bottle of wine.")
+		Attribute("id", Int, "Unique ID")
+		Attribute("name", String)
+		Attribute("vintage-year", Int)
+		Attribute("tags", ArrayOf(String))
+		Attribute("ratings", MapOf(String, Float64))
+		Attribute("winery", Winery)
+		Attribute("origin", func() {
+			Attribute("country", String)
+			Required("country")
+		})
+		Required("id", "name")
+	})
+	var _ = API("TypeScriptAPI", func() {
+		Meta("typescript:client")
+		Server("server", func() {
+			Host("local", func() {
+				URI("http://localhost:8088/api")
+			})
+		})
+	})
+	var _ = Service("TSService", func() {
+		Description("Manages the wine cellar.")
+		Method("show", func() {
+			Description("Show a bottle.")
+			Security(JWT)
+			Payload(func() {
+				Token("token", String)
+				Attribute("id", Int)
+				Attribute("views", ArrayOf(String))
+				Attribute("session", String)
+				Required("id")
+			})
+			Result(Bottle)
+			Error("not_found")
+			Error("gone")
+			HTTP(func() {
+				GET("/bottles/{id}")
+				Param("views:view")
+				Cookie("session")
+				Response(StatusOK)
+				Response("not_found", StatusNotFound)
+				Response("gone", StatusNotFound)
+			})
+		})
+		Method("add", func() {
+			Security(Basic)
+			Payload(func() {
+				Username("user", String)
+				Password("pass", String)
+				Attribute("bottle", Bottle)
+				Required("bottle")
+			})
+			Result(func() {
+				Attribute("id", Int)
+				Attribute("location", String)
+				Attribute("rate", Int)
+			})
+			HTTP(func() {
+				POST("/bottles")
+				Body("bottle")
+				Response(StatusCreated, func() {
+					Header("location:Location")
+					Header("rate:X-Rate")
+				})
+			})
+		})
+		Method("files", func() {
+			Payload(func() {
+				Attribute("path", String)
+				Attribute("note", String)
+			})
+			Result(String)
+			HTTP(func() {
+				POST("/files/{*path}")
+				FormEncodedRequest()
+				Response(StatusOK)
+			})
+		})
+		Method("ping", func() {
+			HTTP(func() {
+				GET("/ping")
+				Response(StatusNoContent)
+			})
+		})
+	})
+}
+
+var TypeScriptStreamingDSL = func() {
+	var Event = Type("Event", func() {
+		Attribute("kind", String)
+	})
+	var _ = API("TypeScriptStreamingAPI", func() {
+		Meta("typescript:client")
+	})
+	var _ = Service("TSStreamingService", func() {
+		Method("watch", func() {
+			Payload(func() {
+				Attribute("since", Int)
+			})
+			StreamingResult(Event)
+			HTTP(func() {
+				GET("/watch")
+				Param("since")
+				StreamingResponse(NDJSON)
+			})
+		})
+		Method("chat", func() {
+			StreamingPayload(Event)
+			StreamingResult(func() {
+				Attribute("text", String)
+			})
+			HTTP(func() {
+				GET("/chat")
+				WebSocket(func() {
+					Subprotocols("chat.v1")
+				})
+			})
+		})
+		Method("upload", func() {
+			StreamingPayload(Event)
+			Result(Int)
+			HTTP(func() {
+				GET("/upload")
+			})
+		})
+	})
+}
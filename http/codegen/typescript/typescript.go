@@ -0,0 +1,673 @@
+// Package typescript generates a TypeScript client for the HTTP endpoints of a
+// design. The client uses the fetch API to make the requests, declares
+// interfaces matching the design types and discriminated unions for the errors
+// of each method, and streams the results of the streaming endpoints over
+// websockets or chunked HTTP responses.
+package typescript
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// serviceData contains the data needed to render the client of a
+	// service.
+	serviceData struct {
+		// Name is the name of the service.
+		Name string
+		// Description is the service description.
+		Description string
+		// ClassName is the name of the client class.
+		ClassName string
+		// Property is the name of the property that holds the service
+		// client in the API client.
+		Property string
+		// Module is the name of the module without extension.
+		Module string
+		// BaseURL is the default base URL of the client.
+		BaseURL string
+		// TypeImports lists the types imported from the runtime module.
+		TypeImports []string
+		// Imports lists the values imported from the runtime module.
+		Imports []string
+		// Types lists the type declarations.
+		Types []string
+		// Methods lists the client methods.
+		Methods []*methodData
+	}
+
+	// methodData contains the data needed to render a client method.
+	methodData struct {
+		// Name is the name of the method in the design.
+		Name string
+		// ServiceName is the name of the service.
+		ServiceName string
+		// FuncName is the name of the client method.
+		FuncName string
+		// Description is the method description.
+		Description string
+		// Payload is the payload type if any.
+		Payload string
+		// Result is the result type, "void" if none.
+		Result string
+		// Errors is the name of the type that lists the method errors if
+		// any.
+		Errors string
+		// ErrorCases lists the errors returned by the method.
+		ErrorCases []*errorData
+		// Request is the TypeScript object literal describing the
+		// request.
+		Request string
+		// ResultCode is the code that builds the result from the
+		// response res.
+		ResultCode []string
+		// Stream is the format of the chunked streaming response if any.
+		Stream string
+		// WebSocket is true if the method streams over a websocket.
+		WebSocket bool
+		// Send is the type of the values sent to the websocket stream.
+		Send string
+		// Recv is the type of the values received from the websocket
+		// stream.
+		Recv string
+		// Protocols is the TypeScript array literal listing the
+		// websocket subprotocols if any.
+		Protocols string
+	}
+
+	// errorData describes an error returned by a method.
+	errorData struct {
+		// Name is the name of the error.
+		Name string
+		// Status is the response status code.
+		Status int
+		// Type is the type of the error response body.
+		Type string
+	}
+
+	// builder builds the type declarations of a service module.
+	builder struct {
+		// names maps the user type hashes to their TypeScript names.
+		names map[string]string
+		// taken records the names already used in the module.
+		taken map[string]bool
+		// decls lists the type declarations.
+		decls []string
+		// imports records the names imported from the runtime module.
+		imports map[string]bool
+	}
+)
+
+// runtimeTypes lists the names exported by the runtime module that are only
+// used as types by the service modules.
+var runtimeTypes = map[string]bool{"ClientOptions": true, "ErrorResult": true, "Stream": true}
+
+// Files returns the files that contain the TypeScript client of the HTTP
+// services of the given API: the runtime module goa.ts, one module per service
+// and the index.ts module that exports the API client.
+func Files(root *expr.RootExpr) []*codegen.File {
+	if root == nil || root.API == nil || root.API.HTTP == nil {
+		return nil
+	}
+	var svcs []*serviceData
+	for _, svc := range root.API.HTTP.Services {
+		if len(svc.HTTPEndpoints) == 0 {
+			continue
+		}
+		svcs = append(svcs, buildServiceData(root, svc))
+	}
+	if len(svcs) == 0 {
+		return nil
+	}
+	dir := filepath.Join(codegen.Gendir, "http", "typescript")
+	files := []*codegen.File{{
+		Path: filepath.Join(dir, "goa.ts"),
+		SectionTemplates: []*codegen.SectionTemplate{
+			header(root.API.Name + " TypeScript client runtime"),
+			{Name: "typescript-runtime", Source: runtimeT},
+		},
+	}}
+	for _, s := range svcs {
+		files = append(files, &codegen.File{
+			Path: filepath.Join(dir, s.Module+".ts"),
+			SectionTemplates: []*codegen.SectionTemplate{
+				header(s.Name + " TypeScript client"),
+				{Name: "typescript-service", Source: serviceT, Data: s, FuncMap: funcs},
+			},
+		})
+	}
+	files = append(files, &codegen.File{
+		Path: filepath.Join(dir, "index.ts"),
+		SectionTemplates: []*codegen.SectionTemplate{
+			header(root.API.Name + " TypeScript client"),
+			{Name: "typescript-index", Source: indexT, Data: map[string]interface{}{"API": root.API.Name, "Services": svcs}},
+		},
+	})
+	return files
+}
+
+// funcs lists the functions used by the service template.
+var funcs = template.FuncMap{
+	"join":  strings.Join,
+	"quote": quote,
+	"jsdoc": func(desc, indent string) string {
+		return strings.TrimSuffix(jsdoc(desc, indent), "\n")
+	},
+	"methodDoc": methodDoc,
+	"suffix": func(desc string) string {
+		if desc == "" {
+			return ""
+		}
+		return " " + desc
+	},
+}
+
+// header returns the section that renders the header of a TypeScript module.
+func header(title string) *codegen.SectionTemplate {
+	return &codegen.SectionTemplate{
+		Name:   "typescript-header",
+		Source: headerT,
+		Data:   map[string]interface{}{"Title": title, "ToolVersion": goa.Version()},
+	}
+}
+
+// buildServiceData returns the data needed to render the client of the given
+// service.
+func buildServiceData(root *expr.RootExpr, svc *expr.HTTPServiceExpr) *serviceData {
+	b := &builder{
+		names:   make(map[string]string),
+		taken:   make(map[string]bool),
+		imports: map[string]bool{"ClientOptions": true, "Transport": true},
+	}
+	data := &serviceData{
+		Name:        svc.Name(),
+		Description: svc.Description(),
+		ClassName:   codegen.Goify(svc.Name(), true) + "Client",
+		Property:    codegen.Goify(codegen.SnakeCase(svc.Name()), false),
+		Module:      codegen.SnakeCase(codegen.Goify(svc.Name(), true)),
+		BaseURL:     root.API.DefaultHTTPURL(),
+	}
+	b.taken[data.ClassName] = true
+	for _, e := range svc.HTTPEndpoints {
+		data.Methods = append(data.Methods, b.method(e))
+	}
+	data.Types = b.decls
+	for name := range b.imports {
+		if runtimeTypes[name] {
+			data.TypeImports = append(data.TypeImports, name)
+		} else {
+			data.Imports = append(data.Imports, name)
+		}
+	}
+	sort.Strings(data.TypeImports)
+	sort.Strings(data.Imports)
+	return data
+}
+
+// method returns the data needed to render the client method that calls the
+// given endpoint.
+func (b *builder) method(e *expr.HTTPEndpointExpr) *methodData {
+	m := e.MethodExpr
+	prefix := codegen.Goify(m.Name, true)
+	data := &methodData{
+		Name:        m.Name,
+		ServiceName: m.Service.Name,
+		FuncName:    codegen.Goify(m.Name, false),
+		Description: m.Description,
+		Result:      "void",
+	}
+	if m.Payload.Type != expr.Empty {
+		data.Payload = b.typeRef(m.Payload, prefix+"Payload")
+	}
+	for _, herr := range e.HTTPErrors {
+		typ := b.typeRef(m.Error(herr.Name).AttributeExpr, codegen.Goify(herr.Name, true)+"Error")
+		data.ErrorCases = append(data.ErrorCases, &errorData{Name: herr.Name, Status: herr.Response.StatusCode, Type: typ})
+	}
+	if len(data.ErrorCases) > 0 {
+		data.Errors = b.unique(prefix + "Error")
+	}
+
+	switch {
+	case e.WebSocket != nil || (m.IsStreaming() && e.StreamingResponse == ""):
+		data.WebSocket = true
+		b.imports["Stream"] = true
+		data.Send, data.Recv = "never", "never"
+		if m.IsPayloadStreaming() {
+			data.Send = b.typeRef(m.StreamingPayload, prefix+"StreamingPayload")
+		}
+		if m.Result.Type != expr.Empty {
+			data.Recv = b.typeRef(m.Result, prefix+"Result")
+		}
+		if e.WebSocket != nil && len(e.WebSocket.Subprotocols) > 0 {
+			data.Protocols = stringArray(e.WebSocket.Subprotocols)
+		}
+	case e.StreamingResponse != "":
+		data.Stream = e.StreamingResponse
+		data.Result = b.typeRef(m.Result, prefix+"Result")
+	default:
+		if m.Result.Type != expr.Empty {
+			data.Result = b.typeRef(m.Result, prefix+"Result")
+			data.ResultCode = b.resultCode(e, data.Result)
+		}
+	}
+	data.Request = b.request(e, data)
+	return data
+}
+
+// request returns the TypeScript object literal that describes the request
+// made to the endpoint.
+func (b *builder) request(e *expr.HTTPEndpointExpr, data *methodData) string {
+	var (
+		m      = e.MethodExpr
+		route  = e.Routes[0]
+		fields []string
+	)
+	fields = append(fields, "method: "+quote(route.Method))
+	fields = append(fields, "path: "+b.path(e, route.FullPaths()[0]))
+	if q := b.values(e.QueryParams(), m.Payload, nil); len(q) > 0 {
+		fields = append(fields, "query: ["+strings.Join(q, ", ")+"]")
+	}
+	var headers []string
+	bearer := make(map[string]bool)
+	for _, req := range e.Requirements {
+		for _, s := range req.Schemes {
+			switch s.Kind {
+			case expr.BasicAuthKind:
+				user := expr.TaggedAttribute(m.Payload, "security:username")
+				pass := expr.TaggedAttribute(m.Payload, "security:password")
+				if user != "" && pass != "" {
+					b.imports["basic"] = true
+					headers = append(headers, fmt.Sprintf(`["Authorization", basic(%s, %s)]`, access(m.Payload, user), access(m.Payload, pass)))
+				}
+			case expr.JWTKind, expr.OAuth2Kind:
+				if s.In == "header" && s.Name == "Authorization" {
+					bearer["Authorization"] = true
+				}
+			}
+		}
+	}
+	headers = append(headers, b.values(e.Headers, m.Payload, bearer)...)
+	if len(headers) > 0 {
+		fields = append(fields, "headers: ["+strings.Join(headers, ", ")+"]")
+	}
+	if c := b.values(e.Cookies, m.Payload, nil); len(c) > 0 {
+		fields = append(fields, "cookies: ["+strings.Join(c, ", ")+"]")
+	}
+	if !data.WebSocket {
+		if body := b.body(e); body != "" {
+			fields = append(fields, "body: "+body)
+			if e.FormEncodedRequest {
+				fields = append(fields, "form: true")
+			}
+		}
+		var codes []string
+		for _, r := range e.Responses {
+			codes = append(codes, fmt.Sprint(r.StatusCode))
+		}
+		fields = append(fields, "status: ["+strings.Join(codes, ", ")+"]")
+		if len(data.ErrorCases) > 0 {
+			var errs []string
+			for _, er := range data.ErrorCases {
+				errs = append(errs, fmt.Sprintf("[%s, %d]", quote(er.Name), er.Status))
+			}
+			fields = append(fields, "errors: ["+strings.Join(errs, ", ")+"]")
+		}
+	} else {
+		fields = append(fields, "status: [101]")
+	}
+	return "{\n\t\t\t" + strings.Join(fields, ",\n\t\t\t") + ",\n\t\t}"
+}
+
+// path returns the TypeScript expression that builds the request path.
+func (b *builder) path(e *expr.HTTPEndpointExpr, path string) string {
+	params := e.PathParams()
+	var parts []string
+	for path != "" {
+		i := strings.Index(path, "{")
+		if i < 0 {
+			parts = append(parts, quote(path))
+			break
+		}
+		j := strings.Index(path[i:], "}") + i
+		if i > 0 {
+			parts = append(parts, quote(path[:i]))
+		}
+		name := path[i+1 : j]
+		wildcard := strings.HasPrefix(name, "*")
+		name = strings.TrimPrefix(name, "*")
+		b.imports["pathValue"] = true
+		value := access(e.MethodExpr.Payload, params.KeyName(name))
+		if wildcard {
+			parts = append(parts, "pathValue("+value+", true)")
+		} else {
+			parts = append(parts, "pathValue("+value+")")
+		}
+		path = path[j+1:]
+	}
+	if len(parts) == 0 {
+		return `"/"`
+	}
+	return strings.Join(parts, " + ")
+}
+
+// values returns the TypeScript tuples that list the values of the given
+// query parameters, headers or cookies. The names listed in bearer are set
+// with the bearer helper.
+func (b *builder) values(ma *expr.MappedAttributeExpr, payload *expr.AttributeExpr, bearer map[string]bool) []string {
+	if ma == nil || ma.Type == nil || ma.IsEmpty() {
+		return nil
+	}
+	var tuples []string
+	expr.WalkMappedAttr(ma, func(name, elem string, _ *expr.AttributeExpr) error {
+		value := access(payload, name)
+		if bearer[elem] {
+			b.imports["bearer"] = true
+			value = "bearer(" + value + ")"
+		}
+		tuples = append(tuples, fmt.Sprintf("[%s, %s]", quote(elem), value))
+		return nil
+	})
+	return tuples
+}
+
+// body returns the TypeScript expression that builds the request body, the
+// empty string if the request has no body.
+func (b *builder) body(e *expr.HTTPEndpointExpr) string {
+	if e.Body == nil || e.Body.Type == expr.Empty {
+		return ""
+	}
+	payload := e.MethodExpr.Payload
+	if origin, ok := e.Body.Meta["origin:attribute"]; ok {
+		return access(payload, origin[0])
+	}
+	obj := expr.AsObject(e.Body.Type)
+	if obj == nil || !expr.IsObject(payload.Type) {
+		return "p"
+	}
+	var fields []string
+	for _, nat := range *obj {
+		fields = append(fields, property(nat)+": "+access(payload, nat.Name))
+	}
+	return "{ " + strings.Join(fields, ", ") + " }"
+}
+
+// resultCode returns the statements that build the result of type typ from
+// the response res.
+func (b *builder) resultCode(e *expr.HTTPEndpointExpr, typ string) []string {
+	var (
+		r       = e.Responses[0]
+		result  = e.MethodExpr.Result
+		hasBody = r.Body != nil && r.Body.Type != expr.Empty
+		headers []string
+	)
+	if r.Headers != nil && !r.Headers.IsEmpty() {
+		expr.WalkMappedAttr(r.Headers, func(name, elem string, att *expr.AttributeExpr) error {
+			b.imports["header"] = true
+			kind, array := headerKind(att.Type)
+			value := fmt.Sprintf("header(res, %s, %s%s)", quote(elem), quote(kind), map[bool]string{true: ", true", false: ""}[array])
+			if !expr.IsObject(result.Type) {
+				headers = append(headers, value)
+				return nil
+			}
+			headers = append(headers, propertyName(name, att)+": "+value)
+			return nil
+		})
+	}
+	if hasBody {
+		b.imports["decode"] = true
+	}
+	switch {
+	case !expr.IsObject(result.Type) && hasBody:
+		return []string{"return (await decode(res)) as " + typ + ";"}
+	case !expr.IsObject(result.Type) && len(headers) > 0:
+		return []string{"return " + headers[0] + ";"}
+	case !expr.IsObject(result.Type):
+		return nil
+	}
+	var spread string
+	if hasBody {
+		if origin, ok := r.Body.Meta["origin:attribute"]; ok {
+			att := result.Find(origin[0])
+			spread = propertyName(origin[0], att) + ": await decode(res)"
+		} else if len(headers) == 0 {
+			return []string{"return (await decode(res)) as " + typ + ";"}
+		} else {
+			spread = "...((await decode(res)) as object)"
+		}
+	}
+	fields := headers
+	if spread != "" {
+		fields = append([]string{spread}, headers...)
+	}
+	return []string{"return { " + strings.Join(fields, ", ") + " } as " + typ + ";"}
+}
+
+// typeRef returns the TypeScript type of the given attribute, name is used to
+// declare the type of inline objects.
+func (b *builder) typeRef(att *expr.AttributeExpr, name string) string {
+	if _, ok := att.Type.(*expr.Object); ok {
+		n := b.unique(name)
+		b.declare(n, att.Description, att)
+		return n
+	}
+	return b.tsType(att)
+}
+
+// tsType returns the TypeScript type of the given attribute and declares the
+// user types it uses.
+func (b *builder) tsType(att *expr.AttributeExpr) string {
+	switch actual := att.Type.(type) {
+	case expr.Primitive:
+		switch actual.Kind() {
+		case expr.BooleanKind:
+			return "boolean"
+		case expr.StringKind, expr.BytesKind:
+			return "string"
+		case expr.AnyKind:
+			return "unknown"
+		default:
+			return "number"
+		}
+	case *expr.Array:
+		elem := b.tsType(actual.ElemType)
+		if strings.ContainsAny(elem, " |") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	case *expr.Map:
+		return "Record<string, " + b.tsType(actual.ElemType) + ">"
+	case *expr.Object:
+		return b.objectType(att, "")
+	case expr.UserType:
+		if actual == expr.ErrorResult {
+			b.imports["ErrorResult"] = true
+			return "ErrorResult"
+		}
+		if n, ok := b.names[actual.Hash()]; ok {
+			return n
+		}
+		n := b.unique(codegen.Goify(actual.Name(), true))
+		b.names[actual.Hash()] = n
+		b.declare(n, actual.Attribute().Description, actual.Attribute())
+		return n
+	default:
+		return "unknown"
+	}
+}
+
+// declare records the declaration of the type with the given name.
+func (b *builder) declare(name, desc string, att *expr.AttributeExpr) {
+	i := len(b.decls)
+	b.decls = append(b.decls, "") // reserve the slot before the nested types
+	var decl string
+	if expr.IsObject(att.Type) {
+		decl = "export interface " + name + " " + b.objectType(att, "")
+	} else {
+		decl = "export type " + name + " = " + b.tsType(&expr.AttributeExpr{Type: att.Type.(expr.UserType).Attribute().Type}) + ";"
+	}
+	if desc != "" {
+		decl = jsdoc(desc, "") + decl
+	}
+	b.decls[i] = decl
+}
+
+// objectType returns the TypeScript object type of the given object
+// attribute indented with indent.
+func (b *builder) objectType(att *expr.AttributeExpr, indent string) string {
+	obj := expr.AsObject(att.Type)
+	if len(*obj) == 0 {
+		return "{}"
+	}
+	var lines []string
+	for _, nat := range *obj {
+		opt := "?"
+		if att.IsRequired(nat.Name) {
+			opt = ""
+		}
+		var typ string
+		if _, ok := nat.Attribute.Type.(*expr.Object); ok {
+			typ = b.objectType(nat.Attribute, indent+"\t")
+		} else {
+			typ = b.tsType(nat.Attribute)
+		}
+		line := indent + "\t" + property(nat) + opt + ": " + typ + ";"
+		if nat.Attribute.Description != "" {
+			line = jsdoc(nat.Attribute.Description, indent+"\t") + line
+		}
+		lines = append(lines, line)
+	}
+	return "{\n" + strings.Join(lines, "\n") + "\n" + indent + "}"
+}
+
+// unique returns a name derived from name that is not used in the module.
+func (b *builder) unique(name string) string {
+	n := name
+	for i := 2; b.taken[n]; i++ {
+		n = fmt.Sprintf("%s%d", name, i)
+	}
+	b.taken[n] = true
+	return n
+}
+
+// headerKind returns the kind of value read from a response header and
+// whether the header lists multiple values.
+func headerKind(dt expr.DataType) (string, bool) {
+	if arr := expr.AsArray(dt); arr != nil {
+		kind, _ := headerKind(arr.ElemType.Type)
+		return kind, true
+	}
+	switch dt.Kind() {
+	case expr.BooleanKind:
+		return "boolean", false
+	case expr.StringKind, expr.BytesKind, expr.AnyKind:
+		return "string", false
+	default:
+		return "number", false
+	}
+}
+
+// property returns the name of the JSON property of the given attribute
+// quoted if needed.
+func property(nat *expr.NamedAttributeExpr) string {
+	return propertyName(nat.Name, nat.Attribute)
+}
+
+// propertyName returns the name of the JSON property of the attribute with
+// the given name quoted if needed.
+func propertyName(name string, att *expr.AttributeExpr) string {
+	if att != nil {
+		if tag, ok := att.Meta.Last("struct:tag:json"); ok {
+			if t := strings.Split(tag, ",")[0]; t != "" && t != "-" {
+				name = t
+			}
+		}
+	}
+	if isIdentifier(name) {
+		return name
+	}
+	return quote(name)
+}
+
+// access returns the TypeScript expression that reads the payload attribute
+// with the given name.
+func access(payload *expr.AttributeExpr, name string) string {
+	if !expr.IsObject(payload.Type) {
+		return "p"
+	}
+	prop := propertyName(name, payload.Find(name))
+	if strings.HasPrefix(prop, `"`) {
+		return "p[" + prop + "]"
+	}
+	return "p." + prop
+}
+
+// isIdentifier returns true if s is a valid TypeScript identifier.
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, c := range s {
+		switch {
+		case c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+		case i > 0 && c >= '0' && c <= '9':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// quote returns s as a TypeScript string literal.
+func quote(s string) string {
+	return fmt.Sprintf("%q", s)
+}
+
+// stringArray returns a TypeScript array literal listing the given strings.
+func stringArray(ss []string) string {
+	quoted := make([]string, len(ss))
+	for i, s := range ss {
+		quoted[i] = quote(s)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// jsdoc returns the JSDoc comment containing desc indented with indent.
+func jsdoc(desc, indent string) string {
+	lines := strings.Split(strings.TrimSpace(desc), "\n")
+	if len(lines) == 1 {
+		return indent + "/** " + escapeComment(lines[0]) + " */\n"
+	}
+	var sb strings.Builder
+	sb.WriteString(indent + "/**\n")
+	for _, l := range lines {
+		sb.WriteString(strings.TrimRight(indent+" * "+escapeComment(l), " ") + "\n")
+	}
+	sb.WriteString(indent + " */\n")
+	return sb.String()
+}
+
+// methodDoc returns the documentation of the client method.
+func methodDoc(m *methodData) string {
+	doc := m.Description
+	if doc == "" {
+		doc = fmt.Sprintf("%s calls the %q method of the %q service.", m.FuncName, m.Name, m.ServiceName)
+	}
+	if m.Errors != "" {
+		doc += fmt.Sprintf("\n\n@throws {ServiceError<%s>} if the server returns one of the method errors.", m.Errors)
+	}
+	return doc
+}
+
+// escapeComment makes sure s does not terminate the comment it is written
+// in.
+func escapeComment(s string) string {
+	return strings.Replace(s, "*/", "*\\/", -1)
+}
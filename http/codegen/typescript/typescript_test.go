@@ -0,0 +1,77 @@
+package typescript
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/typescript/testdata"
+)
+
+func TestFiles(t *testing.T) {
+	cases := []struct {
+		Name    string
+		DSL     func()
+		File    string
+		Section string
+		Code    string
+	}{
+		{"service", testdata.TypeScriptDSL, "ts_service.ts", "typescript-service", testdata.TypeScriptServiceCode},
+		{"index", testdata.TypeScriptDSL, "index.ts", "typescript-index", testdata.TypeScriptIndexCode},
+		{"streaming", testdata.TypeScriptStreamingDSL, "ts_streaming_service.ts", "typescript-service", testdata.TypeScriptStreamingServiceCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := expr.RunDSL(t, c.DSL)
+			var file *codegen.File
+			for _, f := range Files(root) {
+				if filepath.Base(f.Path) == c.File {
+					file = f
+				}
+			}
+			if file == nil {
+				t.Fatalf("file %q not found", c.File)
+			}
+			sections := file.Section(c.Section)
+			if len(sections) != 1 {
+				t.Fatalf("got %d %q sections, expected one", len(sections), c.Section)
+			}
+			var buf bytes.Buffer
+			if err := sections[0].Write(&buf); err != nil {
+				t.Fatal(err)
+			}
+			if code := buf.String(); code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestFilesNil(t *testing.T) {
+	if fs := Files(nil); fs != nil {
+		t.Errorf("got %d files, expected nil", len(fs))
+	}
+}
+
+func TestPropertyName(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Meta     expr.MetaExpr
+		Expected string
+	}{
+		{"id", nil, "id"},
+		{"vintage-year", nil, `"vintage-year"`},
+		{"2fa", nil, `"2fa"`},
+		{"id", expr.MetaExpr{"struct:tag:json": {"ID,omitempty"}}, "ID"},
+		{"id", expr.MetaExpr{"struct:tag:json": {"-"}}, "id"},
+	}
+	for _, c := range cases {
+		t.Run(c.Expected, func(t *testing.T) {
+			if got := propertyName(c.Name, &expr.AttributeExpr{Meta: c.Meta}); got != c.Expected {
+				t.Errorf("got %s, expected %s", got, c.Expected)
+			}
+		})
+	}
+}
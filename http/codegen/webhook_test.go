@@ -0,0 +1,45 @@
+package codegen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestWebhooks(t *testing.T) {
+	const genpkg = "gen"
+	RunHTTPDSL(t, testdata.WebhookDSL)
+	fs := ClientFiles(genpkg, expr.Root)
+	if len(fs) != 3 {
+		t.Fatalf("got %d files, expected 3", len(fs))
+	}
+	f := fs[2]
+	if p := filepath.ToSlash(f.Path); p != "gen/http/orders/client/webhooks.go" {
+		t.Errorf("got path %q, expected gen/http/orders/client/webhooks.go", p)
+	}
+	sections := f.Section("client-webhook-send")
+	if len(sections) != 2 {
+		t.Fatalf("got %d sections, expected 2", len(sections))
+	}
+	for i, c := range []string{testdata.WebhookOrderCreatedCode, testdata.WebhookOrderDeletedCode} {
+		code := codegen.SectionCode(t, sections[i])
+		if code != c {
+			t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c))
+		}
+	}
+
+	tf := ClientTypeFiles(genpkg, expr.Root)[0]
+	var types []string
+	for _, s := range tf.Section("client-body-attributes") {
+		types = append(types, codegen.SectionCode(t, s))
+	}
+	if len(types) != 3 {
+		t.Fatalf("got %d body attribute types, expected 3", len(types))
+	}
+	if types[0] != testdata.WebhookOrderCreatedBodyCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", types[0], codegen.Diff(t, types[0], testdata.WebhookOrderCreatedBodyCode))
+	}
+}
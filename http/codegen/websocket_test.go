@@ -0,0 +1,42 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestWebSocketConfig(t *testing.T) {
+	const genpkg = "gen"
+	cases := []struct {
+		Name    string
+		DSL     func()
+		Files   func(string, *expr.RootExpr) []*codegen.File
+		Section string
+		Code    string
+	}{
+		{"server-handler-init", testdata.StreamingWebSocketDSL, ServerFiles, "server-handler-init", testdata.StreamingWebSocketServerHandlerInitCode},
+		{"client-endpoint-init", testdata.StreamingWebSocketDSL, ClientFiles, "client-endpoint-init", testdata.StreamingWebSocketClientEndpointInitCode},
+		{"server-handler-init-send-only", testdata.StreamingWebSocketResultDSL, ServerFiles, "server-handler-init", testdata.StreamingWebSocketSendOnlyServerHandlerInitCode},
+		{"client-endpoint-init-payload", testdata.StreamingWebSocketPayloadDSL, ClientFiles, "client-endpoint-init", testdata.StreamingWebSocketPayloadClientEndpointInitCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := c.Files(genpkg, expr.Root)
+			for _, s := range fs[0].SectionTemplates {
+				if s.Name != c.Section {
+					continue
+				}
+				code := codegen.SectionCode(t, s)
+				if code != c.Code {
+					t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+				}
+				return
+			}
+			t.Fatalf("section %q not found", c.Section)
+		})
+	}
+}
@@ -0,0 +1,357 @@
+package http
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type (
+	// Compressor implements a HTTP content coding. Implementations for
+	// content codings not provided by goa such as "br" may be registered
+	// with RegisterCompressor.
+	Compressor interface {
+		// Encoding returns the name of the content coding, e.g. "br".
+		Encoding() string
+		// NewWriter returns a writer that compresses the data written
+		// to it and writes the result to w. The writer is closed once
+		// the response is complete. The writer may implement a
+		// "Flush() error" method which is called when the handler
+		// flushes the response.
+		NewWriter(w io.Writer) io.WriteCloser
+	}
+
+	// CompressOptions configures the handlers returned by
+	// CompressHandler.
+	CompressOptions struct {
+		// Encodings lists the content codings in order of preference.
+		Encodings []string
+		// ContentTypes lists the media types of the responses that are
+		// compressed, a media type may use a wildcard subtype (e.g.
+		// "text/*"). DefaultCompressTypes is used when empty.
+		ContentTypes []string
+		// MinSize is the minimum size in bytes of compressed responses.
+		MinSize int
+	}
+
+	// compressWriter is the response writer used by CompressHandler. It
+	// buffers the response until it can decide whether to compress it.
+	compressWriter struct {
+		http.ResponseWriter
+		opts *CompressOptions
+		c    Compressor
+		// status is the status code given to WriteHeader, zero if not
+		// called yet.
+		status int
+		// buf holds the data written before the decision is made.
+		buf []byte
+		// decided is true once the headers have been written.
+		decided bool
+		// cw is the compressing writer, nil if the response is not
+		// compressed.
+		cw io.WriteCloser
+		// hijacked is true if the handler hijacked the connection.
+		hijacked bool
+	}
+
+	// gzipCompressor implements the "gzip" content coding.
+	gzipCompressor struct {
+		pool sync.Pool
+	}
+
+	// gzipWriter returns the underlying gzip writer to the pool on close.
+	gzipWriter struct {
+		*gzip.Writer
+		c *gzipCompressor
+	}
+
+	// deflateCompressor implements the "deflate" content coding.
+	deflateCompressor struct{}
+)
+
+// DefaultCompressTypes lists the media types compressed when CompressOptions
+// does not specify any. Media types with a "+json" or "+xml" suffix are also
+// compressed by default.
+var DefaultCompressTypes = []string{
+	"text/*",
+	"application/json",
+	"application/xml",
+	"application/javascript",
+	"application/x-ndjson",
+	"image/svg+xml",
+}
+
+var (
+	compressorsMu sync.RWMutex
+	compressors   = map[string]Compressor{
+		"gzip":    &gzipCompressor{},
+		"deflate": deflateCompressor{},
+	}
+)
+
+// RegisterCompressor registers the implementation of a content coding, it
+// replaces any implementation registered for the same content coding.
+func RegisterCompressor(c Compressor) {
+	compressorsMu.Lock()
+	defer compressorsMu.Unlock()
+	compressors[c.Encoding()] = c
+}
+
+// CompressHandler returns a handler that compresses the responses written by h
+// using the first encoding listed in opts that the client accepts. Responses
+// that are smaller than the minimum size, that already have a Content-Encoding
+// header, that are partial or whose content type is not compressible are
+// written as is. The Content-Length header is removed from compressed
+// responses. Websocket upgrade requests are not affected.
+func CompressHandler(h http.HandlerFunc, opts *CompressOptions) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") != "" {
+			h(w, r)
+			return
+		}
+		w.Header().Add("Vary", "Accept-Encoding")
+		c := negotiateEncoding(r.Header.Get("Accept-Encoding"), opts.Encodings)
+		if c == nil || r.Method == http.MethodHead {
+			h(w, r)
+			return
+		}
+		cw := &compressWriter{ResponseWriter: w, opts: opts, c: c}
+		defer cw.close()
+		h(cw, r)
+	}
+}
+
+// negotiateEncoding returns the compressor for the first encoding in encodings
+// accepted by the client according to the given Accept-Encoding header value,
+// nil if there is none.
+func negotiateEncoding(header string, encodings []string) Compressor {
+	if header == "" {
+		return nil
+	}
+	accepted := make(map[string]float64)
+	for _, part := range strings.Split(header, ",") {
+		name, q := strings.TrimSpace(part), 1.0
+		if i := strings.Index(name, ";"); i >= 0 {
+			params := strings.TrimSpace(name[i+1:])
+			name = strings.TrimSpace(name[:i])
+			if strings.HasPrefix(params, "q=") {
+				v, err := strconv.ParseFloat(params[2:], 64)
+				if err != nil {
+					continue
+				}
+				q = v
+			}
+		}
+		accepted[strings.ToLower(name)] = q
+	}
+	compressorsMu.RLock()
+	defer compressorsMu.RUnlock()
+	for _, enc := range encodings {
+		q, ok := accepted[enc]
+		if !ok {
+			q, ok = accepted["*"]
+		}
+		if !ok || q <= 0 {
+			continue
+		}
+		if c, ok := compressors[enc]; ok {
+			return c
+		}
+	}
+	return nil
+}
+
+// compressible returns true if responses with the given content type may be
+// compressed.
+func compressible(contentType string, types []string) bool {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	if len(types) == 0 {
+		if strings.HasSuffix(mt, "+json") || strings.HasSuffix(mt, "+xml") {
+			return true
+		}
+		types = DefaultCompressTypes
+	}
+	for _, t := range types {
+		if t == "*/*" || t == mt {
+			return true
+		}
+		if strings.HasSuffix(t, "/*") && strings.HasPrefix(mt, t[:len(t)-1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteHeader records the status code, the headers are written once the
+// compression is decided.
+func (w *compressWriter) WriteHeader(code int) {
+	if w.status != 0 || w.decided {
+		return
+	}
+	w.status = code
+	if !bodyAllowedForStatus(code) {
+		w.decide(false)
+	}
+}
+
+// Write buffers the data until the minimum size is reached and compresses it
+// if the response is compressible.
+func (w *compressWriter) Write(p []byte) (int, error) {
+	if w.decided {
+		if w.cw != nil {
+			return w.cw.Write(p)
+		}
+		return w.ResponseWriter.Write(p)
+	}
+	w.buf = append(w.buf, p...)
+	if len(w.buf) >= w.opts.MinSize {
+		if err := w.decide(w.compressible()); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush compresses the buffered data if the response is compressible and
+// flushes the response.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		w.decide(w.compressible())
+	}
+	if f, ok := w.cw.(interface{ Flush() error }); ok {
+		f.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack lets the handler take over the connection.
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not implement http.Hijacker")
+	}
+	w.hijacked = true
+	return h.Hijack()
+}
+
+// compressible returns true if the response may be compressed.
+func (w *compressWriter) compressible() bool {
+	h := w.Header()
+	if h.Get("Content-Encoding") != "" || h.Get("Content-Range") != "" {
+		return false
+	}
+	if w.status != 0 && (!bodyAllowedForStatus(w.status) || w.status == http.StatusPartialContent) {
+		return false
+	}
+	if cl := h.Get("Content-Length"); cl != "" {
+		if n, err := strconv.Atoi(cl); err == nil && n < w.opts.MinSize {
+			return false
+		}
+	}
+	ct := h.Get("Content-Type")
+	if ct == "" && len(w.buf) > 0 {
+		ct = http.DetectContentType(w.buf)
+		h.Set("Content-Type", ct)
+	}
+	return compressible(ct, w.opts.ContentTypes)
+}
+
+// decide writes the headers and the buffered data, compressing the data if
+// compress is true.
+func (w *compressWriter) decide(compress bool) error {
+	w.decided = true
+	if compress {
+		h := w.Header()
+		h.Set("Content-Encoding", w.c.Encoding())
+		h.Del("Content-Length")
+		if etag := h.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			h.Set("ETag", "W/"+etag)
+		}
+		w.cw = w.c.NewWriter(w.ResponseWriter)
+	}
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	var err error
+	if w.cw != nil {
+		_, err = w.cw.Write(buf)
+	} else {
+		_, err = w.ResponseWriter.Write(buf)
+	}
+	return err
+}
+
+// close writes the buffered data and terminates the compressed stream.
+func (w *compressWriter) close() error {
+	if w.hijacked {
+		return nil
+	}
+	if !w.decided {
+		if err := w.decide(false); err != nil {
+			return err
+		}
+	}
+	if w.cw != nil {
+		return w.cw.Close()
+	}
+	return nil
+}
+
+// Encoding returns "gzip".
+func (c *gzipCompressor) Encoding() string { return "gzip" }
+
+// NewWriter returns a pooled gzip writer.
+func (c *gzipCompressor) NewWriter(w io.Writer) io.WriteCloser {
+	if gw, ok := c.pool.Get().(*gzip.Writer); ok {
+		gw.Reset(w)
+		return &gzipWriter{Writer: gw, c: c}
+	}
+	return &gzipWriter{Writer: gzip.NewWriter(w), c: c}
+}
+
+// Close terminates the gzip stream and returns the writer to the pool.
+func (w *gzipWriter) Close() error {
+	err := w.Writer.Close()
+	w.c.pool.Put(w.Writer)
+	return err
+}
+
+// Encoding returns "deflate".
+func (deflateCompressor) Encoding() string { return "deflate" }
+
+// NewWriter returns a deflate writer.
+func (deflateCompressor) NewWriter(w io.Writer) io.WriteCloser {
+	fw, _ := flate.NewWriter(w, flate.DefaultCompression)
+	return fw
+}
+
+// bodyAllowedForStatus reports whether a given response status code permits a
+// body, see RFC 7230 section 3.3.
+func bodyAllowedForStatus(status int) bool {
+	switch {
+	case status >= 100 && status <= 199:
+		return false
+	case status == http.StatusNoContent, status == http.StatusNotModified:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,148 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// upperCompressor is a fake "br" compressor that upper cases the data.
+type upperCompressor struct{}
+
+type upperWriter struct{ w io.Writer }
+
+func (upperCompressor) Encoding() string { return "br" }
+
+func (upperCompressor) NewWriter(w io.Writer) io.WriteCloser { return upperWriter{w} }
+
+func (u upperWriter) Write(p []byte) (int, error) { return u.w.Write(bytes.ToUpper(p)) }
+
+func (u upperWriter) Close() error { return nil }
+
+func TestCompressHandler(t *testing.T) {
+	RegisterCompressor(upperCompressor{})
+	defer func() {
+		compressorsMu.Lock()
+		delete(compressors, "br")
+		compressorsMu.Unlock()
+	}()
+	large := strings.Repeat(`{"name":"goa"}`, 100)
+	opts := &CompressOptions{Encodings: []string{"br", "gzip"}, MinSize: 1024}
+	jsonOpts := &CompressOptions{Encodings: []string{"gzip"}, ContentTypes: []string{"application/json"}, MinSize: 10}
+	cases := []struct {
+		Name           string
+		Options        *CompressOptions
+		AcceptEncoding string
+		ContentType    string
+		Header         map[string]string
+		Status         int
+		Body           string
+		Encoding       string
+	}{
+		{"gzip", opts, "gzip, deflate", "application/json", nil, http.StatusOK, large, "gzip"},
+		{"preferred", opts, "gzip, br", "application/json", nil, http.StatusOK, large, "br"},
+		{"wildcard", opts, "*", "application/json", nil, http.StatusOK, large, "br"},
+		{"refused", opts, "br;q=0, gzip;q=0.5", "application/json", nil, http.StatusOK, large, "gzip"},
+		{"no-accept-encoding", opts, "", "application/json", nil, http.StatusOK, large, ""},
+		{"unsupported", opts, "zstd", "application/json", nil, http.StatusOK, large, ""},
+		{"too-small", opts, "gzip", "application/json", nil, http.StatusOK, `{"name":"goa"}`, ""},
+		{"suffix", opts, "gzip", "application/vnd.goa+json", nil, http.StatusOK, large, "gzip"},
+		{"sniffed", opts, "gzip", "", nil, http.StatusOK, strings.Repeat("goa ", 300), "gzip"},
+		{"not-compressible", opts, "gzip", "image/png", nil, http.StatusOK, large, ""},
+		{"content-types", jsonOpts, "gzip", "text/plain", nil, http.StatusOK, large, ""},
+		{"encoded", opts, "gzip", "application/json", map[string]string{"Content-Encoding": "identity"}, http.StatusOK, large, "identity"},
+		{"partial", opts, "gzip", "application/json", map[string]string{"Content-Range": "bytes 0-1399/2800"}, http.StatusPartialContent, large, ""},
+		{"no-body", opts, "gzip", "application/json", nil, http.StatusNoContent, "", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			h := CompressHandler(func(w http.ResponseWriter, r *http.Request) {
+				if c.ContentType != "" {
+					w.Header().Set("Content-Type", c.ContentType)
+				}
+				for k, v := range c.Header {
+					w.Header().Set(k, v)
+				}
+				w.Header().Set("Content-Length", "1400")
+				w.WriteHeader(c.Status)
+				io.WriteString(w, c.Body[:len(c.Body)/2])
+				io.WriteString(w, c.Body[len(c.Body)/2:])
+			}, c.Options)
+			r := httptest.NewRequest("GET", "/", nil)
+			if c.AcceptEncoding != "" {
+				r.Header.Set("Accept-Encoding", c.AcceptEncoding)
+			}
+			w := httptest.NewRecorder()
+			h(w, r)
+			if w.Code != c.Status {
+				t.Errorf("got status %d, expected %d", w.Code, c.Status)
+			}
+			if got := w.Header().Get("Content-Encoding"); got != c.Encoding {
+				t.Fatalf("got Content-Encoding %q, expected %q", got, c.Encoding)
+			}
+			if got := w.Header().Get("Vary"); got != "Accept-Encoding" {
+				t.Errorf("got Vary %q, expected %q", got, "Accept-Encoding")
+			}
+			body := w.Body.String()
+			switch c.Encoding {
+			case "gzip":
+				if w.Header().Get("Content-Length") != "" {
+					t.Errorf("unexpected Content-Length header")
+				}
+				gr, err := gzip.NewReader(w.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				b, err := ioutil.ReadAll(gr)
+				if err != nil {
+					t.Fatal(err)
+				}
+				body = string(b)
+			case "br":
+				body = strings.ToLower(body)
+			}
+			if body != c.Body {
+				t.Errorf("got body %q, expected %q", body, c.Body)
+			}
+		})
+	}
+}
+
+func TestCompressHandlerFlush(t *testing.T) {
+	h := CompressHandler(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Header().Set("ETag", `"v1"`)
+		io.WriteString(w, "{\"n\":1}\n")
+		w.(http.Flusher).Flush()
+		io.WriteString(w, "{\"n\":2}\n")
+	}, &CompressOptions{Encodings: []string{"gzip"}, MinSize: 1024})
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	h(w, r)
+	if !w.Flushed {
+		t.Error("response was not flushed")
+	}
+	if got := w.Header().Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("got Content-Encoding %q, expected gzip", got)
+	}
+	if got := w.Header().Get("ETag"); got != `W/"v1"` {
+		t.Errorf("got ETag %q, expected %q", got, `W/"v1"`)
+	}
+	gr, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadAll(gr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(b); got != "{\"n\":1}\n{\"n\":2}\n" {
+		t.Errorf("got body %q", got)
+	}
+}
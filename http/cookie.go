@@ -0,0 +1,265 @@
+package http
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// CookieCodec encodes and decodes the values of the cookies defined with
+	// the CookieSigned DSL. The cookie name is given to the codec so that
+	// the encoded value of a cookie cannot be used for another cookie.
+	CookieCodec interface {
+		// Encode returns the value written to the cookie with the given
+		// name.
+		Encode(name, value string) (string, error)
+		// Decode returns the value encoded in the cookie with the given
+		// name. It returns an error if the value was not produced by
+		// Encode with the same name.
+		Decode(name, value string) (string, error)
+	}
+
+	// CookieOptions lists the attributes of the cookies written by SetCookie.
+	CookieOptions struct {
+		// MaxAge is the number of seconds until the cookie expires.
+		// Zero means no Max-Age attribute and a negative value deletes
+		// the cookie.
+		MaxAge int
+		// Domain is the cookie Domain attribute.
+		Domain string
+		// Path is the cookie Path attribute.
+		Path string
+		// Secure sets the cookie Secure attribute.
+		Secure bool
+		// HTTPOnly sets the cookie HttpOnly attribute.
+		HTTPOnly bool
+		// SameSite is the cookie SameSite attribute value: "Lax",
+		// "Strict", "None" or empty for no attribute.
+		SameSite string
+		// Partitioned sets the cookie Partitioned attribute.
+		Partitioned bool
+		// Signed indicates that the cookie value is encoded with the
+		// cookie codec stored in the context.
+		Signed bool
+	}
+
+	// signedCookieCodec is the CookieCodec returned by
+	// NewSignedCookieCodec.
+	signedCookieCodec struct {
+		keys [][]byte
+	}
+
+	// encryptedCookieCodec is the CookieCodec returned by
+	// NewEncryptedCookieCodec.
+	encryptedCookieCodec struct {
+		aead cipher.AEAD
+	}
+
+	// cookieCodecKey is the private type used to store the cookie codec in
+	// the context.
+	cookieCodecKey struct{}
+)
+
+// ErrInvalidCookie is the error returned by the codecs when a cookie value was
+// not produced by the codec.
+var ErrInvalidCookie = fmt.Errorf("invalid cookie value")
+
+// WithCookieCodec returns a handler that stores codec in the request context
+// before calling h. The generated code uses the codec to encode and decode the
+// values of the cookies defined with the CookieSigned DSL.
+func WithCookieCodec(h http.Handler, codec CookieCodec) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r.WithContext(ContextWithCookieCodec(r.Context(), codec)))
+	})
+}
+
+// ContextWithCookieCodec returns a copy of ctx that holds the given cookie
+// codec.
+func ContextWithCookieCodec(ctx context.Context, codec CookieCodec) context.Context {
+	return context.WithValue(ctx, cookieCodecKey{}, codec)
+}
+
+// CookieCodecFromContext returns the cookie codec stored in ctx if any, nil
+// otherwise.
+func CookieCodecFromContext(ctx context.Context) CookieCodec {
+	c, _ := ctx.Value(cookieCodecKey{}).(CookieCodec)
+	return c
+}
+
+// NewSignedCookieCodec returns a codec that appends a HMAC-SHA256 signature to
+// the cookie values. The values are signed with the first key and verified
+// with any of the keys so that keys can be rotated. The values are not
+// encrypted.
+func NewSignedCookieCodec(key []byte, oldKeys ...[]byte) CookieCodec {
+	return &signedCookieCodec{keys: append([][]byte{key}, oldKeys...)}
+}
+
+// NewEncryptedCookieCodec returns a codec that encrypts and authenticates the
+// cookie values with AES-GCM. The key must be 16, 24 or 32 bytes long to
+// select AES-128, AES-192 or AES-256.
+func NewEncryptedCookieCodec(key []byte) (CookieCodec, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedCookieCodec{aead: aead}, nil
+}
+
+// SetCookie adds a Set-Cookie header with the given name, value and attributes
+// to the response. It uses the codec stored in ctx to encode the value of
+// signed cookies. The SameSite and Partitioned attributes are written
+// explicitly so that all their values are supported regardless of the Go
+// version.
+func SetCookie(ctx context.Context, w http.ResponseWriter, name, value string, opts *CookieOptions) error {
+	if opts == nil {
+		opts = &CookieOptions{}
+	}
+	if opts.Signed {
+		codec := CookieCodecFromContext(ctx)
+		if codec == nil {
+			return fmt.Errorf("cannot set signed cookie %q: no cookie codec in context, use WithCookieCodec", name)
+		}
+		v, err := codec.Encode(name, value)
+		if err != nil {
+			return err
+		}
+		value = v
+	}
+	c := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     opts.Path,
+		Domain:   opts.Domain,
+		MaxAge:   opts.MaxAge,
+		Secure:   opts.Secure,
+		HttpOnly: opts.HTTPOnly,
+	}
+	s := c.String()
+	if s == "" {
+		return fmt.Errorf("invalid cookie name %q", name)
+	}
+	if opts.SameSite != "" {
+		s += "; SameSite=" + opts.SameSite
+	}
+	if opts.Partitioned {
+		s += "; Partitioned"
+	}
+	w.Header().Add("Set-Cookie", s)
+	return nil
+}
+
+// ReadCookie returns the value of the request cookie with the given name, the
+// empty string if the request has no such cookie.
+func ReadCookie(r *http.Request, name string) string {
+	c, err := r.Cookie(name)
+	if err != nil {
+		return ""
+	}
+	return c.Value
+}
+
+// ReadSignedCookie returns the value of the request cookie with the given name
+// decoded with the codec stored in the request context, the empty string if
+// the request has no such cookie. It returns an error if the cookie value was
+// not produced by the codec.
+func ReadSignedCookie(r *http.Request, name string) (string, error) {
+	c, err := r.Cookie(name)
+	if err != nil {
+		return "", nil
+	}
+	codec := CookieCodecFromContext(r.Context())
+	if codec == nil {
+		return "", fmt.Errorf("cannot read signed cookie %q: no cookie codec in context, use WithCookieCodec", name)
+	}
+	v, err := codec.Decode(name, c.Value)
+	if err != nil {
+		return "", goa.InvalidFieldTypeError(name, c.Value, "signed cookie")
+	}
+	return v, nil
+}
+
+// ResponseCookie returns the value of the cookie with the given name set by the
+// response, the empty string if the response does not set such cookie.
+func ResponseCookie(resp *http.Response, name string) string {
+	for _, c := range resp.Cookies() {
+		if c.Name == name {
+			return c.Value
+		}
+	}
+	return ""
+}
+
+// Encode appends the base64 encoded signature of the name and value to the
+// value.
+func (c *signedCookieCodec) Encode(name, value string) (string, error) {
+	return value + "." + base64.RawURLEncoding.EncodeToString(sign(c.keys[0], name, value)), nil
+}
+
+// Decode verifies the signature of the value using each key in turn.
+func (c *signedCookieCodec) Decode(name, value string) (string, error) {
+	i := strings.LastIndex(value, ".")
+	if i < 0 {
+		return "", ErrInvalidCookie
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(value[i+1:])
+	if err != nil {
+		return "", ErrInvalidCookie
+	}
+	v := value[:i]
+	for _, key := range c.keys {
+		if hmac.Equal(sig, sign(key, name, v)) {
+			return v, nil
+		}
+	}
+	return "", ErrInvalidCookie
+}
+
+// Encode encrypts the value using a random nonce and the name as additional
+// data and returns the base64 encoding of the nonce followed by the cipher
+// text.
+func (c *encryptedCookieCodec) Encode(name, value string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(value), []byte(name))
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Decode decrypts and authenticates the value.
+func (c *encryptedCookieCodec) Decode(name, value string) (string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(data) < c.aead.NonceSize() {
+		return "", ErrInvalidCookie
+	}
+	n := c.aead.NonceSize()
+	plain, err := c.aead.Open(nil, data[:n], data[n:], []byte(name))
+	if err != nil {
+		return "", ErrInvalidCookie
+	}
+	return string(plain), nil
+}
+
+// sign returns the HMAC-SHA256 signature of the cookie name and value.
+func sign(key []byte, name, value string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(name))
+	h.Write([]byte{0})
+	h.Write([]byte(value))
+	return h.Sum(nil)
+}
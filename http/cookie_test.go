@@ -0,0 +1,123 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCookieCodecs(t *testing.T) {
+	enc, err := NewEncryptedCookieCodec([]byte("0123456789abcdef"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		Name  string
+		Codec CookieCodec
+	}{
+		{"signed", NewSignedCookieCodec([]byte("secret"))},
+		{"encrypted", enc},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			v, err := c.Codec.Encode("SID", "a.b")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, err := c.Codec.Decode("SID", v); err != nil || got != "a.b" {
+				t.Errorf("got %q, %v, expected %q", got, err, "a.b")
+			}
+			if _, err := c.Codec.Decode("other", v); err != ErrInvalidCookie {
+				t.Errorf("got error %v decoding value of another cookie, expected %v", err, ErrInvalidCookie)
+			}
+			if _, err := c.Codec.Decode("SID", v[:len(v)-2]+"xx"); err != ErrInvalidCookie {
+				t.Errorf("got error %v decoding tampered value, expected %v", err, ErrInvalidCookie)
+			}
+		})
+	}
+}
+
+func TestSignedCookieCodecRotation(t *testing.T) {
+	old := NewSignedCookieCodec([]byte("old"))
+	v, _ := old.Encode("SID", "abc")
+	codec := NewSignedCookieCodec([]byte("new"), []byte("old"))
+	if got, err := codec.Decode("SID", v); err != nil || got != "abc" {
+		t.Errorf("got %q, %v, expected value signed with old key to be valid", got, err)
+	}
+	if _, err := NewSignedCookieCodec([]byte("new")).Decode("SID", v); err != ErrInvalidCookie {
+		t.Errorf("got error %v, expected value signed with unknown key to be invalid", err)
+	}
+}
+
+func TestNewEncryptedCookieCodecInvalidKey(t *testing.T) {
+	if _, err := NewEncryptedCookieCodec([]byte("short")); err == nil {
+		t.Error("expected an error for an invalid key size")
+	}
+}
+
+func TestSetCookie(t *testing.T) {
+	opts := &CookieOptions{
+		MaxAge:      3600,
+		Domain:      "goa.design",
+		Path:        "/",
+		Secure:      true,
+		HTTPOnly:    true,
+		SameSite:    "None",
+		Partitioned: true,
+	}
+	w := httptest.NewRecorder()
+	if err := SetCookie(context.Background(), w, "session", "abc", opts); err != nil {
+		t.Fatal(err)
+	}
+	expected := "session=abc; Path=/; Domain=goa.design; Max-Age=3600; HttpOnly; Secure; SameSite=None; Partitioned"
+	if got := w.Header().Get("Set-Cookie"); got != expected {
+		t.Errorf("got Set-Cookie %q, expected %q", got, expected)
+	}
+	if err := SetCookie(context.Background(), w, "bad name", "abc", nil); err == nil {
+		t.Error("expected an error for an invalid cookie name")
+	}
+}
+
+func TestSignedCookies(t *testing.T) {
+	codec := NewSignedCookieCodec([]byte("secret"))
+	if err := SetCookie(context.Background(), httptest.NewRecorder(), "SID", "abc", &CookieOptions{Signed: true}); err == nil {
+		t.Error("expected an error when setting a signed cookie without codec")
+	}
+
+	ctx := ContextWithCookieCodec(context.Background(), codec)
+	w := httptest.NewRecorder()
+	if err := SetCookie(ctx, w, "SID", "abc", &CookieOptions{Signed: true}); err != nil {
+		t.Fatal(err)
+	}
+	resp := &http.Response{Header: w.Header()}
+	signed := ResponseCookie(resp, "SID")
+	if signed == "" || signed == "abc" {
+		t.Fatalf("got cookie value %q, expected signed value", signed)
+	}
+
+	var got string
+	var err error
+	h := WithCookieCodec(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, err = ReadSignedCookie(r, "SID")
+	}), codec)
+	r := httptest.NewRequest("GET", "/", nil)
+	r.AddCookie(&http.Cookie{Name: "SID", Value: signed})
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	if err != nil || got != "abc" {
+		t.Errorf("got %q, %v, expected %q", got, err, "abc")
+	}
+
+	r = httptest.NewRequest("GET", "/", nil)
+	r.AddCookie(&http.Cookie{Name: "SID", Value: "abc.invalid"})
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	if err == nil {
+		t.Error("expected an error for a tampered cookie")
+	}
+
+	r = httptest.NewRequest("GET", "/", nil)
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	if err != nil || got != "" {
+		t.Errorf("got %q, %v, expected missing cookie to be empty", got, err)
+	}
+}
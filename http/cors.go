@@ -0,0 +1,156 @@
+package http
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type (
+	// CORSPolicy describes a Cross-Origin Resource Sharing policy. The
+	// generated server code initializes one policy per origin defined in
+	// the design for each endpoint.
+	CORSPolicy struct {
+		// Origin is the origin the policy applies to, "*" matches all
+		// origins.
+		Origin string
+		// OriginRegexp if not nil is used to match the request origin
+		// instead of Origin.
+		OriginRegexp *regexp.Regexp
+		// Methods lists the allowed HTTP methods.
+		Methods []string
+		// Headers lists the allowed request headers.
+		Headers []string
+		// Expose lists the response headers exposed to the client.
+		Expose []string
+		// MaxAge is the number of seconds preflight responses may be
+		// cached by the client, 0 means no caching header is sent.
+		MaxAge int
+		// Credentials indicates whether credentials are allowed.
+		Credentials bool
+		// PrivateNetwork indicates whether private network access is
+		// allowed.
+		PrivateNetwork bool
+	}
+)
+
+// MatchOrigin returns true if the policy applies to the given origin.
+func (p *CORSPolicy) MatchOrigin(origin string) bool {
+	if p.OriginRegexp != nil {
+		return p.OriginRegexp.MatchString(origin)
+	}
+	return p.Origin == "*" || p.Origin == origin
+}
+
+// AllowMethod returns true if the policy allows the given HTTP method.
+func (p *CORSPolicy) AllowMethod(method string) bool {
+	for _, m := range p.Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// CORSHandler returns a handler that adds the CORS response headers defined by
+// the first policy matching the request origin prior to calling h. policies
+// lists the CORS policies of the endpoint served by h.
+func CORSHandler(h http.HandlerFunc, policies []*CORSPolicy) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin != "" {
+			for _, p := range policies {
+				if p.MatchOrigin(origin) {
+					setAllowOrigin(w, p, origin)
+					if p.Origin != "*" || p.OriginRegexp != nil {
+						w.Header().Add("Vary", "Origin")
+					}
+					if len(p.Expose) > 0 {
+						w.Header().Set("Access-Control-Expose-Headers", strings.Join(p.Expose, ", "))
+					}
+					break
+				}
+			}
+		}
+		h(w, r)
+	}
+}
+
+// CORSPreflightHandler returns a handler that responds to CORS preflight
+// requests. policies lists the CORS policies of each endpoint served under the
+// request path. The handler uses the first policy that matches both the
+// request origin and the requested method. It responds with 200 OK in all
+// cases so that clients may inspect the headers to determine the outcome.
+func CORSPreflightHandler(policies ...[]*CORSPolicy) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		method := r.Header.Get("Access-Control-Request-Method")
+		w.Header().Add("Vary", "Origin")
+		w.Header().Add("Vary", "Access-Control-Request-Method")
+		w.Header().Add("Vary", "Access-Control-Request-Headers")
+		if origin == "" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		var policy *CORSPolicy
+		var methods []string
+		for _, ps := range policies {
+			for _, p := range ps {
+				if !p.MatchOrigin(origin) {
+					continue
+				}
+				methods = appendUnique(methods, p.Methods...)
+				if policy == nil && (method == "" || p.AllowMethod(method)) {
+					policy = p
+				}
+			}
+		}
+		if policy == nil {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		setAllowOrigin(w, policy, origin)
+		w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+		if len(policy.Headers) > 0 {
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(policy.Headers, ", "))
+		}
+		if policy.MaxAge > 0 {
+			w.Header().Set("Access-Control-Max-Age", strconv.Itoa(policy.MaxAge))
+		}
+		if policy.PrivateNetwork && r.Header.Get("Access-Control-Request-Private-Network") == "true" {
+			w.Header().Set("Access-Control-Allow-Private-Network", "true")
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// setAllowOrigin writes the Access-Control-Allow-Origin and
+// Access-Control-Allow-Credentials headers.
+func setAllowOrigin(w http.ResponseWriter, p *CORSPolicy, origin string) {
+	if p.Origin == "*" && p.OriginRegexp == nil {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	} else {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+	}
+	if p.Credentials {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+// appendUnique appends the values of vals not already in s to s.
+func appendUnique(s []string, vals ...string) []string {
+	for _, v := range vals {
+		found := false
+		for _, e := range s {
+			if e == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			s = append(s, v)
+		}
+	}
+	return s
+}
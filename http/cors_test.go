@@ -0,0 +1,108 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestCORSHandler(t *testing.T) {
+	var (
+		all    = &CORSPolicy{Origin: "*", Methods: []string{"GET"}}
+		exact  = &CORSPolicy{Origin: "https://goa.design", Methods: []string{"GET"}, Expose: []string{"X-Time"}, Credentials: true}
+		regex  = &CORSPolicy{Origin: "/.*localhost.*/", OriginRegexp: regexp.MustCompile(".*localhost.*"), Methods: []string{"GET"}}
+		called bool
+		h      = func(w http.ResponseWriter, r *http.Request) { called = true }
+	)
+	cases := []struct {
+		Name        string
+		Policies    []*CORSPolicy
+		Origin      string
+		AllowOrigin string
+		Expose      string
+		Credentials string
+	}{
+		{"no origin", []*CORSPolicy{all}, "", "", "", ""},
+		{"wildcard", []*CORSPolicy{all}, "https://example.com", "*", "", ""},
+		{"exact", []*CORSPolicy{exact}, "https://goa.design", "https://goa.design", "X-Time", "true"},
+		{"exact mismatch", []*CORSPolicy{exact}, "https://example.com", "", "", ""},
+		{"regexp", []*CORSPolicy{exact, regex}, "http://localhost:8080", "http://localhost:8080", "", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			called = false
+			r := httptest.NewRequest("GET", "/", nil)
+			if c.Origin != "" {
+				r.Header.Set("Origin", c.Origin)
+			}
+			w := httptest.NewRecorder()
+			CORSHandler(h, c.Policies)(w, r)
+			if !called {
+				t.Error("handler not called")
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != c.AllowOrigin {
+				t.Errorf("got allow origin %q, expected %q", got, c.AllowOrigin)
+			}
+			if got := w.Header().Get("Access-Control-Expose-Headers"); got != c.Expose {
+				t.Errorf("got expose headers %q, expected %q", got, c.Expose)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Credentials"); got != c.Credentials {
+				t.Errorf("got allow credentials %q, expected %q", got, c.Credentials)
+			}
+		})
+	}
+}
+
+func TestCORSPreflightHandler(t *testing.T) {
+	var (
+		get  = []*CORSPolicy{{Origin: "*", Methods: []string{"GET"}, MaxAge: 600}}
+		post = []*CORSPolicy{{Origin: "https://goa.design", Methods: []string{"POST"}, Headers: []string{"X-Secret"}, PrivateNetwork: true}}
+	)
+	cases := []struct {
+		Name           string
+		Origin         string
+		Method         string
+		PrivateNetwork bool
+		AllowOrigin    string
+		AllowMethods   string
+		AllowHeaders   string
+		MaxAge         string
+		AllowPrivate   string
+	}{
+		{"no origin", "", "GET", false, "", "", "", "", ""},
+		{"get", "https://example.com", "GET", false, "*", "GET", "", "600", ""},
+		{"post", "https://goa.design", "POST", false, "https://goa.design", "GET, POST", "X-Secret", "", ""},
+		{"post private network", "https://goa.design", "POST", true, "https://goa.design", "GET, POST", "X-Secret", "", "true"},
+		{"post disallowed", "https://example.com", "POST", true, "", "", "", "", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest("OPTIONS", "/", nil)
+			if c.Origin != "" {
+				r.Header.Set("Origin", c.Origin)
+			}
+			r.Header.Set("Access-Control-Request-Method", c.Method)
+			if c.PrivateNetwork {
+				r.Header.Set("Access-Control-Request-Private-Network", "true")
+			}
+			w := httptest.NewRecorder()
+			CORSPreflightHandler(get, post)(w, r)
+			if w.Code != http.StatusOK {
+				t.Errorf("got status %d, expected %d", w.Code, http.StatusOK)
+			}
+			expected := map[string]string{
+				"Access-Control-Allow-Origin":          c.AllowOrigin,
+				"Access-Control-Allow-Methods":         c.AllowMethods,
+				"Access-Control-Allow-Headers":         c.AllowHeaders,
+				"Access-Control-Max-Age":               c.MaxAge,
+				"Access-Control-Allow-Private-Network": c.AllowPrivate,
+			}
+			for h, v := range expected {
+				if got := w.Header().Get(h); got != v {
+					t.Errorf("got %s %q, expected %q", h, got, v)
+				}
+			}
+		})
+	}
+}
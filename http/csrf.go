@@ -0,0 +1,215 @@
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+const (
+	// DefaultCSRFHeader is the name of the header that carries the CSRF
+	// token by default.
+	DefaultCSRFHeader = "X-CSRF-Token"
+
+	// DefaultCSRFCookie is the name of the cookie that carries the CSRF
+	// token in the double-submit mode by default.
+	DefaultCSRFCookie = "csrf_token"
+)
+
+var (
+	// ErrCSRFTokenMissing is the error returned by CSRFProtector when an
+	// unsafe request authenticated with a cookie does not carry a CSRF
+	// token.
+	ErrCSRFTokenMissing = errors.New("CSRF token is missing")
+
+	// ErrCSRFTokenInvalid is the error returned by CSRFProtector when the
+	// CSRF token of an unsafe request does not match the expected value.
+	ErrCSRFTokenInvalid = errors.New("CSRF token is invalid")
+
+	// ErrCSRFSecretMissing is the error returned by the generated
+	// ProtectCSRF server methods when the design uses synchronizer tokens
+	// and the given secret is empty.
+	ErrCSRFSecretMissing = errors.New("CSRF synchronizer tokens require a secret")
+)
+
+type (
+	// CSRFProtector issues and verifies the tokens that protect the
+	// endpoints that authenticate requests with cookies against Cross-Site
+	// Request Forgery. Unsafe requests must send the token in a header
+	// which cannot be set by cross-site forms or by scripts running on
+	// other origins.
+	//
+	// In the double-submit mode (Secret is nil) the token is a random value
+	// stored in a cookie readable by scripts and the header value must match
+	// the cookie value. In the synchronizer mode (Secret is set) the token
+	// is derived from the value of the session cookie with HMAC-SHA256 so
+	// that no cookie other than the session cookie is needed. In both modes
+	// the token is returned in the header of the responses to safe
+	// requests.
+	CSRFProtector struct {
+		// SessionCookie is the name of the cookie that carries the
+		// request credentials. Requests without such cookie are not
+		// subject to CSRF and are not verified.
+		SessionCookie string
+		// Secret is the key used to derive the tokens from the session
+		// cookie in the synchronizer mode. The double-submit mode is
+		// used if nil.
+		Secret []byte
+		// Header is the name of the header that carries the token,
+		// DefaultCSRFHeader if empty.
+		Header string
+		// Cookie is the name of the cookie that carries the token in the
+		// double-submit mode, DefaultCSRFCookie if empty.
+		Cookie string
+		// CookieOptions lists the attributes of the double-submit
+		// cookie. The cookie uses the "/" path and the Lax SameSite
+		// attribute and is secure if the request uses TLS by default.
+		CookieOptions *CookieOptions
+	}
+
+	// csrfDoer is the Doer returned by CSRFDoer.
+	csrfDoer struct {
+		doer   Doer
+		header string
+		cookie string
+
+		mu    sync.Mutex
+		token string
+	}
+)
+
+// Issue returns the CSRF token of the request and writes it to the response
+// header. In the double-submit mode it also sets the token cookie if the
+// request does not carry it already. It returns the empty string in the
+// synchronizer mode if the request does not carry the session cookie.
+func (p *CSRFProtector) Issue(w http.ResponseWriter, r *http.Request) (string, error) {
+	var token string
+	if p.Secret != nil {
+		session := ReadCookie(r, p.SessionCookie)
+		if session == "" {
+			return "", nil
+		}
+		token = p.sessionToken(session)
+	} else {
+		token = ReadCookie(r, p.cookieName())
+		if token == "" {
+			b := make([]byte, 32)
+			if _, err := rand.Read(b); err != nil {
+				return "", err
+			}
+			token = base64.RawURLEncoding.EncodeToString(b)
+			opts := p.CookieOptions
+			if opts == nil {
+				opts = &CookieOptions{Path: "/", SameSite: "Lax", Secure: r.TLS != nil}
+			}
+			if err := SetCookie(r.Context(), w, p.cookieName(), token, opts); err != nil {
+				return "", err
+			}
+		}
+	}
+	w.Header().Set(p.headerName(), token)
+	return token, nil
+}
+
+// Verify returns ErrCSRFTokenMissing if the request carries the session cookie
+// but no CSRF token and ErrCSRFTokenInvalid if the token does not match the
+// expected value. It does not check the request method, callers only verify
+// unsafe requests.
+func (p *CSRFProtector) Verify(r *http.Request) error {
+	session := ReadCookie(r, p.SessionCookie)
+	if session == "" {
+		return nil
+	}
+	token := r.Header.Get(p.headerName())
+	if token == "" {
+		return ErrCSRFTokenMissing
+	}
+	var expected string
+	if p.Secret != nil {
+		expected = p.sessionToken(session)
+	} else {
+		expected = ReadCookie(r, p.cookieName())
+	}
+	if expected == "" || !hmac.Equal([]byte(token), []byte(expected)) {
+		return ErrCSRFTokenInvalid
+	}
+	return nil
+}
+
+// CSRFDoer returns a Doer that sends the CSRF token received in the responses
+// to safe requests with the unsafe requests that do not set it already. header
+// is the name of the header that carries the token. cookie is the name of the
+// cookie that carries the token in the double-submit mode and should be empty
+// in the synchronizer mode, the doer adds the cookie to the requests that do
+// not carry it so that the HTTP client does not need a cookie jar.
+func CSRFDoer(doer Doer, header, cookie string) Doer {
+	if header == "" {
+		header = DefaultCSRFHeader
+	}
+	return &csrfDoer{doer: doer, header: header, cookie: cookie}
+}
+
+// Do sends the CSRF token with unsafe requests and records the token returned
+// by the server.
+func (d *csrfDoer) Do(req *http.Request) (*http.Response, error) {
+	d.mu.Lock()
+	token := d.token
+	d.mu.Unlock()
+	if token != "" && !IsSafeMethod(req.Method) && req.Header.Get(d.header) == "" {
+		req.Header.Set(d.header, token)
+		if d.cookie != "" {
+			if _, err := req.Cookie(d.cookie); err != nil {
+				req.AddCookie(&http.Cookie{Name: d.cookie, Value: token})
+			}
+		}
+	}
+	resp, err := d.doer.Do(req)
+	if err != nil {
+		return resp, err
+	}
+	if t := resp.Header.Get(d.header); t != "" {
+		d.mu.Lock()
+		d.token = t
+		d.mu.Unlock()
+	}
+	return resp, nil
+}
+
+// sessionToken returns the synchronizer token derived from the given session
+// cookie value.
+func (p *CSRFProtector) sessionToken(session string) string {
+	mac := hmac.New(sha256.New, p.Secret)
+	mac.Write([]byte("goa-csrf\n" + session))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// headerName returns the name of the header that carries the token.
+func (p *CSRFProtector) headerName() string {
+	if p.Header == "" {
+		return DefaultCSRFHeader
+	}
+	return p.Header
+}
+
+// cookieName returns the name of the double-submit cookie.
+func (p *CSRFProtector) cookieName() string {
+	if p.Cookie == "" {
+		return DefaultCSRFCookie
+	}
+	return p.Cookie
+}
+
+// IsSafeMethod returns true if the HTTP method is safe as defined by RFC 7231
+// section 4.2.1. CSRF tokens are issued in the responses to safe requests and
+// verified for the others.
+func IsSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
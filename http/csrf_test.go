@@ -0,0 +1,135 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCSRFProtector(t *testing.T) {
+	var (
+		secret  = []byte("secret")
+		session = &http.Cookie{Name: "SID", Value: "session"}
+	)
+	issue := func(p *CSRFProtector, cookies ...*http.Cookie) (string, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest("GET", "/", nil)
+		for _, c := range cookies {
+			req.AddCookie(c)
+		}
+		w := httptest.NewRecorder()
+		token, err := p.Issue(w, req)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return token, w
+	}
+
+	t.Run("double-submit", func(t *testing.T) {
+		p := &CSRFProtector{SessionCookie: "SID"}
+		token, w := issue(p, session)
+		if token == "" {
+			t.Fatal("got empty token")
+		}
+		if got := w.Header().Get(DefaultCSRFHeader); got != token {
+			t.Errorf("got header %q, expected %q", got, token)
+		}
+		setCookie := w.Header().Get("Set-Cookie")
+		if !strings.HasPrefix(setCookie, DefaultCSRFCookie+"="+token) || !strings.Contains(setCookie, "SameSite=Lax") {
+			t.Errorf("got Set-Cookie %q", setCookie)
+		}
+		again, w := issue(p, session, &http.Cookie{Name: DefaultCSRFCookie, Value: token})
+		if again != token {
+			t.Errorf("got token %q, expected existing token %q", again, token)
+		}
+		if sc := w.Header().Get("Set-Cookie"); sc != "" {
+			t.Errorf("got Set-Cookie %q, expected none", sc)
+		}
+	})
+
+	t.Run("synchronizer", func(t *testing.T) {
+		p := &CSRFProtector{SessionCookie: "SID", Secret: secret}
+		token, w := issue(p, session)
+		if token == "" {
+			t.Fatal("got empty token")
+		}
+		if sc := w.Header().Get("Set-Cookie"); sc != "" {
+			t.Errorf("got Set-Cookie %q, expected none", sc)
+		}
+		other, _ := issue(p, &http.Cookie{Name: "SID", Value: "other"})
+		if other == token {
+			t.Error("got same token for different sessions")
+		}
+		none, _ := issue(p)
+		if none != "" {
+			t.Errorf("got token %q without session, expected none", none)
+		}
+	})
+
+	cases := []struct {
+		Name      string
+		Protector *CSRFProtector
+		Cookies   []*http.Cookie
+		Token     string
+		Error     error
+	}{
+		{"no session", &CSRFProtector{SessionCookie: "SID"}, nil, "", nil},
+		{"double-submit valid", &CSRFProtector{SessionCookie: "SID"}, []*http.Cookie{session, {Name: DefaultCSRFCookie, Value: "token"}}, "token", nil},
+		{"double-submit missing", &CSRFProtector{SessionCookie: "SID"}, []*http.Cookie{session, {Name: DefaultCSRFCookie, Value: "token"}}, "", ErrCSRFTokenMissing},
+		{"double-submit no cookie", &CSRFProtector{SessionCookie: "SID"}, []*http.Cookie{session}, "token", ErrCSRFTokenInvalid},
+		{"double-submit mismatch", &CSRFProtector{SessionCookie: "SID"}, []*http.Cookie{session, {Name: DefaultCSRFCookie, Value: "token"}}, "other", ErrCSRFTokenInvalid},
+		{"synchronizer valid", &CSRFProtector{SessionCookie: "SID", Secret: secret}, []*http.Cookie{session}, (&CSRFProtector{Secret: secret}).sessionToken("session"), nil},
+		{"synchronizer other session", &CSRFProtector{SessionCookie: "SID", Secret: secret}, []*http.Cookie{session}, (&CSRFProtector{Secret: secret}).sessionToken("other"), ErrCSRFTokenInvalid},
+		{"custom header", &CSRFProtector{SessionCookie: "SID", Secret: secret, Header: "X-XSRF-Token"}, []*http.Cookie{session}, "", ErrCSRFTokenMissing},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/", nil)
+			for _, ck := range c.Cookies {
+				req.AddCookie(ck)
+			}
+			if c.Token != "" {
+				req.Header.Set(DefaultCSRFHeader, c.Token)
+			}
+			if err := c.Protector.Verify(req); err != c.Error {
+				t.Errorf("got error %v, expected %v", err, c.Error)
+			}
+		})
+	}
+}
+
+func TestCSRFDoer(t *testing.T) {
+	p := &CSRFProtector{SessionCookie: "SID"}
+	var err error
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if IsSafeMethod(r.Method) {
+			_, err = p.Issue(w, r)
+			return
+		}
+		err = p.Verify(r)
+	}))
+	defer srv.Close()
+
+	doer := CSRFDoer(http.DefaultClient, "", DefaultCSRFCookie)
+	do := func(method string) {
+		req, _ := http.NewRequest(method, srv.URL, nil)
+		req.AddCookie(&http.Cookie{Name: "SID", Value: "session"})
+		resp, derr := doer.Do(req)
+		if derr != nil {
+			t.Fatalf("unexpected error: %s", derr)
+		}
+		resp.Body.Close()
+	}
+	do("POST")
+	if err != ErrCSRFTokenMissing {
+		t.Errorf("got error %v before token issuance, expected %v", err, ErrCSRFTokenMissing)
+	}
+	do("GET")
+	if err != nil {
+		t.Fatalf("unexpected issue error: %s", err)
+	}
+	do("POST")
+	if err != nil {
+		t.Errorf("got error %v, expected none", err)
+	}
+}
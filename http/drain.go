@@ -0,0 +1,48 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// DrainStreams returns a HTTP middleware that registers the websocket
+// connections with d so that the server waits for the streams to complete when
+// shutting down, see goa.Drainer. The context of the streams is canceled if
+// they do not complete within the grace period. New streams are rejected with
+// a 503 Service Unavailable response once draining has started.
+func DrainStreams(d *goa.Drainer) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+				h.ServeHTTP(w, r)
+				return
+			}
+			if !d.Ready() {
+				w.Header().Set("Connection", "close")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			ctx, done := d.Track(r.Context())
+			defer done()
+			h.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// ReadyHandler returns a handler that responds with 200 OK while d is ready
+// and with 503 Service Unavailable once draining has started. The generated
+// example servers mount it on "/readyz".
+func ReadyHandler(d *goa.Drainer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if !d.Ready() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("draining\n")) // nolint: errcheck
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok\n")) // nolint: errcheck
+	}
+}
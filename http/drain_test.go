@@ -0,0 +1,57 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestDrainStreams(t *testing.T) {
+	d := goa.NewDrainer(time.Second)
+	var streamCtx context.Context
+	h := DrainStreams(d)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		streamCtx = r.Context()
+	}))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Upgrade", "websocket")
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusOK)
+	}
+	if streamCtx == nil || streamCtx.Err() != context.Canceled {
+		t.Errorf("got stream context %v, expected tracked and released", streamCtx)
+	}
+
+	d.Drain()
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("got status %d for plain request, expected %d", w.Code, http.StatusOK)
+	}
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("got status %d for stream while draining, expected %d", w.Code, http.StatusServiceUnavailable)
+	}
+}
+
+func TestReadyHandler(t *testing.T) {
+	d := goa.NewDrainer(time.Second)
+	w := httptest.NewRecorder()
+	ReadyHandler(d)(w, httptest.NewRequest("GET", "/readyz", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusOK)
+	}
+	d.Drain()
+	w = httptest.NewRecorder()
+	ReadyHandler(d)(w, httptest.NewRequest("GET", "/readyz", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusServiceUnavailable)
+	}
+}
@@ -4,14 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/gob"
-	"encoding/json"
-	"encoding/xml"
+		"encoding/xml"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"mime"
 	"net/http"
 	"strings"
+
+	goa "goa.design/goa/v3/pkg"
 )
 
 const (
@@ -51,9 +52,10 @@ type (
 // RequestDecoder returns a HTTP request body decoder suitable for the given
 // request. The decoder handles the following mime types:
 //
-//     * application/json using package encoding/json
+//     * application/json using the JSON codec, see SetJSONCodec
 //     * application/xml using package encoding/xml
 //     * application/gob using package encoding/gob
+//     * application/x-www-form-urlencoded using NewFormDecoder
 //
 // RequestDecoder defaults to the JSON decoder if the request "Content-Type"
 // header does not match any of the supported mime type or is missing
@@ -71,15 +73,17 @@ func RequestDecoder(r *http.Request) Decoder {
 	}
 	switch contentType {
 	case "application/json":
-		return json.NewDecoder(r.Body)
+		return JSON().NewDecoder(r.Body)
 	case "application/gob":
 		return gob.NewDecoder(r.Body)
 	case "application/xml":
 		return xml.NewDecoder(r.Body)
+	case FormContentType:
+		return NewFormDecoder(r.Body)
 	case "text/html", "text/plain":
 		return newTextDecoder(r.Body, contentType)
 	default:
-		return json.NewDecoder(r.Body)
+		return JSON().NewDecoder(r.Body)
 	}
 }
 
@@ -87,7 +91,7 @@ func RequestDecoder(r *http.Request) Decoder {
 // set in the context under the AcceptTypeKey or the ContentTypeKey if any.
 // The encoder supports the following mime types:
 //
-//     * application/json using package encoding/json
+//     * application/json using the JSON codec, see SetJSONCodec
 //     * application/xml using package encoding/xml
 //     * application/gob using package encoding/gob
 //     * text/html and text/plain for strings
@@ -96,11 +100,17 @@ func RequestDecoder(r *http.Request) Decoder {
 // ContentTypeKey value does not match any of the supported mime types or is
 // missing altogether.
 func ResponseEncoder(ctx context.Context, w http.ResponseWriter) Encoder {
+	return responseEncoder(ctx, w, newJSONEncoder)
+}
+
+// responseEncoder implements ResponseEncoder using newJSON to create the JSON
+// encoders.
+func responseEncoder(ctx context.Context, w http.ResponseWriter, newJSON func(io.Writer) Encoder) Encoder {
 	negotiate := func(a string) (Encoder, string) {
 		switch a {
 		case "", "application/json":
 			// default to JSON
-			return json.NewEncoder(w), "application/json"
+			return newJSON(w), "application/json"
 		case "application/xml":
 			return xml.NewEncoder(w), "application/xml"
 		case "application/gob":
@@ -134,7 +144,7 @@ func ResponseEncoder(ctx context.Context, w http.ResponseWriter) Encoder {
 			if mt, _, err = mime.ParseMediaType(ct); err == nil {
 				switch {
 				case ct == "application/json" || strings.HasSuffix(ct, "+json"):
-					enc = json.NewEncoder(w)
+					enc = newJSON(w)
 				case ct == "application/xml" || strings.HasSuffix(ct, "+xml"):
 					enc = xml.NewEncoder(w)
 				case ct == "application/gob" || strings.HasSuffix(ct, "+gob"):
@@ -143,7 +153,7 @@ func ResponseEncoder(ctx context.Context, w http.ResponseWriter) Encoder {
 					strings.HasSuffix(ct, "+html") || strings.HasSuffix(ct, "+txt"):
 					enc = newTextEncoder(w, ct)
 				default:
-					enc = json.NewEncoder(w)
+					enc = newJSON(w)
 				}
 			}
 			SetContentType(w, mt)
@@ -166,17 +176,17 @@ func ResponseEncoder(ctx context.Context, w http.ResponseWriter) Encoder {
 }
 
 // RequestEncoder returns a HTTP request encoder.
-// The encoder uses package encoding/json.
+// The encoder uses the JSON codec, see SetJSONCodec.
 func RequestEncoder(r *http.Request) Encoder {
 	var buf bytes.Buffer
 	r.Body = ioutil.NopCloser(&buf)
-	return json.NewEncoder(&buf)
+	return JSON().NewEncoder(&buf)
 }
 
 // ResponseDecoder returns a HTTP response decoder.
 // The decoder handles the following content types:
 //
-//   * application/json using package encoding/json (default)
+//   * application/json using the JSON codec, see SetJSONCodec (default)
 //   * application/xml using package encoding/xml
 //   * application/gob using package encoding/gob
 //   * text/html and text/plain for strings
@@ -184,14 +194,14 @@ func RequestEncoder(r *http.Request) Encoder {
 func ResponseDecoder(resp *http.Response) Decoder {
 	ct := resp.Header.Get("Content-Type")
 	if ct == "" {
-		return json.NewDecoder(resp.Body)
+		return JSON().NewDecoder(resp.Body)
 	}
 	if mediaType, _, err := mime.ParseMediaType(ct); err == nil {
 		ct = mediaType
 	}
 	switch {
 	case ct == "application/json" || strings.HasSuffix(ct, "+json"):
-		return json.NewDecoder(resp.Body)
+		return JSON().NewDecoder(resp.Body)
 	case ct == "application/xml" || strings.HasSuffix(ct, "+xml"):
 		return xml.NewDecoder(resp.Body)
 	case ct == "application/gob" || strings.HasSuffix(ct, "+gob"):
@@ -200,7 +210,7 @@ func ResponseDecoder(resp *http.Response) Decoder {
 		strings.HasSuffix(ct, "+html") || strings.HasSuffix(ct, "+txt"):
 		return newTextDecoder(resp.Body, ct)
 	default:
-		return json.NewDecoder(resp.Body)
+		return JSON().NewDecoder(resp.Body)
 	}
 }
 
@@ -213,8 +223,13 @@ func ResponseDecoder(resp *http.Response) Decoder {
 func ErrorEncoder(encoder func(context.Context, http.ResponseWriter) Encoder) func(context.Context, http.ResponseWriter, error) error {
 	return func(ctx context.Context, w http.ResponseWriter, err error) error {
 		enc := encoder(ctx, w)
-		resp := NewErrorResponse(err)
-		w.WriteHeader(resp.StatusCode())
+		resp := NewErrorResponse(goa.LocalizeError(ctx, err))
+		status := writeAuthChallenges(w, err)
+		if status == 0 {
+			status = resp.StatusCode()
+		}
+		writeRetryAfter(w, err)
+		w.WriteHeader(status)
 		return enc.Encode(resp)
 	}
 }
@@ -251,6 +266,11 @@ func SetContentType(w http.ResponseWriter, ct string) {
 	w.Header().Set("Content-Type", h+suffix)
 }
 
+// newJSONEncoder returns a JSON encoder writing to w.
+func newJSONEncoder(w io.Writer) Encoder {
+	return JSON().NewEncoder(w)
+}
+
 func newTextEncoder(w io.Writer, ct string) Encoder {
 	return &textEncoder{w, ct}
 }
@@ -2,8 +2,11 @@ package http
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	goa "goa.design/goa/v3/pkg"
+	"goa.design/goa/v3/security"
 )
 
 type (
@@ -23,24 +26,66 @@ type (
 		Timeout bool `json:"timeout" xml:"timeout" form:"timeout"`
 		// Fault indicates whether the error is a server-side fault.
 		Fault bool `json:"fault" xml:"fault" form:"fault"`
+		// Violations lists the validation rules violated by the request
+		// when the error is a validation error.
+		Violations []*ErrorViolation `json:"violations,omitempty" xml:"violation,omitempty" form:"violations,omitempty"`
+	}
+
+	// ErrorViolation describes a validation rule violated by a value of a
+	// request, see goa.Violation.
+	ErrorViolation struct {
+		// Pointer is the JSON Pointer to the invalid value, e.g.
+		// "/items/*/name" for a body field or "/X-Request-Id" for a
+		// header.
+		Pointer string `json:"pointer" xml:"pointer" form:"pointer"`
+		// Rule is the name of the violated rule.
+		Rule string `json:"rule" xml:"rule" form:"rule"`
+		// Constraint is the value of the constraint defined by the rule.
+		Constraint interface{} `json:"constraint,omitempty" xml:"constraint,omitempty" form:"constraint,omitempty"`
+		// Message describes the violation.
+		Message string `json:"message" xml:"message" form:"message"`
 	}
 )
 
 // NewErrorResponse creates a HTTP response from the given error.
 func NewErrorResponse(err error) *ErrorResponse {
+	if aerr, ok := err.(*security.AuthError); ok {
+		err = aerr.ServiceError()
+	}
 	if gerr, ok := err.(*goa.ServiceError); ok {
 		return &ErrorResponse{
-			Name:      gerr.Name,
-			ID:        gerr.ID,
-			Message:   gerr.Message,
-			Timeout:   gerr.Timeout,
-			Temporary: gerr.Temporary,
-			Fault:     gerr.Fault,
+			Name:       gerr.Name,
+			ID:         gerr.ID,
+			Message:    gerr.Message,
+			Timeout:    gerr.Timeout,
+			Temporary:  gerr.Temporary,
+			Fault:      gerr.Fault,
+			Violations: NewErrorViolations(gerr),
 		}
 	}
 	return NewErrorResponse(goa.Fault(err.Error()))
 }
 
+// NewErrorViolations returns the violations of the validation rules that
+// caused err with the paths to the invalid values expressed as JSON Pointers,
+// see goa.JSONPointer. It returns nil if err is not a validation error.
+func NewErrorViolations(err *goa.ServiceError) []*ErrorViolation {
+	vs := err.Violations()
+	if len(vs) == 0 {
+		return nil
+	}
+	res := make([]*ErrorViolation, len(vs))
+	for i, v := range vs {
+		res[i] = &ErrorViolation{
+			Pointer:    goa.JSONPointer(v.Field),
+			Rule:       v.Rule,
+			Constraint: v.Constraint,
+			Message:    v.Message,
+		}
+	}
+	return res
+}
+
 // StatusCode implements a heuristic that computes a HTTP response status code
 // appropriate for the timeout, temporary and fault characteristics of the
 // error. This method is used by the generated server code when the error is not
@@ -60,3 +105,15 @@ func (resp *ErrorResponse) StatusCode() int {
 	}
 	return http.StatusBadRequest
 }
+
+// writeRetryAfter sets the Retry-After header of w if err is a goa
+// ServiceError that specifies when the clients may retry the request, see
+// goa.OverloadedError. The delay is rounded up to the second.
+func writeRetryAfter(w http.ResponseWriter, err error) {
+	gerr, ok := err.(*goa.ServiceError)
+	if !ok || gerr.RetryAfter() <= 0 {
+		return
+	}
+	secs := (gerr.RetryAfter() + time.Second - 1) / time.Second
+	w.Header().Set("Retry-After", strconv.Itoa(int(secs)))
+}
@@ -0,0 +1,38 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestErrorEncoderRetryAfter(t *testing.T) {
+	cases := []struct {
+		Name       string
+		Error      error
+		Status     int
+		RetryAfter string
+	}{
+		{"overloaded", goa.OverloadedError(1500 * time.Millisecond), http.StatusServiceUnavailable, "2"},
+		{"temporary", goa.TemporaryError("unavailable", "try again"), http.StatusServiceUnavailable, ""},
+		{"fault", goa.Fault("oops"), http.StatusInternalServerError, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			if err := ErrorEncoder(ResponseEncoder)(context.Background(), w, c.Error); err != nil {
+				t.Fatal(err)
+			}
+			if w.Code != c.Status {
+				t.Errorf("got status %d, expected %d", w.Code, c.Status)
+			}
+			if ra := w.Header().Get("Retry-After"); ra != c.RetryAfter {
+				t.Errorf("got Retry-After %q, expected %q", ra, c.RetryAfter)
+			}
+		})
+	}
+}
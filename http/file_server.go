@@ -0,0 +1,181 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// FileServerConfig configures the handlers returned by NewFileServer.
+	FileServerConfig struct {
+		// FilePath is the path of the file or directory served. It is
+		// relative to the root of the file system given to
+		// NewFileServer if any, a path on disk otherwise.
+		FilePath string
+		// IsDir is true if FilePath is a directory, in which case the
+		// request path minus the matching prefix in Prefixes identifies
+		// the file served.
+		IsDir bool
+		// Prefixes lists the request path prefixes of directory file
+		// servers.
+		Prefixes []string
+		// Fallback is the path relative to FilePath of the file served
+		// when the requested file does not exist, typically
+		// "index.html" for single page applications. Requests for
+		// missing files result in 404 responses if empty.
+		Fallback string
+		// CacheControl is the value of the Cache-Control header of the
+		// responses. The fallback file is served with "no-cache" when
+		// set so that clients pick up new versions of the application.
+		CacheControl string
+		// ETag enables the generation of ETag headers computed from the
+		// content of the files.
+		ETag bool
+	}
+
+	// fileServer is the handler returned by NewFileServer.
+	fileServer struct {
+		fs  http.FileSystem
+		cfg *FileServerConfig
+		// etags caches the ETags computed for each file.
+		etags sync.Map
+	}
+
+	// etagEntry is the ETag computed for a given version of a file.
+	etagEntry struct {
+		modTime time.Time
+		size    int64
+		etag    string
+	}
+
+	// diskFileSystem is the file system used to serve files from disk.
+	diskFileSystem struct{}
+)
+
+// NewFileServer returns a handler that serves the files described by cfg from
+// fs. The files are read from disk if fs is nil. fs is typically the result of
+// calling http.FS with an embed.FS when the files are embedded in the binary.
+//
+// Requests made to a directory are served the "index.html" file the directory
+// contains if any, directory listings are never served. Range and conditional
+// requests are handled by http.ServeContent.
+func NewFileServer(fs http.FileSystem, cfg *FileServerConfig) http.Handler {
+	if fs == nil {
+		fs = diskFileSystem{}
+	}
+	return &fileServer{fs: fs, cfg: cfg}
+}
+
+// ServeHTTP serves the file that corresponds to the request path.
+func (s *fileServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := s.cfg.FilePath
+	if s.cfg.IsDir {
+		upath := path.Clean("/" + r.URL.Path)
+		rpath, plen := upath, -1
+		for _, p := range s.cfg.Prefixes {
+			if len(p) > plen && strings.HasPrefix(upath, p) {
+				rpath, plen = upath[len(p):], len(p)
+			}
+		}
+		name = path.Join(s.cfg.FilePath, rpath)
+	}
+	f, d, err := s.open(name)
+	fallback := false
+	if os.IsNotExist(err) && s.cfg.Fallback != "" {
+		fallback = true
+		name = path.Join(s.cfg.FilePath, s.cfg.Fallback)
+		f, d, err = s.open(name)
+	}
+	if err != nil {
+		switch {
+		case os.IsNotExist(err):
+			http.Error(w, "404 page not found", http.StatusNotFound)
+		case os.IsPermission(err):
+			http.Error(w, "403 Forbidden", http.StatusForbidden)
+		default:
+			http.Error(w, "500 Internal Server Error", http.StatusInternalServerError)
+		}
+		return
+	}
+	defer f.Close()
+	if s.cfg.CacheControl != "" {
+		if fallback {
+			w.Header().Set("Cache-Control", "no-cache")
+		} else {
+			w.Header().Set("Cache-Control", s.cfg.CacheControl)
+		}
+	}
+	if s.cfg.ETag {
+		etag, err := s.etag(name, d, f)
+		if err != nil {
+			http.Error(w, "500 Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("ETag", etag)
+	}
+	http.ServeContent(w, r, d.Name(), d.ModTime(), f)
+}
+
+// open opens the file with the given name. It opens the "index.html" file if
+// name is a directory.
+func (s *fileServer) open(name string) (http.File, os.FileInfo, error) {
+	f, err := s.fs.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	d, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	if !d.IsDir() {
+		return f, d, nil
+	}
+	f.Close()
+	f, err = s.fs.Open(path.Join(name, "index.html"))
+	if err != nil {
+		return nil, nil, err
+	}
+	if d, err = f.Stat(); err != nil || d.IsDir() {
+		f.Close()
+		if err == nil {
+			err = os.ErrNotExist
+		}
+		return nil, nil, err
+	}
+	return f, d, nil
+}
+
+// etag returns the ETag of the file, it computes it from the file content if
+// the file changed since the last computation.
+func (s *fileServer) etag(name string, d os.FileInfo, f http.File) (string, error) {
+	if v, ok := s.etags.Load(name); ok {
+		e := v.(*etagEntry)
+		if e.size == d.Size() && e.modTime.Equal(d.ModTime()) {
+			return e.etag, nil
+		}
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	etag := `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+	s.etags.Store(name, &etagEntry{modTime: d.ModTime(), size: d.Size(), etag: etag})
+	return etag, nil
+}
+
+// Open opens the file on disk.
+func (diskFileSystem) Open(name string) (http.File, error) {
+	return os.Open(filepath.FromSlash(name))
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileServer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goa-file-server")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"app/index.html":      "app",
+		"app/js/main.js":      "main",
+		"app/docs/index.html": "docs",
+		"favicon.ico":         "icon",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	appDir := &FileServerConfig{FilePath: filepath.ToSlash(filepath.Join(dir, "app")), IsDir: true, Prefixes: []string{"/app"}}
+	spa := &FileServerConfig{FilePath: "app", IsDir: true, Prefixes: []string{"/", "/app"}, Fallback: "index.html", CacheControl: "max-age=60"}
+	icon := &FileServerConfig{FilePath: "favicon.ico", CacheControl: "public, max-age=31536000, immutable"}
+	cases := []struct {
+		Name         string
+		FS           http.FileSystem
+		Config       *FileServerConfig
+		Path         string
+		Status       int
+		Body         string
+		CacheControl string
+	}{
+		{"disk", nil, appDir, "/app/js/main.js", http.StatusOK, "main", ""},
+		{"disk-index", nil, appDir, "/app/docs/", http.StatusOK, "docs", ""},
+		{"disk-not-found", nil, appDir, "/app/js/missing.js", http.StatusNotFound, "404 page not found\n", ""},
+		{"disk-no-listing", nil, appDir, "/app/js", http.StatusNotFound, "404 page not found\n", ""},
+		{"disk-escape", nil, appDir, "/app/../favicon.ico", http.StatusNotFound, "404 page not found\n", ""},
+		{"fs", http.Dir(dir), spa, "/app/js/main.js", http.StatusOK, "main", "max-age=60"},
+		{"fs-fallback", http.Dir(dir), spa, "/app/users/42", http.StatusOK, "app", "no-cache"},
+		{"fs-file", http.Dir(dir), icon, "/favicon.ico", http.StatusOK, "icon", "public, max-age=31536000, immutable"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			NewFileServer(c.FS, c.Config).ServeHTTP(w, httptest.NewRequest("GET", c.Path, nil))
+			if w.Code != c.Status {
+				t.Errorf("got status %d, expected %d", w.Code, c.Status)
+			}
+			if got := w.Body.String(); got != c.Body {
+				t.Errorf("got body %q, expected %q", got, c.Body)
+			}
+			if got := w.Header().Get("Cache-Control"); got != c.CacheControl {
+				t.Errorf("got Cache-Control %q, expected %q", got, c.CacheControl)
+			}
+		})
+	}
+}
+
+func TestFileServerETag(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goa-file-server")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	p := filepath.Join(dir, "main.js")
+	if err := ioutil.WriteFile(p, []byte("main"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	h := NewFileServer(http.Dir(dir), &FileServerConfig{FilePath: "main.js", ETag: true})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/main.js", nil))
+	etag := w.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("missing ETag header")
+	}
+
+	r := httptest.NewRequest("GET", "/main.js", nil)
+	r.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusNotModified)
+	}
+
+	if err := ioutil.WriteFile(p, []byte("main v2"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Errorf("got status %d after file change, expected %d", w.Code, http.StatusOK)
+	}
+	if w.Header().Get("ETag") == etag {
+		t.Errorf("ETag did not change after file change")
+	}
+	if got := w.Body.String(); got != "main v2" {
+		t.Errorf("got body %q, expected %q", got, "main v2")
+	}
+}
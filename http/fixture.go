@@ -0,0 +1,85 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+)
+
+// ReadFixtureRequest reads the raw HTTP/1.1 request stored in the file at the
+// given path, e.g. a fixture generated from the design examples. The request
+// may be given to a HTTP handler.
+func ReadFixtureRequest(path string) (*http.Request, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.ReadRequest(bufio.NewReader(bytes.NewReader(b)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid request fixture %q: %s", path, err)
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request fixture %q: %s", path, err)
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return req, nil
+}
+
+// ReadFixtureResponse reads the raw HTTP/1.1 response stored in the file at
+// the given path, e.g. a fixture generated from the design examples. The
+// response may be given to a client response decoder.
+func ReadFixtureResponse(path string) (*http.Response, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(b)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid response fixture %q: %s", path, err)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid response fixture %q: %s", path, err)
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// ReplayFixture sends the request stored in the file at reqPath to h and
+// returns an error if the status code or the media type of the response
+// differ from the ones of the response stored in the file at respPath. It
+// makes it possible to verify that a server still accepts the requests
+// recorded by a previous release.
+func ReplayFixture(h http.Handler, reqPath, respPath string) error {
+	req, err := ReadFixtureRequest(reqPath)
+	if err != nil {
+		return err
+	}
+	expected, err := ReadFixtureResponse(respPath)
+	if err != nil {
+		return err
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != expected.StatusCode {
+		return fmt.Errorf("%s %s: got status code %d, expected %d: %s", req.Method, req.URL.RequestURI(), w.Code, expected.StatusCode, w.Body.String())
+	}
+	if got, exp := mediaType(w.Header().Get("Content-Type")), mediaType(expected.Header.Get("Content-Type")); got != exp {
+		return fmt.Errorf("%s %s: got content type %q, expected %q", req.Method, req.URL.RequestURI(), got, exp)
+	}
+	return nil
+}
+
+// mediaType returns the media type of the given content type without its
+// parameters.
+func mediaType(ct string) string {
+	if mt, _, err := mime.ParseMediaType(ct); err == nil {
+		return mt
+	}
+	return ct
+}
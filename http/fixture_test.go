@@ -0,0 +1,95 @@
+package http
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	fixtureRequest = `POST /accounts?tenant=a HTTP/1.1
+Host: localhost
+Content-Type: application/json
+Content-Length: 16
+
+{"name": "goa"}
+`
+
+	fixtureResponse = `HTTP/1.1 201 Created
+Content-Type: application/json
+Content-Length: 10
+
+{"id": 1}
+`
+)
+
+func TestReplayFixture(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fixtures")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	reqPath := filepath.Join(dir, "create.request.http")
+	respPath := filepath.Join(dir, "create.response.http")
+	if err := ioutil.WriteFile(reqPath, []byte(fixtureRequest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(respPath, []byte(fixtureResponse), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := ReadFixtureRequest(reqPath)
+	if err != nil {
+		t.Fatalf("unexpected error reading the request: %s", err)
+	}
+	body, _ := ioutil.ReadAll(req.Body)
+	if req.Method != "POST" || req.URL.Path != "/accounts" || req.URL.Query().Get("tenant") != "a" || string(body) != `{"name": "goa"}`+"\n" {
+		t.Errorf("got request %s %s with body %q", req.Method, req.URL, string(body))
+	}
+	resp, err := ReadFixtureResponse(respPath)
+	if err != nil {
+		t.Fatalf("unexpected error reading the response: %s", err)
+	}
+	body, _ = ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusCreated || string(body) != `{"id": 1}`+"\n" {
+		t.Errorf("got response %d with body %q", resp.StatusCode, string(body))
+	}
+
+	cases := []struct {
+		Name   string
+		Status int
+		Type   string
+		Error  string
+	}{
+		{"match", http.StatusCreated, "application/json; charset=utf-8", ""},
+		{"status mismatch", http.StatusBadRequest, "application/json", "got status code 400, expected 201"},
+		{"content type mismatch", http.StatusCreated, "text/plain", `got content type "text/plain", expected "application/json"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", c.Type)
+				w.WriteHeader(c.Status)
+			})
+			err := ReplayFixture(h, reqPath, respPath)
+			if c.Error == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), c.Error) {
+				t.Errorf("got error %v, expected %q", err, c.Error)
+			}
+		})
+	}
+}
+
+func TestReadFixtureRequestInvalid(t *testing.T) {
+	if _, err := ReadFixtureRequest(filepath.Join("testdata", "missing.http")); err == nil {
+		t.Error("expected an error reading a missing fixture")
+	}
+}
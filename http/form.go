@@ -0,0 +1,456 @@
+package http
+
+import (
+	"bytes"
+	"encoding"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type (
+	// formEncoder encodes values using the
+	// "application/x-www-form-urlencoded" encoding.
+	formEncoder struct {
+		w io.Writer
+	}
+
+	// formDecoder decodes "application/x-www-form-urlencoded" content.
+	formDecoder struct {
+		r io.Reader
+	}
+
+	// formNode is a node of the tree built from the form keys, each
+	// bracket in a key introduces a new level.
+	formNode struct {
+		values   []string
+		children map[string]*formNode
+	}
+)
+
+// FormContentType is the content type of form-encoded request bodies.
+const FormContentType = "application/x-www-form-urlencoded"
+
+// NewFormEncoder returns an encoder that writes values using the
+// "application/x-www-form-urlencoded" encoding. The encoder accepts structs
+// and maps. Struct fields are named using their "form" tag or their "json"
+// tag if there is no "form" tag. Nested structs and maps use the bracket
+// syntax (e.g. "address[city]=Paris"), arrays of primitive values repeat the
+// key and arrays of structs or maps use indices (e.g. "items[0][id]=1").
+func NewFormEncoder(w io.Writer) Encoder {
+	return &formEncoder{w}
+}
+
+// NewFormDecoder returns a decoder that reads
+// "application/x-www-form-urlencoded" content into structs or maps. The
+// decoder understands the syntax produced by the encoder returned by
+// NewFormEncoder. It also accepts empty brackets to denote array values (e.g.
+// "tags[]=a&tags[]=b").
+func NewFormDecoder(r io.Reader) Decoder {
+	return &formDecoder{r}
+}
+
+// FormRequestEncoder returns a HTTP request encoder that writes the request
+// body using the "application/x-www-form-urlencoded" encoding and sets the
+// request Content-Type header accordingly. It is used by the generated client
+// code for endpoints that use FormEncodedRequest.
+func FormRequestEncoder(r *http.Request) Encoder {
+	var buf bytes.Buffer
+	r.Body = ioutil.NopCloser(&buf)
+	r.Header.Set("Content-Type", FormContentType)
+	return NewFormEncoder(&buf)
+}
+
+// FormRequestDecoder returns a HTTP request body decoder for
+// "application/x-www-form-urlencoded" content. It is used by the generated
+// server code for endpoints that use FormEncodedRequest.
+func FormRequestDecoder(r *http.Request) Decoder {
+	return NewFormDecoder(r.Body)
+}
+
+// Encode encodes v and writes the result to the underlying writer.
+func (e *formEncoder) Encode(v interface{}) error {
+	rv := indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct && rv.Kind() != reflect.Map {
+		return fmt.Errorf("can't encode %T as %s", v, FormContentType)
+	}
+	vals := make(url.Values)
+	if err := encodeForm("", rv, vals); err != nil {
+		return err
+	}
+	_, err := io.WriteString(e.w, vals.Encode())
+	return err
+}
+
+// Decode reads the form content and stores the result in v which must be a
+// pointer to a struct or a map.
+func (d *formDecoder) Decode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("can't decode %s into %T", FormContentType, v)
+	}
+	b, err := ioutil.ReadAll(d.r)
+	if err != nil {
+		return err
+	}
+	vals, err := url.ParseQuery(string(b))
+	if err != nil {
+		return err
+	}
+	root := &formNode{}
+	for k, vs := range vals {
+		node := root
+		for _, seg := range formKeySegments(k) {
+			node = node.child(seg)
+		}
+		node.values = append(node.values, vs...)
+	}
+	return decodeForm("", root, rv)
+}
+
+// encodeForm encodes v into vals using key as prefix.
+func encodeForm(key string, v reflect.Value, vals url.Values) error {
+	v = indirect(v)
+	if !v.IsValid() {
+		return nil
+	}
+	if s, ok, err := formatFormValue(v); ok {
+		if err != nil {
+			return err
+		}
+		vals.Add(key, s)
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name, omitempty := formFieldName(f)
+			if name == "" {
+				continue
+			}
+			fv := v.Field(i)
+			if omitempty && isEmptyValue(fv) {
+				continue
+			}
+			if err := encodeForm(formKey(key, name), fv, vals); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		keys := make([]string, 0, v.Len())
+		values := make(map[string]reflect.Value, v.Len())
+		for _, k := range v.MapKeys() {
+			s, _, err := formatFormValue(k)
+			if err != nil {
+				return err
+			}
+			keys = append(keys, s)
+			values[s] = v.MapIndex(k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if err := encodeForm(formKey(key, k), values[k], vals); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			elem := v.Index(i)
+			k := key
+			if !isFormPrimitive(indirect(elem)) {
+				k = formKey(key, strconv.Itoa(i))
+			}
+			if err := encodeForm(k, elem, vals); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("can't encode %s of type %s as %s", key, v.Type(), FormContentType)
+	}
+	return nil
+}
+
+// decodeForm stores the values of node in v.
+func decodeForm(key string, node *formNode, v reflect.Value) error {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeForm(key, node, v.Elem())
+	}
+	if v.CanAddr() {
+		if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			return u.UnmarshalText([]byte(node.value()))
+		}
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			name, _ := formFieldName(t.Field(i))
+			if name == "" {
+				continue
+			}
+			child, ok := node.children[name]
+			if !ok {
+				continue
+			}
+			if err := decodeForm(formKey(key, name), child, v.Field(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		for k, child := range node.children {
+			mk := reflect.New(v.Type().Key()).Elem()
+			if err := parseFormValue(formKey(key, k), k, mk); err != nil {
+				return err
+			}
+			mv := reflect.New(v.Type().Elem()).Elem()
+			if err := decodeForm(formKey(key, k), child, mv); err != nil {
+				return err
+			}
+			v.SetMapIndex(mk, mv)
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			v.SetBytes([]byte(node.value()))
+			return nil
+		}
+		for _, n := range node.elems() {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := decodeForm(key, n, elem); err != nil {
+				return err
+			}
+			v.Set(reflect.Append(v, elem))
+		}
+	case reflect.Interface:
+		if len(node.children) > 0 {
+			m := make(map[string]interface{}, len(node.children))
+			if err := decodeForm(key, node, reflect.ValueOf(&m).Elem()); err != nil {
+				return err
+			}
+			v.Set(reflect.ValueOf(m))
+		} else if len(node.values) > 1 {
+			v.Set(reflect.ValueOf(node.values))
+		} else {
+			v.Set(reflect.ValueOf(node.value()))
+		}
+	default:
+		return parseFormValue(key, node.value(), v)
+	}
+	return nil
+}
+
+// formatFormValue returns the string representation of v if v is a primitive
+// value or implements encoding.TextMarshaler. The boolean return value is
+// false if v is neither.
+func formatFormValue(v reflect.Value) (string, bool, error) {
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		b, err := m.MarshalText()
+		return string(b), true, err
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true, nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true, nil
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 32), true, nil
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), true, nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return string(v.Bytes()), true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// parseFormValue parses s into the primitive value v.
+func parseFormValue(key, s string, v reflect.Value) error {
+	var err error
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(s); err == nil {
+			v.SetBool(b)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		if i, err = strconv.ParseInt(s, 10, v.Type().Bits()); err == nil {
+			v.SetInt(i)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var u uint64
+		if u, err = strconv.ParseUint(s, 10, v.Type().Bits()); err == nil {
+			v.SetUint(u)
+		}
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if f, err = strconv.ParseFloat(s, v.Type().Bits()); err == nil {
+			v.SetFloat(f)
+		}
+	default:
+		return fmt.Errorf("can't decode %s into value of type %s", key, v.Type())
+	}
+	if err != nil {
+		return fmt.Errorf("invalid value %q for %s, must be a %s", s, key, v.Kind())
+	}
+	return nil
+}
+
+// formFieldName returns the form name of the struct field and whether the
+// field is omitted when empty. The name is empty if the field must be skipped.
+func formFieldName(f reflect.StructField) (string, bool) {
+	if f.PkgPath != "" {
+		return "", false
+	}
+	tag, ok := f.Tag.Lookup("form")
+	if !ok {
+		tag, ok = f.Tag.Lookup("json")
+	}
+	if !ok {
+		return f.Name, false
+	}
+	if tag == "-" {
+		return "", false
+	}
+	parts := strings.Split(tag, ",")
+	name := parts[0]
+	if name == "" {
+		name = f.Name
+	}
+	for _, o := range parts[1:] {
+		if o == "omitempty" {
+			return name, true
+		}
+	}
+	return name, false
+}
+
+// formKeySegments splits the form key k into its bracket delimited segments,
+// for example "a[b][]" returns "a", "b" and "".
+func formKeySegments(k string) []string {
+	i := strings.Index(k, "[")
+	if i <= 0 || !strings.HasSuffix(k, "]") {
+		return []string{k}
+	}
+	segs := []string{k[:i]}
+	for _, s := range strings.Split(k[i+1:len(k)-1], "][") {
+		segs = append(segs, s)
+	}
+	return segs
+}
+
+// formKey returns the key of the form value named name nested under prefix.
+func formKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "[" + name + "]"
+}
+
+// child returns the child node with the given name, creating it if needed.
+func (n *formNode) child(name string) *formNode {
+	if n.children == nil {
+		n.children = make(map[string]*formNode)
+	}
+	c, ok := n.children[name]
+	if !ok {
+		c = &formNode{}
+		n.children[name] = c
+	}
+	return c
+}
+
+// value returns the first value of the node.
+func (n *formNode) value() string {
+	if len(n.values) == 0 {
+		return ""
+	}
+	return n.values[0]
+}
+
+// elems returns the nodes that make up the elements of an array: one node per
+// value followed by the nodes of the empty bracket child and the indexed
+// children sorted by index.
+func (n *formNode) elems() []*formNode {
+	var nodes []*formNode
+	for _, v := range n.values {
+		nodes = append(nodes, &formNode{values: []string{v}})
+	}
+	if c, ok := n.children[""]; ok {
+		for _, v := range c.values {
+			nodes = append(nodes, &formNode{values: []string{v}})
+		}
+	}
+	var idx []int
+	for k := range n.children {
+		if i, err := strconv.Atoi(k); err == nil {
+			idx = append(idx, i)
+		}
+	}
+	sort.Ints(idx)
+	for _, i := range idx {
+		nodes = append(nodes, n.children[strconv.Itoa(i)])
+	}
+	return nodes
+}
+
+// indirect dereferences pointers and interfaces until it reaches a non-pointer
+// value. It returns the zero Value if a nil pointer is found.
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// isFormPrimitive returns true if v is encoded as a single form value.
+func isFormPrimitive(v reflect.Value) bool {
+	if !v.IsValid() {
+		return true
+	}
+	_, ok, _ := formatFormValue(v)
+	return ok
+}
+
+// isEmptyValue returns true if v is the zero value of its type using the same
+// definition as encoding/json for the omitempty option.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
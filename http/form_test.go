@@ -0,0 +1,140 @@
+package http
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type (
+	formAddress struct {
+		City *string `form:"city,omitempty" json:"city,omitempty"`
+		Zip  int     `form:"zip" json:"zip"`
+	}
+
+	formItem struct {
+		ID   int    `form:"id" json:"id"`
+		Name string `form:"name,omitempty" json:"name,omitempty"`
+	}
+
+	formBody struct {
+		Name    *string           `form:"name,omitempty" json:"name,omitempty"`
+		Count   int               `form:"count" json:"count"`
+		Ratio   float64           `form:"ratio,omitempty" json:"ratio,omitempty"`
+		Public  *bool             `form:"public,omitempty" json:"public,omitempty"`
+		Tags    []string          `form:"tags,omitempty" json:"tags,omitempty"`
+		Address *formAddress      `form:"address,omitempty" json:"address,omitempty"`
+		Items   []*formItem       `form:"items,omitempty" json:"items,omitempty"`
+		Labels  map[string]string `form:"labels,omitempty" json:"labels,omitempty"`
+		Scores  map[int]float32   `form:"scores,omitempty" json:"scores,omitempty"`
+		Skipped string            `form:"-" json:"-"`
+	}
+)
+
+func TestFormEncoder(t *testing.T) {
+	var (
+		name = "goa"
+		city = "Paris"
+		yes  = true
+	)
+	cases := []struct {
+		Name     string
+		Value    interface{}
+		Expected string
+	}{
+		{"empty", &formBody{}, "count=0"},
+		{"primitives", &formBody{Name: &name, Count: 2, Ratio: 0.5, Public: &yes}, "count=2&name=goa&public=true&ratio=0.5"},
+		{"array", &formBody{Tags: []string{"a", "b"}}, "count=0&tags=a&tags=b"},
+		{"nested", &formBody{Address: &formAddress{City: &city, Zip: 75001}}, "address%5Bcity%5D=Paris&address%5Bzip%5D=75001&count=0"},
+		{"array of objects", &formBody{Items: []*formItem{{ID: 1, Name: "a"}, {ID: 2}}}, "count=0&items%5B0%5D%5Bid%5D=1&items%5B0%5D%5Bname%5D=a&items%5B1%5D%5Bid%5D=2"},
+		{"maps", &formBody{Labels: map[string]string{"b": "2", "a": "1"}, Scores: map[int]float32{1: 1.5}}, "count=0&labels%5Ba%5D=1&labels%5Bb%5D=2&scores%5B1%5D=1.5"},
+		{"skipped", &formBody{Skipped: "x"}, "count=0"},
+		{"map", map[string]interface{}{"a": 1, "b": []int{1, 2}}, "a=1&b=1&b=2"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewFormEncoder(&buf).Encode(c.Value); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := buf.String(); got != c.Expected {
+				t.Errorf("got %q, expected %q", got, c.Expected)
+			}
+		})
+	}
+	if err := NewFormEncoder(&bytes.Buffer{}).Encode("string"); err == nil {
+		t.Error("expected error when encoding a string")
+	}
+}
+
+func TestFormDecoder(t *testing.T) {
+	var (
+		name = "goa"
+		city = "Paris"
+		yes  = true
+	)
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected *formBody
+		Error    string
+	}{
+		{"empty", "", &formBody{}, ""},
+		{"primitives", "name=goa&count=2&ratio=0.5&public=true&unknown=1", &formBody{Name: &name, Count: 2, Ratio: 0.5, Public: &yes}, ""},
+		{"repeated array", "tags=a&tags=b", &formBody{Tags: []string{"a", "b"}}, ""},
+		{"bracket array", "tags[]=a&tags[]=b", &formBody{Tags: []string{"a", "b"}}, ""},
+		{"nested", "address[city]=Paris&address[zip]=75001", &formBody{Address: &formAddress{City: &city, Zip: 75001}}, ""},
+		{"array of objects", "items[1][id]=2&items[0][id]=1&items[0][name]=a", &formBody{Items: []*formItem{{ID: 1, Name: "a"}, {ID: 2}}}, ""},
+		{"maps", "labels[a]=1&labels[b]=2&scores[1]=1.5", &formBody{Labels: map[string]string{"a": "1", "b": "2"}, Scores: map[int]float32{1: 1.5}}, ""},
+		{"skipped", "Skipped=x&-=x", &formBody{}, ""},
+		{"invalid int", "count=foo", nil, `invalid value "foo" for count, must be a int`},
+		{"invalid nested", "address[zip]=foo", nil, `invalid value "foo" for address[zip], must be a int`},
+		{"invalid map key", "scores[a]=1", nil, `invalid value "a" for scores[a], must be a int`},
+		{"invalid content", "name=%zz", nil, `invalid URL escape "%zz"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var body formBody
+			err := NewFormDecoder(strings.NewReader(c.Content)).Decode(&body)
+			if c.Error != "" {
+				if err == nil || err.Error() != c.Error {
+					t.Fatalf("got error %v, expected %q", err, c.Error)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(&body, c.Expected) {
+				t.Errorf("got %#v, expected %#v", body, *c.Expected)
+			}
+		})
+	}
+}
+
+func TestFormRoundTrip(t *testing.T) {
+	city := "Paris"
+	body := &formBody{
+		Count:   1,
+		Tags:    []string{"a"},
+		Address: &formAddress{City: &city},
+		Items:   []*formItem{{ID: 1}},
+		Labels:  map[string]string{"k": "v"},
+	}
+	r := httptest.NewRequest("POST", "/", nil)
+	if err := FormRequestEncoder(r).Encode(body); err != nil {
+		t.Fatalf("unexpected encode error: %s", err)
+	}
+	if ct := r.Header.Get("Content-Type"); ct != FormContentType {
+		t.Errorf("got content type %q, expected %q", ct, FormContentType)
+	}
+	var decoded formBody
+	if err := RequestDecoder(r).Decode(&decoded); err != nil {
+		t.Fatalf("unexpected decode error: %s", err)
+	}
+	if !reflect.DeepEqual(&decoded, body) {
+		t.Errorf("got %#v, expected %#v", decoded, *body)
+	}
+}
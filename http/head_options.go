@@ -0,0 +1,48 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+)
+
+type (
+	// headResponseWriter is the response writer used by HeadHandler, it
+	// discards the response body.
+	headResponseWriter struct {
+		http.ResponseWriter
+	}
+)
+
+// HeadHandler returns a handler that serves HEAD requests with the GET handler
+// h. The response has the status and headers written by h, its body is
+// discarded. The generated code mounts HeadHandler on the paths of the GET
+// routes of the services that use the AutoHeadOptions DSL.
+func HeadHandler(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h(&headResponseWriter{w}, r)
+	}
+}
+
+// OptionsHandler returns a handler that responds to OPTIONS requests with a 204
+// No Content response whose Allow header lists the given methods. The generated
+// code mounts OptionsHandler on the paths of the routes of the services that
+// use the AutoHeadOptions DSL.
+func OptionsHandler(methods ...string) http.HandlerFunc {
+	allow := strings.Join(methods, ", ")
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Allow", allow)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Write discards the response body.
+func (w *headResponseWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+// Flush implements http.Flusher.
+func (w *headResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,39 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHeadHandler(t *testing.T) {
+	get := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Custom", "value")
+		w.WriteHeader(http.StatusAccepted)
+		if _, err := w.Write([]byte("body")); err != nil {
+			t.Errorf("got write error %v", err)
+		}
+	}
+	w := httptest.NewRecorder()
+	HeadHandler(get)(w, httptest.NewRequest("HEAD", "/", nil))
+	if w.Code != http.StatusAccepted {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusAccepted)
+	}
+	if h := w.Header().Get("X-Custom"); h != "value" {
+		t.Errorf("got header %q, expected %q", h, "value")
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("got body %q, expected none", w.Body.String())
+	}
+}
+
+func TestOptionsHandler(t *testing.T) {
+	w := httptest.NewRecorder()
+	OptionsHandler("GET", "HEAD", "OPTIONS")(w, httptest.NewRequest("OPTIONS", "/", nil))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusNoContent)
+	}
+	if a := w.Header().Get("Allow"); a != "GET, HEAD, OPTIONS" {
+		t.Errorf("got Allow header %q, expected %q", a, "GET, HEAD, OPTIONS")
+	}
+}
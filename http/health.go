@@ -0,0 +1,28 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// HealthHandler returns a handler that writes the JSON encoded health report
+// returned by report. The response status code is 200 OK if the report status
+// is ok and 503 Service Unavailable otherwise. The code generated for the
+// HealthCheck DSL mounts HealthHandler with goa.Health.Live on the liveness
+// path and with goa.Health.Ready on the readiness path.
+func HealthHandler(report func(context.Context) *goa.HealthReport) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res := report(r.Context())
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		if res.OK() {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(res) // nolint: errcheck
+	}
+}
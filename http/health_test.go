@@ -0,0 +1,38 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestHealthHandler(t *testing.T) {
+	h := goa.NewHealth(nil)
+	w := httptest.NewRecorder()
+	HealthHandler(h.Ready)(w, httptest.NewRequest("GET", "/readyz", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusOK)
+	}
+	if body := w.Body.String(); body != "{\"status\":\"ok\"}\n" {
+		t.Errorf("got body %q", body)
+	}
+
+	h.Register("db", func(context.Context) error { return errors.New("down") })
+	w = httptest.NewRecorder()
+	HealthHandler(h.Ready)(w, httptest.NewRequest("GET", "/readyz", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusServiceUnavailable)
+	}
+	if body := w.Body.String(); body != "{\"status\":\"unavailable\",\"checks\":{\"db\":\"down\"}}\n" {
+		t.Errorf("got body %q", body)
+	}
+	w = httptest.NewRecorder()
+	HealthHandler(h.Live)(w, httptest.NewRequest("GET", "/healthz", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("got liveness status %d, expected %d", w.Code, http.StatusOK)
+	}
+}
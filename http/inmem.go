@@ -0,0 +1,89 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+)
+
+type (
+	// InMemoryListener is a net.Listener whose connections are established
+	// in memory by calling Dial. It makes it possible to serve HTTP requests
+	// and websocket connections without opening network ports, e.g. to wire
+	// a generated client to a generated server in unit tests.
+	InMemoryListener struct {
+		conns chan net.Conn
+		done  chan struct{}
+		once  sync.Once
+	}
+
+	// inMemoryAddr is the address of the in-memory listeners.
+	inMemoryAddr struct{}
+)
+
+// ErrListenerClosed is the error returned by the Accept and Dial methods of a
+// closed InMemoryListener.
+var ErrListenerClosed = errors.New("in-memory listener closed")
+
+// NewInMemoryListener returns a listener whose connections are established in
+// memory.
+func NewInMemoryListener() *InMemoryListener {
+	return &InMemoryListener{conns: make(chan net.Conn), done: make(chan struct{})}
+}
+
+// NewInMemoryClient returns a HTTP client that sends the requests to the
+// server serving the given listener regardless of the request URL host.
+func NewInMemoryClient(l *InMemoryListener) *http.Client {
+	return &http.Client{Transport: &http.Transport{DialContext: l.DialContext}}
+}
+
+// Accept waits for and returns the next connection established with Dial.
+func (l *InMemoryListener) Accept() (net.Conn, error) {
+	select {
+	case c := <-l.conns:
+		return c, nil
+	case <-l.done:
+		return nil, ErrListenerClosed
+	}
+}
+
+// Close closes the listener, the connections already accepted are not
+// closed.
+func (l *InMemoryListener) Close() error {
+	l.once.Do(func() { close(l.done) })
+	return nil
+}
+
+// Addr returns the listener address.
+func (l *InMemoryListener) Addr() net.Addr {
+	return inMemoryAddr{}
+}
+
+// Dial establishes a connection with the listener. It blocks until the
+// connection is accepted or the listener is closed.
+func (l *InMemoryListener) Dial() (net.Conn, error) {
+	return l.DialContext(context.Background(), "", "")
+}
+
+// DialContext establishes a connection with the listener, it ignores the
+// network and address so that it may be used as the dial function of
+// http.Transport or websocket.Dialer.
+func (l *InMemoryListener) DialContext(ctx context.Context, _, _ string) (net.Conn, error) {
+	client, server := net.Pipe()
+	select {
+	case l.conns <- server:
+		return client, nil
+	case <-l.done:
+		return nil, ErrListenerClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Network returns the name of the network of the in-memory listeners.
+func (inMemoryAddr) Network() string { return "memory" }
+
+// String returns the address of the in-memory listeners.
+func (inMemoryAddr) String() string { return "memory" }
@@ -0,0 +1,52 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestInMemoryListener(t *testing.T) {
+	l := NewInMemoryListener()
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Method + " " + r.URL.Path)) // nolint: errcheck
+	})}
+	go srv.Serve(l) // nolint: errcheck
+
+	resp, err := NewInMemoryClient(l).Get("http://inmem/accounts/1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "GET /accounts/1" {
+		t.Errorf("got body %q, expected %q", string(body), "GET /accounts/1")
+	}
+	if n := l.Addr().Network(); n != "memory" {
+		t.Errorf("got network %q, expected %q", n, "memory")
+	}
+
+	if err := srv.Close(); err != nil {
+		t.Fatalf("unexpected error closing the server: %s", err)
+	}
+	if _, err := l.Dial(); err != ErrListenerClosed {
+		t.Errorf("got error %v dialing a closed listener, expected %v", err, ErrListenerClosed)
+	}
+	if _, err := l.Accept(); err != ErrListenerClosed {
+		t.Errorf("got error %v accepting from a closed listener, expected %v", err, ErrListenerClosed)
+	}
+	if err := l.Close(); err != nil {
+		t.Errorf("got error %v closing the listener twice", err)
+	}
+}
+
+func TestInMemoryListenerDialContext(t *testing.T) {
+	l := NewInMemoryListener()
+	defer l.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := l.DialContext(ctx, "tcp", "inmem:80"); err != context.Canceled {
+		t.Errorf("got error %v, expected %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,152 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sync/atomic"
+)
+
+type (
+	// JSONCodec is the JSON implementation used by the HTTP request and
+	// response encoders and decoders, the streamed results, the webhooks
+	// and the JSON encoded multipart parts of the generated code. Use
+	// SetJSONCodec to replace package encoding/json with another
+	// implementation such as encoding/json/v2, sonic or go-json.
+	JSONCodec interface {
+		// Marshal returns the JSON encoding of v.
+		Marshal(v interface{}) ([]byte, error)
+		// Unmarshal decodes the JSON encoded data into v.
+		Unmarshal(data []byte, v interface{}) error
+		// NewEncoder returns an encoder that writes JSON values to w.
+		NewEncoder(w io.Writer) Encoder
+		// NewDecoder returns a decoder that reads JSON values from r.
+		NewDecoder(r io.Reader) Decoder
+	}
+
+	// StdJSONCodec is the JSONCodec implemented with package encoding/json.
+	// It is used unless SetJSONCodec is called.
+	StdJSONCodec struct{}
+
+	// jsonCodecHolder makes it possible to store codecs of different types
+	// in an atomic.Value.
+	jsonCodecHolder struct {
+		codec JSONCodec
+	}
+)
+
+var (
+	// jsonCodec holds the codec returned by JSON.
+	jsonCodec atomic.Value
+
+	// stdJSONCodec holds the default codec.
+	stdJSONCodec = &jsonCodecHolder{codec: StdJSONCodec{}}
+)
+
+// SetJSONCodec sets the JSON implementation used by the HTTP encoders and
+// decoders. SetJSONCodec should be called before the servers and clients are
+// created, typically in an init function. A nil codec restores StdJSONCodec.
+// Use CheckJSONCodec to make sure the codec encodes and decodes the generated
+// types like package encoding/json.
+func SetJSONCodec(c JSONCodec) {
+	if c == nil {
+		jsonCodec.Store(stdJSONCodec)
+		return
+	}
+	jsonCodec.Store(&jsonCodecHolder{codec: c})
+}
+
+// JSON returns the JSON implementation set with SetJSONCodec, StdJSONCodec by
+// default.
+func JSON() JSONCodec {
+	return currentJSONCodec().codec
+}
+
+// CheckJSONCodec makes sure that c produces the same JSON documents as package
+// encoding/json for the given values and that it decodes them into the same
+// values. The values are typically instances of the generated request and
+// response body types initialized with examples. Documents are compared
+// semantically so that differences in whitespace, key order or escaping are
+// ignored. CheckJSONCodec returns an error describing the first discrepancy.
+func CheckJSONCodec(c JSONCodec, values ...interface{}) error {
+	var std StdJSONCodec
+	for _, v := range values {
+		if v == nil {
+			continue
+		}
+		expected, err := std.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("encoding/json cannot marshal %T: %s", v, err)
+		}
+		got, err := c.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %T: %s", v, err)
+		}
+		if !sameJSON(got, expected) {
+			return fmt.Errorf("%T: got %s, expected %s", v, got, expected)
+		}
+		var buf bytes.Buffer
+		if err := c.NewEncoder(&buf).Encode(v); err != nil {
+			return fmt.Errorf("failed to encode %T: %s", v, err)
+		}
+		if !sameJSON(buf.Bytes(), expected) {
+			return fmt.Errorf("%T: got %s from encoder, expected %s", v, buf.Bytes(), expected)
+		}
+
+		t := reflect.TypeOf(v)
+		want := reflect.New(t)
+		if err := std.Unmarshal(expected, want.Interface()); err != nil {
+			return fmt.Errorf("encoding/json cannot unmarshal %T: %s", v, err)
+		}
+		decoded := reflect.New(t)
+		if err := c.Unmarshal(expected, decoded.Interface()); err != nil {
+			return fmt.Errorf("failed to unmarshal %T: %s", v, err)
+		}
+		if !reflect.DeepEqual(decoded.Elem().Interface(), want.Elem().Interface()) {
+			return fmt.Errorf("%T: got %#v once unmarshaled, expected %#v", v, decoded.Elem().Interface(), want.Elem().Interface())
+		}
+		streamed := reflect.New(t)
+		if err := c.NewDecoder(bytes.NewReader(expected)).Decode(streamed.Interface()); err != nil {
+			return fmt.Errorf("failed to decode %T: %s", v, err)
+		}
+		if !reflect.DeepEqual(streamed.Elem().Interface(), want.Elem().Interface()) {
+			return fmt.Errorf("%T: got %#v once decoded, expected %#v", v, streamed.Elem().Interface(), want.Elem().Interface())
+		}
+	}
+	return nil
+}
+
+// currentJSONCodec returns the holder of the codec returned by JSON. Holders
+// are compared instead of codecs as codecs may not be comparable.
+func currentJSONCodec() *jsonCodecHolder {
+	if h, ok := jsonCodec.Load().(*jsonCodecHolder); ok {
+		return h
+	}
+	return stdJSONCodec
+}
+
+// Marshal returns the JSON encoding of v using json.Marshal.
+func (StdJSONCodec) Marshal(v interface{}) ([]byte, error) { return json.Marshal(v) }
+
+// Unmarshal decodes data into v using json.Unmarshal.
+func (StdJSONCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+
+// NewEncoder returns a json.Encoder writing to w.
+func (StdJSONCodec) NewEncoder(w io.Writer) Encoder { return json.NewEncoder(w) }
+
+// NewDecoder returns a json.Decoder reading from r.
+func (StdJSONCodec) NewDecoder(r io.Reader) Decoder { return json.NewDecoder(r) }
+
+// sameJSON returns true if a and b are the same JSON documents.
+func sameJSON(a, b []byte) bool {
+	var va, vb interface{}
+	if err := json.Unmarshal(a, &va); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(b, &vb); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
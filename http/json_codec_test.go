@@ -0,0 +1,166 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type (
+	// countingCodec is a JSON codec that counts its uses.
+	countingCodec struct {
+		StdJSONCodec
+		calls *int
+	}
+
+	// lossyCodec is a JSON codec that loses the "any" field when decoding.
+	lossyCodec struct {
+		StdJSONCodec
+	}
+
+	// codecBody mimics a generated body type.
+	codecBody struct {
+		Name     *string                `form:"name,omitempty" json:"name,omitempty" xml:"name,omitempty"`
+		Count    int                    `form:"count" json:"count" xml:"count"`
+		Ratio    *float64               `form:"ratio,omitempty" json:"ratio,omitempty" xml:"ratio,omitempty"`
+		Tags     []string               `form:"tags,omitempty" json:"tags,omitempty" xml:"tags,omitempty"`
+		Labels   map[string]string      `form:"labels,omitempty" json:"labels,omitempty" xml:"labels,omitempty"`
+		Bytes    []byte                 `form:"bytes,omitempty" json:"bytes,omitempty" xml:"bytes,omitempty"`
+		Any      interface{}            `form:"any,omitempty" json:"any,omitempty" xml:"any,omitempty"`
+		Nested   *codecBody             `form:"nested,omitempty" json:"nested,omitempty" xml:"nested,omitempty"`
+		Children []*codecBody           `form:"children,omitempty" json:"children,omitempty" xml:"children,omitempty"`
+		Meta     map[string]interface{} `form:"meta,omitempty" json:"meta,omitempty" xml:"meta,omitempty"`
+	}
+)
+
+func (c countingCodec) Marshal(v interface{}) ([]byte, error) {
+	*c.calls++
+	return c.StdJSONCodec.Marshal(v)
+}
+
+func (c countingCodec) Unmarshal(data []byte, v interface{}) error {
+	*c.calls++
+	return c.StdJSONCodec.Unmarshal(data, v)
+}
+
+func (c countingCodec) NewEncoder(w io.Writer) Encoder {
+	enc := c.StdJSONCodec.NewEncoder(w)
+	return EncodingFunc(func(v interface{}) error {
+		*c.calls++
+		return enc.Encode(v)
+	})
+}
+
+func (c countingCodec) NewDecoder(r io.Reader) Decoder {
+	dec := c.StdJSONCodec.NewDecoder(r)
+	return EncodingFunc(func(v interface{}) error {
+		*c.calls++
+		return dec.Decode(v)
+	})
+}
+
+func (lossyCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(bytes.Replace(data, []byte(`"any":"value"`), []byte(`"any":null`), -1), v)
+}
+
+func TestSetJSONCodec(t *testing.T) {
+	var calls int
+	SetJSONCodec(countingCodec{calls: &calls})
+	defer SetJSONCodec(nil)
+
+	ctx := context.Background()
+	cases := []struct {
+		Name string
+		Use  func() error
+	}{
+		{"request-decoder", func() error {
+			r := httptest.NewRequest("POST", "/", strings.NewReader(`{"count":1}`))
+			var b codecBody
+			return RequestDecoder(r).Decode(&b)
+		}},
+		{"response-encoder", func() error {
+			return ResponseEncoder(ctx, httptest.NewRecorder()).Encode(&codecBody{Count: 1})
+		}},
+		{"pooled-response-encoder", func() error {
+			return PooledResponseEncoder(ctx, httptest.NewRecorder()).Encode(&codecBody{Count: 1})
+		}},
+		{"request-encoder", func() error {
+			r := httptest.NewRequest("POST", "/", nil)
+			return RequestEncoder(r).Encode(&codecBody{Count: 1})
+		}},
+		{"pooled-request-encoder", func() error {
+			r := httptest.NewRequest("POST", "/", nil)
+			return PooledRequestEncoder(r).Encode(&codecBody{Count: 1})
+		}},
+		{"response-decoder", func() error {
+			resp := &http.Response{Header: http.Header{"Content-Type": {"application/json"}}, Body: ioutil.NopCloser(strings.NewReader(`{"count":1}`))}
+			var b codecBody
+			return ResponseDecoder(resp).Decode(&b)
+		}},
+		{"stream", func() error {
+			w := httptest.NewRecorder()
+			sw := NewStreamWriter(w, StreamNDJSON)
+			if err := sw.Send(&codecBody{Count: 1}); err != nil {
+				return err
+			}
+			sr := NewStreamReader(ioutil.NopCloser(w.Body), StreamNDJSON)
+			var b codecBody
+			return sr.Recv(&b)
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			calls = 0
+			if err := c.Use(); err != nil {
+				t.Fatal(err)
+			}
+			if calls == 0 {
+				t.Error("got no call to the JSON codec")
+			}
+		})
+	}
+
+	SetJSONCodec(nil)
+	if _, ok := JSON().(StdJSONCodec); !ok {
+		t.Errorf("got codec %T, expected StdJSONCodec", JSON())
+	}
+}
+
+func TestCheckJSONCodec(t *testing.T) {
+	var (
+		name  = "name"
+		ratio = 0.5
+	)
+	values := []interface{}{
+		&codecBody{},
+		&codecBody{
+			Name:     &name,
+			Count:    42,
+			Ratio:    &ratio,
+			Tags:     []string{"a", "<b>"},
+			Labels:   map[string]string{"k": "v"},
+			Bytes:    []byte{0, 1, 2},
+			Any:      "value",
+			Nested:   &codecBody{Count: 1},
+			Children: []*codecBody{{Count: 2}, {Tags: []string{}}},
+			Meta:     map[string]interface{}{"n": 1.5, "s": "x", "l": []interface{}{true, nil}},
+		},
+		[]*codecBody{{Count: 3}},
+		map[string]*codecBody{"x": {Count: 4}},
+		"text",
+		nil,
+	}
+	if err := CheckJSONCodec(StdJSONCodec{}, values...); err != nil {
+		t.Errorf("got error %q for encoding/json, expected none", err)
+	}
+	err := CheckJSONCodec(lossyCodec{}, values...)
+	if err == nil || !strings.Contains(err.Error(), "once unmarshaled") {
+		t.Errorf("got error %v, expected unmarshal discrepancy", err)
+	}
+}
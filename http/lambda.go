@@ -0,0 +1,321 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type (
+	// LambdaRequest is the event sent by the API Gateway HTTP APIs to the
+	// AWS Lambda functions (payload format version 2.0).
+	LambdaRequest struct {
+		// Version is the payload format version, "2.0".
+		Version string `json:"version"`
+		// RouteKey is the API Gateway route, e.g. "POST /add".
+		RouteKey string `json:"routeKey"`
+		// RawPath is the request path.
+		RawPath string `json:"rawPath"`
+		// RawQueryString is the request query string.
+		RawQueryString string `json:"rawQueryString"`
+		// Cookies lists the request cookies.
+		Cookies []string `json:"cookies,omitempty"`
+		// Headers lists the request headers, the values of the headers
+		// that appear more than once are joined with commas.
+		Headers map[string]string `json:"headers"`
+		// RequestContext describes the request.
+		RequestContext LambdaRequestContext `json:"requestContext"`
+		// Body is the request body, base64 encoded if IsBase64Encoded
+		// is true.
+		Body string `json:"body,omitempty"`
+		// IsBase64Encoded is true if Body is base64 encoded.
+		IsBase64Encoded bool `json:"isBase64Encoded"`
+	}
+
+	// LambdaRequestContext describes the request of a LambdaRequest.
+	LambdaRequestContext struct {
+		// DomainName is the API domain name.
+		DomainName string `json:"domainName"`
+		// RequestID is the API Gateway request ID.
+		RequestID string `json:"requestId"`
+		// HTTP describes the HTTP request.
+		HTTP LambdaHTTPDescription `json:"http"`
+	}
+
+	// LambdaHTTPDescription describes the HTTP request of a LambdaRequest.
+	LambdaHTTPDescription struct {
+		// Method is the request method.
+		Method string `json:"method"`
+		// Path is the request path.
+		Path string `json:"path"`
+		// Protocol is the request protocol, e.g. "HTTP/1.1".
+		Protocol string `json:"protocol"`
+		// SourceIP is the IP address of the client.
+		SourceIP string `json:"sourceIp"`
+	}
+
+	// LambdaResponse is the response returned by the AWS Lambda functions
+	// to the API Gateway HTTP APIs (payload format version 2.0).
+	LambdaResponse struct {
+		// StatusCode is the response status code.
+		StatusCode int `json:"statusCode"`
+		// Headers lists the response headers, the values of the headers
+		// set more than once are joined with commas.
+		Headers map[string]string `json:"headers,omitempty"`
+		// Cookies lists the values of the Set-Cookie headers.
+		Cookies []string `json:"cookies,omitempty"`
+		// Body is the response body, base64 encoded if IsBase64Encoded
+		// is true.
+		Body string `json:"body"`
+		// IsBase64Encoded is true if Body is base64 encoded.
+		IsBase64Encoded bool `json:"isBase64Encoded"`
+	}
+
+	// LambdaHandlerFunc handles the API Gateway events received by an AWS
+	// Lambda function, see NewLambdaHandler.
+	LambdaHandlerFunc func(context.Context, *LambdaRequest) (*LambdaResponse, error)
+
+	// lambdaResponseWriter records the response written by the HTTP
+	// handler served by a Lambda function.
+	lambdaResponseWriter struct {
+		header http.Header
+		status int
+		body   bytes.Buffer
+	}
+
+	// lambdaError is the error reported to the Lambda runtime API.
+	lambdaError struct {
+		Message string `json:"errorMessage"`
+		Type    string `json:"errorType"`
+	}
+)
+
+// lambdaRuntimeAPI is the path prefix of the Lambda runtime API invocation
+// endpoints.
+const lambdaRuntimeAPI = "/2018-06-01/runtime/invocation/"
+
+// NewLambdaHandler returns a Lambda handler that serves the API Gateway
+// requests with h. The handler converts the events to HTTP requests and
+// records the responses written by h so that the errors returned by the
+// endpoints keep their status codes. The response bodies that are not valid
+// UTF-8 are base64 encoded.
+//
+// The Lambda functions buffer the responses: the chunks of the streaming
+// responses are delivered once the endpoint completes and the websocket
+// upgrades fail as the API Gateway HTTP APIs do not proxy websocket
+// connections.
+func NewLambdaHandler(h http.Handler) LambdaHandlerFunc {
+	return func(ctx context.Context, ev *LambdaRequest) (*LambdaResponse, error) {
+		r, err := newLambdaHTTPRequest(ctx, ev)
+		if err != nil {
+			return nil, err
+		}
+		w := &lambdaResponseWriter{header: make(http.Header)}
+		h.ServeHTTP(w, r)
+		return w.response(), nil
+	}
+}
+
+// StartLambda serves h as an AWS Lambda function invoked by an API Gateway
+// HTTP API. StartLambda implements the Lambda runtime API so that the function
+// may be deployed with a custom runtime ("provided.al2") by naming the
+// executable "bootstrap". StartLambda returns only if the runtime API cannot
+// be reached.
+func StartLambda(h http.Handler) error {
+	api := os.Getenv("AWS_LAMBDA_RUNTIME_API")
+	if api == "" {
+		return errors.New("AWS_LAMBDA_RUNTIME_API is not set, the function must run in the AWS Lambda execution environment")
+	}
+	return ServeLambda(context.Background(), api, NewLambdaHandler(h))
+}
+
+// ServeLambda polls the Lambda runtime API listening on the given host and
+// port for invocations and handles them with fn until ctx is canceled. The
+// context given to fn is canceled when the invocation deadline is reached.
+// The errors returned by fn are reported to the runtime API.
+func ServeLambda(ctx context.Context, api string, fn LambdaHandlerFunc) error {
+	var (
+		client = &http.Client{}
+		base   = "http://" + api + lambdaRuntimeAPI
+	)
+	for {
+		req, err := http.NewRequest("GET", base+"next", nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req.WithContext(ctx))
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		event, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("lambda runtime API returned %s", resp.Status)
+		}
+		id := resp.Header.Get("Lambda-Runtime-Aws-Request-Id")
+		if trace := resp.Header.Get("Lambda-Runtime-Trace-Id"); trace != "" {
+			os.Setenv("_X_AMZN_TRACE_ID", trace) // nolint: errcheck
+		}
+		ictx, cancel := context.WithCancel(ctx)
+		if ms, err := strconv.ParseInt(resp.Header.Get("Lambda-Runtime-Deadline-Ms"), 10, 64); err == nil {
+			cancel()
+			ictx, cancel = context.WithDeadline(ctx, time.Unix(0, ms*int64(time.Millisecond)))
+		}
+		res, err := invokeLambda(ictx, fn, event)
+		cancel()
+		if err := postLambdaResult(ctx, client, base+id, res, err); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+	}
+}
+
+// postLambdaResult posts the response of the invocation with the given URL to
+// the Lambda runtime API or ferr if not nil.
+func postLambdaResult(ctx context.Context, client *http.Client, invocation string, res []byte, ferr error) error {
+	path := invocation + "/response"
+	if ferr != nil {
+		path = invocation + "/error"
+		res, _ = json.Marshal(&lambdaError{Message: ferr.Error(), Type: "Unhandled"})
+	}
+	req, err := http.NewRequest("POST", path, bytes.NewReader(res))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if ferr != nil {
+		req.Header.Set("Lambda-Runtime-Function-Error-Type", "Unhandled")
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("lambda runtime API returned %s", resp.Status)
+	}
+	return nil
+}
+
+// invokeLambda decodes the given event, handles it with fn and returns the
+// JSON encoded response. invokeLambda recovers the panics of fn and returns
+// them as errors.
+func invokeLambda(ctx context.Context, fn LambdaHandlerFunc, event []byte) (res []byte, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	var ev LambdaRequest
+	if err := json.Unmarshal(event, &ev); err != nil {
+		return nil, fmt.Errorf("invalid API Gateway event: %s", err)
+	}
+	resp, err := fn(ctx, &ev)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(resp)
+}
+
+// newLambdaHTTPRequest converts the given API Gateway event to a HTTP request.
+func newLambdaHTTPRequest(ctx context.Context, ev *LambdaRequest) (*http.Request, error) {
+	body := []byte(ev.Body)
+	if ev.IsBase64Encoded {
+		b, err := base64.StdEncoding.DecodeString(ev.Body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 encoded request body: %s", err)
+		}
+		body = b
+	}
+	path := ev.RawPath
+	if path == "" {
+		path = ev.RequestContext.HTTP.Path
+	}
+	u := "https://" + ev.RequestContext.DomainName + path
+	if ev.RawQueryString != "" {
+		u += "?" + ev.RawQueryString
+	}
+	r, err := http.NewRequest(ev.RequestContext.HTTP.Method, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range ev.Headers {
+		r.Header.Set(k, v)
+	}
+	if len(ev.Cookies) > 0 {
+		r.Header.Set("Cookie", strings.Join(ev.Cookies, "; "))
+	}
+	if r.Header.Get("X-Request-Id") == "" && ev.RequestContext.RequestID != "" {
+		r.Header.Set("X-Request-Id", ev.RequestContext.RequestID)
+	}
+	if host := r.Header.Get("Host"); host != "" {
+		r.Host = host
+	}
+	r.RemoteAddr = ev.RequestContext.HTTP.SourceIP
+	return r.WithContext(ctx), nil
+}
+
+// Header returns the response headers.
+func (w *lambdaResponseWriter) Header() http.Header {
+	return w.header
+}
+
+// WriteHeader records the response status code.
+func (w *lambdaResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+// Write records the response body.
+func (w *lambdaResponseWriter) Write(b []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.body.Write(b)
+}
+
+// Flush is a no-op: the responses of the Lambda functions are buffered.
+func (w *lambdaResponseWriter) Flush() {}
+
+// response returns the recorded response.
+func (w *lambdaResponseWriter) response() *LambdaResponse {
+	status := w.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	res := &LambdaResponse{StatusCode: status}
+	for k, v := range w.header {
+		if k == "Set-Cookie" {
+			res.Cookies = v
+			continue
+		}
+		if res.Headers == nil {
+			res.Headers = make(map[string]string, len(w.header))
+		}
+		res.Headers[k] = strings.Join(v, ",")
+	}
+	if b := w.body.Bytes(); utf8.Valid(b) {
+		res.Body = string(b)
+	} else {
+		res.Body = base64.StdEncoding.EncodeToString(b)
+		res.IsBase64Encoded = true
+	}
+	return res
+}
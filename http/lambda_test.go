@@ -0,0 +1,138 @@
+package http
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLambdaHandler(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		c, _ := r.Cookie("session")
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Add("X-Values", "a")
+		w.Header().Add("X-Values", "b")
+		http.SetCookie(w, &http.Cookie{Name: "seen", Value: "yes"})
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, "%s %s %s %s %s %s %s", r.Method, r.URL.Path, r.URL.Query().Get("x"), r.Header.Get("X-Custom"), r.Header.Get("X-Request-Id"), c.Value, body)
+	})
+	ev := &LambdaRequest{
+		Version:        "2.0",
+		RawPath:        "/add/1",
+		RawQueryString: "x=2",
+		Cookies:        []string{"session=abc"},
+		Headers:        map[string]string{"x-custom": "custom"},
+		RequestContext: LambdaRequestContext{
+			DomainName: "api.example.com",
+			RequestID:  "req-1",
+			HTTP:       LambdaHTTPDescription{Method: "POST", Path: "/add/1", SourceIP: "10.0.0.1"},
+		},
+		Body:            base64.StdEncoding.EncodeToString([]byte("payload")),
+		IsBase64Encoded: true,
+	}
+	res, err := NewLambdaHandler(h)(context.Background(), ev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusCreated {
+		t.Errorf("got status %d, expected %d", res.StatusCode, http.StatusCreated)
+	}
+	if expected := "POST /add/1 2 custom req-1 abc payload"; res.Body != expected || res.IsBase64Encoded {
+		t.Errorf("got body %q (base64: %v), expected %q", res.Body, res.IsBase64Encoded, expected)
+	}
+	if v := res.Headers["X-Values"]; v != "a,b" {
+		t.Errorf("got X-Values header %q, expected %q", v, "a,b")
+	}
+	if len(res.Cookies) != 1 || res.Cookies[0] != "seen=yes" {
+		t.Errorf("got cookies %v, expected [seen=yes]", res.Cookies)
+	}
+	if _, ok := res.Headers["Set-Cookie"]; ok {
+		t.Error("got Set-Cookie header, expected cookies only")
+	}
+}
+
+func TestLambdaHandlerBinary(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte{0xff, 0xfe})
+	})
+	res, err := NewLambdaHandler(h)(context.Background(), &LambdaRequest{RequestContext: LambdaRequestContext{HTTP: LambdaHTTPDescription{Method: "GET", Path: "/"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("got status %d, expected %d", res.StatusCode, http.StatusOK)
+	}
+	if !res.IsBase64Encoded || res.Body != base64.StdEncoding.EncodeToString([]byte{0xff, 0xfe}) {
+		t.Errorf("got body %q (base64: %v), expected base64 encoded body", res.Body, res.IsBase64Encoded)
+	}
+	_, err = NewLambdaHandler(h)(context.Background(), &LambdaRequest{Body: "!", IsBase64Encoded: true})
+	if err == nil {
+		t.Error("got no error, expected invalid base64 body error")
+	}
+}
+
+func TestServeLambda(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		events      = []string{
+			`{"version":"2.0","rawPath":"/ping","requestContext":{"http":{"method":"GET","path":"/ping"}}}`,
+			`{"version":"2.0","rawPath":"/fail","requestContext":{"http":{"method":"GET","path":"/fail"}}}`,
+		}
+		results = make(map[string]string)
+		next    int
+	)
+	defer cancel()
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == lambdaRuntimeAPI+"next":
+			if next == len(events) {
+				cancel()
+				<-r.Context().Done()
+				return
+			}
+			w.Header().Set("Lambda-Runtime-Aws-Request-Id", fmt.Sprintf("id%d", next))
+			w.Header().Set("Lambda-Runtime-Deadline-Ms", "32503680000000")
+			w.Write([]byte(events[next]))
+			next++
+		case strings.HasPrefix(r.URL.Path, lambdaRuntimeAPI):
+			b, _ := ioutil.ReadAll(r.Body)
+			results[strings.TrimPrefix(r.URL.Path, lambdaRuntimeAPI)] = string(b)
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer api.Close()
+	fn := NewLambdaHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			panic("boom")
+		}
+		if _, ok := r.Context().Deadline(); !ok {
+			t.Error("got no deadline, expected invocation deadline")
+		}
+		w.Write([]byte("pong"))
+	}))
+
+	err := ServeLambda(ctx, strings.TrimPrefix(api.URL, "http://"), fn)
+
+	if err != context.Canceled {
+		t.Errorf("got error %v, expected %v", err, context.Canceled)
+	}
+	var res LambdaResponse
+	if err := json.Unmarshal([]byte(results["id0/response"]), &res); err != nil {
+		t.Fatalf("invalid response %q: %s", results["id0/response"], err)
+	}
+	if res.StatusCode != http.StatusOK || res.Body != "pong" {
+		t.Errorf("got response %+v, expected 200 pong", res)
+	}
+	if e := results["id1/error"]; !strings.Contains(e, "panic: boom") {
+		t.Errorf("got error %q, expected panic", e)
+	}
+}
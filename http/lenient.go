@@ -0,0 +1,203 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type (
+	// lenientDecoder is a request body decoder that coerces the values of
+	// the JSON body to the types of the decoded value prior to decoding
+	// it.
+	lenientDecoder struct {
+		decoder func(*http.Request) Decoder
+		r       *http.Request
+		lenient bool
+	}
+)
+
+// LenientDecoder wraps the given request decoder so that the values of a JSON
+// request body are coerced to the types of the fields of the decoded value
+// when possible. Strings containing numbers are accepted for number fields and
+// the strings accepted by ParseBool as well as the numbers 1 and 0 are
+// accepted for boolean fields. If lenient is false only the struct fields
+// whose "decode" tag contains "lenient" (and their elements or fields) are
+// coerced. The string values of the struct fields whose "decode" tag contains
+// "trim" are trimmed of leading and trailing white space. Bodies using other
+// media types are decoded by the wrapped decoder as is. The generated code
+// uses LenientDecoder to decode the request bodies of the endpoints that use
+// the LenientDecoding DSL or that define attributes with the "decode:lenient"
+// or "decode:trim" meta.
+func LenientDecoder(decoder func(*http.Request) Decoder, lenient bool) func(*http.Request) Decoder {
+	return func(r *http.Request) Decoder {
+		if !isJSONRequest(r) {
+			return decoder(r)
+		}
+		return &lenientDecoder{decoder: decoder, r: r, lenient: lenient}
+	}
+}
+
+// ParseBool returns the boolean value represented by the string. It accepts
+// "1", "t", "true", "y", "yes" and "on" for true and "0", "f", "false", "n",
+// "no" and "off" for false. The comparison is case insensitive and ignores
+// leading and trailing white space. The generated code uses ParseBool to
+// decode the boolean parameters of the endpoints that use the LenientDecoding
+// DSL.
+func ParseBool(s string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "1", "t", "true", "y", "yes", "on":
+		return true, nil
+	case "0", "f", "false", "n", "no", "off":
+		return false, nil
+	}
+	return false, &strconv.NumError{Func: "ParseBool", Num: s, Err: strconv.ErrSyntax}
+}
+
+// Decode coerces the values of the request body and decodes it into v with
+// the wrapped decoder.
+func (d *lenientDecoder) Decode(v interface{}) error {
+	b, err := ioutil.ReadAll(d.r.Body)
+	if err != nil {
+		// Leave the body as is so that the caller may inspect it, e.g.
+		// with BodyLimitError.
+		return err
+	}
+	d.r.Body = ioutil.NopCloser(bytes.NewReader(b))
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var raw interface{}
+	if err := dec.Decode(&raw); err == nil {
+		if coerced, ok := coerce(raw, reflect.TypeOf(v), d.lenient, false); ok {
+			if cb, err := json.Marshal(coerced); err == nil {
+				d.r.Body = ioutil.NopCloser(bytes.NewReader(cb))
+			}
+		}
+	}
+	// Let the wrapped decoder report invalid and empty bodies.
+	return d.decoder(d.r).Decode(v)
+}
+
+// coerce converts the decoded JSON value raw so that it can be decoded into a
+// value of type t. lenient indicates whether strings are coerced to numbers
+// and booleans and trim whether strings are trimmed. coerce returns the
+// converted value and true if raw was modified, raw and false otherwise.
+func coerce(raw interface{}, t reflect.Type, lenient, trim bool) (interface{}, bool) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() == reflect.Interface || reflect.PtrTo(t).Implements(unmarshalerType) {
+		return raw, false
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := raw.(map[string]interface{})
+		if !ok {
+			return raw, false
+		}
+		fields := jsonFields(t)
+		var changed bool
+		for k, v := range obj {
+			f, ok := lookupField(fields, k)
+			if !ok {
+				continue
+			}
+			fl, ft := decodeTag(f)
+			if nv, ok := coerce(v, f.Type, lenient || fl, trim || ft); ok {
+				obj[k] = nv
+				changed = true
+			}
+		}
+		return obj, changed
+	case reflect.Map:
+		obj, ok := raw.(map[string]interface{})
+		if !ok {
+			return raw, false
+		}
+		var changed bool
+		for k, v := range obj {
+			if nv, ok := coerce(v, t.Elem(), lenient, trim); ok {
+				obj[k] = nv
+				changed = true
+			}
+		}
+		return obj, changed
+	case reflect.Slice, reflect.Array:
+		arr, ok := raw.([]interface{})
+		if !ok {
+			return raw, false
+		}
+		var changed bool
+		for i, e := range arr {
+			if ne, ok := coerce(e, t.Elem(), lenient, trim); ok {
+				arr[i] = ne
+				changed = true
+			}
+		}
+		return arr, changed
+	case reflect.String:
+		if s, ok := raw.(string); ok && trim {
+			ts := strings.TrimSpace(s)
+			return ts, ts != s
+		}
+	case reflect.Bool:
+		if !lenient {
+			break
+		}
+		switch actual := raw.(type) {
+		case string:
+			if b, err := ParseBool(actual); err == nil {
+				return b, true
+			}
+		case json.Number:
+			switch actual {
+			case "1":
+				return true, true
+			case "0":
+				return false, true
+			}
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if !lenient {
+			break
+		}
+		if s, ok := raw.(string); ok {
+			if n := strings.TrimSpace(s); isJSONNumber(n) {
+				return json.Number(n), true
+			}
+		}
+	}
+	return raw, false
+}
+
+// decodeTag returns whether the "decode" tag of the struct field contains
+// "lenient" and "trim".
+func decodeTag(f reflect.StructField) (lenient, trim bool) {
+	tag, ok := f.Tag.Lookup("decode")
+	if !ok {
+		return false, false
+	}
+	for _, opt := range strings.Split(tag, ",") {
+		switch opt {
+		case "lenient":
+			lenient = true
+		case "trim":
+			trim = true
+		}
+	}
+	return
+}
+
+// isJSONNumber returns true if s is a valid JSON number literal.
+func isJSONNumber(s string) bool {
+	if s == "" || s[0] != '-' && (s[0] < '0' || s[0] > '9') {
+		return false
+	}
+	return json.Valid([]byte(s))
+}
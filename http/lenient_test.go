@@ -0,0 +1,112 @@
+package http
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLenientDecoder(t *testing.T) {
+	type (
+		item struct {
+			Count *int  `json:"count,omitempty"`
+			Flag  *bool `json:"flag,omitempty"`
+		}
+		body struct {
+			Count   *int              `json:"count,omitempty"`
+			Price   *float64          `json:"price,omitempty"`
+			Flag    *bool             `json:"flag,omitempty"`
+			Name    *string           `json:"name,omitempty" decode:"trim"`
+			Code    *string           `json:"code,omitempty"`
+			Items   []*item           `json:"items,omitempty"`
+			Tags    map[string]string `json:"tags,omitempty" decode:"trim"`
+			Limit   *int              `json:"limit,omitempty" decode:"lenient"`
+			Sub     *item             `json:"sub,omitempty" decode:"lenient"`
+			Payload interface{}       `json:"payload,omitempty"`
+		}
+	)
+	var (
+		one   = 1
+		two   = 2
+		ten   = 10
+		price = 1.5
+		yes   = true
+		no    = false
+		name  = "foo"
+		code  = " bar "
+	)
+	cases := []struct {
+		Name     string
+		Body     string
+		Lenient  bool
+		Expected body
+		Error    bool
+	}{
+		{"typed", `{"count":1,"flag":true}`, true, body{Count: &one, Flag: &yes}, false},
+		{"numbers", `{"count":" 1 ","price":"1.5","items":[{"count":"2"}]}`, true, body{Count: &one, Price: &price, Items: []*item{{Count: &two}}}, false},
+		{"booleans", `{"flag":"yes","items":[{"flag":0}]}`, true, body{Flag: &yes, Items: []*item{{Flag: &no}}}, false},
+		{"trim", `{"name":" foo ","code":" bar ","tags":{"a":" b "}}`, false, body{Name: &name, Code: &code, Tags: map[string]string{"a": "b"}}, false},
+		{"tagged", `{"limit":"10","sub":{"count":"1","flag":"on"}}`, false, body{Limit: &ten, Sub: &item{Count: &one, Flag: &yes}}, false},
+		{"any", `{"payload":{"count":"1"}}`, true, body{Payload: map[string]interface{}{"count": "1"}}, false},
+		{"not-lenient", `{"count":"1"}`, false, body{}, true},
+		{"not-a-number", `{"count":"one"}`, true, body{}, true},
+		{"not-a-boolean", `{"flag":"maybe"}`, true, body{}, true},
+		{"invalid", `{"count":`, true, body{}, true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/", strings.NewReader(c.Body))
+			var b body
+			err := LenientDecoder(RequestDecoder, c.Lenient)(r).Decode(&b)
+			if c.Error {
+				if err == nil {
+					t.Errorf("got no error, expected one")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %s, expected none", err)
+			}
+			if !reflect.DeepEqual(b, c.Expected) {
+				t.Errorf("got %+v, expected %+v", b, c.Expected)
+			}
+		})
+	}
+}
+
+func TestLenientDecoderNotJSON(t *testing.T) {
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`<body><count>1</count></body>`))
+	r.Header.Set("Content-Type", "application/xml")
+	var b struct {
+		Count int `xml:"count"`
+	}
+	if err := LenientDecoder(RequestDecoder, true)(r).Decode(&b); err != nil {
+		t.Fatalf("got error %s, expected none", err)
+	}
+	if b.Count != 1 {
+		t.Errorf("got count %d, expected 1", b.Count)
+	}
+}
+
+func TestParseBool(t *testing.T) {
+	cases := map[string]bool{
+		"1": true, "t": true, "TRUE": true, " yes ": true, "Y": true, "on": true,
+		"0": false, "f": false, "False": false, "no": false, "N": false, "OFF": false,
+	}
+	for s, expected := range cases {
+		actual, err := ParseBool(s)
+		if err != nil {
+			t.Errorf("%q: got error %s, expected none", s, err)
+			continue
+		}
+		if actual != expected {
+			t.Errorf("%q: got %v, expected %v", s, actual, expected)
+		}
+	}
+	for _, s := range []string{"", "maybe", "2"} {
+		if _, err := ParseBool(s); err == nil {
+			t.Errorf("%q: got no error, expected one", s)
+		}
+	}
+}
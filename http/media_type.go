@@ -0,0 +1,74 @@
+package http
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// CheckContentType returns an "unsupported_media_type" error if the request
+// Content-Type header is set and its media type is not one of the given types.
+// The generated code calls CheckContentType before decoding the requests of
+// the endpoints that use the StrictMediaTypes DSL.
+func CheckContentType(r *http.Request, types ...string) error {
+	ct := r.Header.Get("Content-Type")
+	if ct == "" {
+		return nil
+	}
+	mt, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return goa.PermanentError("unsupported_media_type", "invalid Content-Type header %q: %s", ct, err)
+	}
+	for _, t := range types {
+		if strings.EqualFold(mt, t) {
+			return nil
+		}
+	}
+	return goa.PermanentError("unsupported_media_type", "unsupported content type %q, must be one of %s", mt, strings.Join(types, ", "))
+}
+
+// CheckAccept returns a "not_acceptable" error if the request Accept header is
+// set and none of the given types matches the media ranges it lists. Media
+// ranges with a zero quality value are ignored. The generated code calls
+// CheckAccept before decoding the requests of the endpoints that use the
+// StrictMediaTypes DSL.
+func CheckAccept(r *http.Request, types ...string) error {
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return nil
+	}
+	for _, rg := range strings.Split(accept, ",") {
+		mr, params, err := mime.ParseMediaType(strings.TrimSpace(rg))
+		if err != nil {
+			continue
+		}
+		if q, ok := params["q"]; ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+				continue
+			}
+		}
+		for _, t := range types {
+			if matchMediaRange(mr, t) {
+				return nil
+			}
+		}
+	}
+	return goa.PermanentError("not_acceptable", "none of the media types in the Accept header %q is produced, must accept one of %s", accept, strings.Join(types, ", "))
+}
+
+// matchMediaRange returns true if the media type t matches the media range mr,
+// a media range is either a media type, "type/*" or "*/*".
+func matchMediaRange(mr, t string) bool {
+	t = strings.ToLower(t)
+	switch {
+	case mr == "*/*":
+		return true
+	case strings.HasSuffix(mr, "/*"):
+		return strings.HasPrefix(t, mr[:len(mr)-1])
+	default:
+		return mr == t
+	}
+}
@@ -0,0 +1,74 @@
+package http
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestCheckContentType(t *testing.T) {
+	cases := []struct {
+		Name        string
+		ContentType string
+		Error       string
+	}{
+		{"missing", "", ""},
+		{"match", "application/json", ""},
+		{"match-params", "application/JSON; charset=utf-8", ""},
+		{"mismatch", "text/plain", "unsupported_media_type"},
+		{"invalid", "application/", "unsupported_media_type"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/", nil)
+			if c.ContentType != "" {
+				r.Header.Set("Content-Type", c.ContentType)
+			}
+			assertErrorName(t, CheckContentType(r, "application/json", "application/xml"), c.Error)
+		})
+	}
+}
+
+func TestCheckAccept(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Accept string
+		Error  string
+	}{
+		{"missing", "", ""},
+		{"match", "application/xml", ""},
+		{"list", "text/html, application/json;q=0.9", ""},
+		{"wildcard", "*/*", ""},
+		{"subtype-wildcard", "application/*", ""},
+		{"mismatch", "text/html", "not_acceptable"},
+		{"subtype-wildcard-mismatch", "text/*", "not_acceptable"},
+		{"zero-quality", "application/json;q=0", "not_acceptable"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			if c.Accept != "" {
+				r.Header.Set("Accept", c.Accept)
+			}
+			assertErrorName(t, CheckAccept(r, "application/json", "application/xml"), c.Error)
+		})
+	}
+}
+
+func assertErrorName(t *testing.T, err error, name string) {
+	t.Helper()
+	if name == "" {
+		if err != nil {
+			t.Errorf("got error %q, expected none", err)
+		}
+		return
+	}
+	serr, ok := err.(*goa.ServiceError)
+	if !ok {
+		t.Fatalf("got error %v, expected a service error", err)
+	}
+	if serr.Name != name {
+		t.Errorf("got error name %q, expected %q", serr.Name, name)
+	}
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+
+	goahttp "goa.design/goa/v3/http"
+)
+
+// ProtectCSRF returns a middleware that protects the endpoints that
+// authenticate requests with cookies against Cross-Site Request Forgery. The
+// responses to safe requests carry the CSRF token, unsafe requests that carry
+// the session cookie but no valid token get a 403 response.
+//
+// example of use:
+//  p := &goahttp.CSRFProtector{SessionCookie: "SID", Secret: secret}
+//  handler = middleware.ProtectCSRF(p)(handler)
+func ProtectCSRF(p *goahttp.CSRFProtector) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if goahttp.IsSafeMethod(r.Method) {
+				if _, err := p.Issue(w, r); err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+					return
+				}
+			} else if err := p.Verify(r); err != nil {
+				http.Error(w, err.Error(), http.StatusForbidden)
+				return
+			}
+			h.ServeHTTP(w, r)
+		})
+	}
+}
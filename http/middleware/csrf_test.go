@@ -0,0 +1,47 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goahttp "goa.design/goa/v3/http"
+	httpm "goa.design/goa/v3/http/middleware"
+)
+
+func TestProtectCSRF(t *testing.T) {
+	var (
+		p       = &goahttp.CSRFProtector{SessionCookie: "SID", Secret: []byte("secret")}
+		session = &http.Cookie{Name: "SID", Value: "session"}
+		called  bool
+	)
+	h := httpm.ProtectCSRF(p)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	serve := func(method, token string) *httptest.ResponseRecorder {
+		called = false
+		req := httptest.NewRequest(method, "/", nil)
+		req.AddCookie(session)
+		if token != "" {
+			req.Header.Set(goahttp.DefaultCSRFHeader, token)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve("GET", "")
+	token := w.Header().Get(goahttp.DefaultCSRFHeader)
+	if w.Code != http.StatusOK || !called || token == "" {
+		t.Fatalf("got status %d, called %v and token %q for safe request", w.Code, called, token)
+	}
+	if w := serve("POST", ""); w.Code != http.StatusForbidden || called {
+		t.Errorf("got status %d and called %v for request without token, expected 403", w.Code, called)
+	}
+	if w := serve("DELETE", "invalid"); w.Code != http.StatusForbidden || called {
+		t.Errorf("got status %d and called %v for request with invalid token, expected 403", w.Code, called)
+	}
+	if w := serve("PUT", token); w.Code != http.StatusOK || !called {
+		t.Errorf("got status %d and called %v for request with valid token, expected 200", w.Code, called)
+	}
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// Locale returns a middleware which initializes the context with the locale
+// used to render the error messages, see goa.WithLocale. The locale is the
+// language listed in the "Accept-Language" request header with the highest
+// quality for which a message catalog was registered with
+// goa.RegisterMessageCatalog. The context is left unchanged if there is no
+// such language so that the default messages are used.
+//
+// example of use:
+//  handler = middleware.Locale()(handler)
+func Locale() func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, l := range acceptedLanguages(r.Header.Get("Accept-Language")) {
+				if goa.HasMessageCatalog(l) {
+					r = r.WithContext(goa.WithLocale(r.Context(), l))
+					break
+				}
+			}
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
+// acceptedLanguages returns the languages listed in the given Accept-Language
+// header value sorted by decreasing quality. Languages with a zero quality and
+// the wildcard are omitted.
+func acceptedLanguages(header string) []string {
+	type lang struct {
+		tag string
+		q   float64
+	}
+	var langs []lang
+	for _, part := range strings.Split(header, ",") {
+		elems := strings.Split(part, ";")
+		tag := strings.TrimSpace(elems[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		for _, p := range elems[1:] {
+			p = strings.TrimSpace(p)
+			if strings.HasPrefix(p, "q=") {
+				if v, err := strconv.ParseFloat(p[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		langs = append(langs, lang{tag, q})
+	}
+	sort.SliceStable(langs, func(i, j int) bool { return langs[i].q > langs[j].q })
+	res := make([]string, len(langs))
+	for i, l := range langs {
+		res[i] = l.tag
+	}
+	return res
+}
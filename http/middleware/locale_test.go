@@ -0,0 +1,49 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpm "goa.design/goa/v3/http/middleware"
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestLocale(t *testing.T) {
+	if err := goa.RegisterMessageCatalog("fr", goa.MessageCatalog{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := goa.RegisterMessageCatalog("de-CH", goa.MessageCatalog{}); err != nil {
+		t.Fatal(err)
+	}
+	cases := map[string]struct {
+		Header   string
+		Expected string
+	}{
+		"no header":   {"", ""},
+		"no catalog":  {"es, en;q=0.8", ""},
+		"exact":       {"de-CH", "de-CH"},
+		"base":        {"fr-CA, en;q=0.8", "fr-CA"},
+		"quality":     {"en;q=0.9, es;q=0.8, de-ch, fr;q=0.5", "de-ch"},
+		"zero":        {"fr;q=0, es", ""},
+		"wildcard":    {"*", ""},
+		"invalid q":   {"fr;q=abc", "fr"},
+		"with spaces": {" es ; q=0.9 ,  fr ; q=0.8", "fr"},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			var locale string
+			h := httpm.Locale()(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				locale = goa.ContextLocale(r.Context())
+			}))
+			req := httptest.NewRequest("GET", "/", nil)
+			if c.Header != "" {
+				req.Header.Set("Accept-Language", c.Header)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), req)
+			if locale != c.Expected {
+				t.Errorf("got locale %q, expected %q", locale, c.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	goahttp "goa.design/goa/v3/http"
+	goa "goa.design/goa/v3/pkg"
+)
+
+// Recover returns a middleware that recovers from the panics raised by the
+// handlers, reports them with r and writes the fault error encoded with
+// encoder in a 500 Internal Server Error response. Panics with the value
+// http.ErrAbortHandler are raised again so that the server aborts the response.
+//
+// example of use:
+//  handler = middleware.Recover(reporter, goahttp.ResponseEncoder)(handler)
+func Recover(r goa.PanicReporter, encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(http.Handler) http.Handler {
+	encodeError := goahttp.ErrorEncoder(encoder)
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			defer func() {
+				p := recover()
+				if p == nil {
+					return
+				}
+				if p == http.ErrAbortHandler {
+					panic(p)
+				}
+				ctx := req.Context()
+				encodeError(ctx, w, goa.PanicError(ctx, r, p))
+			}()
+			h.ServeHTTP(w, req)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goahttp "goa.design/goa/v3/http"
+	httpm "goa.design/goa/v3/http/middleware"
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestRecover(t *testing.T) {
+	var reported interface{}
+	reporter := goa.PanicReporterFunc(func(_ context.Context, p interface{}, stack []byte) {
+		if len(stack) == 0 {
+			t.Error("got empty stack trace")
+		}
+		reported = p
+	})
+	h := httpm.Recover(reporter, goahttp.ResponseEncoder)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+	if reported != "boom" {
+		t.Errorf("got reported panic %v, expected boom", reported)
+	}
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusInternalServerError)
+	}
+	var resp goahttp.ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode error response: %s", err)
+	}
+	if !resp.Fault || resp.Name != "fault" {
+		t.Errorf("got error %+v, expected fault", resp)
+	}
+}
+
+func TestRecoverAbortHandler(t *testing.T) {
+	h := httpm.Recover(nil, goahttp.ResponseEncoder)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	defer func() {
+		if p := recover(); p != http.ErrAbortHandler {
+			t.Errorf("got panic %v, expected %v", p, http.ErrAbortHandler)
+		}
+	}()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+}
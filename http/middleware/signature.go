@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+
+	goahttp "goa.design/goa/v3/http"
+	"goa.design/goa/v3/security"
+)
+
+// VerifySignature returns a middleware that verifies the signature of the
+// requests signed with goahttp.RequestSigner. Requests with an invalid,
+// expired or replayed signature get a 401 response, requests whose body is too
+// large to be verified get a 413 response. The ID of the key that
+// signed a valid request is stored in the request context, see
+// security.ContextSignatureKeyID. Unsigned requests are passed to the handler
+// as is so that the endpoint authorization function may reject them or not.
+//
+// example of use:
+//  v := &goahttp.SignatureVerifier{Keys: keys, ClockSkew: 5 * time.Minute}
+//  handler = middleware.VerifySignature(v)(handler)
+func VerifySignature(v *goahttp.SignatureVerifier) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			keyID, err := v.Verify(r)
+			if err == goahttp.ErrSignatureMissing {
+				h.ServeHTTP(w, r)
+				return
+			}
+			if err == goahttp.ErrSignatureBodyTooLarge {
+				http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+				return
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+			h.ServeHTTP(w, r.WithContext(security.WithSignatureKeyID(r.Context(), keyID)))
+		})
+	}
+}
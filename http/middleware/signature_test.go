@@ -0,0 +1,63 @@
+package middleware_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	goahttp "goa.design/goa/v3/http"
+	httpm "goa.design/goa/v3/http/middleware"
+	"goa.design/goa/v3/security"
+)
+
+func TestVerifySignature(t *testing.T) {
+	var (
+		secret = []byte("secret")
+		body   = `{"id":"1"}`
+		keys   = func(context.Context, string) ([]byte, error) { return secret, nil }
+	)
+	cases := []struct {
+		Name   string
+		Signer *goahttp.RequestSigner
+		KeyID  string
+		Status int
+	}{
+		{"valid", &goahttp.RequestSigner{KeyID: "key", Secret: secret}, "key", http.StatusOK},
+		{"unsigned", nil, "", http.StatusOK},
+		{"wrong secret", &goahttp.RequestSigner{KeyID: "key", Secret: []byte("other")}, "", http.StatusUnauthorized},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var (
+				got   string
+				keyID string
+			)
+			v := &goahttp.SignatureVerifier{Keys: keys}
+			h := httpm.VerifySignature(v)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := ioutil.ReadAll(r.Body)
+				got = string(b)
+				keyID, _ = security.ContextSignatureKeyID(r.Context())
+			}))
+			req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+			if c.Signer != nil {
+				if err := c.Signer.Sign(req); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+			if w.Code != c.Status {
+				t.Errorf("got status %d, expected %d", w.Code, c.Status)
+			}
+			if keyID != c.KeyID {
+				t.Errorf("got key ID %q, expected %q", keyID, c.KeyID)
+			}
+			if c.Status == http.StatusOK && got != body {
+				t.Errorf("got body %q, expected %q", got, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	goahttp "goa.design/goa/v3/http"
+)
+
+// VerifyWebhook returns a middleware that rejects the webhook requests whose
+// Webhook-Signature header was not computed with secret for the request body
+// or whose timestamp is not within tolerance of the current time. A tolerance
+// of zero disables the timestamp check. The rejected requests get a 401
+// response, see goahttp.VerifyWebhook for the details of the verification.
+// The request body is made available to the handler unchanged.
+//
+// example of use:
+//  handler = middleware.VerifyWebhook(secret, 5*time.Minute)(handler)
+func VerifyWebhook(secret []byte, tolerance time.Duration) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body []byte
+			if r.Body != nil {
+				b, err := ioutil.ReadAll(r.Body)
+				r.Body.Close()
+				if err != nil {
+					http.Error(w, "failed to read webhook body", http.StatusBadRequest)
+					return
+				}
+				body = b
+			}
+			sig := r.Header.Get(goahttp.WebhookSignatureHeader)
+			if err := goahttp.VerifyWebhook(secret, sig, body, time.Now(), tolerance); err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+			h.ServeHTTP(w, r)
+		})
+	}
+}
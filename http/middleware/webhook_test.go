@@ -0,0 +1,51 @@
+package middleware_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	goahttp "goa.design/goa/v3/http"
+	httpm "goa.design/goa/v3/http/middleware"
+)
+
+func TestVerifyWebhook(t *testing.T) {
+	var (
+		secret = []byte("secret")
+		body   = `{"id":"1"}`
+	)
+	cases := []struct {
+		Name      string
+		Signature string
+		Status    int
+	}{
+		{"valid", goahttp.SignWebhook(secret, time.Now(), []byte(body)), http.StatusOK},
+		{"missing", "", http.StatusUnauthorized},
+		{"wrong secret", goahttp.SignWebhook([]byte("other"), time.Now(), []byte(body)), http.StatusUnauthorized},
+		{"expired", goahttp.SignWebhook(secret, time.Now().Add(-time.Hour), []byte(body)), http.StatusUnauthorized},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var got string
+			h := httpm.VerifyWebhook(secret, 5*time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := ioutil.ReadAll(r.Body)
+				got = string(b)
+			}))
+			req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+			if c.Signature != "" {
+				req.Header.Set(goahttp.WebhookSignatureHeader, c.Signature)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+			if w.Code != c.Status {
+				t.Errorf("got status %d, expected %d", w.Code, c.Status)
+			}
+			if c.Status == http.StatusOK && got != body {
+				t.Errorf("got body %q, expected %q", got, body)
+			}
+		})
+	}
+}
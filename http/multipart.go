@@ -0,0 +1,60 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+// DefaultPartMemory is the maximum number of bytes of a multipart file part
+// kept in memory by the generated default multipart decoders, the content of
+// larger parts is written to a temporary file.
+const DefaultPartMemory = 10 << 20
+
+// spooledPart is the reader returned by SpoolPart for parts written to a
+// temporary file.
+type spooledPart struct {
+	*os.File
+}
+
+// SpoolPart reads r, typically a *multipart.Part, and returns a reader over
+// its content. The content is kept in memory if it does not exceed maxMemory
+// bytes and is written to a temporary file otherwise. Closing the returned
+// reader removes the file. The generated default multipart decoders use
+// SpoolPart to read the parts mapped to payload attributes whose Go type is
+// io.Reader or io.ReadCloser so that the size of the uploaded files is not
+// bounded by the server memory.
+func SpoolPart(r io.Reader, maxMemory int64) (io.ReadCloser, error) {
+	var buf bytes.Buffer
+	n, err := io.CopyN(&buf, r, maxMemory+1)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if n <= maxMemory {
+		return ioutil.NopCloser(&buf), nil
+	}
+	f, err := ioutil.TempFile("", "goa-part-")
+	if err != nil {
+		return nil, err
+	}
+	p := &spooledPart{File: f}
+	if _, err := io.Copy(f, io.MultiReader(&buf, r)); err != nil {
+		p.Close()
+		return nil, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		p.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+// Close closes and removes the temporary file.
+func (p *spooledPart) Close() error {
+	err := p.File.Close()
+	if rerr := os.Remove(p.Name()); err == nil {
+		err = rerr
+	}
+	return err
+}
@@ -0,0 +1,48 @@
+package http
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSpoolPart(t *testing.T) {
+	cases := []struct {
+		Name      string
+		Content   string
+		MaxMemory int64
+		File      bool
+	}{
+		{"empty", "", 4, false},
+		{"in memory", "part", 4, false},
+		{"temporary file", "large part", 4, true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r, err := SpoolPart(strings.NewReader(c.Content), c.MaxMemory)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f, ok := r.(*spooledPart)
+			if ok != c.File {
+				t.Errorf("got temporary file %v, expected %v", ok, c.File)
+			}
+			b, err := ioutil.ReadAll(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != c.Content {
+				t.Errorf("got content %q, expected %q", string(b), c.Content)
+			}
+			if err := r.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if ok {
+				if _, err := os.Stat(f.Name()); !os.IsNotExist(err) {
+					t.Errorf("got error %v, expected the temporary file to be removed", err)
+				}
+			}
+		})
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"regexp"
+	"strings"
 
 	"github.com/dimfeld/httptreemux"
 )
@@ -31,7 +33,20 @@ type (
 	//     example the pattern "/images/{*filename}" captures
 	//     "/images/public/thumbnail.jpg" and associates the key key
 	//     "filename" with "public/thumbnail.jpg" in the map returned by
-	//     Vars.
+	//     Vars. The form "{name*}" is equivalent.
+	//
+	// The default implementation returned by NewMuxer also supports optional
+	// label and matrix style wildcards at the end of the pattern:
+	//
+	//   - "{.name}" wildcards capture the value that follows a dot, for
+	//     example the pattern "/report{.format}" matches both "/report" and
+	//     "/report.json" and associates the key "format" with "json" in
+	//     the latter case.
+	//
+	//   - "{;name}" wildcards capture the value of a matrix parameter, for
+	//     example the pattern "/cars{;color}" matches both "/cars" and
+	//     "/cars;color=blue" and associates the key "color" with "blue" in
+	//     the latter case.
 	//
 	// The names of wildcards must match the regular expression
 	// "[a-zA-Z0-9_]+".
@@ -56,9 +71,34 @@ type (
 	// "{*wildcard}" respectively.
 	mux struct {
 		*httptreemux.ContextMux
+		// styled lists the patterns that end with label or matrix
+		// style wildcards.
+		styled []*styledPattern
 	}
+
+	// styledPattern describes the label and matrix style wildcards that
+	// appear at the end of a pattern.
+	styledPattern struct {
+		// names lists the names of the styled wildcards in order of
+		// appearance.
+		names []string
+		// suffix matches the values of the styled wildcards at the end
+		// of the last path segment.
+		suffix *regexp.Regexp
+		// last is the name of the wildcard that captures the last
+		// segment of the pattern preceding the styled wildcards if any.
+		last string
+	}
+
+	// styledKey is the private type used to store the styled wildcard
+	// values stripped from the request path in the request context.
+	styledKey int
 )
 
+// styledSuffixKey is the request context key used to store the styled wildcard
+// values stripped from the request path.
+const styledSuffixKey styledKey = iota + 1
+
 // NewMuxer returns a Muxer implementation based on the httptreemux router.
 func NewMuxer() Muxer {
 	r := httptreemux.NewContextMux()
@@ -69,24 +109,142 @@ func NewMuxer() Muxer {
 		w.WriteHeader(http.StatusNotFound)
 		enc.Encode(NewErrorResponse(fmt.Errorf("404 page not found")))
 	}
-	return &mux{r}
+	return &mux{ContextMux: r}
 }
 
 // Handle maps the wildcard format used by goa to the one used by httptreemux.
 func (m *mux) Handle(method, pattern string, handler http.HandlerFunc) {
+	if loc := wildStyled.FindStringIndex(pattern); loc != nil {
+		sp := newStyledPattern(pattern[:loc[0]], pattern[loc[0]:])
+		m.styled = append(m.styled, sp)
+		handler = sp.handle(handler)
+		pattern = pattern[:loc[0]]
+		if pattern == "" {
+			pattern = "/"
+		}
+	}
 	m.ContextMux.Handle(method, treemuxify(pattern), handler)
 }
 
+// ServeHTTP dispatches the request to the registered handler. Requests whose
+// path does not match any pattern but ends with the values of label or matrix
+// style wildcards are dispatched to the handler of the corresponding pattern.
+func (m *mux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if len(m.styled) > 0 {
+		if _, ok := m.Lookup(w, r); !ok {
+			if sr := m.stripStyled(w, r); sr != nil {
+				r = sr
+			}
+		}
+	}
+	m.ContextMux.ServeHTTP(w, r)
+}
+
 // Vars extracts the path variables from the request context.
 func (m *mux) Vars(r *http.Request) map[string]string {
 	return httptreemux.ContextParams(r.Context())
 }
 
+// stripStyled returns a copy of r whose path does not include the trailing
+// label and matrix style wildcard values if the resulting path matches a
+// registered pattern, nil otherwise.
+func (m *mux) stripStyled(w http.ResponseWriter, r *http.Request) *http.Request {
+	path := r.RequestURI
+	if path == "" {
+		path = r.URL.EscapedPath()
+	} else if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	seg := path[strings.LastIndexByte(path, '/')+1:]
+	for _, sp := range m.styled {
+		if sp.last != "" {
+			// The router already matches the path.
+			continue
+		}
+		loc := sp.suffix.FindStringIndex(seg)
+		if loc == nil || loc[0] == len(seg) {
+			continue
+		}
+		suffix := seg[loc[0]:]
+		unescaped, err := url.PathUnescape(suffix)
+		if err != nil {
+			continue
+		}
+		u := *r.URL
+		u.Path = strings.TrimSuffix(u.Path, unescaped)
+		u.RawPath = ""
+		sr := r.WithContext(context.WithValue(r.Context(), styledSuffixKey, suffix))
+		sr.URL = &u
+		sr.RequestURI = u.RequestURI()
+		if _, ok := m.Lookup(w, sr); ok {
+			return sr
+		}
+	}
+	return nil
+}
+
+// newStyledPattern returns the styled pattern for the given styled wildcards
+// that follow base.
+func newStyledPattern(base, styled string) *styledPattern {
+	var (
+		names []string
+		expr  strings.Builder
+	)
+	for _, m := range wildStyledParam.FindAllStringSubmatch(styled, -1) {
+		names = append(names, m[2])
+		if m[1] == "." {
+			expr.WriteString(`(?:\.([^/.;]*))?`)
+		} else {
+			expr.WriteString(`(?:;` + regexp.QuoteMeta(m[2]) + `=([^/;]*))?`)
+		}
+	}
+	expr.WriteString("$")
+	var last string
+	if m := wildLastSeg.FindStringSubmatch(base); m != nil {
+		last = m[1]
+	}
+	return &styledPattern{names: names, suffix: regexp.MustCompile(expr.String()), last: last}
+}
+
+// handle returns a handler that adds the styled wildcard values to the path
+// variables prior to calling h.
+func (sp *styledPattern) handle(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := httptreemux.ContextParams(r.Context())
+		vars := make(map[string]string, len(params)+len(sp.names))
+		for k, v := range params {
+			vars[k] = v
+		}
+		var seg string
+		if sp.last != "" {
+			seg = vars[sp.last]
+		} else if s, ok := r.Context().Value(styledSuffixKey).(string); ok {
+			seg = s
+		}
+		if loc := sp.suffix.FindStringSubmatchIndex(seg); loc != nil {
+			for i, n := range sp.names {
+				if start := loc[2*i+2]; start >= 0 {
+					vars[n] = seg[start:loc[2*i+3]]
+				}
+			}
+			if sp.last != "" {
+				vars[sp.last] = seg[:loc[0]]
+			}
+		}
+		h(w, r.WithContext(httptreemux.AddParamsToContext(r.Context(), vars)))
+	}
+}
+
 var wildSeg = regexp.MustCompile(`/{([a-zA-Z0-9_]+)}`)
 var wildPath = regexp.MustCompile(`/{\*([a-zA-Z0-9_]+)}`)
+var wildPathSuffix = regexp.MustCompile(`/{([a-zA-Z0-9_]+)\*}`)
+var wildStyled = regexp.MustCompile(`({[.;][a-zA-Z0-9_]+})+$`)
+var wildStyledParam = regexp.MustCompile(`{([.;])([a-zA-Z0-9_]+)}`)
+var wildLastSeg = regexp.MustCompile(`/{([a-zA-Z0-9_]+)}$`)
 
 func treemuxify(pattern string) string {
 	pattern = wildSeg.ReplaceAllString(pattern, "/:$1")
 	pattern = wildPath.ReplaceAllString(pattern, "/*$1")
+	pattern = wildPathSuffix.ReplaceAllString(pattern, "/*$1")
 	return pattern
 }
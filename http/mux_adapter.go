@@ -0,0 +1,163 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type (
+	// RouterSyntax describes the syntax a third party router uses to define
+	// path wildcards.
+	RouterSyntax struct {
+		// Segment is the format of the wildcards that capture a single
+		// path segment, "%s" is replaced with the wildcard name.
+		Segment string
+		// CatchAll is the format of the wildcards that capture the rest
+		// of the path. "%s" is replaced with the wildcard name, routers
+		// whose catch-all wildcards cannot be named use "*".
+		CatchAll string
+		// CatchAllSlash is true if the router includes the leading slash
+		// in the values captured by catch-all wildcards.
+		CatchAllSlash bool
+		// CatchAllEmpty is true if the router catch-all wildcards also
+		// match an empty value and the router rejects a pattern that
+		// ends with the slash that precedes a catch-all wildcard of
+		// another pattern.
+		CatchAllEmpty bool
+	}
+
+	// RouteHandler is the handler registered with third party routers by
+	// the Muxer returned by NewMuxAdapter. param returns the value captured
+	// by the router for the router wildcard with the given name.
+	RouteHandler func(w http.ResponseWriter, r *http.Request, param func(name string) string)
+
+	// RouteFunc registers h with a third party router for the given HTTP
+	// method and router pattern.
+	RouteFunc func(method, pattern string, h RouteHandler)
+
+	// muxAdapter is the Muxer implementation returned by NewMuxAdapter.
+	muxAdapter struct {
+		syntax  RouterSyntax
+		handler http.Handler
+		route   RouteFunc
+		// catchAlls records the methods and patterns that precede the
+		// catch-all wildcards of the registered patterns.
+		catchAlls map[string]bool
+	}
+
+	// routerWildcard maps a goa wildcard to the wildcard of the router
+	// pattern.
+	routerWildcard struct {
+		// name is the goa wildcard name.
+		name string
+		// param is the name of the router wildcard.
+		param string
+		// catchAll is true if the wildcard captures the rest of the
+		// path.
+		catchAll bool
+	}
+
+	// muxVarsKey is the private type used to store the path variables in
+	// the request context.
+	muxVarsKey struct{}
+)
+
+var (
+	// ChiSyntax is the wildcard syntax of the chi router.
+	ChiSyntax = RouterSyntax{Segment: "{%s}", CatchAll: "*"}
+	// EchoSyntax is the wildcard syntax of the echo router.
+	EchoSyntax = RouterSyntax{Segment: ":%s", CatchAll: "*"}
+	// GinSyntax is the wildcard syntax of the gin router.
+	GinSyntax = RouterSyntax{Segment: ":%s", CatchAll: "*%s", CatchAllSlash: true, CatchAllEmpty: true}
+	// GorillaSyntax is the wildcard syntax of the gorilla router.
+	GorillaSyntax = RouterSyntax{Segment: "{%s}", CatchAll: "{%s:.*}"}
+)
+
+// NewMuxAdapter returns a Muxer that registers the handlers of the generated
+// servers with a third party router. syntax is the router wildcard syntax,
+// handler dispatches the requests (usually the router itself) and route
+// registers handlers with the router. The label and matrix style wildcards
+// are not supported, Handle panics if a pattern uses them.
+//
+// The handlers are registered natively with the router so that middleware
+// added to the router wraps the handlers and thus runs before the middleware
+// mounted on the generated servers.
+func NewMuxAdapter(syntax RouterSyntax, handler http.Handler, route RouteFunc) Muxer {
+	return &muxAdapter{syntax: syntax, handler: handler, route: route, catchAlls: make(map[string]bool)}
+}
+
+// Handle translates pattern into the router syntax and registers the handler
+// with the router. If the router catch-all wildcards match empty values then
+// Handle does not register a pattern that ends with the slash that precedes
+// the catch-all wildcard of a pattern registered previously with the same
+// method, such as the patterns mounted by the generated directory file
+// servers, the router dispatches the corresponding requests to the catch-all
+// handler.
+func (m *muxAdapter) Handle(method, pattern string, handler http.HandlerFunc) {
+	p, wcs, err := m.syntax.pattern(pattern)
+	if err != nil {
+		panic(err)
+	}
+	if m.syntax.CatchAllEmpty {
+		if m.catchAlls[method+" "+pattern] {
+			return
+		}
+		if len(wcs) > 0 && wcs[len(wcs)-1].catchAll {
+			m.catchAlls[method+" "+pattern[:strings.LastIndex(pattern, "/")+1]] = true
+		}
+	}
+	m.route(method, p, func(w http.ResponseWriter, r *http.Request, param func(string) string) {
+		vars := make(map[string]string, len(wcs))
+		for _, wc := range wcs {
+			v := param(wc.param)
+			if wc.catchAll && m.syntax.CatchAllSlash {
+				v = strings.TrimPrefix(v, "/")
+			}
+			vars[wc.name] = v
+		}
+		handler(w, r.WithContext(context.WithValue(r.Context(), muxVarsKey{}, vars)))
+	})
+}
+
+// ServeHTTP dispatches the request to the router.
+func (m *muxAdapter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.handler.ServeHTTP(w, r)
+}
+
+// Vars returns the path variables captured by the router for the request.
+func (m *muxAdapter) Vars(r *http.Request) map[string]string {
+	vars, _ := r.Context().Value(muxVarsKey{}).(map[string]string)
+	return vars
+}
+
+// pattern translates the goa pattern into the router syntax and returns the
+// wildcards it defines. It returns an error if the pattern uses label or matrix
+// style wildcards.
+func (s RouterSyntax) pattern(pattern string) (string, []*routerWildcard, error) {
+	if wildStyled.MatchString(pattern) {
+		return "", nil, fmt.Errorf("goa: label and matrix style wildcards are not supported by router adapters: %q", pattern)
+	}
+	var wcs []*routerWildcard
+	segs := strings.Split(pattern, "/")
+	for i, seg := range segs {
+		if len(seg) < 3 || seg[0] != '{' || seg[len(seg)-1] != '}' {
+			continue
+		}
+		name := seg[1 : len(seg)-1]
+		if !strings.HasPrefix(name, "*") && !strings.HasSuffix(name, "*") {
+			wcs = append(wcs, &routerWildcard{name: name, param: name})
+			segs[i] = strings.Replace(s.Segment, "%s", name, 1)
+			continue
+		}
+		name = strings.Trim(name, "*")
+		param := name
+		if !strings.Contains(s.CatchAll, "%s") {
+			param = s.CatchAll
+		}
+		wcs = append(wcs, &routerWildcard{name: name, param: param, catchAll: true})
+		segs[i] = strings.Replace(s.CatchAll, "%s", name, 1)
+	}
+	return strings.Join(segs, "/"), wcs, nil
+}
@@ -0,0 +1,99 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestRouterSyntaxPattern(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Syntax   RouterSyntax
+		Pattern  string
+		Expected string
+		Params   map[string]string
+	}{
+		{"chi segment", ChiSyntax, "/users/{id}", "/users/{id}", map[string]string{"id": "id"}},
+		{"chi catch all", ChiSyntax, "/files/{*path}", "/files/*", map[string]string{"path": "*"}},
+		{"echo segment", EchoSyntax, "/users/{id}/posts/{pid}", "/users/:id/posts/:pid", map[string]string{"id": "id", "pid": "pid"}},
+		{"echo catch all", EchoSyntax, "/files/{path*}", "/files/*", map[string]string{"path": "*"}},
+		{"gin catch all", GinSyntax, "/files/{*path}", "/files/*path", map[string]string{"path": "path"}},
+		{"gorilla catch all", GorillaSyntax, "/{id}/files/{*path}", "/{id}/files/{path:.*}", map[string]string{"id": "id", "path": "path"}},
+		{"no wildcard", GinSyntax, "/users", "/users", map[string]string{}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			p, wcs, err := c.Syntax.pattern(c.Pattern)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if p != c.Expected {
+				t.Errorf("got pattern %q, expected %q", p, c.Expected)
+			}
+			params := make(map[string]string)
+			for _, wc := range wcs {
+				params[wc.name] = wc.param
+			}
+			if !reflect.DeepEqual(params, c.Params) {
+				t.Errorf("got params %v, expected %v", params, c.Params)
+			}
+		})
+	}
+	if _, _, err := ChiSyntax.pattern("/report{.format}"); err == nil {
+		t.Error("expected an error for label style wildcards")
+	}
+}
+
+func TestMuxAdapter(t *testing.T) {
+	var (
+		routes = make(map[string]RouteHandler)
+		params = map[string]string{"id": "42", "path": "/a/b"}
+		router = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			routes[r.Method+" "+r.URL.Path](w, r, func(n string) string { return params[n] })
+		})
+		vars map[string]string
+	)
+	m := NewMuxAdapter(GinSyntax, router, func(method, pattern string, h RouteHandler) {
+		routes[method+" "+pattern] = h
+	})
+	m.Handle("GET", "/users/{id}/files/{*path}", func(w http.ResponseWriter, r *http.Request) {
+		vars = m.Vars(r)
+	})
+	if _, ok := routes["GET /users/:id/files/*path"]; !ok {
+		t.Fatalf("route not registered, got %v", routes)
+	}
+	m.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/users/:id/files/*path", nil))
+	expected := map[string]string{"id": "42", "path": "a/b"}
+	if !reflect.DeepEqual(vars, expected) {
+		t.Errorf("got vars %v, expected %v", vars, expected)
+	}
+}
+
+func TestMuxAdapterCatchAllEmpty(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Syntax   RouterSyntax
+		Patterns []string
+		Expected []string
+	}{
+		{"gin", GinSyntax, []string{"/files/{*path}", "/files/", "/users/"}, []string{"/files/*path", "/users/"}},
+		{"gin slash first", GinSyntax, []string{"/files/", "/files/{*path}"}, []string{"/files/", "/files/*path"}},
+		{"chi", ChiSyntax, []string{"/files/{*path}", "/files/"}, []string{"/files/*", "/files/"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var routes []string
+			m := NewMuxAdapter(c.Syntax, http.NotFoundHandler(), func(method, pattern string, h RouteHandler) {
+				routes = append(routes, pattern)
+			})
+			for _, p := range c.Patterns {
+				m.Handle("GET", p, func(w http.ResponseWriter, r *http.Request) {})
+			}
+			if !reflect.DeepEqual(routes, c.Expected) {
+				t.Errorf("got routes %v, expected %v", routes, c.Expected)
+			}
+		})
+	}
+}
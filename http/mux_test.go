@@ -1,6 +1,11 @@
 package http
 
-import "testing"
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
 
 func TestMuxRegexp(t *testing.T) {
 	cases := []struct{ Name, Pattern, Expected string }{
@@ -16,6 +21,8 @@ func TestMuxRegexp(t *testing.T) {
 		{"segment 4", "/a/{b}/c", "/a/:b/c"},
 		{"path", "/{*a}", "/*a"},
 		{"path 2", "/a/{*b}", "/a/*b"},
+		{"path suffix", "/{a*}", "/*a"},
+		{"path suffix 2", "/a/{b*}", "/a/*b"},
 	}
 	for _, c := range cases {
 		actual := treemuxify(c.Pattern)
@@ -24,3 +31,63 @@ func TestMuxRegexp(t *testing.T) {
 		}
 	}
 }
+
+func TestMuxStyledWildcards(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Pattern  string
+		Path     string
+		Expected map[string]string
+	}{
+		{"label absent", "/report{.format}", "/report", map[string]string{}},
+		{"label", "/report{.format}", "/report.json", map[string]string{"format": "json"}},
+		{"matrix", "/cars{;color}", "/cars;color=blue", map[string]string{"color": "blue"}},
+		{"label and matrix", "/cars{.format}{;color}", "/cars.xml;color=red", map[string]string{"format": "xml", "color": "red"}},
+		{"matrix only", "/cars{.format}{;color}", "/cars;color=red", map[string]string{"color": "red"}},
+		{"segment absent", "/users/{id}{.format}", "/users/42", map[string]string{"id": "42"}},
+		{"segment", "/users/{id}{.format}", "/users/42.json", map[string]string{"id": "42", "format": "json"}},
+		{"segment with dots", "/users/{id}{.format}", "/users/a.b.json", map[string]string{"id": "a.b", "format": "json"}},
+		{"catch all", "/files/{path*}", "/files/a/b", map[string]string{"path": "a/b"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var vars map[string]string
+			m := NewMuxer()
+			m.Handle("GET", c.Pattern, func(w http.ResponseWriter, r *http.Request) {
+				vars = m.Vars(r)
+			})
+			w := httptest.NewRecorder()
+			m.ServeHTTP(w, httptest.NewRequest("GET", c.Path, nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("got status %d, expected %d", w.Code, http.StatusOK)
+			}
+			if !reflect.DeepEqual(vars, c.Expected) {
+				t.Errorf("got vars %v, expected %v", vars, c.Expected)
+			}
+		})
+	}
+}
+
+func TestMuxStyledWildcardsNotFound(t *testing.T) {
+	m := NewMuxer()
+	m.Handle("GET", "/report{.format}", func(w http.ResponseWriter, r *http.Request) {})
+	m.Handle("GET", "/report.csv", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusAccepted) })
+	cases := []struct {
+		Name     string
+		Path     string
+		Expected int
+	}{
+		{"static takes precedence", "/report.csv", http.StatusAccepted},
+		{"unknown prefix", "/reports.json", http.StatusNotFound},
+		{"extra segment", "/report/x.json", http.StatusNotFound},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			m.ServeHTTP(w, httptest.NewRequest("GET", c.Path, nil))
+			if w.Code != c.Expected {
+				t.Errorf("got status %d, expected %d", w.Code, c.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+)
+
+type (
+	// pooledJSONEncoder is a JSON encoder that encodes the values in a
+	// pooled buffer before writing them to the underlying writer.
+	pooledJSONEncoder struct {
+		w io.Writer
+	}
+
+	// pooledRequestEncoder is a JSON encoder that encodes the values in a
+	// pooled buffer used as request body.
+	pooledRequestEncoder struct {
+		r *http.Request
+	}
+
+	// jsonBuffer is a buffer and a JSON encoder writing to it.
+	jsonBuffer struct {
+		buf   bytes.Buffer
+		enc   Encoder
+		codec *jsonCodecHolder
+	}
+
+	// pooledBody is a request body that reads a pooled buffer. The buffer
+	// is held until the round trip is done and the body as well as the
+	// bodies returned by GetBody are closed so that the body can be
+	// replayed without copying it.
+	pooledBody struct {
+		mu sync.Mutex
+		jb *jsonBuffer
+		r  *bytes.Reader
+		// closed is true once the body is closed.
+		closed bool
+		// replays is the number of open bodies returned by GetBody.
+		replays int
+		// done is true once the round trip is done.
+		done bool
+	}
+
+	// replayBody is a body returned by the GetBody function of a pooled
+	// body, it reads the pooled buffer.
+	replayBody struct {
+		b      *pooledBody
+		r      *bytes.Reader
+		closed bool
+	}
+
+	// pooledDoer is a Doer that releases the pooled request bodies once the
+	// round trips are done.
+	pooledDoer struct {
+		Doer
+	}
+)
+
+// maxPooledBufferSize is the capacity above which the buffers are not returned
+// to the pool so that occasional large bodies do not pin memory.
+const maxPooledBufferSize = 1 << 20
+
+// jsonBufferPool holds the buffers used by the pooled JSON encoders.
+var jsonBufferPool = sync.Pool{New: func() interface{} { return new(jsonBuffer) }}
+
+// PooledResponseEncoder is equivalent to ResponseEncoder except that the JSON
+// encoder it returns encodes the values in a pooled buffer which is then
+// written to the response in a single call. This avoids allocating a new
+// encoder and growing a new buffer for each response which reduces the
+// allocations significantly when serving large results.
+func PooledResponseEncoder(ctx context.Context, w http.ResponseWriter) Encoder {
+	return responseEncoder(ctx, w, newPooledJSONEncoder)
+}
+
+// PooledRequestEncoder is equivalent to RequestEncoder except that it encodes
+// the request body in a pooled buffer. The encoder also sets the request
+// Content-Length header and the request GetBody function so that the body can
+// be replayed on redirects and retries without copying it. The buffer is given
+// back to the pool once the round trip made with the Doer returned by
+// PooledDoer is done and the HTTP client closed the bodies, it is left to the
+// garbage collector otherwise.
+func PooledRequestEncoder(r *http.Request) Encoder {
+	return &pooledRequestEncoder{r: r}
+}
+
+// PooledDoer returns a Doer that gives the buffers holding the request bodies
+// encoded by PooledRequestEncoder back to the pool once d is done with the
+// requests.
+func PooledDoer(d Doer) Doer {
+	return &pooledDoer{Doer: d}
+}
+
+// Do sends the request with the wrapped doer and releases the pooled request
+// body. The body is retrieved before the request is sent as the wrapped doer
+// may replace it.
+func (d *pooledDoer) Do(req *http.Request) (*http.Response, error) {
+	b, _ := req.Body.(*pooledBody)
+	resp, err := d.Doer.Do(req)
+	if b != nil {
+		b.finish()
+	}
+	return resp, err
+}
+
+// newPooledJSONEncoder returns a pooled JSON encoder writing to w.
+func newPooledJSONEncoder(w io.Writer) Encoder {
+	return &pooledJSONEncoder{w: w}
+}
+
+// Encode encodes v in a pooled buffer and writes the result to the underlying
+// writer.
+func (e *pooledJSONEncoder) Encode(v interface{}) error {
+	jb := getJSONBuffer()
+	defer putJSONBuffer(jb)
+	if err := jb.enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := jb.buf.WriteTo(e.w)
+	return err
+}
+
+// Encode encodes v in a pooled buffer and sets the request body to read it.
+func (e *pooledRequestEncoder) Encode(v interface{}) error {
+	jb := getJSONBuffer()
+	if err := jb.enc.Encode(v); err != nil {
+		putJSONBuffer(jb)
+		return err
+	}
+	if b, ok := e.r.Body.(*pooledBody); ok {
+		b.Close()
+		b.finish()
+	}
+	body := &pooledBody{jb: jb, r: bytes.NewReader(jb.buf.Bytes())}
+	e.r.Body = body
+	e.r.ContentLength = int64(jb.buf.Len())
+	e.r.GetBody = body.replay
+	return nil
+}
+
+// Read reads from the pooled buffer, it returns io.EOF once the body is
+// closed.
+func (b *pooledBody) Read(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed || b.jb == nil {
+		return 0, io.EOF
+	}
+	return b.r.Read(p)
+}
+
+// Close closes the body. The HTTP transport may close the body while another
+// goroutine reads it, hence the lock.
+func (b *pooledBody) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	b.release()
+	return nil
+}
+
+// replay returns a new body reading the pooled buffer, it is used as request
+// GetBody function.
+func (b *pooledBody) replay() (io.ReadCloser, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.jb == nil {
+		return nil, errors.New("request body already released")
+	}
+	b.replays++
+	return &replayBody{b: b, r: bytes.NewReader(b.jb.buf.Bytes())}, nil
+}
+
+// finish records that the round trip is done.
+func (b *pooledBody) finish() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.done = true
+	b.release()
+}
+
+// release gives the buffer back to the pool once the round trip is done and
+// all the bodies reading it are closed, the lock must be held.
+func (b *pooledBody) release() {
+	if b.jb == nil || !b.done || !b.closed || b.replays > 0 {
+		return
+	}
+	putJSONBuffer(b.jb)
+	b.jb, b.r = nil, nil
+}
+
+// Read reads from the pooled buffer, it returns io.EOF once the body is
+// closed.
+func (r *replayBody) Read(p []byte) (int, error) {
+	r.b.mu.Lock()
+	defer r.b.mu.Unlock()
+	if r.closed {
+		return 0, io.EOF
+	}
+	return r.r.Read(p)
+}
+
+// Close closes the body and releases the pooled buffer if the round trip is
+// done.
+func (r *replayBody) Close() error {
+	r.b.mu.Lock()
+	defer r.b.mu.Unlock()
+	if !r.closed {
+		r.closed = true
+		r.b.replays--
+		r.b.release()
+	}
+	return nil
+}
+
+// getJSONBuffer returns an empty pooled JSON buffer whose encoder uses the
+// JSON codec.
+func getJSONBuffer() *jsonBuffer {
+	jb := jsonBufferPool.Get().(*jsonBuffer)
+	jb.buf.Reset()
+	if h := currentJSONCodec(); jb.codec != h {
+		jb.enc, jb.codec = h.codec.NewEncoder(&jb.buf), h
+	}
+	return jb
+}
+
+// putJSONBuffer returns jb to the pool unless its buffer grew too large.
+func putJSONBuffer(jb *jsonBuffer) {
+	if jb.buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	jsonBufferPool.Put(jb)
+}
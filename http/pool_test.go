@@ -0,0 +1,228 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type pooledItem struct {
+	ID   int      `json:"id"`
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+func largeResult(n int) []*pooledItem {
+	items := make([]*pooledItem, n)
+	for i := range items {
+		items[i] = &pooledItem{ID: i, Name: fmt.Sprintf("item %d", i), Tags: []string{"a", "b", "c"}}
+	}
+	return items
+}
+
+func TestPooledResponseEncoder(t *testing.T) {
+	cases := []struct {
+		Name        string
+		ContentType string
+		AcceptType  string
+		EncoderType string
+	}{
+		{"no ct, no at", "", "", "*http.pooledJSONEncoder"},
+		{"ct +json", "+json", "application/gob", "*http.pooledJSONEncoder"},
+		{"no ct, at xml", "", "application/xml", "*xml.Encoder"},
+		{"ct plain", "text/plain", "application/gob", "*http.textEncoder"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), AcceptTypeKey, c.AcceptType)
+			ctx = context.WithValue(ctx, ContentTypeKey, c.ContentType)
+			if enc := PooledResponseEncoder(ctx, httptest.NewRecorder()); fmt.Sprintf("%T", enc) != c.EncoderType {
+				t.Errorf("got encoder type %T, expected %s", enc, c.EncoderType)
+			}
+		})
+	}
+
+	items := largeResult(3)
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		if err := PooledResponseEncoder(context.Background(), w).Encode(items); err != nil {
+			t.Fatal(err)
+		}
+		var got []*pooledItem
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatalf("invalid body %q: %s", w.Body.String(), err)
+		}
+		if len(got) != 3 || got[2].Name != "item 2" {
+			t.Errorf("got %+v, expected %+v", got, items)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("got Content-Type %q, expected application/json", ct)
+		}
+	}
+	if err := PooledResponseEncoder(context.Background(), httptest.NewRecorder()).Encode(func() {}); err == nil {
+		t.Error("got no error, expected unsupported type error")
+	}
+}
+
+func TestPooledRequestEncoder(t *testing.T) {
+	var received []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		if r.ContentLength != int64(len(b)) {
+			t.Errorf("got Content-Length %d, expected %d", r.ContentLength, len(b))
+		}
+		received = append(received, string(b))
+	}))
+	defer svr.Close()
+
+	for _, v := range []string{"first", "second"} {
+		req, _ := http.NewRequest("POST", svr.URL, nil)
+		if err := PooledRequestEncoder(req).Encode(map[string]string{"v": v}); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if n, _ := req.Body.Read(make([]byte, 1)); n != 0 {
+			t.Errorf("got %d bytes read from closed body, expected none", n)
+		}
+	}
+	expected := []string{"{\"v\":\"first\"}\n", "{\"v\":\"second\"}\n"}
+	if len(received) != 2 || received[0] != expected[0] || received[1] != expected[1] {
+		t.Errorf("got bodies %q, expected %q", received, expected)
+	}
+}
+
+func TestPooledRequestEncoderReplay(t *testing.T) {
+	var received []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		http.Redirect(w, r, "/new", http.StatusTemporaryRedirect)
+	})
+	mux.HandleFunc("/new", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		received = append(received, string(b))
+	})
+	svr := httptest.NewServer(mux)
+	defer svr.Close()
+
+	req, _ := http.NewRequest("POST", svr.URL+"/old", nil)
+	if err := PooledRequestEncoder(req).Encode(map[string]string{"v": "redirected"}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	expected := "{\"v\":\"redirected\"}\n"
+	if len(received) != 1 || received[0] != expected {
+		t.Errorf("got bodies %q, expected %q", received, []string{expected})
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := ioutil.ReadAll(body); string(b) != expected {
+		t.Errorf("got replayed body %q after close, expected %q", string(b), expected)
+	}
+}
+
+func TestPooledDoer(t *testing.T) {
+	var received []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		http.Redirect(w, r, "/new", http.StatusTemporaryRedirect)
+	})
+	mux.HandleFunc("/new", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		received = append(received, string(b))
+	})
+	svr := httptest.NewServer(mux)
+	defer svr.Close()
+
+	req, _ := http.NewRequest("POST", svr.URL+"/old", nil)
+	if err := PooledRequestEncoder(req).Encode(map[string]string{"v": "redirected"}); err != nil {
+		t.Fatal(err)
+	}
+	body := req.Body.(*pooledBody)
+	resp, err := PooledDoer(http.DefaultClient).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	expected := "{\"v\":\"redirected\"}\n"
+	if len(received) != 1 || received[0] != expected {
+		t.Errorf("got bodies %q, expected %q", received, []string{expected})
+	}
+	body.mu.Lock()
+	released := body.jb == nil
+	body.mu.Unlock()
+	if !released {
+		t.Error("got pooled buffer held after the round trip, expected it to be released")
+	}
+	if _, err := req.GetBody(); err == nil {
+		t.Error("got no error replaying a released body")
+	}
+}
+
+func BenchmarkResponseEncoder(b *testing.B) {
+	items := largeResult(1000)
+	for _, c := range []struct {
+		Name    string
+		Encoder func(context.Context, http.ResponseWriter) Encoder
+	}{
+		{"default", ResponseEncoder},
+		{"pooled", PooledResponseEncoder},
+	} {
+		b.Run(c.Name, func(b *testing.B) {
+			w := httptest.NewRecorder()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				w.Body.Reset()
+				if err := c.Encoder(context.Background(), w).Encode(items); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRequestEncoder(b *testing.B) {
+	items := largeResult(1000)
+	doer := doerFunc(func(req *http.Request) (*http.Response, error) {
+		io.Copy(ioutil.Discard, req.Body)
+		req.Body.Close()
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+	for _, c := range []struct {
+		Name    string
+		Encoder func(*http.Request) Encoder
+		Doer    Doer
+	}{
+		{"default", RequestEncoder, doer},
+		{"pooled", PooledRequestEncoder, PooledDoer(doer)},
+	} {
+		b.Run(c.Name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				req, _ := http.NewRequest("POST", "http://localhost", nil)
+				if err := c.Encoder(req).Encode(items); err != nil {
+					b.Fatal(err)
+				}
+				if _, err := c.Doer.Do(req); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
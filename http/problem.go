@@ -0,0 +1,106 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// ProblemContentType is the content type of the problem details documents
+// defined by RFC 9457.
+const ProblemContentType = "application/problem+json"
+
+type (
+	// ProblemDetails is the problem details document (RFC 9457) encoded in
+	// the HTTP responses that correspond to errors when the design uses the
+	// ProblemDetails DSL. The document also contains the fields of
+	// ErrorResponse as extension members so that clients decoding error
+	// responses keep working.
+	ProblemDetails struct {
+		// Type is a URI reference that identifies the problem type.
+		Type string `json:"type" xml:"type" form:"type"`
+		// Title is a short summary of the problem type.
+		Title string `json:"title" xml:"title" form:"title"`
+		// Status is the HTTP status code of the response.
+		Status int `json:"status" xml:"status" form:"status"`
+		// Detail describes the specific problem occurrence.
+		Detail string `json:"detail,omitempty" xml:"detail,omitempty" form:"detail,omitempty"`
+		// Instance is a URI reference that identifies the specific problem
+		// occurrence.
+		Instance string `json:"instance,omitempty" xml:"instance,omitempty" form:"instance,omitempty"`
+		// Name is a name for that class of errors.
+		Name string `json:"name" xml:"name" form:"name"`
+		// ID is the unique error instance identifier.
+		ID string `json:"id" xml:"id" form:"id"`
+		// Message describes the specific error occurrence.
+		Message string `json:"message" xml:"message" form:"message"`
+		// Temporary indicates whether the error is temporary.
+		Temporary bool `json:"temporary" xml:"temporary" form:"temporary"`
+		// Timeout indicates whether the error is a timeout.
+		Timeout bool `json:"timeout" xml:"timeout" form:"timeout"`
+		// Fault indicates whether the error is a server-side fault.
+		Fault bool `json:"fault" xml:"fault" form:"fault"`
+		// Violations lists the validation rules violated by the request
+		// when the error is a validation error.
+		Violations []*ErrorViolation `json:"violations,omitempty" xml:"violation,omitempty" form:"violations,omitempty"`
+	}
+)
+
+// NewProblemDetails creates a problem details document from the given error.
+// The problem type URI is built from typeBase and the error name, see
+// ProblemType. The status is computed with ErrorResponse.StatusCode if zero.
+func NewProblemDetails(err error, typeBase string, status int) *ProblemDetails {
+	resp := NewErrorResponse(err)
+	if status == 0 {
+		status = resp.StatusCode()
+	}
+	return &ProblemDetails{
+		Type:       ProblemType(typeBase, resp.Name),
+		Title:      http.StatusText(status),
+		Status:     status,
+		Detail:     resp.Message,
+		Name:       resp.Name,
+		ID:         resp.ID,
+		Message:    resp.Message,
+		Temporary:  resp.Temporary,
+		Timeout:    resp.Timeout,
+		Fault:      resp.Fault,
+		Violations: resp.Violations,
+	}
+}
+
+// ProblemType returns the URI of the problem type for the errors with the
+// given name: the escaped name appended to typeBase. It returns "about:blank"
+// if typeBase is empty as specified by RFC 9457.
+func ProblemType(typeBase, name string) string {
+	if typeBase == "" {
+		return "about:blank"
+	}
+	return typeBase + url.PathEscape(name)
+}
+
+// ProblemErrorEncoder returns an encoder that encodes errors as problem
+// details documents with the "application/problem+json" content type. It is
+// used by the generated server code instead of ErrorEncoder when the design
+// uses the ProblemDetails DSL.
+func ProblemErrorEncoder(encoder func(context.Context, http.ResponseWriter) Encoder, typeBase string) func(context.Context, http.ResponseWriter, error) error {
+	return func(ctx context.Context, w http.ResponseWriter, err error) error {
+		return EncodeProblem(ctx, encoder, w, err, typeBase, 0)
+	}
+}
+
+// EncodeProblem writes the problem details document built from err to w using
+// the given status or the status computed from the error characteristics if
+// zero.
+func EncodeProblem(ctx context.Context, encoder func(context.Context, http.ResponseWriter) Encoder, w http.ResponseWriter, err error, typeBase string, status int) error {
+	if status == 0 {
+		status = writeAuthChallenges(w, err)
+	}
+	p := NewProblemDetails(goa.LocalizeError(ctx, err), typeBase, status)
+	enc := encoder(context.WithValue(ctx, ContentTypeKey, ProblemContentType), w)
+	writeRetryAfter(w, err)
+	w.WriteHeader(p.Status)
+	return enc.Encode(p)
+}
@@ -0,0 +1,149 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestProblemErrorEncoder(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Error    error
+		TypeBase string
+		Status   int
+		Type     string
+	}{
+		{"validation", goa.MissingFieldError("name", "body"), "https://goa.design/problems/", http.StatusBadRequest, "https://goa.design/problems/missing_field"},
+		{"timeout", goa.PermanentTimeoutError("slow", "too slow"), "https://goa.design/problems/", http.StatusRequestTimeout, "https://goa.design/problems/slow"},
+		{"fault", errors.New("boom"), "https://goa.design/problems/", http.StatusInternalServerError, "https://goa.design/problems/fault"},
+		{"no-type-base", goa.MissingFieldError("name", "body"), "", http.StatusBadRequest, "about:blank"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			encode := ProblemErrorEncoder(ResponseEncoder, c.TypeBase)
+			ctx := context.WithValue(context.Background(), AcceptTypeKey, "application/xml")
+			if err := encode(ctx, w, c.Error); err != nil {
+				t.Fatal(err)
+			}
+			if w.Code != c.Status {
+				t.Errorf("got status %d, expected %d", w.Code, c.Status)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != ProblemContentType {
+				t.Errorf("got content type %q, expected %q", ct, ProblemContentType)
+			}
+			var p ProblemDetails
+			if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+				t.Fatal(err)
+			}
+			if p.Type != c.Type {
+				t.Errorf("got type %q, expected %q", p.Type, c.Type)
+			}
+			if p.Status != c.Status {
+				t.Errorf("got status member %d, expected %d", p.Status, c.Status)
+			}
+			if p.Title != http.StatusText(c.Status) {
+				t.Errorf("got title %q, expected %q", p.Title, http.StatusText(c.Status))
+			}
+			if p.Detail != c.Error.Error() || p.Message != p.Detail {
+				t.Errorf("got detail %q and message %q, expected %q", p.Detail, p.Message, c.Error.Error())
+			}
+		})
+	}
+}
+
+func TestEncodeProblemStatus(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := goa.PermanentError("not_found", "no such thing")
+	if err := EncodeProblem(context.Background(), ResponseEncoder, w, err, "urn:problems:", http.StatusNotFound); err != nil {
+		t.Fatal(err)
+	}
+	if w.Code != http.StatusNotFound {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusNotFound)
+	}
+	var p ProblemDetails
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Type != "urn:problems:not_found" || p.Status != http.StatusNotFound || p.Name != "not_found" {
+		t.Errorf("got problem %+v", p)
+	}
+}
+
+func TestProblemViolations(t *testing.T) {
+	err := goa.MergeErrors(goa.MissingFieldError("name", "body"), goa.InvalidRangeError("body.items[3].count", 0, 1, true))
+	w := httptest.NewRecorder()
+	if err := EncodeProblem(context.Background(), ResponseEncoder, w, err, "", 0); err != nil {
+		t.Fatal(err)
+	}
+	var p struct {
+		Violations []map[string]interface{} `json:"violations"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+		t.Fatal(err)
+	}
+	expected := []map[string]interface{}{
+		{"pointer": "/name", "rule": "missing_field", "message": `"name" is missing from body`},
+		{"pointer": "/items/3/count", "rule": "invalid_range", "constraint": 1.0, "message": "body.items[3].count must be greater or equal than 1 but got value 0"},
+	}
+	if !reflect.DeepEqual(p.Violations, expected) {
+		t.Errorf("got violations %v, expected %v", p.Violations, expected)
+	}
+
+	w = httptest.NewRecorder()
+	if err := ErrorEncoder(ResponseEncoder)(context.Background(), w, goa.PermanentError("custom", "custom")); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(w.Body.String(), "violations") {
+		t.Errorf("got violations for a non validation error: %s", w.Body.String())
+	}
+}
+
+func TestLocalizedErrors(t *testing.T) {
+	if err := goa.RegisterMessageCatalog("it", goa.MessageCatalog{"missing_field": "{{ .Name }} manca in {{ .Context }}"}); err != nil {
+		t.Fatal(err)
+	}
+	ctx := goa.WithLocale(context.Background(), "it-IT")
+	expected := "name manca in body"
+
+	w := httptest.NewRecorder()
+	if err := EncodeProblem(ctx, ResponseEncoder, w, goa.MissingFieldError("name", "body"), "", 0); err != nil {
+		t.Fatal(err)
+	}
+	var p ProblemDetails
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Detail != expected {
+		t.Errorf("got problem detail %q, expected %q", p.Detail, expected)
+	}
+
+	w = httptest.NewRecorder()
+	if err := ErrorEncoder(ResponseEncoder)(ctx, w, goa.MissingFieldError("name", "body")); err != nil {
+		t.Fatal(err)
+	}
+	var resp ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Violations) != 1 || resp.Violations[0].Message != expected {
+		t.Errorf("got violations %+v, expected message %q", resp.Violations, expected)
+	}
+	if resp.Message != expected || resp.Name != "missing_field" {
+		t.Errorf("got error response %+v, expected message %q", resp, expected)
+	}
+}
+
+func TestProblemType(t *testing.T) {
+	if got := ProblemType("https://goa.design/problems/", "a b"); got != "https://goa.design/problems/a%20b" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,50 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// TimeoutHeader is the name of the HTTP header used to propagate request
+// deadlines. Its value is the number of milliseconds left until the deadline.
+const TimeoutHeader = "Goa-Timeout"
+
+// PropagateIncoming returns a copy of ctx that holds the values of the headers
+// of r listed in p. If p propagates deadlines and r has a Goa-Timeout header
+// then the returned context expires accordingly. The caller must call the
+// returned cancel function once the request is handled.
+func PropagateIncoming(ctx context.Context, r *http.Request, p *goa.Propagation) (context.Context, context.CancelFunc) {
+	ctx = p.Incoming(ctx, r.Header.Get)
+	if p.Deadline {
+		if ms, err := strconv.ParseInt(r.Header.Get(TimeoutHeader), 10, 64); err == nil && ms >= 0 {
+			return context.WithTimeout(ctx, time.Duration(ms)*time.Millisecond)
+		}
+	}
+	return ctx, func() {}
+}
+
+// PropagateOutgoing sets the headers of req listed in p with the values held by
+// ctx. Headers already set on req are left untouched. If p propagates deadlines
+// and ctx has a deadline then PropagateOutgoing also sets the Goa-Timeout
+// header.
+func PropagateOutgoing(ctx context.Context, req *http.Request, p *goa.Propagation) {
+	for n, v := range p.Outgoing(ctx) {
+		if req.Header.Get(n) == "" {
+			req.Header.Set(n, v)
+		}
+	}
+	if !p.Deadline {
+		return
+	}
+	if d, ok := ctx.Deadline(); ok {
+		ms := int64(time.Until(d) / time.Millisecond)
+		if ms < 0 {
+			ms = 0
+		}
+		req.Header.Set(TimeoutHeader, strconv.FormatInt(ms, 10))
+	}
+}
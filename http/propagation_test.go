@@ -0,0 +1,66 @@
+package http
+
+import (
+	"context"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"goa.design/goa/v3/middleware"
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestPropagation(t *testing.T) {
+	p := &goa.Propagation{Headers: []string{"X-Request-Id", "traceparent"}, Deadline: true}
+	in := httptest.NewRequest("GET", "/", nil)
+	in.Header.Set("X-Request-Id", "abc")
+	in.Header.Set("Traceparent", "00-trace")
+	in.Header.Set(TimeoutHeader, "5000")
+
+	ctx, cancel := PropagateIncoming(context.Background(), in, p)
+	defer cancel()
+	d, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("expected incoming context to have a deadline")
+	}
+	if rem := time.Until(d); rem <= 0 || rem > 5*time.Second {
+		t.Errorf("got remaining time %s, expected at most 5s", rem)
+	}
+
+	out := httptest.NewRequest("GET", "/", nil)
+	out.Header.Set("Traceparent", "00-other")
+	PropagateOutgoing(ctx, out, p)
+	if got := out.Header.Get("X-Request-Id"); got != "abc" {
+		t.Errorf("got request ID %q, expected %q", got, "abc")
+	}
+	if got := out.Header.Get("Traceparent"); got != "00-other" {
+		t.Errorf("got traceparent %q, expected existing header to be kept", got)
+	}
+	ms, err := strconv.Atoi(out.Header.Get(TimeoutHeader))
+	if err != nil || ms <= 0 || ms > 5000 {
+		t.Errorf("got timeout header %q, expected value in (0, 5000]", out.Header.Get(TimeoutHeader))
+	}
+}
+
+func TestPropagateOutgoingRequestID(t *testing.T) {
+	p := &goa.Propagation{Headers: []string{"X-Request-Id"}}
+	ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "xyz")
+	out := httptest.NewRequest("GET", "/", nil)
+	PropagateOutgoing(ctx, out, p)
+	if got := out.Header.Get("X-Request-Id"); got != "xyz" {
+		t.Errorf("got request ID %q, expected %q", got, "xyz")
+	}
+	if got := out.Header.Get(TimeoutHeader); got != "" {
+		t.Errorf("got timeout header %q, expected none", got)
+	}
+}
+
+func TestPropagateIncomingNoDeadline(t *testing.T) {
+	p := &goa.Propagation{Deadline: true}
+	ctx, cancel := PropagateIncoming(context.Background(), httptest.NewRequest("GET", "/", nil), p)
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("expected no deadline")
+	}
+}
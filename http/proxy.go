@@ -0,0 +1,149 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+)
+
+type (
+	// Proxy forwards requests to an upstream service. The generated server
+	// code creates one proxy per endpoint that uses the Proxy DSL and
+	// exposes it on the server struct so that the hooks may be set prior
+	// to serving requests.
+	Proxy struct {
+		// Upstream is the URL of the upstream service. The request path
+		// is appended to the upstream URL path.
+		Upstream *url.URL
+		// StripPrefix is removed from the request path before it is
+		// appended to the upstream URL path.
+		StripPrefix string
+		// Header lists the headers set on forwarded requests.
+		Header http.Header
+		// RemoveHeaders lists the headers removed from forwarded
+		// requests.
+		RemoveHeaders []string
+		// Rewrite if not nil is called with the outgoing request after
+		// it has been rewritten to target the upstream service.
+		Rewrite func(*http.Request)
+		// ModifyResponse if not nil is called with the upstream
+		// response prior to copying it to the client. An error causes
+		// ErrorHandler to be called.
+		ModifyResponse func(*http.Response) error
+		// ErrorHandler if not nil is called when the upstream service
+		// cannot be reached or ModifyResponse returns an error. The
+		// default handler responds with 502 Bad Gateway.
+		ErrorHandler func(http.ResponseWriter, *http.Request, error)
+		// Transport is used to make the upstream requests,
+		// http.DefaultTransport is used if nil.
+		Transport http.RoundTripper
+	}
+)
+
+// DefaultProxyBodyLimit is the maximum size in bytes of the request bodies
+// buffered by the servers of proxy endpoints whose design does not set a limit
+// with BodyLimit. The servers only buffer the bodies of the endpoints whose
+// payload is decoded from the request body, the other request bodies are
+// streamed to the upstream service.
+const DefaultProxyBodyLimit = 10 << 20
+
+// NewProxy returns a proxy that forwards requests to the given upstream URL.
+// NewProxy panics if the URL cannot be parsed, the generated code only calls it
+// with URLs validated when the design is evaluated.
+func NewProxy(upstream string) *Proxy {
+	u, err := url.Parse(upstream)
+	if err != nil {
+		panic(err)
+	}
+	return &Proxy{Upstream: u}
+}
+
+// ServeHTTP forwards r to the upstream service and copies the response to w.
+// The body of r is replayed if it was buffered with BufferRequestBody.
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rp := &httputil.ReverseProxy{
+		Director:       p.direct,
+		Transport:      p.Transport,
+		ModifyResponse: p.ModifyResponse,
+		ErrorHandler:   p.ErrorHandler,
+	}
+	rp.ServeHTTP(w, r)
+}
+
+// BufferRequestBody reads the body of r in memory so that it may be read again
+// after the request has been decoded. The generated code calls it prior to
+// decoding requests made to proxy endpoints whose payload is decoded from the
+// request body after limiting the body size with LimitRequestBody.
+func BufferRequestBody(r *http.Request) error {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	r.Body.Close()
+	r.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(b)), nil
+	}
+	r.Body, _ = r.GetBody()
+	r.ContentLength = int64(len(b))
+	return nil
+}
+
+// direct rewrites the outgoing request so that it targets the upstream
+// service.
+func (p *Proxy) direct(r *http.Request) {
+	if r.GetBody != nil {
+		if body, err := r.GetBody(); err == nil {
+			r.Body = body
+		}
+	}
+	path := r.URL.Path
+	if p.StripPrefix != "" && strings.HasPrefix(path, p.StripPrefix) {
+		rest := path[len(p.StripPrefix):]
+		if rest == "" || rest[0] == '/' || strings.HasSuffix(p.StripPrefix, "/") {
+			path = "/" + strings.TrimPrefix(rest, "/")
+		}
+	}
+	r.URL.Scheme = p.Upstream.Scheme
+	r.URL.Host = p.Upstream.Host
+	r.URL.Path = joinPath(p.Upstream.Path, path)
+	r.URL.RawPath = ""
+	if p.Upstream.RawQuery == "" || r.URL.RawQuery == "" {
+		r.URL.RawQuery = p.Upstream.RawQuery + r.URL.RawQuery
+	} else {
+		r.URL.RawQuery = p.Upstream.RawQuery + "&" + r.URL.RawQuery
+	}
+	r.Host = p.Upstream.Host
+	if _, ok := r.Header["User-Agent"]; !ok {
+		// Prevent the default user agent from being set.
+		r.Header.Set("User-Agent", "")
+	}
+	for _, h := range p.RemoveHeaders {
+		r.Header.Del(h)
+	}
+	for h, vals := range p.Header {
+		r.Header[h] = append([]string(nil), vals...)
+	}
+	if p.Rewrite != nil {
+		p.Rewrite(r)
+	}
+}
+
+// joinPath joins the upstream path and the request path with a single slash.
+func joinPath(a, b string) string {
+	aslash := strings.HasSuffix(a, "/")
+	bslash := strings.HasPrefix(b, "/")
+	switch {
+	case aslash && bslash:
+		return a + b[1:]
+	case !aslash && !bslash:
+		return a + "/" + b
+	}
+	return a + b
+}
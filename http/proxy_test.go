@@ -0,0 +1,96 @@
+package http
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestProxy(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("X-Path", r.URL.Path)
+		w.Header().Set("X-Query", r.URL.RawQuery)
+		w.Header().Set("X-Gateway", r.Header.Get("X-Gateway"))
+		w.Header().Set("X-Authorization", r.Header.Get("Authorization"))
+		w.Header().Set("X-Hook", r.Header.Get("X-Hook"))
+		w.WriteHeader(http.StatusAccepted)
+		w.Write(body) // nolint: errcheck
+	}))
+	defer upstream.Close()
+
+	cases := []struct {
+		Name     string
+		Upstream string
+		Strip    string
+		Hook     bool
+		Path     string
+		Query    string
+	}{
+		{"plain", "", "", false, "/accounts/1", "page=2"},
+		{"upstream path", "/v1", "", false, "/v1/accounts/1", "page=2"},
+		{"upstream query", "/v1?tenant=a", "", false, "/v1/accounts/1", "tenant=a&page=2"},
+		{"strip prefix", "/v1/", "/accounts", false, "/v1/1", "page=2"},
+		{"strip prefix mismatch", "", "/account", false, "/accounts/1", "page=2"},
+		{"rewrite hook", "", "", true, "/accounts/1", "page=2"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			p := NewProxy(upstream.URL + c.Upstream)
+			p.StripPrefix = c.Strip
+			p.Header = http.Header{"X-Gateway": {"goa"}}
+			p.RemoveHeaders = []string{"Authorization"}
+			if c.Hook {
+				p.Rewrite = func(r *http.Request) { r.Header.Set("X-Hook", "called") }
+			}
+			r := httptest.NewRequest("POST", "/accounts/1?page=2", strings.NewReader("payload"))
+			r.Header.Set("Authorization", "Bearer secret")
+			r.Header.Set("X-Gateway", "client")
+			if err := BufferRequestBody(r); err != nil {
+				t.Fatal(err)
+			}
+			if b, _ := ioutil.ReadAll(r.Body); string(b) != "payload" {
+				t.Fatalf("got buffered body %q, expected %q", string(b), "payload")
+			}
+			w := httptest.NewRecorder()
+			p.ServeHTTP(w, r)
+			if w.Code != http.StatusAccepted {
+				t.Fatalf("got status %d, expected %d", w.Code, http.StatusAccepted)
+			}
+			if got := w.Body.String(); got != "payload" {
+				t.Errorf("got body %q, expected %q", got, "payload")
+			}
+			expected := map[string]string{
+				"X-Path":          c.Path,
+				"X-Query":         c.Query,
+				"X-Gateway":       "goa",
+				"X-Authorization": "",
+				"X-Hook":          "",
+			}
+			if c.Hook {
+				expected["X-Hook"] = "called"
+			}
+			for h, v := range expected {
+				if got := w.Header().Get(h); got != v {
+					t.Errorf("got %s %q, expected %q", h, got, v)
+				}
+			}
+		})
+	}
+}
+
+func TestProxyUnreachable(t *testing.T) {
+	upstream := httptest.NewServer(http.NotFoundHandler())
+	upstream.Close()
+	p := NewProxy(upstream.URL)
+	p.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusServiceUnavailable)
+	}
+}
@@ -0,0 +1,22 @@
+package http
+
+import "strings"
+
+// SplitQueryValues splits each of the given query string values using sep and
+// returns the resulting list of values. It is used by the generated server code
+// to decode array query string parameters serialized with a delimited style
+// (e.g. "id=1,2" or "id=1|2"). SplitQueryValues returns nil if vals is nil so
+// that missing parameters can be detected.
+func SplitQueryValues(vals []string, sep string) []string {
+	if vals == nil {
+		return nil
+	}
+	res := make([]string, 0, len(vals))
+	for _, v := range vals {
+		if v == "" {
+			continue
+		}
+		res = append(res, strings.Split(v, sep)...)
+	}
+	return res
+}
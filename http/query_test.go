@@ -0,0 +1,31 @@
+package http
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitQueryValues(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Values   []string
+		Sep      string
+		Expected []string
+	}{
+		{"nil", nil, ",", nil},
+		{"empty", []string{""}, ",", []string{}},
+		{"single", []string{"a"}, ",", []string{"a"}},
+		{"comma", []string{"a,b"}, ",", []string{"a", "b"}},
+		{"pipe", []string{"a|b|c"}, "|", []string{"a", "b", "c"}},
+		{"space", []string{"a b"}, " ", []string{"a", "b"}},
+		{"repeated", []string{"a,b", "c"}, ",", []string{"a", "b", "c"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			got := SplitQueryValues(c.Values, c.Sep)
+			if !reflect.DeepEqual(got, c.Expected) {
+				t.Errorf("got %#v, expected %#v", got, c.Expected)
+			}
+		})
+	}
+}
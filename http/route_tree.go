@@ -0,0 +1,297 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type (
+	// RouteTree is a tree of routes whose nodes match path segments. The
+	// code generator compiles the routes of the design into a RouteTree so
+	// that the muxer returned by NewRadixMuxer does not have to parse the
+	// patterns at startup nor run regular expressions when serving requests.
+	RouteTree struct {
+		// Routes lists the routes of the tree, the nodes refer to the
+		// routes by index.
+		Routes []*TreeRoute
+		// Root is the root node, its children match the first segment of
+		// the path.
+		Root *RouteNode
+	}
+
+	// TreeRoute is a route of a RouteTree.
+	TreeRoute struct {
+		// Method is the HTTP method.
+		Method string
+		// Pattern is the route pattern.
+		Pattern string
+		// Vars lists the names of the pattern wildcards in order of
+		// appearance.
+		Vars []string
+	}
+
+	// RouteNode is a node of a RouteTree.
+	RouteNode struct {
+		// Static maps the literal segments that may follow the node to
+		// the corresponding child nodes.
+		Static map[string]*RouteNode
+		// Param is the child node that matches any non empty segment if
+		// any.
+		Param *RouteNode
+		// Routes maps the HTTP methods to the index of the route whose
+		// pattern ends with the node.
+		Routes map[string]int
+		// CatchAll maps the HTTP methods to the index of the route whose
+		// catch-all wildcard captures the rest of the path that follows
+		// the node.
+		CatchAll map[string]int
+	}
+
+	// radixMux is the Muxer implementation returned by NewRadixMuxer.
+	radixMux struct {
+		tree     *RouteTree
+		fallback Muxer
+		// handlers lists the handlers of the routes indexed by route.
+		handlers []http.HandlerFunc
+		// disabled is true if a pattern cannot be added to the tree in
+		// which case all the requests are dispatched to fallback.
+		disabled bool
+	}
+
+	// radixVars records the route matched by the tree and the values of
+	// its wildcards.
+	radixVars struct {
+		route  *TreeRoute
+		values []string
+	}
+
+	// radixVarsKey is the private type used to store the matched route in
+	// the request context.
+	radixVarsKey struct{}
+)
+
+// NewRouteTree returns an empty route tree.
+func NewRouteTree() *RouteTree {
+	return &RouteTree{Root: &RouteNode{}}
+}
+
+// Add adds the route with the given method and pattern to the tree and
+// returns its index. Adding a pattern that only differs from the pattern of
+// an existing route of the same method by the names of its wildcards replaces
+// that route in the tree. Add returns an error if the pattern uses label or
+// matrix style wildcards or wildcards that do not span entire segments as
+// these are not supported by RouteTree.
+func (t *RouteTree) Add(method, pattern string) (int, error) {
+	if !strings.HasPrefix(pattern, "/") {
+		return 0, fmt.Errorf("goa: pattern %q does not start with /", pattern)
+	}
+	var (
+		n    = t.Root
+		vars []string
+		all  bool
+		segs = strings.Split(pattern[1:], "/")
+	)
+	for i, seg := range segs {
+		name, ok := treeWildcard(seg)
+		if !ok {
+			if strings.ContainsAny(seg, "{}") {
+				return 0, fmt.Errorf("goa: unsupported wildcard in pattern %q", pattern)
+			}
+			child, ok := n.Static[seg]
+			if !ok {
+				child = &RouteNode{}
+				if n.Static == nil {
+					n.Static = make(map[string]*RouteNode)
+				}
+				n.Static[seg] = child
+			}
+			n = child
+			continue
+		}
+		if strings.HasPrefix(name, "*") || strings.HasSuffix(name, "*") {
+			if i != len(segs)-1 {
+				return 0, fmt.Errorf("goa: catch-all wildcard must end pattern %q", pattern)
+			}
+			vars = append(vars, strings.Trim(name, "*"))
+			all = true
+			break
+		}
+		vars = append(vars, name)
+		if n.Param == nil {
+			n.Param = &RouteNode{}
+		}
+		n = n.Param
+	}
+	idx := t.route(method, pattern)
+	if idx < 0 {
+		idx = len(t.Routes)
+		t.Routes = append(t.Routes, &TreeRoute{Method: method, Pattern: pattern, Vars: vars})
+	}
+	if all {
+		if n.CatchAll == nil {
+			n.CatchAll = make(map[string]int)
+		}
+		n.CatchAll[method] = idx
+	} else {
+		if n.Routes == nil {
+			n.Routes = make(map[string]int)
+		}
+		n.Routes[method] = idx
+	}
+	return idx, nil
+}
+
+// Match returns the route that matches the given method and path and the
+// values captured by its wildcards. Static segments take precedence over
+// wildcards which take precedence over catch-all wildcards. Match returns nil
+// if no route matches.
+func (t *RouteTree) Match(method, path string) (*TreeRoute, []string) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, nil
+	}
+	idx, values, ok := t.Root.match(method, path[1:], nil)
+	if !ok {
+		return nil, nil
+	}
+	return t.Routes[idx], values
+}
+
+// route returns the index of the route with the given method and pattern, -1
+// if there is none.
+func (t *RouteTree) route(method, pattern string) int {
+	for i, r := range t.Routes {
+		if r.Method == method && r.Pattern == pattern {
+			return i
+		}
+	}
+	return -1
+}
+
+// match matches path against the node children, path is the part of the
+// request path that follows the node and the slash that separates it from the
+// next segment. values lists the values captured by the parent nodes.
+func (n *RouteNode) match(method, path string, values []string) (int, []string, bool) {
+	seg, rest, last := path, "", true
+	if i := strings.IndexByte(path, '/'); i >= 0 {
+		seg, rest, last = path[:i], path[i+1:], false
+	}
+	if child, ok := n.Static[seg]; ok {
+		if idx, vals, ok := child.next(method, rest, last, values); ok {
+			return idx, vals, true
+		}
+	}
+	if n.Param != nil && seg != "" {
+		if idx, vals, ok := n.Param.next(method, rest, last, append(values, seg)); ok {
+			return idx, vals, true
+		}
+	}
+	if idx, ok := n.CatchAll[method]; ok && path != "" {
+		return idx, append(values, path), true
+	}
+	return 0, nil, false
+}
+
+// next matches the rest of the path once the node matched a segment, last is
+// true if the segment is the last one.
+func (n *RouteNode) next(method, rest string, last bool, values []string) (int, []string, bool) {
+	if last {
+		idx, ok := n.Routes[method]
+		return idx, values, ok
+	}
+	return n.match(method, rest, values)
+}
+
+// NewRadixMuxer returns a Muxer that dispatches the requests using the given
+// route tree, typically compiled by the code generator from the routes of the
+// design. The patterns registered with Handle that are not already part of the
+// tree are added to it. The requests that the tree does not match, for example
+// HEAD requests served by GET handlers or requests that must be redirected,
+// are dispatched to fallback which must implement the semantics of the muxer
+// returned by NewMuxer. NewRadixMuxer uses NewMuxer if fallback is nil.
+//
+// All the handlers are also registered with fallback. If a registered pattern
+// cannot be added to the tree, for example because it uses label or matrix
+// style wildcards, the muxer dispatches all the requests to fallback.
+func NewRadixMuxer(tree *RouteTree, fallback Muxer) Muxer {
+	if tree == nil {
+		tree = NewRouteTree()
+	}
+	if fallback == nil {
+		fallback = NewMuxer()
+	}
+	return &radixMux{
+		tree:     tree,
+		fallback: fallback,
+		handlers: make([]http.HandlerFunc, len(tree.Routes)),
+	}
+}
+
+// Handle registers the handler with the tree and with the fallback muxer.
+func (m *radixMux) Handle(method, pattern string, handler http.HandlerFunc) {
+	m.fallback.Handle(method, pattern, handler)
+	if m.disabled {
+		return
+	}
+	// Adding the route again makes it the one matched by the tree when
+	// other routes only differ by the names of their wildcards so that,
+	// like with the default muxer, the last handler registered wins.
+	idx, err := m.tree.Add(method, pattern)
+	if err != nil {
+		m.disabled = true
+		return
+	}
+	for idx >= len(m.handlers) {
+		m.handlers = append(m.handlers, nil)
+	}
+	m.handlers[idx] = handler
+}
+
+// ServeHTTP dispatches the request to the handler of the route matched by the
+// tree, it dispatches the request to the fallback muxer if there is none.
+func (m *radixMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !m.disabled && r.URL.RawPath == "" && strings.HasPrefix(r.URL.Path, "/") && cleanTreePath(r.URL.Path) {
+		idx, values, ok := m.tree.Root.match(r.Method, r.URL.Path[1:], nil)
+		if ok && m.handlers[idx] != nil {
+			ctx := context.WithValue(r.Context(), radixVarsKey{}, &radixVars{route: m.tree.Routes[idx], values: values})
+			m.handlers[idx](w, r.WithContext(ctx))
+			return
+		}
+	}
+	m.fallback.ServeHTTP(w, r)
+}
+
+// Vars returns the path variables captured for the request.
+func (m *radixMux) Vars(r *http.Request) map[string]string {
+	rv, ok := r.Context().Value(radixVarsKey{}).(*radixVars)
+	if !ok {
+		return m.fallback.Vars(r)
+	}
+	vars := make(map[string]string, len(rv.values))
+	for i, v := range rv.values {
+		vars[rv.route.Vars[i]] = v
+	}
+	return vars
+}
+
+// treeWildcard returns the name of the wildcard that spans seg if any.
+func treeWildcard(seg string) (string, bool) {
+	if len(seg) < 3 || seg[0] != '{' || seg[len(seg)-1] != '}' {
+		return "", false
+	}
+	name := seg[1 : len(seg)-1]
+	if strings.ContainsAny(name, "{}.;/") {
+		return "", false
+	}
+	return name, true
+}
+
+// cleanTreePath returns true if path contains no empty, "." or ".." segment
+// except for a trailing slash. The default muxer redirects the requests made to
+// other paths.
+func cleanTreePath(path string) bool {
+	return !strings.Contains(path, "//") && !strings.Contains(path, "/./") &&
+		!strings.Contains(path, "/../") && !strings.HasSuffix(path, "/.") &&
+		!strings.HasSuffix(path, "/..")
+}
@@ -0,0 +1,152 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+var treeRoutes = []struct{ Method, Pattern string }{
+	{"GET", "/"},
+	{"GET", "/users"},
+	{"GET", "/users/me"},
+	{"GET", "/users/{id}"},
+	{"POST", "/users/{id}"},
+	{"GET", "/users/{id}/posts/{post}"},
+	{"GET", "/users/{id}/posts/latest"},
+	{"GET", "/files/{*path}"},
+	{"GET", "/files/readme"},
+	{"DELETE", "/assets/{path*}"},
+}
+
+func TestRouteTreeAdd(t *testing.T) {
+	cases := []struct{ Name, Pattern, Error string }{
+		{"relative", "users", `goa: pattern "users" does not start with /`},
+		{"label", "/report{.format}", `goa: unsupported wildcard in pattern "/report{.format}"`},
+		{"matrix", "/cars{;color}", `goa: unsupported wildcard in pattern "/cars{;color}"`},
+		{"partial", "/users/id{id}", `goa: unsupported wildcard in pattern "/users/id{id}"`},
+		{"catch-all", "/files/{*path}/name", `goa: catch-all wildcard must end pattern "/files/{*path}/name"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			_, err := NewRouteTree().Add("GET", c.Pattern)
+			if err == nil || err.Error() != c.Error {
+				t.Errorf("got error %v, expected %q", err, c.Error)
+			}
+		})
+	}
+
+	tree := NewRouteTree()
+	first, _ := tree.Add("GET", "/users/{id}")
+	second, _ := tree.Add("GET", "/users/{name}")
+	if again, _ := tree.Add("GET", "/users/{id}"); again != first || first == second {
+		t.Errorf("got indices %d, %d and %d, expected the same index for the same pattern", first, second, again)
+	}
+	if r, vals := tree.Match("GET", "/users/42"); r == nil || r.Pattern != "/users/{id}" || !reflect.DeepEqual(vals, []string{"42"}) {
+		t.Errorf("got route %v with values %v, expected the last added route", r, vals)
+	}
+}
+
+func TestRadixMuxer(t *testing.T) {
+	paths := []struct{ Method, Path string }{
+		{"GET", "/"},
+		{"GET", "/users"},
+		{"GET", "/users/"},
+		{"GET", "/users/me"},
+		{"GET", "/users/42"},
+		{"POST", "/users/me"},
+		{"GET", "/users/42/posts/7"},
+		{"GET", "/users/42/posts/latest"},
+		{"GET", "/users/me/posts/7"},
+		{"GET", "/files/readme"},
+		{"GET", "/files/a/b/c"},
+		{"GET", "/files/"},
+		{"DELETE", "/assets/css/main.css"},
+		{"GET", "/unknown"},
+		{"PUT", "/users/42"},
+		{"HEAD", "/users/42"},
+		{"GET", "/users//me"},
+		{"GET", "/users/a%2Fb"},
+		{"GET", "/users/a%20b"},
+	}
+	serve := func(m Muxer, method, path string) string {
+		w := httptest.NewRecorder()
+		m.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		if w.Code != http.StatusOK {
+			// Error responses include random IDs.
+			return fmt.Sprintf("%d %s", w.Code, w.Header().Get("Location"))
+		}
+		return w.Body.String()
+	}
+	register := func(m Muxer) {
+		for _, r := range treeRoutes {
+			pattern := r.Pattern
+			m.Handle(r.Method, pattern, func(w http.ResponseWriter, req *http.Request) {
+				fmt.Fprintf(w, "%s %v", pattern, m.Vars(req))
+			})
+		}
+	}
+
+	compiled := NewRouteTree()
+	for _, r := range treeRoutes {
+		if _, err := compiled.Add(r.Method, r.Pattern); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, tree := range map[string]*RouteTree{"compiled": compiled, "empty": nil} {
+		t.Run(name, func(t *testing.T) {
+			expected, radix := NewMuxer(), NewRadixMuxer(tree, nil)
+			register(expected)
+			register(radix)
+			for _, p := range paths {
+				if got, want := serve(radix, p.Method, p.Path), serve(expected, p.Method, p.Path); got != want {
+					t.Errorf("%s %s: got %q, expected %q", p.Method, p.Path, got, want)
+				}
+			}
+		})
+	}
+
+	t.Run("unsupported pattern", func(t *testing.T) {
+		var called bool
+		fallback := NewMuxer()
+		m := NewRadixMuxer(compiled, fallback)
+		m.Handle("GET", "/users/{id}", func(w http.ResponseWriter, r *http.Request) { called = true })
+		m.Handle("GET", "/report{.format}", func(w http.ResponseWriter, r *http.Request) {})
+		m.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/users/42", nil))
+		if !called {
+			t.Error("handler not called")
+		}
+		if m.(*radixMux).disabled != true {
+			t.Error("got tree enabled, expected requests dispatched to fallback")
+		}
+	})
+}
+
+func BenchmarkRadixMuxer(b *testing.B) {
+	h := func(w http.ResponseWriter, r *http.Request) {}
+	compiled := NewRouteTree()
+	for _, r := range treeRoutes {
+		compiled.Add(r.Method, r.Pattern)
+	}
+	for _, c := range []struct {
+		Name  string
+		Muxer Muxer
+	}{
+		{"default", NewMuxer()},
+		{"radix", NewRadixMuxer(compiled, nil)},
+	} {
+		for _, r := range treeRoutes {
+			c.Muxer.Handle(r.Method, r.Pattern, h)
+		}
+		b.Run(c.Name, func(b *testing.B) {
+			req := httptest.NewRequest("GET", "/users/42/posts/7", nil)
+			w := httptest.NewRecorder()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				c.Muxer.ServeHTTP(w, req)
+			}
+		})
+	}
+}
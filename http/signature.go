@@ -0,0 +1,426 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// SignatureAlgorithm is the name of the algorithm used to sign the
+	// requests. It prefixes the value of the Authorization header of the
+	// signed requests.
+	SignatureAlgorithm = "GOA-HMAC-SHA256"
+
+	// SignatureDateHeader is the name of the header that contains the time
+	// at which the request was signed.
+	SignatureDateHeader = "X-Goa-Date"
+
+	// SignatureContentHeader is the name of the header that contains the
+	// hex encoded SHA-256 hash of the signed request body.
+	SignatureContentHeader = "X-Goa-Content-Sha256"
+
+	// SignatureNonceHeader is the name of the header that contains a
+	// random value that makes the signature of each request unique so
+	// that retried requests are not rejected as replays.
+	SignatureNonceHeader = "X-Goa-Nonce"
+
+	// SignatureDateFormat is the format of the SignatureDateHeader values.
+	SignatureDateFormat = "20060102T150405Z"
+
+	// DefaultSignatureClockSkew is the default maximum difference between
+	// the time a request was signed and the time it is verified.
+	DefaultSignatureClockSkew = 5 * time.Minute
+
+	// DefaultSignatureBodyLimit is the default maximum size in bytes of
+	// the request bodies read by SignatureVerifier.
+	DefaultSignatureBodyLimit = 10 << 20
+)
+
+var (
+	// ErrSignatureMissing is the error returned by SignatureVerifier when
+	// the request is not signed.
+	ErrSignatureMissing = errors.New("request signature is missing")
+
+	// ErrSignatureInvalid is the error returned by SignatureVerifier when
+	// the request signature is malformed or does not match the request.
+	ErrSignatureInvalid = errors.New("request signature is invalid")
+
+	// ErrSignatureExpired is the error returned by SignatureVerifier when
+	// the request was signed outside of the allowed clock skew.
+	ErrSignatureExpired = errors.New("request signature is expired")
+
+	// ErrSignatureReplayed is the error returned by SignatureVerifier when
+	// the request signature was already used during the replay window.
+	ErrSignatureReplayed = errors.New("request signature was already used")
+
+	// ErrSignatureBodyTooLarge is the error returned by SignatureVerifier
+	// when the request body exceeds the maximum size of the bodies it
+	// reads.
+	ErrSignatureBodyTooLarge = errors.New("request body is too large to verify its signature")
+)
+
+type (
+	// RequestSigner signs HTTP requests in a way similar to AWS Signature
+	// Version 4: the signature is the HMAC-SHA256 computed with a secret
+	// shared with the server of a string that includes the signing time
+	// and the hash of the canonical request. The canonical request is made
+	// of the request method, path, query string, signed headers and body
+	// hash. The signature is sent in the Authorization header together
+	// with the ID of the key and the names of the signed headers.
+	RequestSigner struct {
+		// KeyID identifies the secret to the server.
+		KeyID string
+		// Secret is the secret shared with the server.
+		Secret []byte
+		// Headers lists the names of the request headers that are signed
+		// in addition to Host, Content-Type, X-Goa-Date, X-Goa-Nonce and
+		// X-Goa-Content-Sha256.
+		Headers []string
+
+		// now returns the current time, used by tests.
+		now func() time.Time
+	}
+
+	// SignatureKeyFunc returns the secret with the given ID. It returns an
+	// error if there is no such secret.
+	SignatureKeyFunc func(ctx context.Context, keyID string) ([]byte, error)
+
+	// SignatureReplayCache records the signatures of the verified requests
+	// to detect replays. Servers that run multiple instances may provide an
+	// implementation backed by a shared store.
+	SignatureReplayCache interface {
+		// Add records the given signature until expires. It returns
+		// false if the signature is already recorded.
+		Add(signature string, expires time.Time) bool
+	}
+
+	// SignatureVerifier verifies the signature of requests signed with
+	// RequestSigner.
+	SignatureVerifier struct {
+		// Keys returns the secrets used to verify the signatures.
+		Keys SignatureKeyFunc
+		// ClockSkew is the maximum difference between the time a
+		// request was signed and the time it is verified,
+		// DefaultSignatureClockSkew is used if zero.
+		ClockSkew time.Duration
+		// ReplayWindow is the duration during which the signatures of
+		// verified requests are recorded to reject replays, twice the
+		// clock skew is used if zero so that a signature cannot be
+		// replayed while its timestamp is valid.
+		ReplayWindow time.Duration
+		// Cache records the signatures of the verified requests, an in
+		// memory cache is used if nil.
+		Cache SignatureReplayCache
+		// MaxBodySize is the maximum size in bytes of the request bodies
+		// read to verify their hash, DefaultSignatureBodyLimit is used
+		// if zero.
+		MaxBodySize int64
+
+		// now returns the current time, used by tests.
+		now func() time.Time
+
+		once  sync.Once
+		cache SignatureReplayCache
+	}
+
+	// signingDoer is a Doer that signs the requests.
+	signingDoer struct {
+		Doer
+		signer *RequestSigner
+	}
+
+	// memoryReplayCache is a SignatureReplayCache that keeps the signatures
+	// in memory.
+	memoryReplayCache struct {
+		mu   sync.Mutex
+		seen map[string]time.Time
+		next time.Time
+		now  func() time.Time
+	}
+)
+
+// SigningDoer returns a Doer that signs the requests with signer before making
+// them with doer.
+func SigningDoer(doer Doer, signer *RequestSigner) Doer {
+	return &signingDoer{Doer: doer, signer: signer}
+}
+
+// Do signs and makes the request.
+func (d *signingDoer) Do(req *http.Request) (*http.Response, error) {
+	if err := d.signer.Sign(req); err != nil {
+		return nil, err
+	}
+	return d.Doer.Do(req)
+}
+
+// Sign sets the X-Goa-Date, X-Goa-Nonce, X-Goa-Content-Sha256 and
+// Authorization headers of the request. The request body is read and replaced with an equivalent
+// reader.
+func (s *RequestSigner) Sign(req *http.Request) error {
+	body, err := readBody(req, 0)
+	if err != nil {
+		return fmt.Errorf("failed to sign request: %s", err)
+	}
+	now := time.Now
+	if s.now != nil {
+		now = s.now
+	}
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("failed to sign request: %s", err)
+	}
+	date := now().UTC().Format(SignatureDateFormat)
+	hash := hashBody(body)
+	req.Header.Set(SignatureDateHeader, date)
+	req.Header.Set(SignatureNonceHeader, hex.EncodeToString(nonce))
+	req.Header.Set(SignatureContentHeader, hash)
+	signed := []string{
+		"host",
+		strings.ToLower(SignatureDateHeader),
+		strings.ToLower(SignatureNonceHeader),
+		strings.ToLower(SignatureContentHeader),
+	}
+	if req.Header.Get("Content-Type") != "" {
+		signed = append(signed, "content-type")
+	}
+	for _, h := range s.Headers {
+		h = strings.ToLower(h)
+		if req.Header.Get(h) != "" && !contains(signed, h) {
+			signed = append(signed, h)
+		}
+	}
+	sort.Strings(signed)
+	sig := computeSignature(s.Secret, date, CanonicalRequest(req, signed, hash))
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s, SignedHeaders=%s, Signature=%s",
+		SignatureAlgorithm, s.KeyID, strings.Join(signed, ";"), sig))
+	return nil
+}
+
+// Verify checks the signature of the request and returns the ID of the key
+// that signed it. The request body is read and replaced with an equivalent
+// reader once the key is found, Verify returns ErrSignatureBodyTooLarge if the
+// body exceeds MaxBodySize. Verify returns ErrSignatureMissing if the request
+// is not signed.
+func (v *SignatureVerifier) Verify(req *http.Request) (string, error) {
+	auth := req.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, SignatureAlgorithm+" ") {
+		return "", ErrSignatureMissing
+	}
+	var keyID, signedHeaders, sig string
+	for _, part := range strings.Split(strings.TrimPrefix(auth, SignatureAlgorithm+" "), ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			return "", ErrSignatureInvalid
+		}
+		switch kv[0] {
+		case "Credential":
+			keyID = kv[1]
+		case "SignedHeaders":
+			signedHeaders = kv[1]
+		case "Signature":
+			sig = kv[1]
+		}
+	}
+	if keyID == "" || sig == "" {
+		return "", ErrSignatureInvalid
+	}
+	signed := strings.Split(signedHeaders, ";")
+	if !contains(signed, "host") || !contains(signed, strings.ToLower(SignatureDateHeader)) {
+		return "", ErrSignatureInvalid
+	}
+	date := req.Header.Get(SignatureDateHeader)
+	t, err := time.Parse(SignatureDateFormat, date)
+	if err != nil {
+		return "", ErrSignatureInvalid
+	}
+	now := time.Now
+	if v.now != nil {
+		now = v.now
+	}
+	skew := v.ClockSkew
+	if skew == 0 {
+		skew = DefaultSignatureClockSkew
+	}
+	if d := now().Sub(t); d > skew || d < -skew {
+		return "", ErrSignatureExpired
+	}
+	secret, err := v.Keys(req.Context(), keyID)
+	if err != nil {
+		return "", ErrSignatureInvalid
+	}
+	limit := v.MaxBodySize
+	if limit == 0 {
+		limit = DefaultSignatureBodyLimit
+	}
+	body, err := readBody(req, limit)
+	if err == ErrSignatureBodyTooLarge {
+		return "", err
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read request body: %s", err)
+	}
+	hash := hashBody(body)
+	if h := req.Header.Get(SignatureContentHeader); h != "" && h != hash {
+		return "", ErrSignatureInvalid
+	}
+	expected := computeSignature(secret, date, CanonicalRequest(req, signed, hash))
+	if !hmac.Equal([]byte(sig), []byte(expected)) {
+		return "", ErrSignatureInvalid
+	}
+	window := v.ReplayWindow
+	if window == 0 {
+		window = 2 * skew
+	}
+	v.once.Do(func() {
+		v.cache = v.Cache
+		if v.cache == nil {
+			v.cache = &memoryReplayCache{seen: make(map[string]time.Time), now: now}
+		}
+	})
+	if !v.cache.Add(sig, now().Add(window)) {
+		return "", ErrSignatureReplayed
+	}
+	return keyID, nil
+}
+
+// CanonicalRequest returns the canonical form of the request that is signed:
+// the method, the escaped path, the query string sorted by key, the signed
+// headers with lower case names and trimmed values, the list of signed header
+// names and the body hash each on a separate line.
+func CanonicalRequest(req *http.Request, signedHeaders []string, bodyHash string) string {
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	query := req.URL.Query()
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var params []string
+	for _, k := range keys {
+		vals := append([]string{}, query[k]...)
+		sort.Strings(vals)
+		for _, v := range vals {
+			params = append(params, escapeQuery(k)+"="+escapeQuery(v))
+		}
+	}
+	var headers strings.Builder
+	for _, h := range signedHeaders {
+		var val string
+		if h == "host" {
+			val = req.Host
+			if val == "" {
+				val = req.URL.Host
+			}
+		} else {
+			vals := append([]string{}, req.Header[textproto.CanonicalMIMEHeaderKey(h)]...)
+			for i, v := range vals {
+				vals[i] = strings.TrimSpace(v)
+			}
+			val = strings.Join(vals, ",")
+		}
+		headers.WriteString(h + ":" + val + "\n")
+	}
+	return strings.Join([]string{
+		req.Method,
+		path,
+		strings.Join(params, "&"),
+		headers.String(),
+		strings.Join(signedHeaders, ";"),
+		bodyHash,
+	}, "\n")
+}
+
+// Add records the signature unless it is already recorded. It purges the
+// expired signatures periodically.
+func (c *memoryReplayCache) Add(signature string, expires time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if now.After(c.next) {
+		for s, exp := range c.seen {
+			if now.After(exp) {
+				delete(c.seen, s)
+			}
+		}
+		c.next = now.Add(time.Minute)
+	}
+	if exp, ok := c.seen[signature]; ok && now.Before(exp) {
+		return false
+	}
+	c.seen[signature] = expires
+	return true
+}
+
+// computeSignature returns the hex encoded HMAC-SHA256 of the string to sign
+// built from the date and the canonical request.
+func computeSignature(secret []byte, date, canonical string) string {
+	h := sha256.Sum256([]byte(canonical))
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(SignatureAlgorithm + "\n" + date + "\n" + hex.EncodeToString(h[:])))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// readBody reads the request body and replaces it with an equivalent reader.
+// It returns ErrSignatureBodyTooLarge if limit is positive and the body is
+// larger.
+func readBody(req *http.Request, limit int64) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	r := io.Reader(req.Body)
+	if limit > 0 {
+		r = io.LimitReader(req.Body, limit+1)
+	}
+	body, err := ioutil.ReadAll(r)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && int64(len(body)) > limit {
+		return nil, ErrSignatureBodyTooLarge
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body, nil
+}
+
+// hashBody returns the hex encoded SHA-256 hash of the body.
+func hashBody(body []byte) string {
+	h := sha256.Sum256(body)
+	return hex.EncodeToString(h[:])
+}
+
+// escapeQuery escapes a query string key or value using %20 for spaces.
+func escapeQuery(s string) string {
+	return strings.Replace(url.QueryEscape(s), "+", "%20", -1)
+}
+
+// contains returns true if vals contains val.
+func contains(vals []string, val string) bool {
+	for _, v := range vals {
+		if v == val {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,195 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCanonicalRequest(t *testing.T) {
+	req := httptest.NewRequest("GET", "http://goa.design/a%20b/c?z=1&a=2&a=1&q=x+y", nil)
+	req.Header.Set("X-Goa-Date", "20200913T122640Z")
+	req.Header.Add("X-Custom", " v1 ")
+	req.Header.Add("X-Custom", "v2")
+	got := CanonicalRequest(req, []string{"host", "x-custom", "x-goa-date"}, "hash")
+	expected := "GET\n/a%20b/c\na=1&a=2&q=x%20y&z=1\nhost:goa.design\nx-custom:v1,v2\nx-goa-date:20200913T122640Z\n\nhost;x-custom;x-goa-date\nhash"
+	if got != expected {
+		t.Errorf("got canonical request:\n%s\nexpected:\n%s", got, expected)
+	}
+	if vals := req.Header["X-Custom"]; vals[0] != " v1 " {
+		t.Errorf("got header values %q, expected the request headers to be left as is", vals)
+	}
+}
+
+func TestSignatureVerifier(t *testing.T) {
+	var (
+		secret = []byte("secret")
+		now    = time.Unix(1600000000, 0)
+		body   = `{"id":"1"}`
+		keys   = func(_ context.Context, keyID string) ([]byte, error) {
+			if keyID != "key" {
+				return nil, fmt.Errorf("unknown key %q", keyID)
+			}
+			return secret, nil
+		}
+		sign = func(s *RequestSigner, r *http.Request) *http.Request {
+			if err := s.Sign(r); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			return r
+		}
+		newReq = func() *http.Request {
+			r := httptest.NewRequest("POST", "http://goa.design/accounts?x=1", strings.NewReader(body))
+			r.Header.Set("Content-Type", "application/json")
+			r.Header.Set("X-Tenant", "acme")
+			return r
+		}
+		signer = &RequestSigner{KeyID: "key", Secret: secret, Headers: []string{"X-Tenant"}, now: func() time.Time { return now }}
+	)
+	cases := []struct {
+		Name    string
+		Request func() *http.Request
+		Now     time.Time
+		KeyID   string
+		Error   error
+	}{
+		{"valid", func() *http.Request { return sign(signer, newReq()) }, now, "key", nil},
+		{"within skew", func() *http.Request { return sign(signer, newReq()) }, now.Add(4 * time.Minute), "key", nil},
+		{"unsigned", newReq, now, "", ErrSignatureMissing},
+		{"expired", func() *http.Request { return sign(signer, newReq()) }, now.Add(6 * time.Minute), "", ErrSignatureExpired},
+		{"unknown key", func() *http.Request {
+			return sign(&RequestSigner{KeyID: "other", Secret: secret, now: signer.now}, newReq())
+		}, now, "", ErrSignatureInvalid},
+		{"wrong secret", func() *http.Request {
+			return sign(&RequestSigner{KeyID: "key", Secret: []byte("other"), now: signer.now}, newReq())
+		}, now, "", ErrSignatureInvalid},
+		{"tampered body", func() *http.Request {
+			r := sign(signer, newReq())
+			r.Body = ioutil.NopCloser(strings.NewReader(`{"id":"2"}`))
+			return r
+		}, now, "", ErrSignatureInvalid},
+		{"tampered query", func() *http.Request {
+			r := sign(signer, newReq())
+			r.URL.RawQuery = "x=2"
+			return r
+		}, now, "", ErrSignatureInvalid},
+		{"tampered header", func() *http.Request {
+			r := sign(signer, newReq())
+			r.Header.Set("X-Tenant", "other")
+			return r
+		}, now, "", ErrSignatureInvalid},
+		{"malformed", func() *http.Request {
+			r := newReq()
+			r.Header.Set("Authorization", SignatureAlgorithm+" Credential=key")
+			return r
+		}, now, "", ErrSignatureInvalid},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			v := &SignatureVerifier{Keys: keys, now: func() time.Time { return c.Now }}
+			req := c.Request()
+			keyID, err := v.Verify(req)
+			if err != c.Error {
+				t.Fatalf("got error %v, expected %v", err, c.Error)
+			}
+			if keyID != c.KeyID {
+				t.Errorf("got key ID %q, expected %q", keyID, c.KeyID)
+			}
+			if err == nil {
+				b, _ := ioutil.ReadAll(req.Body)
+				if string(b) != body {
+					t.Errorf("got body %q, expected %q", string(b), body)
+				}
+			}
+		})
+	}
+
+	t.Run("replayed", func(t *testing.T) {
+		v := &SignatureVerifier{Keys: keys, now: func() time.Time { return now }}
+		req := sign(signer, newReq())
+		replay := newReq()
+		for k, v := range req.Header {
+			replay.Header[k] = v
+		}
+		if _, err := v.Verify(req); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if _, err := v.Verify(replay); err != ErrSignatureReplayed {
+			t.Errorf("got error %v, expected %v", err, ErrSignatureReplayed)
+		}
+	})
+
+	t.Run("body too large", func(t *testing.T) {
+		v := &SignatureVerifier{Keys: keys, MaxBodySize: int64(len(body) - 1), now: func() time.Time { return now }}
+		if _, err := v.Verify(sign(signer, newReq())); err != ErrSignatureBodyTooLarge {
+			t.Errorf("got error %v, expected %v", err, ErrSignatureBodyTooLarge)
+		}
+		v.MaxBodySize = int64(len(body))
+		if _, err := v.Verify(sign(signer, newReq())); err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("unknown key body unread", func(t *testing.T) {
+		v := &SignatureVerifier{Keys: keys, now: func() time.Time { return now }}
+		req := sign(&RequestSigner{KeyID: "other", Secret: secret, now: signer.now}, newReq())
+		var read bool
+		req.Body = ioutil.NopCloser(readerFunc(func(p []byte) (int, error) {
+			read = true
+			return 0, io.EOF
+		}))
+		if _, err := v.Verify(req); err != ErrSignatureInvalid {
+			t.Errorf("got error %v, expected %v", err, ErrSignatureInvalid)
+		}
+		if read {
+			t.Error("got body read, expected the key to be looked up first")
+		}
+	})
+
+	t.Run("resigned", func(t *testing.T) {
+		v := &SignatureVerifier{Keys: keys, now: func() time.Time { return now }}
+		for i := 0; i < 2; i++ {
+			if _, err := v.Verify(sign(signer, newReq())); err != nil {
+				t.Errorf("attempt %d: unexpected error: %s", i, err)
+			}
+		}
+	})
+}
+
+func TestSigningDoer(t *testing.T) {
+	var (
+		secret = []byte("secret")
+		keyID  string
+		err    error
+	)
+	v := &SignatureVerifier{Keys: func(context.Context, string) ([]byte, error) { return secret, nil }}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keyID, err = v.Verify(r)
+	}))
+	defer srv.Close()
+
+	doer := SigningDoer(http.DefaultClient, &RequestSigner{KeyID: "key", Secret: secret})
+	req, _ := http.NewRequest("PUT", srv.URL+"/items/1?force=true", strings.NewReader("payload"))
+	resp, rerr := doer.Do(req)
+	if rerr != nil {
+		t.Fatalf("unexpected error: %s", rerr)
+	}
+	resp.Body.Close()
+	if err != nil {
+		t.Errorf("unexpected verification error: %s", err)
+	}
+	if keyID != "key" {
+		t.Errorf("got key ID %q, expected %q", keyID, "key")
+	}
+}
+
+// readerFunc implements io.Reader with a function.
+type readerFunc func(p []byte) (int, error)
+
+func (f readerFunc) Read(p []byte) (int, error) { return f(p) }
@@ -0,0 +1,211 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+type (
+	// StreamFormat identifies the encoding of the results streamed in the
+	// body of chunked HTTP responses.
+	StreamFormat string
+
+	// StreamWriter writes the results sent by a server stream to a chunked
+	// HTTP response. The generated server code uses a StreamWriter for the
+	// endpoints whose design uses StreamingResponse.
+	StreamWriter struct {
+		w       http.ResponseWriter
+		format  StreamFormat
+		mu      sync.Mutex
+		started bool
+		closed  bool
+	}
+
+	// StreamReader reads the results streamed in the body of a chunked HTTP
+	// response written by a StreamWriter. The generated client code uses a
+	// StreamReader for the endpoints whose design uses StreamingResponse.
+	StreamReader struct {
+		body    io.ReadCloser
+		dec     *json.Decoder
+		format  StreamFormat
+		started bool
+		done    bool
+	}
+)
+
+const (
+	// StreamNDJSON streams each result as a JSON value followed by a
+	// newline using the "application/x-ndjson" content type.
+	StreamNDJSON StreamFormat = "ndjson"
+	// StreamJSONArray streams the results as the elements of a JSON array
+	// using the "application/json" content type.
+	StreamJSONArray StreamFormat = "json-array"
+)
+
+// ErrStreamClosed is the error returned when sending a result to a closed
+// stream.
+var ErrStreamClosed = errors.New("stream is closed")
+
+// ContentType returns the content type of the responses that use the format.
+func (f StreamFormat) ContentType() string {
+	if f == StreamJSONArray {
+		return "application/json"
+	}
+	return "application/x-ndjson"
+}
+
+// NewStreamWriter returns a stream writer that writes results to w using the
+// given format. The response status code and headers are written when the
+// first result is sent or when the stream is closed, whichever comes first.
+func NewStreamWriter(w http.ResponseWriter, format StreamFormat) *StreamWriter {
+	return &StreamWriter{w: w, format: format}
+}
+
+// Header returns the header map of the underlying response. Changes made to
+// the map after the first result is sent have no effect.
+func (s *StreamWriter) Header() http.Header {
+	return s.w.Header()
+}
+
+// Started returns true if the response status code and headers have been
+// written. Errors returned by the service after the stream has started cannot
+// be written to the response.
+func (s *StreamWriter) Started() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.started
+}
+
+// Send encodes v as JSON, writes it to the response and flushes it to the
+// client.
+func (s *StreamWriter) Send(v interface{}) error {
+	b, err := JSON().Marshal(v)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ErrStreamClosed
+	}
+	if s.started && s.format == StreamJSONArray {
+		b = append([]byte{','}, b...)
+	}
+	if s.format != StreamJSONArray {
+		b = append(b, '\n')
+	}
+	if err := s.start(); err != nil {
+		return err
+	}
+	if _, err := s.w.Write(b); err != nil {
+		return err
+	}
+	s.flush()
+	return nil
+}
+
+// Close terminates the stream. It writes the response status code and headers
+// if no result was sent. Close is idempotent.
+func (s *StreamWriter) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	if err := s.start(); err != nil {
+		return err
+	}
+	if s.format == StreamJSONArray {
+		if _, err := io.WriteString(s.w, "]"); err != nil {
+			return err
+		}
+	}
+	s.flush()
+	return nil
+}
+
+// start writes the response status code and headers and the opening bracket
+// of JSON arrays if not done already.
+func (s *StreamWriter) start() error {
+	if s.started {
+		return nil
+	}
+	s.started = true
+	h := s.w.Header()
+	h.Set("Content-Type", s.format.ContentType())
+	h.Set("X-Content-Type-Options", "nosniff")
+	s.w.WriteHeader(http.StatusOK)
+	if s.format == StreamJSONArray {
+		_, err := io.WriteString(s.w, "[")
+		return err
+	}
+	return nil
+}
+
+// flush sends the buffered data to the client if the response writer
+// supports it.
+func (s *StreamWriter) flush() {
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// NewStreamReader returns a stream reader that reads results from body using
+// the given format.
+func NewStreamReader(body io.ReadCloser, format StreamFormat) *StreamReader {
+	return &StreamReader{body: body, dec: json.NewDecoder(body), format: format}
+}
+
+// Recv reads the next result into v. It returns io.EOF and closes the response
+// body once all the results have been read.
+func (s *StreamReader) Recv(v interface{}) error {
+	if s.done {
+		return io.EOF
+	}
+	if s.format == StreamJSONArray {
+		if !s.started {
+			s.started = true
+			tok, err := s.dec.Token()
+			if err != nil {
+				return s.fail(err)
+			}
+			if d, ok := tok.(json.Delim); !ok || d != '[' {
+				return s.fail(fmt.Errorf("invalid stream content, expected '[' got %v", tok))
+			}
+		}
+		if !s.dec.More() {
+			if _, err := s.dec.Token(); err != nil {
+				return s.fail(err)
+			}
+			return s.fail(io.EOF)
+		}
+	}
+	var raw json.RawMessage
+	if err := s.dec.Decode(&raw); err != nil {
+		return s.fail(err)
+	}
+	if err := JSON().Unmarshal(raw, v); err != nil {
+		return s.fail(err)
+	}
+	return nil
+}
+
+// Close closes the response body, subsequent calls to Recv return io.EOF.
+func (s *StreamReader) Close() error {
+	if s.done {
+		return nil
+	}
+	s.done = true
+	return s.body.Close()
+}
+
+// fail closes the stream and returns err.
+func (s *StreamReader) fail(err error) error {
+	s.Close()
+	return err
+}
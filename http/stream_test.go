@@ -0,0 +1,164 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type streamResult struct {
+	ID int `json:"id"`
+}
+
+func TestStreamWriter(t *testing.T) {
+	cases := []struct {
+		Name        string
+		Format      StreamFormat
+		Results     []int
+		ContentType string
+		Body        string
+	}{
+		{"ndjson", StreamNDJSON, []int{1, 2}, "application/x-ndjson", "{\"id\":1}\n{\"id\":2}\n"},
+		{"ndjson empty", StreamNDJSON, nil, "application/x-ndjson", ""},
+		{"json array", StreamJSONArray, []int{1, 2}, "application/json", `[{"id":1},{"id":2}]`},
+		{"json array empty", StreamJSONArray, nil, "application/json", "[]"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			s := NewStreamWriter(w, c.Format)
+			if s.Started() {
+				t.Error("stream started before sending")
+			}
+			for _, id := range c.Results {
+				if err := s.Send(&streamResult{ID: id}); err != nil {
+					t.Fatalf("unexpected send error: %s", err)
+				}
+			}
+			if err := s.Close(); err != nil {
+				t.Fatalf("unexpected close error: %s", err)
+			}
+			if err := s.Close(); err != nil {
+				t.Errorf("unexpected error when closing twice: %s", err)
+			}
+			if err := s.Send(&streamResult{}); err != ErrStreamClosed {
+				t.Errorf("got error %v when sending to closed stream, expected %v", err, ErrStreamClosed)
+			}
+			if !s.Started() {
+				t.Error("stream not started")
+			}
+			if w.Code != http.StatusOK {
+				t.Errorf("got status %d, expected %d", w.Code, http.StatusOK)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != c.ContentType {
+				t.Errorf("got content type %q, expected %q", ct, c.ContentType)
+			}
+			if !w.Flushed {
+				t.Error("response not flushed")
+			}
+			if got := w.Body.String(); got != c.Body {
+				t.Errorf("got body %q, expected %q", got, c.Body)
+			}
+		})
+	}
+}
+
+func TestStreamReader(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Format   StreamFormat
+		Body     string
+		Expected []int
+		Error    string
+	}{
+		{"ndjson", StreamNDJSON, "{\"id\":1}\n{\"id\":2}\n", []int{1, 2}, ""},
+		{"ndjson empty", StreamNDJSON, "", nil, ""},
+		{"ndjson invalid", StreamNDJSON, "{\"id\":1}\n{\"id\"", []int{1}, "unexpected EOF"},
+		{"json array", StreamJSONArray, `[{"id":1}, {"id":2}]`, []int{1, 2}, ""},
+		{"json array empty", StreamJSONArray, "[]", nil, ""},
+		{"json array not array", StreamJSONArray, `{"id":1}`, nil, "invalid stream content, expected '[' got {"},
+		{"json array truncated", StreamJSONArray, `[{"id":1},`, []int{1}, "unexpected end of JSON input"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			body := &closeRecorder{Reader: strings.NewReader(c.Body)}
+			r := NewStreamReader(body, c.Format)
+			var ids []int
+			var err error
+			for {
+				var res streamResult
+				if err = r.Recv(&res); err != nil {
+					break
+				}
+				ids = append(ids, res.ID)
+			}
+			if c.Error == "" && err != io.EOF {
+				t.Errorf("got error %v, expected %v", err, io.EOF)
+			}
+			if c.Error != "" && (err == nil || err.Error() != c.Error) {
+				t.Errorf("got error %v, expected %q", err, c.Error)
+			}
+			if len(ids) != len(c.Expected) {
+				t.Fatalf("got %v, expected %v", ids, c.Expected)
+			}
+			for i, id := range ids {
+				if id != c.Expected[i] {
+					t.Errorf("got result %d at index %d, expected %d", id, i, c.Expected[i])
+				}
+			}
+			if !body.closed {
+				t.Error("body not closed")
+			}
+			if err := r.Recv(&streamResult{}); err != io.EOF {
+				t.Errorf("got error %v after end of stream, expected %v", err, io.EOF)
+			}
+		})
+	}
+}
+
+func TestStreamRoundTrip(t *testing.T) {
+	for _, format := range []StreamFormat{StreamNDJSON, StreamJSONArray} {
+		t.Run(string(format), func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				s := NewStreamWriter(w, format)
+				for i := 1; i <= 3; i++ {
+					s.Send(&streamResult{ID: i})
+				}
+				s.Close()
+			}))
+			defer srv.Close()
+			resp, err := http.Get(srv.URL)
+			if err != nil {
+				t.Fatalf("unexpected request error: %s", err)
+			}
+			r := NewStreamReader(resp.Body, format)
+			sum := 0
+			for {
+				var res streamResult
+				if err := r.Recv(&res); err != nil {
+					if err != io.EOF {
+						t.Fatalf("unexpected receive error: %s", err)
+					}
+					break
+				}
+				sum += res.ID
+			}
+			if sum != 6 {
+				t.Errorf("got sum %d, expected 6", sum)
+			}
+		})
+	}
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
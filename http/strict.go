@@ -0,0 +1,207 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// strictDecoder is a request body decoder that checks the JSON body
+	// for unknown fields prior to decoding it.
+	strictDecoder struct {
+		decoder func(*http.Request) Decoder
+		r       *http.Request
+	}
+)
+
+// unmarshalerType is the type of the values that implement custom JSON
+// decoding.
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// StrictDecoder wraps the given request decoder so that decoding a JSON
+// request body fails with an "unknown_fields" validation error if the body
+// contains fields that do not correspond to any field of the decoded value.
+// The error lists the paths to all the unknown fields. Fields are matched
+// using the "json" struct tags of the decoded value and the same case
+// insensitive matching as package encoding/json. Bodies using other media
+// types are decoded by the wrapped decoder as is. The generated code uses
+// StrictDecoder to decode the request bodies of the endpoints that use the
+// StrictDecoding DSL.
+func StrictDecoder(decoder func(*http.Request) Decoder) func(*http.Request) Decoder {
+	return func(r *http.Request) Decoder {
+		if !isJSONRequest(r) {
+			return decoder(r)
+		}
+		return &strictDecoder{decoder: decoder, r: r}
+	}
+}
+
+// CheckQueryParams returns an "unknown_fields" validation error listing the
+// query string parameters of the request whose names are not one of the given
+// names. A name ending with "[" matches all the parameters starting with the
+// name, this makes it possible to accept the keys of deepObject style
+// parameters (e.g. "filter[" matches "filter[name]"). The generated code calls
+// CheckQueryParams before decoding the requests of the endpoints that use the
+// StrictDecoding DSL.
+func CheckQueryParams(r *http.Request, names ...string) error {
+	var unknown []string
+	for key := range r.URL.Query() {
+		if !matchQueryParam(key, names) {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return goa.UnknownFieldsError("query string", unknown)
+}
+
+// Decode checks the request body for unknown fields and decodes it into v
+// with the wrapped decoder.
+func (d *strictDecoder) Decode(v interface{}) error {
+	b, err := ioutil.ReadAll(d.r.Body)
+	if err != nil {
+		// Leave the body as is so that the caller may inspect it, e.g.
+		// with BodyLimitError.
+		return err
+	}
+	d.r.Body = ioutil.NopCloser(bytes.NewReader(b))
+	var raw interface{}
+	if err := json.Unmarshal(b, &raw); err == nil {
+		if unknown := unknownFields("body", raw, reflect.TypeOf(v)); len(unknown) > 0 {
+			return goa.UnknownFieldsError("body", unknown)
+		}
+	}
+	// Let the wrapped decoder report invalid and empty bodies.
+	return d.decoder(d.r).Decode(v)
+}
+
+// unknownFields returns the paths to the fields of the decoded JSON value raw
+// that do not correspond to any field of a value of type t.
+func unknownFields(path string, raw interface{}, t reflect.Type) []string {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() == reflect.Interface || reflect.PtrTo(t).Implements(unmarshalerType) {
+		return nil
+	}
+	var unknown []string
+	switch actual := raw.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(actual))
+		for k := range actual {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		switch t.Kind() {
+		case reflect.Struct:
+			fields := jsonFields(t)
+			for _, k := range keys {
+				f, ok := lookupField(fields, k)
+				if !ok {
+					unknown = append(unknown, path+"."+k)
+					continue
+				}
+				unknown = append(unknown, unknownFields(path+"."+k, actual[k], f.Type)...)
+			}
+		case reflect.Map:
+			for _, k := range keys {
+				unknown = append(unknown, unknownFields(path+"["+k+"]", actual[k], t.Elem())...)
+			}
+		}
+	case []interface{}:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for i, e := range actual {
+				unknown = append(unknown, unknownFields(path+"["+strconv.Itoa(i)+"]", e, t.Elem())...)
+			}
+		}
+	}
+	return unknown
+}
+
+// jsonFields returns the fields of the struct type t indexed by JSON name. The
+// fields of embedded structs are promoted.
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := f.Name
+		if tag, ok := f.Tag.Lookup("json"); ok {
+			if tag == "-" {
+				continue
+			}
+			if n := strings.Split(tag, ",")[0]; n != "" {
+				name = n
+			}
+		} else if f.Anonymous {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for n, et := range jsonFields(ft) {
+					if _, ok := fields[n]; !ok {
+						fields[n] = et
+					}
+				}
+				continue
+			}
+		}
+		if f.PkgPath != "" && !f.Anonymous {
+			// unexported
+			continue
+		}
+		fields[name] = f
+	}
+	return fields
+}
+
+// lookupField returns the field with the given JSON name. It uses the same case
+// insensitive matching as package encoding/json if there is no exact match.
+func lookupField(fields map[string]reflect.StructField, name string) (reflect.StructField, bool) {
+	if f, ok := fields[name]; ok {
+		return f, true
+	}
+	for n, f := range fields {
+		if strings.EqualFold(n, name) {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// matchQueryParam returns true if key is one of the given names or starts with
+// one of the names ending with "[".
+func matchQueryParam(key string, names []string) bool {
+	for _, n := range names {
+		if key == n || strings.HasSuffix(n, "[") && strings.HasPrefix(key, n) {
+			return true
+		}
+	}
+	return false
+}
+
+// isJSONRequest returns true if the request body is decoded as JSON by
+// RequestDecoder, that is if the request Content-Type header is missing or
+// denotes a JSON media type.
+func isJSONRequest(r *http.Request) bool {
+	ct := r.Header.Get("Content-Type")
+	if ct == "" {
+		return true
+	}
+	mt, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return false
+	}
+	return mt == "application/json" || strings.HasSuffix(mt, "+json")
+}
@@ -0,0 +1,144 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestStrictDecoder(t *testing.T) {
+	type (
+		item struct {
+			ID *string `json:"id,omitempty"`
+		}
+		body struct {
+			Name  *string          `json:"name,omitempty"`
+			Items []*item          `json:"items,omitempty"`
+			Tags  map[string]*item `json:"tags,omitempty"`
+			Any   interface{}      `json:"any,omitempty"`
+			Skip  string           `json:"-"`
+		}
+	)
+	cases := []struct {
+		Name        string
+		Body        string
+		ContentType string
+		Unknown     []string
+		DecodeError bool
+	}{
+		{"valid", `{"name":"a","items":[{"id":"b"}],"tags":{"x":{"id":"c"}},"any":{"foo":1}}`, "", nil, false},
+		{"case-insensitive", `{"Name":"a"}`, "application/json", nil, false},
+		{"unknown", `{"name":"a","foo":1,"bar":{}}`, "", []string{"body.bar", "body.foo"}, false},
+		{"nested", `{"items":[{"id":"b"},{"idx":"c"}],"tags":{"x":{"foo":1}}}`, "application/json; charset=utf-8", []string{"body.items[1].idx", "body.tags[x].foo"}, false},
+		{"skipped", `{"Skip":"a"}`, "application/vnd.api+json", []string{"body.Skip"}, false},
+		{"not-json", `{"foo":1}`, "text/plain", nil, true},
+		{"invalid", `{"foo":`, "", nil, true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/", strings.NewReader(c.Body))
+			if c.ContentType != "" {
+				r.Header.Set("Content-Type", c.ContentType)
+			}
+			var b body
+			err := StrictDecoder(RequestDecoder)(r).Decode(&b)
+			if c.Unknown == nil {
+				if c.DecodeError && err == nil {
+					t.Fatal("got no error, expected a decoding error")
+				}
+				if !c.DecodeError && err != nil {
+					t.Fatalf("got error %s, expected none", err)
+				}
+				return
+			}
+			se, ok := err.(*goa.ServiceError)
+			if !ok {
+				t.Fatalf("got error %v, expected a service error", err)
+			}
+			vs := se.Violations()
+			if len(vs) != 1 || vs[0].Rule != "unknown_fields" || vs[0].Field != "body" {
+				t.Fatalf("got violations %+v, expected one unknown_fields violation", vs)
+			}
+			for _, u := range c.Unknown {
+				if !strings.Contains(se.Message, `"`+u+`"`) {
+					t.Errorf("got message %q, expected it to list %q", se.Message, u)
+				}
+			}
+		})
+	}
+}
+
+func TestStrictDecoderBodyLimit(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"name":"too long"}`))
+	LimitRequestBody(w, r, 5)
+	var b struct {
+		Name *string `json:"name"`
+	}
+	err := StrictDecoder(RequestDecoder)(r).Decode(&b)
+	if err == nil {
+		t.Fatal("got no error, expected one")
+	}
+	se, ok := BodyLimitError(r, err).(*goa.ServiceError)
+	if !ok || se.Name != "request_too_large" {
+		t.Errorf("got error %v, expected request_too_large", err)
+	}
+}
+
+func TestCheckQueryParams(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Query   string
+		Known   []string
+		Unknown []string
+	}{
+		{"none", "", nil, nil},
+		{"known", "page=1&tags=a&tags=b", []string{"page", "tags"}, nil},
+		{"deep-object", "filter[name]=a&filter[owner][id]=b", []string{"filter["}, nil},
+		{"unknown", "page=1&foo=a&bar=b", []string{"page"}, []string{"bar", "foo"}},
+		{"prefix-only", "filterx=a&filter=b", []string{"filter["}, []string{"filter", "filterx"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/?"+c.Query, nil)
+			err := CheckQueryParams(r, c.Known...)
+			if c.Unknown == nil {
+				if err != nil {
+					t.Errorf("got error %s, expected none", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("got no error, expected one")
+			}
+			expected := goa.UnknownFieldsError("query string", c.Unknown).(*goa.ServiceError)
+			if err.(*goa.ServiceError).Message != expected.Message {
+				t.Errorf("got message %q, expected %q", err.(*goa.ServiceError).Message, expected.Message)
+			}
+		})
+	}
+}
+
+func TestIsJSONRequest(t *testing.T) {
+	cases := map[string]bool{
+		"":                                true,
+		"application/json":                true,
+		"application/problem+json":        true,
+		"application/json; charset=utf-8": true,
+		"application/xml":                 false,
+		"multipart/form-data":             false,
+		"invalid/":                        false,
+	}
+	for ct, expected := range cases {
+		r := &http.Request{Header: http.Header{}}
+		if ct != "" {
+			r.Header.Set("Content-Type", ct)
+		}
+		if actual := isJSONRequest(r); actual != expected {
+			t.Errorf("%q: got %v, expected %v", ct, actual, expected)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// WebhookSignatureHeader is the name of the header that contains the
+	// signature of the webhook requests.
+	WebhookSignatureHeader = "Webhook-Signature"
+
+	// WebhookEventHeader is the name of the header that contains the name
+	// of the webhook event.
+	WebhookEventHeader = "Webhook-Event"
+)
+
+var (
+	// ErrWebhookSignatureMissing is the error returned by VerifyWebhook
+	// when the signature does not contain a timestamp or a signature.
+	ErrWebhookSignatureMissing = errors.New("webhook signature is missing")
+
+	// ErrWebhookSignatureInvalid is the error returned by VerifyWebhook
+	// when none of the signatures match the request body.
+	ErrWebhookSignatureInvalid = errors.New("webhook signature is invalid")
+
+	// ErrWebhookSignatureExpired is the error returned by VerifyWebhook
+	// when the signature timestamp is outside of the tolerance.
+	ErrWebhookSignatureExpired = errors.New("webhook signature is expired")
+)
+
+// SignWebhook returns the value of the Webhook-Signature header of a webhook
+// request sent at time t with the given body. The value has the form
+// "t=<timestamp>,v1=<signature>" where timestamp is the Unix time of t and
+// signature is the hex encoded HMAC-SHA256 of the timestamp followed by a dot
+// and the body computed with secret.
+func SignWebhook(secret []byte, t time.Time, body []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", ts, webhookSignature(secret, ts, body))
+}
+
+// VerifyWebhook checks that the given Webhook-Signature header value was
+// computed with secret for the given body, see SignWebhook. The header may
+// list multiple "v1" signatures to make it possible to rotate secrets, the
+// verification succeeds if any of them matches. If tolerance is greater than
+// zero VerifyWebhook also checks that the signature timestamp is within
+// tolerance of now to prevent replay attacks.
+func VerifyWebhook(secret []byte, signature string, body []byte, now time.Time, tolerance time.Duration) error {
+	var (
+		ts   string
+		sigs []string
+	)
+	for _, part := range strings.Split(signature, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "t":
+			ts = kv[1]
+		case "v1":
+			sigs = append(sigs, kv[1])
+		}
+	}
+	if ts == "" || len(sigs) == 0 {
+		return ErrWebhookSignatureMissing
+	}
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return ErrWebhookSignatureMissing
+	}
+	if tolerance > 0 {
+		d := now.Sub(time.Unix(sec, 0))
+		if d < 0 {
+			d = -d
+		}
+		if d > tolerance {
+			return ErrWebhookSignatureExpired
+		}
+	}
+	expected := webhookSignature(secret, ts, body)
+	for _, sig := range sigs {
+		if hmac.Equal([]byte(sig), []byte(expected)) {
+			return nil
+		}
+	}
+	return ErrWebhookSignatureInvalid
+}
+
+// SendWebhook sends the webhook request for the given event to the URL u
+// using doer. The request is a POST whose body is the JSON encoding of body
+// signed with secret. SendWebhook returns an error if the request fails or if
+// the response status code is not 2xx.
+func SendWebhook(ctx context.Context, doer Doer, u string, secret []byte, event string, body interface{}) error {
+	b, err := JSON().Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode %q webhook body: %s", event, err)
+	}
+	req, err := http.NewRequest("POST", u, bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("invalid %q webhook URL: %s", event, err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, event)
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(secret, time.Now(), b))
+	resp, err := doer.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send %q webhook: %s", event, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%q webhook rejected with status %d", event, resp.StatusCode)
+	}
+	return nil
+}
+
+// webhookSignature returns the hex encoded HMAC-SHA256 of the given timestamp
+// and body computed with secret.
+func webhookSignature(secret []byte, ts string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,97 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerifyWebhook(t *testing.T) {
+	var (
+		secret = []byte("secret")
+		body   = []byte(`{"id":"1"}`)
+		now    = time.Unix(1600000000, 0)
+		sig    = SignWebhook(secret, now, body)
+	)
+	cases := []struct {
+		Name      string
+		Secret    []byte
+		Signature string
+		Body      []byte
+		Now       time.Time
+		Tolerance time.Duration
+		Error     error
+	}{
+		{"valid", secret, sig, body, now, time.Minute, nil},
+		{"no tolerance", secret, sig, body, now.Add(time.Hour), 0, nil},
+		{"rotated secret", secret, SignWebhook([]byte("old"), now, body) + "," + sig[strings.Index(sig, "v1="):], body, now, 0, nil},
+		{"missing", secret, "", body, now, 0, ErrWebhookSignatureMissing},
+		{"no timestamp", secret, sig[strings.Index(sig, "v1="):], body, now, 0, ErrWebhookSignatureMissing},
+		{"invalid timestamp", secret, "t=x," + sig[strings.Index(sig, "v1="):], body, now, 0, ErrWebhookSignatureMissing},
+		{"wrong secret", []byte("other"), sig, body, now, 0, ErrWebhookSignatureInvalid},
+		{"tampered body", secret, sig, []byte(`{"id":"2"}`), now, 0, ErrWebhookSignatureInvalid},
+		{"expired", secret, sig, body, now.Add(2 * time.Minute), time.Minute, ErrWebhookSignatureExpired},
+		{"future", secret, sig, body, now.Add(-2 * time.Minute), time.Minute, ErrWebhookSignatureExpired},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := VerifyWebhook(c.Secret, c.Signature, c.Body, c.Now, c.Tolerance)
+			if err != c.Error {
+				t.Errorf("got error %v, expected %v", err, c.Error)
+			}
+		})
+	}
+}
+
+func TestSignWebhook(t *testing.T) {
+	sig := SignWebhook([]byte("secret"), time.Unix(1600000000, 0), []byte("body"))
+	expected := "t=1600000000,v1=244993394588ac85981b4505c45730b0a3a72b224b1365e89b5b3c231b84c7f6"
+	if sig != expected {
+		t.Errorf("got signature %q, expected %q", sig, expected)
+	}
+}
+
+func TestSendWebhook(t *testing.T) {
+	var (
+		secret = []byte("secret")
+		status = http.StatusNoContent
+		req    *http.Request
+		body   []byte
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+		body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	payload := map[string]string{"id": "1"}
+	if err := SendWebhook(context.Background(), http.DefaultClient, srv.URL, secret, "order.created", payload); err != nil {
+		t.Fatalf("got error %v", err)
+	}
+	if req.Method != "POST" {
+		t.Errorf("got method %q, expected POST", req.Method)
+	}
+	if ct := req.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("got content type %q, expected application/json", ct)
+	}
+	if ev := req.Header.Get(WebhookEventHeader); ev != "order.created" {
+		t.Errorf("got event %q, expected order.created", ev)
+	}
+	if string(body) != `{"id":"1"}` {
+		t.Errorf("got body %q", string(body))
+	}
+	if err := VerifyWebhook(secret, req.Header.Get(WebhookSignatureHeader), body, time.Now(), time.Minute); err != nil {
+		t.Errorf("got signature error %v", err)
+	}
+
+	status = http.StatusBadRequest
+	err := SendWebhook(context.Background(), http.DefaultClient, srv.URL, secret, "order.created", payload)
+	if err == nil || !strings.Contains(err.Error(), "status 400") {
+		t.Errorf("got error %v, expected rejection error", err)
+	}
+}
@@ -3,6 +3,9 @@ package http
 import (
 	"context"
 	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
@@ -25,3 +28,200 @@ type (
 	// invoked in the configure function.
 	ConnConfigureFunc func(conn *websocket.Conn, cancel context.CancelFunc) *websocket.Conn
 )
+
+type (
+	// WebSocketConfig holds the websocket settings defined in the design for
+	// a streaming endpoint. The generated code uses it to wrap the upgrader,
+	// dialer and connection configurer provided by the user.
+	WebSocketConfig struct {
+		// Subprotocols lists the supported subprotocols in order of
+		// preference.
+		Subprotocols []string
+		// EnableCompression enables permessage-deflate compression.
+		EnableCompression bool
+		// PingInterval is the interval at which ping messages are sent
+		// to keep the connection alive. Zero disables pings.
+		PingInterval time.Duration
+		// PongWait is the duration after which the peer is considered
+		// dead if no pong message answered the pings, the connection is
+		// then closed and the request context canceled. Zero disables
+		// the detection. The pong messages are only processed while the
+		// connection is read, see SendOnly.
+		PongWait time.Duration
+		// SendOnly indicates that the stream never reads the connection.
+		// The connection configurer then reads and discards the messages
+		// sent by the peer so that the pong and close messages are
+		// processed.
+		SendOnly bool
+		// ReadLimit is the maximum size in bytes of a message read from
+		// the connection. Zero means no limit.
+		ReadLimit int64
+	}
+
+	// wsUpgrader negotiates the websocket subprotocol prior to upgrading
+	// the connection.
+	wsUpgrader struct {
+		up     Upgrader
+		config *WebSocketConfig
+	}
+
+	// wsDialer requests the websocket subprotocols when dialing.
+	wsDialer struct {
+		dialer Dialer
+		config *WebSocketConfig
+	}
+
+	// keepAlive pings the peer of a websocket connection and closes the
+	// connection when the peer stops answering.
+	keepAlive struct {
+		// deadline is the read deadline of the connection in
+		// nanoseconds since the epoch, it is first in the struct so
+		// that it is 64-bit aligned for the atomic operations.
+		deadline int64
+		conn     *websocket.Conn
+		cancel   context.CancelFunc
+		interval time.Duration
+		wait     time.Duration
+	}
+)
+
+// Upgrader returns an upgrader that negotiates the subprotocol with the client
+// and enables compression if configured to do so prior to upgrading the
+// connection using up.
+func (c *WebSocketConfig) Upgrader(up Upgrader) Upgrader {
+	if u, ok := up.(*websocket.Upgrader); ok && c.EnableCompression && !u.EnableCompression {
+		cu := *u
+		cu.EnableCompression = true
+		up = &cu
+	}
+	if len(c.Subprotocols) == 0 {
+		return up
+	}
+	return &wsUpgrader{up: up, config: c}
+}
+
+// Dialer returns a dialer that requests the configured subprotocols and enables
+// compression if configured to do so prior to dialing using d.
+func (c *WebSocketConfig) Dialer(d Dialer) Dialer {
+	if wd, ok := d.(*websocket.Dialer); ok && c.EnableCompression && !wd.EnableCompression {
+		cd := *wd
+		cd.EnableCompression = true
+		d = &cd
+	}
+	if len(c.Subprotocols) == 0 {
+		return d
+	}
+	return &wsDialer{dialer: d, config: c}
+}
+
+// ConnConfigureFunc returns a connection configurer that applies the read
+// limit, compression and keep-alive settings prior to calling fn if not nil.
+func (c *WebSocketConfig) ConnConfigureFunc(fn ConnConfigureFunc) ConnConfigureFunc {
+	return func(conn *websocket.Conn, cancel context.CancelFunc) *websocket.Conn {
+		if c.ReadLimit > 0 {
+			conn.SetReadLimit(c.ReadLimit)
+		}
+		if c.EnableCompression {
+			conn.EnableWriteCompression(true)
+		}
+		if c.PingInterval > 0 {
+			k := &keepAlive{conn: conn, cancel: cancel, interval: c.PingInterval, wait: c.PongWait}
+			if k.wait > 0 {
+				k.pong()
+				conn.SetPongHandler(func(string) error { return k.pong() })
+			}
+			go k.ping()
+		}
+		if c.SendOnly {
+			go discard(conn, cancel)
+		}
+		if fn != nil {
+			conn = fn(conn, cancel)
+		}
+		return conn
+	}
+}
+
+// NegotiateSubprotocol returns the first subprotocol in protocols requested by
+// the client, the empty string if there is none.
+func NegotiateSubprotocol(r *http.Request, protocols []string) string {
+	requested := websocket.Subprotocols(r)
+	for _, p := range protocols {
+		for _, rp := range requested {
+			if p == rp {
+				return p
+			}
+		}
+	}
+	return ""
+}
+
+// Upgrade sets the negotiated subprotocol in the response header and upgrades
+// the connection.
+func (u *wsUpgrader) Upgrade(w http.ResponseWriter, r *http.Request, responseHeader http.Header) (*websocket.Conn, error) {
+	if p := NegotiateSubprotocol(r, u.config.Subprotocols); p != "" {
+		if responseHeader == nil {
+			responseHeader = make(http.Header)
+		}
+		responseHeader.Set("Sec-Websocket-Protocol", p)
+	}
+	return u.up.Upgrade(w, r, responseHeader)
+}
+
+// DialContext adds the subprotocols to the request header and dials.
+func (d *wsDialer) DialContext(ctx context.Context, url string, h http.Header) (*websocket.Conn, *http.Response, error) {
+	hdr := make(http.Header, len(h)+1)
+	for k, v := range h {
+		hdr[k] = v
+	}
+	hdr.Set("Sec-Websocket-Protocol", strings.Join(d.config.Subprotocols, ", "))
+	return d.dialer.DialContext(ctx, url, hdr)
+}
+
+// ping sends ping messages at the configured interval. It closes the
+// connection and cancels the request context when writing to the connection
+// fails or when no pong message was received for the configured wait.
+func (k *keepAlive) ping() {
+	ticker := time.NewTicker(k.interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if k.wait > 0 && time.Now().UnixNano() > atomic.LoadInt64(&k.deadline) {
+			k.close()
+			return
+		}
+		if err := k.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(k.interval)); err != nil {
+			k.close()
+			return
+		}
+	}
+}
+
+// pong extends the read deadline of the connection, it is used as pong
+// handler.
+func (k *keepAlive) pong() error {
+	deadline := time.Now().Add(k.wait)
+	atomic.StoreInt64(&k.deadline, deadline.UnixNano())
+	return k.conn.SetReadDeadline(deadline)
+}
+
+// close closes the connection and cancels the request context.
+func (k *keepAlive) close() {
+	k.conn.Close()
+	if k.cancel != nil {
+		k.cancel()
+	}
+}
+
+// discard reads and discards the messages sent by the peer until reading fails
+// and then closes the connection and cancels the request context.
+func discard(conn *websocket.Conn, cancel context.CancelFunc) {
+	for {
+		if _, _, err := conn.NextReader(); err != nil {
+			conn.Close()
+			if cancel != nil {
+				cancel()
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+type (
+	headerUpgrader struct {
+		header http.Header
+	}
+
+	headerDialer struct {
+		header http.Header
+	}
+)
+
+func (u *headerUpgrader) Upgrade(w http.ResponseWriter, r *http.Request, h http.Header) (*websocket.Conn, error) {
+	u.header = h
+	return nil, nil
+}
+
+func (d *headerDialer) DialContext(ctx context.Context, url string, h http.Header) (*websocket.Conn, *http.Response, error) {
+	d.header = h
+	return nil, nil, nil
+}
+
+func TestNegotiateSubprotocol(t *testing.T) {
+	cases := []struct {
+		Name      string
+		Requested string
+		Supported []string
+		Expected  string
+	}{
+		{"none requested", "", []string{"v1"}, ""},
+		{"match", "v1", []string{"v1"}, "v1"},
+		{"server preference", "v1, v2", []string{"v2", "v1"}, "v2"},
+		{"no match", "v3", []string{"v2", "v1"}, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			if c.Requested != "" {
+				r.Header.Set("Sec-Websocket-Protocol", c.Requested)
+			}
+			if actual := NegotiateSubprotocol(r, c.Supported); actual != c.Expected {
+				t.Errorf("got %q, expected %q", actual, c.Expected)
+			}
+		})
+	}
+}
+
+func TestWebSocketConfigUpgrader(t *testing.T) {
+	up := &headerUpgrader{}
+	cfg := &WebSocketConfig{Subprotocols: []string{"v2", "v1"}}
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Sec-Websocket-Protocol", "v1")
+	cfg.Upgrader(up).Upgrade(httptest.NewRecorder(), r, nil)
+	if p := up.header.Get("Sec-Websocket-Protocol"); p != "v1" {
+		t.Errorf("got subprotocol %q, expected %q", p, "v1")
+	}
+	if u := (&WebSocketConfig{}).Upgrader(up); u != up {
+		t.Error("expected upgrader to be returned as is when no subprotocol is configured")
+	}
+	wu := &websocket.Upgrader{}
+	cu, ok := (&WebSocketConfig{EnableCompression: true}).Upgrader(wu).(*websocket.Upgrader)
+	if !ok || !cu.EnableCompression {
+		t.Error("expected compression to be enabled on the upgrader")
+	}
+	if wu.EnableCompression {
+		t.Error("original upgrader was modified")
+	}
+}
+
+func TestWebSocketConfigDialer(t *testing.T) {
+	d := &headerDialer{}
+	cfg := &WebSocketConfig{Subprotocols: []string{"v2", "v1"}}
+	h := http.Header{"Authorization": []string{"token"}}
+	cfg.Dialer(d).DialContext(context.Background(), "ws://localhost", h)
+	if p := d.header.Get("Sec-Websocket-Protocol"); p != "v2, v1" {
+		t.Errorf("got subprotocols %q, expected %q", p, "v2, v1")
+	}
+	if a := d.header.Get("Authorization"); a != "token" {
+		t.Errorf("got authorization %q, expected %q", a, "token")
+	}
+	if _, ok := h["Sec-Websocket-Protocol"]; ok {
+		t.Error("original header was modified")
+	}
+}
+
+func TestWebSocketConfigKeepAlive(t *testing.T) {
+	cases := []struct {
+		Name       string
+		ClientRead bool
+		Canceled   bool
+	}{
+		{"live peer", true, false},
+		{"dead peer", false, true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			cfg := &WebSocketConfig{PingInterval: 10 * time.Millisecond, PongWait: 30 * time.Millisecond, SendOnly: true}
+			var (
+				canceled = make(chan struct{})
+				once     sync.Once
+			)
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				cfg.ConnConfigureFunc(nil)(conn, func() { once.Do(func() { close(canceled) }) })
+			}))
+			defer svr.Close()
+
+			conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(svr.URL, "http"), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+			if c.ClientRead {
+				// Reading the connection answers the pings.
+				go func() {
+					for {
+						if _, _, err := conn.NextReader(); err != nil {
+							return
+						}
+					}
+				}()
+			}
+			select {
+			case <-canceled:
+				if !c.Canceled {
+					t.Error("got connection closed, expected it to be kept alive")
+				}
+			case <-time.After(200 * time.Millisecond):
+				if c.Canceled {
+					t.Error("got connection kept alive, expected it to be closed")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package goa
+
+import (
+	"container/list"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type (
+	// CacheStore stores the results memoized by ResultCache. The generated
+	// caching packages accept any store so that the results may be kept in
+	// memory with LRUCache or in a shared cache.
+	CacheStore interface {
+		// Get returns the entry stored under key, nil if there is none.
+		Get(ctx context.Context, key string) (*CacheEntry, error)
+		// Set stores e under key, the store may discard e once it
+		// expires.
+		Set(ctx context.Context, key string, e *CacheEntry) error
+		// Delete removes the entry stored under key if any.
+		Delete(ctx context.Context, key string) error
+	}
+
+	// CacheEntry is a result memoized by ResultCache.
+	CacheEntry struct {
+		// Value is the endpoint result.
+		Value interface{}
+		// Stored is the time the result was stored.
+		Stored time.Time
+		// Expires is the time after which the result is not served
+		// anymore.
+		Expires time.Time
+	}
+
+	// CacheStatus describes how the result of a request to a cached
+	// endpoint was produced. The transports use it to set the caching
+	// headers of the responses, see WithCacheStatus.
+	CacheStatus struct {
+		// Hit is true if the result was served from the cache.
+		Hit bool
+		// Age is the age of the result served from the cache.
+		Age time.Duration
+		// TTL is the duration during which the result is served from
+		// the cache once stored.
+		TTL time.Duration
+		// Private is true if the result is cached for the caller only.
+		Private bool
+	}
+
+	// ResultCache memoizes the results of the methods defined with the
+	// Cache DSL. The generated endpoints call Do once the request is
+	// authenticated and authorized so that the cached results are only
+	// served to the callers allowed to get them. A ResultCache with no
+	// store does not memoize the results.
+	ResultCache struct {
+		// Store stores the results.
+		Store CacheStore
+		// Tenancy identifies the tenant of the requests if any, the
+		// results are then cached per tenant.
+		Tenancy *Tenancy
+	}
+
+	// LRUCache is an in-memory CacheStore that holds a bounded number of
+	// entries and evicts the least recently used entries first.
+	LRUCache struct {
+		size    int
+		mu      sync.Mutex
+		entries map[string]*list.Element
+		lru     *list.List
+	}
+
+	// lruEntry is an element of the LRUCache list.
+	lruEntry struct {
+		key   string
+		entry *CacheEntry
+	}
+
+	// cacheStatusKeyType is the type of the context key used to store the
+	// cache status of a request.
+	cacheStatusKeyType struct{}
+)
+
+// cacheStatusKey is the context key used to store the cache status of a
+// request.
+var cacheStatusKey = cacheStatusKeyType{}
+
+// NewLRUCache returns an in-memory store that holds at most size entries.
+func NewLRUCache(size int) *LRUCache {
+	return &LRUCache{size: size, entries: make(map[string]*list.Element), lru: list.New()}
+}
+
+// Get returns the entry stored under key, nil if there is none or if it
+// expired.
+func (c *LRUCache) Get(_ context.Context, key string) (*CacheEntry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	e := el.Value.(*lruEntry).entry
+	if !time.Now().Before(e.Expires) {
+		c.lru.Remove(el)
+		delete(c.entries, key)
+		return nil, nil
+	}
+	c.lru.MoveToFront(el)
+	return e, nil
+}
+
+// Set stores e under key and evicts the least recently used entry if the
+// cache is full.
+func (c *LRUCache) Set(_ context.Context, key string, e *CacheEntry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		el.Value.(*lruEntry).entry = e
+		c.lru.MoveToFront(el)
+		return nil
+	}
+	c.entries[key] = c.lru.PushFront(&lruEntry{key: key, entry: e})
+	for c.size > 0 && c.lru.Len() > c.size {
+		el := c.lru.Back()
+		c.lru.Remove(el)
+		delete(c.entries, el.Value.(*lruEntry).key)
+	}
+	return nil
+}
+
+// Delete removes the entry stored under key if any.
+func (c *LRUCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.lru.Remove(el)
+		delete(c.entries, key)
+	}
+	return nil
+}
+
+// CacheKey returns the key of the results of the given method for the given
+// key values. Pointers are dereferenced so that the keys computed from the
+// payload fields and from the arguments of the generated invalidation
+// functions match.
+func CacheKey(service, method string, vals ...interface{}) string {
+	b, err := json.Marshal(vals)
+	if err != nil {
+		b = []byte(fmt.Sprint(vals...))
+	}
+	return service + "." + method + ":" + string(b)
+}
+
+// WithCacheStatus returns a copy of ctx that carries a cache status. The
+// endpoints of the cached methods record how the result was produced in the
+// status so that the transport can set the caching headers of the
+// response.
+func WithCacheStatus(ctx context.Context) (context.Context, *CacheStatus) {
+	st := &CacheStatus{}
+	return context.WithValue(ctx, cacheStatusKey, st), st
+}
+
+// Do returns the result cached under key for the given caller if any,
+// otherwise it calls fn and caches its result for ttl unless fn fails. caller
+// identifies the caller, typically the authenticated principal and the granted
+// scopes, so that the result computed for a caller is not served to the other
+// callers. Deleting key from the store invalidates the results cached under key
+// for all the callers. The store errors do not fail the requests, fn is called
+// instead.
+func (c *ResultCache) Do(ctx context.Context, key string, ttl time.Duration, caller []interface{}, fn func() (interface{}, error)) (interface{}, error) {
+	if c == nil || c.Store == nil {
+		return fn()
+	}
+	if c.Tenancy != nil {
+		if t, ok := c.Tenancy.Tenant(ctx); ok {
+			caller = append([]interface{}{t}, caller...)
+		}
+	}
+	gen, err := c.generation(ctx, key, ttl)
+	if err != nil {
+		return fn()
+	}
+	k := CacheKey(key, gen, caller...)
+	st, _ := ctx.Value(cacheStatusKey).(*CacheStatus)
+	if st != nil {
+		st.Private = caller != nil
+	}
+	if ce, err := c.Store.Get(ctx, k); err == nil && ce != nil && time.Now().Before(ce.Expires) {
+		if st != nil {
+			st.Hit = true
+			st.Age = time.Since(ce.Stored)
+			st.TTL = ce.Expires.Sub(ce.Stored)
+		}
+		return ce.Value, nil
+	}
+	res, err := fn()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	c.Store.Set(ctx, k, &CacheEntry{Value: res, Stored: now, Expires: now.Add(ttl)})
+	if st != nil {
+		st.TTL = ttl
+	}
+	return res, nil
+}
+
+// generation returns the generation stored under key, it stores a new one if
+// there is none. The results of the callers are cached under keys that include
+// the generation so that deleting key invalidates all of them.
+func (c *ResultCache) generation(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	ce, err := c.Store.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	if ce != nil && time.Now().Before(ce.Expires) {
+		if gen, ok := ce.Value.(string); ok {
+			return gen, nil
+		}
+	}
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	gen := hex.EncodeToString(b)
+	now := time.Now()
+	if err := c.Store.Set(ctx, key, &CacheEntry{Value: gen, Stored: now, Expires: now.Add(ttl)}); err != nil {
+		return "", err
+	}
+	return gen, nil
+}
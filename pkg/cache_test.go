@@ -0,0 +1,110 @@
+package goa
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestResultCache(t *testing.T) {
+	var (
+		calls int
+		store = NewLRUCache(10)
+		c     = &ResultCache{Store: store}
+	)
+	do := func(ctx context.Context, key string, caller ...interface{}) interface{} {
+		res, _ := c.Do(ctx, CacheKey("svc", "show", key), time.Minute, caller, func() (interface{}, error) {
+			calls++
+			if key == "fail" {
+				return nil, errors.New("failed")
+			}
+			return calls, nil
+		})
+		return res
+	}
+
+	ctx, st := WithCacheStatus(context.Background())
+	if res := do(ctx, "a"); res != 1 || st.Hit || st.TTL != time.Minute || st.Private {
+		t.Errorf("got result %v and status %+v, expected 1 and miss", res, st)
+	}
+	ctx, st = WithCacheStatus(context.Background())
+	if res := do(ctx, "a"); res != 1 || !st.Hit || st.TTL != time.Minute {
+		t.Errorf("got result %v and status %+v, expected 1 and hit", res, st)
+	}
+	if res := do(context.Background(), "b"); res != 2 {
+		t.Errorf("got result %v for other key, expected 2", res)
+	}
+	do(context.Background(), "fail")
+	do(context.Background(), "fail")
+	if calls != 4 {
+		t.Errorf("got %d calls, expected errors not to be cached", calls)
+	}
+
+	ctx, st = WithCacheStatus(context.Background())
+	if res := do(ctx, "c", "alice", []string{"read"}); res != 5 || !st.Private {
+		t.Errorf("got result %v and status %+v, expected 5 and private", res, st)
+	}
+	if res := do(context.Background(), "c", "bob", []string{"read"}); res != 6 {
+		t.Errorf("got result %v for other caller, expected 6", res)
+	}
+	if res := do(context.Background(), "c", "alice", []string{"read", "admin"}); res != 7 {
+		t.Errorf("got result %v for other scopes, expected 7", res)
+	}
+	if res := do(context.Background(), "c", "alice", []string{"read"}); res != 5 {
+		t.Errorf("got result %v for same caller, expected 5", res)
+	}
+
+	store.Delete(context.Background(), CacheKey("svc", "show", "c"))
+	if res := do(context.Background(), "c", "alice", []string{"read"}); res != 8 {
+		t.Errorf("got result %v after invalidation, expected 8", res)
+	}
+	if res := do(context.Background(), "c", "bob", []string{"read"}); res != 9 {
+		t.Errorf("got result %v for other caller after invalidation, expected 9", res)
+	}
+
+	tc := &ResultCache{Store: NewLRUCache(10), Tenancy: &Tenancy{Header: "X-Tenant"}}
+	calls = 0
+	for _, tenant := range []string{"acme", "initech", "acme"} {
+		ctx := context.WithValue(context.Background(), PropagatedKey, map[string]string{"x-tenant": tenant})
+		tc.Do(ctx, "t", time.Minute, nil, func() (interface{}, error) { calls++; return tenant, nil })
+	}
+	if calls != 2 {
+		t.Errorf("got %d calls for two tenants, expected 2", calls)
+	}
+
+	var nocache *ResultCache
+	if res, _ := nocache.Do(context.Background(), "k", time.Minute, nil, func() (interface{}, error) { return "v", nil }); res != "v" {
+		t.Errorf("got result %v without store, expected v", res)
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRUCache(2)
+	live := &CacheEntry{Value: 1, Stored: time.Now(), Expires: time.Now().Add(time.Minute)}
+	c.Set(ctx, "a", live)
+	c.Set(ctx, "b", live)
+	c.Get(ctx, "a")
+	c.Set(ctx, "c", live)
+	if e, _ := c.Get(ctx, "b"); e != nil {
+		t.Error("got least recently used entry, expected evicted")
+	}
+	if e, _ := c.Get(ctx, "a"); e == nil {
+		t.Error("got no recently used entry, expected kept")
+	}
+	c.Set(ctx, "d", &CacheEntry{Value: 1, Stored: time.Now(), Expires: time.Now().Add(-time.Second)})
+	if e, _ := c.Get(ctx, "d"); e != nil {
+		t.Error("got expired entry, expected nil")
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	id := "x"
+	if k, e := CacheKey("svc", "show", &id, 1), CacheKey("svc", "show", id, 1); k != e {
+		t.Errorf("got key %q from pointer, expected %q", k, e)
+	}
+	if k := CacheKey("svc", "show", "a,b"); k == CacheKey("svc", "show", "a", "b") {
+		t.Errorf("got same key %q for different values", k)
+	}
+}
@@ -0,0 +1,112 @@
+package goa
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Drainer coordinates the graceful shutdown of the servers of a process. It
+// reports the readiness of the process, which flips to not ready as soon as
+// draining starts so that load balancers stop routing requests to it, and it
+// tracks the long lived streams (e.g. websocket connections) that the servers
+// do not wait for when shutting down.
+type Drainer struct {
+	// Grace is the maximum duration given to the in-flight requests and
+	// streams to complete once draining starts.
+	Grace time.Duration
+
+	draining int32
+	once     sync.Once
+	drainc   chan struct{}
+
+	mu      sync.Mutex
+	nextID  int
+	streams map[int]context.CancelFunc
+	idle    chan struct{}
+}
+
+// NewDrainer returns a drainer that gives grace to the in-flight requests and
+// streams to complete.
+func NewDrainer(grace time.Duration) *Drainer {
+	return &Drainer{
+		Grace:   grace,
+		drainc:  make(chan struct{}),
+		streams: make(map[int]context.CancelFunc),
+	}
+}
+
+// Ready returns false once draining has started.
+func (d *Drainer) Ready() bool {
+	return atomic.LoadInt32(&d.draining) == 0
+}
+
+// Draining returns a channel that is closed when draining starts. Stream
+// implementations may select on it to end the streams early.
+func (d *Drainer) Draining() <-chan struct{} {
+	return d.drainc
+}
+
+// Drain starts draining. It is safe to call Drain multiple times.
+func (d *Drainer) Drain() {
+	d.once.Do(func() {
+		atomic.StoreInt32(&d.draining, 1)
+		close(d.drainc)
+	})
+}
+
+// Track registers a stream that must complete before the process exits. It
+// returns a copy of ctx that is canceled if the stream does not complete
+// within the grace period and a function that the caller must call when the
+// stream completes.
+func (d *Drainer) Track(ctx context.Context) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	d.mu.Lock()
+	id := d.nextID
+	d.nextID++
+	d.streams[id] = cancel
+	d.mu.Unlock()
+	var once sync.Once
+	return ctx, func() {
+		once.Do(func() {
+			cancel()
+			d.mu.Lock()
+			delete(d.streams, id)
+			if len(d.streams) == 0 && d.idle != nil {
+				close(d.idle)
+				d.idle = nil
+			}
+			d.mu.Unlock()
+		})
+	}
+}
+
+// Wait starts draining and waits for the tracked streams to complete or for
+// ctx to be done. In the latter case Wait cancels the contexts of the streams
+// that are still active and returns the context error.
+func (d *Drainer) Wait(ctx context.Context) error {
+	d.Drain()
+	d.mu.Lock()
+	if len(d.streams) == 0 {
+		d.mu.Unlock()
+		return nil
+	}
+	if d.idle == nil {
+		d.idle = make(chan struct{})
+	}
+	idle := d.idle
+	d.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		d.mu.Lock()
+		for _, cancel := range d.streams {
+			cancel()
+		}
+		d.mu.Unlock()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,56 @@
+package goa
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDrainer(t *testing.T) {
+	d := NewDrainer(time.Second)
+	if !d.Ready() {
+		t.Fatal("got not ready, expected ready")
+	}
+	_, done := d.Track(context.Background())
+	ctx, _ := d.Track(context.Background())
+	done()
+	done()
+
+	wctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := d.Wait(wctx); err != context.DeadlineExceeded {
+		t.Errorf("got error %v, expected %v", err, context.DeadlineExceeded)
+	}
+	if d.Ready() {
+		t.Error("got ready, expected not ready")
+	}
+	select {
+	case <-d.Draining():
+	default:
+		t.Error("got draining channel open, expected closed")
+	}
+	select {
+	case <-ctx.Done():
+	default:
+		t.Error("got active stream context, expected canceled")
+	}
+	d.Drain()
+}
+
+func TestDrainerWait(t *testing.T) {
+	d := NewDrainer(time.Second)
+	if err := d.Wait(context.Background()); err != nil {
+		t.Fatalf("got error %v with no stream, expected nil", err)
+	}
+	ctx, done := d.Track(context.Background())
+	go func() {
+		<-d.Draining()
+		done()
+	}()
+	if err := d.Wait(context.Background()); err != nil {
+		t.Errorf("got error %v, expected nil", err)
+	}
+	if ctx.Err() != context.Canceled {
+		t.Errorf("got stream context error %v, expected %v", ctx.Err(), context.Canceled)
+	}
+}
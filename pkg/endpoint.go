@@ -12,6 +12,16 @@ const (
 	// service as defined in the design. The generated transport code
 	// initializes the corresponding value prior to invoking the endpoint.
 	ServiceKey
+
+	// PropagatedKey is the request context key used to store the values
+	// propagated from the incoming request to the outgoing requests made by
+	// the generated clients, see Propagation.
+	PropagatedKey
+
+	// LocaleKey is the request context key used to store the locale used
+	// to render the messages of the errors returned by the endpoints, see
+	// WithLocale.
+	LocaleKey
 )
 
 type (
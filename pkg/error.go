@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 )
 
 type (
@@ -24,6 +25,13 @@ type (
 		Temporary bool
 		// Is the error a server-side fault?
 		Fault bool
+
+		// msgs holds the parts of Message that can be localized, see
+		// LocalizeError.
+		msgs []*errorMessage
+		// retryAfter is the duration after which the clients may retry
+		// the request, see OverloadedError.
+		retryAfter time.Duration
 	}
 )
 
@@ -63,7 +71,7 @@ func TemporaryTimeoutError(name, format string, v ...interface{}) *ServiceError
 // MissingPayloadError is the error produced by the generated code when a
 // request is missing a required payload.
 func MissingPayloadError() error {
-	return PermanentError("missing_payload", "missing required payload")
+	return validationError("missing_payload", nil, "missing required payload")
 }
 
 // DecodePayloadError is the error produced by the generated code when a request
@@ -75,13 +83,15 @@ func DecodePayloadError(msg string) error {
 // InvalidFieldTypeError is the error produced by the generated code when the
 // type of a payload field does not match the type defined in the design.
 func InvalidFieldTypeError(name string, val interface{}, expected string) error {
-	return PermanentError("invalid_field_type", "invalid value %#v for %q, must be a %s", val, name, expected)
+	params := map[string]interface{}{"Name": name, "Value": val, "Expected": expected}
+	return validationError("invalid_field_type", params, "invalid value %#v for %q, must be a %s", val, name, expected)
 }
 
 // MissingFieldError is the error produced by the generated code when a payload
 // is missing a required field.
 func MissingFieldError(name, context string) error {
-	return PermanentError("missing_field", "%q is missing from %s", name, context)
+	params := map[string]interface{}{"Name": name, "Context": context}
+	return validationError("missing_field", params, "%q is missing from %s", name, context)
 }
 
 // InvalidEnumValueError is the error produced by the generated code when the
@@ -92,21 +102,24 @@ func InvalidEnumValueError(name string, val interface{}, allowed []interface{})
 	for i, a := range allowed {
 		elems[i] = fmt.Sprintf("%#v", a)
 	}
-	return PermanentError("invalid_enum_value", "value of %s must be one of %s but got value %#v", name, strings.Join(elems, ", "), val)
+	params := map[string]interface{}{"Name": name, "Value": val, "Allowed": allowed}
+	return validationError("invalid_enum_value", params, "value of %s must be one of %s but got value %#v", name, strings.Join(elems, ", "), val)
 }
 
 // InvalidFormatError is the error produced by the generated code when the value
 // of a payload field does not match the format validation defined in the
 // design.
 func InvalidFormatError(name, target string, format Format, formatError error) error {
-	return PermanentError("invalid_format", "%s must be formatted as a %s but got value %q, %s", name, format, target, formatError.Error())
+	params := map[string]interface{}{"Name": name, "Value": target, "Format": format, "Error": formatError.Error()}
+	return validationError("invalid_format", params, "%s must be formatted as a %s but got value %q, %s", name, format, target, formatError.Error())
 }
 
 // InvalidPatternError is the error produced by the generated code when the
 // value of a payload field does not match the pattern validation defined in the
 // design.
 func InvalidPatternError(name, target string, pattern string) error {
-	return PermanentError("invalid_pattern", "%s must match the regexp %q but got value %q", name, pattern, target)
+	params := map[string]interface{}{"Name": name, "Value": target, "Pattern": pattern}
+	return validationError("invalid_pattern", params, "%s must match the regexp %q but got value %q", name, pattern, target)
 }
 
 // InvalidRangeError is the error produced by the generated code when the value
@@ -117,7 +130,28 @@ func InvalidRangeError(name string, target interface{}, value interface{}, min b
 	if !min {
 		comp = "lesser or equal"
 	}
-	return PermanentError("invalid_range", "%s must be %s than %d but got value %#v", name, comp, value, target)
+	params := map[string]interface{}{"Name": name, "Value": target, "Limit": value, "Min": min}
+	return validationError("invalid_range", params, "%s must be %s than %d but got value %#v", name, comp, value, target)
+}
+
+// InvalidExclusiveRangeError is the error produced by the generated code when
+// the value of a payload field does not match the exclusive range validation
+// defined in the design. value may be an int or a float64.
+func InvalidExclusiveRangeError(name string, target interface{}, value interface{}, min bool) error {
+	comp := "greater"
+	if !min {
+		comp = "lesser"
+	}
+	params := map[string]interface{}{"Name": name, "Value": target, "Limit": value, "Min": min}
+	return validationError("invalid_exclusive_range", params, "%s must be %s than %v but got value %#v", name, comp, value, target)
+}
+
+// InvalidMultipleOfError is the error produced by the generated code when the
+// value of a payload field is not a multiple of the value defined in the
+// design MultipleOf validation. value may be an int or a float64.
+func InvalidMultipleOfError(name string, target interface{}, value interface{}) error {
+	params := map[string]interface{}{"Name": name, "Value": target, "MultipleOf": value}
+	return validationError("invalid_multiple_of", params, "%s must be a multiple of %v but got value %#v", name, value, target)
 }
 
 // InvalidLengthError is the error produced by the generated code when the value
@@ -128,7 +162,58 @@ func InvalidLengthError(name string, target interface{}, ln, value int, min bool
 	if !min {
 		comp = "lesser or equal"
 	}
-	return PermanentError("invalid_length", "length of %s must be %s than %d but got value %#v (len=%d)", name, comp, value, target, ln)
+	params := map[string]interface{}{"Name": name, "Value": target, "Length": ln, "Limit": value, "Min": min}
+	return validationError("invalid_length", params, "length of %s must be %s than %d but got value %#v (len=%d)", name, comp, value, target, ln)
+}
+
+// InvalidMaxBytesError is the error produced by the generated code when the
+// UTF-8 encoding of the value of a payload field is longer than the number of
+// bytes defined in the design MaxBytes validation.
+func InvalidMaxBytesError(name string, target interface{}, ln, value int) error {
+	params := map[string]interface{}{"Name": name, "Value": target, "Length": ln, "Limit": value}
+	return validationError("invalid_max_bytes", params, "byte length of %s must be lesser or equal than %d but got value %#v (bytes=%d)", name, value, target, ln)
+}
+
+// InvalidUniqueItemsError is the error produced by the generated code when
+// two elements of an array of objects have the same value for the attribute
+// defined in the design UniqueBy validation. value is the duplicated value.
+func InvalidUniqueItemsError(name, key string, value interface{}) error {
+	params := map[string]interface{}{"Name": name, "Value": value, "Key": key}
+	return validationError("invalid_unique_items", params, "elements of %s must have unique %s values but got duplicate value %#v", name, key, value)
+}
+
+// InvalidContentMediaTypeError is the error produced by the generated code when
+// the media type detected from the content of a payload field does not match
+// the design ContentMediaType validation.
+func InvalidContentMediaTypeError(name, detected, mediaType string) error {
+	params := map[string]interface{}{"Name": name, "Value": detected, "MediaType": mediaType}
+	return validationError("invalid_content_media_type", params, "content of %s must be of type %s but got %s", name, mediaType, detected)
+}
+
+// InvalidValueError is the error produced by the generated code when a
+// validation function defined in the design with ValidateWith returns an
+// error for the value of a payload field. The error is returned as is if it
+// is already a ServiceError so that validation functions may produce their
+// own validation errors.
+func InvalidValueError(name string, target interface{}, err error) error {
+	if se, ok := err.(*ServiceError); ok {
+		return se
+	}
+	params := map[string]interface{}{"Name": name, "Value": target, "Error": err.Error()}
+	return validationError("invalid_value", params, "%s is invalid: %s", name, err.Error())
+}
+
+// UnknownFieldsError is the error produced by the generated code when the
+// request body or query string of an endpoint that uses strict decoding
+// contains fields that are not defined in the design. fields lists the paths
+// to the unknown fields.
+func UnknownFieldsError(name string, fields []string) error {
+	elems := make([]string, len(fields))
+	for i, f := range fields {
+		elems[i] = fmt.Sprintf("%q", f)
+	}
+	params := map[string]interface{}{"Name": name, "Fields": fields}
+	return validationError("unknown_fields", params, "%s contains unknown fields %s", name, strings.Join(elems, ", "))
 }
 
 // NewErrorID creates a unique 8 character ID that is well suited to use as an
@@ -170,6 +255,9 @@ func MergeErrors(err, other error) error {
 	if e.Name == "error" {
 		e.Name = o.Name
 	}
+	if len(e.msgs) > 0 || len(o.msgs) > 0 {
+		e.msgs = append(e.messages(), o.messages()...)
+	}
 	e.Message = e.Message + "; " + o.Message
 	e.Timeout = e.Timeout && o.Timeout
 	e.Temporary = e.Temporary && o.Temporary
@@ -184,6 +272,10 @@ func (s *ServiceError) Error() string { return s.Message }
 // ErrorName returns the error name.
 func (s *ServiceError) ErrorName() string { return s.Name }
 
+// RetryAfter returns the duration after which the clients may retry the
+// request, zero if the error does not specify one.
+func (s *ServiceError) RetryAfter() time.Duration { return s.retryAfter }
+
 func newError(name string, timeout, temporary, fault bool, format string, v ...interface{}) *ServiceError {
 	return &ServiceError{
 		Name:      name,
@@ -195,6 +287,14 @@ func newError(name string, timeout, temporary, fault bool, format string, v ...i
 	}
 }
 
+// validationError creates a permanent error whose message can be localized
+// using the message catalog key name and the given template parameters.
+func validationError(name string, params map[string]interface{}, format string, v ...interface{}) *ServiceError {
+	err := newError(name, false, false, false, format, v...)
+	err.msgs = []*errorMessage{{key: name, params: params, text: err.Message}}
+	return err
+}
+
 func asError(err error) *ServiceError {
 	e, ok := err.(*ServiceError)
 	if !ok {
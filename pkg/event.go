@@ -0,0 +1,131 @@
+package goa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+type (
+	// EventPublisher publishes the events emitted by the service methods,
+	// see PublishEvents.
+	EventPublisher interface {
+		// Publish publishes event on topic.
+		Publish(ctx context.Context, topic string, event interface{}) error
+	}
+
+	// EventPublisherFunc is a function that implements EventPublisher.
+	EventPublisherFunc func(ctx context.Context, topic string, event interface{}) error
+
+	// NATSConn is the subset of the NATS connection (nats.Conn) used by
+	// the publisher returned by NewNATSPublisher.
+	NATSConn interface {
+		// Publish publishes data on subject.
+		Publish(subject string, data []byte) error
+	}
+
+	// KafkaWriter writes messages to Kafka topics, it is implemented by
+	// wrapping the producer of the Kafka client library used by the
+	// service, see NewKafkaPublisher.
+	KafkaWriter interface {
+		// WriteMessage writes a message with the given key and value to
+		// topic.
+		WriteMessage(ctx context.Context, topic string, key, value []byte) error
+	}
+
+	// outbox records the events emitted while a request is served.
+	outbox struct {
+		mu     sync.Mutex
+		events []*emittedEvent
+	}
+
+	// emittedEvent is an event recorded in an outbox.
+	emittedEvent struct {
+		topic string
+		event interface{}
+	}
+
+	// outboxKeyType is the type of the context key used to store the
+	// outbox of a request.
+	outboxKeyType struct{}
+)
+
+// outboxKey is the context key used to store the outbox of a request.
+var outboxKey = outboxKeyType{}
+
+// Publish calls f(ctx, topic, event).
+func (f EventPublisherFunc) Publish(ctx context.Context, topic string, event interface{}) error {
+	return f(ctx, topic, event)
+}
+
+// EmitEvent records event so that it is published on topic once the endpoint
+// completes successfully. It returns an error if the endpoint is not wrapped
+// with PublishEvents. The generated Emit functions call EmitEvent with the
+// topic and type defined in the design.
+func EmitEvent(ctx context.Context, topic string, event interface{}) error {
+	o, ok := ctx.Value(outboxKey).(*outbox)
+	if !ok {
+		return fmt.Errorf("cannot emit event %q: endpoint does not publish events", topic)
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, &emittedEvent{topic: topic, event: event})
+	return nil
+}
+
+// PublishEvents returns an endpoint middleware that publishes the events
+// emitted by the endpoint with p once the endpoint returns without error. The
+// events emitted by endpoints that fail are discarded. The events are published
+// in the order they were emitted, the middleware returns the first publication
+// error if any.
+func PublishEvents(p EventPublisher) func(Endpoint) Endpoint {
+	return func(e Endpoint) Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			o := &outbox{}
+			res, err := e(context.WithValue(ctx, outboxKey, o), req)
+			if err != nil {
+				return nil, err
+			}
+			o.mu.Lock()
+			defer o.mu.Unlock()
+			for _, ev := range o.events {
+				if err := p.Publish(ctx, ev.topic, ev.event); err != nil {
+					return nil, err
+				}
+			}
+			return res, nil
+		}
+	}
+}
+
+// NewNATSPublisher returns a publisher that publishes the events encoded in
+// JSON on the NATS subjects named after the topics.
+func NewNATSPublisher(conn NATSConn) EventPublisher {
+	return EventPublisherFunc(func(_ context.Context, topic string, event interface{}) error {
+		b, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		return conn.Publish(topic, b)
+	})
+}
+
+// NewKafkaPublisher returns a publisher that writes the events encoded in JSON
+// to the Kafka topics with no key. For example with github.com/segmentio/kafka-go:
+//
+//    type writer struct{ w *kafka.Writer }
+//
+//    func (w writer) WriteMessage(ctx context.Context, topic string, key, value []byte) error {
+//        return w.w.WriteMessages(ctx, kafka.Message{Topic: topic, Key: key, Value: value})
+//    }
+//
+func NewKafkaPublisher(w KafkaWriter) EventPublisher {
+	return EventPublisherFunc(func(ctx context.Context, topic string, event interface{}) error {
+		b, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		return w.WriteMessage(ctx, topic, nil, b)
+	})
+}
@@ -0,0 +1,46 @@
+package goa
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type natsConn struct {
+	subjects []string
+	data     []string
+}
+
+func (c *natsConn) Publish(subject string, data []byte) error {
+	c.subjects = append(c.subjects, subject)
+	c.data = append(c.data, string(data))
+	return nil
+}
+
+func TestPublishEvents(t *testing.T) {
+	conn := &natsConn{}
+	e := PublishEvents(NewNATSPublisher(conn))(func(ctx context.Context, req interface{}) (interface{}, error) {
+		if err := EmitEvent(ctx, "order.created", map[string]string{"id": "1"}); err != nil {
+			return nil, err
+		}
+		if req == "fail" {
+			return nil, errors.New("failed")
+		}
+		return "ok", nil
+	})
+	if res, err := e(context.Background(), "ok"); err != nil || res != "ok" {
+		t.Fatalf("got %v, %v, expected ok", res, err)
+	}
+	if len(conn.subjects) != 1 || conn.subjects[0] != "order.created" || conn.data[0] != `{"id":"1"}` {
+		t.Errorf("got subjects %v and data %v, expected one order.created event", conn.subjects, conn.data)
+	}
+	if _, err := e(context.Background(), "fail"); err == nil {
+		t.Error("got no error, expected endpoint error")
+	}
+	if len(conn.subjects) != 1 {
+		t.Errorf("got %d events, expected events of failed requests to be discarded", len(conn.subjects))
+	}
+	if err := EmitEvent(context.Background(), "order.created", nil); err == nil {
+		t.Error("got no error emitting without outbox, expected error")
+	}
+}
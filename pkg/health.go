@@ -0,0 +1,170 @@
+package goa
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// HealthStatusOK is the status of a healthy server or dependency.
+	HealthStatusOK = "ok"
+	// HealthStatusUnavailable is the status of a server with an unhealthy
+	// dependency.
+	HealthStatusUnavailable = "unavailable"
+	// HealthStatusDraining is the status of a server that is shutting down.
+	HealthStatusDraining = "draining"
+)
+
+// DefaultHealthCheckTimeout is the default maximum duration of a dependency
+// check.
+const DefaultHealthCheckTimeout = 5 * time.Second
+
+type (
+	// Health aggregates the dependency checks reported by the readiness
+	// endpoints generated with the HealthCheck DSL.
+	Health struct {
+		// Timeout is the default maximum duration of a dependency
+		// check.
+		Timeout time.Duration
+
+		drainer *Drainer
+		mu      sync.Mutex
+		checks  []*healthCheck
+	}
+
+	// HealthCheckFunc checks a dependency of the server, it returns an
+	// error if the dependency is not healthy.
+	HealthCheckFunc func(context.Context) error
+
+	// HealthCheckOption configures a dependency check.
+	HealthCheckOption func(*healthCheck)
+
+	// HealthReport is the result of a health check.
+	HealthReport struct {
+		// Status is the overall status, one of HealthStatusOK,
+		// HealthStatusUnavailable or HealthStatusDraining.
+		Status string `json:"status"`
+		// Checks lists the results of the dependency checks indexed
+		// by name. The results are HealthStatusOK or the check errors.
+		Checks map[string]string `json:"checks,omitempty"`
+	}
+
+	// healthCheck is a registered dependency check.
+	healthCheck struct {
+		name    string
+		check   HealthCheckFunc
+		timeout time.Duration
+		ttl     time.Duration
+
+		mu      sync.Mutex
+		checked time.Time
+		err     error
+	}
+)
+
+// NewHealth returns a Health that reports the servers as not ready once d
+// starts draining. d may be nil.
+func NewHealth(d *Drainer) *Health {
+	return &Health{Timeout: DefaultHealthCheckTimeout, drainer: d}
+}
+
+// WithCheckTimeout sets the maximum duration of the check, the check fails if
+// it does not complete in time.
+func WithCheckTimeout(timeout time.Duration) HealthCheckOption {
+	return func(c *healthCheck) { c.timeout = timeout }
+}
+
+// WithCheckCache caches the result of the check for ttl so that frequent
+// readiness probes do not overload the dependency.
+func WithCheckCache(ttl time.Duration) HealthCheckOption {
+	return func(c *healthCheck) { c.ttl = ttl }
+}
+
+// Register adds a dependency check to the readiness reports.
+func (h *Health) Register(name string, check HealthCheckFunc, opts ...HealthCheckOption) {
+	c := &healthCheck{name: name, check: check}
+	for _, o := range opts {
+		o(c)
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checks = append(h.checks, c)
+}
+
+// Live reports whether the server is alive. It does not run the dependency
+// checks so that an unhealthy dependency does not cause the server to be
+// restarted.
+func (h *Health) Live(ctx context.Context) *HealthReport {
+	return &HealthReport{Status: HealthStatusOK}
+}
+
+// Ready runs the dependency checks concurrently and reports whether the server
+// is ready to serve requests. The server is not ready if a check fails or if it
+// is draining.
+func (h *Health) Ready(ctx context.Context) *HealthReport {
+	if h.drainer != nil && !h.drainer.Ready() {
+		return &HealthReport{Status: HealthStatusDraining}
+	}
+	h.mu.Lock()
+	checks := make([]*healthCheck, len(h.checks))
+	copy(checks, h.checks)
+	h.mu.Unlock()
+	if len(checks) == 0 {
+		return &HealthReport{Status: HealthStatusOK}
+	}
+
+	errs := make([]error, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c *healthCheck) {
+			defer wg.Done()
+			errs[i] = c.run(ctx, h.Timeout)
+		}(i, c)
+	}
+	wg.Wait()
+
+	res := &HealthReport{Status: HealthStatusOK, Checks: make(map[string]string, len(checks))}
+	for i, c := range checks {
+		if errs[i] != nil {
+			res.Status = HealthStatusUnavailable
+			res.Checks[c.name] = errs[i].Error()
+			continue
+		}
+		res.Checks[c.name] = HealthStatusOK
+	}
+	return res
+}
+
+// OK returns true if the status of the report is HealthStatusOK.
+func (r *HealthReport) OK() bool {
+	return r.Status == HealthStatusOK
+}
+
+// run runs the check with the given default timeout or returns the cached
+// result.
+func (c *healthCheck) run(ctx context.Context, timeout time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ttl > 0 && !c.checked.IsZero() && time.Since(c.checked) < c.ttl {
+		return c.err
+	}
+	if c.timeout > 0 {
+		timeout = c.timeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	errc := make(chan error, 1)
+	go func() { errc <- c.check(ctx) }()
+	select {
+	case c.err = <-errc:
+	case <-ctx.Done():
+		c.err = ctx.Err()
+	}
+	c.checked = time.Now()
+	return c.err
+}
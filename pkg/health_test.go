@@ -0,0 +1,50 @@
+package goa
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHealth(t *testing.T) {
+	d := NewDrainer(time.Second)
+	h := NewHealth(d)
+	if r := h.Ready(context.Background()); !r.OK() || len(r.Checks) > 0 {
+		t.Errorf("got %+v with no check, expected ok", r)
+	}
+
+	var calls int
+	h.Register("db", func(context.Context) error {
+		calls++
+		return nil
+	}, WithCheckCache(time.Minute))
+	h.Register("cache", func(context.Context) error { return errors.New("down") })
+	h.Register("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}, WithCheckTimeout(10*time.Millisecond))
+
+	r := h.Ready(context.Background())
+	if r.Status != HealthStatusUnavailable {
+		t.Errorf("got status %q, expected %q", r.Status, HealthStatusUnavailable)
+	}
+	expected := map[string]string{"db": HealthStatusOK, "cache": "down", "slow": context.DeadlineExceeded.Error()}
+	for n, e := range expected {
+		if got := r.Checks[n]; got != e {
+			t.Errorf("got check %q result %q, expected %q", n, got, e)
+		}
+	}
+	h.Ready(context.Background())
+	if calls != 1 {
+		t.Errorf("got %d calls to the cached check, expected 1", calls)
+	}
+	if r := h.Live(context.Background()); !r.OK() {
+		t.Errorf("got liveness status %q, expected %q", r.Status, HealthStatusOK)
+	}
+
+	d.Drain()
+	if r := h.Ready(context.Background()); r.Status != HealthStatusDraining {
+		t.Errorf("got status %q while draining, expected %q", r.Status, HealthStatusDraining)
+	}
+}
@@ -0,0 +1,213 @@
+package goa
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	// JobPending is the state of the jobs waiting to be run.
+	JobPending = "pending"
+	// JobRunning is the state of the jobs being run.
+	JobRunning = "running"
+	// JobSucceeded is the state of the jobs that completed successfully.
+	JobSucceeded = "succeeded"
+	// JobFailed is the state of the jobs that failed.
+	JobFailed = "failed"
+)
+
+type (
+	// Job describes a job started by an async method, see Jobs.
+	Job struct {
+		// ID is the unique job identifier.
+		ID string
+		// State is the job state, one of JobPending, JobRunning,
+		// JobSucceeded or JobFailed.
+		State string
+		// Result is the result of the job once it succeeded.
+		Result interface{}
+		// Error is the error message of the job once it failed.
+		Error string
+		// Created is the time the job was started.
+		Created time.Time
+		// Updated is the time the job state last changed.
+		Updated time.Time
+	}
+
+	// JobFunc is the function run by a job, it returns the job result.
+	JobFunc func(ctx context.Context) (interface{}, error)
+
+	// JobStore stores the state of the jobs so that the job status methods
+	// can report it. The servers that share a store may report the status
+	// of jobs run by other servers.
+	JobStore interface {
+		// Get returns the job with the given ID, nil if there is none.
+		Get(ctx context.Context, id string) (*Job, error)
+		// Save stores j.
+		Save(ctx context.Context, j *Job) error
+	}
+
+	// JobDispatcher runs the jobs started by the async methods. It may run
+	// them in the server process, see WorkerPool, or hand them to external
+	// workers.
+	JobDispatcher interface {
+		// Dispatch schedules the call to run. It returns an error if
+		// the job cannot be scheduled.
+		Dispatch(ctx context.Context, run func(context.Context)) error
+	}
+
+	// Jobs starts the jobs of the async methods and records their state in
+	// a store.
+	Jobs struct {
+		store      JobStore
+		dispatcher JobDispatcher
+	}
+
+	// MemoryJobStore is an in-memory JobStore.
+	MemoryJobStore struct {
+		mu   sync.Mutex
+		jobs map[string]Job
+	}
+
+	// WorkerPool is a JobDispatcher that runs the jobs with a fixed number
+	// of goroutines. The jobs that cannot be run immediately wait in a
+	// bounded queue.
+	WorkerPool struct {
+		queue chan func()
+		wg    sync.WaitGroup
+		once  sync.Once
+	}
+
+	// detachedContext is a context that carries the values of its parent
+	// but that is never canceled.
+	detachedContext struct {
+		context.Context
+	}
+)
+
+// NewJobs returns a value that starts jobs with d and records their state in
+// store.
+func NewJobs(store JobStore, d JobDispatcher) *Jobs {
+	return &Jobs{store: store, dispatcher: d}
+}
+
+// Start records a pending job and dispatches it. The job runs fn with a
+// context that carries the values of ctx but that is not canceled when the
+// request completes. Start returns the pending job or the error returned by
+// the store or the dispatcher.
+func (j *Jobs) Start(ctx context.Context, fn JobFunc) (*Job, error) {
+	now := time.Now()
+	job := &Job{ID: newJobID(), State: JobPending, Created: now, Updated: now}
+	if err := j.store.Save(ctx, job); err != nil {
+		return nil, err
+	}
+	res := *job
+	err := j.dispatcher.Dispatch(ctx, func(jctx context.Context) {
+		job.State = JobRunning
+		job.Updated = time.Now()
+		j.store.Save(jctx, job)
+		v, err := fn(jctx)
+		if err != nil {
+			job.State = JobFailed
+			job.Error = err.Error()
+		} else {
+			job.State = JobSucceeded
+			job.Result = v
+		}
+		job.Updated = time.Now()
+		j.store.Save(jctx, job)
+	})
+	if err != nil {
+		job.State = JobFailed
+		job.Error = err.Error()
+		job.Updated = time.Now()
+		j.store.Save(ctx, job)
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Status returns the job with the given ID, nil if there is none.
+func (j *Jobs) Status(ctx context.Context, id string) (*Job, error) {
+	return j.store.Get(ctx, id)
+}
+
+// NewMemoryJobStore returns an in-memory job store.
+func NewMemoryJobStore() *MemoryJobStore {
+	return &MemoryJobStore{jobs: make(map[string]Job)}
+}
+
+// Get returns a copy of the job with the given ID, nil if there is none.
+func (s *MemoryJobStore) Get(_ context.Context, id string) (*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j, ok := s.jobs[id]
+	if !ok {
+		return nil, nil
+	}
+	return &j, nil
+}
+
+// Save stores a copy of j.
+func (s *MemoryJobStore) Save(_ context.Context, j *Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[j.ID] = *j
+	return nil
+}
+
+// NewWorkerPool starts workers goroutines that run the dispatched jobs. At
+// most queue jobs wait for a worker, Dispatch returns an overloaded error
+// when the queue is full.
+func NewWorkerPool(workers, queue int) *WorkerPool {
+	p := &WorkerPool{queue: make(chan func(), queue)}
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer p.wg.Done()
+			for run := range p.queue {
+				run()
+			}
+		}()
+	}
+	return p
+}
+
+// Dispatch queues the call to run. The context given to run carries the
+// values of ctx but is not canceled when ctx is.
+func (p *WorkerPool) Dispatch(ctx context.Context, run func(context.Context)) error {
+	jctx := detachedContext{ctx}
+	select {
+	case p.queue <- func() { run(jctx) }:
+		return nil
+	default:
+		return OverloadedError(time.Second)
+	}
+}
+
+// Close stops accepting jobs and waits for the queued jobs to complete. The
+// pool must not be used once closed.
+func (p *WorkerPool) Close() {
+	p.once.Do(func() { close(p.queue) })
+	p.wg.Wait()
+}
+
+// Deadline returns no deadline.
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+// Done returns nil so that the context is never canceled.
+func (detachedContext) Done() <-chan struct{} { return nil }
+
+// Err returns nil.
+func (detachedContext) Err() error { return nil }
+
+// newJobID returns a random job identifier.
+func newJobID() string {
+	b := make([]byte, 12)
+	io.ReadFull(rand.Reader, b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,66 @@
+package goa
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestJobs(t *testing.T) {
+	ctx := context.Background()
+	pool := NewWorkerPool(1, 10)
+	jobs := NewJobs(NewMemoryJobStore(), pool)
+	release := make(chan struct{})
+	ok, err := jobs.Start(ctx, func(context.Context) (interface{}, error) {
+		<-release
+		return 42, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok.State != JobPending || ok.ID == "" {
+		t.Errorf("got job %+v, expected pending job with ID", ok)
+	}
+	ko, err := jobs.Start(ctx, func(context.Context) (interface{}, error) {
+		return nil, errors.New("boom")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	close(release)
+	pool.Close()
+
+	if j, _ := jobs.Status(ctx, ok.ID); j == nil || j.State != JobSucceeded || j.Result != 42 {
+		t.Errorf("got job %+v, expected succeeded job with result 42", j)
+	}
+	if j, _ := jobs.Status(ctx, ko.ID); j == nil || j.State != JobFailed || j.Error != "boom" {
+		t.Errorf("got job %+v, expected failed job with error boom", j)
+	}
+	if j, _ := jobs.Status(ctx, "unknown"); j != nil {
+		t.Errorf("got job %+v for unknown ID, expected nil", j)
+	}
+}
+
+func TestWorkerPoolDispatch(t *testing.T) {
+	pool := NewWorkerPool(0, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	done := make(chan error, 1)
+	if err := pool.Dispatch(ctx, func(jctx context.Context) { done <- jctx.Err() }); err != nil {
+		t.Fatalf("got error %v, expected job to be queued", err)
+	}
+	err := pool.Dispatch(ctx, func(context.Context) {})
+	if gerr, ok := err.(*ServiceError); !ok || gerr.Name != OverloadedErrorName {
+		t.Errorf("got error %v with full queue, expected overloaded error", err)
+	}
+	go (<-pool.queue)()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("got job context error %v, expected detached context", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("job did not run")
+	}
+}
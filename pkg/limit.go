@@ -0,0 +1,103 @@
+package goa
+
+import (
+	"context"
+	"time"
+)
+
+// OverloadedErrorName is the name of the errors returned by the endpoints
+// wrapped with LimitEndpoint when the limiter is saturated.
+const OverloadedErrorName = "overloaded"
+
+// Limiter bounds the number of requests served concurrently by an endpoint.
+// The requests that exceed the limit wait in a bounded queue for at most the
+// queue timeout, the requests that cannot be queued or that time out are
+// rejected with an overloaded error.
+type Limiter struct {
+	sem     chan struct{}
+	queue   chan struct{}
+	timeout time.Duration
+}
+
+// NewLimiter returns a limiter that serves at most max requests concurrently
+// and queues at most queue requests for at most timeout. The queued requests
+// wait until their context is done if timeout is zero.
+func NewLimiter(max, queue int, timeout time.Duration) *Limiter {
+	return &Limiter{
+		sem:     make(chan struct{}, max),
+		queue:   make(chan struct{}, queue),
+		timeout: timeout,
+	}
+}
+
+// OverloadedError creates a temporary error that signals that the server is
+// saturated and that the clients may retry the request after retryAfter. The
+// HTTP servers respond with 503 Service Unavailable and the Retry-After header,
+// the gRPC servers respond with code ResourceExhausted.
+func OverloadedError(retryAfter time.Duration) *ServiceError {
+	e := newError(OverloadedErrorName, false, true, false, "server is overloaded, retry later")
+	e.retryAfter = retryAfter
+	return e
+}
+
+// Acquire waits for a slot to serve a request. It returns a function that the
+// caller must call once the request is served or an overloaded error if the
+// limiter is saturated. It returns the context error if ctx is done first.
+func (l *Limiter) Acquire(ctx context.Context) (func(), error) {
+	select {
+	case l.sem <- struct{}{}:
+		return l.release, nil
+	default:
+	}
+	select {
+	case l.queue <- struct{}{}:
+	default:
+		return nil, l.overloaded()
+	}
+	defer func() { <-l.queue }()
+	var timeout <-chan time.Time
+	if l.timeout > 0 {
+		t := time.NewTimer(l.timeout)
+		defer t.Stop()
+		timeout = t.C
+	}
+	select {
+	case l.sem <- struct{}{}:
+		return l.release, nil
+	case <-timeout:
+		return nil, l.overloaded()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// LimitEndpoint returns an endpoint middleware that bounds the number of
+// requests served concurrently by the endpoint with l.
+func LimitEndpoint(l *Limiter) func(Endpoint) Endpoint {
+	return func(e Endpoint) Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			release, err := l.Acquire(ctx)
+			if err != nil {
+				return nil, err
+			}
+			defer release()
+			return e(ctx, req)
+		}
+	}
+}
+
+// release frees a slot.
+func (l *Limiter) release() {
+	<-l.sem
+}
+
+// overloaded returns the error returned when the limiter is saturated. The
+// clients are told to retry after the queue timeout or after a second if
+// there is none.
+func (l *Limiter) overloaded() error {
+	retryAfter := l.timeout
+	if retryAfter < time.Second {
+		retryAfter = time.Second
+	}
+	return OverloadedError(retryAfter)
+}
@@ -0,0 +1,76 @@
+package goa
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+	l := NewLimiter(1, 1, 10*time.Millisecond)
+	release, err := l.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("got error %v, expected slot", err)
+	}
+
+	queued := make(chan error)
+	go func() {
+		release, err := l.Acquire(ctx)
+		if err == nil {
+			release()
+		}
+		queued <- err
+	}()
+	for len(l.queue) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	_, err = l.Acquire(ctx)
+	gerr, ok := err.(*ServiceError)
+	if !ok || gerr.Name != OverloadedErrorName || !gerr.Temporary {
+		t.Fatalf("got error %v with full queue, expected overloaded", err)
+	}
+	if ra := gerr.RetryAfter(); ra != time.Second {
+		t.Errorf("got retry after %s, expected %s", ra, time.Second)
+	}
+	release()
+	if err := <-queued; err != nil {
+		t.Errorf("got error %v for queued request, expected slot", err)
+	}
+
+	release, _ = l.Acquire(ctx)
+	defer release()
+	if _, err := l.Acquire(ctx); err == nil {
+		t.Error("got slot after queue timeout, expected overloaded error")
+	}
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := l.Acquire(cctx); err != context.Canceled {
+		t.Errorf("got error %v with canceled context, expected %v", err, context.Canceled)
+	}
+}
+
+func TestLimitEndpoint(t *testing.T) {
+	block := make(chan struct{})
+	l := NewLimiter(1, 0, 0)
+	e := LimitEndpoint(l)(func(context.Context, interface{}) (interface{}, error) {
+		<-block
+		return "ok", nil
+	})
+	done := make(chan struct{})
+	go func() {
+		e(context.Background(), nil)
+		close(done)
+	}()
+	for len(l.sem) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if _, err := e(context.Background(), nil); err == nil {
+		t.Error("got no error while saturated, expected overloaded error")
+	}
+	close(block)
+	<-done
+	if res, err := e(context.Background(), nil); err != nil || res != "ok" {
+		t.Errorf("got %v, %v after release, expected ok", res, err)
+	}
+}
@@ -0,0 +1,211 @@
+package goa
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// MessageCatalog maps the names of the errors produced by the generated code
+// (e.g. "missing_field") to the text/template sources used to render the
+// corresponding messages in a given locale. The templates are executed with a
+// map holding the error parameters:
+//
+//   - "missing_payload": no parameter.
+//
+//   - "invalid_field_type": Name, Value and Expected (the expected type).
+//
+//   - "missing_field": Name and Context (the name of the parent value).
+//
+//   - "invalid_enum_value": Name, Value and Allowed (the list of allowed
+//     values).
+//
+//   - "invalid_format": Name, Value, Format and Error (the format error
+//     message).
+//
+//   - "invalid_pattern": Name, Value and Pattern.
+//
+//   - "invalid_range": Name, Value, Limit and Min (true if Limit is the
+//     minimum).
+//
+//   - "invalid_exclusive_range": Name, Value, Limit and Min (true if Limit is
+//     the exclusive minimum).
+//
+//   - "invalid_multiple_of": Name, Value and MultipleOf.
+//
+//   - "invalid_length": Name, Value, Length (the length of Value), Limit and
+//     Min (true if Limit is the minimum).
+//
+//   - "invalid_max_bytes": Name, Value, Length (the length of Value in bytes)
+//     and Limit.
+//
+//   - "invalid_unique_items": Name, Value (the duplicate value) and Key (the
+//     name of the attribute that must be unique).
+//
+//   - "invalid_content_media_type": Name, Value (the detected media type) and
+//     MediaType.
+//
+//   - "invalid_value": Name, Value and Error (the message of the error
+//     returned by the validation function).
+//
+//   - "unknown_fields": Name and Fields (the paths to the unknown fields).
+//
+// The "value" template function formats a value as in Go syntax and the "join"
+// template function formats the elements of a list the same way and joins them
+// with ", ". Errors with no template in the catalog keep their default English
+// message.
+type MessageCatalog map[string]string
+
+type (
+	// catalog is a registered message catalog.
+	catalog map[string]*template.Template
+
+	// errorMessage is a part of the message of a ServiceError. Merged
+	// errors have one part per original error.
+	errorMessage struct {
+		// key is the message catalog key, empty if the message cannot
+		// be localized.
+		key string
+		// params are the template parameters.
+		params map[string]interface{}
+		// text is the default message.
+		text string
+	}
+)
+
+var (
+	catalogsMu sync.RWMutex
+	catalogs   = make(map[string]catalog)
+
+	messageFuncs = template.FuncMap{
+		"value": func(v interface{}) string { return fmt.Sprintf("%#v", v) },
+		"join": func(vals []interface{}) string {
+			elems := make([]string, len(vals))
+			for i, v := range vals {
+				elems[i] = fmt.Sprintf("%#v", v)
+			}
+			return strings.Join(elems, ", ")
+		},
+	}
+)
+
+// RegisterMessageCatalog registers the message catalog used to render the
+// error messages for the given locale (e.g. "fr" or "fr-CA"), replacing any
+// catalog previously registered for the same locale. It returns an error if
+// one of the templates cannot be parsed.
+func RegisterMessageCatalog(locale string, c MessageCatalog) error {
+	cat := make(catalog, len(c))
+	for key, src := range c {
+		t, err := template.New(key).Funcs(messageFuncs).Option("missingkey=error").Parse(src)
+		if err != nil {
+			return fmt.Errorf("message catalog %q: %s", locale, err)
+		}
+		cat[key] = t
+	}
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+	catalogs[normalizeLocale(locale)] = cat
+	return nil
+}
+
+// HasMessageCatalog returns true if a message catalog was registered for the
+// given locale or for its base language.
+func HasMessageCatalog(locale string) bool {
+	_, ok := lookupCatalog(locale)
+	return ok
+}
+
+// WithLocale returns a copy of ctx that holds the locale used to render the
+// error messages, see LocalizeError.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, LocaleKey, locale)
+}
+
+// ContextLocale returns the locale stored in ctx by WithLocale if any, the
+// empty string otherwise.
+func ContextLocale(ctx context.Context) string {
+	l, _ := ctx.Value(LocaleKey).(string)
+	return l
+}
+
+// LocalizeError returns a copy of err whose message and violations (see
+// ServiceError.Violations) are rendered with the message catalog registered
+// for the locale stored in ctx. The catalog of the base language is used if
+// there is no catalog for the locale (e.g. "fr" for "fr-CA"). LocalizeError
+// returns err unchanged if err is not a ServiceError, if ctx holds no locale
+// or if no catalog was registered for it. The generated transport code calls
+// LocalizeError prior to encoding the errors returned by the endpoints.
+func LocalizeError(ctx context.Context, err error) error {
+	s, ok := err.(*ServiceError)
+	if !ok || len(s.msgs) == 0 {
+		return err
+	}
+	locale := ContextLocale(ctx)
+	if locale == "" {
+		return err
+	}
+	cat, ok := lookupCatalog(locale)
+	if !ok {
+		return err
+	}
+	var (
+		msgs  = make([]*errorMessage, len(s.msgs))
+		texts = make([]string, len(s.msgs))
+	)
+	for i, m := range s.msgs {
+		texts[i] = m.localize(cat)
+		msgs[i] = &errorMessage{key: m.key, params: m.params, text: texts[i]}
+	}
+	res := *s
+	res.Message = strings.Join(texts, "; ")
+	res.msgs = msgs
+	return &res
+}
+
+// lookupCatalog returns the catalog registered for locale or for its base
+// language.
+func lookupCatalog(locale string) (catalog, bool) {
+	locale = normalizeLocale(locale)
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+	if c, ok := catalogs[locale]; ok {
+		return c, true
+	}
+	if i := strings.Index(locale, "-"); i > 0 {
+		if c, ok := catalogs[locale[:i]]; ok {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// normalizeLocale returns the lower case form of locale using dashes as
+// separators, e.g. "fr-ca" for "fr_CA".
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.Replace(locale, "_", "-", -1))
+}
+
+// localize renders the message using the template of cat if any. It returns
+// the default message otherwise or if the template fails to execute.
+func (m *errorMessage) localize(cat catalog) string {
+	t, ok := cat[m.key]
+	if !ok || m.key == "" {
+		return m.text
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, m.params); err != nil {
+		return m.text
+	}
+	return buf.String()
+}
+
+// messages returns the parts of the message of s.
+func (s *ServiceError) messages() []*errorMessage {
+	if len(s.msgs) > 0 {
+		return s.msgs
+	}
+	return []*errorMessage{{text: s.Message}}
+}
@@ -0,0 +1,58 @@
+package goa
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestLocalizeError(t *testing.T) {
+	err := RegisterMessageCatalog("fr", MessageCatalog{
+		"missing_field":      "{{ printf \"%q\" .Name }} est absent de {{ .Context }}",
+		"invalid_range":      "{{ .Name }} doit être {{ if .Min }}supérieur{{ else }}inférieur{{ end }} ou égal à {{ .Limit }}",
+		"invalid_enum_value": "{{ .Name }} doit être l'une des valeurs {{ join .Allowed }}",
+		"invalid_pattern":    "{{ .Undefined.Field }}",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterMessageCatalog("de", MessageCatalog{"missing_field": "{{ .Name"}); err == nil {
+		t.Error("expected an error for an invalid template")
+	}
+	merged := MergeErrors(MissingFieldError("name", "body"), InvalidRangeError("body.age", 200, 150, false))
+	merged = MergeErrors(merged, errors.New("other"))
+
+	cases := map[string]struct {
+		Locale   string
+		Err      error
+		Expected string
+	}{
+		"no locale":       {"", MissingFieldError("name", "body"), `"name" is missing from body`},
+		"unknown locale":  {"es", MissingFieldError("name", "body"), `"name" is missing from body`},
+		"locale":          {"fr", MissingFieldError("name", "body"), `"name" est absent de body`},
+		"region":          {"fr_CA", MissingFieldError("name", "body"), `"name" est absent de body`},
+		"list":            {"fr", InvalidEnumValueError("body.c", "x", []interface{}{"a", "b"}), `body.c doit être l'une des valeurs "a", "b"`},
+		"missing message": {"fr", InvalidLengthError("body.n", "x", 1, 2, true), `length of body.n must be greater or equal than 2 but got value "x" (len=1)`},
+		"failed template": {"fr", InvalidPatternError("body.p", "x", "^a$"), `body.p must match the regexp "^a$" but got value "x"`},
+		"merged":          {"fr", merged, `"name" est absent de body; body.age doit être inférieur ou égal à 150; other`},
+		"not localizable": {"fr", PermanentError("custom", "custom error"), "custom error"},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			ctx := context.Background()
+			if c.Locale != "" {
+				ctx = WithLocale(ctx, c.Locale)
+			}
+			actual := LocalizeError(ctx, c.Err)
+			if actual.Error() != c.Expected {
+				t.Errorf("got %q, expected %q", actual.Error(), c.Expected)
+			}
+			if actual.(*ServiceError).Name != c.Err.(*ServiceError).Name {
+				t.Errorf("got name %q, expected %q", actual.(*ServiceError).Name, c.Err.(*ServiceError).Name)
+			}
+		})
+	}
+	if merged.Error() != `"name" is missing from body; body.age must be lesser or equal than 150 but got value 200; other` {
+		t.Errorf("localizing modified the original error: %q", merged.Error())
+	}
+}
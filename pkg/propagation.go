@@ -0,0 +1,59 @@
+package goa
+
+import (
+	"context"
+	"strings"
+
+	"goa.design/goa/v3/middleware"
+)
+
+// Propagation lists the values that the generated servers record from the
+// incoming requests and that the generated clients add to the outgoing requests
+// made with the same context. The values are HTTP headers for the HTTP
+// transport and metadata for the gRPC transport.
+type Propagation struct {
+	// Headers lists the names of the propagated headers.
+	Headers []string
+	// Deadline indicates whether the request deadline is propagated. The
+	// gRPC transport always propagates deadlines.
+	Deadline bool
+}
+
+// Incoming returns a copy of ctx that holds the values of the propagated
+// headers. get returns the value of the incoming header with the given name.
+func (p *Propagation) Incoming(ctx context.Context, get func(name string) string) context.Context {
+	var vals map[string]string
+	for _, h := range p.Headers {
+		if v := get(h); v != "" {
+			if vals == nil {
+				vals = make(map[string]string, len(p.Headers))
+			}
+			vals[strings.ToLower(h)] = v
+		}
+	}
+	if vals == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, PropagatedKey, vals)
+}
+
+// Outgoing returns the values of the propagated headers indexed by lower case
+// header name. The value of the "X-Request-Id" header defaults to the request
+// ID initialized by the RequestID middleware if any.
+func (p *Propagation) Outgoing(ctx context.Context) map[string]string {
+	vals, _ := ctx.Value(PropagatedKey).(map[string]string)
+	res := make(map[string]string, len(p.Headers))
+	for _, h := range p.Headers {
+		n := strings.ToLower(h)
+		if v, ok := vals[n]; ok {
+			res[n] = v
+			continue
+		}
+		if n == "x-request-id" {
+			if id, ok := ctx.Value(middleware.RequestIDKey).(string); ok && id != "" {
+				res[n] = id
+			}
+		}
+	}
+	return res
+}
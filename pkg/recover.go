@@ -0,0 +1,60 @@
+package goa
+
+import (
+	"context"
+	"runtime/debug"
+)
+
+type (
+	// PanicReporter reports the panics recovered by the recovery
+	// middlewares, for example by logging them or by sending them to an
+	// error tracking service.
+	PanicReporter interface {
+		// ReportPanic reports the value p given to panic and the stack
+		// trace of the goroutine that panicked. ctx is the context of
+		// the request being served.
+		ReportPanic(ctx context.Context, p interface{}, stack []byte)
+	}
+
+	// PanicReporterFunc is an adapter that makes it possible to use a
+	// function as a PanicReporter.
+	PanicReporterFunc func(ctx context.Context, p interface{}, stack []byte)
+)
+
+// ReportPanic calls f(ctx, p, stack).
+func (f PanicReporterFunc) ReportPanic(ctx context.Context, p interface{}, stack []byte) {
+	f(ctx, p, stack)
+}
+
+// PanicError reports the recovered panic value p with r and returns the fault
+// error that the recovery middlewares return in place of the panic. The error
+// message does not include p so that internal details are not leaked to the
+// clients. PanicError must be called by the function deferred by the
+// middleware so that the stack trace includes the frames of the goroutine that
+// panicked. r may be nil.
+func PanicError(ctx context.Context, r PanicReporter, p interface{}) *ServiceError {
+	if r != nil {
+		r.ReportPanic(ctx, p, debug.Stack())
+	}
+	return Fault("internal error")
+}
+
+// RecoverEndpoint returns an endpoint middleware that recovers from the panics
+// raised by the endpoint, reports them with r and returns a fault error
+// instead. The transports encode the error as a 500 Internal Server Error
+// HTTP response or as a gRPC status error with code Internal.
+//
+// example of use:
+//  endpoints.Use(goa.RecoverEndpoint(reporter))
+func RecoverEndpoint(r PanicReporter) func(Endpoint) Endpoint {
+	return func(e Endpoint) Endpoint {
+		return func(ctx context.Context, req interface{}) (res interface{}, err error) {
+			defer func() {
+				if p := recover(); p != nil {
+					res, err = nil, PanicError(ctx, r, p)
+				}
+			}()
+			return e(ctx, req)
+		}
+	}
+}
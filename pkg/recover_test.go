@@ -0,0 +1,47 @@
+package goa
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestRecoverEndpoint(t *testing.T) {
+	var (
+		reported interface{}
+		trace    []byte
+	)
+	r := PanicReporterFunc(func(_ context.Context, p interface{}, stack []byte) {
+		reported, trace = p, stack
+	})
+	e := RecoverEndpoint(r)(func(context.Context, interface{}) (interface{}, error) {
+		panicky()
+		return "response", nil
+	})
+
+	res, err := e(context.Background(), nil)
+	if res != nil {
+		t.Errorf("got response %v, expected nil", res)
+	}
+	serr, ok := err.(*ServiceError)
+	if !ok || !serr.Fault {
+		t.Fatalf("got error %#v, expected fault", err)
+	}
+	if reported != "boom" {
+		t.Errorf("got reported panic %v, expected boom", reported)
+	}
+	if !bytes.Contains(trace, []byte("panicky")) {
+		t.Errorf("got stack trace %s, expected it to include the panicking function", trace)
+	}
+
+	e = RecoverEndpoint(nil)(func(context.Context, interface{}) (interface{}, error) {
+		return "response", nil
+	})
+	if res, err := e(context.Background(), nil); res != "response" || err != nil {
+		t.Errorf("got response %v and error %v, expected response and no error", res, err)
+	}
+}
+
+func panicky() {
+	panic("boom")
+}
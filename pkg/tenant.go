@@ -0,0 +1,160 @@
+package goa
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MissingTenantErrorName is the name of the error returned by
+	// RequireTenant when the request does not identify a tenant.
+	MissingTenantErrorName = "missing_tenant"
+
+	// InvalidTenantErrorName is the name of the error returned by
+	// RequireTenant when the tenant of the request does not match the
+	// tenant claim of the bearer token.
+	InvalidTenantErrorName = "invalid_tenant"
+)
+
+type (
+	// Tenancy describes how the generated servers resolve the tenant of
+	// the incoming requests. The tenant is carried by a HTTP header or gRPC
+	// metadata propagated by the generated clients, see Propagation.
+	Tenancy struct {
+		// Header is the name of the header that carries the tenant.
+		Header string
+		// Claim is the name of the bearer token claim that must match the
+		// tenant if any.
+		Claim string
+	}
+
+	// TenantValidator validates the tenant of a request, see
+	// ValidateTenant.
+	TenantValidator func(ctx context.Context, tenant string) error
+
+	// tenantClaim is the value of the tenant claim of the bearer token of
+	// a request.
+	tenantClaim struct {
+		value string
+		ok    bool
+	}
+
+	// tenantClaimKeyType is the type of the context key used to store the
+	// tenant claim of a request.
+	tenantClaimKeyType struct{}
+)
+
+// tenantClaimKey is the context key used to store the tenant claim of a
+// request.
+var tenantClaimKey = tenantClaimKeyType{}
+
+// Tenant returns the tenant carried by ctx, false if there is none.
+func (t *Tenancy) Tenant(ctx context.Context) (string, bool) {
+	vals, _ := ctx.Value(PropagatedKey).(map[string]string)
+	v, ok := vals[strings.ToLower(t.Header)]
+	return v, ok && v != ""
+}
+
+// Incoming returns a copy of ctx that holds the value of the tenant claim of
+// the bearer token of the incoming request so that RequireTenant may check it
+// against the tenant. get returns the value of the incoming header with the
+// given name. Incoming returns ctx if t does not define a claim or if the
+// request does not carry a bearer token. The signature of the token is not
+// verified: the security schemes of the methods must authenticate the token.
+func (t *Tenancy) Incoming(ctx context.Context, get func(name string) string) context.Context {
+	if t.Claim == "" {
+		return ctx
+	}
+	auth := get("Authorization")
+	if len(auth) < 7 || !strings.EqualFold(auth[:7], "bearer ") {
+		return ctx
+	}
+	v, ok := jwtClaim(strings.TrimSpace(auth[7:]), t.Claim)
+	return context.WithValue(ctx, tenantClaimKey, &tenantClaim{value: v, ok: ok})
+}
+
+// RequireTenant returns an endpoint middleware that rejects the requests that
+// do not carry a tenant with a "missing_tenant" error. If t defines a claim
+// and the request carries a bearer token then the middleware also rejects the
+// requests whose tenant does not match the claim with an "invalid_tenant"
+// error.
+func RequireTenant(t *Tenancy) func(Endpoint) Endpoint {
+	return func(e Endpoint) Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tenant, ok := t.Tenant(ctx)
+			if !ok {
+				return nil, PermanentError(MissingTenantErrorName, "missing tenant, the request must set the %q header", t.Header)
+			}
+			if c, ok := ctx.Value(tenantClaimKey).(*tenantClaim); ok {
+				if !c.ok {
+					return nil, PermanentError(InvalidTenantErrorName, "bearer token has no %q claim", t.Claim)
+				}
+				if c.value != tenant {
+					return nil, PermanentError(InvalidTenantErrorName, "tenant %q does not match the %q claim of the bearer token", tenant, t.Claim)
+				}
+			}
+			return e(ctx, req)
+		}
+	}
+}
+
+// ValidateTenant returns an endpoint middleware that calls v with the tenant of
+// the requests and returns the error returned by v if any, e.g. when the tenant
+// does not exist or is suspended. The middleware does not call v if the
+// request does not carry a tenant.
+//
+// Example:
+//
+//    endpoints := calc.NewEndpoints(svc)
+//    endpoints.Use(goa.ValidateTenant(func(ctx context.Context, tenant string) error {
+//        if !tenants.Exists(tenant) {
+//            return goa.PermanentError(goa.InvalidTenantErrorName, "unknown tenant %q", tenant)
+//        }
+//        return nil
+//    }))
+//
+func ValidateTenant(t *Tenancy, v TenantValidator) func(Endpoint) Endpoint {
+	return func(e Endpoint) Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if tenant, ok := t.Tenant(ctx); ok {
+				if err := v(ctx, tenant); err != nil {
+					return nil, err
+				}
+			}
+			return e(ctx, req)
+		}
+	}
+}
+
+// jwtClaim returns the value of the given claim of the JWT token formatted as
+// a string, false if the token cannot be decoded or has no such claim.
+func jwtClaim(token, claim string) (string, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", false
+	}
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return "", false
+	}
+	var claims map[string]interface{}
+	if err := json.Unmarshal(b, &claims); err != nil {
+		return "", false
+	}
+	switch v := claims[claim].(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	case nil:
+		return "", false
+	default:
+		return fmt.Sprint(v), true
+	}
+}
@@ -0,0 +1,80 @@
+package goa
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+func TestRequireTenant(t *testing.T) {
+	token := func(claims string) string {
+		return "Bearer e30." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".sig"
+	}
+	tenancy := &Tenancy{Header: "X-Tenant-ID", Claim: "tid"}
+	endpoint := RequireTenant(tenancy)(func(ctx context.Context, req interface{}) (interface{}, error) {
+		tenant, _ := tenancy.Tenant(ctx)
+		return tenant, nil
+	})
+	cases := []struct {
+		Name    string
+		Headers map[string]string
+		Error   string
+	}{
+		{"tenant", map[string]string{"X-Tenant-ID": "acme"}, ""},
+		{"missing", nil, MissingTenantErrorName},
+		{"claim", map[string]string{"X-Tenant-ID": "acme", "Authorization": token(`{"tid":"acme"}`)}, ""},
+		{"numeric-claim", map[string]string{"X-Tenant-ID": "42", "Authorization": token(`{"tid":42}`)}, ""},
+		{"claim-mismatch", map[string]string{"X-Tenant-ID": "acme", "Authorization": token(`{"tid":"other"}`)}, InvalidTenantErrorName},
+		{"no-claim", map[string]string{"X-Tenant-ID": "acme", "Authorization": token(`{"sub":"joe"}`)}, InvalidTenantErrorName},
+		{"invalid-token", map[string]string{"X-Tenant-ID": "acme", "Authorization": "Bearer invalid"}, InvalidTenantErrorName},
+		{"basic-auth", map[string]string{"X-Tenant-ID": "acme", "Authorization": "Basic dXNlcjpwYXNz"}, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			get := func(name string) string { return c.Headers[name] }
+			p := &Propagation{Headers: []string{"X-Tenant-ID"}}
+			ctx := tenancy.Incoming(p.Incoming(context.Background(), get), get)
+			res, err := endpoint(ctx, nil)
+			if c.Error == "" {
+				if err != nil {
+					t.Fatalf("got error %v, expected none", err)
+				}
+				if res != c.Headers["X-Tenant-ID"] {
+					t.Errorf("got tenant %v, expected %q", res, c.Headers["X-Tenant-ID"])
+				}
+				return
+			}
+			if gerr, ok := err.(*ServiceError); !ok || gerr.Name != c.Error {
+				t.Errorf("got error %v, expected %q", err, c.Error)
+			}
+		})
+	}
+}
+
+func TestValidateTenant(t *testing.T) {
+	tenancy := &Tenancy{Header: "X-Tenant-ID"}
+	errUnknown := errors.New("unknown tenant")
+	var validated []string
+	endpoint := ValidateTenant(tenancy, func(_ context.Context, tenant string) error {
+		validated = append(validated, tenant)
+		if tenant != "acme" {
+			return errUnknown
+		}
+		return nil
+	})(func(context.Context, interface{}) (interface{}, error) { return "ok", nil })
+	ctx := context.WithValue(context.Background(), PropagatedKey, map[string]string{"x-tenant-id": "acme"})
+	if _, err := endpoint(ctx, nil); err != nil {
+		t.Errorf("got error %v, expected none", err)
+	}
+	ctx = context.WithValue(context.Background(), PropagatedKey, map[string]string{"x-tenant-id": "other"})
+	if _, err := endpoint(ctx, nil); err != errUnknown {
+		t.Errorf("got error %v, expected %v", err, errUnknown)
+	}
+	if _, err := endpoint(context.Background(), nil); err != nil {
+		t.Errorf("got error %v without tenant, expected none", err)
+	}
+	if len(validated) != 2 {
+		t.Errorf("got %d validations, expected 2", len(validated))
+	}
+}
@@ -4,10 +4,14 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
+	"mime"
 	"net"
+	"net/http"
 	"net/mail"
 	"net/url"
 	"regexp"
+	"strings"
 	"sync"
 	"time"
 )
@@ -164,6 +168,51 @@ func ValidatePattern(name, val, p string) error {
 	return nil
 }
 
+// ValidateContentMediaType returns an error if the media type detected from
+// the content of val does not match mediaType. The media type is detected with
+// http.DetectContentType and its parameters (e.g. charset) are ignored.
+// mediaType may use a wildcard subtype (e.g. "image/*"). Empty values are
+// valid. name is the name of the variable used in error messages.
+func ValidateContentMediaType(name string, val []byte, mediaType string) error {
+	if len(val) == 0 {
+		return nil
+	}
+	detected := http.DetectContentType(val)
+	if mt, _, err := mime.ParseMediaType(detected); err == nil {
+		detected = mt
+	}
+	if matchMediaType(detected, mediaType) {
+		return nil
+	}
+	return InvalidContentMediaTypeError(name, detected, mediaType)
+}
+
+// matchMediaType returns true if the media type mt matches the media type
+// range r.
+func matchMediaType(mt, r string) bool {
+	if m, _, err := mime.ParseMediaType(r); err == nil {
+		r = m
+	}
+	if r == "*/*" || strings.EqualFold(mt, r) {
+		return true
+	}
+	if strings.HasSuffix(r, "/*") {
+		return strings.HasPrefix(strings.ToLower(mt), strings.ToLower(strings.TrimSuffix(r, "*")))
+	}
+	return false
+}
+
+// multipleOfEpsilon is the fraction of the divisor tolerated by IsMultipleOf.
+const multipleOfEpsilon = 1e-6
+
+// IsMultipleOf returns true if val is a multiple of m. The remainder of the
+// division may differ from zero by a small fraction of m to tolerate the
+// rounding errors of the decimal values so that for example 0.3 is a multiple
+// of 0.1. m must be strictly positive.
+func IsMultipleOf(val, m float64) bool {
+	return math.Abs(math.Remainder(val, m)) <= multipleOfEpsilon*math.Abs(m)
+}
+
 // The following formats are supported:
 // "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
 // "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}",
@@ -123,3 +123,57 @@ func TestValidatePattern(t *testing.T) {
 		}
 	}
 }
+
+func TestIsMultipleOf(t *testing.T) {
+	cases := []struct {
+		Val, M   float64
+		Expected bool
+	}{
+		{10, 5, true},
+		{0, 5, true},
+		{-10, 5, true},
+		{7, 5, false},
+		{0.3, 0.1, true},
+		{0.75, 0.25, true},
+		{0.8, 0.25, false},
+		{1e12, 0.5, true},
+		{5000001.01, 0.01, true},
+		{5000001.003, 0.01, false},
+		{1e10 + 0.4, 1, false},
+		{1e10 + 0.5, 0.5, true},
+	}
+	for _, c := range cases {
+		if actual := IsMultipleOf(c.Val, c.M); actual != c.Expected {
+			t.Errorf("IsMultipleOf(%v, %v): got %v, expected %v", c.Val, c.M, actual, c.Expected)
+		}
+	}
+}
+
+func TestValidateContentMediaType(t *testing.T) {
+	png := []byte("\x89PNG\x0D\x0A\x1A\x0A\x00\x00\x00\x0DIHDR")
+	cases := []struct {
+		Name      string
+		Val       []byte
+		MediaType string
+		Valid     bool
+	}{
+		{"empty", nil, "image/png", true},
+		{"exact", png, "image/png", true},
+		{"wildcard", png, "image/*", true},
+		{"any", png, "*/*", true},
+		{"params", []byte("hello"), "text/plain; charset=utf-8", true},
+		{"mismatch", []byte("hello"), "image/*", false},
+		{"other-subtype", png, "image/jpeg", false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := ValidateContentMediaType("logo", c.Val, c.MediaType)
+			if c.Valid && err != nil {
+				t.Errorf("got error %s, expected none", err)
+			}
+			if !c.Valid && err == nil {
+				t.Error("got no error, expected one")
+			}
+		})
+	}
+}
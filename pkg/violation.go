@@ -0,0 +1,150 @@
+package goa
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Violation describes a validation rule violated by a value validated by the
+// generated code.
+type Violation struct {
+	// Field is the path to the invalid value as reported by the generated
+	// code, e.g. "body.items[3].name". Use JSONPointer or ProtoFieldPath
+	// to compute the path to the value in the request document.
+	Field string
+	// Rule is the name of the violated rule, e.g. "missing_field" or
+	// "invalid_range", see MessageCatalog for the list of rules.
+	Rule string
+	// Constraint is the value of the constraint defined by the rule in the
+	// design, e.g. the minimum for an "invalid_range" violation. It is nil
+	// for rules that do not define a constraint.
+	Constraint interface{}
+	// Message describes the violation.
+	Message string
+}
+
+// locations lists the contexts of missing fields that denote request or
+// response locations rather than values.
+var locations = map[string]bool{
+	"header":       true,
+	"cookie":       true,
+	"query string": true,
+	"path":         true,
+	"metadata":     true,
+	"trailer":      true,
+}
+
+// elemsRegexp matches the array and map elements of field paths.
+var elemsRegexp = regexp.MustCompile(`\[[^\]]*\]`)
+
+// Violations returns the validation rules violated by the values validated by
+// the generated code that produced the error. Merged errors list the
+// violations of all the merged validation errors. Violations returns nil if
+// the error is not a validation error.
+func (s *ServiceError) Violations() []*Violation {
+	var vs []*Violation
+	for _, m := range s.msgs {
+		v := m.violation()
+		if v != nil {
+			vs = append(vs, v)
+		}
+	}
+	return vs
+}
+
+// violation returns the violation described by the message if any.
+func (m *errorMessage) violation() *Violation {
+	if m.key == "" || m.key == "missing_payload" {
+		return nil
+	}
+	name, _ := m.params["Name"].(string)
+	v := &Violation{Field: name, Rule: m.key, Message: m.text}
+	switch m.key {
+	case "missing_field":
+		if ctx, _ := m.params["Context"].(string); ctx != "" && !locations[ctx] {
+			v.Field = ctx + "." + name
+		}
+	case "invalid_field_type":
+		v.Constraint = m.params["Expected"]
+	case "invalid_enum_value":
+		v.Constraint = m.params["Allowed"]
+	case "invalid_format":
+		v.Constraint = m.params["Format"]
+	case "invalid_pattern":
+		v.Constraint = m.params["Pattern"]
+	case "invalid_range", "invalid_exclusive_range", "invalid_length":
+		v.Constraint = m.params["Limit"]
+	case "invalid_multiple_of":
+		v.Constraint = m.params["MultipleOf"]
+	case "invalid_max_bytes":
+		v.Constraint = m.params["Limit"]
+	case "invalid_unique_items":
+		v.Constraint = m.params["Key"]
+	case "invalid_content_media_type":
+		v.Constraint = m.params["MediaType"]
+	}
+	return v
+}
+
+// JSONPointer returns the JSON Pointer (RFC 6901) to the value with the given
+// field path (see Violation) in the HTTP request or response body. The
+// leading "body" or "result" element of the path is omitted so that the
+// pointer to the body itself is the empty string. The generated validation
+// code records the index of the invalid array elements and the key of the
+// invalid map values.
+//
+//	JSONPointer("body.items[3].name") == "/items/3/name"
+func JSONPointer(field string) string {
+	var ptr strings.Builder
+	for _, e := range fieldPath(field, "body", "result") {
+		e = strings.Replace(e, "~", "~0", -1)
+		ptr.WriteString("/" + strings.Replace(e, "/", "~1", -1))
+	}
+	return ptr.String()
+}
+
+// ProtoFieldPath returns the path to the value with the given field path (see
+// Violation) in the gRPC request or response message using dots to separate
+// the field names as in the google.rpc.BadRequest field violations. The
+// leading "message" or "result" element is omitted as well as the array and
+// map elements.
+//
+//	ProtoFieldPath("message.items[3].name") == "items.name"
+func ProtoFieldPath(field string) string {
+	return strings.Join(fieldPath(elemsRegexp.ReplaceAllString(field, ""), "message", "result"), ".")
+}
+
+// fieldPath splits the given field path into its elements omitting the
+// leading element if it is one of roots. The array index or map key enclosed
+// in brackets makes up a single element even if it contains dots.
+func fieldPath(field string, roots ...string) []string {
+	var elems []string
+	for field != "" {
+		switch field[0] {
+		case '.':
+			field = field[1:]
+		case '[':
+			end := strings.IndexByte(field, ']')
+			if end < 0 {
+				elems = append(elems, field[1:])
+				field = ""
+				break
+			}
+			elems = append(elems, field[1:end])
+			field = field[end+1:]
+		default:
+			end := strings.IndexAny(field, ".[")
+			if end < 0 {
+				end = len(field)
+			}
+			elems = append(elems, field[:end])
+			field = field[end:]
+		}
+	}
+	for _, r := range roots {
+		if len(elems) > 0 && elems[0] == r {
+			return elems[1:]
+		}
+	}
+	return elems
+}
@@ -0,0 +1,89 @@
+package goa
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestViolations(t *testing.T) {
+	var err error
+	err = MergeErrors(err, MissingFieldError("name", "body"))
+	err = MergeErrors(err, MissingFieldError("X-Request-ID", "header"))
+	err = MergeErrors(err, InvalidRangeError("body.items[*].count", 0, 1, true))
+	err = MergeErrors(err, InvalidEnumValueError("body.kind", "c", []interface{}{"a", "b"}))
+	err = MergeErrors(err, InvalidPatternError("body.map[key]", "x", "^a$"))
+	err = MergeErrors(err, InvalidExclusiveRangeError("body.ratio", 1.0, 1.0, false))
+	err = MergeErrors(err, InvalidMultipleOfError("body.count", 7, 5))
+	err = MergeErrors(err, InvalidMaxBytesError("body.name", "ééé", 6, 4))
+	err = MergeErrors(err, InvalidUniqueItemsError("body.items", "id", "a"))
+	err = MergeErrors(err, InvalidContentMediaTypeError("body.logo", "text/plain", "image/*"))
+	err = MergeErrors(err, InvalidValueError("body.iban", "x", errors.New("bad checksum")))
+	err = MergeErrors(err, UnknownFieldsError("body", []string{"body.foo", "body.items[0].bar"}))
+	err = MergeErrors(err, errors.New("other"))
+
+	expected := []*Violation{
+		{Field: "body.name", Rule: "missing_field", Message: `"name" is missing from body`},
+		{Field: "X-Request-ID", Rule: "missing_field", Message: `"X-Request-ID" is missing from header`},
+		{Field: "body.items[*].count", Rule: "invalid_range", Constraint: 1, Message: "body.items[*].count must be greater or equal than 1 but got value 0"},
+		{Field: "body.kind", Rule: "invalid_enum_value", Constraint: []interface{}{"a", "b"}, Message: `value of body.kind must be one of "a", "b" but got value "c"`},
+		{Field: "body.map[key]", Rule: "invalid_pattern", Constraint: "^a$", Message: `body.map[key] must match the regexp "^a$" but got value "x"`},
+		{Field: "body.ratio", Rule: "invalid_exclusive_range", Constraint: 1.0, Message: "body.ratio must be lesser than 1 but got value 1"},
+		{Field: "body.count", Rule: "invalid_multiple_of", Constraint: 5, Message: "body.count must be a multiple of 5 but got value 7"},
+		{Field: "body.name", Rule: "invalid_max_bytes", Constraint: 4, Message: `byte length of body.name must be lesser or equal than 4 but got value "ééé" (bytes=6)`},
+		{Field: "body.items", Rule: "invalid_unique_items", Constraint: "id", Message: `elements of body.items must have unique id values but got duplicate value "a"`},
+		{Field: "body.logo", Rule: "invalid_content_media_type", Constraint: "image/*", Message: "content of body.logo must be of type image/* but got text/plain"},
+		{Field: "body.iban", Rule: "invalid_value", Message: "body.iban is invalid: bad checksum"},
+		{Field: "body", Rule: "unknown_fields", Message: `body contains unknown fields "body.foo", "body.items[0].bar"`},
+	}
+	actual := err.(*ServiceError).Violations()
+	if !reflect.DeepEqual(actual, expected) {
+		for _, v := range actual {
+			t.Logf("%+v", v)
+		}
+		t.Errorf("got unexpected violations")
+	}
+	if vs := PermanentError("custom", "custom").Violations(); vs != nil {
+		t.Errorf("got violations %v for a non validation error", vs)
+	}
+	if vs := MissingPayloadError().(*ServiceError).Violations(); vs != nil {
+		t.Errorf("got violations %v for a missing payload error", vs)
+	}
+	custom := InvalidFieldTypeError("body.iban", 1, "string")
+	if err := InvalidValueError("body.iban", 1, custom); err != custom {
+		t.Errorf("got %v, expected service error returned by validation function", err)
+	}
+}
+
+func TestFieldPaths(t *testing.T) {
+	cases := []struct {
+		Field   string
+		Pointer string
+		Proto   string
+	}{
+		{"body", "", "body"},
+		{"body.name", "/name", "body.name"},
+		{"body.items[*].name", "/items/*/name", "body.items.name"},
+		{"body.items[3].name", "/items/3/name", "body.items.name"},
+		{"body.items[0][12]", "/items/0/12", "body.items"},
+		{"body.map[a.b/c]", "/map/a.b~1c", "body.map"},
+		{"body.map[key]", "/map/key", "body.map"},
+		{"message.items[*].name", "/message/items/*/name", "items.name"},
+		{"message.items[3].name", "/message/items/3/name", "items.name"},
+		{"result.a.b", "/a/b", "a.b"},
+		{"q", "/q", "q"},
+		{"message.key", "/message/key", "key"},
+		{"q[*]", "/q/*", "q"},
+		{"a/b~c", "/a~1b~0c", "a/b~c"},
+	}
+	for _, c := range cases {
+		t.Run(c.Field, func(t *testing.T) {
+			if p := JSONPointer(c.Field); p != c.Pointer {
+				t.Errorf("got JSON pointer %q, expected %q", p, c.Pointer)
+			}
+			if p := ProtoFieldPath(c.Field); p != c.Proto {
+				t.Errorf("got proto field path %q, expected %q", p, c.Proto)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package security
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"strings"
+	"sync"
+)
+
+type (
+	// APIKeyRing holds the API keys accepted by a service. Keys may be
+	// added and removed at any time with Set so that keys can be rotated
+	// without restarting the service: during a rotation the ring holds both
+	// the new and the old keys. APIKeyRing is safe for concurrent use.
+	APIKeyRing struct {
+		// Separator separates the ID of a key from its secret part, see
+		// APIKeyID.
+		Separator string
+
+		mu   sync.RWMutex
+		keys [][sha256.Size]byte
+		ids  []string
+	}
+
+	// apiKeyIDKey is the context key used to store the ID of the API key
+	// that authorized the request.
+	apiKeyIDKey struct{}
+)
+
+// NewAPIKeyRing returns a key ring that accepts the given keys. separator
+// separates the ID of a key from its secret part, see APIKeyID.
+func NewAPIKeyRing(separator string, keys ...string) *APIKeyRing {
+	r := &APIKeyRing{Separator: separator}
+	r.Set(keys...)
+	return r
+}
+
+// Set replaces the keys accepted by the ring. Empty keys are ignored.
+func (r *APIKeyRing) Set(keys ...string) {
+	hashes := make([][sha256.Size]byte, 0, len(keys))
+	ids := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if k == "" {
+			continue
+		}
+		hashes = append(hashes, sha256.Sum256([]byte(k)))
+		ids = append(ids, APIKeyID(k, r.Separator))
+	}
+	r.mu.Lock()
+	r.keys, r.ids = hashes, ids
+	r.mu.Unlock()
+}
+
+// Verify returns the ID of the key if the ring accepts it, see APIKeyID. The
+// key is compared with all the keys of the ring in constant time so that the
+// duration of the verification does not leak which key matched or how many
+// bytes did.
+func (r *APIKeyRing) Verify(key string) (string, bool) {
+	if key == "" {
+		return "", false
+	}
+	h := sha256.Sum256([]byte(key))
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	match := -1
+	for i, k := range r.keys {
+		if subtle.ConstantTimeCompare(h[:], k[:]) == 1 {
+			match = i
+		}
+	}
+	if match < 0 {
+		return "", false
+	}
+	return r.ids[match], true
+}
+
+// CompareAPIKeys returns true if the two keys are equal. The comparison takes
+// the same time whatever the keys so that it cannot be used to guess a key one
+// byte at a time.
+func CompareAPIKeys(key, expected string) bool {
+	a, b := sha256.Sum256([]byte(key)), sha256.Sum256([]byte(expected))
+	return subtle.ConstantTimeCompare(a[:], b[:]) == 1 && key != ""
+}
+
+// APIKeyID returns the ID of a key made of an ID and a secret separated by
+// separator, e.g. "2024.0b7a2c" has ID "2024" with separator ".". Key IDs make
+// it possible to tell which key authorized a request and thus to know when an
+// old key is no longer used. APIKeyID returns the empty string if separator is
+// empty or if the key does not contain it.
+func APIKeyID(key, separator string) string {
+	if separator == "" {
+		return ""
+	}
+	if i := strings.Index(key, separator); i > 0 {
+		return key[:i]
+	}
+	return ""
+}
+
+// ParseAPIKeys returns the keys of a comma separated list, typically the value
+// of an environment variable. Spaces around the keys and empty keys are
+// ignored.
+func ParseAPIKeys(list string) []string {
+	var keys []string
+	for _, k := range strings.Split(list, ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// WithAPIKeyID returns a copy of ctx that records the ID of the API key that
+// authorized the request.
+func WithAPIKeyID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, apiKeyIDKey{}, id)
+}
+
+// ContextAPIKeyID returns the ID of the API key that authorized the request,
+// see WithAPIKeyID.
+func ContextAPIKeyID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(apiKeyIDKey{}).(string)
+	return id, ok
+}
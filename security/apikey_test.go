@@ -0,0 +1,92 @@
+package security
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestAPIKeyRing(t *testing.T) {
+	r := NewAPIKeyRing(".", "2023.old-secret", "", "2024.new-secret", "legacy")
+	cases := []struct {
+		Key string
+		ID  string
+		OK  bool
+	}{
+		{"2023.old-secret", "2023", true},
+		{"2024.new-secret", "2024", true},
+		{"legacy", "", true},
+		{"2024.old-secret", "", false},
+		{"2024.new-secre", "", false},
+		{"", "", false},
+	}
+	for _, c := range cases {
+		id, ok := r.Verify(c.Key)
+		if id != c.ID || ok != c.OK {
+			t.Errorf("Verify(%q): got %q, %v, expected %q, %v", c.Key, id, ok, c.ID, c.OK)
+		}
+	}
+
+	r.Set("2024.new-secret")
+	if _, ok := r.Verify("2023.old-secret"); ok {
+		t.Error("got rotated key accepted, expected rejected")
+	}
+	if id, ok := r.Verify("2024.new-secret"); !ok || id != "2024" {
+		t.Errorf("got %q, %v, expected %q, true", id, ok, "2024")
+	}
+}
+
+func TestCompareAPIKeys(t *testing.T) {
+	cases := []struct {
+		Key, Expected string
+		Equal         bool
+	}{
+		{"secret", "secret", true},
+		{"secret", "secreT", false},
+		{"secret", "secret2", false},
+		{"", "", false},
+	}
+	for _, c := range cases {
+		if actual := CompareAPIKeys(c.Key, c.Expected); actual != c.Equal {
+			t.Errorf("CompareAPIKeys(%q, %q): got %v, expected %v", c.Key, c.Expected, actual, c.Equal)
+		}
+	}
+}
+
+func TestAPIKeyID(t *testing.T) {
+	cases := []struct {
+		Key, Separator, ID string
+	}{
+		{"2024.secret", ".", "2024"},
+		{"pk_live_secret", "_", "pk"},
+		{".secret", ".", ""},
+		{"secret", ".", ""},
+		{"2024.secret", "", ""},
+	}
+	for _, c := range cases {
+		if id := APIKeyID(c.Key, c.Separator); id != c.ID {
+			t.Errorf("APIKeyID(%q, %q): got %q, expected %q", c.Key, c.Separator, id, c.ID)
+		}
+	}
+}
+
+func TestParseAPIKeys(t *testing.T) {
+	actual := ParseAPIKeys(" k1.a ,, k2.b,")
+	expected := []string{"k1.a", "k2.b"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("got %v, expected %v", actual, expected)
+	}
+	if keys := ParseAPIKeys(""); keys != nil {
+		t.Errorf("got %v, expected nil", keys)
+	}
+}
+
+func TestContextAPIKeyID(t *testing.T) {
+	if _, ok := ContextAPIKeyID(context.Background()); ok {
+		t.Error("got key ID in empty context")
+	}
+	ctx := WithAPIKeyID(context.Background(), "2024")
+	if id, ok := ContextAPIKeyID(ctx); !ok || id != "2024" {
+		t.Errorf("got %q, %v, expected %q, true", id, ok, "2024")
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Package audit records structured audit events that describe who called which
+method on which resources and with what outcome. The generated audit packages
+wrap the endpoints of the methods audited in the design with Endpoint and the
+generated endpoints record the security scheme that authorized the request with
+Authenticated.
+
+Events are recorded in a Sink which may write them to a log, a database or a
+dedicated audit service. NewJSONSink returns a sink that writes one JSON
+document per event.
+*/
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+	"goa.design/goa/v3/security"
+	"goa.design/goa/v3/security/jwt"
+)
+
+// Outcomes of the audited requests.
+const (
+	// Success means the method returned successfully.
+	Success Outcome = "success"
+	// Denied means the request was not authorized, either because it
+	// satisfied none of the security requirements of the method or because
+	// the method returned an "unauthorized" or "forbidden" error.
+	Denied Outcome = "denied"
+	// Failure means the method returned any other error.
+	Failure Outcome = "failure"
+)
+
+type (
+	// Outcome describes the outcome of an audited request.
+	Outcome string
+
+	// Event describes an audited request.
+	Event struct {
+		// Time is the time the request was received.
+		Time time.Time `json:"time"`
+		// Service is the name of the service.
+		Service string `json:"service"`
+		// Method is the name of the method.
+		Method string `json:"method"`
+		// Principal identifies the caller, see Principal.
+		Principal string `json:"principal,omitempty"`
+		// Scheme is the name of the security scheme that authorized the
+		// request if any.
+		Scheme string `json:"scheme,omitempty"`
+		// Scopes lists the scopes granted to the caller if any.
+		Scopes []string `json:"scopes,omitempty"`
+		// Resources maps the names of the payload attributes that
+		// identify the resources accessed by the request to their
+		// values.
+		Resources map[string]string `json:"resources,omitempty"`
+		// Outcome is the outcome of the request.
+		Outcome Outcome `json:"outcome"`
+		// Error is the message of the error returned by the endpoint if
+		// any.
+		Error string `json:"error,omitempty"`
+	}
+
+	// Sink records audit events. Record must be safe for concurrent use,
+	// it is called once per audited request after the endpoint returns.
+	Sink interface {
+		Record(ctx context.Context, e *Event)
+	}
+
+	// SinkFunc is a function that implements Sink.
+	SinkFunc func(ctx context.Context, e *Event)
+
+	// ResourcesFunc returns the resources accessed by a request given its
+	// payload, see Event.Resources.
+	ResourcesFunc func(payload interface{}) map[string]string
+
+	// jsonSink is the sink returned by NewJSONSink.
+	jsonSink struct {
+		mu  sync.Mutex
+		enc *json.Encoder
+	}
+
+	// ctxKey is the type of the context keys used by the package.
+	ctxKey int
+)
+
+const (
+	// eventKey is the context key used to store the event of the request.
+	eventKey ctxKey = iota + 1
+	// principalKey is the context key used to store the principal.
+	principalKey
+)
+
+// Record calls f(ctx, e).
+func (f SinkFunc) Record(ctx context.Context, e *Event) {
+	f(ctx, e)
+}
+
+// NewJSONSink returns a sink that writes the events to w as JSON documents
+// separated by newlines. Write errors are ignored.
+func NewJSONSink(w io.Writer) Sink {
+	return &jsonSink{enc: json.NewEncoder(w)}
+}
+
+// Record writes the event.
+func (s *jsonSink) Record(_ context.Context, e *Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.enc.Encode(e) // nolint: errcheck
+}
+
+// Endpoint returns an endpoint that records an event in sink for each request
+// handled by next. resources returns the resources accessed by the request, it
+// may be nil.
+func Endpoint(sink Sink, service, method string, resources ResourcesFunc, next goa.Endpoint) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		e := &Event{Time: time.Now(), Service: service, Method: method}
+		if resources != nil {
+			e.Resources = resources(req)
+		}
+		res, err := next(context.WithValue(ctx, eventKey, e), req)
+		e.Outcome = outcome(err)
+		if err != nil {
+			e.Error = err.Error()
+		}
+		sink.Record(ctx, e)
+		return res, err
+	}
+}
+
+// Authenticated records the security scheme that authorized the request, the
+// principal and the scopes found in ctx in the event of the request. The
+// generated endpoints call it once the request is authorized. It does nothing
+// if the request is not audited.
+func Authenticated(ctx context.Context, scheme string) {
+	e, ok := ctx.Value(eventKey).(*Event)
+	if !ok {
+		return
+	}
+	e.Scheme = scheme
+	e.Principal = Principal(ctx)
+	e.Scopes, _ = security.ContextScopes(ctx)
+}
+
+// WithPrincipal returns a copy of ctx that records the principal of the
+// request. Authorization functions use it to identify the caller when the
+// default principal is not suitable, see Principal.
+func WithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey, principal)
+}
+
+// Principal returns the principal of the request: the value stored with
+// WithPrincipal if any, otherwise the subject of the JWT claims, the ID of the
+// API key, the ID of the key that signed the request or the name of the user
+// authenticated with basic auth found in ctx. It returns the empty string if
+// ctx contains none of these.
+func Principal(ctx context.Context) string {
+	if p, ok := ctx.Value(principalKey).(string); ok {
+		return p
+	}
+	if c, ok := jwt.ContextClaims(ctx); ok && c.Subject != "" {
+		return c.Subject
+	}
+	if id, ok := security.ContextAPIKeyID(ctx); ok && id != "" {
+		return id
+	}
+	if id, ok := security.ContextSignatureKeyID(ctx); ok && id != "" {
+		return id
+	}
+	if u, ok := security.ContextBasicUser(ctx); ok && u != "" {
+		return u
+	}
+	return ""
+}
+
+// outcome returns the outcome of a request given the error returned by the
+// endpoint.
+func outcome(err error) Outcome {
+	switch e := err.(type) {
+	case nil:
+		return Success
+	case *security.AuthError:
+		return Denied
+	case *goa.ServiceError:
+		if e.Name == "unauthorized" || e.Name == "forbidden" {
+			return Denied
+		}
+	}
+	return Failure
+}
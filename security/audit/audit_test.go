@@ -0,0 +1,132 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+	"goa.design/goa/v3/security"
+	"goa.design/goa/v3/security/jwt"
+)
+
+func TestEndpoint(t *testing.T) {
+	resources := func(payload interface{}) map[string]string {
+		return map[string]string{"id": payload.(string)}
+	}
+	cases := []struct {
+		Name      string
+		Err       error
+		Auth      bool
+		Principal string
+		Outcome   Outcome
+	}{
+		{"success", nil, true, "alice", Success},
+		{"anonymous", nil, false, "", Success},
+		{"auth-error", &security.AuthError{}, false, "", Denied},
+		{"forbidden", goa.PermanentError("forbidden", "no access"), true, "alice", Denied},
+		{"failure", errors.New("boom"), true, "alice", Failure},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var events []*Event
+			sink := SinkFunc(func(_ context.Context, e *Event) { events = append(events, e) })
+			next := func(ctx context.Context, req interface{}) (interface{}, error) {
+				if c.Auth {
+					ctx = security.WithScopes(jwt.WithClaims(ctx, &jwt.Claims{Subject: "alice"}), "api:read")
+					Authenticated(ctx, "jwt")
+				}
+				return "res", c.Err
+			}
+			res, err := Endpoint(sink, "svc", "get", resources, next)(context.Background(), "42")
+			if res != "res" || err != c.Err {
+				t.Errorf("got %v, %v, expected the endpoint result and error", res, err)
+			}
+			if len(events) != 1 {
+				t.Fatalf("got %d events, expected 1", len(events))
+			}
+			e := events[0]
+			if e.Service != "svc" || e.Method != "get" || e.Time.IsZero() {
+				t.Errorf("got event %+v", e)
+			}
+			if e.Outcome != c.Outcome {
+				t.Errorf("got outcome %q, expected %q", e.Outcome, c.Outcome)
+			}
+			if e.Principal != c.Principal {
+				t.Errorf("got principal %q, expected %q", e.Principal, c.Principal)
+			}
+			if c.Auth && (e.Scheme != "jwt" || !reflect.DeepEqual(e.Scopes, []string{"api:read"})) {
+				t.Errorf("got scheme %q and scopes %v", e.Scheme, e.Scopes)
+			}
+			if (e.Error != "") != (c.Err != nil) {
+				t.Errorf("got error %q, expected %v", e.Error, c.Err)
+			}
+			if !reflect.DeepEqual(e.Resources, map[string]string{"id": "42"}) {
+				t.Errorf("got resources %v", e.Resources)
+			}
+		})
+	}
+}
+
+func TestAuthenticatedNotAudited(t *testing.T) {
+	// Must not panic.
+	Authenticated(context.Background(), "jwt")
+}
+
+func TestPrincipal(t *testing.T) {
+	ctx := context.Background()
+	if p := Principal(ctx); p != "" {
+		t.Errorf("got %q, expected empty principal", p)
+	}
+	ctx = security.WithBasicUser(ctx, "carol")
+	if p := Principal(ctx); p != "carol" {
+		t.Errorf("got %q, expected %q", p, "carol")
+	}
+	ctx = security.WithSignatureKeyID(ctx, "partner")
+	if p := Principal(ctx); p != "partner" {
+		t.Errorf("got %q, expected %q", p, "partner")
+	}
+	ctx = security.WithAPIKeyID(ctx, "2024")
+	if p := Principal(ctx); p != "2024" {
+		t.Errorf("got %q, expected %q", p, "2024")
+	}
+	ctx = jwt.WithClaims(ctx, &jwt.Claims{Subject: "alice"})
+	if p := Principal(ctx); p != "alice" {
+		t.Errorf("got %q, expected %q", p, "alice")
+	}
+	ctx = WithPrincipal(ctx, "bob")
+	if p := Principal(ctx); p != "bob" {
+		t.Errorf("got %q, expected %q", p, "bob")
+	}
+}
+
+func TestJSONSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewJSONSink(&buf)
+	sink.Record(context.Background(), &Event{Service: "svc", Method: "get", Resources: map[string]string{"id": "42"}, Outcome: Success})
+	sink.Record(context.Background(), &Event{Service: "svc", Method: "del", Outcome: Denied, Error: "unauthorized"})
+	dec := json.NewDecoder(&buf)
+	var events []map[string]interface{}
+	for dec.More() {
+		var e map[string]interface{}
+		if err := dec.Decode(&e); err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, e)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events, expected 2", len(events))
+	}
+	if events[0]["outcome"] != "success" || events[0]["resources"].(map[string]interface{})["id"] != "42" {
+		t.Errorf("got %v", events[0])
+	}
+	if _, ok := events[0]["error"]; ok {
+		t.Errorf("got error in successful event %v", events[0])
+	}
+	if events[1]["outcome"] != "denied" || events[1]["error"] != "unauthorized" {
+		t.Errorf("got %v", events[1])
+	}
+}
@@ -0,0 +1,97 @@
+package security
+
+import (
+	"strings"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// AuthError is the error returned by the generated endpoints when a
+	// request satisfies none of the security requirements of the method. It
+	// lists the failures of the requirements in the order they were tried.
+	AuthError struct {
+		// Failures lists the authorization failures.
+		Failures []*AuthFailure
+		// Authorized is the name of the last security scheme whose
+		// authorization function succeeded. The request satisfies the
+		// requirement that uses the scheme if the endpoint does not
+		// return an error.
+		Authorized string
+		// Realm is the protection space of the service defined in the
+		// design with Realm if any. HTTP transports use it as the realm
+		// of the WWW-Authenticate challenges, the challenges use the
+		// names of the security schemes as realm otherwise.
+		Realm string
+	}
+
+	// AuthFailure describes the failure of the authorization function of a
+	// security scheme.
+	AuthFailure struct {
+		// Scheme is the name of the security scheme.
+		Scheme string
+		// Kind is the kind of security scheme, one of "Basic", "APIKey",
+		// "JWT", "OAuth2", "MTLS" or "Signature".
+		Kind string
+		// RequiredScopes lists the scopes required by the requirement.
+		RequiredScopes []string
+		// Err is the error returned by the authorization function.
+		Err error
+	}
+)
+
+// Record adds a failure for the given scheme if err is not nil and records the
+// scheme in Authorized otherwise. It returns err so that the generated code can
+// record and test the error in one statement.
+func (e *AuthError) Record(scheme, kind string, requiredScopes []string, err error) error {
+	if err != nil {
+		e.Failures = append(e.Failures, &AuthFailure{Scheme: scheme, Kind: kind, RequiredScopes: requiredScopes, Err: err})
+		return err
+	}
+	e.Authorized = scheme
+	return nil
+}
+
+// Err returns the error returned by the generated endpoints once all the
+// requirements failed. Server faults returned by the authorization functions
+// are returned as is so that they are not reported as authorization failures.
+func (e *AuthError) Err() error {
+	for _, f := range e.Failures {
+		if gerr, ok := f.Err.(*goa.ServiceError); ok && gerr.Fault {
+			return gerr
+		}
+	}
+	return e
+}
+
+// Last returns the error returned by the last authorization function that
+// failed, nil if there is none. Transports use it to encode the error as
+// described in the design if it is a design error.
+func (e *AuthError) Last() error {
+	if len(e.Failures) == 0 {
+		return nil
+	}
+	return e.Failures[len(e.Failures)-1].Err
+}
+
+// Unwrap returns the error returned by the last authorization function that
+// failed so that errors.Is and errors.As match the errors returned by the
+// authorization functions.
+func (e *AuthError) Unwrap() error {
+	return e.Last()
+}
+
+// Error returns the messages of all the failures.
+func (e *AuthError) Error() string {
+	msgs := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		msgs[i] = f.Scheme + ": " + f.Err.Error()
+	}
+	return "unauthorized: " + strings.Join(msgs, "; ")
+}
+
+// ServiceError returns the goa service error that describes e, transports use
+// it to encode authorization failures that are not described in the design.
+func (e *AuthError) ServiceError() *goa.ServiceError {
+	return goa.PermanentError("unauthorized", "%s", e.Error())
+}
@@ -0,0 +1,79 @@
+package security
+
+import (
+	"errors"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestAuthErrorRecord(t *testing.T) {
+	var aerr AuthError
+	if err := aerr.Record("jwt", "JWT", nil, nil); err != nil {
+		t.Errorf("got error %v, expected nil", err)
+	}
+	if len(aerr.Failures) != 0 {
+		t.Errorf("got %d failures, expected none", len(aerr.Failures))
+	}
+	if aerr.Last() != nil {
+		t.Errorf("got last error %v, expected nil", aerr.Last())
+	}
+	if aerr.Authorized != "jwt" {
+		t.Errorf("got authorized scheme %q, expected %q", aerr.Authorized, "jwt")
+	}
+	invalid := errors.New("invalid token")
+	if err := aerr.Record("jwt", "JWT", []string{"api:read"}, invalid); err != invalid {
+		t.Errorf("got error %v, expected %v", err, invalid)
+	}
+	unknown := errors.New("unknown key")
+	aerr.Record("api_key", "APIKey", nil, unknown)
+	if len(aerr.Failures) != 2 {
+		t.Fatalf("got %d failures, expected 2", len(aerr.Failures))
+	}
+	if aerr.Last() != unknown {
+		t.Errorf("got last error %v, expected %v", aerr.Last(), unknown)
+	}
+	if aerr.Err() != &aerr {
+		t.Errorf("got error %v, expected the auth error", aerr.Err())
+	}
+	expected := "unauthorized: jwt: invalid token; api_key: unknown key"
+	if aerr.Error() != expected {
+		t.Errorf("got message %q, expected %q", aerr.Error(), expected)
+	}
+	serr := aerr.ServiceError()
+	if serr.Name != "unauthorized" || serr.Fault || serr.Message != expected {
+		t.Errorf("got service error %#v", serr)
+	}
+}
+
+func TestAuthErrorFault(t *testing.T) {
+	var aerr AuthError
+	fault := goa.Fault("key store unavailable")
+	aerr.Record("jwt", "JWT", nil, errors.New("invalid token"))
+	aerr.Record("api_key", "APIKey", nil, fault)
+	if aerr.Err() != fault {
+		t.Errorf("got error %v, expected the fault %v", aerr.Err(), fault)
+	}
+}
+
+type expiredError struct{ scheme string }
+
+func (e *expiredError) Error() string { return e.scheme + " credentials expired" }
+
+func TestAuthErrorUnwrap(t *testing.T) {
+	var aerr AuthError
+	expired := &expiredError{scheme: "jwt"}
+	aerr.Record("jwt", "JWT", nil, expired)
+	err := aerr.Err()
+	var target *expiredError
+	if !errors.As(err, &target) || target != expired {
+		t.Errorf("got errors.As target %v, expected %v", target, expired)
+	}
+	if !errors.Is(err, expired) {
+		t.Errorf("got errors.Is false, expected %v to wrap %v", err, expired)
+	}
+	var empty AuthError
+	if empty.Unwrap() != nil {
+		t.Errorf("got unwrapped error %v, expected nil", empty.Unwrap())
+	}
+}
@@ -0,0 +1,42 @@
+package security
+
+import "context"
+
+type (
+	// TransportMetadata describes the transport request of a method call to
+	// the authorization policies. The generated transport handlers record it
+	// in the request context of the methods that require authorization so
+	// that the generated endpoints can include it in the authorization
+	// inputs. It never contains the request credentials.
+	TransportMetadata struct {
+		// Protocol is the transport protocol, "http" or "grpc".
+		Protocol string `json:"protocol"`
+		// Method is the HTTP method or the full gRPC method name.
+		Method string `json:"method,omitempty"`
+		// Path is the path of the HTTP request URL, empty for gRPC.
+		Path string `json:"path,omitempty"`
+		// RemoteAddr is the network address of the client.
+		RemoteAddr string `json:"remote_addr,omitempty"`
+		// Headers contains the HTTP request headers or the gRPC request
+		// metadata indexed by lower case names. Headers that carry
+		// credentials are removed.
+		Headers map[string][]string `json:"headers,omitempty"`
+	}
+
+	// transportMetadataKey is the context key used to store the transport
+	// metadata of the request.
+	transportMetadataKey struct{}
+)
+
+// WithTransportMetadata returns a copy of ctx that records the transport
+// metadata of the request.
+func WithTransportMetadata(ctx context.Context, md *TransportMetadata) context.Context {
+	return context.WithValue(ctx, transportMetadataKey{}, md)
+}
+
+// ContextTransportMetadata returns the transport metadata of the request, nil
+// if ctx does not contain any, see WithTransportMetadata.
+func ContextTransportMetadata(ctx context.Context) *TransportMetadata {
+	md, _ := ctx.Value(transportMetadataKey{}).(*TransportMetadata)
+	return md
+}
@@ -0,0 +1,17 @@
+package security
+
+import (
+	"context"
+	"testing"
+)
+
+func TestContextTransportMetadata(t *testing.T) {
+	if md := ContextTransportMetadata(context.Background()); md != nil {
+		t.Errorf("got %v in empty context, expected nil", md)
+	}
+	md := &TransportMetadata{Protocol: "http", Method: "GET", Path: "/orders"}
+	ctx := WithTransportMetadata(context.Background(), md)
+	if actual := ContextTransportMetadata(ctx); actual != md {
+		t.Errorf("got %v, expected %v", actual, md)
+	}
+}
@@ -0,0 +1,234 @@
+package security
+
+import (
+	"bufio"
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	// ErrUnknownUser is the error returned by the user stores when the user
+	// does not exist.
+	ErrUnknownUser = errors.New("unknown user")
+
+	// ErrInvalidCredentials is the error returned by BasicAuthenticator when
+	// the user does not exist or the password does not match. The two
+	// cases are not distinguished so that clients cannot discover the user
+	// names.
+	ErrInvalidCredentials = errors.New("invalid username or password")
+)
+
+const (
+	// dummyBcryptHash is the bcrypt hash of a random password computed
+	// with the default cost.
+	dummyBcryptHash = "$2a$10$auVzZYaclxbE/r3JRwtlJ.je72K4BH3fNtLn5KfK9GVxg2oZfje8q"
+
+	// dummyArgon2idHash is the argon2id hash of a random password computed
+	// with the parameters recommended by golang.org/x/crypto/argon2.
+	dummyArgon2idHash = "$argon2id$v=19$m=65536,t=1,p=4$tcDsUCJKgE4uWX2k6zQdmg$hqrXF3oYfJRZnHGfNgfQRAcTM4DMXyO4zV4D5/elsC8"
+)
+
+type (
+	// UserStore retrieves the password hashes of the users of a basic auth
+	// security scheme.
+	UserStore interface {
+		// PasswordHash returns the password hash of the user,
+		// ErrUnknownUser if the user does not exist.
+		PasswordHash(ctx context.Context, user string) (string, error)
+	}
+
+	// StaticUserStore is a UserStore that maps the user names to their
+	// password hashes, see ParseHtpasswd.
+	StaticUserStore map[string]string
+
+	// PasswordVerifier returns nil if password matches hash and an error
+	// otherwise. bcrypt.CompareHashAndPassword of golang.org/x/crypto/bcrypt
+	// is a PasswordVerifier.
+	PasswordVerifier func(hash, password []byte) error
+
+	// Argon2IDKeyFunc derives a key from a password with Argon2id, it has
+	// the signature of argon2.IDKey of golang.org/x/crypto/argon2.
+	Argon2IDKeyFunc func(password, salt []byte, time, memory uint32, threads uint8, keyLen uint32) []byte
+
+	// BasicAuthenticator verifies basic auth credentials with the password
+	// hashes of a user store. The hashes are verified by the verifier
+	// registered for their prefix so that the hashing algorithm can be
+	// changed progressively, see Register.
+	BasicAuthenticator struct {
+		// Users is the user store.
+		Users UserStore
+		// DummyHash is the password hash verified when the user does not
+		// exist so that the response time does not reveal whether it
+		// does. It should use the algorithm and the cost of the hashes
+		// of the store. UseBcrypt and UseArgon2id set it to a hash
+		// computed with the default parameters if it is empty.
+		DummyHash string
+
+		prefixes  []string
+		verifiers []PasswordVerifier
+	}
+
+	// basicUserKey is the context key used to store the name of the user
+	// authenticated with basic auth.
+	basicUserKey struct{}
+)
+
+// NewBasicAuthenticator returns an authenticator that verifies the credentials
+// with the password hashes of the given store. Use UseBcrypt, UseArgon2id or
+// Register to register the verifiers of the hash formats used by the store.
+func NewBasicAuthenticator(users UserStore) *BasicAuthenticator {
+	return &BasicAuthenticator{Users: users}
+}
+
+// Register registers the verifier of the hashes that start with one of the
+// given prefixes, e.g. "$2b$" for bcrypt. Set DummyHash to a hash of that
+// format if the store uses it.
+func (a *BasicAuthenticator) Register(v PasswordVerifier, prefixes ...string) {
+	for _, p := range prefixes {
+		a.prefixes = append(a.prefixes, p)
+		a.verifiers = append(a.verifiers, v)
+	}
+}
+
+// UseBcrypt registers the verifier of the bcrypt hashes, typically
+// bcrypt.CompareHashAndPassword of golang.org/x/crypto/bcrypt.
+func (a *BasicAuthenticator) UseBcrypt(v PasswordVerifier) {
+	a.Register(v, "$2a$", "$2b$", "$2y$")
+	if a.DummyHash == "" {
+		a.DummyHash = dummyBcryptHash
+	}
+}
+
+// UseArgon2id registers the verifier of the argon2id hashes encoded in the PHC
+// string format, see Argon2idVerifier.
+func (a *BasicAuthenticator) UseArgon2id(idKey Argon2IDKeyFunc) {
+	a.Register(Argon2idVerifier(idKey), "$argon2id$")
+	if a.DummyHash == "" {
+		a.DummyHash = dummyArgon2idHash
+	}
+}
+
+// Verify returns nil if the password of the user matches the password hash of
+// the store and ErrInvalidCredentials if the user does not exist or the
+// password does not match. Other errors are returned as is. The password of
+// the users that do not exist is verified against DummyHash so that both cases
+// take the same time.
+func (a *BasicAuthenticator) Verify(ctx context.Context, user, pass string) error {
+	hash, err := a.Users.PasswordHash(ctx, user)
+	if err != nil {
+		if err == ErrUnknownUser {
+			a.verify(a.DummyHash, pass)
+			return ErrInvalidCredentials
+		}
+		return err
+	}
+	match, ok := a.verify(hash, pass)
+	if !ok {
+		return fmt.Errorf("no verifier for the password hash of user %q", user)
+	}
+	if !match {
+		return ErrInvalidCredentials
+	}
+	return nil
+}
+
+// verify verifies pass with the verifier registered for the prefix of hash. ok
+// is false if there is no such verifier.
+func (a *BasicAuthenticator) verify(hash, pass string) (match, ok bool) {
+	for i, p := range a.prefixes {
+		if strings.HasPrefix(hash, p) {
+			return a.verifiers[i]([]byte(hash), []byte(pass)) == nil, true
+		}
+	}
+	return false, false
+}
+
+// Argon2idVerifier returns a verifier of the argon2id hashes encoded in the PHC
+// string format, e.g.:
+//
+//    $argon2id$v=19$m=65536,t=3,p=4$c2FsdHNhbHQ$aGFzaGhhc2g
+//
+// idKey computes the hashes, typically argon2.IDKey of
+// golang.org/x/crypto/argon2.
+func Argon2idVerifier(idKey Argon2IDKeyFunc) PasswordVerifier {
+	return func(hash, password []byte) error {
+		parts := strings.Split(string(hash), "$")
+		if len(parts) != 6 || parts[1] != "argon2id" {
+			return errors.New("invalid argon2id hash")
+		}
+		var version int
+		if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != 19 {
+			return errors.New("unsupported argon2id version")
+		}
+		var (
+			memory, time uint32
+			threads      uint8
+		)
+		if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+			return fmt.Errorf("invalid argon2id parameters: %s", err)
+		}
+		salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+		if err != nil {
+			return fmt.Errorf("invalid argon2id salt: %s", err)
+		}
+		key, err := base64.RawStdEncoding.DecodeString(parts[5])
+		if err != nil {
+			return fmt.Errorf("invalid argon2id key: %s", err)
+		}
+		actual := idKey(password, salt, time, memory, threads, uint32(len(key)))
+		if subtle.ConstantTimeCompare(actual, key) != 1 {
+			return errors.New("password does not match")
+		}
+		return nil
+	}
+}
+
+// PasswordHash returns the password hash of the user.
+func (s StaticUserStore) PasswordHash(_ context.Context, user string) (string, error) {
+	h, ok := s[user]
+	if !ok {
+		return "", ErrUnknownUser
+	}
+	return h, nil
+}
+
+// ParseHtpasswd reads the users and password hashes of a file in the htpasswd
+// format: one "user:hash" entry per line. Empty lines and lines starting with
+// "#" are ignored.
+func ParseHtpasswd(r io.Reader) (StaticUserStore, error) {
+	users := make(StaticUserStore)
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.Index(line, ":")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid htpasswd entry line %d", n)
+		}
+		users[line[:i]] = line[i+1:]
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// WithBasicUser returns a copy of ctx that records the name of the user
+// authenticated with basic auth.
+func WithBasicUser(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, basicUserKey{}, user)
+}
+
+// ContextBasicUser returns the name of the user authenticated with basic auth,
+// see WithBasicUser.
+func ContextBasicUser(ctx context.Context) (string, bool) {
+	u, ok := ctx.Value(basicUserKey{}).(string)
+	return u, ok
+}
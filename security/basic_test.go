@@ -0,0 +1,146 @@
+package security
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestBasicAuthenticator(t *testing.T) {
+	plain := func(hash, password []byte) error {
+		if string(hash[len("$plain$"):]) != string(password) {
+			return errors.New("mismatch")
+		}
+		return nil
+	}
+	users := StaticUserStore{
+		"alice": "$plain$secret",
+		"bob":   "$2b$10$notverified",
+		"carol": "$argon2id$v=19$m=64,t=1,p=1$" + b64("salt") + "$" + b64(string(fakeIDKey([]byte("pass"), []byte("salt"), 1, 64, 1, 16))),
+	}
+	a := NewBasicAuthenticator(users)
+	a.Register(plain, "$plain$")
+	a.UseArgon2id(fakeIDKey)
+
+	cases := []struct {
+		Name  string
+		User  string
+		Pass  string
+		Error string
+	}{
+		{"valid", "alice", "secret", ""},
+		{"invalid-password", "alice", "wrong", ErrInvalidCredentials.Error()},
+		{"unknown-user", "dave", "secret", ErrInvalidCredentials.Error()},
+		{"no-verifier", "bob", "secret", `no verifier for the password hash of user "bob"`},
+		{"argon2id", "carol", "pass", ""},
+		{"argon2id-invalid-password", "carol", "wrong", ErrInvalidCredentials.Error()},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := a.Verify(context.Background(), c.User, c.Pass)
+			if c.Error == "" {
+				if err != nil {
+					t.Errorf("got error %q, expected nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != c.Error {
+				t.Errorf("got error %v, expected %q", err, c.Error)
+			}
+		})
+	}
+}
+
+func TestBasicAuthenticatorUnknownUser(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Register func(a *BasicAuthenticator, v PasswordVerifier)
+		Expected string
+	}{
+		{"bcrypt", func(a *BasicAuthenticator, v PasswordVerifier) { a.UseBcrypt(v) }, dummyBcryptHash},
+		{"custom", func(a *BasicAuthenticator, v PasswordVerifier) {
+			a.Register(v, "$plain$")
+			a.DummyHash = "$plain$dummy"
+		}, "$plain$dummy"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var verified []string
+			a := NewBasicAuthenticator(StaticUserStore{})
+			c.Register(a, func(hash, password []byte) error {
+				verified = append(verified, string(hash))
+				return nil
+			})
+			if err := a.Verify(context.Background(), "dave", "secret"); err != ErrInvalidCredentials {
+				t.Errorf("got error %v, expected %v", err, ErrInvalidCredentials)
+			}
+			if len(verified) != 1 || verified[0] != c.Expected {
+				t.Errorf("got verified hashes %v, expected %q", verified, c.Expected)
+			}
+		})
+	}
+}
+
+func TestArgon2idVerifier(t *testing.T) {
+	v := Argon2idVerifier(fakeIDKey)
+	cases := []struct {
+		Name string
+		Hash string
+	}{
+		{"not-argon2id", "$argon2i$v=19$m=64,t=1,p=1$c2FsdA$a2V5"},
+		{"version", "$argon2id$v=16$m=64,t=1,p=1$c2FsdA$a2V5"},
+		{"parameters", "$argon2id$v=19$m=64$c2FsdA$a2V5"},
+		{"salt", "$argon2id$v=19$m=64,t=1,p=1$!!$a2V5"},
+		{"missing-key", "$argon2id$v=19$m=64,t=1,p=1$c2FsdA"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if err := v([]byte(c.Hash), []byte("pass")); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestParseHtpasswd(t *testing.T) {
+	users, err := ParseHtpasswd(strings.NewReader("# users\nalice:$2y$05$abc\n\nbob:$argon2id$v=19$m=64,t=1,p=1$c2FsdA$a2V5\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 2 || users["alice"] != "$2y$05$abc" || users["bob"] != "$argon2id$v=19$m=64,t=1,p=1$c2FsdA$a2V5" {
+		t.Errorf("got %v", users)
+	}
+	if _, err := ParseHtpasswd(strings.NewReader("alice\n")); err == nil {
+		t.Error("expected an error for an entry without hash")
+	}
+	if _, err := users.PasswordHash(context.Background(), "carol"); err != ErrUnknownUser {
+		t.Errorf("got error %v, expected %v", err, ErrUnknownUser)
+	}
+}
+
+func TestContextBasicUser(t *testing.T) {
+	if _, ok := ContextBasicUser(context.Background()); ok {
+		t.Error("got user in empty context")
+	}
+	ctx := WithBasicUser(context.Background(), "alice")
+	if u, ok := ContextBasicUser(ctx); !ok || u != "alice" {
+		t.Errorf("got %q, expected %q", u, "alice")
+	}
+}
+
+// fakeIDKey is a key derivation function with the signature of argon2.IDKey
+// used to test the decoding of the hashes.
+func fakeIDKey(password, salt []byte, time, memory uint32, threads uint8, keyLen uint32) []byte {
+	h := sha256.New()
+	h.Write(password)
+	h.Write(salt)
+	h.Write([]byte{byte(time), byte(memory), threads})
+	return h.Sum(nil)[:keyLen]
+}
+
+func b64(s string) string {
+	return base64.RawStdEncoding.EncodeToString([]byte(s))
+}
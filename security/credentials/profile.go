@@ -0,0 +1,282 @@
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// expiryDelta is the duration before the expiration of the access tokens at
+// which Profile refreshes them.
+const expiryDelta = 10 * time.Second
+
+type (
+	// Profile gives access to the credentials of a profile. The generated
+	// command line clients use the profile to initialize the security
+	// flags that are not given explicitly.
+	Profile struct {
+		// Name is the name of the profile, the API host by default.
+		Name string
+		// Store stores the credentials.
+		Store Store
+		// BaseURL is the URL used to resolve the relative token URLs,
+		// usually the scheme and host of the API.
+		BaseURL string
+		// Client is the HTTP client used to refresh the OAuth2 access
+		// tokens, http.DefaultClient is used if nil.
+		Client Doer
+
+		// now returns the current time, used by tests.
+		now func() time.Time
+	}
+
+	// Credential describes the credential used to initialize a security
+	// flag of a generated command line client.
+	Credential struct {
+		// Name is the name of the credential: the name of the security
+		// scheme for API keys, JWTs and OAuth2 access tokens and the
+		// name of the security scheme followed by ".username" or
+		// ".password" for basic auth.
+		Name string
+		// Kind is the kind of security scheme, one of "Basic",
+		// "APIKey", "JWT" or "OAuth2".
+		Kind string
+		// TokenURL is the URL of the token endpoint used to refresh the
+		// OAuth2 access tokens if any.
+		TokenURL string
+		// ClientCredentials is true if the token endpoint supports the
+		// client credentials grant.
+		ClientCredentials bool
+		// Scopes lists the scopes requested with the client credentials
+		// grant.
+		Scopes []string
+	}
+
+	// Provider provides the values of the credentials used to secure the
+	// requests made by the generated SDK clients. Value returns the empty
+	// string if the provider does not define the credential. *Profile and
+	// StaticProvider implement Provider.
+	Provider interface {
+		Value(ctx context.Context, c *Credential) (string, error)
+	}
+
+	// StaticProvider is a Provider that returns fixed credential values
+	// indexed by credential name.
+	StaticProvider map[string]string
+
+	// Doer sends HTTP requests, *http.Client implements it.
+	Doer interface {
+		Do(*http.Request) (*http.Response, error)
+	}
+
+	// tokenResponse is the successful response of the token endpoint.
+	tokenResponse struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+	}
+
+	// tokenError is the error response of the token endpoint.
+	tokenError struct {
+		Code        string `json:"error"`
+		Description string `json:"error_description"`
+	}
+)
+
+// NewProfile returns the profile with the given name that stores the
+// credentials in store.
+func NewProfile(name string, store Store) *Profile {
+	return &Profile{Name: name, Store: store}
+}
+
+// Value returns the value of the credential, the empty string if the profile
+// does not define it. The OAuth2 access tokens are stored together with their
+// expiration time NAME.expiry and refresh token NAME.refresh_token. Value
+// retrieves a new access token from the token endpoint if the stored token
+// expired or is missing: it uses the refresh token if any and the client
+// credentials NAME.client_id and NAME.client_secret otherwise. The new token
+// is saved in the store.
+func (p *Profile) Value(ctx context.Context, c *Credential) (string, error) {
+	if c.Kind != "OAuth2" {
+		return p.get(c.Name)
+	}
+	tok, err := p.get(c.Name)
+	if err != nil {
+		return "", err
+	}
+	exp, err := p.get(c.Name + ".expiry")
+	if err != nil {
+		return "", err
+	}
+	if (tok != "" && !p.expired(exp)) || c.TokenURL == "" {
+		return tok, nil
+	}
+	return p.refresh(ctx, c, tok)
+}
+
+// Value returns the value of the credential with the name of c, the empty
+// string if p does not define it.
+func (p StaticProvider) Value(_ context.Context, c *Credential) (string, error) {
+	return p[c.Name], nil
+}
+
+// refresh retrieves and stores a new access token, it returns tok if the
+// profile has neither a refresh token nor client credentials.
+func (p *Profile) refresh(ctx context.Context, c *Credential, tok string) (string, error) {
+	var (
+		refresh, id, secret string
+		err                 error
+	)
+	for name, v := range map[string]*string{".refresh_token": &refresh, ".client_id": &id, ".client_secret": &secret} {
+		if *v, err = p.get(c.Name + name); err != nil {
+			return "", err
+		}
+	}
+	var resp *tokenResponse
+	if refresh != "" {
+		resp, err = p.request(ctx, c, id, secret, url.Values{"grant_type": {"refresh_token"}, "refresh_token": {refresh}})
+	}
+	if resp == nil && c.ClientCredentials && id != "" {
+		// No refresh token or the refresh failed, use the client
+		// credentials.
+		form := url.Values{"grant_type": {"client_credentials"}}
+		if len(c.Scopes) > 0 {
+			form.Set("scope", strings.Join(c.Scopes, " "))
+		}
+		resp, err = p.request(ctx, c, id, secret, form)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to refresh %q access token: %s", c.Name, err)
+	}
+	if resp == nil {
+		return tok, nil
+	}
+	exp := ""
+	if resp.ExpiresIn > 0 {
+		exp = p.time().Add(time.Duration(resp.ExpiresIn) * time.Second).UTC().Format(time.RFC3339)
+	}
+	vals := map[string]string{c.Name: resp.AccessToken, c.Name + ".expiry": exp}
+	if resp.RefreshToken != "" {
+		vals[c.Name+".refresh_token"] = resp.RefreshToken
+	}
+	for name, v := range vals {
+		if err := p.set(name, v); err != nil {
+			return "", err
+		}
+	}
+	return resp.AccessToken, nil
+}
+
+// request sends a token request with the given parameters.
+func (p *Profile) request(ctx context.Context, c *Credential, id, secret string, form url.Values) (*tokenResponse, error) {
+	u, err := p.tokenURL(c.TokenURL)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", u, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if id != "" {
+		req.SetBasicAuth(url.QueryEscape(id), url.QueryEscape(secret))
+	}
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var terr tokenError
+		if err := json.NewDecoder(resp.Body).Decode(&terr); err != nil || terr.Code == "" {
+			return nil, fmt.Errorf("token request failed: %s", resp.Status)
+		}
+		if terr.Description != "" {
+			return nil, fmt.Errorf("token request failed: %s: %s", terr.Code, terr.Description)
+		}
+		return nil, fmt.Errorf("token request failed: %s", terr.Code)
+	}
+	var tok tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tok); err != nil {
+		return nil, fmt.Errorf("invalid token response: %s", err)
+	}
+	if tok.AccessToken == "" {
+		return nil, fmt.Errorf("invalid token response: missing access token")
+	}
+	return &tok, nil
+}
+
+// tokenURL resolves the token URL against the base URL of the profile.
+func (p *Profile) tokenURL(tokenURL string) (string, error) {
+	u, err := url.Parse(tokenURL)
+	if err != nil {
+		return "", err
+	}
+	if u.IsAbs() {
+		return tokenURL, nil
+	}
+	if p.BaseURL == "" {
+		return "", fmt.Errorf("relative token URL %q requires a base URL", tokenURL)
+	}
+	base, err := url.Parse(p.BaseURL)
+	if err != nil {
+		return "", err
+	}
+	return base.ResolveReference(u).String(), nil
+}
+
+// expired returns true if the expiration time exp is in less than expiryDelta.
+// Tokens without expiration time never expire.
+func (p *Profile) expired(exp string) bool {
+	if exp == "" {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339, exp)
+	if err != nil {
+		return true
+	}
+	return !p.time().Add(expiryDelta).Before(t)
+}
+
+// get returns the value of the credential, the empty string if it does not
+// exist.
+func (p *Profile) get(name string) (string, error) {
+	v, err := p.Store.Get(p.Name, name)
+	if err == ErrNotFound {
+		return "", nil
+	}
+	return v, err
+}
+
+// set stores the value of the credential, deleting it if value is empty. The
+// credentials are not saved if the store is read-only.
+func (p *Profile) set(name, value string) error {
+	var err error
+	if value == "" {
+		err = p.Store.Delete(p.Name, name)
+	} else {
+		err = p.Store.Set(p.Name, name, value)
+	}
+	if err == ErrNotFound || err == ErrReadOnly {
+		return nil
+	}
+	return err
+}
+
+// time returns the current time.
+func (p *Profile) time() time.Time {
+	if p.now != nil {
+		return p.now()
+	}
+	return time.Now()
+}
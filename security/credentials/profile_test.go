@@ -0,0 +1,128 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestProfileValue(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	var grants []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		grant := r.PostForm.Get("grant_type")
+		grants = append(grants, grant)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case grant == "refresh_token" && r.PostForm.Get("refresh_token") == "refresh":
+			fmt.Fprint(w, `{"access_token":"refreshed","refresh_token":"refresh2","expires_in":3600}`)
+		case grant == "client_credentials":
+			if id, secret, ok := r.BasicAuth(); !ok || id != "id" || secret != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprint(w, `{"error":"invalid_client"}`)
+				return
+			}
+			fmt.Fprint(w, `{"access_token":"issued","expires_in":3600}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"invalid_grant","error_description":"unknown refresh token"}`)
+		}
+	}))
+	defer srv.Close()
+	oauth2 := &Credential{Name: "oauth2", Kind: "OAuth2", TokenURL: "/token", ClientCredentials: true}
+
+	cases := []struct {
+		Name     string
+		Creds    map[string]string
+		Cred     *Credential
+		Expected string
+		Grants   []string
+		Stored   map[string]string
+		Error    string
+	}{
+		{"api-key", map[string]string{"api_key": "key"}, &Credential{Name: "api_key", Kind: "APIKey"}, "key", nil, nil, ""},
+		{"missing", nil, &Credential{Name: "jwt", Kind: "JWT"}, "", nil, nil, ""},
+		{"valid-token", map[string]string{"oauth2": "tok", "oauth2.expiry": "2020-01-01T01:00:00Z"}, oauth2, "tok", nil, nil, ""},
+		{"no-expiry", map[string]string{"oauth2": "tok"}, oauth2, "tok", nil, nil, ""},
+		{"refresh", map[string]string{"oauth2": "tok", "oauth2.expiry": "2020-01-01T00:00:05Z", "oauth2.refresh_token": "refresh"}, oauth2, "refreshed", []string{"refresh_token"},
+			map[string]string{"oauth2": "refreshed", "oauth2.expiry": "2020-01-01T01:00:00Z", "oauth2.refresh_token": "refresh2"}, ""},
+		{"client-credentials", map[string]string{"oauth2.client_id": "id", "oauth2.client_secret": "secret"}, oauth2, "issued", []string{"client_credentials"},
+			map[string]string{"oauth2": "issued", "oauth2.expiry": "2020-01-01T01:00:00Z"}, ""},
+		{"refresh-fallback", map[string]string{"oauth2.refresh_token": "revoked", "oauth2.client_id": "id", "oauth2.client_secret": "secret"}, oauth2, "issued", []string{"refresh_token", "client_credentials"},
+			map[string]string{"oauth2": "issued"}, ""},
+		{"refresh-error", map[string]string{"oauth2": "tok", "oauth2.expiry": "2019-12-31T00:00:00Z", "oauth2.refresh_token": "revoked"}, oauth2, "", []string{"refresh_token"},
+			nil, `failed to refresh "oauth2" access token: token request failed: invalid_grant: unknown refresh token`},
+		{"expired-no-refresh", map[string]string{"oauth2": "tok", "oauth2.expiry": "2019-12-31T00:00:00Z"}, oauth2, "tok", nil, nil, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			grants = nil
+			k := make(mapKeyring)
+			for name, v := range c.Creds {
+				k.Set("cars", "localhost/"+name, v)
+			}
+			p := NewProfile("localhost", &KeyringStore{Keyring: k, Service: "cars"})
+			p.BaseURL = srv.URL
+			p.now = func() time.Time { return now }
+
+			v, err := p.Value(context.Background(), c.Cred)
+
+			if c.Error != "" {
+				if err == nil || err.Error() != c.Error {
+					t.Fatalf("got error %v, expected %q", err, c.Error)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v != c.Expected {
+				t.Errorf("got %q, expected %q", v, c.Expected)
+			}
+			if fmt.Sprint(grants) != fmt.Sprint(c.Grants) {
+				t.Errorf("got grants %v, expected %v", grants, c.Grants)
+			}
+			for name, expected := range c.Stored {
+				if actual := k["cars"]["localhost/"+name]; actual != expected {
+					t.Errorf("got stored %s %q, expected %q", name, actual, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestProfileRelativeTokenURL(t *testing.T) {
+	p := NewProfile("localhost", Chain{})
+	if _, err := p.tokenURL("/token"); err == nil {
+		t.Error("expected an error without base URL")
+	}
+	p.BaseURL = "https://localhost:8443/api"
+	u, err := p.tokenURL("/token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u != "https://localhost:8443/token" {
+		t.Errorf("got %q, expected %q", u, "https://localhost:8443/token")
+	}
+}
+
+func TestStaticProvider(t *testing.T) {
+	var p Provider = StaticProvider{"api_key": "secret", "basic.username": "user"}
+	cases := map[string]string{"api_key": "secret", "basic.username": "user", "basic.password": ""}
+	for name, expected := range cases {
+		v, err := p.Value(context.Background(), &Credential{Name: name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != expected {
+			t.Errorf("%s: got %q, expected %q", name, v, expected)
+		}
+	}
+	var _ Provider = &Profile{}
+}
@@ -0,0 +1,307 @@
+// Package credentials implements the storage of the credentials used by the
+// generated command line clients. Credentials are grouped in profiles, one per
+// API host by default, and are read from the environment, from a credentials
+// file or from the operating system keychain. The generated clients use the
+// stored credentials to initialize the security flags that are not given on
+// the command line and refresh the OAuth2 access tokens that expired. The
+// generated SDK clients get the credentials from a Provider such as Profile.
+package credentials
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrNotFound is the error returned by the stores when a credential
+	// does not exist.
+	ErrNotFound = errors.New("credential not found")
+
+	// ErrReadOnly is the error returned by the stores that cannot modify
+	// the credentials.
+	ErrReadOnly = errors.New("credential store is read-only")
+)
+
+type (
+	// Store stores the credentials by profile and credential name.
+	Store interface {
+		// Get returns the value of the credential, ErrNotFound if it
+		// does not exist.
+		Get(profile, name string) (string, error)
+		// Set creates or replaces the credential.
+		Set(profile, name, value string) error
+		// Delete deletes the credential, ErrNotFound if it does not
+		// exist.
+		Delete(profile, name string) error
+	}
+
+	// Chain is a store that looks up the credentials in a list of stores.
+	// Get returns the credential of the first store that has it, Set
+	// modifies the first store that is not read-only and Delete deletes
+	// the credential from all the stores.
+	Chain []Store
+
+	// EnvStore is a read-only store that reads the credentials from the
+	// environment. The credential name of profile is read from the
+	// variable PREFIX_PROFILE_NAME and then PREFIX_NAME if not set, where
+	// PREFIX, PROFILE and NAME are the upper case prefix, profile and name
+	// with the characters that are neither letters nor digits replaced
+	// with underscores. For example the "jwt" credential of the
+	// "localhost:8080" profile with the "CARS" prefix is read from
+	// CARS_LOCALHOST_8080_JWT and then CARS_JWT.
+	EnvStore struct {
+		// Prefix is the prefix of the environment variable names.
+		Prefix string
+	}
+
+	// FileStore stores the credentials in a JSON file that only the
+	// current user can read. The file contains an object whose keys are
+	// the profile names and whose values are objects that map the
+	// credential names to their values.
+	FileStore struct {
+		// Path is the path to the file.
+		Path string
+
+		mu sync.Mutex
+	}
+
+	// Keyring is the interface implemented by the operating system
+	// keychains, for example github.com/zalando/go-keyring. Get and
+	// Delete must return ErrNotFound if the secret does not exist.
+	Keyring interface {
+		// Get returns the secret of user for service.
+		Get(service, user string) (string, error)
+		// Set creates or replaces the secret of user for service.
+		Set(service, user, secret string) error
+		// Delete deletes the secret of user for service.
+		Delete(service, user string) error
+	}
+
+	// KeyringStore stores the credentials in the operating system
+	// keychain. The credentials are stored under the given service name
+	// with the user "PROFILE/NAME".
+	KeyringStore struct {
+		// Keyring is the keychain.
+		Keyring Keyring
+		// Service is the service name of the secrets, usually the name
+		// of the API.
+		Service string
+	}
+)
+
+// DefaultStore returns the store used by the example command line clients of
+// the API with the given name: the environment variables prefixed with the
+// upper case API name followed by the file returned by DefaultPath.
+func DefaultStore(api string) Store {
+	chain := Chain{&EnvStore{Prefix: api}}
+	if path, err := DefaultPath(api); err == nil {
+		chain = append(chain, &FileStore{Path: path})
+	}
+	return chain
+}
+
+// DefaultPath returns the path to the credentials file of the API with the
+// given name, $HOME/.config/API/credentials.json.
+func DefaultPath(api string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", strings.ToLower(api), "credentials.json"), nil
+}
+
+// Get returns the credential of the first store that has it.
+func (c Chain) Get(profile, name string) (string, error) {
+	for _, s := range c {
+		v, err := s.Get(profile, name)
+		if err != ErrNotFound {
+			return v, err
+		}
+	}
+	return "", ErrNotFound
+}
+
+// Set sets the credential in the first store that is not read-only.
+func (c Chain) Set(profile, name, value string) error {
+	for _, s := range c {
+		if err := s.Set(profile, name, value); err != ErrReadOnly {
+			return err
+		}
+	}
+	return ErrReadOnly
+}
+
+// Delete deletes the credential from all the stores that are not read-only.
+func (c Chain) Delete(profile, name string) error {
+	found := false
+	for _, s := range c {
+		switch err := s.Delete(profile, name); err {
+		case nil:
+			found = true
+		case ErrNotFound, ErrReadOnly:
+		default:
+			return err
+		}
+	}
+	if !found {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Get returns the value of the environment variable that holds the credential.
+func (s *EnvStore) Get(profile, name string) (string, error) {
+	for _, key := range []string{envName(s.Prefix, profile, name), envName(s.Prefix, name)} {
+		if v, ok := os.LookupEnv(key); ok {
+			return v, nil
+		}
+	}
+	return "", ErrNotFound
+}
+
+// Set returns ErrReadOnly.
+func (s *EnvStore) Set(profile, name, value string) error {
+	return ErrReadOnly
+}
+
+// Delete returns ErrReadOnly.
+func (s *EnvStore) Delete(profile, name string) error {
+	return ErrReadOnly
+}
+
+// Get reads the credential from the file.
+func (s *FileStore) Get(profile, name string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	profiles, err := s.read()
+	if err != nil {
+		return "", err
+	}
+	v, ok := profiles[profile][name]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return v, nil
+}
+
+// Set writes the credential to the file, creating the file and its directory
+// if needed.
+func (s *FileStore) Set(profile, name, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	profiles, err := s.read()
+	if err != nil {
+		return err
+	}
+	if profiles[profile] == nil {
+		profiles[profile] = make(map[string]string)
+	}
+	profiles[profile][name] = value
+	return s.write(profiles)
+}
+
+// Delete removes the credential from the file.
+func (s *FileStore) Delete(profile, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	profiles, err := s.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := profiles[profile][name]; !ok {
+		return ErrNotFound
+	}
+	delete(profiles[profile], name)
+	if len(profiles[profile]) == 0 {
+		delete(profiles, profile)
+	}
+	return s.write(profiles)
+}
+
+// read reads the content of the file, it returns an empty map if the file does
+// not exist.
+func (s *FileStore) read() (map[string]map[string]string, error) {
+	profiles := make(map[string]map[string]string)
+	b, err := ioutil.ReadFile(s.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return profiles, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &profiles); err != nil {
+		return nil, fmt.Errorf("invalid credentials file %s: %s", s.Path, err)
+	}
+	return profiles, nil
+}
+
+// write replaces the content of the file atomically.
+func (s *FileStore) write(profiles map[string]map[string]string) error {
+	b, err := json.MarshalIndent(profiles, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(s.Path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(dir, ".credentials")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(f.Name(), 0600); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.Path)
+}
+
+// Get returns the credential stored in the keychain.
+func (s *KeyringStore) Get(profile, name string) (string, error) {
+	return s.Keyring.Get(s.Service, profile+"/"+name)
+}
+
+// Set stores the credential in the keychain.
+func (s *KeyringStore) Set(profile, name, value string) error {
+	return s.Keyring.Set(s.Service, profile+"/"+name, value)
+}
+
+// Delete deletes the credential from the keychain.
+func (s *KeyringStore) Delete(profile, name string) error {
+	return s.Keyring.Delete(s.Service, profile+"/"+name)
+}
+
+// envName returns the name of the environment variable made of the given
+// parts.
+func envName(parts ...string) string {
+	var b strings.Builder
+	for _, p := range parts {
+		if p == "" {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('_')
+		}
+		for _, r := range strings.ToUpper(p) {
+			if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+				b.WriteRune(r)
+			} else {
+				b.WriteByte('_')
+			}
+		}
+	}
+	return b.String()
+}
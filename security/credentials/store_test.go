@@ -0,0 +1,142 @@
+package credentials
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEnvStore(t *testing.T) {
+	defer os.Unsetenv("CARS_LOCALHOST_8080_JWT")
+	defer os.Unsetenv("CARS_JWT")
+	os.Setenv("CARS_LOCALHOST_8080_JWT", "profile")
+	os.Setenv("CARS_JWT", "default")
+	s := &EnvStore{Prefix: "cars"}
+
+	cases := []struct {
+		Name     string
+		Profile  string
+		Cred     string
+		Expected string
+		Error    error
+	}{
+		{"profile", "localhost:8080", "jwt", "profile", nil},
+		{"default", "example.com", "jwt", "default", nil},
+		{"not-found", "localhost:8080", "api_key", "", ErrNotFound},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			v, err := s.Get(c.Profile, c.Cred)
+			if err != c.Error {
+				t.Fatalf("got error %v, expected %v", err, c.Error)
+			}
+			if v != c.Expected {
+				t.Errorf("got %q, expected %q", v, c.Expected)
+			}
+		})
+	}
+	if err := s.Set("localhost:8080", "jwt", "x"); err != ErrReadOnly {
+		t.Errorf("got error %v on set, expected %v", err, ErrReadOnly)
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "credentials")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cars", "credentials.json")
+	s := &FileStore{Path: path}
+
+	if _, err := s.Get("localhost", "jwt"); err != ErrNotFound {
+		t.Errorf("got error %v without file, expected %v", err, ErrNotFound)
+	}
+	if err := s.Set("localhost", "jwt", "token"); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("got file permissions %v, expected %v", perm, os.FileMode(0600))
+	}
+	if v, err := (&FileStore{Path: path}).Get("localhost", "jwt"); err != nil || v != "token" {
+		t.Errorf("got %q and error %v, expected %q", v, err, "token")
+	}
+	if err := s.Delete("localhost", "jwt"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete("localhost", "jwt"); err != ErrNotFound {
+		t.Errorf("got error %v on second delete, expected %v", err, ErrNotFound)
+	}
+}
+
+func TestKeyringStore(t *testing.T) {
+	k := make(mapKeyring)
+	s := &KeyringStore{Keyring: k, Service: "cars"}
+	if err := s.Set("localhost", "jwt", "token"); err != nil {
+		t.Fatal(err)
+	}
+	if v := k["cars"]["localhost/jwt"]; v != "token" {
+		t.Errorf("got secret %q, expected %q", v, "token")
+	}
+	if v, err := s.Get("localhost", "jwt"); err != nil || v != "token" {
+		t.Errorf("got %q and error %v, expected %q", v, err, "token")
+	}
+}
+
+func TestChain(t *testing.T) {
+	defer os.Unsetenv("CARS_JWT")
+	os.Setenv("CARS_JWT", "env")
+	k := make(mapKeyring)
+	c := Chain{&EnvStore{Prefix: "cars"}, &KeyringStore{Keyring: k, Service: "cars"}}
+
+	if v, err := c.Get("localhost", "jwt"); err != nil || v != "env" {
+		t.Errorf("got %q and error %v, expected %q", v, err, "env")
+	}
+	if err := c.Set("localhost", "api_key", "key"); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := c.Get("localhost", "api_key"); err != nil || v != "key" {
+		t.Errorf("got %q and error %v, expected %q", v, err, "key")
+	}
+	if err := c.Delete("localhost", "api_key"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Get("localhost", "api_key"); err != ErrNotFound {
+		t.Errorf("got error %v after delete, expected %v", err, ErrNotFound)
+	}
+	if err := c.Delete("localhost", "api_key"); err != ErrNotFound {
+		t.Errorf("got error %v on second delete, expected %v", err, ErrNotFound)
+	}
+}
+
+// mapKeyring is a Keyring that stores the secrets in memory.
+type mapKeyring map[string]map[string]string
+
+func (k mapKeyring) Get(service, user string) (string, error) {
+	v, ok := k[service][user]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return v, nil
+}
+
+func (k mapKeyring) Set(service, user, secret string) error {
+	if k[service] == nil {
+		k[service] = make(map[string]string)
+	}
+	k[service][user] = secret
+	return nil
+}
+
+func (k mapKeyring) Delete(service, user string) error {
+	if _, ok := k[service][user]; !ok {
+		return ErrNotFound
+	}
+	delete(k[service], user)
+	return nil
+}
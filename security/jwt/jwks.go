@@ -0,0 +1,312 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultKeySetTTL is the default duration during which a retrieved
+	// key set is cached.
+	DefaultKeySetTTL = time.Hour
+
+	// DefaultKeySetMinRefreshInterval is the default minimum duration
+	// between two retrievals of a key set triggered by unknown key IDs or
+	// following a failed retrieval.
+	DefaultKeySetMinRefreshInterval = time.Minute
+
+	// DefaultKeySetMaxStale is the default duration during which the keys
+	// of an expired key set are used when the key set cannot be retrieved.
+	DefaultKeySetMaxStale = 24 * time.Hour
+)
+
+type (
+	// KeySet is a KeyProvider that retrieves the keys from a JSON Web Key
+	// Set (RFC 7517) served at a given URL. The keys are cached and the
+	// set is retrieved again when the cache expires or when a token uses
+	// an unknown key ID, for example after the keys have been rotated.
+	// Concurrent retrievals are coalesced into one request and the cached
+	// keys remain available to the other tokens while the set is being
+	// retrieved. The keys of an expired set keep being used if the set
+	// cannot be retrieved until MaxStale elapses.
+	KeySet struct {
+		// URL is the URL of the JSON Web Key Set.
+		URL string
+		// Client is the HTTP client used to retrieve the key set,
+		// http.DefaultClient is used if nil.
+		Client *http.Client
+		// TTL is the duration during which the key set is cached,
+		// DefaultKeySetTTL is used if zero.
+		TTL time.Duration
+		// MinRefreshInterval is the minimum duration between two
+		// retrievals triggered by unknown key IDs or following a failed
+		// retrieval, DefaultKeySetMinRefreshInterval is used if zero.
+		MinRefreshInterval time.Duration
+		// MaxStale is the duration during which the keys of an expired
+		// key set are used when the key set cannot be retrieved,
+		// DefaultKeySetMaxStale is used if zero.
+		MaxStale time.Duration
+
+		// now returns the current time, used by tests.
+		now func() time.Time
+
+		// mu protects the fields below. It is never held while the
+		// key set is retrieved.
+		mu sync.Mutex
+		// keys is the cached key set. The map is replaced and never
+		// modified once cached.
+		keys map[string]*jwk
+		// fetched is the time the cached key set was retrieved at.
+		fetched time.Time
+		// tried is the time of the last retrieval attempt.
+		tried time.Time
+		// fetch is the retrieval in progress if any.
+		fetch *keySetFetch
+	}
+
+	// keySetFetch is a retrieval of a key set shared by the concurrent
+	// callers of KeySet.Key.
+	keySetFetch struct {
+		// done is closed once the retrieval completes.
+		done chan struct{}
+		// err is the retrieval error if any, set before done is closed.
+		err error
+	}
+
+	// jwk is a JSON Web Key.
+	jwk struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Use string `json:"use"`
+		Alg string `json:"alg"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+		Crv string `json:"crv"`
+		X   string `json:"x"`
+		Y   string `json:"y"`
+		K   string `json:"k"`
+
+		// key is the parsed key.
+		key interface{}
+	}
+)
+
+// NewKeySet returns a key set that retrieves the keys from the given URL with
+// the given client.
+func NewKeySet(url string, client *http.Client) *KeySet {
+	return &KeySet{URL: url, Client: client}
+}
+
+// Key returns the key with the given ID, retrieving the key set if needed.
+// The only key of the set is returned if kid is empty.
+func (s *KeySet) Key(ctx context.Context, kid, alg string) (interface{}, error) {
+	now := time.Now
+	if s.now != nil {
+		now = s.now
+	}
+	ttl := s.TTL
+	if ttl == 0 {
+		ttl = DefaultKeySetTTL
+	}
+	minRefresh := s.MinRefreshInterval
+	if minRefresh == 0 {
+		minRefresh = DefaultKeySetMinRefreshInterval
+	}
+	maxStale := s.MaxStale
+	if maxStale == 0 {
+		maxStale = DefaultKeySetMaxStale
+	}
+	keys, fetched, tried := s.state()
+	unusable := keys == nil || now().Sub(fetched) > ttl+maxStale
+	if unusable || now().Sub(fetched) > ttl && now().Sub(tried) >= minRefresh {
+		err := s.refresh(ctx, now())
+		keys, fetched, tried = s.state()
+		if err != nil && (keys == nil || now().Sub(fetched) > ttl+maxStale) {
+			return nil, err
+		}
+	}
+	k := lookup(keys, kid)
+	if k == nil && now().Sub(tried) >= minRefresh {
+		if err := s.refresh(ctx, now()); err != nil {
+			return nil, err
+		}
+		keys, _, _ = s.state()
+		k = lookup(keys, kid)
+	}
+	if k == nil {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+	if k.Alg != "" && k.Alg != alg {
+		return nil, fmt.Errorf("key %q cannot be used with algorithm %q", kid, alg)
+	}
+	return k.key, nil
+}
+
+// state returns the cached keys, the time they were retrieved at and the time
+// of the last retrieval attempt.
+func (s *KeySet) state() (map[string]*jwk, time.Time, time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.keys, s.fetched, s.tried
+}
+
+// lookup returns the key with the given ID or the only key of the set if kid
+// is empty.
+func lookup(keys map[string]*jwk, kid string) *jwk {
+	if k, ok := keys[kid]; ok {
+		return k
+	}
+	if kid == "" && len(keys) == 1 {
+		for _, k := range keys {
+			return k
+		}
+	}
+	return nil
+}
+
+// refresh retrieves the key set and caches it on success. The caller waits for
+// the retrieval in progress if any instead of starting a new one.
+func (s *KeySet) refresh(ctx context.Context, now time.Time) error {
+	s.mu.Lock()
+	if f := s.fetch; f != nil {
+		s.mu.Unlock()
+		select {
+		case <-f.done:
+			return f.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	f := &keySetFetch{done: make(chan struct{})}
+	s.fetch = f
+	s.tried = now
+	s.mu.Unlock()
+
+	keys, err := s.retrieve(ctx)
+
+	s.mu.Lock()
+	if err == nil {
+		s.keys = keys
+		s.fetched = now
+	}
+	s.fetch = nil
+	s.mu.Unlock()
+	f.err = err
+	close(f.done)
+	return err
+}
+
+// retrieve retrieves the key set.
+func (s *KeySet) retrieve(ctx context.Context) (map[string]*jwk, error) {
+	req, err := http.NewRequest("GET", s.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key set URL %q: %s", s.URL, err)
+	}
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve key set: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to retrieve key set: %s", resp.Status)
+	}
+	var set struct {
+		Keys []*jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("invalid key set: %s", err)
+	}
+	keys := make(map[string]*jwk, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.parse()
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q: %s", k.Kid, err)
+		}
+		if key == nil {
+			continue // unsupported key type
+		}
+		k.key = key
+		keys[k.Kid] = k
+	}
+	return keys, nil
+}
+
+// parse returns the key described by k or nil if the key type is not
+// supported.
+func (k *jwk) parse() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus: %s", err)
+		}
+		e, err := decodeInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent: %s", err)
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("exponent too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x coordinate: %s", err)
+		}
+		y, err := decodeInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid y coordinate: %s", err)
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("point is not on curve %s", k.Crv)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "oct":
+		secret, err := base64.RawURLEncoding.DecodeString(k.K)
+		if err != nil {
+			return nil, fmt.Errorf("invalid secret: %s", err)
+		}
+		return secret, nil
+	default:
+		return nil, nil
+	}
+}
+
+// decodeInt decodes a base64url encoded big-endian integer.
+func decodeInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, fmt.Errorf("empty value")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
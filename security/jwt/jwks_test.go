@@ -0,0 +1,255 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestKeySet(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc := func(i *big.Int) string { return base64.RawURLEncoding.EncodeToString(i.Bytes()) }
+	keys := []map[string]string{
+		{"kty": "RSA", "kid": "rsa", "alg": "RS256", "n": enc(rsaKey.N), "e": enc(big.NewInt(int64(rsaKey.E)))},
+		{"kty": "RSA", "kid": "enc", "use": "enc", "n": enc(rsaKey.N), "e": enc(big.NewInt(int64(rsaKey.E)))},
+		{"kty": "OKP", "kid": "okp", "crv": "Ed25519", "x": "AA"},
+	}
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys})
+	}))
+	defer srv.Close()
+
+	clock := time.Unix(1700000000, 0)
+	ks := NewKeySet(srv.URL, srv.Client())
+	ks.now = func() time.Time { return clock }
+	ctx := context.Background()
+
+	key, err := ks.Key(ctx, "rsa", "RS256")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if pub, ok := key.(*rsa.PublicKey); !ok || pub.N.Cmp(rsaKey.N) != 0 || pub.E != rsaKey.E {
+		t.Errorf("got key %v, expected %v", key, &rsaKey.PublicKey)
+	}
+	if _, err := ks.Key(ctx, "", "RS256"); err != nil {
+		t.Errorf("got error %s, expected the only key", err)
+	}
+	if _, err := ks.Key(ctx, "rsa", "PS256"); err == nil || err.Error() != `key "rsa" cannot be used with algorithm "PS256"` {
+		t.Errorf("got error %v, expected algorithm mismatch", err)
+	}
+	if _, err := ks.Key(ctx, "enc", "RS256"); err == nil || err.Error() != `unknown key "enc"` {
+		t.Errorf("got error %v, expected encryption key to be ignored", err)
+	}
+	if requests != 1 {
+		t.Errorf("got %d requests, expected the key set to be cached", requests)
+	}
+
+	// Rotate the keys: unknown key IDs trigger a refresh once the minimum
+	// refresh interval has elapsed.
+	keys = append(keys, map[string]string{"kty": "EC", "kid": "ec", "crv": "P-256", "x": enc(ecKey.X), "y": enc(ecKey.Y)})
+	if _, err := ks.Key(ctx, "ec", "ES256"); err == nil {
+		t.Errorf("expected error before the minimum refresh interval")
+	}
+	clock = clock.Add(DefaultKeySetMinRefreshInterval)
+	key, err = ks.Key(ctx, "ec", "ES256")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if pub, ok := key.(*ecdsa.PublicKey); !ok || pub.X.Cmp(ecKey.X) != 0 || pub.Y.Cmp(ecKey.Y) != 0 {
+		t.Errorf("got key %v, expected %v", key, &ecKey.PublicKey)
+	}
+	if requests != 2 {
+		t.Errorf("got %d requests, expected 2", requests)
+	}
+
+	// The key set is retrieved again once the cache expires.
+	clock = clock.Add(DefaultKeySetTTL + time.Second)
+	if _, err := ks.Key(ctx, "rsa", "RS256"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if requests != 3 {
+		t.Errorf("got %d requests, expected 3", requests)
+	}
+}
+
+func TestKeySetErrors(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Body     string
+		Status   int
+		Expected string
+	}{
+		{"status", "", http.StatusNotFound, "failed to retrieve key set: 404 Not Found"},
+		{"invalid-json", "{", http.StatusOK, "invalid key set: unexpected EOF"},
+		{"invalid-curve", `{"keys":[{"kty":"EC","kid":"k","crv":"P-1","x":"AA","y":"AA"}]}`, http.StatusOK, `invalid key "k": unsupported curve "P-1"`},
+		{"off-curve", `{"keys":[{"kty":"EC","kid":"k","crv":"P-256","x":"AQ","y":"AQ"}]}`, http.StatusOK, `invalid key "k": point is not on curve P-256`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(c.Status)
+				w.Write([]byte(c.Body))
+			}))
+			defer srv.Close()
+			_, err := NewKeySet(srv.URL, srv.Client()).Key(context.Background(), "k", "ES256")
+			if err == nil || err.Error() != c.Expected {
+				t.Errorf("got error %v, expected %q", err, c.Expected)
+			}
+		})
+	}
+}
+
+func TestKeySetStale(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc := func(i *big.Int) string { return base64.RawURLEncoding.EncodeToString(i.Bytes()) }
+	var (
+		requests int
+		failing  bool
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{
+			{"kty": "RSA", "kid": "rsa", "n": enc(rsaKey.N), "e": enc(big.NewInt(int64(rsaKey.E)))},
+		}})
+	}))
+	defer srv.Close()
+
+	clock := time.Unix(1700000000, 0)
+	ks := NewKeySet(srv.URL, srv.Client())
+	ks.now = func() time.Time { return clock }
+	ctx := context.Background()
+	if _, err := ks.Key(ctx, "rsa", "RS256"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The expired keys are used when the key set cannot be retrieved and
+	// the retrieval is not attempted again before the minimum refresh
+	// interval.
+	failing = true
+	clock = clock.Add(DefaultKeySetTTL + time.Second)
+	if _, err := ks.Key(ctx, "rsa", "RS256"); err != nil {
+		t.Errorf("got error %s, expected the stale key", err)
+	}
+	if _, err := ks.Key(ctx, "rsa", "RS256"); err != nil {
+		t.Errorf("got error %s, expected the stale key", err)
+	}
+	if requests != 2 {
+		t.Errorf("got %d requests, expected 2", requests)
+	}
+	clock = clock.Add(DefaultKeySetMinRefreshInterval)
+	if _, err := ks.Key(ctx, "rsa", "RS256"); err != nil {
+		t.Errorf("got error %s, expected the stale key", err)
+	}
+	if requests != 3 {
+		t.Errorf("got %d requests, expected 3", requests)
+	}
+
+	// The keys are not used anymore once the staleness limit is reached.
+	clock = clock.Add(DefaultKeySetMaxStale)
+	if _, err := ks.Key(ctx, "rsa", "RS256"); err == nil || err.Error() != "failed to retrieve key set: 503 Service Unavailable" {
+		t.Errorf("got error %v, expected retrieval error", err)
+	}
+
+	failing = false
+	if _, err := ks.Key(ctx, "rsa", "RS256"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestKeySetConcurrentRefresh(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc := func(i *big.Int) string { return base64.RawURLEncoding.EncodeToString(i.Bytes()) }
+	key := func(kid string) map[string]string {
+		return map[string]string{"kty": "RSA", "kid": kid, "n": enc(rsaKey.N), "e": enc(big.NewInt(int64(rsaKey.E)))}
+	}
+	var (
+		requests int32
+		received = make(chan struct{}, 1)
+		release  = make(chan struct{})
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys := []map[string]string{key("old")}
+		if atomic.AddInt32(&requests, 1) > 1 {
+			received <- struct{}{}
+			<-release
+			keys = append(keys, key("new"))
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys})
+	}))
+	defer srv.Close()
+
+	ks := NewKeySet(srv.URL, srv.Client())
+	ks.MinRefreshInterval = time.Nanosecond
+	ctx := context.Background()
+	if _, err := ks.Key(ctx, "old", "RS256"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Tokens that use the rotated key trigger a single retrieval.
+	var wg sync.WaitGroup
+	errs := make([]error, 4)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = ks.Key(ctx, "new", "RS256")
+		}(i)
+	}
+	<-received
+
+	// The cached keys remain available while the key set is retrieved.
+	done := make(chan error)
+	go func() {
+		_, err := ks.Key(ctx, "old", "RS256")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("cached key blocked by the key set retrieval")
+	}
+
+	close(release)
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("got %d requests, expected 2", n)
+	}
+}
@@ -0,0 +1,367 @@
+/*
+Package jwt validates JSON Web Tokens (RFC 7519) signed with keys retrieved
+from a JSON Web Key Set (RFC 7517). It provides the reference implementation
+of the JWT security scheme authorization functions used by the generated
+jwtauth packages.
+
+The supported signing algorithms are RS256, RS384, RS512, PS256, PS384, PS512,
+ES256, ES384, ES512, HS256, HS384 and HS512.
+*/
+package jwt
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	// Register the hash functions used by the signing algorithms.
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+type (
+	// Claims contains the standard claims of a validated token.
+	Claims struct {
+		// Issuer is the value of the "iss" claim.
+		Issuer string
+		// Subject is the value of the "sub" claim.
+		Subject string
+		// Audience lists the values of the "aud" claim.
+		Audience []string
+		// ExpiresAt is the value of the "exp" claim if any.
+		ExpiresAt time.Time
+		// NotBefore is the value of the "nbf" claim if any.
+		NotBefore time.Time
+		// IssuedAt is the value of the "iat" claim if any.
+		IssuedAt time.Time
+		// ID is the value of the "jti" claim.
+		ID string
+		// Scopes lists the scopes granted to the token as defined by
+		// the "scope" claim (space separated list, RFC 8693) or the
+		// "scp" claim (list).
+		Scopes []string
+		// Raw contains all the claims of the token.
+		Raw map[string]interface{}
+	}
+
+	// KeyProvider retrieves the keys used to verify the token signatures.
+	KeyProvider interface {
+		// Key returns the key with the given ID for the given algorithm.
+		// The key ID is empty if the token header does not define one.
+		// The returned key must be a *rsa.PublicKey, a *ecdsa.PublicKey
+		// or a []byte (HMAC secret).
+		Key(ctx context.Context, kid, alg string) (interface{}, error)
+	}
+
+	// StaticKeys is a KeyProvider that indexes a fixed set of keys by ID.
+	// The only key of the set is used to verify the tokens whose header
+	// does not define a key ID.
+	StaticKeys map[string]interface{}
+
+	// Validator validates tokens.
+	Validator struct {
+		// Keys provides the keys used to verify the token signatures.
+		Keys KeyProvider
+		// Issuer is the expected value of the "iss" claim if not empty.
+		Issuer string
+		// Audience is the value that the "aud" claim must contain if
+		// not empty.
+		Audience string
+		// Leeway is the tolerance used when checking the "exp" and
+		// "nbf" claims to account for clock skew.
+		Leeway time.Duration
+		// Now returns the current time, time.Now is used if nil.
+		Now func() time.Time
+	}
+
+	// header is the JOSE header of a token.
+	header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+
+	// algorithm describes a signing algorithm.
+	algorithm struct {
+		// hash is the hash function applied to the signed content.
+		hash crypto.Hash
+		// verify verifies the signature with the given key.
+		verify func(key interface{}, hash crypto.Hash, signed, sig []byte) error
+	}
+
+	// ctxKey is the type of the context keys used by the package.
+	ctxKey int
+)
+
+// claimsKey is the context key used to store the claims of validated tokens.
+const claimsKey ctxKey = iota + 1
+
+// algorithms lists the supported signing algorithms.
+var algorithms = map[string]*algorithm{
+	"RS256": {crypto.SHA256, verifyPKCS1v15},
+	"RS384": {crypto.SHA384, verifyPKCS1v15},
+	"RS512": {crypto.SHA512, verifyPKCS1v15},
+	"PS256": {crypto.SHA256, verifyPSS},
+	"PS384": {crypto.SHA384, verifyPSS},
+	"PS512": {crypto.SHA512, verifyPSS},
+	"ES256": {crypto.SHA256, verifyECDSA},
+	"ES384": {crypto.SHA384, verifyECDSA},
+	"ES512": {crypto.SHA512, verifyECDSA},
+	"HS256": {crypto.SHA256, verifyHMAC},
+	"HS384": {crypto.SHA384, verifyHMAC},
+	"HS512": {crypto.SHA512, verifyHMAC},
+}
+
+// Validate verifies the signature of the given token, checks its registered
+// claims and returns its claims.
+func (v *Validator) Validate(ctx context.Context, token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid token: must be composed of 3 parts")
+	}
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, fmt.Errorf("invalid token header: %s", err)
+	}
+	alg, ok := algorithms[h.Alg]
+	if !ok {
+		return nil, fmt.Errorf("unsupported token signing algorithm %q", h.Alg)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid token signature: %s", err)
+	}
+	key, err := v.Keys.Key(ctx, h.Kid, h.Alg)
+	if err != nil {
+		return nil, err
+	}
+	if err := alg.verify(key, alg.hash, []byte(parts[0]+"."+parts[1]), sig); err != nil {
+		return nil, err
+	}
+	var raw map[string]interface{}
+	if err := decodeSegment(parts[1], &raw); err != nil {
+		return nil, fmt.Errorf("invalid token claims: %s", err)
+	}
+	claims, err := newClaims(raw)
+	if err != nil {
+		return nil, err
+	}
+	return claims, v.check(claims)
+}
+
+// Key returns the key with the given ID.
+func (k StaticKeys) Key(_ context.Context, kid, _ string) (interface{}, error) {
+	if key, ok := k[kid]; ok {
+		return key, nil
+	}
+	if kid == "" && len(k) == 1 {
+		for _, key := range k {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown key %q", kid)
+}
+
+// WithClaims returns a copy of ctx that contains the given claims.
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey, claims)
+}
+
+// ContextClaims returns the claims stored in ctx by WithClaims if any.
+func ContextClaims(ctx context.Context) (*Claims, bool) {
+	c, ok := ctx.Value(claimsKey).(*Claims)
+	return c, ok
+}
+
+// check validates the registered claims.
+func (v *Validator) check(c *Claims) error {
+	now := time.Now()
+	if v.Now != nil {
+		now = v.Now()
+	}
+	if !c.ExpiresAt.IsZero() && now.After(c.ExpiresAt.Add(v.Leeway)) {
+		return fmt.Errorf("token is expired")
+	}
+	if !c.NotBefore.IsZero() && now.Add(v.Leeway).Before(c.NotBefore) {
+		return fmt.Errorf("token is not valid yet")
+	}
+	if v.Issuer != "" && c.Issuer != v.Issuer {
+		return fmt.Errorf("invalid token issuer %q", c.Issuer)
+	}
+	if v.Audience != "" {
+		for _, a := range c.Audience {
+			if a == v.Audience {
+				return nil
+			}
+		}
+		return fmt.Errorf("token audience does not include %q", v.Audience)
+	}
+	return nil
+}
+
+// newClaims maps the standard claims of raw.
+func newClaims(raw map[string]interface{}) (*Claims, error) {
+	c := &Claims{Raw: raw}
+	var err error
+	if c.Issuer, err = stringClaim(raw, "iss"); err != nil {
+		return nil, err
+	}
+	if c.Subject, err = stringClaim(raw, "sub"); err != nil {
+		return nil, err
+	}
+	if c.ID, err = stringClaim(raw, "jti"); err != nil {
+		return nil, err
+	}
+	if c.Audience, err = stringsClaim(raw, "aud"); err != nil {
+		return nil, err
+	}
+	if c.ExpiresAt, err = timeClaim(raw, "exp"); err != nil {
+		return nil, err
+	}
+	if c.NotBefore, err = timeClaim(raw, "nbf"); err != nil {
+		return nil, err
+	}
+	if c.IssuedAt, err = timeClaim(raw, "iat"); err != nil {
+		return nil, err
+	}
+	if s, ok := raw["scope"].(string); ok {
+		c.Scopes = strings.Fields(s)
+	} else if c.Scopes, err = stringsClaim(raw, "scp"); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// stringClaim returns the value of the string claim with the given name.
+func stringClaim(raw map[string]interface{}, name string) (string, error) {
+	v, ok := raw[name]
+	if !ok {
+		return "", nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("invalid %q claim: must be a string", name)
+	}
+	return s, nil
+}
+
+// stringsClaim returns the value of the claim with the given name which may
+// be a string or a list of strings.
+func stringsClaim(raw map[string]interface{}, name string) ([]string, error) {
+	switch v := raw[name].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		res := make([]string, len(v))
+		for i, e := range v {
+			s, ok := e.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid %q claim: must be a list of strings", name)
+			}
+			res[i] = s
+		}
+		return res, nil
+	default:
+		return nil, fmt.Errorf("invalid %q claim: must be a string or a list of strings", name)
+	}
+}
+
+// timeClaim returns the value of the NumericDate claim with the given name.
+func timeClaim(raw map[string]interface{}, name string) (time.Time, error) {
+	v, ok := raw[name]
+	if !ok {
+		return time.Time{}, nil
+	}
+	n, ok := v.(json.Number)
+	if !ok {
+		return time.Time{}, fmt.Errorf("invalid %q claim: must be a number", name)
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %q claim: %s", name, err)
+	}
+	sec, frac := math.Modf(f)
+	return time.Unix(int64(sec), int64(frac*1e9)), nil
+}
+
+// decodeSegment decodes the base64url encoded JSON token segment into v.
+func decodeSegment(seg string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// digest returns the hash of signed.
+func digest(hash crypto.Hash, signed []byte) []byte {
+	h := hash.New()
+	h.Write(signed)
+	return h.Sum(nil)
+}
+
+func verifyPKCS1v15(key interface{}, hash crypto.Hash, signed, sig []byte) error {
+	pub, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("invalid key type %T for RSA signature", key)
+	}
+	if err := rsa.VerifyPKCS1v15(pub, hash, digest(hash, signed), sig); err != nil {
+		return fmt.Errorf("invalid token signature")
+	}
+	return nil
+}
+
+func verifyPSS(key interface{}, hash crypto.Hash, signed, sig []byte) error {
+	pub, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("invalid key type %T for RSA-PSS signature", key)
+	}
+	opts := &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}
+	if err := rsa.VerifyPSS(pub, hash, digest(hash, signed), sig, opts); err != nil {
+		return fmt.Errorf("invalid token signature")
+	}
+	return nil
+}
+
+func verifyECDSA(key interface{}, hash crypto.Hash, signed, sig []byte) error {
+	pub, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("invalid key type %T for ECDSA signature", key)
+	}
+	size := (pub.Curve.Params().BitSize + 7) / 8
+	if len(sig) != 2*size {
+		return fmt.Errorf("invalid token signature")
+	}
+	r := new(big.Int).SetBytes(sig[:size])
+	s := new(big.Int).SetBytes(sig[size:])
+	if !ecdsa.Verify(pub, digest(hash, signed), r, s) {
+		return fmt.Errorf("invalid token signature")
+	}
+	return nil
+}
+
+func verifyHMAC(key interface{}, hash crypto.Hash, signed, sig []byte) error {
+	secret, ok := key.([]byte)
+	if !ok {
+		return fmt.Errorf("invalid key type %T for HMAC signature", key)
+	}
+	mac := hmac.New(hash.New, secret)
+	mac.Write(signed)
+	if !hmac.Equal(mac.Sum(nil), sig) {
+		return fmt.Errorf("invalid token signature")
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var now = time.Unix(1700000000, 0)
+
+func TestValidate(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := []byte("secret")
+	keys := StaticKeys{"rsa": &rsaKey.PublicKey, "ec": &ecKey.PublicKey, "hmac": secret}
+	claims := map[string]interface{}{
+		"iss":   "https://issuer.goa.design",
+		"sub":   "user",
+		"aud":   []string{"api", "other"},
+		"exp":   now.Add(time.Minute).Unix(),
+		"iat":   now.Add(-time.Minute).Unix(),
+		"jti":   "id",
+		"scope": "orders:read orders:write",
+	}
+	with := func(name string, value interface{}) map[string]interface{} {
+		res := make(map[string]interface{}, len(claims))
+		for k, v := range claims {
+			res[k] = v
+		}
+		if value == nil {
+			delete(res, name)
+		} else {
+			res[name] = value
+		}
+		return res
+	}
+	scp := with("scope", nil)
+	scp["scp"] = []string{"orders:read", "orders:write"}
+	cases := []struct {
+		Name     string
+		Token    string
+		Expected string
+	}{
+		{"rs256", sign(t, "RS256", "rsa", rsaKey, claims), ""},
+		{"ps384", sign(t, "PS384", "rsa", rsaKey, claims), ""},
+		{"es256", sign(t, "ES256", "ec", ecKey, claims), ""},
+		{"hs512", sign(t, "HS512", "hmac", secret, claims), ""},
+		{"scp", sign(t, "RS256", "rsa", rsaKey, scp), ""},
+		{"none", sign(t, "none", "rsa", nil, claims), `unsupported token signing algorithm "none"`},
+		{"unknown-key", sign(t, "RS256", "unknown", rsaKey, claims), `unknown key "unknown"`},
+		{"algorithm-confusion", sign(t, "HS256", "rsa", []byte("public"), claims), "invalid key type *rsa.PublicKey for HMAC signature"},
+		{"tampered", sign(t, "RS256", "rsa", rsaKey, claims) + "A", "invalid token signature"},
+		{"malformed", "abc.def", "invalid token: must be composed of 3 parts"},
+		{"expired", sign(t, "RS256", "rsa", rsaKey, with("exp", now.Add(-time.Minute).Unix())), "token is expired"},
+		{"leeway", sign(t, "RS256", "rsa", rsaKey, with("exp", now.Add(-time.Second).Unix())), ""},
+		{"not-yet-valid", sign(t, "RS256", "rsa", rsaKey, with("nbf", now.Add(time.Minute).Unix())), "token is not valid yet"},
+		{"issuer", sign(t, "RS256", "rsa", rsaKey, with("iss", "other")), `invalid token issuer "other"`},
+		{"audience", sign(t, "RS256", "rsa", rsaKey, with("aud", "other")), `token audience does not include "api"`},
+		{"invalid-claim", sign(t, "RS256", "rsa", rsaKey, with("sub", 1)), `invalid "sub" claim: must be a string`},
+	}
+	v := &Validator{
+		Keys:     keys,
+		Issuer:   "https://issuer.goa.design",
+		Audience: "api",
+		Leeway:   5 * time.Second,
+		Now:      func() time.Time { return now },
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			claims, err := v.Validate(context.Background(), c.Token)
+			if c.Expected != "" {
+				if err == nil || err.Error() != c.Expected {
+					t.Fatalf("got error %v, expected %q", err, c.Expected)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if claims.Subject != "user" || claims.ID != "id" {
+				t.Errorf("invalid claims %+v", claims)
+			}
+			if !reflect.DeepEqual(claims.Audience, []string{"api", "other"}) {
+				t.Errorf("got audience %v", claims.Audience)
+			}
+			if c.Name != "leeway" && !claims.ExpiresAt.Equal(now.Add(time.Minute)) {
+				t.Errorf("got expiration %v", claims.ExpiresAt)
+			}
+			if !reflect.DeepEqual(claims.Scopes, []string{"orders:read", "orders:write"}) {
+				t.Errorf("got scopes %v", claims.Scopes)
+			}
+		})
+	}
+}
+
+func TestContextClaims(t *testing.T) {
+	ctx := context.Background()
+	if _, ok := ContextClaims(ctx); ok {
+		t.Errorf("expected no claims")
+	}
+	c := &Claims{Subject: "user"}
+	if actual, ok := ContextClaims(WithClaims(ctx, c)); !ok || actual != c {
+		t.Errorf("got %v, expected %v", actual, c)
+	}
+}
+
+// sign returns a token signed with the given algorithm and key.
+func sign(t *testing.T, alg, kid string, key interface{}, claims map[string]interface{}) string {
+	t.Helper()
+	enc := func(v interface{}) string {
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+	signed := enc(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"}) + "." + enc(claims)
+	var sig []byte
+	var err error
+	switch k := key.(type) {
+	case nil:
+	case *rsa.PrivateKey:
+		if alg[0] == 'P' {
+			sig, err = rsa.SignPSS(rand.Reader, k, crypto.SHA384, digest(crypto.SHA384, []byte(signed)), &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		} else {
+			sig, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest(crypto.SHA256, []byte(signed)))
+		}
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest(crypto.SHA256, []byte(signed)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig = make([]byte, 64)
+		rb, sb := r.Bytes(), s.Bytes()
+		copy(sig[32-len(rb):32], rb)
+		copy(sig[64-len(sb):], sb)
+	case []byte:
+		h := crypto.SHA256
+		if alg == "HS512" {
+			h = crypto.SHA512
+		}
+		mac := hmac.New(h.New, k)
+		mac.Write([]byte(signed))
+		sig = mac.Sum(nil)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
@@ -0,0 +1,121 @@
+package security
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/url"
+)
+
+type (
+	// MTLSScheme represents the mutual TLS security scheme where clients
+	// authenticate with a X.509 certificate during the TLS handshake.
+	MTLSScheme struct {
+		// Name is the scheme name defined in the design.
+		Name string
+		// Scopes holds a list of scopes for the scheme.
+		Scopes []string
+		// RequiredScopes holds a list of scopes which are required
+		// by the scheme. It is a subset of Scopes field.
+		RequiredScopes []string
+		// WildcardScopes is true if the design enables wildcard scopes
+		// for the scheme, see ScopeImplies.
+		WildcardScopes bool
+	}
+
+	// ClientCertificate describes the certificate presented by a client
+	// during the TLS handshake.
+	ClientCertificate struct {
+		// CommonName is the common name of the certificate subject.
+		CommonName string
+		// OrganizationalUnits lists the organizational units of the
+		// certificate subject.
+		OrganizationalUnits []string
+		// DNSNames lists the DNS subject alternative names.
+		DNSNames []string
+		// EmailAddresses lists the email subject alternative names.
+		EmailAddresses []string
+		// URIs lists the URI subject alternative names, e.g. SPIFFE
+		// IDs.
+		URIs []*url.URL
+		// IPAddresses lists the IP subject alternative names.
+		IPAddresses []net.IP
+		// Certificate is the parsed certificate.
+		Certificate *x509.Certificate
+	}
+
+	// AuthMTLSFunc is the function type that implements the mutual TLS
+	// scheme using the client certificate. cert is nil if the client did
+	// not present a certificate.
+	AuthMTLSFunc func(ctx context.Context, cert *ClientCertificate, s *MTLSScheme) (context.Context, error)
+)
+
+// Validate returns a non-nil error if scopes does not contain all of MTLS
+// scheme's required scopes or the scopes that imply them if WildcardScopes is
+// true, see ScopeImplies.
+func (s *MTLSScheme) Validate(scopes []string) error {
+	return validateScopes(s.RequiredScopes, scopes, s.WildcardScopes)
+}
+
+// PeerCertificate returns the PEM encoding of the leaf certificate presented by
+// the peer of the given TLS connection. It returns the empty string if the
+// connection does not use TLS or if the peer did not present a certificate.
+// The generated server code uses PeerCertificate to initialize the payload
+// attributes defined with ClientCertificate.
+func PeerCertificate(state *tls.ConnectionState) string {
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return ""
+	}
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: state.PeerCertificates[0].Raw,
+	}))
+}
+
+// ParseClientCertificate parses the PEM encoded client certificate and
+// extracts the subject organizational units and alternative names.
+func ParseClientCertificate(data string) (*ClientCertificate, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("invalid client certificate: no PEM encoded certificate found")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client certificate: %s", err)
+	}
+	return &ClientCertificate{
+		CommonName:          cert.Subject.CommonName,
+		OrganizationalUnits: cert.Subject.OrganizationalUnit,
+		DNSNames:            cert.DNSNames,
+		EmailAddresses:      cert.EmailAddresses,
+		URIs:                cert.URIs,
+		IPAddresses:         cert.IPAddresses,
+		Certificate:         cert,
+	}, nil
+}
+
+// HasOrganizationalUnit returns true if the certificate subject belongs to the
+// given organizational unit.
+func (c *ClientCertificate) HasOrganizationalUnit(ou string) bool {
+	for _, u := range c.OrganizationalUnits {
+		if u == ou {
+			return true
+		}
+	}
+	return false
+}
+
+// MTLSConfig returns a TLS configuration for servers that implement the mutual
+// TLS security scheme: clients must present a certificate signed by one of the
+// given certificate authorities.
+func MTLSConfig(cert tls.Certificate, clientCAs *x509.CertPool) *tls.Config {
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}
+}
@@ -0,0 +1,87 @@
+package security
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestClientCertificate(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spiffe, _ := url.Parse("spiffe://goa.design/ns/default/sa/client")
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject: pkix.Name{
+			CommonName:         "client",
+			OrganizationalUnit: []string{"billing", "payments"},
+		},
+		DNSNames:       []string{"client.goa.design"},
+		EmailAddresses: []string{"client@goa.design"},
+		URIs:           []*url.URL{spiffe},
+		IPAddresses:    []net.IP{net.ParseIP("10.0.0.1").To4()},
+		NotBefore:      time.Now().Add(-time.Hour),
+		NotAfter:       time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if PeerCertificate(nil) != "" {
+		t.Errorf("got a certificate for a connection without TLS")
+	}
+	if PeerCertificate(&tls.ConnectionState{}) != "" {
+		t.Errorf("got a certificate for a connection without peer certificate")
+	}
+	data := PeerCertificate(&tls.ConnectionState{PeerCertificates: []*x509.Certificate{parsed}})
+	cert, err := ParseClientCertificate(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if cert.CommonName != "client" {
+		t.Errorf("got common name %q, expected %q", cert.CommonName, "client")
+	}
+	if !reflect.DeepEqual(cert.OrganizationalUnits, []string{"billing", "payments"}) {
+		t.Errorf("got organizational units %v", cert.OrganizationalUnits)
+	}
+	if !reflect.DeepEqual(cert.DNSNames, []string{"client.goa.design"}) {
+		t.Errorf("got DNS names %v", cert.DNSNames)
+	}
+	if !reflect.DeepEqual(cert.EmailAddresses, []string{"client@goa.design"}) {
+		t.Errorf("got email addresses %v", cert.EmailAddresses)
+	}
+	if len(cert.URIs) != 1 || cert.URIs[0].String() != spiffe.String() {
+		t.Errorf("got URIs %v", cert.URIs)
+	}
+	if len(cert.IPAddresses) != 1 || !cert.IPAddresses[0].Equal(net.ParseIP("10.0.0.1")) {
+		t.Errorf("got IP addresses %v", cert.IPAddresses)
+	}
+	if !cert.HasOrganizationalUnit("billing") || cert.HasOrganizationalUnit("sales") {
+		t.Errorf("invalid organizational unit check for %v", cert.OrganizationalUnits)
+	}
+	if !cert.Certificate.Equal(parsed) {
+		t.Errorf("got certificate %v, expected %v", cert.Certificate, parsed)
+	}
+
+	for _, invalid := range []string{"", "not a certificate", "-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n"} {
+		if _, err := ParseClientCertificate(invalid); err == nil {
+			t.Errorf("expected error for %q", invalid)
+		}
+	}
+}
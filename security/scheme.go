@@ -5,6 +5,8 @@ secure goa endpoint. It supports the following security schemes:
   * API key security using keys.
   * JWT security using JWT tokens.
   * OAuth2 security using OAuth2 tokens.
+  * Mutual TLS security using client certificates.
+  * Request signature security using shared secrets.
 */
 package security
 
@@ -25,6 +27,9 @@ type (
 		// RequiredScopes holds a list of scopes which are required
 		// by the scheme. It is a subset of Scopes field.
 		RequiredScopes []string
+		// WildcardScopes is true if the design enables wildcard scopes
+		// for the scheme, see ScopeImplies.
+		WildcardScopes bool
 	}
 
 	// APIKeyScheme represents the API key security scheme.
@@ -37,6 +42,9 @@ type (
 		// RequiredScopes holds a list of scopes which are required
 		// by the scheme. It is a subset of Scopes field.
 		RequiredScopes []string
+		// WildcardScopes is true if the design enables wildcard scopes
+		// for the scheme, see ScopeImplies.
+		WildcardScopes bool
 	}
 
 	// JWTScheme represents an API key based scheme with support
@@ -49,6 +57,9 @@ type (
 		// RequiredScopes holds a list of scopes which are required
 		// by the scheme. It is a subset of Scopes field.
 		RequiredScopes []string
+		// WildcardScopes is true if the design enables wildcard scopes
+		// for the scheme, see ScopeImplies.
+		WildcardScopes bool
 	}
 
 	// OAuth2Scheme represents the oauth2 security scheme.
@@ -60,6 +71,9 @@ type (
 		// RequiredScopes holds a list of scopes which are required
 		// by the scheme. It is a subset of Scopes field.
 		RequiredScopes []string
+		// WildcardScopes is true if the design enables wildcard scopes
+		// for the scheme, see ScopeImplies.
+		WildcardScopes bool
 		// Flows determine the oauth2 flows.
 		Flows []*OAuthFlow
 	}
@@ -93,36 +107,40 @@ type (
 	AuthJWTFunc func(ctx context.Context, token string, s *JWTScheme) (context.Context, error)
 )
 
-// Validate returns a non-nil error if scopes does not contain all of
-// Basic scheme's required scopes.
+// Validate returns a non-nil error if scopes does not contain all of Basic
+// scheme's required scopes or the scopes that imply them if WildcardScopes is
+// true, see ScopeImplies.
 func (s *BasicScheme) Validate(scopes []string) error {
-	return validateScopes(s.RequiredScopes, scopes)
+	return validateScopes(s.RequiredScopes, scopes, s.WildcardScopes)
 }
 
-// Validate returns a non-nil error if scopes does not contain all of
-// APIKey scheme's required scopes.
+// Validate returns a non-nil error if scopes does not contain all of APIKey
+// scheme's required scopes or the scopes that imply them if WildcardScopes is
+// true, see ScopeImplies.
 func (s *APIKeyScheme) Validate(scopes []string) error {
-	return validateScopes(s.RequiredScopes, scopes)
+	return validateScopes(s.RequiredScopes, scopes, s.WildcardScopes)
 }
 
-// Validate returns a non-nil error if scopes does not contain all of
-// OAuth2 scheme's required scopes.
+// Validate returns a non-nil error if scopes does not contain all of OAuth2
+// scheme's required scopes or the scopes that imply them if WildcardScopes is
+// true, see ScopeImplies.
 func (s *OAuth2Scheme) Validate(scopes []string) error {
-	return validateScopes(s.RequiredScopes, scopes)
+	return validateScopes(s.RequiredScopes, scopes, s.WildcardScopes)
 }
 
-// Validate returns a non-nil error if scopes does not contain all of
-// JWT scheme's required scopes.
+// Validate returns a non-nil error if scopes does not contain all of JWT
+// scheme's required scopes or the scopes that imply them if WildcardScopes is
+// true, see ScopeImplies.
 func (s *JWTScheme) Validate(scopes []string) error {
-	return validateScopes(s.RequiredScopes, scopes)
+	return validateScopes(s.RequiredScopes, scopes, s.WildcardScopes)
 }
 
-func validateScopes(expected, actual []string) error {
+func validateScopes(expected, actual []string, wildcards bool) error {
 	var missing []string
 	for _, r := range expected {
 		found := false
 		for _, s := range actual {
-			if s == r {
+			if s == r || wildcards && ScopeImplies(s, r) {
 				found = true
 				break
 			}
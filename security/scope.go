@@ -0,0 +1,87 @@
+package security
+
+import (
+	"context"
+	"strings"
+)
+
+const (
+	// ScopeSeparator separates the segments of hierarchical scope names,
+	// e.g. "orders:read".
+	ScopeSeparator = ":"
+
+	// ScopeWildcard is the last segment of the scopes that imply all the
+	// scopes sharing the same prefix, e.g. "orders:*" implies "orders:read"
+	// and "orders:items:write". The scope "*" implies all scopes. Wildcard
+	// scopes are only interpreted for the security schemes whose design
+	// enables them, they are matched literally otherwise.
+	ScopeWildcard = "*"
+)
+
+type (
+	// scopesKey is the context key used to store the scopes granted to
+	// the caller.
+	scopesKey struct{}
+
+	// scopeWildcardsKey is the context key used to record that the granted
+	// wildcard scopes imply the scopes sharing their prefix.
+	scopeWildcardsKey struct{}
+)
+
+// ScopeImplies returns true if the granted scope implies the required scope,
+// that is if the two scopes are identical or if granted is a wildcard scope
+// whose prefix is a prefix of required.
+func ScopeImplies(granted, required string) bool {
+	if granted == required || granted == ScopeWildcard {
+		return true
+	}
+	if !strings.HasSuffix(granted, ScopeSeparator+ScopeWildcard) {
+		return false
+	}
+	prefix := strings.TrimSuffix(granted, ScopeWildcard)
+	return strings.HasPrefix(required, prefix) && len(required) > len(prefix)
+}
+
+// ImpliedScopes returns the scopes in candidates that are implied by the
+// given scope excluding the scope itself.
+func ImpliedScopes(scope string, candidates []string) []string {
+	var implied []string
+	for _, c := range candidates {
+		if c != scope && ScopeImplies(scope, c) {
+			implied = append(implied, c)
+		}
+	}
+	return implied
+}
+
+// WithScopeWildcards returns a copy of ctx that records that the wildcard
+// scopes granted to the caller imply the scopes sharing their prefix when
+// checked by HasScopes. The generated endpoints call WithScopeWildcards when the
+// design enables wildcard scopes for one of the method security schemes.
+func WithScopeWildcards(ctx context.Context) context.Context {
+	return context.WithValue(ctx, scopeWildcardsKey{}, true)
+}
+
+// WithScopes returns a copy of ctx that records the scopes granted to the
+// caller. Authorization functions call WithScopes so that the generated
+// endpoints can remove the result attributes that require other scopes, see
+// HasScopes.
+func WithScopes(ctx context.Context, scopes ...string) context.Context {
+	return context.WithValue(ctx, scopesKey{}, scopes)
+}
+
+// ContextScopes returns the scopes granted to the caller, see WithScopes.
+func ContextScopes(ctx context.Context) ([]string, bool) {
+	scopes, ok := ctx.Value(scopesKey{}).([]string)
+	return scopes, ok
+}
+
+// HasScopes returns true if the scopes granted to the caller include all the
+// required scopes or the wildcard scopes that imply them if ctx enables
+// wildcard scopes, see WithScopeWildcards. It returns false if ctx does not
+// record the granted scopes and required is not empty.
+func HasScopes(ctx context.Context, required ...string) bool {
+	granted, _ := ContextScopes(ctx)
+	wildcards, _ := ctx.Value(scopeWildcardsKey{}).(bool)
+	return validateScopes(required, granted, wildcards) == nil
+}
@@ -0,0 +1,87 @@
+package security
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestScopeImplies(t *testing.T) {
+	cases := []struct {
+		Granted, Required string
+		Expected          bool
+	}{
+		{"orders:read", "orders:read", true},
+		{"orders:read", "orders:write", false},
+		{"orders:*", "orders:read", true},
+		{"orders:*", "orders:items:write", true},
+		{"orders:*", "orders", false},
+		{"orders:*", "orders:", false},
+		{"orders:*", "ordersx:read", false},
+		{"orders:*", "orders:*", true},
+		{"orders:items:*", "orders:read", false},
+		{"*", "orders:read", true},
+		{"orders*", "orders:read", false},
+	}
+	for _, c := range cases {
+		if actual := ScopeImplies(c.Granted, c.Required); actual != c.Expected {
+			t.Errorf("ScopeImplies(%q, %q): got %v, expected %v", c.Granted, c.Required, actual, c.Expected)
+		}
+	}
+}
+
+func TestImpliedScopes(t *testing.T) {
+	candidates := []string{"orders:*", "orders:read", "orders:items:write", "users:read"}
+	actual := ImpliedScopes("orders:*", candidates)
+	expected := []string{"orders:read", "orders:items:write"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("got %v, expected %v", actual, expected)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	s := &JWTScheme{RequiredScopes: []string{"orders:read", "users:write"}, WildcardScopes: true}
+	if err := s.Validate([]string{"orders:*", "users:write"}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	err := s.Validate([]string{"orders:*", "users:read"})
+	if err == nil || err.Error() != "missing scopes: users:write" {
+		t.Errorf("got error %v, expected missing scopes: users:write", err)
+	}
+	s.WildcardScopes = false
+	err = s.Validate([]string{"orders:*", "users:write"})
+	if err == nil || err.Error() != "missing scopes: orders:read" {
+		t.Errorf("got error %v without wildcards, expected missing scopes: orders:read", err)
+	}
+	if err := s.Validate([]string{"orders:read", "users:write"}); err != nil {
+		t.Errorf("unexpected error without wildcards: %s", err)
+	}
+}
+
+func TestHasScopes(t *testing.T) {
+	ctx := WithScopes(context.Background(), "orders:*", "pii:read")
+	wctx := WithScopeWildcards(ctx)
+	cases := []struct {
+		Name     string
+		Ctx      context.Context
+		Required []string
+		Expected bool
+	}{
+		{"granted", ctx, []string{"pii:read"}, true},
+		{"literal wildcard", ctx, []string{"orders:*"}, true},
+		{"not implied", ctx, []string{"orders:read", "pii:read"}, false},
+		{"implied", wctx, []string{"orders:read", "pii:read"}, true},
+		{"missing", ctx, []string{"pii:write"}, false},
+		{"none required", ctx, nil, true},
+		{"no scopes", context.Background(), []string{"pii:read"}, false},
+		{"no scopes none required", context.Background(), nil, true},
+	}
+	for _, c := range cases {
+		if actual := HasScopes(c.Ctx, c.Required...); actual != c.Expected {
+			t.Errorf("%s: got %v, expected %v", c.Name, actual, c.Expected)
+		}
+	}
+	if scopes, ok := ContextScopes(ctx); !ok || !reflect.DeepEqual(scopes, []string{"orders:*", "pii:read"}) {
+		t.Errorf("got scopes %v", scopes)
+	}
+}
@@ -0,0 +1,52 @@
+package security
+
+import "context"
+
+type (
+	// SignatureScheme represents the request signature security scheme where
+	// clients sign the requests with a secret shared with the server.
+	SignatureScheme struct {
+		// Name is the scheme name defined in the design.
+		Name string
+		// Scopes holds a list of scopes for the scheme.
+		Scopes []string
+		// RequiredScopes holds a list of scopes which are required
+		// by the scheme. It is a subset of Scopes field.
+		RequiredScopes []string
+		// WildcardScopes is true if the design enables wildcard scopes
+		// for the scheme, see ScopeImplies.
+		WildcardScopes bool
+	}
+
+	// AuthSignatureFunc is the function type that implements the request
+	// signature scheme. keyID is the ID of the key used to sign the request
+	// once the transport has verified the signature, it is empty if the
+	// request is not signed.
+	AuthSignatureFunc func(ctx context.Context, keyID string, s *SignatureScheme) (context.Context, error)
+
+	// signatureKeyIDKey is the context key used to store the ID of the
+	// key that signed the request.
+	signatureKeyIDKey struct{}
+)
+
+// Validate returns a non-nil error if scopes does not contain all of Signature
+// scheme's required scopes or the scopes that imply them if WildcardScopes is
+// true, see ScopeImplies.
+func (s *SignatureScheme) Validate(scopes []string) error {
+	return validateScopes(s.RequiredScopes, scopes, s.WildcardScopes)
+}
+
+// WithSignatureKeyID returns a copy of ctx that records that the request
+// signature was verified with the key with the given ID. The transport
+// middlewares that verify the request signatures call WithSignatureKeyID, the
+// generated endpoints give the key ID to the authorization function.
+func WithSignatureKeyID(ctx context.Context, keyID string) context.Context {
+	return context.WithValue(ctx, signatureKeyIDKey{}, keyID)
+}
+
+// ContextSignatureKeyID returns the ID of the key that signed the request, see
+// WithSignatureKeyID.
+func ContextSignatureKeyID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(signatureKeyIDKey{}).(string)
+	return id, ok
+}